@@ -36,6 +36,10 @@ type Verification struct {
 	HasToken     bool
 	IsTokenValid bool
 	RawClaims    map[string]interface{}
+
+	// SCIMGroups are the groups the user has been provisioned into over
+	// SCIM. Teams grant roles to them as "scim:<group>".
+	SCIMGroups []string
 }
 
 type access struct {
@@ -130,6 +134,12 @@ func (a *access) rolesForTeam(auth atc.TeamAuth) []string {
 					}
 				}
 			}
+
+			for _, scimGroup := range a.verification.SCIMGroups {
+				if strings.EqualFold(group, "scim:"+scimGroup) {
+					roleSet[role] = true
+				}
+			}
 		}
 	}
 
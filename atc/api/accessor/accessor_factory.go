@@ -18,9 +18,16 @@ type TeamFetcher interface {
 	GetTeams() ([]db.Team, error)
 }
 
+//counterfeiter:generate . SCIMGroupFetcher
+type SCIMGroupFetcher interface {
+	GetSCIMGroupMemberships() (map[string][]string, error)
+}
+
 func NewAccessFactory(
 	tokenVerifier TokenVerifier,
 	teamFetcher TeamFetcher,
+	scimGroupFetcher SCIMGroupFetcher,
+	scimConnector string,
 	systemClaimKey string,
 	systemClaimValues []string,
 	displayUserIdGenerator atc.DisplayUserIdGenerator,
@@ -28,6 +35,8 @@ func NewAccessFactory(
 	return &accessFactory{
 		tokenVerifier:          tokenVerifier,
 		teamFetcher:            teamFetcher,
+		scimGroupFetcher:       scimGroupFetcher,
+		scimConnector:          scimConnector,
 		systemClaimKey:         systemClaimKey,
 		systemClaimValues:      systemClaimValues,
 		displayUserIdGenerator: displayUserIdGenerator,
@@ -37,6 +46,8 @@ func NewAccessFactory(
 type accessFactory struct {
 	tokenVerifier          TokenVerifier
 	teamFetcher            TeamFetcher
+	scimGroupFetcher       SCIMGroupFetcher
+	scimConnector          string
 	systemClaimKey         string
 	systemClaimValues      []string
 	displayUserIdGenerator atc.DisplayUserIdGenerator
@@ -47,7 +58,16 @@ func (a *accessFactory) Create(req *http.Request, role string) (Access, error) {
 	if err != nil {
 		return nil, fmt.Errorf("fetch teams: %w", err)
 	}
-	return NewAccessor(a.verifyToken(req), role, a.systemClaimKey, a.systemClaimValues, teams, a.displayUserIdGenerator), nil
+
+	verification := a.verifyToken(req)
+	if verification.IsTokenValid {
+		verification.SCIMGroups, err = a.scimGroups(verification.RawClaims)
+		if err != nil {
+			return nil, fmt.Errorf("fetch scim groups: %w", err)
+		}
+	}
+
+	return NewAccessor(verification, role, a.systemClaimKey, a.systemClaimValues, teams, a.displayUserIdGenerator), nil
 }
 
 func (a *accessFactory) verifyToken(req *http.Request) Verification {
@@ -63,3 +83,46 @@ func (a *accessFactory) verifyToken(req *http.Request) Verification {
 
 	return Verification{HasToken: true, IsTokenValid: true, RawClaims: claims}
 }
+
+// scimGroups returns the groups an identity provider has provisioned the user
+// into. Only users who logged in through the connector the identity provider
+// backs are matched, by the connector's user ID or by their verified email;
+// any other claim could be set to anything through another connector.
+func (a *accessFactory) scimGroups(claims map[string]interface{}) ([]string, error) {
+	federatedClaims, _ := claims["federated_claims"].(map[string]interface{})
+	connectorID, _ := federatedClaims["connector_id"].(string)
+	if a.scimConnector == "" || connectorID != a.scimConnector {
+		return nil, nil
+	}
+
+	memberships, err := a.scimGroupFetcher.GetSCIMGroupMemberships()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(memberships) == 0 {
+		return nil, nil
+	}
+
+	var identities []string
+	if userID, _ := federatedClaims["user_id"].(string); userID != "" {
+		identities = append(identities, db.SCIMExternalIDIdentity(userID))
+	}
+
+	if verified, _ := claims["email_verified"].(bool); verified {
+		if email, _ := claims["email"].(string); email != "" {
+			identities = append(identities, db.SCIMEmailIdentity(email))
+		}
+	}
+
+	var groups []string
+	for _, identity := range identities {
+		for _, group := range memberships[identity] {
+			if !contains(groups, group) {
+				groups = append(groups, group)
+			}
+		}
+	}
+
+	return groups, nil
+}
@@ -18,11 +18,13 @@ import (
 
 var _ = Describe("AccessorFactory", func() {
 	var (
+		scimConnector     string
 		systemClaimKey    string
 		systemClaimValues []string
 
 		fakeTokenVerifier *accessorfakes.FakeTokenVerifier
 		fakeTeamFetcher   *accessorfakes.FakeTeamFetcher
+		fakeSCIMFetcher   *accessorfakes.FakeSCIMGroupFetcher
 		dummyRequest      *http.Request
 
 		fakeDisplayUserIdGenerator *atcfakes.FakeDisplayUserIdGenerator
@@ -31,11 +33,13 @@ var _ = Describe("AccessorFactory", func() {
 	)
 
 	BeforeEach(func() {
+		scimConnector = "okta"
 		systemClaimKey = "sub"
 		systemClaimValues = []string{"some-sub"}
 
 		fakeTokenVerifier = new(accessorfakes.FakeTokenVerifier)
 		fakeTeamFetcher = new(accessorfakes.FakeTeamFetcher)
+		fakeSCIMFetcher = new(accessorfakes.FakeSCIMGroupFetcher)
 		dummyRequest, _ = http.NewRequest("GET", "/", nil)
 
 		fakeDisplayUserIdGenerator = new(atcfakes.FakeDisplayUserIdGenerator)
@@ -51,7 +55,7 @@ var _ = Describe("AccessorFactory", func() {
 		)
 
 		JustBeforeEach(func() {
-			factory := accessor.NewAccessFactory(fakeTokenVerifier, fakeTeamFetcher, systemClaimKey, systemClaimValues, fakeDisplayUserIdGenerator)
+			factory := accessor.NewAccessFactory(fakeTokenVerifier, fakeTeamFetcher, fakeSCIMFetcher, scimConnector, systemClaimKey, systemClaimValues, fakeDisplayUserIdGenerator)
 			access, err = factory.Create(dummyRequest, role)
 		})
 
@@ -83,6 +87,126 @@ var _ = Describe("AccessorFactory", func() {
 			It("returns an accessor with the correct teams", func() {
 				Expect(access.TeamNames()).To(ConsistOf("t1", "t3"))
 			})
+
+			Context("when the user has been provisioned into a group over SCIM", func() {
+				var claims map[string]interface{}
+
+				BeforeEach(func() {
+					claims = map[string]interface{}{
+						"email":          "user1@example.com",
+						"email_verified": true,
+						"name":           "user1",
+						"federated_claims": map[string]interface{}{
+							"connector_id": "okta",
+							"user_id":      "some-okta-id",
+						},
+					}
+					fakeTokenVerifier.VerifyReturns(claims, nil)
+
+					fakeSCIMFetcher.GetSCIMGroupMembershipsReturns(map[string][]string{
+						"email:user1@example.com":  {"Developers"},
+						"external_id:some-okta-id": {"Operators"},
+						"email:user1":              {"Admins"},
+					}, nil)
+
+					team := new(dbfakes.FakeTeam)
+					team.NameReturns("t4")
+					team.AuthReturns(atc.TeamAuth{
+						"member": map[string][]string{
+							"groups": {"scim:developers"},
+						},
+						"pipeline-operator": map[string][]string{
+							"groups": {"scim:operators"},
+						},
+						"owner": map[string][]string{
+							"groups": {"scim:admins"},
+						},
+					})
+					fakeTeamFetcher.GetTeamsReturns([]db.Team{team}, nil)
+				})
+
+				It("grants the roles of the groups matching the verified email and user ID", func() {
+					Expect(access.TeamRoles()).To(HaveKeyWithValue("t4", ConsistOf("member", "pipeline-operator")))
+				})
+
+				Context("when the email is not verified", func() {
+					BeforeEach(func() {
+						claims["email_verified"] = false
+					})
+
+					It("only grants the roles of the group matching the user ID", func() {
+						Expect(access.TeamRoles()).To(Equal(map[string][]string{"t4": {"pipeline-operator"}}))
+					})
+				})
+
+				Context("when the token is from another connector", func() {
+					BeforeEach(func() {
+						claims["federated_claims"] = map[string]interface{}{
+							"connector_id": "github",
+							"user_id":      "some-okta-id",
+						}
+					})
+
+					It("grants no groups' roles", func() {
+						Expect(access.TeamRoles()).To(BeEmpty())
+					})
+				})
+
+				Context("when no SCIM connector is configured", func() {
+					BeforeEach(func() {
+						scimConnector = ""
+					})
+
+					It("grants no groups' roles", func() {
+						Expect(access.TeamRoles()).To(BeEmpty())
+					})
+				})
+			})
+
+			Context("when a token from another connector has a name matching a SCIM user", func() {
+				BeforeEach(func() {
+					fakeTokenVerifier.VerifyReturns(map[string]interface{}{
+						"name":               "user1@example.com",
+						"preferred_username": "user1@example.com",
+						"email":              "user1@example.com",
+						"federated_claims": map[string]interface{}{
+							"connector_id": "github",
+							"user_id":      "1234",
+						},
+					}, nil)
+
+					fakeSCIMFetcher.GetSCIMGroupMembershipsReturns(map[string][]string{
+						"email:user1@example.com": {"Developers"},
+					}, nil)
+
+					team := new(dbfakes.FakeTeam)
+					team.NameReturns("t4")
+					team.AuthReturns(atc.TeamAuth{"member": map[string][]string{
+						"groups": {"scim:developers"},
+					}})
+					fakeTeamFetcher.GetTeamsReturns([]db.Team{team}, nil)
+				})
+
+				It("grants no groups", func() {
+					Expect(access.TeamRoles()).To(BeEmpty())
+				})
+			})
+
+			Context("when fetching the SCIM groups fails", func() {
+				BeforeEach(func() {
+					fakeTokenVerifier.VerifyReturns(map[string]interface{}{
+						"federated_claims": map[string]interface{}{
+							"connector_id": "okta",
+							"user_id":      "some-okta-id",
+						},
+					}, nil)
+					fakeSCIMFetcher.GetSCIMGroupMembershipsReturns(nil, errors.New("nope"))
+				})
+
+				It("returns an error", func() {
+					Expect(err).To(HaveOccurred())
+				})
+			})
 		})
 
 		Context("when the team fetcher returns an error", func() {
@@ -0,0 +1,91 @@
+package accessor
+
+import (
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/patrickmn/go-cache"
+)
+
+const revokedTokensCacheName = "revoked_access_tokens"
+
+// revocationsCacher refuses access tokens which have been revoked. It has to
+// sit in front of the claims cacher, which would otherwise keep accepting a
+// token it has already seen.
+type revocationsCacher struct {
+	logger             lager.Logger
+	cache              *cache.Cache
+	notifications      Notifications
+	accessTokenFetcher AccessTokenFetcher
+	sessionRepository  db.SessionRepository
+}
+
+func NewRevocationsCacher(
+	logger lager.Logger,
+	notifications Notifications,
+	accessTokenFetcher AccessTokenFetcher,
+	sessionRepository db.SessionRepository,
+	expiration time.Duration,
+	cleanupInterval time.Duration,
+) *revocationsCacher {
+	c := &revocationsCacher{
+		logger:             logger,
+		cache:              cache.New(expiration, cleanupInterval),
+		notifications:      notifications,
+		accessTokenFetcher: accessTokenFetcher,
+		sessionRepository:  sessionRepository,
+	}
+
+	go c.waitForNotifications()
+
+	return c
+}
+
+func (c *revocationsCacher) GetAccessToken(rawToken string) (db.AccessToken, bool, error) {
+	revoked, err := c.revokedTokens()
+	if err != nil {
+		return db.AccessToken{}, false, err
+	}
+
+	if _, found := revoked[rawToken]; found {
+		return db.AccessToken{}, false, nil
+	}
+
+	return c.accessTokenFetcher.GetAccessToken(rawToken)
+}
+
+func (c *revocationsCacher) revokedTokens() (map[string]struct{}, error) {
+	if revoked, found := c.cache.Get(revokedTokensCacheName); found {
+		return revoked.(map[string]struct{}), nil
+	}
+
+	tokens, err := c.sessionRepository.RevokedAccessTokens()
+	if err != nil {
+		return nil, err
+	}
+
+	revoked := make(map[string]struct{}, len(tokens))
+	for _, token := range tokens {
+		revoked[token] = struct{}{}
+	}
+
+	c.cache.Set(revokedTokensCacheName, revoked, cache.DefaultExpiration)
+
+	return revoked, nil
+}
+
+func (c *revocationsCacher) waitForNotifications() {
+	notifier, err := c.notifications.Listen(atc.AccessTokenRevocationChannel, 1)
+	if err != nil {
+		c.logger.Error("failed-to-listen-for-access-token-revocations", err)
+	}
+
+	defer c.notifications.Unlisten(atc.AccessTokenRevocationChannel, notifier)
+
+	for {
+		<-notifier
+		c.cache.Delete(revokedTokensCacheName)
+	}
+}
@@ -0,0 +1,98 @@
+package accessor_test
+
+import (
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/api/accessor"
+	"github.com/concourse/concourse/atc/api/accessor/accessorfakes"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RevocationsCacher", func() {
+	var (
+		fakeNotifications      *accessorfakes.FakeNotifications
+		fakeAccessTokenFetcher *accessorfakes.FakeAccessTokenFetcher
+		fakeSessionRepository  *dbfakes.FakeSessionRepository
+		notifier               chan db.Notification
+
+		revocationsCacher accessor.AccessTokenFetcher
+	)
+
+	BeforeEach(func() {
+		notifier = make(chan db.Notification, 1)
+		fakeNotifications = new(accessorfakes.FakeNotifications)
+		fakeNotifications.ListenReturns(notifier, nil)
+
+		fakeAccessTokenFetcher = new(accessorfakes.FakeAccessTokenFetcher)
+		fakeAccessTokenFetcher.GetAccessTokenReturns(db.AccessToken{Token: "some-token"}, true, nil)
+
+		fakeSessionRepository = new(dbfakes.FakeSessionRepository)
+		fakeSessionRepository.RevokedAccessTokensReturns([]string{"revoked-token"}, nil)
+	})
+
+	JustBeforeEach(func() {
+		revocationsCacher = accessor.NewRevocationsCacher(
+			lager.NewLogger("test"),
+			fakeNotifications,
+			fakeAccessTokenFetcher,
+			fakeSessionRepository,
+			time.Minute,
+			time.Minute,
+		)
+	})
+
+	It("fetches tokens which have not been revoked", func() {
+		token, found, err := revocationsCacher.GetAccessToken("some-token")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(token.Token).To(Equal("some-token"))
+	})
+
+	It("refuses revoked tokens without fetching them", func() {
+		_, found, err := revocationsCacher.GetAccessToken("revoked-token")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeFalse())
+		Expect(fakeAccessTokenFetcher.GetAccessTokenCallCount()).To(BeZero())
+	})
+
+	It("caches the revoked tokens", func() {
+		revocationsCacher.GetAccessToken("some-token")
+		revocationsCacher.GetAccessToken("revoked-token")
+		Expect(fakeSessionRepository.RevokedAccessTokensCallCount()).To(Equal(1))
+	})
+
+	Context("when a token is revoked", func() {
+		JustBeforeEach(func() {
+			_, found, err := revocationsCacher.GetAccessToken("some-token")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			fakeSessionRepository.RevokedAccessTokensReturns([]string{"revoked-token", "some-token"}, nil)
+			notifier <- db.Notification{Healthy: true}
+		})
+
+		It("refuses it straight away", func() {
+			Eventually(func() bool {
+				_, found, _ := revocationsCacher.GetAccessToken("some-token")
+				return found
+			}).Should(BeFalse())
+		})
+	})
+
+	Context("when fetching the revoked tokens fails", func() {
+		BeforeEach(func() {
+			fakeSessionRepository.RevokedAccessTokensReturns(nil, errors.New("nope"))
+		})
+
+		It("returns the error", func() {
+			_, _, err := revocationsCacher.GetAccessToken("some-token")
+			Expect(err).To(MatchError("nope"))
+		})
+	})
+})
@@ -14,14 +14,24 @@ const (
 var DefaultRoles = map[string]string{
 	atc.SaveConfig:                     MemberRole,
 	atc.GetConfig:                      ViewerRole,
+	atc.ValidateConfig:                 ViewerRole,
+	atc.ReportConfigVars:               MemberRole,
+	atc.StageConfig:                    MemberRole,
+	atc.PromoteCandidateConfig:         MemberRole,
+	atc.DiscardCandidateConfig:         MemberRole,
+	atc.GetConfigSchema:                ViewerRole,
 	atc.GetCC:                          ViewerRole,
 	atc.GetBuild:                       ViewerRole,
 	atc.GetBuildPlan:                   ViewerRole,
+	atc.GetBuildSteps:                  ViewerRole,
+	atc.GetBuildProvenance:             ViewerRole,
 	atc.CreateBuild:                    MemberRole,
+	atc.RunTask:                        MemberRole,
 	atc.ListBuilds:                     ViewerRole,
 	atc.BuildEvents:                    ViewerRole,
 	atc.BuildResources:                 ViewerRole,
 	atc.AbortBuild:                     OperatorRole,
+	atc.AbortBuildStep:                 OperatorRole,
 	atc.GetBuildPreparation:            ViewerRole,
 	atc.GetJob:                         ViewerRole,
 	atc.CreateJobBuild:                 OperatorRole,
@@ -31,6 +41,11 @@ var DefaultRoles = map[string]string{
 	atc.ListJobs:                       ViewerRole,
 	atc.ListJobBuilds:                  ViewerRole,
 	atc.ListJobInputs:                  ViewerRole,
+	atc.ListJobStatistics:              ViewerRole,
+	atc.GetJobStatistics:               ViewerRole,
+	atc.ListJobFlakiness:               ViewerRole,
+	atc.ListFlakyJobs:                  ViewerRole,
+	atc.ListTeamBuildQueue:             ViewerRole,
 	atc.GetJobBuild:                    ViewerRole,
 	atc.PauseJob:                       OperatorRole,
 	atc.UnpauseJob:                     OperatorRole,
@@ -45,7 +60,10 @@ var DefaultRoles = map[string]string{
 	atc.GetResource:                    ViewerRole,
 	atc.UnpinResource:                  OperatorRole,
 	atc.SetPinCommentOnResource:        OperatorRole,
+	atc.ResetResourceCheckFrom:         OperatorRole,
 	atc.CheckResource:                  OperatorRole,
+	atc.FetchResourceVersionMetadata:   OperatorRole,
+	atc.BackfillResourceMetadata:       OperatorRole,
 	atc.CheckResourceWebHook:           OperatorRole,
 	atc.CheckResourceType:              OperatorRole,
 	atc.CheckPrototype:                 OperatorRole,
@@ -54,10 +72,12 @@ var DefaultRoles = map[string]string{
 	atc.EnableResourceVersion:          OperatorRole,
 	atc.DisableResourceVersion:         OperatorRole,
 	atc.PinResourceVersion:             OperatorRole,
+	atc.PushResourceVersions:           OperatorRole,
 	atc.ListBuildsWithVersionAsInput:   ViewerRole,
 	atc.ListBuildsWithVersionAsOutput:  ViewerRole,
 	atc.GetDownstreamResourceCausality: ViewerRole,
 	atc.GetUpstreamResourceCausality:   ViewerRole,
+	atc.GetResourceVersionImpact:       ViewerRole,
 	atc.ClearResourceCache:             OperatorRole,
 	atc.ListAllPipelines:               ViewerRole,
 	atc.ListPipelines:                  ViewerRole,
@@ -70,10 +90,17 @@ var DefaultRoles = map[string]string{
 	atc.UnpausePipeline:                OperatorRole,
 	atc.ExposePipeline:                 MemberRole,
 	atc.HidePipeline:                   MemberRole,
+	atc.PausePipelines:                 OperatorRole,
+	atc.UnpausePipelines:               OperatorRole,
+	atc.ExposePipelines:                MemberRole,
+	atc.HidePipelines:                  MemberRole,
+	atc.CheckPipelines:                 OperatorRole,
 	atc.RenamePipeline:                 MemberRole,
+	atc.MovePipeline:                   MemberRole,
 	atc.ListPipelineBuilds:             ViewerRole,
 	atc.CreatePipelineBuild:            MemberRole,
 	atc.PipelineBadge:                  ViewerRole,
+	atc.GetPipelineGraph:               ViewerRole,
 	atc.RegisterWorker:                 MemberRole,
 	atc.LandWorker:                     MemberRole,
 	atc.RetireWorker:                   MemberRole,
@@ -81,25 +108,59 @@ var DefaultRoles = map[string]string{
 	atc.HeartbeatWorker:                MemberRole,
 	atc.ListWorkers:                    ViewerRole,
 	atc.DeleteWorker:                   MemberRole,
+	atc.ListWorkerKeys:                 MemberRole,
 	atc.SetLogLevel:                    MemberRole,
 	atc.GetLogLevel:                    ViewerRole,
+	atc.GetRequestLog:                  ViewerRole,
+	atc.SetRequestLog:                  MemberRole,
 	atc.DownloadCLI:                    ViewerRole,
 	atc.GetInfo:                        ViewerRole,
+	atc.GetOpenAPI:                     ViewerRole,
 	atc.GetInfoCreds:                   ViewerRole,
+	atc.GetHealth:                      ViewerRole,
 	atc.ListContainers:                 ViewerRole,
 	atc.GetContainer:                   ViewerRole,
 	atc.HijackContainer:                MemberRole,
+	atc.ListContainerProcesses:         ViewerRole,
+	atc.ListBuildContainers:            ViewerRole,
 	atc.ListDestroyingContainers:       ViewerRole,
 	atc.ReportWorkerContainers:         MemberRole,
 	atc.ListVolumes:                    ViewerRole,
+	atc.ListBuildVolumes:               ViewerRole,
 	atc.ListDestroyingVolumes:          ViewerRole,
 	atc.ReportWorkerVolumes:            MemberRole,
 	atc.ListTeams:                      ViewerRole,
 	atc.GetTeam:                        ViewerRole,
 	atc.SetTeam:                        OwnerRole,
 	atc.RenameTeam:                     OwnerRole,
+	atc.ListTeamUsers:                  OwnerRole,
 	atc.DestroyTeam:                    OwnerRole,
 	atc.ListTeamBuilds:                 ViewerRole,
+	atc.GetTeamWorkerKeys:              MemberRole,
+	atc.SetTeamWorkerKeys:              OwnerRole,
+	atc.GetTeamInterceptSettings:       ViewerRole,
+	atc.SetTeamInterceptSettings:       OwnerRole,
+	atc.GetTeamPrivilegedSettings:      ViewerRole,
+	atc.SetTeamPrivilegedSettings:      OwnerRole,
+	atc.GetTeamProxySettings:           ViewerRole,
+	atc.SetTeamProxySettings:           OwnerRole,
+	atc.ListNotifiers:                  MemberRole,
+	atc.SetNotifier:                    OwnerRole,
+	atc.DestroyNotifier:                OwnerRole,
+	atc.ListTeamWebhooks:               MemberRole,
+	atc.SetTeamWebhook:                 OwnerRole,
+	atc.DestroyTeamWebhook:             OwnerRole,
+	atc.ListTeamWebhookDeliveries:      MemberRole,
+	atc.ListTeamLogDrains:              MemberRole,
+	atc.SetTeamLogDrain:                OwnerRole,
+	atc.DestroyTeamLogDrain:            OwnerRole,
+	atc.ListTeamFreezeWindows:          ViewerRole,
+	atc.CreateTeamFreezeWindow:         MemberRole,
+	atc.DestroyTeamFreezeWindow:        MemberRole,
+	atc.GetTeamInjectedEnv:             MemberRole,
+	atc.SetTeamInjectedEnv:             OwnerRole,
+	atc.GetTeamCABundle:                ViewerRole,
+	atc.SetTeamCABundle:                OwnerRole,
 	atc.CreateArtifact:                 MemberRole,
 	atc.GetArtifact:                    MemberRole,
 	atc.ListBuildArtifacts:             ViewerRole,
@@ -0,0 +1,65 @@
+package accessor
+
+import (
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/patrickmn/go-cache"
+)
+
+type scimGroupsCacher struct {
+	logger         lager.Logger
+	cache          *cache.Cache
+	notifications  Notifications
+	scimRepository db.SCIMRepository
+}
+
+func NewSCIMGroupsCacher(
+	logger lager.Logger,
+	notifications Notifications,
+	scimRepository db.SCIMRepository,
+	expiration time.Duration,
+	cleanupInterval time.Duration,
+) *scimGroupsCacher {
+	c := &scimGroupsCacher{
+		logger:         logger,
+		cache:          cache.New(expiration, cleanupInterval),
+		notifications:  notifications,
+		scimRepository: scimRepository,
+	}
+
+	go c.waitForNotifications()
+
+	return c
+}
+
+func (c *scimGroupsCacher) GetSCIMGroupMemberships() (map[string][]string, error) {
+	if memberships, found := c.cache.Get(atc.SCIMGroupsCacheName); found {
+		return memberships.(map[string][]string), nil
+	}
+
+	memberships, err := c.scimRepository.SCIMGroupMemberships()
+	if err != nil {
+		return nil, err
+	}
+
+	c.cache.Set(atc.SCIMGroupsCacheName, memberships, cache.DefaultExpiration)
+
+	return memberships, nil
+}
+
+func (c *scimGroupsCacher) waitForNotifications() {
+	notifier, err := c.notifications.Listen(atc.SCIMChannel, 1)
+	if err != nil {
+		c.logger.Error("failed-to-listen-for-scim-changes", err)
+	}
+
+	defer c.notifications.Unlisten(atc.SCIMChannel, notifier)
+
+	for {
+		<-notifier
+		c.cache.Delete(atc.SCIMGroupsCacheName)
+	}
+}
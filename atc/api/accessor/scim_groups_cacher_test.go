@@ -0,0 +1,74 @@
+package accessor_test
+
+import (
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/api/accessor"
+	"github.com/concourse/concourse/atc/api/accessor/accessorfakes"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SCIMGroupsCacher", func() {
+	var (
+		fakeNotifications  *accessorfakes.FakeNotifications
+		fakeSCIMRepository *dbfakes.FakeSCIMRepository
+
+		fetcher     accessor.SCIMGroupFetcher
+		notifier    chan db.Notification
+		memberships map[string][]string
+	)
+
+	BeforeEach(func() {
+		notifier = make(chan db.Notification, 1)
+		fakeNotifications = new(accessorfakes.FakeNotifications)
+		fakeNotifications.ListenReturns(notifier, nil)
+
+		fakeSCIMRepository = new(dbfakes.FakeSCIMRepository)
+		fakeSCIMRepository.SCIMGroupMembershipsReturns(map[string][]string{
+			"some-user": {"developers"},
+		}, nil)
+	})
+
+	JustBeforeEach(func() {
+		fetcher = accessor.NewSCIMGroupsCacher(lager.NewLogger("test"), fakeNotifications, fakeSCIMRepository, time.Minute, time.Minute)
+
+		var err error
+		memberships, err = fetcher.GetSCIMGroupMemberships()
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("fetches the memberships from the DB", func() {
+		Expect(fakeSCIMRepository.SCIMGroupMembershipsCallCount()).To(Equal(1))
+		Expect(memberships).To(Equal(map[string][]string{"some-user": {"developers"}}))
+	})
+
+	It("caches the memberships", func() {
+		_, err := fetcher.GetSCIMGroupMemberships()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fakeSCIMRepository.SCIMGroupMembershipsCallCount()).To(Equal(1))
+	})
+
+	It("listens for SCIM changes", func() {
+		Eventually(fakeNotifications.ListenCallCount).Should(Equal(1))
+		channel, _ := fakeNotifications.ListenArgsForCall(0)
+		Expect(channel).To(Equal("scim"))
+	})
+
+	Context("when a user or group changes", func() {
+		JustBeforeEach(func() {
+			notifier <- db.Notification{Healthy: true}
+		})
+
+		It("fetches the memberships again", func() {
+			Eventually(func() int {
+				_, err := fetcher.GetSCIMGroupMemberships()
+				Expect(err).NotTo(HaveOccurred())
+				return fakeSCIMRepository.SCIMGroupMembershipsCallCount()
+			}).Should(BeNumerically(">=", 2))
+		})
+	})
+})
@@ -21,6 +21,7 @@ import (
 	"github.com/concourse/concourse/atc/api/apifakes"
 	"github.com/concourse/concourse/atc/api/auth"
 	"github.com/concourse/concourse/atc/api/containerserver/containerserverfakes"
+	"github.com/concourse/concourse/atc/api/healthserver/healthserverfakes"
 	"github.com/concourse/concourse/atc/api/policychecker/policycheckerfakes"
 	"github.com/concourse/concourse/atc/auditor/auditorfakes"
 	"github.com/concourse/concourse/atc/creds"
@@ -29,14 +30,19 @@ import (
 	"github.com/concourse/concourse/atc/db/dbfakes"
 	"github.com/concourse/concourse/atc/gc/gcfakes"
 	"github.com/concourse/concourse/atc/policy"
+	"github.com/concourse/concourse/atc/requestlog"
+	"github.com/concourse/concourse/atc/worker/gardenruntime/transport/transportfakes"
 	"github.com/concourse/concourse/atc/wrappa"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
 
+const maxRunningOneOffBuilds = 5
+
 var (
-	sink *lager.ReconfigurableSink
+	sink               *lager.ReconfigurableSink
+	requestLogRecorder *requestlog.Recorder
 
 	externalURL      = "https://example.com"
 	clusterName      = "Test Cluster"
@@ -47,10 +53,12 @@ var (
 	"global_resources": false,
 	"pipeline_instances": true,
 	"redact_secrets": false,
-	"resource_causality": false
+	"resource_causality": false,
+	"volume_digests": false
 }`
 
 	fakeWorkerPool          *apifakes.FakePool
+	fakeCircuitBreakers     *transportfakes.FakeCircuitBreakers
 	fakeVolumeRepository    *dbfakes.FakeVolumeRepository
 	fakeContainerRepository *dbfakes.FakeContainerRepository
 	fakeDestroyer           *gcfakes.FakeDestroyer
@@ -71,12 +79,34 @@ var (
 	dbCheckFactory          *dbfakes.FakeCheckFactory
 	dbTeam                  *dbfakes.FakeTeam
 	dbWall                  *dbfakes.FakeWall
+	dbWebhookRepository     *dbfakes.FakeOutgoingWebhookRepository
+	dbLogDrainRepository    *dbfakes.FakeLogDrainRepository
+	dbFreezeWindows         *dbfakes.FakeFreezeWindowRepository
+	dbInjectedEnv           *dbfakes.FakeInjectedEnvRepository
+	dbCABundles             *dbfakes.FakeCABundleRepository
+	dbBuildStats            *dbfakes.FakeBuildStatsRepository
+	dbFlakiness             *dbfakes.FakeFlakinessRepository
+	dbBuildQueue            *dbfakes.FakeBuildQueueRepository
+	dbSchedulingStats       *dbfakes.FakeSchedulingStatsRepository
+	dbComponentFactory      *dbfakes.FakeComponentFactory
+	dbSchemaReference       *dbfakes.FakeSchemaReference
+	dbMaintenance           *dbfakes.FakeMaintenanceRepository
+	dbGrowth                *dbfakes.FakeGrowthRepository
+	dbDeprecationUsage      *dbfakes.FakeDeprecationUsageRepository
+	dbAutoscaling           *dbfakes.FakeAutoscalingRepository
+	dbTeamRequests          *dbfakes.FakeTeamRequestRepository
+	dbDashboardPreferences  *dbfakes.FakeDashboardPreferenceRepository
+	dbUserPreferences       *dbfakes.FakeUserPreferenceRepository
+	dbSessions              *dbfakes.FakeSessionRepository
+	fakeHealthChecker       *healthserverfakes.FakeChecker
+	dbClusterOverview       *dbfakes.FakeClusterOverviewRepository
 	fakeSecretManager       *credsfakes.FakeSecrets
 	fakeVarSourcePool       *credsfakes.FakeVarSourcePool
 	fakePolicyChecker       *policycheckerfakes.FakePolicyChecker
 	credsManagers           creds.Managers
 	interceptTimeoutFactory *containerserverfakes.FakeInterceptTimeoutFactory
 	interceptTimeout        *containerserverfakes.FakeInterceptTimeout
+	fakeAuditor             *auditorfakes.FakeAuditor
 	isTLSEnabled            bool
 	cliDownloadsDir         string
 	logger                  *lagertest.TestLogger
@@ -119,9 +149,31 @@ var _ = BeforeEach(func() {
 	dbUserFactory = new(dbfakes.FakeUserFactory)
 	dbCheckFactory = new(dbfakes.FakeCheckFactory)
 	dbWall = new(dbfakes.FakeWall)
+	dbWebhookRepository = new(dbfakes.FakeOutgoingWebhookRepository)
+	dbLogDrainRepository = new(dbfakes.FakeLogDrainRepository)
+	dbFreezeWindows = new(dbfakes.FakeFreezeWindowRepository)
+	dbInjectedEnv = new(dbfakes.FakeInjectedEnvRepository)
+	dbCABundles = new(dbfakes.FakeCABundleRepository)
+	dbBuildStats = new(dbfakes.FakeBuildStatsRepository)
+	dbFlakiness = new(dbfakes.FakeFlakinessRepository)
+	dbBuildQueue = new(dbfakes.FakeBuildQueueRepository)
+	dbSchedulingStats = new(dbfakes.FakeSchedulingStatsRepository)
+	dbComponentFactory = new(dbfakes.FakeComponentFactory)
+	dbSchemaReference = new(dbfakes.FakeSchemaReference)
+	dbMaintenance = new(dbfakes.FakeMaintenanceRepository)
+	dbGrowth = new(dbfakes.FakeGrowthRepository)
+	dbDeprecationUsage = new(dbfakes.FakeDeprecationUsageRepository)
+	dbAutoscaling = new(dbfakes.FakeAutoscalingRepository)
+	dbTeamRequests = new(dbfakes.FakeTeamRequestRepository)
+	dbDashboardPreferences = new(dbfakes.FakeDashboardPreferenceRepository)
+	dbUserPreferences = new(dbfakes.FakeUserPreferenceRepository)
+	dbSessions = new(dbfakes.FakeSessionRepository)
+	fakeHealthChecker = new(healthserverfakes.FakeChecker)
+	dbClusterOverview = new(dbfakes.FakeClusterOverviewRepository)
 
 	interceptTimeoutFactory = new(containerserverfakes.FakeInterceptTimeoutFactory)
 	interceptTimeout = new(containerserverfakes.FakeInterceptTimeout)
+	fakeAuditor = new(auditorfakes.FakeAuditor)
 	interceptTimeoutFactory.NewInterceptTimeoutReturns(interceptTimeout)
 
 	dbTeam = new(dbfakes.FakeTeam)
@@ -142,6 +194,7 @@ var _ = BeforeEach(func() {
 	dbWorkerLifecycle = new(dbfakes.FakeWorkerLifecycle)
 
 	fakeWorkerPool = new(apifakes.FakePool)
+	fakeCircuitBreakers = new(transportfakes.FakeCircuitBreakers)
 
 	fakeVolumeRepository = new(dbfakes.FakeVolumeRepository)
 	fakeContainerRepository = new(dbfakes.FakeContainerRepository)
@@ -163,6 +216,9 @@ var _ = BeforeEach(func() {
 
 	sink = lager.NewReconfigurableSink(lager.NewPrettySink(GinkgoWriter, lager.DEBUG), lager.DEBUG)
 
+	requestLogRecorder, err = requestlog.NewRecorder(logger, atc.RequestLogSettings{})
+	Expect(err).NotTo(HaveOccurred())
+
 	isTLSEnabled = false
 
 	build = new(dbfakes.FakeBuild)
@@ -211,10 +267,13 @@ var _ = BeforeEach(func() {
 		dbUserFactory,
 
 		constructedEventHandler.Construct,
+		maxRunningOneOffBuilds,
 
 		fakeWorkerPool,
+		fakeCircuitBreakers,
 
 		sink,
+		requestLogRecorder,
 
 		isTLSEnabled,
 
@@ -226,7 +285,29 @@ var _ = BeforeEach(func() {
 		credsManagers,
 		interceptTimeoutFactory,
 		time.Second,
+		fakeAuditor,
 		dbWall,
+		dbWebhookRepository,
+		dbLogDrainRepository,
+		dbFreezeWindows,
+		dbBuildStats,
+		dbFlakiness,
+		dbBuildQueue,
+		dbSchedulingStats,
+		dbComponentFactory,
+		dbSchemaReference,
+		dbMaintenance,
+		dbGrowth,
+		dbAutoscaling,
+		dbDeprecationUsage,
+		dbTeamRequests,
+		dbDashboardPreferences,
+		dbUserPreferences,
+		dbSessions,
+		fakeHealthChecker,
+		dbClusterOverview,
+		dbInjectedEnv,
+		dbCABundles,
 		fakeClock,
 	)
 
@@ -239,7 +320,7 @@ var _ = BeforeEach(func() {
 		"some-action",
 		handler,
 		fakeAccessor,
-		new(auditorfakes.FakeAuditor),
+		fakeAuditor,
 		map[string]string{},
 	)
 
@@ -130,10 +130,20 @@ func (h checkBuildReadAccessHandler) allow(build db.BuildForAPI, acc accessor.Ac
 		return false, nil
 	}
 
+	exposure := pipeline.Exposure()
+	if !exposure.JobExposed(build.JobName()) {
+		return false, nil
+	}
+
 	if h.allowPrivateJob {
 		return true, nil
 	}
 
+	// pipelines exposing only build statuses never expose their logs
+	if exposure.StatusOnly {
+		return false, nil
+	}
+
 	if build.JobID() == 0 {
 		return false, nil
 	}
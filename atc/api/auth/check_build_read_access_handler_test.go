@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/api/accessor"
 	"github.com/concourse/concourse/atc/api/accessor/accessorfakes"
 	"github.com/concourse/concourse/atc/api/auth"
@@ -194,6 +195,25 @@ var _ = Describe("CheckBuildReadAccessHandler", func() {
 					})
 
 					ItReturnsTheBuild()
+
+					Context("when the pipeline only exposes build statuses", func() {
+						BeforeEach(func() {
+							pipeline.ExposureReturns(atc.PipelineExposure{StatusOnly: true})
+						})
+
+						ItReturnsTheBuild()
+					})
+
+					Context("when the pipeline does not expose the build's job", func() {
+						BeforeEach(func() {
+							build.JobNameReturns("some-job")
+							pipeline.ExposureReturns(atc.PipelineExposure{Jobs: []string{"other-job"}})
+						})
+
+						It("returns 401", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+						})
+					})
 				})
 
 				Context("when pipeline is private", func() {
@@ -278,6 +298,28 @@ var _ = Describe("CheckBuildReadAccessHandler", func() {
 					})
 
 					ItReturnsTheBuild()
+
+					Context("but the pipeline only exposes build statuses", func() {
+						BeforeEach(func() {
+							build.JobNameReturns("some-job")
+							pipeline.ExposureReturns(atc.PipelineExposure{StatusOnly: true})
+						})
+
+						It("returns "+fmt.Sprint(status), func() {
+							Expect(response.StatusCode).To(Equal(status))
+						})
+					})
+
+					Context("but the pipeline does not expose the job", func() {
+						BeforeEach(func() {
+							build.JobNameReturns("some-job")
+							pipeline.ExposureReturns(atc.PipelineExposure{Jobs: []string{"other-job"}})
+						})
+
+						It("returns "+fmt.Sprint(status), func() {
+							Expect(response.StatusCode).To(Equal(status))
+						})
+					})
 				})
 
 				Context("and job is private", func() {
@@ -0,0 +1,119 @@
+package api_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Autoscaling API", func() {
+	Describe("GET /api/v1/autoscaling", func() {
+		var response *http.Response
+
+		BeforeEach(func() {
+			dbAutoscaling.PendingJobBuildsReturns([]db.PendingJobBuilds{
+				{
+					JobID: 1,
+					Config: atc.JobConfig{
+						Name: "some-job",
+						PlanSequence: []atc.Step{
+							{Config: &atc.TaskStep{Name: "some-task", Tags: atc.Tags{"gpu"}}},
+						},
+					},
+					Pending:       3,
+					OldestPending: time.Unix(63, 0),
+				},
+			}, nil)
+
+			worker := new(dbfakes.FakeWorker)
+			worker.StateReturns(db.WorkerStateRunning)
+			worker.PlatformReturns("linux")
+			worker.TagsReturns([]string{"gpu"})
+			worker.ActiveContainersReturns(4)
+			dbWorkerFactory.WorkersReturns([]db.Worker{worker}, nil)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/autoscaling")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated as an admin", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAdminReturns(true)
+			})
+
+			It("returns 200 with the demand for workers", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(body).To(MatchJSON(`{
+					"time": 123,
+					"groups": [
+						{
+							"tags": ["gpu"],
+							"pending_builds": 3,
+							"projected_containers": 3,
+							"oldest_pending_seconds": 60,
+							"workers": 1,
+							"active_containers": 4
+						},
+						{
+							"platform": "linux",
+							"tags": ["gpu"],
+							"pending_builds": 0,
+							"projected_containers": 0,
+							"oldest_pending_seconds": 0,
+							"workers": 1,
+							"active_containers": 4
+						}
+					]
+				}`))
+			})
+
+			Context("when getting the pending builds fails", func() {
+				BeforeEach(func() {
+					dbAutoscaling.PendingJobBuildsReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when authenticated but not an admin", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAdminReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(dbAutoscaling.PendingJobBuildsCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+})
@@ -0,0 +1,30 @@
+package autoscalingserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/concourse/concourse/atc/autoscaling"
+)
+
+// GetAutoscalingSignal returns the demand for workers from the builds waiting
+// to start, along with the workers which can meet it.
+func (s *Server) GetAutoscalingSignal(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("get-autoscaling-signal")
+
+	signal, err := autoscaling.Current(s.repository, s.workerFactory, s.clock.Now())
+	if err != nil {
+		logger.Error("failed-to-get-signal", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	err = json.NewEncoder(w).Encode(signal)
+	if err != nil {
+		logger.Error("failed-to-encode-signal", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,28 @@
+package autoscalingserver
+
+import (
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/db"
+)
+
+type Server struct {
+	logger        lager.Logger
+	repository    db.AutoscalingRepository
+	workerFactory db.WorkerFactory
+	clock         clock.Clock
+}
+
+func NewServer(
+	logger lager.Logger,
+	repository db.AutoscalingRepository,
+	workerFactory db.WorkerFactory,
+	clock clock.Clock,
+) *Server {
+	return &Server{
+		logger:        logger,
+		repository:    repository,
+		workerFactory: workerFactory,
+		clock:         clock,
+	}
+}
@@ -0,0 +1,105 @@
+package api_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Build Queue API", func() {
+	Describe("GET /api/v1/teams/:team_name/build_queue", func() {
+		var response *http.Response
+
+		BeforeEach(func() {
+			dbTeam.IDReturns(5)
+
+			dbBuildQueue.TeamBuildQueueReturns([]db.QueuedBuild{
+				{
+					ID:           42,
+					Name:         "3",
+					Status:       db.BuildStatusPending,
+					TeamName:     "some-team",
+					PipelineName: "some-pipeline",
+					JobID:        7,
+					JobName:      "some-job",
+					CreateTime:   time.Now().Add(-time.Minute),
+				},
+			}, nil)
+
+			dbBuildStats.BuildStatsReturns([]db.BuildStatsBucket{
+				{JobID: 7, Durations: []int64{120}},
+			}, nil)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/build_queue")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+			})
+
+			It("returns the team's queued builds with estimates", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+				Expect(dbBuildQueue.TeamBuildQueueArgsForCall(0)).To(Equal(5))
+
+				jobIDs, _ := dbBuildStats.BuildStatsArgsForCall(0)
+				Expect(jobIDs).To(Equal([]int{7}))
+
+				var queue []atc.QueuedBuild
+				err := json.NewDecoder(response.Body).Decode(&queue)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(queue).To(HaveLen(1))
+				Expect(queue[0].ID).To(Equal(42))
+				Expect(queue[0].JobName).To(Equal("some-job"))
+				Expect(queue[0].Position).To(Equal(1))
+				Expect(queue[0].EstimatedDuration).To(Equal(int64(120)))
+				Expect(queue[0].EstimatedStartTime).ToNot(BeZero())
+			})
+
+			Context("when getting the queue fails", func() {
+				BeforeEach(func() {
+					dbBuildQueue.TeamBuildQueueReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+
+			Context("when getting the build stats fails", func() {
+				BeforeEach(func() {
+					dbBuildStats.BuildStatsReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(dbBuildQueue.TeamBuildQueueCallCount()).To(BeZero())
+			})
+		})
+	})
+})
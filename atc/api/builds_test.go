@@ -12,6 +12,7 @@ import (
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/event"
 	. "github.com/concourse/concourse/atc/testhelpers"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -141,6 +142,166 @@ var _ = Describe("Builds API", func() {
 					})
 
 				})
+
+				Context("when the team has as many one-off builds running as allowed", func() {
+					BeforeEach(func() {
+						dbTeam.RunningOneOffBuildsReturns(maxRunningOneOffBuilds, nil)
+					})
+
+					It("returns 429 Too Many Requests", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusTooManyRequests))
+					})
+
+					It("does not create a build", func() {
+						Expect(dbTeam.CreateStartedBuildCallCount()).To(BeZero())
+					})
+				})
+			})
+		})
+	})
+
+	Describe("POST /api/v1/teams/:team_name/tasks", func() {
+		var request atc.RunTaskRequest
+		var response *http.Response
+
+		BeforeEach(func() {
+			request = atc.RunTaskRequest{
+				Config: atc.TaskConfig{
+					Platform: "linux",
+					Run: atc.TaskRunConfig{
+						Path: "ls",
+					},
+					Inputs: []atc.TaskInputConfig{
+						{Name: "some-input"},
+					},
+					Outputs: []atc.TaskOutputConfig{
+						{Name: "some-output"},
+					},
+				},
+				Inputs:  map[string]int{"some-input": 12},
+				Outputs: []string{"some-output"},
+			}
+		})
+
+		JustBeforeEach(func() {
+			reqPayload, err := json.Marshal(request)
+			Expect(err).NotTo(HaveOccurred())
+
+			req, err := http.NewRequest("POST", server.URL+"/api/v1/teams/some-team/tasks", bytes.NewBuffer(reqPayload))
+			Expect(err).NotTo(HaveOccurred())
+
+			req.Header.Set("Content-Type", "application/json")
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when authorized", func() {
+			var fakeBuild *dbfakes.FakeBuild
+
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+
+				fakeBuild = new(dbfakes.FakeBuild)
+				fakeBuild.IDReturns(42)
+				fakeBuild.NameReturns("1")
+				fakeBuild.TeamNameReturns("some-team")
+				fakeBuild.StatusReturns("started")
+				fakeBuild.StartTimeReturns(time.Unix(1, 0))
+
+				dbTeam.CreateStartedBuildReturns(fakeBuild, nil)
+			})
+
+			It("returns 201 Created with the build", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusCreated))
+
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(body).To(MatchJSON(`{
+					"id": 42,
+					"name": "1",
+					"team_name": "some-team",
+					"status": "started",
+					"api_url": "/api/v1/builds/42",
+					"start_time": 1
+				}`))
+			})
+
+			It("creates a started build running the task", func() {
+				Expect(dbTeam.CreateStartedBuildCallCount()).To(Equal(1))
+
+				plan := dbTeam.CreateStartedBuildArgsForCall(0)
+				Expect(plan.Ensure).ToNot(BeNil())
+
+				steps := *plan.Ensure.Step.Do
+				Expect(steps[0].InParallel.Steps[0].ArtifactInput.ArtifactID).To(Equal(12))
+				Expect(*steps[1].Task.Config).To(Equal(request.Config))
+				Expect(plan.Ensure.Next.InParallel.Steps[0].ArtifactOutput.Name).To(Equal("some-output"))
+			})
+
+			Context("when the request is invalid", func() {
+				BeforeEach(func() {
+					request.Outputs = []string{"bogus"}
+				})
+
+				It("returns 400 with the error", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(string(body)).To(ContainSubstring("unknown output 'bogus'"))
+				})
+
+				It("does not create a build", func() {
+					Expect(dbTeam.CreateStartedBuildCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the team has as many one-off builds running as allowed", func() {
+				BeforeEach(func() {
+					dbTeam.RunningOneOffBuildsReturns(maxRunningOneOffBuilds, nil)
+				})
+
+				It("returns 429 Too Many Requests", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusTooManyRequests))
+				})
+
+				It("does not create a build", func() {
+					Expect(dbTeam.CreateStartedBuildCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when creating the build fails", func() {
+				BeforeEach(func() {
+					dbTeam.CreateStartedBuildReturns(nil, errors.New("oh no!"))
+				})
+
+				It("returns 500 Internal Server Error", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
 			})
 		})
 	})
@@ -471,6 +632,74 @@ var _ = Describe("Builds API", func() {
 					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
 				})
 			})
+
+			Context("when an external ref is given", func() {
+				BeforeEach(func() {
+					queryParams = "?ref=pr-42@abc123"
+
+					build := new(dbfakes.FakeBuildForAPI)
+					build.IDReturns(4)
+					build.NameReturns("2")
+					build.JobNameReturns("job2")
+					build.PipelineNameReturns("pipeline2")
+					build.TeamNameReturns("some-team")
+					build.StatusReturns(db.BuildStatusStarted)
+					build.ExternalRefReturns("pr-42@abc123")
+
+					dbBuildFactory.VisibleBuildsWithExternalRefReturns([]db.BuildForAPI{build}, nil)
+				})
+
+				It("returns the builds with the ref", func() {
+					Expect(dbBuildFactory.VisibleBuildsCallCount()).To(Equal(0))
+					Expect(dbBuildFactory.VisibleBuildsWithExternalRefCallCount()).To(Equal(1))
+
+					teamNames, ref := dbBuildFactory.VisibleBuildsWithExternalRefArgsForCall(0)
+					Expect(teamNames).To(ConsistOf("some-team"))
+					Expect(ref).To(Equal("pr-42@abc123"))
+
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`[
+						{
+							"id": 4,
+							"name": "2",
+							"job_name": "job2",
+							"pipeline_name": "pipeline2",
+							"team_name": "some-team",
+							"status": "started",
+							"api_url": "/api/v1/builds/4",
+							"external_ref": "pr-42@abc123"
+						}
+					]`))
+				})
+
+				It("does not return Link headers", func() {
+					Expect(response.Header["Link"]).To(BeEmpty())
+				})
+
+				Context("when user has the admin privilege", func() {
+					BeforeEach(func() {
+						fakeAccess.IsAdminReturns(true)
+					})
+
+					It("looks through the builds of all teams", func() {
+						Expect(dbBuildFactory.AllBuildsWithExternalRefCallCount()).To(Equal(1))
+						Expect(dbBuildFactory.AllBuildsWithExternalRefArgsForCall(0)).To(Equal("pr-42@abc123"))
+						Expect(dbBuildFactory.VisibleBuildsWithExternalRefCallCount()).To(Equal(0))
+					})
+				})
+
+				Context("when getting the builds fails", func() {
+					BeforeEach(func() {
+						dbBuildFactory.VisibleBuildsWithExternalRefReturns(nil, errors.New("oh no!"))
+					})
+
+					It("returns 500 Internal Server Error", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
 		})
 	})
 
@@ -1120,6 +1349,101 @@ var _ = Describe("Builds API", func() {
 		})
 	})
 
+	Describe("PUT /api/v1/builds/:build_id/steps/:plan_id/abort", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequest("PUT", server.URL+"/api/v1/builds/128/steps/some-plan-id/abort", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+			})
+
+			Context("when the build can not be found", func() {
+				BeforeEach(func() {
+					dbBuildFactory.BuildForAPIReturns(nil, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when the build is found", func() {
+				BeforeEach(func() {
+					build.TeamNameReturns("some-team")
+					build.AllAssociatedTeamNamesReturns([]string{"some-team"})
+					build.IsRunningReturns(true)
+					dbBuildFactory.BuildForAPIReturns(build, true, nil)
+				})
+
+				Context("when not authorized", func() {
+					BeforeEach(func() {
+						fakeAccess.IsAuthorizedReturns(false)
+					})
+
+					It("returns 403", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+					})
+
+					It("does not abort the step", func() {
+						Expect(build.AbortStepCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when authorized", func() {
+					BeforeEach(func() {
+						fakeAccess.IsAuthorizedReturns(true)
+					})
+
+					It("aborts the step and returns 204", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+
+						Expect(build.AbortStepCallCount()).To(Equal(1))
+						Expect(build.AbortStepArgsForCall(0)).To(Equal(atc.PlanID("some-plan-id")))
+					})
+
+					Context("when aborting the step fails", func() {
+						BeforeEach(func() {
+							build.AbortStepReturns(errors.New("nope"))
+						})
+
+						It("returns 500", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+						})
+					})
+
+					Context("when the build is not running", func() {
+						BeforeEach(func() {
+							build.IsRunningReturns(false)
+						})
+
+						It("returns 409 without aborting anything", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusConflict))
+							Expect(build.AbortStepCallCount()).To(BeZero())
+						})
+					})
+				})
+			})
+		})
+	})
+
 	Describe("GET /api/v1/builds/:build_id/preparation", func() {
 		var response *http.Response
 
@@ -1138,6 +1462,7 @@ var _ = Describe("Builds API", func() {
 					PausedPipeline:   db.BuildPreparationStatusNotBlocking,
 					PausedJob:        db.BuildPreparationStatusNotBlocking,
 					MaxRunningBuilds: db.BuildPreparationStatusBlocking,
+					WorkerCapacity:   db.BuildPreparationStatusNotBlocking,
 					Inputs: map[string]db.BuildPreparationStatus{
 						"foo": db.BuildPreparationStatusNotBlocking,
 						"bar": db.BuildPreparationStatusBlocking,
@@ -1279,6 +1604,7 @@ var _ = Describe("Builds API", func() {
 					"paused_pipeline": "not_blocking",
 					"paused_job": "not_blocking",
 					"max_running_builds": "blocking",
+					"worker_capacity": "not_blocking",
 					"inputs": {
 						"foo": "not_blocking",
 						"bar": "blocking"
@@ -1540,4 +1866,283 @@ var _ = Describe("Builds API", func() {
 			})
 		})
 	})
+
+	Describe("GET /api/v1/builds/:build_id/steps", func() {
+		var response *http.Response
+
+		envelope := func(ev atc.Event) event.Envelope {
+			payload, err := json.Marshal(ev)
+			Expect(err).NotTo(HaveOccurred())
+
+			data := json.RawMessage(payload)
+
+			return event.Envelope{
+				Data:    &data,
+				Event:   ev.EventType(),
+				Version: ev.Version(),
+			}
+		}
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = http.Get(server.URL + "/api/v1/builds/42/steps")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the build is found", func() {
+			BeforeEach(func() {
+				build.IDReturns(42)
+				build.TeamNameReturns("some-team")
+				build.AllAssociatedTeamNamesReturns([]string{"some-team"})
+				build.JobIDReturns(42)
+				build.JobNameReturns("job1")
+				build.PipelineIDReturns(42)
+				dbBuildFactory.BuildForAPIReturns(build, true, nil)
+			})
+
+			Context("when authenticated, but not authorized", func() {
+				BeforeEach(func() {
+					fakeAccess.IsAuthenticatedReturns(true)
+					fakeAccess.IsAuthorizedReturns(false)
+
+					build.PipelineReturns(fakePipeline, true, nil)
+				})
+
+				It("returns 403", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				})
+			})
+
+			Context("when authenticated", func() {
+				BeforeEach(func() {
+					fakeAccess.IsAuthenticatedReturns(true)
+					fakeAccess.IsAuthorizedReturns(true)
+				})
+
+				Context("when the build has a plan", func() {
+					BeforeEach(func() {
+						plan := atc.Plan{
+							ID: "on-failure",
+							OnFailure: &atc.OnFailurePlan{
+								Step: atc.Plan{
+									ID: "do",
+									Do: &atc.DoPlan{
+										{ID: "get", Get: &atc.GetPlan{Name: "some-input"}},
+										{ID: "task", Task: &atc.TaskPlan{Name: "some-task"}},
+									},
+								},
+								Next: atc.Plan{ID: "notify", Task: &atc.TaskPlan{Name: "notify"}},
+							},
+						}
+
+						build.HasPlanReturns(true)
+						build.PublicPlanReturns(plan.Public())
+						build.SavedEventsReturns([]event.Envelope{
+							envelope(event.InitializeGet{Origin: event.Origin{ID: "get"}, Time: 1}),
+							envelope(event.FinishGet{Origin: event.Origin{ID: "get"}, Time: 2, ExitStatus: 0}),
+							envelope(event.StartTask{Origin: event.Origin{ID: "task"}, Time: 3}),
+						}, nil)
+					})
+
+					Context("when the build is running", func() {
+						BeforeEach(func() {
+							build.StatusReturns(db.BuildStatusStarted)
+							build.IsRunningReturns(true)
+						})
+
+						It("returns Content-Type 'application/json'", func() {
+							expectedHeaderEntries := map[string]string{
+								"Content-Type": "application/json",
+							}
+							Expect(response).Should(IncludeHeaderEntries(expectedHeaderEntries))
+						})
+
+						It("returns the steps with the state they're in", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+							body, err := ioutil.ReadAll(response.Body)
+							Expect(err).NotTo(HaveOccurred())
+
+							Expect(body).To(MatchJSON(`{
+								"build_id": 42,
+								"status": "started",
+								"plan": {
+									"id": "on-failure",
+									"type": "on_failure",
+									"state": "running",
+									"steps": [
+										{
+											"id": "do",
+											"type": "do",
+											"state": "running",
+											"steps": [
+												{"id": "get", "type": "get", "name": "some-input", "state": "succeeded", "start_time": 1, "end_time": 2},
+												{"id": "task", "type": "task", "name": "some-task", "state": "running", "start_time": 3}
+											]
+										},
+										{"id": "notify", "type": "task", "name": "notify", "state": "pending"}
+									]
+								}
+							}`))
+						})
+					})
+
+					Context("when the build has succeeded", func() {
+						BeforeEach(func() {
+							events, _ := build.SavedEvents()
+							build.SavedEventsReturns(append(events,
+								envelope(event.FinishTask{Origin: event.Origin{ID: "task"}, Time: 4, ExitStatus: 0}),
+							), nil)
+
+							build.StatusReturns(db.BuildStatusSucceeded)
+							build.IsRunningReturns(false)
+						})
+
+						It("skips the hook which didn't run", func() {
+							body, err := ioutil.ReadAll(response.Body)
+							Expect(err).NotTo(HaveOccurred())
+
+							var steps atc.BuildSteps
+							err = json.Unmarshal(body, &steps)
+							Expect(err).NotTo(HaveOccurred())
+
+							Expect(steps.Status).To(Equal(atc.StatusSucceeded))
+							Expect(steps.Plan.State).To(Equal(atc.BuildStepStateSucceeded))
+							Expect(steps.Plan.Steps[0].State).To(Equal(atc.BuildStepStateSucceeded))
+							Expect(steps.Plan.Steps[1].State).To(Equal(atc.BuildStepStateSkipped))
+						})
+					})
+
+					Context("when the build was aborted while a step was running", func() {
+						BeforeEach(func() {
+							build.StatusReturns(db.BuildStatusAborted)
+							build.IsRunningReturns(false)
+						})
+
+						It("marks the running step as aborted", func() {
+							body, err := ioutil.ReadAll(response.Body)
+							Expect(err).NotTo(HaveOccurred())
+
+							var steps atc.BuildSteps
+							err = json.Unmarshal(body, &steps)
+							Expect(err).NotTo(HaveOccurred())
+
+							Expect(steps.Plan.State).To(Equal(atc.BuildStepStateAborted))
+							Expect(steps.Plan.Steps[0].Steps[1].State).To(Equal(atc.BuildStepStateAborted))
+							Expect(steps.Plan.Steps[1].State).To(Equal(atc.BuildStepStateSkipped))
+						})
+					})
+
+					Context("when getting the events fails", func() {
+						BeforeEach(func() {
+							build.SavedEventsReturns(nil, errors.New("nope"))
+						})
+
+						It("returns 500", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+						})
+					})
+				})
+
+				Context("when the build has no plan", func() {
+					BeforeEach(func() {
+						build.HasPlanReturns(false)
+					})
+
+					It("returns not found", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+					})
+				})
+			})
+		})
+
+		Context("when the build is not found", func() {
+			BeforeEach(func() {
+				dbBuildFactory.BuildForAPIReturns(nil, false, nil)
+			})
+
+			It("returns Not Found", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
+
+	Describe("GET /api/v1/builds/:build_id/provenance", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = http.Get(server.URL + "/api/v1/builds/42/provenance")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the build is found", func() {
+			BeforeEach(func() {
+				build.IDReturns(42)
+				build.TeamNameReturns("some-team")
+				build.AllAssociatedTeamNamesReturns([]string{"some-team"})
+				build.JobIDReturns(42)
+				build.JobNameReturns("job1")
+				build.PipelineIDReturns(42)
+				dbBuildFactory.BuildForAPIReturns(build, true, nil)
+			})
+
+			Context("when not authenticated and the pipeline is private", func() {
+				BeforeEach(func() {
+					fakeAccess.IsAuthenticatedReturns(false)
+					build.PipelineReturns(fakePipeline, true, nil)
+					fakePipeline.PublicReturns(false)
+				})
+
+				It("returns 401", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				})
+			})
+
+			Context("when authenticated", func() {
+				BeforeEach(func() {
+					fakeAccess.IsAuthenticatedReturns(true)
+					fakeAccess.IsAuthorizedReturns(true)
+				})
+
+				Context("when the build has provenance", func() {
+					BeforeEach(func() {
+						build.ProvenanceReturns(json.RawMessage(`{"payloadType":"application/vnd.in-toto+json"}`), true, nil)
+					})
+
+					It("returns the signed envelope as a download", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(response).To(IncludeHeaderEntries(map[string]string{
+							"Content-Type":        "application/json",
+							"Content-Disposition": `attachment; filename="build-42.intoto.json"`,
+						}))
+
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(body).To(MatchJSON(`{"payloadType":"application/vnd.in-toto+json"}`))
+					})
+				})
+
+				Context("when the build has no provenance", func() {
+					BeforeEach(func() {
+						build.ProvenanceReturns(nil, false, nil)
+					})
+
+					It("returns 404", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+					})
+				})
+
+				Context("when getting the provenance fails", func() {
+					BeforeEach(func() {
+						build.ProvenanceReturns(nil, false, errors.New("nope"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+		})
+	})
 })
@@ -3,6 +3,8 @@ package buildserver
 import (
 	"net/http"
 
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 )
 
@@ -20,3 +22,27 @@ func (s *Server) AbortBuild(build db.BuildForAPI) http.Handler {
 		w.WriteHeader(http.StatusNoContent)
 	})
 }
+
+func (s *Server) AbortBuildStep(build db.BuildForAPI) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		planID := atc.PlanID(r.FormValue(":plan_id"))
+
+		aLog := s.logger.Session("abort-step", build.LagerData()).WithData(lager.Data{
+			"plan-id": planID,
+		})
+
+		if !build.IsRunning() {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+
+		err := build.AbortStep(planID)
+		if err != nil {
+			aLog.Error("failed-to-abort-build-step", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
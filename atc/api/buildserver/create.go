@@ -22,6 +22,10 @@ func (s *Server) CreateBuild(team db.Team) http.Handler {
 			return
 		}
 
+		if !s.withinOneOffBuildQuota(hLog, w, team) {
+			return
+		}
+
 		build, err := team.CreateStartedBuild(plan)
 		if err != nil {
 			hLog.Error("failed-to-create-one-off-build", err)
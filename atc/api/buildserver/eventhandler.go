@@ -8,12 +8,19 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/event"
+	"github.com/concourse/concourse/atc/eventv2"
 	"github.com/vito/go-sse/sse"
 )
 
 const ProtocolVersionHeader = "X-ATC-Stream-Version"
 const CurrentProtocolVersion = "2.0"
 
+// EventSchemaVersionHeader is the version of the schema of the events being
+// streamed, when a version of the typed events is asked for with the 'schema'
+// query parameter. Without it, events are streamed as they were saved.
+const EventSchemaVersionHeader = "X-ATC-Event-Schema-Version"
+
 func NewEventHandler(logger lager.Logger, build db.BuildForAPI) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		var eventID uint = 0
@@ -29,6 +36,24 @@ func NewEventHandler(logger lager.Logger, build db.BuildForAPI) http.Handler {
 			eventID++
 		}
 
+		translate := func(envelope event.Envelope) interface{} {
+			return envelope
+		}
+
+		switch schema := r.URL.Query().Get("schema"); schema {
+		case "":
+		case eventv2.SchemaMajorVersion:
+			translate = func(envelope event.Envelope) interface{} {
+				return eventv2.Translate(envelope)
+			}
+
+			w.Header().Add(EventSchemaVersionHeader, eventv2.SchemaVersion)
+		default:
+			logger.Info("unknown-event-schema", lager.Data{"schema": schema})
+			http.Error(w, fmt.Sprintf("unknown event schema version '%s', supported: %s", schema, eventv2.SchemaMajorVersion), http.StatusBadRequest)
+			return
+		}
+
 		w.Header().Add("Content-Type", "text/event-stream; charset=utf-8")
 		w.Header().Add("Cache-Control", "no-cache, no-store, must-revalidate")
 		w.Header().Add("X-Accel-Buffering", "no")
@@ -69,7 +94,7 @@ func NewEventHandler(logger lager.Logger, build db.BuildForAPI) http.Handler {
 				return
 			}
 
-			err = writer.WriteEvent(eventID, ev)
+			err = writer.WriteEvent(eventID, translate(ev))
 			if err != nil {
 				logger.Info("failed-to-write-event", lager.Data{"error": err.Error()})
 				return
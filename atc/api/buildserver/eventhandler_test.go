@@ -180,6 +180,51 @@ var _ = Describe("Handler", func() {
 					Expect(actualFrom).To(Equal(uint(2)))
 				})
 			})
+
+			Context("when version 2 of the event schema is asked for", func() {
+				BeforeEach(func() {
+					request.URL.RawQuery = "schema=2"
+				})
+
+				It("returns the schema version as X-ATC-Event-Schema-Version", func() {
+					_ = response.Body.Close()
+					Expect(response).Should(IncludeHeaderEntries(map[string]string{
+						"X-Atc-Event-Schema-Version": "2.0",
+					}))
+				})
+
+				It("emits the translated events", func() {
+					defer db.Close(response.Body)
+					reader := sse.NewReadCloser(response.Body)
+
+					Expect(reader.Next()).To(Equal(sse.Event{
+						ID:   "0",
+						Name: "event",
+						Data: []byte(`{"type":"legacy","legacy":{"event":"fake","version":"42.0","data":{"event":1}}}`),
+					}))
+				})
+			})
+		})
+
+		Context("when an unknown version of the event schema is asked for", func() {
+			BeforeEach(func() {
+				request.URL.RawQuery = "schema=1"
+			})
+
+			JustBeforeEach(func() {
+				var err error
+
+				client := &http.Client{
+					Transport: &http.Transport{},
+				}
+				response, err = client.Do(request)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns 400 without subscribing to the build", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				Expect(build.EventsCallCount()).To(BeZero())
+			})
 		})
 
 		Context("when the eventsource returns an error", func() {
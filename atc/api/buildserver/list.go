@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"strconv"
 
+	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/api/accessor"
 	"github.com/concourse/concourse/atc/api/present"
@@ -16,6 +17,11 @@ func (s *Server) ListBuilds(w http.ResponseWriter, r *http.Request) {
 
 	logger := s.logger.Session("list-builds")
 
+	if ref := r.FormValue(atc.BuildsQueryExternalRef); ref != "" {
+		s.listBuildsWithExternalRef(logger, w, r, ref)
+		return
+	}
+
 	var (
 		err  error
 		from int
@@ -86,6 +92,41 @@ func (s *Server) ListBuilds(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// listBuildsWithExternalRef lists the builds triggered with the given external
+// ref. There are only ever a handful of them, one per job at most, so they
+// aren't paginated.
+func (s *Server) listBuildsWithExternalRef(logger lager.Logger, w http.ResponseWriter, r *http.Request, ref string) {
+	var builds []db.BuildForAPI
+	var err error
+
+	acc := accessor.GetAccessor(r)
+	if acc.IsAdmin() {
+		builds, err = s.buildFactory.AllBuildsWithExternalRef(ref)
+	} else {
+		builds, err = s.buildFactory.VisibleBuildsWithExternalRef(acc.TeamNames(), ref)
+	}
+
+	if err != nil {
+		logger.Error("failed-to-get-builds-with-external-ref", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	presented := make([]atc.Build, len(builds))
+	for i, build := range builds {
+		presented[i] = present.Build(build, nil, nil)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	err = json.NewEncoder(w).Encode(presented)
+	if err != nil {
+		logger.Error("failed-to-encode-builds", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
 func (s *Server) addNextLink(w http.ResponseWriter, page db.Page) {
 	w.Header().Add("Link", fmt.Sprintf(
 		`<%s/api/v1/builds?%s=%d&%s=%d>; rel="%s"`,
@@ -0,0 +1,33 @@
+package buildserver
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) GetBuildProvenance(build db.BuildForAPI) http.Handler {
+	logger := s.logger.Session("get-build-provenance")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		envelope, found, err := build.Provenance()
+		if err != nil {
+			logger.Error("failed-to-get-provenance", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="build-%d.intoto.json"`, build.ID()))
+
+		w.WriteHeader(http.StatusOK)
+
+		_, _ = w.Write(envelope)
+	})
+}
@@ -0,0 +1,81 @@
+package buildserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/present"
+	"github.com/concourse/concourse/atc/db"
+)
+
+// RunTask runs a task as a one-off build of the team, like fly execute does,
+// without the caller having to construct the build plan itself. The build's
+// events and artifacts are then available through the usual build endpoints.
+func (s *Server) RunTask(team db.Team) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("run-task", lager.Data{"team": team.Name()})
+
+		var request atc.RunTaskRequest
+		err := json.NewDecoder(r.Body).Decode(&request)
+		if err != nil {
+			logger.Info("malformed-request", lager.Data{"error": err.Error()})
+			http.Error(w, fmt.Sprintf("malformed request: %s", err), http.StatusBadRequest)
+			return
+		}
+
+		err = request.Validate()
+		if err != nil {
+			logger.Info("invalid-request", lager.Data{"error": err.Error()})
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if !s.withinOneOffBuildQuota(logger, w, team) {
+			return
+		}
+
+		plan := request.Plan(atc.NewPlanFactory(time.Now().Unix()))
+
+		build, err := team.CreateStartedBuild(plan)
+		if err != nil {
+			logger.Error("failed-to-create-one-off-build", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+
+		err = json.NewEncoder(w).Encode(present.Build(build, nil, nil))
+		if err != nil {
+			logger.Error("failed-to-encode-build", err)
+		}
+	})
+}
+
+// withinOneOffBuildQuota returns whether the team may start another one-off
+// build, responding to the request if not.
+func (s *Server) withinOneOffBuildQuota(logger lager.Logger, w http.ResponseWriter, team db.Team) bool {
+	if s.maxRunningOneOffBuilds <= 0 {
+		return true
+	}
+
+	running, err := team.RunningOneOffBuilds()
+	if err != nil {
+		logger.Error("failed-to-count-running-one-off-builds", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return false
+	}
+
+	if running >= s.maxRunningOneOffBuilds {
+		logger.Info("one-off-build-quota-reached", lager.Data{"running": running})
+		http.Error(w, fmt.Sprintf("team '%s' already has %d one-off builds running, the most allowed", team.Name(), running), http.StatusTooManyRequests)
+		return false
+	}
+
+	return true
+}
@@ -19,6 +19,8 @@ type Server struct {
 	buildFactory        db.BuildFactory
 	eventHandlerFactory EventHandlerFactory
 	rejector            auth.Rejector
+
+	maxRunningOneOffBuilds int
 }
 
 func NewServer(
@@ -27,6 +29,7 @@ func NewServer(
 	teamFactory db.TeamFactory,
 	buildFactory db.BuildFactory,
 	eventHandlerFactory EventHandlerFactory,
+	maxRunningOneOffBuilds int,
 ) *Server {
 	return &Server{
 		logger: logger,
@@ -38,5 +41,7 @@ func NewServer(
 		eventHandlerFactory: eventHandlerFactory,
 
 		rejector: auth.UnauthorizedRejector{},
+
+		maxRunningOneOffBuilds: maxRunningOneOffBuilds,
 	}
 }
@@ -0,0 +1,411 @@
+package buildserver
+
+import (
+	"encoding/json"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/event"
+)
+
+// stepTree follows the state of each step of a build's plan as the build's
+// events are applied to it.
+type stepTree struct {
+	root  *stepNode
+	nodes map[atc.PlanID]*stepNode
+}
+
+type stepNode struct {
+	step     atc.BuildStep
+	children []*stepNode
+}
+
+func newStepTree(publicPlan *json.RawMessage) (*stepTree, error) {
+	tree := &stepTree{
+		nodes: map[atc.PlanID]*stepNode{},
+	}
+
+	root, err := tree.parse(*publicPlan)
+	if err != nil {
+		return nil, err
+	}
+
+	tree.root = root
+
+	return tree, nil
+}
+
+// parse adds the step of the public plan to the tree, along with the steps
+// within it.
+func (tree *stepTree) parse(publicPlan json.RawMessage) (*stepNode, error) {
+	var plan map[string]json.RawMessage
+	err := json.Unmarshal(publicPlan, &plan)
+	if err != nil {
+		return nil, err
+	}
+
+	node := &stepNode{
+		step: atc.BuildStep{
+			State: atc.BuildStepStatePending,
+		},
+	}
+
+	for key, value := range plan {
+		switch key {
+		case "id":
+			err = json.Unmarshal(value, &node.step.ID)
+		case "attempts":
+		default:
+			node.step.Type = key
+			err = tree.parseStep(node, key, value)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	tree.nodes[node.step.ID] = node
+
+	return node, nil
+}
+
+func (tree *stepTree) parseStep(node *stepNode, stepType string, body json.RawMessage) error {
+	var substeps []json.RawMessage
+
+	switch stepType {
+	case "do", "retry":
+		err := json.Unmarshal(body, &substeps)
+		if err != nil {
+			return err
+		}
+
+	case "in_parallel":
+		var inParallel struct {
+			Steps []json.RawMessage `json:"steps"`
+		}
+
+		err := json.Unmarshal(body, &inParallel)
+		if err != nil {
+			return err
+		}
+
+		substeps = inParallel.Steps
+
+	case "on_success", "on_failure", "on_abort", "on_error", "ensure", "try", "timeout":
+		var hooked map[string]json.RawMessage
+		err := json.Unmarshal(body, &hooked)
+		if err != nil {
+			return err
+		}
+
+		substeps = []json.RawMessage{hooked["step"]}
+		if hook, found := hooked[stepType]; found {
+			substeps = append(substeps, hook)
+		}
+
+	default:
+		var named struct {
+			Name string `json:"name"`
+		}
+
+		// not every step is named, so the step is fine without one
+		_ = json.Unmarshal(body, &named)
+
+		node.step.Name = named.Name
+	}
+
+	return tree.addSubsteps(node, substeps)
+}
+
+func (tree *stepTree) addSubsteps(node *stepNode, substeps []json.RawMessage) error {
+	for _, substep := range substeps {
+		if len(substep) == 0 || string(substep) == "null" {
+			continue
+		}
+
+		var plan struct {
+			ID atc.PlanID `json:"id"`
+		}
+
+		err := json.Unmarshal(substep, &plan)
+		if err != nil {
+			return err
+		}
+
+		if _, found := tree.nodes[plan.ID]; found {
+			// already in the tree
+			continue
+		}
+
+		child, err := tree.parse(substep)
+		if err != nil {
+			return err
+		}
+
+		node.children = append(node.children, child)
+	}
+
+	return nil
+}
+
+// apply updates the steps the event is about.
+func (tree *stepTree) apply(ev atc.Event) error {
+	switch e := ev.(type) {
+	case event.Initialize:
+		tree.start(e.Origin, e.Time)
+	case event.InitializeTask:
+		tree.start(e.Origin, e.Time)
+	case event.InitializeGet:
+		tree.start(e.Origin, e.Time)
+	case event.InitializePut:
+		tree.start(e.Origin, e.Time)
+	case event.InitializeCheck:
+		tree.start(e.Origin, e.Time)
+	case event.Start:
+		tree.start(e.Origin, e.Time)
+	case event.StartTask:
+		tree.start(e.Origin, e.Time)
+	case event.StartGet:
+		tree.start(e.Origin, e.Time)
+	case event.StartPut:
+		tree.start(e.Origin, e.Time)
+	case event.SelectedWorker:
+		tree.start(e.Origin, e.Time)
+	case event.WaitingForWorker:
+		tree.start(e.Origin, e.Time)
+	case event.Log:
+		tree.start(e.Origin, e.Time)
+
+	case event.Finish:
+		tree.finish(e.Origin, e.Time, e.Succeeded)
+	case event.FinishTask:
+		tree.finish(e.Origin, e.Time, e.ExitStatus == 0)
+	case event.FinishGet:
+		tree.finish(e.Origin, e.Time, e.ExitStatus == 0)
+	case event.FinishPut:
+		tree.finish(e.Origin, e.Time, e.ExitStatus == 0)
+
+	case event.Error:
+		node, found := tree.nodes[atc.PlanID(e.Origin.ID)]
+		if found {
+			node.step.State = atc.BuildStepStateErrored
+			node.step.EndTime = e.Time
+		}
+
+	case event.AcrossSubsteps:
+		return tree.addDynamicSubsteps(e.Origin, e.Substeps, true)
+	case event.ImageCheck:
+		return tree.addDynamicSubsteps(e.Origin, []*json.RawMessage{e.PublicPlan}, false)
+	case event.ImageGet:
+		return tree.addDynamicSubsteps(e.Origin, []*json.RawMessage{e.PublicPlan}, false)
+	}
+
+	return nil
+}
+
+func (tree *stepTree) start(origin event.Origin, time int64) {
+	node, found := tree.nodes[atc.PlanID(origin.ID)]
+	if !found {
+		return
+	}
+
+	if node.step.State == atc.BuildStepStatePending {
+		node.step.State = atc.BuildStepStateRunning
+	}
+
+	if node.step.StartTime == 0 {
+		node.step.StartTime = time
+	}
+}
+
+func (tree *stepTree) finish(origin event.Origin, time int64, succeeded bool) {
+	node, found := tree.nodes[atc.PlanID(origin.ID)]
+	if !found {
+		return
+	}
+
+	if succeeded {
+		node.step.State = atc.BuildStepStateSucceeded
+	} else {
+		node.step.State = atc.BuildStepStateFailed
+	}
+
+	node.step.EndTime = time
+}
+
+// addDynamicSubsteps adds the steps only known once the build runs, i.e. the
+// substeps of an across step and the checks and gets of custom images.
+// Across substeps come scoped by the values of their vars.
+func (tree *stepTree) addDynamicSubsteps(origin event.Origin, plans []*json.RawMessage, varScoped bool) error {
+	node, found := tree.nodes[atc.PlanID(origin.ID)]
+	if !found {
+		return nil
+	}
+
+	substeps := []json.RawMessage{}
+	for _, plan := range plans {
+		if plan == nil {
+			continue
+		}
+
+		if !varScoped {
+			substeps = append(substeps, *plan)
+			continue
+		}
+
+		var scoped struct {
+			Step json.RawMessage `json:"step"`
+		}
+
+		err := json.Unmarshal(*plan, &scoped)
+		if err != nil {
+			return err
+		}
+
+		substeps = append(substeps, scoped.Step)
+	}
+
+	return tree.addSubsteps(node, substeps)
+}
+
+// steps returns the tree with the state of the composite steps figured out
+// from their substeps. Once the build is over, the steps which never ran are
+// skipped and the ones still running were cut short by the build being
+// aborted or erroring.
+func (tree *stepTree) steps(buildStatus atc.BuildStatus, finished bool) atc.BuildStep {
+	return tree.root.resolve(buildStatus, finished)
+}
+
+func (node *stepNode) resolve(buildStatus atc.BuildStatus, finished bool) atc.BuildStep {
+	step := node.step
+
+	for _, child := range node.children {
+		step.Steps = append(step.Steps, child.resolve(buildStatus, finished))
+	}
+
+	if compositeStepTypes[step.Type] && len(step.Steps) > 0 {
+		skipUntriggeredHook(&step)
+		step.State = compositeState(step)
+		return step
+	}
+
+	if finished {
+		switch step.State {
+		case atc.BuildStepStatePending:
+			step.State = atc.BuildStepStateSkipped
+		case atc.BuildStepStateRunning:
+			if buildStatus == atc.StatusAborted {
+				step.State = atc.BuildStepStateAborted
+			} else {
+				step.State = atc.BuildStepStateErrored
+			}
+		}
+	}
+
+	return step
+}
+
+// compositeStepTypes are the steps which don't have any events of their own.
+var compositeStepTypes = map[string]bool{
+	"do":          true,
+	"in_parallel": true,
+	"across":      true,
+	"retry":       true,
+	"try":         true,
+	"timeout":     true,
+	"on_success":  true,
+	"on_failure":  true,
+	"on_abort":    true,
+	"on_error":    true,
+	"ensure":      true,
+}
+
+// skipUntriggeredHook skips the hook of a step which finished in a way which
+// doesn't run it.
+func skipUntriggeredHook(step *atc.BuildStep) {
+	if len(step.Steps) != 2 || !isFinished(step.Steps[0].State) {
+		return
+	}
+
+	triggeredBy := map[string]atc.BuildStepState{
+		"on_success": atc.BuildStepStateSucceeded,
+		"on_failure": atc.BuildStepStateFailed,
+		"on_error":   atc.BuildStepStateErrored,
+		"on_abort":   atc.BuildStepStateAborted,
+	}
+
+	state, isHook := triggeredBy[step.Type]
+	if isHook && step.Steps[0].State != state {
+		skip(&step.Steps[1])
+	}
+}
+
+func skip(step *atc.BuildStep) {
+	if step.State == atc.BuildStepStatePending {
+		step.State = atc.BuildStepStateSkipped
+	}
+
+	for i := range step.Steps {
+		skip(&step.Steps[i])
+	}
+}
+
+func isFinished(state atc.BuildStepState) bool {
+	return state != atc.BuildStepStatePending && state != atc.BuildStepStateRunning
+}
+
+func compositeState(step atc.BuildStep) atc.BuildStepState {
+	switch step.Type {
+	case "try":
+		state := step.Steps[0].State
+		if state == atc.BuildStepStateFailed || state == atc.BuildStepStateErrored {
+			return atc.BuildStepStateSucceeded
+		}
+
+		return state
+
+	case "retry":
+		// only the last attempt which ran counts, unless it failed and there
+		// are attempts left
+		for i := len(step.Steps) - 1; i >= 0; i-- {
+			state := step.Steps[i].State
+			if state == atc.BuildStepStatePending || state == atc.BuildStepStateSkipped {
+				continue
+			}
+
+			if state == atc.BuildStepStateFailed && i < len(step.Steps)-1 &&
+				step.Steps[i+1].State == atc.BuildStepStatePending {
+				return atc.BuildStepStateRunning
+			}
+
+			return state
+		}
+
+		return step.Steps[0].State
+	}
+
+	states := map[atc.BuildStepState]bool{}
+	for _, substep := range step.Steps {
+		states[substep.State] = true
+	}
+
+	switch {
+	case states[atc.BuildStepStateRunning]:
+		return atc.BuildStepStateRunning
+	case len(states) == 1 && states[atc.BuildStepStatePending]:
+		return atc.BuildStepStatePending
+	case len(states) == 1 && states[atc.BuildStepStateSkipped]:
+		return atc.BuildStepStateSkipped
+	case states[atc.BuildStepStatePending]:
+		// some substeps are done and the rest are yet to run
+		return atc.BuildStepStateRunning
+	case states[atc.BuildStepStateErrored]:
+		return atc.BuildStepStateErrored
+	case states[atc.BuildStepStateAborted]:
+		return atc.BuildStepStateAborted
+	case states[atc.BuildStepStateFailed]:
+		return atc.BuildStepStateFailed
+	default:
+		return atc.BuildStepStateSucceeded
+	}
+}
@@ -0,0 +1,65 @@
+package buildserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/event"
+)
+
+func (s *Server) GetBuildSteps(build db.BuildForAPI) http.Handler {
+	hLog := s.logger.Session("get-build-steps")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !build.HasPlan() {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		tree, err := newStepTree(build.PublicPlan())
+		if err != nil {
+			hLog.Error("failed-to-parse-public-build-plan", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		envelopes, err := build.SavedEvents()
+		if err != nil {
+			hLog.Error("failed-to-get-build-events", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		for _, envelope := range envelopes {
+			ev, err := event.ParseEvent(envelope.Version, envelope.Event, *envelope.Data)
+			if err != nil {
+				// events of old builds which can no longer be parsed don't
+				// change the state of any step
+				continue
+			}
+
+			err = tree.apply(ev)
+			if err != nil {
+				hLog.Error("failed-to-apply-build-event", err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+		}
+
+		status := atc.BuildStatus(build.Status())
+
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(atc.BuildSteps{
+			BuildID: build.ID(),
+			Status:  status,
+			Plan:    tree.steps(status, !build.IsRunning()),
+		})
+		if err != nil {
+			hLog.Error("failed-to-encode-build-steps", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+	})
+}
@@ -0,0 +1,193 @@
+package api_test
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CA Bundle API", func() {
+	var (
+		fakeTeam *dbfakes.FakeTeam
+		response *http.Response
+	)
+
+	BeforeEach(func() {
+		fakeTeam = new(dbfakes.FakeTeam)
+		fakeTeam.IDReturns(3)
+		fakeTeam.NameReturns("a-team")
+	})
+
+	put := func(path string, body string) {
+		req, err := http.NewRequest("PUT", server.URL+path, bytes.NewBufferString(body))
+		Expect(err).NotTo(HaveOccurred())
+		req.Header.Set("Content-Type", "application/json")
+
+		response, err = client.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	Describe("GET /api/v1/teams/:team_name/ca_bundle", func() {
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/teams/a-team/ca_bundle")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+				dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+
+				dbCABundles.CABundleReturns(atc.CABundle{Certificates: "some-certs"}, nil)
+			})
+
+			It("returns the team's CA bundle", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(dbCABundles.CABundleArgsForCall(0)).To(Equal(3))
+				Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`{"certificates":"some-certs"}`))
+			})
+
+			Context("when getting the CA bundle fails", func() {
+				BeforeEach(func() {
+					dbCABundles.CABundleReturns(atc.CABundle{}, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/teams/:team_name/ca_bundle", func() {
+		var body string
+
+		BeforeEach(func() {
+			body = `{"certificates":""}`
+		})
+
+		JustBeforeEach(func() {
+			put("/api/v1/teams/a-team/ca_bundle", body)
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+				dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+			})
+
+			It("sets the team's CA bundle", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+
+				teamID, bundle := dbCABundles.SetCABundleArgsForCall(0)
+				Expect(teamID).To(Equal(3))
+				Expect(bundle).To(Equal(atc.CABundle{}))
+			})
+
+			Context("when the bundle does not hold certificates", func() {
+				BeforeEach(func() {
+					body = `{"certificates":"not-a-cert"}`
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(dbCABundles.SetCABundleCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the request body is malformed", func() {
+				BeforeEach(func() {
+					body = `{`
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(dbCABundles.SetCABundleCallCount()).To(BeZero())
+				})
+			})
+		})
+
+		Context("when unauthorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(false)
+				dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(dbCABundles.SetCABundleCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("GET /api/v1/ca_bundle", func() {
+		BeforeEach(func() {
+			fakeAccess.IsAuthenticatedReturns(true)
+			dbCABundles.CABundleReturns(atc.CABundle{Certificates: "cluster-certs"}, nil)
+		})
+
+		It("returns the cluster's CA bundle to any user", func() {
+			response, err := client.Get(server.URL + "/api/v1/ca_bundle")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+			Expect(dbCABundles.CABundleArgsForCall(0)).To(BeZero())
+			Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`{"certificates":"cluster-certs"}`))
+		})
+	})
+
+	Describe("PUT /api/v1/ca_bundle", func() {
+		JustBeforeEach(func() {
+			put("/api/v1/ca_bundle", `{"certificates":""}`)
+		})
+
+		BeforeEach(func() {
+			fakeAccess.IsAuthenticatedReturns(true)
+		})
+
+		Context("when admin", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAdminReturns(true)
+			})
+
+			It("sets the cluster's CA bundle", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+
+				teamID, _ := dbCABundles.SetCABundleArgsForCall(0)
+				Expect(teamID).To(BeZero())
+			})
+		})
+
+		Context("when not admin", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAdminReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(dbCABundles.SetCABundleCallCount()).To(BeZero())
+			})
+		})
+	})
+})
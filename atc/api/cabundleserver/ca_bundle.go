@@ -0,0 +1,79 @@
+package cabundleserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	. "github.com/concourse/concourse/atc/api/helpers"
+	"github.com/concourse/concourse/atc/db"
+)
+
+// The handlers of the cluster's CA bundle use a teamID of 0.
+
+func (s *Server) GetTeamCABundle(team db.Team) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.getCABundle(w, r, team.ID())
+	})
+}
+
+func (s *Server) GetClusterCABundle(w http.ResponseWriter, r *http.Request) {
+	s.getCABundle(w, r, 0)
+}
+
+func (s *Server) SetTeamCABundle(team db.Team) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.setCABundle(w, r, team.ID())
+	})
+}
+
+func (s *Server) SetClusterCABundle(w http.ResponseWriter, r *http.Request) {
+	s.setCABundle(w, r, 0)
+}
+
+func (s *Server) getCABundle(w http.ResponseWriter, r *http.Request, teamID int) {
+	logger := s.logger.Session("get-ca-bundle", lager.Data{"team-id": teamID})
+
+	bundle, err := s.repository.CABundle(teamID)
+	if err != nil {
+		logger.Error("failed-to-get-ca-bundle", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(bundle)
+	if err != nil {
+		logger.Error("failed-to-encode-ca-bundle", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+func (s *Server) setCABundle(w http.ResponseWriter, r *http.Request, teamID int) {
+	logger := s.logger.Session("set-ca-bundle", lager.Data{"team-id": teamID})
+
+	var bundle atc.CABundle
+	err := json.NewDecoder(r.Body).Decode(&bundle)
+	if err != nil {
+		logger.Info("malformed-request", lager.Data{"error": err.Error()})
+		HandleBadRequest(w, fmt.Sprintf("malformed CA bundle: %s", err))
+		return
+	}
+
+	err = bundle.Validate()
+	if err != nil {
+		HandleBadRequest(w, fmt.Sprintf("invalid CA bundle: %s", err))
+		return
+	}
+
+	err = s.repository.SetCABundle(teamID, bundle)
+	if err != nil {
+		logger.Error("failed-to-set-ca-bundle", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,21 @@
+package cabundleserver
+
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/db"
+)
+
+type Server struct {
+	logger     lager.Logger
+	repository db.CABundleRepository
+}
+
+func NewServer(
+	logger lager.Logger,
+	repository db.CABundleRepository,
+) *Server {
+	return &Server{
+		logger:     logger,
+		repository: repository,
+	}
+}
@@ -0,0 +1,171 @@
+package api_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Cluster Overview API", func() {
+	Describe("GET /api/v1/cluster/overview", func() {
+		var response *http.Response
+
+		get := func() *http.Response {
+			response, err := client.Get(server.URL + "/api/v1/cluster/overview")
+			Expect(err).NotTo(HaveOccurred())
+			return response
+		}
+
+		BeforeEach(func() {
+			dbClusterOverview.OverviewReturns(atc.ClusterOverview{
+				Workers: []atc.WorkerUsage{
+					{
+						Name:          "some-worker",
+						Platform:      "linux",
+						State:         "running",
+						Containers:    3,
+						RunningBuilds: 2,
+						Volumes:       5,
+						VolumesByType: map[string]int{"container": 4, "resource": 1},
+						DiskVolumes:   2,
+					},
+				},
+				Teams: []atc.TeamUsage{
+					{
+						Name:          "some-team",
+						Pipelines:     1,
+						Containers:    3,
+						Volumes:       5,
+						RunningBuilds: 2,
+						PendingBuilds: 1,
+					},
+				},
+				TopPipelines: []atc.PipelineUsage{
+					{
+						ID:            1,
+						Name:          "some-pipeline",
+						TeamName:      "some-team",
+						BuildSeconds:  3600,
+						Containers:    3,
+						Volumes:       5,
+						RunningBuilds: 2,
+					},
+				},
+			}, nil)
+		})
+
+		JustBeforeEach(func() {
+			response = get()
+		})
+
+		Context("when authenticated as an admin", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAdminReturns(true)
+			})
+
+			It("returns 200 with what the workers, teams and pipelines are using", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(body).To(MatchJSON(`{
+					"generated_at": 123,
+					"workers": [
+						{
+							"name": "some-worker",
+							"platform": "linux",
+							"state": "running",
+							"containers": 3,
+							"running_builds": 2,
+							"volumes": 5,
+							"volumes_by_type": {"container": 4, "resource": 1},
+							"disk_volumes": 2
+						}
+					],
+					"teams": [
+						{
+							"name": "some-team",
+							"pipelines": 1,
+							"containers": 3,
+							"volumes": 5,
+							"running_builds": 2,
+							"pending_builds": 1
+						}
+					],
+					"top_pipelines": [
+						{
+							"id": 1,
+							"name": "some-pipeline",
+							"team_name": "some-team",
+							"build_seconds": 3600,
+							"containers": 3,
+							"volumes": 5,
+							"running_builds": 2
+						}
+					]
+				}`))
+			})
+
+			It("asks for the top pipelines", func() {
+				Expect(dbClusterOverview.OverviewArgsForCall(0)).To(Equal(10))
+			})
+
+			It("caches the overview", func() {
+				Expect(get().StatusCode).To(Equal(http.StatusOK))
+				Expect(dbClusterOverview.OverviewCallCount()).To(Equal(1))
+
+				fakeClock.Increment(time.Minute)
+
+				Expect(get().StatusCode).To(Equal(http.StatusOK))
+				Expect(dbClusterOverview.OverviewCallCount()).To(Equal(2))
+			})
+
+			Context("when getting the overview fails", func() {
+				BeforeEach(func() {
+					dbClusterOverview.OverviewReturns(atc.ClusterOverview{}, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+
+				It("does not cache the failure", func() {
+					dbClusterOverview.OverviewReturns(atc.ClusterOverview{}, nil)
+
+					Expect(get().StatusCode).To(Equal(http.StatusOK))
+					Expect(dbClusterOverview.OverviewCallCount()).To(Equal(2))
+				})
+			})
+		})
+
+		Context("when authenticated but not an admin", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAdminReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(dbClusterOverview.OverviewCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+})
@@ -0,0 +1,28 @@
+package clusteroverviewserver
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// GetClusterOverview returns what the workers, teams and busiest pipelines of
+// the cluster are using.
+func (s *Server) GetClusterOverview(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("get-cluster-overview")
+
+	overview, err := s.overview()
+	if err != nil {
+		logger.Error("failed-to-get-overview", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	err = json.NewEncoder(w).Encode(overview)
+	if err != nil {
+		logger.Error("failed-to-encode-overview", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,62 @@
+package clusteroverviewserver
+
+import (
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+// CacheDuration is how long an overview is served for before it is worked
+// out again. Working it out counts every container, volume and build, so it
+// is not done on every request.
+const CacheDuration = time.Minute
+
+// TopPipelines is how many of the pipelines which used the most build time
+// are included in the overview.
+const TopPipelines = 10
+
+type Server struct {
+	logger     lager.Logger
+	repository db.ClusterOverviewRepository
+	clock      clock.Clock
+
+	cacheLock sync.Mutex
+	cached    *atc.ClusterOverview
+	cachedAt  time.Time
+}
+
+func NewServer(
+	logger lager.Logger,
+	repository db.ClusterOverviewRepository,
+	clock clock.Clock,
+) *Server {
+	return &Server{
+		logger:     logger,
+		repository: repository,
+		clock:      clock,
+	}
+}
+
+func (s *Server) overview() (atc.ClusterOverview, error) {
+	s.cacheLock.Lock()
+	defer s.cacheLock.Unlock()
+
+	if s.cached != nil && s.clock.Since(s.cachedAt) < CacheDuration {
+		return *s.cached, nil
+	}
+
+	overview, err := s.repository.Overview(TopPipelines)
+	if err != nil {
+		return atc.ClusterOverview{}, err
+	}
+
+	s.cachedAt = s.clock.Now()
+	overview.GeneratedAt = s.cachedAt.Unix()
+	s.cached = &overview
+
+	return overview, nil
+}
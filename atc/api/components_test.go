@@ -0,0 +1,210 @@
+package api_test
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Components API", func() {
+	var response *http.Response
+
+	Describe("GET /api/v1/components", func() {
+		BeforeEach(func() {
+			scheduler := new(dbfakes.FakeComponent)
+			scheduler.NameReturns("scheduler")
+			scheduler.IntervalReturns(30 * time.Second)
+			scheduler.DefaultIntervalReturns(10 * time.Second)
+			scheduler.LastRanReturns(time.Unix(100, 0))
+
+			tracker := new(dbfakes.FakeComponent)
+			tracker.NameReturns("tracker")
+			tracker.IntervalReturns(10 * time.Second)
+			tracker.DefaultIntervalReturns(10 * time.Second)
+			tracker.PausedReturns(true)
+
+			dbComponentFactory.AllReturns([]db.Component{scheduler, tracker}, nil)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/components")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated as an admin", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAdminReturns(true)
+			})
+
+			It("returns 200 with the status of each component", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(body).To(MatchJSON(`[
+					{
+						"name": "scheduler",
+						"interval": "30s",
+						"default_interval": "10s",
+						"paused": false,
+						"last_ran": 100
+					},
+					{
+						"name": "tracker",
+						"interval": "10s",
+						"default_interval": "10s",
+						"paused": true
+					}
+				]`))
+			})
+
+			Context("when getting the components fails", func() {
+				BeforeEach(func() {
+					dbComponentFactory.AllReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when authenticated but not an admin", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/components/:component_name/interval", func() {
+		var (
+			fakeComponent *dbfakes.FakeComponent
+			payload       string
+		)
+
+		BeforeEach(func() {
+			fakeComponent = new(dbfakes.FakeComponent)
+			dbComponentFactory.FindReturns(fakeComponent, true, nil)
+
+			payload = `{"interval":"1m"}`
+		})
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequest("PUT", server.URL+"/api/v1/components/scheduler/interval", bytes.NewBufferString(payload))
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated as an admin", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAdminReturns(true)
+			})
+
+			It("overrides the interval of the component", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+
+				Expect(dbComponentFactory.FindArgsForCall(0)).To(Equal("scheduler"))
+				Expect(fakeComponent.SetIntervalCallCount()).To(Equal(1))
+				Expect(fakeComponent.SetIntervalArgsForCall(0)).To(Equal(time.Minute))
+			})
+
+			Context("when the interval is invalid", func() {
+				BeforeEach(func() {
+					payload = `{"interval":"-1m"}`
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(fakeComponent.SetIntervalCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the component does not exist", func() {
+				BeforeEach(func() {
+					dbComponentFactory.FindReturns(nil, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when setting the interval fails", func() {
+				BeforeEach(func() {
+					fakeComponent.SetIntervalReturns(errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when authenticated but not an admin", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(fakeComponent.SetIntervalCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("changing the state of a component", func() {
+		var fakeComponent *dbfakes.FakeComponent
+
+		BeforeEach(func() {
+			fakeComponent = new(dbfakes.FakeComponent)
+			dbComponentFactory.FindReturns(fakeComponent, true, nil)
+
+			fakeAccess.IsAuthenticatedReturns(true)
+			fakeAccess.IsAdminReturns(true)
+		})
+
+		request := func(method, path string) {
+			req, err := http.NewRequest(method, server.URL+path, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		It("resets the interval of the component", func() {
+			request("DELETE", "/api/v1/components/scheduler/interval")
+			Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+			Expect(fakeComponent.ResetIntervalCallCount()).To(Equal(1))
+		})
+
+		It("pauses the component", func() {
+			request("PUT", "/api/v1/components/scheduler/pause")
+			Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+			Expect(fakeComponent.PauseCallCount()).To(Equal(1))
+		})
+
+		It("unpauses the component", func() {
+			request("PUT", "/api/v1/components/scheduler/unpause")
+			Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+			Expect(fakeComponent.UnpauseCallCount()).To(Equal(1))
+		})
+	})
+})
@@ -0,0 +1,108 @@
+package componentserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) ListComponents(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("list-components")
+
+	components, err := s.componentFactory.All()
+	if err != nil {
+		logger.Error("failed-to-get-components", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	presented := []atc.ComponentStatus{}
+	for _, component := range components {
+		status := atc.ComponentStatus{
+			Name:            component.Name(),
+			Interval:        component.Interval().String(),
+			DefaultInterval: component.DefaultInterval().String(),
+			Paused:          component.Paused(),
+		}
+
+		if !component.LastRan().IsZero() {
+			status.LastRan = component.LastRan().Unix()
+		}
+
+		presented = append(presented, status)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	err = json.NewEncoder(w).Encode(presented)
+	if err != nil {
+		logger.Error("failed-to-encode-components", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+func (s *Server) SetComponentInterval(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("set-component-interval")
+
+	var req atc.SetComponentIntervalRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		logger.Info("malformed-request", lager.Data{"error": err.Error()})
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	interval, err := time.ParseDuration(req.Interval)
+	if err != nil || interval <= 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "invalid interval '%s': must be a positive duration such as '30s'", req.Interval)
+		return
+	}
+
+	s.updateComponent(logger, w, r, func(component db.Component) error {
+		return component.SetInterval(interval)
+	})
+}
+
+func (s *Server) ResetComponentInterval(w http.ResponseWriter, r *http.Request) {
+	s.updateComponent(s.logger.Session("reset-component-interval"), w, r, db.Component.ResetInterval)
+}
+
+func (s *Server) PauseComponent(w http.ResponseWriter, r *http.Request) {
+	s.updateComponent(s.logger.Session("pause-component"), w, r, db.Component.Pause)
+}
+
+func (s *Server) UnpauseComponent(w http.ResponseWriter, r *http.Request) {
+	s.updateComponent(s.logger.Session("unpause-component"), w, r, db.Component.Unpause)
+}
+
+func (s *Server) updateComponent(logger lager.Logger, w http.ResponseWriter, r *http.Request, update func(db.Component) error) {
+	componentName := r.FormValue(":component_name")
+
+	component, found, err := s.componentFactory.Find(componentName)
+	if err != nil {
+		logger.Error("failed-to-find-component", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	err = update(component)
+	if err != nil {
+		logger.Error("failed-to-update-component", err, lager.Data{"component": componentName})
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
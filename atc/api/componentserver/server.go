@@ -0,0 +1,21 @@
+package componentserver
+
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/db"
+)
+
+type Server struct {
+	logger           lager.Logger
+	componentFactory db.ComponentFactory
+}
+
+func NewServer(
+	logger lager.Logger,
+	componentFactory db.ComponentFactory,
+) *Server {
+	return &Server{
+		logger:           logger,
+		componentFactory: componentFactory,
+	}
+}
@@ -9,6 +9,8 @@ import (
 	"time"
 
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/creds/dummy"
 	"github.com/concourse/concourse/atc/creds/noop"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
@@ -17,9 +19,6 @@ import (
 	"github.com/tedsuo/rata"
 	"sigs.k8s.io/yaml"
 
-	// load dummy credential manager
-	_ "github.com/concourse/concourse/atc/creds/dummy"
-
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
@@ -233,10 +232,11 @@ var _ = Describe("Config API", func() {
 							Expect(response.StatusCode).To(Equal(http.StatusOK))
 						})
 
-						It("returns Content-Type 'application/json' and config version as X-Concourse-Config-Version", func() {
+						It("returns Content-Type 'application/json' and config version as X-Concourse-Config-Version and ETag", func() {
 							expectedHeaderEntries := map[string]string{
 								"Content-Type":          "application/json",
 								atc.ConfigVersionHeader: "1",
+								"Etag":                  `"1"`,
 							}
 							Expect(response).Should(IncludeHeaderEntries(expectedHeaderEntries))
 						})
@@ -251,6 +251,29 @@ var _ = Describe("Config API", func() {
 							}))
 						})
 
+						Context("when the config has warnings", func() {
+							BeforeEach(func() {
+								pipelineConfig.Groups = append(pipelineConfig.Groups, atc.GroupConfig{
+									Name: "empty-group",
+								})
+								fakePipeline.ConfigReturns(pipelineConfig, nil)
+							})
+
+							It("returns them along with the config", func() {
+								var actualConfigResponse atc.ConfigResponse
+								err := json.NewDecoder(response.Body).Decode(&actualConfigResponse)
+								Expect(err).NotTo(HaveOccurred())
+
+								Expect(actualConfigResponse.Warnings).To(Equal([]atc.ConfigWarning{
+									{
+										Type:    "empty_group",
+										Message: "groups.empty-group: group has no jobs",
+										Pointer: "/groups/1",
+									},
+								}))
+							})
+						})
+
 						Context("when finding the config fails", func() {
 							BeforeEach(func() {
 								fakePipeline.ConfigReturns(atc.Config{}, errors.New("fail"))
@@ -415,6 +438,73 @@ var _ = Describe("Config API", func() {
 
 			})
 
+			Context("when If-Match is specified", func() {
+				BeforeEach(func() {
+					request.Header.Set("If-Match", `"42"`)
+					request.Header.Set("Content-Type", "application/json")
+
+					payload, err := json.Marshal(pipelineConfig)
+					Expect(err).NotTo(HaveOccurred())
+
+					request.Body = gbytes.BufferWithBytes(payload)
+
+					dbTeam.PipelineReturns(new(dbfakes.FakePipeline), true, nil)
+					dbTeam.SavePipelineReturns(new(dbfakes.FakePipeline), false, nil)
+				})
+
+				It("saves the config if it is still the version of the ETag", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+					Expect(dbTeam.SavePipelineCallCount()).To(Equal(1))
+					_, _, version, _ := dbTeam.SavePipelineArgsForCall(0)
+					Expect(version).To(Equal(db.ConfigVersion(42)))
+				})
+
+				Context("when a config version is specified too", func() {
+					BeforeEach(func() {
+						request.Header.Set(atc.ConfigVersionHeader, "41")
+					})
+
+					It("takes precedence", func() {
+						_, _, version, _ := dbTeam.SavePipelineArgsForCall(0)
+						Expect(version).To(Equal(db.ConfigVersion(42)))
+					})
+				})
+
+				Context("when the config has changed since", func() {
+					BeforeEach(func() {
+						dbTeam.SavePipelineReturns(nil, false, db.ErrConfigComparisonFailed)
+					})
+
+					It("returns 412", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusPreconditionFailed))
+						Expect(ioutil.ReadAll(response.Body)).To(Equal([]byte("the config has changed since it was fetched")))
+					})
+				})
+
+				Context("when the pipeline does not exist", func() {
+					BeforeEach(func() {
+						dbTeam.PipelineReturns(nil, false, nil)
+					})
+
+					It("returns 412 without saving it", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusPreconditionFailed))
+						Expect(dbTeam.SavePipelineCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when it is not an ETag", func() {
+					BeforeEach(func() {
+						request.Header.Set("If-Match", "42")
+					})
+
+					It("returns 400", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+						Expect(dbTeam.SavePipelineCallCount()).To(BeZero())
+					})
+				})
+			})
+
 			Context("when a config version is specified", func() {
 				BeforeEach(func() {
 					request.Header.Set(atc.ConfigVersionHeader, "42")
@@ -549,6 +639,54 @@ var _ = Describe("Config API", func() {
 							})
 						})
 
+						Context("when a task runs on a platform without an image", func() {
+							BeforeEach(func() {
+								taskStep := pipelineConfig.Jobs[0].PlanSequence[1].Config.(*atc.TaskStep)
+								taskStep.Config.Platform = "windows"
+								taskStep.Config.RootfsURI = ""
+
+								payload, err := json.Marshal(pipelineConfig)
+								Expect(err).NotTo(HaveOccurred())
+								request.Body = gbytes.BufferWithBytes(payload)
+							})
+
+							Context("when no workers with the platform are registered", func() {
+								BeforeEach(func() {
+									fakeWorker := new(dbfakes.FakeWorker)
+									fakeWorker.PlatformReturns("linux")
+									dbWorkerFactory.WorkersReturns([]db.Worker{fakeWorker}, nil)
+								})
+
+								It("saves it", func() {
+									Expect(dbTeam.SavePipelineCallCount()).To(Equal(1))
+								})
+
+								It("returns a warning", func() {
+									Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`
+										{
+											"warnings": [
+												{
+													"type": "pipeline",
+													"message": "jobs.some-job.plan.task(some-task): no workers with platform 'windows' are registered, so the task will not be able to run"
+												}
+											]
+										}`))
+								})
+							})
+
+							Context("when a worker with the platform is registered", func() {
+								BeforeEach(func() {
+									fakeWorker := new(dbfakes.FakeWorker)
+									fakeWorker.PlatformReturns("windows")
+									dbWorkerFactory.WorkersReturns([]db.Worker{fakeWorker}, nil)
+								})
+
+								It("returns no warnings", func() {
+									Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`{}`))
+								})
+							})
+						})
+
 						Context("when the config is invalid", func() {
 							BeforeEach(func() {
 								pipelineConfig.Groups[0].Resources = []string{"missing-resource"}
@@ -572,7 +710,7 @@ var _ = Describe("Config API", func() {
 								Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`
 								{
 									"errors": [
-										"invalid groups:\n\tgroup 'some-group' has unknown resource 'missing-resource'\n"
+										"invalid groups:\n\tgroups.some-group: unknown resource 'missing-resource'\n"
 									]
 								}`))
 							})
@@ -1064,7 +1202,7 @@ jobs:
 								Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`
 								{
 									"errors": [
-										"invalid groups:\n\tgroup 'some-group' has unknown resource 'missing-resource'\n"
+										"invalid groups:\n\tgroups.some-group: unknown resource 'missing-resource'\n"
 									]
 								}`))
 							})
@@ -1319,4 +1457,564 @@ jobs:
 			})
 		})
 	})
+
+	Describe("POST /api/v1/teams/:team_name/pipelines/:name/config/validate", func() {
+		var (
+			request  *http.Request
+			response *http.Response
+		)
+
+		BeforeEach(func() {
+			var err error
+			request, err = requestGenerator.CreateRequest(atc.ValidateConfig, rata.Params{
+				"team_name":     "a-team",
+				"pipeline_name": "a-pipeline",
+			}, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			request.Header.Set("Content-Type", "application/json")
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+			})
+
+			Context("when the config is valid", func() {
+				BeforeEach(func() {
+					payload, err := json.Marshal(pipelineConfig)
+					Expect(err).NotTo(HaveOccurred())
+
+					request.Body = gbytes.BufferWithBytes(payload)
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("reports the config as valid", func() {
+					Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`
+						{
+							"schema_version": 2,
+							"valid": true
+						}`))
+				})
+
+				It("does not save it", func() {
+					Expect(dbTeam.SavePipelineCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when the config is invalid", func() {
+				BeforeEach(func() {
+					pipelineConfig.Jobs[0].PlanSequence[1].Config.(*atc.TaskStep).Config.Platform = ""
+					pipelineConfig.Jobs[0].BuildLogsToRetain = 5
+
+					payload, err := json.Marshal(pipelineConfig)
+					Expect(err).NotTo(HaveOccurred())
+
+					request.Body = gbytes.BufferWithBytes(payload)
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("returns each error and warning with a pointer into the config", func() {
+					Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`
+						{
+							"schema_version": 2,
+							"valid": false,
+							"errors": [
+								{
+									"message": "jobs.some-job.plan.do[1].task(some-task).config: missing 'platform'",
+									"pointer": "/jobs/0/plan/1/config"
+								}
+							],
+							"warnings": [
+								{
+									"type": "deprecation",
+									"message": "jobs.some-job: build_logs_to_retain is deprecated, use build_log_retention.builds instead",
+									"pointer": "/jobs/0"
+								}
+							]
+						}`))
+				})
+			})
+
+			Context("when the config has unknown top-level fields", func() {
+				BeforeEach(func() {
+					payload, err := yaml.Marshal(pipelineConfig)
+					Expect(err).NotTo(HaveOccurred())
+
+					payload = append(payload, []byte("resource_typez: []\n")...)
+
+					request.Header.Set("Content-Type", "application/x-yaml")
+					request.Body = gbytes.BufferWithBytes(payload)
+				})
+
+				It("warns about them", func() {
+					Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`
+						{
+							"schema_version": 2,
+							"valid": true,
+							"warnings": [
+								{
+									"type": "unknown_field",
+									"message": "unknown field 'resource_typez' will be ignored",
+									"pointer": "/resource_typez"
+								}
+							]
+						}`))
+				})
+			})
+
+			Context("when the config is malformed", func() {
+				BeforeEach(func() {
+					request.Body = gbytes.BufferWithBytes([]byte(`{"jobs": 42}`))
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("reports the config as invalid", func() {
+					var body atc.ValidateConfigResponse
+					Expect(json.NewDecoder(response.Body).Decode(&body)).To(Succeed())
+					Expect(body.Valid).To(BeFalse())
+					Expect(body.Errors).To(HaveLen(1))
+					Expect(body.Errors[0].Message).To(HavePrefix("malformed config:"))
+				})
+			})
+
+			Context("when the content type is not supported", func() {
+				BeforeEach(func() {
+					request.Header.Set("Content-Type", "text/plain")
+					request.Body = gbytes.BufferWithBytes([]byte(`{}`))
+				})
+
+				It("returns 415", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusUnsupportedMediaType))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("POST /api/v1/teams/:team_name/pipelines/:name/config/vars", func() {
+		var (
+			request  *http.Request
+			response *http.Response
+		)
+
+		BeforeEach(func() {
+			var err error
+			request, err = requestGenerator.CreateRequest(atc.ReportConfigVars, rata.Params{
+				"team_name":     "a-team",
+				"pipeline_name": "a-pipeline",
+			}, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			request.Header.Set("Content-Type", "application/x-yaml")
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+			})
+
+			Context("when the config refers to vars", func() {
+				BeforeEach(func() {
+					fakeSecretManager.NewSecretLookupPathsReturns([]creds.SecretLookupPath{
+						creds.NewSecretLookupWithPrefix("/concourse/a-team/a-pipeline/"),
+						creds.NewSecretLookupWithPrefix("/concourse/a-team/"),
+					})
+					fakeSecretManager.GetStub = func(path string) (interface{}, *time.Time, bool, error) {
+						if path == "/concourse/a-team/username" {
+							return "some-secret-username", nil, true, nil
+						}
+						return nil, nil, false, nil
+					}
+
+					fakeVarSourcePool.FindOrCreateReturns(dummy.NewSecretsFactory([]dummy.VarFlag{
+						{Name: "a-team/key", Value: "some-secret-key"},
+					}).NewSecrets(), nil)
+
+					request.Body = gbytes.BufferWithBytes([]byte(`
+vars:
+- name: defaulted
+  default: some-default
+var_sources:
+- name: some
+  type: dummy
+  config:
+    vars: {}
+resources:
+- name: some-resource
+  type: some-type
+  source:
+    username: ((username))
+    password: ((missing))
+    token: ((defaulted))
+    key: ((some:key))
+    other: ((unknown:key))
+`))
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("reports where each var resolves without revealing values", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`[
+						{
+							"name": "defaulted",
+							"paths": ["/concourse/a-team/a-pipeline/defaulted", "/concourse/a-team/defaulted"],
+							"resolved": true,
+							"default": true
+						},
+						{
+							"name": "missing",
+							"paths": ["/concourse/a-team/a-pipeline/missing", "/concourse/a-team/missing"],
+							"resolved": false
+						},
+						{
+							"name": "some:key",
+							"source": "some",
+							"paths": ["a-team/a-pipeline/key", "a-team/key", "key"],
+							"resolved_path": "a-team/key",
+							"resolved": true
+						},
+						{
+							"name": "unknown:key",
+							"source": "unknown",
+							"resolved": false,
+							"error": "missing source 'unknown' in var: unknown:key"
+						},
+						{
+							"name": "username",
+							"paths": ["/concourse/a-team/a-pipeline/username", "/concourse/a-team/username"],
+							"resolved_path": "/concourse/a-team/username",
+							"resolved": true
+						}
+					]`))
+					Expect(string(body)).NotTo(ContainSubstring("some-secret"))
+				})
+
+				It("looks up the global vars for the team and pipeline", func() {
+					Expect(fakeSecretManager.NewSecretLookupPathsCallCount()).To(Equal(1))
+					teamName, pipelineName, allowRootPath := fakeSecretManager.NewSecretLookupPathsArgsForCall(0)
+					Expect(teamName).To(Equal("a-team"))
+					Expect(pipelineName).To(Equal("a-pipeline"))
+					Expect(allowRootPath).To(BeFalse())
+				})
+			})
+
+			Context("when the config is malformed", func() {
+				BeforeEach(func() {
+					request.Body = gbytes.BufferWithBytes([]byte(`{"jobs": 42}`))
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+			})
+
+			Context("when the content type is not supported", func() {
+				BeforeEach(func() {
+					request.Header.Set("Content-Type", "text/plain")
+					request.Body = gbytes.BufferWithBytes([]byte(`{}`))
+				})
+
+				It("returns 415", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusUnsupportedMediaType))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/teams/:team_name/pipelines/:name/config/candidate", func() {
+		var (
+			request  *http.Request
+			response *http.Response
+		)
+
+		BeforeEach(func() {
+			var err error
+			request, err = requestGenerator.CreateRequest(atc.StageConfig, rata.Params{
+				"team_name":     "a-team",
+				"pipeline_name": "a-pipeline",
+			}, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			request.Header.Set("Content-Type", "application/json")
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+			})
+
+			Context("when the config is valid", func() {
+				BeforeEach(func() {
+					payload, err := json.Marshal(pipelineConfig)
+					Expect(err).NotTo(HaveOccurred())
+
+					request.Body = gbytes.BufferWithBytes(payload)
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("stages the config without saving it", func() {
+					Expect(fakePipeline.StageConfigCallCount()).To(Equal(1))
+					Expect(fakePipeline.StageConfigArgsForCall(0)).To(Equal(pipelineConfig))
+
+					Expect(dbTeam.SavePipelineCallCount()).To(Equal(0))
+				})
+
+				Context("when staging fails", func() {
+					BeforeEach(func() {
+						fakePipeline.StageConfigReturns(db.PipelineConfigVersion{}, errors.New("oh no"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+
+			Context("when the config is invalid", func() {
+				BeforeEach(func() {
+					pipelineConfig.Groups[0].Resources = []string{"missing-resource"}
+
+					payload, err := json.Marshal(pipelineConfig)
+					Expect(err).NotTo(HaveOccurred())
+
+					request.Body = gbytes.BufferWithBytes(payload)
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+
+				It("does not stage it", func() {
+					Expect(fakePipeline.StageConfigCallCount()).To(BeZero())
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("POST /api/v1/teams/:team_name/pipelines/:name/config/candidate/promote", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			request, err := requestGenerator.CreateRequest(atc.PromoteCandidateConfig, rata.Params{
+				"team_name":     "a-team",
+				"pipeline_name": "a-pipeline",
+			}, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+			})
+
+			Context("when the candidate is promoted", func() {
+				BeforeEach(func() {
+					fakePipeline.PromoteCandidateConfigReturns(true, nil)
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("notifies the resource scanner", func() {
+					Expect(dbTeamFactory.NotifyResourceScannerCallCount()).To(Equal(1))
+				})
+			})
+
+			Context("when there is no candidate", func() {
+				BeforeEach(func() {
+					fakePipeline.PromoteCandidateConfigReturns(false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when the active config has changed since the candidate was staged", func() {
+				BeforeEach(func() {
+					fakePipeline.PromoteCandidateConfigReturns(false, db.ErrConfigComparisonFailed)
+				})
+
+				It("returns 409", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusConflict))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("DELETE /api/v1/teams/:team_name/pipelines/:name/config/candidate", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			request, err := requestGenerator.CreateRequest(atc.DiscardCandidateConfig, rata.Params{
+				"team_name":     "a-team",
+				"pipeline_name": "a-pipeline",
+			}, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+			})
+
+			Context("when the candidate is discarded", func() {
+				BeforeEach(func() {
+					fakePipeline.DiscardCandidateConfigReturns(true, nil)
+				})
+
+				It("returns 204", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+				})
+			})
+
+			Context("when there is no candidate", func() {
+				BeforeEach(func() {
+					fakePipeline.DiscardCandidateConfigReturns(false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("GET /api/v1/schemas/:schema_name", func() {
+		var (
+			schemaName string
+			response   *http.Response
+		)
+
+		BeforeEach(func() {
+			schemaName = "pipeline"
+		})
+
+		JustBeforeEach(func() {
+			request, err := requestGenerator.CreateRequest(atc.GetConfigSchema, rata.Params{
+				"schema_name": schemaName,
+			}, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the schema without requiring authentication", func() {
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+			Expect(response.Header.Get("Content-Type")).To(Equal("application/schema+json"))
+
+			var schema map[string]interface{}
+			Expect(json.NewDecoder(response.Body).Decode(&schema)).To(Succeed())
+			Expect(schema["$id"]).To(Equal("/api/v1/schemas/pipeline"))
+			Expect(schema["properties"]).To(HaveKey("jobs"))
+		})
+
+		Context("when the schema does not exist", func() {
+			BeforeEach(func() {
+				schemaName = "bogus"
+			})
+
+			It("returns 404", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
 })
@@ -0,0 +1,151 @@
+package configserver
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	. "github.com/concourse/concourse/atc/api/helpers"
+	"github.com/concourse/concourse/atc/configvalidate"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/db"
+)
+
+// StageConfig validates the config and stages it as the pipeline's candidate
+// config alongside the active one, to be promoted or discarded later.
+func (s *Server) StageConfig(pipeline db.Pipeline) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		session := s.logger.Session("stage-config", lager.Data{
+			"team":     pipeline.TeamName(),
+			"pipeline": pipeline.Name(),
+		})
+
+		var config atc.Config
+		switch r.Header.Get("Content-type") {
+		case "application/json", "application/x-yaml":
+			body, err := ioutil.ReadAll(r.Body)
+			if err != nil {
+				HandleBadRequest(w, fmt.Sprintf("read failed: %s", err))
+				return
+			}
+
+			err = atc.UnmarshalConfig(body, &config)
+			if err != nil {
+				session.Error("malformed-request-payload", err, lager.Data{
+					"content-type": r.Header.Get("Content-Type"),
+				})
+
+				HandleBadRequest(w, fmt.Sprintf("malformed config: %s", err))
+				return
+			}
+		default:
+			w.WriteHeader(http.StatusUnsupportedMediaType)
+			return
+		}
+
+		warnings, errorMessages := configvalidate.Validate(config)
+		if len(errorMessages) > 0 {
+			session.Info("ignoring-invalid-config", lager.Data{"errors": errorMessages})
+			HandleBadRequest(w, errorMessages...)
+			return
+		}
+
+		platformWarnings, err := s.validateTaskPlatforms(config)
+		if err != nil {
+			session.Error("failed-to-validate-task-platforms", err)
+		}
+		warnings = append(warnings, platformWarnings...)
+
+		if _, exists := r.URL.Query()[atc.SaveConfigCheckCreds]; exists {
+			variables := creds.NewVariables(s.secretManager, pipeline.TeamName(), pipeline.Name(), false)
+
+			errs := validateCredParams(variables, config, session)
+			if errs != nil {
+				HandleBadRequest(w, fmt.Sprintf("credential validation failed\n\n%s", errs))
+				return
+			}
+		}
+
+		_, err = pipeline.StageConfig(config)
+		if err != nil {
+			session.Error("failed-to-stage-config", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprintf(w, "failed to stage config: %s", err)
+			return
+		}
+
+		session.Info("staged")
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		WriteSaveConfigResponse(w, atc.SaveConfigResponse{Warnings: warnings})
+	})
+}
+
+// PromoteCandidateConfig atomically replaces the pipeline's active config with
+// its candidate config. It conflicts if the active config has changed since
+// the candidate was staged.
+func (s *Server) PromoteCandidateConfig(pipeline db.Pipeline) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		session := s.logger.Session("promote-candidate-config", lager.Data{
+			"team":     pipeline.TeamName(),
+			"pipeline": pipeline.Name(),
+		})
+
+		promoted, err := pipeline.PromoteCandidateConfig()
+		if err != nil {
+			if errors.Is(err, db.ErrConfigComparisonFailed) {
+				session.Info("active-config-changed")
+				w.WriteHeader(http.StatusConflict)
+				fmt.Fprintf(w, "the active config has changed since the candidate was staged")
+				return
+			}
+
+			session.Error("failed-to-promote-candidate-config", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !promoted {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		err = s.teamFactory.NotifyResourceScanner()
+		if err != nil {
+			session.Error("failed-to-notify-resource-scanner", err)
+		}
+
+		session.Info("promoted")
+
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+// DiscardCandidateConfig discards the pipeline's candidate config.
+func (s *Server) DiscardCandidateConfig(pipeline db.Pipeline) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		session := s.logger.Session("discard-candidate-config", lager.Data{
+			"team":     pipeline.TeamName(),
+			"pipeline": pipeline.Name(),
+		})
+
+		discarded, err := pipeline.DiscardCandidateConfig()
+		if err != nil {
+			session.Error("failed-to-discard-candidate-config", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !discarded {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
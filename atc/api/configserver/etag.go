@@ -0,0 +1,29 @@
+package configserver
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/concourse/concourse/atc/db"
+)
+
+// configETag is the entity tag of a version of a pipeline's config, which the
+// If-Match header of a request to save the config may refer to.
+func configETag(version db.ConfigVersion) string {
+	return fmt.Sprintf(`"%d"`, version)
+}
+
+func parseConfigETag(etag string) (db.ConfigVersion, error) {
+	tag := strings.TrimPrefix(strings.TrimSpace(etag), "W/")
+	if len(tag) < 2 || !strings.HasPrefix(tag, `"`) || !strings.HasSuffix(tag, `"`) {
+		return 0, fmt.Errorf("%s is not an entity tag", etag)
+	}
+
+	version, err := strconv.Atoi(tag[1 : len(tag)-1])
+	if err != nil {
+		return 0, fmt.Errorf("%s is not the entity tag of a config", etag)
+	}
+
+	return db.ConfigVersion(version), nil
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/concourse/concourse/atc"
 	. "github.com/concourse/concourse/atc/api/helpers"
+	"github.com/concourse/concourse/atc/configvalidate"
 	"github.com/tedsuo/rata"
 )
 
@@ -64,11 +65,15 @@ func (s *Server) GetConfig(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	warnings, _ := configvalidate.ValidateDetailed(config)
+
 	w.Header().Set(atc.ConfigVersionHeader, fmt.Sprintf("%d", pipeline.ConfigVersion()))
+	w.Header().Set("ETag", configETag(pipeline.ConfigVersion()))
 	w.Header().Set("Content-Type", "application/json")
 
 	err = json.NewEncoder(w).Encode(atc.ConfigResponse{
-		Config: config,
+		Config:   config,
+		Warnings: warnings,
 	})
 	if err != nil {
 		logger.Error("failed-to-encode-config", err)
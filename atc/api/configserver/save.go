@@ -2,6 +2,7 @@ package configserver
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -38,6 +39,19 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	// If-Match refers to the version by the ETag the config was returned
+	// with, and takes precedence over the config version header
+	ifMatch := r.Header.Get("If-Match")
+	if ifMatch != "" {
+		var err error
+		version, err = parseConfigETag(ifMatch)
+		if err != nil {
+			session.Error("malformed-if-match", err)
+			HandleBadRequest(w, fmt.Sprintf("If-Match is malformed: %s", err))
+			return
+		}
+	}
+
 	var config atc.Config
 	switch r.Header.Get("Content-type") {
 	case "application/json", "application/x-yaml":
@@ -68,6 +82,12 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	platformWarnings, err := s.validateTaskPlatforms(config)
+	if err != nil {
+		session.Error("failed-to-validate-task-platforms", err)
+	}
+	warnings = append(warnings, platformWarnings...)
+
 	pipelineName := rata.Param(r, "pipeline_name")
 	warning, err := atc.ValidateIdentifier(pipelineName, "pipeline")
 	if err != nil {
@@ -128,8 +148,31 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if ifMatch != "" {
+		_, found, err := team.Pipeline(pipelineRef)
+		if err != nil {
+			session.Error("failed-to-find-pipeline", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			session.Info("if-match-pipeline-not-found")
+			w.WriteHeader(http.StatusPreconditionFailed)
+			fmt.Fprintf(w, "the pipeline does not exist")
+			return
+		}
+	}
+
 	_, created, err := team.SavePipeline(pipelineRef, config, version, true)
 	if err != nil {
+		if ifMatch != "" && errors.Is(err, db.ErrConfigComparisonFailed) {
+			session.Info("config-changed-since-if-match")
+			w.WriteHeader(http.StatusPreconditionFailed)
+			fmt.Fprintf(w, "the config has changed since it was fetched")
+			return
+		}
+
 		session.Error("failed-to-save-config", err)
 		w.WriteHeader(http.StatusInternalServerError)
 		fmt.Fprintf(w, "failed to save config: %s", err)
@@ -155,6 +198,57 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 	WriteSaveConfigResponse(w, atc.SaveConfigResponse{Warnings: warnings})
 }
 
+// validateTaskPlatforms warns about tasks which run without a container image
+// (i.e. on windows or darwin) when no worker with the task's platform is
+// registered, as the task would otherwise only fail once a build runs it.
+func (s *Server) validateTaskPlatforms(config atc.Config) ([]atc.ConfigWarning, error) {
+	type task struct {
+		job      string
+		name     string
+		platform string
+	}
+
+	var tasks []task
+	for _, job := range config.Jobs {
+		_ = job.StepConfig().Visit(atc.StepRecursor{
+			OnTask: func(step *atc.TaskStep) error {
+				if step.Config != nil && atc.PlatformRunsWithoutImage(step.Config.Platform) {
+					tasks = append(tasks, task{job: job.Name, name: step.Name, platform: step.Config.Platform})
+				}
+				return nil
+			},
+		})
+	}
+
+	if len(tasks) == 0 {
+		return nil, nil
+	}
+
+	workers, err := s.workerFactory.Workers()
+	if err != nil {
+		return nil, err
+	}
+
+	platforms := map[string]bool{}
+	for _, worker := range workers {
+		platforms[worker.Platform()] = true
+	}
+
+	var warnings []atc.ConfigWarning
+	for _, t := range tasks {
+		if platforms[t.platform] {
+			continue
+		}
+
+		warnings = append(warnings, atc.ConfigWarning{
+			Type:    "pipeline",
+			Message: fmt.Sprintf("jobs.%s.plan.task(%s): no workers with platform '%s' are registered, so the task will not be able to run", t.job, t.name, t.platform),
+		})
+	}
+
+	return warnings, nil
+}
+
 // Simply validate that the credentials exist; don't do anything with the actual secrets
 func validateCredParams(credMgrVars vars.Variables, config atc.Config, session lager.Logger) error {
 	var errs error
@@ -0,0 +1,29 @@
+package configserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/configschema"
+	"github.com/tedsuo/rata"
+)
+
+func (s *Server) GetConfigSchema(w http.ResponseWriter, r *http.Request) {
+	schemaName := rata.Param(r, "schema_name")
+	logger := s.logger.Session("get-config-schema", lager.Data{"schema": schemaName})
+
+	schema, found := configschema.Names[schemaName]
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/schema+json")
+	w.WriteHeader(http.StatusOK)
+
+	err := json.NewEncoder(w).Encode(schema())
+	if err != nil {
+		logger.Error("failed-to-encode-schema", err)
+	}
+}
@@ -9,17 +9,23 @@ import (
 type Server struct {
 	logger        lager.Logger
 	teamFactory   db.TeamFactory
+	workerFactory db.WorkerFactory
 	secretManager creds.Secrets
+	varSourcePool creds.VarSourcePool
 }
 
 func NewServer(
 	logger lager.Logger,
 	teamFactory db.TeamFactory,
+	workerFactory db.WorkerFactory,
 	secretManager creds.Secrets,
+	varSourcePool creds.VarSourcePool,
 ) *Server {
 	return &Server{
 		logger:        logger,
 		teamFactory:   teamFactory,
+		workerFactory: workerFactory,
 		secretManager: secretManager,
+		varSourcePool: varSourcePool,
 	}
 }
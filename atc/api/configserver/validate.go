@@ -0,0 +1,99 @@
+package configserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/configvalidate"
+	"github.com/tedsuo/rata"
+)
+
+// ValidateConfig validates a config without saving it. Unlike SaveConfig, an
+// invalid config is not a bad request: the response always describes each
+// error and warning along with a JSON pointer into the submitted config, so
+// that editors and linters can point at the offending part of it.
+func (s *Server) ValidateConfig(w http.ResponseWriter, r *http.Request) {
+	session := s.logger.Session("validate-config")
+
+	switch r.Header.Get("Content-type") {
+	case "application/json", "application/x-yaml":
+	default:
+		w.WriteHeader(http.StatusUnsupportedMediaType)
+		return
+	}
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		session.Error("failed-to-read-body", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	response := atc.ValidateConfigResponse{
+		SchemaVersion: atc.ConfigSchemaVersion,
+		Errors:        []atc.ConfigError{},
+		Warnings:      []atc.ConfigWarning{},
+	}
+
+	var config atc.Config
+	err = atc.UnmarshalConfig(body, &config)
+	if err != nil {
+		response.Errors = append(response.Errors, atc.ConfigError{
+			Message: fmt.Sprintf("malformed config: %s", err),
+		})
+		s.writeValidateConfigResponse(session, w, response)
+		return
+	}
+
+	unknownFields, err := configvalidate.UnknownFields(body)
+	if err != nil {
+		session.Error("failed-to-find-unknown-fields", err)
+	}
+	response.Warnings = append(response.Warnings, unknownFields...)
+
+	warnings, configErrors := configvalidate.ValidateDetailed(config)
+	response.Warnings = append(response.Warnings, warnings...)
+	response.Errors = append(response.Errors, configErrors...)
+
+	if len(configErrors) == 0 {
+		platformWarnings, err := s.validateTaskPlatforms(config)
+		if err != nil {
+			session.Error("failed-to-validate-task-platforms", err)
+		}
+		response.Warnings = append(response.Warnings, platformWarnings...)
+	}
+
+	for _, identifier := range []struct {
+		param string
+		kind  string
+	}{
+		{"pipeline_name", "pipeline"},
+		{"team_name", "team"},
+	} {
+		warning, err := atc.ValidateIdentifier(rata.Param(r, identifier.param), identifier.kind)
+		if err != nil {
+			response.Errors = append(response.Errors, atc.ConfigError{Message: err.Error()})
+		}
+		if warning != nil {
+			response.Warnings = append(response.Warnings, *warning)
+		}
+	}
+
+	s.writeValidateConfigResponse(session, w, response)
+}
+
+func (s *Server) writeValidateConfigResponse(logger lager.Logger, w http.ResponseWriter, response atc.ValidateConfigResponse) {
+	response.Valid = len(response.Errors) == 0
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	err := json.NewEncoder(w).Encode(response)
+	if err != nil {
+		logger.Error("failed-to-encode-response", err)
+	}
+}
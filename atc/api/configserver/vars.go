@@ -0,0 +1,198 @@
+package configserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	. "github.com/concourse/concourse/atc/api/helpers"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/vars"
+	"github.com/tedsuo/rata"
+)
+
+// ReportConfigVars reports each ((var)) referenced by a config along with the
+// credential manager paths it is looked up at and whether it currently
+// resolves, so that missing credentials can be found before any build runs.
+// The values of the vars are never included.
+func (s *Server) ReportConfigVars(w http.ResponseWriter, r *http.Request) {
+	teamName := rata.Param(r, "team_name")
+	pipelineName := rata.Param(r, "pipeline_name")
+
+	session := s.logger.Session("report-config-vars", lager.Data{
+		"team":     teamName,
+		"pipeline": pipelineName,
+	})
+
+	switch r.Header.Get("Content-type") {
+	case "application/json", "application/x-yaml":
+	default:
+		w.WriteHeader(http.StatusUnsupportedMediaType)
+		return
+	}
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		session.Error("failed-to-read-body", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	var config atc.Config
+	err = atc.UnmarshalConfig(body, &config)
+	if err != nil {
+		session.Error("malformed-config", err)
+		HandleBadRequest(w, fmt.Sprintf("malformed config: %s", err))
+		return
+	}
+
+	globalVars := creds.VariableLookupFromSecrets{
+		Secrets:     s.secretManager,
+		LookupPaths: s.secretManager.NewSecretLookupPaths(teamName, pipelineName, false),
+	}
+
+	varSources, varSourceErrs, err := s.varSources(session, config, globalVars, teamName, pipelineName)
+	if err != nil {
+		HandleBadRequest(w, err.Error())
+		return
+	}
+
+	names := map[string]bool{}
+	for _, name := range vars.NewTemplate(body).ExtraVarNames() {
+		names[name] = true
+	}
+
+	reports := []atc.VarReport{}
+	for name := range names {
+		report := atc.VarReport{Name: name}
+
+		ref, err := vars.ParseReference(name)
+		if err != nil {
+			report.Error = err.Error()
+			reports = append(reports, report)
+			continue
+		}
+
+		report.Source = ref.Source
+
+		lookup := globalVars
+		if ref.Source != "" {
+			if err, failed := varSourceErrs[ref.Source]; failed {
+				report.Error = err.Error()
+				reports = append(reports, report)
+				continue
+			}
+
+			var found bool
+			lookup, found = varSources[ref.Source]
+			if !found {
+				report.Error = vars.MissingSourceError{Name: name, Source: ref.Source}.Error()
+				reports = append(reports, report)
+				continue
+			}
+		}
+
+		reports = append(reports, reportVar(report, lookup, ref, config.Vars))
+	}
+
+	sort.Slice(reports, func(i, j int) bool {
+		return reports[i].Name < reports[j].Name
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	err = json.NewEncoder(w).Encode(reports)
+	if err != nil {
+		session.Error("failed-to-encode-reports", err)
+	}
+}
+
+func reportVar(report atc.VarReport, lookup creds.VariableLookupFromSecrets, ref vars.Reference, declarations atc.VarDeclarations) atc.VarReport {
+	paths, err := lookup.SecretPaths(ref.Path)
+	if err != nil {
+		report.Error = err.Error()
+		return report
+	}
+
+	report.Paths = paths
+
+	path, found, err := lookup.Locate(ref)
+	if err != nil {
+		report.Error = err.Error()
+		return report
+	}
+
+	if found {
+		report.ResolvedPath = path
+		report.Resolved = true
+		return report
+	}
+
+	if ref.Source == "" {
+		declaration, declared := declarations.Lookup(ref.Path)
+		if declared && declaration.Default != nil {
+			report.Default = true
+			report.Resolved = true
+		}
+	}
+
+	return report
+}
+
+// varSources creates the var_sources of the config in the same way as a
+// pipeline's variables are created. A var_source which cannot be created is
+// reported against the vars which refer to it rather than failing the whole
+// report.
+func (s *Server) varSources(logger lager.Logger, config atc.Config, globalVars vars.Variables, teamName string, pipelineName string) (map[string]creds.VariableLookupFromSecrets, map[string]error, error) {
+	lookups := map[string]creds.VariableLookupFromSecrets{}
+	errs := map[string]error{}
+
+	orderedVarSources, err := config.VarSources.OrderByDependency()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	namedVars := vars.NamedVariables{}
+	allVars := vars.NewMultiVars([]vars.Variables{namedVars, globalVars})
+
+	for _, cm := range orderedVarSources {
+		factory := creds.ManagerFactories()[cm.Type]
+		if factory == nil {
+			errs[cm.Name] = fmt.Errorf("unknown credential manager type: %s", cm.Type)
+			continue
+		}
+
+		newConfig, err := creds.NewParams(allVars, atc.Params{"config": cm.Config}).Evaluate()
+		if err != nil {
+			errs[cm.Name] = fmt.Errorf("evaluate var_source '%s' error: %w", cm.Name, err)
+			continue
+		}
+
+		sourceConfig, ok := newConfig["config"].(map[string]interface{})
+		if !ok {
+			errs[cm.Name] = fmt.Errorf("var_source '%s' invalid config", cm.Name)
+			continue
+		}
+
+		secrets, err := s.varSourcePool.FindOrCreate(logger, sourceConfig, factory)
+		if err != nil {
+			errs[cm.Name] = fmt.Errorf("create var_source '%s' error: %w", cm.Name, err)
+			continue
+		}
+
+		lookup := creds.VariableLookupFromSecrets{
+			Secrets:     secrets,
+			LookupPaths: secrets.NewSecretLookupPaths(teamName, pipelineName, true),
+		}
+
+		lookups[cm.Name] = lookup
+		namedVars[cm.Name] = lookup
+	}
+
+	return lookups, errs, nil
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/event"
 	"github.com/concourse/concourse/atc/runtime"
 	"github.com/concourse/concourse/atc/runtime/runtimetest"
 	. "github.com/concourse/concourse/atc/testhelpers"
@@ -684,6 +685,18 @@ var _ = Describe("Containers API", func() {
 							dbTeam.IsContainerWithinTeamReturns(true, nil)
 						})
 
+						Context("when the team has disabled intercept", func() {
+							BeforeEach(func() {
+								expectBadHandshake = true
+
+								dbTeam.InterceptSettingsReturns(atc.InterceptSettings{Disabled: true}, nil)
+							})
+
+							It("returns 403 forbidden", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+							})
+						})
+
 						Context("when the call to lookup the container returns an error", func() {
 							BeforeEach(func() {
 								expectBadHandshake = true
@@ -831,6 +844,35 @@ var _ = Describe("Containers API", func() {
 										Stdout: []byte("some stdout\n"),
 									}))
 								})
+
+								It("does not record it to the build's events", func() {
+									var hijackOutput atc.HijackOutput
+									err := conn.ReadJSON(&hijackOutput)
+									Expect(err).NotTo(HaveOccurred())
+
+									Expect(build.SaveEventCallCount()).To(BeZero())
+								})
+
+								Context("when the team records intercept transcripts", func() {
+									BeforeEach(func() {
+										dbTeam.InterceptSettingsReturns(atc.InterceptSettings{RecordTranscripts: true}, nil)
+										container.DBContainer_.MetadataReturns(db.ContainerMetadata{BuildID: 42})
+										dbBuildFactory.BuildReturns(build, true, nil)
+									})
+
+									It("records it to the events of the container's build", func() {
+										var hijackOutput atc.HijackOutput
+										err := conn.ReadJSON(&hijackOutput)
+										Expect(err).NotTo(HaveOccurred())
+
+										Expect(dbBuildFactory.BuildArgsForCall(0)).To(Equal(42))
+										Expect(build.SaveEventCallCount()).To(Equal(1))
+
+										savedEvent := build.SaveEventArgsForCall(0).(event.InterceptLog)
+										Expect(savedEvent.Source).To(Equal(event.OriginSourceStdout))
+										Expect(savedEvent.Payload).To(Equal("some stdout\n"))
+									})
+								})
 							})
 
 							Context("when the process prints to stderr", func() {
@@ -868,6 +910,14 @@ var _ = Describe("Containers API", func() {
 									}))
 								})
 
+								It("audits the session", func() {
+									Eventually(fakeAuditor.AuditInterceptSessionCallCount).Should(Equal(1))
+
+									session := fakeAuditor.AuditInterceptSessionArgsForCall(0)
+									Expect(session.Path).To(Equal("ls"))
+									Expect(session.Team).To(Equal(dbTeam.Name()))
+								})
+
 								It("closes the process' stdin pipe", func() {
 									process := waitForHijack()
 
@@ -1211,4 +1261,181 @@ var _ = Describe("Containers API", func() {
 			})
 		})
 	})
+
+	Describe("GET /api/v1/builds/:build_id/containers", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/builds/3333/containers")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401 Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+
+				build.IDReturns(buildID)
+				build.TeamNameReturns("some-team")
+				build.AllAssociatedTeamNamesReturns([]string{"some-team"})
+				dbBuildFactory.BuildForAPIReturns(build, true, nil)
+			})
+
+			Context("when not authorized", func() {
+				BeforeEach(func() {
+					fakeAccess.IsAuthorizedReturns(false)
+				})
+
+				It("returns 403 Forbidden", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				})
+			})
+
+			Context("when authorized", func() {
+				BeforeEach(func() {
+					fakeAccess.IsAuthorizedReturns(true)
+				})
+
+				Context("when finding the containers succeeds", func() {
+					BeforeEach(func() {
+						fakeContainerRepository.FindBuildContainersReturns([]db.Container{fakeContainer1}, nil)
+					})
+
+					It("finds the containers of the build", func() {
+						Expect(fakeContainerRepository.FindBuildContainersCallCount()).To(Equal(1))
+						Expect(fakeContainerRepository.FindBuildContainersArgsForCall(0)).To(Equal(buildID))
+					})
+
+					It("returns the containers", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(body).To(MatchJSON(`[
+							{
+								"id": "some-handle",
+								"state": "container-state",
+								"worker_name": "some-worker-name",
+								"type": "task",
+								"step_name": "some-step",
+								"attempt": "1.5",
+								"pipeline_id": 1111,
+								"job_id": 2222,
+								"build_id": 3333,
+								"working_directory": "/tmp/build/my-favorite-guid",
+								"user": "snoopy"
+							}
+						]`))
+					})
+				})
+
+				Context("when finding the containers fails", func() {
+					BeforeEach(func() {
+						fakeContainerRepository.FindBuildContainersReturns(nil, errors.New("nope"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+		})
+	})
+
+	Describe("GET /api/v1/teams/a-team/containers/:id/processes", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/teams/a-team/containers/some-handle/processes")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401 Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+			})
+
+			Context("when the container is not found on a worker", func() {
+				BeforeEach(func() {
+					fakeWorkerPool.LocateContainerReturns(nil, nil, false, nil)
+				})
+
+				It("returns 404 Not Found", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when the container is found on a worker", func() {
+				BeforeEach(func() {
+					spec := runtime.ProcessSpec{ID: "some-process", Path: "sleep"}
+					container := runtimetest.NewContainer().WithProcess(spec, runtimetest.ProcessStub{})
+
+					_, err := container.Run(context.Background(), spec, runtime.ProcessIO{})
+					Expect(err).NotTo(HaveOccurred())
+
+					fakeWorkerPool.LocateContainerReturns(container, runtimetest.NewWorker("worker"), true, nil)
+					dbTeam.IsCheckContainerReturns(false, nil)
+				})
+
+				Context("when the container is within the team", func() {
+					BeforeEach(func() {
+						dbTeam.IsContainerWithinTeamReturns(true, nil)
+					})
+
+					It("returns the processes running in the container", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(body).To(MatchJSON(`[{"id": "some-process"}]`))
+					})
+				})
+
+				Context("when the container is not within the team", func() {
+					BeforeEach(func() {
+						dbTeam.IsContainerWithinTeamReturns(false, nil)
+					})
+
+					It("returns 404 Not Found", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+					})
+				})
+
+				Context("when the container is a check container and the user is not an admin", func() {
+					BeforeEach(func() {
+						dbTeam.IsCheckContainerReturns(true, nil)
+						fakeAccess.IsAdminReturns(false)
+					})
+
+					It("returns 403 Forbidden", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+					})
+				})
+			})
+		})
+	})
 })
@@ -8,10 +8,13 @@ import (
 	"net/http"
 	"time"
 
+	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/api/accessor"
+	"github.com/concourse/concourse/atc/auditor"
 	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/event"
 	"github.com/concourse/concourse/atc/runtime"
 	"github.com/gorilla/websocket"
 )
@@ -90,6 +93,8 @@ func (s *Server) HijackContainer(team db.Team) http.Handler {
 			"handle": handle,
 		})
 
+		acc := accessor.GetAccessor(r)
+
 		container, _, found, err := s.workerPool.LocateContainer(ctx, team.ID(), handle)
 		if err != nil {
 			hLog.Error("failed-to-find-container", err)
@@ -111,7 +116,6 @@ func (s *Server) HijackContainer(team db.Team) http.Handler {
 		}
 
 		if isCheckContainer {
-			acc := accessor.GetAccessor(r)
 			if !acc.IsAdmin() {
 				hLog.Error("user-not-authorized-to-hijack-check-container", err)
 				w.WriteHeader(http.StatusForbidden)
@@ -132,8 +136,43 @@ func (s *Server) HijackContainer(team db.Team) http.Handler {
 			return
 		}
 
+		settings, err := team.InterceptSettings()
+		if err != nil {
+			hLog.Error("failed-to-get-intercept-settings", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if settings.Disabled {
+			hLog.Info("intercept-disabled-for-team")
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
 		hLog.Debug("found-container")
 
+		buildID := container.DBContainer().Metadata().BuildID
+
+		var recorder *transcript
+		if settings.RecordTranscripts && buildID != 0 {
+			build, found, err := s.buildFactory.Build(buildID)
+			if err != nil {
+				hLog.Error("failed-to-find-build", err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			if found {
+				recorder = &transcript{
+					logger:    hLog,
+					clock:     s.clock,
+					build:     build,
+					user:      acc.Claims().UserName,
+					container: handle,
+				}
+			}
+		}
+
 		conn, err := upgrader.Upgrade(w, r, nil)
 		if err != nil {
 			hLog.Error("unable-to-upgrade-connection-for-websockets", err)
@@ -151,8 +190,12 @@ func (s *Server) HijackContainer(team db.Team) http.Handler {
 		}
 
 		hijackRequest := hijackRequest{
-			Container: container,
-			Process:   processSpec,
+			Container:  container,
+			Process:    processSpec,
+			User:       acc.Claims().UserName,
+			Team:       team.Name(),
+			BuildID:    buildID,
+			Transcript: recorder,
 		}
 
 		s.hijack(r.Context(), hLog, conn, hijackRequest)
@@ -160,8 +203,12 @@ func (s *Server) HijackContainer(team db.Team) http.Handler {
 }
 
 type hijackRequest struct {
-	Container runtime.Container
-	Process   atc.HijackProcessSpec
+	Container  runtime.Container
+	Process    atc.HijackProcessSpec
+	User       string
+	Team       string
+	BuildID    int
+	Transcript *transcript
 }
 
 func closeWithErr(log lager.Logger, conn *websocket.Conn, code int, reason string) {
@@ -245,6 +292,20 @@ func (s *Server) hijack(ctx context.Context, hLog lager.Logger, conn *websocket.
 		return
 	}
 
+	startTime := s.clock.Now()
+	defer func() {
+		s.auditor.AuditInterceptSession(auditor.InterceptSession{
+			User:      request.User,
+			Team:      request.Team,
+			Container: request.Container.DBContainer().Handle(),
+			BuildID:   request.BuildID,
+			Path:      request.Process.Path,
+			Args:      request.Process.Args,
+			StartTime: startTime,
+			EndTime:   s.clock.Now(),
+		})
+	}()
+
 	err = request.Container.DBContainer().UpdateLastHijack()
 	if err != nil {
 		hLog.Error("failed-to-update-container-hijack-time", err)
@@ -316,6 +377,7 @@ func (s *Server) hijack(ctx context.Context, hLog lager.Logger, conn *websocket.
 					})
 				}
 			} else {
+				request.Transcript.Record(event.OriginSourceStdin, input.Stdin)
 				_, _ = stdinW.Write(input.Stdin)
 			}
 
@@ -323,6 +385,12 @@ func (s *Server) hijack(ctx context.Context, hLog lager.Logger, conn *websocket.
 			errs <- idle.Error()
 
 		case output := <-outputs:
+			if output.Stdout != nil {
+				request.Transcript.Record(event.OriginSourceStdout, output.Stdout)
+			} else if output.Stderr != nil {
+				request.Transcript.Record(event.OriginSourceStderr, output.Stderr)
+			}
+
 			err := conn.WriteJSON(output)
 			if err != nil {
 				return
@@ -386,3 +454,30 @@ func (writer *stderrWriter) Write(b []byte) (int, error) {
 
 	return len(b), nil
 }
+
+// transcript records what is sent to and received from an intercepted
+// process to the events of the container's build.
+type transcript struct {
+	logger    lager.Logger
+	clock     clock.Clock
+	build     db.Build
+	user      string
+	container string
+}
+
+func (t *transcript) Record(source event.OriginSource, payload []byte) {
+	if t == nil {
+		return
+	}
+
+	err := t.build.SaveEvent(event.InterceptLog{
+		Time:      t.clock.Now().Unix(),
+		User:      t.user,
+		Container: t.container,
+		Source:    source,
+		Payload:   string(payload),
+	})
+	if err != nil {
+		t.logger.Error("failed-to-record-intercept-log", err)
+	}
+}
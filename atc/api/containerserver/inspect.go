@@ -0,0 +1,121 @@
+package containerserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/accessor"
+	"github.com/concourse/concourse/atc/api/present"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/runtime"
+)
+
+// ListBuildContainers lists the containers of the build's steps in any state,
+// for debugging a build without access to its workers.
+func (s *Server) ListBuildContainers(build db.BuildForAPI) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hLog := s.logger.Session("list-build-containers", build.LagerData())
+
+		containers, err := s.containerRepository.FindBuildContainers(build.ID())
+		if err != nil {
+			hLog.Error("failed-to-find-containers", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		presentedContainers := make([]atc.Container, len(containers))
+		for i, container := range containers {
+			presentedContainers[i] = present.Container(container, time.Time{})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(presentedContainers)
+		if err != nil {
+			hLog.Error("failed-to-encode-containers", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
+
+// ListContainerProcesses lists the processes running in the container, as
+// reported by its worker.
+func (s *Server) ListContainerProcesses(team db.Team) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handle := r.FormValue(":id")
+
+		hLog := s.logger.Session("list-container-processes", lager.Data{
+			"handle": handle,
+		})
+
+		container, _, found, err := s.workerPool.LocateContainer(r.Context(), team.ID(), handle)
+		if err != nil {
+			hLog.Error("failed-to-find-container", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			hLog.Info("container-not-found")
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		isCheckContainer, err := team.IsCheckContainer(handle)
+		if err != nil {
+			hLog.Error("failed-to-find-container", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if isCheckContainer {
+			acc := accessor.GetAccessor(r)
+			if !acc.IsAdmin() {
+				hLog.Info("user-not-authorized-to-inspect-check-container")
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+		}
+
+		ok, err := team.IsContainerWithinTeam(handle, isCheckContainer)
+		if err != nil {
+			hLog.Error("failed-to-find-container-within-team", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !ok {
+			hLog.Info("container-not-found-within-team")
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		inspectable, ok := container.(runtime.InspectableContainer)
+		if !ok {
+			hLog.Info("container-not-inspectable")
+			w.WriteHeader(http.StatusNotImplemented)
+			return
+		}
+
+		ids, err := inspectable.ProcessIDs(r.Context())
+		if err != nil {
+			hLog.Error("failed-to-list-processes", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		processes := []atc.ContainerProcess{}
+		for _, id := range ids {
+			processes = append(processes, atc.ContainerProcess{ID: id})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(processes)
+		if err != nil {
+			hLog.Error("failed-to-encode-processes", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
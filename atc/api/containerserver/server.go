@@ -6,6 +6,7 @@ import (
 
 	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/auditor"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/gc"
 	"github.com/concourse/concourse/atc/runtime"
@@ -22,7 +23,9 @@ type Server struct {
 	interceptTimeoutFactory InterceptTimeoutFactory
 	interceptUpdateInterval time.Duration
 	containerRepository     db.ContainerRepository
+	buildFactory            db.BuildFactory
 	destroyer               gc.Destroyer
+	auditor                 auditor.Auditor
 	clock                   clock.Clock
 }
 
@@ -32,7 +35,9 @@ func NewServer(
 	interceptTimeoutFactory InterceptTimeoutFactory,
 	interceptUpdateInterval time.Duration,
 	containerRepository db.ContainerRepository,
+	buildFactory db.BuildFactory,
 	destroyer gc.Destroyer,
+	auditor auditor.Auditor,
 	clock clock.Clock,
 ) *Server {
 	return &Server{
@@ -41,7 +46,9 @@ func NewServer(
 		interceptTimeoutFactory: interceptTimeoutFactory,
 		interceptUpdateInterval: interceptUpdateInterval,
 		containerRepository:     containerRepository,
+		buildFactory:            buildFactory,
 		destroyer:               destroyer,
+		auditor:                 auditor,
 		clock:                   clock,
 	}
 }
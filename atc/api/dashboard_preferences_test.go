@@ -0,0 +1,174 @@
+package api_test
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Dashboard Preferences API", func() {
+	var response *http.Response
+
+	BeforeEach(func() {
+		fakeAccess.IsAuthenticatedReturns(true)
+		fakeAccess.UserInfoReturns(atc.UserInfo{Sub: "some-sub"})
+	})
+
+	Describe("GET /api/v1/user/dashboard", func() {
+		BeforeEach(func() {
+			dbDashboardPreferences.DashboardPreferencesReturns(atc.DashboardPreferences{
+				PipelineOrder:     []int{2, 1},
+				FavoritePipelines: []int{1},
+			}, nil)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/user/dashboard")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the user's preferences", func() {
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+			Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+			Expect(dbDashboardPreferences.DashboardPreferencesArgsForCall(0)).To(Equal("some-sub"))
+			Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`{
+				"pipeline_order": [2, 1],
+				"favorite_pipelines": [1]
+			}`))
+		})
+
+		Context("when the token has no subject", func() {
+			BeforeEach(func() {
+				fakeAccess.UserInfoReturns(atc.UserInfo{IsSystem: true})
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(dbDashboardPreferences.DashboardPreferencesCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when getting the preferences fails", func() {
+			BeforeEach(func() {
+				dbDashboardPreferences.DashboardPreferencesReturns(atc.DashboardPreferences{}, errors.New("nope"))
+			})
+
+			It("returns 500", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/user/dashboard/order", func() {
+		var requestBody string
+
+		BeforeEach(func() {
+			requestBody = `[3, 1, 2]`
+		})
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequest("PUT", server.URL+"/api/v1/user/dashboard/order", bytes.NewBufferString(requestBody))
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("orders the user's pipelines", func() {
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+			sub, pipelineIDs := dbDashboardPreferences.OrderDashboardPipelinesArgsForCall(0)
+			Expect(sub).To(Equal("some-sub"))
+			Expect(pipelineIDs).To(Equal([]int{3, 1, 2}))
+		})
+
+		Context("when the body is malformed", func() {
+			BeforeEach(func() {
+				requestBody = `["some-pipeline"]`
+			})
+
+			It("returns 400", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				Expect(dbDashboardPreferences.OrderDashboardPipelinesCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				Expect(dbDashboardPreferences.OrderDashboardPipelinesCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/user/dashboard/favorites/:pipeline_id", func() {
+		JustBeforeEach(func() {
+			req, err := http.NewRequest("PUT", server.URL+"/api/v1/user/dashboard/favorites/42", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		BeforeEach(func() {
+			dbDashboardPreferences.FavoritePipelineReturns(true, nil)
+		})
+
+		It("favorites the pipeline", func() {
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+			sub, pipelineID := dbDashboardPreferences.FavoritePipelineArgsForCall(0)
+			Expect(sub).To(Equal("some-sub"))
+			Expect(pipelineID).To(Equal(42))
+		})
+
+		Context("when the pipeline does not exist", func() {
+			BeforeEach(func() {
+				dbDashboardPreferences.FavoritePipelineReturns(false, nil)
+			})
+
+			It("returns 404", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
+
+	Describe("DELETE /api/v1/user/dashboard/favorites/:pipeline_id", func() {
+		JustBeforeEach(func() {
+			req, err := http.NewRequest("DELETE", server.URL+"/api/v1/user/dashboard/favorites/42", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("unfavorites the pipeline", func() {
+			Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+
+			sub, pipelineID := dbDashboardPreferences.UnfavoritePipelineArgsForCall(0)
+			Expect(sub).To(Equal("some-sub"))
+			Expect(pipelineID).To(Equal(42))
+		})
+	})
+})
@@ -0,0 +1,135 @@
+package api_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DB Growth API", func() {
+	Describe("GET /api/v1/db/growth", func() {
+		var (
+			query    string
+			response *http.Response
+		)
+
+		BeforeEach(func() {
+			query = ""
+
+			dbGrowth.SnapshotsReturns([]atc.DBGrowthSnapshot{
+				{
+					TakenAt:          1700000000,
+					Team:             "some-team",
+					Builds:           10,
+					BuildEvents:      1000,
+					BuildEventsBytes: 81920,
+					Versions:         20,
+					Containers:       3,
+					Volumes:          5,
+				},
+			}, nil)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/db/growth" + query)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated as an admin", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAdminReturns(true)
+			})
+
+			Context("when a team and a time are given", func() {
+				BeforeEach(func() {
+					query = "?team=some-team&since=1690000000"
+				})
+
+				It("returns 200 with the snapshots of the team since then", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+
+					team, since := dbGrowth.SnapshotsArgsForCall(0)
+					Expect(team).To(Equal("some-team"))
+					Expect(since).To(Equal(time.Unix(1690000000, 0)))
+
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`[
+						{
+							"taken_at": 1700000000,
+							"team": "some-team",
+							"builds": 10,
+							"build_events": 1000,
+							"build_events_bytes": 81920,
+							"versions": 20,
+							"containers": 3,
+							"volumes": 5
+						}
+					]`))
+				})
+			})
+
+			Context("when neither is given", func() {
+				It("returns every snapshot of the cluster", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+					team, since := dbGrowth.SnapshotsArgsForCall(0)
+					Expect(team).To(BeEmpty())
+					Expect(since).To(BeZero())
+				})
+			})
+
+			Context("when the time is not a unix timestamp", func() {
+				BeforeEach(func() {
+					query = "?since=yesterday"
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(dbGrowth.SnapshotsCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when getting the snapshots fails", func() {
+				BeforeEach(func() {
+					dbGrowth.SnapshotsReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when authenticated but not an admin", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAdminReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(dbGrowth.SnapshotsCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+})
@@ -0,0 +1,128 @@
+package api_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DB Maintenance API", func() {
+	Describe("GET /api/v1/db/maintenance", func() {
+		var response *http.Response
+
+		BeforeEach(func() {
+			dbMaintenance.MaintenanceReturns(atc.DBMaintenance{
+				Tables: []atc.DBTableHealth{
+					{
+						Name:       "build_events",
+						Partitions: 2,
+						LiveTuples: 1000,
+						DeadTuples: 400,
+						Bytes:      81920,
+						LastVacuum: 1700000000,
+						Indexes: []atc.DBIndexHealth{
+							{
+								Name:       "build_events_build_id_idx",
+								Table:      "build_events",
+								Bytes:      16384,
+								BloatBytes: 8192,
+							},
+						},
+					},
+				},
+				Advice: []atc.DBMaintenanceAdvice{
+					{
+						Action:   atc.DBMaintenanceVacuum,
+						Relation: "pipeline_build_events_1",
+						Reason:   "400 of its 1400 tuples are dead",
+					},
+				},
+			}, nil)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/db/maintenance")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated as an admin", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAdminReturns(true)
+			})
+
+			It("returns 200 with the health of the tables and the advice", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(body).To(MatchJSON(`{
+					"tables": [
+						{
+							"name": "build_events",
+							"partitions": 2,
+							"live_tuples": 1000,
+							"dead_tuples": 400,
+							"bytes": 81920,
+							"last_vacuum": 1700000000,
+							"indexes": [
+								{
+									"name": "build_events_build_id_idx",
+									"table": "build_events",
+									"bytes": 16384,
+									"bloat_bytes": 8192
+								}
+							]
+						}
+					],
+					"advice": [
+						{
+							"action": "vacuum",
+							"relation": "pipeline_build_events_1",
+							"reason": "400 of its 1400 tuples are dead"
+						}
+					]
+				}`))
+			})
+
+			Context("when getting the maintenance fails", func() {
+				BeforeEach(func() {
+					dbMaintenance.MaintenanceReturns(atc.DBMaintenance{}, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when authenticated but not an admin", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAdminReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(dbMaintenance.MaintenanceCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+})
@@ -0,0 +1,136 @@
+package api_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DB Schema API", func() {
+	Describe("GET /api/v1/db/schema", func() {
+		var response *http.Response
+
+		BeforeEach(func() {
+			dbSchemaReference.SchemaReturns(atc.DBSchema{
+				Version: 1794649339,
+				Tables: []atc.DBTable{
+					{
+						Name: "pipelines",
+						Columns: []atc.DBColumn{
+							{Name: "id", Type: "integer", Default: "nextval('pipelines_id_seq'::regclass)"},
+							{Name: "team_id", Type: "integer"},
+							{Name: "groups", Type: "json", Nullable: true},
+						},
+						ForeignKeys: []atc.DBForeignKey{
+							{
+								Name:              "pipelines_team_id_fkey",
+								Columns:           []string{"team_id"},
+								ReferencedTable:   "teams",
+								ReferencedColumns: []string{"id"},
+								OnDelete:          "cascade",
+							},
+						},
+						Indexes: []atc.DBIndex{
+							{
+								Name:       "pipelines_pkey",
+								Unique:     true,
+								Primary:    true,
+								Definition: "CREATE UNIQUE INDEX pipelines_pkey ON public.pipelines USING btree (id)",
+							},
+						},
+						Migrations: []int{1510262030},
+					},
+				},
+			}, nil)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/db/schema")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated as an admin", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAdminReturns(true)
+			})
+
+			It("returns 200 with the schema", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(body).To(MatchJSON(`{
+					"version": 1794649339,
+					"tables": [
+						{
+							"name": "pipelines",
+							"columns": [
+								{"name": "id", "type": "integer", "nullable": false, "default": "nextval('pipelines_id_seq'::regclass)"},
+								{"name": "team_id", "type": "integer", "nullable": false},
+								{"name": "groups", "type": "json", "nullable": true}
+							],
+							"foreign_keys": [
+								{
+									"name": "pipelines_team_id_fkey",
+									"columns": ["team_id"],
+									"referenced_table": "teams",
+									"referenced_columns": ["id"],
+									"on_delete": "cascade"
+								}
+							],
+							"indexes": [
+								{
+									"name": "pipelines_pkey",
+									"unique": true,
+									"primary": true,
+									"definition": "CREATE UNIQUE INDEX pipelines_pkey ON public.pipelines USING btree (id)"
+								}
+							],
+							"migrations": [1510262030]
+						}
+					]
+				}`))
+			})
+
+			Context("when getting the schema fails", func() {
+				BeforeEach(func() {
+					dbSchemaReference.SchemaReturns(atc.DBSchema{}, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when authenticated but not an admin", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAdminReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(dbSchemaReference.SchemaCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+})
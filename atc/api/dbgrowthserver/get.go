@@ -0,0 +1,48 @@
+package dbgrowthserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+)
+
+// GetDBGrowth returns the snapshots of the growth of the database taken
+// since the time the request gives as a unix timestamp, or every snapshot
+// still kept if it gives none. They are of the team the request names, or
+// of the whole cluster if it names none.
+func (s *Server) GetDBGrowth(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("get-db-growth")
+
+	team := r.URL.Query().Get("team")
+
+	var since time.Time
+	if value := r.URL.Query().Get("since"); value != "" {
+		seconds, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			logger.Info("malformed-since", lager.Data{"since": value})
+			http.Error(w, "since must be a unix timestamp", http.StatusBadRequest)
+			return
+		}
+
+		since = time.Unix(seconds, 0)
+	}
+
+	snapshots, err := s.repository.Snapshots(team, since)
+	if err != nil {
+		logger.Error("failed-to-get-snapshots", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	err = json.NewEncoder(w).Encode(snapshots)
+	if err != nil {
+		logger.Error("failed-to-encode-snapshots", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
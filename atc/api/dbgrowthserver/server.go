@@ -0,0 +1,21 @@
+package dbgrowthserver
+
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/db"
+)
+
+type Server struct {
+	logger     lager.Logger
+	repository db.GrowthRepository
+}
+
+func NewServer(
+	logger lager.Logger,
+	repository db.GrowthRepository,
+) *Server {
+	return &Server{
+		logger:     logger,
+		repository: repository,
+	}
+}
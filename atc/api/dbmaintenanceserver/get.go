@@ -0,0 +1,28 @@
+package dbmaintenanceserver
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// GetDBMaintenance returns the dead tuples and index bloat of the busiest
+// tables, and which of them should be vacuumed or rebuilt.
+func (s *Server) GetDBMaintenance(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("get-db-maintenance")
+
+	maintenance, err := s.repository.Maintenance()
+	if err != nil {
+		logger.Error("failed-to-get-maintenance", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	err = json.NewEncoder(w).Encode(maintenance)
+	if err != nil {
+		logger.Error("failed-to-encode-maintenance", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,21 @@
+package dbmaintenanceserver
+
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/db"
+)
+
+type Server struct {
+	logger     lager.Logger
+	repository db.MaintenanceRepository
+}
+
+func NewServer(
+	logger lager.Logger,
+	repository db.MaintenanceRepository,
+) *Server {
+	return &Server{
+		logger:     logger,
+		repository: repository,
+	}
+}
@@ -0,0 +1,28 @@
+package dbschemaserver
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// GetDBSchema returns the tables of the database with their columns, foreign
+// keys and indexes, and the migrations which mention each of them.
+func (s *Server) GetDBSchema(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("get-db-schema")
+
+	schema, err := s.schema.Schema()
+	if err != nil {
+		logger.Error("failed-to-get-schema", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	err = json.NewEncoder(w).Encode(schema)
+	if err != nil {
+		logger.Error("failed-to-encode-schema", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
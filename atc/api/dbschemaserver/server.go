@@ -0,0 +1,21 @@
+package dbschemaserver
+
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/db"
+)
+
+type Server struct {
+	logger lager.Logger
+	schema db.SchemaReference
+}
+
+func NewServer(
+	logger lager.Logger,
+	schema db.SchemaReference,
+) *Server {
+	return &Server{
+		logger: logger,
+		schema: schema,
+	}
+}
@@ -0,0 +1,102 @@
+package api_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Deprecations API", func() {
+	Describe("GET /api/v1/deprecations", func() {
+		var response *http.Response
+
+		BeforeEach(func() {
+			dbDeprecationUsage.UsageReturns([]atc.DeprecationUsage{
+				{
+					Route:     atc.MainJobBadge,
+					UserAgent: "some-agent",
+					Team:      "some-team",
+					Requests:  5,
+					FirstSeen: 1690000000,
+					LastSeen:  1700000000,
+				},
+			}, nil)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/deprecations")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated as an admin", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAdminReturns(true)
+			})
+
+			It("returns 200 with the deprecations and their usage", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(body).To(MatchJSON(`{
+					"deprecations": [
+						{
+							"route": "MainJobBadge",
+							"replacement": "GET /api/v1/teams/main/pipelines/:pipeline_name/jobs/:job_name/badge"
+						}
+					],
+					"usage": [
+						{
+							"route": "MainJobBadge",
+							"user_agent": "some-agent",
+							"team": "some-team",
+							"requests": 5,
+							"first_seen": 1690000000,
+							"last_seen": 1700000000
+						}
+					]
+				}`))
+			})
+
+			Context("when getting the usage fails", func() {
+				BeforeEach(func() {
+					dbDeprecationUsage.UsageReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when authenticated but not an admin", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAdminReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(dbDeprecationUsage.UsageCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+})
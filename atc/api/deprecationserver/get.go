@@ -0,0 +1,34 @@
+package deprecationserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+)
+
+// GetDeprecationReport returns the deprecated parts of the API, along with
+// the clients which still use them. Usage is flushed to the database every
+// minute or so, so the most recent requests may not be counted yet.
+func (s *Server) GetDeprecationReport(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("get-deprecation-report")
+
+	usage, err := s.repository.Usage()
+	if err != nil {
+		logger.Error("failed-to-get-usage", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	err = json.NewEncoder(w).Encode(atc.DeprecationReport{
+		Deprecations: atc.APIDeprecations,
+		Usage:        usage,
+	})
+	if err != nil {
+		logger.Error("failed-to-encode-report", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
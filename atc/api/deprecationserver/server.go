@@ -0,0 +1,21 @@
+package deprecationserver
+
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/db"
+)
+
+type Server struct {
+	logger     lager.Logger
+	repository db.DeprecationUsageRepository
+}
+
+func NewServer(
+	logger lager.Logger,
+	repository db.DeprecationUsageRepository,
+) *Server {
+	return &Server{
+		logger:     logger,
+		repository: repository,
+	}
+}
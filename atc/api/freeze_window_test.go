@@ -0,0 +1,276 @@
+package api_test
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Freeze Windows API", func() {
+	var (
+		fakeTeam *dbfakes.FakeTeam
+		response *http.Response
+	)
+
+	BeforeEach(func() {
+		fakeTeam = new(dbfakes.FakeTeam)
+		fakeTeam.IDReturns(3)
+		fakeTeam.NameReturns("a-team")
+	})
+
+	Describe("GET /api/v1/teams/:team_name/freeze_windows", func() {
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/teams/a-team/freeze_windows")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+				dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+
+				dbFreezeWindows.FreezeWindowsReturns([]atc.FreezeWindow{
+					{
+						ID:              1,
+						TeamName:        "a-team",
+						StartTime:       100,
+						EndTime:         200,
+						Reason:          "release",
+						ExemptPipelines: []string{"hotfixes"},
+					},
+				}, nil)
+			})
+
+			It("returns the team's freeze windows", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(dbFreezeWindows.FreezeWindowsArgsForCall(0)).To(Equal(3))
+				Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`[
+					{
+						"id": 1,
+						"team_name": "a-team",
+						"start_time": 100,
+						"end_time": 200,
+						"reason": "release",
+						"exempt_pipelines": ["hotfixes"]
+					}
+				]`))
+			})
+
+			Context("when getting the freeze windows fails", func() {
+				BeforeEach(func() {
+					dbFreezeWindows.FreezeWindowsReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				Expect(dbFreezeWindows.FreezeWindowsCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("POST /api/v1/teams/:team_name/freeze_windows", func() {
+		var requestBody string
+
+		BeforeEach(func() {
+			requestBody = `{"start_time":100,"end_time":200,"reason":"release","exempt_pipelines":["hotfixes"]}`
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Post(
+				server.URL+"/api/v1/teams/a-team/freeze_windows",
+				"application/json",
+				bytes.NewBufferString(requestBody),
+			)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+				dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+
+				dbFreezeWindows.CreateFreezeWindowStub = func(teamID int, window atc.FreezeWindow) (atc.FreezeWindow, error) {
+					window.ID = 42
+					window.TeamName = "a-team"
+					return window, nil
+				}
+			})
+
+			It("creates the freeze window for the team", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusCreated))
+				Expect(dbFreezeWindows.CreateFreezeWindowCallCount()).To(Equal(1))
+
+				teamID, window := dbFreezeWindows.CreateFreezeWindowArgsForCall(0)
+				Expect(teamID).To(Equal(3))
+				Expect(window).To(Equal(atc.FreezeWindow{
+					StartTime:       100,
+					EndTime:         200,
+					Reason:          "release",
+					ExemptPipelines: []string{"hotfixes"},
+				}))
+			})
+
+			It("returns the created freeze window", func() {
+				Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`{
+					"id": 42,
+					"team_name": "a-team",
+					"start_time": 100,
+					"end_time": 200,
+					"reason": "release",
+					"exempt_pipelines": ["hotfixes"]
+				}`))
+			})
+
+			Context("when the window ends before it starts", func() {
+				BeforeEach(func() {
+					requestBody = `{"start_time":200,"end_time":100}`
+				})
+
+				It("returns 400 without creating it", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`{
+						"errors": ["invalid freeze window: end_time must be after start_time"]
+					}`))
+					Expect(dbFreezeWindows.CreateFreezeWindowCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the request body is malformed", func() {
+				BeforeEach(func() {
+					requestBody = `{`
+				})
+
+				It("returns 400 without creating it", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(dbFreezeWindows.CreateFreezeWindowCallCount()).To(BeZero())
+				})
+			})
+		})
+
+		Context("when unauthorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(false)
+				dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(dbFreezeWindows.CreateFreezeWindowCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("POST /api/v1/freeze_windows", func() {
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Post(
+				server.URL+"/api/v1/freeze_windows",
+				"application/json",
+				bytes.NewBufferString(`{"start_time":100,"end_time":200}`),
+			)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		BeforeEach(func() {
+			fakeAccess.IsAuthenticatedReturns(true)
+		})
+
+		Context("when admin", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAdminReturns(true)
+			})
+
+			It("creates the cluster's freeze window", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusCreated))
+
+				teamID, window := dbFreezeWindows.CreateFreezeWindowArgsForCall(0)
+				Expect(teamID).To(BeZero())
+				Expect(window).To(Equal(atc.FreezeWindow{StartTime: 100, EndTime: 200}))
+			})
+		})
+
+		Context("when not admin", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAdminReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(dbFreezeWindows.CreateFreezeWindowCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("DELETE /api/v1/teams/:team_name/freeze_windows/:freeze_window_id", func() {
+		var windowID string
+
+		BeforeEach(func() {
+			windowID = "42"
+
+			fakeAccess.IsAuthenticatedReturns(true)
+			fakeAccess.IsAuthorizedReturns(true)
+			dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+			dbFreezeWindows.DeleteFreezeWindowReturns(true, nil)
+		})
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequest("DELETE", server.URL+"/api/v1/teams/a-team/freeze_windows/"+windowID, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("deletes the team's freeze window", func() {
+			Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+
+			teamID, id := dbFreezeWindows.DeleteFreezeWindowArgsForCall(0)
+			Expect(teamID).To(Equal(3))
+			Expect(id).To(Equal(42))
+		})
+
+		Context("when the freeze window does not exist", func() {
+			BeforeEach(func() {
+				dbFreezeWindows.DeleteFreezeWindowReturns(false, nil)
+			})
+
+			It("returns 404", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+			})
+		})
+
+		Context("when the id is not an integer", func() {
+			BeforeEach(func() {
+				windowID = "nope"
+			})
+
+			It("returns 400", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				Expect(dbFreezeWindows.DeleteFreezeWindowCallCount()).To(BeZero())
+			})
+		})
+	})
+})
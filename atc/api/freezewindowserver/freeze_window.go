@@ -0,0 +1,121 @@
+package freezewindowserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	. "github.com/concourse/concourse/atc/api/helpers"
+	"github.com/concourse/concourse/atc/db"
+)
+
+// The handlers of the cluster's freeze windows use a teamID of 0.
+
+func (s *Server) ListTeamFreezeWindows(team db.Team) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.listFreezeWindows(w, r, team.ID())
+	})
+}
+
+func (s *Server) ListClusterFreezeWindows(w http.ResponseWriter, r *http.Request) {
+	s.listFreezeWindows(w, r, 0)
+}
+
+func (s *Server) CreateTeamFreezeWindow(team db.Team) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.createFreezeWindow(w, r, team.ID())
+	})
+}
+
+func (s *Server) CreateClusterFreezeWindow(w http.ResponseWriter, r *http.Request) {
+	s.createFreezeWindow(w, r, 0)
+}
+
+func (s *Server) DestroyTeamFreezeWindow(team db.Team) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.destroyFreezeWindow(w, r, team.ID())
+	})
+}
+
+func (s *Server) DestroyClusterFreezeWindow(w http.ResponseWriter, r *http.Request) {
+	s.destroyFreezeWindow(w, r, 0)
+}
+
+func (s *Server) listFreezeWindows(w http.ResponseWriter, r *http.Request, teamID int) {
+	logger := s.logger.Session("list-freeze-windows", lager.Data{"team-id": teamID})
+
+	windows, err := s.repository.FreezeWindows(teamID)
+	if err != nil {
+		logger.Error("failed-to-get-freeze-windows", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(windows)
+	if err != nil {
+		logger.Error("failed-to-encode-freeze-windows", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+func (s *Server) createFreezeWindow(w http.ResponseWriter, r *http.Request, teamID int) {
+	logger := s.logger.Session("create-freeze-window", lager.Data{"team-id": teamID})
+
+	var window atc.FreezeWindow
+	err := json.NewDecoder(r.Body).Decode(&window)
+	if err != nil {
+		logger.Info("malformed-request", lager.Data{"error": err.Error()})
+		HandleBadRequest(w, fmt.Sprintf("malformed freeze window: %s", err))
+		return
+	}
+
+	err = window.Validate()
+	if err != nil {
+		HandleBadRequest(w, fmt.Sprintf("invalid freeze window: %s", err))
+		return
+	}
+
+	window, err = s.repository.CreateFreezeWindow(teamID, window)
+	if err != nil {
+		logger.Error("failed-to-create-freeze-window", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+
+	err = json.NewEncoder(w).Encode(window)
+	if err != nil {
+		logger.Error("failed-to-encode-freeze-window", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+func (s *Server) destroyFreezeWindow(w http.ResponseWriter, r *http.Request, teamID int) {
+	logger := s.logger.Session("destroy-freeze-window", lager.Data{"team-id": teamID})
+
+	windowID, err := strconv.Atoi(r.FormValue(":freeze_window_id"))
+	if err != nil {
+		HandleBadRequest(w, "freeze_window_id must be an integer")
+		return
+	}
+
+	deleted, err := s.repository.DeleteFreezeWindow(teamID, windowID)
+	if err != nil {
+		logger.Error("failed-to-delete-freeze-window", err, lager.Data{"freeze-window": windowID})
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !deleted {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,21 @@
+package freezewindowserver
+
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/db"
+)
+
+type Server struct {
+	logger     lager.Logger
+	repository db.FreezeWindowRepository
+}
+
+func NewServer(
+	logger lager.Logger,
+	repository db.FreezeWindowRepository,
+) *Server {
+	return &Server{
+		logger:     logger,
+		repository: repository,
+	}
+}
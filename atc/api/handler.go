@@ -9,26 +9,43 @@ import (
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/api/artifactserver"
+	"github.com/concourse/concourse/atc/api/autoscalingserver"
 	"github.com/concourse/concourse/atc/api/buildserver"
+	"github.com/concourse/concourse/atc/api/cabundleserver"
 	"github.com/concourse/concourse/atc/api/ccserver"
 	"github.com/concourse/concourse/atc/api/cliserver"
+	"github.com/concourse/concourse/atc/api/clusteroverviewserver"
+	"github.com/concourse/concourse/atc/api/componentserver"
 	"github.com/concourse/concourse/atc/api/configserver"
 	"github.com/concourse/concourse/atc/api/containerserver"
+	"github.com/concourse/concourse/atc/api/dbgrowthserver"
+	"github.com/concourse/concourse/atc/api/dbmaintenanceserver"
+	"github.com/concourse/concourse/atc/api/dbschemaserver"
+	"github.com/concourse/concourse/atc/api/deprecationserver"
+	"github.com/concourse/concourse/atc/api/freezewindowserver"
+	"github.com/concourse/concourse/atc/api/healthserver"
 	"github.com/concourse/concourse/atc/api/infoserver"
+	"github.com/concourse/concourse/atc/api/injectedenvserver"
 	"github.com/concourse/concourse/atc/api/jobserver"
+	"github.com/concourse/concourse/atc/api/logdrainserver"
 	"github.com/concourse/concourse/atc/api/loglevelserver"
 	"github.com/concourse/concourse/atc/api/pipelineserver"
 	"github.com/concourse/concourse/atc/api/resourceserver"
 	"github.com/concourse/concourse/atc/api/resourceserver/versionserver"
+	"github.com/concourse/concourse/atc/api/schedulerserver"
+	"github.com/concourse/concourse/atc/api/teamrequestserver"
 	"github.com/concourse/concourse/atc/api/teamserver"
 	"github.com/concourse/concourse/atc/api/usersserver"
 	"github.com/concourse/concourse/atc/api/volumeserver"
 	"github.com/concourse/concourse/atc/api/wallserver"
+	"github.com/concourse/concourse/atc/api/webhookserver"
 	"github.com/concourse/concourse/atc/api/workerserver"
+	"github.com/concourse/concourse/atc/auditor"
 	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/gc"
 	"github.com/concourse/concourse/atc/mainredirect"
+	"github.com/concourse/concourse/atc/requestlog"
 	"github.com/concourse/concourse/atc/wrappa"
 	"github.com/tedsuo/rata"
 )
@@ -38,6 +55,7 @@ import (
 type Pool interface {
 	artifactserver.Pool
 	containerserver.Pool
+	volumeserver.Pool
 }
 
 func NewHandler(
@@ -63,10 +81,13 @@ func NewHandler(
 	dbUserFactory db.UserFactory,
 
 	eventHandlerFactory buildserver.EventHandlerFactory,
+	maxRunningOneOffBuilds int,
 
 	workerPool Pool,
+	workerCircuitBreakers workerserver.CircuitBreakers,
 
 	sink *lager.ReconfigurableSink,
+	requestLogRecorder *requestlog.Recorder,
 
 	isTLSEnabled bool,
 
@@ -78,7 +99,29 @@ func NewHandler(
 	credsManagers creds.Managers,
 	interceptTimeoutFactory containerserver.InterceptTimeoutFactory,
 	interceptUpdateInterval time.Duration,
+	aud auditor.Auditor,
 	dbWall db.Wall,
+	dbOutgoingWebhookRepository db.OutgoingWebhookRepository,
+	dbLogDrainRepository db.LogDrainRepository,
+	dbFreezeWindowRepository db.FreezeWindowRepository,
+	dbBuildStatsRepository db.BuildStatsRepository,
+	dbFlakinessRepository db.FlakinessRepository,
+	dbBuildQueueRepository db.BuildQueueRepository,
+	dbSchedulingStatsRepository db.SchedulingStatsRepository,
+	dbComponentFactory db.ComponentFactory,
+	dbSchemaReference db.SchemaReference,
+	dbMaintenanceRepository db.MaintenanceRepository,
+	dbGrowthRepository db.GrowthRepository,
+	dbAutoscalingRepository db.AutoscalingRepository,
+	dbDeprecationUsageRepository db.DeprecationUsageRepository,
+	dbTeamRequestRepository db.TeamRequestRepository,
+	dbDashboardPreferenceRepository db.DashboardPreferenceRepository,
+	dbUserPreferenceRepository db.UserPreferenceRepository,
+	dbSessionRepository db.SessionRepository,
+	healthChecker healthserver.Checker,
+	dbClusterOverviewRepository db.ClusterOverviewRepository,
+	dbInjectedEnvRepository db.InjectedEnvRepository,
+	dbCABundleRepository db.CABundleRepository,
 	clock clock.Clock,
 ) (http.Handler, error) {
 
@@ -91,37 +134,64 @@ func NewHandler(
 	buildHandlerFactory := buildserver.NewScopedHandlerFactory(logger)
 	teamHandlerFactory := NewTeamScopedHandlerFactory(logger, dbTeamFactory)
 
-	buildServer := buildserver.NewServer(logger, externalURL, dbTeamFactory, dbBuildFactory, eventHandlerFactory)
-	jobServer := jobserver.NewServer(logger, externalURL, secretManager, dbJobFactory, dbCheckFactory)
+	buildServer := buildserver.NewServer(logger, externalURL, dbTeamFactory, dbBuildFactory, eventHandlerFactory, maxRunningOneOffBuilds)
+	jobServer := jobserver.NewServer(logger, externalURL, secretManager, dbJobFactory, dbCheckFactory, dbBuildStatsRepository, dbFlakinessRepository, dbBuildQueueRepository)
 	resourceServer := resourceserver.NewServer(logger, secretManager, varSourcePool, dbCheckFactory, dbResourceFactory, dbResourceConfigFactory)
 
 	versionServer := versionserver.NewServer(logger, externalURL)
-	pipelineServer := pipelineserver.NewServer(logger, dbTeamFactory, dbPipelineFactory, externalURL)
-	configServer := configserver.NewServer(logger, dbTeamFactory, secretManager)
+	pipelineServer := pipelineserver.NewServer(logger, dbTeamFactory, dbPipelineFactory, dbCheckFactory, externalURL)
+	configServer := configserver.NewServer(logger, dbTeamFactory, dbWorkerFactory, secretManager, varSourcePool)
 	ccServer := ccserver.NewServer(logger, dbTeamFactory, externalURL)
-	workerServer := workerserver.NewServer(logger, workerTeamFactory, dbWorkerFactory)
-	logLevelServer := loglevelserver.NewServer(logger, sink)
+	workerServer := workerserver.NewServer(logger, workerTeamFactory, dbWorkerFactory, workerCircuitBreakers)
+	logLevelServer := loglevelserver.NewServer(logger, sink, requestLogRecorder)
 	cliServer := cliserver.NewServer(logger, absCLIDownloadsDir)
-	containerServer := containerserver.NewServer(logger, workerPool, interceptTimeoutFactory, interceptUpdateInterval, containerRepository, destroyer, clock)
-	volumesServer := volumeserver.NewServer(logger, volumeRepository, destroyer)
+	containerServer := containerserver.NewServer(logger, workerPool, interceptTimeoutFactory, interceptUpdateInterval, containerRepository, dbBuildFactory, destroyer, aud, clock)
+	volumesServer := volumeserver.NewServer(logger, volumeRepository, containerRepository, workerPool, destroyer)
 	teamServer := teamserver.NewServer(logger, dbTeamFactory, externalURL)
-	infoServer := infoserver.NewServer(logger, version, workerVersion, externalURL, clusterName, credsManagers)
+	infoServer := infoserver.NewServer(logger, version, workerVersion, externalURL, clusterName, credsManagers, dbWall)
 	artifactServer := artifactserver.NewServer(logger, workerPool)
-	usersServer := usersserver.NewServer(logger, dbUserFactory)
+	usersServer := usersserver.NewServer(logger, dbUserFactory, dbDashboardPreferenceRepository, dbUserPreferenceRepository, dbSessionRepository)
 	wallServer := wallserver.NewServer(dbWall, logger)
+	webhookServer := webhookserver.NewServer(logger, dbOutgoingWebhookRepository)
+	logDrainServer := logdrainserver.NewServer(logger, dbLogDrainRepository)
+	freezeWindowServer := freezewindowserver.NewServer(logger, dbFreezeWindowRepository)
+	injectedEnvServer := injectedenvserver.NewServer(logger, dbInjectedEnvRepository)
+	caBundleServer := cabundleserver.NewServer(logger, dbCABundleRepository)
+	schedulerServer := schedulerserver.NewServer(logger, dbSchedulingStatsRepository)
+	componentServer := componentserver.NewServer(logger, dbComponentFactory)
+	dbSchemaServer := dbschemaserver.NewServer(logger, dbSchemaReference)
+	dbMaintenanceServer := dbmaintenanceserver.NewServer(logger, dbMaintenanceRepository)
+	dbGrowthServer := dbgrowthserver.NewServer(logger, dbGrowthRepository)
+	deprecationServer := deprecationserver.NewServer(logger, dbDeprecationUsageRepository)
+	autoscalingServer := autoscalingserver.NewServer(logger, dbAutoscalingRepository, dbWorkerFactory, clock)
+	healthServer := healthserver.NewServer(logger, healthChecker)
+	clusterOverviewServer := clusteroverviewserver.NewServer(logger, dbClusterOverviewRepository, clock)
+	teamRequestServer := teamrequestserver.NewServer(logger, dbTeamFactory, dbTeamRequestRepository)
 
 	handlers := map[string]http.Handler{
-		atc.GetConfig:  http.HandlerFunc(configServer.GetConfig),
-		atc.SaveConfig: http.HandlerFunc(configServer.SaveConfig),
+		atc.GetConfig:        http.HandlerFunc(configServer.GetConfig),
+		atc.SaveConfig:       http.HandlerFunc(configServer.SaveConfig),
+		atc.ValidateConfig:   http.HandlerFunc(configServer.ValidateConfig),
+		atc.ReportConfigVars: http.HandlerFunc(configServer.ReportConfigVars),
+
+		atc.StageConfig:            pipelineHandlerFactory.HandlerFor(configServer.StageConfig),
+		atc.PromoteCandidateConfig: pipelineHandlerFactory.HandlerFor(configServer.PromoteCandidateConfig),
+		atc.DiscardCandidateConfig: pipelineHandlerFactory.HandlerFor(configServer.DiscardCandidateConfig),
+
+		atc.GetConfigSchema: http.HandlerFunc(configServer.GetConfigSchema),
 
 		atc.GetCC: http.HandlerFunc(ccServer.GetCC),
 
 		atc.ListBuilds:          http.HandlerFunc(buildServer.ListBuilds),
 		atc.CreateBuild:         teamHandlerFactory.HandlerFor(buildServer.CreateBuild),
+		atc.RunTask:             teamHandlerFactory.HandlerFor(buildServer.RunTask),
 		atc.GetBuild:            buildHandlerFactory.HandlerFor(buildServer.GetBuild),
 		atc.BuildResources:      buildHandlerFactory.HandlerFor(buildServer.BuildResources),
 		atc.AbortBuild:          buildHandlerFactory.HandlerFor(buildServer.AbortBuild),
+		atc.AbortBuildStep:      buildHandlerFactory.HandlerFor(buildServer.AbortBuildStep),
 		atc.GetBuildPlan:        buildHandlerFactory.HandlerFor(buildServer.GetBuildPlan),
+		atc.GetBuildSteps:       buildHandlerFactory.HandlerFor(buildServer.GetBuildSteps),
+		atc.GetBuildProvenance:  buildHandlerFactory.HandlerFor(buildServer.GetBuildProvenance),
 		atc.GetBuildPreparation: buildHandlerFactory.HandlerFor(buildServer.GetBuildPreparation),
 		atc.BuildEvents:         buildHandlerFactory.HandlerFor(buildServer.BuildEvents),
 		atc.ListBuildArtifacts:  buildHandlerFactory.HandlerFor(buildServer.GetBuildArtifacts),
@@ -139,6 +209,14 @@ func NewHandler(
 		atc.UnpauseJob:     pipelineHandlerFactory.HandlerFor(jobServer.UnpauseJob),
 		atc.ScheduleJob:    pipelineHandlerFactory.HandlerFor(jobServer.ScheduleJob),
 		atc.JobBadge:       pipelineHandlerFactory.HandlerFor(jobServer.JobBadge),
+
+		atc.ListJobStatistics: pipelineHandlerFactory.HandlerFor(jobServer.ListJobStatistics),
+		atc.GetJobStatistics:  pipelineHandlerFactory.HandlerFor(jobServer.GetJobStatistics),
+		atc.ListJobFlakiness:  pipelineHandlerFactory.HandlerFor(jobServer.ListJobFlakiness),
+		atc.ListFlakyJobs:     teamHandlerFactory.HandlerFor(jobServer.ListFlakyJobs),
+
+		atc.ListTeamBuildQueue: teamHandlerFactory.HandlerFor(jobServer.ListTeamBuildQueue),
+
 		atc.MainJobBadge: mainredirect.Handler{
 			Routes: atc.Routes,
 			Route:  atc.JobBadge,
@@ -151,6 +229,11 @@ func NewHandler(
 		atc.GetPipeline:               pipelineHandlerFactory.HandlerFor(pipelineServer.GetPipeline),
 		atc.DeletePipeline:            pipelineHandlerFactory.HandlerFor(pipelineServer.DeletePipeline),
 		atc.OrderPipelines:            teamHandlerFactory.HandlerFor(pipelineServer.OrderPipelines),
+		atc.PausePipelines:            teamHandlerFactory.HandlerFor(pipelineServer.PausePipelines),
+		atc.UnpausePipelines:          teamHandlerFactory.HandlerFor(pipelineServer.UnpausePipelines),
+		atc.ExposePipelines:           teamHandlerFactory.HandlerFor(pipelineServer.ExposePipelines),
+		atc.HidePipelines:             teamHandlerFactory.HandlerFor(pipelineServer.HidePipelines),
+		atc.CheckPipelines:            teamHandlerFactory.HandlerFor(pipelineServer.CheckPipelines),
 		atc.OrderPipelinesWithinGroup: teamHandlerFactory.HandlerFor(pipelineServer.OrderPipelinesWithinGroup),
 		atc.PausePipeline:             pipelineHandlerFactory.HandlerFor(pipelineServer.PausePipeline),
 		atc.ArchivePipeline:           pipelineHandlerFactory.HandlerFor(pipelineServer.ArchivePipeline),
@@ -159,9 +242,11 @@ func NewHandler(
 		atc.HidePipeline:              pipelineHandlerFactory.HandlerFor(pipelineServer.HidePipeline),
 		atc.GetVersionsDB:             pipelineHandlerFactory.HandlerFor(pipelineServer.GetVersionsDB),
 		atc.RenamePipeline:            teamHandlerFactory.HandlerFor(pipelineServer.RenamePipeline),
+		atc.MovePipeline:              pipelineHandlerFactory.HandlerFor(pipelineServer.MovePipeline),
 		atc.ListPipelineBuilds:        pipelineHandlerFactory.HandlerFor(pipelineServer.ListPipelineBuilds),
 		atc.CreatePipelineBuild:       pipelineHandlerFactory.HandlerFor(pipelineServer.CreateBuild),
 		atc.PipelineBadge:             pipelineHandlerFactory.HandlerFor(pipelineServer.PipelineBadge),
+		atc.GetPipelineGraph:          pipelineHandlerFactory.HandlerFor(pipelineServer.GetPipelineGraph),
 
 		atc.ListAllResources:          http.HandlerFunc(resourceServer.ListAllResources),
 		atc.ListSharedForResource:     pipelineHandlerFactory.HandlerFor(resourceServer.ListSharedForResource),
@@ -171,14 +256,19 @@ func NewHandler(
 		atc.GetResource:               pipelineHandlerFactory.HandlerFor(resourceServer.GetResource),
 		atc.UnpinResource:             pipelineHandlerFactory.HandlerFor(resourceServer.UnpinResource),
 		atc.SetPinCommentOnResource:   pipelineHandlerFactory.HandlerFor(resourceServer.SetPinCommentOnResource),
+		atc.ResetResourceCheckFrom:    pipelineHandlerFactory.HandlerFor(resourceServer.ResetResourceCheckFrom),
 		atc.CheckResource:             pipelineHandlerFactory.HandlerFor(resourceServer.CheckResource),
 		atc.CheckResourceWebHook:      pipelineHandlerFactory.HandlerFor(resourceServer.CheckResourceWebHook),
 		atc.CheckResourceType:         pipelineHandlerFactory.HandlerFor(resourceServer.CheckResourceType),
 		atc.CheckPrototype:            pipelineHandlerFactory.HandlerFor(resourceServer.CheckPrototype),
 		atc.ClearResourceCache:        pipelineHandlerFactory.HandlerFor(resourceServer.ClearResourceCache),
 
+		atc.FetchResourceVersionMetadata: pipelineHandlerFactory.HandlerFor(resourceServer.FetchResourceVersionMetadata),
+		atc.BackfillResourceMetadata:     pipelineHandlerFactory.HandlerFor(resourceServer.BackfillResourceMetadata),
+
 		atc.ListResourceVersions:           pipelineHandlerFactory.HandlerFor(versionServer.ListResourceVersions),
 		atc.ClearResourceVersions:          pipelineHandlerFactory.HandlerFor(versionServer.ClearResourceVersions),
+		atc.PushResourceVersions:           pipelineHandlerFactory.HandlerFor(versionServer.PushResourceVersions),
 		atc.ClearResourceTypeVersions:      pipelineHandlerFactory.HandlerFor(versionServer.ClearResourceTypeVersions),
 		atc.GetResourceVersion:             pipelineHandlerFactory.HandlerFor(versionServer.GetResourceVersion),
 		atc.EnableResourceVersion:          pipelineHandlerFactory.HandlerFor(versionServer.EnableResourceVersion),
@@ -188,6 +278,7 @@ func NewHandler(
 		atc.ListBuildsWithVersionAsOutput:  pipelineHandlerFactory.HandlerFor(versionServer.ListBuildsWithVersionAsOutput),
 		atc.GetDownstreamResourceCausality: pipelineHandlerFactory.HandlerFor(versionServer.GetDownstreamResourceCausality),
 		atc.GetUpstreamResourceCausality:   pipelineHandlerFactory.HandlerFor(versionServer.GetUpstreamResourceCausality),
+		atc.GetResourceVersionImpact:       pipelineHandlerFactory.HandlerFor(versionServer.GetResourceVersionImpact),
 
 		atc.ListWorkers:     http.HandlerFunc(workerServer.ListWorkers),
 		atc.RegisterWorker:  http.HandlerFunc(workerServer.RegisterWorker),
@@ -200,22 +291,44 @@ func NewHandler(
 		atc.SetLogLevel: http.HandlerFunc(logLevelServer.SetMinLevel),
 		atc.GetLogLevel: http.HandlerFunc(logLevelServer.GetMinLevel),
 
+		atc.GetRequestLog: http.HandlerFunc(logLevelServer.GetRequestLog),
+		atc.SetRequestLog: http.HandlerFunc(logLevelServer.SetRequestLog),
+
 		atc.DownloadCLI:  http.HandlerFunc(cliServer.Download),
 		atc.GetInfo:      http.HandlerFunc(infoServer.Info),
 		atc.GetInfoCreds: http.HandlerFunc(infoServer.Creds),
+		atc.GetHealth:    http.HandlerFunc(healthServer.GetHealth),
+		atc.GetOpenAPI:   http.HandlerFunc(infoServer.OpenAPI),
 
 		atc.GetUser:              http.HandlerFunc(usersServer.GetUser),
 		atc.ListActiveUsersSince: http.HandlerFunc(usersServer.GetUsersSince),
 
+		atc.GetDashboardPreferences: http.HandlerFunc(usersServer.GetDashboardPreferences),
+		atc.OrderDashboardPipelines: http.HandlerFunc(usersServer.OrderDashboardPipelines),
+		atc.FavoritePipeline:        http.HandlerFunc(usersServer.FavoritePipeline),
+		atc.UnfavoritePipeline:      http.HandlerFunc(usersServer.UnfavoritePipeline),
+
+		atc.GetUserPreferences:   http.HandlerFunc(usersServer.GetUserPreferences),
+		atc.SetUserPreference:    http.HandlerFunc(usersServer.SetUserPreference),
+		atc.DeleteUserPreference: http.HandlerFunc(usersServer.DeleteUserPreference),
+
+		atc.ListSessions:       http.HandlerFunc(usersServer.ListSessions),
+		atc.RevokeSession:      http.HandlerFunc(usersServer.RevokeSession),
+		atc.ListUserSessions:   http.HandlerFunc(usersServer.ListUserSessions),
+		atc.RevokeUserSessions: http.HandlerFunc(usersServer.RevokeUserSessions),
+
 		atc.ListContainers:           teamHandlerFactory.HandlerFor(containerServer.ListContainers),
 		atc.GetContainer:             teamHandlerFactory.HandlerFor(containerServer.GetContainer),
 		atc.HijackContainer:          teamHandlerFactory.HandlerFor(containerServer.HijackContainer),
+		atc.ListContainerProcesses:   teamHandlerFactory.HandlerFor(containerServer.ListContainerProcesses),
+		atc.ListBuildContainers:      buildHandlerFactory.HandlerFor(containerServer.ListBuildContainers),
 		atc.ListDestroyingContainers: http.HandlerFunc(containerServer.ListDestroyingContainers),
 		atc.ReportWorkerContainers:   http.HandlerFunc(containerServer.ReportWorkerContainers),
 
 		atc.ListVolumes:           teamHandlerFactory.HandlerFor(volumesServer.ListVolumes),
 		atc.ListDestroyingVolumes: http.HandlerFunc(volumesServer.ListDestroyingVolumes),
 		atc.ReportWorkerVolumes:   http.HandlerFunc(volumesServer.ReportWorkerVolumes),
+		atc.ListBuildVolumes:      buildHandlerFactory.HandlerFor(volumesServer.ListBuildVolumes),
 
 		atc.ListTeams:      http.HandlerFunc(teamServer.ListTeams),
 		atc.GetTeam:        teamHandlerFactory.HandlerFor(teamServer.GetTeam),
@@ -223,6 +336,77 @@ func NewHandler(
 		atc.RenameTeam:     teamHandlerFactory.HandlerFor(teamServer.RenameTeam),
 		atc.DestroyTeam:    teamHandlerFactory.HandlerFor(teamServer.DestroyTeam),
 		atc.ListTeamBuilds: teamHandlerFactory.HandlerFor(teamServer.ListTeamBuilds),
+		atc.ListTeamUsers:  teamHandlerFactory.HandlerFor(usersServer.ListTeamUsers),
+
+		atc.GetTeamWorkerKeys: teamHandlerFactory.HandlerFor(teamServer.GetWorkerKeys),
+		atc.SetTeamWorkerKeys: teamHandlerFactory.HandlerFor(teamServer.SetWorkerKeys),
+		atc.ListWorkerKeys:    http.HandlerFunc(teamServer.ListWorkerKeys),
+
+		atc.GetTeamInterceptSettings:  teamHandlerFactory.HandlerFor(teamServer.GetInterceptSettings),
+		atc.SetTeamInterceptSettings:  teamHandlerFactory.HandlerFor(teamServer.SetInterceptSettings),
+		atc.GetTeamPrivilegedSettings: teamHandlerFactory.HandlerFor(teamServer.GetPrivilegedSettings),
+		atc.SetTeamPrivilegedSettings: teamHandlerFactory.HandlerFor(teamServer.SetPrivilegedSettings),
+		atc.GetTeamProxySettings:      teamHandlerFactory.HandlerFor(teamServer.GetProxySettings),
+		atc.SetTeamProxySettings:      teamHandlerFactory.HandlerFor(teamServer.SetProxySettings),
+
+		atc.ListNotifiers:   teamHandlerFactory.HandlerFor(teamServer.ListNotifiers),
+		atc.SetNotifier:     teamHandlerFactory.HandlerFor(teamServer.SetNotifier),
+		atc.DestroyNotifier: teamHandlerFactory.HandlerFor(teamServer.DestroyNotifier),
+
+		atc.ListTeamWebhooks:          teamHandlerFactory.HandlerFor(webhookServer.ListTeamWebhooks),
+		atc.SetTeamWebhook:            teamHandlerFactory.HandlerFor(webhookServer.SetTeamWebhook),
+		atc.DestroyTeamWebhook:        teamHandlerFactory.HandlerFor(webhookServer.DestroyTeamWebhook),
+		atc.ListTeamWebhookDeliveries: teamHandlerFactory.HandlerFor(webhookServer.ListTeamWebhookDeliveries),
+
+		atc.ListClusterWebhooks:          http.HandlerFunc(webhookServer.ListClusterWebhooks),
+		atc.SetClusterWebhook:            http.HandlerFunc(webhookServer.SetClusterWebhook),
+		atc.DestroyClusterWebhook:        http.HandlerFunc(webhookServer.DestroyClusterWebhook),
+		atc.ListClusterWebhookDeliveries: http.HandlerFunc(webhookServer.ListClusterWebhookDeliveries),
+
+		atc.ListTeamLogDrains:   teamHandlerFactory.HandlerFor(logDrainServer.ListTeamLogDrains),
+		atc.SetTeamLogDrain:     teamHandlerFactory.HandlerFor(logDrainServer.SetTeamLogDrain),
+		atc.DestroyTeamLogDrain: teamHandlerFactory.HandlerFor(logDrainServer.DestroyTeamLogDrain),
+
+		atc.ListTeamFreezeWindows:   teamHandlerFactory.HandlerFor(freezeWindowServer.ListTeamFreezeWindows),
+		atc.CreateTeamFreezeWindow:  teamHandlerFactory.HandlerFor(freezeWindowServer.CreateTeamFreezeWindow),
+		atc.DestroyTeamFreezeWindow: teamHandlerFactory.HandlerFor(freezeWindowServer.DestroyTeamFreezeWindow),
+
+		atc.ListClusterFreezeWindows:   http.HandlerFunc(freezeWindowServer.ListClusterFreezeWindows),
+		atc.CreateClusterFreezeWindow:  http.HandlerFunc(freezeWindowServer.CreateClusterFreezeWindow),
+		atc.DestroyClusterFreezeWindow: http.HandlerFunc(freezeWindowServer.DestroyClusterFreezeWindow),
+
+		atc.GetTeamInjectedEnv:    teamHandlerFactory.HandlerFor(injectedEnvServer.GetTeamInjectedEnv),
+		atc.SetTeamInjectedEnv:    teamHandlerFactory.HandlerFor(injectedEnvServer.SetTeamInjectedEnv),
+		atc.GetClusterInjectedEnv: http.HandlerFunc(injectedEnvServer.GetClusterInjectedEnv),
+		atc.SetClusterInjectedEnv: http.HandlerFunc(injectedEnvServer.SetClusterInjectedEnv),
+
+		atc.GetTeamCABundle:    teamHandlerFactory.HandlerFor(caBundleServer.GetTeamCABundle),
+		atc.SetTeamCABundle:    teamHandlerFactory.HandlerFor(caBundleServer.SetTeamCABundle),
+		atc.GetClusterCABundle: http.HandlerFunc(caBundleServer.GetClusterCABundle),
+		atc.SetClusterCABundle: http.HandlerFunc(caBundleServer.SetClusterCABundle),
+
+		atc.ListTeamRequests:   http.HandlerFunc(teamRequestServer.ListTeamRequests),
+		atc.CreateTeamRequest:  http.HandlerFunc(teamRequestServer.CreateTeamRequest),
+		atc.ApproveTeamRequest: http.HandlerFunc(teamRequestServer.ApproveTeamRequest),
+		atc.RejectTeamRequest:  http.HandlerFunc(teamRequestServer.RejectTeamRequest),
+
+		atc.GetSchedulerProfile: http.HandlerFunc(schedulerServer.GetSchedulerProfile),
+
+		atc.GetDBSchema:      http.HandlerFunc(dbSchemaServer.GetDBSchema),
+		atc.GetDBMaintenance: http.HandlerFunc(dbMaintenanceServer.GetDBMaintenance),
+		atc.GetDBGrowth:      http.HandlerFunc(dbGrowthServer.GetDBGrowth),
+
+		atc.GetAutoscalingSignal: http.HandlerFunc(autoscalingServer.GetAutoscalingSignal),
+
+		atc.GetDeprecationReport: http.HandlerFunc(deprecationServer.GetDeprecationReport),
+
+		atc.GetClusterOverview: http.HandlerFunc(clusterOverviewServer.GetClusterOverview),
+
+		atc.ListComponents:         http.HandlerFunc(componentServer.ListComponents),
+		atc.SetComponentInterval:   http.HandlerFunc(componentServer.SetComponentInterval),
+		atc.ResetComponentInterval: http.HandlerFunc(componentServer.ResetComponentInterval),
+		atc.PauseComponent:         http.HandlerFunc(componentServer.PauseComponent),
+		atc.UnpauseComponent:       http.HandlerFunc(componentServer.UnpauseComponent),
 
 		atc.CreateArtifact: teamHandlerFactory.HandlerFor(artifactServer.CreateArtifact),
 		atc.GetArtifact:    teamHandlerFactory.HandlerFor(artifactServer.GetArtifact),
@@ -0,0 +1,72 @@
+package api_test
+
+import (
+	"io/ioutil"
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Health API", func() {
+	Describe("GET /api/v1/health", func() {
+		var response *http.Response
+
+		BeforeEach(func() {
+			fakeHealthChecker.CheckReturns(atc.ClusterHealth{
+				Status:    atc.HealthStatusDegraded,
+				CheckedAt: 123,
+				Checks: []atc.HealthCheck{
+					{
+						Name:       "database",
+						Status:     atc.HealthStatusDegraded,
+						DurationMS: 1500,
+						Details:    map[string]int{"latency_ms": 1500},
+					},
+				},
+			})
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/health")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns 200 with the outcome of the checks, without authentication", func() {
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+			Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+
+			body, err := ioutil.ReadAll(response.Body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(body).To(MatchJSON(`{
+				"status": "degraded",
+				"checked_at": 123,
+				"checks": [
+					{
+						"name": "database",
+						"status": "degraded",
+						"duration_ms": 1500,
+						"details": {"latency_ms": 1500}
+					}
+				]
+			}`))
+		})
+
+		Context("when a check is failing", func() {
+			BeforeEach(func() {
+				fakeHealthChecker.CheckReturns(atc.ClusterHealth{
+					Status: atc.HealthStatusFailing,
+					Checks: []atc.HealthCheck{
+						{Name: "workers", Status: atc.HealthStatusFailing, Error: "no running workers"},
+					},
+				})
+			})
+
+			It("returns 503", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusServiceUnavailable))
+			})
+		})
+	})
+})
@@ -0,0 +1,31 @@
+package healthserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc"
+)
+
+// GetHealth checks the dependencies of the cluster. It responds with 503 if
+// any of them are failing, so that load balancers can take the web node out
+// of rotation; a degraded dependency still responds with 200.
+func (s *Server) GetHealth(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("get-health")
+
+	health := s.checker.Check(lagerctx.NewContext(r.Context(), logger))
+
+	status := http.StatusOK
+	if health.Status == atc.HealthStatusFailing {
+		status = http.StatusServiceUnavailable
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	err := json.NewEncoder(w).Encode(health)
+	if err != nil {
+		logger.Error("failed-to-encode-health", err)
+	}
+}
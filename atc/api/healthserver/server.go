@@ -0,0 +1,32 @@
+package healthserver
+
+import (
+	"context"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+
+// Checker checks the dependencies of the cluster.
+//
+//counterfeiter:generate . Checker
+type Checker interface {
+	Check(context.Context) atc.ClusterHealth
+}
+
+type Server struct {
+	logger  lager.Logger
+	checker Checker
+}
+
+func NewServer(
+	logger lager.Logger,
+	checker Checker,
+) *Server {
+	return &Server{
+		logger:  logger,
+		checker: checker,
+	}
+}
@@ -13,10 +13,12 @@ import (
 	"github.com/aws/aws-sdk-go/service/secretsmanager/secretsmanageriface"
 	awsssm "github.com/aws/aws-sdk-go/service/ssm"
 	"github.com/aws/aws-sdk-go/service/ssm/ssmiface"
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/creds/credhub"
 	"github.com/concourse/concourse/atc/creds/secretsmanager"
 	"github.com/concourse/concourse/atc/creds/ssm"
 	"github.com/concourse/concourse/atc/creds/vault"
+	"github.com/concourse/concourse/atc/openapi"
 	. "github.com/concourse/concourse/atc/testhelpers"
 	vaultapi "github.com/hashicorp/vault/api"
 	. "github.com/onsi/ginkgo"
@@ -74,6 +76,63 @@ var _ = Describe("Pipelines API", func() {
 				"cluster_name": "Test Cluster"
 			}`, featureFlagsJson)))
 		})
+
+		Context("when there is a message on the wall", func() {
+			BeforeEach(func() {
+				dbWall.GetWallReturns(atc.Wall{Message: "change freeze until friday"}, nil)
+			})
+
+			It("contains the message as the banner", func() {
+				var info atc.Info
+				err := json.NewDecoder(response.Body).Decode(&info)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(info.Banner).To(Equal("change freeze until friday"))
+			})
+		})
+
+		Context("when getting the wall fails", func() {
+			BeforeEach(func() {
+				dbWall.GetWallReturns(atc.Wall{}, errors.New("nope"))
+			})
+
+			It("still returns the info without a banner", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+				var info atc.Info
+				err := json.NewDecoder(response.Body).Decode(&info)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(info.Version).To(Equal("1.2.3"))
+				Expect(info.Banner).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("GET /api/v1/openapi.json", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/openapi.json")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the OpenAPI document served from the external URL", func() {
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+			Expect(response).Should(IncludeHeaderEntries(map[string]string{
+				"Content-Type": "application/json",
+			}))
+
+			var doc openapi.Document
+			err := json.NewDecoder(response.Body).Decode(&doc)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(doc.Info.Version).To(Equal("1.2.3"))
+			Expect(doc.Servers).To(Equal([]openapi.Server{{URL: "https://example.com"}}))
+			Expect(doc.Paths).To(HaveKey("/api/v1/builds/{build_id}"))
+		})
 	})
 
 	Describe("GET /api/v1/info/creds", func() {
@@ -10,13 +10,24 @@ import (
 func (s *Server) Info(w http.ResponseWriter, r *http.Request) {
 	logger := s.logger.Session("info")
 
-	w.Header().Set("Content-Type", "application/json")
-	err := json.NewEncoder(w).Encode(atc.Info{Version: s.version,
+	info := atc.Info{Version: s.version,
 		WorkerVersion: s.workerVersion,
 		ExternalURL:   s.externalURL,
 		ClusterName:   s.clusterName,
 		FeatureFlags:  atc.FeatureFlags(),
-	})
+	}
+
+	// The banner is informational, so the rest of the info is still
+	// returned if it cannot be determined.
+	wall, err := s.wall.GetWall()
+	if err != nil {
+		logger.Error("failed-to-get-wall", err)
+	} else {
+		info.Banner = wall.Message
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(info)
 	if err != nil {
 		logger.Error("failed-to-encode-info", err)
 		w.WriteHeader(http.StatusInternalServerError)
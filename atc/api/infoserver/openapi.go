@@ -0,0 +1,27 @@
+package infoserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/concourse/concourse/atc/openapi"
+)
+
+// OpenAPI returns the OpenAPI document describing the API, served from the
+// external URL.
+func (s *Server) OpenAPI(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("openapi")
+
+	doc := openapi.NewDocument(s.version)
+	if s.externalURL != "" {
+		doc.Servers = []openapi.Server{{URL: s.externalURL}}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	err := json.NewEncoder(w).Encode(doc)
+	if err != nil {
+		logger.Error("failed-to-encode-openapi-document", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,189 @@
+package api_test
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Injected Env API", func() {
+	var (
+		fakeTeam *dbfakes.FakeTeam
+		response *http.Response
+	)
+
+	BeforeEach(func() {
+		fakeTeam = new(dbfakes.FakeTeam)
+		fakeTeam.IDReturns(3)
+		fakeTeam.NameReturns("a-team")
+	})
+
+	Describe("GET /api/v1/teams/:team_name/injected_env", func() {
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/teams/a-team/injected_env")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+				dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+
+				dbInjectedEnv.InjectedEnvReturns(atc.TaskEnv{"HTTP_PROXY": "http://proxy:3128"}, nil)
+			})
+
+			It("returns the team's injected env", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(dbInjectedEnv.InjectedEnvArgsForCall(0)).To(Equal(3))
+				Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`{"HTTP_PROXY":"http://proxy:3128"}`))
+			})
+
+			Context("when getting the injected env fails", func() {
+				BeforeEach(func() {
+					dbInjectedEnv.InjectedEnvReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/teams/:team_name/injected_env", func() {
+		var body string
+
+		BeforeEach(func() {
+			body = `{"HTTP_PROXY":"http://proxy:3128"}`
+		})
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/a-team/injected_env", bytes.NewBufferString(body))
+			Expect(err).NotTo(HaveOccurred())
+			req.Header.Set("Content-Type", "application/json")
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+				dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+			})
+
+			It("sets the team's injected env", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+
+				teamID, env := dbInjectedEnv.SetInjectedEnvArgsForCall(0)
+				Expect(teamID).To(Equal(3))
+				Expect(env).To(Equal(atc.TaskEnv{"HTTP_PROXY": "http://proxy:3128"}))
+			})
+
+			Context("when a name is not a valid env var name", func() {
+				BeforeEach(func() {
+					body = `{"NO-DASHES":"x"}`
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(dbInjectedEnv.SetInjectedEnvCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the request body is malformed", func() {
+				BeforeEach(func() {
+					body = `{`
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(dbInjectedEnv.SetInjectedEnvCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when setting the injected env fails", func() {
+				BeforeEach(func() {
+					dbInjectedEnv.SetInjectedEnvReturns(errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when unauthorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(false)
+				dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(dbInjectedEnv.SetInjectedEnvCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/injected_env", func() {
+		JustBeforeEach(func() {
+			req, err := http.NewRequest("PUT", server.URL+"/api/v1/injected_env", bytes.NewBufferString(`{"COMPLIANCE_ZONE":"eu"}`))
+			Expect(err).NotTo(HaveOccurred())
+			req.Header.Set("Content-Type", "application/json")
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		BeforeEach(func() {
+			fakeAccess.IsAuthenticatedReturns(true)
+		})
+
+		Context("when admin", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAdminReturns(true)
+			})
+
+			It("sets the cluster's injected env", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+
+				teamID, env := dbInjectedEnv.SetInjectedEnvArgsForCall(0)
+				Expect(teamID).To(BeZero())
+				Expect(env).To(Equal(atc.TaskEnv{"COMPLIANCE_ZONE": "eu"}))
+			})
+		})
+
+		Context("when not admin", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAdminReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(dbInjectedEnv.SetInjectedEnvCallCount()).To(BeZero())
+			})
+		})
+	})
+})
@@ -0,0 +1,79 @@
+package injectedenvserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	. "github.com/concourse/concourse/atc/api/helpers"
+	"github.com/concourse/concourse/atc/db"
+)
+
+// The handlers of the cluster's injected env use a teamID of 0.
+
+func (s *Server) GetTeamInjectedEnv(team db.Team) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.getInjectedEnv(w, r, team.ID())
+	})
+}
+
+func (s *Server) GetClusterInjectedEnv(w http.ResponseWriter, r *http.Request) {
+	s.getInjectedEnv(w, r, 0)
+}
+
+func (s *Server) SetTeamInjectedEnv(team db.Team) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.setInjectedEnv(w, r, team.ID())
+	})
+}
+
+func (s *Server) SetClusterInjectedEnv(w http.ResponseWriter, r *http.Request) {
+	s.setInjectedEnv(w, r, 0)
+}
+
+func (s *Server) getInjectedEnv(w http.ResponseWriter, r *http.Request, teamID int) {
+	logger := s.logger.Session("get-injected-env", lager.Data{"team-id": teamID})
+
+	env, err := s.repository.InjectedEnv(teamID)
+	if err != nil {
+		logger.Error("failed-to-get-injected-env", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(env)
+	if err != nil {
+		logger.Error("failed-to-encode-injected-env", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+func (s *Server) setInjectedEnv(w http.ResponseWriter, r *http.Request, teamID int) {
+	logger := s.logger.Session("set-injected-env", lager.Data{"team-id": teamID})
+
+	var env atc.TaskEnv
+	err := json.NewDecoder(r.Body).Decode(&env)
+	if err != nil {
+		logger.Info("malformed-request", lager.Data{"error": err.Error()})
+		HandleBadRequest(w, fmt.Sprintf("malformed env: %s", err))
+		return
+	}
+
+	err = atc.ValidateInjectedEnv(env)
+	if err != nil {
+		HandleBadRequest(w, fmt.Sprintf("invalid env: %s", err))
+		return
+	}
+
+	err = s.repository.SetInjectedEnv(teamID, env)
+	if err != nil {
+		logger.Error("failed-to-set-injected-env", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,21 @@
+package injectedenvserver
+
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/db"
+)
+
+type Server struct {
+	logger     lager.Logger
+	repository db.InjectedEnvRepository
+}
+
+func NewServer(
+	logger lager.Logger,
+	repository db.InjectedEnvRepository,
+) *Server {
+	return &Server{
+		logger:     logger,
+		repository: repository,
+	}
+}
@@ -266,6 +266,18 @@ var _ = Describe("Jobs API", func() {
 				It("returns 200 OK", func() {
 					Expect(response.StatusCode).To(Equal(http.StatusOK))
 				})
+
+				Context("but the job is not exposed", func() {
+					BeforeEach(func() {
+						fakePipeline.ExposureReturns(atc.PipelineExposure{
+							Jobs: []string{"some-other-job"},
+						})
+					})
+
+					It("returns 404", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+					})
+				})
 			})
 		})
 
@@ -585,13 +597,56 @@ var _ = Describe("Jobs API", func() {
 				Expect(response.StatusCode).To(Equal(http.StatusOK))
 			})
 
-			It("returns Content-Type as image/svg+xml and disables caching", func() {
+			It("returns Content-Type as image/svg+xml and requires revalidation", func() {
 				expectedHeaderEntries := map[string]string{
 					"Content-Type":  "image/svg+xml",
-					"Cache-Control": "no-cache, no-store, must-revalidate",
+					"Cache-Control": "no-cache, max-age=0, must-revalidate",
 					"Expires":       "0",
 				}
 				Expect(response).Should(IncludeHeaderEntries(expectedHeaderEntries))
+				Expect(response.Header.Get("ETag")).ToNot(BeEmpty())
+			})
+
+			Context("when the badge has not changed since it was last fetched", func() {
+				It("returns 304 Not Modified", func() {
+					req, err := http.NewRequest("GET", server.URL+"/api/v1/teams/some-team/pipelines/some-pipeline/jobs/some-job/badge", nil)
+					Expect(err).NotTo(HaveOccurred())
+
+					req.Header.Set("If-None-Match", response.Header.Get("ETag"))
+
+					notModified, err := client.Do(req)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(notModified.StatusCode).To(Equal(http.StatusNotModified))
+				})
+			})
+
+			Context("when requesting json", func() {
+				It("returns the badge for shields.io", func() {
+					response, err := client.Get(server.URL + "/api/v1/teams/some-team/pipelines/some-pipeline/jobs/some-job/badge?format=json&title=cov")
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`{
+						"schemaVersion": 1,
+						"label": "cov",
+						"message": "unknown",
+						"color": "9f9f9f"
+					}`))
+				})
+			})
+
+			Context("when requesting an unknown format", func() {
+				It("returns 400", func() {
+					response, err := client.Get(server.URL + "/api/v1/teams/some-team/pipelines/some-pipeline/jobs/some-job/badge?format=png")
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
 			})
 
 			Context("when generates bagde title", func() {
@@ -630,6 +685,25 @@ var _ = Describe("Jobs API", func() {
       <text x="18.5" y="15" fill="#010101" fill-opacity=".3">build</text>
       <text x="18.5" y="14">build</text>
 `)).Should(BeTrue())
+				})
+				It("widens the badge to fit a long title", func() {
+					response, err := client.Get(server.URL + "/api/v1/teams/some-team/pipelines/some-pipeline/jobs/some-job/badge?title=integration")
+					Expect(err).NotTo(HaveOccurred())
+
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(string(body)).To(ContainSubstring(`<svg xmlns="http://www.w3.org/2000/svg" width="133" height="20">`))
+					Expect(string(body)).To(ContainSubstring(`
+      <path fill="#555" d="M0 0h72v20H0z" />
+      <path fill="#9f9f9f" d="M72 0h61v20H72z" />
+`))
+					Expect(string(body)).To(ContainSubstring(`
+      <text x="36.0" y="14">integration</text>
+`))
+					Expect(string(body)).To(ContainSubstring(`
+      <text x="101.5" y="14">unknown</text>
+`))
 				})
 				It("html escapes title", func() {
 					response, err := client.Get(server.URL + "/api/v1/teams/some-team/pipelines/some-pipeline/jobs/some-job/badge?title=%24cov")
@@ -1062,6 +1136,24 @@ var _ = Describe("Jobs API", func() {
 						It("returns 200 OK", func() {
 							Expect(response.StatusCode).To(Equal(http.StatusOK))
 						})
+
+						Context("and only some of its jobs are exposed", func() {
+							BeforeEach(func() {
+								fakePipeline.ExposureReturns(atc.PipelineExposure{
+									Jobs: []string{"job-1", "job-3"},
+								})
+							})
+
+							It("returns only the exposed jobs", func() {
+								var jobs []atc.JobSummary
+								err := json.NewDecoder(response.Body).Decode(&jobs)
+								Expect(err).NotTo(HaveOccurred())
+
+								Expect(jobs).To(HaveLen(2))
+								Expect(jobs[0].Name).To(Equal("job-1"))
+								Expect(jobs[1].Name).To(Equal("job-3"))
+							})
+						})
 					})
 				})
 			})
@@ -1535,6 +1627,206 @@ var _ = Describe("Jobs API", func() {
 						})
 					})
 
+					Context("when inputs are pinned", func() {
+						BeforeEach(func() {
+							var err error
+							request, err = http.NewRequest("POST", server.URL+"/api/v1/teams/some-team/pipelines/some-pipeline/jobs/some-job/builds", strings.NewReader(`{
+								"inputs": [
+									{"name": "some-input", "version": {"ref": "abc"}},
+									{"name": "other-input", "version_id": 7, "override_passed": true}
+								]
+							}`))
+							Expect(err).NotTo(HaveOccurred())
+
+							fakeJob.CreateBuildWithOverridesReturns(new(dbfakes.FakeBuild), nil)
+						})
+
+						It("triggers the build with the pinned inputs", func() {
+							Expect(fakeJob.CreateBuildCallCount()).To(Equal(0))
+							Expect(fakeJob.CreateBuildWithOverridesCallCount()).To(Equal(1))
+
+							_, pins, vars, _ := fakeJob.CreateBuildWithOverridesArgsForCall(0)
+							Expect(pins).To(Equal([]atc.BuildInputPin{
+								{Name: "some-input", Version: atc.Version{"ref": "abc"}},
+								{Name: "other-input", VersionID: 7, OverridePassed: true},
+							}))
+							Expect(vars).To(BeEmpty())
+						})
+
+						It("returns 200 OK", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusOK))
+						})
+
+						Context("when a pin is invalid", func() {
+							BeforeEach(func() {
+								fakeJob.CreateBuildWithOverridesReturns(nil, db.BuildInputPinError{
+									InputName: "some-input",
+									Reason:    "version has not passed some-other-job",
+								})
+							})
+
+							It("returns 400 with the reason", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+
+								body, err := ioutil.ReadAll(response.Body)
+								Expect(err).NotTo(HaveOccurred())
+								Expect(string(body)).To(ContainSubstring("cannot pin input 'some-input': version has not passed some-other-job"))
+							})
+						})
+
+						Context("when triggering the build fails", func() {
+							BeforeEach(func() {
+								fakeJob.CreateBuildWithOverridesReturns(nil, errors.New("nopers"))
+							})
+
+							It("returns a 500", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+							})
+						})
+					})
+
+					Context("when vars are set", func() {
+						BeforeEach(func() {
+							var err error
+							request, err = http.NewRequest("POST", server.URL+"/api/v1/teams/some-team/pipelines/some-pipeline/jobs/some-job/builds", strings.NewReader(`{
+								"vars": [
+									{"name": "deploy_target", "value": "staging2"},
+									{"name": "api_token", "value": "shh", "secret": true}
+								]
+							}`))
+							Expect(err).NotTo(HaveOccurred())
+
+							build := new(dbfakes.FakeBuild)
+							build.IDReturns(42)
+							build.NameReturns("1")
+							build.JobNameReturns("some-job")
+							build.PipelineNameReturns("a-pipeline")
+							build.TeamNameReturns("some-team")
+							build.StatusReturns(db.BuildStatusPending)
+							build.VarsReturns([]atc.BuildVar{
+								{Name: "deploy_target", Value: "staging2"},
+								{Name: "api_token", Value: "shh", Secret: true},
+							})
+
+							fakeJob.CreateBuildWithOverridesReturns(build, nil)
+						})
+
+						It("triggers the build with the vars", func() {
+							Expect(fakeJob.CreateBuildCallCount()).To(Equal(0))
+							Expect(fakeJob.CreateBuildWithOverridesCallCount()).To(Equal(1))
+
+							_, pins, vars, _ := fakeJob.CreateBuildWithOverridesArgsForCall(0)
+							Expect(pins).To(BeEmpty())
+							Expect(vars).To(Equal([]atc.BuildVar{
+								{Name: "deploy_target", Value: "staging2"},
+								{Name: "api_token", Value: "shh", Secret: true},
+							}))
+						})
+
+						It("returns the build with the secret vars redacted", func() {
+							body, err := ioutil.ReadAll(response.Body)
+							Expect(err).NotTo(HaveOccurred())
+
+							Expect(body).To(MatchJSON(`{
+								"id": 42,
+								"name": "1",
+								"job_name": "some-job",
+								"status": "pending",
+								"api_url": "/api/v1/builds/42",
+								"pipeline_name": "a-pipeline",
+								"team_name": "some-team",
+								"vars": [
+									{"name": "deploy_target", "value": "staging2"},
+									{"name": "api_token", "secret": true}
+								]
+							}`))
+						})
+
+						Context("when a var is set more than once", func() {
+							BeforeEach(func() {
+								var err error
+								request, err = http.NewRequest("POST", server.URL+"/api/v1/teams/some-team/pipelines/some-pipeline/jobs/some-job/builds", strings.NewReader(`{
+									"vars": [
+										{"name": "deploy_target", "value": "staging2"},
+										{"name": "deploy_target", "value": "staging3"}
+									]
+								}`))
+								Expect(err).NotTo(HaveOccurred())
+							})
+
+							It("returns a 400", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+							})
+
+							It("does not trigger the build", func() {
+								Expect(fakeJob.CreateBuildWithOverridesCallCount()).To(Equal(0))
+							})
+						})
+					})
+
+					Context("when an external ref is given", func() {
+						BeforeEach(func() {
+							var err error
+							request, err = http.NewRequest("POST", server.URL+"/api/v1/teams/some-team/pipelines/some-pipeline/jobs/some-job/builds", strings.NewReader(`{
+								"external_ref": "pr-42@abc123"
+							}`))
+							Expect(err).NotTo(HaveOccurred())
+
+							build := new(dbfakes.FakeBuild)
+							build.IDReturns(42)
+							build.NameReturns("1")
+							build.JobNameReturns("some-job")
+							build.PipelineNameReturns("a-pipeline")
+							build.TeamNameReturns("some-team")
+							build.StatusReturns(db.BuildStatusPending)
+							build.ExternalRefReturns("pr-42@abc123")
+
+							fakeJob.CreateBuildWithOverridesReturns(build, nil)
+						})
+
+						It("triggers the build with the ref", func() {
+							Expect(fakeJob.CreateBuildCallCount()).To(Equal(0))
+							Expect(fakeJob.CreateBuildWithOverridesCallCount()).To(Equal(1))
+
+							_, pins, vars, ref := fakeJob.CreateBuildWithOverridesArgsForCall(0)
+							Expect(pins).To(BeEmpty())
+							Expect(vars).To(BeEmpty())
+							Expect(ref).To(Equal("pr-42@abc123"))
+						})
+
+						It("returns the build with its ref", func() {
+							body, err := ioutil.ReadAll(response.Body)
+							Expect(err).NotTo(HaveOccurred())
+
+							Expect(body).To(MatchJSON(`{
+								"id": 42,
+								"name": "1",
+								"job_name": "some-job",
+								"status": "pending",
+								"api_url": "/api/v1/builds/42",
+								"pipeline_name": "a-pipeline",
+								"team_name": "some-team",
+								"external_ref": "pr-42@abc123"
+							}`))
+						})
+					})
+
+					Context("when the request body is malformed", func() {
+						BeforeEach(func() {
+							var err error
+							request, err = http.NewRequest("POST", server.URL+"/api/v1/teams/some-team/pipelines/some-pipeline/jobs/some-job/builds", strings.NewReader(`{"inputs":`))
+							Expect(err).NotTo(HaveOccurred())
+						})
+
+						It("returns a 400", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+						})
+
+						It("does not trigger the build", func() {
+							Expect(fakeJob.CreateBuildCallCount()).To(Equal(0))
+						})
+					})
+
 					Context("when triggering the build succeeds", func() {
 						BeforeEach(func() {
 							build := new(dbfakes.FakeBuild)
@@ -2608,6 +2900,375 @@ var _ = Describe("Jobs API", func() {
 			})
 		})
 	})
+
+	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/statistics", func() {
+		var (
+			query    string
+			response *http.Response
+		)
+
+		BeforeEach(func() {
+			query = ""
+
+			fakeJob.IDReturns(7)
+			fakeJob.NameReturns("some-job")
+			fakePipeline.JobReturns(fakeJob, true, nil)
+
+			dbBuildStats.BuildStatsReturns([]db.BuildStatsBucket{
+				{
+					JobID:      7,
+					Start:      time.Now().Add(-time.Hour).Truncate(time.Hour),
+					Succeeded:  1,
+					Failed:     1,
+					Durations:  []int64{10, 20},
+					QueueTimes: []int64{1, 3},
+				},
+			}, nil)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/pipelines/some-pipeline/jobs/some-job/statistics" + query)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+			})
+
+			It("returns the job's statistics", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+				var statistics atc.JobStatistics
+				err := json.NewDecoder(response.Body).Decode(&statistics)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(statistics.JobName).To(Equal("some-job"))
+				Expect(statistics.Builds).To(Equal(2))
+				Expect(statistics.SuccessRate).To(Equal(0.5))
+				Expect(statistics.Duration.P50).To(Equal(15.0))
+				Expect(statistics.QueueTime.P50).To(Equal(2.0))
+				Expect(statistics.Trend).To(HaveLen(1))
+				Expect(statistics.DurationObjective).To(BeNil())
+			})
+
+			It("looks at the builds of the last week", func() {
+				jobIDs, since := dbBuildStats.BuildStatsArgsForCall(0)
+				Expect(jobIDs).To(Equal([]int{7}))
+				Expect(since).To(BeTemporally("~", time.Now().Add(-7*24*time.Hour), time.Minute))
+			})
+
+			Context("when a window and max duration are given", func() {
+				BeforeEach(func() {
+					query = "?window=30d&max_duration=15s"
+				})
+
+				It("uses them", func() {
+					_, since := dbBuildStats.BuildStatsArgsForCall(0)
+					Expect(since).To(BeTemporally("~", time.Now().Add(-30*24*time.Hour), time.Minute))
+
+					var statistics atc.JobStatistics
+					err := json.NewDecoder(response.Body).Decode(&statistics)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(statistics.DurationObjective).To(Equal(&atc.DurationObjective{
+						MaxDuration: 15,
+						Attained:    0.5,
+					}))
+				})
+			})
+
+			Context("when the interval is shorter than an hour", func() {
+				BeforeEach(func() {
+					query = "?interval=10m"
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(dbBuildStats.BuildStatsCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the window is invalid", func() {
+				BeforeEach(func() {
+					query = "?window=forever"
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+			})
+
+			Context("when the job does not exist", func() {
+				BeforeEach(func() {
+					fakePipeline.JobReturns(nil, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when getting the statistics fails", func() {
+				BeforeEach(func() {
+					dbBuildStats.BuildStatsReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authenticated and the pipeline is public", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+				fakePipeline.PublicReturns(true)
+			})
+
+			It("returns 200 OK", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+			})
+
+			Context("but the job is not exposed", func() {
+				BeforeEach(func() {
+					fakePipeline.ExposureReturns(atc.PipelineExposure{
+						Jobs: []string{"some-other-job"},
+					})
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+					Expect(dbBuildStats.BuildStatsCallCount()).To(BeZero())
+				})
+			})
+		})
+
+		Context("when not authenticated and the pipeline is private", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+				fakePipeline.PublicReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/statistics", func() {
+		var response *http.Response
+
+		BeforeEach(func() {
+			fakeAccess.IsAuthenticatedReturns(true)
+			fakeAccess.IsAuthorizedReturns(true)
+
+			job1 := new(dbfakes.FakeJob)
+			job1.IDReturns(1)
+			job1.NameReturns("job-1")
+
+			job2 := new(dbfakes.FakeJob)
+			job2.IDReturns(2)
+			job2.NameReturns("job-2")
+
+			fakePipeline.JobsReturns(db.Jobs{job1, job2}, nil)
+
+			dbBuildStats.BuildStatsReturns([]db.BuildStatsBucket{
+				{JobID: 1, Succeeded: 2},
+				{JobID: 2, Failed: 1},
+			}, nil)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/pipelines/some-pipeline/statistics")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the statistics of every job without trends", func() {
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+			jobIDs, _ := dbBuildStats.BuildStatsArgsForCall(0)
+			Expect(jobIDs).To(Equal([]int{1, 2}))
+
+			var statistics []atc.JobStatistics
+			err := json.NewDecoder(response.Body).Decode(&statistics)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(statistics).To(HaveLen(2))
+			Expect(statistics[0].JobName).To(Equal("job-1"))
+			Expect(statistics[0].Succeeded).To(Equal(2))
+			Expect(statistics[0].SuccessRate).To(Equal(1.0))
+			Expect(statistics[0].Trend).To(BeEmpty())
+			Expect(statistics[1].JobName).To(Equal("job-2"))
+			Expect(statistics[1].Failed).To(Equal(1))
+		})
+	})
+
+	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/flakiness", func() {
+		var response *http.Response
+
+		BeforeEach(func() {
+			fakePipeline.IDReturns(4)
+
+			dbFlakiness.PipelineFlakinessReturns([]atc.JobFlakiness{
+				{
+					JobName:      "job-1",
+					PipelineName: "some-pipeline",
+					TeamName:     "some-team",
+					Builds:       10,
+					Repeats:      4,
+					Flips:        2,
+					Score:        0.5,
+					Flaky:        true,
+					AnalyzedAt:   100,
+				},
+				{
+					JobName:      "job-2",
+					PipelineName: "some-pipeline",
+					TeamName:     "some-team",
+					Builds:       3,
+					AnalyzedAt:   100,
+				},
+			}, nil)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/pipelines/some-pipeline/flakiness")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+			})
+
+			It("returns the flakiness of the pipeline's jobs", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(dbFlakiness.PipelineFlakinessArgsForCall(0)).To(Equal(4))
+
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(body).To(MatchJSON(`[
+					{
+						"job_name": "job-1",
+						"pipeline_name": "some-pipeline",
+						"team_name": "some-team",
+						"builds": 10,
+						"repeats": 4,
+						"flips": 2,
+						"score": 0.5,
+						"flaky": true,
+						"analyzed_at": 100
+					},
+					{
+						"job_name": "job-2",
+						"pipeline_name": "some-pipeline",
+						"team_name": "some-team",
+						"builds": 3,
+						"repeats": 0,
+						"flips": 0,
+						"score": 0,
+						"flaky": false,
+						"analyzed_at": 100
+					}
+				]`))
+			})
+
+			Context("when getting the flakiness fails", func() {
+				BeforeEach(func() {
+					dbFlakiness.PipelineFlakinessReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authenticated and the pipeline only exposes some jobs", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+				fakePipeline.PublicReturns(true)
+				fakePipeline.ExposureReturns(atc.PipelineExposure{
+					Jobs: []string{"job-2"},
+				})
+			})
+
+			It("only returns the exposed jobs", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+				var flakiness []atc.JobFlakiness
+				err := json.NewDecoder(response.Body).Decode(&flakiness)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(flakiness).To(HaveLen(1))
+				Expect(flakiness[0].JobName).To(Equal("job-2"))
+			})
+		})
+	})
+
+	Describe("GET /api/v1/teams/:team_name/flaky_jobs", func() {
+		var response *http.Response
+
+		BeforeEach(func() {
+			dbTeam.IDReturns(5)
+
+			dbFlakiness.FlakyJobsReturns([]atc.JobFlakiness{
+				{
+					JobName:      "job-1",
+					PipelineName: "some-pipeline",
+					TeamName:     "some-team",
+					Flips:        3,
+					Score:        0.75,
+					Flaky:        true,
+				},
+			}, nil)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/flaky_jobs")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+			})
+
+			It("returns the team's flaky jobs", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(dbFlakiness.FlakyJobsArgsForCall(0)).To(Equal(5))
+
+				var flakyJobs []atc.JobFlakiness
+				err := json.NewDecoder(response.Body).Decode(&flakyJobs)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(flakyJobs).To(HaveLen(1))
+				Expect(flakyJobs[0].JobName).To(Equal("job-1"))
+				Expect(flakyJobs[0].Score).To(Equal(0.75))
+			})
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(dbFlakiness.FlakyJobsCallCount()).To(BeZero())
+			})
+		})
+	})
 })
 
 func fakeDBResourceType(t atc.ResourceType) *dbfakes.FakeResourceType {
@@ -2,10 +2,14 @@ package jobserver
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
 	"text/template"
+	"unicode/utf8"
 
 	"github.com/concourse/concourse/atc/db"
 )
@@ -18,6 +22,11 @@ var (
 	badgeErrored = Badge{Width: 88, FillColor: `#fe7d37`, Status: `errored`, Title: `build`}
 )
 
+// Badge is rendered either as an SVG image or as the JSON understood by
+// shields.io's endpoint badges.
+//
+// Width is the width of the badge with the default title, which is widened
+// to fit longer titles.
 type Badge struct {
 	Width     int
 	FillColor string
@@ -25,12 +34,41 @@ type Badge struct {
 	Title     string
 }
 
+const defaultTitleWidth = 37
+
+// ShieldsBadge is the schema of shields.io's endpoint badges.
+type ShieldsBadge struct {
+	SchemaVersion int    `json:"schemaVersion"`
+	Label         string `json:"label"`
+	Message       string `json:"message"`
+	Color         string `json:"color"`
+}
+
+// TitleWidth approximates the width of the title in the badge's font, so
+// that custom titles are not cut off.
+func (b *Badge) TitleWidth() int {
+	width := utf8.RuneCountInString(b.Title)*6 + 6
+	if width < defaultTitleWidth {
+		return defaultTitleWidth
+	}
+
+	return width
+}
+
+func (b *Badge) TotalWidth() int {
+	return b.TitleWidth() + b.StatusWidth()
+}
+
 func (b *Badge) StatusWidth() int {
-	return b.Width - 37
+	return b.Width - defaultTitleWidth
+}
+
+func (b *Badge) TitleTextWidth() string {
+	return fmt.Sprintf("%.1f", float64(b.TitleWidth())/2)
 }
 
 func (b *Badge) StatusTextWidth() string {
-	return fmt.Sprintf("%.1f", float64(b.Width)/2+17.5)
+	return fmt.Sprintf("%.1f", float64(b.TitleWidth())+float64(b.StatusWidth())/2-1)
 }
 
 func (b *Badge) String() string {
@@ -46,12 +84,70 @@ func (b *Badge) String() string {
 	return buffer.String()
 }
 
+func (b *Badge) Shields() ShieldsBadge {
+	return ShieldsBadge{
+		SchemaVersion: 1,
+		Label:         b.Title,
+		Message:       b.Status,
+		Color:         strings.TrimPrefix(b.FillColor, "#"),
+	}
+}
+
 func (b *Badge) EnrichFromQuery(params url.Values) {
 	if title := params.Get("title"); title != "" {
 		b.Title = title
 	}
 }
 
+// ServeBadge writes the badge as an SVG image, or as JSON for shields.io
+// when requested with '?format=json'.
+//
+// Badges are served with an ETag and must be revalidated on every request,
+// so that image proxies such as GitHub's do not show a stale status while
+// still being able to avoid downloading an unchanged badge.
+func ServeBadge(w http.ResponseWriter, r *http.Request, badge Badge) {
+	badge.EnrichFromQuery(r.URL.Query())
+
+	var (
+		contentType string
+		body        []byte
+	)
+
+	switch format := r.URL.Query().Get("format"); format {
+	case "", "svg":
+		contentType = "image/svg+xml"
+		body = []byte(badge.String())
+	case "json":
+		var err error
+		body, err = json.Marshal(badge.Shields())
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		contentType = "application/json"
+	default:
+		http.Error(w, fmt.Sprintf("unknown format '%s' (must be svg or json)", format), http.StatusBadRequest)
+		return
+	}
+
+	etag := fmt.Sprintf(`"%x"`, sha256.Sum256(body))
+
+	w.Header().Set("Cache-Control", "no-cache, max-age=0, must-revalidate")
+	w.Header().Set("Expires", "0")
+	w.Header().Set("ETag", etag)
+
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(http.StatusOK)
+
+	_, _ = w.Write(body)
+}
+
 func BadgeForBuild(build db.Build) Badge {
 	switch {
 	case build == nil:
@@ -70,22 +166,22 @@ func BadgeForBuild(build db.Build) Badge {
 }
 
 const badgeTemplate = `<?xml version="1.0" encoding="UTF-8"?>
-<svg xmlns="http://www.w3.org/2000/svg" width="{{ .Width }}" height="20">
+<svg xmlns="http://www.w3.org/2000/svg" width="{{ .TotalWidth }}" height="20">
    <linearGradient id="b" x2="0" y2="100%">
       <stop offset="0" stop-color="#bbb" stop-opacity=".1" />
       <stop offset="1" stop-opacity=".1" />
    </linearGradient>
    <mask id="a">
-      <rect width="{{ .Width }}" height="20" rx="3" fill="#fff" />
+      <rect width="{{ .TotalWidth }}" height="20" rx="3" fill="#fff" />
    </mask>
    <g mask="url(#a)">
-      <path fill="#555" d="M0 0h37v20H0z" />
-      <path fill="{{ .FillColor }}" d="M37 0h{{ .StatusWidth }}v20H37z" />
-      <path fill="url(#b)" d="M0 0h{{ .Width }}v20H0z" />
+      <path fill="#555" d="M0 0h{{ .TitleWidth }}v20H0z" />
+      <path fill="{{ .FillColor }}" d="M{{ .TitleWidth }} 0h{{ .StatusWidth }}v20H{{ .TitleWidth }}z" />
+      <path fill="url(#b)" d="M0 0h{{ .TotalWidth }}v20H0z" />
    </g>
    <g fill="#fff" text-anchor="middle" font-family="DejaVu Sans,Verdana,Geneva,sans-serif" font-size="11">
-      <text x="18.5" y="15" fill="#010101" fill-opacity=".3">{{ .Title }}</text>
-      <text x="18.5" y="14">{{ .Title }}</text>
+      <text x="{{ .TitleTextWidth }}" y="15" fill="#010101" fill-opacity=".3">{{ .Title }}</text>
+      <text x="{{ .TitleTextWidth }}" y="14">{{ .Title }}</text>
       <text x="{{ .StatusTextWidth }}" y="15" fill="#010101" fill-opacity=".3">{{ .Status }}</text>
       <text x="{{ .StatusTextWidth }}" y="14">{{ .Status }}</text>
    </g>
@@ -103,7 +199,7 @@ func (s *Server) JobBadge(pipeline db.Pipeline) http.Handler {
 			return
 		}
 
-		if !found {
+		if !found || jobHidden(r, pipeline, jobName) {
 			w.WriteHeader(http.StatusNotFound)
 			return
 		}
@@ -115,15 +211,6 @@ func (s *Server) JobBadge(pipeline db.Pipeline) http.Handler {
 			return
 		}
 
-		w.Header().Set("Content-type", "image/svg+xml")
-
-		w.Header().Set("Cache-Control", "no-cache, no-store, must-revalidate")
-		w.Header().Set("Expires", "0")
-
-		w.WriteHeader(http.StatusOK)
-
-		badge := BadgeForBuild(build)
-		badge.EnrichFromQuery(r.URL.Query())
-		fmt.Fprint(w, &badge)
+		ServeBadge(w, r, BadgeForBuild(build))
 	})
 }
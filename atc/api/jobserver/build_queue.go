@@ -0,0 +1,56 @@
+package jobserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/concourse/concourse/atc/buildstats"
+	"github.com/concourse/concourse/atc/db"
+)
+
+// ListTeamBuildQueue returns the builds of the team's jobs which have yet to
+// finish, oldest first, with when they are expected to start and finish
+// going by the builds of their jobs over the last week.
+func (s *Server) ListTeamBuildQueue(team db.Team) http.Handler {
+	logger := s.logger.Session("list-team-build-queue")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		builds, err := s.buildQueue.TeamBuildQueue(team.ID())
+		if err != nil {
+			logger.Error("failed-to-get-build-queue", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		var jobIDs []int
+		seen := map[int]bool{}
+		for _, build := range builds {
+			if !seen[build.JobID] {
+				seen[build.JobID] = true
+				jobIDs = append(jobIDs, build.JobID)
+			}
+		}
+
+		now := time.Now()
+
+		var buckets []db.BuildStatsBucket
+		if len(jobIDs) > 0 {
+			buckets, err = s.buildStats.BuildStats(jobIDs, now.Add(-defaultStatisticsWindow))
+			if err != nil {
+				logger.Error("failed-to-get-build-stats", err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		err = json.NewEncoder(w).Encode(buildstats.EstimateQueue(now, builds, buckets))
+		if err != nil {
+			logger.Error("failed-to-encode-build-queue", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
@@ -3,10 +3,15 @@ package jobserver
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
 
+	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagerctx"
 
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/api/accessor"
 	"github.com/concourse/concourse/atc/api/present"
 	"github.com/concourse/concourse/atc/db"
@@ -36,9 +41,38 @@ func (s *Server) CreateJobBuild(pipeline db.Pipeline) http.Handler {
 			return
 		}
 
+		// the body is optional, pinning inputs for this one build
+		var reqBody atc.CreateJobBuildRequestBody
+		err = json.NewDecoder(r.Body).Decode(&reqBody)
+		if err != nil && err != io.EOF {
+			logger.Info("malformed-request", lager.Data{"error": err.Error()})
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		err = validateBuildVars(reqBody.Vars)
+		if err != nil {
+			logger.Info("invalid-vars", lager.Data{"error": err.Error()})
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
 		acc := accessor.GetAccessor(r)
-		build, err := job.CreateBuild(acc.UserInfo().DisplayUserId)
+
+		var build db.Build
+		if len(reqBody.Inputs) == 0 && len(reqBody.Vars) == 0 && reqBody.ExternalRef == "" {
+			build, err = job.CreateBuild(acc.UserInfo().DisplayUserId)
+		} else {
+			build, err = job.CreateBuildWithOverrides(acc.UserInfo().DisplayUserId, reqBody.Inputs, reqBody.Vars, reqBody.ExternalRef)
+		}
 		if err != nil {
+			var pinErr db.BuildInputPinError
+			if errors.As(err, &pinErr) {
+				logger.Info("invalid-input-pin", lager.Data{"error": err.Error()})
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+
 			logger.Error("failed-to-create-job-build", err)
 			w.WriteHeader(http.StatusInternalServerError)
 			return
@@ -84,10 +118,27 @@ func (s *Server) CreateJobBuild(pipeline db.Pipeline) http.Handler {
 			}
 		}
 
-		err = json.NewEncoder(w).Encode(present.Build(build, nil, nil))
+		err = json.NewEncoder(w).Encode(present.Build(build, job, acc))
 		if err != nil {
 			logger.Error("failed-to-encode-build", err)
 			w.WriteHeader(http.StatusInternalServerError)
 		}
 	})
 }
+
+func validateBuildVars(vars []atc.BuildVar) error {
+	seen := map[string]bool{}
+	for _, v := range vars {
+		if v.Name == "" {
+			return errors.New("var has no name")
+		}
+
+		if seen[v.Name] {
+			return fmt.Errorf("var '%s' is set more than once", v.Name)
+		}
+
+		seen[v.Name] = true
+	}
+
+	return nil
+}
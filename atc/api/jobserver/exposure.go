@@ -0,0 +1,19 @@
+package jobserver
+
+import (
+	"net/http"
+
+	"github.com/concourse/concourse/atc/api/accessor"
+	"github.com/concourse/concourse/atc/db"
+)
+
+// jobHidden reports whether the job is left out of the pipeline's public
+// exposure for a user who is not a member of the pipeline's team.
+func jobHidden(r *http.Request, pipeline db.Pipeline, jobName string) bool {
+	if accessor.GetAccessor(r).IsAuthorized(pipeline.TeamName()) {
+		return false
+	}
+
+	exposure := pipeline.Exposure()
+	return !exposure.JobExposed(jobName)
+}
@@ -0,0 +1,62 @@
+package jobserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+// ListJobFlakiness returns the flakiness of each of the pipeline's jobs
+// which has been analyzed.
+func (s *Server) ListJobFlakiness(pipeline db.Pipeline) http.Handler {
+	logger := s.logger.Session("list-job-flakiness")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		flakiness, err := s.flakiness.PipelineFlakiness(pipeline.ID())
+		if err != nil {
+			logger.Error("failed-to-get-flakiness", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		exposed := []atc.JobFlakiness{}
+		for _, job := range flakiness {
+			if !jobHidden(r, pipeline, job.JobName) {
+				exposed = append(exposed, job)
+			}
+		}
+
+		writeFlakiness(logger, w, exposed)
+	})
+}
+
+// ListFlakyJobs returns the jobs of the team which are flagged as flaky, the
+// flakiest first.
+func (s *Server) ListFlakyJobs(team db.Team) http.Handler {
+	logger := s.logger.Session("list-flaky-jobs")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		flakyJobs, err := s.flakiness.FlakyJobs(team.ID())
+		if err != nil {
+			logger.Error("failed-to-get-flaky-jobs", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		writeFlakiness(logger, w, flakyJobs)
+	})
+}
+
+func writeFlakiness(logger lager.Logger, w http.ResponseWriter, flakiness []atc.JobFlakiness) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	err := json.NewEncoder(w).Encode(flakiness)
+	if err != nil {
+		logger.Error("failed-to-encode-flakiness", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
@@ -21,7 +21,7 @@ func (s *Server) GetJob(pipeline db.Pipeline) http.Handler {
 			return
 		}
 
-		if !found {
+		if !found || jobHidden(r, pipeline, jobName) {
 			w.WriteHeader(http.StatusNotFound)
 			return
 		}
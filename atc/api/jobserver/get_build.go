@@ -22,7 +22,7 @@ func (s *Server) GetJobBuild(pipeline db.Pipeline) http.Handler {
 			return
 		}
 
-		if !found {
+		if !found || jobHidden(r, pipeline, jobName) {
 			w.WriteHeader(http.StatusNotFound)
 			return
 		}
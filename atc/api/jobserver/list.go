@@ -19,14 +19,17 @@ func (s *Server) ListJobs(pipeline db.Pipeline) http.Handler {
 			return
 		}
 
-		if jobs == nil {
-			jobs = []atc.JobSummary{}
+		exposedJobs := []atc.JobSummary{}
+		for _, job := range jobs {
+			if !jobHidden(r, pipeline, job.Name) {
+				exposedJobs = append(exposedJobs, job)
+			}
 		}
 
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
 
-		err = json.NewEncoder(w).Encode(jobs)
+		err = json.NewEncoder(w).Encode(exposedJobs)
 		if err != nil {
 			logger.Error("failed-to-encode-jobs", err)
 			w.WriteHeader(http.StatusInternalServerError)
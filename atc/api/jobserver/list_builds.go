@@ -56,7 +56,7 @@ func (s *Server) ListJobBuilds(pipeline db.Pipeline) http.Handler {
 			return
 		}
 
-		if !found {
+		if !found || jobHidden(r, pipeline, jobName) {
 			w.WriteHeader(http.StatusNotFound)
 			return
 		}
@@ -15,6 +15,9 @@ type Server struct {
 	secretManager creds.Secrets
 	jobFactory    db.JobFactory
 	checkFactory  db.CheckFactory
+	buildStats    db.BuildStatsRepository
+	flakiness     db.FlakinessRepository
+	buildQueue    db.BuildQueueRepository
 }
 
 func NewServer(
@@ -23,6 +26,9 @@ func NewServer(
 	secretManager creds.Secrets,
 	jobFactory db.JobFactory,
 	checkFactory db.CheckFactory,
+	buildStats db.BuildStatsRepository,
+	flakiness db.FlakinessRepository,
+	buildQueue db.BuildQueueRepository,
 ) *Server {
 	return &Server{
 		logger:        logger,
@@ -31,5 +37,8 @@ func NewServer(
 		secretManager: secretManager,
 		jobFactory:    jobFactory,
 		checkFactory:  checkFactory,
+		buildStats:    buildStats,
+		flakiness:     flakiness,
+		buildQueue:    buildQueue,
 	}
 }
@@ -0,0 +1,196 @@
+package jobserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	. "github.com/concourse/concourse/atc/api/helpers"
+	"github.com/concourse/concourse/atc/buildstats"
+	"github.com/concourse/concourse/atc/db"
+)
+
+const defaultStatisticsWindow = 7 * 24 * time.Hour
+
+// The statistics handlers take the following query parameters, as durations
+// which may also be given in days, e.g. '7d':
+//
+// * window: how far back to look at builds, 7 days by default.
+// * interval: how long each interval of the trend is, by default an hour for
+//   windows of up to two days and a day otherwise. It is rounded down to
+//   whole hours, as that is what the statistics are rolled up by.
+// * max_duration: the duration objective to track, if any.
+
+// ListJobStatistics returns the statistics of every job of the pipeline,
+// without their trends.
+func (s *Server) ListJobStatistics(pipeline db.Pipeline) http.Handler {
+	logger := s.logger.Session("list-job-statistics")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		params, err := parseStatisticsParams(r)
+		if err != nil {
+			HandleBadRequest(w, err.Error())
+			return
+		}
+
+		jobs, err := pipeline.Jobs()
+		if err != nil {
+			logger.Error("failed-to-get-jobs", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		var exposedJobs db.Jobs
+		var jobIDs []int
+		for _, job := range jobs {
+			if !jobHidden(r, pipeline, job.Name()) {
+				exposedJobs = append(exposedJobs, job)
+				jobIDs = append(jobIDs, job.ID())
+			}
+		}
+
+		buckets, err := s.buildStats.BuildStats(jobIDs, params.since)
+		if err != nil {
+			logger.Error("failed-to-get-build-stats", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		jobBuckets := map[int][]db.BuildStatsBucket{}
+		for _, bucket := range buckets {
+			jobBuckets[bucket.JobID] = append(jobBuckets[bucket.JobID], bucket)
+		}
+
+		statistics := []atc.JobStatistics{}
+		for _, job := range exposedJobs {
+			statistics = append(statistics, buildstats.Summarize(
+				job.Name(),
+				jobBuckets[job.ID()],
+				params.since,
+				0,
+				params.maxDuration,
+			))
+		}
+
+		writeStatistics(logger, w, statistics)
+	})
+}
+
+func (s *Server) GetJobStatistics(pipeline db.Pipeline) http.Handler {
+	logger := s.logger.Session("get-job-statistics")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		jobName := r.FormValue(":job_name")
+
+		params, err := parseStatisticsParams(r)
+		if err != nil {
+			HandleBadRequest(w, err.Error())
+			return
+		}
+
+		job, found, err := pipeline.Job(jobName)
+		if err != nil {
+			logger.Error("failed-to-get-job", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found || jobHidden(r, pipeline, jobName) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		buckets, err := s.buildStats.BuildStats([]int{job.ID()}, params.since)
+		if err != nil {
+			logger.Error("failed-to-get-build-stats", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		writeStatistics(logger, w, buildstats.Summarize(
+			job.Name(),
+			buckets,
+			params.since,
+			params.interval,
+			params.maxDuration,
+		))
+	})
+}
+
+type statisticsParams struct {
+	since       time.Time
+	interval    time.Duration
+	maxDuration time.Duration
+}
+
+func parseStatisticsParams(r *http.Request) (statisticsParams, error) {
+	window := defaultStatisticsWindow
+	if value := r.FormValue("window"); value != "" {
+		var err error
+		window, err = parseStatisticsDuration(value)
+		if err != nil || window <= 0 {
+			return statisticsParams{}, fmt.Errorf("invalid window: %s", value)
+		}
+	}
+
+	interval := 24 * time.Hour
+	if window <= 48*time.Hour {
+		interval = time.Hour
+	}
+
+	if value := r.FormValue("interval"); value != "" {
+		var err error
+		interval, err = parseStatisticsDuration(value)
+		if err != nil || interval < time.Hour {
+			return statisticsParams{}, fmt.Errorf("invalid interval: %s (must be at least an hour)", value)
+		}
+
+		interval = interval.Truncate(time.Hour)
+	}
+
+	var maxDuration time.Duration
+	if value := r.FormValue("max_duration"); value != "" {
+		var err error
+		maxDuration, err = parseStatisticsDuration(value)
+		if err != nil || maxDuration <= 0 {
+			return statisticsParams{}, fmt.Errorf("invalid max_duration: %s", value)
+		}
+	}
+
+	return statisticsParams{
+		since:       time.Now().Add(-window),
+		interval:    interval,
+		maxDuration: maxDuration,
+	}, nil
+}
+
+// parseStatisticsDuration parses a duration, allowing a number of days such
+// as '30d' in addition to what time.ParseDuration allows.
+func parseStatisticsDuration(value string) (time.Duration, error) {
+	if days := strings.TrimSuffix(value, "d"); days != value {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, err
+		}
+
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+
+	return time.ParseDuration(value)
+}
+
+func writeStatistics(logger lager.Logger, w http.ResponseWriter, statistics interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	err := json.NewEncoder(w).Encode(statistics)
+	if err != nil {
+		logger.Error("failed-to-encode-statistics", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
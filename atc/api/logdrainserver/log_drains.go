@@ -0,0 +1,108 @@
+package logdrainserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	. "github.com/concourse/concourse/atc/api/helpers"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) ListTeamLogDrains(team db.Team) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("list-log-drains", lager.Data{"team": team.Name()})
+
+		drains, err := s.repository.Drains(team.ID())
+		if err != nil {
+			logger.Error("failed-to-get-log-drains", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(drains)
+		if err != nil {
+			logger.Error("failed-to-encode-log-drains", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
+
+func (s *Server) SetTeamLogDrain(team db.Team) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		drainName := r.FormValue(":log_drain_name")
+
+		logger := s.logger.Session("set-log-drain", lager.Data{
+			"team":      team.Name(),
+			"log-drain": drainName,
+		})
+
+		var drain atc.LogDrain
+		err := json.NewDecoder(r.Body).Decode(&drain)
+		if err != nil {
+			logger.Info("malformed-request", lager.Data{"error": err.Error()})
+			HandleBadRequest(w, fmt.Sprintf("malformed log drain: %s", err))
+			return
+		}
+
+		drain.Name = drainName
+
+		var warnings []atc.ConfigWarning
+		warning, err := atc.ValidateIdentifier(drain.Name, "log drain")
+		if err != nil {
+			HandleBadRequest(w, err.Error())
+			return
+		}
+		if warning != nil {
+			warnings = append(warnings, *warning)
+		}
+
+		err = drain.Validate()
+		if err != nil {
+			HandleBadRequest(w, fmt.Sprintf("invalid log drain: %s", err))
+			return
+		}
+
+		err = s.repository.SaveDrain(team.ID(), drain)
+		if err != nil {
+			logger.Error("failed-to-save-log-drain", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(atc.SaveConfigResponse{Warnings: warnings})
+		if err != nil {
+			logger.Error("failed-to-encode-response", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
+
+func (s *Server) DestroyTeamLogDrain(team db.Team) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		drainName := r.FormValue(":log_drain_name")
+
+		logger := s.logger.Session("destroy-log-drain", lager.Data{
+			"team":      team.Name(),
+			"log-drain": drainName,
+		})
+
+		deleted, err := s.repository.DeleteDrain(team.ID(), drainName)
+		if err != nil {
+			logger.Error("failed-to-delete-log-drain", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !deleted {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
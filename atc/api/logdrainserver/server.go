@@ -0,0 +1,21 @@
+package logdrainserver
+
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/db"
+)
+
+type Server struct {
+	logger     lager.Logger
+	repository db.LogDrainRepository
+}
+
+func NewServer(
+	logger lager.Logger,
+	repository db.LogDrainRepository,
+) *Server {
+	return &Server{
+		logger:     logger,
+		repository: repository,
+	}
+}
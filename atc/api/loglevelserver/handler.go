@@ -1,17 +1,22 @@
 package loglevelserver
 
-import "code.cloudfoundry.org/lager"
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/requestlog"
+)
 
 type Server struct {
 	logger lager.Logger
 
-	sink *lager.ReconfigurableSink
+	sink               *lager.ReconfigurableSink
+	requestLogRecorder *requestlog.Recorder
 }
 
-func NewServer(logger lager.Logger, sink *lager.ReconfigurableSink) *Server {
+func NewServer(logger lager.Logger, sink *lager.ReconfigurableSink, requestLogRecorder *requestlog.Recorder) *Server {
 	return &Server{
 		logger: logger,
 
-		sink: sink,
+		sink:               sink,
+		requestLogRecorder: requestLogRecorder,
 	}
 }
@@ -0,0 +1,49 @@
+package loglevelserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+)
+
+func (s *Server) GetRequestLog(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("get-request-log")
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	err := json.NewEncoder(w).Encode(s.requestLogRecorder.Settings())
+	if err != nil {
+		logger.Error("failed-to-encode-request-log-settings", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+func (s *Server) SetRequestLog(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("set-request-log")
+
+	var settings atc.RequestLogSettings
+	err := json.NewDecoder(r.Body).Decode(&settings)
+	if err != nil {
+		logger.Info("malformed-request", lager.Data{"error": err.Error()})
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	err = s.requestLogRecorder.SetSettings(settings)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "%s", err)
+		return
+	}
+
+	logger.Info("set", lager.Data{
+		"sample-rate":    settings.SampleRate,
+		"slow-threshold": settings.SlowThreshold,
+	})
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -5,8 +5,10 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/concourse/concourse/atc"
@@ -534,9 +536,12 @@ var _ = Describe("Pipelines API", func() {
 
 	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/badge", func() {
 		var response *http.Response
+		var query string
 		var jobWithNoBuilds, jobWithSucceededBuild, jobWithAbortedBuild, jobWithErroredBuild, jobWithFailedBuild *dbfakes.FakeJob
 
 		BeforeEach(func() {
+			query = ""
+
 			dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
 			dbPipeline.NameReturns("some-pipeline")
 			fakeTeam.PipelineReturns(dbPipeline, true, nil)
@@ -567,7 +572,7 @@ var _ = Describe("Pipelines API", func() {
 		JustBeforeEach(func() {
 			var err error
 
-			response, err = client.Get(server.URL + "/api/v1/teams/some-team/pipelines/some-pipeline/badge")
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/pipelines/some-pipeline/badge" + query)
 			Expect(err).NotTo(HaveOccurred())
 		})
 
@@ -622,13 +627,79 @@ var _ = Describe("Pipelines API", func() {
 				Expect(response.StatusCode).To(Equal(http.StatusOK))
 			})
 
-			It("returns Content-Type as image/svg+xml and disables caching", func() {
+			It("returns Content-Type as image/svg+xml and requires revalidation", func() {
 				expectedHeaderEntries := map[string]string{
 					"Content-Type":  "image/svg+xml",
-					"Cache-Control": "no-cache, no-store, must-revalidate",
+					"Cache-Control": "no-cache, max-age=0, must-revalidate",
 					"Expires":       "0",
 				}
 				Expect(response).Should(IncludeHeaderEntries(expectedHeaderEntries))
+				Expect(response.Header.Get("ETag")).ToNot(BeEmpty())
+			})
+
+			Context("when getting the pipeline's jobs fails", func() {
+				BeforeEach(func() {
+					dbPipeline.JobsReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+
+			Context("when requesting json", func() {
+				BeforeEach(func() {
+					query = "?format=json"
+					dbPipeline.JobsReturns([]db.Job{jobWithSucceededBuild, jobWithErroredBuild}, nil)
+				})
+
+				It("returns the badge of the worst status for shields.io", func() {
+					Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`{
+						"schemaVersion": 1,
+						"label": "build",
+						"message": "errored",
+						"color": "fe7d37"
+					}`))
+				})
+			})
+
+			Context("when requesting the badge of a group", func() {
+				BeforeEach(func() {
+					query = "?group=some-group&format=json"
+
+					dbPipeline.GroupsReturns(atc.GroupConfigs{{Name: "some-group"}})
+
+					jobWithSucceededBuild.TagsReturns([]string{"some-group"})
+					jobWithFailedBuild.TagsReturns([]string{"other-group"})
+					dbPipeline.JobsReturns([]db.Job{jobWithSucceededBuild, jobWithFailedBuild}, nil)
+				})
+
+				It("only aggregates the jobs in the group", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`{
+						"schemaVersion": 1,
+						"label": "build",
+						"message": "passing",
+						"color": "44cc11"
+					}`))
+				})
+
+				Context("when the group does not exist", func() {
+					BeforeEach(func() {
+						query = "?group=bogus-group"
+					})
+
+					It("returns 404", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+					})
+				})
 			})
 
 			Context("when the pipeline has no finished builds", func() {
@@ -1004,6 +1075,120 @@ var _ = Describe("Pipelines API", func() {
 		})
 	})
 
+	Describe("PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/move", func() {
+		var (
+			response    *http.Response
+			requestBody string
+			otherTeam   *dbfakes.FakeTeam
+		)
+
+		BeforeEach(func() {
+			requestBody = `{"team":"other-team"}`
+
+			otherTeam = new(dbfakes.FakeTeam)
+			otherTeam.IDReturns(2)
+			otherTeam.NameReturns("other-team")
+
+			fakeAccess.IsAuthenticatedReturns(true)
+			fakeAccess.IsAuthorizedReturns(true)
+			dbTeamFactory.FindTeamStub = func(name string) (db.Team, bool, error) {
+				switch name {
+				case "a-team":
+					return fakeTeam, true, nil
+				case "other-team":
+					return otherTeam, true, nil
+				default:
+					return nil, false, nil
+				}
+			}
+			fakeTeam.PipelineReturns(dbPipeline, true, nil)
+		})
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/move", bytes.NewBufferString(requestBody))
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("moves the pipeline to the team", func() {
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+			Expect(dbPipeline.MoveToTeamCallCount()).To(Equal(1))
+			Expect(dbPipeline.MoveToTeamArgsForCall(0)).To(Equal(2))
+		})
+
+		It("checks that the user is authorized in the team", func() {
+			Expect(fakeAccess.IsAuthorizedArgsForCall(fakeAccess.IsAuthorizedCallCount() - 1)).To(Equal("other-team"))
+		})
+
+		Context("when the user is not authorized in the team", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthorizedStub = func(team string) bool {
+					return team == "a-team"
+				}
+			})
+
+			It("returns 403 without moving the pipeline", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(dbPipeline.MoveToTeamCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when the team does not exist", func() {
+			BeforeEach(func() {
+				requestBody = `{"team":"missing-team"}`
+			})
+
+			It("returns 404", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				Expect(dbPipeline.MoveToTeamCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when no team is given", func() {
+			BeforeEach(func() {
+				requestBody = `{}`
+			})
+
+			It("returns 400", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				Expect(dbPipeline.MoveToTeamCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when the team has a pipeline with the same name", func() {
+			BeforeEach(func() {
+				dbPipeline.MoveToTeamReturns(db.ErrPipelineExistsInTeam)
+			})
+
+			It("returns 409", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusConflict))
+			})
+		})
+
+		Context("when moving the pipeline fails", func() {
+			BeforeEach(func() {
+				dbPipeline.MoveToTeamReturns(errors.New("nope"))
+			})
+
+			It("returns 500", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				Expect(dbPipeline.MoveToTeamCallCount()).To(BeZero())
+			})
+		})
+	})
+
 	Describe("PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/unpause", func() {
 		var response *http.Response
 
@@ -1089,12 +1274,19 @@ var _ = Describe("Pipelines API", func() {
 	})
 
 	Describe("PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/expose", func() {
-		var response *http.Response
+		var (
+			response    *http.Response
+			requestBody io.Reader
+		)
+
+		BeforeEach(func() {
+			requestBody = nil
+		})
 
 		JustBeforeEach(func() {
 			var err error
 
-			request, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/expose", nil)
+			request, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/expose", requestBody)
 			Expect(err).NotTo(HaveOccurred())
 
 			response, err = client.Do(request)
@@ -1144,6 +1336,55 @@ var _ = Describe("Pipelines API", func() {
 						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
 					})
 				})
+
+				Context("when the request restricts the exposure", func() {
+					BeforeEach(func() {
+						fakeTeam.PipelineReturns(dbPipeline, true, nil)
+						requestBody = strings.NewReader(`{"jobs":["some-job"],"status_only":true}`)
+					})
+
+					Context("when the jobs exist", func() {
+						BeforeEach(func() {
+							dbPipeline.JobReturns(new(dbfakes.FakeJob), true, nil)
+						})
+
+						It("returns 200", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusOK))
+						})
+
+						It("exposes only the given jobs' statuses", func() {
+							Expect(dbPipeline.JobArgsForCall(0)).To(Equal("some-job"))
+							Expect(dbPipeline.ExposeCallCount()).To(Equal(0))
+							Expect(dbPipeline.ExposeWithCallCount()).To(Equal(1))
+							Expect(dbPipeline.ExposeWithArgsForCall(0)).To(Equal(atc.PipelineExposure{
+								Jobs:       []string{"some-job"},
+								StatusOnly: true,
+							}))
+						})
+					})
+
+					Context("when a job does not exist", func() {
+						BeforeEach(func() {
+							dbPipeline.JobReturns(nil, false, nil)
+						})
+
+						It("returns 400 without exposing the pipeline", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+							Expect(dbPipeline.ExposeWithCallCount()).To(BeZero())
+						})
+					})
+				})
+
+				Context("when the request body is malformed", func() {
+					BeforeEach(func() {
+						fakeTeam.PipelineReturns(dbPipeline, true, nil)
+						requestBody = strings.NewReader(`{"jobs":`)
+					})
+
+					It("returns 400", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
 			})
 
 			Context("when requester does not belong to the team", func() {
@@ -1246,6 +1487,153 @@ var _ = Describe("Pipelines API", func() {
 		})
 	})
 
+	Describe("PUT /api/v1/teams/:team_name/pipelines/pause", func() {
+		var (
+			response *http.Response
+			query    string
+		)
+
+		BeforeEach(func() {
+			query = ""
+
+			fakeAccess.IsAuthenticatedReturns(true)
+			fakeAccess.IsAuthorizedReturns(true)
+			dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+
+			pipeline1 := new(dbfakes.FakePipeline)
+			pipeline1.IDReturns(1)
+			pipeline1.NameReturns("api-pipeline")
+
+			pipeline2 := new(dbfakes.FakePipeline)
+			pipeline2.IDReturns(2)
+			pipeline2.NameReturns("web-pipeline")
+			pipeline2.InstanceVarsReturns(atc.InstanceVars{"branch": "main"})
+
+			archivedPipeline := new(dbfakes.FakePipeline)
+			archivedPipeline.IDReturns(3)
+			archivedPipeline.NameReturns("api-archived")
+			archivedPipeline.ArchivedReturns(true)
+
+			fakeTeam.PipelinesReturns([]db.Pipeline{pipeline1, pipeline2, archivedPipeline}, nil)
+			fakeTeam.BatchUpdatePipelinesReturns([]int{2}, nil)
+		})
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/a-team/pipelines/pause"+query, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("pauses every unarchived pipeline at once", func() {
+			Expect(fakeTeam.BatchUpdatePipelinesCallCount()).To(Equal(1))
+			action, ids, _ := fakeTeam.BatchUpdatePipelinesArgsForCall(0)
+			Expect(action).To(Equal(atc.PipelineBatchPause))
+			Expect(ids).To(Equal([]int{1, 2}))
+		})
+
+		It("reports the outcome for each pipeline", func() {
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+			Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`[
+				{"pipeline": {"name": "api-pipeline"}, "changed": false},
+				{"pipeline": {"name": "web-pipeline", "instance_vars": {"branch": "main"}}, "changed": true}
+			]`))
+		})
+
+		Context("when filtering by a pattern", func() {
+			BeforeEach(func() {
+				query = "?pattern=api-*"
+			})
+
+			It("acts only on the matching pipelines", func() {
+				_, ids, _ := fakeTeam.BatchUpdatePipelinesArgsForCall(0)
+				Expect(ids).To(Equal([]int{1}))
+			})
+		})
+
+		Context("when the pattern is invalid", func() {
+			BeforeEach(func() {
+				query = "?pattern=%5B"
+			})
+
+			It("returns 400", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				Expect(fakeTeam.BatchUpdatePipelinesCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when updating the pipelines fails", func() {
+			BeforeEach(func() {
+				fakeTeam.BatchUpdatePipelinesReturns(nil, errors.New("nope"))
+			})
+
+			It("returns 500", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("POST /api/v1/teams/:team_name/pipelines/check", func() {
+		var (
+			response     *http.Response
+			fakeResource *dbfakes.FakeResource
+		)
+
+		BeforeEach(func() {
+			fakeAccess.IsAuthenticatedReturns(true)
+			fakeAccess.IsAuthorizedReturns(true)
+			dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+
+			fakeResource = new(dbfakes.FakeResource)
+			fakeResource.NameReturns("some-resource")
+
+			okPipeline := new(dbfakes.FakePipeline)
+			okPipeline.NameReturns("ok-pipeline")
+			okPipeline.ResourcesReturns(db.Resources{fakeResource}, nil)
+
+			brokenPipeline := new(dbfakes.FakePipeline)
+			brokenPipeline.NameReturns("broken-pipeline")
+			brokenPipeline.ResourcesReturns(nil, errors.New("disaster"))
+
+			fakeTeam.PipelinesReturns([]db.Pipeline{okPipeline, brokenPipeline}, nil)
+			dbCheckFactory.TryCreateCheckReturns(new(dbfakes.FakeBuild), true, nil)
+		})
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequest("POST", server.URL+"/api/v1/teams/a-team/pipelines/check", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("checks the resources of every pipeline", func() {
+			Expect(dbCheckFactory.TryCreateCheckCallCount()).To(Equal(1))
+			_, checkable, _, _, manuallyTriggered, _, _ := dbCheckFactory.TryCreateCheckArgsForCall(0)
+			Expect(checkable).To(Equal(fakeResource))
+			Expect(manuallyTriggered).To(BeTrue())
+		})
+
+		It("reports the outcome for each pipeline", func() {
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+			Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`[
+				{"pipeline": {"name": "ok-pipeline"}, "changed": true},
+				{"pipeline": {"name": "broken-pipeline"}, "changed": false, "error": "get resources: disaster"}
+			]`))
+		})
+	})
+
 	Describe("PUT /api/v1/teams/:team_name/pipelines/ordering", func() {
 		var response *http.Response
 		var pipelineNames []string
@@ -1649,6 +2037,166 @@ var _ = Describe("Pipelines API", func() {
 		})
 	})
 
+	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/graph", func() {
+		var (
+			format   string
+			response *http.Response
+		)
+
+		BeforeEach(func() {
+			format = ""
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			request, err := http.NewRequest("GET", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/graph?format="+format, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+				dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+				fakeTeam.PipelineReturns(dbPipeline, true, nil)
+				dbPipeline.NameReturns("a-pipeline")
+			})
+
+			Context("when the config is found", func() {
+				BeforeEach(func() {
+					dbPipeline.ConfigReturns(atc.Config{
+						Resources: atc.ResourceConfigs{
+							{Name: "some-resource", Type: "git"},
+						},
+						Jobs: atc.JobConfigs{
+							{
+								Name: "some-job",
+								PlanSequence: []atc.Step{
+									{Config: &atc.GetStep{Name: "some-resource", Trigger: true}},
+								},
+							},
+						},
+					}, nil)
+				})
+
+				It("returns the graph as json", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`{
+						"nodes": [
+							{"id": "job:some-job", "type": "job", "name": "some-job"},
+							{"id": "resource:some-resource", "type": "resource", "name": "some-resource", "resource_type": "git"}
+						],
+						"edges": [
+							{"from": "resource:some-resource", "to": "job:some-job", "type": "input", "trigger": true}
+						]
+					}`))
+				})
+
+				Context("when dot is requested", func() {
+					BeforeEach(func() {
+						format = "dot"
+					})
+
+					It("returns the graph in the DOT language", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(response.Header.Get("Content-Type")).To(Equal("text/vnd.graphviz"))
+
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(string(body)).To(HavePrefix(`digraph "a-pipeline" {`))
+						Expect(string(body)).To(ContainSubstring(`"resource:some-resource" -> "job:some-job";`))
+					})
+				})
+
+				Context("when an unknown format is requested", func() {
+					BeforeEach(func() {
+						format = "svg"
+					})
+
+					It("returns 400", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+			})
+
+			Context("when getting the config fails", func() {
+				BeforeEach(func() {
+					dbPipeline.ConfigReturns(atc.Config{}, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when the pipeline is public and only exposes some of its jobs", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthorizedReturns(false)
+				dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+				fakeTeam.PipelineReturns(dbPipeline, true, nil)
+				dbPipeline.PublicReturns(true)
+				dbPipeline.ExposureReturns(atc.PipelineExposure{Jobs: []string{"exposed-job"}})
+				dbPipeline.ConfigReturns(atc.Config{
+					Resources: atc.ResourceConfigs{
+						{Name: "some-resource", Type: "git"},
+					},
+					Jobs: atc.JobConfigs{
+						{
+							Name: "exposed-job",
+							PlanSequence: []atc.Step{
+								{Config: &atc.GetStep{Name: "some-resource"}},
+							},
+						},
+						{
+							Name: "hidden-job",
+							PlanSequence: []atc.Step{
+								{Config: &atc.GetStep{Name: "some-resource", Passed: []string{"exposed-job"}}},
+							},
+						},
+					},
+				}, nil)
+			})
+
+			It("leaves out the hidden jobs and their edges", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(body).To(MatchJSON(`{
+					"nodes": [
+						{"id": "job:exposed-job", "type": "job", "name": "exposed-job"},
+						{"id": "resource:some-resource", "type": "resource", "name": "some-resource", "resource_type": "git"}
+					],
+					"edges": [
+						{"from": "resource:some-resource", "to": "job:exposed-job", "type": "input"}
+					]
+				}`))
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401 Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
 	Describe("PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/rename", func() {
 		var response *http.Response
 		var requestBody string
@@ -33,6 +33,7 @@ var _ = Describe("Archive Handler", func() {
 			fakeLogger,
 			new(dbfakes.FakeTeamFactory),
 			new(dbfakes.FakePipelineFactory),
+			new(dbfakes.FakeCheckFactory),
 			"",
 		)
 		dbPipeline = new(dbfakes.FakePipeline)
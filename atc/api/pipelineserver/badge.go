@@ -1,7 +1,6 @@
 package pipelineserver
 
 import (
-	"fmt"
 	"net/http"
 
 	"code.cloudfoundry.org/lager"
@@ -10,7 +9,10 @@ import (
 	"github.com/concourse/concourse/atc/db"
 )
 
-func badgeForPipeline(pipeline db.Pipeline, logger lager.Logger) (jobserver.Badge, error) {
+// badgeForPipeline aggregates the latest finished builds of the pipeline's
+// jobs, or of only the jobs in the group when one is given, into the badge
+// of the worst status.
+func badgeForPipeline(pipeline db.Pipeline, group string, logger lager.Logger) (jobserver.Badge, error) {
 	var build db.Build
 
 	jobStatusPrecedence := map[db.BuildStatus]int{
@@ -27,6 +29,10 @@ func badgeForPipeline(pipeline db.Pipeline, logger lager.Logger) (jobserver.Badg
 	}
 
 	for _, job := range jobs {
+		if group != "" && !inGroup(job, group) {
+			continue
+		}
+
 		b, _, err := job.FinishedAndNextBuild()
 		if err != nil {
 			logger.Error("could-not-get-finished-and-next-build", err)
@@ -45,23 +51,41 @@ func badgeForPipeline(pipeline db.Pipeline, logger lager.Logger) (jobserver.Badg
 	return jobserver.BadgeForBuild(build), nil
 }
 
+func inGroup(job db.Job, group string) bool {
+	for _, tag := range job.Tags() {
+		if tag == group {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (s *Server) PipelineBadge(pipeline db.Pipeline) http.Handler {
 	logger := s.logger.Session("pipeline-badge")
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Content-type", "image/svg+xml")
+		group := r.URL.Query().Get("group")
+		if group != "" {
+			found := false
+			for _, g := range pipeline.Groups() {
+				if g.Name == group {
+					found = true
+					break
+				}
+			}
 
-		w.Header().Set("Cache-Control", "no-cache, no-store, must-revalidate")
-		w.Header().Set("Expires", "0")
-
-		w.WriteHeader(http.StatusOK)
+			if !found {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+		}
 
-		badge, err := badgeForPipeline(pipeline, logger)
-		badge.EnrichFromQuery(r.URL.Query())
+		badge, err := badgeForPipeline(pipeline, group, logger)
 		if err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
 			return
 		}
 
-		fmt.Fprint(w, &badge)
+		jobserver.ServeBadge(w, r, badge)
 	})
 }
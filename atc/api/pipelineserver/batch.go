@@ -0,0 +1,211 @@
+package pipelineserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/accessor"
+	. "github.com/concourse/concourse/atc/api/helpers"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/gobwas/glob"
+)
+
+// The batch handlers act on every unarchived pipeline of the team whose name
+// matches the glob given as the 'pattern' query parameter, or on all of them
+// if there is none, and report the outcome for each pipeline.
+
+func (s *Server) PausePipelines(team db.Team) http.Handler {
+	return s.batchUpdatePipelines(team, atc.PipelineBatchPause)
+}
+
+func (s *Server) UnpausePipelines(team db.Team) http.Handler {
+	return s.batchUpdatePipelines(team, atc.PipelineBatchUnpause)
+}
+
+func (s *Server) ExposePipelines(team db.Team) http.Handler {
+	return s.batchUpdatePipelines(team, atc.PipelineBatchExpose)
+}
+
+func (s *Server) HidePipelines(team db.Team) http.Handler {
+	return s.batchUpdatePipelines(team, atc.PipelineBatchHide)
+}
+
+// batchUpdatePipelines applies the action to all of the matching pipelines
+// at once; if it fails for any of them, none of them are changed.
+func (s *Server) batchUpdatePipelines(team db.Team, action atc.PipelineBatchAction) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("batch-update-pipelines", lager.Data{
+			"team":   team.Name(),
+			"action": action,
+		})
+
+		pattern, err := pipelinePattern(r)
+		if err != nil {
+			logger.Info("invalid-pattern", lager.Data{"error": err.Error()})
+			HandleBadRequest(w, err.Error())
+			return
+		}
+
+		pipelines, err := matchingPipelines(team, pattern)
+		if err != nil {
+			logger.Error("failed-to-get-pipelines", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		pipelineIDs := make([]int, len(pipelines))
+		for i, pipeline := range pipelines {
+			pipelineIDs[i] = pipeline.ID()
+		}
+
+		user := accessor.GetAccessor(r).UserInfo().DisplayUserId
+
+		changedIDs, err := team.BatchUpdatePipelines(action, pipelineIDs, user)
+		if err != nil {
+			logger.Error("failed-to-update-pipelines", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		changed := map[int]bool{}
+		for _, id := range changedIDs {
+			changed[id] = true
+		}
+
+		results := make([]atc.PipelineBatchResult, len(pipelines))
+		for i, pipeline := range pipelines {
+			results[i] = atc.PipelineBatchResult{
+				Pipeline: pipelineRef(pipeline),
+				Changed:  changed[pipeline.ID()],
+			}
+		}
+
+		writeBatchResults(logger, w, results)
+	})
+}
+
+// CheckPipelines checks every resource of the matching pipelines. Unlike the
+// other batch actions, each pipeline is checked independently of the others.
+func (s *Server) CheckPipelines(team db.Team) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("check-pipelines", lager.Data{
+			"team": team.Name(),
+		})
+
+		pattern, err := pipelinePattern(r)
+		if err != nil {
+			logger.Info("invalid-pattern", lager.Data{"error": err.Error()})
+			HandleBadRequest(w, err.Error())
+			return
+		}
+
+		pipelines, err := matchingPipelines(team, pattern)
+		if err != nil {
+			logger.Error("failed-to-get-pipelines", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		results := make([]atc.PipelineBatchResult, len(pipelines))
+		for i, pipeline := range pipelines {
+			results[i] = atc.PipelineBatchResult{
+				Pipeline: pipelineRef(pipeline),
+			}
+
+			created, err := s.checkPipeline(logger, pipeline)
+			if err != nil {
+				logger.Error("failed-to-check-pipeline", err, lager.Data{"pipeline": pipeline.Name()})
+				results[i].Error = err.Error()
+			}
+
+			results[i].Changed = created
+		}
+
+		writeBatchResults(logger, w, results)
+	})
+}
+
+func (s *Server) checkPipeline(logger lager.Logger, pipeline db.Pipeline) (bool, error) {
+	resources, err := pipeline.Resources()
+	if err != nil {
+		return false, fmt.Errorf("get resources: %w", err)
+	}
+
+	resourceTypes, err := pipeline.ResourceTypes()
+	if err != nil {
+		return false, fmt.Errorf("get resource types: %w", err)
+	}
+
+	anyCreated := false
+	for _, resource := range resources {
+		_, created, err := s.checkFactory.TryCreateCheck(
+			lagerctx.NewContext(context.Background(), logger),
+			resource,
+			resourceTypes,
+			nil,
+			true,
+			false,
+			true,
+		)
+		if err != nil {
+			return anyCreated, fmt.Errorf("check resource '%s': %w", resource.Name(), err)
+		}
+
+		anyCreated = anyCreated || created
+	}
+
+	return anyCreated, nil
+}
+
+func pipelinePattern(r *http.Request) (glob.Glob, error) {
+	pattern := r.FormValue("pattern")
+	if pattern == "" {
+		pattern = "*"
+	}
+
+	g, err := glob.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern '%s': %w", pattern, err)
+	}
+
+	return g, nil
+}
+
+func matchingPipelines(team db.Team, pattern glob.Glob) ([]db.Pipeline, error) {
+	pipelines, err := team.Pipelines()
+	if err != nil {
+		return nil, err
+	}
+
+	var matching []db.Pipeline
+	for _, pipeline := range pipelines {
+		if !pipeline.Archived() && pattern.Match(pipeline.Name()) {
+			matching = append(matching, pipeline)
+		}
+	}
+
+	return matching, nil
+}
+
+func pipelineRef(pipeline db.Pipeline) atc.PipelineRef {
+	return atc.PipelineRef{
+		Name:         pipeline.Name(),
+		InstanceVars: pipeline.InstanceVars(),
+	}
+}
+
+func writeBatchResults(logger lager.Logger, w http.ResponseWriter, results []atc.PipelineBatchResult) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	err := json.NewEncoder(w).Encode(results)
+	if err != nil {
+		logger.Error("failed-to-encode-results", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
@@ -1,15 +1,50 @@
 package pipelineserver
 
 import (
+	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
 
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	. "github.com/concourse/concourse/atc/api/helpers"
 	"github.com/concourse/concourse/atc/db"
 )
 
+// ExposePipeline makes the pipeline public. The request body may restrict
+// what is exposed to some of the pipeline's jobs, or to only their build
+// statuses; without a body the whole pipeline is exposed.
 func (s *Server) ExposePipeline(pipeline db.Pipeline) http.Handler {
 	logger := s.logger.Session("expose-pipeline")
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		err := pipeline.Expose()
+		var exposure atc.PipelineExposure
+		err := json.NewDecoder(r.Body).Decode(&exposure)
+		if err != nil && err != io.EOF {
+			logger.Info("malformed-request", lager.Data{"error": err.Error()})
+			HandleBadRequest(w, fmt.Sprintf("malformed exposure: %s", err))
+			return
+		}
+
+		for _, jobName := range exposure.Jobs {
+			_, found, err := pipeline.Job(jobName)
+			if err != nil {
+				logger.Error("failed-to-get-job", err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			if !found {
+				HandleBadRequest(w, fmt.Sprintf("unknown job: %s", jobName))
+				return
+			}
+		}
+
+		if exposure.Restricted() {
+			err = pipeline.ExposeWith(exposure)
+		} else {
+			err = pipeline.Expose()
+		}
 		if err != nil {
 			logger.Error("failed-to-expose-pipeline", err)
 			w.WriteHeader(http.StatusInternalServerError)
@@ -0,0 +1,85 @@
+package pipelineserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/accessor"
+	"github.com/concourse/concourse/atc/db"
+)
+
+// GetPipelineGraph returns how the jobs and resources of the pipeline depend
+// on each other, as JSON or, with '?format=dot', in the DOT language of
+// Graphviz.
+func (s *Server) GetPipelineGraph(pipeline db.Pipeline) http.Handler {
+	logger := s.logger.Session("get-pipeline-graph")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		format := r.URL.Query().Get("format")
+		if format != "" && format != "json" && format != "dot" {
+			http.Error(w, fmt.Sprintf("unknown format '%s' (must be json or dot)", format), http.StatusBadRequest)
+			return
+		}
+
+		config, err := pipeline.Config()
+		if err != nil {
+			logger.Error("failed-to-get-pipeline-config", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		graph := atc.NewPipelineGraph(config)
+		if !accessor.GetAccessor(r).IsAuthorized(pipeline.TeamName()) {
+			graph = exposedGraph(graph, pipeline.Exposure())
+		}
+
+		if format == "dot" {
+			w.Header().Set("Content-Type", "text/vnd.graphviz")
+			w.WriteHeader(http.StatusOK)
+
+			_, _ = w.Write([]byte(graph.DOT(pipeline.Name())))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		err = json.NewEncoder(w).Encode(graph)
+		if err != nil {
+			logger.Error("failed-to-encode-pipeline-graph", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
+
+// exposedGraph leaves the jobs the pipeline does not expose out of the graph,
+// along with their edges.
+func exposedGraph(graph atc.PipelineGraph, exposure atc.PipelineExposure) atc.PipelineGraph {
+	hidden := map[string]bool{}
+
+	nodes := []atc.PipelineGraphNode{}
+	for _, node := range graph.Nodes {
+		if node.Type == atc.PipelineGraphJob && !exposure.JobExposed(node.Name) {
+			hidden[node.ID] = true
+			continue
+		}
+
+		nodes = append(nodes, node)
+	}
+
+	if len(hidden) == 0 {
+		return graph
+	}
+
+	edges := []atc.PipelineGraphEdge{}
+	for _, edge := range graph.Edges {
+		if hidden[edge.From] || hidden[edge.To] {
+			continue
+		}
+
+		edges = append(edges, edge)
+	}
+
+	return atc.PipelineGraph{Nodes: nodes, Edges: edges}
+}
@@ -7,6 +7,7 @@ import (
 	"strconv"
 
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/accessor"
 	"github.com/concourse/concourse/atc/api/present"
 	"github.com/concourse/concourse/atc/db"
 )
@@ -79,13 +80,21 @@ func (s *Server) ListPipelineBuilds(pipeline db.Pipeline) http.Handler {
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
 
-		atc := make([]atc.Build, len(builds))
-		for i := 0; i < len(builds); i++ {
-			build := builds[i]
-			atc[i] = present.Build(build, nil, nil)
+		// builds of jobs the pipeline does not expose are left out for users
+		// outside of its team
+		authorized := accessor.GetAccessor(r).IsAuthorized(pipeline.TeamName())
+		exposure := pipeline.Exposure()
+
+		presentedBuilds := []atc.Build{}
+		for _, build := range builds {
+			if !authorized && !exposure.JobExposed(build.JobName()) {
+				continue
+			}
+
+			presentedBuilds = append(presentedBuilds, present.Build(build, nil, nil))
 		}
 
-		err = json.NewEncoder(w).Encode(atc)
+		err = json.NewEncoder(w).Encode(presentedBuilds)
 		if err != nil {
 			logger.Error("failed-to-encode-builds", err)
 			w.WriteHeader(http.StatusInternalServerError)
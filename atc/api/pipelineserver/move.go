@@ -0,0 +1,65 @@
+package pipelineserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/accessor"
+	. "github.com/concourse/concourse/atc/api/helpers"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) MovePipeline(pipelineDB db.Pipeline) http.Handler {
+	logger := s.logger.Session("move-pipeline")
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var move atc.MovePipelineRequest
+		err := json.NewDecoder(r.Body).Decode(&move)
+		if err != nil {
+			HandleBadRequest(w, fmt.Sprintf("malformed request: %s", err))
+			return
+		}
+
+		if move.Team == "" {
+			HandleBadRequest(w, "team must be specified")
+			return
+		}
+
+		team, found, err := s.teamFactory.FindTeam(move.Team)
+		if err != nil {
+			logger.Error("failed-to-find-team", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			logger.Info("team-not-found", lager.Data{"team_name": move.Team})
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		// the pipeline's current team was checked by the auth wrappa; the
+		// user must be allowed to do the same in the team it is moving to
+		if !accessor.GetAccessor(r).IsAuthorized(team.Name()) {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		err = pipelineDB.MoveToTeam(team.ID())
+		if err != nil {
+			if err == db.ErrPipelineExistsInTeam {
+				w.WriteHeader(http.StatusConflict)
+				fmt.Fprintf(w, "%s", err)
+				return
+			}
+
+			logger.Error("failed-to-move-pipeline", err, lager.Data{"team_name": team.Name()})
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	})
+}
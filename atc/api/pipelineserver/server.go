@@ -11,6 +11,7 @@ type Server struct {
 	teamFactory     db.TeamFactory
 	rejector        auth.Rejector
 	pipelineFactory db.PipelineFactory
+	checkFactory    db.CheckFactory
 	externalURL     string
 }
 
@@ -18,6 +19,7 @@ func NewServer(
 	logger lager.Logger,
 	teamFactory db.TeamFactory,
 	pipelineFactory db.PipelineFactory,
+	checkFactory db.CheckFactory,
 	externalURL string,
 ) *Server {
 	return &Server{
@@ -25,6 +27,7 @@ func NewServer(
 		teamFactory:     teamFactory,
 		rejector:        auth.UnauthorizedRejector{},
 		pipelineFactory: pipelineFactory,
+		checkFactory:    checkFactory,
 		externalURL:     externalURL,
 	}
 }
@@ -8,6 +8,7 @@ import (
 
 	"sigs.k8s.io/yaml"
 
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/api/accessor"
 	"github.com/concourse/concourse/atc/policy"
 )
@@ -45,14 +46,21 @@ func (c *checker) Check(action string, acc accessor.Access, req *http.Request) (
 		return policy.PassedPolicyCheck(), nil
 	}
 
+	instanceVars, err := atc.InstanceVarsFromQueryParams(req.URL.Query())
+	if err != nil {
+		return nil, err
+	}
+
 	team := req.FormValue(":team_name")
 	input := policy.PolicyCheckInput{
-		HttpMethod: req.Method,
-		Action:     action,
-		User:       acc.Claims().UserName,
-		Roles:      acc.TeamRoles()[team],
-		Team:       team,
-		Pipeline:   req.FormValue(":pipeline_name"),
+		HttpMethod:   req.Method,
+		Action:       action,
+		User:         acc.Claims().UserName,
+		Roles:        acc.TeamRoles()[team],
+		Team:         team,
+		Pipeline:     req.FormValue(":pipeline_name"),
+		InstanceVars: instanceVars,
+		Job:          req.FormValue(":job_name"),
 	}
 
 	switch ct := req.Header.Get("Content-type"); ct {
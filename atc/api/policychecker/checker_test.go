@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/api/accessor"
 	"github.com/concourse/concourse/atc/api/accessor/accessorfakes"
 	"github.com/concourse/concourse/atc/api/policychecker"
@@ -190,6 +191,19 @@ var _ = Describe("PolicyChecker", func() {
 					Expect(body).To(Equal([]byte("a: b")))
 				})
 
+				Context("when the action is on a job of a pipeline instance", func() {
+					BeforeEach(func() {
+						fakeRequest = httptest.NewRequest("PUT", "/something?:team_name=some-team&:pipeline_name=some-pipeline&:job_name=some-job&vars.env=%22prod%22", nil)
+						fakeRequest.ParseForm()
+					})
+
+					It("Agent should take the job and the instance vars", func() {
+						input := fakePolicyAgent.CheckArgsForCall(0)
+						Expect(input.Job).To(Equal("some-job"))
+						Expect(input.InstanceVars).To(Equal(atc.InstanceVars{"env": "prod"}))
+					})
+				})
+
 				Context("when Agent says pass", func() {
 					BeforeEach(func() {
 						fakePolicyAgent.CheckReturns(policy.PassedPolicyCheck(), nil)
@@ -2,6 +2,7 @@ package present
 
 import (
 	"strconv"
+	"time"
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/api/accessor"
@@ -31,21 +32,34 @@ func Build(build db.BuildForAPI, job db.Job, access accessor.Access) atc.Build {
 		Status:               atc.BuildStatus(build.Status()),
 		APIURL:               apiURL,
 		CreatedBy:            build.CreatedBy(),
+		ExternalRef:          build.ExternalRef(),
 	}
 
-	showComments := false
+	showDetails := false
 	if job != nil {
-		showComments = showComments || job.Public()
+		showDetails = showDetails || job.Public()
 	}
 	if access != nil {
-		showComments = showComments || access.IsAuthorized(build.TeamName())
+		showDetails = showDetails || access.IsAuthorized(build.TeamName())
 	}
 
-	if showComments {
+	if showDetails {
 		comment := build.Comment()
 		atcBuild.Comment = comment
+		atcBuild.Vars = redactedVars(build.Vars())
 	}
 
+	if build.CollapsedInto() != 0 {
+		atcBuild.CollapsedInto = build.CollapsedInto()
+	}
+
+	if build.RetryOf() != 0 {
+		atcBuild.RetryOf = build.RetryOf()
+	}
+
+	atcBuild.FailureReason = build.FailureReason()
+	atcBuild.StartLatency = build.StartLatency()
+
 	if build.RerunOf() != 0 {
 		atcBuild.RerunNumber = build.RerunNumber()
 		atcBuild.RerunOf = &atc.RerunOfBuild{
@@ -66,5 +80,46 @@ func Build(build db.BuildForAPI, job db.Job, access accessor.Access) atc.Build {
 		atcBuild.ReapTime = build.ReapTime().Unix()
 	}
 
+	if duration, found := build.EstimatedDuration(); found && duration > 0 {
+		atcBuild.EstimatedDuration = int64(duration / time.Second)
+
+		if build.IsRunning() && !build.StartTime().IsZero() {
+			atcBuild.EstimatedEndTime = build.StartTime().Add(duration).Unix()
+			atcBuild.Progress = progress(time.Since(build.StartTime()), duration)
+		}
+	}
+
 	return atcBuild
 }
+
+func redactedVars(vars []atc.BuildVar) []atc.BuildVar {
+	if len(vars) == 0 {
+		return nil
+	}
+
+	redacted := make([]atc.BuildVar, len(vars))
+	for i, v := range vars {
+		if v.Secret {
+			v.Value = nil
+		}
+
+		redacted[i] = v
+	}
+
+	return redacted
+}
+
+// progress is how much of the expected duration has elapsed. It stays short
+// of 1 for builds which overrun, as they have yet to finish.
+func progress(elapsed, expected time.Duration) float64 {
+	fraction := float64(elapsed) / float64(expected)
+	if fraction < 0 {
+		return 0
+	}
+
+	if fraction > 0.99 {
+		return 0.99
+	}
+
+	return fraction
+}
@@ -17,6 +17,7 @@ func BuildPreparation(preparation db.BuildPreparation) atc.BuildPreparation {
 		PausedPipeline:      atc.BuildPreparationStatus(preparation.PausedPipeline),
 		PausedJob:           atc.BuildPreparationStatus(preparation.PausedJob),
 		MaxRunningBuilds:    atc.BuildPreparationStatus(preparation.MaxRunningBuilds),
+		WorkerCapacity:      atc.BuildPreparationStatus(preparation.WorkerCapacity),
 		Inputs:              inputs,
 		InputsSatisfied:     atc.BuildPreparationStatus(preparation.InputsSatisfied),
 		MissingInputReasons: atc.MissingInputReasons(preparation.MissingInputReasons),
@@ -2,7 +2,9 @@ package present_test
 
 import (
 	"fmt"
+	"time"
 
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/api/accessor"
 	"github.com/concourse/concourse/atc/api/accessor/accessorfakes"
 	"github.com/concourse/concourse/atc/api/present"
@@ -15,6 +17,10 @@ import (
 var _ = Describe("Build", func() {
 	var dbBuild dbfakes.FakeBuild
 
+	BeforeEach(func() {
+		dbBuild = dbfakes.FakeBuild{}
+	})
+
 	Describe("Comments", func() {
 		var comment string = "🎉 Comments Work! 🥳"
 
@@ -52,4 +58,89 @@ var _ = Describe("Build", func() {
 			})
 		}
 	})
+
+	Describe("CollapsedInto", func() {
+		It("is set when the build was collapsed into another", func() {
+			dbBuild.CollapsedIntoReturns(42)
+
+			Expect(present.Build(&dbBuild, nil, nil).CollapsedInto).To(Equal(42))
+		})
+	})
+
+	Describe("RetryOf", func() {
+		It("is set when the build retries another after a worker error", func() {
+			dbBuild.RetryOfReturns(42)
+
+			Expect(present.Build(&dbBuild, nil, nil).RetryOf).To(Equal(42))
+		})
+	})
+
+	Describe("FailureReason", func() {
+		It("is presented as recorded on the build", func() {
+			dbBuild.FailureReasonReturns(atc.FailureReasonTimedOut)
+
+			Expect(present.Build(&dbBuild, nil, nil).FailureReason).To(Equal(atc.FailureReasonTimedOut))
+		})
+	})
+
+	Describe("StartLatency", func() {
+		It("is presented as recorded on the build", func() {
+			dbBuild.StartLatencyReturns(&atc.BuildStartLatency{Scheduling: 100, ImageFetch: 2000, Total: 2500})
+
+			Expect(present.Build(&dbBuild, nil, nil).StartLatency).To(Equal(&atc.BuildStartLatency{
+				Scheduling: 100,
+				ImageFetch: 2000,
+				Total:      2500,
+			}))
+		})
+
+		It("is left out until the build has started a task", func() {
+			Expect(present.Build(&dbBuild, nil, nil).StartLatency).To(BeNil())
+		})
+	})
+
+	Describe("EstimatedDuration", func() {
+		It("is not set when the job has no estimate", func() {
+			build := present.Build(&dbBuild, nil, nil)
+			Expect(build.EstimatedDuration).To(BeZero())
+			Expect(build.EstimatedEndTime).To(BeZero())
+			Expect(build.Progress).To(BeZero())
+		})
+
+		Context("when the job has an estimate", func() {
+			BeforeEach(func() {
+				dbBuild.EstimatedDurationReturns(10*time.Minute, true)
+			})
+
+			It("is set without progress when the build isn't running", func() {
+				build := present.Build(&dbBuild, nil, nil)
+				Expect(build.EstimatedDuration).To(Equal(int64(600)))
+				Expect(build.EstimatedEndTime).To(BeZero())
+				Expect(build.Progress).To(BeZero())
+			})
+
+			Context("when the build is running", func() {
+				var startTime time.Time
+
+				BeforeEach(func() {
+					startTime = time.Now().Add(-5 * time.Minute)
+
+					dbBuild.IsRunningReturns(true)
+					dbBuild.StartTimeReturns(startTime)
+				})
+
+				It("estimates when it ends and how far along it is", func() {
+					build := present.Build(&dbBuild, nil, nil)
+					Expect(build.EstimatedEndTime).To(Equal(startTime.Add(10 * time.Minute).Unix()))
+					Expect(build.Progress).To(BeNumerically("~", 0.5, 0.01))
+				})
+
+				It("stays short of done when the build overruns", func() {
+					dbBuild.StartTimeReturns(time.Now().Add(-time.Hour))
+
+					Expect(present.Build(&dbBuild, nil, nil).Progress).To(Equal(0.99))
+				})
+			})
+		})
+	})
 })
@@ -22,6 +22,10 @@ func Pipeline(savedPipeline db.Pipeline) atc.Pipeline {
 		LastUpdated:   savedPipeline.LastUpdated().Unix(),
 	}
 
+	if exposure := savedPipeline.Exposure(); exposure.Restricted() {
+		atcPipeline.Exposure = &exposure
+	}
+
 	if !savedPipeline.PausedAt().IsZero() {
 		atcPipeline.PausedAt = savedPipeline.PausedAt().Unix()
 	}
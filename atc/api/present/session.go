@@ -0,0 +1,21 @@
+package present
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func Session(session db.Session, current bool) atc.Session {
+	presented := atc.Session{
+		ID:        session.ID,
+		CreatedAt: session.CreatedAt.Unix(),
+		ExpiresAt: session.ExpiresAt.Unix(),
+		Current:   current,
+	}
+
+	if len(session.Claims.Audience) > 0 {
+		presented.ClientID = session.Claims.Audience[0]
+	}
+
+	return presented
+}
@@ -34,12 +34,16 @@ func Worker(workerInfo db.Worker) atc.Worker {
 		ActiveTasks:      activeTasks,
 		ResourceTypes:    workerInfo.ResourceTypes(),
 		Platform:         workerInfo.Platform(),
+		Runtime:          workerInfo.Runtime(),
 		Tags:             workerInfo.Tags(),
 		Name:             workerInfo.Name(),
 		Team:             workerInfo.TeamName(),
 		State:            string(workerInfo.State()),
 		Version:          version,
 		Ephemeral:        workerInfo.Ephemeral(),
+		Certificate:      workerInfo.Certificate(),
+		Capabilities:     workerInfo.Capabilities(),
+		DiskUsage:        workerInfo.DiskUsage(),
 	}
 
 	if !workerInfo.StartTime().IsZero() {
@@ -0,0 +1,106 @@
+package api_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Request Log API", func() {
+	Describe("PUT /api/v1/request-log", func() {
+		var (
+			payload string
+
+			response *http.Response
+		)
+
+		BeforeEach(func() {
+			payload = `{"sample_rate":0.1,"slow_threshold":"2s"}`
+		})
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequest("PUT", server.URL+"/api/v1/request-log", bytes.NewBufferString(payload))
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+			})
+
+			Context("is admin", func() {
+				BeforeEach(func() {
+					fakeAccess.IsAdminReturns(true)
+				})
+
+				It("changes how requests are logged", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+
+					Expect(requestLogRecorder.Settings()).To(Equal(atc.RequestLogSettings{
+						SampleRate:    0.1,
+						SlowThreshold: "2s",
+					}))
+				})
+
+				Describe("GET /api/v1/request-log", func() {
+					var getResponse *http.Response
+
+					JustBeforeEach(func() {
+						var err error
+						getResponse, err = client.Get(server.URL + "/api/v1/request-log")
+						Expect(err).NotTo(HaveOccurred())
+					})
+
+					It("returns the settings", func() {
+						Expect(getResponse.StatusCode).To(Equal(http.StatusOK))
+						Expect(ioutil.ReadAll(getResponse.Body)).To(MatchJSON(`{"sample_rate":0.1,"slow_threshold":"2s"}`))
+					})
+				})
+
+				Context("when the settings are invalid", func() {
+					BeforeEach(func() {
+						payload = `{"sample_rate":2}`
+					})
+
+					It("returns Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+						Expect(ioutil.ReadAll(response.Body)).To(ContainSubstring("invalid sample rate"))
+					})
+				})
+
+				Context("when the settings are malformed", func() {
+					BeforeEach(func() {
+						payload = `{`
+					})
+
+					It("returns Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+			})
+
+			Context("is not admin", func() {
+				It("return 403 Forbidden", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+})
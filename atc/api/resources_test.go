@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"strings"
 	"time"
 
 	. "github.com/onsi/ginkgo"
@@ -606,6 +607,124 @@ var _ = Describe("Resources API", func() {
 		})
 	})
 
+	Describe("PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/check_from", func() {
+		var response *http.Response
+		var requestBody string
+		var fakeResource *dbfakes.FakeResource
+
+		BeforeEach(func() {
+			requestBody = `{"version":{"ref":"abc"}}`
+		})
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/resources/resource-name/check_from", strings.NewReader(requestBody))
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+			})
+
+			Context("when finding the resource succeeds", func() {
+				BeforeEach(func() {
+					fakeResource = new(dbfakes.FakeResource)
+					fakeResource.ResetCheckFromReturns(true, nil)
+					fakePipeline.ResourceReturns(fakeResource, true, nil)
+				})
+
+				It("resets the check to start from the version", func() {
+					Expect(fakePipeline.ResourceArgsForCall(0)).To(Equal("resource-name"))
+					Expect(fakeResource.ResetCheckFromCallCount()).To(Equal(1))
+					Expect(fakeResource.ResetCheckFromArgsForCall(0)).To(Equal(atc.Version{"ref": "abc"}))
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				Context("when no version is given", func() {
+					BeforeEach(func() {
+						requestBody = ""
+					})
+
+					It("resets the check to start from scratch", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(fakeResource.ResetCheckFromArgsForCall(0)).To(BeNil())
+					})
+				})
+
+				Context("when the resource has never been checked", func() {
+					BeforeEach(func() {
+						fakeResource.ResetCheckFromReturns(false, nil)
+					})
+
+					It("returns 409", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusConflict))
+					})
+				})
+
+				Context("when the resource is in push mode", func() {
+					BeforeEach(func() {
+						fakeResource.ConfigReturns(atc.ResourceConfig{
+							Push: &atc.PushConfig{Version: []string{"ref"}},
+						})
+					})
+
+					It("returns 409 without resetting it", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusConflict))
+						Expect(fakeResource.ResetCheckFromCallCount()).To(Equal(0))
+					})
+				})
+
+				Context("when resetting fails", func() {
+					BeforeEach(func() {
+						fakeResource.ResetCheckFromReturns(false, errors.New("nope"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+
+				Context("when the request body is malformed", func() {
+					BeforeEach(func() {
+						requestBody = `{"version":`
+					})
+
+					It("returns 400", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+						Expect(fakeResource.ResetCheckFromCallCount()).To(Equal(0))
+					})
+				})
+			})
+
+			Context("when the resource is not found", func() {
+				BeforeEach(func() {
+					fakePipeline.ResourceReturns(nil, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+		})
+	})
+
 	Describe("POST /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/check", func() {
 		var checkRequestBody atc.CheckRequestBody
 		var response *http.Response
@@ -668,6 +787,19 @@ var _ = Describe("Resources API", func() {
 					})
 				})
 
+				Context("when the resource is in push mode", func() {
+					BeforeEach(func() {
+						fakeResource.ConfigReturns(atc.ResourceConfig{
+							Push: &atc.PushConfig{Version: []string{"ref"}},
+						})
+					})
+
+					It("returns 409 without checking it", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusConflict))
+						Expect(dbCheckFactory.TryCreateCheckCallCount()).To(Equal(0))
+					})
+				})
+
 				Context("when looking up the resource types succeeds", func() {
 					var fakeResourceTypes db.ResourceTypes
 
@@ -782,6 +914,171 @@ var _ = Describe("Resources API", func() {
 		})
 	})
 
+	Describe("POST /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_config_version_id/metadata", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequest("POST", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/resources/resource-name/versions/42/metadata", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+			})
+
+			Context("when the resource is not found", func() {
+				BeforeEach(func() {
+					fakePipeline.ResourceReturns(nil, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when it finds the resource", func() {
+				var fakeResource *dbfakes.FakeResource
+
+				BeforeEach(func() {
+					fakeResource = new(dbfakes.FakeResource)
+					fakePipeline.ResourceReturns(fakeResource, true, nil)
+				})
+
+				Context("when the version is not found", func() {
+					BeforeEach(func() {
+						fakeResource.VersionByIDReturns(nil, false, nil)
+					})
+
+					It("returns 404", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+						Expect(fakeResource.VersionByIDArgsForCall(0)).To(Equal(42))
+					})
+				})
+
+				Context("when the version is found", func() {
+					BeforeEach(func() {
+						fakeResource.VersionByIDReturns(atc.Version{"ref": "v1"}, true, nil)
+						fakePipeline.ResourceTypesReturns(db.ResourceTypes{}, nil)
+					})
+
+					Context("when creating the build succeeds", func() {
+						BeforeEach(func() {
+							fakeBuild := new(dbfakes.FakeBuild)
+							fakeBuild.IDReturns(10)
+							fakeBuild.NameReturns("check")
+							fakeBuild.TeamNameReturns("some-team")
+							fakeBuild.StatusReturns("started")
+
+							dbCheckFactory.CreateMetadataFetchReturns(fakeBuild, nil)
+						})
+
+						It("fetches the version", func() {
+							Expect(dbCheckFactory.CreateMetadataFetchCallCount()).To(Equal(1))
+							_, actualResource, actualResourceTypes, actualVersions := dbCheckFactory.CreateMetadataFetchArgsForCall(0)
+							Expect(actualResource).To(Equal(fakeResource))
+							Expect(actualResourceTypes).To(Equal(db.ResourceTypes{}))
+							Expect(actualVersions).To(Equal([]atc.Version{{"ref": "v1"}}))
+						})
+
+						It("returns 201 with the build", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusCreated))
+							Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`{
+								"id": 10,
+								"name": "check",
+								"team_name": "some-team",
+								"status": "started",
+								"api_url": "/api/v1/builds/10"
+							}`))
+						})
+					})
+
+					Context("when creating the build fails", func() {
+						BeforeEach(func() {
+							dbCheckFactory.CreateMetadataFetchReturns(nil, errors.New("nope"))
+						})
+
+						It("returns 500", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+						})
+					})
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("POST /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/metadata", func() {
+		var (
+			response     *http.Response
+			fakeResource *dbfakes.FakeResource
+		)
+
+		BeforeEach(func() {
+			fakeAccess.IsAuthenticatedReturns(true)
+			fakeAccess.IsAuthorizedReturns(true)
+
+			fakeResource = new(dbfakes.FakeResource)
+			fakePipeline.ResourceReturns(fakeResource, true, nil)
+		})
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequest("POST", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/resources/resource-name/metadata", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when every version has metadata", func() {
+			BeforeEach(func() {
+				fakeResource.VersionsWithoutMetadataReturns(nil, nil)
+			})
+
+			It("returns 204 without creating a build", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+				Expect(dbCheckFactory.CreateMetadataFetchCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when some versions have no metadata", func() {
+			BeforeEach(func() {
+				fakeResource.VersionsWithoutMetadataReturns([]atc.Version{{"ref": "v2"}, {"ref": "v1"}}, nil)
+				dbCheckFactory.CreateMetadataFetchReturns(new(dbfakes.FakeBuild), nil)
+			})
+
+			It("fetches each of them", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusCreated))
+
+				_, _, _, actualVersions := dbCheckFactory.CreateMetadataFetchArgsForCall(0)
+				Expect(actualVersions).To(Equal([]atc.Version{{"ref": "v2"}, {"ref": "v1"}}))
+			})
+		})
+
+		Context("when getting the versions fails", func() {
+			BeforeEach(func() {
+				fakeResource.VersionsWithoutMetadataReturns(nil, errors.New("nope"))
+			})
+
+			It("returns 500", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+			})
+		})
+	})
+
 	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/resource-types", func() {
 		var response *http.Response
 
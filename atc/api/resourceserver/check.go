@@ -42,6 +42,12 @@ func (s *Server) CheckResource(dbPipeline db.Pipeline) http.Handler {
 			return
 		}
 
+		if dbResource.Config().Push != nil {
+			logger.Info("resource-in-push-mode")
+			http.Error(w, "resource is in push mode, its versions are pushed rather than checked", http.StatusConflict)
+			return
+		}
+
 		dbResourceTypes, err := dbPipeline.ResourceTypes()
 		if err != nil {
 			logger.Error("failed-to-get-resource-types", err)
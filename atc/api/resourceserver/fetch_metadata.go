@@ -0,0 +1,129 @@
+package resourceserver
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/present"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/tedsuo/rata"
+)
+
+// FetchResourceVersionMetadata fetches an existing version of the resource
+// again in order to update its metadata.
+func (s *Server) FetchResourceVersionMetadata(dbPipeline db.Pipeline) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resourceName := rata.Param(r, "resource_name")
+
+		logger := s.logger.Session("fetch-resource-version-metadata", lager.Data{
+			"resource": resourceName,
+		})
+
+		versionID, err := strconv.Atoi(rata.Param(r, "resource_config_version_id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		dbResource, found, err := dbPipeline.Resource(resourceName)
+		if err != nil {
+			logger.Error("failed-to-get-resource", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			logger.Info("resource-not-found")
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		version, found, err := dbResource.VersionByID(versionID)
+		if err != nil {
+			logger.Error("failed-to-get-resource-version", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			logger.Info("resource-version-not-found", lager.Data{"resource_config_version_id": versionID})
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		s.createMetadataFetch(logger, w, dbPipeline, dbResource, []atc.Version{version})
+	})
+}
+
+// BackfillResourceMetadata fetches every version of the resource which has no
+// metadata, e.g. because it was saved before the resource type emitted any.
+func (s *Server) BackfillResourceMetadata(dbPipeline db.Pipeline) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resourceName := rata.Param(r, "resource_name")
+
+		logger := s.logger.Session("backfill-resource-metadata", lager.Data{
+			"resource": resourceName,
+		})
+
+		dbResource, found, err := dbPipeline.Resource(resourceName)
+		if err != nil {
+			logger.Error("failed-to-get-resource", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			logger.Info("resource-not-found")
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		versions, err := dbResource.VersionsWithoutMetadata()
+		if err != nil {
+			logger.Error("failed-to-get-versions-without-metadata", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if len(versions) == 0 {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		s.createMetadataFetch(logger, w, dbPipeline, dbResource, versions)
+	})
+}
+
+func (s *Server) createMetadataFetch(logger lager.Logger, w http.ResponseWriter, dbPipeline db.Pipeline, dbResource db.Resource, versions []atc.Version) {
+	dbResourceTypes, err := dbPipeline.ResourceTypes()
+	if err != nil {
+		logger.Error("failed-to-get-resource-types", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	build, err := s.checkFactory.CreateMetadataFetch(
+		lagerctx.NewContext(context.Background(), logger),
+		dbResource,
+		dbResourceTypes,
+		versions,
+	)
+	if err != nil {
+		logger.Error("failed-to-create-metadata-fetch", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+
+	err = json.NewEncoder(w).Encode(present.Build(build, nil, nil))
+	if err != nil {
+		logger.Error("failed-to-encode-build", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
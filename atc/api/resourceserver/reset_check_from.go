@@ -0,0 +1,65 @@
+package resourceserver
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) ResetResourceCheckFrom(pipeline db.Pipeline) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resourceName := r.FormValue(":resource_name")
+
+		logger := s.logger.Session("reset-resource-check-from", lager.Data{
+			"resource": resourceName,
+		})
+
+		// the body is optional, without a version the next check starts from
+		// scratch
+		var reqBody atc.ResetCheckFromRequestBody
+		err := json.NewDecoder(r.Body).Decode(&reqBody)
+		if err != nil && err != io.EOF {
+			logger.Info("malformed-request", lager.Data{"error": err.Error()})
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		resource, found, err := pipeline.Resource(resourceName)
+		if err != nil {
+			logger.Error("failed-to-get-resource", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			logger.Info("resource-not-found")
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if resource.Config().Push != nil {
+			http.Error(w, "resource is in push mode, its versions are pushed rather than checked", http.StatusConflict)
+			return
+		}
+
+		reset, err := resource.ResetCheckFrom(reqBody.Version)
+		if err != nil {
+			logger.Error("failed-to-reset-check-from", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !reset {
+			http.Error(w, "resource has not been checked yet", http.StatusConflict)
+			return
+		}
+
+		logger.Info("reset", lager.Data{"version": reqBody.Version})
+
+		w.WriteHeader(http.StatusOK)
+	})
+}
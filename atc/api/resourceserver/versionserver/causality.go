@@ -67,7 +67,7 @@ func (s *Server) getResourceCausality(direction db.CausalityDirection, pipeline
 		}
 
 		w.Header().Set("Content-Type", "application/json")
-		err = json.NewEncoder(w).Encode(causality)
+		err = json.NewEncoder(w).Encode(exposedCausality(causality, jobVisible(r, pipeline)))
 		if err != nil {
 			logger.Error("failed-to-encode", err, lager.Data{"resource-name": resourceName, "resource-config-version": versionID})
 			w.WriteHeader(http.StatusInternalServerError)
@@ -0,0 +1,66 @@
+package versionserver
+
+import (
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/accessor"
+	"github.com/concourse/concourse/atc/db"
+)
+
+// jobVisible returns whether the builds of a job can be shown for the
+// request. Members of the pipeline's team see every job, while everyone else
+// only sees the jobs the pipeline exposes.
+func jobVisible(r *http.Request, pipeline db.Pipeline) func(jobName string) bool {
+	if accessor.GetAccessor(r).IsAuthorized(pipeline.TeamName()) {
+		return func(string) bool { return true }
+	}
+
+	return pipeline.Exposure().JobExposed
+}
+
+// exposedCausality leaves the jobs which are not visible out of the
+// causality, along with their builds.
+func exposedCausality(causality atc.Causality, visible func(jobName string) bool) atc.Causality {
+	hiddenJobs := map[int]bool{}
+	jobs := causality.Jobs[:0]
+	for _, job := range causality.Jobs {
+		if !visible(job.Name) {
+			hiddenJobs[job.ID] = true
+			continue
+		}
+
+		jobs = append(jobs, job)
+	}
+
+	if len(hiddenJobs) == 0 {
+		return causality
+	}
+
+	hiddenBuilds := map[int]bool{}
+	builds := causality.Builds[:0]
+	for _, build := range causality.Builds {
+		if hiddenJobs[build.JobId] {
+			hiddenBuilds[build.ID] = true
+			continue
+		}
+
+		builds = append(builds, build)
+	}
+
+	for i, version := range causality.ResourceVersions {
+		var buildIDs []int
+		for _, buildID := range version.BuildIDs {
+			if !hiddenBuilds[buildID] {
+				buildIDs = append(buildIDs, buildID)
+			}
+		}
+
+		causality.ResourceVersions[i].BuildIDs = buildIDs
+	}
+
+	causality.Jobs = jobs
+	causality.Builds = builds
+
+	return causality
+}
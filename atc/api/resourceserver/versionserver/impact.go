@@ -0,0 +1,147 @@
+package versionserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/accessor"
+	"github.com/concourse/concourse/atc/db"
+)
+
+const queryAcrossPipelines = "across_pipelines"
+
+func (s *Server) GetResourceVersionImpact(pipeline db.Pipeline) http.Handler {
+	logger := s.logger.Session("get-resource-version-impact")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !atc.EnableResourceCausality {
+			logger.Info("causality-disabled")
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		teamName := r.FormValue(":team_name")
+		resourceName := r.FormValue(":resource_name")
+		versionID, err := strconv.Atoi(r.FormValue(":resource_config_version_id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		acrossPipelines, _ := strconv.ParseBool(r.FormValue(queryAcrossPipelines))
+
+		// the pipeline may be public, but the team's other pipelines may not
+		if acrossPipelines && !accessor.GetAccessor(r).IsAuthorized(teamName) {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		limit, _ := strconv.Atoi(r.FormValue(atc.PaginationQueryLimit))
+		if limit == 0 {
+			limit = atc.PaginationAPIDefaultLimit
+		}
+
+		page := db.Page{Limit: limit}
+		if from, err := strconv.Atoi(r.FormValue(atc.PaginationQueryFrom)); err == nil {
+			page.From = db.NewIntPtr(from)
+		}
+		if to, err := strconv.Atoi(r.FormValue(atc.PaginationQueryTo)); err == nil {
+			page.To = db.NewIntPtr(to)
+		}
+
+		resource, found, err := pipeline.Resource(resourceName)
+		if err != nil {
+			logger.Error("failed-to-get-resource", err, lager.Data{"resource-name": resourceName})
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			logger.Info("resource-not-found", lager.Data{"resource-name": resourceName})
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		builds, pagination, found, err := resource.DownstreamImpact(versionID, acrossPipelines, page)
+		if err != nil {
+			if err == db.ErrTooManyBuilds {
+				logger.Error("too-many-builds", err, lager.Data{"resource-name": resourceName, "resource-config-version": versionID})
+				w.WriteHeader(http.StatusUnprocessableEntity)
+				return
+			}
+
+			logger.Error("failed-to-fetch", err, lager.Data{"resource-name": resourceName, "resource-config-version": versionID})
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			logger.Info("resource-version-not-found", lager.Data{"resource-name": resourceName, "resource-config-version": versionID})
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		// only members can trace across pipelines, so anyone else only gets
+		// builds of this pipeline
+		visible := jobVisible(r, pipeline)
+
+		exposedBuilds := []atc.ImpactedBuild{}
+		for _, build := range builds {
+			if visible(build.JobName) {
+				exposedBuilds = append(exposedBuilds, build)
+			}
+		}
+
+		query := url.Values{}
+		for k, v := range (atc.PipelineRef{Name: pipeline.Name(), InstanceVars: pipeline.InstanceVars()}).QueryParams() {
+			query[k] = v
+		}
+
+		if acrossPipelines {
+			query.Set(queryAcrossPipelines, "true")
+		}
+
+		path := fmt.Sprintf(
+			"%s/api/v1/teams/%s/pipelines/%s/resources/%s/versions/%d/impact",
+			s.externalURL,
+			url.PathEscape(teamName),
+			url.PathEscape(pipeline.Name()),
+			url.PathEscape(resourceName),
+			versionID,
+		)
+
+		if pagination.Older != nil {
+			addImpactLink(w, path, query, atc.PaginationQueryTo, *pagination.Older.To, limit, atc.LinkRelNext)
+		}
+
+		if pagination.Newer != nil {
+			addImpactLink(w, path, query, atc.PaginationQueryFrom, *pagination.Newer.From, limit, atc.LinkRelPrevious)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		w.WriteHeader(http.StatusOK)
+
+		err = json.NewEncoder(w).Encode(exposedBuilds)
+		if err != nil {
+			logger.Error("failed-to-encode-builds", err)
+		}
+	})
+}
+
+func addImpactLink(w http.ResponseWriter, path string, query url.Values, boundary string, id int, limit int, rel string) {
+	linkQuery := url.Values{}
+	for k, v := range query {
+		linkQuery[k] = v
+	}
+
+	linkQuery.Set(boundary, strconv.Itoa(id))
+	linkQuery.Set(atc.PaginationQueryLimit, strconv.Itoa(limit))
+
+	w.Header().Add("Link", fmt.Sprintf(`<%s?%s>; rel="%s"`, path, linkQuery.Encode(), rel))
+}
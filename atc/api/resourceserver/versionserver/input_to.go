@@ -38,8 +38,14 @@ func (s *Server) ListBuildsWithVersionAsInput(pipeline db.Pipeline) http.Handler
 			return
 		}
 
+		visible := jobVisible(r, pipeline)
+
 		presentedBuilds := []atc.Build{}
 		for _, build := range builds {
+			if !visible(build.JobName()) {
+				continue
+			}
+
 			presentedBuilds = append(presentedBuilds, present.Build(build, nil, nil))
 		}
 
@@ -38,8 +38,14 @@ func (s *Server) ListBuildsWithVersionAsOutput(pipeline db.Pipeline) http.Handle
 			return
 		}
 
+		visible := jobVisible(r, pipeline)
+
 		presentedBuilds := []atc.Build{}
 		for _, build := range builds {
+			if !visible(build.JobName()) {
+				continue
+			}
+
 			presentedBuilds = append(presentedBuilds, present.Build(build, nil, nil))
 		}
 
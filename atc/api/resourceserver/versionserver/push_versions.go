@@ -0,0 +1,79 @@
+package versionserver
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+// MaxPushedVersions is the most versions that can be pushed in one request.
+const MaxPushedVersions = 1000
+
+func (s *Server) PushResourceVersions(pipeline db.Pipeline) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("push-resource-versions")
+		resourceName := r.FormValue(":resource_name")
+
+		var versions []atc.Version
+		err := json.NewDecoder(r.Body).Decode(&versions)
+		if err != nil {
+			logger.Info("malformed-request", lager.Data{"error": err.Error()})
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if len(versions) > MaxPushedVersions {
+			http.Error(w, fmt.Sprintf("cannot push more than %d versions at once", MaxPushedVersions), http.StatusBadRequest)
+			return
+		}
+
+		resource, found, err := pipeline.Resource(resourceName)
+		if err != nil {
+			logger.Error("failed-to-get-resource", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			logger.Debug("resource-not-found", lager.Data{"resource": resourceName})
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		push := resource.Config().Push
+		if push == nil {
+			http.Error(w, "resource is not in push mode", http.StatusConflict)
+			return
+		}
+
+		for i, version := range versions {
+			err := push.ValidateVersion(version)
+			if err != nil {
+				logger.Info("invalid-version", lager.Data{"error": err.Error()})
+				http.Error(w, fmt.Sprintf("version %d: %s", i, err), http.StatusBadRequest)
+				return
+			}
+		}
+
+		err = resource.PushVersions(db.NewSpanContext(r.Context()), versions)
+		if err != nil {
+			var notFoundErr db.BaseResourceTypeNotFoundError
+			if errors.As(err, &notFoundErr) {
+				logger.Info("not-a-base-resource-type", lager.Data{"error": err.Error()})
+				http.Error(w, "push mode is only supported for resources of a base resource type", http.StatusUnprocessableEntity)
+				return
+			}
+
+			logger.Error("failed-to-push-versions", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		s.writeJSONResponse(w, atc.PushVersionsResponse{VersionsPushed: len(versions)})
+	})
+}
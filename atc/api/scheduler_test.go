@@ -0,0 +1,100 @@
+package api_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Scheduler API", func() {
+	Describe("GET /api/v1/scheduler/profile", func() {
+		var response *http.Response
+
+		BeforeEach(func() {
+			dbSchedulingStats.SchedulingStatsReturns([]atc.PipelineSchedulingStats{
+				{
+					PipelineID:      1,
+					PipelineName:    "slow-pipeline",
+					TeamName:        "some-team",
+					TickStartedAt:   100,
+					Jobs:            3,
+					LoadingVersions: 1.5,
+					ResolvingInputs: 200,
+					CreatingBuilds:  10,
+					Total:           211.5,
+				},
+			}, nil)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/scheduler/profile")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated as an admin", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAdminReturns(true)
+			})
+
+			It("returns 200 with the scheduling stats of each pipeline", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(body).To(MatchJSON(`[
+					{
+						"pipeline_id": 1,
+						"pipeline_name": "slow-pipeline",
+						"team_name": "some-team",
+						"tick_started_at": 100,
+						"jobs": 3,
+						"loading_versions_ms": 1.5,
+						"resolving_inputs_ms": 200,
+						"creating_builds_ms": 10,
+						"total_ms": 211.5
+					}
+				]`))
+			})
+
+			Context("when getting the stats fails", func() {
+				BeforeEach(func() {
+					dbSchedulingStats.SchedulingStatsReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when authenticated but not an admin", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAdminReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(dbSchedulingStats.SchedulingStatsCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+})
@@ -0,0 +1,28 @@
+package schedulerserver
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// GetSchedulerProfile returns how long the last scheduling tick of each
+// pipeline took, broken down by phase, the slowest pipelines first.
+func (s *Server) GetSchedulerProfile(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("get-scheduler-profile")
+
+	stats, err := s.stats.SchedulingStats()
+	if err != nil {
+		logger.Error("failed-to-get-scheduling-stats", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	err = json.NewEncoder(w).Encode(stats)
+	if err != nil {
+		logger.Error("failed-to-encode-scheduling-stats", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,21 @@
+package schedulerserver
+
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/db"
+)
+
+type Server struct {
+	logger lager.Logger
+	stats  db.SchedulingStatsRepository
+}
+
+func NewServer(
+	logger lager.Logger,
+	stats db.SchedulingStatsRepository,
+) *Server {
+	return &Server{
+		logger: logger,
+		stats:  stats,
+	}
+}
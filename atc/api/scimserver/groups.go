@@ -0,0 +1,353 @@
+package scimserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/tedsuo/rata"
+)
+
+func (s *Server) ListGroups(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("list-groups")
+
+	displayName, err := parseFilter(r, "displayName")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalidFilter", err.Error())
+		return
+	}
+
+	groups, err := s.repository.SCIMGroups(displayName)
+	if err != nil {
+		logger.Error("failed-to-get-groups", err)
+		writeError(w, http.StatusInternalServerError, "", "failed to get groups")
+		return
+	}
+
+	startIndex, count := paginate(r)
+	from, to := page(len(groups), startIndex, count)
+
+	resources := []interface{}{}
+	for _, group := range groups[from:to] {
+		resources = append(resources, s.presentGroup(group))
+	}
+
+	writeList(w, resources, len(groups), startIndex)
+}
+
+func (s *Server) CreateGroup(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("create-group")
+
+	group, ok := decodeGroup(w, r)
+	if !ok {
+		return
+	}
+
+	created, err := s.repository.CreateSCIMGroup(group)
+	if err != nil {
+		s.writeGroupError(w, logger, err)
+		return
+	}
+
+	logger.Info("created", lager.Data{"id": created.ID, "display_name": created.DisplayName, "members": len(created.Members)})
+
+	writeJSON(w, http.StatusCreated, s.presentGroup(created))
+}
+
+func (s *Server) GetGroup(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("get-group")
+
+	group, found, err := s.repository.SCIMGroup(rata.Param(r, "id"))
+	if err != nil {
+		logger.Error("failed-to-get-group", err)
+		writeError(w, http.StatusInternalServerError, "", "failed to get group")
+		return
+	}
+
+	if !found {
+		writeError(w, http.StatusNotFound, "", "group not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, s.presentGroup(group))
+}
+
+func (s *Server) ReplaceGroup(w http.ResponseWriter, r *http.Request) {
+	group, ok := decodeGroup(w, r)
+	if !ok {
+		return
+	}
+
+	group.ID = rata.Param(r, "id")
+
+	s.updateGroup(w, s.logger.Session("replace-group"), group)
+}
+
+func (s *Server) PatchGroup(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("patch-group")
+
+	var patch PatchRequest
+	err := json.NewDecoder(r.Body).Decode(&patch)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalidSyntax", fmt.Sprintf("malformed request: %s", err))
+		return
+	}
+
+	group, found, err := s.repository.SCIMGroup(rata.Param(r, "id"))
+	if err != nil {
+		logger.Error("failed-to-get-group", err)
+		writeError(w, http.StatusInternalServerError, "", "failed to get group")
+		return
+	}
+
+	if !found {
+		writeError(w, http.StatusNotFound, "", "group not found")
+		return
+	}
+
+	for _, op := range patch.Operations {
+		err := patchGroup(&group, op)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalidValue", err.Error())
+			return
+		}
+	}
+
+	s.updateGroup(w, logger, group)
+}
+
+func (s *Server) DeleteGroup(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("delete-group")
+
+	id := rata.Param(r, "id")
+
+	deleted, err := s.repository.DeleteSCIMGroup(id)
+	if err != nil {
+		logger.Error("failed-to-delete-group", err)
+		writeError(w, http.StatusInternalServerError, "", "failed to delete group")
+		return
+	}
+
+	if !deleted {
+		writeError(w, http.StatusNotFound, "", "group not found")
+		return
+	}
+
+	logger.Info("deleted", lager.Data{"id": id})
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) updateGroup(w http.ResponseWriter, logger lager.Logger, group db.SCIMGroup) {
+	if group.DisplayName == "" {
+		writeError(w, http.StatusBadRequest, "invalidValue", "displayName must be specified")
+		return
+	}
+
+	updated, found, err := s.repository.UpdateSCIMGroup(group)
+	if err != nil {
+		s.writeGroupError(w, logger, err)
+		return
+	}
+
+	if !found {
+		writeError(w, http.StatusNotFound, "", "group not found")
+		return
+	}
+
+	logger.Info("updated", lager.Data{"id": updated.ID, "display_name": updated.DisplayName, "members": len(updated.Members)})
+
+	writeJSON(w, http.StatusOK, s.presentGroup(updated))
+}
+
+func (s *Server) writeGroupError(w http.ResponseWriter, logger lager.Logger, err error) {
+	switch err {
+	case db.ErrSCIMGroupExists:
+		writeError(w, http.StatusConflict, "uniqueness", err.Error())
+	case db.ErrSCIMGroupMemberUnknown:
+		writeError(w, http.StatusBadRequest, "invalidValue", err.Error())
+	default:
+		logger.Error("failed-to-save-group", err)
+		writeError(w, http.StatusInternalServerError, "", "failed to save group")
+	}
+}
+
+func decodeGroup(w http.ResponseWriter, r *http.Request) (db.SCIMGroup, bool) {
+	var group Group
+	err := json.NewDecoder(r.Body).Decode(&group)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalidSyntax", fmt.Sprintf("malformed request: %s", err))
+		return db.SCIMGroup{}, false
+	}
+
+	if group.DisplayName == "" {
+		writeError(w, http.StatusBadRequest, "invalidValue", "displayName must be specified")
+		return db.SCIMGroup{}, false
+	}
+
+	return db.SCIMGroup{
+		DisplayName: group.DisplayName,
+		ExternalID:  group.ExternalID,
+		Members:     groupMembers(group.Members),
+	}, true
+}
+
+var memberFilterRegexp = regexp.MustCompile(`(?i)^members\[\s*value\s+eq\s+"([^"]*)"\s*\]$`)
+
+func patchGroup(group *db.SCIMGroup, op PatchOperation) error {
+	path := strings.ToLower(op.Path)
+
+	switch strings.ToLower(op.Op) {
+	case "add":
+		if path == "" {
+			return patchGroupAttributes(group, op.Value, false)
+		}
+
+		if path != "members" {
+			return patchGroupAttribute(group, path, op.Value)
+		}
+
+		var members []Member
+		err := json.Unmarshal(op.Value, &members)
+		if err != nil {
+			return fmt.Errorf("invalid value for 'members': %s", err)
+		}
+
+		group.Members = append(group.Members, groupMembers(members)...)
+
+	case "remove":
+		if match := memberFilterRegexp.FindStringSubmatch(op.Path); match != nil {
+			removeMembers(group, match[1])
+			return nil
+		}
+
+		if path != "members" {
+			return fmt.Errorf("cannot remove '%s'", op.Path)
+		}
+
+		// without a value, every member is removed
+		if len(op.Value) == 0 {
+			group.Members = nil
+			return nil
+		}
+
+		var members []Member
+		err := json.Unmarshal(op.Value, &members)
+		if err != nil {
+			return fmt.Errorf("invalid value for 'members': %s", err)
+		}
+
+		for _, member := range members {
+			removeMembers(group, member.Value)
+		}
+
+	case "replace":
+		if path == "" {
+			return patchGroupAttributes(group, op.Value, true)
+		}
+
+		return patchGroupAttribute(group, path, op.Value)
+
+	default:
+		return fmt.Errorf("unknown op '%s'", op.Op)
+	}
+
+	return nil
+}
+
+func patchGroupAttributes(group *db.SCIMGroup, value json.RawMessage, replace bool) error {
+	var values map[string]json.RawMessage
+	err := json.Unmarshal(value, &values)
+	if err != nil {
+		return fmt.Errorf("value must be an object when no path is given")
+	}
+
+	for path, value := range values {
+		path = strings.ToLower(path)
+
+		if path == "members" && !replace {
+			err = patchGroup(group, PatchOperation{Op: "add", Path: path, Value: value})
+		} else {
+			err = patchGroupAttribute(group, path, value)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func patchGroupAttribute(group *db.SCIMGroup, path string, value json.RawMessage) error {
+	var err error
+	switch path {
+	case "displayname":
+		err = json.Unmarshal(value, &group.DisplayName)
+	case "externalid":
+		err = json.Unmarshal(value, &group.ExternalID)
+	case "members":
+		var members []Member
+		err = json.Unmarshal(value, &members)
+		group.Members = groupMembers(members)
+	default:
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("invalid value for '%s': %s", path, err)
+	}
+
+	return nil
+}
+
+func removeMembers(group *db.SCIMGroup, userID string) {
+	members := []db.SCIMGroupMember{}
+	for _, member := range group.Members {
+		if member.UserID != userID {
+			members = append(members, member)
+		}
+	}
+
+	group.Members = members
+}
+
+func groupMembers(members []Member) []db.SCIMGroupMember {
+	groupMembers := []db.SCIMGroupMember{}
+	for _, member := range members {
+		if member.Value != "" {
+			groupMembers = append(groupMembers, db.SCIMGroupMember{UserID: member.Value})
+		}
+	}
+
+	return groupMembers
+}
+
+func (s *Server) presentGroup(group db.SCIMGroup) Group {
+	members := []Member{}
+	for _, member := range group.Members {
+		members = append(members, Member{
+			Value:   member.UserID,
+			Display: member.UserName,
+			Ref:     s.location("Users", member.UserID),
+		})
+	}
+
+	return Group{
+		Schemas:     []string{GroupSchema},
+		ID:          group.ID,
+		ExternalID:  group.ExternalID,
+		DisplayName: group.DisplayName,
+		Members:     members,
+		Meta: &Meta{
+			ResourceType: "Group",
+			Created:      group.CreatedAt,
+			LastModified: group.UpdatedAt,
+			Location:     s.location("Groups", group.ID),
+		},
+	}
+}
@@ -0,0 +1,46 @@
+package scimserver
+
+import "github.com/tedsuo/rata"
+
+const (
+	GetServiceProviderConfig = "GetServiceProviderConfig"
+	ListResourceTypes        = "ListResourceTypes"
+	ListSchemas              = "ListSchemas"
+
+	ListUsers   = "ListUsers"
+	CreateUser  = "CreateUser"
+	GetUser     = "GetUser"
+	ReplaceUser = "ReplaceUser"
+	PatchUser   = "PatchUser"
+	DeleteUser  = "DeleteUser"
+
+	ListGroups   = "ListGroups"
+	CreateGroup  = "CreateGroup"
+	GetGroup     = "GetGroup"
+	ReplaceGroup = "ReplaceGroup"
+	PatchGroup   = "PatchGroup"
+	DeleteGroup  = "DeleteGroup"
+)
+
+// BasePath is where the SCIM 2.0 service is served.
+const BasePath = "/scim/v2"
+
+var Routes = rata.Routes{
+	{Path: BasePath + "/ServiceProviderConfig", Method: "GET", Name: GetServiceProviderConfig},
+	{Path: BasePath + "/ResourceTypes", Method: "GET", Name: ListResourceTypes},
+	{Path: BasePath + "/Schemas", Method: "GET", Name: ListSchemas},
+
+	{Path: BasePath + "/Users", Method: "GET", Name: ListUsers},
+	{Path: BasePath + "/Users", Method: "POST", Name: CreateUser},
+	{Path: BasePath + "/Users/:id", Method: "GET", Name: GetUser},
+	{Path: BasePath + "/Users/:id", Method: "PUT", Name: ReplaceUser},
+	{Path: BasePath + "/Users/:id", Method: "PATCH", Name: PatchUser},
+	{Path: BasePath + "/Users/:id", Method: "DELETE", Name: DeleteUser},
+
+	{Path: BasePath + "/Groups", Method: "GET", Name: ListGroups},
+	{Path: BasePath + "/Groups", Method: "POST", Name: CreateGroup},
+	{Path: BasePath + "/Groups/:id", Method: "GET", Name: GetGroup},
+	{Path: BasePath + "/Groups/:id", Method: "PUT", Name: ReplaceGroup},
+	{Path: BasePath + "/Groups/:id", Method: "PATCH", Name: PatchGroup},
+	{Path: BasePath + "/Groups/:id", Method: "DELETE", Name: DeleteGroup},
+}
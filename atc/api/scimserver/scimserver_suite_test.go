@@ -0,0 +1,13 @@
+package scimserver_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestSCIMServer(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "SCIM Server Suite")
+}
@@ -0,0 +1,242 @@
+package scimserver
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/tedsuo/rata"
+)
+
+// Server is a SCIM 2.0 service provider which identity providers push their
+// users and groups to. Teams grant roles to the groups as "scim:<group>", so
+// that membership changes made in the identity provider take effect without
+// running set-team.
+type Server struct {
+	logger      lager.Logger
+	externalURL string
+	repository  db.SCIMRepository
+}
+
+// NewHandler serves the SCIM API to clients which present the token as a
+// bearer token.
+func NewHandler(
+	logger lager.Logger,
+	externalURL string,
+	token string,
+	repository db.SCIMRepository,
+) (http.Handler, error) {
+	s := &Server{
+		logger:      logger,
+		externalURL: strings.TrimRight(externalURL, "/"),
+		repository:  repository,
+	}
+
+	router, err := rata.NewRouter(Routes, rata.Handlers{
+		GetServiceProviderConfig: http.HandlerFunc(s.GetServiceProviderConfig),
+		ListResourceTypes:        http.HandlerFunc(s.ListResourceTypes),
+		ListSchemas:              http.HandlerFunc(s.ListSchemas),
+
+		ListUsers:   http.HandlerFunc(s.ListUsers),
+		CreateUser:  http.HandlerFunc(s.CreateUser),
+		GetUser:     http.HandlerFunc(s.GetUser),
+		ReplaceUser: http.HandlerFunc(s.ReplaceUser),
+		PatchUser:   http.HandlerFunc(s.PatchUser),
+		DeleteUser:  http.HandlerFunc(s.DeleteUser),
+
+		ListGroups:   http.HandlerFunc(s.ListGroups),
+		CreateGroup:  http.HandlerFunc(s.CreateGroup),
+		GetGroup:     http.HandlerFunc(s.GetGroup),
+		ReplaceGroup: http.HandlerFunc(s.ReplaceGroup),
+		PatchGroup:   http.HandlerFunc(s.PatchGroup),
+		DeleteGroup:  http.HandlerFunc(s.DeleteGroup),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return tokenHandler{
+		token:   token,
+		handler: router,
+	}, nil
+}
+
+type tokenHandler struct {
+	token   string
+	handler http.Handler
+}
+
+func (h tokenHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	header := r.Header.Get("Authorization")
+	if len(header) < 7 || !strings.EqualFold(header[:7], "bearer ") ||
+		subtle.ConstantTimeCompare([]byte(header[7:]), []byte(h.token)) != 1 {
+		writeError(w, http.StatusUnauthorized, "", "a valid bearer token is required")
+		return
+	}
+
+	h.handler.ServeHTTP(w, r)
+}
+
+func (s *Server) GetServiceProviderConfig(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"schemas":        []string{ServiceProviderConfigSchema},
+		"patch":          map[string]bool{"supported": true},
+		"bulk":           map[string]interface{}{"supported": false, "maxOperations": 0, "maxPayloadSize": 0},
+		"filter":         map[string]interface{}{"supported": true, "maxResults": maxResults},
+		"changePassword": map[string]bool{"supported": false},
+		"sort":           map[string]bool{"supported": false},
+		"etag":           map[string]bool{"supported": false},
+		"authenticationSchemes": []map[string]interface{}{{
+			"type":        "oauthbearertoken",
+			"name":        "Bearer Token",
+			"description": "The token configured with --scim-token",
+			"primary":     true,
+		}},
+	})
+}
+
+func (s *Server) ListResourceTypes(w http.ResponseWriter, r *http.Request) {
+	writeList(w, []interface{}{
+		map[string]interface{}{
+			"schemas":  []string{ResourceTypeSchema},
+			"id":       "User",
+			"name":     "User",
+			"endpoint": "/Users",
+			"schema":   UserSchema,
+		},
+		map[string]interface{}{
+			"schemas":  []string{ResourceTypeSchema},
+			"id":       "Group",
+			"name":     "Group",
+			"endpoint": "/Groups",
+			"schema":   GroupSchema,
+		},
+	}, 2, 1)
+}
+
+func (s *Server) ListSchemas(w http.ResponseWriter, r *http.Request) {
+	attribute := func(name string, typ string, multiValued bool, required bool) map[string]interface{} {
+		return map[string]interface{}{
+			"name":        name,
+			"type":        typ,
+			"multiValued": multiValued,
+			"required":    required,
+		}
+	}
+
+	writeList(w, []interface{}{
+		map[string]interface{}{
+			"schemas": []string{SchemaSchema},
+			"id":      UserSchema,
+			"name":    "User",
+			"attributes": []interface{}{
+				attribute("userName", "string", false, true),
+				attribute("displayName", "string", false, false),
+				attribute("emails", "complex", true, false),
+				attribute("active", "boolean", false, false),
+			},
+		},
+		map[string]interface{}{
+			"schemas": []string{SchemaSchema},
+			"id":      GroupSchema,
+			"name":    "Group",
+			"attributes": []interface{}{
+				attribute("displayName", "string", false, true),
+				attribute("members", "complex", true, false),
+			},
+		},
+	}, 2, 1)
+}
+
+const maxResults = 1000
+
+var filterRegexp = regexp.MustCompile(`^\s*(\w+)\s+eq\s+"((?:[^"\\]|\\.)*)"\s*$`)
+
+// parseFilter supports the equality filters identity providers use to look
+// up a resource before creating it, e.g. userName eq "some-user".
+func parseFilter(r *http.Request, attribute string) (string, error) {
+	filter := r.URL.Query().Get("filter")
+	if filter == "" {
+		return "", nil
+	}
+
+	match := filterRegexp.FindStringSubmatch(filter)
+	if match == nil || !strings.EqualFold(match[1], attribute) {
+		return "", fmt.Errorf("only '%s eq \"...\"' filters are supported", attribute)
+	}
+
+	value, err := strconv.Unquote(`"` + match[2] + `"`)
+	if err != nil {
+		return "", fmt.Errorf("malformed filter value: %s", err)
+	}
+
+	if value == "" {
+		return "", fmt.Errorf("filter value must not be empty")
+	}
+
+	return value, nil
+}
+
+// paginate returns the 1-based startIndex and count of the page asked for.
+func paginate(r *http.Request) (int, int) {
+	startIndex, err := strconv.Atoi(r.URL.Query().Get("startIndex"))
+	if err != nil || startIndex < 1 {
+		startIndex = 1
+	}
+
+	count, err := strconv.Atoi(r.URL.Query().Get("count"))
+	if err != nil || count < 0 || count > maxResults {
+		count = maxResults
+	}
+
+	return startIndex, count
+}
+
+func page(total int, startIndex int, count int) (int, int) {
+	from := startIndex - 1
+	if from > total {
+		from = total
+	}
+
+	to := from + count
+	if to > total {
+		to = total
+	}
+
+	return from, to
+}
+
+func (s *Server) location(resource string, id string) string {
+	return s.externalURL + BasePath + "/" + resource + "/" + id
+}
+
+func writeList(w http.ResponseWriter, resources []interface{}, total int, startIndex int) {
+	writeJSON(w, http.StatusOK, ListResponse{
+		Schemas:      []string{ListResponseSchema},
+		TotalResults: total,
+		StartIndex:   startIndex,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	})
+}
+
+func writeError(w http.ResponseWriter, status int, scimType string, detail string) {
+	writeJSON(w, status, Error{
+		Schemas:  []string{ErrorSchema},
+		Status:   strconv.Itoa(status),
+		ScimType: scimType,
+		Detail:   detail,
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", ContentType)
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
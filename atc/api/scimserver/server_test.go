@@ -0,0 +1,377 @@
+package scimserver_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc/api/scimserver"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SCIM Server", func() {
+	var (
+		fakeRepository *dbfakes.FakeSCIMRepository
+		server         *httptest.Server
+
+		token string
+
+		user  db.SCIMUser
+		group db.SCIMGroup
+	)
+
+	BeforeEach(func() {
+		fakeRepository = new(dbfakes.FakeSCIMRepository)
+		token = "some-token"
+
+		created := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+		user = db.SCIMUser{
+			ID:        "some-user-id",
+			UserName:  "some-user",
+			Emails:    []string{"some-user@example.com"},
+			Active:    true,
+			CreatedAt: created,
+			UpdatedAt: created,
+		}
+
+		group = db.SCIMGroup{
+			ID:          "some-group-id",
+			DisplayName: "developers",
+			Members:     []db.SCIMGroupMember{{UserID: "some-user-id", UserName: "some-user"}},
+			CreatedAt:   created,
+			UpdatedAt:   created,
+		}
+	})
+
+	JustBeforeEach(func() {
+		handler, err := scimserver.NewHandler(lagertest.NewTestLogger("scim"), "https://ci.example.com/", "some-token", fakeRepository)
+		Expect(err).ToNot(HaveOccurred())
+
+		server = httptest.NewServer(handler)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	request := func(method string, path string, body string) *http.Response {
+		req, err := http.NewRequest(method, server.URL+path, bytes.NewBufferString(body))
+		Expect(err).ToNot(HaveOccurred())
+
+		req.Header.Set("Content-Type", scimserver.ContentType)
+		req.Header.Set("Authorization", "Bearer "+token)
+
+		response, err := http.DefaultClient.Do(req)
+		Expect(err).ToNot(HaveOccurred())
+
+		return response
+	}
+
+	body := func(response *http.Response) []byte {
+		defer response.Body.Close()
+
+		payload, err := ioutil.ReadAll(response.Body)
+		Expect(err).ToNot(HaveOccurred())
+
+		return payload
+	}
+
+	Context("without the bearer token", func() {
+		BeforeEach(func() {
+			token = "wrong-token"
+		})
+
+		It("returns 401", func() {
+			response := request("GET", "/scim/v2/Users", "")
+			Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			Expect(fakeRepository.SCIMUsersCallCount()).To(BeZero())
+		})
+	})
+
+	Describe("GET /scim/v2/Users", func() {
+		BeforeEach(func() {
+			fakeRepository.SCIMUsersReturns([]db.SCIMUser{user}, nil)
+		})
+
+		It("lists the users", func() {
+			response := request("GET", "/scim/v2/Users", "")
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+			Expect(response.Header.Get("Content-Type")).To(Equal("application/scim+json"))
+			Expect(body(response)).To(MatchJSON(`{
+				"schemas": ["urn:ietf:params:scim:api:messages:2.0:ListResponse"],
+				"totalResults": 1,
+				"startIndex": 1,
+				"itemsPerPage": 1,
+				"Resources": [{
+					"schemas": ["urn:ietf:params:scim:schemas:core:2.0:User"],
+					"id": "some-user-id",
+					"userName": "some-user",
+					"emails": [{"value": "some-user@example.com", "primary": true}],
+					"active": true,
+					"meta": {
+						"resourceType": "User",
+						"created": "2026-01-02T03:04:05Z",
+						"lastModified": "2026-01-02T03:04:05Z",
+						"location": "https://ci.example.com/scim/v2/Users/some-user-id"
+					}
+				}]
+			}`))
+		})
+
+		It("filters users by userName", func() {
+			response := request("GET", `/scim/v2/Users?filter=userName+eq+%22Some-User%22`, "")
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+			Expect(fakeRepository.SCIMUsersArgsForCall(0)).To(Equal("Some-User"))
+		})
+
+		It("rejects other filters", func() {
+			response := request("GET", `/scim/v2/Users?filter=name.familyName+co+%22x%22`, "")
+			Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+			Expect(body(response)).To(ContainSubstring(`"scimType":"invalidFilter"`))
+		})
+
+		It("pages through the users", func() {
+			response := request("GET", "/scim/v2/Users?startIndex=2&count=10", "")
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+			var list scimserver.ListResponse
+			Expect(json.Unmarshal(body(response), &list)).To(Succeed())
+			Expect(list.TotalResults).To(Equal(1))
+			Expect(list.StartIndex).To(Equal(2))
+			Expect(list.Resources).To(BeEmpty())
+		})
+	})
+
+	Describe("POST /scim/v2/Users", func() {
+		BeforeEach(func() {
+			fakeRepository.CreateSCIMUserReturns(user, nil)
+		})
+
+		It("creates an active user", func() {
+			response := request("POST", "/scim/v2/Users", `{
+				"schemas": ["urn:ietf:params:scim:schemas:core:2.0:User"],
+				"userName": "some-user",
+				"emails": [{"value": "some-user@example.com", "primary": true}]
+			}`)
+			Expect(response.StatusCode).To(Equal(http.StatusCreated))
+			Expect(fakeRepository.CreateSCIMUserArgsForCall(0)).To(Equal(db.SCIMUser{
+				UserName: "some-user",
+				Emails:   []string{"some-user@example.com"},
+				Active:   true,
+			}))
+		})
+
+		It("requires a userName", func() {
+			response := request("POST", "/scim/v2/Users", `{"displayName": "Some User"}`)
+			Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+			Expect(fakeRepository.CreateSCIMUserCallCount()).To(BeZero())
+		})
+
+		Context("when the user exists", func() {
+			BeforeEach(func() {
+				fakeRepository.CreateSCIMUserReturns(db.SCIMUser{}, db.ErrSCIMUserExists)
+			})
+
+			It("returns 409", func() {
+				response := request("POST", "/scim/v2/Users", `{"userName": "some-user"}`)
+				Expect(response.StatusCode).To(Equal(http.StatusConflict))
+				Expect(body(response)).To(ContainSubstring(`"scimType":"uniqueness"`))
+			})
+		})
+	})
+
+	Describe("PATCH /scim/v2/Users/:id", func() {
+		BeforeEach(func() {
+			fakeRepository.SCIMUserReturns(user, true, nil)
+			fakeRepository.UpdateSCIMUserReturns(user, true, nil)
+		})
+
+		It("deactivates the user", func() {
+			response := request("PATCH", "/scim/v2/Users/some-user-id", `{
+				"schemas": ["urn:ietf:params:scim:api:messages:2.0:PatchOp"],
+				"Operations": [{"op": "Replace", "path": "active", "value": "False"}]
+			}`)
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+			Expect(fakeRepository.SCIMUserArgsForCall(0)).To(Equal("some-user-id"))
+
+			updated := fakeRepository.UpdateSCIMUserArgsForCall(0)
+			Expect(updated.ID).To(Equal("some-user-id"))
+			Expect(updated.Active).To(BeFalse())
+		})
+
+		It("replaces attributes given without a path", func() {
+			response := request("PATCH", "/scim/v2/Users/some-user-id", `{
+				"Operations": [{"op": "replace", "value": {"active": false, "displayName": "Some User"}}]
+			}`)
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+			updated := fakeRepository.UpdateSCIMUserArgsForCall(0)
+			Expect(updated.Active).To(BeFalse())
+			Expect(updated.DisplayName).To(Equal("Some User"))
+		})
+
+		Context("when the user does not exist", func() {
+			BeforeEach(func() {
+				fakeRepository.SCIMUserReturns(db.SCIMUser{}, false, nil)
+			})
+
+			It("returns 404", func() {
+				response := request("PATCH", "/scim/v2/Users/some-user-id", `{"Operations": []}`)
+				Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				Expect(fakeRepository.UpdateSCIMUserCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("DELETE /scim/v2/Users/:id", func() {
+		It("deletes the user", func() {
+			fakeRepository.DeleteSCIMUserReturns(true, nil)
+
+			response := request("DELETE", "/scim/v2/Users/some-user-id", "")
+			Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+			Expect(fakeRepository.DeleteSCIMUserArgsForCall(0)).To(Equal("some-user-id"))
+		})
+
+		It("returns 404 if there is no such user", func() {
+			response := request("DELETE", "/scim/v2/Users/some-user-id", "")
+			Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+		})
+	})
+
+	Describe("POST /scim/v2/Groups", func() {
+		BeforeEach(func() {
+			fakeRepository.CreateSCIMGroupReturns(group, nil)
+		})
+
+		It("creates the group with its members", func() {
+			response := request("POST", "/scim/v2/Groups", `{
+				"schemas": ["urn:ietf:params:scim:schemas:core:2.0:Group"],
+				"displayName": "developers",
+				"members": [{"value": "some-user-id"}]
+			}`)
+			Expect(response.StatusCode).To(Equal(http.StatusCreated))
+			Expect(fakeRepository.CreateSCIMGroupArgsForCall(0)).To(Equal(db.SCIMGroup{
+				DisplayName: "developers",
+				Members:     []db.SCIMGroupMember{{UserID: "some-user-id"}},
+			}))
+
+			Expect(body(response)).To(MatchJSON(`{
+				"schemas": ["urn:ietf:params:scim:schemas:core:2.0:Group"],
+				"id": "some-group-id",
+				"displayName": "developers",
+				"members": [{
+					"value": "some-user-id",
+					"display": "some-user",
+					"$ref": "https://ci.example.com/scim/v2/Users/some-user-id"
+				}],
+				"meta": {
+					"resourceType": "Group",
+					"created": "2026-01-02T03:04:05Z",
+					"lastModified": "2026-01-02T03:04:05Z",
+					"location": "https://ci.example.com/scim/v2/Groups/some-group-id"
+				}
+			}`))
+		})
+
+		Context("when a member is not a user", func() {
+			BeforeEach(func() {
+				fakeRepository.CreateSCIMGroupReturns(db.SCIMGroup{}, db.ErrSCIMGroupMemberUnknown)
+			})
+
+			It("returns 400", func() {
+				response := request("POST", "/scim/v2/Groups", `{"displayName": "developers", "members": [{"value": "bogus"}]}`)
+				Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Context("when saving the group fails", func() {
+			BeforeEach(func() {
+				fakeRepository.CreateSCIMGroupReturns(db.SCIMGroup{}, errors.New("nope"))
+			})
+
+			It("returns 500", func() {
+				response := request("POST", "/scim/v2/Groups", `{"displayName": "developers"}`)
+				Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+			})
+		})
+	})
+
+	Describe("PATCH /scim/v2/Groups/:id", func() {
+		BeforeEach(func() {
+			fakeRepository.SCIMGroupReturns(group, true, nil)
+			fakeRepository.UpdateSCIMGroupReturns(group, true, nil)
+		})
+
+		It("adds and removes members", func() {
+			response := request("PATCH", "/scim/v2/Groups/some-group-id", `{
+				"schemas": ["urn:ietf:params:scim:api:messages:2.0:PatchOp"],
+				"Operations": [
+					{"op": "add", "path": "members", "value": [{"value": "other-user-id"}]},
+					{"op": "remove", "path": "members[value eq \"some-user-id\"]"}
+				]
+			}`)
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+			updated := fakeRepository.UpdateSCIMGroupArgsForCall(0)
+			Expect(updated.ID).To(Equal("some-group-id"))
+			Expect(updated.Members).To(Equal([]db.SCIMGroupMember{{UserID: "other-user-id"}}))
+		})
+
+		It("removes the members given as a value", func() {
+			response := request("PATCH", "/scim/v2/Groups/some-group-id", `{
+				"Operations": [{"op": "Remove", "path": "members", "value": [{"value": "some-user-id"}]}]
+			}`)
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+			Expect(fakeRepository.UpdateSCIMGroupArgsForCall(0).Members).To(BeEmpty())
+		})
+
+		It("renames the group", func() {
+			response := request("PATCH", "/scim/v2/Groups/some-group-id", `{
+				"Operations": [{"op": "replace", "value": {"displayName": "devs"}}]
+			}`)
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+			updated := fakeRepository.UpdateSCIMGroupArgsForCall(0)
+			Expect(updated.DisplayName).To(Equal("devs"))
+			Expect(updated.Members).To(Equal(group.Members))
+		})
+
+		It("rejects unknown ops", func() {
+			response := request("PATCH", "/scim/v2/Groups/some-group-id", `{
+				"Operations": [{"op": "move", "path": "members"}]
+			}`)
+			Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+			Expect(fakeRepository.UpdateSCIMGroupCallCount()).To(BeZero())
+		})
+	})
+
+	Describe("GET /scim/v2/Groups", func() {
+		It("filters groups by displayName", func() {
+			fakeRepository.SCIMGroupsReturns([]db.SCIMGroup{group}, nil)
+
+			response := request("GET", `/scim/v2/Groups?filter=displayName+eq+%22developers%22`, "")
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+			Expect(fakeRepository.SCIMGroupsArgsForCall(0)).To(Equal("developers"))
+		})
+	})
+
+	Describe("GET /scim/v2/ServiceProviderConfig", func() {
+		It("advertises patch and filter support", func() {
+			response := request("GET", "/scim/v2/ServiceProviderConfig", "")
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+			var config map[string]interface{}
+			Expect(json.Unmarshal(body(response), &config)).To(Succeed())
+			Expect(config["patch"]).To(Equal(map[string]interface{}{"supported": true}))
+		})
+	})
+})
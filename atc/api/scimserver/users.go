@@ -0,0 +1,324 @@
+package scimserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/tedsuo/rata"
+)
+
+func (s *Server) ListUsers(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("list-users")
+
+	userName, err := parseFilter(r, "userName")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalidFilter", err.Error())
+		return
+	}
+
+	users, err := s.repository.SCIMUsers(userName)
+	if err != nil {
+		logger.Error("failed-to-get-users", err)
+		writeError(w, http.StatusInternalServerError, "", "failed to get users")
+		return
+	}
+
+	startIndex, count := paginate(r)
+	from, to := page(len(users), startIndex, count)
+
+	resources := []interface{}{}
+	for _, user := range users[from:to] {
+		resources = append(resources, s.presentUser(user))
+	}
+
+	writeList(w, resources, len(users), startIndex)
+}
+
+func (s *Server) CreateUser(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("create-user")
+
+	user, ok := decodeUser(w, r)
+	if !ok {
+		return
+	}
+
+	created, err := s.repository.CreateSCIMUser(user)
+	if err != nil {
+		if err == db.ErrSCIMUserExists {
+			writeError(w, http.StatusConflict, "uniqueness", err.Error())
+			return
+		}
+
+		logger.Error("failed-to-create-user", err)
+		writeError(w, http.StatusInternalServerError, "", "failed to create user")
+		return
+	}
+
+	logger.Info("created", lager.Data{"id": created.ID, "user_name": created.UserName})
+
+	writeJSON(w, http.StatusCreated, s.presentUser(created))
+}
+
+func (s *Server) GetUser(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("get-user")
+
+	user, found, err := s.repository.SCIMUser(rata.Param(r, "id"))
+	if err != nil {
+		logger.Error("failed-to-get-user", err)
+		writeError(w, http.StatusInternalServerError, "", "failed to get user")
+		return
+	}
+
+	if !found {
+		writeError(w, http.StatusNotFound, "", "user not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, s.presentUser(user))
+}
+
+func (s *Server) ReplaceUser(w http.ResponseWriter, r *http.Request) {
+	user, ok := decodeUser(w, r)
+	if !ok {
+		return
+	}
+
+	user.ID = rata.Param(r, "id")
+
+	s.updateUser(w, s.logger.Session("replace-user"), user)
+}
+
+func (s *Server) PatchUser(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("patch-user")
+
+	var patch PatchRequest
+	err := json.NewDecoder(r.Body).Decode(&patch)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalidSyntax", fmt.Sprintf("malformed request: %s", err))
+		return
+	}
+
+	user, found, err := s.repository.SCIMUser(rata.Param(r, "id"))
+	if err != nil {
+		logger.Error("failed-to-get-user", err)
+		writeError(w, http.StatusInternalServerError, "", "failed to get user")
+		return
+	}
+
+	if !found {
+		writeError(w, http.StatusNotFound, "", "user not found")
+		return
+	}
+
+	for _, op := range patch.Operations {
+		err := patchUser(&user, op)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalidValue", err.Error())
+			return
+		}
+	}
+
+	s.updateUser(w, logger, user)
+}
+
+func (s *Server) DeleteUser(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("delete-user")
+
+	id := rata.Param(r, "id")
+
+	deleted, err := s.repository.DeleteSCIMUser(id)
+	if err != nil {
+		logger.Error("failed-to-delete-user", err)
+		writeError(w, http.StatusInternalServerError, "", "failed to delete user")
+		return
+	}
+
+	if !deleted {
+		writeError(w, http.StatusNotFound, "", "user not found")
+		return
+	}
+
+	logger.Info("deleted", lager.Data{"id": id})
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) updateUser(w http.ResponseWriter, logger lager.Logger, user db.SCIMUser) {
+	if user.UserName == "" {
+		writeError(w, http.StatusBadRequest, "invalidValue", "userName must be specified")
+		return
+	}
+
+	updated, found, err := s.repository.UpdateSCIMUser(user)
+	if err != nil {
+		if err == db.ErrSCIMUserExists {
+			writeError(w, http.StatusConflict, "uniqueness", err.Error())
+			return
+		}
+
+		logger.Error("failed-to-update-user", err)
+		writeError(w, http.StatusInternalServerError, "", "failed to update user")
+		return
+	}
+
+	if !found {
+		writeError(w, http.StatusNotFound, "", "user not found")
+		return
+	}
+
+	logger.Info("updated", lager.Data{"id": updated.ID, "user_name": updated.UserName, "active": updated.Active})
+
+	writeJSON(w, http.StatusOK, s.presentUser(updated))
+}
+
+func decodeUser(w http.ResponseWriter, r *http.Request) (db.SCIMUser, bool) {
+	var user User
+	err := json.NewDecoder(r.Body).Decode(&user)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalidSyntax", fmt.Sprintf("malformed request: %s", err))
+		return db.SCIMUser{}, false
+	}
+
+	if user.UserName == "" {
+		writeError(w, http.StatusBadRequest, "invalidValue", "userName must be specified")
+		return db.SCIMUser{}, false
+	}
+
+	// users are active unless the identity provider says otherwise
+	active := true
+	if user.Active != nil {
+		active = *user.Active
+	}
+
+	return db.SCIMUser{
+		UserName:    user.UserName,
+		ExternalID:  user.ExternalID,
+		DisplayName: user.DisplayName,
+		Emails:      emailValues(user.Emails),
+		Active:      active,
+	}, true
+}
+
+// patchUser applies an operation to the attributes Concourse keeps. Others,
+// such as a user's name or phone numbers, are ignored.
+func patchUser(user *db.SCIMUser, op PatchOperation) error {
+	switch strings.ToLower(op.Op) {
+	case "add", "replace":
+	case "remove":
+		switch strings.ToLower(op.Path) {
+		case "externalid":
+			user.ExternalID = ""
+		case "displayname":
+			user.DisplayName = ""
+		case "emails":
+			user.Emails = nil
+		}
+
+		return nil
+	default:
+		return fmt.Errorf("unknown op '%s'", op.Op)
+	}
+
+	if op.Path == "" {
+		var values map[string]json.RawMessage
+		err := json.Unmarshal(op.Value, &values)
+		if err != nil {
+			return fmt.Errorf("value must be an object when no path is given")
+		}
+
+		for path, value := range values {
+			err := patchUserAttribute(user, path, value)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	return patchUserAttribute(user, op.Path, op.Value)
+}
+
+func patchUserAttribute(user *db.SCIMUser, path string, value json.RawMessage) error {
+	var err error
+	switch strings.ToLower(path) {
+	case "username":
+		err = json.Unmarshal(value, &user.UserName)
+	case "externalid":
+		err = json.Unmarshal(value, &user.ExternalID)
+	case "displayname":
+		err = json.Unmarshal(value, &user.DisplayName)
+	case "active":
+		user.Active, err = unmarshalBool(value)
+	case "emails":
+		var emails []Email
+		err = json.Unmarshal(value, &emails)
+		user.Emails = emailValues(emails)
+	default:
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("invalid value for '%s': %s", path, err)
+	}
+
+	return nil
+}
+
+// unmarshalBool accepts "True" and "False" as well, which some identity
+// providers send.
+func unmarshalBool(value json.RawMessage) (bool, error) {
+	var b bool
+	err := json.Unmarshal(value, &b)
+	if err == nil {
+		return b, nil
+	}
+
+	var s string
+	if json.Unmarshal(value, &s) == nil {
+		return strconv.ParseBool(strings.ToLower(s))
+	}
+
+	return false, err
+}
+
+func emailValues(emails []Email) []string {
+	values := []string{}
+	for _, email := range emails {
+		if email.Value != "" {
+			values = append(values, email.Value)
+		}
+	}
+
+	return values
+}
+
+func (s *Server) presentUser(user db.SCIMUser) User {
+	emails := []Email{}
+	for i, email := range user.Emails {
+		emails = append(emails, Email{Value: email, Primary: i == 0})
+	}
+
+	active := user.Active
+
+	return User{
+		Schemas:     []string{UserSchema},
+		ID:          user.ID,
+		ExternalID:  user.ExternalID,
+		UserName:    user.UserName,
+		DisplayName: user.DisplayName,
+		Emails:      emails,
+		Active:      &active,
+		Meta: &Meta{
+			ResourceType: "User",
+			Created:      user.CreatedAt,
+			LastModified: user.UpdatedAt,
+			Location:     s.location("Users", user.ID),
+		},
+	}
+}
@@ -0,0 +1,252 @@
+package api_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"gopkg.in/square/go-jose.v2/jwt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Sessions API", func() {
+	var (
+		response *http.Response
+		sessions []db.Session
+	)
+
+	BeforeEach(func() {
+		sessions = []db.Session{
+			{
+				ID:        2,
+				Token:     "some-token",
+				Claims:    db.Claims{Claims: jwt.Claims{Audience: jwt.Audience{"fly"}}},
+				CreatedAt: time.Unix(100, 0),
+				ExpiresAt: time.Unix(200, 0),
+			},
+			{
+				ID:        1,
+				Token:     "other-token",
+				Claims:    db.Claims{Claims: jwt.Claims{Audience: jwt.Audience{"concourse-web"}}},
+				CreatedAt: time.Unix(50, 0),
+				ExpiresAt: time.Unix(150, 0),
+			},
+		}
+	})
+
+	request := func(method, path string) {
+		req, err := http.NewRequest(method, server.URL+path, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		req.Header.Set("Authorization", "Bearer some-token")
+
+		response, err = client.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	Describe("GET /api/v1/user/sessions", func() {
+		JustBeforeEach(func() {
+			request("GET", "/api/v1/user/sessions")
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.UserInfoReturns(atc.UserInfo{Sub: "some-sub"})
+
+				dbSessions.SessionsReturns(sessions, nil)
+			})
+
+			It("returns the user's sessions without their tokens", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(dbSessions.SessionsArgsForCall(0)).To(Equal("some-sub"))
+				Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`[
+					{"id": 2, "client_id": "fly", "created_at": 100, "expires_at": 200, "current": true},
+					{"id": 1, "client_id": "concourse-web", "created_at": 50, "expires_at": 150}
+				]`))
+			})
+
+			Context("when the token has no subject", func() {
+				BeforeEach(func() {
+					fakeAccess.UserInfoReturns(atc.UserInfo{IsSystem: true})
+				})
+
+				It("returns 403", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+					Expect(dbSessions.SessionsCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when getting the sessions fails", func() {
+				BeforeEach(func() {
+					dbSessions.SessionsReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("DELETE /api/v1/user/sessions/:session_id", func() {
+		JustBeforeEach(func() {
+			request("DELETE", "/api/v1/user/sessions/1")
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.UserInfoReturns(atc.UserInfo{Sub: "some-sub", DisplayUserId: "some-user"})
+
+				dbSessions.RevokeSessionReturns(true, nil)
+			})
+
+			It("revokes the user's session", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+
+				sub, id, revokedBy := dbSessions.RevokeSessionArgsForCall(0)
+				Expect(sub).To(Equal("some-sub"))
+				Expect(id).To(Equal(1))
+				Expect(revokedBy).To(Equal("some-user"))
+			})
+
+			Context("when the user has no such session", func() {
+				BeforeEach(func() {
+					dbSessions.RevokeSessionReturns(false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				Expect(dbSessions.RevokeSessionCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("GET /api/v1/users/:user_id/sessions", func() {
+		JustBeforeEach(func() {
+			request("GET", "/api/v1/users/42/sessions")
+		})
+
+		Context("when the user is an admin", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAdminReturns(true)
+
+				user := new(dbfakes.FakeUser)
+				user.SubReturns("other-sub")
+				dbUserFactory.GetUserReturns(user, true, nil)
+
+				dbSessions.SessionsReturns(sessions[1:], nil)
+			})
+
+			It("returns the user's sessions", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(dbUserFactory.GetUserArgsForCall(0)).To(Equal(42))
+				Expect(dbSessions.SessionsArgsForCall(0)).To(Equal("other-sub"))
+				Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`[
+					{"id": 1, "client_id": "concourse-web", "created_at": 50, "expires_at": 150}
+				]`))
+			})
+
+			Context("when there is no such user", func() {
+				BeforeEach(func() {
+					dbUserFactory.GetUserReturns(nil, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+					Expect(dbSessions.SessionsCallCount()).To(BeZero())
+				})
+			})
+		})
+
+		Context("when the user is not an admin", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAdminReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+	})
+
+	Describe("DELETE /api/v1/users/:user_id/sessions", func() {
+		JustBeforeEach(func() {
+			request("DELETE", "/api/v1/users/42/sessions")
+		})
+
+		Context("when the user is an admin", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAdminReturns(true)
+				fakeAccess.UserInfoReturns(atc.UserInfo{DisplayUserId: "some-admin"})
+
+				user := new(dbfakes.FakeUser)
+				user.SubReturns("other-sub")
+				dbUserFactory.GetUserReturns(user, true, nil)
+
+				dbSessions.RevokeSessionsReturns(2, nil)
+			})
+
+			It("revokes every one of the user's sessions", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+
+				sub, revokedBy := dbSessions.RevokeSessionsArgsForCall(0)
+				Expect(sub).To(Equal("other-sub"))
+				Expect(revokedBy).To(Equal("some-admin"))
+			})
+
+			Context("when revoking the sessions fails", func() {
+				BeforeEach(func() {
+					dbSessions.RevokeSessionsReturns(0, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when the user is not an admin", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAdminReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(dbSessions.RevokeSessionsCallCount()).To(BeZero())
+			})
+		})
+	})
+})
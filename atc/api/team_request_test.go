@@ -0,0 +1,344 @@
+package api_test
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Team Requests API", func() {
+	var (
+		response *http.Response
+
+		teamRequest atc.TeamRequest
+	)
+
+	BeforeEach(func() {
+		teamRequest = atc.TeamRequest{
+			ID:          42,
+			TeamName:    "some-team",
+			Auth:        atc.TeamAuth{"owner": {"users": {"local:some-user"}}},
+			Reason:      "for some-project",
+			Status:      atc.TeamRequestPending,
+			RequestedBy: "some-user",
+			RequestedAt: 100,
+		}
+	})
+
+	Describe("GET /api/v1/team_requests", func() {
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/team_requests")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.UserInfoReturns(atc.UserInfo{DisplayUserId: "some-user"})
+
+				dbTeamRequests.TeamRequestsReturns([]atc.TeamRequest{teamRequest}, nil)
+			})
+
+			It("returns the user's requests", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(dbTeamRequests.TeamRequestsArgsForCall(0)).To(Equal("some-user"))
+				Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`[
+					{
+						"id": 42,
+						"team_name": "some-team",
+						"auth": {"owner": {"users": ["local:some-user"]}},
+						"reason": "for some-project",
+						"status": "pending",
+						"requested_by": "some-user",
+						"requested_at": 100
+					}
+				]`))
+			})
+
+			Context("when the user is an admin", func() {
+				BeforeEach(func() {
+					fakeAccess.IsAdminReturns(true)
+				})
+
+				It("returns every request", func() {
+					Expect(dbTeamRequests.TeamRequestsArgsForCall(0)).To(BeEmpty())
+				})
+			})
+
+			Context("when getting the requests fails", func() {
+				BeforeEach(func() {
+					dbTeamRequests.TeamRequestsReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				Expect(dbTeamRequests.TeamRequestsCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("POST /api/v1/team_requests", func() {
+		var requestBody string
+
+		BeforeEach(func() {
+			requestBody = `{
+				"team_name": "some-team",
+				"auth": {"owner": {"users": ["local:some-user"]}},
+				"reason": "for some-project"
+			}`
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Post(
+				server.URL+"/api/v1/team_requests",
+				"application/json",
+				bytes.NewBufferString(requestBody),
+			)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.UserInfoReturns(atc.UserInfo{DisplayUserId: "some-user"})
+
+				dbTeamRequests.CreateTeamRequestReturns(teamRequest, nil)
+			})
+
+			It("requests the team on behalf of the user", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusCreated))
+				Expect(dbTeamRequests.CreateTeamRequestCallCount()).To(Equal(1))
+				Expect(dbTeamRequests.CreateTeamRequestArgsForCall(0)).To(Equal(atc.TeamRequest{
+					TeamName:    "some-team",
+					Auth:        atc.TeamAuth{"owner": {"users": {"local:some-user"}}},
+					Reason:      "for some-project",
+					RequestedBy: "some-user",
+				}))
+			})
+
+			It("returns the request", func() {
+				Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`{
+					"id": 42,
+					"team_name": "some-team",
+					"auth": {"owner": {"users": ["local:some-user"]}},
+					"reason": "for some-project",
+					"status": "pending",
+					"requested_by": "some-user",
+					"requested_at": 100
+				}`))
+			})
+
+			Context("when the team's name is not a valid identifier", func() {
+				BeforeEach(func() {
+					requestBody = `{"team_name":"Some Team","auth":{"owner":{"users":["local:some-user"]}}}`
+				})
+
+				It("returns 400 without requesting it", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(ioutil.ReadAll(response.Body)).To(ContainSubstring("'Some Team' is not a valid identifier"))
+					Expect(dbTeamRequests.CreateTeamRequestCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the auth config is empty", func() {
+				BeforeEach(func() {
+					requestBody = `{"team_name":"some-team"}`
+				})
+
+				It("returns 400 without requesting it", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(dbTeamRequests.CreateTeamRequestCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the team already exists or has been requested", func() {
+				BeforeEach(func() {
+					dbTeamRequests.CreateTeamRequestReturns(atc.TeamRequest{}, db.ErrTeamRequestPending)
+				})
+
+				It("returns 409", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusConflict))
+					Expect(ioutil.ReadAll(response.Body)).To(ContainSubstring("team has already been requested"))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				Expect(dbTeamRequests.CreateTeamRequestCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/team_requests/:team_request_id/approve", func() {
+		var requestBody string
+
+		BeforeEach(func() {
+			requestBody = `{"comment":"welcome"}`
+		})
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequest("PUT", server.URL+"/api/v1/team_requests/42/approve", bytes.NewBufferString(requestBody))
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the user is an admin", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAdminReturns(true)
+				fakeAccess.UserInfoReturns(atc.UserInfo{DisplayUserId: "some-admin"})
+
+				teamRequest.Status = atc.TeamRequestApproved
+				teamRequest.DecidedBy = "some-admin"
+				teamRequest.DecidedAt = 200
+				teamRequest.Comment = "welcome"
+				dbTeamRequests.ApproveTeamRequestReturns(teamRequest, true, nil)
+			})
+
+			It("approves the request", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(dbTeamRequests.ApproveTeamRequestCallCount()).To(Equal(1))
+
+				id, decidedBy, comment := dbTeamRequests.ApproveTeamRequestArgsForCall(0)
+				Expect(id).To(Equal(42))
+				Expect(decidedBy).To(Equal("some-admin"))
+				Expect(comment).To(Equal("welcome"))
+
+				Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`{
+					"id": 42,
+					"team_name": "some-team",
+					"auth": {"owner": {"users": ["local:some-user"]}},
+					"reason": "for some-project",
+					"status": "approved",
+					"requested_by": "some-user",
+					"requested_at": 100,
+					"decided_by": "some-admin",
+					"decided_at": 200,
+					"comment": "welcome"
+				}`))
+			})
+
+			It("lets the API know about the new team", func() {
+				Expect(dbTeamFactory.NotifyCacherCallCount()).To(Equal(1))
+			})
+
+			Context("without a comment", func() {
+				BeforeEach(func() {
+					requestBody = ``
+				})
+
+				It("approves the request", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+					_, _, comment := dbTeamRequests.ApproveTeamRequestArgsForCall(0)
+					Expect(comment).To(BeEmpty())
+				})
+			})
+
+			Context("when the request has already been decided", func() {
+				BeforeEach(func() {
+					dbTeamRequests.ApproveTeamRequestReturns(atc.TeamRequest{}, false, db.ErrTeamRequestDecided)
+				})
+
+				It("returns 409", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusConflict))
+					Expect(dbTeamFactory.NotifyCacherCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the request does not exist", func() {
+				BeforeEach(func() {
+					dbTeamRequests.ApproveTeamRequestReturns(atc.TeamRequest{}, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+		})
+
+		Context("when the user is not an admin", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAdminReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(dbTeamRequests.ApproveTeamRequestCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/team_requests/:team_request_id/reject", func() {
+		JustBeforeEach(func() {
+			req, err := http.NewRequest("PUT", server.URL+"/api/v1/team_requests/42/reject", bytes.NewBufferString(`{"comment":"use an existing team"}`))
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the user is an admin", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAdminReturns(true)
+				fakeAccess.UserInfoReturns(atc.UserInfo{DisplayUserId: "some-admin"})
+
+				teamRequest.Status = atc.TeamRequestRejected
+				dbTeamRequests.RejectTeamRequestReturns(teamRequest, true, nil)
+			})
+
+			It("rejects the request", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+				id, decidedBy, comment := dbTeamRequests.RejectTeamRequestArgsForCall(0)
+				Expect(id).To(Equal(42))
+				Expect(decidedBy).To(Equal("some-admin"))
+				Expect(comment).To(Equal("use an existing team"))
+
+				Expect(dbTeamFactory.NotifyCacherCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when the user is not an admin", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAdminReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(dbTeamRequests.RejectTeamRequestCallCount()).To(BeZero())
+			})
+		})
+	})
+})
@@ -0,0 +1,24 @@
+package teamrequestserver
+
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/db"
+)
+
+type Server struct {
+	logger      lager.Logger
+	teamFactory db.TeamFactory
+	repository  db.TeamRequestRepository
+}
+
+func NewServer(
+	logger lager.Logger,
+	teamFactory db.TeamFactory,
+	repository db.TeamRequestRepository,
+) *Server {
+	return &Server{
+		logger:      logger,
+		teamFactory: teamFactory,
+		repository:  repository,
+	}
+}
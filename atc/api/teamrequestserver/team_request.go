@@ -0,0 +1,163 @@
+package teamrequestserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/accessor"
+	. "github.com/concourse/concourse/atc/api/helpers"
+	"github.com/concourse/concourse/atc/db"
+)
+
+// ListTeamRequests returns every request to admins, and only the requests
+// they made to everyone else.
+func (s *Server) ListTeamRequests(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("list-team-requests")
+
+	acc := accessor.GetAccessor(r)
+
+	var requestedBy string
+	if !acc.IsAdmin() {
+		requestedBy = acc.UserInfo().DisplayUserId
+	}
+
+	requests, err := s.repository.TeamRequests(requestedBy)
+	if err != nil {
+		logger.Error("failed-to-get-team-requests", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(requests)
+	if err != nil {
+		logger.Error("failed-to-encode-team-requests", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+func (s *Server) CreateTeamRequest(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("create-team-request")
+
+	var request atc.TeamRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		logger.Info("malformed-request", lager.Data{"error": err.Error()})
+		HandleBadRequest(w, fmt.Sprintf("malformed team request: %s", err))
+		return
+	}
+
+	err = request.Validate()
+	if err != nil {
+		HandleBadRequest(w, fmt.Sprintf("invalid team request: %s", err))
+		return
+	}
+
+	request.RequestedBy = accessor.GetAccessor(r).UserInfo().DisplayUserId
+
+	request, err = s.repository.CreateTeamRequest(request)
+	if err != nil {
+		if err == db.ErrRequestedTeamExists || err == db.ErrTeamRequestPending {
+			w.WriteHeader(http.StatusConflict)
+			fmt.Fprintf(w, "%s", err)
+			return
+		}
+
+		logger.Error("failed-to-create-team-request", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	logger.Info("requested", lager.Data{
+		"team":         request.TeamName,
+		"requested-by": request.RequestedBy,
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+
+	err = json.NewEncoder(w).Encode(request)
+	if err != nil {
+		logger.Error("failed-to-encode-team-request", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+func (s *Server) ApproveTeamRequest(w http.ResponseWriter, r *http.Request) {
+	s.decideTeamRequest(w, r, "approve-team-request", s.repository.ApproveTeamRequest)
+}
+
+func (s *Server) RejectTeamRequest(w http.ResponseWriter, r *http.Request) {
+	s.decideTeamRequest(w, r, "reject-team-request", s.repository.RejectTeamRequest)
+}
+
+func (s *Server) decideTeamRequest(
+	w http.ResponseWriter,
+	r *http.Request,
+	action string,
+	decide func(id int, decidedBy string, comment string) (atc.TeamRequest, bool, error),
+) {
+	logger := s.logger.Session(action)
+
+	requestID, err := strconv.Atoi(r.FormValue(":team_request_id"))
+	if err != nil {
+		HandleBadRequest(w, "team_request_id must be an integer")
+		return
+	}
+
+	// the decision's body is optional
+	var decision atc.TeamRequestDecision
+	err = json.NewDecoder(r.Body).Decode(&decision)
+	if err != nil && err != io.EOF {
+		logger.Info("malformed-request", lager.Data{"error": err.Error()})
+		HandleBadRequest(w, fmt.Sprintf("malformed decision: %s", err))
+		return
+	}
+
+	decidedBy := accessor.GetAccessor(r).UserInfo().DisplayUserId
+
+	request, found, err := decide(requestID, decidedBy, decision.Comment)
+	if err != nil {
+		if err == db.ErrRequestedTeamExists || err == db.ErrTeamRequestDecided {
+			w.WriteHeader(http.StatusConflict)
+			fmt.Fprintf(w, "%s", err)
+			return
+		}
+
+		logger.Error("failed-to-decide-team-request", err, lager.Data{"team-request": requestID})
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	logger.Info("decided", lager.Data{
+		"team":       request.TeamName,
+		"status":     request.Status,
+		"decided-by": request.DecidedBy,
+	})
+
+	if request.Status == atc.TeamRequestApproved {
+		err = s.teamFactory.NotifyCacher()
+		if err != nil {
+			logger.Error("failed-to-notify-cacher", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(request)
+	if err != nil {
+		logger.Error("failed-to-encode-team-request", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
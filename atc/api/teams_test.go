@@ -564,6 +564,24 @@ var _ = Describe("Teams API", func() {
 					Expect(response.StatusCode).To(Equal(http.StatusOK))
 				})
 
+				It("lets the API know about the new name", func() {
+					Expect(dbTeamFactory.NotifyCacherCallCount()).To(Equal(1))
+				})
+
+				Context("when another team has the name", func() {
+					BeforeEach(func() {
+						fakeTeam.RenameReturns(db.ErrTeamNameTaken)
+					})
+
+					It("returns 409", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusConflict))
+						Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`{
+							"errors": ["a team with the same name already exists"]
+						}`))
+						Expect(dbTeamFactory.NotifyCacherCallCount()).To(BeZero())
+					})
+				})
+
 				Context("when the new name is an invalid identifier", func() {
 					Context("and is a string", func() {
 						BeforeEach(func() {
@@ -790,4 +808,562 @@ var _ = Describe("Teams API", func() {
 			})
 		})
 	})
+
+	Describe("GET /api/v1/teams/:team_name/notifiers", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/teams/a-team/notifiers")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+				dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+
+				fakeTeam.NotifiersReturns([]atc.NotifierConfig{
+					{
+						Name:   "some-notifier",
+						Type:   "slack",
+						Config: map[string]interface{}{"url": "https://hooks.example.com/secret"},
+					},
+				}, nil)
+			})
+
+			It("returns 200 with the notifiers, without their config", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`[
+					{"name": "some-notifier", "type": "slack"}
+				]`))
+			})
+
+			Context("when getting the notifiers fails", func() {
+				BeforeEach(func() {
+					fakeTeam.NotifiersReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				Expect(fakeTeam.NotifiersCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/teams/:team_name/notifiers/:notifier_name", func() {
+		var (
+			response    *http.Response
+			requestBody string
+		)
+
+		BeforeEach(func() {
+			requestBody = `{"type":"webhook","config":{"url":"https://example.com/hook"}}`
+		})
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequest(
+				"PUT",
+				server.URL+"/api/v1/teams/a-team/notifiers/some-notifier",
+				bytes.NewBufferString(requestBody),
+			)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+				dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+			})
+
+			It("saves the notifier with the name from the URL", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(fakeTeam.SaveNotifierCallCount()).To(Equal(1))
+				Expect(fakeTeam.SaveNotifierArgsForCall(0)).To(Equal(atc.NotifierConfig{
+					Name:   "some-notifier",
+					Type:   "webhook",
+					Config: map[string]interface{}{"url": "https://example.com/hook"},
+				}))
+			})
+
+			Context("when the type is unknown", func() {
+				BeforeEach(func() {
+					requestBody = `{"type":"pigeon","config":{}}`
+				})
+
+				It("returns 400 without saving", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`{
+						"errors": ["invalid notifier: unknown notifier type 'pigeon'"]
+					}`))
+					Expect(fakeTeam.SaveNotifierCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when the config is invalid", func() {
+				BeforeEach(func() {
+					requestBody = `{"type":"webhook","config":{}}`
+				})
+
+				It("returns 400 without saving", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`{
+						"errors": ["invalid notifier: missing url"]
+					}`))
+					Expect(fakeTeam.SaveNotifierCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when saving the notifier fails", func() {
+				BeforeEach(func() {
+					fakeTeam.SaveNotifierReturns(errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when unauthorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(false)
+				dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(fakeTeam.SaveNotifierCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/teams/:team_name/worker_keys", func() {
+		var (
+			response    *http.Response
+			requestBody string
+		)
+
+		BeforeEach(func() {
+			requestBody = `["ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIKVdSCgbTlRANaGYBP+0FXXXtrU3RrmzNkvkdrVJHFYv some-worker"]`
+		})
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequest(
+				"PUT",
+				server.URL+"/api/v1/teams/a-team/worker_keys",
+				bytes.NewBufferString(requestBody),
+			)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+				dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+			})
+
+			It("saves the keys", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+				Expect(fakeTeam.SetWorkerKeysCallCount()).To(Equal(1))
+				Expect(fakeTeam.SetWorkerKeysArgsForCall(0)).To(Equal([]string{
+					"ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIKVdSCgbTlRANaGYBP+0FXXXtrU3RrmzNkvkdrVJHFYv some-worker",
+				}))
+			})
+
+			Context("when a key is invalid", func() {
+				BeforeEach(func() {
+					requestBody = `["not-a-key"]`
+				})
+
+				It("returns 400 without saving", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(fakeTeam.SetWorkerKeysCallCount()).To(Equal(0))
+				})
+			})
+		})
+
+		Context("when unauthorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(false)
+				dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(fakeTeam.SetWorkerKeysCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/teams/:team_name/intercept_settings", func() {
+		var (
+			response    *http.Response
+			requestBody string
+		)
+
+		BeforeEach(func() {
+			requestBody = `{"disabled": true, "record_transcripts": true}`
+		})
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequest(
+				"PUT",
+				server.URL+"/api/v1/teams/a-team/intercept_settings",
+				bytes.NewBufferString(requestBody),
+			)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+				dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+			})
+
+			It("saves the settings", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+				Expect(fakeTeam.SetInterceptSettingsCallCount()).To(Equal(1))
+				Expect(fakeTeam.SetInterceptSettingsArgsForCall(0)).To(Equal(atc.InterceptSettings{
+					Disabled:          true,
+					RecordTranscripts: true,
+				}))
+			})
+
+			Context("when the settings are malformed", func() {
+				BeforeEach(func() {
+					requestBody = `{"disabled": "yes"}`
+				})
+
+				It("returns 400 without saving", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(fakeTeam.SetInterceptSettingsCallCount()).To(Equal(0))
+				})
+			})
+		})
+
+		Context("when unauthorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(false)
+				dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(fakeTeam.SetInterceptSettingsCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Describe("GET /api/v1/teams/:team_name/privileged_settings", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/teams/a-team/privileged_settings")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+				dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+				fakeTeam.PrivilegedSettingsReturns(atc.PrivilegedSettings{Policy: atc.PrivilegedPolicyWarn}, nil)
+			})
+
+			It("returns the settings", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(body).To(MatchJSON(`{"policy": "warn"}`))
+			})
+		})
+
+		Context("when unauthorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(false)
+				dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/teams/:team_name/privileged_settings", func() {
+		var (
+			response    *http.Response
+			requestBody string
+		)
+
+		BeforeEach(func() {
+			requestBody = `{"policy": "deny"}`
+		})
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequest(
+				"PUT",
+				server.URL+"/api/v1/teams/a-team/privileged_settings",
+				bytes.NewBufferString(requestBody),
+			)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated as an admin", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAdminReturns(true)
+				dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+			})
+
+			It("saves the settings", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+				Expect(fakeTeam.SetPrivilegedSettingsCallCount()).To(Equal(1))
+				Expect(fakeTeam.SetPrivilegedSettingsArgsForCall(0)).To(Equal(atc.PrivilegedSettings{
+					Policy: atc.PrivilegedPolicyDeny,
+				}))
+			})
+
+			Context("when the policy is unknown", func() {
+				BeforeEach(func() {
+					requestBody = `{"policy": "sometimes"}`
+				})
+
+				It("returns 400 without saving", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(fakeTeam.SetPrivilegedSettingsCallCount()).To(Equal(0))
+				})
+			})
+		})
+
+		Context("when authorized on the team but not an admin", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+				fakeAccess.IsAdminReturns(false)
+				dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(fakeTeam.SetPrivilegedSettingsCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Describe("GET /api/v1/teams/:team_name/proxy_settings", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/teams/a-team/proxy_settings")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+				dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+				fakeTeam.ProxySettingsReturns(atc.ProxySettings{HTTPProxy: "http://proxy:3128"}, nil)
+			})
+
+			It("returns the settings", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(body).To(MatchJSON(`{"http_proxy": "http://proxy:3128"}`))
+			})
+		})
+
+		Context("when unauthorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(false)
+				dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/teams/:team_name/proxy_settings", func() {
+		var (
+			response    *http.Response
+			requestBody string
+		)
+
+		BeforeEach(func() {
+			requestBody = `{"http_proxy": "http://proxy:3128", "no_proxy": "localhost"}`
+		})
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequest(
+				"PUT",
+				server.URL+"/api/v1/teams/a-team/proxy_settings",
+				bytes.NewBufferString(requestBody),
+			)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated as an admin", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAdminReturns(true)
+				dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+			})
+
+			It("saves the settings", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+				Expect(fakeTeam.SetProxySettingsCallCount()).To(Equal(1))
+				Expect(fakeTeam.SetProxySettingsArgsForCall(0)).To(Equal(atc.ProxySettings{
+					HTTPProxy: "http://proxy:3128",
+					NoProxy:   "localhost",
+				}))
+			})
+
+			Context("when a proxy is not a URL", func() {
+				BeforeEach(func() {
+					requestBody = `{"https_proxy": "proxy"}`
+				})
+
+				It("returns 400 without saving", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(fakeTeam.SetProxySettingsCallCount()).To(Equal(0))
+				})
+			})
+		})
+
+		Context("when authorized on the team but not an admin", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+				fakeAccess.IsAdminReturns(false)
+				dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(fakeTeam.SetProxySettingsCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Describe("GET /api/v1/worker_keys", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/worker_keys")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		BeforeEach(func() {
+			fakeAccess.IsAuthenticatedReturns(true)
+			dbTeamFactory.WorkerKeysReturns([]atc.TeamWorkerKeys{
+				{Team: "a-team", Keys: []string{"some-key"}},
+			}, nil)
+		})
+
+		Context("when requested by the system", func() {
+			BeforeEach(func() {
+				fakeAccess.IsSystemReturns(true)
+			})
+
+			It("returns the worker keys of every team", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`[
+					{"team": "a-team", "keys": ["some-key"]}
+				]`))
+			})
+		})
+
+		Context("when not requested by the system", func() {
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(dbTeamFactory.WorkerKeysCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Describe("DELETE /api/v1/teams/:team_name/notifiers/:notifier_name", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequest(
+				"DELETE",
+				server.URL+"/api/v1/teams/a-team/notifiers/some-notifier",
+				nil,
+			)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+				dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+				fakeTeam.DeleteNotifierReturns(true, nil)
+			})
+
+			It("deletes the notifier and returns 204", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+				Expect(fakeTeam.DeleteNotifierCallCount()).To(Equal(1))
+				Expect(fakeTeam.DeleteNotifierArgsForCall(0)).To(Equal("some-notifier"))
+			})
+
+			Context("when the notifier does not exist", func() {
+				BeforeEach(func() {
+					fakeTeam.DeleteNotifierReturns(false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+		})
+	})
 })
@@ -0,0 +1,58 @@
+package teamserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	. "github.com/concourse/concourse/atc/api/helpers"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) GetInterceptSettings(team db.Team) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("get-intercept-settings", lager.Data{"team": team.Name()})
+
+		settings, err := team.InterceptSettings()
+		if err != nil {
+			logger.Error("failed-to-get-intercept-settings", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(settings)
+		if err != nil {
+			logger.Error("failed-to-encode-intercept-settings", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
+
+// SetInterceptSettings controls whether the team's containers may be
+// intercepted, and whether intercept sessions are recorded to the builds'
+// events.
+func (s *Server) SetInterceptSettings(team db.Team) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("set-intercept-settings", lager.Data{"team": team.Name()})
+
+		var settings atc.InterceptSettings
+		err := json.NewDecoder(r.Body).Decode(&settings)
+		if err != nil {
+			logger.Info("malformed-request", lager.Data{"error": err.Error()})
+			HandleBadRequest(w, fmt.Sprintf("malformed intercept settings: %s", err))
+			return
+		}
+
+		err = team.SetInterceptSettings(settings)
+		if err != nil {
+			logger.Error("failed-to-set-intercept-settings", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
@@ -0,0 +1,117 @@
+package teamserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	. "github.com/concourse/concourse/atc/api/helpers"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/notify"
+)
+
+func (s *Server) ListNotifiers(team db.Team) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("list-notifiers", lager.Data{"team": team.Name()})
+
+		configs, err := team.Notifiers()
+		if err != nil {
+			logger.Error("failed-to-get-notifiers", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		notifiers := []atc.Notifier{}
+		for _, config := range configs {
+			notifiers = append(notifiers, atc.Notifier{
+				Name: config.Name,
+				Type: config.Type,
+			})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(notifiers)
+		if err != nil {
+			logger.Error("failed-to-encode-notifiers", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
+
+func (s *Server) SetNotifier(team db.Team) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		notifierName := r.FormValue(":notifier_name")
+
+		logger := s.logger.Session("set-notifier", lager.Data{
+			"team":     team.Name(),
+			"notifier": notifierName,
+		})
+
+		var notifier atc.NotifierConfig
+		err := json.NewDecoder(r.Body).Decode(&notifier)
+		if err != nil {
+			logger.Info("malformed-request", lager.Data{"error": err.Error()})
+			HandleBadRequest(w, fmt.Sprintf("malformed notifier: %s", err))
+			return
+		}
+
+		notifier.Name = notifierName
+
+		var warnings []atc.ConfigWarning
+		warning, err := atc.ValidateIdentifier(notifier.Name, "notifier")
+		if err != nil {
+			HandleBadRequest(w, err.Error())
+			return
+		}
+		if warning != nil {
+			warnings = append(warnings, *warning)
+		}
+
+		_, err = notify.NewSender(notifier)
+		if err != nil {
+			HandleBadRequest(w, fmt.Sprintf("invalid notifier: %s", err))
+			return
+		}
+
+		err = team.SaveNotifier(notifier)
+		if err != nil {
+			logger.Error("failed-to-save-notifier", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(atc.SaveConfigResponse{Warnings: warnings})
+		if err != nil {
+			logger.Error("failed-to-encode-response", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
+
+func (s *Server) DestroyNotifier(team db.Team) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		notifierName := r.FormValue(":notifier_name")
+
+		logger := s.logger.Session("destroy-notifier", lager.Data{
+			"team":     team.Name(),
+			"notifier": notifierName,
+		})
+
+		deleted, err := team.DeleteNotifier(notifierName)
+		if err != nil {
+			logger.Error("failed-to-delete-notifier", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !deleted {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
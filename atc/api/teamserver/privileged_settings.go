@@ -0,0 +1,64 @@
+package teamserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	. "github.com/concourse/concourse/atc/api/helpers"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) GetPrivilegedSettings(team db.Team) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("get-privileged-settings", lager.Data{"team": team.Name()})
+
+		settings, err := team.PrivilegedSettings()
+		if err != nil {
+			logger.Error("failed-to-get-privileged-settings", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(settings)
+		if err != nil {
+			logger.Error("failed-to-encode-privileged-settings", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
+
+// SetPrivilegedSettings controls whether the team's tasks and resource types
+// may use privileged containers, which only admins may change.
+func (s *Server) SetPrivilegedSettings(team db.Team) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("set-privileged-settings", lager.Data{"team": team.Name()})
+
+		var settings atc.PrivilegedSettings
+		err := json.NewDecoder(r.Body).Decode(&settings)
+		if err != nil {
+			logger.Info("malformed-request", lager.Data{"error": err.Error()})
+			HandleBadRequest(w, fmt.Sprintf("malformed privileged settings: %s", err))
+			return
+		}
+
+		err = settings.Policy.Validate()
+		if err != nil {
+			logger.Info("invalid-privileged-policy", lager.Data{"error": err.Error()})
+			HandleBadRequest(w, err.Error())
+			return
+		}
+
+		err = team.SetPrivilegedSettings(settings)
+		if err != nil {
+			logger.Error("failed-to-set-privileged-settings", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
@@ -0,0 +1,64 @@
+package teamserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	. "github.com/concourse/concourse/atc/api/helpers"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) GetProxySettings(team db.Team) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("get-proxy-settings", lager.Data{"team": team.Name()})
+
+		settings, err := team.ProxySettings()
+		if err != nil {
+			logger.Error("failed-to-get-proxy-settings", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(settings)
+		if err != nil {
+			logger.Error("failed-to-encode-proxy-settings", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
+
+// SetProxySettings configures the proxies through which the team's containers
+// egress, which only admins may change.
+func (s *Server) SetProxySettings(team db.Team) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("set-proxy-settings", lager.Data{"team": team.Name()})
+
+		var settings atc.ProxySettings
+		err := json.NewDecoder(r.Body).Decode(&settings)
+		if err != nil {
+			logger.Info("malformed-request", lager.Data{"error": err.Error()})
+			HandleBadRequest(w, fmt.Sprintf("malformed proxy settings: %s", err))
+			return
+		}
+
+		err = settings.Validate()
+		if err != nil {
+			logger.Info("invalid-proxy-settings", lager.Data{"error": err.Error()})
+			HandleBadRequest(w, err.Error())
+			return
+		}
+
+		err = team.SetProxySettings(settings)
+		if err != nil {
+			logger.Error("failed-to-set-proxy-settings", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
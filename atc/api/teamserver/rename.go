@@ -43,11 +43,23 @@ func (s *Server) RenameTeam(team db.Team) http.Handler {
 
 		err = team.Rename(rename.NewName)
 		if err != nil {
+			if err == db.ErrTeamNameTaken {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusConflict)
+				WriteSaveConfigResponse(w, atc.SaveConfigResponse{Errors: []string{err.Error()}})
+				return
+			}
+
 			logger.Error("failed-to-update-team-name", err)
 			w.WriteHeader(http.StatusInternalServerError)
 			return
 		}
 
+		err = s.teamFactory.NotifyCacher()
+		if err != nil {
+			logger.Error("failed-to-notify-cacher", err)
+		}
+
 		w.Header().Set("Content-Type", "application/json")
 		err = json.NewEncoder(w).Encode(atc.SaveConfigResponse{Warnings: warnings, Errors: errs})
 		if err != nil {
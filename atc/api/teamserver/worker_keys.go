@@ -0,0 +1,97 @@
+package teamserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/api/accessor"
+	. "github.com/concourse/concourse/atc/api/helpers"
+	"github.com/concourse/concourse/atc/db"
+	"golang.org/x/crypto/ssh"
+)
+
+func (s *Server) GetWorkerKeys(team db.Team) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("get-worker-keys", lager.Data{"team": team.Name()})
+
+		keys, err := team.WorkerKeys()
+		if err != nil {
+			logger.Error("failed-to-get-worker-keys", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(keys)
+		if err != nil {
+			logger.Error("failed-to-encode-worker-keys", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
+
+// SetWorkerKeys replaces the team's worker keys, each of which must be a
+// public key in authorized_keys format.
+func (s *Server) SetWorkerKeys(team db.Team) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("set-worker-keys", lager.Data{"team": team.Name()})
+
+		var keys []string
+		err := json.NewDecoder(r.Body).Decode(&keys)
+		if err != nil {
+			logger.Info("malformed-request", lager.Data{"error": err.Error()})
+			HandleBadRequest(w, fmt.Sprintf("malformed worker keys: %s", err))
+			return
+		}
+
+		for i, key := range keys {
+			key = strings.TrimSpace(key)
+
+			_, _, _, _, err := ssh.ParseAuthorizedKey([]byte(key))
+			if err != nil {
+				HandleBadRequest(w, fmt.Sprintf("invalid worker key #%d: %s", i+1, err))
+				return
+			}
+
+			keys[i] = key
+		}
+
+		err = team.SetWorkerKeys(keys)
+		if err != nil {
+			logger.Error("failed-to-set-worker-keys", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// ListWorkerKeys lists the worker keys of every team, for the TSA to
+// authorize the teams' workers with.
+func (s *Server) ListWorkerKeys(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("list-worker-keys")
+
+	acc := accessor.GetAccessor(r)
+	if !acc.IsSystem() {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	teamKeys, err := s.teamFactory.WorkerKeys()
+	if err != nil {
+		logger.Error("failed-to-get-worker-keys", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(teamKeys)
+	if err != nil {
+		logger.Error("failed-to-encode-worker-keys", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
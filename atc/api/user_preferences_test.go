@@ -0,0 +1,227 @@
+package api_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("User Preferences API", func() {
+	var response *http.Response
+
+	BeforeEach(func() {
+		fakeAccess.IsAuthenticatedReturns(true)
+		fakeAccess.UserInfoReturns(atc.UserInfo{Sub: "some-sub"})
+	})
+
+	Describe("GET /api/v1/user/preferences", func() {
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/user/preferences")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		BeforeEach(func() {
+			dbUserPreferences.UserPreferencesReturns(map[string]json.RawMessage{
+				"theme": json.RawMessage(`"dark"`),
+			}, nil)
+		})
+
+		It("returns the user's preferences", func() {
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+			Expect(dbUserPreferences.UserPreferencesArgsForCall(0)).To(Equal("some-sub"))
+			Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`{"theme":"dark"}`))
+		})
+
+		Context("when getting the preferences fails", func() {
+			BeforeEach(func() {
+				dbUserPreferences.UserPreferencesReturns(nil, errors.New("nope"))
+			})
+
+			It("returns 500", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/user/preferences/:preference_key", func() {
+		var (
+			key         string
+			requestBody string
+		)
+
+		BeforeEach(func() {
+			key = "theme"
+			requestBody = `"dark"`
+		})
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequest("PUT", server.URL+"/api/v1/user/preferences/"+key, bytes.NewBufferString(requestBody))
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("keeps the preference", func() {
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+			sub, key, value := dbUserPreferences.SetUserPreferenceArgsForCall(0)
+			Expect(sub).To(Equal("some-sub"))
+			Expect(key).To(Equal("theme"))
+			Expect(value).To(MatchJSON(`"dark"`))
+		})
+
+		Context("when the value is not JSON", func() {
+			BeforeEach(func() {
+				requestBody = `dark`
+			})
+
+			It("returns 400", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				Expect(dbUserPreferences.SetUserPreferenceCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when the value is too large", func() {
+			BeforeEach(func() {
+				requestBody = `"` + strings.Repeat("a", 64*1024) + `"`
+			})
+
+			It("returns 413", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusRequestEntityTooLarge))
+				Expect(dbUserPreferences.SetUserPreferenceCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when the key is invalid", func() {
+			BeforeEach(func() {
+				key = "Some%20Key"
+			})
+
+			It("returns 400", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				Expect(dbUserPreferences.SetUserPreferenceCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("DELETE /api/v1/user/preferences/:preference_key", func() {
+		JustBeforeEach(func() {
+			req, err := http.NewRequest("DELETE", server.URL+"/api/v1/user/preferences/theme", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("deletes the preference", func() {
+			Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+
+			sub, key := dbUserPreferences.DeleteUserPreferenceArgsForCall(0)
+			Expect(sub).To(Equal("some-sub"))
+			Expect(key).To(Equal("theme"))
+		})
+	})
+
+	Describe("GET /api/v1/teams/:team_name/users", func() {
+		var (
+			fakeTeam *dbfakes.FakeTeam
+			query    string
+		)
+
+		BeforeEach(func() {
+			query = ""
+
+			fakeTeam = new(dbfakes.FakeTeam)
+			fakeTeam.NameReturns("some-team")
+			fakeTeam.AuthReturns(atc.TeamAuth{
+				"owner":  {"users": {"github:some-owner"}},
+				"member": {"groups": {"github:some-org"}},
+			})
+			dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+
+			fakeAccess.IsAuthorizedReturns(true)
+
+			user := func(id int, name string, claims map[string]interface{}) db.User {
+				user := new(dbfakes.FakeUser)
+				user.IDReturns(id)
+				user.NameReturns(name)
+				user.ConnectorReturns("github")
+				user.LastLoginReturns(time.Unix(10, 0))
+				user.ClaimsReturns(db.Claims{RawClaims: claims})
+				return user
+			}
+
+			dbUserFactory.GetAllUsersReturns([]db.User{
+				user(1, "some-owner", map[string]interface{}{
+					"federated_claims": map[string]interface{}{"connector_id": "github", "user_id": "some-owner"},
+				}),
+				user(2, "some-member", map[string]interface{}{
+					"federated_claims": map[string]interface{}{"connector_id": "github", "user_id": "some-member"},
+					"groups":           []interface{}{"some-org"},
+				}),
+				user(3, "some-stranger", map[string]interface{}{
+					"federated_claims": map[string]interface{}{"connector_id": "github", "user_id": "some-stranger"},
+				}),
+				user(4, "some-old-user", nil),
+			}, nil)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/users" + query)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the users with a role in the team", func() {
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+			Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`[
+				{"id": 1, "username": "some-owner", "connector": "github", "last_login": 10, "roles": ["owner"]},
+				{"id": 2, "username": "some-member", "connector": "github", "last_login": 10, "roles": ["member"]}
+			]`))
+		})
+
+		Context("when the since date is malformed", func() {
+			BeforeEach(func() {
+				query = "?since=yesterday"
+			})
+
+			It("returns 400", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(dbUserFactory.GetAllUsersCallCount()).To(BeZero())
+			})
+		})
+	})
+})
@@ -0,0 +1,128 @@
+package usersserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/api/accessor"
+)
+
+func (s *Server) GetDashboardPreferences(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("get-dashboard-preferences")
+
+	sub, ok := userSub(w, r)
+	if !ok {
+		return
+	}
+
+	preferences, err := s.dashboardPreferenceRepo.DashboardPreferences(sub)
+	if err != nil {
+		logger.Error("failed-to-get-dashboard-preferences", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	err = json.NewEncoder(w).Encode(preferences)
+	if err != nil {
+		logger.Error("failed-to-encode-dashboard-preferences", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+func (s *Server) OrderDashboardPipelines(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("order-dashboard-pipelines")
+
+	sub, ok := userSub(w, r)
+	if !ok {
+		return
+	}
+
+	var pipelineIDs []int
+	err := json.NewDecoder(r.Body).Decode(&pipelineIDs)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "malformed request: %s", err)
+		return
+	}
+
+	err = s.dashboardPreferenceRepo.OrderDashboardPipelines(sub, pipelineIDs)
+	if err != nil {
+		logger.Error("failed-to-order-dashboard-pipelines", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) FavoritePipeline(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("favorite-pipeline")
+
+	sub, ok := userSub(w, r)
+	if !ok {
+		return
+	}
+
+	pipelineID, err := strconv.Atoi(r.FormValue(":pipeline_id"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	found, err := s.dashboardPreferenceRepo.FavoritePipeline(sub, pipelineID)
+	if err != nil {
+		logger.Error("failed-to-favorite-pipeline", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		logger.Info("pipeline-not-found", lager.Data{"pipeline_id": pipelineID})
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) UnfavoritePipeline(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("unfavorite-pipeline")
+
+	sub, ok := userSub(w, r)
+	if !ok {
+		return
+	}
+
+	pipelineID, err := strconv.Atoi(r.FormValue(":pipeline_id"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	err = s.dashboardPreferenceRepo.UnfavoritePipeline(sub, pipelineID)
+	if err != nil {
+		logger.Error("failed-to-unfavorite-pipeline", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// userSub returns the subject the user's preferences are kept under. Tokens
+// without a subject, such as the system's, have nowhere to keep them.
+func userSub(w http.ResponseWriter, r *http.Request) (string, bool) {
+	sub := accessor.GetAccessor(r).UserInfo().Sub
+	if sub == "" {
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, "preferences can only be kept for users")
+		return "", false
+	}
+
+	return sub, true
+}
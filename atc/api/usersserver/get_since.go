@@ -17,21 +17,16 @@ func (s *Server) GetUsersSince(w http.ResponseWriter, r *http.Request) {
 	hLog := s.logger.Session("list-users")
 	w.Header().Set("Content-Type", "application/json")
 
-	var (
-		users []db.User
-		err   error
-	)
-
-	err = r.ParseForm()
+	err := r.ParseForm()
 	if err != nil {
 		hLog.Error("failed-to-parse-form-data", err)
 		w.WriteHeader(http.StatusBadRequest)
 		return
 	}
 
-	if isSinceSet(r) {
-		tmSince, err := time.Parse(dateLayout, r.FormValue(since))
-		if err != nil {
+	users, err := s.usersSince(r)
+	if err != nil {
+		if _, ok := err.(*time.ParseError); ok {
 			hLog.Error("failed-to-parse-time", err)
 			w.WriteHeader(http.StatusBadRequest)
 			if err = json.NewEncoder(w).Encode(map[string]string{"error": "wrong date format (yyyy-mm-dd)"}); err != nil {
@@ -40,19 +35,10 @@ func (s *Server) GetUsersSince(w http.ResponseWriter, r *http.Request) {
 			}
 			return
 		}
-		users, err = s.userFactory.GetAllUsersByLoginDate(tmSince)
-		if err != nil {
-			hLog.Error("failed-to-get-users-since", err)
-			w.WriteHeader(http.StatusInternalServerError)
-			return
-		}
-	} else {
-		users, err = s.userFactory.GetAllUsers()
-		if err != nil {
-			hLog.Error("failed-to-get-users", err)
-			w.WriteHeader(http.StatusInternalServerError)
-			return
-		}
+
+		hLog.Error("failed-to-get-users", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
 	}
 
 	presentedUsers := make([]atc.User, len(users))
@@ -67,6 +53,21 @@ func (s *Server) GetUsersSince(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// usersSince returns the users who have logged in since the date the request
+// gives, or every user if it gives none.
+func (s *Server) usersSince(r *http.Request) ([]db.User, error) {
+	if !isSinceSet(r) {
+		return s.userFactory.GetAllUsers()
+	}
+
+	tmSince, err := time.Parse(dateLayout, r.FormValue(since))
+	if err != nil {
+		return nil, err
+	}
+
+	return s.userFactory.GetAllUsersByLoginDate(tmSince)
+}
+
 func isSinceSet(r *http.Request) bool {
 	return len(r.FormValue(since)) > 0
 }
@@ -0,0 +1,118 @@
+package usersserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"regexp"
+
+	"code.cloudfoundry.org/lager"
+)
+
+// MaxUserPreferenceSize is the most a preference's value may take up, which
+// stops clients from using preferences as general purpose storage.
+const MaxUserPreferenceSize = 64 * 1024
+
+var preferenceKeyRegexp = regexp.MustCompile(`^[a-z0-9][a-z0-9_.-]{0,63}$`)
+
+func (s *Server) GetUserPreferences(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("get-user-preferences")
+
+	sub, ok := userSub(w, r)
+	if !ok {
+		return
+	}
+
+	preferences, err := s.userPreferenceRepo.UserPreferences(sub)
+	if err != nil {
+		logger.Error("failed-to-get-user-preferences", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	err = json.NewEncoder(w).Encode(preferences)
+	if err != nil {
+		logger.Error("failed-to-encode-user-preferences", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+func (s *Server) SetUserPreference(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("set-user-preference")
+
+	sub, ok := userSub(w, r)
+	if !ok {
+		return
+	}
+
+	key, ok := preferenceKey(w, r)
+	if !ok {
+		return
+	}
+
+	value, err := ioutil.ReadAll(io.LimitReader(r.Body, MaxUserPreferenceSize+1))
+	if err != nil {
+		logger.Error("failed-to-read-body", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if len(value) > MaxUserPreferenceSize {
+		w.WriteHeader(http.StatusRequestEntityTooLarge)
+		fmt.Fprintf(w, "preferences may be at most %d bytes", MaxUserPreferenceSize)
+		return
+	}
+
+	if !json.Valid(value) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, "preference must be JSON")
+		return
+	}
+
+	err = s.userPreferenceRepo.SetUserPreference(sub, key, json.RawMessage(value))
+	if err != nil {
+		logger.Error("failed-to-set-user-preference", err, lager.Data{"key": key})
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) DeleteUserPreference(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("delete-user-preference")
+
+	sub, ok := userSub(w, r)
+	if !ok {
+		return
+	}
+
+	key, ok := preferenceKey(w, r)
+	if !ok {
+		return
+	}
+
+	err := s.userPreferenceRepo.DeleteUserPreference(sub, key)
+	if err != nil {
+		logger.Error("failed-to-delete-user-preference", err, lager.Data{"key": key})
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func preferenceKey(w http.ResponseWriter, r *http.Request) (string, bool) {
+	key := r.FormValue(":preference_key")
+	if !preferenceKeyRegexp.MatchString(key) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "invalid preference key '%s': must be lowercase letters, numbers, '_', '.' and '-', at most 64 long", key)
+		return "", false
+	}
+
+	return key, true
+}
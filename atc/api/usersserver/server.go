@@ -6,16 +6,25 @@ import (
 )
 
 type Server struct {
-	logger      lager.Logger
-	userFactory db.UserFactory
+	logger                  lager.Logger
+	userFactory             db.UserFactory
+	dashboardPreferenceRepo db.DashboardPreferenceRepository
+	userPreferenceRepo      db.UserPreferenceRepository
+	sessionRepo             db.SessionRepository
 }
 
 func NewServer(
 	logger lager.Logger,
 	userFactory db.UserFactory,
+	dashboardPreferenceRepo db.DashboardPreferenceRepository,
+	userPreferenceRepo db.UserPreferenceRepository,
+	sessionRepo db.SessionRepository,
 ) *Server {
 	return &Server{
-		logger:      logger,
-		userFactory: userFactory,
+		logger:                  logger,
+		userFactory:             userFactory,
+		dashboardPreferenceRepo: dashboardPreferenceRepo,
+		userPreferenceRepo:      userPreferenceRepo,
+		sessionRepo:             sessionRepo,
 	}
 }
@@ -0,0 +1,162 @@
+package usersserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/accessor"
+	"github.com/concourse/concourse/atc/api/present"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) ListSessions(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("list-sessions")
+
+	sub, ok := sessionsSub(w, r)
+	if !ok {
+		return
+	}
+
+	s.writeSessions(logger, w, r, sub)
+}
+
+func (s *Server) RevokeSession(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("revoke-session")
+
+	sub, ok := sessionsSub(w, r)
+	if !ok {
+		return
+	}
+
+	sessionID, err := strconv.Atoi(r.FormValue(":session_id"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	acc := accessor.GetAccessor(r)
+
+	found, err := s.sessionRepo.RevokeSession(sub, sessionID, acc.UserInfo().DisplayUserId)
+	if err != nil {
+		logger.Error("failed-to-revoke-session", err, lager.Data{"session_id": sessionID})
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		logger.Info("session-not-found", lager.Data{"session_id": sessionID})
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) ListUserSessions(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("list-user-sessions")
+
+	user, found := s.findUser(logger, w, r)
+	if !found {
+		return
+	}
+
+	s.writeSessions(logger, w, r, user.Sub())
+}
+
+// RevokeUserSessions revokes every one of a user's sessions, so that they
+// have to log in again.
+func (s *Server) RevokeUserSessions(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("revoke-user-sessions")
+
+	user, found := s.findUser(logger, w, r)
+	if !found {
+		return
+	}
+
+	acc := accessor.GetAccessor(r)
+
+	revoked, err := s.sessionRepo.RevokeSessions(user.Sub(), acc.UserInfo().DisplayUserId)
+	if err != nil {
+		logger.Error("failed-to-revoke-sessions", err, lager.Data{"user_id": user.ID()})
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	logger.Info("revoked-sessions", lager.Data{"user_id": user.ID(), "sessions": revoked})
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) writeSessions(logger lager.Logger, w http.ResponseWriter, r *http.Request, sub string) {
+	sessions, err := s.sessionRepo.Sessions(sub)
+	if err != nil {
+		logger.Error("failed-to-get-sessions", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	currentToken := bearerToken(r)
+
+	presented := []atc.Session{}
+	for _, session := range sessions {
+		presented = append(presented, present.Session(session, session.Token == currentToken))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	err = json.NewEncoder(w).Encode(presented)
+	if err != nil {
+		logger.Error("failed-to-encode-sessions", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+func (s *Server) findUser(logger lager.Logger, w http.ResponseWriter, r *http.Request) (db.User, bool) {
+	userID, err := strconv.Atoi(r.FormValue(":user_id"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return nil, false
+	}
+
+	user, found, err := s.userFactory.GetUser(userID)
+	if err != nil {
+		logger.Error("failed-to-get-user", err, lager.Data{"user_id": userID})
+		w.WriteHeader(http.StatusInternalServerError)
+		return nil, false
+	}
+
+	if !found {
+		logger.Info("user-not-found", lager.Data{"user_id": userID})
+		w.WriteHeader(http.StatusNotFound)
+		return nil, false
+	}
+
+	return user, true
+}
+
+// sessionsSub returns the subject the requesting user's tokens were issued
+// for. Tokens without a subject, such as the system's, are not sessions.
+func sessionsSub(w http.ResponseWriter, r *http.Request) (string, bool) {
+	sub := accessor.GetAccessor(r).UserInfo().Sub
+	if sub == "" {
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, "only users have sessions")
+		return "", false
+	}
+
+	return sub, true
+}
+
+func bearerToken(r *http.Request) string {
+	parts := strings.Split(r.Header.Get("Authorization"), " ")
+	if len(parts) != 2 || !strings.EqualFold(parts[0], "bearer") {
+		return ""
+	}
+
+	return parts[1]
+}
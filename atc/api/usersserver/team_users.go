@@ -0,0 +1,75 @@
+package usersserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/accessor"
+	"github.com/concourse/concourse/atc/api/present"
+	"github.com/concourse/concourse/atc/db"
+)
+
+// ListTeamUsers lists the users whose last login gave them a role in the
+// team, working their roles out from their claims the same way requests are
+// authorized. Users who have not logged in since their claims started being
+// recorded are left out.
+func (s *Server) ListTeamUsers(team db.Team) http.Handler {
+	logger := s.logger.Session("list-team-users")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		users, err := s.usersSince(r)
+		if err != nil {
+			if _, ok := err.(*time.ParseError); ok {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			logger.Error("failed-to-get-users", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		presentedUsers := []atc.User{}
+		for _, user := range users {
+			claims := user.Claims()
+			if claims.RawClaims == nil {
+				continue
+			}
+
+			acc := accessor.NewAccessor(
+				accessor.Verification{
+					HasToken:     true,
+					IsTokenValid: true,
+					RawClaims:    claims.RawClaims,
+				},
+				"",
+				"",
+				nil,
+				[]db.Team{team},
+				nil,
+			)
+
+			roles := acc.TeamRoles()[team.Name()]
+			if len(roles) == 0 {
+				continue
+			}
+
+			sort.Strings(roles)
+
+			presentedUser := present.User(user)
+			presentedUser.Roles = roles
+			presentedUsers = append(presentedUsers, presentedUser)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		err = json.NewEncoder(w).Encode(presentedUsers)
+		if err != nil {
+			logger.Error("failed-to-encode-users", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
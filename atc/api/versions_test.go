@@ -1,10 +1,12 @@
 package api_test
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/concourse/concourse/atc"
@@ -853,6 +855,31 @@ var _ = Describe("Versions API", func() {
 				It("returns 200 OK", func() {
 					Expect(response.StatusCode).To(Equal(http.StatusOK))
 				})
+
+				Context("when the pipeline only exposes some of its jobs", func() {
+					BeforeEach(func() {
+						fakePipeline.ExposureReturns(atc.PipelineExposure{Jobs: []string{"exposed-job"}})
+
+						exposedBuild := new(dbfakes.FakeBuild)
+						exposedBuild.IDReturns(1)
+						exposedBuild.JobNameReturns("exposed-job")
+
+						hiddenBuild := new(dbfakes.FakeBuild)
+						hiddenBuild.IDReturns(2)
+						hiddenBuild.JobNameReturns("hidden-job")
+
+						fakePipeline.GetBuildsWithVersionAsInputReturns([]db.Build{exposedBuild, hiddenBuild}, nil)
+					})
+
+					It("leaves out the builds of the hidden jobs", func() {
+						var builds []atc.Build
+						err := json.NewDecoder(response.Body).Decode(&builds)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(builds).To(HaveLen(1))
+						Expect(builds[0].ID).To(Equal(1))
+					})
+				})
 			})
 		})
 
@@ -1053,6 +1080,31 @@ var _ = Describe("Versions API", func() {
 				It("returns 200 OK", func() {
 					Expect(response.StatusCode).To(Equal(http.StatusOK))
 				})
+
+				Context("when the pipeline only exposes some of its jobs", func() {
+					BeforeEach(func() {
+						fakePipeline.ExposureReturns(atc.PipelineExposure{Jobs: []string{"exposed-job"}})
+
+						exposedBuild := new(dbfakes.FakeBuild)
+						exposedBuild.IDReturns(1)
+						exposedBuild.JobNameReturns("exposed-job")
+
+						hiddenBuild := new(dbfakes.FakeBuild)
+						hiddenBuild.IDReturns(2)
+						hiddenBuild.JobNameReturns("hidden-job")
+
+						fakePipeline.GetBuildsWithVersionAsOutputReturns([]db.Build{exposedBuild, hiddenBuild}, nil)
+					})
+
+					It("leaves out the builds of the hidden jobs", func() {
+						var builds []atc.Build
+						err := json.NewDecoder(response.Body).Decode(&builds)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(builds).To(HaveLen(1))
+						Expect(builds[0].ID).To(Equal(1))
+					})
+				})
 			})
 		})
 
@@ -1192,6 +1244,402 @@ var _ = Describe("Versions API", func() {
 		})
 	})
 
+	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/impact", func() {
+		var response *http.Response
+		var queryParams string
+		var fakeResource *dbfakes.FakeResource
+
+		BeforeEach(func() {
+			atc.EnableResourceCausality = true
+
+			queryParams = ""
+
+			fakeResource = new(dbfakes.FakeResource)
+			fakeResource.IDReturns(1)
+			fakePipeline.NameReturns("a-pipeline")
+			fakePipeline.ResourceReturns(fakeResource, true, nil)
+			fakeResource.DownstreamImpactReturns([]atc.ImpactedBuild{}, db.Pagination{}, true, nil)
+
+			fakeAccess.IsAuthenticatedReturns(true)
+			fakeAccess.IsAuthorizedReturns(true)
+		})
+
+		AfterEach(func() {
+			atc.EnableResourceCausality = false
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/teams/a-team/pipelines/a-pipeline/resources/some-resource/versions/123/impact" + queryParams)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when causality is disabled", func() {
+			BeforeEach(func() {
+				atc.EnableResourceCausality = false
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when the impacted builds are found", func() {
+			BeforeEach(func() {
+				queryParams = "?limit=1"
+
+				fakeResource.DownstreamImpactReturns([]atc.ImpactedBuild{
+					{
+						ID:           4,
+						Name:         "2",
+						Status:       atc.StatusSucceeded,
+						TeamName:     "a-team",
+						PipelineID:   1,
+						PipelineName: "a-pipeline",
+						JobID:        2,
+						JobName:      "deploy",
+						Inputs: []atc.ImpactedResourceVersion{
+							{ID: 123, ResourceID: 1, ResourceName: "some-resource", Version: atc.Version{"ref": "abc"}},
+						},
+						Outputs: []atc.ImpactedResourceVersion{},
+					},
+				}, db.Pagination{
+					Older: &db.Page{To: db.NewIntPtr(3), Limit: 1},
+				}, true, nil)
+			})
+
+			It("fetches the impact of the version within the pipeline", func() {
+				Expect(fakeResource.DownstreamImpactCallCount()).To(Equal(1))
+				versionID, acrossPipelines, page := fakeResource.DownstreamImpactArgsForCall(0)
+				Expect(versionID).To(Equal(123))
+				Expect(acrossPipelines).To(BeFalse())
+				Expect(page).To(Equal(db.Page{Limit: 1}))
+			})
+
+			It("returns the builds", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(body).To(MatchJSON(`[
+					{
+						"id": 4,
+						"name": "2",
+						"status": "succeeded",
+						"team_name": "a-team",
+						"pipeline_id": 1,
+						"pipeline_name": "a-pipeline",
+						"job_id": 2,
+						"job_name": "deploy",
+						"inputs": [
+							{"id": 123, "resource_id": 1, "resource_name": "some-resource", "version": {"ref": "abc"}}
+						],
+						"outputs": []
+					}
+				]`))
+			})
+
+			It("returns a Link header to the older builds", func() {
+				Expect(response.Header["Link"]).To(ConsistOf([]string{
+					`<https://example.com/api/v1/teams/a-team/pipelines/a-pipeline/resources/some-resource/versions/123/impact?limit=1&to=3>; rel="next"`,
+				}))
+			})
+		})
+
+		Context("when only allowed to see the public pipeline", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthorizedReturns(false)
+				fakePipeline.PublicReturns(true)
+				fakePipeline.ExposureReturns(atc.PipelineExposure{Jobs: []string{"exposed-job"}})
+
+				fakeResource.DownstreamImpactReturns([]atc.ImpactedBuild{
+					{ID: 4, Name: "1", JobName: "exposed-job"},
+					{ID: 5, Name: "1", JobName: "hidden-job"},
+				}, db.Pagination{}, true, nil)
+			})
+
+			It("leaves out the builds of the jobs the pipeline hides", func() {
+				var builds []atc.ImpactedBuild
+				err := json.NewDecoder(response.Body).Decode(&builds)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(builds).To(HaveLen(1))
+				Expect(builds[0].ID).To(Equal(4))
+			})
+		})
+
+		Context("when tracing across pipelines", func() {
+			BeforeEach(func() {
+				queryParams = "?across_pipelines=true"
+			})
+
+			It("fetches the impact across the team's pipelines", func() {
+				Expect(fakeResource.DownstreamImpactCallCount()).To(Equal(1))
+				_, acrossPipelines, _ := fakeResource.DownstreamImpactArgsForCall(0)
+				Expect(acrossPipelines).To(BeTrue())
+			})
+
+			Context("when only allowed to see the public pipeline", func() {
+				BeforeEach(func() {
+					fakeAccess.IsAuthorizedReturns(false)
+					fakePipeline.PublicReturns(true)
+				})
+
+				It("returns 403", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+					Expect(fakeResource.DownstreamImpactCallCount()).To(BeZero())
+				})
+			})
+		})
+
+		Context("when the version is not found", func() {
+			BeforeEach(func() {
+				fakeResource.DownstreamImpactReturns(nil, db.Pagination{}, false, nil)
+			})
+
+			It("returns 404", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+			})
+		})
+
+		Context("when the graph is too large", func() {
+			BeforeEach(func() {
+				fakeResource.DownstreamImpactReturns(nil, db.Pagination{}, false, db.ErrTooManyBuilds)
+			})
+
+			It("returns 422", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnprocessableEntity))
+			})
+		})
+	})
+
+	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/downstream", func() {
+		var response *http.Response
+		var fakeResource *dbfakes.FakeResource
+
+		BeforeEach(func() {
+			atc.EnableResourceCausality = true
+
+			fakeResource = new(dbfakes.FakeResource)
+			fakePipeline.ResourceReturns(fakeResource, true, nil)
+			fakeResource.CausalityReturns(atc.Causality{
+				Jobs: []atc.CausalityJob{
+					{ID: 1, Name: "exposed-job", BuildIDs: []int{10}},
+					{ID: 2, Name: "hidden-job", BuildIDs: []int{20}},
+				},
+				Builds: []atc.CausalityBuild{
+					{ID: 10, Name: "1", JobId: 1, Status: atc.StatusSucceeded},
+					{ID: 20, Name: "1", JobId: 2, Status: atc.StatusSucceeded},
+				},
+				Resources: []atc.CausalityResource{
+					{ID: 1, Name: "some-resource", VersionIDs: []int{123}},
+				},
+				ResourceVersions: []atc.CausalityResourceVersion{
+					{ID: 123, ResourceID: 1, Version: atc.Version{"ref": "abc"}, BuildIDs: []int{10, 20}},
+				},
+			}, true, nil)
+		})
+
+		AfterEach(func() {
+			atc.EnableResourceCausality = false
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/teams/a-team/pipelines/a-pipeline/resources/some-resource/versions/123/downstream")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+				fakePipeline.ExposureReturns(atc.PipelineExposure{Jobs: []string{"exposed-job"}})
+			})
+
+			It("returns every job", func() {
+				var causality atc.Causality
+				err := json.NewDecoder(response.Body).Decode(&causality)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(causality.Jobs).To(HaveLen(2))
+				Expect(causality.Builds).To(HaveLen(2))
+			})
+		})
+
+		Context("when only allowed to see the public pipeline", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthorizedReturns(false)
+				fakePipeline.PublicReturns(true)
+				fakePipeline.ExposureReturns(atc.PipelineExposure{Jobs: []string{"exposed-job"}})
+			})
+
+			It("leaves out the jobs the pipeline hides and their builds", func() {
+				var causality atc.Causality
+				err := json.NewDecoder(response.Body).Decode(&causality)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(causality.Jobs).To(Equal([]atc.CausalityJob{
+					{ID: 1, Name: "exposed-job", BuildIDs: []int{10}},
+				}))
+				Expect(causality.Builds).To(Equal([]atc.CausalityBuild{
+					{ID: 10, Name: "1", JobId: 1, Status: atc.StatusSucceeded},
+				}))
+				Expect(causality.ResourceVersions[0].BuildIDs).To(Equal([]int{10}))
+			})
+		})
+	})
+
+	Describe("POST /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions", func() {
+		var response *http.Response
+		var requestBody string
+		var fakeResource *dbfakes.FakeResource
+
+		BeforeEach(func() {
+			requestBody = `[{"ref": "v1"}, {"ref": "v2"}]`
+
+			fakeResource = new(dbfakes.FakeResource)
+			fakeResource.ConfigReturns(atc.ResourceConfig{
+				Name: "some-resource",
+				Push: &atc.PushConfig{Version: []string{"ref"}},
+			})
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			request, err := http.NewRequest("POST", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/resources/some-resource/versions", strings.NewReader(requestBody))
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+			})
+
+			Context("when not authorized", func() {
+				BeforeEach(func() {
+					fakeAccess.IsAuthorizedReturns(false)
+				})
+
+				It("returns Forbidden", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				})
+			})
+
+			Context("when authorized", func() {
+				BeforeEach(func() {
+					fakeAccess.IsAuthorizedReturns(true)
+				})
+
+				Context("when the resource exists", func() {
+					BeforeEach(func() {
+						fakePipeline.ResourceReturns(fakeResource, true, nil)
+					})
+
+					It("pushes the versions", func() {
+						Expect(fakePipeline.ResourceArgsForCall(0)).To(Equal("some-resource"))
+						Expect(fakeResource.PushVersionsCallCount()).To(Equal(1))
+
+						_, versions := fakeResource.PushVersionsArgsForCall(0)
+						Expect(versions).To(Equal([]atc.Version{{"ref": "v1"}, {"ref": "v2"}}))
+					})
+
+					It("returns the number of versions pushed", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(body).To(MatchJSON(`{"versions_pushed": 2}`))
+					})
+
+					Context("when a version does not match the declared fields", func() {
+						BeforeEach(func() {
+							requestBody = `[{"ref": "v1"}, {"sha": "abc"}]`
+						})
+
+						It("returns 400 without pushing any of them", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+
+							body, err := ioutil.ReadAll(response.Body)
+							Expect(err).NotTo(HaveOccurred())
+							Expect(string(body)).To(ContainSubstring("version 1: missing field 'ref'"))
+
+							Expect(fakeResource.PushVersionsCallCount()).To(Equal(0))
+						})
+					})
+
+					Context("when the resource is not in push mode", func() {
+						BeforeEach(func() {
+							fakeResource.ConfigReturns(atc.ResourceConfig{Name: "some-resource"})
+						})
+
+						It("returns 409", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusConflict))
+							Expect(fakeResource.PushVersionsCallCount()).To(Equal(0))
+						})
+					})
+
+					Context("when the resource type is not a base type", func() {
+						BeforeEach(func() {
+							fakeResource.PushVersionsReturns(db.BaseResourceTypeNotFoundError{Name: "some-custom-type"})
+						})
+
+						It("returns 422", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusUnprocessableEntity))
+						})
+					})
+
+					Context("when pushing the versions fails", func() {
+						BeforeEach(func() {
+							fakeResource.PushVersionsReturns(errors.New("nope"))
+						})
+
+						It("returns 500", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+						})
+					})
+
+					Context("when the request body is malformed", func() {
+						BeforeEach(func() {
+							requestBody = `{"ref": "v1"}`
+						})
+
+						It("returns 400", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+						})
+					})
+				})
+
+				Context("when the resource is not found", func() {
+					BeforeEach(func() {
+						fakePipeline.ResourceReturns(nil, false, nil)
+					})
+
+					It("returns 404", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+					})
+				})
+			})
+		})
+	})
+
 	Describe("DELETE /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions", func() {
 		var response *http.Response
 		var fakeResource *dbfakes.FakeResource
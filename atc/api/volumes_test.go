@@ -2,6 +2,8 @@ package api_test
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
 	"errors"
 	"io"
 	"io/ioutil"
@@ -11,6 +13,8 @@ import (
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/runtime"
+	"github.com/concourse/concourse/atc/runtime/runtimetest"
 	. "github.com/concourse/concourse/atc/testhelpers"
 
 	. "github.com/onsi/ginkgo"
@@ -532,4 +536,90 @@ var _ = Describe("Volumes API", func() {
 			})
 		})
 	})
+
+	Describe("GET /api/v1/builds/:build_id/volumes", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/builds/42/volumes")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401 Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("when authenticated and authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+
+				build.IDReturns(42)
+				build.TeamIDReturns(1)
+				build.TeamNameReturns("some-team")
+				build.AllAssociatedTeamNamesReturns([]string{"some-team"})
+				dbBuildFactory.BuildForAPIReturns(build, true, nil)
+
+				createdContainer := new(dbfakes.FakeCreatedContainer)
+				createdContainer.HandleReturns("some-container-handle")
+				creatingContainer := new(dbfakes.FakeCreatingContainer)
+				fakeContainerRepository.FindBuildContainersReturns([]db.Container{createdContainer, creatingContainer}, nil)
+
+				sizedVolume := new(dbfakes.FakeCreatedVolume)
+				sizedVolume.HandleReturns("some-sized-handle")
+				sizedVolume.WorkerNameReturns(fakeWorker.Name())
+				sizedVolume.TypeReturns(db.VolumeTypeContainer)
+				unreachableVolume := new(dbfakes.FakeCreatedVolume)
+				unreachableVolume.HandleReturns("some-unreachable-handle")
+				unreachableVolume.WorkerNameReturns(fakeWorker.Name())
+				unreachableVolume.TypeReturns(db.VolumeTypeContainer)
+				fakeVolumeRepository.FindVolumesForContainerReturns([]db.CreatedVolume{sizedVolume, unreachableVolume}, nil)
+
+				fakeWorkerPool.LocateVolumeStub = func(_ context.Context, _ int, handle string) (runtime.Volume, runtime.Worker, bool, error) {
+					if handle != "some-sized-handle" {
+						return nil, nil, false, errors.New("worker unreachable")
+					}
+
+					volume := runtimetest.NewVolume(handle).WithContent(runtimetest.VolumeContent{
+						"some-file": {Data: []byte("some-content")},
+					})
+					return volume, runtimetest.NewWorker("some-worker"), true, nil
+				}
+			})
+
+			It("only finds the volumes of created containers", func() {
+				Expect(fakeVolumeRepository.FindVolumesForContainerCallCount()).To(Equal(1))
+				Expect(fakeVolumeRepository.FindVolumesForContainerArgsForCall(0).Handle()).To(Equal("some-container-handle"))
+			})
+
+			It("locates the volumes within the build's team", func() {
+				Expect(fakeWorkerPool.LocateVolumeCallCount()).To(Equal(2))
+				_, teamID, _ := fakeWorkerPool.LocateVolumeArgsForCall(0)
+				Expect(teamID).To(Equal(1))
+			})
+
+			It("returns the volumes with the sizes their workers report", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+				var volumes []atc.Volume
+				err := json.NewDecoder(response.Body).Decode(&volumes)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(volumes).To(HaveLen(2))
+				Expect(volumes[0].ID).To(Equal("some-sized-handle"))
+				Expect(volumes[0].State).To(Equal("created"))
+				Expect(volumes[0].Size).NotTo(BeNil())
+				Expect(*volumes[0].Size).To(Equal(int64(len("some-content"))))
+				Expect(volumes[1].ID).To(Equal("some-unreachable-handle"))
+				Expect(volumes[1].Size).To(BeNil())
+			})
+		})
+	})
 })
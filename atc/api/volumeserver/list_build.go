@@ -0,0 +1,103 @@
+package volumeserver
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/present"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/runtime"
+)
+
+// sizeTimeout bounds how long to wait on a worker for each volume's size, so
+// that a single unresponsive worker doesn't hold up the whole listing.
+const sizeTimeout = 5 * time.Second
+
+// ListBuildVolumes lists the volumes of the build's created containers along
+// with their sizes on disk, as reported by their workers. The size is omitted
+// for any volume whose worker could not be reached.
+func (s *Server) ListBuildVolumes(build db.BuildForAPI) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hLog := s.logger.Session("list-build-volumes", build.LagerData())
+
+		containers, err := s.containerRepository.FindBuildContainers(build.ID())
+		if err != nil {
+			hLog.Error("failed-to-find-containers", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		presentedVolumes := []atc.Volume{}
+		for _, container := range containers {
+			createdContainer, ok := container.(db.CreatedContainer)
+			if !ok {
+				continue
+			}
+
+			volumes, err := s.repository.FindVolumesForContainer(createdContainer)
+			if err != nil {
+				hLog.Error("failed-to-find-volumes", err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			for _, volume := range volumes {
+				vol, err := present.Volume(volume)
+				if err != nil {
+					hLog.Error("failed-to-present-volume", err)
+					continue
+				}
+
+				vol.State = string(db.VolumeStateCreated)
+
+				size, found := s.volumeSize(r.Context(), hLog, build.TeamID(), volume.Handle())
+				if found {
+					vol.Size = &size
+				}
+
+				presentedVolumes = append(presentedVolumes, vol)
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(presentedVolumes)
+		if err != nil {
+			hLog.Error("failed-to-encode-volumes", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
+
+func (s *Server) volumeSize(ctx context.Context, logger lager.Logger, teamID int, handle string) (int64, bool) {
+	ctx, cancel := context.WithTimeout(ctx, sizeTimeout)
+	defer cancel()
+
+	logger = logger.WithData(lager.Data{"volume": handle})
+
+	volume, _, found, err := s.workerPool.LocateVolume(ctx, teamID, handle)
+	if err != nil {
+		logger.Info("failed-to-locate-volume", lager.Data{"error": err.Error()})
+		return 0, false
+	}
+
+	if !found {
+		return 0, false
+	}
+
+	sized, ok := volume.(runtime.SizedVolume)
+	if !ok {
+		return 0, false
+	}
+
+	size, err := sized.Size(ctx)
+	if err != nil {
+		logger.Info("failed-to-get-volume-size", lager.Data{"error": err.Error()})
+		return 0, false
+	}
+
+	return size, true
+}
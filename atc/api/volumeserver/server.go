@@ -1,25 +1,38 @@
 package volumeserver
 
 import (
+	"context"
+
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/gc"
+	"github.com/concourse/concourse/atc/runtime"
 )
 
+type Pool interface {
+	LocateVolume(ctx context.Context, teamID int, handle string) (runtime.Volume, runtime.Worker, bool, error)
+}
+
 type Server struct {
-	logger     lager.Logger
-	repository db.VolumeRepository
-	destroyer  gc.Destroyer
+	logger              lager.Logger
+	repository          db.VolumeRepository
+	containerRepository db.ContainerRepository
+	workerPool          Pool
+	destroyer           gc.Destroyer
 }
 
 func NewServer(
 	logger lager.Logger,
 	volumeRepository db.VolumeRepository,
+	containerRepository db.ContainerRepository,
+	workerPool Pool,
 	destroyer gc.Destroyer,
 ) *Server {
 	return &Server{
-		logger:     logger,
-		repository: volumeRepository,
-		destroyer:  destroyer,
+		logger:              logger,
+		repository:          volumeRepository,
+		containerRepository: containerRepository,
+		workerPool:          workerPool,
+		destroyer:           destroyer,
 	}
 }
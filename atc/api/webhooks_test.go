@@ -0,0 +1,295 @@
+package api_test
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Webhooks API", func() {
+	var (
+		fakeTeam *dbfakes.FakeTeam
+		response *http.Response
+	)
+
+	BeforeEach(func() {
+		fakeTeam = new(dbfakes.FakeTeam)
+		fakeTeam.IDReturns(3)
+		fakeTeam.NameReturns("a-team")
+	})
+
+	Describe("GET /api/v1/teams/:team_name/webhooks", func() {
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/teams/a-team/webhooks")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+				dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+
+				dbWebhookRepository.WebhooksReturns([]atc.OutgoingWebhook{
+					{
+						Name:   "some-webhook",
+						URL:    "https://example.com/hook",
+						Events: []atc.WebhookEvent{atc.WebhookEventBuildFinished},
+					},
+				}, nil)
+			})
+
+			It("returns the team's webhooks", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(dbWebhookRepository.WebhooksArgsForCall(0)).To(Equal(3))
+				Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`[
+					{
+						"name": "some-webhook",
+						"url": "https://example.com/hook",
+						"events": ["build_finished"]
+					}
+				]`))
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				Expect(dbWebhookRepository.WebhooksCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/teams/:team_name/webhooks/:webhook_name", func() {
+		var requestBody string
+
+		BeforeEach(func() {
+			requestBody = `{"url":"https://example.com/hook","secret":"s3cr3t","events":["build_started","pipeline_set"]}`
+		})
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequest(
+				"PUT",
+				server.URL+"/api/v1/teams/a-team/webhooks/some-webhook",
+				bytes.NewBufferString(requestBody),
+			)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+				dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+			})
+
+			It("saves the webhook for the team", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(dbWebhookRepository.SaveWebhookCallCount()).To(Equal(1))
+
+				teamID, webhook := dbWebhookRepository.SaveWebhookArgsForCall(0)
+				Expect(teamID).To(Equal(3))
+				Expect(webhook).To(Equal(atc.OutgoingWebhook{
+					Name:   "some-webhook",
+					URL:    "https://example.com/hook",
+					Secret: "s3cr3t",
+					Events: []atc.WebhookEvent{atc.WebhookEventBuildStarted, atc.WebhookEventPipelineSet},
+				}))
+			})
+
+			Context("when the webhook has an unknown event", func() {
+				BeforeEach(func() {
+					requestBody = `{"url":"https://example.com/hook","secret":"s3cr3t","events":["build_exploded"]}`
+				})
+
+				It("returns 400 without saving", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`{
+						"errors": ["invalid webhook: unknown event 'build_exploded' (must be one of build_started, build_finished, pipeline_set, worker_stalled, team_requested, team_request_approved, team_request_rejected)"]
+					}`))
+					Expect(dbWebhookRepository.SaveWebhookCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the webhook has no secret", func() {
+				BeforeEach(func() {
+					requestBody = `{"url":"https://example.com/hook","events":["build_started"]}`
+				})
+
+				It("returns 400 without saving", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`{
+						"errors": ["invalid webhook: missing secret"]
+					}`))
+					Expect(dbWebhookRepository.SaveWebhookCallCount()).To(BeZero())
+				})
+			})
+		})
+
+		Context("when unauthorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(false)
+				dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(dbWebhookRepository.SaveWebhookCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("GET /api/v1/teams/:team_name/webhooks/:webhook_name/deliveries", func() {
+		var query string
+
+		BeforeEach(func() {
+			query = ""
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/teams/a-team/webhooks/some-webhook/deliveries" + query)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+				dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+
+				dbWebhookRepository.DeliveriesReturns([]atc.WebhookDelivery{
+					{
+						ID:             2,
+						Event:          atc.WebhookEventBuildFinished,
+						Status:         atc.WebhookDeliveryPending,
+						Attempts:       1,
+						ResponseStatus: 502,
+						Error:          "unexpected response status: 502 Bad Gateway",
+						CreatedAt:      100,
+						NextAttemptAt:  110,
+					},
+				}, true, nil)
+			})
+
+			It("returns the deliveries", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+				teamID, name, limit := dbWebhookRepository.DeliveriesArgsForCall(0)
+				Expect(teamID).To(Equal(3))
+				Expect(name).To(Equal("some-webhook"))
+				Expect(limit).To(Equal(atc.PaginationAPIDefaultLimit))
+
+				Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`[
+					{
+						"id": 2,
+						"event": "build_finished",
+						"status": "pending",
+						"attempts": 1,
+						"response_status": 502,
+						"error": "unexpected response status: 502 Bad Gateway",
+						"created_at": 100,
+						"next_attempt_at": 110
+					}
+				]`))
+			})
+
+			Context("when a limit is given", func() {
+				BeforeEach(func() {
+					query = "?limit=5"
+				})
+
+				It("passes it through", func() {
+					_, _, limit := dbWebhookRepository.DeliveriesArgsForCall(0)
+					Expect(limit).To(Equal(5))
+				})
+			})
+
+			Context("when the webhook does not exist", func() {
+				BeforeEach(func() {
+					dbWebhookRepository.DeliveriesReturns(nil, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when getting the deliveries fails", func() {
+				BeforeEach(func() {
+					dbWebhookRepository.DeliveriesReturns(nil, false, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+
+	Describe("DELETE /api/v1/webhooks/:webhook_name", func() {
+		JustBeforeEach(func() {
+			request, err := http.NewRequest("DELETE", server.URL+"/api/v1/webhooks/some-webhook", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		BeforeEach(func() {
+			fakeAccess.IsAuthenticatedReturns(true)
+		})
+
+		Context("when admin", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAdminReturns(true)
+				dbWebhookRepository.DeleteWebhookReturns(true, nil)
+			})
+
+			It("deletes the cluster's webhook", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+
+				teamID, name := dbWebhookRepository.DeleteWebhookArgsForCall(0)
+				Expect(teamID).To(BeZero())
+				Expect(name).To(Equal("some-webhook"))
+			})
+
+			Context("when the webhook does not exist", func() {
+				BeforeEach(func() {
+					dbWebhookRepository.DeleteWebhookReturns(false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+		})
+
+		Context("when not admin", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAdminReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(dbWebhookRepository.DeleteWebhookCallCount()).To(BeZero())
+			})
+		})
+	})
+})
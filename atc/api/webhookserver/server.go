@@ -0,0 +1,21 @@
+package webhookserver
+
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/db"
+)
+
+type Server struct {
+	logger     lager.Logger
+	repository db.OutgoingWebhookRepository
+}
+
+func NewServer(
+	logger lager.Logger,
+	repository db.OutgoingWebhookRepository,
+) *Server {
+	return &Server{
+		logger:     logger,
+		repository: repository,
+	}
+}
@@ -0,0 +1,183 @@
+package webhookserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	. "github.com/concourse/concourse/atc/api/helpers"
+	"github.com/concourse/concourse/atc/db"
+)
+
+// The handlers of the cluster's webhooks use a teamID of 0.
+
+func (s *Server) ListTeamWebhooks(team db.Team) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.listWebhooks(w, r, team.ID())
+	})
+}
+
+func (s *Server) ListClusterWebhooks(w http.ResponseWriter, r *http.Request) {
+	s.listWebhooks(w, r, 0)
+}
+
+func (s *Server) SetTeamWebhook(team db.Team) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.setWebhook(w, r, team.ID())
+	})
+}
+
+func (s *Server) SetClusterWebhook(w http.ResponseWriter, r *http.Request) {
+	s.setWebhook(w, r, 0)
+}
+
+func (s *Server) DestroyTeamWebhook(team db.Team) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.destroyWebhook(w, r, team.ID())
+	})
+}
+
+func (s *Server) DestroyClusterWebhook(w http.ResponseWriter, r *http.Request) {
+	s.destroyWebhook(w, r, 0)
+}
+
+func (s *Server) ListTeamWebhookDeliveries(team db.Team) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.listDeliveries(w, r, team.ID())
+	})
+}
+
+func (s *Server) ListClusterWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+	s.listDeliveries(w, r, 0)
+}
+
+func (s *Server) listWebhooks(w http.ResponseWriter, r *http.Request, teamID int) {
+	logger := s.logger.Session("list-webhooks", lager.Data{"team-id": teamID})
+
+	webhooks, err := s.repository.Webhooks(teamID)
+	if err != nil {
+		logger.Error("failed-to-get-webhooks", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(webhooks)
+	if err != nil {
+		logger.Error("failed-to-encode-webhooks", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+func (s *Server) setWebhook(w http.ResponseWriter, r *http.Request, teamID int) {
+	webhookName := r.FormValue(":webhook_name")
+
+	logger := s.logger.Session("set-webhook", lager.Data{
+		"team-id": teamID,
+		"webhook": webhookName,
+	})
+
+	var webhook atc.OutgoingWebhook
+	err := json.NewDecoder(r.Body).Decode(&webhook)
+	if err != nil {
+		logger.Info("malformed-request", lager.Data{"error": err.Error()})
+		HandleBadRequest(w, fmt.Sprintf("malformed webhook: %s", err))
+		return
+	}
+
+	webhook.Name = webhookName
+
+	var warnings []atc.ConfigWarning
+	warning, err := atc.ValidateIdentifier(webhook.Name, "webhook")
+	if err != nil {
+		HandleBadRequest(w, err.Error())
+		return
+	}
+	if warning != nil {
+		warnings = append(warnings, *warning)
+	}
+
+	err = webhook.Validate()
+	if err != nil {
+		HandleBadRequest(w, fmt.Sprintf("invalid webhook: %s", err))
+		return
+	}
+
+	err = s.repository.SaveWebhook(teamID, webhook)
+	if err != nil {
+		logger.Error("failed-to-save-webhook", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(atc.SaveConfigResponse{Warnings: warnings})
+	if err != nil {
+		logger.Error("failed-to-encode-response", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+func (s *Server) destroyWebhook(w http.ResponseWriter, r *http.Request, teamID int) {
+	webhookName := r.FormValue(":webhook_name")
+
+	logger := s.logger.Session("destroy-webhook", lager.Data{
+		"team-id": teamID,
+		"webhook": webhookName,
+	})
+
+	deleted, err := s.repository.DeleteWebhook(teamID, webhookName)
+	if err != nil {
+		logger.Error("failed-to-delete-webhook", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !deleted {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) listDeliveries(w http.ResponseWriter, r *http.Request, teamID int) {
+	webhookName := r.FormValue(":webhook_name")
+
+	logger := s.logger.Session("list-webhook-deliveries", lager.Data{
+		"team-id": teamID,
+		"webhook": webhookName,
+	})
+
+	limit := atc.PaginationAPIDefaultLimit
+	if limitStr := r.FormValue(atc.PaginationQueryLimit); limitStr != "" {
+		var err error
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil || limit <= 0 {
+			HandleBadRequest(w, fmt.Sprintf("invalid limit: %s", limitStr))
+			return
+		}
+	}
+
+	deliveries, found, err := s.repository.Deliveries(teamID, webhookName, limit)
+	if err != nil {
+		logger.Error("failed-to-get-deliveries", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(deliveries)
+	if err != nil {
+		logger.Error("failed-to-encode-deliveries", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
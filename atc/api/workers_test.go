@@ -19,10 +19,17 @@ import (
 var _ = Describe("Workers API", func() {
 
 	Describe("GET /api/v1/workers", func() {
-		var response *http.Response
+		var (
+			query    string
+			response *http.Response
+		)
+
+		BeforeEach(func() {
+			query = ""
+		})
 
 		JustBeforeEach(func() {
-			req, err := http.NewRequest("GET", server.URL+"/api/v1/workers", nil)
+			req, err := http.NewRequest("GET", server.URL+"/api/v1/workers"+query, nil)
 			Expect(err).NotTo(HaveOccurred())
 
 			response, err = client.Do(req)
@@ -136,6 +143,105 @@ var _ = Describe("Workers API", func() {
 				})
 			})
 
+			Context("when requests to a worker are failing", func() {
+				BeforeEach(func() {
+					teamWorker1.NameReturns("some-worker")
+					dbWorkerFactory.VisibleWorkersReturns([]db.Worker{teamWorker1}, nil)
+
+					fakeCircuitBreakers.StatesReturns([]atc.WorkerCircuitBreaker{
+						{
+							Service:             "baggageclaim",
+							State:               "closed",
+							ConsecutiveFailures: 1,
+						},
+						{
+							Service:             "garden",
+							State:               "open",
+							ConsecutiveFailures: 5,
+							OpenedAt:            100,
+							RetryAt:             130,
+						},
+					})
+				})
+
+				It("returns the states of its circuit breakers", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(fakeCircuitBreakers.StatesArgsForCall(0)).To(Equal("some-worker"))
+
+					var returnedWorkers []map[string]interface{}
+					err := json.NewDecoder(response.Body).Decode(&returnedWorkers)
+					Expect(err).NotTo(HaveOccurred())
+
+					breakers, err := json.Marshal(returnedWorkers[0]["circuit_breakers"])
+					Expect(err).NotTo(HaveOccurred())
+					Expect(breakers).To(MatchJSON(`[
+						{"service": "baggageclaim", "state": "closed", "consecutive_failures": 1},
+						{"service": "garden", "state": "open", "consecutive_failures": 5, "opened_at": 100, "retry_at": 130}
+					]`))
+				})
+			})
+
+			Context("when a worker has reported its disk usage", func() {
+				BeforeEach(func() {
+					teamWorker1.DiskUsageReturns(&atc.WorkerDiskUsage{
+						TotalBytes: 1024,
+						UsedBytes:  768,
+					})
+					dbWorkerFactory.VisibleWorkersReturns([]db.Worker{teamWorker1}, nil)
+				})
+
+				It("returns it", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+					var returnedWorkers []map[string]interface{}
+					err := json.NewDecoder(response.Body).Decode(&returnedWorkers)
+					Expect(err).NotTo(HaveOccurred())
+
+					diskUsage, err := json.Marshal(returnedWorkers[0]["disk_usage"])
+					Expect(err).NotTo(HaveOccurred())
+					Expect(diskUsage).To(MatchJSON(`{"total_bytes": 1024, "used_bytes": 768}`))
+				})
+			})
+
+			Context("when filtering by team", func() {
+				BeforeEach(func() {
+					query = "?team=some-team"
+
+					teamWorker1.TeamNameReturns("some-team")
+
+					dbWorkerFactory.VisibleWorkersReturns([]db.Worker{
+						teamWorker1,
+						teamWorker2,
+					}, nil)
+				})
+
+				It("returns only the team's workers", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+					var returnedWorkers []atc.Worker
+					err := json.NewDecoder(response.Body).Decode(&returnedWorkers)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(returnedWorkers).To(Equal([]atc.Worker{
+						{
+							GardenAddr:      "1.2.3.4:7777",
+							BaggageclaimURL: "1.2.3.4:8888",
+							Team:            "some-team",
+						},
+					}))
+				})
+
+				Context("when not authorized for the team", func() {
+					BeforeEach(func() {
+						fakeAccess.IsAuthorizedReturns(false)
+					})
+
+					It("returns 403", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+					})
+				})
+			})
+
 			Context("when getting the workers fails", func() {
 				BeforeEach(func() {
 					dbWorkerFactory.VisibleWorkersReturns(nil, errors.New("error!"))
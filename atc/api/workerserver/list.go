@@ -20,6 +20,14 @@ func (s *Server) ListWorkers(w http.ResponseWriter, r *http.Request) {
 
 	acc := accessor.GetAccessor(r)
 
+	// the 'team' query param lists only the workers registered by the team,
+	// leaving out the global workers it shares with everyone else
+	teamName := r.URL.Query().Get("team")
+	if teamName != "" && !acc.IsAuthorized(teamName) {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
 	if acc.IsAdmin() {
 		workers, err = s.dbWorkerFactory.Workers()
 	} else {
@@ -32,9 +40,16 @@ func (s *Server) ListWorkers(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	atcWorkers := make([]atc.Worker, len(workers))
-	for i, savedWorker := range workers {
-		atcWorkers[i] = present.Worker(savedWorker)
+	atcWorkers := []atc.Worker{}
+	for _, savedWorker := range workers {
+		if teamName != "" && savedWorker.TeamName() != teamName {
+			continue
+		}
+
+		atcWorker := present.Worker(savedWorker)
+		atcWorker.CircuitBreakers = s.circuitBreakers.States(savedWorker.Name())
+
+		atcWorkers = append(atcWorkers, atcWorker)
 	}
 
 	w.Header().Set("Content-Type", "application/json")
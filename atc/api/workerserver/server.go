@@ -2,25 +2,34 @@ package workerserver
 
 import (
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 )
 
+// CircuitBreakers reports the state of the web node's circuit breakers
+// around each worker's servers.
+type CircuitBreakers interface {
+	States(workerName string) []atc.WorkerCircuitBreaker
+}
+
 type Server struct {
 	logger lager.Logger
 
 	teamFactory     db.TeamFactory
 	dbWorkerFactory db.WorkerFactory
+	circuitBreakers CircuitBreakers
 }
 
 func NewServer(
 	logger lager.Logger,
 	teamFactory db.TeamFactory,
 	dbWorkerFactory db.WorkerFactory,
-
+	circuitBreakers CircuitBreakers,
 ) *Server {
 	return &Server{
 		logger:          logger,
 		teamFactory:     teamFactory,
 		dbWorkerFactory: dbWorkerFactory,
+		circuitBreakers: circuitBreakers,
 	}
 }
@@ -9,31 +9,30 @@ import (
 	"golang.org/x/crypto/acme/autocert"
 )
 
+// the queries aren't prepared, as prepared statements belong to a session,
+// which a pooler in transaction pooling mode doesn't keep
+const (
+	getCert    = "SELECT cert, nonce FROM cert_cache WHERE domain = $1"
+	putCert    = "INSERT INTO cert_cache (domain, cert, nonce) VALUES ($1, $2, $3) ON CONFLICT (domain) DO UPDATE SET domain = EXCLUDED.domain, cert = EXCLUDED.cert, nonce = EXCLUDED.nonce"
+	deleteCert = "DELETE FROM cert_cache WHERE domain = $1"
+)
+
 type dbCache struct {
-	get, put, delete *sql.Stmt
-	es               encryption.Strategy
+	conn db.Conn
+	es   encryption.Strategy
 }
 
 func newDbCache(conn db.Conn) (autocert.Cache, error) {
-	c := new(dbCache)
-	c.es = conn.EncryptionStrategy()
-	var err error
-	c.get, err = conn.Prepare("SELECT cert, nonce FROM cert_cache WHERE domain = $1")
-	if err != nil {
-		return nil, err
-	}
-	c.put, err = conn.Prepare("INSERT INTO cert_cache (domain, cert, nonce) VALUES ($1, $2, $3) ON CONFLICT (domain) DO UPDATE SET domain = EXCLUDED.domain, cert = EXCLUDED.cert, nonce = EXCLUDED.nonce")
-	if err != nil {
-		return nil, err
-	}
-	c.delete, err = conn.Prepare("DELETE FROM cert_cache WHERE domain = $1")
-	return c, err
+	return &dbCache{
+		conn: conn,
+		es:   conn.EncryptionStrategy(),
+	}, nil
 }
 
 func (c *dbCache) Get(ctx context.Context, domain string) ([]byte, error) {
 	var ciphertext string
 	var nonce sql.NullString
-	err := c.get.QueryRowContext(ctx, domain).Scan(&ciphertext, &nonce)
+	err := c.conn.QueryRowContext(ctx, getCert, domain).Scan(&ciphertext, &nonce)
 	if err == sql.ErrNoRows {
 		err = autocert.ErrCacheMiss
 	}
@@ -52,11 +51,11 @@ func (c *dbCache) Put(ctx context.Context, domain string, cert []byte) error {
 	if err != nil {
 		return err
 	}
-	_, err = c.put.ExecContext(ctx, domain, ciphertext, nonce)
+	_, err = c.conn.ExecContext(ctx, putCert, domain, ciphertext, nonce)
 	return err
 }
 
 func (c *dbCache) Delete(ctx context.Context, domain string) error {
-	_, err := c.delete.ExecContext(ctx, domain)
+	_, err := c.conn.ExecContext(ctx, deleteCert, domain)
 	return err
 }
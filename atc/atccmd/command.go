@@ -26,32 +26,58 @@ import (
 	"github.com/concourse/concourse/atc/api/auth"
 	"github.com/concourse/concourse/atc/api/buildserver"
 	"github.com/concourse/concourse/atc/api/containerserver"
+	"github.com/concourse/concourse/atc/api/healthserver"
 	"github.com/concourse/concourse/atc/api/pipelineserver"
 	"github.com/concourse/concourse/atc/api/policychecker"
+	"github.com/concourse/concourse/atc/api/scimserver"
 	"github.com/concourse/concourse/atc/auditor"
+	"github.com/concourse/concourse/atc/autoscaling"
 	"github.com/concourse/concourse/atc/builds"
+	"github.com/concourse/concourse/atc/buildstats"
+	"github.com/concourse/concourse/atc/commitstatus"
 	"github.com/concourse/concourse/atc/component"
 	"github.com/concourse/concourse/atc/compression"
 	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/creds/noop"
 	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dialect"
 	"github.com/concourse/concourse/atc/db/encryption"
+	"github.com/concourse/concourse/atc/db/iam"
 	"github.com/concourse/concourse/atc/db/lock"
 	"github.com/concourse/concourse/atc/db/migration"
+	"github.com/concourse/concourse/atc/deprecation"
 	"github.com/concourse/concourse/atc/engine"
+	"github.com/concourse/concourse/atc/firehose"
+	"github.com/concourse/concourse/atc/flakiness"
 	"github.com/concourse/concourse/atc/gc"
+	"github.com/concourse/concourse/atc/growth"
+	"github.com/concourse/concourse/atc/health"
+	"github.com/concourse/concourse/atc/jobqueue"
 	"github.com/concourse/concourse/atc/lidar"
+	"github.com/concourse/concourse/atc/logdrain"
+	"github.com/concourse/concourse/atc/maintenance"
 	"github.com/concourse/concourse/atc/metric"
+	"github.com/concourse/concourse/atc/notify"
 	"github.com/concourse/concourse/atc/pauser"
 	"github.com/concourse/concourse/atc/policy"
+	"github.com/concourse/concourse/atc/policy/imagescan"
+	"github.com/concourse/concourse/atc/provenance"
+	"github.com/concourse/concourse/atc/requestlog"
+	"github.com/concourse/concourse/atc/resource"
+	"github.com/concourse/concourse/atc/resource/native"
 	"github.com/concourse/concourse/atc/scheduler"
 	"github.com/concourse/concourse/atc/scheduler/algorithm"
 	"github.com/concourse/concourse/atc/syslog"
 	"github.com/concourse/concourse/atc/util"
+	"github.com/concourse/concourse/atc/warmpool"
+	"github.com/concourse/concourse/atc/webhooks"
 	"github.com/concourse/concourse/atc/worker"
+	"github.com/concourse/concourse/atc/worker/gardenruntime/transport"
+	"github.com/concourse/concourse/atc/worker/k8sruntime"
 	"github.com/concourse/concourse/atc/wrappa"
 	"github.com/concourse/concourse/skymarshal/dexserver"
 	"github.com/concourse/concourse/skymarshal/legacyserver"
+	"github.com/concourse/concourse/skymarshal/lockout"
 	"github.com/concourse/concourse/skymarshal/skycmd"
 	"github.com/concourse/concourse/skymarshal/skyserver"
 	"github.com/concourse/concourse/skymarshal/storage"
@@ -80,6 +106,7 @@ import (
 
 	// dynamically registered policy checkers
 	_ "github.com/concourse/concourse/atc/policy/opa"
+	_ "github.com/concourse/concourse/atc/policy/webhook"
 
 	// dynamically registered credential managers
 	_ "github.com/concourse/concourse/atc/creds/conjur"
@@ -97,6 +124,7 @@ var schedulerCache = gocache.New(10*time.Second, 10*time.Second)
 
 var defaultDriverName = "postgres"
 var retryingDriverName = "too-many-connections-retrying"
+var tokenAuthDriverName = "iam-token-auth"
 
 var flyClientID = "fly"
 var flyClientSecret = "Zmx5"
@@ -109,7 +137,10 @@ type ATCCommand struct {
 type RunCommand struct {
 	Logger flag.Lager
 
-	varSourcePool creds.VarSourcePool
+	varSourcePool         creds.VarSourcePool
+	workerCircuitBreakers transport.CircuitBreakers
+	dbDialect             dialect.Dialect
+	dbTokens              iam.TokenSource
 
 	BindIP   flag.IP `long:"bind-ip"   default:"0.0.0.0" description:"IP address on which to listen for web traffic."`
 	BindPort uint16  `long:"bind-port" default:"8080"    description:"Port on which to listen for HTTP traffic."`
@@ -128,10 +159,33 @@ type RunCommand struct {
 
 	Postgres flag.PostgresConfig `group:"PostgreSQL Configuration" namespace:"postgres"`
 
+	PostgresMigration struct {
+		User     string `long:"user"     description:"The user to run the migrations as. If set, queries are run as --postgres-user, which is granted the privileges it needs after migrating, so that it doesn't have to own the schema."`
+		Password string `long:"password" description:"The password of the user to run the migrations as."`
+	} `group:"PostgreSQL Migration Configuration" namespace:"postgres-migration"`
+
+	PostgresTransactionPooling bool `long:"postgres-transaction-pooling" description:"Whether the PostgreSQL configuration points at a connection pooler, such as pgbouncer, in transaction pooling mode. Locks are then kept in a table rather than in the session, and notifications are listened for through --postgres-session-host."`
+
+	PostgresSession struct {
+		Host string `long:"host" description:"The host to listen for notifications on, which must keep the session, e.g. PostgreSQL itself or a pooler in session pooling mode. Required with --postgres-transaction-pooling."`
+		Port uint16 `long:"port" description:"The port to listen for notifications on. Defaults to --postgres-port."`
+	} `group:"PostgreSQL Session Configuration" namespace:"postgres-session"`
+
+	PostgresIAM iam.Config `group:"PostgreSQL IAM Authentication" namespace:"postgres-iam"`
+
+	HeavyMigrations migration.HeavyMigrationLimits `group:"Heavy Migrations"`
+
+	SkipPostgresPreflight bool `long:"skip-postgres-preflight" description:"Start even if the database fails the checks of its version, extensions, encoding, collation and settings."`
+
+	DatabaseDialect string `long:"database-dialect" default:"postgres" choice:"postgres" choice:"cockroachdb" description:"The kind of database the PostgreSQL configuration points at. CockroachDB support is experimental."`
+
 	ConcurrentRequestLimits   map[wrappa.LimitedRoute]int `long:"concurrent-request-limit" description:"Limit the number of concurrent requests to an API endpoint (Example: ListAllJobs:5)"`
 	APIMaxOpenConnections     int                         `long:"api-max-conns" description:"The maximum number of open connections for the api connection pool." default:"10"`
 	BackendMaxOpenConnections int                         `long:"backend-max-conns" description:"The maximum number of open connections for the backend connection pool." default:"50"`
 
+	APIRequestLogSampleRate float64       `long:"api-request-log-sample-rate" default:"0" description:"Fraction of API requests to log along with the number of queries they make, between 0 and 1. Can be changed at runtime through the API."`
+	APISlowRequestThreshold time.Duration `long:"api-slow-request-threshold" description:"Log the API requests taking at least this long along with the timings of their queries. Can be changed at runtime through the API."`
+
 	CredentialManagement creds.CredentialManagementConfig `group:"Credential Management"`
 	CredentialManagers   creds.Managers
 
@@ -150,19 +204,34 @@ type RunCommand struct {
 	GlobalResourceCheckTimeout          time.Duration `long:"global-resource-check-timeout" default:"1h" description:"Time limit on checking for new versions of resources."`
 	ResourceCheckingInterval            time.Duration `long:"resource-checking-interval" default:"1m" description:"Interval on which to check for new versions of resources."`
 	ResourceWithWebhookCheckingInterval time.Duration `long:"resource-with-webhook-checking-interval" default:"1m" description:"Interval on which to check for new versions of resources that has webhook defined."`
+	NativeResourceTypes                 []string      `long:"native-resource-type" description:"Base resource type to check inside the ATC rather than in a container. Sources using configuration the native implementation doesn't support are still checked in a container. Supported types: time, registry-image. Can be specified multiple times."`
+	CheckContainerPoolSize              int           `long:"check-container-pool-size" default:"4" description:"Number of check containers each team shares per base resource type. The checks of every resource of a base resource type run in these containers, each check in a process of its own. A value of zero gives each resource its own check containers."`
 	MaxChecksPerSecond                  int           `long:"max-checks-per-second" description:"Maximum number of checks that can be started per second. If not specified, this will be calculated as (# of resources)/(resource checking interval). -1 value will remove this maximum limit of checks per second."`
+	MaxChecksInFlight                   int           `long:"max-checks-in-flight" default:"0" description:"Maximum number of resource checks running at once across all the ATCs. 0 means no limit."`
+	MaxChecksInFlightPerWorker          int           `long:"max-checks-in-flight-per-worker" default:"0" description:"Maximum number of resource checks running at once on each worker, across all the ATCs. 0 means no limit."`
 	PausePipelinesAfter                 int           `long:"pause-pipelines-after" default:"0" description:"The number of days after which a pipeline will be automatically paused if none of its jobs have run in more than the given number of days. A value of zero disables this component."`
 	PipelinePauserInterval              time.Duration `long:"pipeline-pauser-interval" default:"24h" hidden:"true" description:"The frequency on which the Pipeline Pauser component will be run to check if any pipelines need to be paused."`
+	BuildStatsRetention                 time.Duration `long:"build-stats-retention" default:"2160h" description:"How long to keep the hourly statistics of job builds for. A value of zero keeps them forever."`
 
 	ContainerPlacementStrategyOptions worker.PlacementOptions `group:"Container Placement Strategy"`
 
 	BaggageclaimResponseHeaderTimeout time.Duration `long:"baggageclaim-response-header-timeout" default:"1m" description:"How long to wait for Baggageclaim to send the response header."`
 	StreamingArtifactsCompression     string        `long:"streaming-artifacts-compression" default:"gzip" choice:"gzip" choice:"zstd" description:"Compression algorithm for internal streaming."`
+	StreamingArtifactsChunkSizeMB     int           `long:"streaming-artifacts-chunk-size-mb" default:"16" description:"Size of the chunks in which artifacts are streamed into workers that support resuming interrupted streams. 0 streams them in one go."`
 
 	GardenRequestTimeout time.Duration `long:"garden-request-timeout" default:"5m" description:"How long to wait for requests to Garden to complete. 0 means no timeout."`
 
+	GardenRetryTimeout       time.Duration `long:"garden-retry-timeout"       default:"5m"  description:"How long to keep retrying a request to Garden which fails to reach the worker."`
+	BaggageclaimRetryTimeout time.Duration `long:"baggageclaim-retry-timeout" default:"60m" description:"How long to keep retrying a request to Baggageclaim which fails to reach the worker."`
+
+	WorkerCircuitBreakerFailures int           `long:"worker-circuit-breaker-failures" default:"5"   description:"Number of requests to a worker's Garden or Baggageclaim server which have to fail to reach it in a row before requests to it fail straight away. 0 disables the circuit breakers."`
+	WorkerCircuitBreakerCooldown time.Duration `long:"worker-circuit-breaker-cooldown" default:"30s" description:"How long requests to a worker's server fail straight away before one is let through to see if it has recovered."`
+
+	KubernetesWorker k8sruntime.Config `group:"Kubernetes Worker" namespace:"kubernetes-worker"`
+
 	CLIArtifactsDir flag.Dir `long:"cli-artifacts-dir" description:"Directory containing downloadable CLI binaries."`
 	WebPublicDir    flag.Dir `long:"web-public-dir" description:"Web public/ directory to serve live for local development."`
+	Headless        bool     `long:"headless" description:"Serve the API only, without the web UI or its assets. Binaries built with the 'headless' build tag leave the assets out, and are always headless."`
 
 	Metrics struct {
 		HostName            string            `long:"metrics-host-name" description:"Host string to attach to emitted metrics."`
@@ -174,7 +243,10 @@ type RunCommand struct {
 	Tracing tracing.Config `group:"Tracing" namespace:"tracing"`
 
 	PolicyCheckers struct {
-		Filter policy.Filter
+		Filter        policy.Filter
+		Images        policy.ImagePolicy
+		ImageScanning imagescan.Config
+		Secrets       policy.SecretDetection
 	} `group:"Policy Checking"`
 
 	Server struct {
@@ -183,6 +255,8 @@ type RunCommand struct {
 		ClusterName           string `long:"cluster-name" description:"A name for this Concourse cluster, to be displayed on the dashboard page."`
 		ClientID              string `long:"client-id" default:"concourse-web" description:"Client ID to use for login flow"`
 		ClientSecret          string `long:"client-secret" required:"true" description:"Client secret to use for login flow"`
+
+		HealthCheckCacheDuration time.Duration `long:"health-check-cache-duration" default:"5s" description:"How long the outcome of the health endpoint's checks is reused for."`
 	} `group:"Web Server"`
 
 	LogDBQueries   bool `long:"log-db-queries" description:"Log database queries."`
@@ -197,10 +271,19 @@ type RunCommand struct {
 		FailedGracePeriod      time.Duration `long:"failed-grace-period" default:"120h" description:"Period after which failed containers will be garbage collected"`
 		CheckRecyclePeriod     time.Duration `long:"check-recycle-period" default:"1m" description:"Period after which to reap checks that are completed."`
 		VarSourceRecyclePeriod time.Duration `long:"var-source-recycle-period" default:"5m" description:"Period after which to reap var_sources that are not used."`
+		IdempotencyKeyTTL      time.Duration `long:"idempotency-key-ttl" default:"24h" description:"Period after which the responses to requests made with an Idempotency-Key are forgotten, and the key can be used again."`
 	} `group:"Garbage Collection" namespace:"gc"`
 
+	DBMaintenance maintenance.Config `group:"Database Maintenance"`
+
+	DBGrowth growth.Config `group:"Database Growth"`
+
 	BuildTrackerInterval time.Duration `long:"build-tracker-interval" default:"10s" description:"Interval on which to run build tracking."`
 
+	BuildLogBufferSize      int           `long:"build-log-buffer-size" default:"1048576" description:"Bytes of build logs to buffer per build before saving its events in a batch. Output beyond that blocks until they are saved. 0 saves every event as it comes."`
+	BuildEventFlushInterval time.Duration `long:"build-event-flush-interval" default:"250ms" description:"Interval on which the buffered events of each build are saved in a batch."`
+	MaxBuildLogRate         int           `long:"max-build-log-rate" default:"10485760" description:"Bytes of logs per second a step may write before the rest of its output for that second is dropped. 0 means unlimited."`
+
 	TelemetryOptIn bool `long:"telemetry-opt-in" hidden:"true" description:"Enable anonymous concourse version reporting."`
 
 	DefaultBuildLogsToRetain uint64 `long:"default-build-logs-to-retain" description:"Default build logs to retain, 0 means all"`
@@ -209,6 +292,8 @@ type RunCommand struct {
 	DefaultDaysToRetainBuildLogs uint64 `long:"default-days-to-retain-build-logs" description:"Default days to retain build logs. 0 means unlimited"`
 	MaxDaysToRetainBuildLogs     uint64 `long:"max-days-to-retain-build-logs" description:"Maximum days to retain build logs, 0 means not specified. Will override values configured in jobs"`
 
+	MaxRunningOneOffBuildsPerTeam int `long:"max-running-one-off-builds-per-team" default:"0" description:"Maximum number of one-off builds, such as those of fly execute and of the run task API, each team may have running at once. 0 means unlimited."`
+
 	JobSchedulingMaxInFlight uint64 `long:"job-scheduling-max-in-flight" default:"32" description:"Maximum number of jobs to be scheduling at the same time"`
 
 	DefaultCpuLimit    *int    `long:"default-task-cpu-limit" description:"Default max number of cpu shares per task, 0 means unlimited"`
@@ -224,6 +309,7 @@ type RunCommand struct {
 		EnableTeamAuditLog      bool `long:"enable-team-auditing" description:"Enable auditing for all api requests connected to teams."`
 		EnableWorkerAuditLog    bool `long:"enable-worker-auditing" description:"Enable auditing for all api requests connected to workers."`
 		EnableVolumeAuditLog    bool `long:"enable-volume-auditing" description:"Enable auditing for all api requests connected to volumes."`
+		EnablePolicyAuditLog    bool `long:"enable-policy-auditing" description:"Enable auditing for the decisions of all policy checks."`
 	}
 
 	Syslog struct {
@@ -234,6 +320,14 @@ type RunCommand struct {
 		CACerts       []string      `long:"syslog-ca-cert"              description:"Paths to PEM-encoded CA cert files to use to verify the Syslog server SSL cert."`
 	} ` group:"Syslog Drainer Configuration"`
 
+	Firehose firehose.Config `group:"Firehose"`
+
+	Autoscaling struct {
+		WebhookURL      flag.URL      `long:"autoscaling-webhook-url" description:"URL to POST the demand for workers to on an interval, for external autoscalers to scale workers on. The demand is always available from /api/v1/autoscaling."`
+		WebhookSecret   string        `long:"autoscaling-webhook-secret" description:"Secret to sign the requests to the autoscaling webhook with. The signature is sent in the X-Concourse-Signature-256 header."`
+		WebhookInterval time.Duration `long:"autoscaling-webhook-interval" default:"30s" description:"Interval on which to send the demand for workers to the autoscaling webhook."`
+	} `group:"Autoscaling"`
+
 	Auth struct {
 		AuthFlags     skycmd.AuthFlags
 		MainTeamFlags skycmd.AuthTeamFlags `group:"Authentication (Main Team)" namespace:"main-team"`
@@ -244,6 +338,9 @@ type RunCommand struct {
 	SystemClaimKey    string   `long:"system-claim-key" default:"aud" description:"The token claim key to use when matching system-claim-values"`
 	SystemClaimValues []string `long:"system-claim-value" default:"concourse-worker" description:"Configure which token requests should be considered 'system' requests."`
 
+	SCIMToken     string `long:"scim-token" description:"Bearer token identity providers use to provision users and groups over SCIM at /scim/v2. Teams grant roles to the groups as 'scim:<group>'. SCIM provisioning is disabled unless this is set."`
+	SCIMConnector string `long:"scim-connector" description:"ID of the connector users log in with that the SCIM provider manages. Only tokens from this connector are matched to SCIM users, by verified email or by the connector's user ID."`
+
 	FeatureFlags struct {
 		EnableGlobalResources                bool `long:"enable-global-resources" description:"Enable equivalent resources across pipelines and teams to share a single version history."`
 		EnableRedactSecrets                  bool `long:"enable-redact-secrets" description:"Enable redacting secrets in build logs."`
@@ -253,10 +350,14 @@ type RunCommand struct {
 		EnableP2PVolumeStreaming             bool `long:"enable-p2p-volume-streaming" description:"Enable P2P volume streaming. NOTE: All workers must be on the same LAN network"`
 		EnableCacheStreamedVolumes           bool `long:"enable-cache-streamed-volumes" description:"When enabled, streamed resource volumes will be cached on the destination worker."`
 		EnableResourceCausality              bool `long:"enable-resource-causality" description:"Enable the resource causality page. Computing causality can be expensive for the database. "`
+		EnableVolumeDigests                  bool `long:"enable-volume-digests" description:"Record a digest of the content of volumes streamed through the ATC, and fail builds whose volumes no longer match their digest when streamed again."`
+		EnableCommitStatuses                 bool `long:"enable-commit-statuses" description:"Report the status of job builds to the commits of their git inputs hosted on GitHub or GitLab, using the team's github_status_token and gitlab_status_token credentials."`
 	} `group:"Feature Flags"`
 
 	BaseResourceTypeDefaults flag.File `long:"base-resource-type-defaults" description:"Base resource type defaults"`
 
+	ProvenanceSigningKey *flag.PrivateKey `long:"provenance-signing-key" description:"File containing an RSA private key, used to sign the provenance generated for each job build. Provenance is only generated when configured."`
+
 	P2pVolumeStreamingTimeout time.Duration `long:"p2p-volume-streaming-timeout" description:"Timeout value of p2p volume streaming" default:"15m"`
 
 	DisplayUserIdPerConnector map[string]string `long:"display-user-id-per-connector" description:"Define how to display user ID for each authentication connector. Format is <connector>:<fieldname>. Valid field names are user_id, name, username and email, where name maps to claims field username, and username maps to claims field preferred username"`
@@ -268,6 +369,8 @@ type RunCommand struct {
 
 type Migration struct {
 	lockFactory lock.LockFactory
+	dbDialect   dialect.Dialect
+	driverName  string
 
 	Postgres               flag.PostgresConfig `group:"PostgreSQL Configuration" namespace:"postgres"`
 	EncryptionKey          flag.Cipher         `long:"encryption-key"     description:"A 16 or 32 length key used to encrypt sensitive information before storing it in the database."`
@@ -276,10 +379,31 @@ type Migration struct {
 	SupportedDBVersion     bool                `long:"supported-db-version" description:"Print the max supported database version and exit"`
 	MigrateDBToVersion     int                 `long:"migrate-db-to-version" description:"Migrate to the specified database version and exit"`
 	MigrateToLatestVersion bool                `long:"migrate-to-latest-version" description:"Migrate to the latest migration version and exit"`
+	RenderSQLBundle        bool                `long:"render-sql-bundle" description:"Print the SQL of the migrations up to --migrate-db-to-version, or the latest version, for a DBA to review and apply, and exit"`
+	GrantToUser            string              `long:"grant-to-user" description:"The user the web nodes run their queries as, to grant the privileges it needs after migrating when it doesn't own the schema"`
+
+	HeavyMigrations migration.HeavyMigrationLimits `group:"Heavy Migrations"`
+
+	DatabaseDialect string `long:"database-dialect" default:"postgres" choice:"postgres" choice:"cockroachdb" description:"The kind of database the PostgreSQL configuration points at. CockroachDB support is experimental."`
+
+	PostgresTransactionPooling bool `long:"postgres-transaction-pooling" description:"Whether the PostgreSQL configuration points at a connection pooler, such as pgbouncer, in transaction pooling mode. Locks are then kept in a table rather than in the session."`
+
+	PostgresIAM iam.Config `group:"PostgreSQL IAM Authentication" namespace:"postgres-iam"`
 }
 
 func (m *Migration) Execute(args []string) error {
-	lockConns, err := constructLockConns(defaultDriverName, m.Postgres.ConnectionString())
+	m.driverName = defaultDriverName
+	if m.PostgresIAM.Enabled() {
+		tokens, err := m.PostgresIAM.TokenSource(m.Postgres)
+		if err != nil {
+			return err
+		}
+
+		db.SetupTokenAuthDriver(tokens, tokenAuthDriverName)
+		m.driverName = tokenAuthDriverName
+	}
+
+	lockConns, err := constructLockConns(m.driverName, m.Postgres.ConnectionString())
 	if err != nil {
 		return err
 	}
@@ -289,8 +413,19 @@ func (m *Migration) Execute(args []string) error {
 		}
 	}()
 
-	m.lockFactory = lock.NewLockFactory(lockConns, metric.LogLockAcquired, metric.LogLockReleased)
+	m.dbDialect, err = dialect.ForName(m.DatabaseDialect)
+	if err != nil {
+		return err
+	}
+
+	m.lockFactory, err = newLockFactory(lockConns, m.dbDialect, m.PostgresTransactionPooling)
+	if err != nil {
+		return err
+	}
 
+	if m.RenderSQLBundle {
+		return m.renderSQLBundle()
+	}
 	if m.MigrateToLatestVersion {
 		return m.migrateToLatestVersion()
 	}
@@ -306,12 +441,12 @@ func (m *Migration) Execute(args []string) error {
 	if m.OldEncryptionKey.AEAD != nil {
 		return m.rotateEncryptionKey()
 	}
-	return errors.New("must specify one of `--migrate-to-latest-version`, `--current-db-version`, `--supported-db-version`, `--migrate-db-to-version`, or `--old-encryption-key`")
+	return errors.New("must specify one of `--migrate-to-latest-version`, `--current-db-version`, `--supported-db-version`, `--migrate-db-to-version`, `--render-sql-bundle`, or `--old-encryption-key`")
 }
 
 func (cmd *Migration) currentDBVersion() error {
 	helper := migration.NewOpenHelper(
-		defaultDriverName,
+		cmd.driverName,
 		cmd.Postgres.ConnectionString(),
 		cmd.lockFactory,
 		nil,
@@ -329,7 +464,7 @@ func (cmd *Migration) currentDBVersion() error {
 
 func (cmd *Migration) supportedDBVersion() error {
 	helper := migration.NewOpenHelper(
-		defaultDriverName,
+		cmd.driverName,
 		cmd.Postgres.ConnectionString(),
 		cmd.lockFactory,
 		nil,
@@ -359,12 +494,12 @@ func (cmd *Migration) migrateDBToVersion() error {
 	}
 
 	helper := migration.NewOpenHelper(
-		defaultDriverName,
+		cmd.driverName,
 		cmd.Postgres.ConnectionString(),
 		cmd.lockFactory,
 		newKey,
 		oldKey,
-	)
+	).WithHeavyMigrationLimits(cmd.HeavyMigrations).WithRuntimeRole(cmd.GrantToUser).WithDialect(cmd.dbDialect)
 
 	err := helper.MigrateToVersion(version)
 	if err != nil {
@@ -387,7 +522,7 @@ func (cmd *Migration) rotateEncryptionKey() error {
 	}
 
 	helper := migration.NewOpenHelper(
-		defaultDriverName,
+		cmd.driverName,
 		cmd.Postgres.ConnectionString(),
 		cmd.lockFactory,
 		newKey,
@@ -402,15 +537,42 @@ func (cmd *Migration) rotateEncryptionKey() error {
 	return helper.MigrateToVersion(version)
 }
 
-func (cmd *Migration) migrateToLatestVersion() error {
+func (cmd *Migration) renderSQLBundle() error {
 	helper := migration.NewOpenHelper(
-		defaultDriverName,
+		cmd.driverName,
 		cmd.Postgres.ConnectionString(),
 		cmd.lockFactory,
 		nil,
 		nil,
 	)
 
+	version := cmd.MigrateDBToVersion
+	if version == 0 {
+		var err error
+		version, err = helper.SupportedVersion()
+		if err != nil {
+			return err
+		}
+	}
+
+	bundle, err := helper.Bundle(version)
+	if err != nil {
+		return err
+	}
+
+	fmt.Print(bundle)
+	return nil
+}
+
+func (cmd *Migration) migrateToLatestVersion() error {
+	helper := migration.NewOpenHelper(
+		cmd.driverName,
+		cmd.Postgres.ConnectionString(),
+		cmd.lockFactory,
+		nil,
+		nil,
+	).WithHeavyMigrationLimits(cmd.HeavyMigrations).WithRuntimeRole(cmd.GrantToUser).WithDialect(cmd.dbDialect)
+
 	version, err := helper.SupportedVersion()
 	if err != nil {
 		return err
@@ -533,6 +695,8 @@ func (cmd *RunCommand) Runner(positionalArguments []string) (ifrit.Runner, error
 	atc.EnablePipelineInstances = cmd.FeatureFlags.EnablePipelineInstances
 	atc.EnableCacheStreamedVolumes = cmd.FeatureFlags.EnableCacheStreamedVolumes
 	atc.EnableResourceCausality = cmd.FeatureFlags.EnableResourceCausality
+	atc.EnableVolumeDigests = cmd.FeatureFlags.EnableVolumeDigests
+	atc.EnableFirehose = cmd.Firehose.IsConfigured()
 	atc.DefaultCheckInterval = cmd.ResourceCheckingInterval
 	atc.DefaultWebhookInterval = cmd.ResourceWithWebhookCheckingInterval
 
@@ -553,8 +717,19 @@ func (cmd *RunCommand) Runner(positionalArguments []string) (ifrit.Runner, error
 
 	//FIXME: These only need to run once for the entire binary. At the moment,
 	//they rely on state of the command.
+	delegateDriverName := defaultDriverName
+	if cmd.PostgresIAM.Enabled() {
+		cmd.dbTokens, err = cmd.PostgresIAM.TokenSource(cmd.Postgres)
+		if err != nil {
+			return nil, err
+		}
+
+		db.SetupTokenAuthDriver(cmd.dbTokens, tokenAuthDriverName)
+		delegateDriverName = tokenAuthDriverName
+	}
+
 	db.SetupConnectionRetryingDriver(
-		"postgres",
+		delegateDriverName,
 		cmd.Postgres.ConnectionString(),
 		retryingDriverName,
 	)
@@ -590,12 +765,43 @@ func (cmd *RunCommand) Runner(positionalArguments []string) (ifrit.Runner, error
 		return nil, err
 	}
 
+	cmd.dbDialect, err = dialect.ForName(cmd.DatabaseDialect)
+	if err != nil {
+		return nil, err
+	}
+
+	if cmd.PostgresTransactionPooling && cmd.PostgresSession.Host == "" {
+		return nil, errors.New("--postgres-session-host must be set with --postgres-transaction-pooling, as notifications can't be listened for through a pooler in transaction pooling mode")
+	}
+
+	// the pools of the connections constructed below, a listener for each of
+	// them, and the lock connections
+	connections := cmd.APIMaxOpenConnections + cmd.BackendMaxOpenConnections + 5 + 1 + 4 + lock.FactoryCount
+	if cmd.PostgresTransactionPooling {
+		// the pooler decides how many connections it opens to the database,
+		// but the listeners each keep one of their own
+		connections = 4
+	}
+
+	// the checks are of PostgreSQL's versions and settings
+	if !cmd.SkipPostgresPreflight && cmd.dbDialect == dialect.Postgres {
+		err = db.Preflight(retryingDriverName, cmd.Postgres.ConnectionString(), db.PreflightRequirements{
+			Connections: connections,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	lockConns, err := constructLockConns(retryingDriverName, cmd.Postgres.ConnectionString())
 	if err != nil {
 		return nil, err
 	}
 
-	lockFactory := lock.NewLockFactory(lockConns, metric.LogLockAcquired, metric.LogLockReleased)
+	lockFactory, err := newLockFactory(lockConns, cmd.dbDialect, cmd.PostgresTransactionPooling)
+	if err != nil {
+		return nil, err
+	}
 
 	apiConn, err := cmd.constructDBConn(retryingDriverName, logger, cmd.APIMaxOpenConnections, cmd.APIMaxOpenConnections/2, "api", lockFactory)
 	if err != nil {
@@ -640,6 +846,15 @@ func (cmd *RunCommand) Runner(positionalArguments []string) (ifrit.Runner, error
 		clock.NewClock(),
 	)
 
+	cmd.workerCircuitBreakers = transport.NewCircuitBreakers(
+		logger.Session("worker-circuit-breakers"),
+		transport.CircuitBreakerConfig{
+			FailureThreshold: cmd.WorkerCircuitBreakerFailures,
+			Cooldown:         cmd.WorkerCircuitBreakerCooldown,
+		},
+		clock.NewClock(),
+	)
+
 	members, err := cmd.constructMembers(logger, reconfigurableSink, apiConn, workerConn, backendConn, gcConn, storage, lockFactory, secretManager)
 	if err != nil {
 		return nil, err
@@ -691,6 +906,11 @@ func (cmd *RunCommand) constructMembers(
 	lockFactory lock.LockFactory,
 	secretManager creds.Secrets,
 ) ([]grouper.Member, error) {
+	err := trustClusterCABundle(logger, db.NewCABundleRepository(backendConn))
+	if err != nil {
+		return nil, err
+	}
+
 	if cmd.TelemetryOptIn {
 		url := fmt.Sprintf("http://telemetry.concourse-ci.org/?version=%s", concourse.Version)
 		go func() {
@@ -706,6 +926,17 @@ func (cmd *RunCommand) constructMembers(
 		return nil, err
 	}
 
+	policyChecker = policy.NewImagePolicyChecker(cmd.PolicyCheckers.Images, policyChecker)
+
+	policyChecker, err = imagescan.NewChecker(logger.Session("image-scan"), cmd.PolicyCheckers.ImageScanning, db.NewImageScanCache(backendConn), policyChecker)
+	if err != nil {
+		return nil, err
+	}
+
+	policyChecker = policy.NewPrivilegedPolicyChecker(db.NewPrivilegedPolicies(backendConn), policyChecker)
+	policyChecker = policy.NewSecretDetectionChecker(cmd.PolicyCheckers.Secrets, policyChecker)
+	policyChecker = policy.NewAuditingChecker(policyChecker, cmd.constructAuditor(logger))
+
 	workerCache, err := db.NewWorkerCache(logger.Session("worker-cache"), backendConn, 1*time.Minute)
 	if err != nil {
 		return nil, err
@@ -788,6 +1019,13 @@ func (cmd *RunCommand) constructAPIMembers(
 		return nil, err
 	}
 
+	requestLogRecorder, err := requestlog.NewRecorder(logger.Session("request-log"), cmd.requestLogSettings())
+	if err != nil {
+		return nil, err
+	}
+
+	dbConn = requestLogRecorder.Conn(dbConn)
+
 	teamFactory := db.NewTeamFactory(dbConn, lockFactory)
 	workerTeamFactory := db.NewTeamFactory(workerConn, lockFactory)
 
@@ -819,14 +1057,42 @@ func (cmd *RunCommand) constructAPIMembers(
 	dbResourceFactory := db.NewResourceFactory(dbConn, lockFactory)
 	dbContainerRepository := db.NewContainerRepository(dbConn)
 	dbVolumeRepository := db.NewVolumeRepository(dbConn)
-	gcContainerDestroyer := gc.NewDestroyer(logger, dbContainerRepository, dbVolumeRepository)
+	gcContainerDestroyer := gc.NewQueuedDestroyer(
+		db.NewJobQueue(dbConn),
+		gc.NewDestroyer(logger, dbContainerRepository, dbVolumeRepository),
+	)
 	dbBuildFactory := db.NewBuildFactory(dbConn, lockFactory, cmd.GC.OneOffBuildGracePeriod, cmd.GC.FailedGracePeriod)
 	dbCheckFactory := db.NewCheckFactory(dbConn, lockFactory, secretManager, cmd.varSourcePool, checkBuildsChan, nil)
 	dbAccessTokenFactory := db.NewAccessTokenFactory(dbConn)
 	dbClock := db.NewClock()
 	dbWall := db.NewWall(dbConn, &dbClock)
-
-	tokenVerifier := cmd.constructTokenVerifier(dbAccessTokenFactory)
+	dbOutgoingWebhookRepository := db.NewOutgoingWebhookRepository(dbConn)
+	dbLogDrainRepository := db.NewLogDrainRepository(dbConn)
+	dbFreezeWindowRepository := db.NewFreezeWindowRepository(dbConn)
+	dbBuildStatsRepository := db.NewBuildStatsRepository(dbConn)
+	dbFlakinessRepository := db.NewFlakinessRepository(dbConn)
+	dbBuildQueueRepository := db.NewBuildQueueRepository(dbConn)
+	dbSchedulingStatsRepository := db.NewSchedulingStatsRepository(dbConn)
+	dbComponentFactory := db.NewComponentFactory(dbConn)
+	dbSchemaReference := db.NewSchemaReference(dbConn)
+	dbMaintenanceRepository := db.NewMaintenanceRepository(dbConn, db.DefaultMaintenanceThresholds)
+	dbGrowthRepository := db.NewGrowthRepository(dbConn)
+	dbAutoscalingRepository := db.NewAutoscalingRepository(dbConn)
+	dbDeprecationUsageRepository := db.NewDeprecationUsageRepository(dbConn)
+	dbIdempotencyKeyRepository := db.NewIdempotencyKeyRepository(dbConn)
+	dbTeamRequestRepository := db.NewTeamRequestRepository(dbConn)
+	dbDashboardPreferenceRepository := db.NewDashboardPreferenceRepository(dbConn)
+	dbUserPreferenceRepository := db.NewUserPreferenceRepository(dbConn)
+	dbSessionRepository := db.NewSessionRepository(dbConn)
+	dbLoginLockoutRepository := db.NewLoginLockoutRepository(dbConn)
+	dbSCIMRepository := db.NewSCIMRepository(dbConn)
+	dbClusterOverviewRepository := db.NewClusterOverviewRepository(dbConn)
+	dbInjectedEnvRepository := db.NewInjectedEnvRepository(dbConn)
+	dbCABundleRepository := db.NewCABundleRepository(dbConn)
+
+	healthChecker := health.NewChecker(dbConn, lockFactory, dbWorkerFactory, credsManagers, clock.NewClock(), cmd.Server.HealthCheckCacheDuration)
+
+	tokenVerifier := cmd.constructTokenVerifier(logger, dbConn.Bus(), dbAccessTokenFactory, dbSessionRepository)
 
 	teamsCacher := accessor.NewTeamsCacher(
 		logger,
@@ -836,6 +1102,14 @@ func (cmd *RunCommand) constructAPIMembers(
 		time.Minute,
 	)
 
+	scimGroupsCacher := accessor.NewSCIMGroupsCacher(
+		logger,
+		dbConn.Bus(),
+		dbSCIMRepository,
+		time.Minute,
+		time.Minute,
+	)
+
 	displayUserIdGenerator, err := skycmd.NewSkyDisplayUserIdGenerator(cmd.DisplayUserIdPerConnector)
 	if err != nil {
 		return nil, err
@@ -844,6 +1118,8 @@ func (cmd *RunCommand) constructAPIMembers(
 	accessFactory := accessor.NewAccessFactory(
 		tokenVerifier,
 		teamsCacher,
+		scimGroupsCacher,
+		cmd.SCIMConnector,
 		cmd.SystemClaimKey,
 		cmd.SystemClaimValues,
 		displayUserIdGenerator,
@@ -851,9 +1127,12 @@ func (cmd *RunCommand) constructAPIMembers(
 
 	middleware := token.NewMiddleware(cmd.Auth.AuthFlags.SecureCookies)
 
+	deprecationTracker := deprecation.NewTracker(dbDeprecationUsageRepository)
+
 	apiHandler, err := cmd.constructAPIHandler(
 		logger,
 		reconfigurableSink,
+		requestLogRecorder,
 		teamFactory,
 		workerTeamFactory,
 		dbPipelineFactory,
@@ -872,6 +1151,29 @@ func (cmd *RunCommand) constructAPIMembers(
 		credsManagers,
 		accessFactory,
 		dbWall,
+		dbOutgoingWebhookRepository,
+		dbLogDrainRepository,
+		dbFreezeWindowRepository,
+		dbBuildStatsRepository,
+		dbFlakinessRepository,
+		dbBuildQueueRepository,
+		dbSchedulingStatsRepository,
+		dbComponentFactory,
+		dbSchemaReference,
+		dbMaintenanceRepository,
+		dbGrowthRepository,
+		dbAutoscalingRepository,
+		dbDeprecationUsageRepository,
+		deprecationTracker,
+		dbIdempotencyKeyRepository,
+		dbTeamRequestRepository,
+		dbDashboardPreferenceRepository,
+		dbUserPreferenceRepository,
+		dbSessionRepository,
+		healthChecker,
+		dbClusterOverviewRepository,
+		dbInjectedEnvRepository,
+		dbCABundleRepository,
 		policyChecker,
 	)
 	if err != nil {
@@ -888,6 +1190,7 @@ func (cmd *RunCommand) constructAPIMembers(
 		storage,
 		dbAccessTokenFactory,
 		userFactory,
+		dbLoginLockoutRepository,
 		displayUserIdGenerator,
 	)
 	if err != nil {
@@ -910,6 +1213,14 @@ func (cmd *RunCommand) constructAPIMembers(
 		return nil, err
 	}
 
+	scimHandler, err := cmd.constructSCIMHandler(
+		logger,
+		dbSCIMRepository,
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	var httpHandler, httpsHandler http.Handler
 	if cmd.isTLSEnabled() {
 		httpHandler = cmd.constructHTTPHandler(
@@ -945,6 +1256,7 @@ func (cmd *RunCommand) constructAPIMembers(
 				externalHost:  cmd.ExternalURL.URL.Host,
 				baseHandler:   legacyHandler,
 			},
+			scimHandler,
 			middleware,
 		)
 
@@ -955,6 +1267,7 @@ func (cmd *RunCommand) constructAPIMembers(
 			authHandler,
 			loginHandler,
 			legacyHandler,
+			scimHandler,
 			middleware,
 		)
 	} else {
@@ -965,6 +1278,7 @@ func (cmd *RunCommand) constructAPIMembers(
 			authHandler,
 			loginHandler,
 			legacyHandler,
+			scimHandler,
 			middleware,
 		)
 	}
@@ -978,6 +1292,10 @@ func (cmd *RunCommand) constructAPIMembers(
 			cmd.nonTLSBindAddr(),
 			httpHandler,
 		)},
+		{Name: "deprecation-usage", Runner: deprecationTracker.Runner(
+			logger.Session("deprecation-usage"),
+			time.Minute,
+		)},
 	}
 
 	if httpsHandler != nil {
@@ -1054,6 +1372,22 @@ func (cmd *RunCommand) backendComponents(
 		clock.NewClock(),
 	)
 
+	checkSlots := db.NewCheckSlots(dbConn)
+	err = checkSlots.SetLimits(db.CheckLimits{
+		MaxInFlight:          cmd.MaxChecksInFlight,
+		MaxInFlightPerWorker: cmd.MaxChecksInFlightPerWorker,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	warmPools := db.NewWarmPoolRepository(dbConn)
+
+	nativeResources, err := native.NewResources(cmd.NativeResourceTypes)
+	if err != nil {
+		return nil, err
+	}
+
 	engine := cmd.constructEngine(
 		pool,
 		dbWorkerFactory,
@@ -1061,6 +1395,11 @@ func (cmd *RunCommand) backendComponents(
 		dbBuildFactory,
 		dbResourceCacheFactory,
 		dbResourceConfigFactory,
+		db.NewPutQueue(dbConn),
+		checkSlots,
+		nativeResources,
+		warmPools,
+		db.NewInjectedEnvRepository(dbConn),
 		secretManager,
 		defaultLimits,
 		buildContainerStrategy,
@@ -1117,8 +1456,11 @@ func (cmd *RunCommand) backendComponents(
 					Algorithm: alg,
 					BuildStarter: scheduler.NewBuildStarter(
 						builds.NewPlanner(atc.NewPlanFactory(time.Now().Unix())),
-						alg),
+						alg,
+						worker.NewCapacityChecker(pool, cmd.ContainerPlacementStrategyOptions)),
+					FreezeWindows: db.NewFreezeWindowRepository(dbConn),
 				},
+				db.NewSchedulingStatsRepository(dbConn),
 				cmd.JobSchedulingMaxInFlight,
 			),
 		},
@@ -1129,6 +1471,98 @@ func (cmd *RunCommand) backendComponents(
 			},
 			Runnable: builds.NewTracker(logger, dbBuildFactory, engine, checkBuildsChan),
 		},
+		{
+			Component: atc.Component{
+				Name:     atc.ComponentBuildStatsAggregator,
+				Interval: time.Minute,
+			},
+			Runnable: buildstats.NewAggregator(
+				db.NewBuildStatsRepository(dbConn),
+				cmd.BuildStatsRetention,
+			),
+		},
+		{
+			Component: atc.Component{
+				Name:     atc.ComponentFlakinessAnalyzer,
+				Interval: 10 * time.Minute,
+			},
+			Runnable: flakiness.NewAnalyzer(
+				db.NewFlakinessRepository(dbConn),
+				flakiness.DefaultCriteria,
+			),
+		},
+		{
+			Component: atc.Component{
+				Name:     atc.ComponentMaintenanceAdvisor,
+				Interval: 10 * time.Minute,
+			},
+			Runnable: maintenance.NewAdvisor(
+				db.NewMaintenanceRepository(dbConn, db.DefaultMaintenanceThresholds),
+				cmd.DBMaintenance,
+			),
+		},
+		{
+			Component: atc.Component{
+				Name:     atc.ComponentGrowthSnapshotter,
+				Interval: time.Hour,
+			},
+			Runnable: growth.NewSnapshotter(
+				db.NewGrowthRepository(dbConn),
+				cmd.DBGrowth,
+			),
+		},
+		{
+			Component: atc.Component{
+				Name:     atc.ComponentWarmPoolManager,
+				Interval: 30 * time.Second,
+			},
+			Runnable: warmpool.NewManager(
+				warmPools,
+				pool,
+				buildContainerStrategy,
+			),
+		},
+		{
+			Component: atc.Component{
+				Name:     atc.ComponentWebhookDeliverer,
+				Interval: 10 * time.Second,
+			},
+			Runnable: webhooks.NewDeliverer(
+				db.NewOutgoingWebhookRepository(dbConn),
+				&http.Client{
+					Transport: http.DefaultTransport,
+					Timeout:   10 * time.Second,
+				},
+			),
+		},
+		{
+			Component: atc.Component{
+				Name:     atc.ComponentLogDrainer,
+				Interval: 5 * time.Second,
+			},
+			Runnable: logdrain.NewDrainer(
+				db.NewLogDrainRepository(dbConn),
+				&http.Client{
+					Transport: http.DefaultTransport,
+					Timeout:   30 * time.Second,
+				},
+				cmd.Syslog.Hostname,
+				cmd.Syslog.CACerts,
+			),
+		},
+		{
+			Component: atc.Component{
+				Name:     atc.ComponentDestroyQueueProcessor,
+				Interval: 10 * time.Second,
+			},
+			Runnable: jobqueue.NewProcessor(
+				db.NewJobQueue(dbConn),
+				gc.DestroyQueue,
+				gc.NewDestroyJobHandler(
+					gc.NewDestroyer(logger, db.NewContainerRepository(dbConn), db.NewVolumeRepository(dbConn)),
+				),
+			),
+		},
 		{
 			Component: atc.Component{
 				Name:     atc.ComponentBuildReaper,
@@ -1165,6 +1599,69 @@ func (cmd *RunCommand) backendComponents(
 		})
 	}
 
+	if cmd.Firehose.IsConfigured() {
+		publisher, err := cmd.Firehose.Publisher()
+		if err != nil {
+			return nil, err
+		}
+
+		components = append(components, RunnableComponent{
+			Component: atc.Component{
+				Name:     atc.ComponentFirehoseExporter,
+				Interval: cmd.Firehose.Interval,
+			},
+			Runnable: firehose.NewExporter(
+				db.NewFirehoseOutbox(dbConn),
+				publisher,
+				cmd.Firehose,
+			),
+		})
+	}
+
+	if cmd.Autoscaling.WebhookURL.URL != nil {
+		components = append(components, RunnableComponent{
+			Component: atc.Component{
+				Name:     atc.ComponentAutoscalingPublisher,
+				Interval: cmd.Autoscaling.WebhookInterval,
+			},
+			Runnable: autoscaling.NewPublisher(
+				db.NewAutoscalingRepository(dbConn),
+				dbWorkerFactory,
+				cmd.Autoscaling.WebhookURL.String(),
+				cmd.Autoscaling.WebhookSecret,
+				&http.Client{
+					Transport: http.DefaultTransport,
+					Timeout:   10 * time.Second,
+				},
+			),
+		})
+	}
+
+	if cmd.KubernetesWorker.IsConfigured() {
+		cluster, err := cmd.KubernetesWorker.Cluster()
+		if err != nil {
+			return nil, err
+		}
+
+		kubernetesWorker := cmd.KubernetesWorker.Worker()
+		kubernetesWorker.Version = concourse.WorkerVersion
+
+		components = append(components, RunnableComponent{
+			Component: atc.Component{
+				Name:     atc.ComponentKubernetesWorker,
+				Interval: cmd.KubernetesWorker.HeartbeatInterval,
+			},
+			Runnable: k8sruntime.Registrar{
+				Worker:        kubernetesWorker,
+				TTL:           2 * cmd.KubernetesWorker.HeartbeatInterval,
+				Cluster:       cluster,
+				WorkerFactory: dbWorkerFactory,
+				ContainerRepo: db.NewContainerRepository(dbConn),
+				VolumeRepo:    db.NewVolumeRepository(dbConn),
+			},
+		})
+	}
+
 	return components, err
 }
 
@@ -1206,15 +1703,30 @@ func (cmd *RunCommand) constructPool(dbConn db.Conn, lockFactory lock.LockFactor
 		dbResourceCacheFactory,
 		dbWorkerBaseResourceTypeFactory,
 		lockFactory,
+		db.NewCABundleRepository(dbConn),
+		db.NewTeamProxies(dbConn),
 	)
 
+	var cluster *k8sruntime.Cluster
+	if cmd.KubernetesWorker.IsConfigured() {
+		c, err := cmd.KubernetesWorker.Cluster()
+		if err != nil {
+			return worker.Pool{}, err
+		}
+		cluster = &c
+	}
+
 	return worker.NewPool(
 		worker.DefaultFactory{
 			DB:                                db,
+			CircuitBreakers:                   cmd.workerCircuitBreakers,
 			GardenRequestTimeout:              cmd.GardenRequestTimeout,
 			BaggageclaimResponseHeaderTimeout: cmd.BaggageclaimResponseHeaderTimeout,
-			HTTPRetryTimeout:                  5 * time.Minute,
+			HTTPRetryTimeout:                  cmd.GardenRetryTimeout,
+			BaggageclaimRetryTimeout:          cmd.BaggageclaimRetryTimeout,
+			StreamingChunkSize:                cmd.StreamingArtifactsChunkSizeMB * 1024 * 1024,
 			Streamer:                          cmd.streamer(dbResourceCacheFactory),
+			Kubernetes:                        cluster,
 		},
 		db,
 		workerVersion,
@@ -1237,6 +1749,7 @@ func (cmd *RunCommand) gcComponents(
 	dbResourceConfigFactory := db.NewResourceConfigFactory(gcConn, lockFactory)
 	dbPipelineLifecycle := db.NewPipelineLifecycle(gcConn, lockFactory)
 	dbCheckLifecycle := db.NewCheckLifecycle(gcConn)
+	dbIdempotencyKeyRepository := db.NewIdempotencyKeyRepository(gcConn)
 
 	dbVolumeRepository := db.NewVolumeRepository(gcConn)
 
@@ -1263,6 +1776,7 @@ func (cmd *RunCommand) gcComponents(
 		atc.ComponentCollectorPipelines:         gc.NewPipelineCollector(dbPipelineLifecycle),
 		atc.ComponentCollectorAccessTokens:      gc.NewAccessTokensCollector(dbAccessTokenLifecycle, jwt.DefaultLeeway),
 		atc.ComponentCollectorChecks:            gc.NewChecksCollector(dbCheckLifecycle),
+		atc.ComponentCollectorIdempotencyKeys:   gc.NewIdempotencyKeysCollector(dbIdempotencyKeyRepository, cmd.GC.IdempotencyKeyTTL),
 	}
 
 	var components []RunnableComponent
@@ -1397,6 +1911,11 @@ func (cmd *RunCommand) oldKey() *encryption.Key {
 }
 
 func (cmd *RunCommand) constructWebHandler(logger lager.Logger) (http.Handler, error) {
+	if cmd.isHeadless() {
+		logger.Info("serving-api-only")
+		return metric.WrapHandler(logger, metric.Metrics, "web", web.NewHeadlessHandler()), nil
+	}
+
 	webHandler, err := web.NewHandler(logger, cmd.WebPublicDir.Path())
 	if err != nil {
 		return nil, err
@@ -1404,6 +1923,43 @@ func (cmd *RunCommand) constructWebHandler(logger lager.Logger) (http.Handler, e
 	return metric.WrapHandler(logger, metric.Metrics, "web", webHandler), nil
 }
 
+// isHeadless returns whether the ATC serves the API only, either because it
+// was told to or because it was built without the web UI. A headless build
+// still serves the web UI from --web-public-dir, for local development.
+func (cmd *RunCommand) isHeadless() bool {
+	return cmd.Headless || (web.Headless && cmd.WebPublicDir.Path() == "")
+}
+
+// trustClusterCABundle makes the ATC's own HTTP clients, which share the
+// default transport, trust the CA certificates registered for the cluster in
+// addition to the system's. Changes to the bundle apply once the ATC restarts.
+func trustClusterCABundle(logger lager.Logger, caBundles db.CABundleRepository) error {
+	bundle, err := caBundles.CABundle(0)
+	if err != nil {
+		return fmt.Errorf("get cluster CA bundle: %w", err)
+	}
+
+	if bundle.Certificates == "" {
+		return nil
+	}
+
+	certPool, err := x509.SystemCertPool()
+	if err != nil {
+		logger.Info("failed-to-load-system-cert-pool", lager.Data{"error": err.Error()})
+		certPool = x509.NewCertPool()
+	}
+
+	if !certPool.AppendCertsFromPEM([]byte(bundle.Certificates)) {
+		return errors.New("cluster CA bundle contains no certificates")
+	}
+
+	http.DefaultTransport.(*http.Transport).TLSClientConfig = &tls.Config{
+		RootCAs: certPool,
+	}
+
+	return nil
+}
+
 func (cmd *RunCommand) skyHttpClient() (*http.Client, error) {
 	httpClient := http.DefaultClient
 
@@ -1602,6 +2158,37 @@ func (cmd *RunCommand) validate() error {
 		errs = multierror.Append(errs, err)
 	}
 
+	if err := cmd.KubernetesWorker.Validate(); err != nil {
+		errs = multierror.Append(errs, err)
+	}
+
+	if err := cmd.Firehose.Validate(); err != nil {
+		errs = multierror.Append(errs, err)
+	}
+
+	if cmd.Headless && cmd.WebPublicDir.Path() != "" {
+		errs = multierror.Append(
+			errs,
+			errors.New("cannot specify --web-public-dir with --headless"),
+		)
+	}
+
+	if cmd.SCIMToken != "" && cmd.SCIMConnector == "" {
+		errs = multierror.Append(
+			errs,
+			errors.New("must specify --scim-connector to use --scim-token"),
+		)
+	}
+
+	if cmd.PostgresIAM.Enabled() {
+		// the auth storage connects through dex with a fixed password, which
+		// would stop working once the token it was given expires
+		errs = multierror.Append(
+			errs,
+			errors.New("--postgres-iam-provider is only supported by 'concourse migrate' until the auth storage can refresh its token"),
+		)
+	}
+
 	return errs.ErrorOrNil()
 }
 
@@ -1626,6 +2213,37 @@ func (cmd *RunCommand) configureMetrics(logger lager.Logger) error {
 	return metric.Metrics.Initialize(logger.Session("metrics"), host, cmd.Metrics.Attributes, cmd.Metrics.BufferSize)
 }
 
+// migrationConnectionString is how to connect to the database to run the
+// migrations, when they are to be run as a user of their own.
+func (cmd *RunCommand) migrationConnectionString() string {
+	if cmd.PostgresMigration.User == "" {
+		return ""
+	}
+
+	config := cmd.Postgres
+	config.User = cmd.PostgresMigration.User
+	config.Password = cmd.PostgresMigration.Password
+
+	return config.ConnectionString()
+}
+
+// sessionConnectionString is how to connect to the database for what relies
+// on the session outliving a transaction, i.e. listening for notifications.
+func (cmd *RunCommand) sessionConnectionString() string {
+	if !cmd.PostgresTransactionPooling {
+		return cmd.Postgres.ConnectionString()
+	}
+
+	config := cmd.Postgres
+	config.Host = cmd.PostgresSession.Host
+	config.Socket = ""
+	if cmd.PostgresSession.Port != 0 {
+		config.Port = cmd.PostgresSession.Port
+	}
+
+	return config.ConnectionString()
+}
+
 func (cmd *RunCommand) constructDBConn(
 	driverName string,
 	logger lager.Logger,
@@ -1634,7 +2252,7 @@ func (cmd *RunCommand) constructDBConn(
 	connectionName string,
 	lockFactory lock.LockFactory,
 ) (db.Conn, error) {
-	dbConn, err := db.Open(logger.Session("db"), driverName, cmd.Postgres.ConnectionString(), cmd.newKey(), cmd.oldKey(), connectionName, lockFactory)
+	dbConn, err := db.Open(logger.Session("db"), driverName, cmd.Postgres.ConnectionString(), cmd.newKey(), cmd.oldKey(), connectionName, lockFactory, cmd.HeavyMigrations, cmd.migrationConnectionString(), db.NewListener(cmd.sessionConnectionString(), cmd.dbTokens), cmd.dbDialect)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database: %s", err)
 	}
@@ -1659,6 +2277,17 @@ type Closer interface {
 	Close() error
 }
 
+// newLockFactory keeps the locks in a table when advisory locks can't be
+// relied on, either because the database doesn't have them or because a
+// pooler in transaction pooling mode doesn't keep the session holding them.
+func newLockFactory(conns [lock.FactoryCount]*sql.DB, d dialect.Dialect, transactionPooling bool) (lock.LockFactory, error) {
+	if transactionPooling {
+		return lock.NewTableLockFactory(conns, d, metric.LogLockAcquired, metric.LogLockReleased)
+	}
+
+	return lock.NewLockFactoryForDialect(conns, d, metric.LogLockAcquired, metric.LogLockReleased)
+}
+
 func constructLockConns(driverName, connectionString string) ([lock.FactoryCount]*sql.DB, error) {
 	conns := [lock.FactoryCount]*sql.DB{}
 	for i := 0; i < lock.FactoryCount; i++ {
@@ -1710,6 +2339,11 @@ func (cmd *RunCommand) constructEngine(
 	buildFactory db.BuildFactory,
 	resourceCacheFactory db.ResourceCacheFactory,
 	resourceConfigFactory db.ResourceConfigFactory,
+	putQueue db.PutQueue,
+	checkSlots db.CheckSlots,
+	nativeResources resource.NativeResources,
+	warmPools db.WarmPoolRepository,
+	injectedEnv db.InjectedEnvRepository,
 	secretManager creds.Secrets,
 	defaultLimits atc.ContainerLimits,
 	strategy worker.PlacementStrategy,
@@ -1718,6 +2352,21 @@ func (cmd *RunCommand) constructEngine(
 	rateLimiter engine.RateLimiter,
 	policyChecker policy.Checker,
 ) engine.Engine {
+	buildNotifiers := engine.BuildNotifiers{
+		notify.NewBuildNotifier(teamFactory, cmd.ExternalURL.String()),
+	}
+
+	if cmd.FeatureFlags.EnableCommitStatuses {
+		buildNotifiers = append(buildNotifiers, commitstatus.NewReporter(secretManager, cmd.ExternalURL.String(), &http.Client{
+			Transport: http.DefaultTransport,
+			Timeout:   10 * time.Second,
+		}))
+	}
+
+	if cmd.ProvenanceSigningKey != nil {
+		buildNotifiers = append(buildNotifiers, provenance.NewGenerator(cmd.ExternalURL.String(), cmd.ProvenanceSigningKey.PrivateKey))
+	}
+
 	return engine.NewEngine(
 		engine.NewStepperFactory(
 			engine.NewCoreStepFactory(
@@ -1728,6 +2377,7 @@ func (cmd *RunCommand) constructEngine(
 				buildFactory,
 				resourceCacheFactory,
 				resourceConfigFactory,
+				putQueue,
 				defaultLimits,
 				strategy,
 				noInputStrategy,
@@ -1735,15 +2385,26 @@ func (cmd *RunCommand) constructEngine(
 				cmd.DefaultGetTimeout,
 				cmd.DefaultPutTimeout,
 				cmd.DefaultTaskTimeout,
+				cmd.CheckContainerPoolSize,
+				checkSlots,
+				nativeResources,
+				warmPools,
 			),
 			cmd.ExternalURL.String(),
 			rateLimiter,
 			policyChecker,
 			workerFactory,
 			lockFactory,
+			injectedEnv,
 		),
 		secretManager,
 		cmd.varSourcePool,
+		buildNotifiers,
+		engine.EventFlowControl{
+			BufferSize:    cmd.BuildLogBufferSize,
+			FlushInterval: cmd.BuildEventFlushInterval,
+			MaxLogRate:    cmd.MaxBuildLogRate,
+		},
 	)
 }
 
@@ -1754,6 +2415,7 @@ func (cmd *RunCommand) constructHTTPHandler(
 	authHandler http.Handler,
 	loginHandler http.Handler,
 	legacyHandler http.Handler,
+	scimHandler http.Handler,
 	middleware token.Middleware,
 ) http.Handler {
 
@@ -1771,6 +2433,10 @@ func (cmd *RunCommand) constructHTTPHandler(
 	webMux.Handle("/logout", legacyHandler)
 	webMux.Handle("/", webHandler)
 
+	if scimHandler != nil {
+		webMux.Handle(scimserver.BasePath+"/", scimHandler)
+	}
+
 	httpHandler := wrappa.LoggerHandler{
 		Logger: logger,
 
@@ -1798,11 +2464,28 @@ func (cmd *RunCommand) constructLegacyHandler(
 	})
 }
 
+func (cmd *RunCommand) constructSCIMHandler(
+	logger lager.Logger,
+	scimRepository db.SCIMRepository,
+) (http.Handler, error) {
+	if cmd.SCIMToken == "" {
+		return nil, nil
+	}
+
+	return scimserver.NewHandler(
+		logger.Session("scim"),
+		cmd.ExternalURL.String(),
+		cmd.SCIMToken,
+		scimRepository,
+	)
+}
+
 func (cmd *RunCommand) constructAuthHandler(
 	logger lager.Logger,
 	storage storage.Storage,
 	accessTokenFactory db.AccessTokenFactory,
 	userFactory db.UserFactory,
+	loginLockoutRepository db.LoginLockoutRepository,
 	displayUserIdGenerator atc.DisplayUserIdGenerator,
 ) (http.Handler, error) {
 
@@ -1830,9 +2513,28 @@ func (cmd *RunCommand) constructAuthHandler(
 		return nil, err
 	}
 
+	var dexHandler http.Handler = dexServer
+
+	// other password connectors, such as LDAP, have lockouts of their own
+	if cmd.Auth.AuthFlags.PasswordConnector == "local" {
+		dexHandler = lockout.ProtectLocalLogins(
+			logger.Session("lockout"),
+			dexHandler,
+			loginLockoutRepository,
+			lockout.Config{
+				MaxFailures: cmd.Auth.AuthFlags.LocalLoginMaxFailures,
+				Duration:    cmd.Auth.AuthFlags.LocalLoginLockout,
+				MaxDuration: cmd.Auth.AuthFlags.LocalLoginMaxLockout,
+				RateLimit:   cmd.Auth.AuthFlags.LocalLoginRateLimit,
+
+				ClientIPHeader: cmd.Auth.AuthFlags.LocalLoginClientIPHeader,
+			},
+		)
+	}
+
 	return token.StoreAccessToken(
 		logger.Session("dex-server"),
-		dexServer,
+		dexHandler,
 		token.Factory{},
 		token.NewClaimsParser(),
 		accessTokenFactory,
@@ -1883,7 +2585,12 @@ func (cmd *RunCommand) constructLoginHandler(
 	return skyserver.NewSkyHandler(skyServer), nil
 }
 
-func (cmd *RunCommand) constructTokenVerifier(accessTokenFactory db.AccessTokenFactory) accessor.TokenVerifier {
+func (cmd *RunCommand) constructTokenVerifier(
+	logger lager.Logger,
+	notifications accessor.Notifications,
+	accessTokenFactory db.AccessTokenFactory,
+	sessionRepository db.SessionRepository,
+) accessor.TokenVerifier {
 
 	validClients := []string{flyClientID}
 	for clientId := range cmd.Auth.AuthFlags.Clients {
@@ -1893,12 +2600,50 @@ func (cmd *RunCommand) constructTokenVerifier(accessTokenFactory db.AccessTokenF
 	MiB := 1024 * 1024
 	claimsCacher := accessor.NewClaimsCacher(accessTokenFactory, 1*MiB)
 
-	return accessor.NewVerifier(claimsCacher, validClients)
+	revocationsCacher := accessor.NewRevocationsCacher(
+		logger,
+		notifications,
+		claimsCacher,
+		sessionRepository,
+		time.Minute,
+		time.Minute,
+	)
+
+	return accessor.NewVerifier(revocationsCacher, validClients)
+}
+
+func (cmd *RunCommand) requestLogSettings() atc.RequestLogSettings {
+	settings := atc.RequestLogSettings{
+		SampleRate: cmd.APIRequestLogSampleRate,
+	}
+
+	if cmd.APISlowRequestThreshold > 0 {
+		settings.SlowThreshold = cmd.APISlowRequestThreshold.String()
+	}
+
+	return settings
+}
+
+func (cmd *RunCommand) constructAuditor(logger lager.Logger) auditor.Auditor {
+	return auditor.NewAuditor(
+		cmd.Auditor.EnableBuildAuditLog,
+		cmd.Auditor.EnableContainerAuditLog,
+		cmd.Auditor.EnableJobAuditLog,
+		cmd.Auditor.EnablePipelineAuditLog,
+		cmd.Auditor.EnableResourceAuditLog,
+		cmd.Auditor.EnableSystemAuditLog,
+		cmd.Auditor.EnableTeamAuditLog,
+		cmd.Auditor.EnableWorkerAuditLog,
+		cmd.Auditor.EnableVolumeAuditLog,
+		cmd.Auditor.EnablePolicyAuditLog,
+		logger,
+	)
 }
 
 func (cmd *RunCommand) constructAPIHandler(
 	logger lager.Logger,
 	reconfigurableSink *lager.ReconfigurableSink,
+	requestLogRecorder *requestlog.Recorder,
 	teamFactory db.TeamFactory,
 	workerTeamFactory db.TeamFactory,
 	dbPipelineFactory db.PipelineFactory,
@@ -1917,6 +2662,29 @@ func (cmd *RunCommand) constructAPIHandler(
 	credsManagers creds.Managers,
 	accessFactory accessor.AccessFactory,
 	dbWall db.Wall,
+	dbOutgoingWebhookRepository db.OutgoingWebhookRepository,
+	dbLogDrainRepository db.LogDrainRepository,
+	dbFreezeWindowRepository db.FreezeWindowRepository,
+	dbBuildStatsRepository db.BuildStatsRepository,
+	dbFlakinessRepository db.FlakinessRepository,
+	dbBuildQueueRepository db.BuildQueueRepository,
+	dbSchedulingStatsRepository db.SchedulingStatsRepository,
+	dbComponentFactory db.ComponentFactory,
+	dbSchemaReference db.SchemaReference,
+	dbMaintenanceRepository db.MaintenanceRepository,
+	dbGrowthRepository db.GrowthRepository,
+	dbAutoscalingRepository db.AutoscalingRepository,
+	dbDeprecationUsageRepository db.DeprecationUsageRepository,
+	deprecationTracker *deprecation.Tracker,
+	dbIdempotencyKeyRepository db.IdempotencyKeyRepository,
+	dbTeamRequestRepository db.TeamRequestRepository,
+	dbDashboardPreferenceRepository db.DashboardPreferenceRepository,
+	dbUserPreferenceRepository db.UserPreferenceRepository,
+	dbSessionRepository db.SessionRepository,
+	healthChecker healthserver.Checker,
+	dbClusterOverviewRepository db.ClusterOverviewRepository,
+	dbInjectedEnvRepository db.InjectedEnvRepository,
+	dbCABundleRepository db.CABundleRepository,
 	policyChecker policy.Checker,
 ) (http.Handler, error) {
 
@@ -1927,18 +2695,7 @@ func (cmd *RunCommand) constructAPIHandler(
 
 	rejectArchivedHandlerFactory := pipelineserver.NewRejectArchivedHandlerFactory(teamFactory)
 
-	aud := auditor.NewAuditor(
-		cmd.Auditor.EnableBuildAuditLog,
-		cmd.Auditor.EnableContainerAuditLog,
-		cmd.Auditor.EnableJobAuditLog,
-		cmd.Auditor.EnablePipelineAuditLog,
-		cmd.Auditor.EnableResourceAuditLog,
-		cmd.Auditor.EnableSystemAuditLog,
-		cmd.Auditor.EnableTeamAuditLog,
-		cmd.Auditor.EnableWorkerAuditLog,
-		cmd.Auditor.EnableVolumeAuditLog,
-		logger,
-	)
+	aud := cmd.constructAuditor(logger)
 
 	customRoles, err := cmd.parseCustomRoles()
 	if err != nil {
@@ -1951,7 +2708,9 @@ func (cmd *RunCommand) constructAPIHandler(
 			wrappa.NewConcurrentRequestPolicy(cmd.ConcurrentRequestLimits),
 		),
 		wrappa.NewAPIMetricsWrappa(logger),
+		wrappa.NewRequestLogWrappa(requestLogRecorder),
 		wrappa.NewPolicyCheckWrappa(logger, policychecker.NewApiPolicyChecker(policyChecker)),
+		wrappa.NewIdempotencyWrappa(logger, dbIdempotencyKeyRepository),
 		wrappa.NewAPIAuthWrappa(
 			checkPipelineAccessHandlerFactory,
 			checkBuildReadAccessHandlerFactory,
@@ -1960,6 +2719,7 @@ func (cmd *RunCommand) constructAPIHandler(
 		),
 		wrappa.NewRejectArchivedWrappa(rejectArchivedHandlerFactory),
 		wrappa.NewConcourseVersionWrappa(concourse.Version),
+		wrappa.NewDeprecationWrappa(deprecationTracker, atc.APIDeprecations),
 		wrappa.NewAccessorWrappa(
 			logger,
 			accessFactory,
@@ -1990,10 +2750,13 @@ func (cmd *RunCommand) constructAPIHandler(
 		dbUserFactory,
 
 		buildserver.NewEventHandler,
+		cmd.MaxRunningOneOffBuildsPerTeam,
 
 		workerPool,
+		cmd.workerCircuitBreakers,
 
 		reconfigurableSink,
+		requestLogRecorder,
 
 		cmd.isTLSEnabled(),
 
@@ -2005,7 +2768,29 @@ func (cmd *RunCommand) constructAPIHandler(
 		credsManagers,
 		containerserver.NewInterceptTimeoutFactory(cmd.InterceptIdleTimeout),
 		time.Minute,
+		aud,
 		dbWall,
+		dbOutgoingWebhookRepository,
+		dbLogDrainRepository,
+		dbFreezeWindowRepository,
+		dbBuildStatsRepository,
+		dbFlakinessRepository,
+		dbBuildQueueRepository,
+		dbSchedulingStatsRepository,
+		dbComponentFactory,
+		dbSchemaReference,
+		dbMaintenanceRepository,
+		dbGrowthRepository,
+		dbAutoscalingRepository,
+		dbDeprecationUsageRepository,
+		dbTeamRequestRepository,
+		dbDashboardPreferenceRepository,
+		dbUserPreferenceRepository,
+		dbSessionRepository,
+		healthChecker,
+		dbClusterOverviewRepository,
+		dbInjectedEnvRepository,
+		dbCABundleRepository,
 		clock.NewClock(),
 	)
 }
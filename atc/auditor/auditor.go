@@ -3,9 +3,11 @@ package auditor
 import (
 	"fmt"
 	"net/http"
+	"time"
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/policy"
 )
 
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
@@ -21,6 +23,7 @@ func NewAuditor(
 	EnableTeamAuditLog bool,
 	EnableWorkerAuditLog bool,
 	EnableVolumeAuditLog bool,
+	EnablePolicyAuditLog bool,
 	logger lager.Logger,
 ) *auditor {
 	return &auditor{
@@ -33,12 +36,28 @@ func NewAuditor(
 		EnableTeamAuditLog:      EnableTeamAuditLog,
 		EnableWorkerAuditLog:    EnableWorkerAuditLog,
 		EnableVolumeAuditLog:    EnableVolumeAuditLog,
+		EnablePolicyAuditLog:    EnablePolicyAuditLog,
 		logger:                  logger,
 	}
 }
 
 type Auditor interface {
 	Audit(action string, userName string, r *http.Request)
+	AuditInterceptSession(session InterceptSession)
+	AuditPolicyDecision(input policy.PolicyCheckInput, result policy.PolicyCheckResult)
+}
+
+// InterceptSession describes a finished session of a user running a process
+// in a container by intercepting it.
+type InterceptSession struct {
+	User      string
+	Team      string
+	Container string
+	BuildID   int
+	Path      string
+	Args      []string
+	StartTime time.Time
+	EndTime   time.Time
 }
 
 type auditor struct {
@@ -51,6 +70,7 @@ type auditor struct {
 	EnableTeamAuditLog      bool
 	EnableWorkerAuditLog    bool
 	EnableVolumeAuditLog    bool
+	EnablePolicyAuditLog    bool
 	logger                  lager.Logger
 }
 
@@ -58,13 +78,17 @@ func (a *auditor) ValidateAction(action string) bool {
 	switch action {
 	case atc.GetBuild,
 		atc.GetBuildPlan,
+		atc.GetBuildSteps,
+		atc.GetBuildProvenance,
 		atc.CreateBuild,
+		atc.RunTask,
 		atc.RerunJobBuild,
 		atc.SetBuildComment,
 		atc.ListBuilds,
 		atc.BuildEvents,
 		atc.BuildResources,
 		atc.AbortBuild,
+		atc.AbortBuildStep,
 		atc.GetBuildPreparation,
 		atc.ListBuildsWithVersionAsInput,
 		atc.ListBuildsWithVersionAsOutput,
@@ -75,6 +99,8 @@ func (a *auditor) ValidateAction(action string) bool {
 	case atc.ListContainers,
 		atc.GetContainer,
 		atc.HijackContainer,
+		atc.ListContainerProcesses,
+		atc.ListBuildContainers,
 		atc.ListDestroyingContainers,
 		atc.ReportWorkerContainers:
 		return a.EnableContainerAuditLog
@@ -82,6 +108,11 @@ func (a *auditor) ValidateAction(action string) bool {
 		atc.CreateJobBuild,
 		atc.ListAllJobs,
 		atc.ListJobs,
+		atc.ListJobStatistics,
+		atc.GetJobStatistics,
+		atc.ListJobFlakiness,
+		atc.ListFlakyJobs,
+		atc.ListTeamBuildQueue,
 		atc.ListJobBuilds,
 		atc.ListJobInputs,
 		atc.GetJobBuild,
@@ -102,10 +133,17 @@ func (a *auditor) ValidateAction(action string) bool {
 		atc.UnpausePipeline,
 		atc.ExposePipeline,
 		atc.HidePipeline,
+		atc.PausePipelines,
+		atc.UnpausePipelines,
+		atc.ExposePipelines,
+		atc.HidePipelines,
+		atc.CheckPipelines,
 		atc.RenamePipeline,
+		atc.MovePipeline,
 		atc.ListPipelineBuilds,
 		atc.CreatePipelineBuild,
-		atc.PipelineBadge:
+		atc.PipelineBadge,
+		atc.GetPipelineGraph:
 		return a.EnablePipelineAuditLog
 	case atc.ListAllResources,
 		atc.ListResources,
@@ -113,7 +151,10 @@ func (a *auditor) ValidateAction(action string) bool {
 		atc.GetResource,
 		atc.UnpinResource,
 		atc.SetPinCommentOnResource,
+		atc.ResetResourceCheckFrom,
 		atc.CheckResource,
+		atc.FetchResourceVersionMetadata,
+		atc.BackfillResourceMetadata,
 		atc.CheckResourceWebHook,
 		atc.CheckResourceType,
 		atc.CheckPrototype,
@@ -122,9 +163,11 @@ func (a *auditor) ValidateAction(action string) bool {
 		atc.EnableResourceVersion,
 		atc.DisableResourceVersion,
 		atc.PinResourceVersion,
+		atc.PushResourceVersions,
 		atc.ClearResourceCache,
 		atc.GetDownstreamResourceCausality,
 		atc.GetUpstreamResourceCausality,
+		atc.GetResourceVersionImpact,
 		atc.ListSharedForResource,
 		atc.ListSharedForResourceType,
 		atc.ClearResourceVersions,
@@ -133,25 +176,99 @@ func (a *auditor) ValidateAction(action string) bool {
 	case
 		atc.SaveConfig,
 		atc.GetConfig,
+		atc.ValidateConfig,
+		atc.ReportConfigVars,
+		atc.StageConfig,
+		atc.PromoteCandidateConfig,
+		atc.DiscardCandidateConfig,
 		atc.GetCC,
 		atc.GetVersionsDB,
 		atc.ClearTaskCache,
 		atc.SetLogLevel,
 		atc.GetLogLevel,
+		atc.GetRequestLog,
+		atc.SetRequestLog,
 		atc.DownloadCLI,
 		atc.GetInfo,
 		atc.GetInfoCreds,
+		atc.GetOpenAPI,
+		atc.GetHealth,
+		atc.GetConfigSchema,
 		atc.ListActiveUsersSince,
 		atc.GetUser,
+		atc.GetDashboardPreferences,
+		atc.OrderDashboardPipelines,
+		atc.FavoritePipeline,
+		atc.UnfavoritePipeline,
+		atc.GetUserPreferences,
+		atc.SetUserPreference,
+		atc.DeleteUserPreference,
+		atc.ListSessions,
+		atc.RevokeSession,
+		atc.ListUserSessions,
+		atc.RevokeUserSessions,
 		atc.GetWall,
 		atc.SetWall,
-		atc.ClearWall:
+		atc.ClearWall,
+		atc.ListClusterWebhooks,
+		atc.SetClusterWebhook,
+		atc.DestroyClusterWebhook,
+		atc.ListClusterWebhookDeliveries,
+		atc.ListClusterFreezeWindows,
+		atc.CreateClusterFreezeWindow,
+		atc.DestroyClusterFreezeWindow,
+		atc.GetClusterInjectedEnv,
+		atc.SetClusterInjectedEnv,
+		atc.GetClusterCABundle,
+		atc.SetClusterCABundle,
+		atc.GetSchedulerProfile,
+		atc.GetDBSchema,
+		atc.GetDBMaintenance,
+		atc.GetDBGrowth,
+		atc.GetAutoscalingSignal,
+		atc.GetDeprecationReport,
+		atc.GetClusterOverview,
+		atc.ListComponents,
+		atc.SetComponentInterval,
+		atc.ResetComponentInterval,
+		atc.PauseComponent,
+		atc.UnpauseComponent:
 		return a.EnableSystemAuditLog
 	case atc.ListTeams,
 		atc.SetTeam,
 		atc.RenameTeam,
 		atc.DestroyTeam,
 		atc.ListTeamBuilds,
+		atc.ListTeamUsers,
+		atc.ListNotifiers,
+		atc.SetNotifier,
+		atc.DestroyNotifier,
+		atc.ListTeamWebhooks,
+		atc.SetTeamWebhook,
+		atc.DestroyTeamWebhook,
+		atc.ListTeamWebhookDeliveries,
+		atc.ListTeamLogDrains,
+		atc.SetTeamLogDrain,
+		atc.DestroyTeamLogDrain,
+		atc.ListTeamFreezeWindows,
+		atc.CreateTeamFreezeWindow,
+		atc.DestroyTeamFreezeWindow,
+		atc.GetTeamInjectedEnv,
+		atc.SetTeamInjectedEnv,
+		atc.GetTeamCABundle,
+		atc.SetTeamCABundle,
+		atc.GetTeamWorkerKeys,
+		atc.SetTeamWorkerKeys,
+		atc.GetTeamInterceptSettings,
+		atc.SetTeamInterceptSettings,
+		atc.GetTeamPrivilegedSettings,
+		atc.SetTeamPrivilegedSettings,
+		atc.GetTeamProxySettings,
+		atc.SetTeamProxySettings,
+		atc.ListTeamRequests,
+		atc.CreateTeamRequest,
+		atc.ApproveTeamRequest,
+		atc.RejectTeamRequest,
 		atc.GetTeam:
 		return a.EnableTeamAuditLog
 	case atc.RegisterWorker,
@@ -160,9 +277,11 @@ func (a *auditor) ValidateAction(action string) bool {
 		atc.PruneWorker,
 		atc.HeartbeatWorker,
 		atc.ListWorkers,
+		atc.ListWorkerKeys,
 		atc.DeleteWorker:
 		return a.EnableWorkerAuditLog
 	case atc.ListVolumes,
+		atc.ListBuildVolumes,
 		atc.ListDestroyingVolumes,
 		atc.ReportWorkerVolumes:
 		return a.EnableVolumeAuditLog
@@ -177,3 +296,49 @@ func (a *auditor) Audit(action string, userName string, r *http.Request) {
 		a.logger.Info("audit", lager.Data{"action": action, "user": userName, "parameters": r.Form})
 	}
 }
+
+func (a *auditor) AuditInterceptSession(session InterceptSession) {
+	if !a.EnableContainerAuditLog {
+		return
+	}
+
+	a.logger.Info("audit-intercept-session", lager.Data{
+		"user":       session.User,
+		"team":       session.Team,
+		"container":  session.Container,
+		"build_id":   session.BuildID,
+		"path":       session.Path,
+		"args":       session.Args,
+		"start_time": session.StartTime.Unix(),
+		"end_time":   session.EndTime.Unix(),
+		"duration":   session.EndTime.Sub(session.StartTime).String(),
+	})
+}
+
+func (a *auditor) AuditPolicyDecision(input policy.PolicyCheckInput, result policy.PolicyCheckResult) {
+	if !a.EnablePolicyAuditLog {
+		return
+	}
+
+	decision := "allowed"
+	var messages []string
+	if !result.Allowed() {
+		decision = "warned"
+		if result.ShouldBlock() {
+			decision = "denied"
+		}
+
+		messages = result.Messages()
+	}
+
+	a.logger.Info("audit-policy-decision", lager.Data{
+		"action":        input.Action,
+		"user":          input.User,
+		"team":          input.Team,
+		"pipeline":      input.Pipeline,
+		"instance_vars": input.InstanceVars,
+		"job":           input.Job,
+		"decision":      decision,
+		"messages":      messages,
+	})
+}
@@ -2,11 +2,14 @@ package auditor_test
 
 import (
 	"net/http"
+	"time"
 
 	"code.cloudfoundry.org/lager/lagertest"
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/auditor"
+	"github.com/concourse/concourse/atc/policy"
+	"github.com/concourse/concourse/atc/policy/policyfakes"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -28,6 +31,7 @@ var _ = Describe("Audit", func() {
 		EnableTeamAuditLog      bool
 		EnableWorkerAuditLog    bool
 		EnableVolumeAuditLog    bool
+		EnablePolicyAuditLog    bool
 	)
 
 	BeforeEach(func() {
@@ -51,6 +55,7 @@ var _ = Describe("Audit", func() {
 			EnableTeamAuditLog,
 			EnableWorkerAuditLog,
 			EnableVolumeAuditLog,
+			EnablePolicyAuditLog,
 			logger,
 		)
 	})
@@ -65,6 +70,7 @@ var _ = Describe("Audit", func() {
 		EnableTeamAuditLog = false
 		EnableWorkerAuditLog = false
 		EnableVolumeAuditLog = false
+		EnablePolicyAuditLog = false
 	})
 	Context("when audit is called", func() {
 		BeforeEach(func() {
@@ -197,6 +203,49 @@ var _ = Describe("Audit", func() {
 				Expect(len(logs)).To(Equal(0))
 			})
 		})
+
+		Context("When an intercept session finishes", func() {
+			var session auditor.InterceptSession
+
+			BeforeEach(func() {
+				startTime := time.Unix(1600000000, 0)
+				session = auditor.InterceptSession{
+					User:      userName,
+					Team:      "some-team",
+					Container: "some-handle",
+					BuildID:   42,
+					Path:      "bash",
+					StartTime: startTime,
+					EndTime:   startTime.Add(90 * time.Second),
+				}
+			})
+
+			Context("When EnableContainerAuditLog is false", func() {
+				BeforeEach(func() {
+					EnableContainerAuditLog = false
+				})
+
+				It("Doesn't create a log", func() {
+					aud.AuditInterceptSession(session)
+					Expect(logger.Logs()).To(BeEmpty())
+				})
+			})
+
+			Context("When EnableContainerAuditLog is true", func() {
+				BeforeEach(func() {
+					EnableContainerAuditLog = true
+				})
+
+				It("Creates a log including who intercepted which container for how long", func() {
+					aud.AuditInterceptSession(session)
+					logs := logger.Logs()
+					Expect(logs).To(HaveLen(1))
+					Expect(logs[0].Data["user"]).To(Equal(userName))
+					Expect(logs[0].Data["container"]).To(Equal("some-handle"))
+					Expect(logs[0].Data["duration"]).To(Equal("1m30s"))
+				})
+			})
+		})
 	})
 
 	Describe("EnableJobAuditLog", func() {
@@ -590,4 +639,64 @@ var _ = Describe("Audit", func() {
 			})
 		})
 	})
+
+	Describe("AuditPolicyDecision", func() {
+		var (
+			input  policy.PolicyCheckInput
+			result *policyfakes.FakePolicyCheckResult
+		)
+
+		BeforeEach(func() {
+			input = policy.PolicyCheckInput{
+				Action:   atc.HijackContainer,
+				User:     userName,
+				Team:     "some-team",
+				Pipeline: "some-pipeline",
+				Job:      "some-job",
+			}
+
+			result = new(policyfakes.FakePolicyCheckResult)
+			result.AllowedReturns(false)
+			result.ShouldBlockReturns(true)
+			result.MessagesReturns([]string{"no hijacking in prod"})
+		})
+
+		Context("When EnablePolicyAuditLog is false", func() {
+			It("Doesn't create a log", func() {
+				aud.AuditPolicyDecision(input, result)
+				Expect(logger.Logs()).To(BeEmpty())
+			})
+		})
+
+		Context("When EnablePolicyAuditLog is true", func() {
+			BeforeEach(func() {
+				EnablePolicyAuditLog = true
+			})
+
+			It("Creates a log including the action and the decision", func() {
+				aud.AuditPolicyDecision(input, result)
+				logs := logger.Logs()
+				Expect(logs).To(HaveLen(1))
+				Expect(logs[0].Message).To(HaveSuffix("audit-policy-decision"))
+				Expect(logs[0].Data["action"]).To(Equal(atc.HijackContainer))
+				Expect(logs[0].Data["user"]).To(Equal(userName))
+				Expect(logs[0].Data["job"]).To(Equal("some-job"))
+				Expect(logs[0].Data["decision"]).To(Equal("denied"))
+				Expect(logs[0].Data["messages"]).To(Equal([]interface{}{"no hijacking in prod"}))
+			})
+
+			Context("when the action is allowed", func() {
+				BeforeEach(func() {
+					result.AllowedReturns(true)
+				})
+
+				It("logs that it was allowed", func() {
+					aud.AuditPolicyDecision(input, result)
+					logs := logger.Logs()
+					Expect(logs).To(HaveLen(1))
+					Expect(logs[0].Data["decision"]).To(Equal("allowed"))
+				})
+			})
+		})
+	})
 })
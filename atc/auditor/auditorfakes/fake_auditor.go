@@ -6,6 +6,7 @@ import (
 	"sync"
 
 	"github.com/concourse/concourse/atc/auditor"
+	"github.com/concourse/concourse/atc/policy"
 )
 
 type FakeAuditor struct {
@@ -16,6 +17,17 @@ type FakeAuditor struct {
 		arg2 string
 		arg3 *http.Request
 	}
+	AuditInterceptSessionStub        func(auditor.InterceptSession)
+	auditInterceptSessionMutex       sync.RWMutex
+	auditInterceptSessionArgsForCall []struct {
+		arg1 auditor.InterceptSession
+	}
+	AuditPolicyDecisionStub        func(policy.PolicyCheckInput, policy.PolicyCheckResult)
+	auditPolicyDecisionMutex       sync.RWMutex
+	auditPolicyDecisionArgsForCall []struct {
+		arg1 policy.PolicyCheckInput
+		arg2 policy.PolicyCheckResult
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -54,9 +66,78 @@ func (fake *FakeAuditor) AuditArgsForCall(i int) (string, string, *http.Request)
 	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
+func (fake *FakeAuditor) AuditInterceptSession(arg1 auditor.InterceptSession) {
+	fake.auditInterceptSessionMutex.Lock()
+	fake.auditInterceptSessionArgsForCall = append(fake.auditInterceptSessionArgsForCall, struct {
+		arg1 auditor.InterceptSession
+	}{arg1})
+	stub := fake.AuditInterceptSessionStub
+	fake.recordInvocation("AuditInterceptSession", []interface{}{arg1})
+	fake.auditInterceptSessionMutex.Unlock()
+	if stub != nil {
+		fake.AuditInterceptSessionStub(arg1)
+	}
+}
+
+func (fake *FakeAuditor) AuditInterceptSessionCallCount() int {
+	fake.auditInterceptSessionMutex.RLock()
+	defer fake.auditInterceptSessionMutex.RUnlock()
+	return len(fake.auditInterceptSessionArgsForCall)
+}
+
+func (fake *FakeAuditor) AuditInterceptSessionCalls(stub func(auditor.InterceptSession)) {
+	fake.auditInterceptSessionMutex.Lock()
+	defer fake.auditInterceptSessionMutex.Unlock()
+	fake.AuditInterceptSessionStub = stub
+}
+
+func (fake *FakeAuditor) AuditInterceptSessionArgsForCall(i int) auditor.InterceptSession {
+	fake.auditInterceptSessionMutex.RLock()
+	defer fake.auditInterceptSessionMutex.RUnlock()
+	argsForCall := fake.auditInterceptSessionArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeAuditor) AuditPolicyDecision(arg1 policy.PolicyCheckInput, arg2 policy.PolicyCheckResult) {
+	fake.auditPolicyDecisionMutex.Lock()
+	fake.auditPolicyDecisionArgsForCall = append(fake.auditPolicyDecisionArgsForCall, struct {
+		arg1 policy.PolicyCheckInput
+		arg2 policy.PolicyCheckResult
+	}{arg1, arg2})
+	stub := fake.AuditPolicyDecisionStub
+	fake.recordInvocation("AuditPolicyDecision", []interface{}{arg1, arg2})
+	fake.auditPolicyDecisionMutex.Unlock()
+	if stub != nil {
+		fake.AuditPolicyDecisionStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeAuditor) AuditPolicyDecisionCallCount() int {
+	fake.auditPolicyDecisionMutex.RLock()
+	defer fake.auditPolicyDecisionMutex.RUnlock()
+	return len(fake.auditPolicyDecisionArgsForCall)
+}
+
+func (fake *FakeAuditor) AuditPolicyDecisionCalls(stub func(policy.PolicyCheckInput, policy.PolicyCheckResult)) {
+	fake.auditPolicyDecisionMutex.Lock()
+	defer fake.auditPolicyDecisionMutex.Unlock()
+	fake.AuditPolicyDecisionStub = stub
+}
+
+func (fake *FakeAuditor) AuditPolicyDecisionArgsForCall(i int) (policy.PolicyCheckInput, policy.PolicyCheckResult) {
+	fake.auditPolicyDecisionMutex.RLock()
+	defer fake.auditPolicyDecisionMutex.RUnlock()
+	argsForCall := fake.auditPolicyDecisionArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
 func (fake *FakeAuditor) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.auditInterceptSessionMutex.RLock()
+	defer fake.auditInterceptSessionMutex.RUnlock()
+	fake.auditPolicyDecisionMutex.RLock()
+	defer fake.auditPolicyDecisionMutex.RUnlock()
 	fake.auditMutex.RLock()
 	defer fake.auditMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
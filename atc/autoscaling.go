@@ -0,0 +1,33 @@
+package atc
+
+// AutoscalingSignal is the demand for workers, broken down by the platform
+// and tags of the steps which need them, for external autoscalers to scale
+// worker fleets on the depth of the build queue.
+type AutoscalingSignal struct {
+	Time   int64              `json:"time"`
+	Groups []AutoscalingGroup `json:"groups"`
+}
+
+// AutoscalingGroup is the demand for, and the supply of, the workers which
+// can run steps with a platform and set of tags. An empty platform stands
+// for steps which can run on any platform. A worker is counted in every
+// group whose steps it can run.
+type AutoscalingGroup struct {
+	Platform string   `json:"platform,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
+
+	// PendingBuilds is how many builds with steps in the group are ready to
+	// start, leaving out the builds held back by their job's max in flight.
+	PendingBuilds int `json:"pending_builds"`
+
+	// ProjectedContainers is how many containers the pending builds are
+	// going to need, counting one for each of their steps in the group.
+	ProjectedContainers int `json:"projected_containers"`
+
+	// OldestPendingSeconds is how long the longest waiting of the pending
+	// builds has been waiting.
+	OldestPendingSeconds int64 `json:"oldest_pending_seconds"`
+
+	Workers          int `json:"workers"`
+	ActiveContainers int `json:"active_containers"`
+}
@@ -0,0 +1,13 @@
+package autoscaling_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestAutoscaling(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Autoscaling Suite")
+}
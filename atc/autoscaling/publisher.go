@@ -0,0 +1,97 @@
+package autoscaling
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/webhooks"
+)
+
+// SignalEvent is sent in the event header of the requests made by the
+// publisher.
+const SignalEvent = "autoscaling_signal"
+
+// Publisher pushes the signal to a webhook each time it runs, so that
+// autoscalers do not have to poll the API.
+type Publisher struct {
+	repository    db.AutoscalingRepository
+	workerFactory db.WorkerFactory
+	url           string
+	secret        string
+	client        *http.Client
+	clock         func() time.Time
+}
+
+func NewPublisher(repository db.AutoscalingRepository, workerFactory db.WorkerFactory, url string, secret string, client *http.Client) *Publisher {
+	return &Publisher{
+		repository:    repository,
+		workerFactory: workerFactory,
+		url:           url,
+		secret:        secret,
+		client:        client,
+		clock:         time.Now,
+	}
+}
+
+// Run sends the current signal to the webhook. A signal which fails to be
+// sent is not retried, as the next run sends a fresher one.
+func (publisher *Publisher) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx).Session("autoscaling-publisher")
+
+	logger.Debug("start")
+	defer logger.Debug("done")
+
+	signal, err := Current(publisher.repository, publisher.workerFactory, publisher.clock())
+	if err != nil {
+		logger.Error("failed-to-get-signal", err)
+		return err
+	}
+
+	payload, err := json.Marshal(signal)
+	if err != nil {
+		logger.Error("failed-to-encode-signal", err)
+		return err
+	}
+
+	err = publisher.send(ctx, payload)
+	if err != nil {
+		logger.Error("failed-to-send-signal", err)
+		return err
+	}
+
+	return nil
+}
+
+func (publisher *Publisher) send(ctx context.Context, payload []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, publisher.url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Concourse-Webhook")
+	req.Header.Set(webhooks.EventHeader, SignalEvent)
+
+	if publisher.secret != "" {
+		req.Header.Set(webhooks.SignatureHeader, webhooks.Sign(publisher.secret, payload))
+	}
+
+	resp, err := publisher.client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected response status: %s", resp.Status)
+	}
+
+	return nil
+}
@@ -0,0 +1,112 @@
+package autoscaling_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/autoscaling"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/webhooks"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Publisher", func() {
+	var (
+		server            *ghttp.Server
+		fakeRepository    *dbfakes.FakeAutoscalingRepository
+		fakeWorkerFactory *dbfakes.FakeWorkerFactory
+		secret            string
+
+		runErr error
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		fakeRepository = new(dbfakes.FakeAutoscalingRepository)
+		fakeWorkerFactory = new(dbfakes.FakeWorkerFactory)
+		secret = ""
+
+		worker := new(dbfakes.FakeWorker)
+		worker.StateReturns(db.WorkerStateRunning)
+		worker.PlatformReturns("linux")
+		worker.ActiveContainersReturns(3)
+		fakeWorkerFactory.WorkersReturns([]db.Worker{worker}, nil)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	JustBeforeEach(func() {
+		runErr = autoscaling.NewPublisher(fakeRepository, fakeWorkerFactory, server.URL()+"/hook", secret, http.DefaultClient).Run(context.TODO())
+	})
+
+	Context("when the webhook responds successfully", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest("POST", "/hook"),
+				ghttp.VerifyHeaderKV(webhooks.EventHeader, autoscaling.SignalEvent),
+				func(w http.ResponseWriter, r *http.Request) {
+					Expect(r.Header.Get(webhooks.SignatureHeader)).To(BeEmpty())
+
+					var signal atc.AutoscalingSignal
+					Expect(json.NewDecoder(r.Body).Decode(&signal)).To(Succeed())
+					Expect(signal.Groups).To(Equal([]atc.AutoscalingGroup{
+						{Platform: "linux", Workers: 1, ActiveContainers: 3},
+					}))
+				},
+				ghttp.RespondWith(http.StatusOK, nil),
+			))
+		})
+
+		It("sends the signal", func() {
+			Expect(runErr).ToNot(HaveOccurred())
+			Expect(server.ReceivedRequests()).To(HaveLen(1))
+		})
+	})
+
+	Context("when a secret is configured", func() {
+		BeforeEach(func() {
+			secret = "some-secret"
+
+			server.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest("POST", "/hook"),
+				func(w http.ResponseWriter, r *http.Request) {
+					Expect(r.Header.Get(webhooks.SignatureHeader)).To(HavePrefix("sha256="))
+				},
+				ghttp.RespondWith(http.StatusNoContent, nil),
+			))
+		})
+
+		It("signs the signal", func() {
+			Expect(runErr).ToNot(HaveOccurred())
+		})
+	})
+
+	Context("when the webhook responds with an error", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(ghttp.RespondWith(http.StatusInternalServerError, nil))
+		})
+
+		It("returns an error", func() {
+			Expect(runErr).To(MatchError(ContainSubstring("unexpected response status")))
+		})
+	})
+
+	Context("when getting the pending builds fails", func() {
+		BeforeEach(func() {
+			fakeRepository.PendingJobBuildsReturns(nil, errors.New("nope"))
+		})
+
+		It("does not send anything", func() {
+			Expect(runErr).To(MatchError("nope"))
+			Expect(server.ReceivedRequests()).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,164 @@
+// Package autoscaling works out the demand for workers from the builds
+// waiting to start, for external autoscalers to scale worker fleets on.
+package autoscaling
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+// Current returns the signal for the builds and workers as they are now.
+func Current(repository db.AutoscalingRepository, workerFactory db.WorkerFactory, now time.Time) (atc.AutoscalingSignal, error) {
+	jobs, err := repository.PendingJobBuilds()
+	if err != nil {
+		return atc.AutoscalingSignal{}, err
+	}
+
+	workers, err := workerFactory.Workers()
+	if err != nil {
+		return atc.AutoscalingSignal{}, err
+	}
+
+	return Signal(jobs, workers, now), nil
+}
+
+// Signal groups the containers needed by the pending builds of the jobs by
+// the platform and tags of their steps, and counts the running workers which
+// can run the steps of each group.
+func Signal(jobs []db.PendingJobBuilds, workers []db.Worker, now time.Time) atc.AutoscalingSignal {
+	groups := map[string]*atc.AutoscalingGroup{}
+	group := func(platform string, tags []string) *atc.AutoscalingGroup {
+		tags = append([]string(nil), tags...)
+		sort.Strings(tags)
+
+		key := platform + "\x00" + strings.Join(tags, "\x00")
+		if groups[key] == nil {
+			groups[key] = &atc.AutoscalingGroup{
+				Platform: platform,
+				Tags:     tags,
+			}
+		}
+
+		return groups[key]
+	}
+
+	for _, job := range jobs {
+		ready := job.Pending
+		if job.MaxInFlight > 0 && job.MaxInFlight-job.Running < ready {
+			ready = job.MaxInFlight - job.Running
+		}
+
+		if ready <= 0 {
+			continue
+		}
+
+		containers := map[*atc.AutoscalingGroup]int{}
+		need := func(platform string, tags []string) {
+			containers[group(platform, tags)]++
+		}
+
+		_ = job.Config.StepConfig().Visit(atc.StepRecursor{
+			OnGet: func(step *atc.GetStep) error {
+				need("", step.Tags)
+				return nil
+			},
+			OnPut: func(step *atc.PutStep) error {
+				need("", step.Tags)
+				return nil
+			},
+			OnTask: func(step *atc.TaskStep) error {
+				// the platform of a task whose config is in a file is only
+				// known once the file has been fetched
+				var platform string
+				if step.Config != nil {
+					platform = step.Config.Platform
+				}
+
+				need(platform, step.Tags)
+				return nil
+			},
+			OnRun: func(step *atc.RunStep) error {
+				need("", step.Tags)
+				return nil
+			},
+		})
+
+		waiting := int64(now.Sub(job.OldestPending).Seconds())
+		for g, count := range containers {
+			g.PendingBuilds += ready
+			g.ProjectedContainers += ready * count
+
+			if waiting > g.OldestPendingSeconds {
+				g.OldestPendingSeconds = waiting
+			}
+		}
+	}
+
+	// groups without demand are reported too, so that autoscalers can scale
+	// the workers in them down
+	for _, worker := range workers {
+		if worker.State() == db.WorkerStateRunning {
+			group(worker.Platform(), worker.Tags())
+		}
+	}
+
+	signal := atc.AutoscalingSignal{
+		Time:   now.Unix(),
+		Groups: []atc.AutoscalingGroup{},
+	}
+
+	for _, g := range groups {
+		for _, worker := range workers {
+			if worker.State() == db.WorkerStateRunning && canRun(worker, g.Platform, g.Tags) {
+				g.Workers++
+				g.ActiveContainers += worker.ActiveContainers()
+			}
+		}
+
+		signal.Groups = append(signal.Groups, *g)
+	}
+
+	sort.Slice(signal.Groups, func(i, j int) bool {
+		a, b := signal.Groups[i], signal.Groups[j]
+		if a.Platform != b.Platform {
+			return a.Platform < b.Platform
+		}
+
+		return strings.Join(a.Tags, ",") < strings.Join(b.Tags, ",")
+	})
+
+	return signal
+}
+
+// canRun matches workers the way steps are placed on them: steps without tags
+// only run on workers without tags, and steps with tags only run on workers
+// with all of them.
+func canRun(worker db.Worker, platform string, tags []string) bool {
+	if platform != "" && worker.Platform() != platform {
+		return false
+	}
+
+	if len(tags) == 0 {
+		return len(worker.Tags()) == 0
+	}
+
+	for _, tag := range tags {
+		found := false
+		for _, workerTag := range worker.Tags() {
+			if workerTag == tag {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
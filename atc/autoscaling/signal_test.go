@@ -0,0 +1,122 @@
+package autoscaling_test
+
+import (
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/autoscaling"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Signal", func() {
+	var (
+		now     time.Time
+		jobs    []db.PendingJobBuilds
+		workers []db.Worker
+
+		signal atc.AutoscalingSignal
+	)
+
+	newWorker := func(platform string, tags []string, state db.WorkerState, containers int) db.Worker {
+		worker := new(dbfakes.FakeWorker)
+		worker.PlatformReturns(platform)
+		worker.TagsReturns(tags)
+		worker.StateReturns(state)
+		worker.ActiveContainersReturns(containers)
+		return worker
+	}
+
+	BeforeEach(func() {
+		now = time.Unix(1000, 0)
+
+		jobs = []db.PendingJobBuilds{
+			{
+				JobID: 1,
+				Config: atc.JobConfig{
+					Name: "some-job",
+					PlanSequence: []atc.Step{
+						{Config: &atc.GetStep{Name: "some-input"}},
+						{Config: &atc.TaskStep{Name: "unit", Config: &atc.TaskConfig{Platform: "linux"}}},
+						{Config: &atc.TaskStep{Name: "gpu", Tags: atc.Tags{"gpu"}, ConfigPath: "some/task.yml"}},
+					},
+				},
+				Pending:       2,
+				OldestPending: now.Add(-time.Minute),
+			},
+		}
+
+		workers = []db.Worker{
+			newWorker("linux", nil, db.WorkerStateRunning, 5),
+			newWorker("linux", []string{"gpu", "big"}, db.WorkerStateRunning, 3),
+			newWorker("linux", nil, db.WorkerStateStalled, 7),
+		}
+	})
+
+	JustBeforeEach(func() {
+		signal = autoscaling.Signal(jobs, workers, now)
+	})
+
+	It("groups the containers the pending builds need by platform and tags", func() {
+		Expect(signal.Time).To(Equal(int64(1000)))
+		Expect(signal.Groups).To(Equal([]atc.AutoscalingGroup{
+			{
+				PendingBuilds:        2,
+				ProjectedContainers:  2,
+				OldestPendingSeconds: 60,
+				Workers:              1,
+				ActiveContainers:     5,
+			},
+			{
+				Tags:                 []string{"gpu"},
+				PendingBuilds:        2,
+				ProjectedContainers:  2,
+				OldestPendingSeconds: 60,
+				Workers:              1,
+				ActiveContainers:     3,
+			},
+			{
+				Platform:             "linux",
+				PendingBuilds:        2,
+				ProjectedContainers:  2,
+				OldestPendingSeconds: 60,
+				Workers:              1,
+				ActiveContainers:     5,
+			},
+			{
+				Platform:         "linux",
+				Tags:             []string{"big", "gpu"},
+				Workers:          1,
+				ActiveContainers: 3,
+			},
+		}))
+	})
+
+	Context("when the job's builds are held back by its max in flight", func() {
+		BeforeEach(func() {
+			jobs[0].MaxInFlight = 2
+			jobs[0].Running = 2
+		})
+
+		It("does not count them", func() {
+			for _, group := range signal.Groups {
+				Expect(group.PendingBuilds).To(BeZero())
+				Expect(group.ProjectedContainers).To(BeZero())
+			}
+		})
+	})
+
+	Context("when there are no pending builds or workers", func() {
+		BeforeEach(func() {
+			jobs = nil
+			workers = nil
+		})
+
+		It("returns no groups", func() {
+			Expect(signal.Groups).ToNot(BeNil())
+			Expect(signal.Groups).To(BeEmpty())
+		})
+	})
+})
@@ -1,5 +1,10 @@
 package atc
 
+import (
+	"fmt"
+	"net/url"
+)
+
 type BuildStatus string
 
 const (
@@ -15,6 +20,18 @@ func (status BuildStatus) String() string {
 	return string(status)
 }
 
+// FailureReason categorizes why a build didn't succeed.
+type FailureReason string
+
+const (
+	FailureReasonTaskFailed          FailureReason = "task-failed"
+	FailureReasonTimedOut            FailureReason = "timed-out"
+	FailureReasonWorkerLost          FailureReason = "worker-lost"
+	FailureReasonImageFetchFailed    FailureReason = "image-fetch-failed"
+	FailureReasonResourceCheckFailed FailureReason = "resource-check-failed"
+	FailureReasonAborted             FailureReason = "aborted"
+)
+
 type Build struct {
 	ID                   int           `json:"id"`
 	TeamName             string        `json:"team_name"`
@@ -33,6 +50,56 @@ type Build struct {
 	RerunNumber          int           `json:"rerun_number,omitempty"`
 	RerunOf              *RerunOfBuild `json:"rerun_of,omitempty"`
 	CreatedBy            *string       `json:"created_by,omitempty"`
+	Vars                 []BuildVar    `json:"vars,omitempty"`
+	CollapsedInto        int           `json:"collapsed_into,omitempty"`
+	RetryOf              int           `json:"retry_of,omitempty"`
+	FailureReason        FailureReason `json:"failure_reason,omitempty"`
+	ExternalRef          string        `json:"external_ref,omitempty"`
+
+	StartLatency *BuildStartLatency `json:"start_latency,omitempty"`
+
+	// EstimatedDuration is how long the builds of the job are expected to
+	// take in seconds. For a running build, EstimatedEndTime is when it is
+	// expected to finish and Progress how much of the estimate has elapsed,
+	// from 0 to 1.
+	EstimatedDuration int64   `json:"estimated_duration,omitempty"`
+	EstimatedEndTime  int64   `json:"estimated_end_time,omitempty"`
+	Progress          float64 `json:"progress,omitempty"`
+}
+
+// StartLatencyPhase is one of the phases between a build being created and
+// its first task process starting.
+type StartLatencyPhase string
+
+const (
+	StartLatencyScheduling        StartLatencyPhase = "scheduling"
+	StartLatencyInputFetch        StartLatencyPhase = "input_fetch"
+	StartLatencyImageFetch        StartLatencyPhase = "image_fetch"
+	StartLatencyContainerCreation StartLatencyPhase = "container_creation"
+	StartLatencyVolumeStreaming   StartLatencyPhase = "volume_streaming"
+)
+
+// BuildStartLatency is how long each phase between a build being created and
+// its first task process starting took, in milliseconds. The phases of steps
+// running in parallel overlap, so they can add up to more than Total.
+type BuildStartLatency struct {
+	Scheduling        int64 `json:"scheduling"`
+	InputFetch        int64 `json:"input_fetch"`
+	ImageFetch        int64 `json:"image_fetch"`
+	ContainerCreation int64 `json:"container_creation"`
+	VolumeStreaming   int64 `json:"volume_streaming"`
+	Total             int64 `json:"total"`
+}
+
+// Phases returns how long each phase took.
+func (latency BuildStartLatency) Phases() map[StartLatencyPhase]int64 {
+	return map[StartLatencyPhase]int64{
+		StartLatencyScheduling:        latency.Scheduling,
+		StartLatencyInputFetch:        latency.InputFetch,
+		StartLatencyImageFetch:        latency.ImageFetch,
+		StartLatencyContainerCreation: latency.ContainerCreation,
+		StartLatencyVolumeStreaming:   latency.VolumeStreaming,
+	}
 }
 
 type RerunOfBuild struct {
@@ -72,7 +139,26 @@ type BuildPreparation struct {
 	PausedPipeline      BuildPreparationStatus            `json:"paused_pipeline"`
 	PausedJob           BuildPreparationStatus            `json:"paused_job"`
 	MaxRunningBuilds    BuildPreparationStatus            `json:"max_running_builds"`
+	WorkerCapacity      BuildPreparationStatus            `json:"worker_capacity"`
 	Inputs              map[string]BuildPreparationStatus `json:"inputs"`
 	InputsSatisfied     BuildPreparationStatus            `json:"inputs_satisfied"`
 	MissingInputReasons MissingInputReasons               `json:"missing_input_reasons"`
 }
+
+// JobBuildURL returns the URL of a job's build in the web UI.
+func JobBuildURL(externalURL string, teamName string, pipelineRef PipelineRef, jobName string, buildName string) string {
+	buildURL := fmt.Sprintf(
+		"%s/teams/%s/pipelines/%s/jobs/%s/builds/%s",
+		externalURL,
+		url.PathEscape(teamName),
+		url.PathEscape(pipelineRef.Name),
+		url.PathEscape(jobName),
+		url.PathEscape(buildName),
+	)
+
+	if query := pipelineRef.QueryParams(); len(query) > 0 {
+		buildURL += "?" + query.Encode()
+	}
+
+	return buildURL
+}
@@ -0,0 +1,32 @@
+package atc
+
+// QueuedBuild is a build of a job which has yet to finish, with when it is
+// expected to start and finish. Times are unix timestamps and durations are
+// in seconds. Estimates are left out when they can't be made, e.g. for a job
+// without any recent builds, or one which is paused.
+type QueuedBuild struct {
+	ID                   int          `json:"id"`
+	Name                 string       `json:"name"`
+	Status               BuildStatus  `json:"status"`
+	TeamName             string       `json:"team_name"`
+	PipelineID           int          `json:"pipeline_id"`
+	PipelineName         string       `json:"pipeline_name"`
+	PipelineInstanceVars InstanceVars `json:"pipeline_instance_vars,omitempty"`
+	JobName              string       `json:"job_name"`
+
+	// MaxInFlight is how many builds of the job may run at once, or zero if
+	// there is no limit.
+	MaxInFlight int  `json:"max_in_flight,omitempty"`
+	Paused      bool `json:"paused,omitempty"`
+
+	CreateTime int64 `json:"create_time"`
+	StartTime  int64 `json:"start_time,omitempty"`
+
+	// Position is how many pending builds of the job are ahead of this one,
+	// plus one. It is zero for builds which have started.
+	Position int `json:"position,omitempty"`
+
+	EstimatedDuration  int64 `json:"estimated_duration,omitempty"`
+	EstimatedStartTime int64 `json:"estimated_start_time,omitempty"`
+	EstimatedEndTime   int64 `json:"estimated_end_time,omitempty"`
+}
@@ -0,0 +1,35 @@
+package atc
+
+type BuildStepState string
+
+const (
+	BuildStepStatePending   BuildStepState = "pending"
+	BuildStepStateRunning   BuildStepState = "running"
+	BuildStepStateSucceeded BuildStepState = "succeeded"
+	BuildStepStateFailed    BuildStepState = "failed"
+	BuildStepStateErrored   BuildStepState = "errored"
+	BuildStepStateAborted   BuildStepState = "aborted"
+	BuildStepStateSkipped   BuildStepState = "skipped"
+)
+
+// BuildSteps is the plan of a build as a tree of steps, each with the state
+// it's in according to the build's events.
+type BuildSteps struct {
+	BuildID int         `json:"build_id"`
+	Status  BuildStatus `json:"status"`
+	Plan    BuildStep   `json:"plan"`
+}
+
+type BuildStep struct {
+	// ID is the ID of the step in the build's plan, as used for the origin
+	// of the step's events.
+	ID   PlanID `json:"id"`
+	Type string `json:"type"`
+	Name string `json:"name,omitempty"`
+
+	State     BuildStepState `json:"state"`
+	StartTime int64          `json:"start_time,omitempty"`
+	EndTime   int64          `json:"end_time,omitempty"`
+
+	Steps []BuildStep `json:"steps,omitempty"`
+}
@@ -87,4 +87,26 @@ var _ = Describe("Build", func() {
 			}
 		})
 	})
+
+	Describe("JobBuildURL", func() {
+		It("returns the URL of the build in the web UI", func() {
+			Expect(atc.JobBuildURL(
+				"https://ci.example.com",
+				"some-team",
+				atc.PipelineRef{Name: "some-pipeline"},
+				"some job",
+				"7",
+			)).To(Equal("https://ci.example.com/teams/some-team/pipelines/some-pipeline/jobs/some%20job/builds/7"))
+		})
+
+		It("includes the pipeline's instance vars", func() {
+			Expect(atc.JobBuildURL(
+				"https://ci.example.com",
+				"some-team",
+				atc.PipelineRef{Name: "some-pipeline", InstanceVars: atc.InstanceVars{"branch": "main"}},
+				"some-job",
+				"7",
+			)).To(Equal(`https://ci.example.com/teams/some-team/pipelines/some-pipeline/jobs/some-job/builds/7?vars.branch=%22main%22`))
+		})
+	})
 })
@@ -166,6 +166,7 @@ func (visitor *planVisitor) VisitPut(step *atc.PutStep) error {
 		Timeout:  step.Timeout,
 
 		ExposeBuildCreatedBy: resource.ExposeBuildCreatedBy,
+		SerializePuts:        resource.SerializePuts,
 	})
 
 	plan.Put.TypeImage = visitor.resourceTypes.ImageForType(plan.ID, resource.Type, step.Tags, visitor.manuallyTriggered)
@@ -307,6 +308,20 @@ func (visitor *planVisitor) VisitTimeout(step *atc.TimeoutStep) error {
 	return nil
 }
 
+func (visitor *planVisitor) VisitWhen(step *atc.WhenStep) error {
+	err := step.Step.Visit(visitor)
+	if err != nil {
+		return err
+	}
+
+	visitor.plan = visitor.planFactory.NewPlan(atc.WhenPlan{
+		Condition: step.Condition,
+		Step:      visitor.plan,
+	})
+
+	return nil
+}
+
 func (visitor *planVisitor) VisitRetry(step *atc.RetryStep) error {
 	retryStep := make(atc.RetryPlan, step.Attempts)
 
@@ -1576,6 +1576,31 @@ var factoryTests = []PlannerTest{
 			}
 		}`,
 	},
+	{
+		Title: "when modifier",
+
+		Config: &atc.WhenStep{
+			Step: &atc.LoadVarStep{
+				Name: "some-var",
+				File: "some-file",
+			},
+			Condition: "((.:flag))",
+		},
+
+		PlanJSON: `{
+			"id": "(unique)",
+			"when": {
+				"step": {
+					"id": "(unique)",
+					"load_var": {
+						"name": "some-var",
+						"file": "some-file"
+					}
+				},
+				"condition": "((.:flag))"
+			}
+		}`,
+	},
 	{
 		Title: "attempts modifier",
 
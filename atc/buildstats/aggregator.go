@@ -0,0 +1,71 @@
+// Package buildstats rolls up the builds of jobs into hourly statistics and
+// summarizes them, e.g. to track the SLOs of pipelines.
+package buildstats
+
+import (
+	"context"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc/db"
+)
+
+// Aggregator keeps the rollups of builds up to date, and deletes the ones
+// which are older than the retention. A retention of zero keeps them
+// forever.
+type Aggregator struct {
+	repository db.BuildStatsRepository
+	retention  time.Duration
+	clock      func() time.Time
+}
+
+func NewAggregator(repository db.BuildStatsRepository, retention time.Duration) *Aggregator {
+	return &Aggregator{
+		repository: repository,
+		retention:  retention,
+		clock:      time.Now,
+	}
+}
+
+// Run rolls up the builds which finished since the latest rollup, which is
+// computed again as its hour may not have been over yet. The first time, the
+// builds of the whole retention are rolled up.
+func (aggregator *Aggregator) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx).Session("build-stats-aggregator")
+
+	logger.Debug("start")
+	defer logger.Debug("done")
+
+	now := aggregator.clock()
+
+	var since time.Time
+	if aggregator.retention != 0 {
+		since = now.Add(-aggregator.retention)
+	}
+
+	latest, found, err := aggregator.repository.LatestBuildStatsBucket()
+	if err != nil {
+		logger.Error("failed-to-get-latest-bucket", err)
+		return err
+	}
+
+	if found && latest.After(since) {
+		since = latest
+	}
+
+	err = aggregator.repository.AggregateBuildStats(since)
+	if err != nil {
+		logger.Error("failed-to-aggregate-build-stats", err)
+		return err
+	}
+
+	if aggregator.retention != 0 {
+		err = aggregator.repository.DeleteBuildStatsBefore(now.Add(-aggregator.retention))
+		if err != nil {
+			logger.Error("failed-to-delete-old-build-stats", err)
+			return err
+		}
+	}
+
+	return nil
+}
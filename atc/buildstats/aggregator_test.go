@@ -0,0 +1,85 @@
+package buildstats_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/concourse/concourse/atc/buildstats"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Aggregator", func() {
+	var (
+		fakeRepository *dbfakes.FakeBuildStatsRepository
+		retention      time.Duration
+
+		runErr error
+	)
+
+	BeforeEach(func() {
+		fakeRepository = new(dbfakes.FakeBuildStatsRepository)
+		retention = 24 * time.Hour
+	})
+
+	JustBeforeEach(func() {
+		runErr = buildstats.NewAggregator(fakeRepository, retention).Run(context.TODO())
+	})
+
+	Context("when nothing has been rolled up yet", func() {
+		It("rolls up the builds of the whole retention", func() {
+			Expect(runErr).ToNot(HaveOccurred())
+			Expect(fakeRepository.AggregateBuildStatsCallCount()).To(Equal(1))
+
+			since := fakeRepository.AggregateBuildStatsArgsForCall(0)
+			Expect(since).To(BeTemporally("~", time.Now().Add(-retention), time.Minute))
+		})
+
+		It("deletes the rollups older than the retention", func() {
+			Expect(fakeRepository.DeleteBuildStatsBeforeCallCount()).To(Equal(1))
+
+			before := fakeRepository.DeleteBuildStatsBeforeArgsForCall(0)
+			Expect(before).To(BeTemporally("~", time.Now().Add(-retention), time.Minute))
+		})
+	})
+
+	Context("when builds have been rolled up before", func() {
+		var latest time.Time
+
+		BeforeEach(func() {
+			latest = time.Now().Add(-time.Hour).Truncate(time.Hour)
+			fakeRepository.LatestBuildStatsBucketReturns(latest, true, nil)
+		})
+
+		It("rolls up the builds since the latest rollup", func() {
+			Expect(fakeRepository.AggregateBuildStatsArgsForCall(0)).To(Equal(latest))
+		})
+	})
+
+	Context("when the retention is zero", func() {
+		BeforeEach(func() {
+			retention = 0
+		})
+
+		It("rolls up all of the builds", func() {
+			Expect(fakeRepository.AggregateBuildStatsArgsForCall(0)).To(BeZero())
+		})
+
+		It("does not delete any rollups", func() {
+			Expect(fakeRepository.DeleteBuildStatsBeforeCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when rolling up the builds fails", func() {
+		BeforeEach(func() {
+			fakeRepository.AggregateBuildStatsReturns(errors.New("nope"))
+		})
+
+		It("returns the error without deleting any rollups", func() {
+			Expect(runErr).To(MatchError("nope"))
+			Expect(fakeRepository.DeleteBuildStatsBeforeCallCount()).To(BeZero())
+		})
+	})
+})
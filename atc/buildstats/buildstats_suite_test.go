@@ -0,0 +1,13 @@
+package buildstats_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestBuildStats(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Build Stats Suite")
+}
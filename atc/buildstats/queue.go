@@ -0,0 +1,159 @@
+package buildstats
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+// EstimateQueue works out when each of the queued builds is expected to
+// start and finish, taking the median duration of the builds of its job in
+// the rollups as how long it takes.
+//
+// The pending builds of a job are expected to start in order, as soon as
+// enough of the builds ahead of them are expected to have finished for the
+// job to be below its max in flight. Pending builds of jobs without a limit
+// are expected to start right away. Builds which overran their expected
+// duration are expected to finish any moment now. Other limits, such as the
+// room left on workers or serial groups shared between jobs, aren't taken
+// into account.
+func EstimateQueue(now time.Time, builds []db.QueuedBuild, buckets []db.BuildStatsBucket) []atc.QueuedBuild {
+	durations := map[int][]int64{}
+	for _, bucket := range buckets {
+		durations[bucket.JobID] = append(durations[bucket.JobID], bucket.Durations...)
+	}
+
+	expectedDuration := func(jobID int) (time.Duration, bool) {
+		if len(durations[jobID]) == 0 {
+			return 0, false
+		}
+
+		median := percentiles(durations[jobID]).P50
+		return time.Duration(math.Round(median)) * time.Second, true
+	}
+
+	queues := map[int]*jobQueue{}
+	queueOf := func(jobID int) *jobQueue {
+		queue, found := queues[jobID]
+		if !found {
+			queue = &jobQueue{}
+			queues[jobID] = queue
+		}
+
+		return queue
+	}
+
+	estimated := make([]atc.QueuedBuild, len(builds))
+	for i, build := range builds {
+		estimated[i] = atc.QueuedBuild{
+			ID:                   build.ID,
+			Name:                 build.Name,
+			Status:               atc.BuildStatus(build.Status),
+			TeamName:             build.TeamName,
+			PipelineID:           build.PipelineID,
+			PipelineName:         build.PipelineName,
+			PipelineInstanceVars: build.PipelineInstanceVars,
+			JobName:              build.JobName,
+			MaxInFlight:          build.MaxInFlight,
+			Paused:               build.Paused,
+			CreateTime:           build.CreateTime.Unix(),
+		}
+
+		duration, known := expectedDuration(build.JobID)
+		if known {
+			estimated[i].EstimatedDuration = int64(duration / time.Second)
+		}
+
+		// every pending build waits on the started builds of its job, so
+		// those are gone through first
+		if build.StartTime.IsZero() {
+			continue
+		}
+
+		estimated[i].StartTime = build.StartTime.Unix()
+
+		var end time.Time
+		if known {
+			end = build.StartTime.Add(duration)
+			if end.Before(now) {
+				end = now
+			}
+
+			estimated[i].EstimatedEndTime = end.Unix()
+		}
+
+		queue := queueOf(build.JobID)
+		queue.ends = append(queue.ends, end)
+	}
+
+	for i, build := range builds {
+		if !build.StartTime.IsZero() {
+			continue
+		}
+
+		queue := queueOf(build.JobID)
+		queue.pending++
+		estimated[i].Position = queue.pending
+
+		if build.Paused || queue.unknown {
+			continue
+		}
+
+		start, known := queue.nextStart(now, build.MaxInFlight)
+		if !known {
+			// neither this build nor the ones behind it can be estimated
+			queue.unknown = true
+			continue
+		}
+
+		estimated[i].EstimatedStartTime = start.Unix()
+
+		var end time.Time
+		if duration, known := expectedDuration(build.JobID); known {
+			end = start.Add(duration)
+			estimated[i].EstimatedEndTime = end.Unix()
+		}
+
+		queue.ends = append(queue.ends, end)
+	}
+
+	return estimated
+}
+
+// jobQueue tracks when the builds of a job which are expected to be running
+// are expected to finish, with a zero time for the ones which can't be told.
+type jobQueue struct {
+	ends    []time.Time
+	pending int
+	unknown bool
+}
+
+// nextStart returns when the next pending build is expected to start, and
+// forgets the builds expected to have finished by then.
+func (queue *jobQueue) nextStart(now time.Time, maxInFlight int) (time.Time, bool) {
+	if maxInFlight == 0 || len(queue.ends) < maxInFlight {
+		return now, true
+	}
+
+	sort.Slice(queue.ends, func(i, j int) bool {
+		if queue.ends[i].IsZero() || queue.ends[j].IsZero() {
+			return !queue.ends[i].IsZero()
+		}
+
+		return queue.ends[i].Before(queue.ends[j])
+	})
+
+	finishing := len(queue.ends) - maxInFlight
+
+	start := queue.ends[finishing]
+	if start.IsZero() {
+		return time.Time{}, false
+	}
+
+	queue.ends = queue.ends[finishing+1:]
+
+	return start, true
+}
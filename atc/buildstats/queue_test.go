@@ -0,0 +1,162 @@
+package buildstats_test
+
+import (
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/buildstats"
+	"github.com/concourse/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("EstimateQueue", func() {
+	var (
+		now     time.Time
+		builds  []db.QueuedBuild
+		buckets []db.BuildStatsBucket
+
+		queue []atc.QueuedBuild
+	)
+
+	started := func(id int, startedAgo time.Duration) db.QueuedBuild {
+		return db.QueuedBuild{
+			ID:          id,
+			Status:      db.BuildStatusStarted,
+			JobID:       1,
+			JobName:     "some-job",
+			MaxInFlight: 1,
+			CreateTime:  now.Add(-time.Hour),
+			StartTime:   now.Add(-startedAgo),
+		}
+	}
+
+	pending := func(id int) db.QueuedBuild {
+		return db.QueuedBuild{
+			ID:          id,
+			Status:      db.BuildStatusPending,
+			JobID:       1,
+			JobName:     "some-job",
+			MaxInFlight: 1,
+			CreateTime:  now.Add(-time.Minute),
+		}
+	}
+
+	BeforeEach(func() {
+		now = time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+
+		buckets = []db.BuildStatsBucket{
+			{JobID: 1, Durations: []int64{500, 600, 700}},
+			{JobID: 2, Durations: []int64{60}},
+		}
+	})
+
+	JustBeforeEach(func() {
+		queue = buildstats.EstimateQueue(now, builds, buckets)
+	})
+
+	Context("when a serial job has a build running and more pending", func() {
+		BeforeEach(func() {
+			builds = []db.QueuedBuild{started(1, 100*time.Second), pending(2), pending(3)}
+		})
+
+		It("expects the pending builds to start one after the other", func() {
+			Expect(queue).To(HaveLen(3))
+
+			Expect(queue[0].StartTime).To(Equal(now.Add(-100 * time.Second).Unix()))
+			Expect(queue[0].EstimatedDuration).To(Equal(int64(600)))
+			Expect(queue[0].EstimatedEndTime).To(Equal(now.Add(500 * time.Second).Unix()))
+			Expect(queue[0].Position).To(BeZero())
+
+			Expect(queue[1].Position).To(Equal(1))
+			Expect(queue[1].EstimatedStartTime).To(Equal(now.Add(500 * time.Second).Unix()))
+			Expect(queue[1].EstimatedEndTime).To(Equal(now.Add(1100 * time.Second).Unix()))
+
+			Expect(queue[2].Position).To(Equal(2))
+			Expect(queue[2].EstimatedStartTime).To(Equal(now.Add(1100 * time.Second).Unix()))
+		})
+	})
+
+	Context("when the running build overran its expected duration", func() {
+		BeforeEach(func() {
+			builds = []db.QueuedBuild{started(1, time.Hour), pending(2)}
+		})
+
+		It("expects it to finish any moment now", func() {
+			Expect(queue[0].EstimatedEndTime).To(Equal(now.Unix()))
+			Expect(queue[1].EstimatedStartTime).To(Equal(now.Unix()))
+		})
+	})
+
+	Context("when the job may run more builds at once", func() {
+		BeforeEach(func() {
+			builds = []db.QueuedBuild{started(1, 100*time.Second), started(2, 0), pending(3), pending(4)}
+			for i := range builds {
+				builds[i].MaxInFlight = 2
+			}
+		})
+
+		It("expects the pending builds to start as the earliest running ones finish", func() {
+			Expect(queue[2].EstimatedStartTime).To(Equal(now.Add(500 * time.Second).Unix()))
+			Expect(queue[3].EstimatedStartTime).To(Equal(now.Add(600 * time.Second).Unix()))
+		})
+	})
+
+	Context("when the job has no limit", func() {
+		BeforeEach(func() {
+			builds = []db.QueuedBuild{started(1, 0), pending(2)}
+			for i := range builds {
+				builds[i].MaxInFlight = 0
+			}
+		})
+
+		It("expects the pending builds to start right away", func() {
+			Expect(queue[1].EstimatedStartTime).To(Equal(now.Unix()))
+		})
+	})
+
+	Context("when the job has no recent builds", func() {
+		BeforeEach(func() {
+			buckets = nil
+			builds = []db.QueuedBuild{started(1, 0), pending(2), pending(3)}
+		})
+
+		It("leaves out the estimates which depend on its duration", func() {
+			Expect(queue[0].EstimatedDuration).To(BeZero())
+			Expect(queue[0].EstimatedEndTime).To(BeZero())
+			Expect(queue[1].EstimatedStartTime).To(BeZero())
+			Expect(queue[2].EstimatedStartTime).To(BeZero())
+			Expect(queue[2].Position).To(Equal(2))
+		})
+	})
+
+	Context("when the job is paused", func() {
+		BeforeEach(func() {
+			builds = []db.QueuedBuild{pending(1)}
+			builds[0].Paused = true
+		})
+
+		It("doesn't expect its builds to start", func() {
+			Expect(queue[0].Paused).To(BeTrue())
+			Expect(queue[0].Position).To(Equal(1))
+			Expect(queue[0].EstimatedStartTime).To(BeZero())
+			Expect(queue[0].EstimatedDuration).To(Equal(int64(600)))
+		})
+	})
+
+	Context("when builds of different jobs are queued", func() {
+		BeforeEach(func() {
+			other := pending(2)
+			other.JobID = 2
+			other.JobName = "other-job"
+
+			builds = []db.QueuedBuild{started(1, 0), other}
+		})
+
+		It("queues them separately", func() {
+			Expect(queue[1].Position).To(Equal(1))
+			Expect(queue[1].EstimatedStartTime).To(Equal(now.Unix()))
+			Expect(queue[1].EstimatedEndTime).To(Equal(now.Add(time.Minute).Unix()))
+		})
+	})
+})
@@ -0,0 +1,156 @@
+package buildstats
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+// Summarize computes the statistics of a job from its rollups since the
+// start of a window.
+//
+// If interval is non-zero, the window is split into intervals of that length
+// from its start, and each one which has any builds is summarized in the
+// trend. If maxDuration is non-zero, the ratio of builds which finished
+// within it is computed.
+func Summarize(
+	jobName string,
+	buckets []db.BuildStatsBucket,
+	since time.Time,
+	interval time.Duration,
+	maxDuration time.Duration,
+) atc.JobStatistics {
+	var total tally
+
+	var trend []tally
+	for _, bucket := range buckets {
+		total.add(bucket)
+
+		if interval == 0 {
+			continue
+		}
+
+		start := since
+		if bucket.Start.After(since) {
+			start = since.Add(bucket.Start.Sub(since).Truncate(interval))
+		}
+
+		if len(trend) == 0 || !trend[len(trend)-1].start.Equal(start) {
+			trend = append(trend, tally{start: start})
+		}
+
+		trend[len(trend)-1].add(bucket)
+	}
+
+	statistics := atc.JobStatistics{
+		JobName: jobName,
+		Since:   since.Unix(),
+
+		Builds:    total.builds(),
+		Succeeded: total.succeeded,
+		Failed:    total.failed,
+		Errored:   total.errored,
+		Aborted:   total.aborted,
+
+		SuccessRate: total.successRate(),
+		Duration:    percentiles(total.durations),
+		QueueTime:   percentiles(total.queueTimes),
+	}
+
+	if maxDuration != 0 {
+		statistics.DurationObjective = &atc.DurationObjective{
+			MaxDuration: int64(maxDuration / time.Second),
+			Attained:    attained(total.durations, maxDuration),
+		}
+	}
+
+	for _, t := range trend {
+		statistics.Trend = append(statistics.Trend, atc.JobStatisticsInterval{
+			Start:       t.start.Unix(),
+			Builds:      t.builds(),
+			SuccessRate: t.successRate(),
+			Duration:    percentiles(t.durations),
+			QueueTime:   percentiles(t.queueTimes),
+		})
+	}
+
+	return statistics
+}
+
+type tally struct {
+	start time.Time
+
+	succeeded int
+	failed    int
+	errored   int
+	aborted   int
+
+	durations  []int64
+	queueTimes []int64
+}
+
+func (t *tally) add(bucket db.BuildStatsBucket) {
+	t.succeeded += bucket.Succeeded
+	t.failed += bucket.Failed
+	t.errored += bucket.Errored
+	t.aborted += bucket.Aborted
+	t.durations = append(t.durations, bucket.Durations...)
+	t.queueTimes = append(t.queueTimes, bucket.QueueTimes...)
+}
+
+func (t tally) builds() int {
+	return t.succeeded + t.failed + t.errored + t.aborted
+}
+
+func (t tally) successRate() float64 {
+	finished := t.succeeded + t.failed + t.errored
+	if finished == 0 {
+		return 0
+	}
+
+	return float64(t.succeeded) / float64(finished)
+}
+
+func percentiles(values []int64) atc.StatisticsPercentiles {
+	if len(values) == 0 {
+		return atc.StatisticsPercentiles{}
+	}
+
+	sorted := make([]int64, len(values))
+	copy(sorted, values)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	return atc.StatisticsPercentiles{
+		P50: percentile(sorted, 0.5),
+		P90: percentile(sorted, 0.9),
+		P99: percentile(sorted, 0.99),
+	}
+}
+
+// percentile interpolates linearly between the closest ranks, the same as
+// postgres' percentile_cont.
+func percentile(sorted []int64, p float64) float64 {
+	rank := p * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+
+	return float64(sorted[lower]) + (rank-float64(lower))*float64(sorted[upper]-sorted[lower])
+}
+
+func attained(durations []int64, maxDuration time.Duration) float64 {
+	if len(durations) == 0 {
+		return 0
+	}
+
+	within := 0
+	for _, duration := range durations {
+		if time.Duration(duration)*time.Second <= maxDuration {
+			within++
+		}
+	}
+
+	return float64(within) / float64(len(durations))
+}
@@ -0,0 +1,123 @@
+package buildstats_test
+
+import (
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/buildstats"
+	"github.com/concourse/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Summarize", func() {
+	var (
+		since   time.Time
+		buckets []db.BuildStatsBucket
+
+		interval    time.Duration
+		maxDuration time.Duration
+
+		statistics atc.JobStatistics
+	)
+
+	BeforeEach(func() {
+		since = time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+
+		buckets = []db.BuildStatsBucket{
+			{
+				Start:      since,
+				Succeeded:  3,
+				Failed:     1,
+				Durations:  []int64{40, 10, 30, 20},
+				QueueTimes: []int64{1, 2, 3, 4},
+			},
+			{
+				Start:      since.Add(25 * time.Hour),
+				Succeeded:  1,
+				Errored:    1,
+				Aborted:    1,
+				Durations:  []int64{50},
+				QueueTimes: []int64{5, 6},
+			},
+		}
+
+		interval = 0
+		maxDuration = 0
+	})
+
+	JustBeforeEach(func() {
+		statistics = buildstats.Summarize("some-job", buckets, since, interval, maxDuration)
+	})
+
+	It("counts the builds", func() {
+		Expect(statistics.JobName).To(Equal("some-job"))
+		Expect(statistics.Since).To(Equal(since.Unix()))
+		Expect(statistics.Builds).To(Equal(7))
+		Expect(statistics.Succeeded).To(Equal(4))
+		Expect(statistics.Failed).To(Equal(1))
+		Expect(statistics.Errored).To(Equal(1))
+		Expect(statistics.Aborted).To(Equal(1))
+	})
+
+	It("leaves aborted builds out of the success rate", func() {
+		Expect(statistics.SuccessRate).To(BeNumerically("~", 4.0/6.0))
+	})
+
+	It("computes the percentiles of the durations and queue times", func() {
+		Expect(statistics.Duration.P50).To(Equal(30.0))
+		Expect(statistics.Duration.P90).To(BeNumerically("~", 46))
+		Expect(statistics.Duration.P99).To(BeNumerically("~", 49.6))
+		Expect(statistics.QueueTime.P50).To(Equal(3.5))
+	})
+
+	It("has no trend or duration objective", func() {
+		Expect(statistics.Trend).To(BeEmpty())
+		Expect(statistics.DurationObjective).To(BeNil())
+	})
+
+	Context("with an interval", func() {
+		BeforeEach(func() {
+			interval = 24 * time.Hour
+		})
+
+		It("summarizes each interval which has any builds", func() {
+			Expect(statistics.Trend).To(HaveLen(2))
+
+			Expect(statistics.Trend[0].Start).To(Equal(since.Unix()))
+			Expect(statistics.Trend[0].Builds).To(Equal(4))
+			Expect(statistics.Trend[0].SuccessRate).To(Equal(0.75))
+			Expect(statistics.Trend[0].Duration.P50).To(Equal(25.0))
+
+			Expect(statistics.Trend[1].Start).To(Equal(since.Add(24 * time.Hour).Unix()))
+			Expect(statistics.Trend[1].Builds).To(Equal(3))
+			Expect(statistics.Trend[1].SuccessRate).To(Equal(0.5))
+			Expect(statistics.Trend[1].QueueTime.P50).To(Equal(5.5))
+		})
+	})
+
+	Context("with a max duration", func() {
+		BeforeEach(func() {
+			maxDuration = 30 * time.Second
+		})
+
+		It("computes the ratio of builds which finished within it", func() {
+			Expect(statistics.DurationObjective).To(Equal(&atc.DurationObjective{
+				MaxDuration: 30,
+				Attained:    0.6,
+			}))
+		})
+	})
+
+	Context("when there are no builds", func() {
+		BeforeEach(func() {
+			buckets = nil
+		})
+
+		It("returns zero statistics", func() {
+			Expect(statistics.Builds).To(BeZero())
+			Expect(statistics.SuccessRate).To(BeZero())
+			Expect(statistics.Duration).To(BeZero())
+		})
+	})
+})
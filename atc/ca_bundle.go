@@ -0,0 +1,45 @@
+package atc
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+)
+
+// CABundle is a set of PEM-encoded CA certificates which operators register
+// for a team or for the cluster, so that containers trust e.g. registries and
+// credential managers with self-signed certificates.
+type CABundle struct {
+	Certificates string `json:"certificates"`
+}
+
+// Validate checks that the bundle only holds PEM-encoded certificates. An
+// empty bundle is valid and removes any registered certificates.
+func (bundle CABundle) Validate() error {
+	rest := []byte(bundle.Certificates)
+
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+
+		if block.Type != "CERTIFICATE" {
+			return fmt.Errorf("unexpected PEM block of type '%s'", block.Type)
+		}
+
+		_, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return fmt.Errorf("invalid certificate: %w", err)
+		}
+	}
+
+	if len(bytes.TrimSpace(rest)) != 0 {
+		return errors.New("bundle contains data which is not a PEM-encoded certificate")
+	}
+
+	return nil
+}
@@ -3,4 +3,9 @@ package atc
 const (
 	TeamCacheName    = "teams"
 	TeamCacheChannel = "team_cache"
+
+	AccessTokenRevocationChannel = "access_token_revocation"
+
+	SCIMGroupsCacheName = "scim_groups"
+	SCIMChannel         = "scim"
 )
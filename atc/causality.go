@@ -37,3 +37,35 @@ type Causality struct {
 	Resources        []CausalityResource        `json:"resources"`
 	ResourceVersions []CausalityResourceVersion `json:"resource_versions"`
 }
+
+// ImpactedBuild is a build downstream of a resource version: it either had
+// the version as an input, or had an input produced by another impacted
+// build.
+//
+// Inputs only include the versions which are part of the graph, i.e. the
+// original version and the outputs of impacted builds, so that edges can be
+// drawn from each output to the builds which took it as an input.
+type ImpactedBuild struct {
+	ID     int         `json:"id"`
+	Name   string      `json:"name"`
+	Status BuildStatus `json:"status"`
+
+	TeamName             string       `json:"team_name"`
+	PipelineID           int          `json:"pipeline_id"`
+	PipelineName         string       `json:"pipeline_name"`
+	PipelineInstanceVars InstanceVars `json:"pipeline_instance_vars,omitempty"`
+	JobID                int          `json:"job_id"`
+	JobName              string       `json:"job_name"`
+
+	Inputs  []ImpactedResourceVersion `json:"inputs"`
+	Outputs []ImpactedResourceVersion `json:"outputs"`
+}
+
+// ImpactedResourceVersion is a version of one of the resources of an
+// impacted build's pipeline.
+type ImpactedResourceVersion struct {
+	ID           int     `json:"id"`
+	ResourceID   int     `json:"resource_id"`
+	ResourceName string  `json:"resource_name"`
+	Version      Version `json:"version"`
+}
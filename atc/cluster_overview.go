@@ -0,0 +1,57 @@
+package atc
+
+// ClusterOverview is what the workers, teams and pipelines of the cluster
+// are using, for capacity planning.
+type ClusterOverview struct {
+	GeneratedAt int64 `json:"generated_at"`
+
+	Workers []WorkerUsage `json:"workers"`
+	Teams   []TeamUsage   `json:"teams"`
+
+	// TopPipelines are the pipelines which used the most build time over the
+	// last day, along with what they are using now.
+	TopPipelines []PipelineUsage `json:"top_pipelines"`
+}
+
+// WorkerUsage is what is on a worker.
+//
+// The sizes of volumes are not tracked, so the disk usage of a worker is
+// estimated by DiskVolumes: the volumes which hold data of their own, rather
+// than being copy-on-write volumes of another.
+type WorkerUsage struct {
+	Name     string `json:"name"`
+	Platform string `json:"platform"`
+	State    string `json:"state"`
+	Team     string `json:"team,omitempty"`
+
+	Containers    int `json:"containers"`
+	RunningBuilds int `json:"running_builds"`
+
+	Volumes       int            `json:"volumes"`
+	VolumesByType map[string]int `json:"volumes_by_type"`
+	DiskVolumes   int            `json:"disk_volumes"`
+}
+
+type TeamUsage struct {
+	Name string `json:"name"`
+
+	Pipelines     int `json:"pipelines"`
+	Containers    int `json:"containers"`
+	Volumes       int `json:"volumes"`
+	RunningBuilds int `json:"running_builds"`
+	PendingBuilds int `json:"pending_builds"`
+}
+
+type PipelineUsage struct {
+	ID           int          `json:"id"`
+	Name         string       `json:"name"`
+	InstanceVars InstanceVars `json:"instance_vars,omitempty"`
+	TeamName     string       `json:"team_name"`
+
+	// BuildSeconds is how long its builds ran for over the last day.
+	BuildSeconds int64 `json:"build_seconds"`
+
+	Containers    int `json:"containers"`
+	Volumes       int `json:"volumes"`
+	RunningBuilds int `json:"running_builds"`
+}
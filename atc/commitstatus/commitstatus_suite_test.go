@@ -0,0 +1,13 @@
+package commitstatus_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestCommitStatus(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Commit Status Suite")
+}
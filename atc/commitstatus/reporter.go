@@ -0,0 +1,317 @@
+// Package commitstatus reports the status of job builds to the commits of
+// their git inputs hosted on GitHub or GitLab, so that jobs do not need a
+// put step to a status resource.
+package commitstatus
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/vars"
+)
+
+// The vars holding the tokens used to report statuses, looked up in the
+// credential manager for the pipeline's team.
+const (
+	GitHubTokenVar = "github_status_token"
+	GitLabTokenVar = "gitlab_status_token"
+)
+
+const reportTimeout = time.Minute
+
+// Reporter reports the status of job builds whose inputs include git
+// resources hosted on GitHub or GitLab. Builds of pipelines whose team has
+// no token configured for the provider are skipped.
+type Reporter struct {
+	secrets     creds.Secrets
+	externalURL string
+	client      *http.Client
+}
+
+func NewReporter(secrets creds.Secrets, externalURL string, client *http.Client) *Reporter {
+	return &Reporter{
+		secrets:     secrets,
+		externalURL: externalURL,
+		client:      client,
+	}
+}
+
+// BuildStarted reports the build as pending.
+func (reporter *Reporter) BuildStarted(logger lager.Logger, build db.Build) {
+	reporter.report(logger.Session("build-started"), build, atc.StatusStarted)
+}
+
+// BuildFinished reports the build's final status.
+func (reporter *Reporter) BuildFinished(logger lager.Logger, build db.Build) {
+	reporter.report(logger.Session("build-finished"), build, atc.BuildStatus(build.Status()))
+}
+
+type commit struct {
+	repo Repository
+	sha  string
+}
+
+func (reporter *Reporter) report(logger lager.Logger, build db.Build, status atc.BuildStatus) {
+	if build.JobID() == 0 {
+		return
+	}
+
+	logger = logger.Session("commit-status")
+
+	commits, err := reporter.commits(build)
+	if err != nil {
+		logger.Error("failed-to-determine-commits", err)
+		return
+	}
+
+	if len(commits) == 0 {
+		return
+	}
+
+	variables := creds.NewVariables(reporter.secrets, build.TeamName(), build.PipelineName(), false)
+
+	targetURL := atc.JobBuildURL(reporter.externalURL, build.TeamName(), build.PipelineRef(), build.JobName(), build.Name())
+	statusContext := fmt.Sprintf("concourse-ci/%s/%s", build.PipelineRef().String(), build.JobName())
+
+	tokens := map[Provider]string{}
+
+	for _, c := range commits {
+		token, found := tokens[c.repo.Provider]
+		if !found {
+			token, err = reporter.token(variables, c.repo.Provider)
+			if err != nil {
+				logger.Error("failed-to-get-token", err, lager.Data{"provider": c.repo.Provider})
+				return
+			}
+
+			tokens[c.repo.Provider] = token
+		}
+
+		if token == "" {
+			continue
+		}
+
+		req, err := newStatusRequest(c, token, status, targetURL, statusContext)
+		if err != nil {
+			logger.Error("failed-to-build-request", err)
+			continue
+		}
+
+		go func(c commit) {
+			ctx, cancel := context.WithTimeout(context.Background(), reportTimeout)
+			defer cancel()
+
+			err := reporter.send(req.WithContext(ctx))
+			if err != nil {
+				logger.Error("failed-to-report-status", err, lager.Data{
+					"repository": c.repo.Path,
+					"sha":        c.sha,
+				})
+			}
+		}(c)
+	}
+}
+
+// commits returns the commits of the build's inputs from git resources
+// hosted on GitHub or GitLab, without duplicates.
+func (reporter *Reporter) commits(build db.Build) ([]commit, error) {
+	inputs, _, err := build.Resources()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(inputs) == 0 {
+		return nil, nil
+	}
+
+	pipeline, found, err := build.Pipeline()
+	if err != nil {
+		return nil, err
+	}
+
+	if !found {
+		return nil, nil
+	}
+
+	resources, err := pipeline.Resources()
+	if err != nil {
+		return nil, err
+	}
+
+	resourcesByID := map[int]db.Resource{}
+	for _, resource := range resources {
+		resourcesByID[resource.ID()] = resource
+	}
+
+	var commits []commit
+	seen := map[commit]bool{}
+	for _, input := range inputs {
+		resource, found := resourcesByID[input.ResourceID]
+		if !found || resource.Type() != "git" {
+			continue
+		}
+
+		uri, ok := resource.Source()["uri"].(string)
+		if !ok {
+			continue
+		}
+
+		repo, found := ParseRepository(uri)
+		if !found {
+			continue
+		}
+
+		sha := input.Version["ref"]
+		if sha == "" {
+			continue
+		}
+
+		c := commit{repo: repo, sha: sha}
+		if seen[c] {
+			continue
+		}
+
+		seen[c] = true
+		commits = append(commits, c)
+	}
+
+	return commits, nil
+}
+
+func (reporter *Reporter) token(variables vars.Variables, provider Provider) (string, error) {
+	path := GitHubTokenVar
+	if provider == ProviderGitLab {
+		path = GitLabTokenVar
+	}
+
+	val, found, err := variables.Get(vars.Reference{Path: path})
+	if err != nil {
+		return "", err
+	}
+
+	if !found {
+		return "", nil
+	}
+
+	token, ok := val.(string)
+	if !ok {
+		return "", fmt.Errorf("var '%s' must be a string", path)
+	}
+
+	return token, nil
+}
+
+func newStatusRequest(c commit, token string, status atc.BuildStatus, targetURL string, statusContext string) (*http.Request, error) {
+	var (
+		endpoint string
+		body     interface{}
+	)
+
+	desc := description(status)
+
+	switch c.repo.Provider {
+	case ProviderGitHub:
+		endpoint = fmt.Sprintf("%s/repos/%s/statuses/%s", c.repo.APIURL, c.repo.Path, c.sha)
+		body = map[string]string{
+			"state":       gitHubState(status),
+			"target_url":  targetURL,
+			"description": desc,
+			"context":     statusContext,
+		}
+	case ProviderGitLab:
+		endpoint = fmt.Sprintf("%s/projects/%s/statuses/%s", c.repo.APIURL, url.PathEscape(c.repo.Path), c.sha)
+		body = map[string]string{
+			"state":       gitLabState(status),
+			"target_url":  targetURL,
+			"description": desc,
+			"name":        statusContext,
+		}
+	default:
+		return nil, fmt.Errorf("unknown provider '%s'", c.repo.Provider)
+	}
+
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	if c.repo.Provider == ProviderGitHub {
+		req.Header.Set("Authorization", "token "+token)
+	} else {
+		req.Header.Set("PRIVATE-TOKEN", token)
+	}
+
+	return req, nil
+}
+
+func (reporter *Reporter) send(req *http.Request) error {
+	resp, err := reporter.client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected response status: %s", resp.Status)
+	}
+
+	return nil
+}
+
+func gitHubState(status atc.BuildStatus) string {
+	switch status {
+	case atc.StatusSucceeded:
+		return "success"
+	case atc.StatusFailed:
+		return "failure"
+	case atc.StatusErrored, atc.StatusAborted:
+		return "error"
+	default:
+		return "pending"
+	}
+}
+
+func gitLabState(status atc.BuildStatus) string {
+	switch status {
+	case atc.StatusSucceeded:
+		return "success"
+	case atc.StatusFailed, atc.StatusErrored:
+		return "failed"
+	case atc.StatusAborted:
+		return "canceled"
+	default:
+		return "running"
+	}
+}
+
+func description(status atc.BuildStatus) string {
+	switch status {
+	case atc.StatusSucceeded:
+		return "the build succeeded"
+	case atc.StatusFailed:
+		return "the build failed"
+	case atc.StatusErrored:
+		return "the build errored"
+	case atc.StatusAborted:
+		return "the build was aborted"
+	default:
+		return "the build is running"
+	}
+}
@@ -0,0 +1,180 @@
+package commitstatus_test
+
+import (
+	"net/http"
+	"net/url"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/commitstatus"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/creds/credsfakes"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+// redirectTransport sends every request to the test server, regardless of
+// the provider's API URL.
+type redirectTransport struct {
+	target *url.URL
+}
+
+func (t redirectTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req.URL.Scheme = t.target.Scheme
+	req.URL.Host = t.target.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+var _ = Describe("Reporter", func() {
+	var (
+		server *ghttp.Server
+
+		fakeSecrets  *credsfakes.FakeSecrets
+		fakePipeline *dbfakes.FakePipeline
+		fakeBuild    *dbfakes.FakeBuild
+
+		reporter *commitstatus.Reporter
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+
+		serverURL, err := url.Parse(server.URL())
+		Expect(err).ToNot(HaveOccurred())
+
+		fakeSecrets = new(credsfakes.FakeSecrets)
+		fakeSecrets.NewSecretLookupPathsReturns([]creds.SecretLookupPath{
+			creds.NewSecretLookupWithPrefix("/concourse/"),
+		})
+		fakeSecrets.GetStub = func(path string) (interface{}, *time.Time, bool, error) {
+			switch path {
+			case "/concourse/github_status_token":
+				return "some-github-token", nil, true, nil
+			case "/concourse/gitlab_status_token":
+				return "some-gitlab-token", nil, true, nil
+			}
+			return nil, nil, false, nil
+		}
+
+		githubResource := new(dbfakes.FakeResource)
+		githubResource.IDReturns(1)
+		githubResource.TypeReturns("git")
+		githubResource.SourceReturns(atc.Source{"uri": "git@github.com:some-owner/some-repo.git"})
+
+		gitlabResource := new(dbfakes.FakeResource)
+		gitlabResource.IDReturns(2)
+		gitlabResource.TypeReturns("git")
+		gitlabResource.SourceReturns(atc.Source{"uri": "https://gitlab.com/some-group/some-repo.git"})
+
+		otherResource := new(dbfakes.FakeResource)
+		otherResource.IDReturns(3)
+		otherResource.TypeReturns("time")
+
+		fakePipeline = new(dbfakes.FakePipeline)
+		fakePipeline.ResourcesReturns(db.Resources{githubResource, gitlabResource, otherResource}, nil)
+
+		fakeBuild = new(dbfakes.FakeBuild)
+		fakeBuild.NameReturns("7")
+		fakeBuild.TeamNameReturns("some-team")
+		fakeBuild.JobIDReturns(2)
+		fakeBuild.JobNameReturns("some-job")
+		fakeBuild.PipelineNameReturns("some-pipeline")
+		fakeBuild.PipelineRefReturns(atc.PipelineRef{Name: "some-pipeline"})
+		fakeBuild.PipelineReturns(fakePipeline, true, nil)
+		fakeBuild.ResourcesReturns([]db.BuildInput{
+			{Name: "some-repo", ResourceID: 1, Version: atc.Version{"ref": "abc123"}},
+			{Name: "same-commit", ResourceID: 1, Version: atc.Version{"ref": "abc123"}},
+			{Name: "some-time", ResourceID: 3, Version: atc.Version{"time": "now"}},
+		}, nil, nil)
+
+		reporter = commitstatus.NewReporter(fakeSecrets, "https://example.com", &http.Client{
+			Transport: redirectTransport{target: serverURL},
+		})
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("BuildStarted", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/repos/some-owner/some-repo/statuses/abc123"),
+					ghttp.VerifyHeaderKV("Authorization", "token some-github-token"),
+					ghttp.VerifyJSONRepresenting(map[string]string{
+						"state":       "pending",
+						"target_url":  "https://example.com/teams/some-team/pipelines/some-pipeline/jobs/some-job/builds/7",
+						"description": "the build is running",
+						"context":     "concourse-ci/some-pipeline/some-job",
+					}),
+				),
+			)
+		})
+
+		It("reports each commit once as pending", func() {
+			reporter.BuildStarted(lagertest.NewTestLogger("test"), fakeBuild)
+
+			Eventually(server.ReceivedRequests).Should(HaveLen(1))
+			Consistently(server.ReceivedRequests).Should(HaveLen(1))
+		})
+	})
+
+	Describe("BuildFinished", func() {
+		BeforeEach(func() {
+			fakeBuild.StatusReturns(db.BuildStatusFailed)
+			fakeBuild.ResourcesReturns([]db.BuildInput{
+				{Name: "some-repo", ResourceID: 2, Version: atc.Version{"ref": "def456"}},
+			}, nil, nil)
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/api/v4/projects/some-group/some-repo/statuses/def456"),
+					ghttp.VerifyHeaderKV("PRIVATE-TOKEN", "some-gitlab-token"),
+					ghttp.VerifyJSONRepresenting(map[string]string{
+						"state":       "failed",
+						"target_url":  "https://example.com/teams/some-team/pipelines/some-pipeline/jobs/some-job/builds/7",
+						"description": "the build failed",
+						"name":        "concourse-ci/some-pipeline/some-job",
+					}),
+				),
+			)
+		})
+
+		It("reports the build's status", func() {
+			reporter.BuildFinished(lagertest.NewTestLogger("test"), fakeBuild)
+
+			Eventually(server.ReceivedRequests).Should(HaveLen(1))
+		})
+	})
+
+	Context("when the team has no token for the provider", func() {
+		BeforeEach(func() {
+			fakeSecrets.GetReturns(nil, nil, false, nil)
+			fakeSecrets.GetStub = nil
+		})
+
+		It("does not report statuses", func() {
+			reporter.BuildStarted(lagertest.NewTestLogger("test"), fakeBuild)
+
+			Consistently(server.ReceivedRequests).Should(BeEmpty())
+		})
+	})
+
+	Context("when the build is not a job build", func() {
+		BeforeEach(func() {
+			fakeBuild.JobIDReturns(0)
+		})
+
+		It("does not report statuses", func() {
+			reporter.BuildStarted(lagertest.NewTestLogger("test"), fakeBuild)
+
+			Expect(fakeBuild.ResourcesCallCount()).To(BeZero())
+			Consistently(server.ReceivedRequests).Should(BeEmpty())
+		})
+	})
+})
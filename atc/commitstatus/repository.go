@@ -0,0 +1,94 @@
+package commitstatus
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+type Provider string
+
+const (
+	ProviderGitHub Provider = "github"
+	ProviderGitLab Provider = "gitlab"
+)
+
+// Repository is a repository hosted by GitHub or GitLab, as identified by
+// the uri of a git resource.
+type Repository struct {
+	Provider Provider
+
+	// APIURL is the base URL of the provider's API for the repository's host.
+	APIURL string
+
+	// Path is the path of the repository on its host, e.g. 'owner/repo'.
+	Path string
+}
+
+// ParseRepository determines the repository of a git uri, which may be an
+// http(s) URL, an ssh:// URL or an scp-like 'git@host:owner/repo.git'
+// address. Repositories which are not hosted by GitHub or GitLab are not
+// found.
+func ParseRepository(uri string) (Repository, bool) {
+	host, path, ok := splitURI(uri)
+	if !ok {
+		return Repository{}, false
+	}
+
+	path = strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+	if !strings.Contains(path, "/") {
+		return Repository{}, false
+	}
+
+	host = strings.ToLower(host)
+
+	switch {
+	case host == "github.com":
+		return Repository{
+			Provider: ProviderGitHub,
+			APIURL:   "https://api.github.com",
+			Path:     path,
+		}, true
+	case strings.Contains(host, "github"):
+		return Repository{
+			Provider: ProviderGitHub,
+			APIURL:   fmt.Sprintf("https://%s/api/v3", host),
+			Path:     path,
+		}, true
+	case strings.Contains(host, "gitlab"):
+		return Repository{
+			Provider: ProviderGitLab,
+			APIURL:   fmt.Sprintf("https://%s/api/v4", host),
+			Path:     path,
+		}, true
+	}
+
+	return Repository{}, false
+}
+
+func splitURI(uri string) (string, string, bool) {
+	if strings.Contains(uri, "://") {
+		u, err := url.Parse(uri)
+		if err != nil || u.Hostname() == "" {
+			return "", "", false
+		}
+
+		return u.Hostname(), u.Path, true
+	}
+
+	// scp-like syntax, e.g. git@github.com:owner/repo.git
+	hostPart, path, found := strings.Cut(uri, ":")
+	if !found {
+		return "", "", false
+	}
+
+	if i := strings.LastIndex(hostPart, "@"); i >= 0 {
+		hostPart = hostPart[i+1:]
+	}
+
+	if hostPart == "" {
+		return "", "", false
+	}
+
+	return hostPart, path, true
+}
@@ -0,0 +1,48 @@
+package commitstatus_test
+
+import (
+	"github.com/concourse/concourse/atc/commitstatus"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ParseRepository", func() {
+	DescribeTable("repositories hosted by GitHub or GitLab",
+		func(uri string, expected commitstatus.Repository) {
+			repo, found := commitstatus.ParseRepository(uri)
+			Expect(found).To(BeTrue())
+			Expect(repo).To(Equal(expected))
+		},
+		Entry("github https", "https://github.com/some-owner/some-repo.git", commitstatus.Repository{
+			Provider: commitstatus.ProviderGitHub,
+			APIURL:   "https://api.github.com",
+			Path:     "some-owner/some-repo",
+		}),
+		Entry("github scp-like", "git@github.com:some-owner/some-repo.git", commitstatus.Repository{
+			Provider: commitstatus.ProviderGitHub,
+			APIURL:   "https://api.github.com",
+			Path:     "some-owner/some-repo",
+		}),
+		Entry("github enterprise ssh", "ssh://git@github.example.com/some-owner/some-repo", commitstatus.Repository{
+			Provider: commitstatus.ProviderGitHub,
+			APIURL:   "https://github.example.com/api/v3",
+			Path:     "some-owner/some-repo",
+		}),
+		Entry("gitlab subgroups", "https://gitlab.com/some-group/some-subgroup/some-repo.git", commitstatus.Repository{
+			Provider: commitstatus.ProviderGitLab,
+			APIURL:   "https://gitlab.com/api/v4",
+			Path:     "some-group/some-subgroup/some-repo",
+		}),
+	)
+
+	DescribeTable("other repositories",
+		func(uri string) {
+			_, found := commitstatus.ParseRepository(uri)
+			Expect(found).To(BeFalse())
+		},
+		Entry("another host", "https://bitbucket.org/some-owner/some-repo.git"),
+		Entry("a local path", "/some/repo"),
+		Entry("no repository", "https://github.com/some-owner"),
+	)
+})
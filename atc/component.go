@@ -21,10 +21,37 @@ const (
 	ComponentCollectorVolumes           = "collector_volumes"
 	ComponentCollectorWorkers           = "collector_workers"
 	ComponentCollectorPipelines         = "collector_pipelines"
+	ComponentCollectorIdempotencyKeys   = "collector_idempotency_keys"
 	ComponentPipelinePauser             = "pipeline_pauser"
+	ComponentWebhookDeliverer           = "webhook_deliverer"
+	ComponentDestroyQueueProcessor      = "destroy_queue_processor"
+	ComponentBuildStatsAggregator       = "build_stats_aggregator"
+	ComponentFlakinessAnalyzer          = "flakiness_analyzer"
+	ComponentMaintenanceAdvisor         = "maintenance_advisor"
+	ComponentGrowthSnapshotter          = "growth_snapshotter"
+	ComponentKubernetesWorker           = "kubernetes_worker"
+	ComponentWarmPoolManager            = "warm_pool_manager"
+	ComponentAutoscalingPublisher       = "autoscaling_publisher"
+	ComponentFirehoseExporter           = "firehose_exporter"
+	ComponentLogDrainer                 = "log_drainer"
 )
 
 type Component struct {
 	Name     string
 	Interval time.Duration
 }
+
+// ComponentStatus is how a periodic component is currently configured.
+// Interval is the one in effect, which differs from DefaultInterval once
+// it has been overridden through the API.
+type ComponentStatus struct {
+	Name            string `json:"name"`
+	Interval        string `json:"interval"`
+	DefaultInterval string `json:"default_interval"`
+	Paused          bool   `json:"paused"`
+	LastRan         int64  `json:"last_ran,omitempty"`
+}
+
+type SetComponentIntervalRequest struct {
+	Interval string `json:"interval"`
+}
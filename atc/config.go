@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -18,25 +19,31 @@ const ConfigVersionHeader = "X-Concourse-Config-Version"
 const DefaultTeamName = "main"
 
 type Config struct {
-	Groups        GroupConfigs     `json:"groups,omitempty"`
-	VarSources    VarSourceConfigs `json:"var_sources,omitempty"`
-	Resources     ResourceConfigs  `json:"resources,omitempty"`
-	ResourceTypes ResourceTypes    `json:"resource_types,omitempty"`
-	Prototypes    Prototypes       `json:"prototypes,omitempty"`
-	Jobs          JobConfigs       `json:"jobs,omitempty"`
-	Display       *DisplayConfig   `json:"display,omitempty"`
+	Groups        GroupConfigs      `json:"groups,omitempty"`
+	Vars          VarDeclarations   `json:"vars,omitempty"`
+	VarSources    VarSourceConfigs  `json:"var_sources,omitempty"`
+	Resources     ResourceConfigs   `json:"resources,omitempty"`
+	ResourceTypes ResourceTypes     `json:"resource_types,omitempty"`
+	Prototypes    Prototypes        `json:"prototypes,omitempty"`
+	Jobs          JobConfigs        `json:"jobs,omitempty"`
+	Notifications NotificationRules `json:"notifications,omitempty"`
+	Display       *DisplayConfig    `json:"display,omitempty"`
+	Workers       *WorkersConfig    `json:"workers,omitempty"`
 }
 
 func UnmarshalConfig(payload []byte, config interface{}) error {
 	// a 'skeleton' of Config, specifying only the toplevel fields
 	type skeletonConfig struct {
 		Groups        interface{} `json:"groups,omitempty"`
+		Vars          interface{} `json:"vars,omitempty"`
 		VarSources    interface{} `json:"var_sources,omitempty"`
 		Resources     interface{} `json:"resources,omitempty"`
 		ResourceTypes interface{} `json:"resource_types,omitempty"`
 		Prototypes    interface{} `json:"prototypes,omitempty"`
 		Jobs          interface{} `json:"jobs,omitempty"`
+		Notifications interface{} `json:"notifications,omitempty"`
 		Display       interface{} `json:"display,omitempty"`
+		Workers       interface{} `json:"workers,omitempty"`
 	}
 
 	var stripped skeletonConfig
@@ -92,6 +99,141 @@ func (c VarSourceConfigs) Lookup(name string) (VarSourceConfig, bool) {
 	return VarSourceConfig{}, false
 }
 
+// VarDeclaration declares a var which is referenced by the pipeline as
+// ((name)), so that it can be given a default and a type.
+type VarDeclaration struct {
+	Name string `json:"name"`
+
+	// Type is one of VarTypes. If it is empty, the var may have any type.
+	Type string `json:"type,omitempty"`
+
+	// Default is used when the var is not provided when setting the pipeline
+	// and is not found in any credential manager.
+	Default interface{} `json:"default,omitempty"`
+
+	// Required vars must be provided when setting the pipeline, either as
+	// vars or instance vars.
+	Required bool `json:"required,omitempty"`
+}
+
+const (
+	VarTypeString  = "string"
+	VarTypeNumber  = "number"
+	VarTypeBoolean = "boolean"
+	VarTypeObject  = "object"
+	VarTypeArray   = "array"
+)
+
+var VarTypes = []string{VarTypeString, VarTypeNumber, VarTypeBoolean, VarTypeObject, VarTypeArray}
+
+// Validate checks that the type is known and that the default, if any, is of
+// that type.
+func (d VarDeclaration) Validate() error {
+	if d.Type != "" {
+		known := false
+		for _, t := range VarTypes {
+			if d.Type == t {
+				known = true
+				break
+			}
+		}
+
+		if !known {
+			return fmt.Errorf("unknown type '%s' (must be one of %s)", d.Type, strings.Join(VarTypes, ", "))
+		}
+	}
+
+	if d.Default == nil {
+		return nil
+	}
+
+	if d.Required {
+		return errors.New("cannot be required and have a default")
+	}
+
+	if !d.Accepts(d.Default) {
+		return fmt.Errorf("default is not of type %s", d.Type)
+	}
+
+	return nil
+}
+
+// Accepts returns whether the value is of the declared type.
+func (d VarDeclaration) Accepts(value interface{}) bool {
+	switch d.Type {
+	case VarTypeString:
+		_, ok := value.(string)
+		return ok
+	case VarTypeNumber:
+		switch value.(type) {
+		case float64, float32, int, int64, json.Number:
+			return true
+		}
+		return false
+	case VarTypeBoolean:
+		_, ok := value.(bool)
+		return ok
+	case VarTypeObject:
+		switch value.(type) {
+		case map[string]interface{}, map[interface{}]interface{}:
+			return true
+		}
+		return false
+	case VarTypeArray:
+		_, ok := value.([]interface{})
+		return ok
+	default:
+		return true
+	}
+}
+
+type VarDeclarations []VarDeclaration
+
+func (d VarDeclarations) Lookup(name string) (VarDeclaration, bool) {
+	for _, declaration := range d {
+		if declaration.Name == name {
+			return declaration, true
+		}
+	}
+
+	return VarDeclaration{}, false
+}
+
+// Defaults returns the defaults of the declared vars, to be used as a last
+// resort when resolving vars.
+func (d VarDeclarations) Defaults() vars.StaticVariables {
+	defaults := vars.StaticVariables{}
+	for _, declaration := range d {
+		if declaration.Default != nil {
+			defaults[declaration.Name] = declaration.Default
+		}
+	}
+
+	return defaults
+}
+
+// Missing returns the names of the required vars which are not provided by
+// the given variables.
+func (d VarDeclarations) Missing(variables vars.Variables) ([]string, error) {
+	var missing []string
+	for _, declaration := range d {
+		if !declaration.Required {
+			continue
+		}
+
+		_, found, err := variables.Get(vars.Reference{Path: declaration.Name})
+		if err != nil {
+			return nil, err
+		}
+
+		if !found {
+			missing = append(missing, declaration.Name)
+		}
+	}
+
+	return missing, nil
+}
+
 type pendingVarSource struct {
 	vs   VarSourceConfig
 	deps []string
@@ -188,6 +330,44 @@ type ResourceConfig struct {
 	Version              Version     `json:"version,omitempty"`
 	Icon                 string      `json:"icon,omitempty"`
 	ExposeBuildCreatedBy bool        `json:"expose_build_created_by,omitempty"`
+	SerializePuts        bool        `json:"serialize_puts,omitempty"`
+	Push                 *PushConfig `json:"push,omitempty"`
+}
+
+// PushConfig puts a resource in push mode: it is never checked, and instead
+// its versions are pushed to it through the API by an external system.
+type PushConfig struct {
+	// Version lists the fields of the resource's versions. A pushed version
+	// has to have all of them, and no others.
+	Version []string `json:"version"`
+}
+
+// ValidateVersion checks a pushed version against the fields declared for
+// the resource's versions.
+func (config PushConfig) ValidateVersion(version Version) error {
+	declared := map[string]bool{}
+	for _, field := range config.Version {
+		declared[field] = true
+
+		if _, found := version[field]; !found {
+			return fmt.Errorf("missing field '%s'", field)
+		}
+	}
+
+	var fields []string
+	for field := range version {
+		fields = append(fields, field)
+	}
+
+	sort.Strings(fields)
+
+	for _, field := range fields {
+		if !declared[field] {
+			return fmt.Errorf("unknown field '%s'", field)
+		}
+	}
+
+	return nil
 }
 
 type ResourceType struct {
@@ -216,6 +396,14 @@ type DisplayConfig struct {
 	BackgroundImage string `json:"background_image,omitempty"`
 }
 
+// WorkersConfig configures which workers the pipeline's steps may be placed
+// on.
+type WorkersConfig struct {
+	// TeamOnly restricts the pipeline to the team's own workers, rather than
+	// falling back to the general workers when the team has none available.
+	TeamOnly bool `json:"team_only,omitempty"`
+}
+
 type CheckEvery struct {
 	Never    bool
 	Interval time.Duration
@@ -91,6 +91,21 @@ func (index GroupIndex) FindEquivalentWithOrder(obj interface{}) (interface{}, i
 	return GroupConfigs(index).Lookup(name(obj))
 }
 
+type VarDeclarationIndex VarDeclarations
+
+func (index VarDeclarationIndex) Slice() []interface{} {
+	slice := make([]interface{}, len(index))
+	for i, object := range index {
+		slice[i] = object
+	}
+
+	return slice
+}
+
+func (index VarDeclarationIndex) FindEquivalent(obj interface{}) (interface{}, bool) {
+	return VarDeclarations(index).Lookup(name(obj))
+}
+
 type VarSourceIndex VarSourceConfigs
 
 func (index VarSourceIndex) Slice() []interface{} {
@@ -289,6 +304,16 @@ func (c Config) Diff(out io.Writer, newConfig Config) bool {
 		}
 	}
 
+	varDiffs := diffIndices(VarDeclarationIndex(c.Vars), VarDeclarationIndex(newConfig.Vars))
+	if len(varDiffs) > 0 {
+		diffExists = true
+		fmt.Fprintln(out, "vars:")
+
+		for _, diff := range varDiffs {
+			diff.Render(indent, "var")
+		}
+	}
+
 	varSourceDiffs := diffIndices(VarSourceIndex(c.VarSources), VarSourceIndex(newConfig.VarSources))
 	if len(varSourceDiffs) > 0 {
 		diffExists = true
@@ -329,11 +354,29 @@ func (c Config) Diff(out io.Writer, newConfig Config) bool {
 		}
 	}
 
+	if (len(c.Notifications) > 0 || len(newConfig.Notifications) > 0) && practicallyDifferent(c.Notifications, newConfig.Notifications) {
+		diffExists = true
+		fmt.Fprintln(out, "notifications:")
+
+		payloadA, _ := yaml.Marshal(c.Notifications)
+		payloadB, _ := yaml.Marshal(newConfig.Notifications)
+		renderDiff(indent, string(payloadA), string(payloadB))
+	}
+
 	displayDiff, diff := diffDisplay(c.Display, newConfig.Display)
 	if diff {
 		diffExists = true
 		displayDiff.Render(indent)
 	}
 
+	if practicallyDifferent(c.Workers, newConfig.Workers) {
+		diffExists = true
+		fmt.Fprintln(out, "workers:")
+
+		payloadA, _ := yaml.Marshal(c.Workers)
+		payloadB, _ := yaml.Marshal(newConfig.Workers)
+		renderDiff(indent, string(payloadA), string(payloadB))
+	}
+
 	return diffExists
 }
@@ -5,7 +5,9 @@ import (
 	"time"
 
 	. "github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/vars"
 	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
 )
 
@@ -40,6 +42,73 @@ var _ = Describe("Config", func() {
 				})
 			})
 		})
+
+		Context("when unmarshaling a sampling strategy from JSON", func() {
+			It("parses every interval", func() {
+				var versionConfig VersionConfig
+				err := json.Unmarshal([]byte(`{ "every": "10m" }`), &versionConfig)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(versionConfig).To(Equal(VersionConfig{
+					Sampling: &VersionSampling{Every: 10 * time.Minute},
+				}))
+			})
+
+			It("parses latest per period", func() {
+				var versionConfig VersionConfig
+				err := json.Unmarshal([]byte(`{ "latest_per": "day" }`), &versionConfig)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(versionConfig).To(Equal(VersionConfig{
+					Sampling: &VersionSampling{LatestPer: VersionPeriodDay},
+				}))
+			})
+
+			It("marshals back to the same config", func() {
+				for _, config := range []string{`{"every":"10m0s"}`, `{"latest_per":"week"}`} {
+					var versionConfig VersionConfig
+					err := json.Unmarshal([]byte(config), &versionConfig)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(json.Marshal(&versionConfig)).To(MatchJSON(config))
+				}
+			})
+
+			It("errors on a bogus interval", func() {
+				var versionConfig VersionConfig
+				err := json.Unmarshal([]byte(`{ "every": "often" }`), &versionConfig)
+				Expect(err).To(MatchError(ContainSubstring("invalid version interval 'often'")))
+			})
+
+			It("errors on an unknown period", func() {
+				var versionConfig VersionConfig
+				err := json.Unmarshal([]byte(`{ "latest_per": "fortnight" }`), &versionConfig)
+				Expect(err).To(MatchError(ContainSubstring("invalid version period 'fortnight'")))
+			})
+
+			It("still pins versions with more fields", func() {
+				var versionConfig VersionConfig
+				err := json.Unmarshal([]byte(`{ "every": "10m", "ref": "abc" }`), &versionConfig)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(versionConfig.Pinned).To(Equal(Version{"every": "10m", "ref": "abc"}))
+				Expect(versionConfig.Sampling).To(BeNil())
+			})
+		})
+
+		DescribeTable("VersionSampling.WindowEnd",
+			func(sampling VersionSampling, found string, end string) {
+				foundTime, err := time.Parse(time.RFC3339, found)
+				Expect(err).NotTo(HaveOccurred())
+
+				endTime, err := time.Parse(time.RFC3339, end)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(sampling.WindowEnd(foundTime)).To(BeTemporally("==", endTime))
+			},
+			Entry("every interval", VersionSampling{Every: 10 * time.Minute}, "2021-06-09T10:55:00Z", "2021-06-09T11:05:00Z"),
+			Entry("latest per hour", VersionSampling{LatestPer: VersionPeriodHour}, "2021-06-09T10:55:00Z", "2021-06-09T11:00:00Z"),
+			Entry("latest per day", VersionSampling{LatestPer: VersionPeriodDay}, "2021-06-09T10:55:00+02:00", "2021-06-10T00:00:00Z"),
+			Entry("latest per week", VersionSampling{LatestPer: VersionPeriodWeek}, "2021-06-09T10:55:00Z", "2021-06-14T00:00:00Z"),
+			Entry("latest per week on a Monday", VersionSampling{LatestPer: VersionPeriodWeek}, "2021-06-14T00:00:00Z", "2021-06-21T00:00:00Z"),
+		)
 	})
 
 	Describe("VarSourceConfigs.OrderByDependency", func() {
@@ -223,4 +292,64 @@ var _ = Describe("Config", func() {
 			})
 		})
 	})
+
+	Describe("VarDeclaration.Validate", func() {
+		DescribeTable("validates the type and default",
+			func(declaration VarDeclaration, expectedErr string) {
+				err := declaration.Validate()
+				if expectedErr == "" {
+					Expect(err).ToNot(HaveOccurred())
+				} else {
+					Expect(err).To(MatchError(expectedErr))
+				}
+			},
+			Entry("untyped", VarDeclaration{Name: "a", Default: 1.0}, ""),
+			Entry("matching default", VarDeclaration{Name: "a", Type: "number", Default: 1.0}, ""),
+			Entry("false default", VarDeclaration{Name: "a", Type: "boolean", Default: false}, ""),
+			Entry("object default", VarDeclaration{Name: "a", Type: "object", Default: map[string]interface{}{}}, ""),
+			Entry("unknown type", VarDeclaration{Name: "a", Type: "int"}, "unknown type 'int' (must be one of string, number, boolean, object, array)"),
+			Entry("mismatched default", VarDeclaration{Name: "a", Type: "string", Default: 1.0}, "default is not of type string"),
+			Entry("required with default", VarDeclaration{Name: "a", Required: true, Default: "x"}, "cannot be required and have a default"),
+		)
+	})
+
+	Describe("PushConfig.ValidateVersion", func() {
+		config := PushConfig{Version: []string{"ref", "branch"}}
+
+		DescribeTable("checks the fields of the version",
+			func(version Version, expectedErr string) {
+				err := config.ValidateVersion(version)
+				if expectedErr == "" {
+					Expect(err).ToNot(HaveOccurred())
+				} else {
+					Expect(err).To(MatchError(expectedErr))
+				}
+			},
+			Entry("all fields", Version{"ref": "abc", "branch": "main"}, ""),
+			Entry("missing field", Version{"ref": "abc"}, "missing field 'branch'"),
+			Entry("unknown field", Version{"ref": "abc", "branch": "main", "tag": "v1"}, "unknown field 'tag'"),
+		)
+	})
+
+	Describe("VarDeclarations", func() {
+		var declarations VarDeclarations
+
+		BeforeEach(func() {
+			declarations = VarDeclarations{
+				{Name: "branch", Default: "main"},
+				{Name: "commit", Required: true},
+				{Name: "replicas", Type: "number", Required: true},
+			}
+		})
+
+		It("returns the defaults", func() {
+			Expect(declarations.Defaults()).To(Equal(vars.StaticVariables{"branch": "main"}))
+		})
+
+		It("returns the required vars which are missing", func() {
+			missing, err := declarations.Missing(vars.StaticVariables{"commit": "abc"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(missing).To(Equal([]string{"replicas"}))
+		})
+	})
 })
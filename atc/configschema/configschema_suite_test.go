@@ -0,0 +1,13 @@
+package configschema_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestConfigschema(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Configschema Suite")
+}
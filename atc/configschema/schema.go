@@ -0,0 +1,298 @@
+// Package configschema generates JSON Schemas describing the pipeline and
+// task config formats from the structs they are unmarshaled into, so that
+// editors can offer completion and validation for configs. The schema of the
+// typed build events is generated the same way.
+package configschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/eventv2"
+)
+
+const draft = "http://json-schema.org/draft-07/schema#"
+
+// Schema is the subset of JSON Schema (draft 7) needed to describe configs.
+type Schema struct {
+	Schema string `json:"$schema,omitempty"`
+	ID     string `json:"$id,omitempty"`
+	Ref    string `json:"$ref,omitempty"`
+	Title  string `json:"title,omitempty"`
+
+	Type string        `json:"type,omitempty"`
+	Enum []interface{} `json:"enum,omitempty"`
+
+	Properties map[string]*Schema `json:"properties,omitempty"`
+	Required   []string           `json:"required,omitempty"`
+
+	// AdditionalProperties is either a *Schema or a bool.
+	AdditionalProperties interface{} `json:"additionalProperties,omitempty"`
+
+	Items *Schema   `json:"items,omitempty"`
+	AnyOf []*Schema `json:"anyOf,omitempty"`
+
+	Definitions map[string]*Schema `json:"definitions,omitempty"`
+}
+
+// Names are the schemas which can be generated, keyed by the name they are
+// served under.
+var Names = map[string]func() *Schema{
+	"pipeline":    Pipeline,
+	"task":        Task,
+	"build-event": BuildEvent,
+}
+
+// Pipeline returns the schema of a pipeline config.
+func Pipeline() *Schema {
+	return generate("pipeline", "Concourse pipeline config", reflect.TypeOf(atc.Config{}))
+}
+
+// Task returns the schema of a task config, i.e. the contents of a task's
+// `file:` or `config:`.
+func Task() *Schema {
+	return generate("task", "Concourse task config", reflect.TypeOf(atc.TaskConfig{}))
+}
+
+// BuildEvent returns the schema of the typed build events, streamed by
+// asking for version 2 of the event schema.
+func BuildEvent() *Schema {
+	// events gain fields within a version of their schema, which consumers
+	// must ignore, so unlike configs they may have unknown properties
+	g := &generator{definitions: map[string]*Schema{}, open: true}
+	return g.generate("build-event", fmt.Sprintf("Concourse build event (schema version %s)", eventv2.SchemaVersion), reflect.TypeOf(eventv2.Event{}))
+}
+
+func generate(name string, title string, t reflect.Type) *Schema {
+	g := &generator{definitions: map[string]*Schema{}}
+	return g.generate(name, fmt.Sprintf("%s (schema version %d)", title, atc.ConfigSchemaVersion), t)
+}
+
+func (g *generator) generate(name string, title string, t reflect.Type) *Schema {
+	schema := g.structSchema(t)
+	schema.Schema = draft
+	schema.ID = fmt.Sprintf("/api/v1/schemas/%s", name)
+	schema.Title = title
+
+	if len(g.definitions) > 0 {
+		schema.Definitions = g.definitions
+	}
+
+	return schema
+}
+
+type generator struct {
+	definitions map[string]*Schema
+
+	// open allows objects to have properties besides those described.
+	open bool
+}
+
+// override describes the types which are unmarshaled with custom logic, and
+// so accept a different structure than their Go type would suggest.
+func (g *generator) override(t reflect.Type) func(*generator) *Schema {
+	switch t {
+	case reflect.TypeOf(atc.Step{}):
+		return (*generator).stepSchema
+
+	case reflect.TypeOf(atc.InParallelConfig{}):
+		return func(g *generator) *Schema {
+			return &Schema{
+				AnyOf: []*Schema{
+					{Type: "array", Items: g.schemaFor(reflect.TypeOf(atc.Step{}))},
+					g.structSchema(t),
+				},
+			}
+		}
+
+	case reflect.TypeOf(atc.CheckEvery{}):
+		return func(*generator) *Schema {
+			return &Schema{Type: "string"}
+		}
+
+	case reflect.TypeOf(atc.CPULimit(0)), reflect.TypeOf(atc.MemoryLimit(0)):
+		return func(*generator) *Schema {
+			return &Schema{AnyOf: []*Schema{{Type: "integer"}, {Type: "string"}}}
+		}
+
+	case reflect.TypeOf(atc.MaxInFlightConfig{}):
+		return func(*generator) *Schema {
+			return &Schema{AnyOf: []*Schema{{Type: "integer"}, {Enum: []interface{}{"all"}}}}
+		}
+
+	case reflect.TypeOf(atc.VersionConfig{}):
+		return func(*generator) *Schema {
+			return &Schema{
+				AnyOf: []*Schema{
+					{Enum: []interface{}{"latest", "every"}},
+					{Type: "object", AdditionalProperties: &Schema{Type: "string"}},
+				},
+			}
+		}
+
+	case reflect.TypeOf(atc.InputsConfig{}):
+		return func(*generator) *Schema {
+			return &Schema{
+				AnyOf: []*Schema{
+					{Enum: []interface{}{"all", "detect"}},
+					{Type: "array", Items: &Schema{Type: "string"}},
+				},
+			}
+		}
+
+	case reflect.TypeOf(json.RawMessage{}):
+		return func(*generator) *Schema {
+			return &Schema{}
+		}
+
+	case reflect.TypeOf(atc.TaskEnv{}):
+		// values are coerced into strings, so any scalar is accepted
+		return func(*generator) *Schema {
+			return &Schema{Type: "object", AdditionalProperties: &Schema{}}
+		}
+	}
+
+	return nil
+}
+
+func (g *generator) schemaFor(t reflect.Type) *Schema {
+	if override := g.override(t); override != nil {
+		return g.define(t, override)
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		return g.schemaFor(t.Elem())
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: g.schemaFor(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.schemaFor(t.Elem())}
+	case reflect.Struct:
+		return g.define(t, func(g *generator) *Schema { return g.structSchema(t) })
+	default:
+		// interface{}, which may hold anything
+		return &Schema{}
+	}
+}
+
+// define adds the schema of a named type to the definitions and returns a
+// reference to it, which allows recursive types such as steps to be
+// described.
+func (g *generator) define(t reflect.Type, generate func(*generator) *Schema) *Schema {
+	if t.Name() == "" {
+		return generate(g)
+	}
+
+	ref := &Schema{Ref: "#/definitions/" + t.Name()}
+	if _, found := g.definitions[t.Name()]; found {
+		return ref
+	}
+
+	// reserve the name before generating so that recursive references
+	// terminate
+	g.definitions[t.Name()] = &Schema{}
+	g.definitions[t.Name()] = generate(g)
+
+	return ref
+}
+
+func (g *generator) structSchema(t reflect.Type) *Schema {
+	schema := &Schema{
+		Type:       "object",
+		Properties: g.properties(t),
+	}
+
+	if !g.open {
+		schema.AdditionalProperties = false
+	}
+
+	return schema
+}
+
+func (g *generator) properties(t reflect.Type) map[string]*Schema {
+	properties := map[string]*Schema{}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		name := strings.Split(tag, ",")[0]
+		if name == "" && field.Anonymous && field.Type.Kind() == reflect.Struct {
+			for name, schema := range g.properties(field.Type) {
+				properties[name] = schema
+			}
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		properties[name] = g.schemaFor(field.Type)
+	}
+
+	return properties
+}
+
+// stepSchema describes a step as one of the core step types (e.g. get, put,
+// task), identified by its required key, alongside any of the step
+// modifiers and hooks.
+func (g *generator) stepSchema() *Schema {
+	modifiers := map[string]*Schema{}
+	var cores []atc.StepDetector
+
+	for _, detector := range atc.StepPrecedence {
+		stepType := reflect.TypeOf(detector.New()).Elem()
+		if _, isWrapper := detector.New().(atc.StepWrapper); isWrapper {
+			for name, schema := range g.properties(stepType) {
+				modifiers[name] = schema
+			}
+			continue
+		}
+
+		cores = append(cores, detector)
+	}
+
+	sort.Slice(cores, func(i, j int) bool {
+		return cores[i].Key < cores[j].Key
+	})
+
+	schema := &Schema{}
+	for _, detector := range cores {
+		properties := g.properties(reflect.TypeOf(detector.New()).Elem())
+		for name, modifier := range modifiers {
+			if _, found := properties[name]; !found {
+				properties[name] = modifier
+			}
+		}
+
+		schema.AnyOf = append(schema.AnyOf, &Schema{
+			Type:                 "object",
+			Properties:           properties,
+			Required:             []string{detector.Key},
+			AdditionalProperties: false,
+		})
+	}
+
+	return schema
+}
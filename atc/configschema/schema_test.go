@@ -0,0 +1,105 @@
+package configschema_test
+
+import (
+	"encoding/json"
+
+	"github.com/concourse/concourse/atc/configschema"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Schema", func() {
+	Describe("Pipeline", func() {
+		var schema *configschema.Schema
+
+		BeforeEach(func() {
+			schema = configschema.Pipeline()
+		})
+
+		It("describes the top-level fields of a pipeline", func() {
+			Expect(schema.Schema).To(Equal("http://json-schema.org/draft-07/schema#"))
+			Expect(schema.Type).To(Equal("object"))
+			Expect(schema.AdditionalProperties).To(Equal(false))
+			Expect(schema.Properties).To(HaveKey("jobs"))
+			Expect(schema.Properties).To(HaveKey("resources"))
+			Expect(schema.Properties).To(HaveKey("resource_types"))
+			Expect(schema.Properties).To(HaveKey("var_sources"))
+			Expect(schema.Properties["jobs"].Items).To(Equal(&configschema.Schema{Ref: "#/definitions/JobConfig"}))
+		})
+
+		It("describes each core step type with its identifying key required", func() {
+			step := schema.Definitions["Step"]
+			Expect(step).ToNot(BeNil())
+
+			var keys []string
+			for _, alternative := range step.AnyOf {
+				Expect(alternative.Required).To(HaveLen(1))
+				keys = append(keys, alternative.Required[0])
+			}
+
+			Expect(keys).To(ConsistOf("do", "get", "in_parallel", "load_var", "put", "run", "set_pipeline", "task", "try"))
+		})
+
+		It("allows modifiers and hooks on every step", func() {
+			for _, alternative := range schema.Definitions["Step"].AnyOf {
+				Expect(alternative.Properties).To(HaveKey("attempts"))
+				Expect(alternative.Properties).To(HaveKey("timeout"))
+				Expect(alternative.Properties).To(HaveKey("across"))
+				Expect(alternative.Properties["ensure"]).To(Equal(&configschema.Schema{Ref: "#/definitions/Step"}))
+			}
+		})
+
+		It("describes fields with custom unmarshaling by what they accept", func() {
+			Expect(schema.Definitions["VersionConfig"].AnyOf).To(ContainElement(&configschema.Schema{
+				Enum: []interface{}{"latest", "every"},
+			}))
+
+			Expect(schema.Definitions["InParallelConfig"].AnyOf).To(ContainElement(&configschema.Schema{
+				Type:  "array",
+				Items: &configschema.Schema{Ref: "#/definitions/Step"},
+			}))
+		})
+
+		It("can be marshaled to JSON", func() {
+			_, err := json.Marshal(schema)
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+
+	Describe("Task", func() {
+		It("describes the fields of a task config", func() {
+			schema := configschema.Task()
+			Expect(schema.Properties).To(HaveKey("platform"))
+			Expect(schema.Properties).To(HaveKey("image_resource"))
+			Expect(schema.Properties).To(HaveKey("run"))
+			Expect(schema.Properties["params"]).To(Equal(&configschema.Schema{Ref: "#/definitions/TaskEnv"}))
+			Expect(schema.Definitions).ToNot(HaveKey("Step"))
+		})
+	})
+	Describe("BuildEvent", func() {
+		var schema *configschema.Schema
+
+		BeforeEach(func() {
+			schema = configschema.BuildEvent()
+		})
+
+		It("describes the fields of an event, including its payloads", func() {
+			Expect(schema.Title).To(Equal("Concourse build event (schema version 2.0)"))
+			Expect(schema.Properties).To(HaveKey("type"))
+			Expect(schema.Properties).To(HaveKey("step"))
+			Expect(schema.Properties["log"]).To(Equal(&configschema.Schema{Ref: "#/definitions/Log"}))
+			Expect(schema.Definitions["Log"].Properties).To(HaveKey("payload"))
+		})
+
+		It("allows properties it doesn't describe, as events may gain them", func() {
+			Expect(schema.AdditionalProperties).To(BeNil())
+			Expect(schema.Definitions["Log"].AdditionalProperties).To(BeNil())
+		})
+
+		It("can be marshaled to JSON", func() {
+			_, err := json.Marshal(schema)
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+})
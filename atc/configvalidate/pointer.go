@@ -0,0 +1,309 @@
+package configvalidate
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/concourse/concourse/atc"
+	"sigs.k8s.io/yaml"
+)
+
+// topLevelFields are the fields of a pipeline config which are understood by
+// atc.UnmarshalConfig. Any other top-level field is silently discarded.
+var topLevelFields = map[string]bool{
+	"groups":         true,
+	"vars":           true,
+	"var_sources":    true,
+	"resources":      true,
+	"resource_types": true,
+	"prototypes":     true,
+	"jobs":           true,
+	"notifications":  true,
+	"display":        true,
+}
+
+// ValidateDetailed validates the config just like Validate, but reports each
+// error individually, along with a JSON pointer to the part of the config it
+// is about where possible.
+func ValidateDetailed(c atc.Config) ([]atc.ConfigWarning, []atc.ConfigError) {
+	warnings, groupErrs := validate(c)
+
+	for i, warning := range warnings {
+		warnings[i].Pointer = Pointer(c, warning.Message)
+	}
+
+	configErrors := []atc.ConfigError{}
+	for _, groupErr := range groupErrs {
+		for _, message := range splitMessages(groupErr.err.Error()) {
+			configErrors = append(configErrors, atc.ConfigError{
+				Message: message,
+				Pointer: Pointer(c, message),
+			})
+		}
+	}
+
+	return warnings, configErrors
+}
+
+// UnknownFields returns a warning for each top-level field of the config
+// payload which is not part of the pipeline config schema. These fields are
+// discarded when the config is saved, so they are usually typos.
+func UnknownFields(payload []byte) ([]atc.ConfigWarning, error) {
+	var fields map[string]interface{}
+	err := yaml.Unmarshal(payload, &fields)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for name := range fields {
+		if !topLevelFields[name] {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	var warnings []atc.ConfigWarning
+	for _, name := range names {
+		warnings = append(warnings, atc.ConfigWarning{
+			Type:    "unknown_field",
+			Message: fmt.Sprintf("unknown field '%s' will be ignored", name),
+			Pointer: "/" + escapePointerToken(name),
+		})
+	}
+
+	return warnings, nil
+}
+
+// splitMessages splits the errors of a validation group into individual
+// messages. Lines which are indented continue the message before them.
+func splitMessages(err string) []string {
+	var messages []string
+	for _, line := range strings.Split(err, "\n") {
+		if len(messages) > 0 && (line == "" || line[0] == ' ' || line[0] == '\t') {
+			messages[len(messages)-1] += "\n" + line
+			continue
+		}
+
+		messages = append(messages, line)
+	}
+
+	return messages
+}
+
+// Pointer determines a JSON pointer into the config from the context that
+// prefixes a validation message, e.g. 'jobs.some-job.plan.do[0].task(x)'
+// becomes '/jobs/0/plan/0'. The pointer is only as precise as the context
+// allows, and is empty if the message does not refer to any section of the
+// config.
+func Pointer(c atc.Config, message string) string {
+	sections := []struct {
+		name  string
+		names []string
+	}{
+		{"groups", groupNames(c)},
+		{"vars", varNames(c)},
+		{"var_sources", varSourceNames(c)},
+		{"resources", resourceNames(c)},
+		{"resource_types", resourceTypeNames(c)},
+		{"prototypes", prototypeNames(c)},
+		{"jobs", jobNames(c)},
+		// notification rules have no names, so can only be referred to by
+		// index
+		{"notifications", make([]string, len(c.Notifications))},
+	}
+
+	for _, section := range sections {
+		rest := strings.TrimPrefix(message, section.name)
+		if rest == message {
+			continue
+		}
+
+		if strings.HasPrefix(rest, ":") {
+			return "/" + section.name
+		}
+
+		index, rest, found := sectionIndex(rest, section.names)
+		if !found {
+			continue
+		}
+
+		pointer := fmt.Sprintf("/%s/%d", section.name, index)
+		if section.name == "jobs" {
+			// the plan of a job is visited as an implicit do step
+			if strings.HasPrefix(rest, ".plan.do[") {
+				rest = strings.TrimPrefix(rest, ".plan.do")
+				pointer += "/plan"
+			}
+		}
+
+		return pointer + contextPointer(rest)
+	}
+
+	return ""
+}
+
+// sectionIndex parses either '[i]' or '.name' off of the start of the
+// context, returning the index of the element it refers to.
+func sectionIndex(context string, names []string) (int, string, bool) {
+	if strings.HasPrefix(context, "[") {
+		end := strings.Index(context, "]")
+		if end == -1 {
+			return 0, "", false
+		}
+
+		index, err := strconv.Atoi(context[1:end])
+		if err != nil || index < 0 || index >= len(names) {
+			return 0, "", false
+		}
+
+		return index, context[end+1:], true
+	}
+
+	if !strings.HasPrefix(context, ".") {
+		return 0, "", false
+	}
+
+	// names may contain dots, so prefer the longest name that matches
+	found := false
+	var index, length int
+	for i, name := range names {
+		if name == "" || len(name) <= length || !strings.HasPrefix(context[1:], name) {
+			continue
+		}
+
+		rest := context[1+len(name):]
+		if rest != "" && !strings.ContainsAny(rest[:1], ".[: ") {
+			continue
+		}
+
+		found = true
+		index = i
+		length = len(name)
+	}
+
+	if !found {
+		return 0, "", false
+	}
+
+	return index, context[1+length:], true
+}
+
+// contextPointer converts the remainder of a step validation context, e.g.
+// '.do[1].task(x).config', into a JSON pointer. Step names in parentheses
+// identify the step rather than a field, so they do not contribute to the
+// pointer. It stops at the first part it does not understand.
+func contextPointer(context string) string {
+	var pointer strings.Builder
+
+	for len(context) > 0 {
+		switch context[0] {
+		case '.':
+			end := 1
+			for end < len(context) && (context[end] == '_' || (context[end] >= 'a' && context[end] <= 'z')) {
+				end++
+			}
+
+			field := context[1:end]
+			if field == "" {
+				return pointer.String()
+			}
+
+			context = context[end:]
+
+			if strings.HasPrefix(context, "(") {
+				closing := strings.Index(context, ")")
+				if closing == -1 {
+					return pointer.String()
+				}
+
+				context = context[closing+1:]
+				continue
+			}
+
+			pointer.WriteString("/" + field)
+
+		case '[':
+			closing := strings.Index(context, "]")
+			if closing == -1 {
+				return pointer.String()
+			}
+
+			index, err := strconv.Atoi(context[1:closing])
+			if err != nil {
+				return pointer.String()
+			}
+
+			pointer.WriteString("/" + strconv.Itoa(index))
+			context = context[closing+1:]
+
+		default:
+			return pointer.String()
+		}
+	}
+
+	return pointer.String()
+}
+
+func escapePointerToken(token string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(token)
+}
+
+func groupNames(c atc.Config) []string {
+	var names []string
+	for _, group := range c.Groups {
+		names = append(names, group.Name)
+	}
+	return names
+}
+
+func varNames(c atc.Config) []string {
+	var names []string
+	for _, declaration := range c.Vars {
+		names = append(names, declaration.Name)
+	}
+	return names
+}
+
+func varSourceNames(c atc.Config) []string {
+	var names []string
+	for _, varSource := range c.VarSources {
+		names = append(names, varSource.Name)
+	}
+	return names
+}
+
+func resourceNames(c atc.Config) []string {
+	var names []string
+	for _, resource := range c.Resources {
+		names = append(names, resource.Name)
+	}
+	return names
+}
+
+func resourceTypeNames(c atc.Config) []string {
+	var names []string
+	for _, resourceType := range c.ResourceTypes {
+		names = append(names, resourceType.Name)
+	}
+	return names
+}
+
+func prototypeNames(c atc.Config) []string {
+	var names []string
+	for _, prototype := range c.Prototypes {
+		names = append(names, prototype.Name)
+	}
+	return names
+}
+
+func jobNames(c atc.Config) []string {
+	var names []string
+	for _, job := range c.Jobs {
+		names = append(names, job.Name)
+	}
+	return names
+}
@@ -39,18 +39,40 @@ func (l location) Identifier(name string) string {
 }
 
 func Validate(c atc.Config) ([]atc.ConfigWarning, []string) {
-	warnings := []atc.ConfigWarning{}
+	warnings, groupErrs := validate(c)
+
 	errorMessages := []string{}
+	for _, groupErr := range groupErrs {
+		errorMessages = append(errorMessages, formatErr(groupErr.group, groupErr.err))
+	}
+
+	return warnings, errorMessages
+}
+
+type groupErr struct {
+	group string
+	err   error
+}
+
+func validate(c atc.Config) ([]atc.ConfigWarning, []groupErr) {
+	warnings := []atc.ConfigWarning{}
+	errs := []groupErr{}
 
 	groupsWarnings, groupsErr := validateGroups(c)
 	if groupsErr != nil {
-		errorMessages = append(errorMessages, formatErr("groups", groupsErr))
+		errs = append(errs, groupErr{"groups", groupsErr})
 	}
 	warnings = append(warnings, groupsWarnings...)
 
+	varsWarnings, varsErr := validateVars(c)
+	if varsErr != nil {
+		errs = append(errs, groupErr{"vars", varsErr})
+	}
+	warnings = append(warnings, varsWarnings...)
+
 	resourcesWarnings, resourcesErr := validateResources(c)
 	if resourcesErr != nil {
-		errorMessages = append(errorMessages, formatErr("resources", resourcesErr))
+		errs = append(errs, groupErr{"resources", resourcesErr})
 	}
 	warnings = append(warnings, resourcesWarnings...)
 
@@ -58,41 +80,46 @@ func Validate(c atc.Config) ([]atc.ConfigWarning, []string) {
 
 	resourceTypesWarnings, resourceTypesErr := validateResourceTypes(c, seenTypes)
 	if resourceTypesErr != nil {
-		errorMessages = append(errorMessages, formatErr("resource types", resourceTypesErr))
+		errs = append(errs, groupErr{"resource types", resourceTypesErr})
 	}
 	warnings = append(warnings, resourceTypesWarnings...)
 
 	prototypesWarnings, prototypesErr := validatePrototypes(c, seenTypes)
 	if prototypesErr != nil {
-		errorMessages = append(errorMessages, formatErr("prototypes", prototypesErr))
+		errs = append(errs, groupErr{"prototypes", prototypesErr})
 	}
 	warnings = append(warnings, prototypesWarnings...)
 
 	varSourcesWarnings, varSourcesErr := validateVarSources(c)
 	if varSourcesErr != nil {
-		errorMessages = append(errorMessages, formatErr("variable sources", varSourcesErr))
+		errs = append(errs, groupErr{"variable sources", varSourcesErr})
 	}
 	warnings = append(warnings, varSourcesWarnings...)
 
 	jobWarnings, jobsErr := validateJobs(c)
 	if jobsErr != nil {
-		errorMessages = append(errorMessages, formatErr("jobs", jobsErr))
+		errs = append(errs, groupErr{"jobs", jobsErr})
 	}
 	warnings = append(warnings, jobWarnings...)
 
+	notificationsErr := validateNotifications(c)
+	if notificationsErr != nil {
+		errs = append(errs, groupErr{"notifications", notificationsErr})
+	}
+
 	displayWarnings, displayErr := validateDisplay(c)
 	if displayErr != nil {
-		errorMessages = append(errorMessages, formatErr("display config", displayErr))
+		errs = append(errs, groupErr{"display config", displayErr})
 	}
 	warnings = append(warnings, displayWarnings...)
 
 	cycleErr := validateCycle(c)
 
 	if cycleErr != nil {
-		errorMessages = append(errorMessages, formatErr("jobs", cycleErr))
+		errs = append(errs, groupErr{"jobs", cycleErr})
 	}
 
-	return warnings, errorMessages
+	return warnings, errs
 }
 
 func validateGroups(c atc.Config) ([]atc.ConfigWarning, error) {
@@ -130,7 +157,7 @@ func validateGroups(c atc.Config) ([]atc.ConfigWarning, error) {
 			g, err := glob.Compile(jobGlob)
 			if err != nil {
 				errorMessages = append(errorMessages,
-					fmt.Sprintf("invalid glob expression '%s' for group '%s'", jobGlob, group.Name))
+					fmt.Sprintf("%s: invalid glob expression '%s'", identifier, jobGlob))
 				continue
 			}
 			for _, job := range c.Jobs {
@@ -141,7 +168,7 @@ func validateGroups(c atc.Config) ([]atc.ConfigWarning, error) {
 			}
 			if !matchingJob {
 				errorMessages = append(errorMessages,
-					fmt.Sprintf("no jobs match '%s' for group '%s'", jobGlob, group.Name))
+					fmt.Sprintf("%s: no jobs match '%s'", identifier, jobGlob))
 			}
 		}
 
@@ -149,9 +176,18 @@ func validateGroups(c atc.Config) ([]atc.ConfigWarning, error) {
 			_, exists := c.Resources.Lookup(resource)
 			if !exists {
 				errorMessages = append(errorMessages,
-					fmt.Sprintf("group '%s' has unknown resource '%s'", group.Name, resource))
+					fmt.Sprintf("%s: unknown resource '%s'", identifier, resource))
 			}
 		}
+
+		if len(group.Jobs) == 0 {
+			warnings = append(warnings, atc.ConfigWarning{
+				Type:    "empty_group",
+				Message: fmt.Sprintf("%s: group has no jobs", identifier),
+			})
+		}
+
+		warnings = append(warnings, spanningWarnings(c, group, identifier)...)
 	}
 
 	for groupName, groupCount := range groupNames {
@@ -162,9 +198,9 @@ func validateGroups(c atc.Config) ([]atc.ConfigWarning, error) {
 	}
 
 	if len(c.Groups) != 0 {
-		for job, grouped := range jobsGrouped {
-			if !grouped {
-				errorMessages = append(errorMessages, fmt.Sprintf("job '%s' belongs to no group", job))
+		for _, job := range c.Jobs {
+			if !jobsGrouped[job.Name] {
+				errorMessages = append(errorMessages, fmt.Sprintf("jobs.%s: job belongs to no group", job.Name))
 			}
 		}
 	}
@@ -172,6 +208,96 @@ func validateGroups(c atc.Config) ([]atc.ConfigWarning, error) {
 	return warnings, compositeErr(errorMessages)
 }
 
+// groupJobs returns the jobs matched by the globs of the group. Invalid globs
+// are reported by validateGroups, and are ignored here.
+func groupJobs(c atc.Config, group atc.GroupConfig) []atc.JobConfig {
+	var jobs []atc.JobConfig
+	for _, job := range c.Jobs {
+		for _, jobGlob := range group.Jobs {
+			g, err := glob.Compile(jobGlob)
+			if err == nil && g.Match(job.Name) {
+				jobs = append(jobs, job)
+				break
+			}
+		}
+	}
+
+	return jobs
+}
+
+// spanningWarnings warns about jobs in the group which depend on jobs that
+// are not in it. The pipeline graph of the group can't show where their
+// inputs come from.
+func spanningWarnings(c atc.Config, group atc.GroupConfig, identifier string) []atc.ConfigWarning {
+	jobs := groupJobs(c, group)
+
+	inGroup := map[string]bool{}
+	for _, job := range jobs {
+		inGroup[job.Name] = true
+	}
+
+	var warnings []atc.ConfigWarning
+	for _, job := range jobs {
+		seen := map[string]bool{}
+
+		_ = job.StepConfig().Visit(atc.StepRecursor{
+			OnGet: func(step *atc.GetStep) error {
+				for _, passed := range step.Passed {
+					if inGroup[passed] || seen[passed] {
+						continue
+					}
+
+					if _, found := c.Jobs.Lookup(passed); !found {
+						// reported as an error by validateJobs
+						continue
+					}
+
+					seen[passed] = true
+
+					warnings = append(warnings, atc.ConfigWarning{
+						Type:    "group_spans_jobs",
+						Message: fmt.Sprintf("%s: job '%s' depends on job '%s', which is not in the group", identifier, job.Name, passed),
+					})
+				}
+
+				return nil
+			},
+		})
+	}
+
+	return warnings
+}
+
+func validateVars(c atc.Config) ([]atc.ConfigWarning, error) {
+	var warnings []atc.ConfigWarning
+	var errorMessages []string
+
+	names := map[string]location{}
+
+	for i, declaration := range c.Vars {
+		location := location{section: "vars", index: i}
+		identifier := location.Identifier(declaration.Name)
+
+		if declaration.Name == "" {
+			errorMessages = append(errorMessages, identifier+" has no name")
+		} else if other, exists := names[declaration.Name]; exists {
+			errorMessages = append(errorMessages,
+				fmt.Sprintf(
+					"%s and %s have the same name ('%s')",
+					other, location, declaration.Name))
+		} else {
+			names[declaration.Name] = location
+		}
+
+		err := declaration.Validate()
+		if err != nil {
+			errorMessages = append(errorMessages, fmt.Sprintf("%s: %s", identifier, err))
+		}
+	}
+
+	return warnings, compositeErr(errorMessages)
+}
+
 func validateResources(c atc.Config) ([]atc.ConfigWarning, error) {
 	var warnings []atc.ConfigWarning
 	var errorMessages []string
@@ -206,6 +332,12 @@ func validateResources(c atc.Config) ([]atc.ConfigWarning, error) {
 		if resource.Type == "" {
 			errorMessages = append(errorMessages, identifier+" has no type")
 		}
+
+		if resource.Push != nil {
+			pushWarnings, pushErrs := validatePush(identifier, resource)
+			warnings = append(warnings, pushWarnings...)
+			errorMessages = append(errorMessages, pushErrs...)
+		}
 	}
 
 	errorMessages = append(errorMessages, validateResourcesUnused(c)...)
@@ -213,6 +345,35 @@ func validateResources(c atc.Config) ([]atc.ConfigWarning, error) {
 	return warnings, compositeErr(errorMessages)
 }
 
+func validatePush(identifier string, resource atc.ResourceConfig) ([]atc.ConfigWarning, []string) {
+	var warnings []atc.ConfigWarning
+	var errorMessages []string
+
+	if len(resource.Push.Version) == 0 {
+		errorMessages = append(errorMessages, identifier+".push: must declare the fields of the version")
+	}
+
+	seen := map[string]bool{}
+	for _, field := range resource.Push.Version {
+		if field == "" {
+			errorMessages = append(errorMessages, identifier+".push: version field has no name")
+		} else if seen[field] {
+			errorMessages = append(errorMessages, fmt.Sprintf("%s.push: version field '%s' is declared more than once", identifier, field))
+		}
+
+		seen[field] = true
+	}
+
+	if resource.CheckEvery != nil || resource.WebhookToken != "" {
+		warnings = append(warnings, atc.ConfigWarning{
+			Type:    "push_mode",
+			Message: identifier + ": resource is in push mode and never checked, so check_every and webhook_token have no effect",
+		})
+	}
+
+	return warnings, errorMessages
+}
+
 func validateResourceTypes(c atc.Config, seenTypes map[string]location) ([]atc.ConfigWarning, error) {
 	var warnings []atc.ConfigWarning
 	var errorMessages []string
@@ -293,7 +454,7 @@ func validateResourcesUnused(c atc.Config) []string {
 	var errorMessages []string
 	for _, resource := range c.Resources {
 		if _, used := usedResources[resource.Name]; !used {
-			message := fmt.Sprintf("resource '%s' is not used", resource.Name)
+			message := fmt.Sprintf("resources.%s: resource is not used", resource.Name)
 			errorMessages = append(errorMessages, message)
 		}
 	}
@@ -366,6 +527,11 @@ func validateJobs(c atc.Config) ([]atc.ConfigWarning, error) {
 				errorMessages,
 				identifier+fmt.Sprintf(" has negative build_logs_to_retain: %d", job.BuildLogsToRetain),
 			)
+		} else if job.BuildLogsToRetain > 0 {
+			warnings = append(warnings, atc.ConfigWarning{
+				Type:    "deprecation",
+				Message: identifier + ": build_logs_to_retain is deprecated, use build_log_retention.builds instead",
+			})
 		}
 
 		if job.BuildLogRetention != nil {
@@ -395,6 +561,26 @@ func validateJobs(c atc.Config) ([]atc.ConfigWarning, error) {
 			}
 		}
 
+		if job.RetryOnWorkerError < 0 {
+			errorMessages = append(
+				errorMessages,
+				identifier+fmt.Sprintf(" has negative retry_on_worker_error: %d", job.RetryOnWorkerError),
+			)
+		}
+
+		if job.WarmPool != nil {
+			if err := job.WarmPool.Validate(); err != nil {
+				errorMessages = append(errorMessages, fmt.Sprintf("%s.warm_pool: %s", identifier, err))
+			}
+		}
+
+		for j, rule := range job.Notifications {
+			err := rule.Validate()
+			if err != nil {
+				errorMessages = append(errorMessages, fmt.Sprintf("%s.notifications[%d]: %s", identifier, j, err))
+			}
+		}
+
 		step := job.Step()
 
 		validator := atc.NewStepValidator(c, []string{identifier, ".plan"})
@@ -409,6 +595,19 @@ func validateJobs(c atc.Config) ([]atc.ConfigWarning, error) {
 	return warnings, compositeErr(errorMessages)
 }
 
+func validateNotifications(c atc.Config) error {
+	var errorMessages []string
+
+	for i, rule := range c.Notifications {
+		err := rule.Validate()
+		if err != nil {
+			errorMessages = append(errorMessages, fmt.Sprintf("notifications[%d]: %s", i, err))
+		}
+	}
+
+	return compositeErr(errorMessages)
+}
+
 func compositeErr(errorMessages []string) error {
 	if len(errorMessages) == 0 {
 		return nil
@@ -509,7 +708,7 @@ func detectCycle(j atc.JobConfig, visited map[string]int, pipelineConfig atc.Con
 			for _, nextJobName := range step.Passed {
 				nextJob := findJobByName(nextJobName, pipelineConfig.Jobs)
 				if visited[nextJobName] == semiVisited {
-					return fmt.Errorf("pipeline contains a cycle that starts at Job '%s'", nextJobName)
+					return fmt.Errorf("jobs.%s: pipeline contains a cycle that starts at Job '%s'", nextJobName, nextJobName)
 				} else if visited[nextJobName] == nonVisited {
 					err := detectCycle(nextJob, visited, pipelineConfig)
 					if err != nil {
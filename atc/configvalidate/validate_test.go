@@ -3,6 +3,7 @@ package configvalidate_test
 import (
 	"encoding/json"
 	"strings"
+	"time"
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/configvalidate"
@@ -11,6 +12,7 @@ import (
 	_ "github.com/concourse/concourse/atc/creds/dummy"
 
 	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
 )
 
@@ -296,7 +298,7 @@ var _ = Describe("ValidateConfig", func() {
 			It("returns an error", func() {
 				Expect(errorMessages).To(HaveLen(1))
 				Expect(errorMessages[0]).To(ContainSubstring("invalid groups:"))
-				Expect(errorMessages[0]).To(ContainSubstring("unknown resource 'bogus-resource'"))
+				Expect(errorMessages[0]).To(ContainSubstring("groups.bogus: unknown resource 'bogus-resource'"))
 			})
 		})
 
@@ -311,7 +313,7 @@ var _ = Describe("ValidateConfig", func() {
 			It("returns an error", func() {
 				Expect(errorMessages).To(HaveLen(1))
 				Expect(errorMessages[0]).To(ContainSubstring("invalid groups:"))
-				Expect(errorMessages[0]).To(ContainSubstring("no jobs match 'bogus-*' for group 'bogus'"))
+				Expect(errorMessages[0]).To(ContainSubstring("groups.bogus: no jobs match 'bogus-*'"))
 			})
 		})
 
@@ -328,8 +330,8 @@ var _ = Describe("ValidateConfig", func() {
 			It("returns an error", func() {
 				Expect(errorMessages).To(HaveLen(1))
 				Expect(errorMessages[0]).To(ContainSubstring("invalid groups:"))
-				Expect(errorMessages[0]).To(ContainSubstring("job 'stand-alone-job' belongs to no group"))
-				Expect(errorMessages[0]).To(ContainSubstring("job 'other-stand-alone-job' belongs to no group"))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.stand-alone-job: job belongs to no group"))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.other-stand-alone-job: job belongs to no group"))
 			})
 
 		})
@@ -380,7 +382,175 @@ var _ = Describe("ValidateConfig", func() {
 			It("returns an error", func() {
 				Expect(errorMessages).To(HaveLen(1))
 				Expect(errorMessages[0]).To(ContainSubstring("invalid groups:"))
-				Expect(errorMessages[0]).To(ContainSubstring("invalid glob expression 'some-bad-glob-[0-9' for group 'a-group'"))
+				Expect(errorMessages[0]).To(ContainSubstring("groups.a-group: invalid glob expression 'some-bad-glob-[0-9'"))
+			})
+		})
+
+		Context("when a group has no jobs", func() {
+			BeforeEach(func() {
+				config.Groups = append(config.Groups, atc.GroupConfig{
+					Name:      "resources-only",
+					Resources: []string{"some-resource"},
+				})
+			})
+
+			It("returns a warning", func() {
+				Expect(errorMessages).To(BeEmpty())
+				Expect(warnings).To(ConsistOf(atc.ConfigWarning{
+					Type:    "empty_group",
+					Message: "groups.resources-only: group has no jobs",
+				}))
+			})
+		})
+
+		Context("when a job depends on a job in another group", func() {
+			BeforeEach(func() {
+				config.Jobs[1].PlanSequence = []atc.Step{
+					{
+						Config: &atc.GetStep{
+							Name:   "some-resource",
+							Passed: []string{"some-job"},
+						},
+					},
+					{
+						Config: &atc.GetStep{
+							Name:     "some-input",
+							Resource: "some-resource",
+							Passed:   []string{"some-job"},
+						},
+					},
+				}
+			})
+
+			It("returns a warning", func() {
+				Expect(errorMessages).To(BeEmpty())
+				Expect(warnings).To(ConsistOf(atc.ConfigWarning{
+					Type:    "group_spans_jobs",
+					Message: "groups.some-other-group: job 'some-empty-job' depends on job 'some-job', which is not in the group",
+				}))
+			})
+
+			Context("when the job is in both groups", func() {
+				BeforeEach(func() {
+					config.Groups[1].Jobs = append(config.Groups[1].Jobs, "some-job")
+				})
+
+				It("does not warn", func() {
+					Expect(warnings).To(BeEmpty())
+				})
+			})
+
+			It("points at the group", func() {
+				warnings, _ := configvalidate.ValidateDetailed(config)
+				Expect(warnings).To(HaveLen(1))
+				Expect(warnings[0].Pointer).To(Equal("/groups/1"))
+			})
+		})
+
+		It("points at the locations of errors", func() {
+			config.Jobs = append(config.Jobs, atc.JobConfig{Name: "stand-alone-job"})
+			config.Groups[1].Resources = []string{"bogus-resource"}
+
+			_, configErrors := configvalidate.ValidateDetailed(config)
+			Expect(configErrors).To(ConsistOf(
+				atc.ConfigError{
+					Message: "groups.some-other-group: unknown resource 'bogus-resource'",
+					Pointer: "/groups/1",
+				},
+				atc.ConfigError{
+					Message: "jobs.stand-alone-job: job belongs to no group",
+					Pointer: "/jobs/2",
+				},
+			))
+		})
+	})
+
+	Describe("invalid vars", func() {
+		Context("when a var has no name", func() {
+			BeforeEach(func() {
+				config.Vars = append(config.Vars, atc.VarDeclaration{Default: "x"})
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("vars[0] has no name"))
+			})
+		})
+
+		Context("when a var is declared twice", func() {
+			BeforeEach(func() {
+				config.Vars = append(config.Vars,
+					atc.VarDeclaration{Name: "some-var"},
+					atc.VarDeclaration{Name: "some-var"},
+				)
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("vars[0] and vars[1] have the same name ('some-var')"))
+			})
+		})
+
+		Context("when the default does not match the type", func() {
+			BeforeEach(func() {
+				config.Vars = append(config.Vars, atc.VarDeclaration{Name: "some-var", Type: "number", Default: "one"})
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("vars.some-var: default is not of type number"))
+			})
+		})
+	})
+
+	Describe("invalid notifications", func() {
+		Context("when a pipeline rule has an unknown event", func() {
+			BeforeEach(func() {
+				config.Notifications = atc.NotificationRules{
+					{On: []atc.NotificationEvent{"explosion"}, Notify: []string{"some-notifier"}},
+				}
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("notifications[0]: unknown event 'explosion' (must be one of failure, error, abort, recovery)"))
+			})
+		})
+
+		Context("when a job rule has no notifiers", func() {
+			BeforeEach(func() {
+				config.Jobs[0].Notifications = atc.NotificationRules{
+					{On: []atc.NotificationEvent{atc.NotificationEventFailure}},
+				}
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring(".notifications[0]: no notifiers specified"))
+			})
+		})
+	})
+
+	Describe("invalid warm pools", func() {
+		Context("when the size is not positive", func() {
+			BeforeEach(func() {
+				config.Jobs[0].WarmPool = &atc.WarmPoolConfig{Size: 0}
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring(".warm_pool: size must be at least 1"))
+			})
+		})
+
+		Context("when the expiry is not a duration", func() {
+			BeforeEach(func() {
+				config.Jobs[0].WarmPool = &atc.WarmPoolConfig{Size: 2, Expiry: "forever"}
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring(".warm_pool: invalid expiry"))
 			})
 		})
 	})
@@ -539,6 +709,44 @@ var _ = Describe("ValidateConfig", func() {
 			})
 		})
 
+		Context("when a resource in push mode declares no version fields", func() {
+			BeforeEach(func() {
+				config.Resources[0].Push = &atc.PushConfig{}
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid resources:"))
+				Expect(errorMessages[0]).To(ContainSubstring("resources.some-resource.push: must declare the fields of the version"))
+			})
+		})
+
+		Context("when a resource in push mode declares a version field twice", func() {
+			BeforeEach(func() {
+				config.Resources[0].Push = &atc.PushConfig{Version: []string{"ref", "ref"}}
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("resources.some-resource.push: version field 'ref' is declared more than once"))
+			})
+		})
+
+		Context("when a resource in push mode has a check interval", func() {
+			BeforeEach(func() {
+				config.Resources[0].Push = &atc.PushConfig{Version: []string{"ref"}}
+				config.Resources[0].CheckEvery = &atc.CheckEvery{Interval: time.Minute}
+			})
+
+			It("warns that it is never checked", func() {
+				Expect(errorMessages).To(BeEmpty())
+				Expect(warnings).To(ContainElement(atc.ConfigWarning{
+					Type:    "push_mode",
+					Message: "resources.some-resource: resource is in push mode and never checked, so check_every and webhook_token have no effect",
+				}))
+			})
+		})
+
 		Context("when a resource has no name or type", func() {
 			BeforeEach(func() {
 				config.Resources = append(config.Resources, atc.ResourceConfig{
@@ -793,9 +1001,9 @@ var _ = Describe("ValidateConfig", func() {
 		Context("when a resource is not used in any jobs", func() {
 			It("returns an error", func() {
 				Expect(errorMessages).To(HaveLen(1))
-				Expect(errorMessages[0]).To(ContainSubstring("resource 'unused-resource' is not used"))
-				Expect(errorMessages[0]).To(ContainSubstring("resource 'get-alias' is not used"))
-				Expect(errorMessages[0]).To(ContainSubstring("resource 'put-alias' is not used"))
+				Expect(errorMessages[0]).To(ContainSubstring("resources.unused-resource: resource is not used"))
+				Expect(errorMessages[0]).To(ContainSubstring("resources.get-alias: resource is not used"))
+				Expect(errorMessages[0]).To(ContainSubstring("resources.put-alias: resource is not used"))
 			})
 		})
 	})
@@ -969,6 +1177,19 @@ var _ = Describe("ValidateConfig", func() {
 			})
 		})
 
+		Context("when a job has a negative retry_on_worker_error", func() {
+			BeforeEach(func() {
+				job.RetryOnWorkerError = -1
+				config.Jobs = append(config.Jobs, job)
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job has negative retry_on_worker_error: -1"))
+			})
+		})
+
 		Context("when a job has duplicate inputs", func() {
 			BeforeEach(func() {
 				job.PlanSequence = append(job.PlanSequence, atc.Step{
@@ -1119,6 +1340,238 @@ var _ = Describe("ValidateConfig", func() {
 				})
 			})
 
+			Context("when a windows task plan specifies an image", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.TaskStep{
+							Name:              "some-task",
+							ImageArtifactName: "some-image",
+							Config: &atc.TaskConfig{
+								Platform: "windows",
+								ImageResource: &atc.ImageResource{
+									Type: "registry-image",
+								},
+								Run: atc.TaskRunConfig{
+									Path: "powershell",
+								},
+							},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].task(some-task): specifies image: on the step, but tasks on the 'windows' platform run without a container image"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].task(some-task).config: image_resource is not supported for tasks on the 'windows' platform"))
+				})
+			})
+
+			Describe("task artifacts", func() {
+				var task *atc.TaskStep
+
+				BeforeEach(func() {
+					task = &atc.TaskStep{
+						Name: "some-task",
+						Config: &atc.TaskConfig{
+							Platform: "linux",
+							Run: atc.TaskRunConfig{
+								Path: "make",
+							},
+							Inputs: []atc.TaskInputConfig{
+								{Name: "some-resource"},
+								{Name: "some-cache", Optional: true},
+							},
+							Outputs: []atc.TaskOutputConfig{
+								{Name: "some-output"},
+							},
+						},
+					}
+				})
+
+				Context("when the inputs are produced by prior steps", func() {
+					BeforeEach(func() {
+						job.PlanSequence = append(job.PlanSequence,
+							atc.Step{Config: &atc.GetStep{Name: "some-resource"}},
+							atc.Step{Config: task},
+							atc.Step{Config: &atc.TaskStep{
+								Name: "use-output",
+								Config: &atc.TaskConfig{
+									Platform: "linux",
+									Run:      atc.TaskRunConfig{Path: "ls"},
+									Inputs:   []atc.TaskInputConfig{{Name: "some-output"}},
+								},
+							}},
+						)
+
+						config.Jobs = append(config.Jobs, job)
+					})
+
+					It("returns no error", func() {
+						Expect(errorMessages).To(BeEmpty())
+					})
+				})
+
+				Context("when an input is not produced by a prior step", func() {
+					BeforeEach(func() {
+						job.PlanSequence = append(job.PlanSequence,
+							atc.Step{Config: task},
+							atc.Step{Config: &atc.GetStep{Name: "some-resource"}},
+						)
+
+						config.Jobs = append(config.Jobs, job)
+					})
+
+					It("returns an error", func() {
+						Expect(errorMessages).To(HaveLen(1))
+						Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].task(some-task): input 'some-resource' is not produced by a prior step"))
+						Expect(errorMessages[0]).ToNot(ContainSubstring("some-cache"))
+					})
+				})
+
+				Context("when an input is mapped to an artifact that is not produced", func() {
+					BeforeEach(func() {
+						task.InputMapping = map[string]string{"some-resource": "bogus"}
+
+						job.PlanSequence = append(job.PlanSequence,
+							atc.Step{Config: &atc.GetStep{Name: "some-resource"}},
+							atc.Step{Config: task},
+						)
+
+						config.Jobs = append(config.Jobs, job)
+					})
+
+					It("returns an error", func() {
+						Expect(errorMessages).To(HaveLen(1))
+						Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[1].task(some-task): input 'some-resource' (mapped to 'bogus') is not produced by a prior step"))
+					})
+				})
+
+				Context("when the input is produced by a step running in parallel", func() {
+					BeforeEach(func() {
+						job.PlanSequence = append(job.PlanSequence,
+							atc.Step{Config: &atc.InParallelStep{
+								Config: atc.InParallelConfig{
+									Steps: []atc.Step{
+										{Config: &atc.GetStep{Name: "some-resource"}},
+										{Config: task},
+									},
+								},
+							}},
+						)
+
+						config.Jobs = append(config.Jobs, job)
+					})
+
+					It("returns an error", func() {
+						Expect(errorMessages).To(HaveLen(1))
+						Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].in_parallel.steps[1].task(some-task): input 'some-resource' is not produced by a prior step"))
+					})
+				})
+
+				Context("when steps running in parallel produce the same artifact", func() {
+					BeforeEach(func() {
+						job.PlanSequence = append(job.PlanSequence,
+							atc.Step{Config: &atc.GetStep{Name: "some-resource"}},
+							atc.Step{Config: &atc.InParallelStep{
+								Config: atc.InParallelConfig{
+									Steps: []atc.Step{
+										{Config: task},
+										{Config: &atc.PutStep{Name: "some-output", Resource: "some-resource"}},
+									},
+								},
+							}},
+						)
+
+						config.Jobs = append(config.Jobs, job)
+					})
+
+					It("returns an error", func() {
+						Expect(errorMessages).To(HaveLen(1))
+						Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[1].in_parallel: steps[0] and steps[1] both produce artifact 'some-output'"))
+					})
+				})
+
+				Context("when outputs are mapped to the same artifact", func() {
+					BeforeEach(func() {
+						task.Config.Outputs = append(task.Config.Outputs, atc.TaskOutputConfig{Name: "other-output"})
+						task.OutputMapping = map[string]string{"other-output": "some-output"}
+
+						job.PlanSequence = append(job.PlanSequence,
+							atc.Step{Config: &atc.GetStep{Name: "some-resource"}},
+							atc.Step{Config: task},
+						)
+
+						config.Jobs = append(config.Jobs, job)
+					})
+
+					It("returns an error", func() {
+						Expect(errorMessages).To(HaveLen(1))
+						Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[1].task(some-task): outputs 'some-output' and 'other-output' are both mapped to 'some-output'"))
+					})
+				})
+
+				Context("when the artifacts are named after the vars of an across step", func() {
+					BeforeEach(func() {
+						task.InputMapping = map[string]string{"some-resource": "some-resource-((.:target))"}
+						task.OutputMapping = map[string]string{"some-output": "some-output-((.:target))"}
+						task.ImageArtifactName = "image-((.:target))"
+
+						job.PlanSequence = append(job.PlanSequence,
+							atc.Step{Config: &atc.GetStep{Name: "some-resource-a", Resource: "some-resource"}},
+							atc.Step{Config: &atc.GetStep{Name: "image-a", Resource: "some-resource"}},
+							atc.Step{Config: &atc.AcrossStep{
+								Step: task,
+								Vars: []atc.AcrossVarConfig{
+									{Var: "target", Values: []interface{}{"a"}},
+								},
+							}},
+							atc.Step{Config: &atc.TaskStep{
+								Name: "use-output",
+								Config: &atc.TaskConfig{
+									Platform: "linux",
+									Run:      atc.TaskRunConfig{Path: "ls"},
+									Inputs:   []atc.TaskInputConfig{{Name: "some-output-a"}},
+								},
+							}},
+						)
+
+						config.Jobs = append(config.Jobs, job)
+					})
+
+					It("leaves them to be resolved once the build runs", func() {
+						Expect(errorMessages).To(BeEmpty())
+					})
+				})
+
+				Context("when a prior task's config is in a file", func() {
+					BeforeEach(func() {
+						job.PlanSequence = append(job.PlanSequence,
+							atc.Step{Config: &atc.GetStep{Name: "some-resource"}},
+							atc.Step{Config: &atc.TaskStep{
+								Name:       "from-file",
+								ConfigPath: "some-resource/task.yml",
+							}},
+							atc.Step{Config: &atc.TaskStep{
+								Name: "use-output",
+								Config: &atc.TaskConfig{
+									Platform: "linux",
+									Run:      atc.TaskRunConfig{Path: "ls"},
+									Inputs:   []atc.TaskInputConfig{{Name: "whatever-it-outputs"}},
+								},
+							}},
+						)
+
+						config.Jobs = append(config.Jobs, job)
+					})
+
+					It("does not know its outputs, so returns no error", func() {
+						Expect(errorMessages).To(BeEmpty())
+					})
+				})
+			})
+
 			Context("when a put plan has refers to a resource that does exist", func() {
 				BeforeEach(func() {
 					job.PlanSequence = append(job.PlanSequence, atc.Step{
@@ -1756,6 +2209,45 @@ var _ = Describe("ValidateConfig", func() {
 				})
 			})
 
+			Context("when a plan has an invalid condition in a step", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.WhenStep{
+							Step: &atc.GetStep{
+								Name: "some-resource",
+							},
+							Condition: "sometimes",
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("throws a validation error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].when: invalid condition 'sometimes'"))
+				})
+			})
+
+			Context("when a plan has a condition set by a var", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.WhenStep{
+							Step: &atc.GetStep{
+								Name: "some-resource",
+							},
+							Condition: "((.:flag))",
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does not throw a validation error", func() {
+					Expect(errorMessages).To(BeEmpty())
+				})
+			})
+
 			Context("when a retry plan has a negative attempts number", func() {
 				BeforeEach(func() {
 					job.PlanSequence = append(job.PlanSequence, atc.Step{
@@ -2290,7 +2782,7 @@ var _ = Describe("ValidateConfig", func() {
 			})
 			It("detects a cycle", func() {
 				Expect(errorMessages).To(HaveLen(1))
-				Expect(errorMessages[0]).To(ContainSubstring("pipeline contains a cycle that starts at Job 'some-job-1'"))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-job-1: pipeline contains a cycle that starts at Job 'some-job-1'"))
 			})
 
 		})
@@ -2440,3 +2932,54 @@ var _ = Describe("ValidateConfig", func() {
 		})
 	})
 })
+
+var _ = Describe("Pointer", func() {
+	var config atc.Config
+
+	BeforeEach(func() {
+		config = atc.Config{
+			Resources: atc.ResourceConfigs{
+				{Name: "some-resource"},
+				{Name: "some-resource.v2"},
+			},
+			Jobs: atc.JobConfigs{
+				{Name: "some-job"},
+				{Name: "some-other-job"},
+			},
+		}
+	})
+
+	DescribeTable("converts the context of a message into a JSON pointer",
+		func(message string, pointer string) {
+			Expect(configvalidate.Pointer(config, message)).To(Equal(pointer))
+		},
+		Entry("a named element", "resources.some-resource has no type", "/resources/0"),
+		Entry("a name containing dots", "resources.some-resource.v2 has no type", "/resources/1"),
+		Entry("an indexed element", "jobs[1] has no name", "/jobs/1"),
+		Entry("a section", "jobs: pipeline must contain at least one job", "/jobs"),
+		Entry("a step in the plan", "jobs.some-other-job.plan.do[2].get(x): unknown resource 'x'", "/jobs/1/plan/2"),
+		Entry("a field of a nested step", "jobs.some-job.plan.do[0].in_parallel.steps[1].task(t).config: missing 'platform'", "/jobs/0/plan/0/in_parallel/steps/1/config"),
+		Entry("a hook", "jobs.some-job.plan.do[0].put(p).on_failure.do[3].task(t): must specify either `file:` or `config:`", "/jobs/0/plan/0/on_failure/do/3"),
+		Entry("an unknown name", "jobs.bogus has no name", ""),
+		Entry("no context", "resource 'some-resource' is not used", ""),
+	)
+})
+
+var _ = Describe("UnknownFields", func() {
+	It("warns about top-level fields which are not part of the schema", func() {
+		warnings, err := configvalidate.UnknownFields([]byte("jobs: []\nresource: []\nshared/anchors: {}\n"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(warnings).To(Equal([]atc.ConfigWarning{
+			{
+				Type:    "unknown_field",
+				Message: "unknown field 'resource' will be ignored",
+				Pointer: "/resource",
+			},
+			{
+				Type:    "unknown_field",
+				Message: "unknown field 'shared/anchors' will be ignored",
+				Pointer: "/shared~1anchors",
+			},
+		}))
+	})
+})
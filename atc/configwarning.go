@@ -9,6 +9,19 @@ import (
 type ConfigWarning struct {
 	Type    string `json:"type"`
 	Message string `json:"message"`
+
+	// Pointer is a JSON pointer (RFC 6901) to the part of the config the
+	// warning is about, if it could be determined.
+	Pointer string `json:"pointer,omitempty"`
+}
+
+// ConfigError is an error which prevents a config from being saved.
+type ConfigError struct {
+	Message string `json:"message"`
+
+	// Pointer is a JSON pointer (RFC 6901) to the part of the config the
+	// error is about, if it could be determined.
+	Pointer string `json:"pointer,omitempty"`
 }
 
 var validIdentifiers = regexp.MustCompile(`^[\p{Ll}\p{Lt}\p{Lm}\p{Lo}][\p{Ll}\p{Lt}\p{Lm}\p{Lo}\d\-_.]*$`)
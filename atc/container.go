@@ -30,6 +30,11 @@ type Container struct {
 	ExpiresIn string `json:"expires_in,omitempty"`
 }
 
+// ContainerProcess is a process running in a container.
+type ContainerProcess struct {
+	ID string `json:"id"`
+}
+
 const (
 	ContainerStateCreated    = "created"
 	ContainerStateCreating   = "creating"
@@ -0,0 +1,34 @@
+package atc
+
+// CreateJobBuildRequestBody is the optional body of a request to trigger a
+// job, pinning some of its inputs and setting some vars for the triggered
+// build only.
+//
+// ExternalRef is an ID the client gives the build, e.g. a pull request number
+// and commit SHA, by which it can be looked up later. Triggering the job again
+// with the same ref returns the build already created for it.
+type CreateJobBuildRequestBody struct {
+	Inputs      []BuildInputPin `json:"inputs,omitempty"`
+	Vars        []BuildVar      `json:"vars,omitempty"`
+	ExternalRef string          `json:"external_ref,omitempty"`
+}
+
+// BuildInputPin pins an input of a triggered build to a version, given either
+// by the version itself or by the ID of the resource version. The version has
+// to have passed the jobs the input is constrained by, unless OverridePassed
+// is set.
+type BuildInputPin struct {
+	Name           string  `json:"name"`
+	Version        Version `json:"version,omitempty"`
+	VersionID      int     `json:"version_id,omitempty"`
+	OverridePassed bool    `json:"override_passed,omitempty"`
+}
+
+// BuildVar is a var set for a triggered build, taking precedence over the
+// pipeline's vars and credentials of the same name. The value of a secret var
+// is left out when the build is shown.
+type BuildVar struct {
+	Name   string      `json:"name"`
+	Value  interface{} `json:"value,omitempty"`
+	Secret bool        `json:"secret,omitempty"`
+}
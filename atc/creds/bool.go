@@ -0,0 +1,47 @@
+package creds
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/concourse/concourse/vars"
+)
+
+type Bool struct {
+	variablesResolver vars.Variables
+	raw               interface{}
+}
+
+func NewBool(variables vars.Variables, raw interface{}) Bool {
+	return Bool{
+		variablesResolver: variables,
+		raw:               raw,
+	}
+}
+
+// Evaluate interpolates the value and interprets it as a bool. Strings such as
+// "true" and "false" are accepted as well, since that is what a var loaded
+// from a file will usually be.
+func (b Bool) Evaluate() (bool, error) {
+	var value interface{}
+
+	err := evaluate(b.variablesResolver, b.raw, &value)
+	if err != nil {
+		return false, err
+	}
+
+	switch v := value.(type) {
+	case bool:
+		return v, nil
+	case string:
+		parsed, err := strconv.ParseBool(strings.TrimSpace(v))
+		if err != nil {
+			return false, fmt.Errorf("invalid bool '%s'", v)
+		}
+
+		return parsed, nil
+	default:
+		return false, fmt.Errorf("invalid bool '%v'", value)
+	}
+}
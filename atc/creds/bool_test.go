@@ -0,0 +1,39 @@
+package creds_test
+
+import (
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/vars"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Bool", func() {
+	It("passes through a bool", func() {
+		value, err := creds.NewBool(vars.StaticVariables{}, true).Evaluate()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(value).To(BeTrue())
+	})
+
+	It("interpolates a bool var", func() {
+		value, err := creds.NewBool(vars.StaticVariables{"flag": false}, "((flag))").Evaluate()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(value).To(BeFalse())
+	})
+
+	It("parses a string var", func() {
+		value, err := creds.NewBool(vars.StaticVariables{"flag": "true\n"}, "((flag))").Evaluate()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(value).To(BeTrue())
+	})
+
+	It("errors on anything else", func() {
+		_, err := creds.NewBool(vars.StaticVariables{"flag": "maybe"}, "((flag))").Evaluate()
+		Expect(err).To(MatchError("invalid bool 'maybe'"))
+	})
+
+	It("errors when the var is missing", func() {
+		_, err := creds.NewBool(vars.StaticVariables{}, "((flag))").Evaluate()
+		Expect(err).To(HaveOccurred())
+	})
+})
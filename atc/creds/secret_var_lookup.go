@@ -17,7 +17,7 @@ func NewVariables(secrets Secrets, teamName string, pipelineName string, allowRo
 }
 
 func (sl VariableLookupFromSecrets) Get(ref vars.Reference) (interface{}, bool, error) {
-	val, found, err := sl.get(ref.Path)
+	val, _, found, err := sl.get(ref.Path)
 	if err != nil {
 		return nil, false, err
 	}
@@ -31,29 +31,59 @@ func (sl VariableLookupFromSecrets) Get(ref vars.Reference) (interface{}, bool,
 	return result, true, nil
 }
 
-func (sl VariableLookupFromSecrets) get(path string) (interface{}, bool, error) {
+// Locate returns the secret path which the var resolves to, without
+// revealing the secret itself.
+func (sl VariableLookupFromSecrets) Locate(ref vars.Reference) (string, bool, error) {
+	val, secretPath, found, err := sl.get(ref.Path)
+	if err != nil {
+		return "", false, err
+	}
+	if !found {
+		return "", false, nil
+	}
+	_, err = vars.Traverse(val, ref.String(), ref.Fields)
+	if err != nil {
+		return "", false, err
+	}
+	return secretPath, true, nil
+}
+
+// SecretPaths returns the secret paths which are searched for the var, in
+// order.
+func (sl VariableLookupFromSecrets) SecretPaths(path string) ([]string, error) {
 	if len(sl.LookupPaths) == 0 {
 		// if no paths are specified (i.e. for fake & noop secret managers), then try 1-to-1 var->secret mapping
-		result, _, found, err := sl.Secrets.Get(path)
-		return result, found, err
+		return []string{path}, nil
 	}
-	// try to find a secret according to our var->secret lookup paths
+	var secretPaths []string
 	for _, rule := range sl.LookupPaths {
 		// prepends any additional prefix paths to front of the path
 		secretPath, err := rule.VariableToSecretPath(path)
 		if err != nil {
-			return nil, false, err
+			return nil, err
 		}
+		secretPaths = append(secretPaths, secretPath)
+	}
+	return secretPaths, nil
+}
+
+func (sl VariableLookupFromSecrets) get(path string) (interface{}, string, bool, error) {
+	secretPaths, err := sl.SecretPaths(path)
+	if err != nil {
+		return nil, "", false, err
+	}
+	// try to find a secret according to our var->secret lookup paths
+	for _, secretPath := range secretPaths {
 		result, _, found, err := sl.Secrets.Get(secretPath)
 		if err != nil {
-			return nil, false, err
+			return nil, "", false, err
 		}
 		if !found {
 			continue
 		}
-		return result, true, nil
+		return result, secretPath, true, nil
 	}
-	return nil, false, nil
+	return nil, "", false, nil
 }
 
 func (sl VariableLookupFromSecrets) List() ([]vars.Reference, error) {
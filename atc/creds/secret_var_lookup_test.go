@@ -11,11 +11,12 @@ import (
 
 var _ = Describe("VariableLookupFromSecrets", func() {
 	var (
+		secrets   creds.Secrets
 		variables vars.Variables
 	)
 
 	BeforeEach(func() {
-		secrets := dummy.NewSecretsFactory([]dummy.VarFlag{
+		secrets = dummy.NewSecretsFactory([]dummy.VarFlag{
 			{
 				Name: "a",
 				Value: map[string]interface{}{
@@ -44,4 +45,43 @@ var _ = Describe("VariableLookupFromSecrets", func() {
 			})
 		})
 	})
+	Describe("SecretPaths", func() {
+		It("returns the paths which are searched in order", func() {
+			lookup := creds.NewVariables(secrets, "team", "pipeline", true).(creds.VariableLookupFromSecrets)
+
+			paths, err := lookup.SecretPaths("a")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(paths).To(Equal([]string{"team/pipeline/a", "team/a", "a"}))
+		})
+	})
+
+	Describe("Locate", func() {
+		var lookup creds.VariableLookupFromSecrets
+
+		BeforeEach(func() {
+			lookup = creds.NewVariables(secrets, "team", "pipeline", true).(creds.VariableLookupFromSecrets)
+		})
+
+		It("returns the path the var was found at", func() {
+			path, found, err := lookup.Locate(vars.Reference{Path: "a", Fields: []string{"b", "c"}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(path).To(Equal("a"))
+		})
+
+		Context("when the var is not found", func() {
+			It("returns false", func() {
+				_, found, err := lookup.Locate(vars.Reference{Path: "missing"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+
+		Context("when a field is missing", func() {
+			It("errors", func() {
+				_, _, err := lookup.Locate(vars.Reference{Path: "a", Fields: []string{"b", "d"}})
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
 })
@@ -0,0 +1,12 @@
+package atc
+
+// DashboardPreferences is a user's own arrangement of the dashboard, kept on
+// the server so that it follows them from browser to browser. Pipelines are
+// referred to by ID.
+type DashboardPreferences struct {
+	// PipelineOrder lists the pipelines the user has ordered, in that order.
+	// Pipelines missing from it are shown after them in their usual order.
+	PipelineOrder []int `json:"pipeline_order"`
+
+	FavoritePipelines []int `json:"favorite_pipelines"`
+}
@@ -21,10 +21,19 @@ func NewAccessTokenLifecycle(conn Conn) AccessTokenLifecycle {
 }
 
 func (a accessTokenLifecycle) RemoveExpiredAccessTokens(leeway time.Duration) (int, error) {
+	expired := sq.Expr(fmt.Sprintf("expires_at < now() - '%d seconds'::interval", int(leeway.Seconds())))
+
 	res, err := sq.Delete("access_tokens").
-		Where(
-			sq.Expr(fmt.Sprintf("expires_at < now() - '%d seconds'::interval", int(leeway.Seconds()))),
-		).
+		Where(expired).
+		RunWith(a.conn).
+		Exec()
+	if err != nil {
+		return 0, err
+	}
+
+	// a revoked token only needs to be remembered until it would have expired
+	_, err = sq.Delete("access_token_revocations").
+		Where(expired).
 		RunWith(a.conn).
 		Exec()
 	if err != nil {
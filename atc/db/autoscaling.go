@@ -0,0 +1,108 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+)
+
+// PendingJobBuilds is how many builds of a job are waiting to start, along
+// with the job's config, for working out how many containers they are going
+// to need.
+type PendingJobBuilds struct {
+	JobID       int
+	TeamName    string
+	Config      atc.JobConfig
+	MaxInFlight int
+
+	Pending int
+	Running int
+
+	// OldestPending is when the longest waiting build was created.
+	OldestPending time.Time
+}
+
+// AutoscalingRepository finds the demand for workers, for external
+// autoscalers to scale worker fleets on.
+//
+//counterfeiter:generate . AutoscalingRepository
+type AutoscalingRepository interface {
+	// PendingJobBuilds returns the jobs with pending builds, leaving out
+	// paused jobs and pipelines, whose builds are not going to start.
+	PendingJobBuilds() ([]PendingJobBuilds, error)
+}
+
+type autoscalingRepository struct {
+	conn Conn
+}
+
+func NewAutoscalingRepository(conn Conn) AutoscalingRepository {
+	return &autoscalingRepository{
+		conn: conn,
+	}
+}
+
+func (repo *autoscalingRepository) PendingJobBuilds() ([]PendingJobBuilds, error) {
+	rows, err := repo.conn.Query(`
+		SELECT j.id, t.name, j.config, j.nonce, j.max_in_flight,
+			COUNT(*) FILTER (WHERE b.status = 'pending'),
+			COUNT(*) FILTER (WHERE b.status = 'started'),
+			MIN(b.create_time) FILTER (WHERE b.status = 'pending')
+		FROM builds b
+		JOIN jobs j ON j.id = b.job_id
+		JOIN pipelines p ON p.id = j.pipeline_id
+		JOIN teams t ON t.id = p.team_id
+		WHERE NOT b.completed
+		AND j.active
+		AND NOT j.paused
+		AND NOT p.paused
+		AND NOT p.archived
+		GROUP BY j.id, t.name
+		HAVING COUNT(*) FILTER (WHERE b.status = 'pending') > 0
+		ORDER BY j.id
+	`)
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	es := repo.conn.EncryptionStrategy()
+
+	var jobs []PendingJobBuilds
+	for rows.Next() {
+		var (
+			job    PendingJobBuilds
+			config sql.NullString
+			nonce  sql.NullString
+		)
+
+		err := rows.Scan(&job.JobID, &job.TeamName, &config, &nonce, &job.MaxInFlight, &job.Pending, &job.Running, &job.OldestPending)
+		if err != nil {
+			return nil, err
+		}
+
+		if config.Valid {
+			var jobNonce *string
+			if nonce.Valid {
+				jobNonce = &nonce.String
+			}
+
+			decrypted, err := es.Decrypt(config.String, jobNonce)
+			if err != nil {
+				return nil, err
+			}
+
+			err = json.Unmarshal(decrypted, &job.Config)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		jobs = append(jobs, job)
+	}
+
+	return jobs, rows.Err()
+}
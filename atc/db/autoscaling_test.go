@@ -0,0 +1,80 @@
+package db_test
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AutoscalingRepository", func() {
+	var repository db.AutoscalingRepository
+
+	BeforeEach(func() {
+		repository = db.NewAutoscalingRepository(dbConn)
+	})
+
+	Describe("PendingJobBuilds", func() {
+		var pendingBuild db.Build
+
+		BeforeEach(func() {
+			startedBuild, err := defaultJob.CreateBuild(defaultBuildCreatedBy)
+			Expect(err).ToNot(HaveOccurred())
+
+			started, err := startedBuild.Start(atc.Plan{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(started).To(BeTrue())
+
+			pendingBuild, err = defaultJob.CreateBuild(defaultBuildCreatedBy)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = defaultJob.CreateBuild(defaultBuildCreatedBy)
+			Expect(err).ToNot(HaveOccurred())
+
+			finishedBuild, err := defaultJob.CreateBuild(defaultBuildCreatedBy)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(finishedBuild.Finish(db.BuildStatusSucceeded)).To(Succeed())
+		})
+
+		It("counts the pending and running builds of the job along with its config", func() {
+			jobs, err := repository.PendingJobBuilds()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(jobs).To(HaveLen(1))
+
+			config, err := defaultJob.Config()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(jobs[0].JobID).To(Equal(defaultJob.ID()))
+			Expect(jobs[0].TeamName).To(Equal(defaultTeam.Name()))
+			Expect(jobs[0].Config).To(Equal(config))
+			Expect(jobs[0].MaxInFlight).To(Equal(defaultJob.MaxInFlight()))
+			Expect(jobs[0].Pending).To(Equal(2))
+			Expect(jobs[0].Running).To(Equal(1))
+			Expect(jobs[0].OldestPending).To(BeTemporally("~", pendingBuild.CreateTime()))
+		})
+
+		Context("when the job is paused", func() {
+			BeforeEach(func() {
+				Expect(defaultJob.Pause("some-user")).To(Succeed())
+			})
+
+			It("leaves it out", func() {
+				jobs, err := repository.PendingJobBuilds()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(jobs).To(BeEmpty())
+			})
+		})
+
+		Context("when the pipeline is paused", func() {
+			BeforeEach(func() {
+				Expect(defaultPipeline.Pause("some-user")).To(Succeed())
+			})
+
+			It("leaves it out", func() {
+				jobs, err := repository.PendingJobBuilds()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(jobs).To(BeEmpty())
+			})
+		})
+	})
+})
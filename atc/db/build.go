@@ -97,7 +97,15 @@ var buildsQuery = psql.Select(`
 		rb.name,
 		b.rerun_number,
 		b.span_context,
-		COALESCE(bc.comment, '')
+		COALESCE(bc.comment, ''),
+		bv.vars,
+		bv.nonce,
+		b.collapsed_into,
+		b.retry_of,
+		b.failure_reason,
+		b.start_latency,
+		de.duration,
+		b.external_ref
 	`).
 	From("builds b").
 	JoinClause("LEFT OUTER JOIN jobs j ON b.job_id = j.id").
@@ -105,7 +113,9 @@ var buildsQuery = psql.Select(`
 	JoinClause("LEFT OUTER JOIN pipelines p ON b.pipeline_id = p.id").
 	JoinClause("LEFT OUTER JOIN teams t ON b.team_id = t.id").
 	JoinClause("LEFT OUTER JOIN builds rb ON rb.id = b.rerun_of").
-	JoinClause("LEFT OUTER JOIN build_comments bc ON b.id = bc.build_id")
+	JoinClause("LEFT OUTER JOIN build_comments bc ON b.id = bc.build_id").
+	JoinClause("LEFT OUTER JOIN build_vars bv ON b.id = bv.build_id").
+	JoinClause("LEFT OUTER JOIN job_duration_estimates de ON de.job_id = b.job_id AND de.step_name = ''")
 
 var minMaxIdQuery = psql.Select("COALESCE(MAX(b.id), 0)", "COALESCE(MIN(b.id), 0)").
 	From("builds as b")
@@ -160,6 +170,13 @@ type Build interface {
 	RerunOfName() string
 	RerunNumber() int
 	CreatedBy() *string
+	Vars() []atc.BuildVar
+	CollapsedInto() int
+	RetryOf() int
+	ExternalRef() string
+	FailureReason() atc.FailureReason
+	StartLatency() *atc.BuildStartLatency
+	EstimatedDuration() (time.Duration, bool)
 
 	LagerData() lager.Data
 	TracingAttrs() tracing.Attrs
@@ -183,8 +200,18 @@ type Build interface {
 	SetComment(string) error
 	SetInterceptible(bool) error
 
+	// SetFailureReason records why the build didn't succeed. Aborted builds
+	// get theirs when they finish.
+	SetFailureReason(atc.FailureReason) error
+
+	// SetStartLatency records how long the build took to start its first
+	// task process. It returns false if the build already has one.
+	SetStartLatency(atc.BuildStartLatency) (bool, error)
+
 	Events(uint) (EventSource, error)
+	SavedEvents() ([]event.Envelope, error)
 	SaveEvent(event atc.Event) error
+	SaveEvents(events []atc.Event) error
 
 	Artifacts() ([]WorkerArtifact, error)
 	Artifact(artifactID int) (WorkerArtifact, error)
@@ -192,15 +219,33 @@ type Build interface {
 	SaveOutput(string, ResourceCache, atc.Source, atc.Version, ResourceConfigMetadataFields, string, string) error
 	AdoptInputsAndPipes() ([]BuildInput, bool, error)
 	AdoptRerunInputsAndPipes() ([]BuildInput, bool, error)
+	PinnedInputs() ([]atc.BuildInputPin, error)
 
 	Resources() ([]BuildInput, []BuildOutput, error)
 	SaveImageResourceVersion(ResourceCache) error
+	ImageResourceVersions() ([]BuildImageResourceVersion, error)
+	WorkerNames() ([]string, error)
+
+	SaveProvenance(json.RawMessage) error
+	Provenance() (json.RawMessage, bool, error)
+
+	// StepDurationEstimate returns how long the step of the build's job
+	// with the given name is expected to take. SaveStepDuration adds how
+	// long it took this time to the estimate. Steps of builds which don't
+	// belong to a job have no estimates.
+	StepDurationEstimate(stepName string) (time.Duration, bool, error)
+	SaveStepDuration(stepName string, duration time.Duration) error
 
 	Delete() (bool, error)
+	CollapseInto(buildID int) (bool, error)
 	MarkAsAborted() error
 	IsAborted() bool
 	AbortNotifier() (Notifier, error)
 
+	AbortStep(atc.PlanID) error
+	AbortedSteps() ([]atc.PlanID, error)
+	StepAbortNotifier() (Notifier, error)
+
 	IsDrained() bool
 	SetDrained(bool) error
 
@@ -243,7 +288,16 @@ type build struct {
 
 	isManuallyTriggered bool
 
-	createdBy *string
+	createdBy   *string
+	vars        []atc.BuildVar
+	externalRef string
+
+	collapsedInto int
+	retryOf       int
+	failureReason atc.FailureReason
+	startLatency  *atc.BuildStartLatency
+
+	estimatedDuration sql.NullFloat64
 
 	rerunOf     int
 	rerunOfName string
@@ -387,6 +441,13 @@ func (b *build) RerunOf() int                     { return b.rerunOf }
 func (b *build) RerunOfName() string              { return b.rerunOfName }
 func (b *build) RerunNumber() int                 { return b.rerunNumber }
 func (b *build) CreatedBy() *string               { return b.createdBy }
+func (b *build) Vars() []atc.BuildVar             { return b.vars }
+func (b *build) CollapsedInto() int               { return b.collapsedInto }
+func (b *build) RetryOf() int                     { return b.retryOf }
+func (b *build) ExternalRef() string              { return b.externalRef }
+func (b *build) FailureReason() atc.FailureReason { return b.failureReason }
+
+func (b *build) StartLatency() *atc.BuildStartLatency { return b.startLatency }
 
 func (b *build) isNewerThanLastCheckOf(input Resource) bool {
 	return b.createTime.After(input.LastCheckEndTime())
@@ -501,6 +562,64 @@ func (b *build) SetInterceptible(i bool) error {
 	return nil
 }
 
+func (b *build) SetFailureReason(reason atc.FailureReason) error {
+	rows, err := psql.Update("builds").
+		Set("failure_reason", reason).
+		Where(sq.Eq{
+			"id": b.id,
+		}).
+		RunWith(b.conn).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	affected, err := rows.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return ErrBuildDisappeared
+	}
+
+	b.failureReason = reason
+
+	return nil
+}
+
+func (b *build) SetStartLatency(latency atc.BuildStartLatency) (bool, error) {
+	payload, err := json.Marshal(latency)
+	if err != nil {
+		return false, err
+	}
+
+	rows, err := psql.Update("builds").
+		Set("start_latency", payload).
+		Where(sq.Eq{
+			"id":            b.id,
+			"start_latency": nil,
+		}).
+		RunWith(b.conn).
+		Exec()
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := rows.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	if affected == 0 {
+		return false, nil
+	}
+
+	b.startLatency = &latency
+
+	return true, nil
+}
+
 func (b *build) ResourcesChecked() (bool, error) {
 	var notChecked bool
 	err := b.conn.QueryRow(`
@@ -594,10 +713,21 @@ func (b *build) start(tx Tx, plan atc.Plan) (bool, error) {
 		return false, err
 	}
 
-	err = b.saveEvent(tx, event.Status{
+	started := event.Status{
 		Status: atc.StatusStarted,
 		Time:   startTime.Unix(),
-	})
+	}
+
+	if duration, found := b.EstimatedDuration(); found {
+		started.EstimatedDuration = int64(duration / time.Second)
+	}
+
+	err = b.saveEvent(tx, started)
+	if err != nil {
+		return false, err
+	}
+
+	err = b.enqueueWebhookDeliveries(tx, atc.WebhookEventBuildStarted, BuildStatusStarted, startTime, time.Time{})
 	if err != nil {
 		return false, err
 	}
@@ -605,6 +735,39 @@ func (b *build) start(tx Tx, plan atc.Plan) (bool, error) {
 	return true, nil
 }
 
+// enqueueWebhookDeliveries queues deliveries of the build event to the
+// webhooks which subscribe to it. Check builds are skipped, as they are far
+// too frequent to be of interest.
+func (b *build) enqueueWebhookDeliveries(tx Tx, webhookEvent atc.WebhookEvent, status BuildStatus, startTime, endTime time.Time) error {
+	if b.name == CheckBuildName {
+		return nil
+	}
+
+	return enqueueWebhookDeliveries(tx, b.teamID, webhookEvent, func() (interface{}, error) {
+		build := atc.Build{
+			ID:                   b.id,
+			TeamName:             b.teamName,
+			Name:                 b.name,
+			Status:               atc.BuildStatus(status),
+			JobName:              b.jobName,
+			PipelineID:           b.pipelineID,
+			PipelineName:         b.pipelineName,
+			PipelineInstanceVars: b.pipelineInstanceVars,
+			CreatedBy:            b.createdBy,
+		}
+
+		if !startTime.IsZero() {
+			build.StartTime = startTime.Unix()
+		}
+
+		if !endTime.IsZero() {
+			build.EndTime = endTime.Unix()
+		}
+
+		return build, nil
+	})
+}
+
 func (b *build) Finish(status BuildStatus) error {
 	tx, err := b.conn.Begin()
 	if err != nil {
@@ -613,19 +776,28 @@ func (b *build) Finish(status BuildStatus) error {
 
 	defer Rollback(tx)
 
-	var endTime time.Time
+	var (
+		startTime pq.NullTime
+		endTime   time.Time
+	)
 
-	err = psql.Update("builds").
+	update := psql.Update("builds").
 		Set("status", status).
 		Set("end_time", sq.Expr("now()")).
 		Set("completed", true).
 		Set("private_plan", nil).
-		Set("nonce", nil).
+		Set("nonce", nil)
+
+	if status == BuildStatusAborted {
+		update = update.Set("failure_reason", atc.FailureReasonAborted)
+	}
+
+	err = update.
 		Where(sq.Eq{"id": b.id}).
-		Suffix("RETURNING end_time").
+		Suffix("RETURNING start_time, end_time").
 		RunWith(tx).
 		QueryRow().
-		Scan(&endTime)
+		Scan(&startTime, &endTime)
 	if err != nil {
 		return err
 	}
@@ -638,6 +810,18 @@ func (b *build) Finish(status BuildStatus) error {
 		return err
 	}
 
+	err = b.enqueueWebhookDeliveries(tx, atc.WebhookEventBuildFinished, status, b.startTime, endTime)
+	if err != nil {
+		return err
+	}
+
+	if b.jobID != 0 && status == BuildStatusSucceeded && startTime.Valid {
+		err = saveDurationSample(tx, b.jobID, "", endTime.Sub(startTime.Time))
+		if err != nil {
+			return err
+		}
+	}
+
 	if b.jobID != 0 && status == BuildStatusSucceeded {
 		_, err = psql.Delete("build_image_resource_caches").
 			Where(sq.And{
@@ -828,7 +1012,21 @@ func (b *build) Variables(logger lager.Logger, globalSecrets creds.Secrets, varS
 		return nil, errors.New("pipeline not found")
 	}
 
-	return pipeline.Variables(logger, globalSecrets, varSourcePool)
+	pipelineVars, err := pipeline.Variables(logger, globalSecrets, varSourcePool)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(b.vars) == 0 {
+		return pipelineVars, nil
+	}
+
+	buildVars := vars.StaticVariables{}
+	for _, v := range b.vars {
+		buildVars[v.Name] = v.Value
+	}
+
+	return vars.NewMultiVars([]vars.Variables{buildVars, pipelineVars}), nil
 }
 
 func (b *build) SetDrained(drained bool) error {
@@ -867,6 +1065,41 @@ func (b *build) Delete() (bool, error) {
 	return true, nil
 }
 
+// CollapseInto aborts the pending build in favour of the given build of the
+// same job, which runs with the same inputs. It returns false if the build is
+// no longer pending.
+func (b *build) CollapseInto(buildID int) (bool, error) {
+	result, err := psql.Update("builds").
+		Set("collapsed_into", buildID).
+		Where(sq.Eq{
+			"id":     b.id,
+			"status": BuildStatusPending,
+		}).
+		RunWith(b.conn).
+		Exec()
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	if rowsAffected == 0 {
+		return false, nil
+	}
+
+	b.collapsedInto = buildID
+
+	err = b.Finish(BuildStatusAborted)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
 // MarkAsAborted will send the abort notification to all build abort
 // channel listeners. It will set the status to aborted that will make
 // AbortNotifier send notification in case if tracking ATC misses the first
@@ -922,6 +1155,57 @@ func (b *build) AbortNotifier() (Notifier, error) {
 	})
 }
 
+// AbortStep asks the ATC running the build to abort the step, leaving the
+// rest of the build to carry on as if the step had failed.
+func (b *build) AbortStep(planID atc.PlanID) error {
+	_, err := psql.Insert("build_step_aborts").
+		Columns("build_id", "plan_id").
+		Values(b.id, string(planID)).
+		Suffix("ON CONFLICT DO NOTHING").
+		RunWith(b.conn).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	return b.conn.Bus().Notify(buildStepAbortChannel(b.id))
+}
+
+func (b *build) AbortedSteps() ([]atc.PlanID, error) {
+	rows, err := psql.Select("plan_id").
+		From("build_step_aborts").
+		Where(sq.Eq{"build_id": b.id}).
+		OrderBy("plan_id").
+		RunWith(b.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	planIDs := []atc.PlanID{}
+	for rows.Next() {
+		var planID string
+		err := rows.Scan(&planID)
+		if err != nil {
+			return nil, err
+		}
+
+		planIDs = append(planIDs, atc.PlanID(planID))
+	}
+
+	return planIDs, rows.Err()
+}
+
+// StepAbortNotifier returns a Notifier that can be watched for when steps of
+// the build are asked to be aborted.
+func (b *build) StepAbortNotifier() (Notifier, error) {
+	return newConditionNotifier(b.conn.Bus(), buildStepAbortChannel(b.id), func() (bool, error) {
+		return true, nil
+	})
+}
+
 func (b *build) SaveImageResourceVersion(rc ResourceCache) error {
 	var jobID sql.NullInt64
 	if b.jobID != 0 {
@@ -989,6 +1273,7 @@ func (b *build) Preparation() (BuildPreparation, bool, error) {
 			PausedPipeline:      BuildPreparationStatusNotBlocking,
 			PausedJob:           BuildPreparationStatusNotBlocking,
 			MaxRunningBuilds:    BuildPreparationStatusNotBlocking,
+			WorkerCapacity:      BuildPreparationStatusNotBlocking,
 			Inputs:              map[string]BuildPreparationStatus{},
 			InputsSatisfied:     BuildPreparationStatusNotBlocking,
 			MissingInputReasons: MissingInputReasons{},
@@ -996,20 +1281,21 @@ func (b *build) Preparation() (BuildPreparation, bool, error) {
 	}
 
 	var (
-		pausedPipeline     bool
-		pausedJob          bool
-		maxInFlightReached bool
-		pipelineID         int
-		jobName            string
+		pausedPipeline        bool
+		pausedJob             bool
+		maxInFlightReached    bool
+		workerCapacityReached bool
+		pipelineID            int
+		jobName               string
 	)
-	err := psql.Select("p.paused, j.paused, j.max_in_flight_reached, j.pipeline_id, j.name").
+	err := psql.Select("p.paused, j.paused, j.max_in_flight_reached, j.worker_capacity_reached, j.pipeline_id, j.name").
 		From("builds b").
 		Join("jobs j ON b.job_id = j.id").
 		Join("pipelines p ON j.pipeline_id = p.id").
 		Where(sq.Eq{"b.id": b.id}).
 		RunWith(b.conn).
 		QueryRow().
-		Scan(&pausedPipeline, &pausedJob, &maxInFlightReached, &pipelineID, &jobName)
+		Scan(&pausedPipeline, &pausedJob, &maxInFlightReached, &workerCapacityReached, &pipelineID, &jobName)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return BuildPreparation{}, false, nil
@@ -1032,6 +1318,11 @@ func (b *build) Preparation() (BuildPreparation, bool, error) {
 		maxInFlightReachedStatus = BuildPreparationStatusBlocking
 	}
 
+	workerCapacityStatus := BuildPreparationStatusNotBlocking
+	if workerCapacityReached {
+		workerCapacityStatus = BuildPreparationStatusBlocking
+	}
+
 	tf := NewTeamFactory(b.conn, b.lockFactory)
 	t, found, err := tf.FindTeam(b.teamName)
 	if err != nil {
@@ -1133,6 +1424,7 @@ func (b *build) Preparation() (BuildPreparation, bool, error) {
 		PausedPipeline:      pausedPipelineStatus,
 		PausedJob:           pausedJobStatus,
 		MaxRunningBuilds:    maxInFlightReachedStatus,
+		WorkerCapacity:      workerCapacityStatus,
 		Inputs:              inputs,
 		InputsSatisfied:     inputsSatisfiedStatus,
 		MissingInputReasons: missingInputReasons,
@@ -1142,22 +1434,14 @@ func (b *build) Preparation() (BuildPreparation, bool, error) {
 }
 
 func (b *build) Events(from uint) (EventSource, error) {
-	notifier, err := newConditionNotifier(b.conn.Bus(), buildEventsChannel(b.id), func() (bool, error) {
-		return true, nil
-	})
-	if err != nil {
-		return nil, err
-	}
-
 	return newBuildEventSource(
 		b.id,
 		b.eventsTable(),
 		b.conn,
-		notifier,
 		from,
 		func(tx Tx, buildID int) (bool, error) {
 			completed := false
-			err = psql.Select("completed").
+			err := psql.Select("completed").
 				From("builds").
 				Where(sq.Eq{"id": buildID}).
 				RunWith(tx).
@@ -1168,7 +1452,11 @@ func (b *build) Events(from uint) (EventSource, error) {
 			}
 			return completed, nil
 		},
-	), nil
+	)
+}
+
+func (b *build) SavedEvents() ([]event.Envelope, error) {
+	return savedBuildEvents(b.conn, b.eventsTable(), b.id)
 }
 
 func (b *build) SaveEvent(event atc.Event) error {
@@ -1192,6 +1480,33 @@ func (b *build) SaveEvent(event atc.Event) error {
 	return b.conn.Bus().Notify(buildEventsChannel(b.id))
 }
 
+// SaveEvents saves the events in a single transaction and notifies
+// listeners once, which is a lot cheaper than saving them one by one.
+func (b *build) SaveEvents(events []atc.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	tx, err := b.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	err = b.saveEvents(tx, events)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	return b.conn.Bus().Notify(buildEventsChannel(b.id))
+}
+
 func (b *build) Artifact(artifactID int) (WorkerArtifact, error) {
 
 	artifact := artifact{
@@ -1387,7 +1702,8 @@ func (b *build) AdoptInputsAndPipes() ([]BuildInput, bool, error) {
 		Select(psql.Select("i.resource_id", "i.version_md5", "i.input_name", "i.first_occurrence").
 			Column("?", b.id).
 			From("next_build_inputs i").
-			Where(sq.Eq{"i.job_id": b.jobID})).
+			Where(sq.Eq{"i.job_id": b.jobID}).
+			Where(sq.Expr("i.input_name NOT IN (SELECT input_name FROM build_pinned_inputs WHERE build_id = ?)", b.id))).
 		Suffix("ON CONFLICT (build_id, resource_id, version_md5, name) DO UPDATE SET first_occurrence = EXCLUDED.first_occurrence").
 		Suffix("RETURNING name, resource_id, version_md5, first_occurrence").
 		RunWith(tx).
@@ -1397,28 +1713,39 @@ func (b *build) AdoptInputsAndPipes() ([]BuildInput, bool, error) {
 	}
 
 	inputs := InputMapping{}
-	for rows.Next() {
-		var (
-			inputName       string
-			firstOccurrence bool
-			versionMD5      string
-			resourceID      int
-		)
+	err = scanAdoptedInputs(rows, inputs)
+	if err != nil {
+		return nil, false, err
+	}
 
-		err := rows.Scan(&inputName, &resourceID, &versionMD5, &firstOccurrence)
-		if err != nil {
-			return nil, false, err
-		}
+	// the pinned inputs of a manually triggered build use the versions they
+	// were pinned to instead
+	rows, err = psql.Insert("build_resource_config_version_inputs").
+		Columns("resource_id", "version_md5", "name", "first_occurrence", "build_id").
+		Select(psql.Select("pi.resource_id", "pi.version_md5", "pi.input_name").
+			Column(`NOT EXISTS (
+				SELECT 1
+				FROM build_resource_config_version_inputs bi
+				JOIN builds b ON b.id = bi.build_id
+				WHERE b.job_id = ?
+				AND bi.resource_id = pi.resource_id
+				AND bi.version_md5 = pi.version_md5
+				AND bi.name = pi.input_name
+			)`, b.jobID).
+			Column("pi.build_id").
+			From("build_pinned_inputs pi").
+			Where(sq.Eq{"pi.build_id": b.id})).
+		Suffix("ON CONFLICT (build_id, resource_id, version_md5, name) DO UPDATE SET first_occurrence = EXCLUDED.first_occurrence").
+		Suffix("RETURNING name, resource_id, version_md5, first_occurrence").
+		RunWith(tx).
+		Query()
+	if err != nil {
+		return nil, false, err
+	}
 
-		inputs[inputName] = InputResult{
-			Input: &AlgorithmInput{
-				AlgorithmVersion: AlgorithmVersion{
-					ResourceID: resourceID,
-					Version:    ResourceVersion(versionMD5),
-				},
-				FirstOccurrence: firstOccurrence,
-			},
-		}
+	err = scanAdoptedInputs(rows, inputs)
+	if err != nil {
+		return nil, false, err
 	}
 
 	buildInputs := []BuildInput{}
@@ -1507,6 +1834,80 @@ func (b *build) AdoptInputsAndPipes() ([]BuildInput, bool, error) {
 	return buildInputs, true, nil
 }
 
+func scanAdoptedInputs(rows *sql.Rows, inputs InputMapping) error {
+	defer Close(rows)
+
+	for rows.Next() {
+		var (
+			inputName       string
+			firstOccurrence bool
+			versionMD5      string
+			resourceID      int
+		)
+
+		err := rows.Scan(&inputName, &resourceID, &versionMD5, &firstOccurrence)
+		if err != nil {
+			return err
+		}
+
+		inputs[inputName] = InputResult{
+			Input: &AlgorithmInput{
+				AlgorithmVersion: AlgorithmVersion{
+					ResourceID: resourceID,
+					Version:    ResourceVersion(versionMD5),
+				},
+				FirstOccurrence: firstOccurrence,
+			},
+		}
+	}
+
+	return rows.Err()
+}
+
+// PinnedInputs returns the versions the inputs of the build were pinned to
+// when it was triggered.
+func (b *build) PinnedInputs() ([]atc.BuildInputPin, error) {
+	rows, err := psql.Select("pi.input_name", "pi.override_passed", "v.id", "v.version").
+		From("build_pinned_inputs pi").
+		Join("resources r ON r.id = pi.resource_id").
+		LeftJoin("resource_config_versions v ON v.resource_config_scope_id = r.resource_config_scope_id AND v.version_md5 = pi.version_md5").
+		Where(sq.Eq{"pi.build_id": b.id}).
+		OrderBy("pi.input_name").
+		RunWith(b.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	pins := []atc.BuildInputPin{}
+	for rows.Next() {
+		var pin atc.BuildInputPin
+		var versionID sql.NullInt64
+		var version sql.NullString
+
+		err := rows.Scan(&pin.Name, &pin.OverridePassed, &versionID, &version)
+		if err != nil {
+			return nil, err
+		}
+
+		// the version may have been removed since
+		if version.Valid {
+			pin.VersionID = int(versionID.Int64)
+
+			err = json.Unmarshal([]byte(version.String), &pin.Version)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		pins = append(pins, pin)
+	}
+
+	return pins, rows.Err()
+}
+
 func (b *build) AdoptRerunInputsAndPipes() ([]BuildInput, bool, error) {
 	tx, err := b.conn.Begin()
 	if err != nil {
@@ -1857,13 +2258,17 @@ func newNullInt64(i int) sql.NullInt64 {
 
 func scanBuild(b *build, row scannable, encryptionStrategy encryption.Strategy) error {
 	var (
-		jobID, resourceID, resourceTypeID, pipelineID, rerunOf, rerunNumber               sql.NullInt64
-		schema, privatePlan, jobName, resourceName, pipelineName, publicPlan, rerunOfName sql.NullString
-		createTime, startTime, endTime, reapTime                                          pq.NullTime
-		nonce, spanContext, createdBy                                                     sql.NullString
-		drained, aborted, completed                                                       bool
-		status                                                                            string
-		pipelineInstanceVars, comment                                                     sql.NullString
+		jobID, resourceID, resourceTypeID, pipelineID, rerunOf, rerunNumber, collapsedInto sql.NullInt64
+		schema, privatePlan, jobName, resourceName, pipelineName, publicPlan, rerunOfName  sql.NullString
+		createTime, startTime, endTime, reapTime                                           pq.NullTime
+		nonce, spanContext, createdBy                                                      sql.NullString
+		drained, aborted, completed                                                        bool
+		status                                                                             string
+		pipelineInstanceVars, comment                                                      sql.NullString
+		buildVars, buildVarsNonce                                                          sql.NullString
+		retryOf                                                                            sql.NullInt64
+		failureReason, externalRef                                                         sql.NullString
+		startLatency                                                                       []byte
 	)
 
 	err := row.Scan(
@@ -1900,6 +2305,14 @@ func scanBuild(b *build, row scannable, encryptionStrategy encryption.Strategy)
 		&rerunNumber,
 		&spanContext,
 		&comment,
+		&buildVars,
+		&buildVarsNonce,
+		&collapsedInto,
+		&retryOf,
+		&failureReason,
+		&startLatency,
+		&b.estimatedDuration,
+		&externalRef,
 	)
 	if err != nil {
 		return err
@@ -1924,6 +2337,18 @@ func scanBuild(b *build, row scannable, encryptionStrategy encryption.Strategy)
 	b.rerunOf = int(rerunOf.Int64)
 	b.rerunOfName = rerunOfName.String
 	b.rerunNumber = int(rerunNumber.Int64)
+	b.collapsedInto = int(collapsedInto.Int64)
+	b.retryOf = int(retryOf.Int64)
+	b.failureReason = atc.FailureReason(failureReason.String)
+	b.externalRef = externalRef.String
+
+	b.startLatency = nil
+	if startLatency != nil {
+		err = json.Unmarshal(startLatency, &b.startLatency)
+		if err != nil {
+			return err
+		}
+	}
 	b.comment = comment.String
 
 	var (
@@ -1973,9 +2398,46 @@ func scanBuild(b *build, row scannable, encryptionStrategy encryption.Strategy)
 		b.createdBy = &createdBy.String
 	}
 
+	b.vars = nil
+	if buildVars.Valid {
+		var noncense *string
+		if buildVarsNonce.Valid {
+			noncense = &buildVarsNonce.String
+		}
+
+		decryptedVars, err := encryptionStrategy.Decrypt(buildVars.String, noncense)
+		if err != nil {
+			return err
+		}
+
+		err = json.Unmarshal(decryptedVars, &b.vars)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+func saveBuildVars(tx Tx, buildID int, vars []atc.BuildVar) error {
+	payload, err := json.Marshal(vars)
+	if err != nil {
+		return err
+	}
+
+	encryptedVars, nonce, err := tx.EncryptionStrategy().Encrypt(payload)
+	if err != nil {
+		return err
+	}
+
+	_, err = psql.Insert("build_vars").
+		Columns("build_id", "vars", "nonce").
+		Values(buildID, encryptedVars, nonce).
+		RunWith(tx).
+		Exec()
+	return err
+}
+
 func (b *build) saveEvent(tx Tx, event atc.Event) error {
 	payload, err := json.Marshal(event)
 	if err != nil {
@@ -1997,6 +2459,65 @@ func (b *build) saveEvent(tx Tx, event atc.Event) error {
 	return err
 }
 
+// copyEventsThreshold is the number of events from which they're saved with
+// COPY rather than with a multi-row insert, which gets slower to plan and
+// eventually hits the limit on the number of parameters of a statement.
+const copyEventsThreshold = 100
+
+func (b *build) saveEvents(tx Tx, events []atc.Event) error {
+	if b.eventIdSeq == nil {
+		err := b.refreshEventIdSeq(tx)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(events) >= copyEventsThreshold {
+		return b.copyEvents(tx, events)
+	}
+
+	insert := psql.Insert(b.eventsTable()).
+		Columns("event_id", "build_id", "type", "version", "payload")
+
+	for _, event := range events {
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+
+		insert = insert.Values(b.eventIdSeq.Next(), b.id, string(event.EventType()), string(event.Version()), payload)
+	}
+
+	_, err := insert.RunWith(tx).Exec()
+	return err
+}
+
+// copyEvents saves the events with COPY. Their event ids still come from the
+// sequence of the build, so they keep their order.
+func (b *build) copyEvents(tx Tx, events []atc.Event) error {
+	stmt, err := tx.Prepare(pq.CopyIn(b.eventsTable(), "event_id", "build_id", "type", "version", "payload"))
+	if err != nil {
+		return err
+	}
+
+	defer stmt.Close()
+
+	for _, event := range events {
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+
+		_, err = stmt.Exec(b.eventIdSeq.Next(), b.id, string(event.EventType()), string(event.Version()), string(payload))
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = stmt.Exec()
+	return err
+}
+
 func (b *build) isForCheck() bool {
 	return b.resourceTypeID != 0 || b.resourceID != 0
 }
@@ -2110,6 +2631,10 @@ func buildAbortChannel(buildID int) string {
 	return fmt.Sprintf("build_abort_%d", buildID)
 }
 
+func buildStepAbortChannel(buildID int) string {
+	return fmt.Sprintf("build_step_abort_%d", buildID)
+}
+
 func latestCompletedNonRerunBuild(tx Tx, jobID int) (int, error) {
 	var latestNonRerunId int
 	err := latestCompletedBuildQuery.
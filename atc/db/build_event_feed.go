@@ -0,0 +1,317 @@
+package db
+
+import (
+	"sync"
+)
+
+// maxBufferedFeedEvents is how many events a feed keeps around for watchers
+// lagging behind. Watchers further behind read from the database instead.
+const maxBufferedFeedEvents = 1000
+
+// buildEventFeeds has the feeds of the builds being watched by this ATC.
+var buildEventFeeds = &buildEventFeedRegistry{
+	feeds: map[buildEventFeedKey]*buildEventFeed{},
+}
+
+type buildEventFeedKey struct {
+	conn    Conn
+	table   string
+	buildID int
+}
+
+type buildEventFeedRegistry struct {
+	lock  sync.Mutex
+	feeds map[buildEventFeedKey]*buildEventFeed
+}
+
+// subscribe returns the feed of the build, starting one if nobody is
+// watching the build yet.
+func (registry *buildEventFeedRegistry) subscribe(
+	key buildEventFeedKey,
+	source *buildEventSource,
+	cursor int,
+	watcher buildCompleteWatcherFunc,
+) (*buildEventFeed, error) {
+	registry.lock.Lock()
+	defer registry.lock.Unlock()
+
+	feed, found := registry.feeds[key]
+	if !found {
+		notifier, err := newConditionNotifier(key.conn.Bus(), buildEventsChannel(key.buildID), func() (bool, error) {
+			return true, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		feed = newBuildEventFeed(key, notifier, cursor, watcher)
+		registry.feeds[key] = feed
+	}
+
+	feed.join(source, cursor)
+
+	return feed, nil
+}
+
+// unsubscribe stops the feed once the last of its watchers is gone.
+func (registry *buildEventFeedRegistry) unsubscribe(feed *buildEventFeed, source *buildEventSource) error {
+	registry.lock.Lock()
+
+	if feed.leave(source) > 0 {
+		registry.lock.Unlock()
+		return nil
+	}
+
+	if registry.feeds[feed.key] == feed {
+		delete(registry.feeds, feed.key)
+	}
+
+	registry.lock.Unlock()
+
+	return feed.close()
+}
+
+// forget makes the next watcher of the build start a new feed.
+func (registry *buildEventFeedRegistry) forget(feed *buildEventFeed) {
+	registry.lock.Lock()
+	defer registry.lock.Unlock()
+
+	if registry.feeds[feed.key] == feed {
+		delete(registry.feeds, feed.key)
+	}
+}
+
+// buildEventFeed fetches the new events of a build whenever it's notified
+// of them, once for all of the build's watchers, and keeps the recent ones
+// around until every watcher has had them.
+type buildEventFeed struct {
+	key         buildEventFeedKey
+	notifier    Notifier
+	watcherFunc buildCompleteWatcherFunc
+
+	lock sync.Mutex
+
+	// start is the last event before the recent ones and cursor the last
+	// event fetched, so recent has every event after start up to cursor.
+	start  int
+	cursor int
+	recent []buildEventRecord
+
+	completed bool
+	err       error
+	changed   chan struct{}
+
+	watchers map[*buildEventSource]int
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// buildEventFeedUpdate is what's new in the feed for a watcher.
+type buildEventFeedUpdate struct {
+	events []buildEventRecord
+
+	// behind is set when the feed no longer has the events following the
+	// watcher's cursor; start is where the feed has them from.
+	behind bool
+	start  int
+
+	completed bool
+	err       error
+
+	// changed is closed once there is more in the feed.
+	changed <-chan struct{}
+}
+
+func newBuildEventFeed(key buildEventFeedKey, notifier Notifier, cursor int, watcher buildCompleteWatcherFunc) *buildEventFeed {
+	feed := &buildEventFeed{
+		key:         key,
+		notifier:    notifier,
+		watcherFunc: watcher,
+
+		start:  cursor,
+		cursor: cursor,
+
+		changed:  make(chan struct{}),
+		watchers: map[*buildEventSource]int{},
+
+		stop: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+
+	go feed.run()
+
+	return feed
+}
+
+func (feed *buildEventFeed) join(source *buildEventSource, cursor int) {
+	feed.lock.Lock()
+	defer feed.lock.Unlock()
+
+	feed.watchers[source] = cursor
+}
+
+func (feed *buildEventFeed) leave(source *buildEventSource) int {
+	feed.lock.Lock()
+	defer feed.lock.Unlock()
+
+	delete(feed.watchers, source)
+	feed.trim()
+
+	return len(feed.watchers)
+}
+
+func (feed *buildEventFeed) close() error {
+	close(feed.stop)
+	<-feed.done
+
+	return feed.notifier.Close()
+}
+
+// next returns the events following the cursor of the watcher.
+func (feed *buildEventFeed) next(source *buildEventSource, cursor int) buildEventFeedUpdate {
+	feed.lock.Lock()
+	defer feed.lock.Unlock()
+
+	feed.watchers[source] = cursor
+
+	if cursor < feed.start {
+		return buildEventFeedUpdate{
+			behind: true,
+			start:  feed.start,
+		}
+	}
+
+	events := []buildEventRecord{}
+	for _, ev := range feed.recent {
+		if ev.id <= cursor {
+			continue
+		}
+
+		events = append(events, ev)
+		if len(events) == buildEventBatchSize {
+			break
+		}
+	}
+
+	if len(events) > 0 {
+		feed.watchers[source] = events[len(events)-1].id
+		feed.trim()
+	}
+
+	return buildEventFeedUpdate{
+		events:    events,
+		completed: feed.completed,
+		err:       feed.err,
+		changed:   feed.changed,
+	}
+}
+
+func (feed *buildEventFeed) run() {
+	defer close(feed.done)
+
+	for {
+		select {
+		case <-feed.notifier.Notify():
+		case <-feed.stop:
+			return
+		}
+
+		for {
+			feed.lock.Lock()
+			cursor := feed.cursor
+			feed.lock.Unlock()
+
+			events, completed, err := feed.fetchEvents(cursor)
+			if err != nil {
+				feed.publish(nil, false, err)
+				buildEventFeeds.forget(feed)
+				return
+			}
+
+			// the build was completed before fetching the events, so
+			// they're only all there is once there are no more to fetch
+			done := completed && len(events) < buildEventBatchSize
+
+			feed.publish(events, done, nil)
+
+			if done {
+				return
+			}
+
+			if len(events) < buildEventBatchSize {
+				break
+			}
+		}
+	}
+}
+
+func (feed *buildEventFeed) fetchEvents(cursor int) ([]buildEventRecord, bool, error) {
+	tx, err := feed.key.conn.Begin()
+	if err != nil {
+		return nil, false, err
+	}
+
+	defer Rollback(tx)
+
+	completed, err := feed.watcherFunc(tx, feed.key.buildID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	events, err := fetchBuildEvents(tx, feed.key.table, feed.key.buildID, cursor)
+	if err != nil {
+		return nil, false, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, false, err
+	}
+
+	return events, completed, nil
+}
+
+func (feed *buildEventFeed) publish(events []buildEventRecord, completed bool, err error) {
+	feed.lock.Lock()
+	defer feed.lock.Unlock()
+
+	if len(events) > 0 {
+		feed.recent = append(feed.recent, events...)
+		feed.cursor = events[len(events)-1].id
+		feed.trim()
+	}
+
+	feed.completed = completed
+	feed.err = err
+
+	close(feed.changed)
+	feed.changed = make(chan struct{})
+}
+
+// trim drops the events every watcher has had already, and the oldest ones
+// past maxBufferedFeedEvents.
+func (feed *buildEventFeed) trim() {
+	seen := feed.cursor
+	for _, cursor := range feed.watchers {
+		if cursor >= feed.start && cursor < seen {
+			seen = cursor
+		}
+	}
+
+	drop := 0
+	for drop < len(feed.recent) {
+		if feed.recent[drop].id > seen && len(feed.recent)-drop <= maxBufferedFeedEvents {
+			break
+		}
+
+		drop++
+	}
+
+	if drop == 0 {
+		return
+	}
+
+	feed.start = feed.recent[drop-1].id
+	feed.recent = feed.recent[drop:]
+}
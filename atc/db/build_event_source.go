@@ -15,6 +15,9 @@ import (
 var ErrEndOfBuildEventStream = errors.New("end of build event stream")
 var ErrBuildEventStreamClosed = errors.New("build event stream closed")
 
+// buildEventBatchSize is how many events are fetched at once.
+const buildEventBatchSize = 2000
+
 //counterfeiter:generate . EventSource
 type EventSource interface {
 	Next() (event.Envelope, error)
@@ -27,46 +30,57 @@ func newBuildEventSource(
 	buildID int,
 	table string,
 	conn Conn,
-	notifier Notifier,
 	from uint,
 	watcher buildCompleteWatcherFunc,
-) *buildEventSource {
-	wg := new(sync.WaitGroup)
-
+) (EventSource, error) {
 	source := &buildEventSource{
 		buildID: buildID,
 		table:   table,
 
 		conn: conn,
 
-		notifier: notifier,
-
-		events: make(chan event.Envelope, 2000),
+		events: make(chan event.Envelope, buildEventBatchSize),
 		stop:   make(chan struct{}),
-		wg:     wg,
+		wg:     new(sync.WaitGroup),
+	}
+
+	// cursor points to the last emitted event, so subtract 1
+	// (the first event is fetched using cursor == -1)
+	cursor := int(from) - 1
 
-		watcherFunc: watcher,
+	feed, err := buildEventFeeds.subscribe(
+		buildEventFeedKey{conn: conn, table: table, buildID: buildID},
+		source,
+		cursor,
+		watcher,
+	)
+	if err != nil {
+		return nil, err
 	}
 
-	wg.Add(1)
-	go source.collectEvents(from)
+	source.feed = feed
 
-	return source
+	source.wg.Add(1)
+	go source.collectEvents(cursor)
+
+	return source, nil
 }
 
+// buildEventSource streams the events of a build to one watcher. Once it has
+// caught up it reads them from the feed shared by every watcher of the build,
+// so that a lot of watchers don't each query the events table every time an
+// event is saved.
 type buildEventSource struct {
 	buildID int
 	table   string
 
-	conn     Conn
-	notifier Notifier
+	conn Conn
+	feed *buildEventFeed
 
 	events chan event.Envelope
 	stop   chan struct{}
 	err    error
 	wg     *sync.WaitGroup
-
-	watcherFunc buildCompleteWatcherFunc
 }
 
 func (source *buildEventSource) Next() (event.Envelope, error) {
@@ -88,123 +102,180 @@ func (source *buildEventSource) Close() error {
 
 	source.wg.Wait()
 
-	return source.notifier.Close()
+	return buildEventFeeds.unsubscribe(source.feed, source)
 }
 
-func (source *buildEventSource) collectEvents(from uint) {
+func (source *buildEventSource) collectEvents(cursor int) {
 	defer source.wg.Done()
 
-	batchSize := cap(source.events)
-	// cursor points to the last emitted event, so subtract 1
-	// (the first event is fetched using cursor == -1)
-	cursor := int(from) - 1
-
 	for {
 		select {
 		case <-source.stop:
-			source.err = ErrBuildEventStreamClosed
-			close(source.events)
+			source.end(ErrBuildEventStreamClosed)
 			return
 		default:
 		}
 
-		tx, err := source.conn.Begin()
-		if err != nil {
+		update := source.feed.next(source, cursor)
+		if update.err != nil {
+			source.end(update.err)
 			return
 		}
 
-		defer Rollback(tx)
+		events := update.events
+		if update.behind {
+			// the feed no longer has the events this watcher is up to, so
+			// catch up from the database
+			var err error
+			events, err = source.fetchEvents(cursor)
+			if err != nil {
+				source.end(err)
+				return
+			}
 
-		completed, err := source.watcherFunc(tx, source.buildID)
-		if err != nil {
-			source.err = err
-			close(source.events)
-			return
+			if len(events) == 0 {
+				cursor = update.start
+				continue
+			}
+		}
+
+		for _, ev := range events {
+			select {
+			case source.events <- ev.envelope:
+				cursor = ev.id
+			case <-source.stop:
+				source.end(ErrBuildEventStreamClosed)
+				return
+			}
 		}
 
-		eventsQuery := psql.Select("event_id", "type", "version", "payload").
-			From(source.table)
-
-		var query sq.SelectBuilder
-		if source.buildID > math.MaxInt32 {
-			query = eventsQuery.Where(sq.Eq{"build_id": source.buildID})
-		} else {
-			query = eventsQuery.Where(sq.Or{
-				sq.Eq{"build_id": source.buildID},
-				sq.Eq{"build_id_old": source.buildID},
-			})
+		if len(events) > 0 {
+			continue
 		}
 
-		rows, err := query.
-			Where(sq.Gt{"event_id": cursor}).
-			OrderBy("event_id ASC").
-			Limit(uint64(batchSize)).
-			RunWith(tx).
-			Query()
-		if err != nil {
-			source.err = err
-			close(source.events)
+		if update.completed {
+			source.end(ErrEndOfBuildEventStream)
 			return
 		}
 
-		rowsReturned := 0
+		select {
+		case <-update.changed:
+		case <-source.stop:
+			source.end(ErrBuildEventStreamClosed)
+			return
+		}
+	}
+}
 
-		for rows.Next() {
-			rowsReturned++
+func (source *buildEventSource) fetchEvents(cursor int) ([]buildEventRecord, error) {
+	tx, err := source.conn.Begin()
+	if err != nil {
+		return nil, err
+	}
 
-			var t, v, p string
-			err := rows.Scan(&cursor, &t, &v, &p)
-			if err != nil {
-				_ = rows.Close()
+	defer Rollback(tx)
 
-				source.err = err
-				close(source.events)
-				return
-			}
+	events, err := fetchBuildEvents(tx, source.table, source.buildID, cursor)
+	if err != nil {
+		return nil, err
+	}
 
-			data := json.RawMessage(p)
+	return events, tx.Commit()
+}
 
-			ev := event.Envelope{
-				Data:    &data,
-				Event:   atc.EventType(t),
-				Version: atc.EventVersion(v),
-				EventID: strconv.Itoa(cursor),
-			}
+func (source *buildEventSource) end(err error) {
+	source.err = err
+	close(source.events)
+}
 
-			select {
-			case source.events <- ev:
-			case <-source.stop:
-				_ = rows.Close()
+// savedBuildEvents returns the events saved for the build so far.
+func savedBuildEvents(conn Conn, table string, buildID int) ([]event.Envelope, error) {
+	tx, err := conn.Begin()
+	if err != nil {
+		return nil, err
+	}
 
-				source.err = ErrBuildEventStreamClosed
-				close(source.events)
-				return
-			}
-		}
+	defer Rollback(tx)
 
-		err = tx.Commit()
+	envelopes := []event.Envelope{}
+	cursor := -1
+	for {
+		events, err := fetchBuildEvents(tx, table, buildID, cursor)
 		if err != nil {
-			close(source.events)
-			return
+			return nil, err
 		}
 
-		if rowsReturned == batchSize {
-			// still more events
-			continue
+		for _, ev := range events {
+			envelopes = append(envelopes, ev.envelope)
+			cursor = ev.id
 		}
 
-		if completed {
-			source.err = ErrEndOfBuildEventStream
-			close(source.events)
-			return
+		if len(events) < buildEventBatchSize {
+			break
 		}
+	}
 
-		select {
-		case <-source.notifier.Notify():
-		case <-source.stop:
-			source.err = ErrBuildEventStreamClosed
-			close(source.events)
-			return
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return envelopes, nil
+}
+
+// buildEventRecord is an event along with its position in the build.
+type buildEventRecord struct {
+	id       int
+	envelope event.Envelope
+}
+
+func fetchBuildEvents(tx Tx, table string, buildID int, cursor int) ([]buildEventRecord, error) {
+	eventsQuery := psql.Select("event_id", "type", "version", "payload").
+		From(table)
+
+	var query sq.SelectBuilder
+	if buildID > math.MaxInt32 {
+		query = eventsQuery.Where(sq.Eq{"build_id": buildID})
+	} else {
+		query = eventsQuery.Where(sq.Or{
+			sq.Eq{"build_id": buildID},
+			sq.Eq{"build_id_old": buildID},
+		})
+	}
+
+	rows, err := query.
+		Where(sq.Gt{"event_id": cursor}).
+		OrderBy("event_id ASC").
+		Limit(uint64(buildEventBatchSize)).
+		RunWith(tx).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	events := []buildEventRecord{}
+	for rows.Next() {
+		var id int
+		var t, v, p string
+		err := rows.Scan(&id, &t, &v, &p)
+		if err != nil {
+			return nil, err
 		}
+
+		data := json.RawMessage(p)
+
+		events = append(events, buildEventRecord{
+			id: id,
+			envelope: event.Envelope{
+				Data:    &data,
+				Event:   atc.EventType(t),
+				Version: atc.EventVersion(v),
+				EventID: strconv.Itoa(id),
+			},
+		})
 	}
+
+	return events, rows.Err()
 }
@@ -10,7 +10,9 @@ import (
 	"code.cloudfoundry.org/lager"
 
 	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db/lock"
+	"github.com/concourse/concourse/atc/event"
 )
 
 //counterfeiter:generate . BuildForAPI
@@ -53,16 +55,26 @@ type BuildForAPI interface {
 	RerunOfName() string
 	RerunNumber() int
 	CreatedBy() *string
+	Vars() []atc.BuildVar
+	CollapsedInto() int
+	RetryOf() int
+	ExternalRef() string
+	FailureReason() atc.FailureReason
+	StartLatency() *atc.BuildStartLatency
+	EstimatedDuration() (time.Duration, bool)
 
 	IsDrained() bool
 	IsRunning() bool
 
 	Artifacts() ([]WorkerArtifact, error)
 	Events(uint) (EventSource, error)
+	SavedEvents() ([]event.Envelope, error)
 	Resources() ([]BuildInput, []BuildOutput, error)
 	Preparation() (BuildPreparation, bool, error)
+	Provenance() (json.RawMessage, bool, error)
 
 	MarkAsAborted() error
+	AbortStep(atc.PlanID) error
 	SetComment(string) error
 }
 
@@ -73,6 +85,11 @@ type BuildFactory interface {
 	AllBuilds(Page) ([]BuildForAPI, Pagination, error)
 	PublicBuilds(Page) ([]BuildForAPI, Pagination, error)
 
+	// VisibleBuildsWithExternalRef and AllBuildsWithExternalRef find the
+	// builds triggered with the given external reference, newest first.
+	VisibleBuildsWithExternalRef([]string, string) ([]BuildForAPI, error)
+	AllBuildsWithExternalRef(string) ([]BuildForAPI, error)
+
 	Build(int) (Build, bool, error)
 	GetAllStartedBuilds() ([]Build, error)
 	GetDrainableBuilds() ([]Build, error)
@@ -155,7 +172,7 @@ func (f *buildFactory) Build(buildID int) (Build, bool, error) {
 func (f *buildFactory) VisibleBuilds(teamNames []string, page Page) ([]BuildForAPI, Pagination, error) {
 	newBuildsQuery := buildsQuery.
 		Where(sq.Or{
-			sq.Eq{"p.public": true},
+			publicJobCondition,
 			sq.Eq{"t.name": teamNames},
 		})
 
@@ -178,10 +195,38 @@ func (f *buildFactory) AllBuilds(page Page) ([]BuildForAPI, Pagination, error) {
 
 func (f *buildFactory) PublicBuilds(page Page) ([]BuildForAPI, Pagination, error) {
 	return getBuildsWithPagination(
-		buildsQuery.Where(sq.Eq{"p.public": true}), minMaxIdQuery,
+		buildsQuery.Where(publicJobCondition), minMaxIdQuery,
 		page, f.conn, f.lockFactory, false)
 }
 
+func (f *buildFactory) VisibleBuildsWithExternalRef(teamNames []string, externalRef string) ([]BuildForAPI, error) {
+	return f.buildsWithExternalRef(buildsQuery.
+		Where(sq.Or{
+			publicJobCondition,
+			sq.Eq{"t.name": teamNames},
+		}), externalRef)
+}
+
+func (f *buildFactory) AllBuildsWithExternalRef(externalRef string) ([]BuildForAPI, error) {
+	return f.buildsWithExternalRef(buildsQuery, externalRef)
+}
+
+func (f *buildFactory) buildsWithExternalRef(query sq.SelectBuilder, externalRef string) ([]BuildForAPI, error) {
+	builds, err := getBuilds(query.
+		Where(sq.Eq{"b.external_ref": externalRef}).
+		OrderBy("b.id DESC"), f.conn, f.lockFactory)
+	if err != nil {
+		return nil, err
+	}
+
+	buildsForAPI := make([]BuildForAPI, len(builds))
+	for i, build := range builds {
+		buildsForAPI[i] = build
+	}
+
+	return buildsForAPI, nil
+}
+
 func (f *buildFactory) MarkNonInterceptibleBuilds() error {
 	_, err := psql.Update("builds b").
 		Set("interceptible", false).
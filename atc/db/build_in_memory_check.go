@@ -71,6 +71,11 @@ func (b *inMemoryCheckBuildForApi) StartTime() time.Time              { return b
 func (b *inMemoryCheckBuildForApi) EndTime() time.Time                { return b.endTime }
 func (b *inMemoryCheckBuildForApi) Status() BuildStatus               { return b.status }
 func (b *inMemoryCheckBuildForApi) CreatedBy() *string                { return nil }
+func (b *inMemoryCheckBuildForApi) Vars() []atc.BuildVar              { return nil }
+func (b *inMemoryCheckBuildForApi) CollapsedInto() int                { return 0 }
+func (b *inMemoryCheckBuildForApi) RetryOf() int                      { return 0 }
+func (b *inMemoryCheckBuildForApi) ExternalRef() string               { return "" }
+func (b *inMemoryCheckBuildForApi) FailureReason() atc.FailureReason  { return "" }
 func (b *inMemoryCheckBuildForApi) Schema() string                    { return schema }
 func (b *inMemoryCheckBuildForApi) IsRunning() bool                   { return b.status == BuildStatusStarted }
 func (b *inMemoryCheckBuildForApi) IsDrained() bool                   { return false }
@@ -78,6 +83,8 @@ func (b *inMemoryCheckBuildForApi) PipelineInstanceVars() atc.InstanceVars {
 	return b.checkable.PipelineInstanceVars()
 }
 
+func (b *inMemoryCheckBuildForApi) StartLatency() *atc.BuildStartLatency { return nil }
+
 // JobID returns 0 because check build doesn't belong to any job.
 func (b *inMemoryCheckBuildForApi) JobID() int { return 0 }
 
@@ -175,24 +182,16 @@ func (b *inMemoryCheckBuildForApi) Events(from uint) (EventSource, error) {
 		return nil, fmt.Errorf("no build event")
 	}
 
-	notifier, err := newConditionNotifier(b.conn.Bus(), buildEventsChannel(b.id), func() (bool, error) {
-		return true, nil
-	})
-	if err != nil {
-		return nil, err
-	}
-
 	return newBuildEventSource(
 		b.id,
 		"check_build_events",
 		b.conn,
-		notifier,
 		from,
 		func(tx Tx, buildID int) (bool, error) {
 			completed := false
 
 			var lastCheckStartTime, lastCheckEndTime pq.NullTime
-			err = psql.Select("rcs.last_check_start_time", "rcs.last_check_end_time").
+			err := psql.Select("rcs.last_check_start_time", "rcs.last_check_end_time").
 				From("resource_config_scopes rcs").
 				Join("resources r ON r.resource_config_scope_id = rcs.id").
 				Where(sq.Eq{"r.id": b.resourceId}).
@@ -230,7 +229,15 @@ func (b *inMemoryCheckBuildForApi) Events(from uint) (EventSource, error) {
 
 			return completed, nil
 		},
-	), nil
+	)
+}
+
+func (b *inMemoryCheckBuildForApi) SavedEvents() ([]event.Envelope, error) {
+	if b.id == 0 {
+		return nil, fmt.Errorf("no build event")
+	}
+
+	return savedBuildEvents(b.conn, "check_build_events", b.id)
 }
 
 func (b *inMemoryCheckBuildForApi) RerunOf() int        { return 0 }
@@ -249,9 +256,21 @@ func (b *inMemoryCheckBuildForApi) Artifacts() ([]WorkerArtifact, error) {
 func (b *inMemoryCheckBuildForApi) Resources() ([]BuildInput, []BuildOutput, error) {
 	return nil, nil, errors.New("not implemented for in memory build")
 }
+func (b *inMemoryCheckBuildForApi) Provenance() (json.RawMessage, bool, error) {
+	return nil, false, nil
+}
+func (b *inMemoryCheckBuildForApi) EstimatedDuration() (time.Duration, bool) {
+	return 0, false
+}
+func (b *inMemoryCheckBuildForApi) CollapseInto(int) (bool, error) {
+	return false, errors.New("not implemented for in memory build")
+}
 func (b *inMemoryCheckBuildForApi) MarkAsAborted() error {
 	return errors.New("not implemented for in memory build")
 }
+func (b *inMemoryCheckBuildForApi) AbortStep(atc.PlanID) error {
+	return errors.New("not implemented for in memory build")
+}
 func (b *inMemoryCheckBuildForApi) Preparation() (BuildPreparation, bool, error) {
 	return BuildPreparation{}, false, errors.New("not implemented for in memory build")
 }
@@ -543,6 +562,17 @@ func (b *inMemoryCheckBuild) SaveEvent(ev atc.Event) error {
 	return b.conn.Bus().Notify(buildEventsChannel(b.id))
 }
 
+func (b *inMemoryCheckBuild) SaveEvents(events []atc.Event) error {
+	for _, ev := range events {
+		err := b.SaveEvent(ev)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // AbortNotifier returns nil because there is no way to abort a in-memory
 // check build. Say a in-memory build may run on ATC-a, but abort-build API call
 // might be received by ATC-b, there is not a channel for ATC-b to tell ATC-a to
@@ -554,6 +584,16 @@ func (b *inMemoryCheckBuild) AbortNotifier() (Notifier, error) {
 	return nil, nil
 }
 
+// AbortedSteps and StepAbortNotifier return nothing for the same reason as
+// AbortNotifier.
+func (b *inMemoryCheckBuild) AbortedSteps() ([]atc.PlanID, error) {
+	return nil, nil
+}
+
+func (b *inMemoryCheckBuild) StepAbortNotifier() (Notifier, error) {
+	return nil, nil
+}
+
 // ResourceCacheUser will use in-memory build's preId as key in order to avoid unnecessary
 // db init. To ensure preId is unique across all ATCs, also use build's create time in
 // the key.
@@ -577,6 +617,26 @@ func (b *inMemoryCheckBuild) SaveImageResourceVersion(ResourceCache) error {
 	return nil
 }
 
+func (b *inMemoryCheckBuild) ImageResourceVersions() ([]BuildImageResourceVersion, error) {
+	return nil, nil
+}
+
+func (b *inMemoryCheckBuild) WorkerNames() ([]string, error) {
+	return nil, errors.New("not implemented for in memory build")
+}
+
+func (b *inMemoryCheckBuild) StepDurationEstimate(string) (time.Duration, bool, error) {
+	return 0, false, nil
+}
+
+func (b *inMemoryCheckBuild) SaveStepDuration(string, time.Duration) error {
+	return nil
+}
+
+func (b *inMemoryCheckBuild) SaveProvenance(json.RawMessage) error {
+	return errors.New("not implemented for in memory build")
+}
+
 func (b *inMemoryCheckBuild) initDbStuff(tx Tx) error {
 	var nextBuildId int
 	err := psql.Select("nextval('builds_id_seq'::regclass)").RunWith(tx).QueryRow().Scan(&nextBuildId)
@@ -653,6 +713,16 @@ func (b *inMemoryCheckBuild) SetInterceptible(bool) error {
 	return errors.New("not implemented for in memory build")
 }
 
+// SetFailureReason is a no-op, as there is no build to record the reason on.
+func (b *inMemoryCheckBuild) SetFailureReason(atc.FailureReason) error {
+	return nil
+}
+
+// SetStartLatency is a no-op, as check builds do not run tasks.
+func (b *inMemoryCheckBuild) SetStartLatency(atc.BuildStartLatency) (bool, error) {
+	return false, nil
+}
+
 func (b *inMemoryCheckBuild) Artifact(int) (WorkerArtifact, error) {
 	return nil, errors.New("not implemented for in memory build")
 }
@@ -671,6 +741,9 @@ func (b *inMemoryCheckBuild) AdoptInputsAndPipes() ([]BuildInput, bool, error) {
 func (b *inMemoryCheckBuild) AdoptRerunInputsAndPipes() ([]BuildInput, bool, error) {
 	return nil, false, errors.New("not implemented for in memory build")
 }
+func (b *inMemoryCheckBuild) PinnedInputs() ([]atc.BuildInputPin, error) {
+	return nil, nil
+}
 func (b *inMemoryCheckBuild) SaveOutput(string, ResourceCache, atc.Source, atc.Version, ResourceConfigMetadataFields, string, string) error {
 	return errors.New("not implemented for in memory build")
 }
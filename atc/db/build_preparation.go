@@ -35,6 +35,7 @@ type BuildPreparation struct {
 	PausedPipeline      BuildPreparationStatus
 	PausedJob           BuildPreparationStatus
 	MaxRunningBuilds    BuildPreparationStatus
+	WorkerCapacity      BuildPreparationStatus
 	Inputs              map[string]BuildPreparationStatus
 	InputsSatisfied     BuildPreparationStatus
 	MissingInputReasons MissingInputReasons
@@ -0,0 +1,118 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
+)
+
+// BuildImageResourceVersion is the version of an image fetched for one of
+// the build's steps, e.g. the image of a task.
+type BuildImageResourceVersion struct {
+	// Type is the base resource type of the image, and is empty when the
+	// image was fetched using a custom resource type.
+	Type    string
+	Version atc.Version
+}
+
+func (b *build) ImageResourceVersions() ([]BuildImageResourceVersion, error) {
+	rows, err := psql.Select("COALESCE(brt.name, '')", "rc.version").
+		From("build_image_resource_caches birc").
+		Join("resource_caches rc ON rc.id = birc.resource_cache_id").
+		Join("resource_configs rcfg ON rcfg.id = rc.resource_config_id").
+		LeftJoin("base_resource_types brt ON brt.id = rcfg.base_resource_type_id").
+		Where(sq.Eq{"birc.build_id": b.id}).
+		OrderBy("rc.id").
+		RunWith(b.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	versions := []BuildImageResourceVersion{}
+	for rows.Next() {
+		var (
+			version BuildImageResourceVersion
+			blob    string
+		)
+
+		err = rows.Scan(&version.Type, &blob)
+		if err != nil {
+			return nil, err
+		}
+
+		err = json.Unmarshal([]byte(blob), &version.Version)
+		if err != nil {
+			return nil, err
+		}
+
+		versions = append(versions, version)
+	}
+
+	return versions, nil
+}
+
+// WorkerNames returns the names of the workers which ran the build's
+// containers, as long as the containers have not yet been garbage
+// collected.
+func (b *build) WorkerNames() ([]string, error) {
+	rows, err := psql.Select("DISTINCT worker_name").
+		From("containers").
+		Where(sq.Eq{"build_id": b.id}).
+		OrderBy("worker_name").
+		RunWith(b.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	names := []string{}
+	for rows.Next() {
+		var name string
+		err = rows.Scan(&name)
+		if err != nil {
+			return nil, err
+		}
+
+		names = append(names, name)
+	}
+
+	return names, nil
+}
+
+// SaveProvenance stores the signed provenance of the build, replacing any
+// which was previously stored.
+func (b *build) SaveProvenance(envelope json.RawMessage) error {
+	_, err := psql.Insert("build_provenance").
+		Columns("build_id", "envelope").
+		Values(b.id, string(envelope)).
+		Suffix("ON CONFLICT (build_id) DO UPDATE SET envelope = EXCLUDED.envelope, created_at = now()").
+		RunWith(b.conn).
+		Exec()
+	return err
+}
+
+func (b *build) Provenance() (json.RawMessage, bool, error) {
+	var envelope string
+	err := psql.Select("envelope").
+		From("build_provenance").
+		Where(sq.Eq{"build_id": b.id}).
+		RunWith(b.conn).
+		QueryRow().
+		Scan(&envelope)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, false, nil
+		}
+
+		return nil, false, err
+	}
+
+	return json.RawMessage(envelope), true, nil
+}
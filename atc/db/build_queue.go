@@ -0,0 +1,127 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
+)
+
+// BuildQueueRepository finds the builds of a team's jobs which have yet to
+// finish, for working out when they are expected to start and finish.
+//
+//counterfeiter:generate . BuildQueueRepository
+type BuildQueueRepository interface {
+	TeamBuildQueue(teamID int) ([]QueuedBuild, error)
+}
+
+// QueuedBuild is a pending or started build of a job, along with what limits
+// how many builds of the job run at once. StartTime is zero for pending
+// builds.
+type QueuedBuild struct {
+	ID                   int
+	Name                 string
+	Status               BuildStatus
+	TeamName             string
+	PipelineID           int
+	PipelineName         string
+	PipelineInstanceVars atc.InstanceVars
+	JobID                int
+	JobName              string
+	MaxInFlight          int
+	Paused               bool
+
+	CreateTime time.Time
+	StartTime  time.Time
+}
+
+type buildQueueRepository struct {
+	conn Conn
+}
+
+func NewBuildQueueRepository(conn Conn) BuildQueueRepository {
+	return &buildQueueRepository{
+		conn: conn,
+	}
+}
+
+// TeamBuildQueue returns the unfinished builds of the team's active jobs in
+// the order they were created, which is the order the pending ones of each
+// job are started in. A build is paused if its job or pipeline is.
+func (repo *buildQueueRepository) TeamBuildQueue(teamID int) ([]QueuedBuild, error) {
+	rows, err := psql.Select(
+		"b.id",
+		"b.name",
+		"b.status",
+		"t.name",
+		"p.id",
+		"p.name",
+		"p.instance_vars",
+		"j.id",
+		"j.name",
+		"j.max_in_flight",
+		"j.paused OR p.paused",
+		"b.create_time",
+		"b.start_time",
+	).
+		From("builds b").
+		Join("jobs j ON j.id = b.job_id").
+		Join("pipelines p ON p.id = j.pipeline_id").
+		Join("teams t ON t.id = p.team_id").
+		Where(sq.Eq{
+			"p.team_id":   teamID,
+			"j.active":    true,
+			"b.completed": false,
+		}).
+		OrderBy("b.id").
+		RunWith(repo.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	builds := []QueuedBuild{}
+	for rows.Next() {
+		var (
+			build        QueuedBuild
+			instanceVars sql.NullString
+			startTime    sql.NullTime
+		)
+
+		err := rows.Scan(
+			&build.ID,
+			&build.Name,
+			&build.Status,
+			&build.TeamName,
+			&build.PipelineID,
+			&build.PipelineName,
+			&instanceVars,
+			&build.JobID,
+			&build.JobName,
+			&build.MaxInFlight,
+			&build.Paused,
+			&build.CreateTime,
+			&startTime,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if instanceVars.Valid {
+			err = json.Unmarshal([]byte(instanceVars.String), &build.PipelineInstanceVars)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		build.StartTime = startTime.Time
+
+		builds = append(builds, build)
+	}
+
+	return builds, rows.Err()
+}
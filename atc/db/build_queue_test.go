@@ -0,0 +1,78 @@
+package db_test
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("BuildQueueRepository", func() {
+	var repository db.BuildQueueRepository
+
+	BeforeEach(func() {
+		repository = db.NewBuildQueueRepository(dbConn)
+	})
+
+	Describe("TeamBuildQueue", func() {
+		var startedBuild, pendingBuild db.Build
+
+		BeforeEach(func() {
+			var err error
+			startedBuild, err = defaultJob.CreateBuild(defaultBuildCreatedBy)
+			Expect(err).ToNot(HaveOccurred())
+
+			started, err := startedBuild.Start(atc.Plan{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(started).To(BeTrue())
+
+			pendingBuild, err = defaultJob.CreateBuild(defaultBuildCreatedBy)
+			Expect(err).ToNot(HaveOccurred())
+
+			finishedBuild, err := defaultJob.CreateBuild(defaultBuildCreatedBy)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(finishedBuild.Finish(db.BuildStatusSucceeded)).To(Succeed())
+
+			_, err = defaultTeam.CreateOneOffBuild()
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("returns the unfinished builds of the team's jobs in order", func() {
+			builds, err := repository.TeamBuildQueue(defaultTeam.ID())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(builds).To(HaveLen(2))
+
+			Expect(builds[0].ID).To(Equal(startedBuild.ID()))
+			Expect(builds[0].Status).To(Equal(db.BuildStatusStarted))
+			Expect(builds[0].StartTime).ToNot(BeZero())
+
+			Expect(builds[1].ID).To(Equal(pendingBuild.ID()))
+			Expect(builds[1].Status).To(Equal(db.BuildStatusPending))
+			Expect(builds[1].StartTime).To(BeZero())
+			Expect(builds[1].JobID).To(Equal(defaultJob.ID()))
+			Expect(builds[1].JobName).To(Equal(defaultJob.Name()))
+			Expect(builds[1].PipelineName).To(Equal(defaultPipeline.Name()))
+			Expect(builds[1].TeamName).To(Equal(defaultTeam.Name()))
+			Expect(builds[1].MaxInFlight).To(Equal(defaultJob.MaxInFlight()))
+			Expect(builds[1].Paused).To(BeFalse())
+		})
+
+		It("doesn't return the builds of other teams", func() {
+			builds, err := repository.TeamBuildQueue(defaultTeam.ID() + 1)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(builds).To(BeEmpty())
+		})
+
+		Context("when the pipeline is paused", func() {
+			BeforeEach(func() {
+				Expect(defaultPipeline.Pause("")).To(Succeed())
+			})
+
+			It("marks the builds as paused", func() {
+				builds, err := repository.TeamBuildQueue(defaultTeam.ID())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(builds[1].Paused).To(BeTrue())
+			})
+		})
+	})
+})
@@ -0,0 +1,158 @@
+package db
+
+import (
+	"database/sql"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/lib/pq"
+)
+
+// BuildStatsRepository maintains hourly rollups of the builds of each job,
+// from which statistics such as duration percentiles and success rates are
+// computed without going through all of the builds.
+//
+//counterfeiter:generate . BuildStatsRepository
+type BuildStatsRepository interface {
+	LatestBuildStatsBucket() (time.Time, bool, error)
+	AggregateBuildStats(since time.Time) error
+	DeleteBuildStatsBefore(before time.Time) error
+
+	BuildStats(jobIDs []int, since time.Time) ([]BuildStatsBucket, error)
+}
+
+// BuildStatsBucket is the rollup of the builds of a job which finished
+// within the hour starting at Start. Durations and queue times are in
+// seconds.
+type BuildStatsBucket struct {
+	JobID int
+	Start time.Time
+
+	Succeeded int
+	Failed    int
+	Errored   int
+	Aborted   int
+
+	Durations  []int64
+	QueueTimes []int64
+}
+
+type buildStatsRepository struct {
+	conn Conn
+}
+
+func NewBuildStatsRepository(conn Conn) BuildStatsRepository {
+	return &buildStatsRepository{
+		conn: conn,
+	}
+}
+
+func (repo *buildStatsRepository) LatestBuildStatsBucket() (time.Time, bool, error) {
+	var latest sql.NullTime
+	err := psql.Select("max(bucket)").
+		From("job_build_stats").
+		RunWith(repo.conn).
+		QueryRow().
+		Scan(&latest)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+
+	return latest.Time, latest.Valid, nil
+}
+
+// AggregateBuildStats (re-)computes the rollups of every hour from the one
+// containing since onwards, replacing any which were computed before the
+// hour was over.
+func (repo *buildStatsRepository) AggregateBuildStats(since time.Time) error {
+	_, err := repo.conn.Exec(`
+		INSERT INTO job_build_stats (job_id, bucket, succeeded, failed, errored, aborted, durations, queue_times)
+		SELECT
+			b.job_id,
+			date_trunc('hour', b.end_time),
+			count(*) FILTER (WHERE b.status = 'succeeded'),
+			count(*) FILTER (WHERE b.status = 'failed'),
+			count(*) FILTER (WHERE b.status = 'errored'),
+			count(*) FILTER (WHERE b.status = 'aborted'),
+			COALESCE(
+				array_agg(extract(epoch FROM b.end_time - b.start_time)::integer)
+					FILTER (WHERE b.status IN ('succeeded', 'failed') AND b.start_time IS NOT NULL),
+				'{}'
+			),
+			COALESCE(
+				array_agg(extract(epoch FROM b.start_time - b.create_time)::integer)
+					FILTER (WHERE b.start_time IS NOT NULL),
+				'{}'
+			)
+		FROM builds b
+		WHERE b.job_id IS NOT NULL
+		AND b.completed
+		AND b.end_time >= date_trunc('hour', $1::timestamptz)
+		GROUP BY b.job_id, date_trunc('hour', b.end_time)
+		ON CONFLICT (job_id, bucket) DO UPDATE SET
+			succeeded = EXCLUDED.succeeded,
+			failed = EXCLUDED.failed,
+			errored = EXCLUDED.errored,
+			aborted = EXCLUDED.aborted,
+			durations = EXCLUDED.durations,
+			queue_times = EXCLUDED.queue_times
+	`, since)
+	return err
+}
+
+func (repo *buildStatsRepository) DeleteBuildStatsBefore(before time.Time) error {
+	_, err := psql.Delete("job_build_stats").
+		Where(sq.Lt{"bucket": before}).
+		RunWith(repo.conn).
+		Exec()
+	return err
+}
+
+// BuildStats returns the rollups of the jobs from the hour containing since
+// onwards, ordered by job and then by time.
+func (repo *buildStatsRepository) BuildStats(jobIDs []int, since time.Time) ([]BuildStatsBucket, error) {
+	rows, err := psql.Select(
+		"job_id",
+		"bucket",
+		"succeeded",
+		"failed",
+		"errored",
+		"aborted",
+		"durations",
+		"queue_times",
+	).
+		From("job_build_stats").
+		Where(sq.Eq{"job_id": jobIDs}).
+		Where(sq.Expr("bucket >= date_trunc('hour', ?::timestamptz)", since)).
+		OrderBy("job_id", "bucket").
+		RunWith(repo.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	var buckets []BuildStatsBucket
+	for rows.Next() {
+		var bucket BuildStatsBucket
+
+		err := rows.Scan(
+			&bucket.JobID,
+			&bucket.Start,
+			&bucket.Succeeded,
+			&bucket.Failed,
+			&bucket.Errored,
+			&bucket.Aborted,
+			(*pq.Int64Array)(&bucket.Durations),
+			(*pq.Int64Array)(&bucket.QueueTimes),
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		buckets = append(buckets, bucket)
+	}
+
+	return buckets, nil
+}
@@ -0,0 +1,112 @@
+package db_test
+
+import (
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("BuildStatsRepository", func() {
+	var (
+		repository db.BuildStatsRepository
+		hour       time.Time
+	)
+
+	BeforeEach(func() {
+		repository = db.NewBuildStatsRepository(dbConn)
+		hour = time.Now().Add(-2 * time.Hour).Truncate(time.Hour)
+	})
+
+	finishedBuild := func(status db.BuildStatus, created, started, ended time.Time) {
+		build, err := defaultJob.CreateBuild(defaultBuildCreatedBy)
+		Expect(err).ToNot(HaveOccurred())
+
+		if !started.IsZero() {
+			_, err = build.Start(atc.Plan{})
+			Expect(err).ToNot(HaveOccurred())
+		}
+
+		Expect(build.Finish(status)).To(Succeed())
+
+		var startTime interface{}
+		if !started.IsZero() {
+			startTime = started
+		}
+
+		_, err = dbConn.Exec(
+			`UPDATE builds SET create_time = $2, start_time = $3, end_time = $4 WHERE id = $1`,
+			build.ID(), created, startTime, ended,
+		)
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	It("has no rollups to begin with", func() {
+		_, found, err := repository.LatestBuildStatsBucket()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeFalse())
+	})
+
+	Describe("AggregateBuildStats", func() {
+		BeforeEach(func() {
+			finishedBuild(db.BuildStatusSucceeded, hour, hour.Add(10*time.Second), hour.Add(70*time.Second))
+			finishedBuild(db.BuildStatusFailed, hour, hour.Add(20*time.Second), hour.Add(50*time.Second))
+			finishedBuild(db.BuildStatusAborted, hour, time.Time{}, hour.Add(5*time.Second))
+			finishedBuild(db.BuildStatusSucceeded, hour, hour.Add(time.Hour), hour.Add(time.Hour+30*time.Second))
+
+			err := repository.AggregateBuildStats(hour.Add(30 * time.Minute))
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("rolls up the builds of each hour", func() {
+			buckets, err := repository.BuildStats([]int{defaultJob.ID()}, hour)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(buckets).To(HaveLen(2))
+
+			Expect(buckets[0].JobID).To(Equal(defaultJob.ID()))
+			Expect(buckets[0].Start).To(BeTemporally("==", hour))
+			Expect(buckets[0].Succeeded).To(Equal(1))
+			Expect(buckets[0].Failed).To(Equal(1))
+			Expect(buckets[0].Aborted).To(Equal(1))
+			Expect(buckets[0].Durations).To(ConsistOf(int64(60), int64(30)))
+			Expect(buckets[0].QueueTimes).To(ConsistOf(int64(10), int64(20)))
+
+			Expect(buckets[1].Start).To(BeTemporally("==", hour.Add(time.Hour)))
+			Expect(buckets[1].Succeeded).To(Equal(1))
+			Expect(buckets[1].Durations).To(ConsistOf(int64(30)))
+			Expect(buckets[1].QueueTimes).To(ConsistOf(int64(3600)))
+		})
+
+		It("finds the latest rollup", func() {
+			latest, found, err := repository.LatestBuildStatsBucket()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(latest).To(BeTemporally("==", hour.Add(time.Hour)))
+		})
+
+		It("replaces the rollups when aggregating again", func() {
+			finishedBuild(db.BuildStatusErrored, hour, hour.Add(time.Hour), hour.Add(time.Hour+10*time.Second))
+
+			err := repository.AggregateBuildStats(hour.Add(time.Hour))
+			Expect(err).ToNot(HaveOccurred())
+
+			buckets, err := repository.BuildStats([]int{defaultJob.ID()}, hour.Add(time.Hour))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(buckets).To(HaveLen(1))
+			Expect(buckets[0].Succeeded).To(Equal(1))
+			Expect(buckets[0].Errored).To(Equal(1))
+		})
+
+		It("deletes old rollups", func() {
+			err := repository.DeleteBuildStatsBefore(hour.Add(time.Hour))
+			Expect(err).ToNot(HaveOccurred())
+
+			buckets, err := repository.BuildStats([]int{defaultJob.ID()}, hour)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(buckets).To(HaveLen(1))
+			Expect(buckets[0].Start).To(BeTemporally("==", hour.Add(time.Hour)))
+		})
+	})
+})
@@ -335,6 +335,35 @@ var _ = Describe("Build", func() {
 		})
 	})
 
+	Describe("Provenance", func() {
+		It("is not found until it is saved", func() {
+			_, found, err := build.Provenance()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		It("returns the latest saved provenance", func() {
+			err := build.SaveProvenance(json.RawMessage(`{"payload":"first"}`))
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.SaveProvenance(json.RawMessage(`{"payload":"second"}`))
+			Expect(err).NotTo(HaveOccurred())
+
+			envelope, found, err := build.Provenance()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(envelope).To(MatchJSON(`{"payload":"second"}`))
+		})
+	})
+
+	Describe("WorkerNames", func() {
+		It("returns no workers when the build has no containers", func() {
+			names, err := build.WorkerNames()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(names).To(BeEmpty())
+		})
+	})
+
 	Describe("Start", func() {
 		var err error
 		var started bool
@@ -417,6 +446,72 @@ var _ = Describe("Build", func() {
 		})
 	})
 
+	Describe("FailureReason", func() {
+		It("is empty by default", func() {
+			Expect(build.FailureReason()).To(BeEmpty())
+		})
+
+		It("can be set", func() {
+			err := build.SetFailureReason(atc.FailureReasonTaskFailed)
+			Expect(err).NotTo(HaveOccurred())
+
+			found, err := build.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(build.FailureReason()).To(Equal(atc.FailureReasonTaskFailed))
+		})
+
+		It("is set when the build finishes aborted", func() {
+			err := build.Finish(db.BuildStatusAborted)
+			Expect(err).NotTo(HaveOccurred())
+
+			found, err := build.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(build.FailureReason()).To(Equal(atc.FailureReasonAborted))
+		})
+
+		It("is left alone when the build finishes otherwise", func() {
+			err := build.Finish(db.BuildStatusFailed)
+			Expect(err).NotTo(HaveOccurred())
+
+			found, err := build.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(build.FailureReason()).To(BeEmpty())
+		})
+	})
+
+	Describe("StartLatency", func() {
+		It("is nil by default", func() {
+			Expect(build.StartLatency()).To(BeNil())
+		})
+
+		It("can be set once", func() {
+			latency := atc.BuildStartLatency{
+				Scheduling:        10,
+				InputFetch:        200,
+				ImageFetch:        3000,
+				ContainerCreation: 400,
+				VolumeStreaming:   50,
+				Total:             4000,
+			}
+
+			saved, err := build.SetStartLatency(latency)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(saved).To(BeTrue())
+
+			saved, err = build.SetStartLatency(atc.BuildStartLatency{Total: 1})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(saved).To(BeFalse())
+
+			found, err := build.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(build.StartLatency()).To(Equal(&latency))
+		})
+	})
+
 	Describe("Finish", func() {
 		var scenario *dbtest.Scenario
 		var build db.Build
@@ -959,6 +1054,71 @@ var _ = Describe("Build", func() {
 				Expect(found).To(BeTrue())
 				Expect(val).To(Equal("caz"))
 			})
+
+			Context("when the build was triggered with vars", func() {
+				BeforeEach(func() {
+					job, found, err := build.Job()
+					Expect(err).ToNot(HaveOccurred())
+					Expect(found).To(BeTrue())
+
+					build, err = job.CreateBuildWithOverrides(defaultBuildCreatedBy, nil, []atc.BuildVar{
+						{Name: "foo", Value: "overridden"},
+						{Name: "some-secret", Value: "shh", Secret: true},
+					}, "")
+					Expect(err).ToNot(HaveOccurred())
+				})
+
+				It("records the vars with the build", func() {
+					found, err := build.Reload()
+					Expect(err).ToNot(HaveOccurred())
+					Expect(found).To(BeTrue())
+
+					Expect(build.Vars()).To(Equal([]atc.BuildVar{
+						{Name: "foo", Value: "overridden"},
+						{Name: "some-secret", Value: "shh", Secret: true},
+					}))
+				})
+
+				It("fetches the vars ahead of the global secrets", func() {
+					v, err := build.Variables(logger, globalSecrets, varSourcePool)
+					Expect(err).ToNot(HaveOccurred())
+
+					val, found, err := v.Get(vars.Reference{Path: "foo"})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(found).To(BeTrue())
+					Expect(val).To(Equal("overridden"))
+
+					val, found, err = v.Get(vars.Reference{Path: "some-secret"})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(found).To(BeTrue())
+					Expect(val).To(Equal("shh"))
+				})
+
+				It("still fetches from the var sources", func() {
+					v, err := build.Variables(logger, globalSecrets, varSourcePool)
+					Expect(err).ToNot(HaveOccurred())
+
+					val, found, err := v.Get(vars.Reference{Source: "some-source", Path: "baz"})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(found).To(BeTrue())
+					Expect(val).To(Equal("caz"))
+				})
+
+				It("is rerun with the same vars", func() {
+					job, found, err := build.Job()
+					Expect(err).ToNot(HaveOccurred())
+					Expect(found).To(BeTrue())
+
+					rerunBuild, err := job.RerunBuild(build, defaultBuildCreatedBy)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(rerunBuild.Vars()).To(Equal(build.Vars()))
+
+					found, err = rerunBuild.Reload()
+					Expect(err).ToNot(HaveOccurred())
+					Expect(found).To(BeTrue())
+					Expect(rerunBuild.Vars()).To(Equal(build.Vars()))
+				})
+			})
 		})
 	})
 
@@ -1016,6 +1176,46 @@ var _ = Describe("Build", func() {
 		})
 	})
 
+	Describe("CollapseInto", func() {
+		var (
+			duplicate db.Build
+			collapsed bool
+			err       error
+		)
+
+		BeforeEach(func() {
+			duplicate, err = job.CreateBuild(defaultBuildCreatedBy)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		JustBeforeEach(func() {
+			collapsed, err = duplicate.CollapseInto(build.ID())
+			Expect(err).NotTo(HaveOccurred())
+
+			found, err := duplicate.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+		})
+
+		It("aborts the build, recording which build it was collapsed into", func() {
+			Expect(collapsed).To(BeTrue())
+			Expect(duplicate.Status()).To(Equal(db.BuildStatusAborted))
+			Expect(duplicate.CollapsedInto()).To(Equal(build.ID()))
+		})
+
+		Context("when the build is no longer pending", func() {
+			BeforeEach(func() {
+				Expect(duplicate.Finish(db.BuildStatusSucceeded)).To(Succeed())
+			})
+
+			It("leaves the build as it is", func() {
+				Expect(collapsed).To(BeFalse())
+				Expect(duplicate.Status()).To(Equal(db.BuildStatusSucceeded))
+				Expect(duplicate.CollapsedInto()).To(BeZero())
+			})
+		})
+	})
+
 	Describe("Events", func() {
 		It("saves and emits status events", func() {
 			By("allowing you to subscribe when no events have yet occurred")
@@ -1155,6 +1355,160 @@ var _ = Describe("Build", func() {
 		})
 	})
 
+	Describe("SaveEvents", func() {
+		It("saves all of the events in order", func() {
+			events, err := build.Events(0)
+			Expect(err).NotTo(HaveOccurred())
+
+			defer db.Close(events)
+
+			err = build.SaveEvents([]atc.Event{
+				event.Log{Payload: "some "},
+				event.Log{Payload: "log"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(events.Next()).To(Equal(envelope(event.Log{
+				Payload: "some ",
+			}, "0")))
+
+			Expect(events.Next()).To(Equal(envelope(event.Log{
+				Payload: "log",
+			}, "1")))
+		})
+
+		Context("when there are a lot of events", func() {
+			It("saves all of them in order", func() {
+				var logs []atc.Event
+				for i := 0; i < 150; i++ {
+					logs = append(logs, event.Log{Payload: fmt.Sprintf("log %d", i)})
+				}
+
+				err := build.SaveEvents(logs)
+				Expect(err).NotTo(HaveOccurred())
+
+				events, err := build.Events(0)
+				Expect(err).NotTo(HaveOccurred())
+
+				defer db.Close(events)
+
+				for i := 0; i < 150; i++ {
+					Expect(events.Next()).To(Equal(envelope(event.Log{
+						Payload: fmt.Sprintf("log %d", i),
+					}, strconv.Itoa(i))))
+				}
+			})
+		})
+	})
+
+	Describe("AbortStep", func() {
+		It("records the steps to abort and notifies the ATC running the build", func() {
+			notifier, err := build.StepAbortNotifier()
+			Expect(err).NotTo(HaveOccurred())
+			defer notifier.Close()
+
+			// the notifier goes off once right away so nothing gets missed
+			Eventually(notifier.Notify()).Should(Receive())
+
+			Expect(build.AbortStep("some-step")).To(Succeed())
+			Expect(build.AbortStep("some-step")).To(Succeed())
+			Expect(build.AbortStep("some-other-step")).To(Succeed())
+
+			Eventually(notifier.Notify()).Should(Receive())
+
+			Expect(build.AbortedSteps()).To(Equal([]atc.PlanID{"some-other-step", "some-step"}))
+		})
+	})
+
+	Describe("watching the events of a build", func() {
+		It("streams the events to every watcher from where they started", func() {
+			err := build.SaveEvent(event.Log{Payload: "first"})
+			Expect(err).NotTo(HaveOccurred())
+
+			fromStart, err := build.Events(0)
+			Expect(err).NotTo(HaveOccurred())
+			defer db.Close(fromStart)
+
+			fromNext, err := build.Events(1)
+			Expect(err).NotTo(HaveOccurred())
+			defer db.Close(fromNext)
+
+			err = build.SaveEvent(event.Log{Payload: "second"})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fromStart.Next()).To(Equal(envelope(event.Log{Payload: "first"}, "0")))
+			Expect(fromStart.Next()).To(Equal(envelope(event.Log{Payload: "second"}, "1")))
+			Expect(fromNext.Next()).To(Equal(envelope(event.Log{Payload: "second"}, "1")))
+
+			By("catching up watchers who start later")
+			late, err := build.Events(0)
+			Expect(err).NotTo(HaveOccurred())
+			defer db.Close(late)
+
+			Expect(late.Next()).To(Equal(envelope(event.Log{Payload: "first"}, "0")))
+			Expect(late.Next()).To(Equal(envelope(event.Log{Payload: "second"}, "1")))
+		})
+
+		It("keeps streaming to a watcher who falls far behind the others", func() {
+			slow, err := build.Events(0)
+			Expect(err).NotTo(HaveOccurred())
+			defer db.Close(slow)
+
+			fast, err := build.Events(0)
+			Expect(err).NotTo(HaveOccurred())
+			defer db.Close(fast)
+
+			for i := 0; i < 3; i++ {
+				var logs []atc.Event
+				for j := 0; j < 1000; j++ {
+					logs = append(logs, event.Log{Payload: fmt.Sprintf("log %d", i*1000+j)})
+				}
+
+				err = build.SaveEvents(logs)
+				Expect(err).NotTo(HaveOccurred())
+
+				for j := 0; j < 1000; j++ {
+					Expect(fast.Next()).To(Equal(envelope(event.Log{
+						Payload: fmt.Sprintf("log %d", i*1000+j),
+					}, strconv.Itoa(i*1000+j))))
+				}
+			}
+
+			for i := 0; i < 3000; i++ {
+				Expect(slow.Next()).To(Equal(envelope(event.Log{
+					Payload: fmt.Sprintf("log %d", i),
+				}, strconv.Itoa(i))))
+			}
+		})
+
+		It("ends every stream once the build has finished", func() {
+			first, err := build.Events(0)
+			Expect(err).NotTo(HaveOccurred())
+			defer db.Close(first)
+
+			second, err := build.Events(0)
+			Expect(err).NotTo(HaveOccurred())
+			defer db.Close(second)
+
+			err = build.Finish(db.BuildStatusSucceeded)
+			Expect(err).NotTo(HaveOccurred())
+
+			found, err := build.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			for _, events := range []db.EventSource{first, second} {
+				Expect(events.Next()).To(Equal(envelope(event.Status{
+					Status: atc.StatusSucceeded,
+					Time:   build.EndTime().Unix(),
+				}, "0")))
+
+				_, err = events.Next()
+				Expect(err).To(Equal(db.ErrEndOfBuildEventStream))
+			}
+		})
+	})
+
 	Describe("SaveOutput", func() {
 		var pipelineConfig atc.Config
 
@@ -1656,6 +2010,7 @@ var _ = Describe("Build", func() {
 				PausedPipeline:      db.BuildPreparationStatusNotBlocking,
 				PausedJob:           db.BuildPreparationStatusNotBlocking,
 				MaxRunningBuilds:    db.BuildPreparationStatusNotBlocking,
+				WorkerCapacity:      db.BuildPreparationStatusNotBlocking,
 				Inputs:              map[string]db.BuildPreparationStatus{},
 				InputsSatisfied:     db.BuildPreparationStatusNotBlocking,
 				MissingInputReasons: db.MissingInputReasons{},
@@ -1788,6 +2143,38 @@ var _ = Describe("Build", func() {
 						})
 					})
 				})
+
+				Context("when no worker has capacity for the job", func() {
+					BeforeEach(func() {
+						err := job.SetWorkerCapacityReached(true)
+						Expect(err).ToNot(HaveOccurred())
+
+						expectedBuildPrep.WorkerCapacity = db.BuildPreparationStatusBlocking
+					})
+
+					It("returns build preparation with worker capacity reached", func() {
+						buildPrep, found, err := build.Preparation()
+						Expect(err).NotTo(HaveOccurred())
+						Expect(found).To(BeTrue())
+						Expect(buildPrep).To(Equal(expectedBuildPrep))
+					})
+
+					Context("when workers have capacity again", func() {
+						BeforeEach(func() {
+							err := job.SetWorkerCapacityReached(false)
+							Expect(err).ToNot(HaveOccurred())
+
+							expectedBuildPrep.WorkerCapacity = db.BuildPreparationStatusNotBlocking
+						})
+
+						It("returns build preparation with worker capacity not reached", func() {
+							buildPrep, found, err := build.Preparation()
+							Expect(err).NotTo(HaveOccurred())
+							Expect(found).To(BeTrue())
+							Expect(buildPrep).To(Equal(expectedBuildPrep))
+						})
+					})
+				})
 			})
 
 			Context("when no resource check finished after build created", func() {
@@ -2139,6 +2526,171 @@ var _ = Describe("Build", func() {
 				})
 			})
 		})
+
+		Describe("adopting the pinned inputs of a manually triggered build", func() {
+			var pins []atc.BuildInputPin
+			var pinnedBuild db.Build
+			var pinErr error
+
+			BeforeEach(func() {
+				var upstreamBuild db.Build
+				scenario.Run(
+					builder.WithPendingJobBuild(&upstreamBuild, "upstream-job"),
+					builder.WithNextInputMapping("upstream-job", dbtest.JobInputs{
+						{
+							Name:    "some-input",
+							Version: atc.Version{"version": "v2"},
+						},
+					}),
+				)
+
+				_, adopted, err := upstreamBuild.AdoptInputsAndPipes()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(adopted).To(BeTrue())
+				Expect(upstreamBuild.Finish(db.BuildStatusSucceeded)).To(Succeed())
+			})
+
+			JustBeforeEach(func() {
+				pinnedBuild, pinErr = scenario.Job("downstream-job").CreateBuildWithOverrides(defaultBuildCreatedBy, pins, nil, "")
+			})
+
+			Context("when the pinned version passed the upstream job", func() {
+				BeforeEach(func() {
+					pins = []atc.BuildInputPin{
+						{Name: "some-input", Version: atc.Version{"version": "v2"}},
+					}
+				})
+
+				It("records the pin on the build", func() {
+					Expect(pinErr).ToNot(HaveOccurred())
+
+					version := scenario.ResourceVersion("some-resource", atc.Version{"version": "v2"})
+
+					recorded, err := pinnedBuild.PinnedInputs()
+					Expect(err).ToNot(HaveOccurred())
+					Expect(recorded).To(Equal([]atc.BuildInputPin{
+						{Name: "some-input", Version: atc.Version{"version": "v2"}, VersionID: version.ID()},
+					}))
+				})
+
+				It("adopts the pinned version rather than the next input", func() {
+					scenario.Run(
+						builder.WithNextInputMapping("downstream-job", dbtest.JobInputs{
+							{
+								Name:    "some-input",
+								Version: atc.Version{"version": "v3"},
+							},
+							{
+								Name:            "some-other-input",
+								Version:         atc.Version{"version": "v1"},
+								FirstOccurrence: true,
+							},
+						}),
+					)
+
+					inputs, adopted, err := pinnedBuild.AdoptInputsAndPipes()
+					Expect(err).ToNot(HaveOccurred())
+					Expect(adopted).To(BeTrue())
+					Expect(inputs).To(ConsistOf([]db.BuildInput{
+						{
+							Name:            "some-input",
+							ResourceID:      scenario.Resource("some-resource").ID(),
+							Version:         atc.Version{"version": "v2"},
+							FirstOccurrence: true,
+						},
+						{
+							Name:            "some-other-input",
+							ResourceID:      scenario.Resource("some-other-resource").ID(),
+							Version:         atc.Version{"version": "v1"},
+							FirstOccurrence: true,
+						},
+					}))
+				})
+			})
+
+			Context("when the input is pinned by version id", func() {
+				BeforeEach(func() {
+					version := scenario.ResourceVersion("some-resource", atc.Version{"version": "v2"})
+
+					pins = []atc.BuildInputPin{
+						{Name: "some-input", VersionID: version.ID()},
+					}
+				})
+
+				It("creates the build", func() {
+					Expect(pinErr).ToNot(HaveOccurred())
+
+					recorded, err := pinnedBuild.PinnedInputs()
+					Expect(err).ToNot(HaveOccurred())
+					Expect(recorded).To(HaveLen(1))
+					Expect(recorded[0].Version).To(Equal(atc.Version{"version": "v2"}))
+				})
+			})
+
+			Context("when the pinned version has not passed the upstream job", func() {
+				BeforeEach(func() {
+					pins = []atc.BuildInputPin{
+						{Name: "some-input", Version: atc.Version{"version": "v3"}},
+					}
+				})
+
+				It("does not create the build", func() {
+					Expect(pinErr).To(Equal(db.BuildInputPinError{
+						InputName: "some-input",
+						Reason:    "version has not passed upstream-job",
+					}))
+
+					builds, _, err := scenario.Job("downstream-job").Builds(db.Page{Limit: 10})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(builds).To(BeEmpty())
+				})
+
+				Context("when the passed constraints are overridden", func() {
+					BeforeEach(func() {
+						pins[0].OverridePassed = true
+					})
+
+					It("creates the build", func() {
+						Expect(pinErr).ToNot(HaveOccurred())
+
+						recorded, err := pinnedBuild.PinnedInputs()
+						Expect(err).ToNot(HaveOccurred())
+						Expect(recorded).To(HaveLen(1))
+						Expect(recorded[0].OverridePassed).To(BeTrue())
+					})
+				})
+			})
+
+			Context("when the input is not an input of the job", func() {
+				BeforeEach(func() {
+					pins = []atc.BuildInputPin{
+						{Name: "bogus-input", Version: atc.Version{"version": "v1"}},
+					}
+				})
+
+				It("does not create the build", func() {
+					Expect(pinErr).To(Equal(db.BuildInputPinError{
+						InputName: "bogus-input",
+						Reason:    "not an input of the job",
+					}))
+				})
+			})
+
+			Context("when the version does not exist", func() {
+				BeforeEach(func() {
+					pins = []atc.BuildInputPin{
+						{Name: "some-other-input", Version: atc.Version{"version": "bogus"}},
+					}
+				})
+
+				It("does not create the build", func() {
+					Expect(pinErr).To(Equal(db.BuildInputPinError{
+						InputName: "some-other-input",
+						Reason:    "version not found",
+					}))
+				})
+			})
+		})
 	})
 
 	Describe("AdoptRerunInputsAndPipes", func() {
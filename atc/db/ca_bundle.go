@@ -0,0 +1,129 @@
+package db
+
+import (
+	"database/sql"
+	"strings"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
+)
+
+// CABundleRepository manages the CA certificates which operators register
+// for a team or for the cluster, and which are made available to every
+// container.
+//
+// A teamID of 0 refers to the bundle of the cluster, which is trusted by the
+// containers of every team.
+//
+//counterfeiter:generate . CABundleRepository
+type CABundleRepository interface {
+	CABundle(teamID int) (atc.CABundle, error)
+	SetCABundle(teamID int, bundle atc.CABundle) error
+
+	// ContainerCABundle returns the certificates to provide to the team's
+	// containers, which are those of the cluster followed by those of the
+	// team. It is empty when neither registered a bundle.
+	ContainerCABundle(teamID int) (string, error)
+}
+
+type caBundleRepository struct {
+	conn Conn
+}
+
+func NewCABundleRepository(conn Conn) CABundleRepository {
+	return &caBundleRepository{
+		conn: conn,
+	}
+}
+
+func caBundleTeamEq(teamID int) sq.Eq {
+	if teamID == 0 {
+		return sq.Eq{"team_id": nil}
+	}
+
+	return sq.Eq{"team_id": teamID}
+}
+
+func (repo *caBundleRepository) CABundle(teamID int) (atc.CABundle, error) {
+	var bundle atc.CABundle
+	err := psql.Select("certificates").
+		From("ca_bundles").
+		Where(caBundleTeamEq(teamID)).
+		RunWith(repo.conn).
+		QueryRow().
+		Scan(&bundle.Certificates)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return atc.CABundle{}, nil
+		}
+
+		return atc.CABundle{}, err
+	}
+
+	return bundle, nil
+}
+
+func (repo *caBundleRepository) SetCABundle(teamID int, bundle atc.CABundle) error {
+	var team sql.NullInt64
+	if teamID != 0 {
+		team = sql.NullInt64{Int64: int64(teamID), Valid: true}
+	}
+
+	tx, err := repo.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	_, err = psql.Delete("ca_bundles").
+		Where(caBundleTeamEq(teamID)).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	if strings.TrimSpace(bundle.Certificates) != "" {
+		_, err = psql.Insert("ca_bundles").
+			Columns("team_id", "certificates").
+			Values(team, bundle.Certificates).
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (repo *caBundleRepository) ContainerCABundle(teamID int) (string, error) {
+	rows, err := psql.Select("certificates").
+		From("ca_bundles").
+		Where(sq.Or{
+			sq.Eq{"team_id": nil},
+			sq.Eq{"team_id": teamID},
+		}).
+		OrderBy("team_id NULLS FIRST").
+		RunWith(repo.conn).
+		Query()
+	if err != nil {
+		return "", err
+	}
+
+	defer Close(rows)
+
+	var bundles []string
+	for rows.Next() {
+		var certificates string
+		err := rows.Scan(&certificates)
+		if err != nil {
+			return "", err
+		}
+
+		bundles = append(bundles, strings.TrimSpace(certificates)+"\n")
+	}
+
+	return strings.Join(bundles, ""), rows.Err()
+}
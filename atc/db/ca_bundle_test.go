@@ -0,0 +1,79 @@
+package db_test
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CABundleRepository", func() {
+	var repository db.CABundleRepository
+
+	BeforeEach(func() {
+		repository = db.NewCABundleRepository(dbConn)
+	})
+
+	It("has no bundle by default", func() {
+		bundle, err := repository.CABundle(0)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(bundle).To(Equal(atc.CABundle{}))
+
+		certificates, err := repository.ContainerCABundle(defaultTeam.ID())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(certificates).To(BeEmpty())
+	})
+
+	Context("when the cluster and the team have bundles", func() {
+		BeforeEach(func() {
+			err := repository.SetCABundle(0, atc.CABundle{Certificates: "cluster-cert"})
+			Expect(err).ToNot(HaveOccurred())
+
+			err = repository.SetCABundle(defaultTeam.ID(), atc.CABundle{Certificates: "team-cert\n"})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("keeps them separately", func() {
+			bundle, err := repository.CABundle(0)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(bundle).To(Equal(atc.CABundle{Certificates: "cluster-cert"}))
+
+			bundle, err = repository.CABundle(defaultTeam.ID())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(bundle).To(Equal(atc.CABundle{Certificates: "team-cert\n"}))
+		})
+
+		It("provides the cluster's certificates followed by the team's", func() {
+			certificates, err := repository.ContainerCABundle(defaultTeam.ID())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(certificates).To(Equal("cluster-cert\nteam-cert\n"))
+		})
+
+		It("only provides the cluster's certificates to other teams", func() {
+			otherTeam, err := teamFactory.CreateTeam(atc.Team{Name: "some-other-team"})
+			Expect(err).ToNot(HaveOccurred())
+
+			certificates, err := repository.ContainerCABundle(otherTeam.ID())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(certificates).To(Equal("cluster-cert\n"))
+		})
+
+		It("replaces the bundle when it is set again", func() {
+			err := repository.SetCABundle(0, atc.CABundle{Certificates: "other-cluster-cert"})
+			Expect(err).ToNot(HaveOccurred())
+
+			bundle, err := repository.CABundle(0)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(bundle).To(Equal(atc.CABundle{Certificates: "other-cluster-cert"}))
+		})
+
+		It("removes the bundle when it is set to be empty", func() {
+			err := repository.SetCABundle(defaultTeam.ID(), atc.CABundle{})
+			Expect(err).ToNot(HaveOccurred())
+
+			certificates, err := repository.ContainerCABundle(defaultTeam.ID())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(certificates).To(Equal("cluster-cert\n"))
+		})
+	})
+})
@@ -39,6 +39,7 @@ type Checkable interface {
 //counterfeiter:generate . CheckFactory
 type CheckFactory interface {
 	TryCreateCheck(context.Context, Checkable, ResourceTypes, atc.Version, bool, bool, bool) (Build, bool, error)
+	CreateMetadataFetch(context.Context, Resource, ResourceTypes, []atc.Version) (Build, error)
 	Resources() ([]Resource, error)
 	ResourceTypesByPipeline() (map[int]ResourceTypes, error)
 }
@@ -81,17 +82,13 @@ func NewCheckFactory(
 func (c *checkFactory) TryCreateCheck(ctx context.Context, checkable Checkable, resourceTypes ResourceTypes, from atc.Version, manuallyTriggered bool, skipIntervalRecursively bool, toDB bool) (Build, bool, error) {
 	logger := lagerctx.FromContext(ctx)
 
-	sourceDefaults := atc.Source{}
-	parentType, found := resourceTypes.Parent(checkable)
-	if found {
-		sourceDefaults = parentType.Defaults()
-	} else {
-		defaults, found := atc.FindBaseResourceTypeDefaults(checkable.Type())
-		if found {
-			sourceDefaults = defaults
-		}
+	if resource, ok := checkable.(Resource); ok && resource.Config().Push != nil {
+		// resources in push mode get their versions through the API instead
+		return nil, false, nil
 	}
 
+	sourceDefaults := sourceDefaultsFor(checkable, resourceTypes)
+
 	interval := atc.CheckEvery{
 		Interval: atc.DefaultCheckInterval,
 	}
@@ -139,6 +136,38 @@ func (c *checkFactory) TryCreateCheck(ctx context.Context, checkable Checkable,
 	}
 }
 
+// CreateMetadataFetch creates a build which fetches the given versions of the
+// resource to update their metadata.
+func (c *checkFactory) CreateMetadataFetch(ctx context.Context, resource Resource, resourceTypes ResourceTypes, versions []atc.Version) (Build, error) {
+	logger := lagerctx.FromContext(ctx)
+
+	deserializedResourceTypes := resourceTypes.Filter(resource).Deserialize()
+	plan := resource.MetadataPlan(c.planFactory, deserializedResourceTypes, versions, sourceDefaultsFor(resource, resourceTypes))
+
+	build, _, err := resource.CreateBuild(ctx, true, plan)
+	if err != nil {
+		return nil, fmt.Errorf("create metadata fetch build: %w", err)
+	}
+
+	logger.Debug("created-metadata-fetch-build", build.LagerData())
+
+	return build, nil
+}
+
+func sourceDefaultsFor(checkable Checkable, resourceTypes ResourceTypes) atc.Source {
+	parentType, found := resourceTypes.Parent(checkable)
+	if found {
+		return parentType.Defaults()
+	}
+
+	defaults, found := atc.FindBaseResourceTypeDefaults(checkable.Type())
+	if found {
+		return defaults
+	}
+
+	return atc.Source{}
+}
+
 func (c *checkFactory) Resources() ([]Resource, error) {
 	var resources []Resource
 
@@ -89,6 +89,20 @@ var _ = Describe("CheckFactory", func() {
 				build, created, err = checkFactory.TryCreateCheck(context.TODO(), fakeResource, fakeResourceTypes, fromVersion, manuallyTriggered, false, toDb)
 			})
 
+			Context("when the resource is in push mode", func() {
+				BeforeEach(func() {
+					fakeResource.ConfigReturns(atc.ResourceConfig{
+						Push: &atc.PushConfig{Version: []string{"ref"}},
+					})
+				})
+
+				It("does not create a check", func() {
+					Expect(err).NotTo(HaveOccurred())
+					Expect(created).To(BeFalse())
+					Expect(fakeResource.CreateBuildCallCount()).To(Equal(0))
+				})
+			})
+
 			Context("when the resource parent type is not a custom type", func() {
 				BeforeEach(func() {
 					fakeResource.TypeReturns("base-type")
@@ -370,6 +384,71 @@ var _ = Describe("CheckFactory", func() {
 		})
 	})
 
+	Describe("CreateMetadataFetch", func() {
+		var (
+			fakeResource     *dbfakes.FakeResource
+			fakeResourceType *dbfakes.FakeResourceType
+			fakeBuild        *dbfakes.FakeBuild
+			metadataPlan     atc.Plan
+			versions         []atc.Version
+		)
+
+		BeforeEach(func() {
+			metadataPlan = atc.NewPlanFactory(0).NewPlan(atc.GetPlan{
+				Type:   "doesnt-matter",
+				Source: atc.Source{"doesnt": "matter"},
+			})
+
+			fakeResource = new(dbfakes.FakeResource)
+			fakeResource.NameReturns("some-name")
+			fakeResource.TypeReturns("some-type")
+			fakeResource.PipelineIDReturns(defaultPipeline.ID())
+			fakeResource.MetadataPlanReturns(metadataPlan)
+
+			fakeBuild = new(dbfakes.FakeBuild)
+			fakeBuild.LagerDataReturns(lager.Data{})
+			fakeResource.CreateBuildReturns(fakeBuild, true, nil)
+
+			fakeResourceType = new(dbfakes.FakeResourceType)
+			fakeResourceType.NameReturns("some-type")
+			fakeResourceType.TypeReturns("some-base-type")
+			fakeResourceType.DefaultsReturns(atc.Source{"some-default": "some-default-value"})
+			fakeResourceType.PipelineIDReturns(defaultPipeline.ID())
+
+			versions = []atc.Version{{"v": "1"}}
+		})
+
+		JustBeforeEach(func() {
+			build, err = checkFactory.CreateMetadataFetch(context.TODO(), fakeResource, db.ResourceTypes{fakeResourceType}, versions)
+		})
+
+		It("plans the fetch with the parent type's defaults", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(fakeResource.MetadataPlanCallCount()).To(Equal(1))
+			_, _, actualVersions, sourceDefaults := fakeResource.MetadataPlanArgsForCall(0)
+			Expect(actualVersions).To(Equal(versions))
+			Expect(sourceDefaults).To(Equal(atc.Source{"some-default": "some-default-value"}))
+		})
+
+		It("creates a manually triggered build for the resource", func() {
+			Expect(build).To(Equal(fakeBuild))
+			Expect(fakeResource.CreateBuildCallCount()).To(Equal(1))
+			_, manuallyTriggered, plan := fakeResource.CreateBuildArgsForCall(0)
+			Expect(manuallyTriggered).To(BeTrue())
+			Expect(plan).To(Equal(metadataPlan))
+		})
+
+		Context("when creating the build fails", func() {
+			BeforeEach(func() {
+				fakeResource.CreateBuildReturns(nil, false, fmt.Errorf("nope"))
+			})
+
+			It("errors", func() {
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
 	Describe("Resources", func() {
 		var (
 			resources                  []db.Resource
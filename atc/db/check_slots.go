@@ -0,0 +1,160 @@
+package db
+
+import (
+	"database/sql"
+	"time"
+)
+
+// CheckLimits caps how many checks run at once across the cluster and on
+// each worker. A limit of 0 means there isn't one.
+type CheckLimits struct {
+	MaxInFlight          int
+	MaxInFlightPerWorker int
+}
+
+// CheckSlots hands out the slots the checks of all the ATCs take up while
+// they run, so that the checks due at the same time are spread over the
+// workers instead of piling onto them.
+//
+//counterfeiter:generate . CheckSlots
+type CheckSlots interface {
+	// Acquire takes up a slot on the worker, unless doing so would go over
+	// one of the limits. The slot is freed after the expiry in case it is
+	// never released.
+	Acquire(workerName string, expiry time.Duration) (CheckSlot, bool, error)
+
+	// InFlightPerWorker returns the number of checks running on each worker.
+	InFlightPerWorker() (map[string]int, error)
+
+	SetLimits(CheckLimits) error
+}
+
+//counterfeiter:generate . CheckSlot
+type CheckSlot interface {
+	Release() error
+}
+
+type checkSlots struct {
+	conn Conn
+}
+
+func NewCheckSlots(conn Conn) CheckSlots {
+	return &checkSlots{
+		conn: conn,
+	}
+}
+
+func (s *checkSlots) Acquire(workerName string, expiry time.Duration) (CheckSlot, bool, error) {
+	tx, err := s.conn.Begin()
+	if err != nil {
+		return nil, false, err
+	}
+
+	defer Rollback(tx)
+
+	// locking the limits lines up the acquisitions, so that the counts below
+	// can't be raced past the limits
+	var limits CheckLimits
+	err = tx.QueryRow(`
+		SELECT max_in_flight, max_in_flight_per_worker
+		FROM check_limits
+		FOR UPDATE
+	`).Scan(&limits.MaxInFlight, &limits.MaxInFlightPerWorker)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, false, err
+	}
+
+	_, err = tx.Exec(`DELETE FROM checks_in_flight WHERE expires_at < now()`)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var inFlight, inFlightOnWorker int
+	err = tx.QueryRow(`
+		SELECT COUNT(*), COUNT(*) FILTER (WHERE worker_name = $1)
+		FROM checks_in_flight
+	`, workerName).Scan(&inFlight, &inFlightOnWorker)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if limits.MaxInFlight > 0 && inFlight >= limits.MaxInFlight {
+		return nil, false, nil
+	}
+
+	if limits.MaxInFlightPerWorker > 0 && inFlightOnWorker >= limits.MaxInFlightPerWorker {
+		return nil, false, nil
+	}
+
+	var id int
+	err = tx.QueryRow(`
+		INSERT INTO checks_in_flight (worker_name, expires_at)
+		VALUES ($1, now() + $2 * '1 second'::interval)
+		RETURNING id
+	`, workerName, int(expiry.Seconds())).Scan(&id)
+	if err != nil {
+		return nil, false, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, false, err
+	}
+
+	return &checkSlot{
+		id:   id,
+		conn: s.conn,
+	}, true, nil
+}
+
+func (s *checkSlots) InFlightPerWorker() (map[string]int, error) {
+	rows, err := s.conn.Query(`
+		SELECT worker_name, COUNT(*)
+		FROM checks_in_flight
+		WHERE expires_at >= now()
+		GROUP BY worker_name
+	`)
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	counts := map[string]int{}
+	for rows.Next() {
+		var (
+			workerName string
+			count      int
+		)
+
+		err = rows.Scan(&workerName, &count)
+		if err != nil {
+			return nil, err
+		}
+
+		counts[workerName] = count
+	}
+
+	return counts, nil
+}
+
+func (s *checkSlots) SetLimits(limits CheckLimits) error {
+	_, err := s.conn.Exec(`
+		INSERT INTO check_limits (max_in_flight, max_in_flight_per_worker)
+		VALUES ($1, $2)
+		ON CONFLICT (id) DO UPDATE SET
+			max_in_flight = EXCLUDED.max_in_flight,
+			max_in_flight_per_worker = EXCLUDED.max_in_flight_per_worker
+	`, limits.MaxInFlight, limits.MaxInFlightPerWorker)
+	return err
+}
+
+type checkSlot struct {
+	id   int
+	conn Conn
+}
+
+func (slot *checkSlot) Release() error {
+	_, err := slot.conn.Exec(`DELETE FROM checks_in_flight WHERE id = $1`, slot.id)
+	return err
+}
@@ -0,0 +1,98 @@
+package db_test
+
+import (
+	"time"
+
+	"github.com/concourse/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CheckSlots", func() {
+	var slots db.CheckSlots
+
+	BeforeEach(func() {
+		slots = db.NewCheckSlots(dbConn)
+	})
+
+	acquire := func(workerName string) (db.CheckSlot, bool) {
+		slot, acquired, err := slots.Acquire(workerName, time.Hour)
+		Expect(err).ToNot(HaveOccurred())
+		return slot, acquired
+	}
+
+	Context("without limits", func() {
+		It("lets every check through", func() {
+			for i := 0; i < 10; i++ {
+				_, acquired := acquire(defaultWorker.Name())
+				Expect(acquired).To(BeTrue())
+			}
+
+			Expect(slots.InFlightPerWorker()).To(Equal(map[string]int{
+				defaultWorker.Name(): 10,
+			}))
+		})
+	})
+
+	Context("with a limit per worker", func() {
+		BeforeEach(func() {
+			Expect(slots.SetLimits(db.CheckLimits{MaxInFlightPerWorker: 2})).To(Succeed())
+		})
+
+		It("holds back the checks of a full worker only", func() {
+			first, acquired := acquire(defaultWorker.Name())
+			Expect(acquired).To(BeTrue())
+
+			_, acquired = acquire(defaultWorker.Name())
+			Expect(acquired).To(BeTrue())
+
+			_, acquired = acquire(defaultWorker.Name())
+			Expect(acquired).To(BeFalse())
+
+			_, acquired = acquire(otherWorker.Name())
+			Expect(acquired).To(BeTrue())
+
+			Expect(first.Release()).To(Succeed())
+
+			_, acquired = acquire(defaultWorker.Name())
+			Expect(acquired).To(BeTrue())
+		})
+	})
+
+	Context("with a global limit", func() {
+		BeforeEach(func() {
+			Expect(slots.SetLimits(db.CheckLimits{MaxInFlight: 2})).To(Succeed())
+		})
+
+		It("holds back the checks of every worker", func() {
+			_, acquired := acquire(defaultWorker.Name())
+			Expect(acquired).To(BeTrue())
+
+			_, acquired = acquire(otherWorker.Name())
+			Expect(acquired).To(BeTrue())
+
+			_, acquired = acquire(otherWorker.Name())
+			Expect(acquired).To(BeFalse())
+
+			_, acquired = acquire(defaultWorker.Name())
+			Expect(acquired).To(BeFalse())
+		})
+	})
+
+	Context("when a slot expires without being released", func() {
+		BeforeEach(func() {
+			Expect(slots.SetLimits(db.CheckLimits{MaxInFlightPerWorker: 1})).To(Succeed())
+
+			_, acquired, err := slots.Acquire(defaultWorker.Name(), -time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(acquired).To(BeTrue())
+		})
+
+		It("frees it", func() {
+			Expect(slots.InFlightPerWorker()).To(BeEmpty())
+
+			_, acquired := acquire(defaultWorker.Name())
+			Expect(acquired).To(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,237 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	"github.com/concourse/concourse/atc"
+)
+
+// ClusterOverviewRepository works out what the workers, teams and pipelines
+// of the cluster are using.
+//
+//counterfeiter:generate . ClusterOverviewRepository
+type ClusterOverviewRepository interface {
+	// Overview returns the usage of every worker and team, and of the given
+	// number of pipelines which used the most build time over the last day.
+	Overview(topPipelines int) (atc.ClusterOverview, error)
+}
+
+type clusterOverviewRepository struct {
+	conn Conn
+}
+
+func NewClusterOverviewRepository(conn Conn) ClusterOverviewRepository {
+	return &clusterOverviewRepository{
+		conn: conn,
+	}
+}
+
+// volumeTypeExpr works out the type of a volume from what it belongs to, the
+// same way as the volume repository does.
+const volumeTypeExpr = `
+	CASE
+		WHEN v.worker_base_resource_type_id IS NOT NULL THEN 'resource-type'
+		WHEN v.worker_resource_cache_id IS NOT NULL THEN 'resource'
+		WHEN v.container_id IS NOT NULL THEN 'container'
+		WHEN v.worker_task_cache_id IS NOT NULL THEN 'task-cache'
+		WHEN v.worker_resource_certs_id IS NOT NULL THEN 'resource-certs'
+		WHEN v.worker_artifact_id IS NOT NULL THEN 'artifact'
+		ELSE 'unknown'
+	END
+`
+
+func (repo *clusterOverviewRepository) Overview(topPipelines int) (atc.ClusterOverview, error) {
+	workers, err := repo.workers()
+	if err != nil {
+		return atc.ClusterOverview{}, err
+	}
+
+	teams, err := repo.teams()
+	if err != nil {
+		return atc.ClusterOverview{}, err
+	}
+
+	pipelines, err := repo.topPipelines(topPipelines)
+	if err != nil {
+		return atc.ClusterOverview{}, err
+	}
+
+	return atc.ClusterOverview{
+		Workers:      workers,
+		Teams:        teams,
+		TopPipelines: pipelines,
+	}, nil
+}
+
+func (repo *clusterOverviewRepository) workers() ([]atc.WorkerUsage, error) {
+	rows, err := repo.conn.Query(`
+		SELECT w.name, COALESCE(w.platform, ''), w.state, COALESCE(t.name, ''),
+			(SELECT COUNT(*) FROM containers c WHERE c.worker_name = w.name),
+			(
+				SELECT COUNT(DISTINCT c.build_id)
+				FROM containers c
+				JOIN builds b ON b.id = c.build_id
+				WHERE c.worker_name = w.name
+				AND NOT b.completed
+			)
+		FROM workers w
+		LEFT JOIN teams t ON t.id = w.team_id
+		ORDER BY w.name
+	`)
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	workers := []atc.WorkerUsage{}
+	byName := map[string]int{}
+	for rows.Next() {
+		worker := atc.WorkerUsage{
+			VolumesByType: map[string]int{},
+		}
+
+		err := rows.Scan(&worker.Name, &worker.Platform, &worker.State, &worker.Team, &worker.Containers, &worker.RunningBuilds)
+		if err != nil {
+			return nil, err
+		}
+
+		byName[worker.Name] = len(workers)
+		workers = append(workers, worker)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	volumeRows, err := repo.conn.Query(`
+		SELECT v.worker_name, ` + volumeTypeExpr + `, v.parent_id IS NULL, COUNT(*)
+		FROM volumes v
+		GROUP BY 1, 2, 3
+	`)
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(volumeRows)
+
+	for volumeRows.Next() {
+		var (
+			workerName, typ string
+			ownData         bool
+			count           int
+		)
+
+		err := volumeRows.Scan(&workerName, &typ, &ownData, &count)
+		if err != nil {
+			return nil, err
+		}
+
+		i, found := byName[workerName]
+		if !found {
+			continue
+		}
+
+		workers[i].Volumes += count
+		workers[i].VolumesByType[typ] += count
+
+		if ownData {
+			workers[i].DiskVolumes += count
+		}
+	}
+
+	return workers, volumeRows.Err()
+}
+
+func (repo *clusterOverviewRepository) teams() ([]atc.TeamUsage, error) {
+	rows, err := repo.conn.Query(`
+		SELECT t.name,
+			(SELECT COUNT(*) FROM pipelines p WHERE p.team_id = t.id),
+			(SELECT COUNT(*) FROM containers c WHERE c.team_id = t.id),
+			(SELECT COUNT(*) FROM volumes v WHERE v.team_id = t.id),
+			COUNT(b.id) FILTER (WHERE b.status = 'started'),
+			COUNT(b.id) FILTER (WHERE b.status = 'pending')
+		FROM teams t
+		LEFT JOIN builds b ON b.team_id = t.id AND NOT b.completed
+		GROUP BY t.id
+		ORDER BY t.name
+	`)
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	teams := []atc.TeamUsage{}
+	for rows.Next() {
+		var team atc.TeamUsage
+
+		err := rows.Scan(&team.Name, &team.Pipelines, &team.Containers, &team.Volumes, &team.RunningBuilds, &team.PendingBuilds)
+		if err != nil {
+			return nil, err
+		}
+
+		teams = append(teams, team)
+	}
+
+	return teams, rows.Err()
+}
+
+func (repo *clusterOverviewRepository) topPipelines(limit int) ([]atc.PipelineUsage, error) {
+	rows, err := repo.conn.Query(`
+		WITH usage AS (
+			SELECT b.pipeline_id,
+				EXTRACT(EPOCH FROM SUM(COALESCE(b.end_time, NOW()) - b.start_time))::bigint AS build_seconds,
+				COUNT(*) FILTER (WHERE NOT b.completed) AS running
+			FROM builds b
+			WHERE b.pipeline_id IS NOT NULL
+			AND b.start_time IS NOT NULL
+			AND (b.end_time IS NULL OR b.end_time > NOW() - interval '1 day')
+			GROUP BY b.pipeline_id
+		)
+		SELECT p.id, p.name, p.instance_vars, t.name, u.build_seconds, u.running,
+			(SELECT COUNT(*) FROM containers c WHERE c.meta_pipeline_id = p.id),
+			(
+				SELECT COUNT(*)
+				FROM volumes v
+				JOIN containers c ON c.id = v.container_id
+				WHERE c.meta_pipeline_id = p.id
+			)
+		FROM usage u
+		JOIN pipelines p ON p.id = u.pipeline_id
+		JOIN teams t ON t.id = p.team_id
+		ORDER BY u.build_seconds DESC, p.id
+		LIMIT $1
+	`, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	pipelines := []atc.PipelineUsage{}
+	for rows.Next() {
+		var (
+			pipeline     atc.PipelineUsage
+			instanceVars sql.NullString
+		)
+
+		err := rows.Scan(&pipeline.ID, &pipeline.Name, &instanceVars, &pipeline.TeamName, &pipeline.BuildSeconds, &pipeline.RunningBuilds, &pipeline.Containers, &pipeline.Volumes)
+		if err != nil {
+			return nil, err
+		}
+
+		if instanceVars.Valid {
+			err = json.Unmarshal([]byte(instanceVars.String), &pipeline.InstanceVars)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		pipelines = append(pipelines, pipeline)
+	}
+
+	return pipelines, rows.Err()
+}
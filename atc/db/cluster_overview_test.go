@@ -0,0 +1,83 @@
+package db_test
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ClusterOverviewRepository", func() {
+	var repository db.ClusterOverviewRepository
+
+	BeforeEach(func() {
+		repository = db.NewClusterOverviewRepository(dbConn)
+	})
+
+	Describe("Overview", func() {
+		var overview atc.ClusterOverview
+
+		BeforeEach(func() {
+			runningBuild, err := defaultJob.CreateBuild(defaultBuildCreatedBy)
+			Expect(err).ToNot(HaveOccurred())
+
+			started, err := runningBuild.Start(atc.Plan{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(started).To(BeTrue())
+
+			_, err = defaultJob.CreateBuild(defaultBuildCreatedBy)
+			Expect(err).ToNot(HaveOccurred())
+
+			container, err := defaultWorker.CreateContainer(
+				db.NewBuildStepContainerOwner(runningBuild.ID(), "some-plan", defaultTeam.ID()),
+				db.ContainerMetadata{
+					PipelineID: defaultPipeline.ID(),
+					JobID:      defaultJob.ID(),
+					BuildID:    runningBuild.ID(),
+				},
+			)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = volumeRepository.CreateContainerVolume(defaultTeam.ID(), defaultWorker.Name(), container, "some-path")
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			overview, err = repository.Overview(10)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("describes what is on each worker", func() {
+			Expect(overview.Workers).To(HaveLen(1))
+			Expect(overview.Workers[0].Name).To(Equal(defaultWorker.Name()))
+			Expect(overview.Workers[0].Containers).To(Equal(1))
+			Expect(overview.Workers[0].RunningBuilds).To(Equal(1))
+			Expect(overview.Workers[0].Volumes).To(Equal(1))
+			Expect(overview.Workers[0].VolumesByType).To(Equal(map[string]int{"container": 1}))
+			Expect(overview.Workers[0].DiskVolumes).To(Equal(1))
+		})
+
+		It("describes what each team is using", func() {
+			Expect(overview.Teams).To(ContainElement(atc.TeamUsage{
+				Name:          defaultTeam.Name(),
+				Pipelines:     1,
+				Containers:    1,
+				Volumes:       1,
+				RunningBuilds: 1,
+				PendingBuilds: 1,
+			}))
+		})
+
+		It("describes the pipelines which have run builds", func() {
+			Expect(overview.TopPipelines).To(HaveLen(1))
+			Expect(overview.TopPipelines[0].ID).To(Equal(defaultPipeline.ID()))
+			Expect(overview.TopPipelines[0].Name).To(Equal(defaultPipeline.Name()))
+			Expect(overview.TopPipelines[0].InstanceVars).To(Equal(defaultPipeline.InstanceVars()))
+			Expect(overview.TopPipelines[0].TeamName).To(Equal(defaultTeam.Name()))
+			Expect(overview.TopPipelines[0].Containers).To(Equal(1))
+			Expect(overview.TopPipelines[0].Volumes).To(Equal(1))
+			Expect(overview.TopPipelines[0].RunningBuilds).To(Equal(1))
+		})
+	})
+})
@@ -9,7 +9,7 @@ import (
 	"github.com/lib/pq"
 )
 
-var componentsQuery = psql.Select("c.id, c.name, c.interval, c.last_ran, c.paused").
+var componentsQuery = psql.Select("c.id, c.name, c.interval, c.interval_override, c.last_ran, c.paused").
 	From("components c")
 
 //counterfeiter:generate . Component
@@ -17,30 +17,38 @@ type Component interface {
 	ID() int
 	Name() string
 	Interval() time.Duration
+	DefaultInterval() time.Duration
 	LastRan() time.Time
 	Paused() bool
 
 	Reload() (bool, error)
 	IntervalElapsed() bool
 	UpdateLastRan() error
+
+	SetInterval(time.Duration) error
+	ResetInterval() error
+	Pause() error
+	Unpause() error
 }
 
 type component struct {
-	id       int
-	name     string
-	interval time.Duration
-	lastRan  time.Time
-	paused   bool
-	rander   *rand.Rand
+	id              int
+	name            string
+	interval        time.Duration
+	defaultInterval time.Duration
+	lastRan         time.Time
+	paused          bool
+	rander          *rand.Rand
 
 	conn Conn
 }
 
-func (c *component) ID() int                 { return c.id }
-func (c *component) Name() string            { return c.name }
-func (c *component) Interval() time.Duration { return c.interval }
-func (c *component) LastRan() time.Time      { return c.lastRan }
-func (c *component) Paused() bool            { return c.paused }
+func (c *component) ID() int                        { return c.id }
+func (c *component) Name() string                   { return c.name }
+func (c *component) Interval() time.Duration        { return c.interval }
+func (c *component) DefaultInterval() time.Duration { return c.defaultInterval }
+func (c *component) LastRan() time.Time             { return c.lastRan }
+func (c *component) Paused() bool                   { return c.paused }
 
 func (c *component) Reload() (bool, error) {
 	row := componentsQuery.Where(sq.Eq{"c.id": c.id}).
@@ -90,16 +98,49 @@ func (c *component) UpdateLastRan() error {
 	return nil
 }
 
+// SetInterval overrides the interval the component was configured with,
+// until the override is reset. The override is picked up by every ATC the
+// next time it reloads the component.
+func (c *component) SetInterval(interval time.Duration) error {
+	return c.update(map[string]interface{}{"interval_override": interval.String()})
+}
+
+// ResetInterval goes back to the interval the component was configured with.
+func (c *component) ResetInterval() error {
+	return c.update(map[string]interface{}{"interval_override": nil})
+}
+
+func (c *component) Pause() error {
+	return c.update(map[string]interface{}{"paused": true})
+}
+
+func (c *component) Unpause() error {
+	return c.update(map[string]interface{}{"paused": false})
+}
+
+func (c *component) update(values map[string]interface{}) error {
+	_, err := psql.Update("components").
+		SetMap(values).
+		Where(sq.Eq{
+			"id": c.id,
+		}).
+		RunWith(c.conn).
+		Exec()
+	return err
+}
+
 func scanComponent(c *component, row scannable) error {
 	var (
-		lastRan  pq.NullTime
-		interval string
+		lastRan          pq.NullTime
+		interval         string
+		intervalOverride sql.NullString
 	)
 
 	err := row.Scan(
 		&c.id,
 		&c.name,
 		&interval,
+		&intervalOverride,
 		&lastRan,
 		&c.paused,
 	)
@@ -109,10 +150,18 @@ func scanComponent(c *component, row scannable) error {
 
 	c.lastRan = lastRan.Time
 
-	c.interval, err = time.ParseDuration(interval)
+	c.defaultInterval, err = time.ParseDuration(interval)
 	if err != nil {
 		return err
 	}
 
+	c.interval = c.defaultInterval
+	if intervalOverride.Valid {
+		c.interval, err = time.ParseDuration(intervalOverride.String)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
@@ -11,6 +11,7 @@ import (
 type ComponentFactory interface {
 	CreateOrUpdate(atc.Component) (Component, error)
 	Find(string) (Component, bool, error)
+	All() ([]Component, error)
 }
 
 type componentFactory struct {
@@ -44,6 +45,34 @@ func (f *componentFactory) Find(componentName string) (Component, bool, error) {
 	return component, true, nil
 }
 
+func (f *componentFactory) All() ([]Component, error) {
+	rows, err := componentsQuery.
+		OrderBy("c.name").
+		RunWith(f.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	var components []Component
+	for rows.Next() {
+		component := &component{
+			conn: f.conn,
+		}
+
+		err := scanComponent(component, rows)
+		if err != nil {
+			return nil, err
+		}
+
+		components = append(components, component)
+	}
+
+	return components, rows.Err()
+}
+
 func (f *componentFactory) CreateOrUpdate(c atc.Component) (Component, error) {
 	tx, err := f.conn.Begin()
 	if err != nil {
@@ -61,7 +90,7 @@ func (f *componentFactory) CreateOrUpdate(c atc.Component) (Component, error) {
 		Values(c.Name, c.Interval.String()).
 		Suffix(`
 			ON CONFLICT (name) DO UPDATE SET interval=EXCLUDED.interval
-			RETURNING id, name, interval, last_ran, paused
+			RETURNING id, name, interval, interval_override, last_ran, paused
 		`).
 		RunWith(tx).
 		QueryRow()
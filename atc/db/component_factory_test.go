@@ -1,6 +1,7 @@
 package db_test
 
 import (
+	"sort"
 	"time"
 
 	"github.com/concourse/concourse/atc"
@@ -36,6 +37,26 @@ var _ = Describe("ComponentFactory", func() {
 		})
 	})
 
+	Describe("All", func() {
+		BeforeEach(func() {
+			_, err := dbConn.Exec("INSERT INTO components (name, interval) VALUES ('scheduler', '100ms'), ('tracker', '10s') ON CONFLICT (name) DO UPDATE SET interval = EXCLUDED.interval")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns every component ordered by name", func() {
+			components, err := componentFactory.All()
+			Expect(err).NotTo(HaveOccurred())
+
+			var names []string
+			for _, component := range components {
+				names = append(names, component.Name())
+			}
+
+			Expect(names).To(ContainElements("scheduler", "tracker"))
+			Expect(sort.StringsAreSorted(names)).To(BeTrue())
+		})
+	})
+
 	Describe("CreateOrUpdate", func() {
 		It("updates component interval", func() {
 			interval := 1 * time.Second
@@ -3,6 +3,7 @@ package db_test
 import (
 	"time"
 
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -62,6 +63,66 @@ var _ = Describe("Component", func() {
 		})
 	})
 
+	Describe("SetInterval", func() {
+		BeforeEach(func() {
+			err = component.SetInterval(time.Minute)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("overrides the interval", func() {
+			Expect(component.Interval()).To(Equal(time.Minute))
+			Expect(component.DefaultInterval()).To(Equal(100 * time.Millisecond))
+		})
+
+		Context("when the component is created again on startup", func() {
+			BeforeEach(func() {
+				_, err = componentFactory.CreateOrUpdate(atc.Component{
+					Name:     "scheduler",
+					Interval: time.Second,
+				})
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("keeps the override", func() {
+				Expect(component.Interval()).To(Equal(time.Minute))
+				Expect(component.DefaultInterval()).To(Equal(time.Second))
+			})
+		})
+
+		Context("when the interval is reset", func() {
+			BeforeEach(func() {
+				err = component.ResetInterval()
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("goes back to the default interval", func() {
+				Expect(component.Interval()).To(Equal(100 * time.Millisecond))
+			})
+		})
+	})
+
+	Describe("Pause", func() {
+		BeforeEach(func() {
+			err = component.Pause()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("pauses the component", func() {
+			Expect(component.Paused()).To(BeTrue())
+		})
+
+		Context("when the component is unpaused", func() {
+			BeforeEach(func() {
+				err = component.Unpause()
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("unpauses the component", func() {
+				Expect(component.Paused()).To(BeFalse())
+			})
+		})
+	})
+
 	Describe("UpdateLastRan", func() {
 		BeforeEach(func() {
 			err = component.UpdateLastRan()
@@ -162,6 +162,149 @@ func (c resourceConfigCheckSessionContainerOwner) Create(tx Tx, workerName strin
 
 }
 
+// NewCheckContainerPoolContainerOwner references a slot in a team's pool of
+// check containers for a worker base resource type, with an expiry. The
+// checks of every resource config of the base resource type share the pool,
+// each resource config sticking to one slot. When the team or worker base
+// resource type disappear, or the expiry is reached, the container can be
+// removed.
+func NewCheckContainerPoolContainerOwner(
+	baseResourceTypeID int,
+	teamID int,
+	slot int,
+	expiries ContainerOwnerExpiries,
+) ContainerOwner {
+	return checkContainerPoolContainerOwner{
+		baseResourceTypeID: baseResourceTypeID,
+		teamID:             teamID,
+		slot:               slot,
+		expiries:           expiries,
+	}
+}
+
+type checkContainerPoolContainerOwner struct {
+	baseResourceTypeID int
+	teamID             int
+	slot               int
+	expiries           ContainerOwnerExpiries
+}
+
+func (c checkContainerPoolContainerOwner) Find(conn Conn) (sq.Eq, bool, error) {
+	var ids []int
+	rows, err := psql.Select("s.id").
+		From("check_container_pool_slots s").
+		Join("worker_base_resource_types wbrt ON wbrt.id = s.worker_base_resource_type_id").
+		Where(sq.And{
+			sq.Eq{
+				"wbrt.base_resource_type_id": c.baseResourceTypeID,
+				"s.team_id":                  c.teamID,
+				"s.slot":                     c.slot,
+			},
+			sq.Expr("s.expires_at > NOW()"),
+		}).
+		RunWith(conn).
+		Query()
+	if err != nil {
+		return nil, false, err
+	}
+
+	defer Close(rows)
+
+	for rows.Next() {
+		var id int
+		err = rows.Scan(&id)
+		if err != nil {
+			return nil, false, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	if len(ids) == 0 {
+		return nil, false, nil
+	}
+
+	return sq.Eq{
+		"check_container_pool_slot_id": ids,
+	}, true, nil
+}
+
+func (c checkContainerPoolContainerOwner) Create(tx Tx, workerName string) (map[string]interface{}, error) {
+	var wbrtID int
+	err := psql.Select("id").
+		From("worker_base_resource_types").
+		Where(sq.Eq{
+			"worker_name":           workerName,
+			"base_resource_type_id": c.baseResourceTypeID,
+		}).
+		Suffix("FOR SHARE").
+		RunWith(tx).
+		QueryRow().
+		Scan(&wbrtID)
+	if err != nil {
+		return nil, fmt.Errorf("get worker base resource type id: %s", err)
+	}
+
+	// spread the expiries so that the containers of a pool aren't all
+	// replaced at once
+	expiryStmt := fmt.Sprintf(
+		"NOW() + '%d seconds'::interval + random() * '%d seconds'::interval",
+		int(c.expiries.Min.Seconds()),
+		int((c.expiries.Max - c.expiries.Min).Seconds()),
+	)
+
+	var slotID int
+	err = psql.Insert("check_container_pool_slots").
+		SetMap(map[string]interface{}{
+			"worker_base_resource_type_id": wbrtID,
+			"team_id":                      c.teamID,
+			"slot":                         c.slot,
+			"expires_at":                   sq.Expr(expiryStmt),
+		}).
+		Suffix(`
+			ON CONFLICT (worker_base_resource_type_id, team_id, slot) DO UPDATE SET
+				expires_at = GREATEST(check_container_pool_slots.expires_at, EXCLUDED.expires_at)
+			RETURNING id
+		`).
+		RunWith(tx).
+		QueryRow().
+		Scan(&slotID)
+	if err != nil {
+		return nil, fmt.Errorf("upsert check container pool slot: %s", err)
+	}
+
+	return map[string]interface{}{
+		"check_container_pool_slot_id": slotID,
+		"team_id":                      c.teamID,
+	}, nil
+}
+
+// NewWarmPoolContainerOwner references a slot in a job's warm pool. When the
+// slot expires or is claimed by a build, the container is either collected or
+// owned by the build.
+func NewWarmPoolContainerOwner(slotID int, teamID int) ContainerOwner {
+	return warmPoolContainerOwner{
+		slotID: slotID,
+		teamID: teamID,
+	}
+}
+
+type warmPoolContainerOwner struct {
+	slotID int
+	teamID int
+}
+
+func (c warmPoolContainerOwner) Find(Conn) (sq.Eq, bool, error) {
+	return sq.Eq{"warm_pool_slot_id": c.slotID}, true, nil
+}
+
+func (c warmPoolContainerOwner) Create(Tx, string) (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"warm_pool_slot_id": c.slotID,
+		"team_id":           c.teamID,
+	}, nil
+}
+
 // NewFixedHandleContainerOwner is used in testing to represent a container
 // with a fixed handle, rather than using the randomly generated UUID as a
 // handle.
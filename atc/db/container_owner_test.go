@@ -132,4 +132,96 @@ var _ = Describe("ContainerOwner", func() {
 			})
 		})
 	})
+
+	Describe("CheckContainerPoolContainerOwner", func() {
+		var (
+			baseResourceTypeID int
+			ownerExpiries      db.ContainerOwnerExpiries
+
+			createOwner func(teamID int, slot int, workerName string) map[string]interface{}
+		)
+
+		ownerExpiries = db.ContainerOwnerExpiries{
+			Min: 5 * time.Minute,
+			Max: 10 * time.Minute,
+		}
+
+		BeforeEach(func() {
+			resourceConfig, err := resourceConfigFactory.FindOrCreateResourceConfig(
+				defaultWorkerResourceType.Type,
+				atc.Source{"some": "source"},
+				nil,
+			)
+			Expect(err).ToNot(HaveOccurred())
+
+			baseResourceTypeID = resourceConfig.OriginBaseResourceType().ID
+
+			createOwner = func(teamID int, slot int, workerName string) map[string]interface{} {
+				tx, err := dbConn.Begin()
+				Expect(err).ToNot(HaveOccurred())
+
+				columns, err := db.NewCheckContainerPoolContainerOwner(baseResourceTypeID, teamID, slot, ownerExpiries).Create(tx, workerName)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(tx.Commit()).To(Succeed())
+
+				return columns
+			}
+		})
+
+		findOwner := func(teamID int, slot int) (sq.Eq, bool) {
+			columns, found, err := db.NewCheckContainerPoolContainerOwner(baseResourceTypeID, teamID, slot, ownerExpiries).Find(dbConn)
+			Expect(err).ToNot(HaveOccurred())
+			return columns, found
+		}
+
+		It("doesn't find a slot which wasn't created", func() {
+			_, found := findOwner(defaultTeam.ID(), 0)
+			Expect(found).To(BeFalse())
+		})
+
+		It("finds the slot on every worker", func() {
+			createdColumns := createOwner(defaultTeam.ID(), 0, defaultWorker.Name())
+			Expect(createdColumns["team_id"]).To(Equal(defaultTeam.ID()))
+
+			createdColumns2 := createOwner(defaultTeam.ID(), 0, otherWorker.Name())
+
+			foundColumns, found := findOwner(defaultTeam.ID(), 0)
+			Expect(found).To(BeTrue())
+			Expect(foundColumns["check_container_pool_slot_id"]).To(ConsistOf(
+				createdColumns["check_container_pool_slot_id"],
+				createdColumns2["check_container_pool_slot_id"],
+			))
+		})
+
+		It("reuses the slot when it's created again", func() {
+			createdColumns := createOwner(defaultTeam.ID(), 0, defaultWorker.Name())
+			createdColumns2 := createOwner(defaultTeam.ID(), 0, defaultWorker.Name())
+			Expect(createdColumns2["check_container_pool_slot_id"]).To(Equal(createdColumns["check_container_pool_slot_id"]))
+		})
+
+		It("keeps the slots of other teams and other slots apart", func() {
+			otherTeam, err := teamFactory.CreateTeam(atc.Team{Name: "some-other-team"})
+			Expect(err).ToNot(HaveOccurred())
+
+			createOwner(otherTeam.ID(), 0, defaultWorker.Name())
+			createOwner(defaultTeam.ID(), 1, defaultWorker.Name())
+
+			_, found := findOwner(defaultTeam.ID(), 0)
+			Expect(found).To(BeFalse())
+		})
+
+		It("doesn't find expired slots", func() {
+			createOwner(defaultTeam.ID(), 0, defaultWorker.Name())
+
+			_, err := psql.Update("check_container_pool_slots").
+				Set("expires_at", sq.Expr("NOW() - '1 second'::interval")).
+				RunWith(dbConn).
+				Exec()
+			Expect(err).ToNot(HaveOccurred())
+
+			_, found := findOwner(defaultTeam.ID(), 0)
+			Expect(found).To(BeFalse())
+		})
+	})
 })
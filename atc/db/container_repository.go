@@ -19,6 +19,7 @@ type ContainerRepository interface {
 	RemoveMissingContainers(time.Duration) (int, error)
 	DestroyUnknownContainers(workerName string, reportedHandles []string) (int, error)
 	DestroyDirtyInMemoryBuildContainers() (int, error)
+	FindBuildContainers(buildID int) ([]Container, error)
 }
 
 type containerRepository struct {
@@ -228,6 +229,8 @@ func (repository *containerRepository) FindOrphanedContainers() ([]CreatingConta
 			sq.Eq{
 				"c.build_id":                         nil,
 				"c.resource_config_check_session_id": nil,
+				"c.check_container_pool_slot_id":     nil,
+				"c.warm_pool_slot_id":                nil,
 				"c.in_memory_build_id":               nil,
 			},
 			sq.And{
@@ -444,3 +447,18 @@ func (repository *containerRepository) DestroyDirtyInMemoryBuildContainers() (in
 
 	return int(affected), nil
 }
+
+// FindBuildContainers returns the containers of the build's steps, in any
+// state.
+func (repository *containerRepository) FindBuildContainers(buildID int) ([]Container, error) {
+	rows, err := selectContainers().
+		Where(sq.Eq{"build_id": buildID}).
+		OrderBy("id").
+		RunWith(repository.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	return scanContainers(rows, repository.conn, nil)
+}
@@ -1068,4 +1068,37 @@ var _ = Describe("ContainerRepository", func() {
 			})
 		})
 	})
+
+	Describe("FindBuildContainers", func() {
+		var build db.Build
+
+		BeforeEach(func() {
+			var err error
+			build, err = defaultJob.CreateBuild(defaultBuildCreatedBy)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = defaultWorker.CreateContainer(
+				db.NewBuildStepContainerOwner(build.ID(), "some-plan", defaultTeam.ID()),
+				db.ContainerMetadata{Type: db.ContainerTypeTask, StepName: "some-step", BuildID: build.ID()},
+			)
+			Expect(err).NotTo(HaveOccurred())
+
+			otherBuild, err := defaultJob.CreateBuild(defaultBuildCreatedBy)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = defaultWorker.CreateContainer(
+				db.NewBuildStepContainerOwner(otherBuild.ID(), "some-plan", defaultTeam.ID()),
+				db.ContainerMetadata{Type: db.ContainerTypeTask, StepName: "some-step", BuildID: otherBuild.ID()},
+			)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns only the containers of the build", func() {
+			containers, err := containerRepository.FindBuildContainers(build.ID())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(containers).To(HaveLen(1))
+			Expect(containers[0].Metadata().StepName).To(Equal("some-step"))
+			Expect(containers[0].State()).To(Equal(atc.ContainerStateCreating))
+		})
+	})
 })
@@ -0,0 +1,139 @@
+package db
+
+import (
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
+	"github.com/lib/pq"
+)
+
+// DashboardPreferenceRepository stores each user's arrangement of the
+// dashboard. Users are identified by their subject.
+//
+//counterfeiter:generate . DashboardPreferenceRepository
+type DashboardPreferenceRepository interface {
+	DashboardPreferences(userSub string) (atc.DashboardPreferences, error)
+
+	// OrderDashboardPipelines replaces the user's ordering of pipelines.
+	// Pipelines which no longer exist are left out of it.
+	OrderDashboardPipelines(userSub string, pipelineIDs []int) error
+
+	// FavoritePipeline returns false if there is no such pipeline.
+	FavoritePipeline(userSub string, pipelineID int) (bool, error)
+	UnfavoritePipeline(userSub string, pipelineID int) error
+}
+
+type dashboardPreferenceRepository struct {
+	conn Conn
+}
+
+func NewDashboardPreferenceRepository(conn Conn) DashboardPreferenceRepository {
+	return &dashboardPreferenceRepository{
+		conn: conn,
+	}
+}
+
+func (repo *dashboardPreferenceRepository) DashboardPreferences(userSub string) (atc.DashboardPreferences, error) {
+	order, err := repo.pipelineIDs(psql.Select("pipeline_id").
+		From("user_pipeline_orderings").
+		Where(sq.Eq{"user_sub": userSub}).
+		OrderBy("ordering"))
+	if err != nil {
+		return atc.DashboardPreferences{}, err
+	}
+
+	favorites, err := repo.pipelineIDs(psql.Select("pipeline_id").
+		From("user_favorite_pipelines").
+		Where(sq.Eq{"user_sub": userSub}).
+		OrderBy("pipeline_id"))
+	if err != nil {
+		return atc.DashboardPreferences{}, err
+	}
+
+	return atc.DashboardPreferences{
+		PipelineOrder:     order,
+		FavoritePipelines: favorites,
+	}, nil
+}
+
+func (repo *dashboardPreferenceRepository) OrderDashboardPipelines(userSub string, pipelineIDs []int) error {
+	tx, err := repo.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	_, err = psql.Delete("user_pipeline_orderings").
+		Where(sq.Eq{"user_sub": userSub}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	// a pipeline listed more than once keeps its first position
+	_, err = tx.Exec(`
+		INSERT INTO user_pipeline_orderings (user_sub, pipeline_id, ordering)
+		SELECT $1, p.id, o.ordering
+		FROM unnest($2::integer[]) WITH ORDINALITY AS o(id, ordering)
+		JOIN pipelines p ON p.id = o.id
+		ORDER BY o.ordering
+		ON CONFLICT DO NOTHING
+	`, userSub, pq.Array(pipelineIDs))
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (repo *dashboardPreferenceRepository) FavoritePipeline(userSub string, pipelineID int) (bool, error) {
+	_, err := psql.Insert("user_favorite_pipelines").
+		Columns("user_sub", "pipeline_id").
+		Values(userSub, pipelineID).
+		Suffix("ON CONFLICT DO NOTHING").
+		RunWith(repo.conn).
+		Exec()
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == pqFKeyViolationErrCode {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (repo *dashboardPreferenceRepository) UnfavoritePipeline(userSub string, pipelineID int) error {
+	_, err := psql.Delete("user_favorite_pipelines").
+		Where(sq.Eq{
+			"user_sub":    userSub,
+			"pipeline_id": pipelineID,
+		}).
+		RunWith(repo.conn).
+		Exec()
+	return err
+}
+
+func (repo *dashboardPreferenceRepository) pipelineIDs(query sq.SelectBuilder) ([]int, error) {
+	rows, err := query.RunWith(repo.conn).Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	ids := []int{}
+	for rows.Next() {
+		var id int
+		err := rows.Scan(&id)
+		if err != nil {
+			return nil, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
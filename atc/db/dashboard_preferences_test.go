@@ -0,0 +1,97 @@
+package db_test
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DashboardPreferenceRepository", func() {
+	var (
+		repository db.DashboardPreferenceRepository
+
+		otherPipeline db.Pipeline
+	)
+
+	BeforeEach(func() {
+		repository = db.NewDashboardPreferenceRepository(dbConn)
+
+		var err error
+		otherPipeline, _, err = defaultTeam.SavePipeline(atc.PipelineRef{Name: "other-pipeline"}, atc.Config{}, db.ConfigVersion(0), false)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("starts out empty", func() {
+		preferences, err := repository.DashboardPreferences("some-sub")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(preferences).To(Equal(atc.DashboardPreferences{
+			PipelineOrder:     []int{},
+			FavoritePipelines: []int{},
+		}))
+	})
+
+	Describe("OrderDashboardPipelines", func() {
+		BeforeEach(func() {
+			err := repository.OrderDashboardPipelines("some-sub", []int{otherPipeline.ID(), defaultPipeline.ID()})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("orders the user's pipelines", func() {
+			preferences, err := repository.DashboardPreferences("some-sub")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(preferences.PipelineOrder).To(Equal([]int{otherPipeline.ID(), defaultPipeline.ID()}))
+		})
+
+		It("does not affect other users", func() {
+			preferences, err := repository.DashboardPreferences("other-sub")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(preferences.PipelineOrder).To(BeEmpty())
+		})
+
+		It("replaces the previous order, leaving out missing and repeated pipelines", func() {
+			err := repository.OrderDashboardPipelines("some-sub", []int{defaultPipeline.ID(), 9999, defaultPipeline.ID()})
+			Expect(err).ToNot(HaveOccurred())
+
+			preferences, err := repository.DashboardPreferences("some-sub")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(preferences.PipelineOrder).To(Equal([]int{defaultPipeline.ID()}))
+		})
+
+		It("forgets pipelines which are destroyed", func() {
+			Expect(otherPipeline.Destroy()).To(Succeed())
+
+			preferences, err := repository.DashboardPreferences("some-sub")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(preferences.PipelineOrder).To(Equal([]int{defaultPipeline.ID()}))
+		})
+	})
+
+	Describe("FavoritePipeline", func() {
+		It("favorites the pipeline for the user", func() {
+			found, err := repository.FavoritePipeline("some-sub", otherPipeline.ID())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			found, err = repository.FavoritePipeline("some-sub", otherPipeline.ID())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			preferences, err := repository.DashboardPreferences("some-sub")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(preferences.FavoritePipelines).To(Equal([]int{otherPipeline.ID()}))
+
+			Expect(repository.UnfavoritePipeline("some-sub", otherPipeline.ID())).To(Succeed())
+
+			preferences, err = repository.DashboardPreferences("some-sub")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(preferences.FavoritePipelines).To(BeEmpty())
+		})
+
+		It("returns false when the pipeline does not exist", func() {
+			found, err := repository.FavoritePipeline("some-sub", 9999)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+	})
+})
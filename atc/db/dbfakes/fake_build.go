@@ -30,6 +30,29 @@ type FakeBuild struct {
 		result1 db.Notifier
 		result2 error
 	}
+	AbortStepStub        func(atc.PlanID) error
+	abortStepMutex       sync.RWMutex
+	abortStepArgsForCall []struct {
+		arg1 atc.PlanID
+	}
+	abortStepReturns struct {
+		result1 error
+	}
+	abortStepReturnsOnCall map[int]struct {
+		result1 error
+	}
+	AbortedStepsStub        func() ([]atc.PlanID, error)
+	abortedStepsMutex       sync.RWMutex
+	abortedStepsArgsForCall []struct {
+	}
+	abortedStepsReturns struct {
+		result1 []atc.PlanID
+		result2 error
+	}
+	abortedStepsReturnsOnCall map[int]struct {
+		result1 []atc.PlanID
+		result2 error
+	}
 	AcquireTrackingLockStub        func(lager.Logger, time.Duration) (lock.Lock, bool, error)
 	acquireTrackingLockMutex       sync.RWMutex
 	acquireTrackingLockArgsForCall []struct {
@@ -109,6 +132,29 @@ type FakeBuild struct {
 		result1 []db.WorkerArtifact
 		result2 error
 	}
+	CollapseIntoStub        func(int) (bool, error)
+	collapseIntoMutex       sync.RWMutex
+	collapseIntoArgsForCall []struct {
+		arg1 int
+	}
+	collapseIntoReturns struct {
+		result1 bool
+		result2 error
+	}
+	collapseIntoReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	CollapsedIntoStub        func() int
+	collapsedIntoMutex       sync.RWMutex
+	collapsedIntoArgsForCall []struct {
+	}
+	collapsedIntoReturns struct {
+		result1 int
+	}
+	collapsedIntoReturnsOnCall map[int]struct {
+		result1 int
+	}
 	CommentStub        func() string
 	commentMutex       sync.RWMutex
 	commentArgsForCall []struct {
@@ -172,6 +218,18 @@ type FakeBuild struct {
 	endTimeReturnsOnCall map[int]struct {
 		result1 time.Time
 	}
+	EstimatedDurationStub        func() (time.Duration, bool)
+	estimatedDurationMutex       sync.RWMutex
+	estimatedDurationArgsForCall []struct {
+	}
+	estimatedDurationReturns struct {
+		result1 time.Duration
+		result2 bool
+	}
+	estimatedDurationReturnsOnCall map[int]struct {
+		result1 time.Duration
+		result2 bool
+	}
 	EventsStub        func(uint) (db.EventSource, error)
 	eventsMutex       sync.RWMutex
 	eventsArgsForCall []struct {
@@ -185,6 +243,26 @@ type FakeBuild struct {
 		result1 db.EventSource
 		result2 error
 	}
+	ExternalRefStub        func() string
+	externalRefMutex       sync.RWMutex
+	externalRefArgsForCall []struct {
+	}
+	externalRefReturns struct {
+		result1 string
+	}
+	externalRefReturnsOnCall map[int]struct {
+		result1 string
+	}
+	FailureReasonStub        func() atc.FailureReason
+	failureReasonMutex       sync.RWMutex
+	failureReasonArgsForCall []struct {
+	}
+	failureReasonReturns struct {
+		result1 atc.FailureReason
+	}
+	failureReasonReturnsOnCall map[int]struct {
+		result1 atc.FailureReason
+	}
 	FinishStub        func(db.BuildStatus) error
 	finishMutex       sync.RWMutex
 	finishArgsForCall []struct {
@@ -216,6 +294,18 @@ type FakeBuild struct {
 	iDReturnsOnCall map[int]struct {
 		result1 int
 	}
+	ImageResourceVersionsStub        func() ([]db.BuildImageResourceVersion, error)
+	imageResourceVersionsMutex       sync.RWMutex
+	imageResourceVersionsArgsForCall []struct {
+	}
+	imageResourceVersionsReturns struct {
+		result1 []db.BuildImageResourceVersion
+		result2 error
+	}
+	imageResourceVersionsReturnsOnCall map[int]struct {
+		result1 []db.BuildImageResourceVersion
+		result2 error
+	}
 	InputsReadyStub        func() bool
 	inputsReadyMutex       sync.RWMutex
 	inputsReadyArgsForCall []struct {
@@ -372,6 +462,18 @@ type FakeBuild struct {
 	onCheckBuildStartReturnsOnCall map[int]struct {
 		result1 error
 	}
+	PinnedInputsStub        func() ([]atc.BuildInputPin, error)
+	pinnedInputsMutex       sync.RWMutex
+	pinnedInputsArgsForCall []struct {
+	}
+	pinnedInputsReturns struct {
+		result1 []atc.BuildInputPin
+		result2 error
+	}
+	pinnedInputsReturnsOnCall map[int]struct {
+		result1 []atc.BuildInputPin
+		result2 error
+	}
 	PipelineStub        func() (db.Pipeline, bool, error)
 	pipelineMutex       sync.RWMutex
 	pipelineArgsForCall []struct {
@@ -450,6 +552,20 @@ type FakeBuild struct {
 	privatePlanReturnsOnCall map[int]struct {
 		result1 atc.Plan
 	}
+	ProvenanceStub        func() (json.RawMessage, bool, error)
+	provenanceMutex       sync.RWMutex
+	provenanceArgsForCall []struct {
+	}
+	provenanceReturns struct {
+		result1 json.RawMessage
+		result2 bool
+		result3 error
+	}
+	provenanceReturnsOnCall map[int]struct {
+		result1 json.RawMessage
+		result2 bool
+		result3 error
+	}
 	PublicPlanStub        func() *json.RawMessage
 	publicPlanMutex       sync.RWMutex
 	publicPlanArgsForCall []struct {
@@ -578,6 +694,16 @@ type FakeBuild struct {
 		result1 bool
 		result2 error
 	}
+	RetryOfStub        func() int
+	retryOfMutex       sync.RWMutex
+	retryOfArgsForCall []struct {
+	}
+	retryOfReturns struct {
+		result1 int
+	}
+	retryOfReturnsOnCall map[int]struct {
+		result1 int
+	}
 	RunStateIDStub        func() string
 	runStateIDMutex       sync.RWMutex
 	runStateIDArgsForCall []struct {
@@ -599,6 +725,17 @@ type FakeBuild struct {
 	saveEventReturnsOnCall map[int]struct {
 		result1 error
 	}
+	SaveEventsStub        func([]atc.Event) error
+	saveEventsMutex       sync.RWMutex
+	saveEventsArgsForCall []struct {
+		arg1 []atc.Event
+	}
+	saveEventsReturns struct {
+		result1 error
+	}
+	saveEventsReturnsOnCall map[int]struct {
+		result1 error
+	}
 	SaveImageResourceVersionStub        func(db.ResourceCache) error
 	saveImageResourceVersionMutex       sync.RWMutex
 	saveImageResourceVersionArgsForCall []struct {
@@ -646,6 +783,41 @@ type FakeBuild struct {
 		result2 bool
 		result3 error
 	}
+	SaveProvenanceStub        func(json.RawMessage) error
+	saveProvenanceMutex       sync.RWMutex
+	saveProvenanceArgsForCall []struct {
+		arg1 json.RawMessage
+	}
+	saveProvenanceReturns struct {
+		result1 error
+	}
+	saveProvenanceReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SaveStepDurationStub        func(string, time.Duration) error
+	saveStepDurationMutex       sync.RWMutex
+	saveStepDurationArgsForCall []struct {
+		arg1 string
+		arg2 time.Duration
+	}
+	saveStepDurationReturns struct {
+		result1 error
+	}
+	saveStepDurationReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SavedEventsStub        func() ([]event.Envelope, error)
+	savedEventsMutex       sync.RWMutex
+	savedEventsArgsForCall []struct {
+	}
+	savedEventsReturns struct {
+		result1 []event.Envelope
+		result2 error
+	}
+	savedEventsReturnsOnCall map[int]struct {
+		result1 []event.Envelope
+		result2 error
+	}
 	SchemaStub        func() string
 	schemaMutex       sync.RWMutex
 	schemaArgsForCall []struct {
@@ -678,6 +850,17 @@ type FakeBuild struct {
 	setDrainedReturnsOnCall map[int]struct {
 		result1 error
 	}
+	SetFailureReasonStub        func(atc.FailureReason) error
+	setFailureReasonMutex       sync.RWMutex
+	setFailureReasonArgsForCall []struct {
+		arg1 atc.FailureReason
+	}
+	setFailureReasonReturns struct {
+		result1 error
+	}
+	setFailureReasonReturnsOnCall map[int]struct {
+		result1 error
+	}
 	SetInterceptibleStub        func(bool) error
 	setInterceptibleMutex       sync.RWMutex
 	setInterceptibleArgsForCall []struct {
@@ -689,6 +872,19 @@ type FakeBuild struct {
 	setInterceptibleReturnsOnCall map[int]struct {
 		result1 error
 	}
+	SetStartLatencyStub        func(atc.BuildStartLatency) (bool, error)
+	setStartLatencyMutex       sync.RWMutex
+	setStartLatencyArgsForCall []struct {
+		arg1 atc.BuildStartLatency
+	}
+	setStartLatencyReturns struct {
+		result1 bool
+		result2 error
+	}
+	setStartLatencyReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	SpanContextStub        func() propagation.TextMapCarrier
 	spanContextMutex       sync.RWMutex
 	spanContextArgsForCall []struct {
@@ -712,6 +908,16 @@ type FakeBuild struct {
 		result1 bool
 		result2 error
 	}
+	StartLatencyStub        func() *atc.BuildStartLatency
+	startLatencyMutex       sync.RWMutex
+	startLatencyArgsForCall []struct {
+	}
+	startLatencyReturns struct {
+		result1 *atc.BuildStartLatency
+	}
+	startLatencyReturnsOnCall map[int]struct {
+		result1 *atc.BuildStartLatency
+	}
 	StartTimeStub        func() time.Time
 	startTimeMutex       sync.RWMutex
 	startTimeArgsForCall []struct {
@@ -732,6 +938,33 @@ type FakeBuild struct {
 	statusReturnsOnCall map[int]struct {
 		result1 db.BuildStatus
 	}
+	StepAbortNotifierStub        func() (db.Notifier, error)
+	stepAbortNotifierMutex       sync.RWMutex
+	stepAbortNotifierArgsForCall []struct {
+	}
+	stepAbortNotifierReturns struct {
+		result1 db.Notifier
+		result2 error
+	}
+	stepAbortNotifierReturnsOnCall map[int]struct {
+		result1 db.Notifier
+		result2 error
+	}
+	StepDurationEstimateStub        func(string) (time.Duration, bool, error)
+	stepDurationEstimateMutex       sync.RWMutex
+	stepDurationEstimateArgsForCall []struct {
+		arg1 string
+	}
+	stepDurationEstimateReturns struct {
+		result1 time.Duration
+		result2 bool
+		result3 error
+	}
+	stepDurationEstimateReturnsOnCall map[int]struct {
+		result1 time.Duration
+		result2 bool
+		result3 error
+	}
 	SyslogTagStub        func(event.OriginID) string
 	syslogTagMutex       sync.RWMutex
 	syslogTagArgsForCall []struct {
@@ -788,6 +1021,28 @@ type FakeBuild struct {
 		result1 vars.Variables
 		result2 error
 	}
+	VarsStub        func() []atc.BuildVar
+	varsMutex       sync.RWMutex
+	varsArgsForCall []struct {
+	}
+	varsReturns struct {
+		result1 []atc.BuildVar
+	}
+	varsReturnsOnCall map[int]struct {
+		result1 []atc.BuildVar
+	}
+	WorkerNamesStub        func() ([]string, error)
+	workerNamesMutex       sync.RWMutex
+	workerNamesArgsForCall []struct {
+	}
+	workerNamesReturns struct {
+		result1 []string
+		result2 error
+	}
+	workerNamesReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -848,6 +1103,123 @@ func (fake *FakeBuild) AbortNotifierReturnsOnCall(i int, result1 db.Notifier, re
 	}{result1, result2}
 }
 
+func (fake *FakeBuild) AbortStep(arg1 atc.PlanID) error {
+	fake.abortStepMutex.Lock()
+	ret, specificReturn := fake.abortStepReturnsOnCall[len(fake.abortStepArgsForCall)]
+	fake.abortStepArgsForCall = append(fake.abortStepArgsForCall, struct {
+		arg1 atc.PlanID
+	}{arg1})
+	stub := fake.AbortStepStub
+	fakeReturns := fake.abortStepReturns
+	fake.recordInvocation("AbortStep", []interface{}{arg1})
+	fake.abortStepMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) AbortStepCallCount() int {
+	fake.abortStepMutex.RLock()
+	defer fake.abortStepMutex.RUnlock()
+	return len(fake.abortStepArgsForCall)
+}
+
+func (fake *FakeBuild) AbortStepCalls(stub func(atc.PlanID) error) {
+	fake.abortStepMutex.Lock()
+	defer fake.abortStepMutex.Unlock()
+	fake.AbortStepStub = stub
+}
+
+func (fake *FakeBuild) AbortStepArgsForCall(i int) atc.PlanID {
+	fake.abortStepMutex.RLock()
+	defer fake.abortStepMutex.RUnlock()
+	argsForCall := fake.abortStepArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuild) AbortStepReturns(result1 error) {
+	fake.abortStepMutex.Lock()
+	defer fake.abortStepMutex.Unlock()
+	fake.AbortStepStub = nil
+	fake.abortStepReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) AbortStepReturnsOnCall(i int, result1 error) {
+	fake.abortStepMutex.Lock()
+	defer fake.abortStepMutex.Unlock()
+	fake.AbortStepStub = nil
+	if fake.abortStepReturnsOnCall == nil {
+		fake.abortStepReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.abortStepReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) AbortedSteps() ([]atc.PlanID, error) {
+	fake.abortedStepsMutex.Lock()
+	ret, specificReturn := fake.abortedStepsReturnsOnCall[len(fake.abortedStepsArgsForCall)]
+	fake.abortedStepsArgsForCall = append(fake.abortedStepsArgsForCall, struct {
+	}{})
+	stub := fake.AbortedStepsStub
+	fakeReturns := fake.abortedStepsReturns
+	fake.recordInvocation("AbortedSteps", []interface{}{})
+	fake.abortedStepsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) AbortedStepsCallCount() int {
+	fake.abortedStepsMutex.RLock()
+	defer fake.abortedStepsMutex.RUnlock()
+	return len(fake.abortedStepsArgsForCall)
+}
+
+func (fake *FakeBuild) AbortedStepsCalls(stub func() ([]atc.PlanID, error)) {
+	fake.abortedStepsMutex.Lock()
+	defer fake.abortedStepsMutex.Unlock()
+	fake.AbortedStepsStub = stub
+}
+
+func (fake *FakeBuild) AbortedStepsReturns(result1 []atc.PlanID, result2 error) {
+	fake.abortedStepsMutex.Lock()
+	defer fake.abortedStepsMutex.Unlock()
+	fake.AbortedStepsStub = nil
+	fake.abortedStepsReturns = struct {
+		result1 []atc.PlanID
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) AbortedStepsReturnsOnCall(i int, result1 []atc.PlanID, result2 error) {
+	fake.abortedStepsMutex.Lock()
+	defer fake.abortedStepsMutex.Unlock()
+	fake.AbortedStepsStub = nil
+	if fake.abortedStepsReturnsOnCall == nil {
+		fake.abortedStepsReturnsOnCall = make(map[int]struct {
+			result1 []atc.PlanID
+			result2 error
+		})
+	}
+	fake.abortedStepsReturnsOnCall[i] = struct {
+		result1 []atc.PlanID
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuild) AcquireTrackingLock(arg1 lager.Logger, arg2 time.Duration) (lock.Lock, bool, error) {
 	fake.acquireTrackingLockMutex.Lock()
 	ret, specificReturn := fake.acquireTrackingLockReturnsOnCall[len(fake.acquireTrackingLockArgsForCall)]
@@ -1207,6 +1579,123 @@ func (fake *FakeBuild) ArtifactsReturnsOnCall(i int, result1 []db.WorkerArtifact
 	}{result1, result2}
 }
 
+func (fake *FakeBuild) CollapseInto(arg1 int) (bool, error) {
+	fake.collapseIntoMutex.Lock()
+	ret, specificReturn := fake.collapseIntoReturnsOnCall[len(fake.collapseIntoArgsForCall)]
+	fake.collapseIntoArgsForCall = append(fake.collapseIntoArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	stub := fake.CollapseIntoStub
+	fakeReturns := fake.collapseIntoReturns
+	fake.recordInvocation("CollapseInto", []interface{}{arg1})
+	fake.collapseIntoMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) CollapseIntoCallCount() int {
+	fake.collapseIntoMutex.RLock()
+	defer fake.collapseIntoMutex.RUnlock()
+	return len(fake.collapseIntoArgsForCall)
+}
+
+func (fake *FakeBuild) CollapseIntoCalls(stub func(int) (bool, error)) {
+	fake.collapseIntoMutex.Lock()
+	defer fake.collapseIntoMutex.Unlock()
+	fake.CollapseIntoStub = stub
+}
+
+func (fake *FakeBuild) CollapseIntoArgsForCall(i int) int {
+	fake.collapseIntoMutex.RLock()
+	defer fake.collapseIntoMutex.RUnlock()
+	argsForCall := fake.collapseIntoArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuild) CollapseIntoReturns(result1 bool, result2 error) {
+	fake.collapseIntoMutex.Lock()
+	defer fake.collapseIntoMutex.Unlock()
+	fake.CollapseIntoStub = nil
+	fake.collapseIntoReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) CollapseIntoReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.collapseIntoMutex.Lock()
+	defer fake.collapseIntoMutex.Unlock()
+	fake.CollapseIntoStub = nil
+	if fake.collapseIntoReturnsOnCall == nil {
+		fake.collapseIntoReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.collapseIntoReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) CollapsedInto() int {
+	fake.collapsedIntoMutex.Lock()
+	ret, specificReturn := fake.collapsedIntoReturnsOnCall[len(fake.collapsedIntoArgsForCall)]
+	fake.collapsedIntoArgsForCall = append(fake.collapsedIntoArgsForCall, struct {
+	}{})
+	stub := fake.CollapsedIntoStub
+	fakeReturns := fake.collapsedIntoReturns
+	fake.recordInvocation("CollapsedInto", []interface{}{})
+	fake.collapsedIntoMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) CollapsedIntoCallCount() int {
+	fake.collapsedIntoMutex.RLock()
+	defer fake.collapsedIntoMutex.RUnlock()
+	return len(fake.collapsedIntoArgsForCall)
+}
+
+func (fake *FakeBuild) CollapsedIntoCalls(stub func() int) {
+	fake.collapsedIntoMutex.Lock()
+	defer fake.collapsedIntoMutex.Unlock()
+	fake.CollapsedIntoStub = stub
+}
+
+func (fake *FakeBuild) CollapsedIntoReturns(result1 int) {
+	fake.collapsedIntoMutex.Lock()
+	defer fake.collapsedIntoMutex.Unlock()
+	fake.CollapsedIntoStub = nil
+	fake.collapsedIntoReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeBuild) CollapsedIntoReturnsOnCall(i int, result1 int) {
+	fake.collapsedIntoMutex.Lock()
+	defer fake.collapsedIntoMutex.Unlock()
+	fake.CollapsedIntoStub = nil
+	if fake.collapsedIntoReturnsOnCall == nil {
+		fake.collapsedIntoReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.collapsedIntoReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
 func (fake *FakeBuild) Comment() string {
 	fake.commentMutex.Lock()
 	ret, specificReturn := fake.commentReturnsOnCall[len(fake.commentArgsForCall)]
@@ -1536,18 +2025,17 @@ func (fake *FakeBuild) EndTimeReturnsOnCall(i int, result1 time.Time) {
 	}{result1}
 }
 
-func (fake *FakeBuild) Events(arg1 uint) (db.EventSource, error) {
-	fake.eventsMutex.Lock()
-	ret, specificReturn := fake.eventsReturnsOnCall[len(fake.eventsArgsForCall)]
-	fake.eventsArgsForCall = append(fake.eventsArgsForCall, struct {
-		arg1 uint
-	}{arg1})
-	stub := fake.EventsStub
-	fakeReturns := fake.eventsReturns
-	fake.recordInvocation("Events", []interface{}{arg1})
-	fake.eventsMutex.Unlock()
+func (fake *FakeBuild) EstimatedDuration() (time.Duration, bool) {
+	fake.estimatedDurationMutex.Lock()
+	ret, specificReturn := fake.estimatedDurationReturnsOnCall[len(fake.estimatedDurationArgsForCall)]
+	fake.estimatedDurationArgsForCall = append(fake.estimatedDurationArgsForCall, struct {
+	}{})
+	stub := fake.EstimatedDurationStub
+	fakeReturns := fake.estimatedDurationReturns
+	fake.recordInvocation("EstimatedDuration", []interface{}{})
+	fake.estimatedDurationMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub()
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -1555,21 +2043,78 @@ func (fake *FakeBuild) Events(arg1 uint) (db.EventSource, error) {
 	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *FakeBuild) EventsCallCount() int {
-	fake.eventsMutex.RLock()
-	defer fake.eventsMutex.RUnlock()
-	return len(fake.eventsArgsForCall)
+func (fake *FakeBuild) EstimatedDurationCallCount() int {
+	fake.estimatedDurationMutex.RLock()
+	defer fake.estimatedDurationMutex.RUnlock()
+	return len(fake.estimatedDurationArgsForCall)
 }
 
-func (fake *FakeBuild) EventsCalls(stub func(uint) (db.EventSource, error)) {
-	fake.eventsMutex.Lock()
-	defer fake.eventsMutex.Unlock()
-	fake.EventsStub = stub
+func (fake *FakeBuild) EstimatedDurationCalls(stub func() (time.Duration, bool)) {
+	fake.estimatedDurationMutex.Lock()
+	defer fake.estimatedDurationMutex.Unlock()
+	fake.EstimatedDurationStub = stub
 }
 
-func (fake *FakeBuild) EventsArgsForCall(i int) uint {
-	fake.eventsMutex.RLock()
-	defer fake.eventsMutex.RUnlock()
+func (fake *FakeBuild) EstimatedDurationReturns(result1 time.Duration, result2 bool) {
+	fake.estimatedDurationMutex.Lock()
+	defer fake.estimatedDurationMutex.Unlock()
+	fake.EstimatedDurationStub = nil
+	fake.estimatedDurationReturns = struct {
+		result1 time.Duration
+		result2 bool
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) EstimatedDurationReturnsOnCall(i int, result1 time.Duration, result2 bool) {
+	fake.estimatedDurationMutex.Lock()
+	defer fake.estimatedDurationMutex.Unlock()
+	fake.EstimatedDurationStub = nil
+	if fake.estimatedDurationReturnsOnCall == nil {
+		fake.estimatedDurationReturnsOnCall = make(map[int]struct {
+			result1 time.Duration
+			result2 bool
+		})
+	}
+	fake.estimatedDurationReturnsOnCall[i] = struct {
+		result1 time.Duration
+		result2 bool
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) Events(arg1 uint) (db.EventSource, error) {
+	fake.eventsMutex.Lock()
+	ret, specificReturn := fake.eventsReturnsOnCall[len(fake.eventsArgsForCall)]
+	fake.eventsArgsForCall = append(fake.eventsArgsForCall, struct {
+		arg1 uint
+	}{arg1})
+	stub := fake.EventsStub
+	fakeReturns := fake.eventsReturns
+	fake.recordInvocation("Events", []interface{}{arg1})
+	fake.eventsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) EventsCallCount() int {
+	fake.eventsMutex.RLock()
+	defer fake.eventsMutex.RUnlock()
+	return len(fake.eventsArgsForCall)
+}
+
+func (fake *FakeBuild) EventsCalls(stub func(uint) (db.EventSource, error)) {
+	fake.eventsMutex.Lock()
+	defer fake.eventsMutex.Unlock()
+	fake.EventsStub = stub
+}
+
+func (fake *FakeBuild) EventsArgsForCall(i int) uint {
+	fake.eventsMutex.RLock()
+	defer fake.eventsMutex.RUnlock()
 	argsForCall := fake.eventsArgsForCall[i]
 	return argsForCall.arg1
 }
@@ -1600,6 +2145,112 @@ func (fake *FakeBuild) EventsReturnsOnCall(i int, result1 db.EventSource, result
 	}{result1, result2}
 }
 
+func (fake *FakeBuild) ExternalRef() string {
+	fake.externalRefMutex.Lock()
+	ret, specificReturn := fake.externalRefReturnsOnCall[len(fake.externalRefArgsForCall)]
+	fake.externalRefArgsForCall = append(fake.externalRefArgsForCall, struct {
+	}{})
+	stub := fake.ExternalRefStub
+	fakeReturns := fake.externalRefReturns
+	fake.recordInvocation("ExternalRef", []interface{}{})
+	fake.externalRefMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) ExternalRefCallCount() int {
+	fake.externalRefMutex.RLock()
+	defer fake.externalRefMutex.RUnlock()
+	return len(fake.externalRefArgsForCall)
+}
+
+func (fake *FakeBuild) ExternalRefCalls(stub func() string) {
+	fake.externalRefMutex.Lock()
+	defer fake.externalRefMutex.Unlock()
+	fake.ExternalRefStub = stub
+}
+
+func (fake *FakeBuild) ExternalRefReturns(result1 string) {
+	fake.externalRefMutex.Lock()
+	defer fake.externalRefMutex.Unlock()
+	fake.ExternalRefStub = nil
+	fake.externalRefReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeBuild) ExternalRefReturnsOnCall(i int, result1 string) {
+	fake.externalRefMutex.Lock()
+	defer fake.externalRefMutex.Unlock()
+	fake.ExternalRefStub = nil
+	if fake.externalRefReturnsOnCall == nil {
+		fake.externalRefReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.externalRefReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeBuild) FailureReason() atc.FailureReason {
+	fake.failureReasonMutex.Lock()
+	ret, specificReturn := fake.failureReasonReturnsOnCall[len(fake.failureReasonArgsForCall)]
+	fake.failureReasonArgsForCall = append(fake.failureReasonArgsForCall, struct {
+	}{})
+	stub := fake.FailureReasonStub
+	fakeReturns := fake.failureReasonReturns
+	fake.recordInvocation("FailureReason", []interface{}{})
+	fake.failureReasonMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) FailureReasonCallCount() int {
+	fake.failureReasonMutex.RLock()
+	defer fake.failureReasonMutex.RUnlock()
+	return len(fake.failureReasonArgsForCall)
+}
+
+func (fake *FakeBuild) FailureReasonCalls(stub func() atc.FailureReason) {
+	fake.failureReasonMutex.Lock()
+	defer fake.failureReasonMutex.Unlock()
+	fake.FailureReasonStub = stub
+}
+
+func (fake *FakeBuild) FailureReasonReturns(result1 atc.FailureReason) {
+	fake.failureReasonMutex.Lock()
+	defer fake.failureReasonMutex.Unlock()
+	fake.FailureReasonStub = nil
+	fake.failureReasonReturns = struct {
+		result1 atc.FailureReason
+	}{result1}
+}
+
+func (fake *FakeBuild) FailureReasonReturnsOnCall(i int, result1 atc.FailureReason) {
+	fake.failureReasonMutex.Lock()
+	defer fake.failureReasonMutex.Unlock()
+	fake.FailureReasonStub = nil
+	if fake.failureReasonReturnsOnCall == nil {
+		fake.failureReasonReturnsOnCall = make(map[int]struct {
+			result1 atc.FailureReason
+		})
+	}
+	fake.failureReasonReturnsOnCall[i] = struct {
+		result1 atc.FailureReason
+	}{result1}
+}
+
 func (fake *FakeBuild) Finish(arg1 db.BuildStatus) error {
 	fake.finishMutex.Lock()
 	ret, specificReturn := fake.finishReturnsOnCall[len(fake.finishArgsForCall)]
@@ -1767,6 +2418,62 @@ func (fake *FakeBuild) IDReturnsOnCall(i int, result1 int) {
 	}{result1}
 }
 
+func (fake *FakeBuild) ImageResourceVersions() ([]db.BuildImageResourceVersion, error) {
+	fake.imageResourceVersionsMutex.Lock()
+	ret, specificReturn := fake.imageResourceVersionsReturnsOnCall[len(fake.imageResourceVersionsArgsForCall)]
+	fake.imageResourceVersionsArgsForCall = append(fake.imageResourceVersionsArgsForCall, struct {
+	}{})
+	stub := fake.ImageResourceVersionsStub
+	fakeReturns := fake.imageResourceVersionsReturns
+	fake.recordInvocation("ImageResourceVersions", []interface{}{})
+	fake.imageResourceVersionsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) ImageResourceVersionsCallCount() int {
+	fake.imageResourceVersionsMutex.RLock()
+	defer fake.imageResourceVersionsMutex.RUnlock()
+	return len(fake.imageResourceVersionsArgsForCall)
+}
+
+func (fake *FakeBuild) ImageResourceVersionsCalls(stub func() ([]db.BuildImageResourceVersion, error)) {
+	fake.imageResourceVersionsMutex.Lock()
+	defer fake.imageResourceVersionsMutex.Unlock()
+	fake.ImageResourceVersionsStub = stub
+}
+
+func (fake *FakeBuild) ImageResourceVersionsReturns(result1 []db.BuildImageResourceVersion, result2 error) {
+	fake.imageResourceVersionsMutex.Lock()
+	defer fake.imageResourceVersionsMutex.Unlock()
+	fake.ImageResourceVersionsStub = nil
+	fake.imageResourceVersionsReturns = struct {
+		result1 []db.BuildImageResourceVersion
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) ImageResourceVersionsReturnsOnCall(i int, result1 []db.BuildImageResourceVersion, result2 error) {
+	fake.imageResourceVersionsMutex.Lock()
+	defer fake.imageResourceVersionsMutex.Unlock()
+	fake.ImageResourceVersionsStub = nil
+	if fake.imageResourceVersionsReturnsOnCall == nil {
+		fake.imageResourceVersionsReturnsOnCall = make(map[int]struct {
+			result1 []db.BuildImageResourceVersion
+			result2 error
+		})
+	}
+	fake.imageResourceVersionsReturnsOnCall[i] = struct {
+		result1 []db.BuildImageResourceVersion
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuild) InputsReady() bool {
 	fake.inputsReadyMutex.Lock()
 	ret, specificReturn := fake.inputsReadyReturnsOnCall[len(fake.inputsReadyArgsForCall)]
@@ -2571,6 +3278,62 @@ func (fake *FakeBuild) OnCheckBuildStartReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeBuild) PinnedInputs() ([]atc.BuildInputPin, error) {
+	fake.pinnedInputsMutex.Lock()
+	ret, specificReturn := fake.pinnedInputsReturnsOnCall[len(fake.pinnedInputsArgsForCall)]
+	fake.pinnedInputsArgsForCall = append(fake.pinnedInputsArgsForCall, struct {
+	}{})
+	stub := fake.PinnedInputsStub
+	fakeReturns := fake.pinnedInputsReturns
+	fake.recordInvocation("PinnedInputs", []interface{}{})
+	fake.pinnedInputsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) PinnedInputsCallCount() int {
+	fake.pinnedInputsMutex.RLock()
+	defer fake.pinnedInputsMutex.RUnlock()
+	return len(fake.pinnedInputsArgsForCall)
+}
+
+func (fake *FakeBuild) PinnedInputsCalls(stub func() ([]atc.BuildInputPin, error)) {
+	fake.pinnedInputsMutex.Lock()
+	defer fake.pinnedInputsMutex.Unlock()
+	fake.PinnedInputsStub = stub
+}
+
+func (fake *FakeBuild) PinnedInputsReturns(result1 []atc.BuildInputPin, result2 error) {
+	fake.pinnedInputsMutex.Lock()
+	defer fake.pinnedInputsMutex.Unlock()
+	fake.PinnedInputsStub = nil
+	fake.pinnedInputsReturns = struct {
+		result1 []atc.BuildInputPin
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) PinnedInputsReturnsOnCall(i int, result1 []atc.BuildInputPin, result2 error) {
+	fake.pinnedInputsMutex.Lock()
+	defer fake.pinnedInputsMutex.Unlock()
+	fake.PinnedInputsStub = nil
+	if fake.pinnedInputsReturnsOnCall == nil {
+		fake.pinnedInputsReturnsOnCall = make(map[int]struct {
+			result1 []atc.BuildInputPin
+			result2 error
+		})
+	}
+	fake.pinnedInputsReturnsOnCall[i] = struct {
+		result1 []atc.BuildInputPin
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuild) Pipeline() (db.Pipeline, bool, error) {
 	fake.pipelineMutex.Lock()
 	ret, specificReturn := fake.pipelineReturnsOnCall[len(fake.pipelineArgsForCall)]
@@ -2954,6 +3717,65 @@ func (fake *FakeBuild) PrivatePlanReturnsOnCall(i int, result1 atc.Plan) {
 	}{result1}
 }
 
+func (fake *FakeBuild) Provenance() (json.RawMessage, bool, error) {
+	fake.provenanceMutex.Lock()
+	ret, specificReturn := fake.provenanceReturnsOnCall[len(fake.provenanceArgsForCall)]
+	fake.provenanceArgsForCall = append(fake.provenanceArgsForCall, struct {
+	}{})
+	stub := fake.ProvenanceStub
+	fakeReturns := fake.provenanceReturns
+	fake.recordInvocation("Provenance", []interface{}{})
+	fake.provenanceMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeBuild) ProvenanceCallCount() int {
+	fake.provenanceMutex.RLock()
+	defer fake.provenanceMutex.RUnlock()
+	return len(fake.provenanceArgsForCall)
+}
+
+func (fake *FakeBuild) ProvenanceCalls(stub func() (json.RawMessage, bool, error)) {
+	fake.provenanceMutex.Lock()
+	defer fake.provenanceMutex.Unlock()
+	fake.ProvenanceStub = stub
+}
+
+func (fake *FakeBuild) ProvenanceReturns(result1 json.RawMessage, result2 bool, result3 error) {
+	fake.provenanceMutex.Lock()
+	defer fake.provenanceMutex.Unlock()
+	fake.ProvenanceStub = nil
+	fake.provenanceReturns = struct {
+		result1 json.RawMessage
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeBuild) ProvenanceReturnsOnCall(i int, result1 json.RawMessage, result2 bool, result3 error) {
+	fake.provenanceMutex.Lock()
+	defer fake.provenanceMutex.Unlock()
+	fake.ProvenanceStub = nil
+	if fake.provenanceReturnsOnCall == nil {
+		fake.provenanceReturnsOnCall = make(map[int]struct {
+			result1 json.RawMessage
+			result2 bool
+			result3 error
+		})
+	}
+	fake.provenanceReturnsOnCall[i] = struct {
+		result1 json.RawMessage
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeBuild) PublicPlan() *json.RawMessage {
 	fake.publicPlanMutex.Lock()
 	ret, specificReturn := fake.publicPlanReturnsOnCall[len(fake.publicPlanArgsForCall)]
@@ -3602,6 +4424,59 @@ func (fake *FakeBuild) ResourcesCheckedReturnsOnCall(i int, result1 bool, result
 	}{result1, result2}
 }
 
+func (fake *FakeBuild) RetryOf() int {
+	fake.retryOfMutex.Lock()
+	ret, specificReturn := fake.retryOfReturnsOnCall[len(fake.retryOfArgsForCall)]
+	fake.retryOfArgsForCall = append(fake.retryOfArgsForCall, struct {
+	}{})
+	stub := fake.RetryOfStub
+	fakeReturns := fake.retryOfReturns
+	fake.recordInvocation("RetryOf", []interface{}{})
+	fake.retryOfMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) RetryOfCallCount() int {
+	fake.retryOfMutex.RLock()
+	defer fake.retryOfMutex.RUnlock()
+	return len(fake.retryOfArgsForCall)
+}
+
+func (fake *FakeBuild) RetryOfCalls(stub func() int) {
+	fake.retryOfMutex.Lock()
+	defer fake.retryOfMutex.Unlock()
+	fake.RetryOfStub = stub
+}
+
+func (fake *FakeBuild) RetryOfReturns(result1 int) {
+	fake.retryOfMutex.Lock()
+	defer fake.retryOfMutex.Unlock()
+	fake.RetryOfStub = nil
+	fake.retryOfReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeBuild) RetryOfReturnsOnCall(i int, result1 int) {
+	fake.retryOfMutex.Lock()
+	defer fake.retryOfMutex.Unlock()
+	fake.RetryOfStub = nil
+	if fake.retryOfReturnsOnCall == nil {
+		fake.retryOfReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.retryOfReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
 func (fake *FakeBuild) RunStateID() string {
 	fake.runStateIDMutex.Lock()
 	ret, specificReturn := fake.runStateIDReturnsOnCall[len(fake.runStateIDArgsForCall)]
@@ -3716,6 +4591,72 @@ func (fake *FakeBuild) SaveEventReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeBuild) SaveEvents(arg1 []atc.Event) error {
+	var arg1Copy []atc.Event
+	if arg1 != nil {
+		arg1Copy = make([]atc.Event, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.saveEventsMutex.Lock()
+	ret, specificReturn := fake.saveEventsReturnsOnCall[len(fake.saveEventsArgsForCall)]
+	fake.saveEventsArgsForCall = append(fake.saveEventsArgsForCall, struct {
+		arg1 []atc.Event
+	}{arg1Copy})
+	stub := fake.SaveEventsStub
+	fakeReturns := fake.saveEventsReturns
+	fake.recordInvocation("SaveEvents", []interface{}{arg1Copy})
+	fake.saveEventsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) SaveEventsCallCount() int {
+	fake.saveEventsMutex.RLock()
+	defer fake.saveEventsMutex.RUnlock()
+	return len(fake.saveEventsArgsForCall)
+}
+
+func (fake *FakeBuild) SaveEventsCalls(stub func([]atc.Event) error) {
+	fake.saveEventsMutex.Lock()
+	defer fake.saveEventsMutex.Unlock()
+	fake.SaveEventsStub = stub
+}
+
+func (fake *FakeBuild) SaveEventsArgsForCall(i int) []atc.Event {
+	fake.saveEventsMutex.RLock()
+	defer fake.saveEventsMutex.RUnlock()
+	argsForCall := fake.saveEventsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuild) SaveEventsReturns(result1 error) {
+	fake.saveEventsMutex.Lock()
+	defer fake.saveEventsMutex.Unlock()
+	fake.SaveEventsStub = nil
+	fake.saveEventsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) SaveEventsReturnsOnCall(i int, result1 error) {
+	fake.saveEventsMutex.Lock()
+	defer fake.saveEventsMutex.Unlock()
+	fake.SaveEventsStub = nil
+	if fake.saveEventsReturnsOnCall == nil {
+		fake.saveEventsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveEventsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeBuild) SaveImageResourceVersion(arg1 db.ResourceCache) error {
 	fake.saveImageResourceVersionMutex.Lock()
 	ret, specificReturn := fake.saveImageResourceVersionReturnsOnCall[len(fake.saveImageResourceVersionArgsForCall)]
@@ -3915,6 +4856,185 @@ func (fake *FakeBuild) SavePipelineReturnsOnCall(i int, result1 db.Pipeline, res
 	}{result1, result2, result3}
 }
 
+func (fake *FakeBuild) SaveProvenance(arg1 json.RawMessage) error {
+	fake.saveProvenanceMutex.Lock()
+	ret, specificReturn := fake.saveProvenanceReturnsOnCall[len(fake.saveProvenanceArgsForCall)]
+	fake.saveProvenanceArgsForCall = append(fake.saveProvenanceArgsForCall, struct {
+		arg1 json.RawMessage
+	}{arg1})
+	stub := fake.SaveProvenanceStub
+	fakeReturns := fake.saveProvenanceReturns
+	fake.recordInvocation("SaveProvenance", []interface{}{arg1})
+	fake.saveProvenanceMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) SaveProvenanceCallCount() int {
+	fake.saveProvenanceMutex.RLock()
+	defer fake.saveProvenanceMutex.RUnlock()
+	return len(fake.saveProvenanceArgsForCall)
+}
+
+func (fake *FakeBuild) SaveProvenanceCalls(stub func(json.RawMessage) error) {
+	fake.saveProvenanceMutex.Lock()
+	defer fake.saveProvenanceMutex.Unlock()
+	fake.SaveProvenanceStub = stub
+}
+
+func (fake *FakeBuild) SaveProvenanceArgsForCall(i int) json.RawMessage {
+	fake.saveProvenanceMutex.RLock()
+	defer fake.saveProvenanceMutex.RUnlock()
+	argsForCall := fake.saveProvenanceArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuild) SaveProvenanceReturns(result1 error) {
+	fake.saveProvenanceMutex.Lock()
+	defer fake.saveProvenanceMutex.Unlock()
+	fake.SaveProvenanceStub = nil
+	fake.saveProvenanceReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) SaveProvenanceReturnsOnCall(i int, result1 error) {
+	fake.saveProvenanceMutex.Lock()
+	defer fake.saveProvenanceMutex.Unlock()
+	fake.SaveProvenanceStub = nil
+	if fake.saveProvenanceReturnsOnCall == nil {
+		fake.saveProvenanceReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveProvenanceReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) SaveStepDuration(arg1 string, arg2 time.Duration) error {
+	fake.saveStepDurationMutex.Lock()
+	ret, specificReturn := fake.saveStepDurationReturnsOnCall[len(fake.saveStepDurationArgsForCall)]
+	fake.saveStepDurationArgsForCall = append(fake.saveStepDurationArgsForCall, struct {
+		arg1 string
+		arg2 time.Duration
+	}{arg1, arg2})
+	stub := fake.SaveStepDurationStub
+	fakeReturns := fake.saveStepDurationReturns
+	fake.recordInvocation("SaveStepDuration", []interface{}{arg1, arg2})
+	fake.saveStepDurationMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) SaveStepDurationCallCount() int {
+	fake.saveStepDurationMutex.RLock()
+	defer fake.saveStepDurationMutex.RUnlock()
+	return len(fake.saveStepDurationArgsForCall)
+}
+
+func (fake *FakeBuild) SaveStepDurationCalls(stub func(string, time.Duration) error) {
+	fake.saveStepDurationMutex.Lock()
+	defer fake.saveStepDurationMutex.Unlock()
+	fake.SaveStepDurationStub = stub
+}
+
+func (fake *FakeBuild) SaveStepDurationArgsForCall(i int) (string, time.Duration) {
+	fake.saveStepDurationMutex.RLock()
+	defer fake.saveStepDurationMutex.RUnlock()
+	argsForCall := fake.saveStepDurationArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeBuild) SaveStepDurationReturns(result1 error) {
+	fake.saveStepDurationMutex.Lock()
+	defer fake.saveStepDurationMutex.Unlock()
+	fake.SaveStepDurationStub = nil
+	fake.saveStepDurationReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) SaveStepDurationReturnsOnCall(i int, result1 error) {
+	fake.saveStepDurationMutex.Lock()
+	defer fake.saveStepDurationMutex.Unlock()
+	fake.SaveStepDurationStub = nil
+	if fake.saveStepDurationReturnsOnCall == nil {
+		fake.saveStepDurationReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveStepDurationReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) SavedEvents() ([]event.Envelope, error) {
+	fake.savedEventsMutex.Lock()
+	ret, specificReturn := fake.savedEventsReturnsOnCall[len(fake.savedEventsArgsForCall)]
+	fake.savedEventsArgsForCall = append(fake.savedEventsArgsForCall, struct {
+	}{})
+	stub := fake.SavedEventsStub
+	fakeReturns := fake.savedEventsReturns
+	fake.recordInvocation("SavedEvents", []interface{}{})
+	fake.savedEventsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) SavedEventsCallCount() int {
+	fake.savedEventsMutex.RLock()
+	defer fake.savedEventsMutex.RUnlock()
+	return len(fake.savedEventsArgsForCall)
+}
+
+func (fake *FakeBuild) SavedEventsCalls(stub func() ([]event.Envelope, error)) {
+	fake.savedEventsMutex.Lock()
+	defer fake.savedEventsMutex.Unlock()
+	fake.SavedEventsStub = stub
+}
+
+func (fake *FakeBuild) SavedEventsReturns(result1 []event.Envelope, result2 error) {
+	fake.savedEventsMutex.Lock()
+	defer fake.savedEventsMutex.Unlock()
+	fake.SavedEventsStub = nil
+	fake.savedEventsReturns = struct {
+		result1 []event.Envelope
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) SavedEventsReturnsOnCall(i int, result1 []event.Envelope, result2 error) {
+	fake.savedEventsMutex.Lock()
+	defer fake.savedEventsMutex.Unlock()
+	fake.SavedEventsStub = nil
+	if fake.savedEventsReturnsOnCall == nil {
+		fake.savedEventsReturnsOnCall = make(map[int]struct {
+			result1 []event.Envelope
+			result2 error
+		})
+	}
+	fake.savedEventsReturnsOnCall[i] = struct {
+		result1 []event.Envelope
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuild) Schema() string {
 	fake.schemaMutex.Lock()
 	ret, specificReturn := fake.schemaReturnsOnCall[len(fake.schemaArgsForCall)]
@@ -4090,6 +5210,67 @@ func (fake *FakeBuild) SetDrainedReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeBuild) SetFailureReason(arg1 atc.FailureReason) error {
+	fake.setFailureReasonMutex.Lock()
+	ret, specificReturn := fake.setFailureReasonReturnsOnCall[len(fake.setFailureReasonArgsForCall)]
+	fake.setFailureReasonArgsForCall = append(fake.setFailureReasonArgsForCall, struct {
+		arg1 atc.FailureReason
+	}{arg1})
+	stub := fake.SetFailureReasonStub
+	fakeReturns := fake.setFailureReasonReturns
+	fake.recordInvocation("SetFailureReason", []interface{}{arg1})
+	fake.setFailureReasonMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) SetFailureReasonCallCount() int {
+	fake.setFailureReasonMutex.RLock()
+	defer fake.setFailureReasonMutex.RUnlock()
+	return len(fake.setFailureReasonArgsForCall)
+}
+
+func (fake *FakeBuild) SetFailureReasonCalls(stub func(atc.FailureReason) error) {
+	fake.setFailureReasonMutex.Lock()
+	defer fake.setFailureReasonMutex.Unlock()
+	fake.SetFailureReasonStub = stub
+}
+
+func (fake *FakeBuild) SetFailureReasonArgsForCall(i int) atc.FailureReason {
+	fake.setFailureReasonMutex.RLock()
+	defer fake.setFailureReasonMutex.RUnlock()
+	argsForCall := fake.setFailureReasonArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuild) SetFailureReasonReturns(result1 error) {
+	fake.setFailureReasonMutex.Lock()
+	defer fake.setFailureReasonMutex.Unlock()
+	fake.SetFailureReasonStub = nil
+	fake.setFailureReasonReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) SetFailureReasonReturnsOnCall(i int, result1 error) {
+	fake.setFailureReasonMutex.Lock()
+	defer fake.setFailureReasonMutex.Unlock()
+	fake.SetFailureReasonStub = nil
+	if fake.setFailureReasonReturnsOnCall == nil {
+		fake.setFailureReasonReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setFailureReasonReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeBuild) SetInterceptible(arg1 bool) error {
 	fake.setInterceptibleMutex.Lock()
 	ret, specificReturn := fake.setInterceptibleReturnsOnCall[len(fake.setInterceptibleArgsForCall)]
@@ -4151,6 +5332,70 @@ func (fake *FakeBuild) SetInterceptibleReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeBuild) SetStartLatency(arg1 atc.BuildStartLatency) (bool, error) {
+	fake.setStartLatencyMutex.Lock()
+	ret, specificReturn := fake.setStartLatencyReturnsOnCall[len(fake.setStartLatencyArgsForCall)]
+	fake.setStartLatencyArgsForCall = append(fake.setStartLatencyArgsForCall, struct {
+		arg1 atc.BuildStartLatency
+	}{arg1})
+	stub := fake.SetStartLatencyStub
+	fakeReturns := fake.setStartLatencyReturns
+	fake.recordInvocation("SetStartLatency", []interface{}{arg1})
+	fake.setStartLatencyMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) SetStartLatencyCallCount() int {
+	fake.setStartLatencyMutex.RLock()
+	defer fake.setStartLatencyMutex.RUnlock()
+	return len(fake.setStartLatencyArgsForCall)
+}
+
+func (fake *FakeBuild) SetStartLatencyCalls(stub func(atc.BuildStartLatency) (bool, error)) {
+	fake.setStartLatencyMutex.Lock()
+	defer fake.setStartLatencyMutex.Unlock()
+	fake.SetStartLatencyStub = stub
+}
+
+func (fake *FakeBuild) SetStartLatencyArgsForCall(i int) atc.BuildStartLatency {
+	fake.setStartLatencyMutex.RLock()
+	defer fake.setStartLatencyMutex.RUnlock()
+	argsForCall := fake.setStartLatencyArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuild) SetStartLatencyReturns(result1 bool, result2 error) {
+	fake.setStartLatencyMutex.Lock()
+	defer fake.setStartLatencyMutex.Unlock()
+	fake.SetStartLatencyStub = nil
+	fake.setStartLatencyReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) SetStartLatencyReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.setStartLatencyMutex.Lock()
+	defer fake.setStartLatencyMutex.Unlock()
+	fake.SetStartLatencyStub = nil
+	if fake.setStartLatencyReturnsOnCall == nil {
+		fake.setStartLatencyReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.setStartLatencyReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuild) SpanContext() propagation.TextMapCarrier {
 	fake.spanContextMutex.Lock()
 	ret, specificReturn := fake.spanContextReturnsOnCall[len(fake.spanContextArgsForCall)]
@@ -4268,6 +5513,59 @@ func (fake *FakeBuild) StartReturnsOnCall(i int, result1 bool, result2 error) {
 	}{result1, result2}
 }
 
+func (fake *FakeBuild) StartLatency() *atc.BuildStartLatency {
+	fake.startLatencyMutex.Lock()
+	ret, specificReturn := fake.startLatencyReturnsOnCall[len(fake.startLatencyArgsForCall)]
+	fake.startLatencyArgsForCall = append(fake.startLatencyArgsForCall, struct {
+	}{})
+	stub := fake.StartLatencyStub
+	fakeReturns := fake.startLatencyReturns
+	fake.recordInvocation("StartLatency", []interface{}{})
+	fake.startLatencyMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) StartLatencyCallCount() int {
+	fake.startLatencyMutex.RLock()
+	defer fake.startLatencyMutex.RUnlock()
+	return len(fake.startLatencyArgsForCall)
+}
+
+func (fake *FakeBuild) StartLatencyCalls(stub func() *atc.BuildStartLatency) {
+	fake.startLatencyMutex.Lock()
+	defer fake.startLatencyMutex.Unlock()
+	fake.StartLatencyStub = stub
+}
+
+func (fake *FakeBuild) StartLatencyReturns(result1 *atc.BuildStartLatency) {
+	fake.startLatencyMutex.Lock()
+	defer fake.startLatencyMutex.Unlock()
+	fake.StartLatencyStub = nil
+	fake.startLatencyReturns = struct {
+		result1 *atc.BuildStartLatency
+	}{result1}
+}
+
+func (fake *FakeBuild) StartLatencyReturnsOnCall(i int, result1 *atc.BuildStartLatency) {
+	fake.startLatencyMutex.Lock()
+	defer fake.startLatencyMutex.Unlock()
+	fake.StartLatencyStub = nil
+	if fake.startLatencyReturnsOnCall == nil {
+		fake.startLatencyReturnsOnCall = make(map[int]struct {
+			result1 *atc.BuildStartLatency
+		})
+	}
+	fake.startLatencyReturnsOnCall[i] = struct {
+		result1 *atc.BuildStartLatency
+	}{result1}
+}
+
 func (fake *FakeBuild) StartTime() time.Time {
 	fake.startTimeMutex.Lock()
 	ret, specificReturn := fake.startTimeReturnsOnCall[len(fake.startTimeArgsForCall)]
@@ -4374,6 +5672,129 @@ func (fake *FakeBuild) StatusReturnsOnCall(i int, result1 db.BuildStatus) {
 	}{result1}
 }
 
+func (fake *FakeBuild) StepAbortNotifier() (db.Notifier, error) {
+	fake.stepAbortNotifierMutex.Lock()
+	ret, specificReturn := fake.stepAbortNotifierReturnsOnCall[len(fake.stepAbortNotifierArgsForCall)]
+	fake.stepAbortNotifierArgsForCall = append(fake.stepAbortNotifierArgsForCall, struct {
+	}{})
+	stub := fake.StepAbortNotifierStub
+	fakeReturns := fake.stepAbortNotifierReturns
+	fake.recordInvocation("StepAbortNotifier", []interface{}{})
+	fake.stepAbortNotifierMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) StepAbortNotifierCallCount() int {
+	fake.stepAbortNotifierMutex.RLock()
+	defer fake.stepAbortNotifierMutex.RUnlock()
+	return len(fake.stepAbortNotifierArgsForCall)
+}
+
+func (fake *FakeBuild) StepAbortNotifierCalls(stub func() (db.Notifier, error)) {
+	fake.stepAbortNotifierMutex.Lock()
+	defer fake.stepAbortNotifierMutex.Unlock()
+	fake.StepAbortNotifierStub = stub
+}
+
+func (fake *FakeBuild) StepAbortNotifierReturns(result1 db.Notifier, result2 error) {
+	fake.stepAbortNotifierMutex.Lock()
+	defer fake.stepAbortNotifierMutex.Unlock()
+	fake.StepAbortNotifierStub = nil
+	fake.stepAbortNotifierReturns = struct {
+		result1 db.Notifier
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) StepAbortNotifierReturnsOnCall(i int, result1 db.Notifier, result2 error) {
+	fake.stepAbortNotifierMutex.Lock()
+	defer fake.stepAbortNotifierMutex.Unlock()
+	fake.StepAbortNotifierStub = nil
+	if fake.stepAbortNotifierReturnsOnCall == nil {
+		fake.stepAbortNotifierReturnsOnCall = make(map[int]struct {
+			result1 db.Notifier
+			result2 error
+		})
+	}
+	fake.stepAbortNotifierReturnsOnCall[i] = struct {
+		result1 db.Notifier
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) StepDurationEstimate(arg1 string) (time.Duration, bool, error) {
+	fake.stepDurationEstimateMutex.Lock()
+	ret, specificReturn := fake.stepDurationEstimateReturnsOnCall[len(fake.stepDurationEstimateArgsForCall)]
+	fake.stepDurationEstimateArgsForCall = append(fake.stepDurationEstimateArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.StepDurationEstimateStub
+	fakeReturns := fake.stepDurationEstimateReturns
+	fake.recordInvocation("StepDurationEstimate", []interface{}{arg1})
+	fake.stepDurationEstimateMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeBuild) StepDurationEstimateCallCount() int {
+	fake.stepDurationEstimateMutex.RLock()
+	defer fake.stepDurationEstimateMutex.RUnlock()
+	return len(fake.stepDurationEstimateArgsForCall)
+}
+
+func (fake *FakeBuild) StepDurationEstimateCalls(stub func(string) (time.Duration, bool, error)) {
+	fake.stepDurationEstimateMutex.Lock()
+	defer fake.stepDurationEstimateMutex.Unlock()
+	fake.StepDurationEstimateStub = stub
+}
+
+func (fake *FakeBuild) StepDurationEstimateArgsForCall(i int) string {
+	fake.stepDurationEstimateMutex.RLock()
+	defer fake.stepDurationEstimateMutex.RUnlock()
+	argsForCall := fake.stepDurationEstimateArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuild) StepDurationEstimateReturns(result1 time.Duration, result2 bool, result3 error) {
+	fake.stepDurationEstimateMutex.Lock()
+	defer fake.stepDurationEstimateMutex.Unlock()
+	fake.StepDurationEstimateStub = nil
+	fake.stepDurationEstimateReturns = struct {
+		result1 time.Duration
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeBuild) StepDurationEstimateReturnsOnCall(i int, result1 time.Duration, result2 bool, result3 error) {
+	fake.stepDurationEstimateMutex.Lock()
+	defer fake.stepDurationEstimateMutex.Unlock()
+	fake.StepDurationEstimateStub = nil
+	if fake.stepDurationEstimateReturnsOnCall == nil {
+		fake.stepDurationEstimateReturnsOnCall = make(map[int]struct {
+			result1 time.Duration
+			result2 bool
+			result3 error
+		})
+	}
+	fake.stepDurationEstimateReturnsOnCall[i] = struct {
+		result1 time.Duration
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeBuild) SyslogTag(arg1 event.OriginID) string {
 	fake.syslogTagMutex.Lock()
 	ret, specificReturn := fake.syslogTagReturnsOnCall[len(fake.syslogTagArgsForCall)]
@@ -4660,9 +6081,134 @@ func (fake *FakeBuild) VariablesReturnsOnCall(i int, result1 vars.Variables, res
 	}{result1, result2}
 }
 
+func (fake *FakeBuild) Vars() []atc.BuildVar {
+	fake.varsMutex.Lock()
+	ret, specificReturn := fake.varsReturnsOnCall[len(fake.varsArgsForCall)]
+	fake.varsArgsForCall = append(fake.varsArgsForCall, struct {
+	}{})
+	stub := fake.VarsStub
+	fakeReturns := fake.varsReturns
+	fake.recordInvocation("Vars", []interface{}{})
+	fake.varsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) VarsCallCount() int {
+	fake.varsMutex.RLock()
+	defer fake.varsMutex.RUnlock()
+	return len(fake.varsArgsForCall)
+}
+
+func (fake *FakeBuild) VarsCalls(stub func() []atc.BuildVar) {
+	fake.varsMutex.Lock()
+	defer fake.varsMutex.Unlock()
+	fake.VarsStub = stub
+}
+
+func (fake *FakeBuild) VarsReturns(result1 []atc.BuildVar) {
+	fake.varsMutex.Lock()
+	defer fake.varsMutex.Unlock()
+	fake.VarsStub = nil
+	fake.varsReturns = struct {
+		result1 []atc.BuildVar
+	}{result1}
+}
+
+func (fake *FakeBuild) VarsReturnsOnCall(i int, result1 []atc.BuildVar) {
+	fake.varsMutex.Lock()
+	defer fake.varsMutex.Unlock()
+	fake.VarsStub = nil
+	if fake.varsReturnsOnCall == nil {
+		fake.varsReturnsOnCall = make(map[int]struct {
+			result1 []atc.BuildVar
+		})
+	}
+	fake.varsReturnsOnCall[i] = struct {
+		result1 []atc.BuildVar
+	}{result1}
+}
+
+func (fake *FakeBuild) WorkerNames() ([]string, error) {
+	fake.workerNamesMutex.Lock()
+	ret, specificReturn := fake.workerNamesReturnsOnCall[len(fake.workerNamesArgsForCall)]
+	fake.workerNamesArgsForCall = append(fake.workerNamesArgsForCall, struct {
+	}{})
+	stub := fake.WorkerNamesStub
+	fakeReturns := fake.workerNamesReturns
+	fake.recordInvocation("WorkerNames", []interface{}{})
+	fake.workerNamesMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) WorkerNamesCallCount() int {
+	fake.workerNamesMutex.RLock()
+	defer fake.workerNamesMutex.RUnlock()
+	return len(fake.workerNamesArgsForCall)
+}
+
+func (fake *FakeBuild) WorkerNamesCalls(stub func() ([]string, error)) {
+	fake.workerNamesMutex.Lock()
+	defer fake.workerNamesMutex.Unlock()
+	fake.WorkerNamesStub = stub
+}
+
+func (fake *FakeBuild) WorkerNamesReturns(result1 []string, result2 error) {
+	fake.workerNamesMutex.Lock()
+	defer fake.workerNamesMutex.Unlock()
+	fake.WorkerNamesStub = nil
+	fake.workerNamesReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) WorkerNamesReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.workerNamesMutex.Lock()
+	defer fake.workerNamesMutex.Unlock()
+	fake.WorkerNamesStub = nil
+	if fake.workerNamesReturnsOnCall == nil {
+		fake.workerNamesReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.workerNamesReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.externalRefMutex.RLock()
+	defer fake.externalRefMutex.RUnlock()
+	fake.abortStepMutex.RLock()
+	defer fake.abortStepMutex.RUnlock()
+	fake.abortedStepsMutex.RLock()
+	defer fake.abortedStepsMutex.RUnlock()
+	fake.collapseIntoMutex.RLock()
+	defer fake.collapseIntoMutex.RUnlock()
+	fake.collapsedIntoMutex.RLock()
+	defer fake.collapsedIntoMutex.RUnlock()
+	fake.estimatedDurationMutex.RLock()
+	defer fake.estimatedDurationMutex.RUnlock()
+	fake.failureReasonMutex.RLock()
+	defer fake.failureReasonMutex.RUnlock()
+	fake.imageResourceVersionsMutex.RLock()
+	defer fake.imageResourceVersionsMutex.RUnlock()
 	fake.abortNotifierMutex.RLock()
 	defer fake.abortNotifierMutex.RUnlock()
 	fake.acquireTrackingLockMutex.RLock()
@@ -4727,6 +6273,8 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.nameMutex.RUnlock()
 	fake.onCheckBuildStartMutex.RLock()
 	defer fake.onCheckBuildStartMutex.RUnlock()
+	fake.pinnedInputsMutex.RLock()
+	defer fake.pinnedInputsMutex.RUnlock()
 	fake.pipelineMutex.RLock()
 	defer fake.pipelineMutex.RUnlock()
 	fake.pipelineIDMutex.RLock()
@@ -4741,6 +6289,8 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.preparationMutex.RUnlock()
 	fake.privatePlanMutex.RLock()
 	defer fake.privatePlanMutex.RUnlock()
+	fake.provenanceMutex.RLock()
+	defer fake.provenanceMutex.RUnlock()
 	fake.publicPlanMutex.RLock()
 	defer fake.publicPlanMutex.RUnlock()
 	fake.reapTimeMutex.RLock()
@@ -4765,32 +6315,52 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.resourcesMutex.RUnlock()
 	fake.resourcesCheckedMutex.RLock()
 	defer fake.resourcesCheckedMutex.RUnlock()
+	fake.retryOfMutex.RLock()
+	defer fake.retryOfMutex.RUnlock()
 	fake.runStateIDMutex.RLock()
 	defer fake.runStateIDMutex.RUnlock()
 	fake.saveEventMutex.RLock()
 	defer fake.saveEventMutex.RUnlock()
+	fake.saveEventsMutex.RLock()
+	defer fake.saveEventsMutex.RUnlock()
 	fake.saveImageResourceVersionMutex.RLock()
 	defer fake.saveImageResourceVersionMutex.RUnlock()
 	fake.saveOutputMutex.RLock()
 	defer fake.saveOutputMutex.RUnlock()
 	fake.savePipelineMutex.RLock()
 	defer fake.savePipelineMutex.RUnlock()
+	fake.saveProvenanceMutex.RLock()
+	defer fake.saveProvenanceMutex.RUnlock()
+	fake.saveStepDurationMutex.RLock()
+	defer fake.saveStepDurationMutex.RUnlock()
+	fake.savedEventsMutex.RLock()
+	defer fake.savedEventsMutex.RUnlock()
 	fake.schemaMutex.RLock()
 	defer fake.schemaMutex.RUnlock()
 	fake.setCommentMutex.RLock()
 	defer fake.setCommentMutex.RUnlock()
 	fake.setDrainedMutex.RLock()
 	defer fake.setDrainedMutex.RUnlock()
+	fake.setFailureReasonMutex.RLock()
+	defer fake.setFailureReasonMutex.RUnlock()
 	fake.setInterceptibleMutex.RLock()
 	defer fake.setInterceptibleMutex.RUnlock()
+	fake.setStartLatencyMutex.RLock()
+	defer fake.setStartLatencyMutex.RUnlock()
 	fake.spanContextMutex.RLock()
 	defer fake.spanContextMutex.RUnlock()
 	fake.startMutex.RLock()
 	defer fake.startMutex.RUnlock()
+	fake.startLatencyMutex.RLock()
+	defer fake.startLatencyMutex.RUnlock()
 	fake.startTimeMutex.RLock()
 	defer fake.startTimeMutex.RUnlock()
 	fake.statusMutex.RLock()
 	defer fake.statusMutex.RUnlock()
+	fake.stepAbortNotifierMutex.RLock()
+	defer fake.stepAbortNotifierMutex.RUnlock()
+	fake.stepDurationEstimateMutex.RLock()
+	defer fake.stepDurationEstimateMutex.RUnlock()
 	fake.syslogTagMutex.RLock()
 	defer fake.syslogTagMutex.RUnlock()
 	fake.teamIDMutex.RLock()
@@ -4801,6 +6371,10 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.tracingAttrsMutex.RUnlock()
 	fake.variablesMutex.RLock()
 	defer fake.variablesMutex.RUnlock()
+	fake.varsMutex.RLock()
+	defer fake.varsMutex.RUnlock()
+	fake.workerNamesMutex.RLock()
+	defer fake.workerNamesMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
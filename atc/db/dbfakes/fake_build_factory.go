@@ -23,6 +23,19 @@ type FakeBuildFactory struct {
 		result2 db.Pagination
 		result3 error
 	}
+	AllBuildsWithExternalRefStub        func(string) ([]db.BuildForAPI, error)
+	allBuildsWithExternalRefMutex       sync.RWMutex
+	allBuildsWithExternalRefArgsForCall []struct {
+		arg1 string
+	}
+	allBuildsWithExternalRefReturns struct {
+		result1 []db.BuildForAPI
+		result2 error
+	}
+	allBuildsWithExternalRefReturnsOnCall map[int]struct {
+		result1 []db.BuildForAPI
+		result2 error
+	}
 	BuildStub        func(int) (db.Build, bool, error)
 	buildMutex       sync.RWMutex
 	buildArgsForCall []struct {
@@ -118,6 +131,20 @@ type FakeBuildFactory struct {
 		result2 db.Pagination
 		result3 error
 	}
+	VisibleBuildsWithExternalRefStub        func([]string, string) ([]db.BuildForAPI, error)
+	visibleBuildsWithExternalRefMutex       sync.RWMutex
+	visibleBuildsWithExternalRefArgsForCall []struct {
+		arg1 []string
+		arg2 string
+	}
+	visibleBuildsWithExternalRefReturns struct {
+		result1 []db.BuildForAPI
+		result2 error
+	}
+	visibleBuildsWithExternalRefReturnsOnCall map[int]struct {
+		result1 []db.BuildForAPI
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -189,6 +216,70 @@ func (fake *FakeBuildFactory) AllBuildsReturnsOnCall(i int, result1 []db.BuildFo
 	}{result1, result2, result3}
 }
 
+func (fake *FakeBuildFactory) AllBuildsWithExternalRef(arg1 string) ([]db.BuildForAPI, error) {
+	fake.allBuildsWithExternalRefMutex.Lock()
+	ret, specificReturn := fake.allBuildsWithExternalRefReturnsOnCall[len(fake.allBuildsWithExternalRefArgsForCall)]
+	fake.allBuildsWithExternalRefArgsForCall = append(fake.allBuildsWithExternalRefArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.AllBuildsWithExternalRefStub
+	fakeReturns := fake.allBuildsWithExternalRefReturns
+	fake.recordInvocation("AllBuildsWithExternalRef", []interface{}{arg1})
+	fake.allBuildsWithExternalRefMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuildFactory) AllBuildsWithExternalRefCallCount() int {
+	fake.allBuildsWithExternalRefMutex.RLock()
+	defer fake.allBuildsWithExternalRefMutex.RUnlock()
+	return len(fake.allBuildsWithExternalRefArgsForCall)
+}
+
+func (fake *FakeBuildFactory) AllBuildsWithExternalRefCalls(stub func(string) ([]db.BuildForAPI, error)) {
+	fake.allBuildsWithExternalRefMutex.Lock()
+	defer fake.allBuildsWithExternalRefMutex.Unlock()
+	fake.AllBuildsWithExternalRefStub = stub
+}
+
+func (fake *FakeBuildFactory) AllBuildsWithExternalRefArgsForCall(i int) string {
+	fake.allBuildsWithExternalRefMutex.RLock()
+	defer fake.allBuildsWithExternalRefMutex.RUnlock()
+	argsForCall := fake.allBuildsWithExternalRefArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuildFactory) AllBuildsWithExternalRefReturns(result1 []db.BuildForAPI, result2 error) {
+	fake.allBuildsWithExternalRefMutex.Lock()
+	defer fake.allBuildsWithExternalRefMutex.Unlock()
+	fake.AllBuildsWithExternalRefStub = nil
+	fake.allBuildsWithExternalRefReturns = struct {
+		result1 []db.BuildForAPI
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuildFactory) AllBuildsWithExternalRefReturnsOnCall(i int, result1 []db.BuildForAPI, result2 error) {
+	fake.allBuildsWithExternalRefMutex.Lock()
+	defer fake.allBuildsWithExternalRefMutex.Unlock()
+	fake.AllBuildsWithExternalRefStub = nil
+	if fake.allBuildsWithExternalRefReturnsOnCall == nil {
+		fake.allBuildsWithExternalRefReturnsOnCall = make(map[int]struct {
+			result1 []db.BuildForAPI
+			result2 error
+		})
+	}
+	fake.allBuildsWithExternalRefReturnsOnCall[i] = struct {
+		result1 []db.BuildForAPI
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuildFactory) Build(arg1 int) (db.Build, bool, error) {
 	fake.buildMutex.Lock()
 	ret, specificReturn := fake.buildReturnsOnCall[len(fake.buildArgsForCall)]
@@ -628,9 +719,81 @@ func (fake *FakeBuildFactory) VisibleBuildsReturnsOnCall(i int, result1 []db.Bui
 	}{result1, result2, result3}
 }
 
+func (fake *FakeBuildFactory) VisibleBuildsWithExternalRef(arg1 []string, arg2 string) ([]db.BuildForAPI, error) {
+	var arg1Copy []string
+	if arg1 != nil {
+		arg1Copy = make([]string, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.visibleBuildsWithExternalRefMutex.Lock()
+	ret, specificReturn := fake.visibleBuildsWithExternalRefReturnsOnCall[len(fake.visibleBuildsWithExternalRefArgsForCall)]
+	fake.visibleBuildsWithExternalRefArgsForCall = append(fake.visibleBuildsWithExternalRefArgsForCall, struct {
+		arg1 []string
+		arg2 string
+	}{arg1Copy, arg2})
+	stub := fake.VisibleBuildsWithExternalRefStub
+	fakeReturns := fake.visibleBuildsWithExternalRefReturns
+	fake.recordInvocation("VisibleBuildsWithExternalRef", []interface{}{arg1Copy, arg2})
+	fake.visibleBuildsWithExternalRefMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuildFactory) VisibleBuildsWithExternalRefCallCount() int {
+	fake.visibleBuildsWithExternalRefMutex.RLock()
+	defer fake.visibleBuildsWithExternalRefMutex.RUnlock()
+	return len(fake.visibleBuildsWithExternalRefArgsForCall)
+}
+
+func (fake *FakeBuildFactory) VisibleBuildsWithExternalRefCalls(stub func([]string, string) ([]db.BuildForAPI, error)) {
+	fake.visibleBuildsWithExternalRefMutex.Lock()
+	defer fake.visibleBuildsWithExternalRefMutex.Unlock()
+	fake.VisibleBuildsWithExternalRefStub = stub
+}
+
+func (fake *FakeBuildFactory) VisibleBuildsWithExternalRefArgsForCall(i int) ([]string, string) {
+	fake.visibleBuildsWithExternalRefMutex.RLock()
+	defer fake.visibleBuildsWithExternalRefMutex.RUnlock()
+	argsForCall := fake.visibleBuildsWithExternalRefArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeBuildFactory) VisibleBuildsWithExternalRefReturns(result1 []db.BuildForAPI, result2 error) {
+	fake.visibleBuildsWithExternalRefMutex.Lock()
+	defer fake.visibleBuildsWithExternalRefMutex.Unlock()
+	fake.VisibleBuildsWithExternalRefStub = nil
+	fake.visibleBuildsWithExternalRefReturns = struct {
+		result1 []db.BuildForAPI
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuildFactory) VisibleBuildsWithExternalRefReturnsOnCall(i int, result1 []db.BuildForAPI, result2 error) {
+	fake.visibleBuildsWithExternalRefMutex.Lock()
+	defer fake.visibleBuildsWithExternalRefMutex.Unlock()
+	fake.VisibleBuildsWithExternalRefStub = nil
+	if fake.visibleBuildsWithExternalRefReturnsOnCall == nil {
+		fake.visibleBuildsWithExternalRefReturnsOnCall = make(map[int]struct {
+			result1 []db.BuildForAPI
+			result2 error
+		})
+	}
+	fake.visibleBuildsWithExternalRefReturnsOnCall[i] = struct {
+		result1 []db.BuildForAPI
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuildFactory) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.allBuildsWithExternalRefMutex.RLock()
+	defer fake.allBuildsWithExternalRefMutex.RUnlock()
 	fake.allBuildsMutex.RLock()
 	defer fake.allBuildsMutex.RUnlock()
 	fake.buildMutex.RLock()
@@ -647,6 +810,8 @@ func (fake *FakeBuildFactory) Invocations() map[string][][]interface{} {
 	defer fake.publicBuildsMutex.RUnlock()
 	fake.visibleBuildsMutex.RLock()
 	defer fake.visibleBuildsMutex.RUnlock()
+	fake.visibleBuildsWithExternalRefMutex.RLock()
+	defer fake.visibleBuildsWithExternalRefMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
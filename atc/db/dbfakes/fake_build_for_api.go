@@ -9,9 +9,21 @@ import (
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/event"
 )
 
 type FakeBuildForAPI struct {
+	AbortStepStub        func(atc.PlanID) error
+	abortStepMutex       sync.RWMutex
+	abortStepArgsForCall []struct {
+		arg1 atc.PlanID
+	}
+	abortStepReturns struct {
+		result1 error
+	}
+	abortStepReturnsOnCall map[int]struct {
+		result1 error
+	}
 	AllAssociatedTeamNamesStub        func() []string
 	allAssociatedTeamNamesMutex       sync.RWMutex
 	allAssociatedTeamNamesArgsForCall []struct {
@@ -34,6 +46,16 @@ type FakeBuildForAPI struct {
 		result1 []db.WorkerArtifact
 		result2 error
 	}
+	CollapsedIntoStub        func() int
+	collapsedIntoMutex       sync.RWMutex
+	collapsedIntoArgsForCall []struct {
+	}
+	collapsedIntoReturns struct {
+		result1 int
+	}
+	collapsedIntoReturnsOnCall map[int]struct {
+		result1 int
+	}
 	CommentStub        func() string
 	commentMutex       sync.RWMutex
 	commentArgsForCall []struct {
@@ -64,6 +86,18 @@ type FakeBuildForAPI struct {
 	endTimeReturnsOnCall map[int]struct {
 		result1 time.Time
 	}
+	EstimatedDurationStub        func() (time.Duration, bool)
+	estimatedDurationMutex       sync.RWMutex
+	estimatedDurationArgsForCall []struct {
+	}
+	estimatedDurationReturns struct {
+		result1 time.Duration
+		result2 bool
+	}
+	estimatedDurationReturnsOnCall map[int]struct {
+		result1 time.Duration
+		result2 bool
+	}
 	EventsStub        func(uint) (db.EventSource, error)
 	eventsMutex       sync.RWMutex
 	eventsArgsForCall []struct {
@@ -77,6 +111,26 @@ type FakeBuildForAPI struct {
 		result1 db.EventSource
 		result2 error
 	}
+	ExternalRefStub        func() string
+	externalRefMutex       sync.RWMutex
+	externalRefArgsForCall []struct {
+	}
+	externalRefReturns struct {
+		result1 string
+	}
+	externalRefReturnsOnCall map[int]struct {
+		result1 string
+	}
+	FailureReasonStub        func() atc.FailureReason
+	failureReasonMutex       sync.RWMutex
+	failureReasonArgsForCall []struct {
+	}
+	failureReasonReturns struct {
+		result1 atc.FailureReason
+	}
+	failureReasonReturnsOnCall map[int]struct {
+		result1 atc.FailureReason
+	}
 	HasPlanStub        func() bool
 	hasPlanMutex       sync.RWMutex
 	hasPlanArgsForCall []struct {
@@ -249,6 +303,20 @@ type FakeBuildForAPI struct {
 		result2 bool
 		result3 error
 	}
+	ProvenanceStub        func() (json.RawMessage, bool, error)
+	provenanceMutex       sync.RWMutex
+	provenanceArgsForCall []struct {
+	}
+	provenanceReturns struct {
+		result1 json.RawMessage
+		result2 bool
+		result3 error
+	}
+	provenanceReturnsOnCall map[int]struct {
+		result1 json.RawMessage
+		result2 bool
+		result3 error
+	}
 	PublicPlanStub        func() *json.RawMessage
 	publicPlanMutex       sync.RWMutex
 	publicPlanArgsForCall []struct {
@@ -333,6 +401,28 @@ type FakeBuildForAPI struct {
 		result2 []db.BuildOutput
 		result3 error
 	}
+	RetryOfStub        func() int
+	retryOfMutex       sync.RWMutex
+	retryOfArgsForCall []struct {
+	}
+	retryOfReturns struct {
+		result1 int
+	}
+	retryOfReturnsOnCall map[int]struct {
+		result1 int
+	}
+	SavedEventsStub        func() ([]event.Envelope, error)
+	savedEventsMutex       sync.RWMutex
+	savedEventsArgsForCall []struct {
+	}
+	savedEventsReturns struct {
+		result1 []event.Envelope
+		result2 error
+	}
+	savedEventsReturnsOnCall map[int]struct {
+		result1 []event.Envelope
+		result2 error
+	}
 	SchemaStub        func() string
 	schemaMutex       sync.RWMutex
 	schemaArgsForCall []struct {
@@ -354,6 +444,16 @@ type FakeBuildForAPI struct {
 	setCommentReturnsOnCall map[int]struct {
 		result1 error
 	}
+	StartLatencyStub        func() *atc.BuildStartLatency
+	startLatencyMutex       sync.RWMutex
+	startLatencyArgsForCall []struct {
+	}
+	startLatencyReturns struct {
+		result1 *atc.BuildStartLatency
+	}
+	startLatencyReturnsOnCall map[int]struct {
+		result1 *atc.BuildStartLatency
+	}
 	StartTimeStub        func() time.Time
 	startTimeMutex       sync.RWMutex
 	startTimeArgsForCall []struct {
@@ -394,10 +494,81 @@ type FakeBuildForAPI struct {
 	teamNameReturnsOnCall map[int]struct {
 		result1 string
 	}
+	VarsStub        func() []atc.BuildVar
+	varsMutex       sync.RWMutex
+	varsArgsForCall []struct {
+	}
+	varsReturns struct {
+		result1 []atc.BuildVar
+	}
+	varsReturnsOnCall map[int]struct {
+		result1 []atc.BuildVar
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeBuildForAPI) AbortStep(arg1 atc.PlanID) error {
+	fake.abortStepMutex.Lock()
+	ret, specificReturn := fake.abortStepReturnsOnCall[len(fake.abortStepArgsForCall)]
+	fake.abortStepArgsForCall = append(fake.abortStepArgsForCall, struct {
+		arg1 atc.PlanID
+	}{arg1})
+	stub := fake.AbortStepStub
+	fakeReturns := fake.abortStepReturns
+	fake.recordInvocation("AbortStep", []interface{}{arg1})
+	fake.abortStepMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuildForAPI) AbortStepCallCount() int {
+	fake.abortStepMutex.RLock()
+	defer fake.abortStepMutex.RUnlock()
+	return len(fake.abortStepArgsForCall)
+}
+
+func (fake *FakeBuildForAPI) AbortStepCalls(stub func(atc.PlanID) error) {
+	fake.abortStepMutex.Lock()
+	defer fake.abortStepMutex.Unlock()
+	fake.AbortStepStub = stub
+}
+
+func (fake *FakeBuildForAPI) AbortStepArgsForCall(i int) atc.PlanID {
+	fake.abortStepMutex.RLock()
+	defer fake.abortStepMutex.RUnlock()
+	argsForCall := fake.abortStepArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuildForAPI) AbortStepReturns(result1 error) {
+	fake.abortStepMutex.Lock()
+	defer fake.abortStepMutex.Unlock()
+	fake.AbortStepStub = nil
+	fake.abortStepReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuildForAPI) AbortStepReturnsOnCall(i int, result1 error) {
+	fake.abortStepMutex.Lock()
+	defer fake.abortStepMutex.Unlock()
+	fake.AbortStepStub = nil
+	if fake.abortStepReturnsOnCall == nil {
+		fake.abortStepReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.abortStepReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeBuildForAPI) AllAssociatedTeamNames() []string {
 	fake.allAssociatedTeamNamesMutex.Lock()
 	ret, specificReturn := fake.allAssociatedTeamNamesReturnsOnCall[len(fake.allAssociatedTeamNamesArgsForCall)]
@@ -507,6 +678,59 @@ func (fake *FakeBuildForAPI) ArtifactsReturnsOnCall(i int, result1 []db.WorkerAr
 	}{result1, result2}
 }
 
+func (fake *FakeBuildForAPI) CollapsedInto() int {
+	fake.collapsedIntoMutex.Lock()
+	ret, specificReturn := fake.collapsedIntoReturnsOnCall[len(fake.collapsedIntoArgsForCall)]
+	fake.collapsedIntoArgsForCall = append(fake.collapsedIntoArgsForCall, struct {
+	}{})
+	stub := fake.CollapsedIntoStub
+	fakeReturns := fake.collapsedIntoReturns
+	fake.recordInvocation("CollapsedInto", []interface{}{})
+	fake.collapsedIntoMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuildForAPI) CollapsedIntoCallCount() int {
+	fake.collapsedIntoMutex.RLock()
+	defer fake.collapsedIntoMutex.RUnlock()
+	return len(fake.collapsedIntoArgsForCall)
+}
+
+func (fake *FakeBuildForAPI) CollapsedIntoCalls(stub func() int) {
+	fake.collapsedIntoMutex.Lock()
+	defer fake.collapsedIntoMutex.Unlock()
+	fake.CollapsedIntoStub = stub
+}
+
+func (fake *FakeBuildForAPI) CollapsedIntoReturns(result1 int) {
+	fake.collapsedIntoMutex.Lock()
+	defer fake.collapsedIntoMutex.Unlock()
+	fake.CollapsedIntoStub = nil
+	fake.collapsedIntoReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeBuildForAPI) CollapsedIntoReturnsOnCall(i int, result1 int) {
+	fake.collapsedIntoMutex.Lock()
+	defer fake.collapsedIntoMutex.Unlock()
+	fake.CollapsedIntoStub = nil
+	if fake.collapsedIntoReturnsOnCall == nil {
+		fake.collapsedIntoReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.collapsedIntoReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
 func (fake *FakeBuildForAPI) Comment() string {
 	fake.commentMutex.Lock()
 	ret, specificReturn := fake.commentReturnsOnCall[len(fake.commentArgsForCall)]
@@ -666,6 +890,62 @@ func (fake *FakeBuildForAPI) EndTimeReturnsOnCall(i int, result1 time.Time) {
 	}{result1}
 }
 
+func (fake *FakeBuildForAPI) EstimatedDuration() (time.Duration, bool) {
+	fake.estimatedDurationMutex.Lock()
+	ret, specificReturn := fake.estimatedDurationReturnsOnCall[len(fake.estimatedDurationArgsForCall)]
+	fake.estimatedDurationArgsForCall = append(fake.estimatedDurationArgsForCall, struct {
+	}{})
+	stub := fake.EstimatedDurationStub
+	fakeReturns := fake.estimatedDurationReturns
+	fake.recordInvocation("EstimatedDuration", []interface{}{})
+	fake.estimatedDurationMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuildForAPI) EstimatedDurationCallCount() int {
+	fake.estimatedDurationMutex.RLock()
+	defer fake.estimatedDurationMutex.RUnlock()
+	return len(fake.estimatedDurationArgsForCall)
+}
+
+func (fake *FakeBuildForAPI) EstimatedDurationCalls(stub func() (time.Duration, bool)) {
+	fake.estimatedDurationMutex.Lock()
+	defer fake.estimatedDurationMutex.Unlock()
+	fake.EstimatedDurationStub = stub
+}
+
+func (fake *FakeBuildForAPI) EstimatedDurationReturns(result1 time.Duration, result2 bool) {
+	fake.estimatedDurationMutex.Lock()
+	defer fake.estimatedDurationMutex.Unlock()
+	fake.EstimatedDurationStub = nil
+	fake.estimatedDurationReturns = struct {
+		result1 time.Duration
+		result2 bool
+	}{result1, result2}
+}
+
+func (fake *FakeBuildForAPI) EstimatedDurationReturnsOnCall(i int, result1 time.Duration, result2 bool) {
+	fake.estimatedDurationMutex.Lock()
+	defer fake.estimatedDurationMutex.Unlock()
+	fake.EstimatedDurationStub = nil
+	if fake.estimatedDurationReturnsOnCall == nil {
+		fake.estimatedDurationReturnsOnCall = make(map[int]struct {
+			result1 time.Duration
+			result2 bool
+		})
+	}
+	fake.estimatedDurationReturnsOnCall[i] = struct {
+		result1 time.Duration
+		result2 bool
+	}{result1, result2}
+}
+
 func (fake *FakeBuildForAPI) Events(arg1 uint) (db.EventSource, error) {
 	fake.eventsMutex.Lock()
 	ret, specificReturn := fake.eventsReturnsOnCall[len(fake.eventsArgsForCall)]
@@ -730,6 +1010,112 @@ func (fake *FakeBuildForAPI) EventsReturnsOnCall(i int, result1 db.EventSource,
 	}{result1, result2}
 }
 
+func (fake *FakeBuildForAPI) ExternalRef() string {
+	fake.externalRefMutex.Lock()
+	ret, specificReturn := fake.externalRefReturnsOnCall[len(fake.externalRefArgsForCall)]
+	fake.externalRefArgsForCall = append(fake.externalRefArgsForCall, struct {
+	}{})
+	stub := fake.ExternalRefStub
+	fakeReturns := fake.externalRefReturns
+	fake.recordInvocation("ExternalRef", []interface{}{})
+	fake.externalRefMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuildForAPI) ExternalRefCallCount() int {
+	fake.externalRefMutex.RLock()
+	defer fake.externalRefMutex.RUnlock()
+	return len(fake.externalRefArgsForCall)
+}
+
+func (fake *FakeBuildForAPI) ExternalRefCalls(stub func() string) {
+	fake.externalRefMutex.Lock()
+	defer fake.externalRefMutex.Unlock()
+	fake.ExternalRefStub = stub
+}
+
+func (fake *FakeBuildForAPI) ExternalRefReturns(result1 string) {
+	fake.externalRefMutex.Lock()
+	defer fake.externalRefMutex.Unlock()
+	fake.ExternalRefStub = nil
+	fake.externalRefReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeBuildForAPI) ExternalRefReturnsOnCall(i int, result1 string) {
+	fake.externalRefMutex.Lock()
+	defer fake.externalRefMutex.Unlock()
+	fake.ExternalRefStub = nil
+	if fake.externalRefReturnsOnCall == nil {
+		fake.externalRefReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.externalRefReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeBuildForAPI) FailureReason() atc.FailureReason {
+	fake.failureReasonMutex.Lock()
+	ret, specificReturn := fake.failureReasonReturnsOnCall[len(fake.failureReasonArgsForCall)]
+	fake.failureReasonArgsForCall = append(fake.failureReasonArgsForCall, struct {
+	}{})
+	stub := fake.FailureReasonStub
+	fakeReturns := fake.failureReasonReturns
+	fake.recordInvocation("FailureReason", []interface{}{})
+	fake.failureReasonMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuildForAPI) FailureReasonCallCount() int {
+	fake.failureReasonMutex.RLock()
+	defer fake.failureReasonMutex.RUnlock()
+	return len(fake.failureReasonArgsForCall)
+}
+
+func (fake *FakeBuildForAPI) FailureReasonCalls(stub func() atc.FailureReason) {
+	fake.failureReasonMutex.Lock()
+	defer fake.failureReasonMutex.Unlock()
+	fake.FailureReasonStub = stub
+}
+
+func (fake *FakeBuildForAPI) FailureReasonReturns(result1 atc.FailureReason) {
+	fake.failureReasonMutex.Lock()
+	defer fake.failureReasonMutex.Unlock()
+	fake.FailureReasonStub = nil
+	fake.failureReasonReturns = struct {
+		result1 atc.FailureReason
+	}{result1}
+}
+
+func (fake *FakeBuildForAPI) FailureReasonReturnsOnCall(i int, result1 atc.FailureReason) {
+	fake.failureReasonMutex.Lock()
+	defer fake.failureReasonMutex.Unlock()
+	fake.FailureReasonStub = nil
+	if fake.failureReasonReturnsOnCall == nil {
+		fake.failureReasonReturnsOnCall = make(map[int]struct {
+			result1 atc.FailureReason
+		})
+	}
+	fake.failureReasonReturnsOnCall[i] = struct {
+		result1 atc.FailureReason
+	}{result1}
+}
+
 func (fake *FakeBuildForAPI) HasPlan() bool {
 	fake.hasPlanMutex.Lock()
 	ret, specificReturn := fake.hasPlanReturnsOnCall[len(fake.hasPlanArgsForCall)]
@@ -1596,6 +1982,65 @@ func (fake *FakeBuildForAPI) PreparationReturnsOnCall(i int, result1 db.BuildPre
 	}{result1, result2, result3}
 }
 
+func (fake *FakeBuildForAPI) Provenance() (json.RawMessage, bool, error) {
+	fake.provenanceMutex.Lock()
+	ret, specificReturn := fake.provenanceReturnsOnCall[len(fake.provenanceArgsForCall)]
+	fake.provenanceArgsForCall = append(fake.provenanceArgsForCall, struct {
+	}{})
+	stub := fake.ProvenanceStub
+	fakeReturns := fake.provenanceReturns
+	fake.recordInvocation("Provenance", []interface{}{})
+	fake.provenanceMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeBuildForAPI) ProvenanceCallCount() int {
+	fake.provenanceMutex.RLock()
+	defer fake.provenanceMutex.RUnlock()
+	return len(fake.provenanceArgsForCall)
+}
+
+func (fake *FakeBuildForAPI) ProvenanceCalls(stub func() (json.RawMessage, bool, error)) {
+	fake.provenanceMutex.Lock()
+	defer fake.provenanceMutex.Unlock()
+	fake.ProvenanceStub = stub
+}
+
+func (fake *FakeBuildForAPI) ProvenanceReturns(result1 json.RawMessage, result2 bool, result3 error) {
+	fake.provenanceMutex.Lock()
+	defer fake.provenanceMutex.Unlock()
+	fake.ProvenanceStub = nil
+	fake.provenanceReturns = struct {
+		result1 json.RawMessage
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeBuildForAPI) ProvenanceReturnsOnCall(i int, result1 json.RawMessage, result2 bool, result3 error) {
+	fake.provenanceMutex.Lock()
+	defer fake.provenanceMutex.Unlock()
+	fake.ProvenanceStub = nil
+	if fake.provenanceReturnsOnCall == nil {
+		fake.provenanceReturnsOnCall = make(map[int]struct {
+			result1 json.RawMessage
+			result2 bool
+			result3 error
+		})
+	}
+	fake.provenanceReturnsOnCall[i] = struct {
+		result1 json.RawMessage
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeBuildForAPI) PublicPlan() *json.RawMessage {
 	fake.publicPlanMutex.Lock()
 	ret, specificReturn := fake.publicPlanReturnsOnCall[len(fake.publicPlanArgsForCall)]
@@ -2026,6 +2471,115 @@ func (fake *FakeBuildForAPI) ResourcesReturnsOnCall(i int, result1 []db.BuildInp
 	}{result1, result2, result3}
 }
 
+func (fake *FakeBuildForAPI) RetryOf() int {
+	fake.retryOfMutex.Lock()
+	ret, specificReturn := fake.retryOfReturnsOnCall[len(fake.retryOfArgsForCall)]
+	fake.retryOfArgsForCall = append(fake.retryOfArgsForCall, struct {
+	}{})
+	stub := fake.RetryOfStub
+	fakeReturns := fake.retryOfReturns
+	fake.recordInvocation("RetryOf", []interface{}{})
+	fake.retryOfMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuildForAPI) RetryOfCallCount() int {
+	fake.retryOfMutex.RLock()
+	defer fake.retryOfMutex.RUnlock()
+	return len(fake.retryOfArgsForCall)
+}
+
+func (fake *FakeBuildForAPI) RetryOfCalls(stub func() int) {
+	fake.retryOfMutex.Lock()
+	defer fake.retryOfMutex.Unlock()
+	fake.RetryOfStub = stub
+}
+
+func (fake *FakeBuildForAPI) RetryOfReturns(result1 int) {
+	fake.retryOfMutex.Lock()
+	defer fake.retryOfMutex.Unlock()
+	fake.RetryOfStub = nil
+	fake.retryOfReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeBuildForAPI) RetryOfReturnsOnCall(i int, result1 int) {
+	fake.retryOfMutex.Lock()
+	defer fake.retryOfMutex.Unlock()
+	fake.RetryOfStub = nil
+	if fake.retryOfReturnsOnCall == nil {
+		fake.retryOfReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.retryOfReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeBuildForAPI) SavedEvents() ([]event.Envelope, error) {
+	fake.savedEventsMutex.Lock()
+	ret, specificReturn := fake.savedEventsReturnsOnCall[len(fake.savedEventsArgsForCall)]
+	fake.savedEventsArgsForCall = append(fake.savedEventsArgsForCall, struct {
+	}{})
+	stub := fake.SavedEventsStub
+	fakeReturns := fake.savedEventsReturns
+	fake.recordInvocation("SavedEvents", []interface{}{})
+	fake.savedEventsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuildForAPI) SavedEventsCallCount() int {
+	fake.savedEventsMutex.RLock()
+	defer fake.savedEventsMutex.RUnlock()
+	return len(fake.savedEventsArgsForCall)
+}
+
+func (fake *FakeBuildForAPI) SavedEventsCalls(stub func() ([]event.Envelope, error)) {
+	fake.savedEventsMutex.Lock()
+	defer fake.savedEventsMutex.Unlock()
+	fake.SavedEventsStub = stub
+}
+
+func (fake *FakeBuildForAPI) SavedEventsReturns(result1 []event.Envelope, result2 error) {
+	fake.savedEventsMutex.Lock()
+	defer fake.savedEventsMutex.Unlock()
+	fake.SavedEventsStub = nil
+	fake.savedEventsReturns = struct {
+		result1 []event.Envelope
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuildForAPI) SavedEventsReturnsOnCall(i int, result1 []event.Envelope, result2 error) {
+	fake.savedEventsMutex.Lock()
+	defer fake.savedEventsMutex.Unlock()
+	fake.SavedEventsStub = nil
+	if fake.savedEventsReturnsOnCall == nil {
+		fake.savedEventsReturnsOnCall = make(map[int]struct {
+			result1 []event.Envelope
+			result2 error
+		})
+	}
+	fake.savedEventsReturnsOnCall[i] = struct {
+		result1 []event.Envelope
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuildForAPI) Schema() string {
 	fake.schemaMutex.Lock()
 	ret, specificReturn := fake.schemaReturnsOnCall[len(fake.schemaArgsForCall)]
@@ -2140,6 +2694,59 @@ func (fake *FakeBuildForAPI) SetCommentReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeBuildForAPI) StartLatency() *atc.BuildStartLatency {
+	fake.startLatencyMutex.Lock()
+	ret, specificReturn := fake.startLatencyReturnsOnCall[len(fake.startLatencyArgsForCall)]
+	fake.startLatencyArgsForCall = append(fake.startLatencyArgsForCall, struct {
+	}{})
+	stub := fake.StartLatencyStub
+	fakeReturns := fake.startLatencyReturns
+	fake.recordInvocation("StartLatency", []interface{}{})
+	fake.startLatencyMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuildForAPI) StartLatencyCallCount() int {
+	fake.startLatencyMutex.RLock()
+	defer fake.startLatencyMutex.RUnlock()
+	return len(fake.startLatencyArgsForCall)
+}
+
+func (fake *FakeBuildForAPI) StartLatencyCalls(stub func() *atc.BuildStartLatency) {
+	fake.startLatencyMutex.Lock()
+	defer fake.startLatencyMutex.Unlock()
+	fake.StartLatencyStub = stub
+}
+
+func (fake *FakeBuildForAPI) StartLatencyReturns(result1 *atc.BuildStartLatency) {
+	fake.startLatencyMutex.Lock()
+	defer fake.startLatencyMutex.Unlock()
+	fake.StartLatencyStub = nil
+	fake.startLatencyReturns = struct {
+		result1 *atc.BuildStartLatency
+	}{result1}
+}
+
+func (fake *FakeBuildForAPI) StartLatencyReturnsOnCall(i int, result1 *atc.BuildStartLatency) {
+	fake.startLatencyMutex.Lock()
+	defer fake.startLatencyMutex.Unlock()
+	fake.StartLatencyStub = nil
+	if fake.startLatencyReturnsOnCall == nil {
+		fake.startLatencyReturnsOnCall = make(map[int]struct {
+			result1 *atc.BuildStartLatency
+		})
+	}
+	fake.startLatencyReturnsOnCall[i] = struct {
+		result1 *atc.BuildStartLatency
+	}{result1}
+}
+
 func (fake *FakeBuildForAPI) StartTime() time.Time {
 	fake.startTimeMutex.Lock()
 	ret, specificReturn := fake.startTimeReturnsOnCall[len(fake.startTimeArgsForCall)]
@@ -2352,9 +2959,72 @@ func (fake *FakeBuildForAPI) TeamNameReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeBuildForAPI) Vars() []atc.BuildVar {
+	fake.varsMutex.Lock()
+	ret, specificReturn := fake.varsReturnsOnCall[len(fake.varsArgsForCall)]
+	fake.varsArgsForCall = append(fake.varsArgsForCall, struct {
+	}{})
+	stub := fake.VarsStub
+	fakeReturns := fake.varsReturns
+	fake.recordInvocation("Vars", []interface{}{})
+	fake.varsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuildForAPI) VarsCallCount() int {
+	fake.varsMutex.RLock()
+	defer fake.varsMutex.RUnlock()
+	return len(fake.varsArgsForCall)
+}
+
+func (fake *FakeBuildForAPI) VarsCalls(stub func() []atc.BuildVar) {
+	fake.varsMutex.Lock()
+	defer fake.varsMutex.Unlock()
+	fake.VarsStub = stub
+}
+
+func (fake *FakeBuildForAPI) VarsReturns(result1 []atc.BuildVar) {
+	fake.varsMutex.Lock()
+	defer fake.varsMutex.Unlock()
+	fake.VarsStub = nil
+	fake.varsReturns = struct {
+		result1 []atc.BuildVar
+	}{result1}
+}
+
+func (fake *FakeBuildForAPI) VarsReturnsOnCall(i int, result1 []atc.BuildVar) {
+	fake.varsMutex.Lock()
+	defer fake.varsMutex.Unlock()
+	fake.VarsStub = nil
+	if fake.varsReturnsOnCall == nil {
+		fake.varsReturnsOnCall = make(map[int]struct {
+			result1 []atc.BuildVar
+		})
+	}
+	fake.varsReturnsOnCall[i] = struct {
+		result1 []atc.BuildVar
+	}{result1}
+}
+
 func (fake *FakeBuildForAPI) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.externalRefMutex.RLock()
+	defer fake.externalRefMutex.RUnlock()
+	fake.abortStepMutex.RLock()
+	defer fake.abortStepMutex.RUnlock()
+	fake.collapsedIntoMutex.RLock()
+	defer fake.collapsedIntoMutex.RUnlock()
+	fake.estimatedDurationMutex.RLock()
+	defer fake.estimatedDurationMutex.RUnlock()
+	fake.failureReasonMutex.RLock()
+	defer fake.failureReasonMutex.RUnlock()
 	fake.allAssociatedTeamNamesMutex.RLock()
 	defer fake.allAssociatedTeamNamesMutex.RUnlock()
 	fake.artifactsMutex.RLock()
@@ -2399,6 +3069,8 @@ func (fake *FakeBuildForAPI) Invocations() map[string][][]interface{} {
 	defer fake.pipelineRefMutex.RUnlock()
 	fake.preparationMutex.RLock()
 	defer fake.preparationMutex.RUnlock()
+	fake.provenanceMutex.RLock()
+	defer fake.provenanceMutex.RUnlock()
 	fake.publicPlanMutex.RLock()
 	defer fake.publicPlanMutex.RUnlock()
 	fake.reapTimeMutex.RLock()
@@ -2415,10 +3087,16 @@ func (fake *FakeBuildForAPI) Invocations() map[string][][]interface{} {
 	defer fake.resourceNameMutex.RUnlock()
 	fake.resourcesMutex.RLock()
 	defer fake.resourcesMutex.RUnlock()
+	fake.retryOfMutex.RLock()
+	defer fake.retryOfMutex.RUnlock()
+	fake.savedEventsMutex.RLock()
+	defer fake.savedEventsMutex.RUnlock()
 	fake.schemaMutex.RLock()
 	defer fake.schemaMutex.RUnlock()
 	fake.setCommentMutex.RLock()
 	defer fake.setCommentMutex.RUnlock()
+	fake.startLatencyMutex.RLock()
+	defer fake.startLatencyMutex.RUnlock()
 	fake.startTimeMutex.RLock()
 	defer fake.startTimeMutex.RUnlock()
 	fake.statusMutex.RLock()
@@ -2427,6 +3105,8 @@ func (fake *FakeBuildForAPI) Invocations() map[string][][]interface{} {
 	defer fake.teamIDMutex.RUnlock()
 	fake.teamNameMutex.RLock()
 	defer fake.teamNameMutex.RUnlock()
+	fake.varsMutex.RLock()
+	defer fake.varsMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
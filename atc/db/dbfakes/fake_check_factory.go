@@ -10,6 +10,22 @@ import (
 )
 
 type FakeCheckFactory struct {
+	CreateMetadataFetchStub        func(context.Context, db.Resource, db.ResourceTypes, []atc.Version) (db.Build, error)
+	createMetadataFetchMutex       sync.RWMutex
+	createMetadataFetchArgsForCall []struct {
+		arg1 context.Context
+		arg2 db.Resource
+		arg3 db.ResourceTypes
+		arg4 []atc.Version
+	}
+	createMetadataFetchReturns struct {
+		result1 db.Build
+		result2 error
+	}
+	createMetadataFetchReturnsOnCall map[int]struct {
+		result1 db.Build
+		result2 error
+	}
 	ResourceTypesByPipelineStub        func() (map[int]db.ResourceTypes, error)
 	resourceTypesByPipelineMutex       sync.RWMutex
 	resourceTypesByPipelineArgsForCall []struct {
@@ -59,6 +75,78 @@ type FakeCheckFactory struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeCheckFactory) CreateMetadataFetch(arg1 context.Context, arg2 db.Resource, arg3 db.ResourceTypes, arg4 []atc.Version) (db.Build, error) {
+	var arg4Copy []atc.Version
+	if arg4 != nil {
+		arg4Copy = make([]atc.Version, len(arg4))
+		copy(arg4Copy, arg4)
+	}
+	fake.createMetadataFetchMutex.Lock()
+	ret, specificReturn := fake.createMetadataFetchReturnsOnCall[len(fake.createMetadataFetchArgsForCall)]
+	fake.createMetadataFetchArgsForCall = append(fake.createMetadataFetchArgsForCall, struct {
+		arg1 context.Context
+		arg2 db.Resource
+		arg3 db.ResourceTypes
+		arg4 []atc.Version
+	}{arg1, arg2, arg3, arg4Copy})
+	stub := fake.CreateMetadataFetchStub
+	fakeReturns := fake.createMetadataFetchReturns
+	fake.recordInvocation("CreateMetadataFetch", []interface{}{arg1, arg2, arg3, arg4Copy})
+	fake.createMetadataFetchMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeCheckFactory) CreateMetadataFetchCallCount() int {
+	fake.createMetadataFetchMutex.RLock()
+	defer fake.createMetadataFetchMutex.RUnlock()
+	return len(fake.createMetadataFetchArgsForCall)
+}
+
+func (fake *FakeCheckFactory) CreateMetadataFetchCalls(stub func(context.Context, db.Resource, db.ResourceTypes, []atc.Version) (db.Build, error)) {
+	fake.createMetadataFetchMutex.Lock()
+	defer fake.createMetadataFetchMutex.Unlock()
+	fake.CreateMetadataFetchStub = stub
+}
+
+func (fake *FakeCheckFactory) CreateMetadataFetchArgsForCall(i int) (context.Context, db.Resource, db.ResourceTypes, []atc.Version) {
+	fake.createMetadataFetchMutex.RLock()
+	defer fake.createMetadataFetchMutex.RUnlock()
+	argsForCall := fake.createMetadataFetchArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeCheckFactory) CreateMetadataFetchReturns(result1 db.Build, result2 error) {
+	fake.createMetadataFetchMutex.Lock()
+	defer fake.createMetadataFetchMutex.Unlock()
+	fake.CreateMetadataFetchStub = nil
+	fake.createMetadataFetchReturns = struct {
+		result1 db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCheckFactory) CreateMetadataFetchReturnsOnCall(i int, result1 db.Build, result2 error) {
+	fake.createMetadataFetchMutex.Lock()
+	defer fake.createMetadataFetchMutex.Unlock()
+	fake.CreateMetadataFetchStub = nil
+	if fake.createMetadataFetchReturnsOnCall == nil {
+		fake.createMetadataFetchReturnsOnCall = make(map[int]struct {
+			result1 db.Build
+			result2 error
+		})
+	}
+	fake.createMetadataFetchReturnsOnCall[i] = struct {
+		result1 db.Build
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeCheckFactory) ResourceTypesByPipeline() (map[int]db.ResourceTypes, error) {
 	fake.resourceTypesByPipelineMutex.Lock()
 	ret, specificReturn := fake.resourceTypesByPipelineReturnsOnCall[len(fake.resourceTypesByPipelineArgsForCall)]
@@ -245,6 +333,8 @@ func (fake *FakeCheckFactory) TryCreateCheckReturnsOnCall(i int, result1 db.Buil
 }
 
 func (fake *FakeCheckFactory) Invocations() map[string][][]interface{} {
+	fake.createMetadataFetchMutex.RLock()
+	defer fake.createMetadataFetchMutex.RUnlock()
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.resourceTypesByPipelineMutex.RLock()
@@ -9,6 +9,16 @@ import (
 )
 
 type FakeComponent struct {
+	DefaultIntervalStub        func() time.Duration
+	defaultIntervalMutex       sync.RWMutex
+	defaultIntervalArgsForCall []struct {
+	}
+	defaultIntervalReturns struct {
+		result1 time.Duration
+	}
+	defaultIntervalReturnsOnCall map[int]struct {
+		result1 time.Duration
+	}
 	IDStub        func() int
 	iDMutex       sync.RWMutex
 	iDArgsForCall []struct {
@@ -59,6 +69,16 @@ type FakeComponent struct {
 	nameReturnsOnCall map[int]struct {
 		result1 string
 	}
+	PauseStub        func() error
+	pauseMutex       sync.RWMutex
+	pauseArgsForCall []struct {
+	}
+	pauseReturns struct {
+		result1 error
+	}
+	pauseReturnsOnCall map[int]struct {
+		result1 error
+	}
 	PausedStub        func() bool
 	pausedMutex       sync.RWMutex
 	pausedArgsForCall []struct {
@@ -81,6 +101,37 @@ type FakeComponent struct {
 		result1 bool
 		result2 error
 	}
+	ResetIntervalStub        func() error
+	resetIntervalMutex       sync.RWMutex
+	resetIntervalArgsForCall []struct {
+	}
+	resetIntervalReturns struct {
+		result1 error
+	}
+	resetIntervalReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SetIntervalStub        func(time.Duration) error
+	setIntervalMutex       sync.RWMutex
+	setIntervalArgsForCall []struct {
+		arg1 time.Duration
+	}
+	setIntervalReturns struct {
+		result1 error
+	}
+	setIntervalReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UnpauseStub        func() error
+	unpauseMutex       sync.RWMutex
+	unpauseArgsForCall []struct {
+	}
+	unpauseReturns struct {
+		result1 error
+	}
+	unpauseReturnsOnCall map[int]struct {
+		result1 error
+	}
 	UpdateLastRanStub        func() error
 	updateLastRanMutex       sync.RWMutex
 	updateLastRanArgsForCall []struct {
@@ -95,6 +146,59 @@ type FakeComponent struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeComponent) DefaultInterval() time.Duration {
+	fake.defaultIntervalMutex.Lock()
+	ret, specificReturn := fake.defaultIntervalReturnsOnCall[len(fake.defaultIntervalArgsForCall)]
+	fake.defaultIntervalArgsForCall = append(fake.defaultIntervalArgsForCall, struct {
+	}{})
+	stub := fake.DefaultIntervalStub
+	fakeReturns := fake.defaultIntervalReturns
+	fake.recordInvocation("DefaultInterval", []interface{}{})
+	fake.defaultIntervalMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeComponent) DefaultIntervalCallCount() int {
+	fake.defaultIntervalMutex.RLock()
+	defer fake.defaultIntervalMutex.RUnlock()
+	return len(fake.defaultIntervalArgsForCall)
+}
+
+func (fake *FakeComponent) DefaultIntervalCalls(stub func() time.Duration) {
+	fake.defaultIntervalMutex.Lock()
+	defer fake.defaultIntervalMutex.Unlock()
+	fake.DefaultIntervalStub = stub
+}
+
+func (fake *FakeComponent) DefaultIntervalReturns(result1 time.Duration) {
+	fake.defaultIntervalMutex.Lock()
+	defer fake.defaultIntervalMutex.Unlock()
+	fake.DefaultIntervalStub = nil
+	fake.defaultIntervalReturns = struct {
+		result1 time.Duration
+	}{result1}
+}
+
+func (fake *FakeComponent) DefaultIntervalReturnsOnCall(i int, result1 time.Duration) {
+	fake.defaultIntervalMutex.Lock()
+	defer fake.defaultIntervalMutex.Unlock()
+	fake.DefaultIntervalStub = nil
+	if fake.defaultIntervalReturnsOnCall == nil {
+		fake.defaultIntervalReturnsOnCall = make(map[int]struct {
+			result1 time.Duration
+		})
+	}
+	fake.defaultIntervalReturnsOnCall[i] = struct {
+		result1 time.Duration
+	}{result1}
+}
+
 func (fake *FakeComponent) ID() int {
 	fake.iDMutex.Lock()
 	ret, specificReturn := fake.iDReturnsOnCall[len(fake.iDArgsForCall)]
@@ -360,6 +464,59 @@ func (fake *FakeComponent) NameReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeComponent) Pause() error {
+	fake.pauseMutex.Lock()
+	ret, specificReturn := fake.pauseReturnsOnCall[len(fake.pauseArgsForCall)]
+	fake.pauseArgsForCall = append(fake.pauseArgsForCall, struct {
+	}{})
+	stub := fake.PauseStub
+	fakeReturns := fake.pauseReturns
+	fake.recordInvocation("Pause", []interface{}{})
+	fake.pauseMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeComponent) PauseCallCount() int {
+	fake.pauseMutex.RLock()
+	defer fake.pauseMutex.RUnlock()
+	return len(fake.pauseArgsForCall)
+}
+
+func (fake *FakeComponent) PauseCalls(stub func() error) {
+	fake.pauseMutex.Lock()
+	defer fake.pauseMutex.Unlock()
+	fake.PauseStub = stub
+}
+
+func (fake *FakeComponent) PauseReturns(result1 error) {
+	fake.pauseMutex.Lock()
+	defer fake.pauseMutex.Unlock()
+	fake.PauseStub = nil
+	fake.pauseReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeComponent) PauseReturnsOnCall(i int, result1 error) {
+	fake.pauseMutex.Lock()
+	defer fake.pauseMutex.Unlock()
+	fake.PauseStub = nil
+	if fake.pauseReturnsOnCall == nil {
+		fake.pauseReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.pauseReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeComponent) Paused() bool {
 	fake.pausedMutex.Lock()
 	ret, specificReturn := fake.pausedReturnsOnCall[len(fake.pausedArgsForCall)]
@@ -469,6 +626,173 @@ func (fake *FakeComponent) ReloadReturnsOnCall(i int, result1 bool, result2 erro
 	}{result1, result2}
 }
 
+func (fake *FakeComponent) ResetInterval() error {
+	fake.resetIntervalMutex.Lock()
+	ret, specificReturn := fake.resetIntervalReturnsOnCall[len(fake.resetIntervalArgsForCall)]
+	fake.resetIntervalArgsForCall = append(fake.resetIntervalArgsForCall, struct {
+	}{})
+	stub := fake.ResetIntervalStub
+	fakeReturns := fake.resetIntervalReturns
+	fake.recordInvocation("ResetInterval", []interface{}{})
+	fake.resetIntervalMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeComponent) ResetIntervalCallCount() int {
+	fake.resetIntervalMutex.RLock()
+	defer fake.resetIntervalMutex.RUnlock()
+	return len(fake.resetIntervalArgsForCall)
+}
+
+func (fake *FakeComponent) ResetIntervalCalls(stub func() error) {
+	fake.resetIntervalMutex.Lock()
+	defer fake.resetIntervalMutex.Unlock()
+	fake.ResetIntervalStub = stub
+}
+
+func (fake *FakeComponent) ResetIntervalReturns(result1 error) {
+	fake.resetIntervalMutex.Lock()
+	defer fake.resetIntervalMutex.Unlock()
+	fake.ResetIntervalStub = nil
+	fake.resetIntervalReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeComponent) ResetIntervalReturnsOnCall(i int, result1 error) {
+	fake.resetIntervalMutex.Lock()
+	defer fake.resetIntervalMutex.Unlock()
+	fake.ResetIntervalStub = nil
+	if fake.resetIntervalReturnsOnCall == nil {
+		fake.resetIntervalReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.resetIntervalReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeComponent) SetInterval(arg1 time.Duration) error {
+	fake.setIntervalMutex.Lock()
+	ret, specificReturn := fake.setIntervalReturnsOnCall[len(fake.setIntervalArgsForCall)]
+	fake.setIntervalArgsForCall = append(fake.setIntervalArgsForCall, struct {
+		arg1 time.Duration
+	}{arg1})
+	stub := fake.SetIntervalStub
+	fakeReturns := fake.setIntervalReturns
+	fake.recordInvocation("SetInterval", []interface{}{arg1})
+	fake.setIntervalMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeComponent) SetIntervalCallCount() int {
+	fake.setIntervalMutex.RLock()
+	defer fake.setIntervalMutex.RUnlock()
+	return len(fake.setIntervalArgsForCall)
+}
+
+func (fake *FakeComponent) SetIntervalCalls(stub func(time.Duration) error) {
+	fake.setIntervalMutex.Lock()
+	defer fake.setIntervalMutex.Unlock()
+	fake.SetIntervalStub = stub
+}
+
+func (fake *FakeComponent) SetIntervalArgsForCall(i int) time.Duration {
+	fake.setIntervalMutex.RLock()
+	defer fake.setIntervalMutex.RUnlock()
+	argsForCall := fake.setIntervalArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeComponent) SetIntervalReturns(result1 error) {
+	fake.setIntervalMutex.Lock()
+	defer fake.setIntervalMutex.Unlock()
+	fake.SetIntervalStub = nil
+	fake.setIntervalReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeComponent) SetIntervalReturnsOnCall(i int, result1 error) {
+	fake.setIntervalMutex.Lock()
+	defer fake.setIntervalMutex.Unlock()
+	fake.SetIntervalStub = nil
+	if fake.setIntervalReturnsOnCall == nil {
+		fake.setIntervalReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setIntervalReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeComponent) Unpause() error {
+	fake.unpauseMutex.Lock()
+	ret, specificReturn := fake.unpauseReturnsOnCall[len(fake.unpauseArgsForCall)]
+	fake.unpauseArgsForCall = append(fake.unpauseArgsForCall, struct {
+	}{})
+	stub := fake.UnpauseStub
+	fakeReturns := fake.unpauseReturns
+	fake.recordInvocation("Unpause", []interface{}{})
+	fake.unpauseMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeComponent) UnpauseCallCount() int {
+	fake.unpauseMutex.RLock()
+	defer fake.unpauseMutex.RUnlock()
+	return len(fake.unpauseArgsForCall)
+}
+
+func (fake *FakeComponent) UnpauseCalls(stub func() error) {
+	fake.unpauseMutex.Lock()
+	defer fake.unpauseMutex.Unlock()
+	fake.UnpauseStub = stub
+}
+
+func (fake *FakeComponent) UnpauseReturns(result1 error) {
+	fake.unpauseMutex.Lock()
+	defer fake.unpauseMutex.Unlock()
+	fake.UnpauseStub = nil
+	fake.unpauseReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeComponent) UnpauseReturnsOnCall(i int, result1 error) {
+	fake.unpauseMutex.Lock()
+	defer fake.unpauseMutex.Unlock()
+	fake.UnpauseStub = nil
+	if fake.unpauseReturnsOnCall == nil {
+		fake.unpauseReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.unpauseReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeComponent) UpdateLastRan() error {
 	fake.updateLastRanMutex.Lock()
 	ret, specificReturn := fake.updateLastRanReturnsOnCall[len(fake.updateLastRanArgsForCall)]
@@ -523,6 +847,8 @@ func (fake *FakeComponent) UpdateLastRanReturnsOnCall(i int, result1 error) {
 }
 
 func (fake *FakeComponent) Invocations() map[string][][]interface{} {
+	fake.defaultIntervalMutex.RLock()
+	defer fake.defaultIntervalMutex.RUnlock()
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.iDMutex.RLock()
@@ -535,10 +861,18 @@ func (fake *FakeComponent) Invocations() map[string][][]interface{} {
 	defer fake.lastRanMutex.RUnlock()
 	fake.nameMutex.RLock()
 	defer fake.nameMutex.RUnlock()
+	fake.pauseMutex.RLock()
+	defer fake.pauseMutex.RUnlock()
 	fake.pausedMutex.RLock()
 	defer fake.pausedMutex.RUnlock()
 	fake.reloadMutex.RLock()
 	defer fake.reloadMutex.RUnlock()
+	fake.resetIntervalMutex.RLock()
+	defer fake.resetIntervalMutex.RUnlock()
+	fake.setIntervalMutex.RLock()
+	defer fake.setIntervalMutex.RUnlock()
+	fake.unpauseMutex.RLock()
+	defer fake.unpauseMutex.RUnlock()
 	fake.updateLastRanMutex.RLock()
 	defer fake.updateLastRanMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
@@ -9,6 +9,18 @@ import (
 )
 
 type FakeComponentFactory struct {
+	AllStub        func() ([]db.Component, error)
+	allMutex       sync.RWMutex
+	allArgsForCall []struct {
+	}
+	allReturns struct {
+		result1 []db.Component
+		result2 error
+	}
+	allReturnsOnCall map[int]struct {
+		result1 []db.Component
+		result2 error
+	}
 	CreateOrUpdateStub        func(atc.Component) (db.Component, error)
 	createOrUpdateMutex       sync.RWMutex
 	createOrUpdateArgsForCall []struct {
@@ -41,6 +53,62 @@ type FakeComponentFactory struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeComponentFactory) All() ([]db.Component, error) {
+	fake.allMutex.Lock()
+	ret, specificReturn := fake.allReturnsOnCall[len(fake.allArgsForCall)]
+	fake.allArgsForCall = append(fake.allArgsForCall, struct {
+	}{})
+	stub := fake.AllStub
+	fakeReturns := fake.allReturns
+	fake.recordInvocation("All", []interface{}{})
+	fake.allMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeComponentFactory) AllCallCount() int {
+	fake.allMutex.RLock()
+	defer fake.allMutex.RUnlock()
+	return len(fake.allArgsForCall)
+}
+
+func (fake *FakeComponentFactory) AllCalls(stub func() ([]db.Component, error)) {
+	fake.allMutex.Lock()
+	defer fake.allMutex.Unlock()
+	fake.AllStub = stub
+}
+
+func (fake *FakeComponentFactory) AllReturns(result1 []db.Component, result2 error) {
+	fake.allMutex.Lock()
+	defer fake.allMutex.Unlock()
+	fake.AllStub = nil
+	fake.allReturns = struct {
+		result1 []db.Component
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeComponentFactory) AllReturnsOnCall(i int, result1 []db.Component, result2 error) {
+	fake.allMutex.Lock()
+	defer fake.allMutex.Unlock()
+	fake.AllStub = nil
+	if fake.allReturnsOnCall == nil {
+		fake.allReturnsOnCall = make(map[int]struct {
+			result1 []db.Component
+			result2 error
+		})
+	}
+	fake.allReturnsOnCall[i] = struct {
+		result1 []db.Component
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeComponentFactory) CreateOrUpdate(arg1 atc.Component) (db.Component, error) {
 	fake.createOrUpdateMutex.Lock()
 	ret, specificReturn := fake.createOrUpdateReturnsOnCall[len(fake.createOrUpdateArgsForCall)]
@@ -173,6 +241,8 @@ func (fake *FakeComponentFactory) FindReturnsOnCall(i int, result1 db.Component,
 }
 
 func (fake *FakeComponentFactory) Invocations() map[string][][]interface{} {
+	fake.allMutex.RLock()
+	defer fake.allMutex.RUnlock()
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.createOrUpdateMutex.RLock()
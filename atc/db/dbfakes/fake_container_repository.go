@@ -47,6 +47,19 @@ type FakeContainerRepository struct {
 		result1 int
 		result2 error
 	}
+	FindBuildContainersStub        func(int) ([]db.Container, error)
+	findBuildContainersMutex       sync.RWMutex
+	findBuildContainersArgsForCall []struct {
+		arg1 int
+	}
+	findBuildContainersReturns struct {
+		result1 []db.Container
+		result2 error
+	}
+	findBuildContainersReturnsOnCall map[int]struct {
+		result1 []db.Container
+		result2 error
+	}
 	FindDestroyingContainersStub        func(string) ([]string, error)
 	findDestroyingContainersMutex       sync.RWMutex
 	findDestroyingContainersArgsForCall []struct {
@@ -301,6 +314,70 @@ func (fake *FakeContainerRepository) DestroyUnknownContainersReturnsOnCall(i int
 	}{result1, result2}
 }
 
+func (fake *FakeContainerRepository) FindBuildContainers(arg1 int) ([]db.Container, error) {
+	fake.findBuildContainersMutex.Lock()
+	ret, specificReturn := fake.findBuildContainersReturnsOnCall[len(fake.findBuildContainersArgsForCall)]
+	fake.findBuildContainersArgsForCall = append(fake.findBuildContainersArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	stub := fake.FindBuildContainersStub
+	fakeReturns := fake.findBuildContainersReturns
+	fake.recordInvocation("FindBuildContainers", []interface{}{arg1})
+	fake.findBuildContainersMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeContainerRepository) FindBuildContainersCallCount() int {
+	fake.findBuildContainersMutex.RLock()
+	defer fake.findBuildContainersMutex.RUnlock()
+	return len(fake.findBuildContainersArgsForCall)
+}
+
+func (fake *FakeContainerRepository) FindBuildContainersCalls(stub func(int) ([]db.Container, error)) {
+	fake.findBuildContainersMutex.Lock()
+	defer fake.findBuildContainersMutex.Unlock()
+	fake.FindBuildContainersStub = stub
+}
+
+func (fake *FakeContainerRepository) FindBuildContainersArgsForCall(i int) int {
+	fake.findBuildContainersMutex.RLock()
+	defer fake.findBuildContainersMutex.RUnlock()
+	argsForCall := fake.findBuildContainersArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeContainerRepository) FindBuildContainersReturns(result1 []db.Container, result2 error) {
+	fake.findBuildContainersMutex.Lock()
+	defer fake.findBuildContainersMutex.Unlock()
+	fake.FindBuildContainersStub = nil
+	fake.findBuildContainersReturns = struct {
+		result1 []db.Container
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeContainerRepository) FindBuildContainersReturnsOnCall(i int, result1 []db.Container, result2 error) {
+	fake.findBuildContainersMutex.Lock()
+	defer fake.findBuildContainersMutex.Unlock()
+	fake.FindBuildContainersStub = nil
+	if fake.findBuildContainersReturnsOnCall == nil {
+		fake.findBuildContainersReturnsOnCall = make(map[int]struct {
+			result1 []db.Container
+			result2 error
+		})
+	}
+	fake.findBuildContainersReturnsOnCall[i] = struct {
+		result1 []db.Container
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeContainerRepository) FindDestroyingContainers(arg1 string) ([]string, error) {
 	fake.findDestroyingContainersMutex.Lock()
 	ret, specificReturn := fake.findDestroyingContainersReturnsOnCall[len(fake.findDestroyingContainersArgsForCall)]
@@ -629,6 +706,8 @@ func (fake *FakeContainerRepository) UpdateContainersMissingSinceReturnsOnCall(i
 }
 
 func (fake *FakeContainerRepository) Invocations() map[string][][]interface{} {
+	fake.findBuildContainersMutex.RLock()
+	defer fake.findBuildContainersMutex.RUnlock()
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.destroyDirtyInMemoryBuildContainersMutex.RLock()
@@ -31,6 +31,20 @@ type FakeCreatedVolume struct {
 	containerHandleReturnsOnCall map[int]struct {
 		result1 string
 	}
+	ContentDigestStub        func() (string, bool, error)
+	contentDigestMutex       sync.RWMutex
+	contentDigestArgsForCall []struct {
+	}
+	contentDigestReturns struct {
+		result1 string
+		result2 bool
+		result3 error
+	}
+	contentDigestReturnsOnCall map[int]struct {
+		result1 string
+		result2 bool
+		result3 error
+	}
 	CreateChildForContainerStub        func(db.CreatingContainer, string) (db.CreatingVolume, error)
 	createChildForContainerMutex       sync.RWMutex
 	createChildForContainerArgsForCall []struct {
@@ -159,6 +173,17 @@ type FakeCreatedVolume struct {
 		result1 *db.VolumeResourceType
 		result2 error
 	}
+	SetContentDigestStub        func(string) error
+	setContentDigestMutex       sync.RWMutex
+	setContentDigestArgsForCall []struct {
+		arg1 string
+	}
+	setContentDigestReturns struct {
+		result1 error
+	}
+	setContentDigestReturnsOnCall map[int]struct {
+		result1 error
+	}
 	TaskIdentifierStub        func() (int, atc.PipelineRef, string, string, error)
 	taskIdentifierMutex       sync.RWMutex
 	taskIdentifierArgsForCall []struct {
@@ -330,6 +355,65 @@ func (fake *FakeCreatedVolume) ContainerHandleReturnsOnCall(i int, result1 strin
 	}{result1}
 }
 
+func (fake *FakeCreatedVolume) ContentDigest() (string, bool, error) {
+	fake.contentDigestMutex.Lock()
+	ret, specificReturn := fake.contentDigestReturnsOnCall[len(fake.contentDigestArgsForCall)]
+	fake.contentDigestArgsForCall = append(fake.contentDigestArgsForCall, struct {
+	}{})
+	stub := fake.ContentDigestStub
+	fakeReturns := fake.contentDigestReturns
+	fake.recordInvocation("ContentDigest", []interface{}{})
+	fake.contentDigestMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeCreatedVolume) ContentDigestCallCount() int {
+	fake.contentDigestMutex.RLock()
+	defer fake.contentDigestMutex.RUnlock()
+	return len(fake.contentDigestArgsForCall)
+}
+
+func (fake *FakeCreatedVolume) ContentDigestCalls(stub func() (string, bool, error)) {
+	fake.contentDigestMutex.Lock()
+	defer fake.contentDigestMutex.Unlock()
+	fake.ContentDigestStub = stub
+}
+
+func (fake *FakeCreatedVolume) ContentDigestReturns(result1 string, result2 bool, result3 error) {
+	fake.contentDigestMutex.Lock()
+	defer fake.contentDigestMutex.Unlock()
+	fake.ContentDigestStub = nil
+	fake.contentDigestReturns = struct {
+		result1 string
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeCreatedVolume) ContentDigestReturnsOnCall(i int, result1 string, result2 bool, result3 error) {
+	fake.contentDigestMutex.Lock()
+	defer fake.contentDigestMutex.Unlock()
+	fake.ContentDigestStub = nil
+	if fake.contentDigestReturnsOnCall == nil {
+		fake.contentDigestReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 bool
+			result3 error
+		})
+	}
+	fake.contentDigestReturnsOnCall[i] = struct {
+		result1 string
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeCreatedVolume) CreateChildForContainer(arg1 db.CreatingContainer, arg2 string) (db.CreatingVolume, error) {
 	fake.createChildForContainerMutex.Lock()
 	ret, specificReturn := fake.createChildForContainerReturnsOnCall[len(fake.createChildForContainerArgsForCall)]
@@ -970,6 +1054,67 @@ func (fake *FakeCreatedVolume) ResourceTypeReturnsOnCall(i int, result1 *db.Volu
 	}{result1, result2}
 }
 
+func (fake *FakeCreatedVolume) SetContentDigest(arg1 string) error {
+	fake.setContentDigestMutex.Lock()
+	ret, specificReturn := fake.setContentDigestReturnsOnCall[len(fake.setContentDigestArgsForCall)]
+	fake.setContentDigestArgsForCall = append(fake.setContentDigestArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.SetContentDigestStub
+	fakeReturns := fake.setContentDigestReturns
+	fake.recordInvocation("SetContentDigest", []interface{}{arg1})
+	fake.setContentDigestMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeCreatedVolume) SetContentDigestCallCount() int {
+	fake.setContentDigestMutex.RLock()
+	defer fake.setContentDigestMutex.RUnlock()
+	return len(fake.setContentDigestArgsForCall)
+}
+
+func (fake *FakeCreatedVolume) SetContentDigestCalls(stub func(string) error) {
+	fake.setContentDigestMutex.Lock()
+	defer fake.setContentDigestMutex.Unlock()
+	fake.SetContentDigestStub = stub
+}
+
+func (fake *FakeCreatedVolume) SetContentDigestArgsForCall(i int) string {
+	fake.setContentDigestMutex.RLock()
+	defer fake.setContentDigestMutex.RUnlock()
+	argsForCall := fake.setContentDigestArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeCreatedVolume) SetContentDigestReturns(result1 error) {
+	fake.setContentDigestMutex.Lock()
+	defer fake.setContentDigestMutex.Unlock()
+	fake.SetContentDigestStub = nil
+	fake.setContentDigestReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeCreatedVolume) SetContentDigestReturnsOnCall(i int, result1 error) {
+	fake.setContentDigestMutex.Lock()
+	defer fake.setContentDigestMutex.Unlock()
+	fake.SetContentDigestStub = nil
+	if fake.setContentDigestReturnsOnCall == nil {
+		fake.setContentDigestReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setContentDigestReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeCreatedVolume) TaskIdentifier() (int, atc.PipelineRef, string, string, error) {
 	fake.taskIdentifierMutex.Lock()
 	ret, specificReturn := fake.taskIdentifierReturnsOnCall[len(fake.taskIdentifierArgsForCall)]
@@ -1248,6 +1393,8 @@ func (fake *FakeCreatedVolume) WorkerNameReturnsOnCall(i int, result1 string) {
 }
 
 func (fake *FakeCreatedVolume) Invocations() map[string][][]interface{} {
+	fake.contentDigestMutex.RLock()
+	defer fake.contentDigestMutex.RUnlock()
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.baseResourceTypeMutex.RLock()
@@ -1276,6 +1423,8 @@ func (fake *FakeCreatedVolume) Invocations() map[string][][]interface{} {
 	defer fake.pathMutex.RUnlock()
 	fake.resourceTypeMutex.RLock()
 	defer fake.resourceTypeMutex.RUnlock()
+	fake.setContentDigestMutex.RLock()
+	defer fake.setContentDigestMutex.RUnlock()
 	fake.taskIdentifierMutex.RLock()
 	defer fake.taskIdentifierMutex.RUnlock()
 	fake.teamIDMutex.RLock()
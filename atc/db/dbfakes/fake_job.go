@@ -139,6 +139,22 @@ type FakeJob struct {
 		result1 db.Build
 		result2 error
 	}
+	CreateBuildWithOverridesStub        func(string, []atc.BuildInputPin, []atc.BuildVar, string) (db.Build, error)
+	createBuildWithOverridesMutex       sync.RWMutex
+	createBuildWithOverridesArgsForCall []struct {
+		arg1 string
+		arg2 []atc.BuildInputPin
+		arg3 []atc.BuildVar
+		arg4 string
+	}
+	createBuildWithOverridesReturns struct {
+		result1 db.Build
+		result2 error
+	}
+	createBuildWithOverridesReturnsOnCall map[int]struct {
+		result1 db.Build
+		result2 error
+	}
 	DisableManualTriggerStub        func() bool
 	disableManualTriggerMutex       sync.RWMutex
 	disableManualTriggerArgsForCall []struct {
@@ -427,6 +443,22 @@ type FakeJob struct {
 		result1 db.Build
 		result2 error
 	}
+	RetryBuildStub        func(db.Build, int) (db.Build, bool, error)
+	retryBuildMutex       sync.RWMutex
+	retryBuildArgsForCall []struct {
+		arg1 db.Build
+		arg2 int
+	}
+	retryBuildReturns struct {
+		result1 db.Build
+		result2 bool
+		result3 error
+	}
+	retryBuildReturnsOnCall map[int]struct {
+		result1 db.Build
+		result2 bool
+		result3 error
+	}
 	SaveNextInputMappingStub        func(db.InputMapping, bool) error
 	saveNextInputMappingMutex       sync.RWMutex
 	saveNextInputMappingArgsForCall []struct {
@@ -473,6 +505,17 @@ type FakeJob struct {
 	setHasNewInputsReturnsOnCall map[int]struct {
 		result1 error
 	}
+	SetWorkerCapacityReachedStub        func(bool) error
+	setWorkerCapacityReachedMutex       sync.RWMutex
+	setWorkerCapacityReachedArgsForCall []struct {
+		arg1 bool
+	}
+	setWorkerCapacityReachedReturns struct {
+		result1 error
+	}
+	setWorkerCapacityReachedReturnsOnCall map[int]struct {
+		result1 error
+	}
 	TagsStub        func() []string
 	tagsMutex       sync.RWMutex
 	tagsArgsForCall []struct {
@@ -1115,6 +1158,83 @@ func (fake *FakeJob) CreateBuildReturnsOnCall(i int, result1 db.Build, result2 e
 	}{result1, result2}
 }
 
+func (fake *FakeJob) CreateBuildWithOverrides(arg1 string, arg2 []atc.BuildInputPin, arg3 []atc.BuildVar, arg4 string) (db.Build, error) {
+	var arg2Copy []atc.BuildInputPin
+	if arg2 != nil {
+		arg2Copy = make([]atc.BuildInputPin, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	var arg3Copy []atc.BuildVar
+	if arg3 != nil {
+		arg3Copy = make([]atc.BuildVar, len(arg3))
+		copy(arg3Copy, arg3)
+	}
+	fake.createBuildWithOverridesMutex.Lock()
+	ret, specificReturn := fake.createBuildWithOverridesReturnsOnCall[len(fake.createBuildWithOverridesArgsForCall)]
+	fake.createBuildWithOverridesArgsForCall = append(fake.createBuildWithOverridesArgsForCall, struct {
+		arg1 string
+		arg2 []atc.BuildInputPin
+		arg3 []atc.BuildVar
+		arg4 string
+	}{arg1, arg2Copy, arg3Copy, arg4})
+	stub := fake.CreateBuildWithOverridesStub
+	fakeReturns := fake.createBuildWithOverridesReturns
+	fake.recordInvocation("CreateBuildWithOverrides", []interface{}{arg1, arg2Copy, arg3Copy, arg4})
+	fake.createBuildWithOverridesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeJob) CreateBuildWithOverridesCallCount() int {
+	fake.createBuildWithOverridesMutex.RLock()
+	defer fake.createBuildWithOverridesMutex.RUnlock()
+	return len(fake.createBuildWithOverridesArgsForCall)
+}
+
+func (fake *FakeJob) CreateBuildWithOverridesCalls(stub func(string, []atc.BuildInputPin, []atc.BuildVar, string) (db.Build, error)) {
+	fake.createBuildWithOverridesMutex.Lock()
+	defer fake.createBuildWithOverridesMutex.Unlock()
+	fake.CreateBuildWithOverridesStub = stub
+}
+
+func (fake *FakeJob) CreateBuildWithOverridesArgsForCall(i int) (string, []atc.BuildInputPin, []atc.BuildVar, string) {
+	fake.createBuildWithOverridesMutex.RLock()
+	defer fake.createBuildWithOverridesMutex.RUnlock()
+	argsForCall := fake.createBuildWithOverridesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeJob) CreateBuildWithOverridesReturns(result1 db.Build, result2 error) {
+	fake.createBuildWithOverridesMutex.Lock()
+	defer fake.createBuildWithOverridesMutex.Unlock()
+	fake.CreateBuildWithOverridesStub = nil
+	fake.createBuildWithOverridesReturns = struct {
+		result1 db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJob) CreateBuildWithOverridesReturnsOnCall(i int, result1 db.Build, result2 error) {
+	fake.createBuildWithOverridesMutex.Lock()
+	defer fake.createBuildWithOverridesMutex.Unlock()
+	fake.CreateBuildWithOverridesStub = nil
+	if fake.createBuildWithOverridesReturnsOnCall == nil {
+		fake.createBuildWithOverridesReturnsOnCall = make(map[int]struct {
+			result1 db.Build
+			result2 error
+		})
+	}
+	fake.createBuildWithOverridesReturnsOnCall[i] = struct {
+		result1 db.Build
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeJob) DisableManualTrigger() bool {
 	fake.disableManualTriggerMutex.Lock()
 	ret, specificReturn := fake.disableManualTriggerReturnsOnCall[len(fake.disableManualTriggerArgsForCall)]
@@ -2554,6 +2674,74 @@ func (fake *FakeJob) RerunBuildReturnsOnCall(i int, result1 db.Build, result2 er
 	}{result1, result2}
 }
 
+func (fake *FakeJob) RetryBuild(arg1 db.Build, arg2 int) (db.Build, bool, error) {
+	fake.retryBuildMutex.Lock()
+	ret, specificReturn := fake.retryBuildReturnsOnCall[len(fake.retryBuildArgsForCall)]
+	fake.retryBuildArgsForCall = append(fake.retryBuildArgsForCall, struct {
+		arg1 db.Build
+		arg2 int
+	}{arg1, arg2})
+	stub := fake.RetryBuildStub
+	fakeReturns := fake.retryBuildReturns
+	fake.recordInvocation("RetryBuild", []interface{}{arg1, arg2})
+	fake.retryBuildMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeJob) RetryBuildCallCount() int {
+	fake.retryBuildMutex.RLock()
+	defer fake.retryBuildMutex.RUnlock()
+	return len(fake.retryBuildArgsForCall)
+}
+
+func (fake *FakeJob) RetryBuildCalls(stub func(db.Build, int) (db.Build, bool, error)) {
+	fake.retryBuildMutex.Lock()
+	defer fake.retryBuildMutex.Unlock()
+	fake.RetryBuildStub = stub
+}
+
+func (fake *FakeJob) RetryBuildArgsForCall(i int) (db.Build, int) {
+	fake.retryBuildMutex.RLock()
+	defer fake.retryBuildMutex.RUnlock()
+	argsForCall := fake.retryBuildArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeJob) RetryBuildReturns(result1 db.Build, result2 bool, result3 error) {
+	fake.retryBuildMutex.Lock()
+	defer fake.retryBuildMutex.Unlock()
+	fake.RetryBuildStub = nil
+	fake.retryBuildReturns = struct {
+		result1 db.Build
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeJob) RetryBuildReturnsOnCall(i int, result1 db.Build, result2 bool, result3 error) {
+	fake.retryBuildMutex.Lock()
+	defer fake.retryBuildMutex.Unlock()
+	fake.RetryBuildStub = nil
+	if fake.retryBuildReturnsOnCall == nil {
+		fake.retryBuildReturnsOnCall = make(map[int]struct {
+			result1 db.Build
+			result2 bool
+			result3 error
+		})
+	}
+	fake.retryBuildReturnsOnCall[i] = struct {
+		result1 db.Build
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeJob) SaveNextInputMapping(arg1 db.InputMapping, arg2 bool) error {
 	fake.saveNextInputMappingMutex.Lock()
 	ret, specificReturn := fake.saveNextInputMappingReturnsOnCall[len(fake.saveNextInputMappingArgsForCall)]
@@ -2794,6 +2982,67 @@ func (fake *FakeJob) SetHasNewInputsReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeJob) SetWorkerCapacityReached(arg1 bool) error {
+	fake.setWorkerCapacityReachedMutex.Lock()
+	ret, specificReturn := fake.setWorkerCapacityReachedReturnsOnCall[len(fake.setWorkerCapacityReachedArgsForCall)]
+	fake.setWorkerCapacityReachedArgsForCall = append(fake.setWorkerCapacityReachedArgsForCall, struct {
+		arg1 bool
+	}{arg1})
+	stub := fake.SetWorkerCapacityReachedStub
+	fakeReturns := fake.setWorkerCapacityReachedReturns
+	fake.recordInvocation("SetWorkerCapacityReached", []interface{}{arg1})
+	fake.setWorkerCapacityReachedMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeJob) SetWorkerCapacityReachedCallCount() int {
+	fake.setWorkerCapacityReachedMutex.RLock()
+	defer fake.setWorkerCapacityReachedMutex.RUnlock()
+	return len(fake.setWorkerCapacityReachedArgsForCall)
+}
+
+func (fake *FakeJob) SetWorkerCapacityReachedCalls(stub func(bool) error) {
+	fake.setWorkerCapacityReachedMutex.Lock()
+	defer fake.setWorkerCapacityReachedMutex.Unlock()
+	fake.SetWorkerCapacityReachedStub = stub
+}
+
+func (fake *FakeJob) SetWorkerCapacityReachedArgsForCall(i int) bool {
+	fake.setWorkerCapacityReachedMutex.RLock()
+	defer fake.setWorkerCapacityReachedMutex.RUnlock()
+	argsForCall := fake.setWorkerCapacityReachedArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeJob) SetWorkerCapacityReachedReturns(result1 error) {
+	fake.setWorkerCapacityReachedMutex.Lock()
+	defer fake.setWorkerCapacityReachedMutex.Unlock()
+	fake.SetWorkerCapacityReachedStub = nil
+	fake.setWorkerCapacityReachedReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeJob) SetWorkerCapacityReachedReturnsOnCall(i int, result1 error) {
+	fake.setWorkerCapacityReachedMutex.Lock()
+	defer fake.setWorkerCapacityReachedMutex.Unlock()
+	fake.SetWorkerCapacityReachedStub = nil
+	if fake.setWorkerCapacityReachedReturnsOnCall == nil {
+		fake.setWorkerCapacityReachedReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setWorkerCapacityReachedReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeJob) Tags() []string {
 	fake.tagsMutex.Lock()
 	ret, specificReturn := fake.tagsReturnsOnCall[len(fake.tagsArgsForCall)]
@@ -3129,6 +3378,8 @@ func (fake *FakeJob) UpdateLastScheduledReturnsOnCall(i int, result1 error) {
 }
 
 func (fake *FakeJob) Invocations() map[string][][]interface{} {
+	fake.createBuildWithOverridesMutex.RLock()
+	defer fake.createBuildWithOverridesMutex.RUnlock()
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.acquireSchedulingLockMutex.RLock()
@@ -3201,6 +3452,8 @@ func (fake *FakeJob) Invocations() map[string][][]interface{} {
 	defer fake.requestScheduleMutex.RUnlock()
 	fake.rerunBuildMutex.RLock()
 	defer fake.rerunBuildMutex.RUnlock()
+	fake.retryBuildMutex.RLock()
+	defer fake.retryBuildMutex.RUnlock()
 	fake.saveNextInputMappingMutex.RLock()
 	defer fake.saveNextInputMappingMutex.RUnlock()
 	fake.scheduleBuildMutex.RLock()
@@ -3209,6 +3462,8 @@ func (fake *FakeJob) Invocations() map[string][][]interface{} {
 	defer fake.scheduleRequestedTimeMutex.RUnlock()
 	fake.setHasNewInputsMutex.RLock()
 	defer fake.setHasNewInputsMutex.RUnlock()
+	fake.setWorkerCapacityReachedMutex.RLock()
+	defer fake.setWorkerCapacityReachedMutex.RUnlock()
 	fake.tagsMutex.RLock()
 	defer fake.tagsMutex.RUnlock()
 	fake.teamIDMutex.RLock()
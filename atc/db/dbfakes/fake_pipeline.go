@@ -63,6 +63,20 @@ type FakePipeline struct {
 		result2 db.Pagination
 		result3 error
 	}
+	CandidateConfigStub        func() (db.PipelineConfigVersion, bool, error)
+	candidateConfigMutex       sync.RWMutex
+	candidateConfigArgsForCall []struct {
+	}
+	candidateConfigReturns struct {
+		result1 db.PipelineConfigVersion
+		result2 bool
+		result3 error
+	}
+	candidateConfigReturnsOnCall map[int]struct {
+		result1 db.PipelineConfigVersion
+		result2 bool
+		result3 error
+	}
 	CheckPausedStub        func() (bool, error)
 	checkPausedMutex       sync.RWMutex
 	checkPausedArgsForCall []struct {
@@ -155,6 +169,18 @@ type FakePipeline struct {
 	destroyReturnsOnCall map[int]struct {
 		result1 error
 	}
+	DiscardCandidateConfigStub        func() (bool, error)
+	discardCandidateConfigMutex       sync.RWMutex
+	discardCandidateConfigArgsForCall []struct {
+	}
+	discardCandidateConfigReturns struct {
+		result1 bool
+		result2 error
+	}
+	discardCandidateConfigReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	DisplayStub        func() *atc.DisplayConfig
 	displayMutex       sync.RWMutex
 	displayArgsForCall []struct {
@@ -175,6 +201,27 @@ type FakePipeline struct {
 	exposeReturnsOnCall map[int]struct {
 		result1 error
 	}
+	ExposeWithStub        func(atc.PipelineExposure) error
+	exposeWithMutex       sync.RWMutex
+	exposeWithArgsForCall []struct {
+		arg1 atc.PipelineExposure
+	}
+	exposeWithReturns struct {
+		result1 error
+	}
+	exposeWithReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ExposureStub        func() atc.PipelineExposure
+	exposureMutex       sync.RWMutex
+	exposureArgsForCall []struct {
+	}
+	exposureReturns struct {
+		result1 atc.PipelineExposure
+	}
+	exposureReturnsOnCall map[int]struct {
+		result1 atc.PipelineExposure
+	}
 	GetBuildsWithVersionAsInputStub        func(int, int) ([]db.Build, error)
 	getBuildsWithVersionAsInputMutex       sync.RWMutex
 	getBuildsWithVersionAsInputArgsForCall []struct {
@@ -292,6 +339,17 @@ type FakePipeline struct {
 		result1 *atc.DebugVersionsDB
 		result2 error
 	}
+	MoveToTeamStub        func(int) error
+	moveToTeamMutex       sync.RWMutex
+	moveToTeamArgsForCall []struct {
+		arg1 int
+	}
+	moveToTeamReturns struct {
+		result1 error
+	}
+	moveToTeamReturnsOnCall map[int]struct {
+		result1 error
+	}
 	NameStub        func() string
 	nameMutex       sync.RWMutex
 	nameArgsForCall []struct {
@@ -302,6 +360,16 @@ type FakePipeline struct {
 	nameReturnsOnCall map[int]struct {
 		result1 string
 	}
+	NotificationsStub        func() atc.NotificationRules
+	notificationsMutex       sync.RWMutex
+	notificationsArgsForCall []struct {
+	}
+	notificationsReturns struct {
+		result1 atc.NotificationRules
+	}
+	notificationsReturnsOnCall map[int]struct {
+		result1 atc.NotificationRules
+	}
 	ParentBuildIDStub        func() int
 	parentBuildIDMutex       sync.RWMutex
 	parentBuildIDArgsForCall []struct {
@@ -363,6 +431,18 @@ type FakePipeline struct {
 	pausedByReturnsOnCall map[int]struct {
 		result1 string
 	}
+	PromoteCandidateConfigStub        func() (bool, error)
+	promoteCandidateConfigMutex       sync.RWMutex
+	promoteCandidateConfigArgsForCall []struct {
+	}
+	promoteCandidateConfigReturns struct {
+		result1 bool
+		result2 error
+	}
+	promoteCandidateConfigReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	PrototypeStub        func(string) (db.Prototype, bool, error)
 	prototypeMutex       sync.RWMutex
 	prototypeArgsForCall []struct {
@@ -559,6 +639,19 @@ type FakePipeline struct {
 	setResourceConfigScopeForResourceTypeReturnsOnCall map[int]struct {
 		result1 error
 	}
+	StageConfigStub        func(atc.Config) (db.PipelineConfigVersion, error)
+	stageConfigMutex       sync.RWMutex
+	stageConfigArgsForCall []struct {
+		arg1 atc.Config
+	}
+	stageConfigReturns struct {
+		result1 db.PipelineConfigVersion
+		result2 error
+	}
+	stageConfigReturnsOnCall map[int]struct {
+		result1 db.PipelineConfigVersion
+		result2 error
+	}
 	TeamIDStub        func() int
 	teamIDMutex       sync.RWMutex
 	teamIDArgsForCall []struct {
@@ -579,6 +672,16 @@ type FakePipeline struct {
 	teamNameReturnsOnCall map[int]struct {
 		result1 string
 	}
+	TeamWorkersOnlyStub        func() bool
+	teamWorkersOnlyMutex       sync.RWMutex
+	teamWorkersOnlyArgsForCall []struct {
+	}
+	teamWorkersOnlyReturns struct {
+		result1 bool
+	}
+	teamWorkersOnlyReturnsOnCall map[int]struct {
+		result1 bool
+	}
 	UnpauseStub        func() error
 	unpauseMutex       sync.RWMutex
 	unpauseArgsForCall []struct {
@@ -589,6 +692,16 @@ type FakePipeline struct {
 	unpauseReturnsOnCall map[int]struct {
 		result1 error
 	}
+	VarDeclarationsStub        func() atc.VarDeclarations
+	varDeclarationsMutex       sync.RWMutex
+	varDeclarationsArgsForCall []struct {
+	}
+	varDeclarationsReturns struct {
+		result1 atc.VarDeclarations
+	}
+	varDeclarationsReturnsOnCall map[int]struct {
+		result1 atc.VarDeclarations
+	}
 	VarSourcesStub        func() atc.VarSourceConfigs
 	varSourcesMutex       sync.RWMutex
 	varSourcesArgsForCall []struct {
@@ -858,6 +971,65 @@ func (fake *FakePipeline) BuildsWithTimeReturnsOnCall(i int, result1 []db.BuildF
 	}{result1, result2, result3}
 }
 
+func (fake *FakePipeline) CandidateConfig() (db.PipelineConfigVersion, bool, error) {
+	fake.candidateConfigMutex.Lock()
+	ret, specificReturn := fake.candidateConfigReturnsOnCall[len(fake.candidateConfigArgsForCall)]
+	fake.candidateConfigArgsForCall = append(fake.candidateConfigArgsForCall, struct {
+	}{})
+	stub := fake.CandidateConfigStub
+	fakeReturns := fake.candidateConfigReturns
+	fake.recordInvocation("CandidateConfig", []interface{}{})
+	fake.candidateConfigMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakePipeline) CandidateConfigCallCount() int {
+	fake.candidateConfigMutex.RLock()
+	defer fake.candidateConfigMutex.RUnlock()
+	return len(fake.candidateConfigArgsForCall)
+}
+
+func (fake *FakePipeline) CandidateConfigCalls(stub func() (db.PipelineConfigVersion, bool, error)) {
+	fake.candidateConfigMutex.Lock()
+	defer fake.candidateConfigMutex.Unlock()
+	fake.CandidateConfigStub = stub
+}
+
+func (fake *FakePipeline) CandidateConfigReturns(result1 db.PipelineConfigVersion, result2 bool, result3 error) {
+	fake.candidateConfigMutex.Lock()
+	defer fake.candidateConfigMutex.Unlock()
+	fake.CandidateConfigStub = nil
+	fake.candidateConfigReturns = struct {
+		result1 db.PipelineConfigVersion
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakePipeline) CandidateConfigReturnsOnCall(i int, result1 db.PipelineConfigVersion, result2 bool, result3 error) {
+	fake.candidateConfigMutex.Lock()
+	defer fake.candidateConfigMutex.Unlock()
+	fake.CandidateConfigStub = nil
+	if fake.candidateConfigReturnsOnCall == nil {
+		fake.candidateConfigReturnsOnCall = make(map[int]struct {
+			result1 db.PipelineConfigVersion
+			result2 bool
+			result3 error
+		})
+	}
+	fake.candidateConfigReturnsOnCall[i] = struct {
+		result1 db.PipelineConfigVersion
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakePipeline) CheckPaused() (bool, error) {
 	fake.checkPausedMutex.Lock()
 	ret, specificReturn := fake.checkPausedReturnsOnCall[len(fake.checkPausedArgsForCall)]
@@ -1318,6 +1490,62 @@ func (fake *FakePipeline) DestroyReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakePipeline) DiscardCandidateConfig() (bool, error) {
+	fake.discardCandidateConfigMutex.Lock()
+	ret, specificReturn := fake.discardCandidateConfigReturnsOnCall[len(fake.discardCandidateConfigArgsForCall)]
+	fake.discardCandidateConfigArgsForCall = append(fake.discardCandidateConfigArgsForCall, struct {
+	}{})
+	stub := fake.DiscardCandidateConfigStub
+	fakeReturns := fake.discardCandidateConfigReturns
+	fake.recordInvocation("DiscardCandidateConfig", []interface{}{})
+	fake.discardCandidateConfigMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePipeline) DiscardCandidateConfigCallCount() int {
+	fake.discardCandidateConfigMutex.RLock()
+	defer fake.discardCandidateConfigMutex.RUnlock()
+	return len(fake.discardCandidateConfigArgsForCall)
+}
+
+func (fake *FakePipeline) DiscardCandidateConfigCalls(stub func() (bool, error)) {
+	fake.discardCandidateConfigMutex.Lock()
+	defer fake.discardCandidateConfigMutex.Unlock()
+	fake.DiscardCandidateConfigStub = stub
+}
+
+func (fake *FakePipeline) DiscardCandidateConfigReturns(result1 bool, result2 error) {
+	fake.discardCandidateConfigMutex.Lock()
+	defer fake.discardCandidateConfigMutex.Unlock()
+	fake.DiscardCandidateConfigStub = nil
+	fake.discardCandidateConfigReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) DiscardCandidateConfigReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.discardCandidateConfigMutex.Lock()
+	defer fake.discardCandidateConfigMutex.Unlock()
+	fake.DiscardCandidateConfigStub = nil
+	if fake.discardCandidateConfigReturnsOnCall == nil {
+		fake.discardCandidateConfigReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.discardCandidateConfigReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakePipeline) Display() *atc.DisplayConfig {
 	fake.displayMutex.Lock()
 	ret, specificReturn := fake.displayReturnsOnCall[len(fake.displayArgsForCall)]
@@ -1424,6 +1652,120 @@ func (fake *FakePipeline) ExposeReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakePipeline) ExposeWith(arg1 atc.PipelineExposure) error {
+	fake.exposeWithMutex.Lock()
+	ret, specificReturn := fake.exposeWithReturnsOnCall[len(fake.exposeWithArgsForCall)]
+	fake.exposeWithArgsForCall = append(fake.exposeWithArgsForCall, struct {
+		arg1 atc.PipelineExposure
+	}{arg1})
+	stub := fake.ExposeWithStub
+	fakeReturns := fake.exposeWithReturns
+	fake.recordInvocation("ExposeWith", []interface{}{arg1})
+	fake.exposeWithMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakePipeline) ExposeWithCallCount() int {
+	fake.exposeWithMutex.RLock()
+	defer fake.exposeWithMutex.RUnlock()
+	return len(fake.exposeWithArgsForCall)
+}
+
+func (fake *FakePipeline) ExposeWithCalls(stub func(atc.PipelineExposure) error) {
+	fake.exposeWithMutex.Lock()
+	defer fake.exposeWithMutex.Unlock()
+	fake.ExposeWithStub = stub
+}
+
+func (fake *FakePipeline) ExposeWithArgsForCall(i int) atc.PipelineExposure {
+	fake.exposeWithMutex.RLock()
+	defer fake.exposeWithMutex.RUnlock()
+	argsForCall := fake.exposeWithArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakePipeline) ExposeWithReturns(result1 error) {
+	fake.exposeWithMutex.Lock()
+	defer fake.exposeWithMutex.Unlock()
+	fake.ExposeWithStub = nil
+	fake.exposeWithReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePipeline) ExposeWithReturnsOnCall(i int, result1 error) {
+	fake.exposeWithMutex.Lock()
+	defer fake.exposeWithMutex.Unlock()
+	fake.ExposeWithStub = nil
+	if fake.exposeWithReturnsOnCall == nil {
+		fake.exposeWithReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.exposeWithReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePipeline) Exposure() atc.PipelineExposure {
+	fake.exposureMutex.Lock()
+	ret, specificReturn := fake.exposureReturnsOnCall[len(fake.exposureArgsForCall)]
+	fake.exposureArgsForCall = append(fake.exposureArgsForCall, struct {
+	}{})
+	stub := fake.ExposureStub
+	fakeReturns := fake.exposureReturns
+	fake.recordInvocation("Exposure", []interface{}{})
+	fake.exposureMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakePipeline) ExposureCallCount() int {
+	fake.exposureMutex.RLock()
+	defer fake.exposureMutex.RUnlock()
+	return len(fake.exposureArgsForCall)
+}
+
+func (fake *FakePipeline) ExposureCalls(stub func() atc.PipelineExposure) {
+	fake.exposureMutex.Lock()
+	defer fake.exposureMutex.Unlock()
+	fake.ExposureStub = stub
+}
+
+func (fake *FakePipeline) ExposureReturns(result1 atc.PipelineExposure) {
+	fake.exposureMutex.Lock()
+	defer fake.exposureMutex.Unlock()
+	fake.ExposureStub = nil
+	fake.exposureReturns = struct {
+		result1 atc.PipelineExposure
+	}{result1}
+}
+
+func (fake *FakePipeline) ExposureReturnsOnCall(i int, result1 atc.PipelineExposure) {
+	fake.exposureMutex.Lock()
+	defer fake.exposureMutex.Unlock()
+	fake.ExposureStub = nil
+	if fake.exposureReturnsOnCall == nil {
+		fake.exposureReturnsOnCall = make(map[int]struct {
+			result1 atc.PipelineExposure
+		})
+	}
+	fake.exposureReturnsOnCall[i] = struct {
+		result1 atc.PipelineExposure
+	}{result1}
+}
+
 func (fake *FakePipeline) GetBuildsWithVersionAsInput(arg1 int, arg2 int) ([]db.Build, error) {
 	fake.getBuildsWithVersionAsInputMutex.Lock()
 	ret, specificReturn := fake.getBuildsWithVersionAsInputReturnsOnCall[len(fake.getBuildsWithVersionAsInputArgsForCall)]
@@ -1998,6 +2340,67 @@ func (fake *FakePipeline) LoadDebugVersionsDBReturnsOnCall(i int, result1 *atc.D
 	}{result1, result2}
 }
 
+func (fake *FakePipeline) MoveToTeam(arg1 int) error {
+	fake.moveToTeamMutex.Lock()
+	ret, specificReturn := fake.moveToTeamReturnsOnCall[len(fake.moveToTeamArgsForCall)]
+	fake.moveToTeamArgsForCall = append(fake.moveToTeamArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	stub := fake.MoveToTeamStub
+	fakeReturns := fake.moveToTeamReturns
+	fake.recordInvocation("MoveToTeam", []interface{}{arg1})
+	fake.moveToTeamMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakePipeline) MoveToTeamCallCount() int {
+	fake.moveToTeamMutex.RLock()
+	defer fake.moveToTeamMutex.RUnlock()
+	return len(fake.moveToTeamArgsForCall)
+}
+
+func (fake *FakePipeline) MoveToTeamCalls(stub func(int) error) {
+	fake.moveToTeamMutex.Lock()
+	defer fake.moveToTeamMutex.Unlock()
+	fake.MoveToTeamStub = stub
+}
+
+func (fake *FakePipeline) MoveToTeamArgsForCall(i int) int {
+	fake.moveToTeamMutex.RLock()
+	defer fake.moveToTeamMutex.RUnlock()
+	argsForCall := fake.moveToTeamArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakePipeline) MoveToTeamReturns(result1 error) {
+	fake.moveToTeamMutex.Lock()
+	defer fake.moveToTeamMutex.Unlock()
+	fake.MoveToTeamStub = nil
+	fake.moveToTeamReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePipeline) MoveToTeamReturnsOnCall(i int, result1 error) {
+	fake.moveToTeamMutex.Lock()
+	defer fake.moveToTeamMutex.Unlock()
+	fake.MoveToTeamStub = nil
+	if fake.moveToTeamReturnsOnCall == nil {
+		fake.moveToTeamReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.moveToTeamReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakePipeline) Name() string {
 	fake.nameMutex.Lock()
 	ret, specificReturn := fake.nameReturnsOnCall[len(fake.nameArgsForCall)]
@@ -2051,6 +2454,59 @@ func (fake *FakePipeline) NameReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakePipeline) Notifications() atc.NotificationRules {
+	fake.notificationsMutex.Lock()
+	ret, specificReturn := fake.notificationsReturnsOnCall[len(fake.notificationsArgsForCall)]
+	fake.notificationsArgsForCall = append(fake.notificationsArgsForCall, struct {
+	}{})
+	stub := fake.NotificationsStub
+	fakeReturns := fake.notificationsReturns
+	fake.recordInvocation("Notifications", []interface{}{})
+	fake.notificationsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakePipeline) NotificationsCallCount() int {
+	fake.notificationsMutex.RLock()
+	defer fake.notificationsMutex.RUnlock()
+	return len(fake.notificationsArgsForCall)
+}
+
+func (fake *FakePipeline) NotificationsCalls(stub func() atc.NotificationRules) {
+	fake.notificationsMutex.Lock()
+	defer fake.notificationsMutex.Unlock()
+	fake.NotificationsStub = stub
+}
+
+func (fake *FakePipeline) NotificationsReturns(result1 atc.NotificationRules) {
+	fake.notificationsMutex.Lock()
+	defer fake.notificationsMutex.Unlock()
+	fake.NotificationsStub = nil
+	fake.notificationsReturns = struct {
+		result1 atc.NotificationRules
+	}{result1}
+}
+
+func (fake *FakePipeline) NotificationsReturnsOnCall(i int, result1 atc.NotificationRules) {
+	fake.notificationsMutex.Lock()
+	defer fake.notificationsMutex.Unlock()
+	fake.NotificationsStub = nil
+	if fake.notificationsReturnsOnCall == nil {
+		fake.notificationsReturnsOnCall = make(map[int]struct {
+			result1 atc.NotificationRules
+		})
+	}
+	fake.notificationsReturnsOnCall[i] = struct {
+		result1 atc.NotificationRules
+	}{result1}
+}
+
 func (fake *FakePipeline) ParentBuildID() int {
 	fake.parentBuildIDMutex.Lock()
 	ret, specificReturn := fake.parentBuildIDReturnsOnCall[len(fake.parentBuildIDArgsForCall)]
@@ -2377,6 +2833,62 @@ func (fake *FakePipeline) PausedByReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakePipeline) PromoteCandidateConfig() (bool, error) {
+	fake.promoteCandidateConfigMutex.Lock()
+	ret, specificReturn := fake.promoteCandidateConfigReturnsOnCall[len(fake.promoteCandidateConfigArgsForCall)]
+	fake.promoteCandidateConfigArgsForCall = append(fake.promoteCandidateConfigArgsForCall, struct {
+	}{})
+	stub := fake.PromoteCandidateConfigStub
+	fakeReturns := fake.promoteCandidateConfigReturns
+	fake.recordInvocation("PromoteCandidateConfig", []interface{}{})
+	fake.promoteCandidateConfigMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePipeline) PromoteCandidateConfigCallCount() int {
+	fake.promoteCandidateConfigMutex.RLock()
+	defer fake.promoteCandidateConfigMutex.RUnlock()
+	return len(fake.promoteCandidateConfigArgsForCall)
+}
+
+func (fake *FakePipeline) PromoteCandidateConfigCalls(stub func() (bool, error)) {
+	fake.promoteCandidateConfigMutex.Lock()
+	defer fake.promoteCandidateConfigMutex.Unlock()
+	fake.PromoteCandidateConfigStub = stub
+}
+
+func (fake *FakePipeline) PromoteCandidateConfigReturns(result1 bool, result2 error) {
+	fake.promoteCandidateConfigMutex.Lock()
+	defer fake.promoteCandidateConfigMutex.Unlock()
+	fake.PromoteCandidateConfigStub = nil
+	fake.promoteCandidateConfigReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) PromoteCandidateConfigReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.promoteCandidateConfigMutex.Lock()
+	defer fake.promoteCandidateConfigMutex.Unlock()
+	fake.PromoteCandidateConfigStub = nil
+	if fake.promoteCandidateConfigReturnsOnCall == nil {
+		fake.promoteCandidateConfigReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.promoteCandidateConfigReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakePipeline) Prototype(arg1 string) (db.Prototype, bool, error) {
 	fake.prototypeMutex.Lock()
 	ret, specificReturn := fake.prototypeReturnsOnCall[len(fake.prototypeArgsForCall)]
@@ -3304,6 +3816,70 @@ func (fake *FakePipeline) SetResourceConfigScopeForResourceTypeReturnsOnCall(i i
 	}{result1}
 }
 
+func (fake *FakePipeline) StageConfig(arg1 atc.Config) (db.PipelineConfigVersion, error) {
+	fake.stageConfigMutex.Lock()
+	ret, specificReturn := fake.stageConfigReturnsOnCall[len(fake.stageConfigArgsForCall)]
+	fake.stageConfigArgsForCall = append(fake.stageConfigArgsForCall, struct {
+		arg1 atc.Config
+	}{arg1})
+	stub := fake.StageConfigStub
+	fakeReturns := fake.stageConfigReturns
+	fake.recordInvocation("StageConfig", []interface{}{arg1})
+	fake.stageConfigMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePipeline) StageConfigCallCount() int {
+	fake.stageConfigMutex.RLock()
+	defer fake.stageConfigMutex.RUnlock()
+	return len(fake.stageConfigArgsForCall)
+}
+
+func (fake *FakePipeline) StageConfigCalls(stub func(atc.Config) (db.PipelineConfigVersion, error)) {
+	fake.stageConfigMutex.Lock()
+	defer fake.stageConfigMutex.Unlock()
+	fake.StageConfigStub = stub
+}
+
+func (fake *FakePipeline) StageConfigArgsForCall(i int) atc.Config {
+	fake.stageConfigMutex.RLock()
+	defer fake.stageConfigMutex.RUnlock()
+	argsForCall := fake.stageConfigArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakePipeline) StageConfigReturns(result1 db.PipelineConfigVersion, result2 error) {
+	fake.stageConfigMutex.Lock()
+	defer fake.stageConfigMutex.Unlock()
+	fake.StageConfigStub = nil
+	fake.stageConfigReturns = struct {
+		result1 db.PipelineConfigVersion
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) StageConfigReturnsOnCall(i int, result1 db.PipelineConfigVersion, result2 error) {
+	fake.stageConfigMutex.Lock()
+	defer fake.stageConfigMutex.Unlock()
+	fake.StageConfigStub = nil
+	if fake.stageConfigReturnsOnCall == nil {
+		fake.stageConfigReturnsOnCall = make(map[int]struct {
+			result1 db.PipelineConfigVersion
+			result2 error
+		})
+	}
+	fake.stageConfigReturnsOnCall[i] = struct {
+		result1 db.PipelineConfigVersion
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakePipeline) TeamID() int {
 	fake.teamIDMutex.Lock()
 	ret, specificReturn := fake.teamIDReturnsOnCall[len(fake.teamIDArgsForCall)]
@@ -3410,6 +3986,59 @@ func (fake *FakePipeline) TeamNameReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakePipeline) TeamWorkersOnly() bool {
+	fake.teamWorkersOnlyMutex.Lock()
+	ret, specificReturn := fake.teamWorkersOnlyReturnsOnCall[len(fake.teamWorkersOnlyArgsForCall)]
+	fake.teamWorkersOnlyArgsForCall = append(fake.teamWorkersOnlyArgsForCall, struct {
+	}{})
+	stub := fake.TeamWorkersOnlyStub
+	fakeReturns := fake.teamWorkersOnlyReturns
+	fake.recordInvocation("TeamWorkersOnly", []interface{}{})
+	fake.teamWorkersOnlyMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakePipeline) TeamWorkersOnlyCallCount() int {
+	fake.teamWorkersOnlyMutex.RLock()
+	defer fake.teamWorkersOnlyMutex.RUnlock()
+	return len(fake.teamWorkersOnlyArgsForCall)
+}
+
+func (fake *FakePipeline) TeamWorkersOnlyCalls(stub func() bool) {
+	fake.teamWorkersOnlyMutex.Lock()
+	defer fake.teamWorkersOnlyMutex.Unlock()
+	fake.TeamWorkersOnlyStub = stub
+}
+
+func (fake *FakePipeline) TeamWorkersOnlyReturns(result1 bool) {
+	fake.teamWorkersOnlyMutex.Lock()
+	defer fake.teamWorkersOnlyMutex.Unlock()
+	fake.TeamWorkersOnlyStub = nil
+	fake.teamWorkersOnlyReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakePipeline) TeamWorkersOnlyReturnsOnCall(i int, result1 bool) {
+	fake.teamWorkersOnlyMutex.Lock()
+	defer fake.teamWorkersOnlyMutex.Unlock()
+	fake.TeamWorkersOnlyStub = nil
+	if fake.teamWorkersOnlyReturnsOnCall == nil {
+		fake.teamWorkersOnlyReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.teamWorkersOnlyReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
 func (fake *FakePipeline) Unpause() error {
 	fake.unpauseMutex.Lock()
 	ret, specificReturn := fake.unpauseReturnsOnCall[len(fake.unpauseArgsForCall)]
@@ -3463,6 +4092,59 @@ func (fake *FakePipeline) UnpauseReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakePipeline) VarDeclarations() atc.VarDeclarations {
+	fake.varDeclarationsMutex.Lock()
+	ret, specificReturn := fake.varDeclarationsReturnsOnCall[len(fake.varDeclarationsArgsForCall)]
+	fake.varDeclarationsArgsForCall = append(fake.varDeclarationsArgsForCall, struct {
+	}{})
+	stub := fake.VarDeclarationsStub
+	fakeReturns := fake.varDeclarationsReturns
+	fake.recordInvocation("VarDeclarations", []interface{}{})
+	fake.varDeclarationsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakePipeline) VarDeclarationsCallCount() int {
+	fake.varDeclarationsMutex.RLock()
+	defer fake.varDeclarationsMutex.RUnlock()
+	return len(fake.varDeclarationsArgsForCall)
+}
+
+func (fake *FakePipeline) VarDeclarationsCalls(stub func() atc.VarDeclarations) {
+	fake.varDeclarationsMutex.Lock()
+	defer fake.varDeclarationsMutex.Unlock()
+	fake.VarDeclarationsStub = stub
+}
+
+func (fake *FakePipeline) VarDeclarationsReturns(result1 atc.VarDeclarations) {
+	fake.varDeclarationsMutex.Lock()
+	defer fake.varDeclarationsMutex.Unlock()
+	fake.VarDeclarationsStub = nil
+	fake.varDeclarationsReturns = struct {
+		result1 atc.VarDeclarations
+	}{result1}
+}
+
+func (fake *FakePipeline) VarDeclarationsReturnsOnCall(i int, result1 atc.VarDeclarations) {
+	fake.varDeclarationsMutex.Lock()
+	defer fake.varDeclarationsMutex.Unlock()
+	fake.VarDeclarationsStub = nil
+	if fake.varDeclarationsReturnsOnCall == nil {
+		fake.varDeclarationsReturnsOnCall = make(map[int]struct {
+			result1 atc.VarDeclarations
+		})
+	}
+	fake.varDeclarationsReturnsOnCall[i] = struct {
+		result1 atc.VarDeclarations
+	}{result1}
+}
+
 func (fake *FakePipeline) VarSources() atc.VarSourceConfigs {
 	fake.varSourcesMutex.Lock()
 	ret, specificReturn := fake.varSourcesReturnsOnCall[len(fake.varSourcesArgsForCall)]
@@ -3583,6 +4265,14 @@ func (fake *FakePipeline) VariablesReturnsOnCall(i int, result1 vars.Variables,
 }
 
 func (fake *FakePipeline) Invocations() map[string][][]interface{} {
+	fake.candidateConfigMutex.RLock()
+	defer fake.candidateConfigMutex.RUnlock()
+	fake.discardCandidateConfigMutex.RLock()
+	defer fake.discardCandidateConfigMutex.RUnlock()
+	fake.exposeWithMutex.RLock()
+	defer fake.exposeWithMutex.RUnlock()
+	fake.exposureMutex.RLock()
+	defer fake.exposureMutex.RUnlock()
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.archiveMutex.RLock()
@@ -3633,8 +4323,12 @@ func (fake *FakePipeline) Invocations() map[string][][]interface{} {
 	defer fake.lastUpdatedMutex.RUnlock()
 	fake.loadDebugVersionsDBMutex.RLock()
 	defer fake.loadDebugVersionsDBMutex.RUnlock()
+	fake.moveToTeamMutex.RLock()
+	defer fake.moveToTeamMutex.RUnlock()
 	fake.nameMutex.RLock()
 	defer fake.nameMutex.RUnlock()
+	fake.notificationsMutex.RLock()
+	defer fake.notificationsMutex.RUnlock()
 	fake.parentBuildIDMutex.RLock()
 	defer fake.parentBuildIDMutex.RUnlock()
 	fake.parentJobIDMutex.RLock()
@@ -3647,6 +4341,8 @@ func (fake *FakePipeline) Invocations() map[string][][]interface{} {
 	defer fake.pausedAtMutex.RUnlock()
 	fake.pausedByMutex.RLock()
 	defer fake.pausedByMutex.RUnlock()
+	fake.promoteCandidateConfigMutex.RLock()
+	defer fake.promoteCandidateConfigMutex.RUnlock()
 	fake.prototypeMutex.RLock()
 	defer fake.prototypeMutex.RUnlock()
 	fake.prototypesMutex.RLock()
@@ -3677,12 +4373,18 @@ func (fake *FakePipeline) Invocations() map[string][][]interface{} {
 	defer fake.setResourceConfigScopeForResourceMutex.RUnlock()
 	fake.setResourceConfigScopeForResourceTypeMutex.RLock()
 	defer fake.setResourceConfigScopeForResourceTypeMutex.RUnlock()
+	fake.stageConfigMutex.RLock()
+	defer fake.stageConfigMutex.RUnlock()
 	fake.teamIDMutex.RLock()
 	defer fake.teamIDMutex.RUnlock()
 	fake.teamNameMutex.RLock()
 	defer fake.teamNameMutex.RUnlock()
+	fake.teamWorkersOnlyMutex.RLock()
+	defer fake.teamWorkersOnlyMutex.RUnlock()
 	fake.unpauseMutex.RLock()
 	defer fake.unpauseMutex.RUnlock()
+	fake.varDeclarationsMutex.RLock()
+	defer fake.varDeclarationsMutex.RUnlock()
 	fake.varSourcesMutex.RLock()
 	defer fake.varSourcesMutex.RUnlock()
 	fake.variablesMutex.RLock()
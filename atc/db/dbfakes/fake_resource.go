@@ -183,6 +183,25 @@ type FakeResource struct {
 	disableVersionReturnsOnCall map[int]struct {
 		result1 error
 	}
+	DownstreamImpactStub        func(int, bool, db.Page) ([]atc.ImpactedBuild, db.Pagination, bool, error)
+	downstreamImpactMutex       sync.RWMutex
+	downstreamImpactArgsForCall []struct {
+		arg1 int
+		arg2 bool
+		arg3 db.Page
+	}
+	downstreamImpactReturns struct {
+		result1 []atc.ImpactedBuild
+		result2 db.Pagination
+		result3 bool
+		result4 error
+	}
+	downstreamImpactReturnsOnCall map[int]struct {
+		result1 []atc.ImpactedBuild
+		result2 db.Pagination
+		result3 bool
+		result4 error
+	}
 	EnableVersionStub        func(int) error
 	enableVersionMutex       sync.RWMutex
 	enableVersionArgsForCall []struct {
@@ -259,6 +278,20 @@ type FakeResource struct {
 	lastCheckStartTimeReturnsOnCall map[int]struct {
 		result1 time.Time
 	}
+	MetadataPlanStub        func(atc.PlanFactory, atc.ImagePlanner, []atc.Version, atc.Source) atc.Plan
+	metadataPlanMutex       sync.RWMutex
+	metadataPlanArgsForCall []struct {
+		arg1 atc.PlanFactory
+		arg2 atc.ImagePlanner
+		arg3 []atc.Version
+		arg4 atc.Source
+	}
+	metadataPlanReturns struct {
+		result1 atc.Plan
+	}
+	metadataPlanReturnsOnCall map[int]struct {
+		result1 atc.Plan
+	}
 	NameStub        func() string
 	nameMutex       sync.RWMutex
 	nameArgsForCall []struct {
@@ -366,6 +399,18 @@ type FakeResource struct {
 	publicReturnsOnCall map[int]struct {
 		result1 bool
 	}
+	PushVersionsStub        func(db.SpanContext, []atc.Version) error
+	pushVersionsMutex       sync.RWMutex
+	pushVersionsArgsForCall []struct {
+		arg1 db.SpanContext
+		arg2 []atc.Version
+	}
+	pushVersionsReturns struct {
+		result1 error
+	}
+	pushVersionsReturnsOnCall map[int]struct {
+		result1 error
+	}
 	ReloadStub        func() (bool, error)
 	reloadMutex       sync.RWMutex
 	reloadArgsForCall []struct {
@@ -378,6 +423,19 @@ type FakeResource struct {
 		result1 bool
 		result2 error
 	}
+	ResetCheckFromStub        func(atc.Version) (bool, error)
+	resetCheckFromMutex       sync.RWMutex
+	resetCheckFromArgsForCall []struct {
+		arg1 atc.Version
+	}
+	resetCheckFromReturns struct {
+		result1 bool
+		result2 error
+	}
+	resetCheckFromReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	ResourceConfigIDStub        func() int
 	resourceConfigIDMutex       sync.RWMutex
 	resourceConfigIDArgsForCall []struct {
@@ -506,6 +564,21 @@ type FakeResource struct {
 		result1 bool
 		result2 error
 	}
+	VersionByIDStub        func(int) (atc.Version, bool, error)
+	versionByIDMutex       sync.RWMutex
+	versionByIDArgsForCall []struct {
+		arg1 int
+	}
+	versionByIDReturns struct {
+		result1 atc.Version
+		result2 bool
+		result3 error
+	}
+	versionByIDReturnsOnCall map[int]struct {
+		result1 atc.Version
+		result2 bool
+		result3 error
+	}
 	VersionsStub        func(db.Page, atc.Version) ([]atc.ResourceVersion, db.Pagination, bool, error)
 	versionsMutex       sync.RWMutex
 	versionsArgsForCall []struct {
@@ -524,6 +597,18 @@ type FakeResource struct {
 		result3 bool
 		result4 error
 	}
+	VersionsWithoutMetadataStub        func() ([]atc.Version, error)
+	versionsWithoutMetadataMutex       sync.RWMutex
+	versionsWithoutMetadataArgsForCall []struct {
+	}
+	versionsWithoutMetadataReturns struct {
+		result1 []atc.Version
+		result2 error
+	}
+	versionsWithoutMetadataReturnsOnCall map[int]struct {
+		result1 []atc.Version
+		result2 error
+	}
 	WebhookTokenStub        func() string
 	webhookTokenMutex       sync.RWMutex
 	webhookTokenArgsForCall []struct {
@@ -1360,6 +1445,78 @@ func (fake *FakeResource) DisableVersionReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeResource) DownstreamImpact(arg1 int, arg2 bool, arg3 db.Page) ([]atc.ImpactedBuild, db.Pagination, bool, error) {
+	fake.downstreamImpactMutex.Lock()
+	ret, specificReturn := fake.downstreamImpactReturnsOnCall[len(fake.downstreamImpactArgsForCall)]
+	fake.downstreamImpactArgsForCall = append(fake.downstreamImpactArgsForCall, struct {
+		arg1 int
+		arg2 bool
+		arg3 db.Page
+	}{arg1, arg2, arg3})
+	stub := fake.DownstreamImpactStub
+	fakeReturns := fake.downstreamImpactReturns
+	fake.recordInvocation("DownstreamImpact", []interface{}{arg1, arg2, arg3})
+	fake.downstreamImpactMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3, ret.result4
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3, fakeReturns.result4
+}
+
+func (fake *FakeResource) DownstreamImpactCallCount() int {
+	fake.downstreamImpactMutex.RLock()
+	defer fake.downstreamImpactMutex.RUnlock()
+	return len(fake.downstreamImpactArgsForCall)
+}
+
+func (fake *FakeResource) DownstreamImpactCalls(stub func(int, bool, db.Page) ([]atc.ImpactedBuild, db.Pagination, bool, error)) {
+	fake.downstreamImpactMutex.Lock()
+	defer fake.downstreamImpactMutex.Unlock()
+	fake.DownstreamImpactStub = stub
+}
+
+func (fake *FakeResource) DownstreamImpactArgsForCall(i int) (int, bool, db.Page) {
+	fake.downstreamImpactMutex.RLock()
+	defer fake.downstreamImpactMutex.RUnlock()
+	argsForCall := fake.downstreamImpactArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeResource) DownstreamImpactReturns(result1 []atc.ImpactedBuild, result2 db.Pagination, result3 bool, result4 error) {
+	fake.downstreamImpactMutex.Lock()
+	defer fake.downstreamImpactMutex.Unlock()
+	fake.DownstreamImpactStub = nil
+	fake.downstreamImpactReturns = struct {
+		result1 []atc.ImpactedBuild
+		result2 db.Pagination
+		result3 bool
+		result4 error
+	}{result1, result2, result3, result4}
+}
+
+func (fake *FakeResource) DownstreamImpactReturnsOnCall(i int, result1 []atc.ImpactedBuild, result2 db.Pagination, result3 bool, result4 error) {
+	fake.downstreamImpactMutex.Lock()
+	defer fake.downstreamImpactMutex.Unlock()
+	fake.DownstreamImpactStub = nil
+	if fake.downstreamImpactReturnsOnCall == nil {
+		fake.downstreamImpactReturnsOnCall = make(map[int]struct {
+			result1 []atc.ImpactedBuild
+			result2 db.Pagination
+			result3 bool
+			result4 error
+		})
+	}
+	fake.downstreamImpactReturnsOnCall[i] = struct {
+		result1 []atc.ImpactedBuild
+		result2 db.Pagination
+		result3 bool
+		result4 error
+	}{result1, result2, result3, result4}
+}
+
 func (fake *FakeResource) EnableVersion(arg1 int) error {
 	fake.enableVersionMutex.Lock()
 	ret, specificReturn := fake.enableVersionReturnsOnCall[len(fake.enableVersionArgsForCall)]
@@ -1753,6 +1910,75 @@ func (fake *FakeResource) LastCheckStartTimeReturnsOnCall(i int, result1 time.Ti
 	}{result1}
 }
 
+func (fake *FakeResource) MetadataPlan(arg1 atc.PlanFactory, arg2 atc.ImagePlanner, arg3 []atc.Version, arg4 atc.Source) atc.Plan {
+	var arg3Copy []atc.Version
+	if arg3 != nil {
+		arg3Copy = make([]atc.Version, len(arg3))
+		copy(arg3Copy, arg3)
+	}
+	fake.metadataPlanMutex.Lock()
+	ret, specificReturn := fake.metadataPlanReturnsOnCall[len(fake.metadataPlanArgsForCall)]
+	fake.metadataPlanArgsForCall = append(fake.metadataPlanArgsForCall, struct {
+		arg1 atc.PlanFactory
+		arg2 atc.ImagePlanner
+		arg3 []atc.Version
+		arg4 atc.Source
+	}{arg1, arg2, arg3Copy, arg4})
+	stub := fake.MetadataPlanStub
+	fakeReturns := fake.metadataPlanReturns
+	fake.recordInvocation("MetadataPlan", []interface{}{arg1, arg2, arg3Copy, arg4})
+	fake.metadataPlanMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeResource) MetadataPlanCallCount() int {
+	fake.metadataPlanMutex.RLock()
+	defer fake.metadataPlanMutex.RUnlock()
+	return len(fake.metadataPlanArgsForCall)
+}
+
+func (fake *FakeResource) MetadataPlanCalls(stub func(atc.PlanFactory, atc.ImagePlanner, []atc.Version, atc.Source) atc.Plan) {
+	fake.metadataPlanMutex.Lock()
+	defer fake.metadataPlanMutex.Unlock()
+	fake.MetadataPlanStub = stub
+}
+
+func (fake *FakeResource) MetadataPlanArgsForCall(i int) (atc.PlanFactory, atc.ImagePlanner, []atc.Version, atc.Source) {
+	fake.metadataPlanMutex.RLock()
+	defer fake.metadataPlanMutex.RUnlock()
+	argsForCall := fake.metadataPlanArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeResource) MetadataPlanReturns(result1 atc.Plan) {
+	fake.metadataPlanMutex.Lock()
+	defer fake.metadataPlanMutex.Unlock()
+	fake.MetadataPlanStub = nil
+	fake.metadataPlanReturns = struct {
+		result1 atc.Plan
+	}{result1}
+}
+
+func (fake *FakeResource) MetadataPlanReturnsOnCall(i int, result1 atc.Plan) {
+	fake.metadataPlanMutex.Lock()
+	defer fake.metadataPlanMutex.Unlock()
+	fake.MetadataPlanStub = nil
+	if fake.metadataPlanReturnsOnCall == nil {
+		fake.metadataPlanReturnsOnCall = make(map[int]struct {
+			result1 atc.Plan
+		})
+	}
+	fake.metadataPlanReturnsOnCall[i] = struct {
+		result1 atc.Plan
+	}{result1}
+}
+
 func (fake *FakeResource) Name() string {
 	fake.nameMutex.Lock()
 	ret, specificReturn := fake.nameReturnsOnCall[len(fake.nameArgsForCall)]
@@ -2300,6 +2526,73 @@ func (fake *FakeResource) PublicReturnsOnCall(i int, result1 bool) {
 	}{result1}
 }
 
+func (fake *FakeResource) PushVersions(arg1 db.SpanContext, arg2 []atc.Version) error {
+	var arg2Copy []atc.Version
+	if arg2 != nil {
+		arg2Copy = make([]atc.Version, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.pushVersionsMutex.Lock()
+	ret, specificReturn := fake.pushVersionsReturnsOnCall[len(fake.pushVersionsArgsForCall)]
+	fake.pushVersionsArgsForCall = append(fake.pushVersionsArgsForCall, struct {
+		arg1 db.SpanContext
+		arg2 []atc.Version
+	}{arg1, arg2Copy})
+	stub := fake.PushVersionsStub
+	fakeReturns := fake.pushVersionsReturns
+	fake.recordInvocation("PushVersions", []interface{}{arg1, arg2Copy})
+	fake.pushVersionsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeResource) PushVersionsCallCount() int {
+	fake.pushVersionsMutex.RLock()
+	defer fake.pushVersionsMutex.RUnlock()
+	return len(fake.pushVersionsArgsForCall)
+}
+
+func (fake *FakeResource) PushVersionsCalls(stub func(db.SpanContext, []atc.Version) error) {
+	fake.pushVersionsMutex.Lock()
+	defer fake.pushVersionsMutex.Unlock()
+	fake.PushVersionsStub = stub
+}
+
+func (fake *FakeResource) PushVersionsArgsForCall(i int) (db.SpanContext, []atc.Version) {
+	fake.pushVersionsMutex.RLock()
+	defer fake.pushVersionsMutex.RUnlock()
+	argsForCall := fake.pushVersionsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeResource) PushVersionsReturns(result1 error) {
+	fake.pushVersionsMutex.Lock()
+	defer fake.pushVersionsMutex.Unlock()
+	fake.PushVersionsStub = nil
+	fake.pushVersionsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeResource) PushVersionsReturnsOnCall(i int, result1 error) {
+	fake.pushVersionsMutex.Lock()
+	defer fake.pushVersionsMutex.Unlock()
+	fake.PushVersionsStub = nil
+	if fake.pushVersionsReturnsOnCall == nil {
+		fake.pushVersionsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.pushVersionsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeResource) Reload() (bool, error) {
 	fake.reloadMutex.Lock()
 	ret, specificReturn := fake.reloadReturnsOnCall[len(fake.reloadArgsForCall)]
@@ -2356,6 +2649,70 @@ func (fake *FakeResource) ReloadReturnsOnCall(i int, result1 bool, result2 error
 	}{result1, result2}
 }
 
+func (fake *FakeResource) ResetCheckFrom(arg1 atc.Version) (bool, error) {
+	fake.resetCheckFromMutex.Lock()
+	ret, specificReturn := fake.resetCheckFromReturnsOnCall[len(fake.resetCheckFromArgsForCall)]
+	fake.resetCheckFromArgsForCall = append(fake.resetCheckFromArgsForCall, struct {
+		arg1 atc.Version
+	}{arg1})
+	stub := fake.ResetCheckFromStub
+	fakeReturns := fake.resetCheckFromReturns
+	fake.recordInvocation("ResetCheckFrom", []interface{}{arg1})
+	fake.resetCheckFromMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeResource) ResetCheckFromCallCount() int {
+	fake.resetCheckFromMutex.RLock()
+	defer fake.resetCheckFromMutex.RUnlock()
+	return len(fake.resetCheckFromArgsForCall)
+}
+
+func (fake *FakeResource) ResetCheckFromCalls(stub func(atc.Version) (bool, error)) {
+	fake.resetCheckFromMutex.Lock()
+	defer fake.resetCheckFromMutex.Unlock()
+	fake.ResetCheckFromStub = stub
+}
+
+func (fake *FakeResource) ResetCheckFromArgsForCall(i int) atc.Version {
+	fake.resetCheckFromMutex.RLock()
+	defer fake.resetCheckFromMutex.RUnlock()
+	argsForCall := fake.resetCheckFromArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeResource) ResetCheckFromReturns(result1 bool, result2 error) {
+	fake.resetCheckFromMutex.Lock()
+	defer fake.resetCheckFromMutex.Unlock()
+	fake.ResetCheckFromStub = nil
+	fake.resetCheckFromReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeResource) ResetCheckFromReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.resetCheckFromMutex.Lock()
+	defer fake.resetCheckFromMutex.Unlock()
+	fake.ResetCheckFromStub = nil
+	if fake.resetCheckFromReturnsOnCall == nil {
+		fake.resetCheckFromReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.resetCheckFromReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeResource) ResourceConfigID() int {
 	fake.resourceConfigIDMutex.Lock()
 	ret, specificReturn := fake.resourceConfigIDReturnsOnCall[len(fake.resourceConfigIDArgsForCall)]
@@ -3023,6 +3380,73 @@ func (fake *FakeResource) UpdateMetadataReturnsOnCall(i int, result1 bool, resul
 	}{result1, result2}
 }
 
+func (fake *FakeResource) VersionByID(arg1 int) (atc.Version, bool, error) {
+	fake.versionByIDMutex.Lock()
+	ret, specificReturn := fake.versionByIDReturnsOnCall[len(fake.versionByIDArgsForCall)]
+	fake.versionByIDArgsForCall = append(fake.versionByIDArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	stub := fake.VersionByIDStub
+	fakeReturns := fake.versionByIDReturns
+	fake.recordInvocation("VersionByID", []interface{}{arg1})
+	fake.versionByIDMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeResource) VersionByIDCallCount() int {
+	fake.versionByIDMutex.RLock()
+	defer fake.versionByIDMutex.RUnlock()
+	return len(fake.versionByIDArgsForCall)
+}
+
+func (fake *FakeResource) VersionByIDCalls(stub func(int) (atc.Version, bool, error)) {
+	fake.versionByIDMutex.Lock()
+	defer fake.versionByIDMutex.Unlock()
+	fake.VersionByIDStub = stub
+}
+
+func (fake *FakeResource) VersionByIDArgsForCall(i int) int {
+	fake.versionByIDMutex.RLock()
+	defer fake.versionByIDMutex.RUnlock()
+	argsForCall := fake.versionByIDArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeResource) VersionByIDReturns(result1 atc.Version, result2 bool, result3 error) {
+	fake.versionByIDMutex.Lock()
+	defer fake.versionByIDMutex.Unlock()
+	fake.VersionByIDStub = nil
+	fake.versionByIDReturns = struct {
+		result1 atc.Version
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeResource) VersionByIDReturnsOnCall(i int, result1 atc.Version, result2 bool, result3 error) {
+	fake.versionByIDMutex.Lock()
+	defer fake.versionByIDMutex.Unlock()
+	fake.VersionByIDStub = nil
+	if fake.versionByIDReturnsOnCall == nil {
+		fake.versionByIDReturnsOnCall = make(map[int]struct {
+			result1 atc.Version
+			result2 bool
+			result3 error
+		})
+	}
+	fake.versionByIDReturnsOnCall[i] = struct {
+		result1 atc.Version
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeResource) Versions(arg1 db.Page, arg2 atc.Version) ([]atc.ResourceVersion, db.Pagination, bool, error) {
 	fake.versionsMutex.Lock()
 	ret, specificReturn := fake.versionsReturnsOnCall[len(fake.versionsArgsForCall)]
@@ -3094,6 +3518,62 @@ func (fake *FakeResource) VersionsReturnsOnCall(i int, result1 []atc.ResourceVer
 	}{result1, result2, result3, result4}
 }
 
+func (fake *FakeResource) VersionsWithoutMetadata() ([]atc.Version, error) {
+	fake.versionsWithoutMetadataMutex.Lock()
+	ret, specificReturn := fake.versionsWithoutMetadataReturnsOnCall[len(fake.versionsWithoutMetadataArgsForCall)]
+	fake.versionsWithoutMetadataArgsForCall = append(fake.versionsWithoutMetadataArgsForCall, struct {
+	}{})
+	stub := fake.VersionsWithoutMetadataStub
+	fakeReturns := fake.versionsWithoutMetadataReturns
+	fake.recordInvocation("VersionsWithoutMetadata", []interface{}{})
+	fake.versionsWithoutMetadataMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeResource) VersionsWithoutMetadataCallCount() int {
+	fake.versionsWithoutMetadataMutex.RLock()
+	defer fake.versionsWithoutMetadataMutex.RUnlock()
+	return len(fake.versionsWithoutMetadataArgsForCall)
+}
+
+func (fake *FakeResource) VersionsWithoutMetadataCalls(stub func() ([]atc.Version, error)) {
+	fake.versionsWithoutMetadataMutex.Lock()
+	defer fake.versionsWithoutMetadataMutex.Unlock()
+	fake.VersionsWithoutMetadataStub = stub
+}
+
+func (fake *FakeResource) VersionsWithoutMetadataReturns(result1 []atc.Version, result2 error) {
+	fake.versionsWithoutMetadataMutex.Lock()
+	defer fake.versionsWithoutMetadataMutex.Unlock()
+	fake.VersionsWithoutMetadataStub = nil
+	fake.versionsWithoutMetadataReturns = struct {
+		result1 []atc.Version
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeResource) VersionsWithoutMetadataReturnsOnCall(i int, result1 []atc.Version, result2 error) {
+	fake.versionsWithoutMetadataMutex.Lock()
+	defer fake.versionsWithoutMetadataMutex.Unlock()
+	fake.VersionsWithoutMetadataStub = nil
+	if fake.versionsWithoutMetadataReturnsOnCall == nil {
+		fake.versionsWithoutMetadataReturnsOnCall = make(map[int]struct {
+			result1 []atc.Version
+			result2 error
+		})
+	}
+	fake.versionsWithoutMetadataReturnsOnCall[i] = struct {
+		result1 []atc.Version
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeResource) WebhookToken() string {
 	fake.webhookTokenMutex.Lock()
 	ret, specificReturn := fake.webhookTokenReturnsOnCall[len(fake.webhookTokenArgsForCall)]
@@ -3150,6 +3630,8 @@ func (fake *FakeResource) WebhookTokenReturnsOnCall(i int, result1 string) {
 func (fake *FakeResource) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.downstreamImpactMutex.RLock()
+	defer fake.downstreamImpactMutex.RUnlock()
 	fake.aPIPinnedVersionMutex.RLock()
 	defer fake.aPIPinnedVersionMutex.RUnlock()
 	fake.buildSummaryMutex.RLock()
@@ -3192,6 +3674,8 @@ func (fake *FakeResource) Invocations() map[string][][]interface{} {
 	defer fake.lastCheckEndTimeMutex.RUnlock()
 	fake.lastCheckStartTimeMutex.RLock()
 	defer fake.lastCheckStartTimeMutex.RUnlock()
+	fake.metadataPlanMutex.RLock()
+	defer fake.metadataPlanMutex.RUnlock()
 	fake.nameMutex.RLock()
 	defer fake.nameMutex.RUnlock()
 	fake.notifyScanMutex.RLock()
@@ -3212,8 +3696,12 @@ func (fake *FakeResource) Invocations() map[string][][]interface{} {
 	defer fake.pipelineRefMutex.RUnlock()
 	fake.publicMutex.RLock()
 	defer fake.publicMutex.RUnlock()
+	fake.pushVersionsMutex.RLock()
+	defer fake.pushVersionsMutex.RUnlock()
 	fake.reloadMutex.RLock()
 	defer fake.reloadMutex.RUnlock()
+	fake.resetCheckFromMutex.RLock()
+	defer fake.resetCheckFromMutex.RUnlock()
 	fake.resourceConfigIDMutex.RLock()
 	defer fake.resourceConfigIDMutex.RUnlock()
 	fake.resourceConfigScopeIDMutex.RLock()
@@ -3238,8 +3726,12 @@ func (fake *FakeResource) Invocations() map[string][][]interface{} {
 	defer fake.unpinVersionMutex.RUnlock()
 	fake.updateMetadataMutex.RLock()
 	defer fake.updateMetadataMutex.RUnlock()
+	fake.versionByIDMutex.RLock()
+	defer fake.versionByIDMutex.RUnlock()
 	fake.versionsMutex.RLock()
 	defer fake.versionsMutex.RUnlock()
+	fake.versionsWithoutMetadataMutex.RLock()
+	defer fake.versionsWithoutMetadataMutex.RUnlock()
 	fake.webhookTokenMutex.RLock()
 	defer fake.webhookTokenMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
@@ -8,6 +8,16 @@ import (
 )
 
 type FakeResourceConfigCheckSessionLifecycle struct {
+	CleanExpiredCheckContainerPoolSlotsStub        func() error
+	cleanExpiredCheckContainerPoolSlotsMutex       sync.RWMutex
+	cleanExpiredCheckContainerPoolSlotsArgsForCall []struct {
+	}
+	cleanExpiredCheckContainerPoolSlotsReturns struct {
+		result1 error
+	}
+	cleanExpiredCheckContainerPoolSlotsReturnsOnCall map[int]struct {
+		result1 error
+	}
 	CleanExpiredResourceConfigCheckSessionsStub        func() error
 	cleanExpiredResourceConfigCheckSessionsMutex       sync.RWMutex
 	cleanExpiredResourceConfigCheckSessionsArgsForCall []struct {
@@ -32,6 +42,59 @@ type FakeResourceConfigCheckSessionLifecycle struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeResourceConfigCheckSessionLifecycle) CleanExpiredCheckContainerPoolSlots() error {
+	fake.cleanExpiredCheckContainerPoolSlotsMutex.Lock()
+	ret, specificReturn := fake.cleanExpiredCheckContainerPoolSlotsReturnsOnCall[len(fake.cleanExpiredCheckContainerPoolSlotsArgsForCall)]
+	fake.cleanExpiredCheckContainerPoolSlotsArgsForCall = append(fake.cleanExpiredCheckContainerPoolSlotsArgsForCall, struct {
+	}{})
+	stub := fake.CleanExpiredCheckContainerPoolSlotsStub
+	fakeReturns := fake.cleanExpiredCheckContainerPoolSlotsReturns
+	fake.recordInvocation("CleanExpiredCheckContainerPoolSlots", []interface{}{})
+	fake.cleanExpiredCheckContainerPoolSlotsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeResourceConfigCheckSessionLifecycle) CleanExpiredCheckContainerPoolSlotsCallCount() int {
+	fake.cleanExpiredCheckContainerPoolSlotsMutex.RLock()
+	defer fake.cleanExpiredCheckContainerPoolSlotsMutex.RUnlock()
+	return len(fake.cleanExpiredCheckContainerPoolSlotsArgsForCall)
+}
+
+func (fake *FakeResourceConfigCheckSessionLifecycle) CleanExpiredCheckContainerPoolSlotsCalls(stub func() error) {
+	fake.cleanExpiredCheckContainerPoolSlotsMutex.Lock()
+	defer fake.cleanExpiredCheckContainerPoolSlotsMutex.Unlock()
+	fake.CleanExpiredCheckContainerPoolSlotsStub = stub
+}
+
+func (fake *FakeResourceConfigCheckSessionLifecycle) CleanExpiredCheckContainerPoolSlotsReturns(result1 error) {
+	fake.cleanExpiredCheckContainerPoolSlotsMutex.Lock()
+	defer fake.cleanExpiredCheckContainerPoolSlotsMutex.Unlock()
+	fake.CleanExpiredCheckContainerPoolSlotsStub = nil
+	fake.cleanExpiredCheckContainerPoolSlotsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeResourceConfigCheckSessionLifecycle) CleanExpiredCheckContainerPoolSlotsReturnsOnCall(i int, result1 error) {
+	fake.cleanExpiredCheckContainerPoolSlotsMutex.Lock()
+	defer fake.cleanExpiredCheckContainerPoolSlotsMutex.Unlock()
+	fake.CleanExpiredCheckContainerPoolSlotsStub = nil
+	if fake.cleanExpiredCheckContainerPoolSlotsReturnsOnCall == nil {
+		fake.cleanExpiredCheckContainerPoolSlotsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.cleanExpiredCheckContainerPoolSlotsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeResourceConfigCheckSessionLifecycle) CleanExpiredResourceConfigCheckSessions() error {
 	fake.cleanExpiredResourceConfigCheckSessionsMutex.Lock()
 	ret, specificReturn := fake.cleanExpiredResourceConfigCheckSessionsReturnsOnCall[len(fake.cleanExpiredResourceConfigCheckSessionsArgsForCall)]
@@ -139,6 +202,8 @@ func (fake *FakeResourceConfigCheckSessionLifecycle) CleanInactiveResourceConfig
 }
 
 func (fake *FakeResourceConfigCheckSessionLifecycle) Invocations() map[string][][]interface{} {
+	fake.cleanExpiredCheckContainerPoolSlotsMutex.RLock()
+	defer fake.cleanExpiredCheckContainerPoolSlotsMutex.RUnlock()
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.cleanExpiredResourceConfigCheckSessionsMutex.RLock()
@@ -27,6 +27,31 @@ type FakeResourceConfigScope struct {
 		result2 bool
 		result3 error
 	}
+	CheckFromStub        func() (atc.Version, bool, error)
+	checkFromMutex       sync.RWMutex
+	checkFromArgsForCall []struct {
+	}
+	checkFromReturns struct {
+		result1 atc.Version
+		result2 bool
+		result3 error
+	}
+	checkFromReturnsOnCall map[int]struct {
+		result1 atc.Version
+		result2 bool
+		result3 error
+	}
+	ClearCheckFromStub        func(atc.Version) error
+	clearCheckFromMutex       sync.RWMutex
+	clearCheckFromArgsForCall []struct {
+		arg1 atc.Version
+	}
+	clearCheckFromReturns struct {
+		result1 error
+	}
+	clearCheckFromReturnsOnCall map[int]struct {
+		result1 error
+	}
 	FindVersionStub        func(atc.Version) (db.ResourceConfigVersion, bool, error)
 	findVersionMutex       sync.RWMutex
 	findVersionArgsForCall []struct {
@@ -208,6 +233,126 @@ func (fake *FakeResourceConfigScope) AcquireResourceCheckingLockReturnsOnCall(i
 	}{result1, result2, result3}
 }
 
+func (fake *FakeResourceConfigScope) CheckFrom() (atc.Version, bool, error) {
+	fake.checkFromMutex.Lock()
+	ret, specificReturn := fake.checkFromReturnsOnCall[len(fake.checkFromArgsForCall)]
+	fake.checkFromArgsForCall = append(fake.checkFromArgsForCall, struct {
+	}{})
+	stub := fake.CheckFromStub
+	fakeReturns := fake.checkFromReturns
+	fake.recordInvocation("CheckFrom", []interface{}{})
+	fake.checkFromMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeResourceConfigScope) CheckFromCallCount() int {
+	fake.checkFromMutex.RLock()
+	defer fake.checkFromMutex.RUnlock()
+	return len(fake.checkFromArgsForCall)
+}
+
+func (fake *FakeResourceConfigScope) CheckFromCalls(stub func() (atc.Version, bool, error)) {
+	fake.checkFromMutex.Lock()
+	defer fake.checkFromMutex.Unlock()
+	fake.CheckFromStub = stub
+}
+
+func (fake *FakeResourceConfigScope) CheckFromReturns(result1 atc.Version, result2 bool, result3 error) {
+	fake.checkFromMutex.Lock()
+	defer fake.checkFromMutex.Unlock()
+	fake.CheckFromStub = nil
+	fake.checkFromReturns = struct {
+		result1 atc.Version
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeResourceConfigScope) CheckFromReturnsOnCall(i int, result1 atc.Version, result2 bool, result3 error) {
+	fake.checkFromMutex.Lock()
+	defer fake.checkFromMutex.Unlock()
+	fake.CheckFromStub = nil
+	if fake.checkFromReturnsOnCall == nil {
+		fake.checkFromReturnsOnCall = make(map[int]struct {
+			result1 atc.Version
+			result2 bool
+			result3 error
+		})
+	}
+	fake.checkFromReturnsOnCall[i] = struct {
+		result1 atc.Version
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeResourceConfigScope) ClearCheckFrom(arg1 atc.Version) error {
+	fake.clearCheckFromMutex.Lock()
+	ret, specificReturn := fake.clearCheckFromReturnsOnCall[len(fake.clearCheckFromArgsForCall)]
+	fake.clearCheckFromArgsForCall = append(fake.clearCheckFromArgsForCall, struct {
+		arg1 atc.Version
+	}{arg1})
+	stub := fake.ClearCheckFromStub
+	fakeReturns := fake.clearCheckFromReturns
+	fake.recordInvocation("ClearCheckFrom", []interface{}{arg1})
+	fake.clearCheckFromMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeResourceConfigScope) ClearCheckFromCallCount() int {
+	fake.clearCheckFromMutex.RLock()
+	defer fake.clearCheckFromMutex.RUnlock()
+	return len(fake.clearCheckFromArgsForCall)
+}
+
+func (fake *FakeResourceConfigScope) ClearCheckFromCalls(stub func(atc.Version) error) {
+	fake.clearCheckFromMutex.Lock()
+	defer fake.clearCheckFromMutex.Unlock()
+	fake.ClearCheckFromStub = stub
+}
+
+func (fake *FakeResourceConfigScope) ClearCheckFromArgsForCall(i int) atc.Version {
+	fake.clearCheckFromMutex.RLock()
+	defer fake.clearCheckFromMutex.RUnlock()
+	argsForCall := fake.clearCheckFromArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeResourceConfigScope) ClearCheckFromReturns(result1 error) {
+	fake.clearCheckFromMutex.Lock()
+	defer fake.clearCheckFromMutex.Unlock()
+	fake.ClearCheckFromStub = nil
+	fake.clearCheckFromReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeResourceConfigScope) ClearCheckFromReturnsOnCall(i int, result1 error) {
+	fake.clearCheckFromMutex.Lock()
+	defer fake.clearCheckFromMutex.Unlock()
+	fake.ClearCheckFromStub = nil
+	if fake.clearCheckFromReturnsOnCall == nil {
+		fake.clearCheckFromReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.clearCheckFromReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeResourceConfigScope) FindVersion(arg1 atc.Version) (db.ResourceConfigVersion, bool, error) {
 	fake.findVersionMutex.Lock()
 	ret, specificReturn := fake.findVersionReturnsOnCall[len(fake.findVersionArgsForCall)]
@@ -748,6 +893,10 @@ func (fake *FakeResourceConfigScope) UpdateLastCheckStartTimeReturnsOnCall(i int
 func (fake *FakeResourceConfigScope) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.checkFromMutex.RLock()
+	defer fake.checkFromMutex.RUnlock()
+	fake.clearCheckFromMutex.RLock()
+	defer fake.clearCheckFromMutex.RUnlock()
 	fake.acquireResourceCheckingLockMutex.RLock()
 	defer fake.acquireResourceCheckingLockMutex.RUnlock()
 	fake.findVersionMutex.RLock()
@@ -31,6 +31,21 @@ type FakeTeam struct {
 	authReturnsOnCall map[int]struct {
 		result1 atc.TeamAuth
 	}
+	BatchUpdatePipelinesStub        func(atc.PipelineBatchAction, []int, string) ([]int, error)
+	batchUpdatePipelinesMutex       sync.RWMutex
+	batchUpdatePipelinesArgsForCall []struct {
+		arg1 atc.PipelineBatchAction
+		arg2 []int
+		arg3 string
+	}
+	batchUpdatePipelinesReturns struct {
+		result1 []int
+		result2 error
+	}
+	batchUpdatePipelinesReturnsOnCall map[int]struct {
+		result1 []int
+		result2 error
+	}
 	BuildsStub        func(db.Page) ([]db.BuildForAPI, db.Pagination, error)
 	buildsMutex       sync.RWMutex
 	buildsArgsForCall []struct {
@@ -108,6 +123,19 @@ type FakeTeam struct {
 	deleteReturnsOnCall map[int]struct {
 		result1 error
 	}
+	DeleteNotifierStub        func(string) (bool, error)
+	deleteNotifierMutex       sync.RWMutex
+	deleteNotifierArgsForCall []struct {
+		arg1 string
+	}
+	deleteNotifierReturns struct {
+		result1 bool
+		result2 error
+	}
+	deleteNotifierReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	FindCheckContainersStub        func(lager.Logger, atc.PipelineRef, string) ([]db.Container, map[int]time.Time, error)
 	findCheckContainersMutex       sync.RWMutex
 	findCheckContainersArgsForCall []struct {
@@ -236,6 +264,18 @@ type FakeTeam struct {
 	iDReturnsOnCall map[int]struct {
 		result1 int
 	}
+	InterceptSettingsStub        func() (atc.InterceptSettings, error)
+	interceptSettingsMutex       sync.RWMutex
+	interceptSettingsArgsForCall []struct {
+	}
+	interceptSettingsReturns struct {
+		result1 atc.InterceptSettings
+		result2 error
+	}
+	interceptSettingsReturnsOnCall map[int]struct {
+		result1 atc.InterceptSettings
+		result2 error
+	}
 	IsCheckContainerStub        func(string) (bool, error)
 	isCheckContainerMutex       sync.RWMutex
 	isCheckContainerArgsForCall []struct {
@@ -273,6 +313,18 @@ type FakeTeam struct {
 	nameReturnsOnCall map[int]struct {
 		result1 string
 	}
+	NotifiersStub        func() ([]atc.NotifierConfig, error)
+	notifiersMutex       sync.RWMutex
+	notifiersArgsForCall []struct {
+	}
+	notifiersReturns struct {
+		result1 []atc.NotifierConfig
+		result2 error
+	}
+	notifiersReturnsOnCall map[int]struct {
+		result1 []atc.NotifierConfig
+		result2 error
+	}
 	OrderPipelinesStub        func([]string) error
 	orderPipelinesMutex       sync.RWMutex
 	orderPipelinesArgsForCall []struct {
@@ -338,6 +390,30 @@ type FakeTeam struct {
 		result2 db.Pagination
 		result3 error
 	}
+	PrivilegedSettingsStub        func() (atc.PrivilegedSettings, error)
+	privilegedSettingsMutex       sync.RWMutex
+	privilegedSettingsArgsForCall []struct {
+	}
+	privilegedSettingsReturns struct {
+		result1 atc.PrivilegedSettings
+		result2 error
+	}
+	privilegedSettingsReturnsOnCall map[int]struct {
+		result1 atc.PrivilegedSettings
+		result2 error
+	}
+	ProxySettingsStub        func() (atc.ProxySettings, error)
+	proxySettingsMutex       sync.RWMutex
+	proxySettingsArgsForCall []struct {
+	}
+	proxySettingsReturns struct {
+		result1 atc.ProxySettings
+		result2 error
+	}
+	proxySettingsReturnsOnCall map[int]struct {
+		result1 atc.ProxySettings
+		result2 error
+	}
 	PublicPipelinesStub        func() ([]db.Pipeline, error)
 	publicPipelinesMutex       sync.RWMutex
 	publicPipelinesArgsForCall []struct {
@@ -375,6 +451,29 @@ type FakeTeam struct {
 		result1 bool
 		result2 error
 	}
+	RunningOneOffBuildsStub        func() (int, error)
+	runningOneOffBuildsMutex       sync.RWMutex
+	runningOneOffBuildsArgsForCall []struct {
+	}
+	runningOneOffBuildsReturns struct {
+		result1 int
+		result2 error
+	}
+	runningOneOffBuildsReturnsOnCall map[int]struct {
+		result1 int
+		result2 error
+	}
+	SaveNotifierStub        func(atc.NotifierConfig) error
+	saveNotifierMutex       sync.RWMutex
+	saveNotifierArgsForCall []struct {
+		arg1 atc.NotifierConfig
+	}
+	saveNotifierReturns struct {
+		result1 error
+	}
+	saveNotifierReturnsOnCall map[int]struct {
+		result1 error
+	}
 	SavePipelineStub        func(atc.PipelineRef, atc.Config, db.ConfigVersion, bool) (db.Pipeline, bool, error)
 	savePipelineMutex       sync.RWMutex
 	savePipelineArgsForCall []struct {
@@ -407,6 +506,50 @@ type FakeTeam struct {
 		result1 db.Worker
 		result2 error
 	}
+	SetInterceptSettingsStub        func(atc.InterceptSettings) error
+	setInterceptSettingsMutex       sync.RWMutex
+	setInterceptSettingsArgsForCall []struct {
+		arg1 atc.InterceptSettings
+	}
+	setInterceptSettingsReturns struct {
+		result1 error
+	}
+	setInterceptSettingsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SetPrivilegedSettingsStub        func(atc.PrivilegedSettings) error
+	setPrivilegedSettingsMutex       sync.RWMutex
+	setPrivilegedSettingsArgsForCall []struct {
+		arg1 atc.PrivilegedSettings
+	}
+	setPrivilegedSettingsReturns struct {
+		result1 error
+	}
+	setPrivilegedSettingsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SetProxySettingsStub        func(atc.ProxySettings) error
+	setProxySettingsMutex       sync.RWMutex
+	setProxySettingsArgsForCall []struct {
+		arg1 atc.ProxySettings
+	}
+	setProxySettingsReturns struct {
+		result1 error
+	}
+	setProxySettingsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SetWorkerKeysStub        func([]string) error
+	setWorkerKeysMutex       sync.RWMutex
+	setWorkerKeysArgsForCall []struct {
+		arg1 []string
+	}
+	setWorkerKeysReturns struct {
+		result1 error
+	}
+	setWorkerKeysReturnsOnCall map[int]struct {
+		result1 error
+	}
 	UpdateProviderAuthStub        func(atc.TeamAuth) error
 	updateProviderAuthMutex       sync.RWMutex
 	updateProviderAuthArgsForCall []struct {
@@ -418,6 +561,18 @@ type FakeTeam struct {
 	updateProviderAuthReturnsOnCall map[int]struct {
 		result1 error
 	}
+	WorkerKeysStub        func() ([]string, error)
+	workerKeysMutex       sync.RWMutex
+	workerKeysArgsForCall []struct {
+	}
+	workerKeysReturns struct {
+		result1 []string
+		result2 error
+	}
+	workerKeysReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
 	WorkersStub        func() ([]db.Worker, error)
 	workersMutex       sync.RWMutex
 	workersArgsForCall []struct {
@@ -540,6 +695,77 @@ func (fake *FakeTeam) AuthReturnsOnCall(i int, result1 atc.TeamAuth) {
 	}{result1}
 }
 
+func (fake *FakeTeam) BatchUpdatePipelines(arg1 atc.PipelineBatchAction, arg2 []int, arg3 string) ([]int, error) {
+	var arg2Copy []int
+	if arg2 != nil {
+		arg2Copy = make([]int, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.batchUpdatePipelinesMutex.Lock()
+	ret, specificReturn := fake.batchUpdatePipelinesReturnsOnCall[len(fake.batchUpdatePipelinesArgsForCall)]
+	fake.batchUpdatePipelinesArgsForCall = append(fake.batchUpdatePipelinesArgsForCall, struct {
+		arg1 atc.PipelineBatchAction
+		arg2 []int
+		arg3 string
+	}{arg1, arg2Copy, arg3})
+	stub := fake.BatchUpdatePipelinesStub
+	fakeReturns := fake.batchUpdatePipelinesReturns
+	fake.recordInvocation("BatchUpdatePipelines", []interface{}{arg1, arg2Copy, arg3})
+	fake.batchUpdatePipelinesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) BatchUpdatePipelinesCallCount() int {
+	fake.batchUpdatePipelinesMutex.RLock()
+	defer fake.batchUpdatePipelinesMutex.RUnlock()
+	return len(fake.batchUpdatePipelinesArgsForCall)
+}
+
+func (fake *FakeTeam) BatchUpdatePipelinesCalls(stub func(atc.PipelineBatchAction, []int, string) ([]int, error)) {
+	fake.batchUpdatePipelinesMutex.Lock()
+	defer fake.batchUpdatePipelinesMutex.Unlock()
+	fake.BatchUpdatePipelinesStub = stub
+}
+
+func (fake *FakeTeam) BatchUpdatePipelinesArgsForCall(i int) (atc.PipelineBatchAction, []int, string) {
+	fake.batchUpdatePipelinesMutex.RLock()
+	defer fake.batchUpdatePipelinesMutex.RUnlock()
+	argsForCall := fake.batchUpdatePipelinesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeTeam) BatchUpdatePipelinesReturns(result1 []int, result2 error) {
+	fake.batchUpdatePipelinesMutex.Lock()
+	defer fake.batchUpdatePipelinesMutex.Unlock()
+	fake.BatchUpdatePipelinesStub = nil
+	fake.batchUpdatePipelinesReturns = struct {
+		result1 []int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) BatchUpdatePipelinesReturnsOnCall(i int, result1 []int, result2 error) {
+	fake.batchUpdatePipelinesMutex.Lock()
+	defer fake.batchUpdatePipelinesMutex.Unlock()
+	fake.BatchUpdatePipelinesStub = nil
+	if fake.batchUpdatePipelinesReturnsOnCall == nil {
+		fake.batchUpdatePipelinesReturnsOnCall = make(map[int]struct {
+			result1 []int
+			result2 error
+		})
+	}
+	fake.batchUpdatePipelinesReturnsOnCall[i] = struct {
+		result1 []int
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) Builds(arg1 db.Page) ([]db.BuildForAPI, db.Pagination, error) {
 	fake.buildsMutex.Lock()
 	ret, specificReturn := fake.buildsReturnsOnCall[len(fake.buildsArgsForCall)]
@@ -903,6 +1129,70 @@ func (fake *FakeTeam) DeleteReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeTeam) DeleteNotifier(arg1 string) (bool, error) {
+	fake.deleteNotifierMutex.Lock()
+	ret, specificReturn := fake.deleteNotifierReturnsOnCall[len(fake.deleteNotifierArgsForCall)]
+	fake.deleteNotifierArgsForCall = append(fake.deleteNotifierArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.DeleteNotifierStub
+	fakeReturns := fake.deleteNotifierReturns
+	fake.recordInvocation("DeleteNotifier", []interface{}{arg1})
+	fake.deleteNotifierMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) DeleteNotifierCallCount() int {
+	fake.deleteNotifierMutex.RLock()
+	defer fake.deleteNotifierMutex.RUnlock()
+	return len(fake.deleteNotifierArgsForCall)
+}
+
+func (fake *FakeTeam) DeleteNotifierCalls(stub func(string) (bool, error)) {
+	fake.deleteNotifierMutex.Lock()
+	defer fake.deleteNotifierMutex.Unlock()
+	fake.DeleteNotifierStub = stub
+}
+
+func (fake *FakeTeam) DeleteNotifierArgsForCall(i int) string {
+	fake.deleteNotifierMutex.RLock()
+	defer fake.deleteNotifierMutex.RUnlock()
+	argsForCall := fake.deleteNotifierArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) DeleteNotifierReturns(result1 bool, result2 error) {
+	fake.deleteNotifierMutex.Lock()
+	defer fake.deleteNotifierMutex.Unlock()
+	fake.DeleteNotifierStub = nil
+	fake.deleteNotifierReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) DeleteNotifierReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.deleteNotifierMutex.Lock()
+	defer fake.deleteNotifierMutex.Unlock()
+	fake.DeleteNotifierStub = nil
+	if fake.deleteNotifierReturnsOnCall == nil {
+		fake.deleteNotifierReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.deleteNotifierReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) FindCheckContainers(arg1 lager.Logger, arg2 atc.PipelineRef, arg3 string) ([]db.Container, map[int]time.Time, error) {
 	fake.findCheckContainersMutex.Lock()
 	ret, specificReturn := fake.findCheckContainersReturnsOnCall[len(fake.findCheckContainersArgsForCall)]
@@ -1488,6 +1778,62 @@ func (fake *FakeTeam) IDReturnsOnCall(i int, result1 int) {
 	}{result1}
 }
 
+func (fake *FakeTeam) InterceptSettings() (atc.InterceptSettings, error) {
+	fake.interceptSettingsMutex.Lock()
+	ret, specificReturn := fake.interceptSettingsReturnsOnCall[len(fake.interceptSettingsArgsForCall)]
+	fake.interceptSettingsArgsForCall = append(fake.interceptSettingsArgsForCall, struct {
+	}{})
+	stub := fake.InterceptSettingsStub
+	fakeReturns := fake.interceptSettingsReturns
+	fake.recordInvocation("InterceptSettings", []interface{}{})
+	fake.interceptSettingsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) InterceptSettingsCallCount() int {
+	fake.interceptSettingsMutex.RLock()
+	defer fake.interceptSettingsMutex.RUnlock()
+	return len(fake.interceptSettingsArgsForCall)
+}
+
+func (fake *FakeTeam) InterceptSettingsCalls(stub func() (atc.InterceptSettings, error)) {
+	fake.interceptSettingsMutex.Lock()
+	defer fake.interceptSettingsMutex.Unlock()
+	fake.InterceptSettingsStub = stub
+}
+
+func (fake *FakeTeam) InterceptSettingsReturns(result1 atc.InterceptSettings, result2 error) {
+	fake.interceptSettingsMutex.Lock()
+	defer fake.interceptSettingsMutex.Unlock()
+	fake.InterceptSettingsStub = nil
+	fake.interceptSettingsReturns = struct {
+		result1 atc.InterceptSettings
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) InterceptSettingsReturnsOnCall(i int, result1 atc.InterceptSettings, result2 error) {
+	fake.interceptSettingsMutex.Lock()
+	defer fake.interceptSettingsMutex.Unlock()
+	fake.InterceptSettingsStub = nil
+	if fake.interceptSettingsReturnsOnCall == nil {
+		fake.interceptSettingsReturnsOnCall = make(map[int]struct {
+			result1 atc.InterceptSettings
+			result2 error
+		})
+	}
+	fake.interceptSettingsReturnsOnCall[i] = struct {
+		result1 atc.InterceptSettings
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) IsCheckContainer(arg1 string) (bool, error) {
 	fake.isCheckContainerMutex.Lock()
 	ret, specificReturn := fake.isCheckContainerReturnsOnCall[len(fake.isCheckContainerArgsForCall)]
@@ -1670,6 +2016,62 @@ func (fake *FakeTeam) NameReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeTeam) Notifiers() ([]atc.NotifierConfig, error) {
+	fake.notifiersMutex.Lock()
+	ret, specificReturn := fake.notifiersReturnsOnCall[len(fake.notifiersArgsForCall)]
+	fake.notifiersArgsForCall = append(fake.notifiersArgsForCall, struct {
+	}{})
+	stub := fake.NotifiersStub
+	fakeReturns := fake.notifiersReturns
+	fake.recordInvocation("Notifiers", []interface{}{})
+	fake.notifiersMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) NotifiersCallCount() int {
+	fake.notifiersMutex.RLock()
+	defer fake.notifiersMutex.RUnlock()
+	return len(fake.notifiersArgsForCall)
+}
+
+func (fake *FakeTeam) NotifiersCalls(stub func() ([]atc.NotifierConfig, error)) {
+	fake.notifiersMutex.Lock()
+	defer fake.notifiersMutex.Unlock()
+	fake.NotifiersStub = stub
+}
+
+func (fake *FakeTeam) NotifiersReturns(result1 []atc.NotifierConfig, result2 error) {
+	fake.notifiersMutex.Lock()
+	defer fake.notifiersMutex.Unlock()
+	fake.NotifiersStub = nil
+	fake.notifiersReturns = struct {
+		result1 []atc.NotifierConfig
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) NotifiersReturnsOnCall(i int, result1 []atc.NotifierConfig, result2 error) {
+	fake.notifiersMutex.Lock()
+	defer fake.notifiersMutex.Unlock()
+	fake.NotifiersStub = nil
+	if fake.notifiersReturnsOnCall == nil {
+		fake.notifiersReturnsOnCall = make(map[int]struct {
+			result1 []atc.NotifierConfig
+			result2 error
+		})
+	}
+	fake.notifiersReturnsOnCall[i] = struct {
+		result1 []atc.NotifierConfig
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) OrderPipelines(arg1 []string) error {
 	var arg1Copy []string
 	if arg1 != nil {
@@ -1993,6 +2395,118 @@ func (fake *FakeTeam) PrivateAndPublicBuildsReturnsOnCall(i int, result1 []db.Bu
 	}{result1, result2, result3}
 }
 
+func (fake *FakeTeam) PrivilegedSettings() (atc.PrivilegedSettings, error) {
+	fake.privilegedSettingsMutex.Lock()
+	ret, specificReturn := fake.privilegedSettingsReturnsOnCall[len(fake.privilegedSettingsArgsForCall)]
+	fake.privilegedSettingsArgsForCall = append(fake.privilegedSettingsArgsForCall, struct {
+	}{})
+	stub := fake.PrivilegedSettingsStub
+	fakeReturns := fake.privilegedSettingsReturns
+	fake.recordInvocation("PrivilegedSettings", []interface{}{})
+	fake.privilegedSettingsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) PrivilegedSettingsCallCount() int {
+	fake.privilegedSettingsMutex.RLock()
+	defer fake.privilegedSettingsMutex.RUnlock()
+	return len(fake.privilegedSettingsArgsForCall)
+}
+
+func (fake *FakeTeam) PrivilegedSettingsCalls(stub func() (atc.PrivilegedSettings, error)) {
+	fake.privilegedSettingsMutex.Lock()
+	defer fake.privilegedSettingsMutex.Unlock()
+	fake.PrivilegedSettingsStub = stub
+}
+
+func (fake *FakeTeam) PrivilegedSettingsReturns(result1 atc.PrivilegedSettings, result2 error) {
+	fake.privilegedSettingsMutex.Lock()
+	defer fake.privilegedSettingsMutex.Unlock()
+	fake.PrivilegedSettingsStub = nil
+	fake.privilegedSettingsReturns = struct {
+		result1 atc.PrivilegedSettings
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) PrivilegedSettingsReturnsOnCall(i int, result1 atc.PrivilegedSettings, result2 error) {
+	fake.privilegedSettingsMutex.Lock()
+	defer fake.privilegedSettingsMutex.Unlock()
+	fake.PrivilegedSettingsStub = nil
+	if fake.privilegedSettingsReturnsOnCall == nil {
+		fake.privilegedSettingsReturnsOnCall = make(map[int]struct {
+			result1 atc.PrivilegedSettings
+			result2 error
+		})
+	}
+	fake.privilegedSettingsReturnsOnCall[i] = struct {
+		result1 atc.PrivilegedSettings
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) ProxySettings() (atc.ProxySettings, error) {
+	fake.proxySettingsMutex.Lock()
+	ret, specificReturn := fake.proxySettingsReturnsOnCall[len(fake.proxySettingsArgsForCall)]
+	fake.proxySettingsArgsForCall = append(fake.proxySettingsArgsForCall, struct {
+	}{})
+	stub := fake.ProxySettingsStub
+	fakeReturns := fake.proxySettingsReturns
+	fake.recordInvocation("ProxySettings", []interface{}{})
+	fake.proxySettingsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) ProxySettingsCallCount() int {
+	fake.proxySettingsMutex.RLock()
+	defer fake.proxySettingsMutex.RUnlock()
+	return len(fake.proxySettingsArgsForCall)
+}
+
+func (fake *FakeTeam) ProxySettingsCalls(stub func() (atc.ProxySettings, error)) {
+	fake.proxySettingsMutex.Lock()
+	defer fake.proxySettingsMutex.Unlock()
+	fake.ProxySettingsStub = stub
+}
+
+func (fake *FakeTeam) ProxySettingsReturns(result1 atc.ProxySettings, result2 error) {
+	fake.proxySettingsMutex.Lock()
+	defer fake.proxySettingsMutex.Unlock()
+	fake.ProxySettingsStub = nil
+	fake.proxySettingsReturns = struct {
+		result1 atc.ProxySettings
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) ProxySettingsReturnsOnCall(i int, result1 atc.ProxySettings, result2 error) {
+	fake.proxySettingsMutex.Lock()
+	defer fake.proxySettingsMutex.Unlock()
+	fake.ProxySettingsStub = nil
+	if fake.proxySettingsReturnsOnCall == nil {
+		fake.proxySettingsReturnsOnCall = make(map[int]struct {
+			result1 atc.ProxySettings
+			result2 error
+		})
+	}
+	fake.proxySettingsReturnsOnCall[i] = struct {
+		result1 atc.ProxySettings
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) PublicPipelines() ([]db.Pipeline, error) {
 	fake.publicPipelinesMutex.Lock()
 	ret, specificReturn := fake.publicPipelinesReturnsOnCall[len(fake.publicPipelinesArgsForCall)]
@@ -2175,6 +2689,123 @@ func (fake *FakeTeam) RenamePipelineReturnsOnCall(i int, result1 bool, result2 e
 	}{result1, result2}
 }
 
+func (fake *FakeTeam) RunningOneOffBuilds() (int, error) {
+	fake.runningOneOffBuildsMutex.Lock()
+	ret, specificReturn := fake.runningOneOffBuildsReturnsOnCall[len(fake.runningOneOffBuildsArgsForCall)]
+	fake.runningOneOffBuildsArgsForCall = append(fake.runningOneOffBuildsArgsForCall, struct {
+	}{})
+	stub := fake.RunningOneOffBuildsStub
+	fakeReturns := fake.runningOneOffBuildsReturns
+	fake.recordInvocation("RunningOneOffBuilds", []interface{}{})
+	fake.runningOneOffBuildsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) RunningOneOffBuildsCallCount() int {
+	fake.runningOneOffBuildsMutex.RLock()
+	defer fake.runningOneOffBuildsMutex.RUnlock()
+	return len(fake.runningOneOffBuildsArgsForCall)
+}
+
+func (fake *FakeTeam) RunningOneOffBuildsCalls(stub func() (int, error)) {
+	fake.runningOneOffBuildsMutex.Lock()
+	defer fake.runningOneOffBuildsMutex.Unlock()
+	fake.RunningOneOffBuildsStub = stub
+}
+
+func (fake *FakeTeam) RunningOneOffBuildsReturns(result1 int, result2 error) {
+	fake.runningOneOffBuildsMutex.Lock()
+	defer fake.runningOneOffBuildsMutex.Unlock()
+	fake.RunningOneOffBuildsStub = nil
+	fake.runningOneOffBuildsReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) RunningOneOffBuildsReturnsOnCall(i int, result1 int, result2 error) {
+	fake.runningOneOffBuildsMutex.Lock()
+	defer fake.runningOneOffBuildsMutex.Unlock()
+	fake.RunningOneOffBuildsStub = nil
+	if fake.runningOneOffBuildsReturnsOnCall == nil {
+		fake.runningOneOffBuildsReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 error
+		})
+	}
+	fake.runningOneOffBuildsReturnsOnCall[i] = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) SaveNotifier(arg1 atc.NotifierConfig) error {
+	fake.saveNotifierMutex.Lock()
+	ret, specificReturn := fake.saveNotifierReturnsOnCall[len(fake.saveNotifierArgsForCall)]
+	fake.saveNotifierArgsForCall = append(fake.saveNotifierArgsForCall, struct {
+		arg1 atc.NotifierConfig
+	}{arg1})
+	stub := fake.SaveNotifierStub
+	fakeReturns := fake.saveNotifierReturns
+	fake.recordInvocation("SaveNotifier", []interface{}{arg1})
+	fake.saveNotifierMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeTeam) SaveNotifierCallCount() int {
+	fake.saveNotifierMutex.RLock()
+	defer fake.saveNotifierMutex.RUnlock()
+	return len(fake.saveNotifierArgsForCall)
+}
+
+func (fake *FakeTeam) SaveNotifierCalls(stub func(atc.NotifierConfig) error) {
+	fake.saveNotifierMutex.Lock()
+	defer fake.saveNotifierMutex.Unlock()
+	fake.SaveNotifierStub = stub
+}
+
+func (fake *FakeTeam) SaveNotifierArgsForCall(i int) atc.NotifierConfig {
+	fake.saveNotifierMutex.RLock()
+	defer fake.saveNotifierMutex.RUnlock()
+	argsForCall := fake.saveNotifierArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) SaveNotifierReturns(result1 error) {
+	fake.saveNotifierMutex.Lock()
+	defer fake.saveNotifierMutex.Unlock()
+	fake.SaveNotifierStub = nil
+	fake.saveNotifierReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) SaveNotifierReturnsOnCall(i int, result1 error) {
+	fake.saveNotifierMutex.Lock()
+	defer fake.saveNotifierMutex.Unlock()
+	fake.SaveNotifierStub = nil
+	if fake.saveNotifierReturnsOnCall == nil {
+		fake.saveNotifierReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveNotifierReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeTeam) SavePipeline(arg1 atc.PipelineRef, arg2 atc.Config, arg3 db.ConfigVersion, arg4 bool) (db.Pipeline, bool, error) {
 	fake.savePipelineMutex.Lock()
 	ret, specificReturn := fake.savePipelineReturnsOnCall[len(fake.savePipelineArgsForCall)]
@@ -2310,6 +2941,255 @@ func (fake *FakeTeam) SaveWorkerReturnsOnCall(i int, result1 db.Worker, result2
 	}{result1, result2}
 }
 
+func (fake *FakeTeam) SetInterceptSettings(arg1 atc.InterceptSettings) error {
+	fake.setInterceptSettingsMutex.Lock()
+	ret, specificReturn := fake.setInterceptSettingsReturnsOnCall[len(fake.setInterceptSettingsArgsForCall)]
+	fake.setInterceptSettingsArgsForCall = append(fake.setInterceptSettingsArgsForCall, struct {
+		arg1 atc.InterceptSettings
+	}{arg1})
+	stub := fake.SetInterceptSettingsStub
+	fakeReturns := fake.setInterceptSettingsReturns
+	fake.recordInvocation("SetInterceptSettings", []interface{}{arg1})
+	fake.setInterceptSettingsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeTeam) SetInterceptSettingsCallCount() int {
+	fake.setInterceptSettingsMutex.RLock()
+	defer fake.setInterceptSettingsMutex.RUnlock()
+	return len(fake.setInterceptSettingsArgsForCall)
+}
+
+func (fake *FakeTeam) SetInterceptSettingsCalls(stub func(atc.InterceptSettings) error) {
+	fake.setInterceptSettingsMutex.Lock()
+	defer fake.setInterceptSettingsMutex.Unlock()
+	fake.SetInterceptSettingsStub = stub
+}
+
+func (fake *FakeTeam) SetInterceptSettingsArgsForCall(i int) atc.InterceptSettings {
+	fake.setInterceptSettingsMutex.RLock()
+	defer fake.setInterceptSettingsMutex.RUnlock()
+	argsForCall := fake.setInterceptSettingsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) SetInterceptSettingsReturns(result1 error) {
+	fake.setInterceptSettingsMutex.Lock()
+	defer fake.setInterceptSettingsMutex.Unlock()
+	fake.SetInterceptSettingsStub = nil
+	fake.setInterceptSettingsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) SetInterceptSettingsReturnsOnCall(i int, result1 error) {
+	fake.setInterceptSettingsMutex.Lock()
+	defer fake.setInterceptSettingsMutex.Unlock()
+	fake.SetInterceptSettingsStub = nil
+	if fake.setInterceptSettingsReturnsOnCall == nil {
+		fake.setInterceptSettingsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setInterceptSettingsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) SetPrivilegedSettings(arg1 atc.PrivilegedSettings) error {
+	fake.setPrivilegedSettingsMutex.Lock()
+	ret, specificReturn := fake.setPrivilegedSettingsReturnsOnCall[len(fake.setPrivilegedSettingsArgsForCall)]
+	fake.setPrivilegedSettingsArgsForCall = append(fake.setPrivilegedSettingsArgsForCall, struct {
+		arg1 atc.PrivilegedSettings
+	}{arg1})
+	stub := fake.SetPrivilegedSettingsStub
+	fakeReturns := fake.setPrivilegedSettingsReturns
+	fake.recordInvocation("SetPrivilegedSettings", []interface{}{arg1})
+	fake.setPrivilegedSettingsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeTeam) SetPrivilegedSettingsCallCount() int {
+	fake.setPrivilegedSettingsMutex.RLock()
+	defer fake.setPrivilegedSettingsMutex.RUnlock()
+	return len(fake.setPrivilegedSettingsArgsForCall)
+}
+
+func (fake *FakeTeam) SetPrivilegedSettingsCalls(stub func(atc.PrivilegedSettings) error) {
+	fake.setPrivilegedSettingsMutex.Lock()
+	defer fake.setPrivilegedSettingsMutex.Unlock()
+	fake.SetPrivilegedSettingsStub = stub
+}
+
+func (fake *FakeTeam) SetPrivilegedSettingsArgsForCall(i int) atc.PrivilegedSettings {
+	fake.setPrivilegedSettingsMutex.RLock()
+	defer fake.setPrivilegedSettingsMutex.RUnlock()
+	argsForCall := fake.setPrivilegedSettingsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) SetPrivilegedSettingsReturns(result1 error) {
+	fake.setPrivilegedSettingsMutex.Lock()
+	defer fake.setPrivilegedSettingsMutex.Unlock()
+	fake.SetPrivilegedSettingsStub = nil
+	fake.setPrivilegedSettingsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) SetPrivilegedSettingsReturnsOnCall(i int, result1 error) {
+	fake.setPrivilegedSettingsMutex.Lock()
+	defer fake.setPrivilegedSettingsMutex.Unlock()
+	fake.SetPrivilegedSettingsStub = nil
+	if fake.setPrivilegedSettingsReturnsOnCall == nil {
+		fake.setPrivilegedSettingsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setPrivilegedSettingsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) SetProxySettings(arg1 atc.ProxySettings) error {
+	fake.setProxySettingsMutex.Lock()
+	ret, specificReturn := fake.setProxySettingsReturnsOnCall[len(fake.setProxySettingsArgsForCall)]
+	fake.setProxySettingsArgsForCall = append(fake.setProxySettingsArgsForCall, struct {
+		arg1 atc.ProxySettings
+	}{arg1})
+	stub := fake.SetProxySettingsStub
+	fakeReturns := fake.setProxySettingsReturns
+	fake.recordInvocation("SetProxySettings", []interface{}{arg1})
+	fake.setProxySettingsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeTeam) SetProxySettingsCallCount() int {
+	fake.setProxySettingsMutex.RLock()
+	defer fake.setProxySettingsMutex.RUnlock()
+	return len(fake.setProxySettingsArgsForCall)
+}
+
+func (fake *FakeTeam) SetProxySettingsCalls(stub func(atc.ProxySettings) error) {
+	fake.setProxySettingsMutex.Lock()
+	defer fake.setProxySettingsMutex.Unlock()
+	fake.SetProxySettingsStub = stub
+}
+
+func (fake *FakeTeam) SetProxySettingsArgsForCall(i int) atc.ProxySettings {
+	fake.setProxySettingsMutex.RLock()
+	defer fake.setProxySettingsMutex.RUnlock()
+	argsForCall := fake.setProxySettingsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) SetProxySettingsReturns(result1 error) {
+	fake.setProxySettingsMutex.Lock()
+	defer fake.setProxySettingsMutex.Unlock()
+	fake.SetProxySettingsStub = nil
+	fake.setProxySettingsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) SetProxySettingsReturnsOnCall(i int, result1 error) {
+	fake.setProxySettingsMutex.Lock()
+	defer fake.setProxySettingsMutex.Unlock()
+	fake.SetProxySettingsStub = nil
+	if fake.setProxySettingsReturnsOnCall == nil {
+		fake.setProxySettingsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setProxySettingsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) SetWorkerKeys(arg1 []string) error {
+	var arg1Copy []string
+	if arg1 != nil {
+		arg1Copy = make([]string, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.setWorkerKeysMutex.Lock()
+	ret, specificReturn := fake.setWorkerKeysReturnsOnCall[len(fake.setWorkerKeysArgsForCall)]
+	fake.setWorkerKeysArgsForCall = append(fake.setWorkerKeysArgsForCall, struct {
+		arg1 []string
+	}{arg1Copy})
+	stub := fake.SetWorkerKeysStub
+	fakeReturns := fake.setWorkerKeysReturns
+	fake.recordInvocation("SetWorkerKeys", []interface{}{arg1Copy})
+	fake.setWorkerKeysMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeTeam) SetWorkerKeysCallCount() int {
+	fake.setWorkerKeysMutex.RLock()
+	defer fake.setWorkerKeysMutex.RUnlock()
+	return len(fake.setWorkerKeysArgsForCall)
+}
+
+func (fake *FakeTeam) SetWorkerKeysCalls(stub func([]string) error) {
+	fake.setWorkerKeysMutex.Lock()
+	defer fake.setWorkerKeysMutex.Unlock()
+	fake.SetWorkerKeysStub = stub
+}
+
+func (fake *FakeTeam) SetWorkerKeysArgsForCall(i int) []string {
+	fake.setWorkerKeysMutex.RLock()
+	defer fake.setWorkerKeysMutex.RUnlock()
+	argsForCall := fake.setWorkerKeysArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) SetWorkerKeysReturns(result1 error) {
+	fake.setWorkerKeysMutex.Lock()
+	defer fake.setWorkerKeysMutex.Unlock()
+	fake.SetWorkerKeysStub = nil
+	fake.setWorkerKeysReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) SetWorkerKeysReturnsOnCall(i int, result1 error) {
+	fake.setWorkerKeysMutex.Lock()
+	defer fake.setWorkerKeysMutex.Unlock()
+	fake.SetWorkerKeysStub = nil
+	if fake.setWorkerKeysReturnsOnCall == nil {
+		fake.setWorkerKeysReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setWorkerKeysReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeTeam) UpdateProviderAuth(arg1 atc.TeamAuth) error {
 	fake.updateProviderAuthMutex.Lock()
 	ret, specificReturn := fake.updateProviderAuthReturnsOnCall[len(fake.updateProviderAuthArgsForCall)]
@@ -2371,6 +3251,62 @@ func (fake *FakeTeam) UpdateProviderAuthReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeTeam) WorkerKeys() ([]string, error) {
+	fake.workerKeysMutex.Lock()
+	ret, specificReturn := fake.workerKeysReturnsOnCall[len(fake.workerKeysArgsForCall)]
+	fake.workerKeysArgsForCall = append(fake.workerKeysArgsForCall, struct {
+	}{})
+	stub := fake.WorkerKeysStub
+	fakeReturns := fake.workerKeysReturns
+	fake.recordInvocation("WorkerKeys", []interface{}{})
+	fake.workerKeysMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) WorkerKeysCallCount() int {
+	fake.workerKeysMutex.RLock()
+	defer fake.workerKeysMutex.RUnlock()
+	return len(fake.workerKeysArgsForCall)
+}
+
+func (fake *FakeTeam) WorkerKeysCalls(stub func() ([]string, error)) {
+	fake.workerKeysMutex.Lock()
+	defer fake.workerKeysMutex.Unlock()
+	fake.WorkerKeysStub = stub
+}
+
+func (fake *FakeTeam) WorkerKeysReturns(result1 []string, result2 error) {
+	fake.workerKeysMutex.Lock()
+	defer fake.workerKeysMutex.Unlock()
+	fake.WorkerKeysStub = nil
+	fake.workerKeysReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) WorkerKeysReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.workerKeysMutex.Lock()
+	defer fake.workerKeysMutex.Unlock()
+	fake.WorkerKeysStub = nil
+	if fake.workerKeysReturnsOnCall == nil {
+		fake.workerKeysReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.workerKeysReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) Workers() ([]db.Worker, error) {
 	fake.workersMutex.Lock()
 	ret, specificReturn := fake.workersReturnsOnCall[len(fake.workersArgsForCall)]
@@ -2430,6 +3366,12 @@ func (fake *FakeTeam) WorkersReturnsOnCall(i int, result1 []db.Worker, result2 e
 func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.batchUpdatePipelinesMutex.RLock()
+	defer fake.batchUpdatePipelinesMutex.RUnlock()
+	fake.deleteNotifierMutex.RLock()
+	defer fake.deleteNotifierMutex.RUnlock()
+	fake.interceptSettingsMutex.RLock()
+	defer fake.interceptSettingsMutex.RUnlock()
 	fake.adminMutex.RLock()
 	defer fake.adminMutex.RUnlock()
 	fake.authMutex.RLock()
@@ -2470,6 +3412,8 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.isContainerWithinTeamMutex.RUnlock()
 	fake.nameMutex.RLock()
 	defer fake.nameMutex.RUnlock()
+	fake.notifiersMutex.RLock()
+	defer fake.notifiersMutex.RUnlock()
 	fake.orderPipelinesMutex.RLock()
 	defer fake.orderPipelinesMutex.RUnlock()
 	fake.orderPipelinesWithinGroupMutex.RLock()
@@ -2480,18 +3424,36 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.pipelinesMutex.RUnlock()
 	fake.privateAndPublicBuildsMutex.RLock()
 	defer fake.privateAndPublicBuildsMutex.RUnlock()
+	fake.privilegedSettingsMutex.RLock()
+	defer fake.privilegedSettingsMutex.RUnlock()
+	fake.proxySettingsMutex.RLock()
+	defer fake.proxySettingsMutex.RUnlock()
 	fake.publicPipelinesMutex.RLock()
 	defer fake.publicPipelinesMutex.RUnlock()
 	fake.renameMutex.RLock()
 	defer fake.renameMutex.RUnlock()
 	fake.renamePipelineMutex.RLock()
 	defer fake.renamePipelineMutex.RUnlock()
+	fake.runningOneOffBuildsMutex.RLock()
+	defer fake.runningOneOffBuildsMutex.RUnlock()
+	fake.saveNotifierMutex.RLock()
+	defer fake.saveNotifierMutex.RUnlock()
 	fake.savePipelineMutex.RLock()
 	defer fake.savePipelineMutex.RUnlock()
 	fake.saveWorkerMutex.RLock()
 	defer fake.saveWorkerMutex.RUnlock()
+	fake.setInterceptSettingsMutex.RLock()
+	defer fake.setInterceptSettingsMutex.RUnlock()
+	fake.setPrivilegedSettingsMutex.RLock()
+	defer fake.setPrivilegedSettingsMutex.RUnlock()
+	fake.setProxySettingsMutex.RLock()
+	defer fake.setProxySettingsMutex.RUnlock()
+	fake.setWorkerKeysMutex.RLock()
+	defer fake.setWorkerKeysMutex.RUnlock()
 	fake.updateProviderAuthMutex.RLock()
 	defer fake.updateProviderAuthMutex.RUnlock()
+	fake.workerKeysMutex.RLock()
+	defer fake.workerKeysMutex.RUnlock()
 	fake.workersMutex.RLock()
 	defer fake.workersMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
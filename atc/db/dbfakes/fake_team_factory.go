@@ -92,6 +92,18 @@ type FakeTeamFactory struct {
 	notifyResourceScannerReturnsOnCall map[int]struct {
 		result1 error
 	}
+	WorkerKeysStub        func() ([]atc.TeamWorkerKeys, error)
+	workerKeysMutex       sync.RWMutex
+	workerKeysArgsForCall []struct {
+	}
+	workerKeysReturns struct {
+		result1 []atc.TeamWorkerKeys
+		result2 error
+	}
+	workerKeysReturnsOnCall map[int]struct {
+		result1 []atc.TeamWorkerKeys
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -506,6 +518,62 @@ func (fake *FakeTeamFactory) NotifyResourceScannerReturnsOnCall(i int, result1 e
 	}{result1}
 }
 
+func (fake *FakeTeamFactory) WorkerKeys() ([]atc.TeamWorkerKeys, error) {
+	fake.workerKeysMutex.Lock()
+	ret, specificReturn := fake.workerKeysReturnsOnCall[len(fake.workerKeysArgsForCall)]
+	fake.workerKeysArgsForCall = append(fake.workerKeysArgsForCall, struct {
+	}{})
+	stub := fake.WorkerKeysStub
+	fakeReturns := fake.workerKeysReturns
+	fake.recordInvocation("WorkerKeys", []interface{}{})
+	fake.workerKeysMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeamFactory) WorkerKeysCallCount() int {
+	fake.workerKeysMutex.RLock()
+	defer fake.workerKeysMutex.RUnlock()
+	return len(fake.workerKeysArgsForCall)
+}
+
+func (fake *FakeTeamFactory) WorkerKeysCalls(stub func() ([]atc.TeamWorkerKeys, error)) {
+	fake.workerKeysMutex.Lock()
+	defer fake.workerKeysMutex.Unlock()
+	fake.WorkerKeysStub = stub
+}
+
+func (fake *FakeTeamFactory) WorkerKeysReturns(result1 []atc.TeamWorkerKeys, result2 error) {
+	fake.workerKeysMutex.Lock()
+	defer fake.workerKeysMutex.Unlock()
+	fake.WorkerKeysStub = nil
+	fake.workerKeysReturns = struct {
+		result1 []atc.TeamWorkerKeys
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeamFactory) WorkerKeysReturnsOnCall(i int, result1 []atc.TeamWorkerKeys, result2 error) {
+	fake.workerKeysMutex.Lock()
+	defer fake.workerKeysMutex.Unlock()
+	fake.WorkerKeysStub = nil
+	if fake.workerKeysReturnsOnCall == nil {
+		fake.workerKeysReturnsOnCall = make(map[int]struct {
+			result1 []atc.TeamWorkerKeys
+			result2 error
+		})
+	}
+	fake.workerKeysReturnsOnCall[i] = struct {
+		result1 []atc.TeamWorkerKeys
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeamFactory) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -523,6 +591,8 @@ func (fake *FakeTeamFactory) Invocations() map[string][][]interface{} {
 	defer fake.notifyCacherMutex.RUnlock()
 	fake.notifyResourceScannerMutex.RLock()
 	defer fake.notifyResourceScannerMutex.RUnlock()
+	fake.workerKeysMutex.RLock()
+	defer fake.workerKeysMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
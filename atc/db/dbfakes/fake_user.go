@@ -9,6 +9,16 @@ import (
 )
 
 type FakeUser struct {
+	ClaimsStub        func() db.Claims
+	claimsMutex       sync.RWMutex
+	claimsArgsForCall []struct {
+	}
+	claimsReturns struct {
+		result1 db.Claims
+	}
+	claimsReturnsOnCall map[int]struct {
+		result1 db.Claims
+	}
 	ConnectorStub        func() string
 	connectorMutex       sync.RWMutex
 	connectorArgsForCall []struct {
@@ -63,6 +73,59 @@ type FakeUser struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeUser) Claims() db.Claims {
+	fake.claimsMutex.Lock()
+	ret, specificReturn := fake.claimsReturnsOnCall[len(fake.claimsArgsForCall)]
+	fake.claimsArgsForCall = append(fake.claimsArgsForCall, struct {
+	}{})
+	stub := fake.ClaimsStub
+	fakeReturns := fake.claimsReturns
+	fake.recordInvocation("Claims", []interface{}{})
+	fake.claimsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeUser) ClaimsCallCount() int {
+	fake.claimsMutex.RLock()
+	defer fake.claimsMutex.RUnlock()
+	return len(fake.claimsArgsForCall)
+}
+
+func (fake *FakeUser) ClaimsCalls(stub func() db.Claims) {
+	fake.claimsMutex.Lock()
+	defer fake.claimsMutex.Unlock()
+	fake.ClaimsStub = stub
+}
+
+func (fake *FakeUser) ClaimsReturns(result1 db.Claims) {
+	fake.claimsMutex.Lock()
+	defer fake.claimsMutex.Unlock()
+	fake.ClaimsStub = nil
+	fake.claimsReturns = struct {
+		result1 db.Claims
+	}{result1}
+}
+
+func (fake *FakeUser) ClaimsReturnsOnCall(i int, result1 db.Claims) {
+	fake.claimsMutex.Lock()
+	defer fake.claimsMutex.Unlock()
+	fake.ClaimsStub = nil
+	if fake.claimsReturnsOnCall == nil {
+		fake.claimsReturnsOnCall = make(map[int]struct {
+			result1 db.Claims
+		})
+	}
+	fake.claimsReturnsOnCall[i] = struct {
+		result1 db.Claims
+	}{result1}
+}
+
 func (fake *FakeUser) Connector() string {
 	fake.connectorMutex.Lock()
 	ret, specificReturn := fake.connectorReturnsOnCall[len(fake.connectorArgsForCall)]
@@ -331,6 +394,8 @@ func (fake *FakeUser) SubReturnsOnCall(i int, result1 string) {
 func (fake *FakeUser) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.claimsMutex.RLock()
+	defer fake.claimsMutex.RUnlock()
 	fake.connectorMutex.RLock()
 	defer fake.connectorMutex.RUnlock()
 	fake.iDMutex.RLock()
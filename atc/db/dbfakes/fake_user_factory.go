@@ -9,12 +9,13 @@ import (
 )
 
 type FakeUserFactory struct {
-	CreateOrUpdateUserStub        func(string, string, string) error
+	CreateOrUpdateUserStub        func(string, string, string, db.Claims) error
 	createOrUpdateUserMutex       sync.RWMutex
 	createOrUpdateUserArgsForCall []struct {
 		arg1 string
 		arg2 string
 		arg3 string
+		arg4 db.Claims
 	}
 	createOrUpdateUserReturns struct {
 		result1 error
@@ -47,24 +48,40 @@ type FakeUserFactory struct {
 		result1 []db.User
 		result2 error
 	}
+	GetUserStub        func(int) (db.User, bool, error)
+	getUserMutex       sync.RWMutex
+	getUserArgsForCall []struct {
+		arg1 int
+	}
+	getUserReturns struct {
+		result1 db.User
+		result2 bool
+		result3 error
+	}
+	getUserReturnsOnCall map[int]struct {
+		result1 db.User
+		result2 bool
+		result3 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeUserFactory) CreateOrUpdateUser(arg1 string, arg2 string, arg3 string) error {
+func (fake *FakeUserFactory) CreateOrUpdateUser(arg1 string, arg2 string, arg3 string, arg4 db.Claims) error {
 	fake.createOrUpdateUserMutex.Lock()
 	ret, specificReturn := fake.createOrUpdateUserReturnsOnCall[len(fake.createOrUpdateUserArgsForCall)]
 	fake.createOrUpdateUserArgsForCall = append(fake.createOrUpdateUserArgsForCall, struct {
 		arg1 string
 		arg2 string
 		arg3 string
-	}{arg1, arg2, arg3})
+		arg4 db.Claims
+	}{arg1, arg2, arg3, arg4})
 	stub := fake.CreateOrUpdateUserStub
 	fakeReturns := fake.createOrUpdateUserReturns
-	fake.recordInvocation("CreateOrUpdateUser", []interface{}{arg1, arg2, arg3})
+	fake.recordInvocation("CreateOrUpdateUser", []interface{}{arg1, arg2, arg3, arg4})
 	fake.createOrUpdateUserMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2, arg3)
+		return stub(arg1, arg2, arg3, arg4)
 	}
 	if specificReturn {
 		return ret.result1
@@ -78,17 +95,17 @@ func (fake *FakeUserFactory) CreateOrUpdateUserCallCount() int {
 	return len(fake.createOrUpdateUserArgsForCall)
 }
 
-func (fake *FakeUserFactory) CreateOrUpdateUserCalls(stub func(string, string, string) error) {
+func (fake *FakeUserFactory) CreateOrUpdateUserCalls(stub func(string, string, string, db.Claims) error) {
 	fake.createOrUpdateUserMutex.Lock()
 	defer fake.createOrUpdateUserMutex.Unlock()
 	fake.CreateOrUpdateUserStub = stub
 }
 
-func (fake *FakeUserFactory) CreateOrUpdateUserArgsForCall(i int) (string, string, string) {
+func (fake *FakeUserFactory) CreateOrUpdateUserArgsForCall(i int) (string, string, string, db.Claims) {
 	fake.createOrUpdateUserMutex.RLock()
 	defer fake.createOrUpdateUserMutex.RUnlock()
 	argsForCall := fake.createOrUpdateUserArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
 }
 
 func (fake *FakeUserFactory) CreateOrUpdateUserReturns(result1 error) {
@@ -234,9 +251,78 @@ func (fake *FakeUserFactory) GetAllUsersByLoginDateReturnsOnCall(i int, result1
 	}{result1, result2}
 }
 
+func (fake *FakeUserFactory) GetUser(arg1 int) (db.User, bool, error) {
+	fake.getUserMutex.Lock()
+	ret, specificReturn := fake.getUserReturnsOnCall[len(fake.getUserArgsForCall)]
+	fake.getUserArgsForCall = append(fake.getUserArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	stub := fake.GetUserStub
+	fakeReturns := fake.getUserReturns
+	fake.recordInvocation("GetUser", []interface{}{arg1})
+	fake.getUserMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeUserFactory) GetUserCallCount() int {
+	fake.getUserMutex.RLock()
+	defer fake.getUserMutex.RUnlock()
+	return len(fake.getUserArgsForCall)
+}
+
+func (fake *FakeUserFactory) GetUserCalls(stub func(int) (db.User, bool, error)) {
+	fake.getUserMutex.Lock()
+	defer fake.getUserMutex.Unlock()
+	fake.GetUserStub = stub
+}
+
+func (fake *FakeUserFactory) GetUserArgsForCall(i int) int {
+	fake.getUserMutex.RLock()
+	defer fake.getUserMutex.RUnlock()
+	argsForCall := fake.getUserArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeUserFactory) GetUserReturns(result1 db.User, result2 bool, result3 error) {
+	fake.getUserMutex.Lock()
+	defer fake.getUserMutex.Unlock()
+	fake.GetUserStub = nil
+	fake.getUserReturns = struct {
+		result1 db.User
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeUserFactory) GetUserReturnsOnCall(i int, result1 db.User, result2 bool, result3 error) {
+	fake.getUserMutex.Lock()
+	defer fake.getUserMutex.Unlock()
+	fake.GetUserStub = nil
+	if fake.getUserReturnsOnCall == nil {
+		fake.getUserReturnsOnCall = make(map[int]struct {
+			result1 db.User
+			result2 bool
+			result3 error
+		})
+	}
+	fake.getUserReturnsOnCall[i] = struct {
+		result1 db.User
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeUserFactory) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.getUserMutex.RLock()
+	defer fake.getUserMutex.RUnlock()
 	fake.createOrUpdateUserMutex.RLock()
 	defer fake.createOrUpdateUserMutex.RUnlock()
 	fake.getAllUsersMutex.RLock()
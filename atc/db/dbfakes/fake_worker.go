@@ -52,6 +52,26 @@ type FakeWorker struct {
 	baggageclaimURLReturnsOnCall map[int]struct {
 		result1 *string
 	}
+	CapabilitiesStub        func() *atc.WorkerCapabilities
+	capabilitiesMutex       sync.RWMutex
+	capabilitiesArgsForCall []struct {
+	}
+	capabilitiesReturns struct {
+		result1 *atc.WorkerCapabilities
+	}
+	capabilitiesReturnsOnCall map[int]struct {
+		result1 *atc.WorkerCapabilities
+	}
+	CertificateStub        func() *atc.WorkerCertificate
+	certificateMutex       sync.RWMutex
+	certificateArgsForCall []struct {
+	}
+	certificateReturns struct {
+		result1 *atc.WorkerCertificate
+	}
+	certificateReturnsOnCall map[int]struct {
+		result1 *atc.WorkerCertificate
+	}
 	CertsPathStub        func() *string
 	certsPathMutex       sync.RWMutex
 	certsPathArgsForCall []struct {
@@ -98,6 +118,16 @@ type FakeWorker struct {
 	deleteReturnsOnCall map[int]struct {
 		result1 error
 	}
+	DiskUsageStub        func() *atc.WorkerDiskUsage
+	diskUsageMutex       sync.RWMutex
+	diskUsageArgsForCall []struct {
+	}
+	diskUsageReturns struct {
+		result1 *atc.WorkerDiskUsage
+	}
+	diskUsageReturnsOnCall map[int]struct {
+		result1 *atc.WorkerDiskUsage
+	}
 	EphemeralStub        func() bool
 	ephemeralMutex       sync.RWMutex
 	ephemeralArgsForCall []struct {
@@ -272,6 +302,16 @@ type FakeWorker struct {
 	retireReturnsOnCall map[int]struct {
 		result1 error
 	}
+	RuntimeStub        func() string
+	runtimeMutex       sync.RWMutex
+	runtimeArgsForCall []struct {
+	}
+	runtimeReturns struct {
+		result1 string
+	}
+	runtimeReturnsOnCall map[int]struct {
+		result1 string
+	}
 	StartTimeStub        func() time.Time
 	startTimeMutex       sync.RWMutex
 	startTimeArgsForCall []struct {
@@ -551,6 +591,112 @@ func (fake *FakeWorker) BaggageclaimURLReturnsOnCall(i int, result1 *string) {
 	}{result1}
 }
 
+func (fake *FakeWorker) Capabilities() *atc.WorkerCapabilities {
+	fake.capabilitiesMutex.Lock()
+	ret, specificReturn := fake.capabilitiesReturnsOnCall[len(fake.capabilitiesArgsForCall)]
+	fake.capabilitiesArgsForCall = append(fake.capabilitiesArgsForCall, struct {
+	}{})
+	stub := fake.CapabilitiesStub
+	fakeReturns := fake.capabilitiesReturns
+	fake.recordInvocation("Capabilities", []interface{}{})
+	fake.capabilitiesMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorker) CapabilitiesCallCount() int {
+	fake.capabilitiesMutex.RLock()
+	defer fake.capabilitiesMutex.RUnlock()
+	return len(fake.capabilitiesArgsForCall)
+}
+
+func (fake *FakeWorker) CapabilitiesCalls(stub func() *atc.WorkerCapabilities) {
+	fake.capabilitiesMutex.Lock()
+	defer fake.capabilitiesMutex.Unlock()
+	fake.CapabilitiesStub = stub
+}
+
+func (fake *FakeWorker) CapabilitiesReturns(result1 *atc.WorkerCapabilities) {
+	fake.capabilitiesMutex.Lock()
+	defer fake.capabilitiesMutex.Unlock()
+	fake.CapabilitiesStub = nil
+	fake.capabilitiesReturns = struct {
+		result1 *atc.WorkerCapabilities
+	}{result1}
+}
+
+func (fake *FakeWorker) CapabilitiesReturnsOnCall(i int, result1 *atc.WorkerCapabilities) {
+	fake.capabilitiesMutex.Lock()
+	defer fake.capabilitiesMutex.Unlock()
+	fake.CapabilitiesStub = nil
+	if fake.capabilitiesReturnsOnCall == nil {
+		fake.capabilitiesReturnsOnCall = make(map[int]struct {
+			result1 *atc.WorkerCapabilities
+		})
+	}
+	fake.capabilitiesReturnsOnCall[i] = struct {
+		result1 *atc.WorkerCapabilities
+	}{result1}
+}
+
+func (fake *FakeWorker) Certificate() *atc.WorkerCertificate {
+	fake.certificateMutex.Lock()
+	ret, specificReturn := fake.certificateReturnsOnCall[len(fake.certificateArgsForCall)]
+	fake.certificateArgsForCall = append(fake.certificateArgsForCall, struct {
+	}{})
+	stub := fake.CertificateStub
+	fakeReturns := fake.certificateReturns
+	fake.recordInvocation("Certificate", []interface{}{})
+	fake.certificateMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorker) CertificateCallCount() int {
+	fake.certificateMutex.RLock()
+	defer fake.certificateMutex.RUnlock()
+	return len(fake.certificateArgsForCall)
+}
+
+func (fake *FakeWorker) CertificateCalls(stub func() *atc.WorkerCertificate) {
+	fake.certificateMutex.Lock()
+	defer fake.certificateMutex.Unlock()
+	fake.CertificateStub = stub
+}
+
+func (fake *FakeWorker) CertificateReturns(result1 *atc.WorkerCertificate) {
+	fake.certificateMutex.Lock()
+	defer fake.certificateMutex.Unlock()
+	fake.CertificateStub = nil
+	fake.certificateReturns = struct {
+		result1 *atc.WorkerCertificate
+	}{result1}
+}
+
+func (fake *FakeWorker) CertificateReturnsOnCall(i int, result1 *atc.WorkerCertificate) {
+	fake.certificateMutex.Lock()
+	defer fake.certificateMutex.Unlock()
+	fake.CertificateStub = nil
+	if fake.certificateReturnsOnCall == nil {
+		fake.certificateReturnsOnCall = make(map[int]struct {
+			result1 *atc.WorkerCertificate
+		})
+	}
+	fake.certificateReturnsOnCall[i] = struct {
+		result1 *atc.WorkerCertificate
+	}{result1}
+}
+
 func (fake *FakeWorker) CertsPath() *string {
 	fake.certsPathMutex.Lock()
 	ret, specificReturn := fake.certsPathReturnsOnCall[len(fake.certsPathArgsForCall)]
@@ -778,6 +924,59 @@ func (fake *FakeWorker) DeleteReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeWorker) DiskUsage() *atc.WorkerDiskUsage {
+	fake.diskUsageMutex.Lock()
+	ret, specificReturn := fake.diskUsageReturnsOnCall[len(fake.diskUsageArgsForCall)]
+	fake.diskUsageArgsForCall = append(fake.diskUsageArgsForCall, struct {
+	}{})
+	stub := fake.DiskUsageStub
+	fakeReturns := fake.diskUsageReturns
+	fake.recordInvocation("DiskUsage", []interface{}{})
+	fake.diskUsageMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorker) DiskUsageCallCount() int {
+	fake.diskUsageMutex.RLock()
+	defer fake.diskUsageMutex.RUnlock()
+	return len(fake.diskUsageArgsForCall)
+}
+
+func (fake *FakeWorker) DiskUsageCalls(stub func() *atc.WorkerDiskUsage) {
+	fake.diskUsageMutex.Lock()
+	defer fake.diskUsageMutex.Unlock()
+	fake.DiskUsageStub = stub
+}
+
+func (fake *FakeWorker) DiskUsageReturns(result1 *atc.WorkerDiskUsage) {
+	fake.diskUsageMutex.Lock()
+	defer fake.diskUsageMutex.Unlock()
+	fake.DiskUsageStub = nil
+	fake.diskUsageReturns = struct {
+		result1 *atc.WorkerDiskUsage
+	}{result1}
+}
+
+func (fake *FakeWorker) DiskUsageReturnsOnCall(i int, result1 *atc.WorkerDiskUsage) {
+	fake.diskUsageMutex.Lock()
+	defer fake.diskUsageMutex.Unlock()
+	fake.DiskUsageStub = nil
+	if fake.diskUsageReturnsOnCall == nil {
+		fake.diskUsageReturnsOnCall = make(map[int]struct {
+			result1 *atc.WorkerDiskUsage
+		})
+	}
+	fake.diskUsageReturnsOnCall[i] = struct {
+		result1 *atc.WorkerDiskUsage
+	}{result1}
+}
+
 func (fake *FakeWorker) Ephemeral() bool {
 	fake.ephemeralMutex.Lock()
 	ret, specificReturn := fake.ephemeralReturnsOnCall[len(fake.ephemeralArgsForCall)]
@@ -1660,6 +1859,59 @@ func (fake *FakeWorker) RetireReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeWorker) Runtime() string {
+	fake.runtimeMutex.Lock()
+	ret, specificReturn := fake.runtimeReturnsOnCall[len(fake.runtimeArgsForCall)]
+	fake.runtimeArgsForCall = append(fake.runtimeArgsForCall, struct {
+	}{})
+	stub := fake.RuntimeStub
+	fakeReturns := fake.runtimeReturns
+	fake.recordInvocation("Runtime", []interface{}{})
+	fake.runtimeMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorker) RuntimeCallCount() int {
+	fake.runtimeMutex.RLock()
+	defer fake.runtimeMutex.RUnlock()
+	return len(fake.runtimeArgsForCall)
+}
+
+func (fake *FakeWorker) RuntimeCalls(stub func() string) {
+	fake.runtimeMutex.Lock()
+	defer fake.runtimeMutex.Unlock()
+	fake.RuntimeStub = stub
+}
+
+func (fake *FakeWorker) RuntimeReturns(result1 string) {
+	fake.runtimeMutex.Lock()
+	defer fake.runtimeMutex.Unlock()
+	fake.RuntimeStub = nil
+	fake.runtimeReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeWorker) RuntimeReturnsOnCall(i int, result1 string) {
+	fake.runtimeMutex.Lock()
+	defer fake.runtimeMutex.Unlock()
+	fake.RuntimeStub = nil
+	if fake.runtimeReturnsOnCall == nil {
+		fake.runtimeReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.runtimeReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
 func (fake *FakeWorker) StartTime() time.Time {
 	fake.startTimeMutex.Lock()
 	ret, specificReturn := fake.startTimeReturnsOnCall[len(fake.startTimeArgsForCall)]
@@ -1981,6 +2233,12 @@ func (fake *FakeWorker) VersionReturnsOnCall(i int, result1 *string) {
 func (fake *FakeWorker) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.diskUsageMutex.RLock()
+	defer fake.diskUsageMutex.RUnlock()
+	fake.capabilitiesMutex.RLock()
+	defer fake.capabilitiesMutex.RUnlock()
+	fake.certificateMutex.RLock()
+	defer fake.certificateMutex.RUnlock()
 	fake.activeContainersMutex.RLock()
 	defer fake.activeContainersMutex.RUnlock()
 	fake.activeTasksMutex.RLock()
@@ -2029,6 +2287,8 @@ func (fake *FakeWorker) Invocations() map[string][][]interface{} {
 	defer fake.resourceTypesMutex.RUnlock()
 	fake.retireMutex.RLock()
 	defer fake.retireMutex.RUnlock()
+	fake.runtimeMutex.RLock()
+	defer fake.runtimeMutex.RUnlock()
 	fake.startTimeMutex.RLock()
 	defer fake.startTimeMutex.RUnlock()
 	fake.stateMutex.RLock()
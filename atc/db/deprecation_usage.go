@@ -0,0 +1,108 @@
+package db
+
+import (
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
+)
+
+// DeprecationUsageRepository keeps track of which clients still use the
+// deprecated parts of the API.
+//
+//counterfeiter:generate . DeprecationUsageRepository
+type DeprecationUsageRepository interface {
+	// RecordUsage adds the given usage to that of each client so far.
+	RecordUsage(usage []atc.DeprecationUsage) error
+
+	// Usage returns the usage of every client, most recently seen first.
+	Usage() ([]atc.DeprecationUsage, error)
+
+	// PruneUsage forgets the clients which haven't been seen since the
+	// given time.
+	PruneUsage(before time.Time) error
+}
+
+type deprecationUsageRepository struct {
+	conn Conn
+}
+
+func NewDeprecationUsageRepository(conn Conn) DeprecationUsageRepository {
+	return &deprecationUsageRepository{
+		conn: conn,
+	}
+}
+
+func (repo *deprecationUsageRepository) RecordUsage(usage []atc.DeprecationUsage) error {
+	if len(usage) == 0 {
+		return nil
+	}
+
+	tx, err := repo.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	for _, u := range usage {
+		_, err := psql.Insert("api_deprecation_usage").
+			Columns("route", "param", "user_agent", "team", "requests", "first_seen", "last_seen").
+			Values(u.Route, u.Param, u.UserAgent, u.Team, u.Requests, time.Unix(u.FirstSeen, 0), time.Unix(u.LastSeen, 0)).
+			Suffix(`
+				ON CONFLICT (route, param, user_agent, team) DO UPDATE SET
+					requests = api_deprecation_usage.requests + EXCLUDED.requests,
+					first_seen = LEAST(api_deprecation_usage.first_seen, EXCLUDED.first_seen),
+					last_seen = GREATEST(api_deprecation_usage.last_seen, EXCLUDED.last_seen)
+			`).
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (repo *deprecationUsageRepository) Usage() ([]atc.DeprecationUsage, error) {
+	rows, err := psql.Select(
+		"route",
+		"param",
+		"user_agent",
+		"team",
+		"requests",
+		"EXTRACT(EPOCH FROM first_seen)::bigint",
+		"EXTRACT(EPOCH FROM last_seen)::bigint",
+	).
+		From("api_deprecation_usage").
+		OrderBy("last_seen DESC", "route", "param", "user_agent", "team").
+		RunWith(repo.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	usage := []atc.DeprecationUsage{}
+	for rows.Next() {
+		var u atc.DeprecationUsage
+		err := rows.Scan(&u.Route, &u.Param, &u.UserAgent, &u.Team, &u.Requests, &u.FirstSeen, &u.LastSeen)
+		if err != nil {
+			return nil, err
+		}
+
+		usage = append(usage, u)
+	}
+
+	return usage, rows.Err()
+}
+
+func (repo *deprecationUsageRepository) PruneUsage(before time.Time) error {
+	_, err := psql.Delete("api_deprecation_usage").
+		Where(sq.Lt{"last_seen": before}).
+		RunWith(repo.conn).
+		Exec()
+	return err
+}
@@ -0,0 +1,99 @@
+package db_test
+
+import (
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DeprecationUsageRepository", func() {
+	var repository db.DeprecationUsageRepository
+
+	BeforeEach(func() {
+		repository = db.NewDeprecationUsageRepository(dbConn)
+	})
+
+	Describe("RecordUsage", func() {
+		It("adds up the usage of each client", func() {
+			Expect(repository.RecordUsage([]atc.DeprecationUsage{
+				{
+					Route:     atc.MainJobBadge,
+					UserAgent: "some-agent",
+					Requests:  2,
+					FirstSeen: 100,
+					LastSeen:  200,
+				},
+				{
+					Route:     atc.MainJobBadge,
+					UserAgent: "other-agent",
+					Requests:  1,
+					FirstSeen: 50,
+					LastSeen:  50,
+				},
+			})).To(Succeed())
+
+			Expect(repository.RecordUsage([]atc.DeprecationUsage{
+				{
+					Route:     atc.MainJobBadge,
+					UserAgent: "some-agent",
+					Requests:  3,
+					FirstSeen: 300,
+					LastSeen:  400,
+				},
+			})).To(Succeed())
+
+			usage, err := repository.Usage()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(usage).To(Equal([]atc.DeprecationUsage{
+				{
+					Route:     atc.MainJobBadge,
+					UserAgent: "some-agent",
+					Requests:  5,
+					FirstSeen: 100,
+					LastSeen:  400,
+				},
+				{
+					Route:     atc.MainJobBadge,
+					UserAgent: "other-agent",
+					Requests:  1,
+					FirstSeen: 50,
+					LastSeen:  50,
+				},
+			}))
+		})
+
+		It("tells teams apart", func() {
+			Expect(repository.RecordUsage([]atc.DeprecationUsage{
+				{Route: atc.MainJobBadge, UserAgent: "some-agent", Team: "some-team", Requests: 1, FirstSeen: 1, LastSeen: 1},
+				{Route: atc.MainJobBadge, UserAgent: "some-agent", Team: "other-team", Requests: 1, FirstSeen: 2, LastSeen: 2},
+			})).To(Succeed())
+
+			usage, err := repository.Usage()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(usage).To(HaveLen(2))
+			Expect(usage[0].Team).To(Equal("other-team"))
+			Expect(usage[1].Team).To(Equal("some-team"))
+		})
+	})
+
+	Describe("PruneUsage", func() {
+		It("forgets the clients which haven't been seen since the given time", func() {
+			now := time.Now().Unix()
+
+			Expect(repository.RecordUsage([]atc.DeprecationUsage{
+				{Route: atc.MainJobBadge, UserAgent: "old-agent", Requests: 1, FirstSeen: now - 7200, LastSeen: now - 7200},
+				{Route: atc.MainJobBadge, UserAgent: "new-agent", Requests: 1, FirstSeen: now, LastSeen: now},
+			})).To(Succeed())
+
+			Expect(repository.PruneUsage(time.Now().Add(-time.Hour))).To(Succeed())
+
+			usage, err := repository.Usage()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(usage).To(HaveLen(1))
+			Expect(usage[0].UserAgent).To(Equal("new-agent"))
+		})
+	})
+})
@@ -0,0 +1,156 @@
+// Package dialect describes how the databases Concourse can run against
+// differ from PostgreSQL, for the lock, migration and db packages to make up
+// for.
+//
+// PostgreSQL is what everything is written for. CockroachDB is supported as
+// far as the migrations allow: it has no advisory locks, which are emulated
+// with a table, it runs DDL poorly within transactions, and it expects
+// transactions which fail to serialize to be retried by the client. It has no
+// table inheritance or triggers either, which the migrations rely on, so
+// migrating a database from scratch fails at the first migration using them.
+package dialect
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/lib/pq"
+)
+
+type Dialect interface {
+	// Name is how the dialect is configured, e.g. "postgres".
+	Name() string
+
+	// AdvisoryLocks is whether the database has pg_try_advisory_lock and
+	// pg_advisory_unlock. Locks are emulated with a table when it doesn't.
+	AdvisoryLocks() bool
+
+	// TransactionalDDL is whether a migration can change the schema within a
+	// transaction, so that it is rolled back as a whole when it fails.
+	TransactionalDDL() bool
+
+	// TranslateDDL rewrites the statements of a SQL migration written for
+	// PostgreSQL into ones the database runs, or returns an
+	// UnsupportedError if they use what it doesn't have.
+	TranslateDDL(statements string) (string, error)
+
+	// ShouldRetry is whether a transaction which failed with err should be
+	// run again from the start.
+	ShouldRetry(err error) bool
+}
+
+var (
+	Postgres    Dialect = postgres{}
+	CockroachDB Dialect = cockroachDB{}
+)
+
+var dialects = map[string]Dialect{
+	Postgres.Name():    Postgres,
+	CockroachDB.Name(): CockroachDB,
+}
+
+// Names lists the dialects which can be configured.
+func Names() []string {
+	names := []string{}
+	for name := range dialects {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// ForName returns the dialect configured by name, PostgreSQL if it's empty.
+func ForName(name string) (Dialect, error) {
+	if name == "" {
+		return Postgres, nil
+	}
+
+	d, found := dialects[name]
+	if !found {
+		return nil, fmt.Errorf("unknown database dialect: %s", name)
+	}
+
+	return d, nil
+}
+
+// UnsupportedError is returned when statements use what the database
+// doesn't have.
+type UnsupportedError struct {
+	Dialect string
+	Feature string
+}
+
+func (err UnsupportedError) Error() string {
+	return fmt.Sprintf("%s doesn't support %s", err.Dialect, err.Feature)
+}
+
+type postgres struct{}
+
+func (postgres) Name() string           { return "postgres" }
+func (postgres) AdvisoryLocks() bool    { return true }
+func (postgres) TransactionalDDL() bool { return true }
+
+func (postgres) TranslateDDL(statements string) (string, error) {
+	return statements, nil
+}
+
+func (postgres) ShouldRetry(err error) bool {
+	return false
+}
+
+type rewrite struct {
+	pattern     *regexp.Regexp
+	replacement string
+}
+
+type unsupported struct {
+	pattern *regexp.Regexp
+	feature string
+}
+
+var cockroachDBRewrites = []rewrite{
+	// inverted indexes take no operator classes
+	{regexp.MustCompile(`(?i)USING\s+gin\s*\(\s*(\w+)\s+jsonb_path_ops\s*\)`), "USING gin($1)"},
+
+	// nor storage parameters
+	{regexp.MustCompile(`(?i)\s+WITH\s*\(\s*fastupdate\s*=\s*\w+\s*\)`), ""},
+}
+
+var cockroachDBUnsupported = []unsupported{
+	{regexp.MustCompile(`(?i)\bINHERITS\s*\(`), "table inheritance"},
+	{regexp.MustCompile(`(?i)\bCREATE\s+(CONSTRAINT\s+)?TRIGGER\b`), "triggers"},
+	{regexp.MustCompile(`(?i)\bSECURITY\s+DEFINER\b`), "SECURITY DEFINER functions"},
+}
+
+type cockroachDB struct{}
+
+func (cockroachDB) Name() string           { return "cockroachdb" }
+func (cockroachDB) AdvisoryLocks() bool    { return false }
+func (cockroachDB) TransactionalDDL() bool { return false }
+
+func (d cockroachDB) TranslateDDL(statements string) (string, error) {
+	for _, u := range cockroachDBUnsupported {
+		if u.pattern.MatchString(statements) {
+			return "", UnsupportedError{Dialect: d.Name(), Feature: u.feature}
+		}
+	}
+
+	for _, r := range cockroachDBRewrites {
+		statements = r.pattern.ReplaceAllString(statements, r.replacement)
+	}
+
+	return statements, nil
+}
+
+func (cockroachDB) ShouldRetry(err error) bool {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return pqErr.Code == "40001"
+	}
+
+	return false
+}
@@ -0,0 +1,13 @@
+package dialect_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestDialect(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Dialect Suite")
+}
@@ -0,0 +1,67 @@
+package dialect_test
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/concourse/concourse/atc/db/dialect"
+	"github.com/lib/pq"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Dialect", func() {
+	Describe("ForName", func() {
+		It("defaults to PostgreSQL", func() {
+			Expect(dialect.ForName("")).To(Equal(dialect.Postgres))
+		})
+
+		It("finds the dialect by name", func() {
+			for _, name := range dialect.Names() {
+				d, err := dialect.ForName(name)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(d.Name()).To(Equal(name))
+			}
+		})
+
+		It("errors for unknown dialects", func() {
+			_, err := dialect.ForName("mysql")
+			Expect(err).To(MatchError("unknown database dialect: mysql"))
+		})
+	})
+
+	Describe("Postgres", func() {
+		It("leaves the statements as they are", func() {
+			statements := `CREATE TABLE some_table () INHERITS (build_events);`
+			Expect(dialect.Postgres.TranslateDDL(statements)).To(Equal(statements))
+		})
+
+		It("never retries", func() {
+			Expect(dialect.Postgres.ShouldRetry(&pq.Error{Code: "40001"})).To(BeFalse())
+		})
+	})
+
+	Describe("CockroachDB", func() {
+		It("rewrites inverted indexes", func() {
+			Expect(dialect.CockroachDB.TranslateDDL(
+				`CREATE INDEX some_index ON some_table USING gin(version jsonb_path_ops) WITH (FASTUPDATE = false);`,
+			)).To(Equal(
+				`CREATE INDEX some_index ON some_table USING gin(version);`,
+			))
+		})
+
+		It("refuses what it doesn't support", func() {
+			_, err := dialect.CockroachDB.TranslateDDL(`CREATE TABLE some_table () INHERITS (build_events);`)
+			Expect(err).To(Equal(dialect.UnsupportedError{Dialect: "cockroachdb", Feature: "table inheritance"}))
+
+			_, err = dialect.CockroachDB.TranslateDDL(`CREATE TRIGGER some_trigger AFTER INSERT ON some_table FOR EACH ROW EXECUTE PROCEDURE some_function();`)
+			Expect(err).To(Equal(dialect.UnsupportedError{Dialect: "cockroachdb", Feature: "triggers"}))
+		})
+
+		It("retries transactions which failed to serialize", func() {
+			Expect(dialect.CockroachDB.ShouldRetry(fmt.Errorf("some migration: %w", &pq.Error{Code: "40001"}))).To(BeTrue())
+			Expect(dialect.CockroachDB.ShouldRetry(&pq.Error{Code: "23505"})).To(BeFalse())
+			Expect(dialect.CockroachDB.ShouldRetry(errors.New("nope"))).To(BeFalse())
+		})
+	})
+})
@@ -0,0 +1,76 @@
+package db
+
+import (
+	"database/sql"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// DurationEstimateWeight is how much each new sample counts towards a
+// rolling duration estimate, so that the estimates follow the builds of a job
+// as they speed up or slow down. Until there are enough samples for that,
+// the estimate is their mean.
+const DurationEstimateWeight = 0.2
+
+// saveDurationSample adds how long a build of the job, or the step of it with
+// the given name, took to its estimate. The estimate of the build as a whole
+// has an empty step name.
+func saveDurationSample(runner sq.Execer, jobID int, stepName string, duration time.Duration) error {
+	_, err := runner.Exec(`
+		INSERT INTO job_duration_estimates AS e (job_id, step_name, duration)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (job_id, step_name) DO UPDATE SET
+			duration = e.duration + (EXCLUDED.duration - e.duration) * greatest($4::float8, 1 / (e.samples + 1)::float8),
+			samples = e.samples + 1
+	`, jobID, stepName, duration.Seconds(), DurationEstimateWeight)
+	return err
+}
+
+func secondsToDuration(seconds float64) time.Duration {
+	return time.Duration(seconds * float64(time.Second)).Round(time.Second)
+}
+
+// EstimatedDuration is how long the builds of the job are expected to take,
+// going by the ones which succeeded before.
+func (b *build) EstimatedDuration() (time.Duration, bool) {
+	if !b.estimatedDuration.Valid {
+		return 0, false
+	}
+
+	return secondsToDuration(b.estimatedDuration.Float64), true
+}
+
+func (b *build) StepDurationEstimate(stepName string) (time.Duration, bool, error) {
+	if b.jobID == 0 {
+		return 0, false, nil
+	}
+
+	var seconds float64
+	err := psql.Select("duration").
+		From("job_duration_estimates").
+		Where(sq.Eq{
+			"job_id":    b.jobID,
+			"step_name": stepName,
+		}).
+		RunWith(b.conn).
+		QueryRow().
+		Scan(&seconds)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, false, nil
+		}
+
+		return 0, false, err
+	}
+
+	return secondsToDuration(seconds), true, nil
+}
+
+func (b *build) SaveStepDuration(stepName string, duration time.Duration) error {
+	if b.jobID == 0 {
+		return nil
+	}
+
+	return saveDurationSample(b.conn, b.jobID, stepName, duration)
+}
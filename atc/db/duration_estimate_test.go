@@ -0,0 +1,123 @@
+package db_test
+
+import (
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Duration estimates", func() {
+	runBuild := func(duration time.Duration, status db.BuildStatus) db.Build {
+		build, err := defaultJob.CreateBuild(defaultBuildCreatedBy)
+		Expect(err).ToNot(HaveOccurred())
+
+		started, err := build.Start(atc.Plan{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(started).To(BeTrue())
+
+		_, err = dbConn.Exec(`UPDATE builds SET start_time = now() - $2 * '1 second'::interval WHERE id = $1`, build.ID(), duration.Seconds())
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(build.Finish(status)).To(Succeed())
+
+		return build
+	}
+
+	estimate := func() (time.Duration, bool) {
+		build, err := defaultJob.CreateBuild(defaultBuildCreatedBy)
+		Expect(err).ToNot(HaveOccurred())
+
+		return build.EstimatedDuration()
+	}
+
+	It("has no estimate before any build of the job succeeded", func() {
+		_, found := estimate()
+		Expect(found).To(BeFalse())
+	})
+
+	It("estimates from the builds of the job which succeeded", func() {
+		runBuild(time.Minute, db.BuildStatusSucceeded)
+		runBuild(3*time.Minute, db.BuildStatusSucceeded)
+		runBuild(time.Hour, db.BuildStatusFailed)
+
+		duration, found := estimate()
+		Expect(found).To(BeTrue())
+		Expect(duration).To(BeNumerically("~", 2*time.Minute, time.Second))
+	})
+
+	It("weighs recent builds more once there are enough of them", func() {
+		for i := 0; i < 10; i++ {
+			runBuild(time.Minute, db.BuildStatusSucceeded)
+		}
+
+		runBuild(11*time.Minute, db.BuildStatusSucceeded)
+
+		duration, found := estimate()
+		Expect(found).To(BeTrue())
+		Expect(duration).To(BeNumerically("~", 3*time.Minute, time.Second))
+	})
+
+	It("is part of the build once it's reloaded", func() {
+		build := runBuild(time.Minute, db.BuildStatusSucceeded)
+
+		_, found := build.EstimatedDuration()
+		Expect(found).To(BeFalse())
+
+		reloaded, err := build.Reload()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(reloaded).To(BeTrue())
+
+		duration, found := build.EstimatedDuration()
+		Expect(found).To(BeTrue())
+		Expect(duration).To(BeNumerically("~", time.Minute, time.Second))
+	})
+
+	Describe("step estimates", func() {
+		var build db.Build
+
+		BeforeEach(func() {
+			var err error
+			build, err = defaultJob.CreateBuild(defaultBuildCreatedBy)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("is the mean of the first durations saved for the step", func() {
+			_, found, err := build.StepDurationEstimate("some-step")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+
+			Expect(build.SaveStepDuration("some-step", time.Minute)).To(Succeed())
+			Expect(build.SaveStepDuration("some-step", 2*time.Minute)).To(Succeed())
+
+			duration, found, err := build.StepDurationEstimate("some-step")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(duration).To(Equal(90 * time.Second))
+
+			_, found, err = build.StepDurationEstimate("other-step")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		It("is kept separately from the estimate of the build", func() {
+			Expect(build.SaveStepDuration("some-step", time.Minute)).To(Succeed())
+
+			_, found := estimate()
+			Expect(found).To(BeFalse())
+		})
+
+		It("isn't kept for builds which don't belong to a job", func() {
+			oneOff, err := defaultTeam.CreateOneOffBuild()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(oneOff.SaveStepDuration("some-step", time.Minute)).To(Succeed())
+
+			_, found, err := oneOff.StepDurationEstimate("some-step")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+	})
+})
@@ -0,0 +1,106 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
+)
+
+// FirehoseOutbox holds the events queued for the firehose exporter until
+// they have been published.
+//
+//counterfeiter:generate . FirehoseOutbox
+type FirehoseOutbox interface {
+	// Pending returns the queued events, oldest first.
+	Pending(limit int) ([]atc.FirehoseEvent, error)
+
+	// Delete deletes the given events once they have been published.
+	Delete(ids []int64) error
+}
+
+type firehoseOutbox struct {
+	conn Conn
+}
+
+func NewFirehoseOutbox(conn Conn) FirehoseOutbox {
+	return &firehoseOutbox{
+		conn: conn,
+	}
+}
+
+func (outbox *firehoseOutbox) Pending(limit int) ([]atc.FirehoseEvent, error) {
+	rows, err := psql.Select("id", "team_name", "event", "data", "created_at").
+		From("firehose_outbox").
+		OrderBy("id").
+		Limit(uint64(limit)).
+		RunWith(outbox.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	var events []atc.FirehoseEvent
+	for rows.Next() {
+		var (
+			event     atc.FirehoseEvent
+			teamName  sql.NullString
+			data      []byte
+			createdAt time.Time
+		)
+
+		err := rows.Scan(&event.ID, &teamName, &event.Event, &data, &createdAt)
+		if err != nil {
+			return nil, err
+		}
+
+		event.Team = teamName.String
+		event.Data = data
+		event.Time = createdAt.Unix()
+
+		events = append(events, event)
+	}
+
+	return events, rows.Err()
+}
+
+func (outbox *firehoseOutbox) Delete(ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	_, err := psql.Delete("firehose_outbox").
+		Where(sq.Eq{"id": ids}).
+		RunWith(outbox.conn).
+		Exec()
+	return err
+}
+
+// enqueueFirehoseEvent queues the event for the firehose exporter, if there
+// is one. A teamID of 0 is for the events of the cluster.
+func enqueueFirehoseEvent(tx Tx, teamID int, event atc.WebhookEvent, data interface{}) error {
+	if !atc.EnableFirehose {
+		return nil
+	}
+
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	var teamName interface{}
+	if teamID != 0 {
+		teamName = sq.Expr("(SELECT name FROM teams WHERE id = ?)", teamID)
+	}
+
+	_, err = psql.Insert("firehose_outbox").
+		Columns("team_name", "event", "data").
+		Values(teamName, string(event), payload).
+		RunWith(tx).
+		Exec()
+	return err
+}
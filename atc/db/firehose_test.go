@@ -0,0 +1,79 @@
+package db_test
+
+import (
+	"encoding/json"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("FirehoseOutbox", func() {
+	var (
+		outbox db.FirehoseOutbox
+		build  db.Build
+	)
+
+	BeforeEach(func() {
+		outbox = db.NewFirehoseOutbox(dbConn)
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		build, err = defaultJob.CreateBuild(defaultBuildCreatedBy)
+		Expect(err).ToNot(HaveOccurred())
+
+		started, err := build.Start(atc.Plan{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(started).To(BeTrue())
+
+		Expect(build.Finish(db.BuildStatusSucceeded)).To(Succeed())
+	})
+
+	Context("when the firehose is enabled", func() {
+		BeforeEach(func() {
+			atc.EnableFirehose = true
+		})
+
+		AfterEach(func() {
+			atc.EnableFirehose = false
+		})
+
+		It("queues the events of the build, oldest first", func() {
+			events, err := outbox.Pending(10)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(events).To(HaveLen(2))
+
+			Expect(events[0].Event).To(Equal(atc.WebhookEventBuildStarted))
+			Expect(events[0].Team).To(Equal(defaultTeam.Name()))
+			Expect(events[0].Time).ToNot(BeZero())
+
+			var data atc.Build
+			Expect(json.Unmarshal(events[0].Data, &data)).To(Succeed())
+			Expect(data.ID).To(Equal(build.ID()))
+
+			Expect(events[1].Event).To(Equal(atc.WebhookEventBuildFinished))
+		})
+
+		It("deletes the published events", func() {
+			events, err := outbox.Pending(10)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(outbox.Delete([]int64{events[0].ID})).To(Succeed())
+
+			remaining, err := outbox.Pending(10)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(remaining).To(HaveLen(1))
+			Expect(remaining[0].ID).To(Equal(events[1].ID))
+		})
+	})
+
+	Context("when the firehose is disabled", func() {
+		It("queues nothing", func() {
+			events, err := outbox.Pending(10)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(events).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,202 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
+)
+
+// FlakinessRepository analyzes the recent builds of jobs for flakiness,
+// i.e. builds which succeed and fail in turn while their inputs stay the
+// same.
+//
+//counterfeiter:generate . FlakinessRepository
+type FlakinessRepository interface {
+	AnalyzeFlakiness(criteria FlakinessCriteria) error
+
+	PipelineFlakiness(pipelineID int) ([]atc.JobFlakiness, error)
+	FlakyJobs(teamID int) ([]atc.JobFlakiness, error)
+}
+
+// FlakinessCriteria determines how many builds of each job are analyzed,
+// and what it takes for a job to be flagged as flaky.
+type FlakinessCriteria struct {
+	Builds   int
+	MinScore float64
+	MinFlips int
+}
+
+var jobFlakinessQuery = psql.Select(
+	"j.name",
+	"p.name",
+	"p.instance_vars",
+	"t.name",
+	"f.builds",
+	"f.repeats",
+	"f.flips",
+	"f.score",
+	"f.flaky",
+	"f.analyzed_at",
+).
+	From("job_flakiness f").
+	Join("jobs j ON j.id = f.job_id").
+	Join("pipelines p ON p.id = j.pipeline_id").
+	Join("teams t ON t.id = p.team_id").
+	Where(sq.Eq{"j.active": true})
+
+type flakinessRepository struct {
+	conn Conn
+}
+
+func NewFlakinessRepository(conn Conn) FlakinessRepository {
+	return &flakinessRepository{
+		conn: conn,
+	}
+}
+
+// AnalyzeFlakiness analyzes every job which has completed builds since it
+// was last analyzed. The inputs of builds are compared by the versions of
+// the resources they used.
+func (repo *flakinessRepository) AnalyzeFlakiness(criteria FlakinessCriteria) error {
+	_, err := repo.conn.Exec(`
+		WITH analyzed AS (
+			SELECT
+				j.id AS job_id,
+				j.latest_completed_build_id,
+				count(*) AS builds,
+				count(*) FILTER (WHERE b.inputs = b.prev_inputs) AS repeats,
+				count(*) FILTER (WHERE b.inputs = b.prev_inputs AND b.status <> b.prev_status) AS flips
+			FROM jobs j
+			LEFT JOIN job_flakiness f ON f.job_id = j.id
+			CROSS JOIN LATERAL (
+				SELECT
+					r.status,
+					r.inputs,
+					lag(r.status) OVER (ORDER BY r.id) AS prev_status,
+					lag(r.inputs) OVER (ORDER BY r.id) AS prev_inputs
+				FROM (
+					SELECT
+						b.id,
+						b.status,
+						(
+							SELECT md5(COALESCE(string_agg(i.name || ':' || i.resource_id || ':' || i.version_md5, ',' ORDER BY i.name, i.resource_id), ''))
+							FROM build_resource_config_version_inputs i
+							WHERE i.build_id = b.id
+						) AS inputs
+					FROM builds b
+					WHERE b.job_id = j.id
+					AND b.status IN ('succeeded', 'failed')
+					ORDER BY b.id DESC
+					LIMIT $1
+				) r
+			) b
+			WHERE j.active
+			AND j.latest_completed_build_id IS NOT NULL
+			AND j.latest_completed_build_id IS DISTINCT FROM f.latest_build_id
+			GROUP BY j.id, j.latest_completed_build_id
+		), scored AS (
+			SELECT
+				a.*,
+				CASE WHEN a.repeats = 0 THEN 0 ELSE a.flips::real / a.repeats END AS score
+			FROM analyzed a
+		)
+		INSERT INTO job_flakiness (job_id, latest_build_id, builds, repeats, flips, score, flaky, analyzed_at)
+		SELECT
+			job_id,
+			latest_completed_build_id,
+			builds,
+			repeats,
+			flips,
+			score,
+			flips >= $3 AND score >= $2,
+			now()
+		FROM scored
+		ON CONFLICT (job_id) DO UPDATE SET
+			latest_build_id = EXCLUDED.latest_build_id,
+			builds = EXCLUDED.builds,
+			repeats = EXCLUDED.repeats,
+			flips = EXCLUDED.flips,
+			score = EXCLUDED.score,
+			flaky = EXCLUDED.flaky,
+			analyzed_at = EXCLUDED.analyzed_at
+	`, criteria.Builds, criteria.MinScore, criteria.MinFlips)
+	return err
+}
+
+// PipelineFlakiness returns the flakiness of each analyzed job of the
+// pipeline.
+func (repo *flakinessRepository) PipelineFlakiness(pipelineID int) ([]atc.JobFlakiness, error) {
+	rows, err := jobFlakinessQuery.
+		Where(sq.Eq{"j.pipeline_id": pipelineID}).
+		OrderBy("j.id").
+		RunWith(repo.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	return scanJobFlakiness(rows)
+}
+
+// FlakyJobs returns the jobs of the team which are flagged as flaky, the
+// flakiest first.
+func (repo *flakinessRepository) FlakyJobs(teamID int) ([]atc.JobFlakiness, error) {
+	rows, err := jobFlakinessQuery.
+		Where(sq.Eq{
+			"p.team_id": teamID,
+			"f.flaky":   true,
+		}).
+		OrderBy("f.score DESC", "j.id").
+		RunWith(repo.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	return scanJobFlakiness(rows)
+}
+
+func scanJobFlakiness(rows *sql.Rows) ([]atc.JobFlakiness, error) {
+	defer Close(rows)
+
+	flakiness := []atc.JobFlakiness{}
+	for rows.Next() {
+		var (
+			job          atc.JobFlakiness
+			instanceVars sql.NullString
+			analyzedAt   time.Time
+		)
+
+		err := rows.Scan(
+			&job.JobName,
+			&job.PipelineName,
+			&instanceVars,
+			&job.TeamName,
+			&job.Builds,
+			&job.Repeats,
+			&job.Flips,
+			&job.Score,
+			&job.Flaky,
+			&analyzedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if instanceVars.Valid {
+			err = json.Unmarshal([]byte(instanceVars.String), &job.PipelineInstanceVars)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		job.AnalyzedAt = analyzedAt.Unix()
+
+		flakiness = append(flakiness, job)
+	}
+
+	return flakiness, nil
+}
@@ -0,0 +1,122 @@
+package db_test
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("FlakinessRepository", func() {
+	var (
+		repository db.FlakinessRepository
+		criteria   db.FlakinessCriteria
+	)
+
+	BeforeEach(func() {
+		repository = db.NewFlakinessRepository(dbConn)
+		criteria = db.FlakinessCriteria{Builds: 10, MinScore: 0.5, MinFlips: 2}
+	})
+
+	finishedBuild := func(status db.BuildStatus, versionMD5 string) {
+		build, err := defaultJob.CreateBuild(defaultBuildCreatedBy)
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = dbConn.Exec(
+			`INSERT INTO build_resource_config_version_inputs (build_id, resource_id, version_md5, name) VALUES ($1, $2, $3, 'some-input')`,
+			build.ID(), defaultResource.ID(), versionMD5,
+		)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(build.Finish(status)).To(Succeed())
+	}
+
+	analyzedJob := func() atc.JobFlakiness {
+		err := repository.AnalyzeFlakiness(criteria)
+		Expect(err).ToNot(HaveOccurred())
+
+		flakiness, err := repository.PipelineFlakiness(defaultPipeline.ID())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(flakiness).To(HaveLen(1))
+
+		return flakiness[0]
+	}
+
+	Context("when the builds alternate with unchanged inputs", func() {
+		BeforeEach(func() {
+			finishedBuild(db.BuildStatusSucceeded, "v1")
+			finishedBuild(db.BuildStatusFailed, "v1")
+			finishedBuild(db.BuildStatusSucceeded, "v1")
+			finishedBuild(db.BuildStatusSucceeded, "v2")
+		})
+
+		It("flags the job as flaky", func() {
+			job := analyzedJob()
+			Expect(job.JobName).To(Equal(defaultJob.Name()))
+			Expect(job.PipelineName).To(Equal(defaultPipeline.Name()))
+			Expect(job.TeamName).To(Equal(defaultTeam.Name()))
+			Expect(job.Builds).To(Equal(4))
+			Expect(job.Repeats).To(Equal(2))
+			Expect(job.Flips).To(Equal(2))
+			Expect(job.Score).To(Equal(1.0))
+			Expect(job.Flaky).To(BeTrue())
+			Expect(job.AnalyzedAt).ToNot(BeZero())
+		})
+
+		It("lists the job as one of the team's flaky jobs", func() {
+			Expect(repository.AnalyzeFlakiness(criteria)).To(Succeed())
+
+			flakyJobs, err := repository.FlakyJobs(defaultTeam.ID())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(flakyJobs).To(HaveLen(1))
+			Expect(flakyJobs[0].JobName).To(Equal(defaultJob.Name()))
+		})
+
+		It("only analyzes the job again once it has new builds", func() {
+			Expect(repository.AnalyzeFlakiness(criteria)).To(Succeed())
+
+			finishedBuild(db.BuildStatusSucceeded, "v2")
+			finishedBuild(db.BuildStatusSucceeded, "v2")
+
+			job := analyzedJob()
+			Expect(job.Builds).To(Equal(6))
+			Expect(job.Repeats).To(Equal(4))
+			Expect(job.Score).To(Equal(0.5))
+		})
+	})
+
+	Context("when the outcome only changes along with the inputs", func() {
+		BeforeEach(func() {
+			finishedBuild(db.BuildStatusSucceeded, "v1")
+			finishedBuild(db.BuildStatusFailed, "v2")
+			finishedBuild(db.BuildStatusFailed, "v2")
+			finishedBuild(db.BuildStatusSucceeded, "v3")
+		})
+
+		It("does not flag the job", func() {
+			job := analyzedJob()
+			Expect(job.Repeats).To(Equal(1))
+			Expect(job.Flips).To(BeZero())
+			Expect(job.Score).To(BeZero())
+			Expect(job.Flaky).To(BeFalse())
+
+			flakyJobs, err := repository.FlakyJobs(defaultTeam.ID())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(flakyJobs).To(BeEmpty())
+		})
+	})
+
+	Context("when errored builds are in between", func() {
+		BeforeEach(func() {
+			finishedBuild(db.BuildStatusSucceeded, "v1")
+			finishedBuild(db.BuildStatusErrored, "v1")
+			finishedBuild(db.BuildStatusSucceeded, "v1")
+		})
+
+		It("leaves them out", func() {
+			job := analyzedJob()
+			Expect(job.Builds).To(Equal(2))
+			Expect(job.Flips).To(BeZero())
+		})
+	})
+})
@@ -0,0 +1,184 @@
+package db
+
+import (
+	"database/sql"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
+	"github.com/lib/pq"
+)
+
+// FreezeWindowRepository manages the freeze windows of teams and the
+// cluster.
+//
+// A teamID of 0 refers to the freeze windows of the cluster, which apply to
+// every team.
+//
+//counterfeiter:generate . FreezeWindowRepository
+type FreezeWindowRepository interface {
+	FreezeWindows(teamID int) ([]atc.FreezeWindow, error)
+	CreateFreezeWindow(teamID int, window atc.FreezeWindow) (atc.FreezeWindow, error)
+	DeleteFreezeWindow(teamID int, id int) (bool, error)
+
+	ActiveFreezeWindow(teamID int, pipelineName string) (atc.FreezeWindow, bool, error)
+}
+
+var freezeWindowsQuery = psql.Select(
+	"w.id",
+	"t.name",
+	"w.start_time",
+	"w.end_time",
+	"w.reason",
+	"w.exempt_pipelines",
+).
+	From("freeze_windows w").
+	LeftJoin("teams t ON t.id = w.team_id")
+
+type freezeWindowRepository struct {
+	conn Conn
+}
+
+func NewFreezeWindowRepository(conn Conn) FreezeWindowRepository {
+	return &freezeWindowRepository{
+		conn: conn,
+	}
+}
+
+func freezeWindowTeamEq(teamID int) sq.Eq {
+	if teamID == 0 {
+		return sq.Eq{"w.team_id": nil}
+	}
+
+	return sq.Eq{"w.team_id": teamID}
+}
+
+func (repo *freezeWindowRepository) FreezeWindows(teamID int) ([]atc.FreezeWindow, error) {
+	rows, err := freezeWindowsQuery.
+		Where(freezeWindowTeamEq(teamID)).
+		OrderBy("w.start_time", "w.id").
+		RunWith(repo.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	windows := []atc.FreezeWindow{}
+	for rows.Next() {
+		window, err := scanFreezeWindow(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		windows = append(windows, window)
+	}
+
+	return windows, nil
+}
+
+func (repo *freezeWindowRepository) CreateFreezeWindow(teamID int, window atc.FreezeWindow) (atc.FreezeWindow, error) {
+	var team sql.NullInt64
+	if teamID != 0 {
+		team = sql.NullInt64{Int64: int64(teamID), Valid: true}
+	}
+
+	exemptPipelines := window.ExemptPipelines
+	if exemptPipelines == nil {
+		exemptPipelines = []string{}
+	}
+
+	err := psql.Insert("freeze_windows").
+		Columns("team_id", "start_time", "end_time", "reason", "exempt_pipelines").
+		Values(
+			team,
+			time.Unix(window.StartTime, 0),
+			time.Unix(window.EndTime, 0),
+			window.Reason,
+			pq.Array(exemptPipelines),
+		).
+		Suffix("RETURNING id").
+		RunWith(repo.conn).
+		QueryRow().
+		Scan(&window.ID)
+	if err != nil {
+		return atc.FreezeWindow{}, err
+	}
+
+	return window, nil
+}
+
+func (repo *freezeWindowRepository) DeleteFreezeWindow(teamID int, id int) (bool, error) {
+	result, err := psql.Delete("freeze_windows w").
+		Where(freezeWindowTeamEq(teamID)).
+		Where(sq.Eq{"w.id": id}).
+		RunWith(repo.conn).
+		Exec()
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// ActiveFreezeWindow returns the freeze window, of either the team or the
+// cluster, which currently prevents builds of the pipeline from starting.
+// If several windows are active, the one ending last is returned.
+func (repo *freezeWindowRepository) ActiveFreezeWindow(teamID int, pipelineName string) (atc.FreezeWindow, bool, error) {
+	row := freezeWindowsQuery.
+		Where(sq.Or{
+			sq.Eq{"w.team_id": nil},
+			sq.Eq{"w.team_id": teamID},
+		}).
+		Where(sq.Expr("w.start_time <= now()")).
+		Where(sq.Expr("w.end_time > now()")).
+		Where(sq.Expr("NOT (? = ANY(w.exempt_pipelines))", pipelineName)).
+		OrderBy("w.end_time DESC").
+		Limit(1).
+		RunWith(repo.conn).
+		QueryRow()
+
+	window, err := scanFreezeWindow(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return atc.FreezeWindow{}, false, nil
+		}
+
+		return atc.FreezeWindow{}, false, err
+	}
+
+	return window, true, nil
+}
+
+func scanFreezeWindow(row scannable) (atc.FreezeWindow, error) {
+	var (
+		window    atc.FreezeWindow
+		teamName  sql.NullString
+		startTime time.Time
+		endTime   time.Time
+	)
+
+	err := row.Scan(
+		&window.ID,
+		&teamName,
+		&startTime,
+		&endTime,
+		&window.Reason,
+		pq.Array(&window.ExemptPipelines),
+	)
+	if err != nil {
+		return atc.FreezeWindow{}, err
+	}
+
+	window.TeamName = teamName.String
+	window.StartTime = startTime.Unix()
+	window.EndTime = endTime.Unix()
+
+	return window, nil
+}
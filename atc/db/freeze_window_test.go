@@ -0,0 +1,173 @@
+package db_test
+
+import (
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("FreezeWindowRepository", func() {
+	var (
+		repository db.FreezeWindowRepository
+		now        int64
+	)
+
+	BeforeEach(func() {
+		repository = db.NewFreezeWindowRepository(dbConn)
+		now = time.Now().Unix()
+	})
+
+	Describe("CreateFreezeWindow", func() {
+		It("keeps the team's and the cluster's freeze windows separately", func() {
+			teamWindow, err := repository.CreateFreezeWindow(defaultTeam.ID(), atc.FreezeWindow{
+				StartTime:       now,
+				EndTime:         now + 3600,
+				Reason:          "release",
+				ExemptPipelines: []string{"hotfixes"},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(teamWindow.ID).ToNot(BeZero())
+
+			clusterWindow, err := repository.CreateFreezeWindow(0, atc.FreezeWindow{
+				StartTime: now,
+				EndTime:   now + 7200,
+				Reason:    "maintenance",
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			teamWindows, err := repository.FreezeWindows(defaultTeam.ID())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(teamWindows).To(HaveLen(1))
+			Expect(teamWindows[0].ID).To(Equal(teamWindow.ID))
+			Expect(teamWindows[0].TeamName).To(Equal(defaultTeam.Name()))
+			Expect(teamWindows[0].StartTime).To(Equal(now))
+			Expect(teamWindows[0].EndTime).To(Equal(now + 3600))
+			Expect(teamWindows[0].Reason).To(Equal("release"))
+			Expect(teamWindows[0].ExemptPipelines).To(Equal([]string{"hotfixes"}))
+
+			clusterWindows, err := repository.FreezeWindows(0)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(clusterWindows).To(HaveLen(1))
+			Expect(clusterWindows[0].ID).To(Equal(clusterWindow.ID))
+			Expect(clusterWindows[0].TeamName).To(BeEmpty())
+			Expect(clusterWindows[0].Reason).To(Equal("maintenance"))
+		})
+	})
+
+	Describe("DeleteFreezeWindow", func() {
+		var window atc.FreezeWindow
+
+		BeforeEach(func() {
+			var err error
+			window, err = repository.CreateFreezeWindow(defaultTeam.ID(), atc.FreezeWindow{
+				StartTime: now,
+				EndTime:   now + 3600,
+			})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("deletes the freeze window", func() {
+			deleted, err := repository.DeleteFreezeWindow(defaultTeam.ID(), window.ID)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(deleted).To(BeTrue())
+
+			windows, err := repository.FreezeWindows(defaultTeam.ID())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(windows).To(BeEmpty())
+		})
+
+		It("does not delete the freeze window of another team", func() {
+			deleted, err := repository.DeleteFreezeWindow(0, window.ID)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(deleted).To(BeFalse())
+
+			windows, err := repository.FreezeWindows(defaultTeam.ID())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(windows).To(HaveLen(1))
+		})
+	})
+
+	Describe("ActiveFreezeWindow", func() {
+		var otherTeam db.Team
+
+		BeforeEach(func() {
+			var err error
+			otherTeam, err = teamFactory.CreateTeam(atc.Team{Name: "some-other-team"})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("does not find a window when there is none", func() {
+			_, found, err := repository.ActiveFreezeWindow(defaultTeam.ID(), "some-pipeline")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		It("finds the team's current window", func() {
+			window, err := repository.CreateFreezeWindow(defaultTeam.ID(), atc.FreezeWindow{
+				StartTime: now - 60,
+				EndTime:   now + 3600,
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			active, found, err := repository.ActiveFreezeWindow(defaultTeam.ID(), "some-pipeline")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(active.ID).To(Equal(window.ID))
+
+			_, found, err = repository.ActiveFreezeWindow(otherTeam.ID(), "some-pipeline")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		It("finds the cluster's current window for every team", func() {
+			window, err := repository.CreateFreezeWindow(0, atc.FreezeWindow{
+				StartTime: now - 60,
+				EndTime:   now + 3600,
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			active, found, err := repository.ActiveFreezeWindow(otherTeam.ID(), "some-pipeline")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(active.ID).To(Equal(window.ID))
+		})
+
+		It("ignores windows which are over or have not started yet", func() {
+			_, err := repository.CreateFreezeWindow(0, atc.FreezeWindow{
+				StartTime: now - 3600,
+				EndTime:   now - 60,
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = repository.CreateFreezeWindow(0, atc.FreezeWindow{
+				StartTime: now + 60,
+				EndTime:   now + 3600,
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			_, found, err := repository.ActiveFreezeWindow(defaultTeam.ID(), "some-pipeline")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		It("ignores windows from which the pipeline is exempt", func() {
+			_, err := repository.CreateFreezeWindow(0, atc.FreezeWindow{
+				StartTime:       now - 60,
+				EndTime:         now + 3600,
+				ExemptPipelines: []string{"hotfixes"},
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			_, found, err := repository.ActiveFreezeWindow(defaultTeam.ID(), "hotfixes")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+
+			_, found, err = repository.ActiveFreezeWindow(defaultTeam.ID(), "some-pipeline")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+		})
+	})
+})
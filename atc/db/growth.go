@@ -0,0 +1,175 @@
+package db
+
+import (
+	"database/sql"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
+)
+
+// GrowthRepository snapshots how much each team has of what grows the
+// database the most, so that its growth can be followed over time.
+//
+//counterfeiter:generate . GrowthRepository
+type GrowthRepository interface {
+	// TakeSnapshot snapshots every team, and the cluster as a whole.
+	TakeSnapshot(takenAt time.Time) error
+
+	// Snapshots returns the snapshots of a team, or of the cluster when the
+	// team is empty, taken since the given time, oldest first.
+	Snapshots(team string, since time.Time) ([]atc.DBGrowthSnapshot, error)
+
+	// PruneSnapshots deletes the snapshots taken before the given time.
+	PruneSnapshots(before time.Time) error
+}
+
+type growthRepository struct {
+	conn Conn
+}
+
+func NewGrowthRepository(conn Conn) GrowthRepository {
+	return &growthRepository{
+		conn: conn,
+	}
+}
+
+// TakeSnapshot counts the rows of each team exactly, except for the build
+// events: counting them would mean scanning the biggest tables there are, so
+// they're estimated from the statistics of the team's build event tables. The
+// versions of a team are those of the resource configs its resources use.
+func (repo *growthRepository) TakeSnapshot(takenAt time.Time) error {
+	tx, err := repo.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	_, err = tx.Exec(`
+		WITH events AS (
+			SELECT p.team_id, s.n_live_tup AS tuples, pg_table_size(s.relid) AS bytes
+			FROM pipelines p
+			JOIN pg_stat_user_tables s
+			ON s.schemaname = current_schema()
+			AND s.relname = 'pipeline_build_events_' || p.id
+			UNION ALL
+			SELECT t.id, s.n_live_tup, pg_table_size(s.relid)
+			FROM teams t
+			JOIN pg_stat_user_tables s
+			ON s.schemaname = current_schema()
+			AND s.relname = 'team_build_events_' || t.id
+		)
+		INSERT INTO growth_snapshots (taken_at, team_id, builds, build_events, build_events_bytes, versions, containers, volumes)
+		SELECT $1, t.id,
+			(SELECT COUNT(*) FROM builds b WHERE b.team_id = t.id),
+			(SELECT coalesce(sum(e.tuples), 0) FROM events e WHERE e.team_id = t.id),
+			(SELECT coalesce(sum(e.bytes), 0) FROM events e WHERE e.team_id = t.id),
+			(
+				SELECT COUNT(*)
+				FROM resource_config_versions v
+				WHERE v.resource_config_scope_id IN (
+					SELECT r.resource_config_scope_id
+					FROM resources r
+					JOIN pipelines p ON p.id = r.pipeline_id
+					WHERE p.team_id = t.id
+				)
+			),
+			(SELECT COUNT(*) FROM containers c WHERE c.team_id = t.id),
+			(SELECT COUNT(*) FROM volumes v WHERE v.team_id = t.id)
+		FROM teams t
+	`, takenAt)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO growth_snapshots (taken_at, team_id, builds, build_events, build_events_bytes, versions, containers, volumes, database_bytes)
+		SELECT $1, NULL,
+			coalesce(sum(s.builds), 0),
+			coalesce(sum(s.build_events), 0),
+			coalesce(sum(s.build_events_bytes), 0),
+			(SELECT COUNT(*) FROM resource_config_versions),
+			coalesce(sum(s.containers), 0),
+			coalesce(sum(s.volumes), 0),
+			pg_database_size(current_database())
+		FROM growth_snapshots s
+		WHERE s.taken_at = $1
+		AND s.team_id IS NOT NULL
+	`, takenAt)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (repo *growthRepository) Snapshots(team string, since time.Time) ([]atc.DBGrowthSnapshot, error) {
+	query := psql.Select(`
+			EXTRACT(EPOCH FROM s.taken_at)::bigint,
+			t.name,
+			s.builds,
+			s.build_events,
+			s.build_events_bytes,
+			s.versions,
+			s.containers,
+			s.volumes,
+			s.database_bytes
+		`).
+		From("growth_snapshots s").
+		LeftJoin("teams t ON t.id = s.team_id").
+		Where(sq.GtOrEq{"s.taken_at": since}).
+		OrderBy("s.taken_at")
+
+	if team == "" {
+		query = query.Where(sq.Eq{"s.team_id": nil})
+	} else {
+		query = query.Where(sq.Eq{"t.name": team})
+	}
+
+	rows, err := query.RunWith(repo.conn).Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	snapshots := []atc.DBGrowthSnapshot{}
+	for rows.Next() {
+		var (
+			snapshot      atc.DBGrowthSnapshot
+			teamName      sql.NullString
+			databaseBytes sql.NullInt64
+		)
+
+		err := rows.Scan(
+			&snapshot.TakenAt,
+			&teamName,
+			&snapshot.Builds,
+			&snapshot.BuildEvents,
+			&snapshot.BuildEventsBytes,
+			&snapshot.Versions,
+			&snapshot.Containers,
+			&snapshot.Volumes,
+			&databaseBytes,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		snapshot.Team = teamName.String
+		snapshot.DatabaseBytes = databaseBytes.Int64
+
+		snapshots = append(snapshots, snapshot)
+	}
+
+	return snapshots, rows.Err()
+}
+
+func (repo *growthRepository) PruneSnapshots(before time.Time) error {
+	_, err := psql.Delete("growth_snapshots").
+		Where(sq.Lt{"taken_at": before}).
+		RunWith(repo.conn).
+		Exec()
+	return err
+}
@@ -0,0 +1,106 @@
+package db_test
+
+import (
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("GrowthRepository", func() {
+	var (
+		repository db.GrowthRepository
+		takenAt    time.Time
+	)
+
+	BeforeEach(func() {
+		repository = db.NewGrowthRepository(dbConn)
+		takenAt = time.Unix(1700000000, 0)
+
+		_, err := defaultJob.CreateBuild(defaultBuildCreatedBy)
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = defaultTeam.CreateOneOffBuild()
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	Describe("TakeSnapshot", func() {
+		JustBeforeEach(func() {
+			err := repository.TakeSnapshot(takenAt)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("snapshots each team", func() {
+			snapshots, err := repository.Snapshots(defaultTeam.Name(), time.Time{})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(snapshots).To(HaveLen(1))
+			Expect(snapshots[0].TakenAt).To(Equal(takenAt.Unix()))
+			Expect(snapshots[0].Team).To(Equal(defaultTeam.Name()))
+			Expect(snapshots[0].Builds).To(Equal(int64(2)))
+			Expect(snapshots[0].DatabaseBytes).To(BeZero())
+		})
+
+		It("snapshots the cluster", func() {
+			snapshots, err := repository.Snapshots("", time.Time{})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(snapshots).To(HaveLen(1))
+			Expect(snapshots[0].Team).To(BeEmpty())
+			Expect(snapshots[0].Builds).To(BeNumerically(">=", 2))
+			Expect(snapshots[0].DatabaseBytes).To(BeNumerically(">", 0))
+		})
+	})
+
+	Describe("Snapshots", func() {
+		BeforeEach(func() {
+			for i := 0; i < 3; i++ {
+				err := repository.TakeSnapshot(takenAt.Add(time.Duration(i) * time.Hour))
+				Expect(err).ToNot(HaveOccurred())
+			}
+		})
+
+		It("returns the snapshots taken since the given time, oldest first", func() {
+			snapshots, err := repository.Snapshots(defaultTeam.Name(), takenAt.Add(time.Hour))
+			Expect(err).ToNot(HaveOccurred())
+
+			var times []int64
+			for _, snapshot := range snapshots {
+				times = append(times, snapshot.TakenAt)
+			}
+
+			Expect(times).To(Equal([]int64{
+				takenAt.Add(time.Hour).Unix(),
+				takenAt.Add(2 * time.Hour).Unix(),
+			}))
+		})
+
+		It("returns nothing for an unknown team", func() {
+			snapshots, err := repository.Snapshots("bogus-team", time.Time{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(snapshots).To(Equal([]atc.DBGrowthSnapshot{}))
+		})
+	})
+
+	Describe("PruneSnapshots", func() {
+		BeforeEach(func() {
+			err := repository.TakeSnapshot(takenAt)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = repository.TakeSnapshot(takenAt.Add(time.Hour))
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("deletes the snapshots taken before the given time", func() {
+			err := repository.PruneSnapshots(takenAt.Add(time.Minute))
+			Expect(err).ToNot(HaveOccurred())
+
+			snapshots, err := repository.Snapshots("", time.Time{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(snapshots).To(HaveLen(1))
+			Expect(snapshots[0].TakenAt).To(Equal(takenAt.Add(time.Hour).Unix()))
+		})
+	})
+})
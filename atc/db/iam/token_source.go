@@ -0,0 +1,134 @@
+// Package iam authenticates to the database with short-lived tokens from a
+// cloud provider's identity and access management, in place of a password.
+package iam
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/rds/rdsutils"
+	"github.com/concourse/flag"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+)
+
+const (
+	ProviderAWS = "aws"
+	ProviderGCP = "gcp"
+)
+
+// cloudSQLLoginScope is the scope of the access tokens Cloud SQL accepts as
+// passwords.
+const cloudSQLLoginScope = "https://www.googleapis.com/auth/sqlservice.login"
+
+// TokenSource produces the tokens to connect to the database with. Each call
+// returns a token which is good for at least one more connection.
+type TokenSource interface {
+	Token() (string, error)
+}
+
+type Config struct {
+	Provider  string `long:"provider" choice:"aws" choice:"gcp" description:"Authenticate with tokens from AWS RDS IAM or GCP Cloud SQL IAM authentication rather than --postgres-password. Credentials are found the way the provider's SDK finds them, e.g. from the environment or the instance's role."`
+	AWSRegion string `long:"aws-region" description:"The AWS region the database is in. Defaults to the region the AWS SDK is configured with."`
+}
+
+func (config Config) Enabled() bool {
+	return config.Provider != ""
+}
+
+// TokenSource returns the source of tokens to connect to the database
+// configured by postgres with.
+func (config Config) TokenSource(postgres flag.PostgresConfig) (TokenSource, error) {
+	if postgres.User == "" {
+		return nil, errors.New("--postgres-user must be set to authenticate with IAM")
+	}
+
+	switch config.Provider {
+	case ProviderAWS:
+		return NewRDSTokenSource(postgres.Host, postgres.Port, config.AWSRegion, postgres.User)
+	case ProviderGCP:
+		return NewCloudSQLTokenSource(context.Background())
+	default:
+		return nil, fmt.Errorf("unknown IAM provider: %s", config.Provider)
+	}
+}
+
+type rdsTokenSource struct {
+	endpoint string
+	region   string
+	user     string
+	session  *session.Session
+}
+
+// NewRDSTokenSource produces AWS RDS IAM authentication tokens for the user.
+// A token is signed locally for each connection, and is good for connecting
+// within 15 minutes.
+func NewRDSTokenSource(host string, port uint16, region string, user string) (TokenSource, error) {
+	sess, err := session.NewSessionWithOptions(session.Options{
+		Config:            aws.Config{Region: aws.String(region)},
+		SharedConfigState: session.SharedConfigEnable,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if aws.StringValue(sess.Config.Region) == "" {
+		return nil, errors.New("--postgres-iam-aws-region must be set, as no region is configured for the AWS SDK")
+	}
+
+	return &rdsTokenSource{
+		endpoint: fmt.Sprintf("%s:%d", host, port),
+		region:   aws.StringValue(sess.Config.Region),
+		user:     user,
+		session:  sess,
+	}, nil
+}
+
+func (source *rdsTokenSource) Token() (string, error) {
+	return rdsutils.BuildAuthToken(source.endpoint, source.region, source.user, source.session.Config.Credentials)
+}
+
+type cloudSQLTokenSource struct {
+	tokens oauth2.TokenSource
+}
+
+// NewCloudSQLTokenSource produces OAuth2 access tokens for Cloud SQL IAM
+// database authentication from the application default credentials. Tokens
+// are reused until they are about to expire.
+func NewCloudSQLTokenSource(ctx context.Context) (TokenSource, error) {
+	tokens, err := google.DefaultTokenSource(ctx, cloudSQLLoginScope)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cloudSQLTokenSource{
+		tokens: oauth2.ReuseTokenSource(nil, tokens),
+	}, nil
+}
+
+func (source *cloudSQLTokenSource) Token() (string, error) {
+	token, err := source.tokens.Token()
+	if err != nil {
+		return "", err
+	}
+
+	return token.AccessToken, nil
+}
+
+var passwordEscape = regexp.MustCompile(`(['\\])`)
+
+// WithToken sets the password of the key/value connection string dsn to a
+// token from source. Later values take precedence, so a password already in
+// dsn is overridden.
+func WithToken(dsn string, source TokenSource) (string, error) {
+	token, err := source.Token()
+	if err != nil {
+		return "", fmt.Errorf("get database token: %w", err)
+	}
+
+	return fmt.Sprintf("%s password='%s'", dsn, passwordEscape.ReplaceAllString(token, `\$1`)), nil
+}
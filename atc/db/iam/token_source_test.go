@@ -0,0 +1,52 @@
+package iam_test
+
+import (
+	"errors"
+
+	"github.com/concourse/concourse/atc/db/iam"
+	"github.com/concourse/flag"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type staticTokenSource struct {
+	token string
+	err   error
+}
+
+func (source staticTokenSource) Token() (string, error) {
+	return source.token, source.err
+}
+
+var _ = Describe("Token Source", func() {
+	Describe("WithToken", func() {
+		It("sets the password to the token", func() {
+			dsn, err := iam.WithToken("host='db' user='concourse'", staticTokenSource{token: "some-token"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(dsn).To(Equal("host='db' user='concourse' password='some-token'"))
+		})
+
+		It("escapes quotes and backslashes in the token", func() {
+			dsn, err := iam.WithToken("host='db'", staticTokenSource{token: `it's\a`})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(dsn).To(Equal(`host='db' password='it\'s\\a'`))
+		})
+
+		It("returns an error when there is no token", func() {
+			_, err := iam.WithToken("host='db'", staticTokenSource{err: errors.New("expired credentials")})
+			Expect(err).To(MatchError(ContainSubstring("expired credentials")))
+		})
+	})
+
+	Describe("Config", func() {
+		It("is enabled with a provider", func() {
+			Expect(iam.Config{}.Enabled()).To(BeFalse())
+			Expect(iam.Config{Provider: iam.ProviderAWS}.Enabled()).To(BeTrue())
+		})
+
+		It("requires a user", func() {
+			_, err := iam.Config{Provider: iam.ProviderGCP}.TokenSource(flag.PostgresConfig{Host: "db"})
+			Expect(err).To(MatchError(ContainSubstring("--postgres-user")))
+		})
+	})
+})
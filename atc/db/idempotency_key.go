@@ -0,0 +1,155 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// IdempotencyKey identifies the requests to a route of a team made with the
+// same Idempotency-Key header.
+type IdempotencyKey struct {
+	TeamName string
+	Route    string
+	Key      string
+}
+
+func (key IdempotencyKey) eq() sq.Eq {
+	return sq.Eq{
+		"team_name": key.TeamName,
+		"route":     key.Route,
+		"key":       key.Key,
+	}
+}
+
+// IdempotentResponse is the response to the first request made with an
+// idempotency key, which is replayed to the requests retrying it.
+type IdempotentResponse struct {
+	// RequestHash identifies the request, so that a key can't be reused for
+	// a different one.
+	RequestHash string
+
+	// StatusCode is 0 while the first request is still being handled.
+	StatusCode  int
+	ContentType string
+	Body        []byte
+}
+
+// IdempotencyKeyRepository stores the responses to requests made with an
+// idempotency key.
+//
+//counterfeiter:generate . IdempotencyKeyRepository
+type IdempotencyKeyRepository interface {
+	// ReserveIdempotencyKey reserves the key for the request, returning
+	// false along with the response to the request which reserved it first
+	// if it's already taken.
+	ReserveIdempotencyKey(key IdempotencyKey, requestHash string) (IdempotentResponse, bool, error)
+
+	// CompleteIdempotencyKey stores the response to the request which
+	// reserved the key.
+	CompleteIdempotencyKey(key IdempotencyKey, response IdempotentResponse) error
+
+	// ReleaseIdempotencyKey gives up a reservation, so that the request can
+	// be retried with the same key.
+	ReleaseIdempotencyKey(key IdempotencyKey) error
+
+	RemoveExpiredIdempotencyKeys(ttl time.Duration) (int, error)
+}
+
+type idempotencyKeyRepository struct {
+	conn Conn
+}
+
+func NewIdempotencyKeyRepository(conn Conn) IdempotencyKeyRepository {
+	return &idempotencyKeyRepository{
+		conn: conn,
+	}
+}
+
+func (repo *idempotencyKeyRepository) ReserveIdempotencyKey(key IdempotencyKey, requestHash string) (IdempotentResponse, bool, error) {
+	result, err := psql.Insert("idempotency_keys").
+		Columns("team_name", "route", "key", "request_hash").
+		Values(key.TeamName, key.Route, key.Key, requestHash).
+		Suffix("ON CONFLICT (team_name, route, key) DO NOTHING").
+		RunWith(repo.conn).
+		Exec()
+	if err != nil {
+		return IdempotentResponse{}, false, err
+	}
+
+	inserted, err := result.RowsAffected()
+	if err != nil {
+		return IdempotentResponse{}, false, err
+	}
+
+	if inserted == 1 {
+		return IdempotentResponse{RequestHash: requestHash}, true, nil
+	}
+
+	var (
+		response    IdempotentResponse
+		status      sql.NullInt64
+		contentType string
+		body        []byte
+	)
+	err = psql.Select("request_hash", "status", "content_type", "body").
+		From("idempotency_keys").
+		Where(key.eq()).
+		RunWith(repo.conn).
+		QueryRow().
+		Scan(&response.RequestHash, &status, &contentType, &body)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			// released since; the first request is as good as still in
+			// progress to the one retrying it
+			return IdempotentResponse{RequestHash: requestHash}, false, nil
+		}
+
+		return IdempotentResponse{}, false, err
+	}
+
+	response.StatusCode = int(status.Int64)
+	response.ContentType = contentType
+	response.Body = body
+
+	return response, false, nil
+}
+
+func (repo *idempotencyKeyRepository) CompleteIdempotencyKey(key IdempotencyKey, response IdempotentResponse) error {
+	_, err := psql.Update("idempotency_keys").
+		Set("status", response.StatusCode).
+		Set("content_type", response.ContentType).
+		Set("body", response.Body).
+		Where(key.eq()).
+		RunWith(repo.conn).
+		Exec()
+	return err
+}
+
+func (repo *idempotencyKeyRepository) ReleaseIdempotencyKey(key IdempotencyKey) error {
+	_, err := psql.Delete("idempotency_keys").
+		Where(key.eq()).
+		Where(sq.Eq{"status": nil}).
+		RunWith(repo.conn).
+		Exec()
+	return err
+}
+
+func (repo *idempotencyKeyRepository) RemoveExpiredIdempotencyKeys(ttl time.Duration) (int, error) {
+	result, err := psql.Delete("idempotency_keys").
+		Where(sq.Expr(fmt.Sprintf("created_at < now() - '%d seconds'::interval", int(ttl.Seconds())))).
+		RunWith(repo.conn).
+		Exec()
+	if err != nil {
+		return 0, err
+	}
+
+	removed, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(removed), nil
+}
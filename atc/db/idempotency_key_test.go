@@ -0,0 +1,138 @@
+package db_test
+
+import (
+	"time"
+
+	"github.com/concourse/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("IdempotencyKeyRepository", func() {
+	var (
+		repository db.IdempotencyKeyRepository
+		key        db.IdempotencyKey
+	)
+
+	BeforeEach(func() {
+		repository = db.NewIdempotencyKeyRepository(dbConn)
+		key = db.IdempotencyKey{
+			TeamName: "some-team",
+			Route:    "CreateJobBuild",
+			Key:      "some-key",
+		}
+	})
+
+	Describe("ReserveIdempotencyKey", func() {
+		It("reserves the key for the first request", func() {
+			response, reserved, err := repository.ReserveIdempotencyKey(key, "some-hash")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(reserved).To(BeTrue())
+			Expect(response).To(Equal(db.IdempotentResponse{RequestHash: "some-hash"}))
+		})
+
+		Context("when the key is reserved", func() {
+			BeforeEach(func() {
+				_, _, err := repository.ReserveIdempotencyKey(key, "some-hash")
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("returns the request in progress", func() {
+				response, reserved, err := repository.ReserveIdempotencyKey(key, "other-hash")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(reserved).To(BeFalse())
+				Expect(response).To(Equal(db.IdempotentResponse{RequestHash: "some-hash"}))
+			})
+
+			It("is reserved separately for other teams and routes", func() {
+				_, reserved, err := repository.ReserveIdempotencyKey(db.IdempotencyKey{
+					TeamName: "other-team",
+					Route:    key.Route,
+					Key:      key.Key,
+				}, "some-hash")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(reserved).To(BeTrue())
+
+				_, reserved, err = repository.ReserveIdempotencyKey(db.IdempotencyKey{
+					TeamName: key.TeamName,
+					Route:    "SaveConfig",
+					Key:      key.Key,
+				}, "some-hash")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(reserved).To(BeTrue())
+			})
+
+			Context("when the request is complete", func() {
+				BeforeEach(func() {
+					err := repository.CompleteIdempotencyKey(key, db.IdempotentResponse{
+						StatusCode:  200,
+						ContentType: "application/json",
+						Body:        []byte(`{"id":1}`),
+					})
+					Expect(err).ToNot(HaveOccurred())
+				})
+
+				It("returns its response", func() {
+					response, reserved, err := repository.ReserveIdempotencyKey(key, "some-hash")
+					Expect(err).ToNot(HaveOccurred())
+					Expect(reserved).To(BeFalse())
+					Expect(response).To(Equal(db.IdempotentResponse{
+						RequestHash: "some-hash",
+						StatusCode:  200,
+						ContentType: "application/json",
+						Body:        []byte(`{"id":1}`),
+					}))
+				})
+
+				It("is not released", func() {
+					err := repository.ReleaseIdempotencyKey(key)
+					Expect(err).ToNot(HaveOccurred())
+
+					_, reserved, err := repository.ReserveIdempotencyKey(key, "some-hash")
+					Expect(err).ToNot(HaveOccurred())
+					Expect(reserved).To(BeFalse())
+				})
+			})
+
+			Context("when it is released", func() {
+				BeforeEach(func() {
+					err := repository.ReleaseIdempotencyKey(key)
+					Expect(err).ToNot(HaveOccurred())
+				})
+
+				It("can be reserved again", func() {
+					_, reserved, err := repository.ReserveIdempotencyKey(key, "other-hash")
+					Expect(err).ToNot(HaveOccurred())
+					Expect(reserved).To(BeTrue())
+				})
+			})
+		})
+	})
+
+	Describe("RemoveExpiredIdempotencyKeys", func() {
+		BeforeEach(func() {
+			_, _, err := repository.ReserveIdempotencyKey(key, "some-hash")
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = dbConn.Exec(`UPDATE idempotency_keys SET created_at = now() - interval '2 hours'`)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, _, err = repository.ReserveIdempotencyKey(db.IdempotencyKey{
+				TeamName: key.TeamName,
+				Route:    key.Route,
+				Key:      "other-key",
+			}, "some-hash")
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("removes the keys older than the ttl", func() {
+			removed, err := repository.RemoveExpiredIdempotencyKeys(time.Hour)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(removed).To(Equal(1))
+
+			_, reserved, err := repository.ReserveIdempotencyKey(key, "some-hash")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(reserved).To(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,79 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
+)
+
+// ImageScanCache keeps what scanning services found in images, so that each
+// image digest is only scanned once in a while.
+//
+//counterfeiter:generate . ImageScanCache
+type ImageScanCache interface {
+	// FindReport returns the report of the image with the given digest, if it
+	// was scanned within the given duration.
+	FindReport(digest string, maxAge time.Duration) (atc.ImageScanReport, bool, error)
+	SaveReport(report atc.ImageScanReport) error
+}
+
+type imageScanCache struct {
+	conn Conn
+}
+
+func NewImageScanCache(conn Conn) ImageScanCache {
+	return &imageScanCache{
+		conn: conn,
+	}
+}
+
+func (cache *imageScanCache) FindReport(digest string, maxAge time.Duration) (atc.ImageScanReport, bool, error) {
+	var vulnerabilities []byte
+	report := atc.ImageScanReport{Digest: digest}
+
+	err := psql.Select("vulnerabilities", "scanned_at").
+		From("image_scan_reports").
+		Where(sq.Eq{"digest": digest}).
+		Where(sq.Expr(fmt.Sprintf("scanned_at > now() - '%d seconds'::interval", int(maxAge.Seconds())))).
+		RunWith(cache.conn).
+		QueryRow().
+		Scan(&vulnerabilities, &report.ScannedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return atc.ImageScanReport{}, false, nil
+		}
+
+		return atc.ImageScanReport{}, false, err
+	}
+
+	err = json.Unmarshal(vulnerabilities, &report.Vulnerabilities)
+	if err != nil {
+		return atc.ImageScanReport{}, false, err
+	}
+
+	return report, true, nil
+}
+
+func (cache *imageScanCache) SaveReport(report atc.ImageScanReport) error {
+	vulnerabilities := report.Vulnerabilities
+	if vulnerabilities == nil {
+		vulnerabilities = []atc.ImageVulnerability{}
+	}
+
+	payload, err := json.Marshal(vulnerabilities)
+	if err != nil {
+		return err
+	}
+
+	_, err = psql.Insert("image_scan_reports").
+		Columns("digest", "vulnerabilities", "scanned_at").
+		Values(report.Digest, payload, report.ScannedAt).
+		Suffix("ON CONFLICT (digest) DO UPDATE SET vulnerabilities = EXCLUDED.vulnerabilities, scanned_at = EXCLUDED.scanned_at").
+		RunWith(cache.conn).
+		Exec()
+	return err
+}
@@ -0,0 +1,69 @@
+package db_test
+
+import (
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ImageScanCache", func() {
+	var cache db.ImageScanCache
+
+	BeforeEach(func() {
+		cache = db.NewImageScanCache(dbConn)
+	})
+
+	It("does not find images which were never scanned", func() {
+		_, found, err := cache.FindReport("sha256:abc", time.Hour)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeFalse())
+	})
+
+	Context("when a report is saved", func() {
+		var report atc.ImageScanReport
+
+		BeforeEach(func() {
+			report = atc.ImageScanReport{
+				Digest: "sha256:abc",
+				Vulnerabilities: []atc.ImageVulnerability{
+					{ID: "CVE-2021-0001", Severity: "HIGH", Package: "openssl", Version: "1.1.1", FixedVersion: "1.1.2"},
+				},
+				ScannedAt: time.Now().Add(-time.Minute).Truncate(time.Second),
+			}
+
+			err := cache.SaveReport(report)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("finds it while it is recent enough", func() {
+			found, ok, err := cache.FindReport("sha256:abc", time.Hour)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ok).To(BeTrue())
+			Expect(found.Digest).To(Equal(report.Digest))
+			Expect(found.Vulnerabilities).To(Equal(report.Vulnerabilities))
+			Expect(found.ScannedAt.Unix()).To(Equal(report.ScannedAt.Unix()))
+		})
+
+		It("does not find it once it is too old", func() {
+			_, ok, err := cache.FindReport("sha256:abc", 30*time.Second)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ok).To(BeFalse())
+		})
+
+		It("replaces it when the image is scanned again", func() {
+			err := cache.SaveReport(atc.ImageScanReport{
+				Digest:    "sha256:abc",
+				ScannedAt: time.Now(),
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			found, ok, err := cache.FindReport("sha256:abc", 30*time.Second)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ok).To(BeTrue())
+			Expect(found.Vulnerabilities).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,129 @@
+package db
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
+)
+
+// InjectedEnvRepository manages the env which operators inject into every
+// task of a team or of the cluster, so that teams need not add the same
+// params, such as proxy settings, to all of their tasks.
+//
+// A teamID of 0 refers to the env of the cluster, which is injected into the
+// tasks of every team.
+//
+//counterfeiter:generate . InjectedEnvRepository
+type InjectedEnvRepository interface {
+	InjectedEnv(teamID int) (atc.TaskEnv, error)
+	SetInjectedEnv(teamID int, env atc.TaskEnv) error
+
+	// TaskEnv returns the env to inject into the team's tasks, which is the
+	// env of the cluster overridden by the env of the team.
+	TaskEnv(teamID int) (atc.TaskEnv, error)
+}
+
+type injectedEnvRepository struct {
+	conn Conn
+}
+
+func NewInjectedEnvRepository(conn Conn) InjectedEnvRepository {
+	return &injectedEnvRepository{
+		conn: conn,
+	}
+}
+
+func injectedEnvTeamEq(teamID int) sq.Eq {
+	if teamID == 0 {
+		return sq.Eq{"team_id": nil}
+	}
+
+	return sq.Eq{"team_id": teamID}
+}
+
+func (repo *injectedEnvRepository) InjectedEnv(teamID int) (atc.TaskEnv, error) {
+	rows, err := psql.Select("name", "value").
+		From("injected_env").
+		Where(injectedEnvTeamEq(teamID)).
+		RunWith(repo.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	return scanInjectedEnv(rows)
+}
+
+func (repo *injectedEnvRepository) SetInjectedEnv(teamID int, env atc.TaskEnv) error {
+	var team sql.NullInt64
+	if teamID != 0 {
+		team = sql.NullInt64{Int64: int64(teamID), Valid: true}
+	}
+
+	tx, err := repo.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	_, err = psql.Delete("injected_env").
+		Where(injectedEnvTeamEq(teamID)).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	if len(env) > 0 {
+		insert := psql.Insert("injected_env").
+			Columns("team_id", "name", "value")
+
+		for name, value := range env {
+			insert = insert.Values(team, name, value)
+		}
+
+		_, err = insert.RunWith(tx).Exec()
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (repo *injectedEnvRepository) TaskEnv(teamID int) (atc.TaskEnv, error) {
+	// the cluster's env comes first, so that the team's env overrides it
+	rows, err := psql.Select("name", "value").
+		From("injected_env").
+		Where(sq.Or{
+			sq.Eq{"team_id": nil},
+			sq.Eq{"team_id": teamID},
+		}).
+		OrderBy("team_id NULLS FIRST").
+		RunWith(repo.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	return scanInjectedEnv(rows)
+}
+
+func scanInjectedEnv(rows *sql.Rows) (atc.TaskEnv, error) {
+	defer Close(rows)
+
+	env := atc.TaskEnv{}
+	for rows.Next() {
+		var name, value string
+		err := rows.Scan(&name, &value)
+		if err != nil {
+			return nil, err
+		}
+
+		env[name] = value
+	}
+
+	return env, rows.Err()
+}
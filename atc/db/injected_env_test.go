@@ -0,0 +1,96 @@
+package db_test
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("InjectedEnvRepository", func() {
+	var repository db.InjectedEnvRepository
+
+	BeforeEach(func() {
+		repository = db.NewInjectedEnvRepository(dbConn)
+	})
+
+	It("has no env by default", func() {
+		env, err := repository.InjectedEnv(0)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(env).To(BeEmpty())
+
+		env, err = repository.TaskEnv(defaultTeam.ID())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(env).To(BeEmpty())
+	})
+
+	Context("when the cluster and the team have env", func() {
+		BeforeEach(func() {
+			err := repository.SetInjectedEnv(0, atc.TaskEnv{
+				"HTTP_PROXY": "http://proxy.example.com",
+				"NO_PROXY":   "localhost",
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			err = repository.SetInjectedEnv(defaultTeam.ID(), atc.TaskEnv{
+				"NO_PROXY":    "localhost,internal.example.com",
+				"COST_CENTER": "1234",
+			})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("keeps them separately", func() {
+			env, err := repository.InjectedEnv(0)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(env).To(Equal(atc.TaskEnv{
+				"HTTP_PROXY": "http://proxy.example.com",
+				"NO_PROXY":   "localhost",
+			}))
+
+			env, err = repository.InjectedEnv(defaultTeam.ID())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(env).To(Equal(atc.TaskEnv{
+				"NO_PROXY":    "localhost,internal.example.com",
+				"COST_CENTER": "1234",
+			}))
+		})
+
+		It("injects the cluster's env overridden by the team's", func() {
+			env, err := repository.TaskEnv(defaultTeam.ID())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(env).To(Equal(atc.TaskEnv{
+				"HTTP_PROXY":  "http://proxy.example.com",
+				"NO_PROXY":    "localhost,internal.example.com",
+				"COST_CENTER": "1234",
+			}))
+		})
+
+		It("only injects the cluster's env into other teams", func() {
+			otherTeam, err := teamFactory.CreateTeam(atc.Team{Name: "some-other-team"})
+			Expect(err).ToNot(HaveOccurred())
+
+			env, err := repository.TaskEnv(otherTeam.ID())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(env).To(Equal(atc.TaskEnv{
+				"HTTP_PROXY": "http://proxy.example.com",
+				"NO_PROXY":   "localhost",
+			}))
+		})
+
+		It("replaces the env when it is set again", func() {
+			err := repository.SetInjectedEnv(defaultTeam.ID(), atc.TaskEnv{"ONLY": "this"})
+			Expect(err).ToNot(HaveOccurred())
+
+			env, err := repository.InjectedEnv(defaultTeam.ID())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(env).To(Equal(atc.TaskEnv{"ONLY": "this"}))
+
+			err = repository.SetInjectedEnv(0, atc.TaskEnv{})
+			Expect(err).ToNot(HaveOccurred())
+
+			env, err = repository.TaskEnv(defaultTeam.ID())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(env).To(Equal(atc.TaskEnv{"ONLY": "this"}))
+		})
+	})
+})
@@ -26,6 +26,7 @@ type InputConfig struct {
 	Passed          JobSet
 	UseEveryVersion bool
 	PinnedVersion   atc.Version
+	Sampling        *atc.VersionSampling
 	ResourceID      int
 	JobID           int
 }
@@ -51,6 +52,15 @@ func (e InputVersionEmptyError) Error() string {
 	return fmt.Sprintf("input '%s' has successfully resolved but contains missing version information", e.InputName)
 }
 
+type BuildInputPinError struct {
+	InputName string
+	Reason    string
+}
+
+func (e BuildInputPinError) Error() string {
+	return fmt.Sprintf("cannot pin input '%s': %s", e.InputName, e.Reason)
+}
+
 //counterfeiter:generate . Job
 type Job interface {
 	PipelineRef
@@ -81,8 +91,14 @@ type Job interface {
 
 	ScheduleBuild(Build) (bool, error)
 	CreateBuild(createdBy string) (Build, error)
+	CreateBuildWithOverrides(createdBy string, pins []atc.BuildInputPin, vars []atc.BuildVar, externalRef string) (Build, error)
 	RerunBuild(build Build, createdBy string) (Build, error)
 
+	// RetryBuild reruns a build which errored because of its workers, unless
+	// it's already the last of maxRetries retries. Each retry is linked to the
+	// build it retries.
+	RetryBuild(build Build, maxRetries int) (Build, bool, error)
+
 	RequestSchedule() error
 	UpdateLastScheduled(time.Time) error
 
@@ -105,6 +121,10 @@ type Job interface {
 
 	SetHasNewInputs(bool) error
 	HasNewInputs() bool
+
+	// SetWorkerCapacityReached records whether the job's pending builds are
+	// being held back because no worker has capacity to run them.
+	SetWorkerCapacityReached(bool) error
 }
 
 var jobsQuery = psql.Select(
@@ -190,6 +210,28 @@ func (j *job) SetHasNewInputs(hasNewInputs bool) error {
 	return nil
 }
 
+func (j *job) SetWorkerCapacityReached(reached bool) error {
+	result, err := psql.Update("jobs").
+		Set("worker_capacity_reached", reached).
+		Where(sq.Eq{"id": j.id}).
+		RunWith(j.conn).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected != 1 {
+		return NonOneRowAffectedError{rowsAffected}
+	}
+
+	return nil
+}
+
 type Jobs []Job
 
 func (jobs Jobs) Configs() (atc.JobConfigs, error) {
@@ -298,6 +340,7 @@ func (j *job) AlgorithmInputs() (InputConfigs, error) {
 			}
 
 			inputConfig.UseEveryVersion = version.Every
+			inputConfig.Sampling = version.Sampling
 
 			if version.Pinned != nil {
 				inputConfig.PinnedVersion = version.Pinned
@@ -841,6 +884,18 @@ func (j *job) GetPendingBuilds() ([]Build, error) {
 }
 
 func (j *job) CreateBuild(createdBy string) (Build, error) {
+	return j.CreateBuildWithOverrides(createdBy, nil, nil, "")
+}
+
+// CreateBuildWithOverrides creates a manually triggered build which uses the
+// pinned versions for its pinned inputs, rather than the versions the job's
+// inputs resolve to, and resolves the given vars ahead of any others. A
+// BuildInputPinError is returned if any of the pins is invalid.
+//
+// If an external reference is given and the job already has a build with it,
+// that build is returned instead, so that an external system retrying the
+// trigger doesn't create the build twice.
+func (j *job) CreateBuildWithOverrides(createdBy string, pins []atc.BuildInputPin, vars []atc.BuildVar, externalRef string) (Build, error) {
 	tx, err := j.conn.Begin()
 	if err != nil {
 		return nil, err
@@ -848,25 +903,64 @@ func (j *job) CreateBuild(createdBy string) (Build, error) {
 
 	defer Rollback(tx)
 
-	buildName, err := j.getNewBuildName(tx)
-	if err != nil {
-		return nil, err
-	}
-
-	build := newEmptyBuild(j.conn, j.lockFactory)
-	err = createBuild(tx, build, map[string]interface{}{
-		"name":               buildName,
+	vals := map[string]interface{}{
 		"job_id":             j.id,
 		"pipeline_id":        j.pipelineID,
 		"team_id":            j.teamID,
 		"status":             BuildStatusPending,
 		"manually_triggered": true,
 		"created_by":         createdBy,
-	})
+	}
+
+	if externalRef != "" {
+		existing := newEmptyBuild(j.conn, j.lockFactory)
+		err = scanBuild(existing, buildsQuery.
+			Where(sq.Eq{
+				"b.job_id":       j.id,
+				"b.external_ref": externalRef,
+			}).
+			RunWith(tx).
+			QueryRow(),
+			j.conn.EncryptionStrategy(),
+		)
+		if err == nil {
+			return existing, nil
+		}
+
+		if err != sql.ErrNoRows {
+			return nil, err
+		}
+
+		vals["external_ref"] = externalRef
+	}
+
+	vals["name"], err = j.getNewBuildName(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	build := newEmptyBuild(j.conn, j.lockFactory)
+	err = createBuild(tx, build, vals)
 	if err != nil {
 		return nil, err
 	}
 
+	for _, pin := range pins {
+		err = j.pinBuildInput(tx, build.ID(), pin)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(vars) > 0 {
+		err = saveBuildVars(tx, build.ID(), vars)
+		if err != nil {
+			return nil, err
+		}
+
+		build.vars = vars
+	}
+
 	latestNonRerunID, err := latestCompletedNonRerunBuild(tx, j.id)
 	if err != nil {
 		return nil, err
@@ -890,9 +984,212 @@ func (j *job) CreateBuild(createdBy string) (Build, error) {
 	return build, nil
 }
 
+// pinBuildInput records the version the input of the build is pinned to,
+// once it's made sure the version can be used for the input.
+func (j *job) pinBuildInput(tx Tx, buildID int, pin atc.BuildInputPin) error {
+	var resourceID int
+	var passedJobIDs []sql.NullInt64
+	err := psql.Select("resource_id", "array_agg(passed_job_id)").
+		From("job_inputs").
+		Where(sq.Eq{
+			"job_id": j.id,
+			"name":   pin.Name,
+		}).
+		GroupBy("resource_id").
+		RunWith(tx).
+		QueryRow().
+		Scan(&resourceID, pq.Array(&passedJobIDs))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return BuildInputPinError{pin.Name, "not an input of the job"}
+		}
+
+		return err
+	}
+
+	versionMD5, err := pinnedVersionMD5(tx, resourceID, pin)
+	if err != nil {
+		return err
+	}
+
+	var disabled bool
+	err = tx.QueryRow(`
+		SELECT EXISTS (
+			SELECT 1
+			FROM resource_disabled_versions
+			WHERE resource_id = $1
+			AND version_md5 = $2
+		)`, resourceID, versionMD5).Scan(&disabled)
+	if err != nil {
+		return err
+	}
+
+	if disabled {
+		return BuildInputPinError{pin.Name, "version is disabled"}
+	}
+
+	if !pin.OverridePassed {
+		passedJobs := []int64{}
+		for _, jobID := range passedJobIDs {
+			if jobID.Valid {
+				passedJobs = append(passedJobs, jobID.Int64)
+			}
+		}
+
+		unpassed, err := jobsNotPassedWithVersion(tx, passedJobs, resourceID, versionMD5)
+		if err != nil {
+			return err
+		}
+
+		if len(unpassed) > 0 {
+			return BuildInputPinError{pin.Name, fmt.Sprintf("version has not passed %s", strings.Join(unpassed, ", "))}
+		}
+	}
+
+	_, err = psql.Insert("build_pinned_inputs").
+		Columns("build_id", "input_name", "resource_id", "version_md5", "override_passed").
+		Values(buildID, pin.Name, resourceID, versionMD5, pin.OverridePassed).
+		RunWith(tx).
+		Exec()
+	return err
+}
+
+// pinnedVersionMD5 finds the version of the resource the pin is for. A pin by
+// version matches the latest version containing it, as with pinning in the
+// pipeline config.
+func pinnedVersionMD5(tx Tx, resourceID int, pin atc.BuildInputPin) (string, error) {
+	query := psql.Select("v.version_md5").
+		From("resource_config_versions v").
+		Join("resources r ON r.resource_config_scope_id = v.resource_config_scope_id").
+		Where(sq.Eq{"r.id": resourceID})
+
+	switch {
+	case pin.VersionID != 0:
+		query = query.Where(sq.Eq{"v.id": pin.VersionID})
+
+	case pin.Version != nil:
+		version, err := json.Marshal(pin.Version)
+		if err != nil {
+			return "", err
+		}
+
+		query = query.
+			Where(sq.Expr("v.version @> ?::jsonb", version)).
+			OrderBy("v.check_order DESC").
+			Limit(1)
+
+	default:
+		return "", BuildInputPinError{pin.Name, "no version given"}
+	}
+
+	var versionMD5 string
+	err := query.
+		RunWith(tx).
+		QueryRow().
+		Scan(&versionMD5)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", BuildInputPinError{pin.Name, "version not found"}
+		}
+
+		return "", err
+	}
+
+	return versionMD5, nil
+}
+
+// jobsNotPassedWithVersion returns the names of the jobs which have no
+// succeeded build with the version as an input or output.
+func jobsNotPassedWithVersion(tx Tx, jobIDs []int64, resourceID int, versionMD5 string) ([]string, error) {
+	if len(jobIDs) == 0 {
+		return nil, nil
+	}
+
+	rows, err := tx.Query(`
+		SELECT j.name
+		FROM jobs j
+		WHERE j.id = ANY($1)
+		AND NOT EXISTS (
+			SELECT 1
+			FROM builds b
+			WHERE b.job_id = j.id
+			AND b.status = 'succeeded'
+			AND (
+				EXISTS (
+					SELECT 1
+					FROM build_resource_config_version_inputs i
+					WHERE i.build_id = b.id
+					AND i.resource_id = $2
+					AND i.version_md5 = $3
+				) OR EXISTS (
+					SELECT 1
+					FROM build_resource_config_version_outputs o
+					WHERE o.build_id = b.id
+					AND o.resource_id = $2
+					AND o.version_md5 = $3
+				)
+			)
+		)
+		ORDER BY j.name
+	`, pq.Array(jobIDs), resourceID, versionMD5)
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	var names []string
+	for rows.Next() {
+		var name string
+		err = rows.Scan(&name)
+		if err != nil {
+			return nil, err
+		}
+
+		names = append(names, name)
+	}
+
+	return names, rows.Err()
+}
+
 func (j *job) RerunBuild(buildToRerun Build, createdBy string) (Build, error) {
+	return j.rerunBuild(buildToRerun, map[string]interface{}{
+		"created_by": createdBy,
+	})
+}
+
+func (j *job) RetryBuild(buildToRetry Build, maxRetries int) (Build, bool, error) {
+	var retries int
+	err := j.conn.QueryRow(`
+		WITH RECURSIVE retried(id, retry_of) AS (
+			SELECT id, retry_of FROM builds WHERE id = $1
+			UNION ALL
+			SELECT b.id, b.retry_of FROM builds b JOIN retried r ON b.id = r.retry_of
+		)
+		SELECT count(*) - 1 FROM retried
+	`, buildToRetry.ID()).Scan(&retries)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if retries >= maxRetries {
+		return nil, false, nil
+	}
+
+	retryBuild, err := j.rerunBuild(buildToRetry, map[string]interface{}{
+		"created_by": buildToRetry.CreatedBy(),
+		"retry_of":   buildToRetry.ID(),
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	return retryBuild, true, nil
+}
+
+func (j *job) rerunBuild(buildToRerun Build, vals map[string]interface{}) (Build, error) {
 	for {
-		rerunBuild, err := j.tryRerunBuild(buildToRerun, createdBy)
+		rerunBuild, err := j.tryRerunBuild(buildToRerun, vals)
 		if err != nil {
 			if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == pqUniqueViolationErrCode {
 				continue
@@ -905,7 +1202,7 @@ func (j *job) RerunBuild(buildToRerun Build, createdBy string) (Build, error) {
 	}
 }
 
-func (j *job) tryRerunBuild(buildToRerun Build, createdBy string) (Build, error) {
+func (j *job) tryRerunBuild(buildToRerun Build, vals map[string]interface{}) (Build, error) {
 	tx, err := j.conn.Begin()
 	if err != nil {
 		return nil, err
@@ -923,8 +1220,7 @@ func (j *job) tryRerunBuild(buildToRerun Build, createdBy string) (Build, error)
 		return nil, err
 	}
 
-	rerunBuild := newEmptyBuild(j.conn, j.lockFactory)
-	err = createBuild(tx, rerunBuild, map[string]interface{}{
+	rerunVals := map[string]interface{}{
 		"name":         rerunBuildName,
 		"job_id":       j.id,
 		"pipeline_id":  j.pipelineID,
@@ -932,12 +1228,31 @@ func (j *job) tryRerunBuild(buildToRerun Build, createdBy string) (Build, error)
 		"status":       BuildStatusPending,
 		"rerun_of":     buildToRerunID,
 		"rerun_number": rerunNumber,
-		"created_by":   createdBy,
-	})
+	}
+
+	for k, v := range vals {
+		rerunVals[k] = v
+	}
+
+	rerunBuild := newEmptyBuild(j.conn, j.lockFactory)
+	err = createBuild(tx, rerunBuild, rerunVals)
 	if err != nil {
 		return nil, err
 	}
 
+	// a rerun is run with the same vars as the build it reruns
+	_, err = tx.Exec(`
+		INSERT INTO build_vars (build_id, vars, nonce)
+		SELECT $1, vars, nonce
+		FROM build_vars
+		WHERE build_id = $2
+	`, rerunBuild.ID(), buildToRerun.ID())
+	if err != nil {
+		return nil, err
+	}
+
+	rerunBuild.vars = buildToRerun.Vars()
+
 	latestNonRerunID, err := latestCompletedNonRerunBuild(tx, j.id)
 	if err != nil {
 		return nil, err
@@ -1016,6 +1331,17 @@ func (j *job) isMaxInFlightReached(tx Tx, buildID int) (bool, error) {
 		return false, err
 	}
 
+	// jobs in a team serial group may be scheduled for different pipelines at
+	// once, so the groups are locked until the build is scheduled
+	for _, serialGroup := range serialGroups.team {
+		lockID := lock.NewTeamSerialGroupLockID(j.teamID, serialGroup)
+
+		_, err = tx.Exec(`SELECT pg_advisory_xact_lock($1, $2)`, lockID[0], lockID[1])
+		if err != nil {
+			return false, err
+		}
+	}
+
 	builds, err := j.getRunningBuildsBySerialGroup(tx, serialGroups)
 	if err != nil {
 		return false, err
@@ -1041,27 +1367,58 @@ func (j *job) isMaxInFlightReached(tx Tx, buildID int) (bool, error) {
 	return false, nil
 }
 
-func (j *job) getSerialGroups(tx Tx) ([]string, error) {
-	rows, err := psql.Select("serial_group").
+// jobSerialGroups are the serial groups of a job. The team serial groups are
+// shared with the jobs of the other pipelines of the team.
+type jobSerialGroups struct {
+	pipeline []string
+	team     []string
+}
+
+// builds matches the builds of the jobs in any of the serial groups.
+func (groups jobSerialGroups) builds(pipelineID int, teamID int) sq.Sqlizer {
+	return sq.Or{
+		sq.Eq{
+			"jsg.serial_group": groups.pipeline,
+			"jsg.team_scoped":  false,
+			"j.pipeline_id":    pipelineID,
+		},
+		sq.Eq{
+			"jsg.serial_group": groups.team,
+			"jsg.team_scoped":  true,
+			"b.team_id":        teamID,
+		},
+	}
+}
+
+func (j *job) getSerialGroups(tx Tx) (jobSerialGroups, error) {
+	rows, err := psql.Select("serial_group", "team_scoped").
 		From("jobs_serial_groups").
 		Where(sq.Eq{
 			"job_id": j.id,
 		}).
+		OrderBy("serial_group").
 		RunWith(tx).
 		Query()
 	if err != nil {
-		return nil, err
+		return jobSerialGroups{}, err
 	}
 
-	var serialGroups []string
+	defer Close(rows)
+
+	var serialGroups jobSerialGroups
 	for rows.Next() {
 		var serialGroup string
-		err = rows.Scan(&serialGroup)
+		var teamScoped bool
+		err = rows.Scan(&serialGroup, &teamScoped)
 		if err != nil {
-			return nil, err
+			return jobSerialGroups{}, err
 		}
 
-		serialGroups = append(serialGroups, serialGroup)
+		if teamScoped {
+			serialGroups.team = append(serialGroups.team, serialGroup)
+		} else {
+			serialGroups.pipeline = append(serialGroups.pipeline, serialGroup)
+		}
 	}
 
 	return serialGroups, nil
@@ -1095,13 +1452,10 @@ func (j *job) UpdateLastScheduled(requestedTime time.Time) error {
 	return err
 }
 
-func (j *job) getRunningBuildsBySerialGroup(tx Tx, serialGroups []string) ([]Build, error) {
+func (j *job) getRunningBuildsBySerialGroup(tx Tx, serialGroups jobSerialGroups) ([]Build, error) {
 	rows, err := buildsQuery.Options(`DISTINCT ON (b.id)`).
 		Join(`jobs_serial_groups jsg ON j.id = jsg.job_id`).
-		Where(sq.Eq{
-			"jsg.serial_group": serialGroups,
-			"j.pipeline_id":    j.pipelineID,
-		}).
+		Where(serialGroups.builds(j.pipelineID, j.teamID)).
 		Where(sq.Eq{"b.completed": false, "b.scheduled": true}).
 		RunWith(tx).
 		Query()
@@ -1126,15 +1480,16 @@ func (j *job) getRunningBuildsBySerialGroup(tx Tx, serialGroups []string) ([]Bui
 	return bs, nil
 }
 
-func (j *job) getNextPendingBuildBySerialGroup(tx Tx, serialGroups []string) (Build, bool, error) {
+func (j *job) getNextPendingBuildBySerialGroup(tx Tx, serialGroups jobSerialGroups) (Build, bool, error) {
 	subQuery, params, err := buildsQuery.Options(`DISTINCT ON (b.id)`).
 		Join(`jobs_serial_groups jsg ON j.id = jsg.job_id`).
+		Where(serialGroups.builds(j.pipelineID, j.teamID)).
 		Where(sq.Eq{
-			"jsg.serial_group":    serialGroups,
 			"b.status":            BuildStatusPending,
 			"j.paused":            false,
 			"j.inputs_determined": true,
-			"j.pipeline_id":       j.pipelineID}).
+			"p.paused":            false,
+		}).
 		ToSql()
 	if err != nil {
 		return nil, false, err
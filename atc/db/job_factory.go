@@ -51,6 +51,7 @@ type SchedulerResource struct {
 	Type                 string
 	Source               atc.Source
 	ExposeBuildCreatedBy bool
+	SerializePuts        bool
 }
 
 func (r *SchedulerResource) ApplySourceDefaults(resourceTypes atc.ResourceTypes) {
@@ -153,6 +154,7 @@ func (j *jobFactory) JobsToSchedule() (SchedulerJobs, error) {
 				Type:                 type_,
 				Source:               config.Source,
 				ExposeBuildCreatedBy: config.ExposeBuildCreatedBy,
+				SerializePuts:        config.SerializePuts,
 			})
 		}
 
@@ -234,7 +236,7 @@ func (j *jobFactory) VisibleJobs(teamNames []string) ([]atc.JobSummary, error) {
 
 	dashboardFactory := newDashboardFactory(tx, sq.Or{
 		sq.Eq{"tm.name": teamNames},
-		sq.Eq{"p.public": true},
+		publicJobCondition,
 	})
 
 	dashboard, err := dashboardFactory.buildDashboard()
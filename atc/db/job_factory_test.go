@@ -198,6 +198,46 @@ var _ = Describe("JobFactory", func() {
 				Expect(visibleJobs[3].Outputs).To(BeNil())
 			})
 
+			Context("when the public pipeline exposes only some of its jobs", func() {
+				BeforeEach(func() {
+					Expect(publicPipeline.ExposeWith(atc.PipelineExposure{
+						Jobs:       []string{"public-pipeline-job-2"},
+						StatusOnly: true,
+					})).To(Succeed())
+				})
+
+				It("records the exposure on the pipeline", func() {
+					found, err := publicPipeline.Reload()
+					Expect(err).ToNot(HaveOccurred())
+					Expect(found).To(BeTrue())
+
+					Expect(publicPipeline.Public()).To(BeTrue())
+					Expect(publicPipeline.Exposure()).To(Equal(atc.PipelineExposure{
+						Jobs:       []string{"public-pipeline-job-2"},
+						StatusOnly: true,
+					}))
+				})
+
+				It("returns only the exposed jobs of the public pipeline", func() {
+					visibleJobs, err := jobFactory.VisibleJobs([]string{"default-team"})
+					Expect(err).ToNot(HaveOccurred())
+
+					Expect(len(visibleJobs)).To(Equal(2))
+					Expect(visibleJobs[0].Name).To(Equal("some-job"))
+					Expect(visibleJobs[1].Name).To(Equal("public-pipeline-job-2"))
+				})
+
+				It("clears the exposure when the pipeline is hidden", func() {
+					Expect(publicPipeline.Hide()).To(Succeed())
+
+					found, err := publicPipeline.Reload()
+					Expect(err).ToNot(HaveOccurred())
+					Expect(found).To(BeTrue())
+
+					Expect(publicPipeline.Exposure()).To(Equal(atc.PipelineExposure{}))
+				})
+			})
+
 			It("returns next build, latest completed build, and transition build for each job", func() {
 				job, found, err := defaultPipeline.Job("some-job")
 				Expect(err).ToNot(HaveOccurred())
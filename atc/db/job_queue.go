@@ -0,0 +1,169 @@
+package db
+
+import (
+	"encoding/json"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// JobQueue is a durable queue of background work, shared by all the ATCs.
+//
+// A job is leased for a while before it is worked on. A job which is neither
+// completed nor failed by the end of its lease, e.g. because the ATC working
+// on it went away, becomes visible again to be leased by another ATC.
+//
+//counterfeiter:generate . JobQueue
+type JobQueue interface {
+	Enqueue(queue string, payload interface{}) error
+
+	// Lease hands out up to limit jobs of the queue which are due, hiding them
+	// from other leases for the visibility timeout.
+	Lease(queue string, limit int, visibilityTimeout time.Duration) ([]QueuedJob, error)
+
+	Complete(id int64) error
+
+	// Fail records a failed attempt of the job. The job is retried at
+	// retryAt, or kept aside as dead if retryAt is nil.
+	Fail(id int64, jobErr string, retryAt *time.Time) error
+
+	Stats(queue string) (JobQueueStats, error)
+
+	// DeleteDeadJobsBefore deletes the dead jobs of the queue created before
+	// the given time.
+	DeleteDeadJobsBefore(queue string, before time.Time) error
+}
+
+// QueuedJob is a leased job. Attempts includes the current attempt.
+type QueuedJob struct {
+	ID       int64
+	Payload  json.RawMessage
+	Attempts int
+}
+
+type JobQueueStats struct {
+	Pending int
+	Dead    int
+}
+
+type jobQueue struct {
+	conn Conn
+}
+
+func NewJobQueue(conn Conn) JobQueue {
+	return &jobQueue{
+		conn: conn,
+	}
+}
+
+func (q *jobQueue) Enqueue(queue string, payload interface{}) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	_, err = psql.Insert("queued_jobs").
+		Columns("queue", "payload").
+		Values(queue, data).
+		RunWith(q.conn).
+		Exec()
+	return err
+}
+
+func (q *jobQueue) Lease(queue string, limit int, visibilityTimeout time.Duration) ([]QueuedJob, error) {
+	// SKIP LOCKED keeps the ATCs leasing at the same time from blocking on,
+	// or handing out, the same jobs
+	rows, err := q.conn.Query(`
+		UPDATE queued_jobs
+		SET attempts = attempts + 1,
+			visible_at = now() + $3 * '1 second'::interval
+		WHERE id IN (
+			SELECT id
+			FROM queued_jobs
+			WHERE queue = $1
+			AND NOT dead
+			AND visible_at <= now()
+			ORDER BY id
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, payload, attempts
+	`, queue, limit, int(visibilityTimeout.Seconds()))
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	var jobs []QueuedJob
+	for rows.Next() {
+		var (
+			job     QueuedJob
+			payload []byte
+		)
+
+		err := rows.Scan(&job.ID, &payload, &job.Attempts)
+		if err != nil {
+			return nil, err
+		}
+
+		job.Payload = payload
+
+		jobs = append(jobs, job)
+	}
+
+	return jobs, nil
+}
+
+func (q *jobQueue) Complete(id int64) error {
+	_, err := psql.Delete("queued_jobs").
+		Where(sq.Eq{"id": id}).
+		RunWith(q.conn).
+		Exec()
+	return err
+}
+
+func (q *jobQueue) Fail(id int64, jobErr string, retryAt *time.Time) error {
+	update := psql.Update("queued_jobs").
+		Set("last_error", jobErr).
+		Where(sq.Eq{"id": id})
+
+	if retryAt != nil {
+		update = update.Set("visible_at", *retryAt)
+	} else {
+		update = update.Set("dead", true)
+	}
+
+	_, err := update.RunWith(q.conn).Exec()
+	return err
+}
+
+func (q *jobQueue) Stats(queue string) (JobQueueStats, error) {
+	var stats JobQueueStats
+	err := psql.Select(
+		"COUNT(*) FILTER (WHERE NOT dead)",
+		"COUNT(*) FILTER (WHERE dead)",
+	).
+		From("queued_jobs").
+		Where(sq.Eq{"queue": queue}).
+		RunWith(q.conn).
+		QueryRow().
+		Scan(&stats.Pending, &stats.Dead)
+	if err != nil {
+		return JobQueueStats{}, err
+	}
+
+	return stats, nil
+}
+
+func (q *jobQueue) DeleteDeadJobsBefore(queue string, before time.Time) error {
+	_, err := psql.Delete("queued_jobs").
+		Where(sq.Eq{
+			"queue": queue,
+			"dead":  true,
+		}).
+		Where(sq.Lt{"created_at": before}).
+		RunWith(q.conn).
+		Exec()
+	return err
+}
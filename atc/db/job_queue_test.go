@@ -0,0 +1,128 @@
+package db_test
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/concourse/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("JobQueue", func() {
+	var queue db.JobQueue
+
+	BeforeEach(func() {
+		queue = db.NewJobQueue(dbConn)
+
+		Expect(queue.Enqueue("some-queue", map[string]string{"some": "first"})).To(Succeed())
+		Expect(queue.Enqueue("some-queue", map[string]string{"some": "second"})).To(Succeed())
+		Expect(queue.Enqueue("some-other-queue", map[string]string{"some": "other"})).To(Succeed())
+	})
+
+	lease := func() []db.QueuedJob {
+		jobs, err := queue.Lease("some-queue", 10, time.Minute)
+		Expect(err).ToNot(HaveOccurred())
+		return jobs
+	}
+
+	It("leases the jobs of the queue in the order they were enqueued", func() {
+		jobs := lease()
+		Expect(jobs).To(HaveLen(2))
+		Expect(jobs[0].Payload).To(MatchJSON(`{"some":"first"}`))
+		Expect(jobs[0].Attempts).To(Equal(1))
+		Expect(jobs[1].Payload).To(MatchJSON(`{"some":"second"}`))
+	})
+
+	It("leases no more jobs than the limit", func() {
+		jobs, err := queue.Lease("some-queue", 1, time.Minute)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(jobs).To(HaveLen(1))
+		Expect(jobs[0].Payload).To(MatchJSON(`{"some":"first"}`))
+	})
+
+	It("does not lease leased jobs again", func() {
+		Expect(lease()).To(HaveLen(2))
+		Expect(lease()).To(BeEmpty())
+
+		Expect(queue.Stats("some-queue")).To(Equal(db.JobQueueStats{Pending: 2}))
+	})
+
+	Context("when the lease of a job runs out", func() {
+		BeforeEach(func() {
+			jobs, err := queue.Lease("some-queue", 1, -time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(jobs).To(HaveLen(1))
+		})
+
+		It("leases the job again", func() {
+			jobs := lease()
+			Expect(jobs).To(HaveLen(2))
+			Expect(jobs[0].Payload).To(MatchJSON(`{"some":"first"}`))
+			Expect(jobs[0].Attempts).To(Equal(2))
+		})
+	})
+
+	Context("when a job is completed", func() {
+		BeforeEach(func() {
+			jobs := lease()
+			Expect(queue.Complete(jobs[0].ID)).To(Succeed())
+		})
+
+		It("removes it from the queue", func() {
+			Expect(queue.Stats("some-queue")).To(Equal(db.JobQueueStats{Pending: 1}))
+		})
+	})
+
+	Context("when a job fails", func() {
+		var job db.QueuedJob
+
+		BeforeEach(func() {
+			job = lease()[0]
+		})
+
+		Context("with a retry", func() {
+			BeforeEach(func() {
+				retryAt := time.Now().Add(-time.Second)
+				Expect(queue.Fail(job.ID, "some-error", &retryAt)).To(Succeed())
+			})
+
+			It("leases it again once the retry is due", func() {
+				jobs := lease()
+				Expect(jobs).To(HaveLen(1))
+				Expect(jobs[0].ID).To(Equal(job.ID))
+				Expect(jobs[0].Attempts).To(Equal(2))
+			})
+		})
+
+		Context("without a retry", func() {
+			BeforeEach(func() {
+				Expect(queue.Fail(job.ID, "some-error", nil)).To(Succeed())
+			})
+
+			It("keeps it aside as dead", func() {
+				Expect(queue.Stats("some-queue")).To(Equal(db.JobQueueStats{Pending: 1, Dead: 1}))
+
+				Expect(lease()).To(BeEmpty())
+			})
+
+			It("deletes it once it is old enough", func() {
+				Expect(queue.DeleteDeadJobsBefore("some-queue", time.Now().Add(-time.Hour))).To(Succeed())
+				Expect(queue.Stats("some-queue")).To(Equal(db.JobQueueStats{Pending: 1, Dead: 1}))
+
+				Expect(queue.DeleteDeadJobsBefore("some-queue", time.Now().Add(time.Hour))).To(Succeed())
+				Expect(queue.Stats("some-queue")).To(Equal(db.JobQueueStats{Pending: 1}))
+			})
+		})
+	})
+
+	It("keeps the payload as it was enqueued", func() {
+		jobs, err := queue.Lease("some-other-queue", 10, time.Minute)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(jobs).To(HaveLen(1))
+
+		var payload map[string]string
+		Expect(json.Unmarshal(jobs[0].Payload, &payload)).To(Succeed())
+		Expect(payload).To(Equal(map[string]string{"some": "other"}))
+	})
+})
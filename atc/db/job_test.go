@@ -647,6 +647,50 @@ var _ = Describe("Job", func() {
 		})
 	})
 
+	Describe("CreateBuildWithOverrides", func() {
+		Context("when an external ref is given", func() {
+			var build db.Build
+
+			BeforeEach(func() {
+				var err error
+				build, err = job.CreateBuildWithOverrides(defaultBuildCreatedBy, nil, nil, "pr-42@abc123")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("records the ref with the build", func() {
+				Expect(build.ExternalRef()).To(Equal("pr-42@abc123"))
+
+				found, err := build.Reload()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(build.ExternalRef()).To(Equal("pr-42@abc123"))
+			})
+
+			It("returns the same build when triggered with the ref again", func() {
+				again, err := job.CreateBuildWithOverrides(defaultBuildCreatedBy, nil, nil, "pr-42@abc123")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(again.ID()).To(Equal(build.ID()))
+			})
+
+			It("creates a new build for another ref", func() {
+				other, err := job.CreateBuildWithOverrides(defaultBuildCreatedBy, nil, nil, "pr-42@def456")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(other.ID()).ToNot(Equal(build.ID()))
+			})
+
+			It("can be found by the ref", func() {
+				builds, err := buildFactory.AllBuildsWithExternalRef("pr-42@abc123")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(builds).To(HaveLen(1))
+				Expect(builds[0].ID()).To(Equal(build.ID()))
+
+				builds, err = buildFactory.VisibleBuildsWithExternalRef([]string{"some-other-team"}, "pr-42@abc123")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(builds).To(BeEmpty())
+			})
+		})
+	})
+
 	Describe("RerunBuild", func() {
 		var firstBuild db.Build
 		var rerunErr error
@@ -731,6 +775,57 @@ var _ = Describe("Job", func() {
 		})
 	})
 
+	Describe("RetryBuild", func() {
+		var erroredBuild db.Build
+
+		BeforeEach(func() {
+			var err error
+			erroredBuild, err = job.CreateBuild(defaultBuildCreatedBy)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = erroredBuild.Finish(db.BuildStatusErrored)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("reruns the build linked to the build it retries", func() {
+			retryBuild, retried, err := job.RetryBuild(erroredBuild, 2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(retried).To(BeTrue())
+
+			Expect(retryBuild.Name()).To(Equal(fmt.Sprintf("%s.1", erroredBuild.Name())))
+			Expect(retryBuild.RerunOf()).To(Equal(erroredBuild.ID()))
+			Expect(retryBuild.RetryOf()).To(Equal(erroredBuild.ID()))
+			Expect(retryBuild.CreatedBy()).To(Equal(erroredBuild.CreatedBy()))
+			Expect(retryBuild.Status()).To(Equal(db.BuildStatusPending))
+		})
+
+		It("retries up to the given number of times", func() {
+			firstRetry, retried, err := job.RetryBuild(erroredBuild, 2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(retried).To(BeTrue())
+
+			secondRetry, retried, err := job.RetryBuild(firstRetry, 2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(retried).To(BeTrue())
+			Expect(secondRetry.RetryOf()).To(Equal(firstRetry.ID()))
+			Expect(secondRetry.RerunOf()).To(Equal(erroredBuild.ID()))
+
+			_, retried, err = job.RetryBuild(secondRetry, 2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(retried).To(BeFalse())
+		})
+
+		It("does not count manual reruns as retries", func() {
+			rerunBuild, err := job.RerunBuild(erroredBuild, defaultBuildCreatedBy)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(rerunBuild.RetryOf()).To(BeZero())
+
+			_, retried, err := job.RetryBuild(rerunBuild, 1)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(retried).To(BeTrue())
+		})
+	})
+
 	Describe("ScheduleBuild", func() {
 		var (
 			schedulingBuild            db.Build
@@ -1338,6 +1433,135 @@ var _ = Describe("Job", func() {
 				})
 			})
 		})
+
+		Context("with team serial groups", func() {
+			var otherPipelineJob db.Job
+
+			teamSerialGroupConfig := func(jobName string) atc.Config {
+				return atc.Config{
+					Jobs: atc.JobConfigs{
+						{
+							Name:             jobName,
+							TeamSerialGroups: []string{"staging"},
+						},
+					},
+				}
+			}
+
+			BeforeEach(func() {
+				var err error
+				pipeline, _, err = team.SavePipeline(atc.PipelineRef{Name: "fake-pipeline"}, teamSerialGroupConfig("some-job"), pipeline.ConfigVersion(), false)
+				Expect(err).ToNot(HaveOccurred())
+
+				otherPipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: "other-deploy-pipeline"}, teamSerialGroupConfig("deploy"), db.ConfigVersion(0), false)
+				Expect(err).ToNot(HaveOccurred())
+
+				var found bool
+				job, found, err = pipeline.Job("some-job")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				otherPipelineJob, found, err = otherPipeline.Job("deploy")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+
+			Context("when a build of the group is running in another pipeline", func() {
+				BeforeEach(func() {
+					runningBuild, err := otherPipelineJob.CreateBuild(defaultBuildCreatedBy)
+					Expect(err).NotTo(HaveOccurred())
+
+					err = otherPipelineJob.SaveNextInputMapping(nil, true)
+					Expect(err).NotTo(HaveOccurred())
+
+					scheduled, err := otherPipelineJob.ScheduleBuild(runningBuild)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(scheduled).To(BeTrue())
+
+					schedulingBuild, err = job.CreateBuild(defaultBuildCreatedBy)
+					Expect(err).NotTo(HaveOccurred())
+
+					err = job.SaveNextInputMapping(nil, true)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("does not schedule the build", func() {
+					Expect(schedulingErr).ToNot(HaveOccurred())
+					Expect(scheduleFound).To(BeFalse())
+				})
+			})
+
+			Context("when a build of the group was created earlier in another pipeline", func() {
+				BeforeEach(func() {
+					_, err := otherPipelineJob.CreateBuild(defaultBuildCreatedBy)
+					Expect(err).NotTo(HaveOccurred())
+
+					err = otherPipelineJob.SaveNextInputMapping(nil, true)
+					Expect(err).NotTo(HaveOccurred())
+
+					schedulingBuild, err = job.CreateBuild(defaultBuildCreatedBy)
+					Expect(err).NotTo(HaveOccurred())
+
+					err = job.SaveNextInputMapping(nil, true)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("does not schedule the build", func() {
+					Expect(schedulingErr).ToNot(HaveOccurred())
+					Expect(scheduleFound).To(BeFalse())
+				})
+
+				Context("when the other pipeline is paused", func() {
+					BeforeEach(func() {
+						otherPipeline, found, err := team.Pipeline(atc.PipelineRef{Name: "other-deploy-pipeline"})
+						Expect(err).NotTo(HaveOccurred())
+						Expect(found).To(BeTrue())
+
+						Expect(otherPipeline.Pause(defaultBuildCreatedBy)).To(Succeed())
+					})
+
+					It("schedules the build", func() {
+						Expect(schedulingErr).ToNot(HaveOccurred())
+						Expect(scheduleFound).To(BeTrue())
+					})
+				})
+			})
+
+			Context("when a build of a group of the same name is running for another team", func() {
+				BeforeEach(func() {
+					otherTeam, err := teamFactory.CreateTeam(atc.Team{Name: "some-other-team"})
+					Expect(err).NotTo(HaveOccurred())
+
+					otherTeamPipeline, _, err := otherTeam.SavePipeline(atc.PipelineRef{Name: "other-deploy-pipeline"}, teamSerialGroupConfig("deploy"), db.ConfigVersion(0), false)
+					Expect(err).NotTo(HaveOccurred())
+
+					otherTeamJob, found, err := otherTeamPipeline.Job("deploy")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+
+					runningBuild, err := otherTeamJob.CreateBuild(defaultBuildCreatedBy)
+					Expect(err).NotTo(HaveOccurred())
+
+					err = otherTeamJob.SaveNextInputMapping(nil, true)
+					Expect(err).NotTo(HaveOccurred())
+
+					scheduled, err := otherTeamJob.ScheduleBuild(runningBuild)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(scheduled).To(BeTrue())
+
+					schedulingBuild, err = job.CreateBuild(defaultBuildCreatedBy)
+					Expect(err).NotTo(HaveOccurred())
+
+					err = job.SaveNextInputMapping(nil, true)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("schedules the build", func() {
+					Expect(schedulingErr).ToNot(HaveOccurred())
+					Expect(scheduleFound).To(BeTrue())
+				})
+			})
+		})
 	})
 
 	Describe("GetNextBuildInputs", func() {
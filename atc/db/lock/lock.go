@@ -11,6 +11,8 @@ import (
 	"sync"
 
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/db/dialect"
+	"github.com/concourse/concourse/atc/faults"
 )
 
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
@@ -27,6 +29,7 @@ const (
 	LockTypeInMemoryCheckBuildTracking
 	LockTypeResourceGet
 	LockTypeVolumeStreaming
+	LockTypeTeamSerialGroup
 )
 
 const (
@@ -104,6 +107,13 @@ func NewVolumeStreamingLockID(resourceCacheID int, worker string) LockID {
 	return LockID{LockTypeVolumeStreaming, lockIDFromString(fmt.Sprintf("%d-%s", resourceCacheID, worker))}
 }
 
+// NewTeamSerialGroupLockID is held while scheduling a build of a job in the
+// team serial group, which may be done for jobs of different pipelines at
+// once.
+func NewTeamSerialGroupLockID(teamID int, serialGroup string) LockID {
+	return LockID{LockTypeTeamSerialGroup, lockIDFromString(fmt.Sprintf("%d-%s", teamID, serialGroup))}
+}
+
 func NewResourceGetLockID(name string) LockID {
 	return LockID{LockTypeResourceGet, lockIDFromString(name)}
 }
@@ -150,6 +160,53 @@ func NewLockFactory(
 	return factories
 }
 
+// NewLockFactoryForDialect is NewLockFactory for databases which may not
+// have advisory locks, in which case they are emulated with a table.
+func NewLockFactoryForDialect(
+	conns [FactoryCount]*sql.DB,
+	d dialect.Dialect,
+	acquire LogFunc,
+	release LogFunc,
+) (LockFactory, error) {
+	if d.AdvisoryLocks() {
+		return NewLockFactory(conns, acquire, release), nil
+	}
+
+	return NewTableLockFactory(conns, d, acquire, release)
+}
+
+// NewTableLockFactory is NewLockFactory with the locks emulated with a table,
+// for when advisory locks can't be relied on, such as behind a connection
+// pooler which hands each transaction a different session.
+func NewTableLockFactory(
+	conns [FactoryCount]*sql.DB,
+	d dialect.Dialect,
+	acquire LogFunc,
+	release LogFunc,
+) (LockFactory, error) {
+	factories := lockFactories{}
+
+	for i := 0; i < FactoryCount; i++ {
+		db, err := NewTableLockDB(conns[i], d)
+		if err != nil {
+			return nil, err
+		}
+
+		factories[i] = &lockFactory{
+			db:          db,
+			acquireFunc: acquire,
+			releaseFunc: release,
+			locks: lockRepo{
+				locks: map[string]bool{},
+				mutex: &sync.Mutex{},
+			},
+			acquireMutex: &sync.Mutex{},
+		}
+	}
+
+	return factories, nil
+}
+
 func NewTestLockFactory(db LockDB) LockFactory {
 	return &lockFactory{
 		db: db,
@@ -233,6 +290,12 @@ func (l *lock) Acquire() (bool, error) {
 		return false, nil
 	}
 
+	err := faults.Inject(faults.Lock)
+	if err != nil {
+		logger.Error("failed-to-register-in-db", err)
+		return false, err
+	}
+
 	acquired, err := l.db.Acquire(l.id)
 	if err != nil {
 		logger.Error("failed-to-register-in-db", err)
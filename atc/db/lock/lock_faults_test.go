@@ -0,0 +1,56 @@
+//go:build faults
+// +build faults
+
+package lock_test
+
+import (
+	"errors"
+	"os"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc/db/lock"
+	"github.com/concourse/concourse/atc/db/lock/lockfakes"
+	"github.com/concourse/concourse/atc/faults"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Lock faults", func() {
+	var (
+		fakeLockDB  *lockfakes.FakeLockDB
+		lockFactory lock.LockFactory
+	)
+
+	BeforeEach(func() {
+		faults.Reset()
+		os.Setenv("CONCOURSE_FAULTS_LOCK_FAIL_EVERY", "2")
+
+		fakeLockDB = new(lockfakes.FakeLockDB)
+		fakeLockDB.AcquireReturns(true, nil)
+		fakeLockDB.ReleaseReturns(true, nil)
+
+		lockFactory = lock.NewTestLockFactory(fakeLockDB)
+	})
+
+	AfterEach(func() {
+		os.Unsetenv("CONCOURSE_FAULTS_LOCK_FAIL_EVERY")
+	})
+
+	It("fails the acquisitions which are due to fail without holding the lock", func() {
+		logger := lagertest.NewTestLogger("test")
+
+		l, acquired, err := lockFactory.Acquire(logger, lock.NewTaskLockID("some-task"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(acquired).To(BeTrue())
+		Expect(l.Release()).To(Succeed())
+
+		_, acquired, err = lockFactory.Acquire(logger, lock.NewTaskLockID("some-task"))
+		Expect(errors.Is(err, faults.ErrInjected)).To(BeTrue())
+		Expect(acquired).To(BeFalse())
+		Expect(fakeLockDB.AcquireCallCount()).To(Equal(1))
+
+		_, acquired, err = lockFactory.Acquire(logger, lock.NewTaskLockID("some-task"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(acquired).To(BeTrue())
+	})
+})
@@ -0,0 +1,146 @@
+package lock
+
+import (
+	"database/sql"
+	"sync"
+	"time"
+
+	"github.com/concourse/concourse/atc/db/dialect"
+	uuid "github.com/nu7hatch/gouuid"
+)
+
+// TableLockLease is how long a lock emulated with a table outlives the ATC
+// holding it, should it go away without releasing it. The leases of the locks
+// an ATC holds are renewed well before they run out.
+var TableLockLease = time.Minute
+
+type tableLockDB struct {
+	conn    *sql.DB
+	dialect dialect.Dialect
+	owner   string
+	mutex   *sync.Mutex
+}
+
+// NewTableLockDB emulates advisory locks with a table, for databases which
+// don't have them. Unlike advisory locks, the locks aren't released when the
+// connection goes away, so they are leased instead, and the leases of the
+// locks held are renewed for as long as the process is around.
+func NewTableLockDB(conn *sql.DB, d dialect.Dialect) (LockDB, error) {
+	_, err := conn.Exec(`
+		CREATE TABLE IF NOT EXISTS concourse_locks (
+			id text PRIMARY KEY,
+			owner text NOT NULL,
+			expires_at timestamp with time zone NOT NULL
+		)
+	`)
+	if err != nil {
+		return nil, err
+	}
+
+	owner, err := uuid.NewV4()
+	if err != nil {
+		return nil, err
+	}
+
+	db := &tableLockDB{
+		conn:    conn,
+		dialect: d,
+		owner:   owner.String(),
+		mutex:   &sync.Mutex{},
+	}
+
+	go db.renewLeases()
+
+	return db, nil
+}
+
+func (db *tableLockDB) Acquire(id LockID) (bool, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	var acquired bool
+	err := db.retry(func() error {
+		result, err := db.conn.Exec(`
+			INSERT INTO concourse_locks (id, owner, expires_at)
+			VALUES ($1, $2, now() + $3 * '1 second'::interval)
+			ON CONFLICT (id) DO UPDATE
+			SET owner = excluded.owner, expires_at = excluded.expires_at
+			WHERE concourse_locks.expires_at < now()
+		`, id.toKey(), db.owner, int(TableLockLease.Seconds()))
+		if err != nil {
+			return err
+		}
+
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+
+		acquired = affected == 1
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return acquired, nil
+}
+
+func (db *tableLockDB) Release(id LockID) (bool, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	var released bool
+	err := db.retry(func() error {
+		result, err := db.conn.Exec(`
+			DELETE FROM concourse_locks
+			WHERE id = $1
+			AND owner = $2
+		`, id.toKey(), db.owner)
+		if err != nil {
+			return err
+		}
+
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+
+		released = affected == 1
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return released, nil
+}
+
+func (db *tableLockDB) renewLeases() {
+	ticker := time.NewTicker(TableLockLease / 3)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		// a lease which fails to be renewed is tried again on the next tick,
+		// well before it runs out
+		_ = db.retry(func() error {
+			_, err := db.conn.Exec(`
+				UPDATE concourse_locks
+				SET expires_at = now() + $2 * '1 second'::interval
+				WHERE owner = $1
+			`, db.owner, int(TableLockLease.Seconds()))
+			return err
+		})
+	}
+}
+
+func (db *tableLockDB) retry(f func() error) error {
+	for {
+		err := f()
+		if err != nil && db.dialect.ShouldRetry(err) {
+			continue
+		}
+
+		return err
+	}
+}
@@ -0,0 +1,68 @@
+package lock_test
+
+import (
+	"database/sql"
+
+	"github.com/concourse/concourse/atc/db/dialect"
+	"github.com/concourse/concourse/atc/db/lock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TableLockDB", func() {
+	var (
+		conn        *sql.DB
+		lockDB      lock.LockDB
+		otherLockDB lock.LockDB
+	)
+
+	BeforeEach(func() {
+		postgresRunner.CreateTestDBFromTemplate()
+
+		conn = postgresRunner.OpenSingleton()
+
+		var err error
+		lockDB, err = lock.NewTableLockDB(conn, dialect.CockroachDB)
+		Expect(err).ToNot(HaveOccurred())
+
+		otherLockDB, err = lock.NewTableLockDB(conn, dialect.CockroachDB)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(conn.Close()).To(Succeed())
+
+		postgresRunner.DropTestDB()
+	})
+
+	It("acquires a lock which isn't held", func() {
+		Expect(lockDB.Acquire(lock.LockID{42})).To(BeTrue())
+		Expect(lockDB.Acquire(lock.LockID{43})).To(BeTrue())
+	})
+
+	It("doesn't acquire a lock held by someone else", func() {
+		Expect(lockDB.Acquire(lock.LockID{42})).To(BeTrue())
+		Expect(otherLockDB.Acquire(lock.LockID{42})).To(BeFalse())
+	})
+
+	It("acquires a lock once it is released", func() {
+		Expect(lockDB.Acquire(lock.LockID{42})).To(BeTrue())
+		Expect(lockDB.Release(lock.LockID{42})).To(BeTrue())
+		Expect(otherLockDB.Acquire(lock.LockID{42})).To(BeTrue())
+	})
+
+	It("doesn't release a lock held by someone else", func() {
+		Expect(lockDB.Acquire(lock.LockID{42})).To(BeTrue())
+		Expect(otherLockDB.Release(lock.LockID{42})).To(BeFalse())
+	})
+
+	It("acquires a lock whose lease ran out", func() {
+		Expect(lockDB.Acquire(lock.LockID{42})).To(BeTrue())
+
+		_, err := conn.Exec(`UPDATE concourse_locks SET expires_at = now() - '1 second'::interval`)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(otherLockDB.Acquire(lock.LockID{42})).To(BeTrue())
+		Expect(lockDB.Release(lock.LockID{42})).To(BeFalse())
+	})
+})
@@ -0,0 +1,469 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"net/url"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/event"
+)
+
+// LogDrainWindow is how long after a build has finished its logs are still
+// forwarded to log drains. A drain which fails for longer misses the logs of
+// the builds which finished in the meantime.
+const LogDrainWindow = 24 * time.Hour
+
+// LogDrainRepository manages the log drains of teams, along with how far each
+// of them has got through the logs of each build. The logs themselves are
+// read from the build events, which act as the drains' buffer.
+//
+//counterfeiter:generate . LogDrainRepository
+type LogDrainRepository interface {
+	Drains(teamID int) ([]atc.LogDrain, error)
+	SaveDrain(teamID int, drain atc.LogDrain) error
+	DeleteDrain(teamID int, name string) (bool, error)
+
+	PendingLogs(limit int) ([]PendingLogDrain, error)
+	LogsDrained(drainID int, builds []DrainedBuild) error
+	DrainFailed(drainID int, drainErr string, retryAt time.Time) error
+	PruneCursors(before time.Time) error
+}
+
+// PendingLogDrain is a drain which is due to be forwarded logs, along with
+// the logs of the builds it has yet to get through.
+type PendingLogDrain struct {
+	ID       int
+	Type     atc.LogDrainType
+	URL      string
+	Failures int
+
+	Builds  []DrainedBuild
+	Records []atc.LogDrainRecord
+}
+
+// DrainedBuild is how far a drain will have got through the events of a
+// build once its records are forwarded. Completed is true once the build has
+// finished and all its events have been read.
+type DrainedBuild struct {
+	ID        int
+	Cursor    int
+	Completed bool
+}
+
+type logDrainRepository struct {
+	conn Conn
+}
+
+func NewLogDrainRepository(conn Conn) LogDrainRepository {
+	return &logDrainRepository{
+		conn: conn,
+	}
+}
+
+// Drains returns the team's drains. The passwords of their URLs are
+// redacted.
+func (repo *logDrainRepository) Drains(teamID int) ([]atc.LogDrain, error) {
+	rows, err := psql.Select("name", "type", "url", "nonce", "pipeline", "failures", "last_error").
+		From("log_drains").
+		Where(sq.Eq{"team_id": teamID}).
+		OrderBy("name").
+		RunWith(repo.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	drains := []atc.LogDrain{}
+	for rows.Next() {
+		var (
+			drain        atc.LogDrain
+			encryptedURL string
+			nonce        sql.NullString
+			pipeline     sql.NullString
+			lastError    sql.NullString
+		)
+
+		err := rows.Scan(&drain.Name, &drain.Type, &encryptedURL, &nonce, &pipeline, &drain.Failures, &lastError)
+		if err != nil {
+			return nil, err
+		}
+
+		drain.URL, err = repo.decryptURL(encryptedURL, nonce)
+		if err != nil {
+			return nil, err
+		}
+
+		drain.URL = redactURL(drain.URL)
+		drain.Pipeline = pipeline.String
+		drain.LastError = lastError.String
+
+		drains = append(drains, drain)
+	}
+
+	return drains, rows.Err()
+}
+
+// SaveDrain creates or replaces the drain with the same name. The URL is
+// encrypted. Replacing a drain keeps its progress, but clears its failures
+// so that it is retried straight away.
+func (repo *logDrainRepository) SaveDrain(teamID int, drain atc.LogDrain) error {
+	encryptedURL, nonce, err := repo.conn.EncryptionStrategy().Encrypt([]byte(drain.URL))
+	if err != nil {
+		return err
+	}
+
+	var pipeline sql.NullString
+	if drain.Pipeline != "" {
+		pipeline = sql.NullString{String: drain.Pipeline, Valid: true}
+	}
+
+	_, err = psql.Insert("log_drains").
+		Columns("team_id", "name", "type", "url", "nonce", "pipeline").
+		Values(teamID, drain.Name, string(drain.Type), encryptedURL, nonce, pipeline).
+		Suffix(`
+			ON CONFLICT (team_id, name) DO UPDATE SET
+				type = EXCLUDED.type,
+				url = EXCLUDED.url,
+				nonce = EXCLUDED.nonce,
+				pipeline = EXCLUDED.pipeline,
+				failures = 0,
+				last_error = NULL,
+				next_attempt_at = now()
+		`).
+		RunWith(repo.conn).
+		Exec()
+	return err
+}
+
+func (repo *logDrainRepository) DeleteDrain(teamID int, name string) (bool, error) {
+	result, err := psql.Delete("log_drains").
+		Where(sq.Eq{"team_id": teamID}).
+		Where(sq.Eq{"name": name}).
+		RunWith(repo.conn).
+		Exec()
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected > 0, nil
+}
+
+type logDrainBuild struct {
+	id           int
+	name         string
+	teamID       int
+	teamName     string
+	pipelineID   int
+	pipelineName string
+	jobName      string
+	completed    bool
+	cursor       int
+}
+
+// PendingLogs returns the drains which are due, each with the logs of up to
+// limit of its builds following its cursors.
+//
+// A drain gets the logs of the builds which started after it was created, and
+// which haven't finished more than LogDrainWindow ago. The logs of check
+// builds are left out.
+func (repo *logDrainRepository) PendingLogs(limit int) ([]PendingLogDrain, error) {
+	tx, err := repo.conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Rollback(tx)
+
+	rows, err := psql.Select("d.id", "d.type", "d.url", "d.nonce", "d.failures", "d.team_id", "d.pipeline", "d.created_at").
+		From("log_drains d").
+		Where(sq.Expr("d.next_attempt_at <= now()")).
+		OrderBy("d.id").
+		RunWith(tx).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	type dueDrain struct {
+		PendingLogDrain
+
+		teamID    int
+		pipeline  sql.NullString
+		createdAt time.Time
+	}
+
+	var due []dueDrain
+	for rows.Next() {
+		var (
+			drain        dueDrain
+			encryptedURL string
+			nonce        sql.NullString
+		)
+
+		err := rows.Scan(&drain.ID, &drain.Type, &encryptedURL, &nonce, &drain.Failures, &drain.teamID, &drain.pipeline, &drain.createdAt)
+		if err != nil {
+			Close(rows)
+			return nil, err
+		}
+
+		drain.URL, err = repo.decryptURL(encryptedURL, nonce)
+		if err != nil {
+			Close(rows)
+			return nil, err
+		}
+
+		due = append(due, drain)
+	}
+
+	Close(rows)
+
+	var drains []PendingLogDrain
+	for _, drain := range due {
+		builds, err := logDrainBuilds(tx, drain.ID, drain.teamID, drain.pipeline, drain.createdAt, limit)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, build := range builds {
+			drained, records, err := logDrainRecords(tx, build)
+			if err != nil {
+				return nil, err
+			}
+
+			drain.Builds = append(drain.Builds, drained)
+			drain.Records = append(drain.Records, records...)
+		}
+
+		if len(drain.Builds) > 0 {
+			drains = append(drains, drain.PendingLogDrain)
+		}
+	}
+
+	return drains, tx.Commit()
+}
+
+func logDrainBuilds(tx Tx, drainID int, teamID int, pipeline sql.NullString, createdAt time.Time, limit int) ([]logDrainBuild, error) {
+	query := psql.Select(
+		"b.id",
+		"b.name",
+		"b.team_id",
+		"t.name",
+		"coalesce(b.pipeline_id, 0)",
+		"coalesce(p.name, '')",
+		"coalesce(j.name, '')",
+		"b.completed",
+		"coalesce(c.cursor, -1)",
+	).
+		From("builds b").
+		Join("teams t ON t.id = b.team_id").
+		LeftJoin("pipelines p ON p.id = b.pipeline_id").
+		LeftJoin("jobs j ON j.id = b.job_id").
+		LeftJoin("log_drain_cursors c ON c.build_id = b.id AND c.drain_id = ?", drainID).
+		Where(sq.Eq{"b.team_id": teamID}).
+		Where(sq.NotEq{"b.name": CheckBuildName}).
+		Where(sq.GtOrEq{"b.start_time": createdAt}).
+		Where(sq.Or{
+			sq.Eq{"b.end_time": nil},
+			sq.Gt{"b.end_time": time.Now().Add(-LogDrainWindow)},
+		}).
+		Where(sq.Or{
+			sq.Eq{"c.completed": nil},
+			sq.Eq{"c.completed": false},
+		}).
+		OrderBy("b.id").
+		Limit(uint64(limit))
+
+	if pipeline.Valid {
+		query = query.Where(sq.Eq{"p.name": pipeline.String})
+	}
+
+	rows, err := query.RunWith(tx).Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	var builds []logDrainBuild
+	for rows.Next() {
+		var build logDrainBuild
+		err := rows.Scan(
+			&build.id,
+			&build.name,
+			&build.teamID,
+			&build.teamName,
+			&build.pipelineID,
+			&build.pipelineName,
+			&build.jobName,
+			&build.completed,
+			&build.cursor,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		builds = append(builds, build)
+	}
+
+	return builds, rows.Err()
+}
+
+// logDrainRecords returns the records of the logs and errors following the
+// cursor of the build.
+func logDrainRecords(tx Tx, build logDrainBuild) (DrainedBuild, []atc.LogDrainRecord, error) {
+	table := fmt.Sprintf("team_build_events_%d", build.teamID)
+	if build.pipelineID != 0 {
+		table = fmt.Sprintf("pipeline_build_events_%d", build.pipelineID)
+	}
+
+	events, err := fetchBuildEvents(tx, table, build.id, build.cursor)
+	if err != nil {
+		return DrainedBuild{}, nil, err
+	}
+
+	drained := DrainedBuild{
+		ID:     build.id,
+		Cursor: build.cursor,
+
+		// the build was completed before fetching the events, so they're
+		// only all there is once there are no more to fetch
+		Completed: build.completed && len(events) < buildEventBatchSize,
+	}
+
+	var records []atc.LogDrainRecord
+	for _, ev := range events {
+		drained.Cursor = ev.id
+
+		if ev.envelope.Event != event.EventTypeLog && ev.envelope.Event != event.EventTypeError {
+			continue
+		}
+
+		parsed, err := event.ParseEvent(ev.envelope.Version, ev.envelope.Event, *ev.envelope.Data)
+		if err != nil {
+			// logs saved by very old versions of Concourse
+			continue
+		}
+
+		record := atc.LogDrainRecord{
+			Team:     build.teamName,
+			Pipeline: build.pipelineName,
+			Job:      build.jobName,
+			Build:    build.name,
+			BuildID:  build.id,
+		}
+
+		switch e := parsed.(type) {
+		case event.Log:
+			record.Time = e.Time
+			record.Step = e.Origin.ID.String()
+			record.Stream = string(e.Origin.Source)
+			record.Message = e.Payload
+		case event.Error:
+			record.Time = e.Time
+			record.Step = e.Origin.ID.String()
+			record.Message = e.Message
+			record.Error = true
+		default:
+			continue
+		}
+
+		records = append(records, record)
+	}
+
+	return drained, records, nil
+}
+
+// LogsDrained moves the cursors of the drain forward, and clears its
+// failures.
+func (repo *logDrainRepository) LogsDrained(drainID int, builds []DrainedBuild) error {
+	tx, err := repo.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	for _, build := range builds {
+		_, err := psql.Insert("log_drain_cursors").
+			Columns("drain_id", "build_id", "cursor", "completed").
+			Values(drainID, build.ID, build.Cursor, build.Completed).
+			Suffix(`
+				ON CONFLICT (drain_id, build_id) DO UPDATE SET
+					cursor = EXCLUDED.cursor,
+					completed = EXCLUDED.completed
+			`).
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = psql.Update("log_drains").
+		Set("failures", 0).
+		Set("last_error", nil).
+		Where(sq.Eq{"id": drainID}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// DrainFailed records a failure to forward logs to the drain, which is
+// retried at retryAt.
+func (repo *logDrainRepository) DrainFailed(drainID int, drainErr string, retryAt time.Time) error {
+	_, err := psql.Update("log_drains").
+		Set("failures", sq.Expr("failures + 1")).
+		Set("last_error", drainErr).
+		Set("next_attempt_at", retryAt).
+		Where(sq.Eq{"id": drainID}).
+		RunWith(repo.conn).
+		Exec()
+	return err
+}
+
+// PruneCursors deletes the cursors of the builds which finished before the
+// given time, as their logs are no longer forwarded anyway.
+func (repo *logDrainRepository) PruneCursors(before time.Time) error {
+	_, err := psql.Delete("log_drain_cursors").
+		Where(sq.Expr("build_id IN (SELECT id FROM builds WHERE end_time < ?)", before)).
+		RunWith(repo.conn).
+		Exec()
+	return err
+}
+
+func (repo *logDrainRepository) decryptURL(encryptedURL string, nonce sql.NullString) (string, error) {
+	var noncense *string
+	if nonce.Valid {
+		noncense = &nonce.String
+	}
+
+	decrypted, err := repo.conn.EncryptionStrategy().Decrypt(encryptedURL, noncense)
+	if err != nil {
+		return "", err
+	}
+
+	return string(decrypted), nil
+}
+
+func redactURL(drainURL string) string {
+	parsed, err := url.Parse(drainURL)
+	if err != nil {
+		return drainURL
+	}
+
+	return parsed.Redacted()
+}
@@ -0,0 +1,100 @@
+package db
+
+import (
+	"database/sql"
+	"strings"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// LoginLockoutRepository counts local users' consecutive failed logins and
+// locks them out. Usernames are compared case-insensitively, as they are
+// when logging in.
+//
+//counterfeiter:generate . LoginLockoutRepository
+type LoginLockoutRepository interface {
+	// LoginLockedFor returns how much longer the user is locked out for, or
+	// zero if they are not.
+	LoginLockedFor(username string) (time.Duration, error)
+
+	// RecordLoginFailure counts a failed login and returns how many there
+	// have been in a row. A failure more than window after the last one
+	// starts counting again.
+	RecordLoginFailure(username string, window time.Duration) (int, error)
+
+	LockLogin(username string, duration time.Duration) error
+
+	// ClearLoginFailures forgets the user's failures, such as once they have
+	// logged in.
+	ClearLoginFailures(username string) error
+}
+
+type loginLockoutRepository struct {
+	conn Conn
+}
+
+func NewLoginLockoutRepository(conn Conn) LoginLockoutRepository {
+	return &loginLockoutRepository{
+		conn: conn,
+	}
+}
+
+func (repo *loginLockoutRepository) LoginLockedFor(username string) (time.Duration, error) {
+	var seconds float64
+	err := psql.Select("EXTRACT(EPOCH FROM locked_until - now())").
+		From("login_failures").
+		Where(sq.Eq{"username": strings.ToLower(username)}).
+		Where(sq.Expr("locked_until > now()")).
+		RunWith(repo.conn).
+		QueryRow().
+		Scan(&seconds)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, nil
+		}
+
+		return 0, err
+	}
+
+	return time.Duration(seconds * float64(time.Second)), nil
+}
+
+func (repo *loginLockoutRepository) RecordLoginFailure(username string, window time.Duration) (int, error) {
+	var failures int
+	err := psql.Insert("login_failures").
+		Columns("username", "failures").
+		Values(strings.ToLower(username), 1).
+		Suffix(`ON CONFLICT (username) DO UPDATE SET
+			failures = CASE
+				WHEN login_failures.last_failed_at < now() - ? * interval '1 second' THEN 1
+				ELSE login_failures.failures + 1
+			END,
+			last_failed_at = now()
+			RETURNING failures`, int(window.Seconds())).
+		RunWith(repo.conn).
+		QueryRow().
+		Scan(&failures)
+	if err != nil {
+		return 0, err
+	}
+
+	return failures, nil
+}
+
+func (repo *loginLockoutRepository) LockLogin(username string, duration time.Duration) error {
+	_, err := psql.Update("login_failures").
+		Set("locked_until", sq.Expr("now() + ? * interval '1 second'", int(duration.Seconds()))).
+		Where(sq.Eq{"username": strings.ToLower(username)}).
+		RunWith(repo.conn).
+		Exec()
+	return err
+}
+
+func (repo *loginLockoutRepository) ClearLoginFailures(username string) error {
+	_, err := psql.Delete("login_failures").
+		Where(sq.Eq{"username": strings.ToLower(username)}).
+		RunWith(repo.conn).
+		Exec()
+	return err
+}
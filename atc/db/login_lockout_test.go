@@ -0,0 +1,75 @@
+package db_test
+
+import (
+	"time"
+
+	"github.com/concourse/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("LoginLockoutRepository", func() {
+	var repository db.LoginLockoutRepository
+
+	BeforeEach(func() {
+		repository = db.NewLoginLockoutRepository(dbConn)
+	})
+
+	Describe("RecordLoginFailure", func() {
+		It("counts consecutive failures", func() {
+			Expect(repository.RecordLoginFailure("some-user", time.Hour)).To(Equal(1))
+			Expect(repository.RecordLoginFailure("Some-User", time.Hour)).To(Equal(2))
+			Expect(repository.RecordLoginFailure("other-user", time.Hour)).To(Equal(1))
+		})
+
+		It("starts counting again once the last failure is older than the window", func() {
+			Expect(repository.RecordLoginFailure("some-user", time.Hour)).To(Equal(1))
+
+			_, err := dbConn.Exec(`UPDATE login_failures SET last_failed_at = now() - interval '2 hours'`)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(repository.RecordLoginFailure("some-user", time.Hour)).To(Equal(1))
+		})
+	})
+
+	Describe("LockLogin", func() {
+		BeforeEach(func() {
+			_, err := repository.RecordLoginFailure("some-user", time.Hour)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("locks the user out for the duration", func() {
+			Expect(repository.LoginLockedFor("some-user")).To(BeZero())
+
+			Expect(repository.LockLogin("some-user", time.Minute)).To(Succeed())
+
+			lockedFor, err := repository.LoginLockedFor("SOME-USER")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(lockedFor).To(BeNumerically("~", time.Minute, 5*time.Second))
+
+			Expect(repository.LoginLockedFor("other-user")).To(BeZero())
+		})
+
+		It("no longer locks the user out once the duration has passed", func() {
+			Expect(repository.LockLogin("some-user", time.Minute)).To(Succeed())
+
+			_, err := dbConn.Exec(`UPDATE login_failures SET locked_until = now() - interval '1 second'`)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(repository.LoginLockedFor("some-user")).To(BeZero())
+		})
+	})
+
+	Describe("ClearLoginFailures", func() {
+		It("forgets the user's failures and lockout", func() {
+			_, err := repository.RecordLoginFailure("some-user", time.Hour)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(repository.LockLogin("some-user", time.Minute)).To(Succeed())
+
+			Expect(repository.ClearLoginFailures("some-user")).To(Succeed())
+
+			Expect(repository.LoginLockedFor("some-user")).To(BeZero())
+			Expect(repository.RecordLoginFailure("some-user", time.Hour)).To(Equal(1))
+		})
+	})
+})
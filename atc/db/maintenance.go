@@ -0,0 +1,267 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/lib/pq"
+)
+
+// MaintainedTables are the tables which churn the most, and so bloat the
+// most. The partitions of build_events are maintained along with it.
+var MaintainedTables = []string{"builds", "build_events", "containers", "volumes"}
+
+// MaintenanceThresholds determine when a table is worth vacuuming and an
+// index worth rebuilding. Both the absolute and the relative threshold have
+// to be reached, so that small tables aren't maintained needlessly.
+type MaintenanceThresholds struct {
+	MinDeadTuples  int64
+	DeadTupleRatio float64
+
+	MinIndexBloatBytes int64
+	IndexBloatRatio    float64
+}
+
+var DefaultMaintenanceThresholds = MaintenanceThresholds{
+	MinDeadTuples:  10000,
+	DeadTupleRatio: 0.2,
+
+	MinIndexBloatBytes: 100 * 1024 * 1024,
+	IndexBloatRatio:    0.3,
+}
+
+// MaintenanceRepository tracks the dead tuples of the maintained tables and
+// the bloat of their indexes, and vacuums and rebuilds them.
+//
+//counterfeiter:generate . MaintenanceRepository
+type MaintenanceRepository interface {
+	Maintenance() (atc.DBMaintenance, error)
+
+	Vacuum(table string) error
+	Reindex(index string) error
+}
+
+type maintenanceRepository struct {
+	conn       Conn
+	thresholds MaintenanceThresholds
+}
+
+func NewMaintenanceRepository(conn Conn, thresholds MaintenanceThresholds) MaintenanceRepository {
+	return &maintenanceRepository{
+		conn:       conn,
+		thresholds: thresholds,
+	}
+}
+
+// maintainedRelations finds the maintained tables and their partitions, each
+// with the maintained table it belongs to.
+const maintainedRelations = `
+	WITH RECURSIVE maintained AS (
+		SELECT c.oid, c.relname::text AS parent
+		FROM pg_class c
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		WHERE n.nspname = current_schema()
+		AND c.relname = ANY($1)
+		UNION ALL
+		SELECT i.inhrelid, m.parent
+		FROM pg_inherits i
+		JOIN maintained m ON m.oid = i.inhparent
+	)
+`
+
+// Maintenance describes the maintained tables and advises which of them, or
+// of their partitions, to vacuum, and then which indexes to rebuild.
+//
+// Dead tuples are as counted by the statistics collector. The bloat of an
+// index is estimated from the number of tuples in it and the average width
+// of the columns it indexes, so it's only known for tables which have been
+// analyzed.
+func (repo *maintenanceRepository) Maintenance() (atc.DBMaintenance, error) {
+	tables := map[string]*atc.DBTableHealth{}
+	for _, name := range MaintainedTables {
+		tables[name] = &atc.DBTableHealth{
+			Name:    name,
+			Indexes: []atc.DBIndexHealth{},
+		}
+	}
+
+	advice := []atc.DBMaintenanceAdvice{}
+
+	rows, err := repo.conn.Query(maintainedRelations+`
+		SELECT
+			m.parent,
+			c.relname,
+			coalesce(s.n_live_tup, 0),
+			coalesce(s.n_dead_tup, 0),
+			pg_table_size(c.oid),
+			extract(epoch FROM greatest(s.last_vacuum, s.last_autovacuum))::bigint
+		FROM maintained m
+		JOIN pg_class c ON c.oid = m.oid
+		LEFT JOIN pg_stat_user_tables s ON s.relid = c.oid
+		ORDER BY c.relname
+	`, pq.Array(MaintainedTables))
+	if err != nil {
+		return atc.DBMaintenance{}, err
+	}
+
+	defer Close(rows)
+
+	for rows.Next() {
+		var (
+			parent, name     string
+			live, dead, size int64
+			lastVacuum       sql.NullInt64
+		)
+
+		err = rows.Scan(&parent, &name, &live, &dead, &size, &lastVacuum)
+		if err != nil {
+			return atc.DBMaintenance{}, err
+		}
+
+		table := tables[parent]
+		if name != parent {
+			table.Partitions++
+		}
+
+		table.LiveTuples += live
+		table.DeadTuples += dead
+		table.Bytes += size
+
+		if lastVacuum.Valid && lastVacuum.Int64 > table.LastVacuum {
+			table.LastVacuum = lastVacuum.Int64
+		}
+
+		if dead >= repo.thresholds.MinDeadTuples && float64(dead) >= repo.thresholds.DeadTupleRatio*float64(live+dead) {
+			advice = append(advice, atc.DBMaintenanceAdvice{
+				Action:   atc.DBMaintenanceVacuum,
+				Relation: name,
+				Reason:   fmt.Sprintf("%d of its %d tuples are dead", dead, live+dead),
+			})
+		}
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return atc.DBMaintenance{}, err
+	}
+
+	rows, err = repo.conn.Query(maintainedRelations+`
+		SELECT
+			m.parent,
+			c.relname,
+			ic.relname,
+			pg_relation_size(ic.oid),
+			greatest(ic.reltuples, 0)::bigint,
+			(
+				SELECT sum(s.avg_width)
+				FROM pg_attribute a
+				JOIN pg_stats s
+				ON s.schemaname = current_schema()
+				AND s.tablename = c.relname
+				AND s.attname = a.attname
+				WHERE a.attrelid = c.oid
+				AND a.attnum = ANY(i.indkey)
+			)
+		FROM maintained m
+		JOIN pg_class c ON c.oid = m.oid
+		JOIN pg_index i ON i.indrelid = c.oid
+		JOIN pg_class ic ON ic.oid = i.indexrelid
+		JOIN pg_am am ON am.oid = ic.relam
+		WHERE am.amname = 'btree'
+		ORDER BY ic.relname
+	`, pq.Array(MaintainedTables))
+	if err != nil {
+		return atc.DBMaintenance{}, err
+	}
+
+	defer Close(rows)
+
+	for rows.Next() {
+		var (
+			parent, table, name string
+			size, tuples        int64
+			width               sql.NullInt64
+		)
+
+		err = rows.Scan(&parent, &table, &name, &size, &tuples, &width)
+		if err != nil {
+			return atc.DBMaintenance{}, err
+		}
+
+		index := atc.DBIndexHealth{
+			Name:  name,
+			Table: table,
+			Bytes: size,
+		}
+
+		if width.Valid {
+			index.BloatBytes = estimateIndexBloat(size, tuples, width.Int64)
+		}
+
+		if table == parent {
+			tables[parent].Indexes = append(tables[parent].Indexes, index)
+		}
+
+		if index.BloatBytes >= repo.thresholds.MinIndexBloatBytes && float64(index.BloatBytes) >= repo.thresholds.IndexBloatRatio*float64(size) {
+			advice = append(advice, atc.DBMaintenanceAdvice{
+				Action:   atc.DBMaintenanceReindex,
+				Relation: name,
+				Reason:   fmt.Sprintf("an estimated %d of its %d bytes are bloat", index.BloatBytes, size),
+			})
+		}
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return atc.DBMaintenance{}, err
+	}
+
+	maintenance := atc.DBMaintenance{
+		Advice: advice,
+	}
+
+	for _, name := range MaintainedTables {
+		maintenance.Tables = append(maintenance.Tables, *tables[name])
+	}
+
+	return maintenance, nil
+}
+
+// estimateIndexBloat estimates how much of a btree index is bloat from what
+// its tuples would take up when packed at the default fill factor of 90%.
+// Each tuple takes up the width of its key, an 8 byte header and a 4 byte
+// line pointer.
+func estimateIndexBloat(size, tuples, width int64) int64 {
+	expected := int64(float64(tuples*(width+12)) / 0.9)
+	if expected >= size {
+		return 0
+	}
+
+	return size - expected
+}
+
+// Vacuum vacuums and analyzes the table, which must be a maintained table or
+// one of their partitions. Vacuuming a table inherited from doesn't vacuum
+// the tables inheriting from it.
+func (repo *maintenanceRepository) Vacuum(table string) error {
+	_, err := repo.conn.Exec("VACUUM (ANALYZE) " + pq.QuoteIdentifier(table))
+	return err
+}
+
+// Reindex rebuilds the index without locking out writes to its table, which
+// takes PostgreSQL 12 or later.
+func (repo *maintenanceRepository) Reindex(index string) error {
+	var version int
+	err := repo.conn.QueryRow(`SELECT current_setting('server_version_num')::int`).Scan(&version)
+	if err != nil {
+		return err
+	}
+
+	if version < 120000 {
+		return fmt.Errorf("rebuilding index %s without locking its table takes PostgreSQL 12 or later", index)
+	}
+
+	_, err = repo.conn.Exec("REINDEX INDEX CONCURRENTLY " + pq.QuoteIdentifier(index))
+	return err
+}
@@ -0,0 +1,97 @@
+package db_test
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("MaintenanceRepository", func() {
+	var (
+		repository db.MaintenanceRepository
+		thresholds db.MaintenanceThresholds
+	)
+
+	BeforeEach(func() {
+		thresholds = db.DefaultMaintenanceThresholds
+	})
+
+	JustBeforeEach(func() {
+		repository = db.NewMaintenanceRepository(dbConn, thresholds)
+	})
+
+	table := func(maintenance atc.DBMaintenance, name string) atc.DBTableHealth {
+		for _, t := range maintenance.Tables {
+			if t.Name == name {
+				return t
+			}
+		}
+
+		Fail("table not found: " + name)
+		return atc.DBTableHealth{}
+	}
+
+	It("describes each of the maintained tables", func() {
+		maintenance, err := repository.Maintenance()
+		Expect(err).ToNot(HaveOccurred())
+
+		var names []string
+		for _, t := range maintenance.Tables {
+			names = append(names, t.Name)
+		}
+		Expect(names).To(Equal(db.MaintainedTables))
+
+		Expect(table(maintenance, "builds").Indexes).To(ContainElement(HaveField("Name", "builds_pkey")))
+	})
+
+	It("counts the partitions of the build events", func() {
+		maintenance, err := repository.Maintenance()
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(table(maintenance, "build_events").Partitions).To(BeNumerically(">=", 1))
+	})
+
+	Context("when a table has enough dead tuples", func() {
+		BeforeEach(func() {
+			thresholds.MinDeadTuples = 1
+			thresholds.DeadTupleRatio = 0
+
+			for i := 0; i < 10; i++ {
+				_, err := defaultTeam.CreateOneOffBuild()
+				Expect(err).ToNot(HaveOccurred())
+			}
+
+			_, err := dbConn.Exec(`DELETE FROM builds WHERE team_id = $1`, defaultTeam.ID())
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("advises vacuuming it once the statistics catch up", func() {
+			Eventually(func() []atc.DBMaintenanceAdvice {
+				maintenance, err := repository.Maintenance()
+				Expect(err).ToNot(HaveOccurred())
+				return maintenance.Advice
+			}, "10s").Should(ContainElement(SatisfyAll(
+				HaveField("Action", atc.DBMaintenanceVacuum),
+				HaveField("Relation", "builds"),
+			)))
+		})
+	})
+
+	It("vacuums tables", func() {
+		Expect(repository.Vacuum("builds")).To(Succeed())
+	})
+
+	It("rebuilds indexes, or says why it can't", func() {
+		var version int
+		err := dbConn.QueryRow(`SELECT current_setting('server_version_num')::int`).Scan(&version)
+		Expect(err).ToNot(HaveOccurred())
+
+		err = repository.Reindex("builds_pkey")
+		if version >= 120000 {
+			Expect(err).ToNot(HaveOccurred())
+		} else {
+			Expect(err).To(MatchError(ContainSubstring("PostgreSQL 12")))
+		}
+	})
+})
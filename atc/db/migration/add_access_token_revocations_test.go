@@ -0,0 +1,67 @@
+package migration_test
+
+import (
+	"database/sql"
+
+	"github.com/concourse/concourse/atc/db/migration/migrationtest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Add access token revocations", func() {
+	const preMigrationVersion = 1795167739
+	const postMigrationVersion = 1795254139
+
+	var (
+		harness *migrationtest.Harness
+		db      *sql.DB
+	)
+
+	BeforeEach(func() {
+		harness = migrationtest.NewHarness(postgresRunner.DataSourceName(), preMigrationVersion, postMigrationVersion)
+
+		db = harness.Open(migrationtest.Exec(`
+			INSERT INTO access_tokens (token, sub, expires_at, claims) VALUES
+				('some-token', 'some-sub', now() + interval '1 day', '{}'),
+				('other-token', 'some-sub', now() + interval '1 day', '{}')
+		`))
+
+		harness.Up()
+	})
+
+	AfterEach(func() {
+		harness.Close()
+	})
+
+	It("gives existing tokens distinct ids", func() {
+		Expect(migrationtest.Rows(db, `SELECT count(DISTINCT id) FROM access_tokens`)).To(Equal([][]interface{}{
+			{int64(2)},
+		}))
+	})
+
+	It("records a token's revocation once", func() {
+		_, err := db.Exec(`INSERT INTO access_token_revocations (token, sub, revoked_by) VALUES ('some-token', 'some-sub', 'some-admin')`)
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = db.Exec(`INSERT INTO access_token_revocations (token, sub, revoked_by) VALUES ('some-token', 'some-sub', 'some-admin')`)
+		Expect(err).To(HaveOccurred())
+	})
+
+	Context("when rolled back", func() {
+		BeforeEach(func() {
+			harness.Down()
+		})
+
+		It("drops the revocations and the tokens' ids", func() {
+			Expect(migrationtest.Rows(db, `SELECT to_regclass('access_token_revocations')::text`)).To(Equal([][]interface{}{
+				{nil},
+			}))
+
+			Expect(migrationtest.Rows(db, `
+				SELECT count(*) FROM information_schema.columns WHERE table_name = 'access_tokens' AND column_name IN ('id', 'created_at')
+			`)).To(Equal([][]interface{}{
+				{int64(0)},
+			}))
+		})
+	})
+})
@@ -0,0 +1,57 @@
+package migration_test
+
+import (
+	"database/sql"
+
+	"github.com/concourse/concourse/atc/db/migration/migrationtest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Add failure reason to builds", func() {
+	const preMigrationVersion = 1794303739
+	const postMigrationVersion = 1794390139
+
+	var (
+		harness *migrationtest.Harness
+		db      *sql.DB
+	)
+
+	BeforeEach(func() {
+		harness = migrationtest.NewHarness(postgresRunner.DataSourceName(), preMigrationVersion, postMigrationVersion)
+
+		db = harness.Open(
+			migrationtest.Exec(`INSERT INTO teams (name, auth) VALUES ('some-team', '{}')`),
+			migrationtest.Exec(`INSERT INTO builds (name, status, team_id) VALUES ('1', 'failed', 1), ('2', 'succeeded', 1)`),
+		)
+
+		harness.Up()
+	})
+
+	AfterEach(func() {
+		harness.Close()
+	})
+
+	It("keeps the builds without giving them a reason", func() {
+		Expect(migrationtest.Rows(db, `SELECT name, status, failure_reason FROM builds ORDER BY id`)).To(Equal([][]interface{}{
+			{"1", "failed", nil},
+			{"2", "succeeded", nil},
+		}))
+	})
+
+	Context("when rolled back", func() {
+		BeforeEach(func() {
+			_, err := db.Exec(`UPDATE builds SET failure_reason = 'task-failed' WHERE name = '1'`)
+			Expect(err).ToNot(HaveOccurred())
+
+			harness.Down()
+		})
+
+		It("keeps the builds", func() {
+			Expect(migrationtest.Rows(db, `SELECT name, status FROM builds ORDER BY id`)).To(Equal([][]interface{}{
+				{"1", "failed"},
+				{"2", "succeeded"},
+			}))
+		})
+	})
+})
@@ -0,0 +1,65 @@
+package migration_test
+
+import (
+	"database/sql"
+
+	"github.com/concourse/concourse/atc/db/migration/migrationtest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Add user claims and preferences", func() {
+	const preMigrationVersion = 1795081339
+	const postMigrationVersion = 1795167739
+
+	var (
+		harness *migrationtest.Harness
+		db      *sql.DB
+	)
+
+	BeforeEach(func() {
+		harness = migrationtest.NewHarness(postgresRunner.DataSourceName(), preMigrationVersion, postMigrationVersion)
+
+		db = harness.Open(migrationtest.Exec(`INSERT INTO users (sub, username, connector) VALUES ('some-sub', 'some-user', 'github')`))
+
+		harness.Up()
+	})
+
+	AfterEach(func() {
+		harness.Close()
+	})
+
+	It("leaves existing users without claims", func() {
+		Expect(migrationtest.Rows(db, `SELECT sub, claims FROM users`)).To(Equal([][]interface{}{
+			{"some-sub", nil},
+		}))
+	})
+
+	It("keeps one value per user and key", func() {
+		_, err := db.Exec(`
+			INSERT INTO user_preferences (user_sub, key, value) VALUES ('some-sub', 'theme', '"dark"'), ('other-sub', 'theme', '"light"')
+		`)
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = db.Exec(`INSERT INTO user_preferences (user_sub, key, value) VALUES ('some-sub', 'theme', '"light"')`)
+		Expect(err).To(HaveOccurred())
+	})
+
+	Context("when rolled back", func() {
+		BeforeEach(func() {
+			harness.Down()
+		})
+
+		It("drops the claims and preferences", func() {
+			Expect(migrationtest.Rows(db, `SELECT to_regclass('user_preferences')::text`)).To(Equal([][]interface{}{
+				{nil},
+			}))
+
+			Expect(migrationtest.Rows(db, `
+				SELECT count(*) FROM information_schema.columns WHERE table_name = 'users' AND column_name = 'claims'
+			`)).To(Equal([][]interface{}{
+				{int64(0)},
+			}))
+		})
+	})
+})
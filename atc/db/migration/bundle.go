@@ -0,0 +1,95 @@
+package migration
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const createMigrationsHistory = "CREATE TABLE IF NOT EXISTS migrations_history (version bigint, tstamp timestamp with time zone, direction varchar, status varchar, dirty boolean)"
+
+// GoMigrationError is returned when a bundle would have to include a Go
+// migration. Go migrations rewrite data in ways which depend on the data and
+// the encryption key, so they can't be rendered as SQL.
+type GoMigrationError struct {
+	Version int
+}
+
+func (err GoMigrationError) Error() string {
+	return fmt.Sprintf("migration %d is written in Go and can't be rendered as SQL; migrate past it with `concourse migrate` first", err.Version)
+}
+
+// Bundle renders the up migrations from the current version of the database
+// to toVersion as SQL, for a DBA to review and apply in place of the ATC.
+// Each migration is applied in a transaction of its own which records it in
+// the migrations history, just as the ATC would, so that the ATC adopts the
+// resulting version when it starts.
+func (helper *migrator) Bundle(toVersion int) (string, error) {
+	currentVersion, err := helper.bundleFromVersion()
+	if err != nil {
+		return "", err
+	}
+
+	migrations, err := helper.Migrations()
+	if err != nil {
+		return "", err
+	}
+
+	if currentVersion > toVersion {
+		return "", fmt.Errorf("database is at version %d, which is past %d; only migrating up can be bundled", currentVersion, toVersion)
+	}
+
+	var bundle strings.Builder
+	fmt.Fprintf(&bundle, "-- Concourse database migrations from version %d to %d.\n", currentVersion, toVersion)
+	fmt.Fprintln(&bundle, "--")
+	fmt.Fprintln(&bundle, "-- Apply with a user allowed to change the schema, stopping at the first")
+	fmt.Fprintln(&bundle, "-- error, e.g. `psql -v ON_ERROR_STOP=1 -f <this file>`. Each migration")
+	fmt.Fprintln(&bundle, "-- runs in a transaction of its own, so a failed one can be fixed and the")
+	fmt.Fprintln(&bundle, "-- rest of the bundle applied again from there.")
+	fmt.Fprintln(&bundle)
+	fmt.Fprintf(&bundle, "%s;\n", createMigrationsHistory)
+
+	for _, m := range migrations {
+		if m.Direction != "up" || m.Version <= currentVersion || m.Version > toVersion {
+			continue
+		}
+
+		if m.Strategy == GoMigration {
+			return "", GoMigrationError{Version: m.Version}
+		}
+
+		fmt.Fprintln(&bundle)
+		fmt.Fprintf(&bundle, "-- %s\n", m.Name)
+		for _, table := range m.HeavyTables {
+			fmt.Fprintf(&bundle, "-- rewrites %s, which may take a long time\n", table)
+		}
+		fmt.Fprintln(&bundle, "BEGIN;")
+		fmt.Fprintln(&bundle, strings.TrimSpace(m.Statements))
+		fmt.Fprintf(&bundle, "INSERT INTO migrations_history (version, tstamp, direction, status, dirty) VALUES (%d, current_timestamp, 'up', 'passed', false);\n", m.Version)
+		fmt.Fprintln(&bundle, "COMMIT;")
+	}
+
+	return bundle.String(), nil
+}
+
+func (helper *migrator) bundleFromVersion() (int, error) {
+	historyExists, err := checkTableExist(helper.db, "migrations_history")
+	if err != nil {
+		return 0, err
+	}
+
+	if historyExists {
+		return helper.CurrentVersion()
+	}
+
+	legacyExists, err := checkTableExist(helper.db, "schema_migrations")
+	if err != nil {
+		return 0, err
+	}
+
+	if legacyExists {
+		return 0, errors.New("database still tracks its version in schema_migrations; migrate it with `concourse migrate` first")
+	}
+
+	return 0, nil
+}
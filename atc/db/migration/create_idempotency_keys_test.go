@@ -0,0 +1,63 @@
+package migration_test
+
+import (
+	"database/sql"
+
+	"github.com/concourse/concourse/atc/db/migration/migrationtest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Create idempotency keys", func() {
+	const preMigrationVersion = 1794822139
+	const postMigrationVersion = 1794908539
+
+	var (
+		harness *migrationtest.Harness
+		db      *sql.DB
+	)
+
+	BeforeEach(func() {
+		harness = migrationtest.NewHarness(postgresRunner.DataSourceName(), preMigrationVersion, postMigrationVersion)
+
+		db = harness.Open()
+
+		harness.Up()
+	})
+
+	AfterEach(func() {
+		harness.Close()
+	})
+
+	It("keeps a key per team and route", func() {
+		_, err := db.Exec(`
+			INSERT INTO idempotency_keys (team_name, route, key, request_hash) VALUES ('some-team', 'SaveConfig', 'some-key', 'some-hash');
+			INSERT INTO idempotency_keys (team_name, route, key, request_hash) VALUES ('some-team', 'CreateJobBuild', 'some-key', 'some-hash');
+		`)
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = db.Exec(`INSERT INTO idempotency_keys (team_name, route, key, request_hash) VALUES ('some-team', 'SaveConfig', 'some-key', 'other-hash')`)
+		Expect(err).To(HaveOccurred())
+
+		Expect(migrationtest.Rows(db, `
+			SELECT route, status, content_type
+			FROM idempotency_keys
+			ORDER BY route
+		`)).To(Equal([][]interface{}{
+			{"CreateJobBuild", nil, ""},
+			{"SaveConfig", nil, ""},
+		}))
+	})
+
+	Context("when rolled back", func() {
+		BeforeEach(func() {
+			harness.Down()
+		})
+
+		It("drops the keys", func() {
+			Expect(migrationtest.Rows(db, `SELECT to_regclass('idempotency_keys')::text`)).To(Equal([][]interface{}{
+				{nil},
+			}))
+		})
+	})
+})
@@ -0,0 +1,76 @@
+package migration_test
+
+import (
+	"database/sql"
+
+	"github.com/concourse/concourse/atc/db/migration/migrationtest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Create job duration estimates", func() {
+	const preMigrationVersion = 1794735739
+	const postMigrationVersion = 1794822139
+
+	var (
+		harness *migrationtest.Harness
+		db      *sql.DB
+	)
+
+	BeforeEach(func() {
+		harness = migrationtest.NewHarness(postgresRunner.DataSourceName(), preMigrationVersion, postMigrationVersion)
+
+		db = harness.Open(
+			migrationtest.Exec(`INSERT INTO teams (name, auth) VALUES ('some-team', '{}')`),
+			migrationtest.Exec(`INSERT INTO pipelines (name, team_id) VALUES ('some-pipeline', 1)`),
+			migrationtest.Exec(`INSERT INTO jobs (name, pipeline_id, config) VALUES ('some-job', 1, '{}')`),
+		)
+
+		harness.Up()
+	})
+
+	AfterEach(func() {
+		harness.Close()
+	})
+
+	It("keeps an estimate of the job and of each of its steps", func() {
+		_, err := db.Exec(`
+			INSERT INTO job_duration_estimates (job_id, duration) VALUES (1, 60);
+			INSERT INTO job_duration_estimates (job_id, step_name, duration) VALUES (1, 'unit', 45);
+		`)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(migrationtest.Rows(db, `
+			SELECT step_name, duration, samples
+			FROM job_duration_estimates
+			ORDER BY step_name
+		`)).To(Equal([][]interface{}{
+			{"", float64(60), int64(1)},
+			{"unit", float64(45), int64(1)},
+		}))
+	})
+
+	It("drops the estimates along with the job", func() {
+		_, err := db.Exec(`INSERT INTO job_duration_estimates (job_id, duration) VALUES (1, 60)`)
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = db.Exec(`DELETE FROM jobs`)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(migrationtest.Rows(db, `SELECT count(*) FROM job_duration_estimates`)).To(Equal([][]interface{}{
+			{int64(0)},
+		}))
+	})
+
+	Context("when rolled back", func() {
+		BeforeEach(func() {
+			harness.Down()
+		})
+
+		It("drops the estimates", func() {
+			Expect(migrationtest.Rows(db, `SELECT to_regclass('job_duration_estimates')::text`)).To(Equal([][]interface{}{
+				{nil},
+			}))
+		})
+	})
+})
@@ -0,0 +1,49 @@
+package migration_test
+
+import (
+	"database/sql"
+
+	"github.com/concourse/concourse/atc/db/migration/migrationtest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Create login failures", func() {
+	const preMigrationVersion = 1795254139
+	const postMigrationVersion = 1795340539
+
+	var (
+		harness *migrationtest.Harness
+		db      *sql.DB
+	)
+
+	BeforeEach(func() {
+		harness = migrationtest.NewHarness(postgresRunner.DataSourceName(), preMigrationVersion, postMigrationVersion)
+		db = harness.Open()
+		harness.Up()
+	})
+
+	AfterEach(func() {
+		harness.Close()
+	})
+
+	It("keeps one count per user", func() {
+		_, err := db.Exec(`INSERT INTO login_failures (username, failures) VALUES ('some-user', 1)`)
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = db.Exec(`INSERT INTO login_failures (username, failures) VALUES ('some-user', 2)`)
+		Expect(err).To(HaveOccurred())
+	})
+
+	Context("when rolled back", func() {
+		BeforeEach(func() {
+			harness.Down()
+		})
+
+		It("drops the table", func() {
+			Expect(migrationtest.Rows(db, `SELECT to_regclass('login_failures')::text`)).To(Equal([][]interface{}{
+				{nil},
+			}))
+		})
+	})
+})
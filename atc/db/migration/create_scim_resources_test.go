@@ -0,0 +1,65 @@
+package migration_test
+
+import (
+	"database/sql"
+
+	"github.com/concourse/concourse/atc/db/migration/migrationtest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Create SCIM resources", func() {
+	const preMigrationVersion = 1795340539
+	const postMigrationVersion = 1795426939
+
+	var (
+		harness *migrationtest.Harness
+		db      *sql.DB
+	)
+
+	BeforeEach(func() {
+		harness = migrationtest.NewHarness(postgresRunner.DataSourceName(), preMigrationVersion, postMigrationVersion)
+		db = harness.Open()
+		harness.Up()
+	})
+
+	AfterEach(func() {
+		harness.Close()
+	})
+
+	It("does not allow usernames which differ only in case", func() {
+		_, err := db.Exec(`INSERT INTO scim_users (id, user_name) VALUES ('1', 'some-user')`)
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = db.Exec(`INSERT INTO scim_users (id, user_name) VALUES ('2', 'Some-User')`)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("removes a deleted user from their groups", func() {
+		_, err := db.Exec(`
+			INSERT INTO scim_users (id, user_name) VALUES ('some-user-id', 'some-user');
+			INSERT INTO scim_groups (id, display_name) VALUES ('some-group-id', 'some-group');
+			INSERT INTO scim_group_members (group_id, user_id) VALUES ('some-group-id', 'some-user-id');
+			DELETE FROM scim_users;
+		`)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(migrationtest.Rows(db, `SELECT count(*) FROM scim_group_members`)).To(Equal([][]interface{}{
+			{int64(0)},
+		}))
+	})
+
+	Context("when rolled back", func() {
+		BeforeEach(func() {
+			harness.Down()
+		})
+
+		It("drops the tables", func() {
+			Expect(migrationtest.Rows(db, `
+				SELECT to_regclass('scim_users')::text, to_regclass('scim_groups')::text, to_regclass('scim_group_members')::text
+			`)).To(Equal([][]interface{}{
+				{nil, nil, nil},
+			}))
+		})
+	})
+})
@@ -0,0 +1,63 @@
+package migration_test
+
+import (
+	"database/sql"
+
+	"github.com/concourse/concourse/atc/db/migration/migrationtest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Create team requests", func() {
+	const preMigrationVersion = 1794908539
+	const postMigrationVersion = 1794994939
+
+	var (
+		harness *migrationtest.Harness
+		db      *sql.DB
+	)
+
+	BeforeEach(func() {
+		harness = migrationtest.NewHarness(postgresRunner.DataSourceName(), preMigrationVersion, postMigrationVersion)
+
+		db = harness.Open()
+
+		harness.Up()
+	})
+
+	AfterEach(func() {
+		harness.Close()
+	})
+
+	It("allows one pending request per team name", func() {
+		_, err := db.Exec(`
+			INSERT INTO team_requests (team_name, auth, requested_by, status) VALUES ('some-team', '{}', 'some-user', 'rejected');
+			INSERT INTO team_requests (team_name, auth, requested_by) VALUES ('some-team', '{}', 'some-user');
+		`)
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = db.Exec(`INSERT INTO team_requests (team_name, auth, requested_by) VALUES ('some-team', '{}', 'other-user')`)
+		Expect(err).To(HaveOccurred())
+
+		Expect(migrationtest.Rows(db, `
+			SELECT team_name, status, reason, comment
+			FROM team_requests
+			ORDER BY id
+		`)).To(Equal([][]interface{}{
+			{"some-team", "rejected", "", ""},
+			{"some-team", "pending", "", ""},
+		}))
+	})
+
+	Context("when rolled back", func() {
+		BeforeEach(func() {
+			harness.Down()
+		})
+
+		It("drops the requests", func() {
+			Expect(migrationtest.Rows(db, `SELECT to_regclass('team_requests')::text`)).To(Equal([][]interface{}{
+				{nil},
+			}))
+		})
+	})
+})
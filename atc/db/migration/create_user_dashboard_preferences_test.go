@@ -0,0 +1,64 @@
+package migration_test
+
+import (
+	"database/sql"
+
+	"github.com/concourse/concourse/atc/db/migration/migrationtest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Create user dashboard preferences", func() {
+	const preMigrationVersion = 1794994939
+	const postMigrationVersion = 1795081339
+
+	var (
+		harness *migrationtest.Harness
+		db      *sql.DB
+	)
+
+	BeforeEach(func() {
+		harness = migrationtest.NewHarness(postgresRunner.DataSourceName(), preMigrationVersion, postMigrationVersion)
+
+		db = harness.Open()
+
+		harness.Up()
+	})
+
+	AfterEach(func() {
+		harness.Close()
+	})
+
+	It("removes a pipeline from every user's preferences along with it", func() {
+		_, err := db.Exec(`
+			INSERT INTO teams (id, name) VALUES (1, 'some-team');
+			INSERT INTO pipelines (id, name, team_id) VALUES (1, 'some-pipeline', 1), (2, 'other-pipeline', 1);
+			INSERT INTO user_pipeline_orderings (user_sub, pipeline_id, ordering) VALUES ('some-sub', 2, 1), ('some-sub', 1, 2);
+			INSERT INTO user_favorite_pipelines (user_sub, pipeline_id) VALUES ('some-sub', 1), ('other-sub', 1);
+			DELETE FROM pipelines WHERE id = 1;
+		`)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(migrationtest.Rows(db, `SELECT user_sub, pipeline_id FROM user_pipeline_orderings`)).To(Equal([][]interface{}{
+			{"some-sub", int64(2)},
+		}))
+
+		Expect(migrationtest.Rows(db, `SELECT count(*) FROM user_favorite_pipelines`)).To(Equal([][]interface{}{
+			{int64(0)},
+		}))
+	})
+
+	Context("when rolled back", func() {
+		BeforeEach(func() {
+			harness.Down()
+		})
+
+		It("drops the tables", func() {
+			Expect(migrationtest.Rows(db, `
+				SELECT to_regclass('user_pipeline_orderings')::text, to_regclass('user_favorite_pipelines')::text
+			`)).To(Equal([][]interface{}{
+				{nil, nil},
+			}))
+		})
+	})
+})
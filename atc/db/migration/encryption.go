@@ -17,6 +17,10 @@ var encryptedColumns = []encryptedColumn{
 	{"builds", "private_plan", "id"},
 	{"cert_cache", "cert", "domain"},
 	{"pipelines", "var_sources", "id"},
+	{"pipeline_config_versions", "config", "id"},
+	{"team_notifiers", "config", "id"},
+	{"outgoing_webhooks", "secret", "id"},
+	{"log_drains", "url", "id"},
 }
 
 type encryptedColumn struct {
@@ -0,0 +1,57 @@
+package migration
+
+import (
+	"fmt"
+
+	"github.com/lib/pq"
+)
+
+// grantRuntimePrivileges grants the role the ATC runs its queries as what it
+// needs on the schema, for when that role doesn't own the schema. Anything
+// the migrating role creates later on, such as the build events partitions,
+// is granted by default.
+func (helper *migrator) grantRuntimePrivileges(role string) error {
+	var schema string
+	err := helper.db.QueryRow("SELECT current_schema()").Scan(&schema)
+	if err != nil {
+		return err
+	}
+
+	schema = pq.QuoteIdentifier(schema)
+	role = pq.QuoteIdentifier(role)
+
+	privileges := []struct {
+		objects    string
+		privileges string
+	}{
+		{"TABLES", "SELECT, INSERT, UPDATE, DELETE, TRUNCATE, REFERENCES, TRIGGER"},
+		{"SEQUENCES", "USAGE, SELECT, UPDATE"},
+		{"FUNCTIONS", "EXECUTE"},
+	}
+
+	tx, err := helper.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	_, err = tx.Exec(fmt.Sprintf("GRANT USAGE ON SCHEMA %s TO %s", schema, role))
+	if err != nil {
+		return err
+	}
+
+	for _, p := range privileges {
+		_, err = tx.Exec(fmt.Sprintf("GRANT %s ON ALL %s IN SCHEMA %s TO %s", p.privileges, p.objects, schema, role))
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(fmt.Sprintf("ALTER DEFAULT PRIVILEGES IN SCHEMA %s GRANT %s ON %s TO %s", schema, p.privileges, p.objects, role))
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,102 @@
+package migration
+
+import (
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// heavyDirective marks a migration as heavy, listing the tables it rewrites,
+// e.g. "-- heavy: builds, containers" in a SQL migration, or the same after
+// "//" in a Go migration.
+var heavyDirective = regexp.MustCompile(`(?m)^\s*(?:--|//)\s*heavy:\s*(.+?)\s*$`)
+
+func heavyTables(migrationContents string) []string {
+	var tables []string
+	for _, match := range heavyDirective.FindAllStringSubmatch(migrationContents, -1) {
+		for _, table := range strings.Split(match[1], ",") {
+			table = strings.TrimSpace(table)
+			if table != "" {
+				tables = append(tables, table)
+			}
+		}
+	}
+
+	return tables
+}
+
+// HeavyMigrationLimits are how large the tables a heavy migration rewrites
+// may be estimated to be before the migration refuses to run unless it is
+// confirmed, as rewriting large tables can take hours. A limit of zero means
+// no limit.
+type HeavyMigrationLimits struct {
+	MaxRows   int64 `long:"heavy-migration-max-rows" default:"10000000" description:"Estimated number of rows in the tables a heavy migration rewrites above which it is not run without --confirm-heavy-migrations. 0 means no limit."`
+	MaxSizeMB int64 `long:"heavy-migration-max-size" default:"10240" description:"Estimated size, in megabytes, of the tables a heavy migration rewrites above which it is not run without --confirm-heavy-migrations. 0 means no limit."`
+	Confirm   bool  `long:"confirm-heavy-migrations" description:"Run heavy migrations even if the tables they rewrite are estimated to be larger than the limits."`
+}
+
+func (limits HeavyMigrationLimits) exceededBy(estimate HeavyMigrationEstimate) bool {
+	if limits.MaxRows > 0 && estimate.Rows > limits.MaxRows {
+		return true
+	}
+
+	if limits.MaxSizeMB > 0 && estimate.Bytes > limits.MaxSizeMB*1024*1024 {
+		return true
+	}
+
+	return false
+}
+
+// HeavyMigrationEstimate is how large a table a heavy migration rewrites is
+// estimated to be, going by the statistics Postgres keeps.
+type HeavyMigrationEstimate struct {
+	Version int
+	Table   string
+	Rows    int64
+	Bytes   int64
+}
+
+func (estimate HeavyMigrationEstimate) String() string {
+	return fmt.Sprintf(
+		"migration %d rewrites %s, estimated at %d rows and %d MB",
+		estimate.Version,
+		estimate.Table,
+		estimate.Rows,
+		estimate.Bytes/1024/1024,
+	)
+}
+
+// HeavyMigrationsError is returned when heavy migrations are pending whose
+// tables are estimated to be larger than the limits, and they have not been
+// confirmed.
+type HeavyMigrationsError struct {
+	Estimates []HeavyMigrationEstimate
+}
+
+func (err HeavyMigrationsError) Error() string {
+	lines := []string{}
+	for _, estimate := range err.Estimates {
+		lines = append(lines, estimate.String())
+	}
+
+	return fmt.Sprintf(
+		"refusing to run heavy migrations which may take a long time (%s); plan for the downtime and pass --confirm-heavy-migrations to run them",
+		strings.Join(lines, "; "),
+	)
+}
+
+func estimateTable(db *sql.DB, table string) (int64, int64, error) {
+	var rows, bytes int64
+	err := db.QueryRow(`
+		SELECT COALESCE(SUM(GREATEST(c.reltuples, 0)), 0)::bigint, COALESCE(SUM(pg_total_relation_size(c.oid)), 0)::bigint
+		FROM pg_class c
+		WHERE c.oid = to_regclass($1)
+		OR c.oid IN (SELECT inhrelid FROM pg_inherits WHERE inhparent = to_regclass($1))
+	`, table).Scan(&rows, &bytes)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return rows, bytes, nil
+}
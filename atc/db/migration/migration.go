@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/db/dialect"
 	"github.com/concourse/concourse/atc/db/encryption"
 	"github.com/concourse/concourse/atc/db/lock"
 	"github.com/concourse/concourse/atc/db/migration/migrations"
@@ -19,11 +20,11 @@ import (
 
 func NewOpenHelper(driver, name string, lockFactory lock.LockFactory, newKey *encryption.Key, oldKey *encryption.Key) *OpenHelper {
 	return &OpenHelper{
-		driver,
-		name,
-		lockFactory,
-		newKey,
-		oldKey,
+		driver:         driver,
+		dataSourceName: name,
+		lockFactory:    lockFactory,
+		newKey:         newKey,
+		oldKey:         oldKey,
 	}
 }
 
@@ -33,6 +34,52 @@ type OpenHelper struct {
 	lockFactory    lock.LockFactory
 	newKey         *encryption.Key
 	oldKey         *encryption.Key
+	heavyLimits    HeavyMigrationLimits
+
+	migrationDataSourceName string
+	runtimeRole             string
+	dialect                 dialect.Dialect
+}
+
+// WithMigrationDataSourceName makes the helper run the migrations through a
+// connection of their own, e.g. as a user allowed to change the schema, and
+// grant the user of the helper's connection what it needs to run queries.
+func (helper *OpenHelper) WithMigrationDataSourceName(name string) *OpenHelper {
+	helper.migrationDataSourceName = name
+	return helper
+}
+
+// WithRuntimeRole makes the helper grant the role what it needs to run
+// queries after migrating.
+func (helper *OpenHelper) WithRuntimeRole(role string) *OpenHelper {
+	helper.runtimeRole = role
+	return helper
+}
+
+// WithDialect makes the helper run the migrations against a database other
+// than PostgreSQL.
+func (helper *OpenHelper) WithDialect(d dialect.Dialect) *OpenHelper {
+	helper.dialect = d
+	return helper
+}
+
+// WithHeavyMigrationLimits makes the helper refuse to run heavy migrations
+// whose tables are estimated to be larger than the limits.
+func (helper *OpenHelper) WithHeavyMigrationLimits(limits HeavyMigrationLimits) *OpenHelper {
+	helper.heavyLimits = limits
+	return helper
+}
+
+func (helper *OpenHelper) migrator(db *sql.DB) Migrator {
+	m := NewMigrator(db, helper.lockFactory).
+		WithHeavyMigrationLimits(helper.heavyLimits).
+		WithRuntimeRole(helper.runtimeRole)
+
+	if helper.dialect != nil {
+		m = m.WithDialect(helper.dialect)
+	}
+
+	return m
 }
 
 func (helper *OpenHelper) CurrentVersion() (int, error) {
@@ -43,7 +90,7 @@ func (helper *OpenHelper) CurrentVersion() (int, error) {
 
 	defer db.Close()
 
-	return NewMigrator(db, helper.lockFactory).CurrentVersion()
+	return helper.migrator(db).CurrentVersion()
 }
 
 func (helper *OpenHelper) SupportedVersion() (int, error) {
@@ -54,7 +101,18 @@ func (helper *OpenHelper) SupportedVersion() (int, error) {
 
 	defer db.Close()
 
-	return NewMigrator(db, helper.lockFactory).SupportedVersion()
+	return helper.migrator(db).SupportedVersion()
+}
+
+func (helper *OpenHelper) Bundle(toVersion int) (string, error) {
+	db, err := sql.Open(helper.driver, helper.dataSourceName)
+	if err != nil {
+		return "", err
+	}
+
+	defer db.Close()
+
+	return helper.migrator(db).Bundle(toVersion)
 }
 
 func (helper *OpenHelper) Open() (*sql.DB, error) {
@@ -63,7 +121,12 @@ func (helper *OpenHelper) Open() (*sql.DB, error) {
 		return nil, err
 	}
 
-	if err := NewMigrator(db, helper.lockFactory).Up(helper.newKey, helper.oldKey); err != nil {
+	if helper.migrationDataSourceName != "" {
+		err = helper.migrateSeparately(db)
+	} else {
+		err = helper.migrator(db).Up(helper.newKey, helper.oldKey)
+	}
+	if err != nil {
 		_ = db.Close()
 		return nil, err
 	}
@@ -71,13 +134,34 @@ func (helper *OpenHelper) Open() (*sql.DB, error) {
 	return db, nil
 }
 
+func (helper *OpenHelper) migrateSeparately(runtimeDB *sql.DB) error {
+	role := helper.runtimeRole
+	if role == "" {
+		err := runtimeDB.QueryRow("SELECT current_user").Scan(&role)
+		if err != nil {
+			return err
+		}
+	}
+
+	migrationDB, err := sql.Open(helper.driver, helper.migrationDataSourceName)
+	if err != nil {
+		return err
+	}
+
+	defer migrationDB.Close()
+
+	return helper.migrator(migrationDB).
+		WithRuntimeRole(role).
+		Up(helper.newKey, helper.oldKey)
+}
+
 func (helper *OpenHelper) OpenAtVersion(version int) (*sql.DB, error) {
 	db, err := sql.Open(helper.driver, helper.dataSourceName)
 	if err != nil {
 		return nil, err
 	}
 
-	if err := NewMigrator(db, helper.lockFactory).Migrate(helper.newKey, helper.oldKey, version); err != nil {
+	if err := helper.migrator(db).Migrate(helper.newKey, helper.oldKey, version); err != nil {
 		_ = db.Close()
 		return nil, err
 	}
@@ -92,7 +176,7 @@ func (helper *OpenHelper) MigrateToVersion(version int) error {
 	}
 
 	defer db.Close()
-	m := NewMigrator(db, helper.lockFactory)
+	m := helper.migrator(db)
 
 	err = helper.migrateFromMigrationVersion(db)
 	if err != nil {
@@ -149,6 +233,10 @@ type Migrator interface {
 	Migrate(newKey, oldKey *encryption.Key, version int) error
 	Up(newKey, oldKey *encryption.Key) error
 	Migrations() ([]migration, error)
+	WithHeavyMigrationLimits(HeavyMigrationLimits) Migrator
+	WithRuntimeRole(role string) Migrator
+	WithDialect(dialect.Dialect) Migrator
+	Bundle(toVersion int) (string, error)
 }
 
 //go:embed migrations
@@ -166,10 +254,11 @@ func NewMigrator(db *sql.DB, lockFactory lock.LockFactory) Migrator {
 
 func NewMigratorForMigrations(db *sql.DB, lockFactory lock.LockFactory, migrationsFS fs.FS) Migrator {
 	return &migrator{
-		db,
-		lockFactory,
-		lager.NewLogger("migrations"),
-		migrationsFS,
+		db:           db,
+		lockFactory:  lockFactory,
+		logger:       lager.NewLogger("migrations"),
+		migrationsFS: migrationsFS,
+		dialect:      dialect.Postgres,
 	}
 }
 
@@ -178,6 +267,31 @@ type migrator struct {
 	lockFactory  lock.LockFactory
 	logger       lager.Logger
 	migrationsFS fs.FS
+	heavyLimits  HeavyMigrationLimits
+	runtimeRole  string
+	dialect      dialect.Dialect
+}
+
+func (helper *migrator) WithHeavyMigrationLimits(limits HeavyMigrationLimits) Migrator {
+	m := *helper
+	m.heavyLimits = limits
+	return &m
+}
+
+// WithDialect makes the migrator run the migrations against a database other
+// than PostgreSQL.
+func (helper *migrator) WithDialect(d dialect.Dialect) Migrator {
+	m := *helper
+	m.dialect = d
+	return &m
+}
+
+// WithRuntimeRole makes the migrator grant the role what it needs to run
+// queries after migrating, for when the role doesn't own the schema.
+func (helper *migrator) WithRuntimeRole(role string) Migrator {
+	m := *helper
+	m.runtimeRole = role
+	return &m
 }
 
 func (helper *migrator) Migrations() ([]migration, error) {
@@ -276,11 +390,20 @@ func (helper *migrator) Migrate(newKey, oldKey *encryption.Key, toVersion int) e
 		return err
 	}
 
-	_, err = helper.db.Exec("CREATE TABLE IF NOT EXISTS migrations_history (version bigint, tstamp timestamp with time zone, direction varchar, status varchar, dirty boolean)")
+	// the table may have been created by a DBA applying a bundle, in which
+	// case the ATC may well not be allowed to create tables
+	historyExists, err := checkTableExist(helper.db, "migrations_history")
 	if err != nil {
 		return err
 	}
 
+	if !historyExists {
+		_, err = helper.db.Exec(createMigrationsHistory)
+		if err != nil {
+			return err
+		}
+	}
+
 	if existingDBVersion > 0 {
 		var containsOldMigrationInfo bool
 		err = helper.db.QueryRow("SELECT EXISTS (SELECT 1 FROM migrations_history where version=$1)", existingDBVersion).Scan(&containsOldMigrationInfo)
@@ -307,6 +430,11 @@ func (helper *migrator) Migrate(newKey, oldKey *encryption.Key, toVersion int) e
 	}
 
 	if currentVersion <= toVersion {
+		err = helper.checkHeavyMigrations(migrations, currentVersion, toVersion)
+		if err != nil {
+			return err
+		}
+
 		for _, m := range migrations {
 			if currentVersion < m.Version && m.Version <= toVersion && m.Direction == "up" {
 				err = helper.runMigration(m, strategy)
@@ -349,6 +477,13 @@ func (helper *migrator) Migrate(newKey, oldKey *encryption.Key, toVersion int) e
 		}
 	}
 
+	if helper.runtimeRole != "" {
+		err = helper.grantRuntimePrivileges(helper.runtimeRole)
+		if err != nil {
+			return fmt.Errorf("grant privileges to %s: %w", helper.runtimeRole, err)
+		}
+	}
+
 	return nil
 }
 
@@ -360,11 +495,55 @@ const (
 )
 
 type migration struct {
-	Name       string
-	Version    int
-	Direction  string
-	Statements string
-	Strategy   Strategy
+	Name        string
+	Version     int
+	Direction   string
+	Statements  string
+	Strategy    Strategy
+	HeavyTables []string
+}
+
+// checkHeavyMigrations estimates how large the tables are which the pending
+// heavy migrations rewrite, and returns a HeavyMigrationsError if any of them
+// exceeds the limits without the migrations having been confirmed.
+func (helper *migrator) checkHeavyMigrations(migrations []migration, currentVersion, toVersion int) error {
+	var exceeded []HeavyMigrationEstimate
+	for _, m := range migrations {
+		if m.Direction != "up" || m.Version <= currentVersion || m.Version > toVersion {
+			continue
+		}
+
+		for _, table := range m.HeavyTables {
+			rows, bytes, err := estimateTable(helper.db, table)
+			if err != nil {
+				return fmt.Errorf("estimate size of %s: %w", table, err)
+			}
+
+			estimate := HeavyMigrationEstimate{
+				Version: m.Version,
+				Table:   table,
+				Rows:    rows,
+				Bytes:   bytes,
+			}
+
+			helper.logger.Info("heavy-migration-pending", lager.Data{
+				"version": estimate.Version,
+				"table":   estimate.Table,
+				"rows":    estimate.Rows,
+				"bytes":   estimate.Bytes,
+			})
+
+			if helper.heavyLimits.exceededBy(estimate) {
+				exceeded = append(exceeded, estimate)
+			}
+		}
+	}
+
+	if len(exceeded) > 0 && !helper.heavyLimits.Confirm {
+		return HeavyMigrationsError{Estimates: exceeded}
+	}
+
+	return nil
 }
 
 func (m *migrator) recordMigrationFailure(migration migration, migrationErr error, dirty bool) error {
@@ -379,20 +558,50 @@ func (m *migrator) recordMigrationFailure(migration migration, migrationErr erro
 	return migrationErr
 }
 
-func (m *migrator) runMigration(migration migration, strategy encryption.Strategy) (err error) {
-	tx, err := m.db.Begin()
-	if err != nil {
-		return err
+func (m *migrator) runMigration(migration migration, strategy encryption.Strategy) error {
+	if migration.Strategy == SQLMigration {
+		statements, err := m.dialect.TranslateDDL(migration.Statements)
+		if err != nil {
+			return m.recordMigrationFailure(
+				migration,
+				fmt.Errorf("migration '%s' can't be run: %w", migration.Name, err),
+				false,
+			)
+		}
+
+		migration.Statements = statements
+
+		if !m.dialect.TransactionalDDL() {
+			return m.runMigrationWithoutTransaction(migration)
+		}
 	}
 
-	defer func() {
+	for {
+		err := m.runMigrationInTransaction(migration, strategy)
+		if err != nil && m.dialect.ShouldRetry(err) {
+			continue
+		}
+
 		if err != nil {
-			err = m.recordMigrationFailure(
+			return m.recordMigrationFailure(
 				migration,
 				fmt.Errorf("migration '%s' failed and was rolled back: %w", migration.Name, err),
 				false,
 			)
+		}
+
+		return nil
+	}
+}
 
+func (m *migrator) runMigrationInTransaction(migration migration, strategy encryption.Strategy) (err error) {
+	tx, err := m.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
 			rbErr := tx.Rollback()
 			if rbErr != nil {
 				err = multierror.Append(err, fmt.Errorf("rollback failed: %w", rbErr))
@@ -421,6 +630,24 @@ func (m *migrator) runMigration(migration migration, strategy encryption.Strateg
 	return tx.Commit()
 }
 
+// runMigrationWithoutTransaction runs a SQL migration for a database which
+// can't change its schema within a transaction. A migration failing partway
+// through can't be rolled back, so it is recorded as having left the schema
+// dirty.
+func (m *migrator) runMigrationWithoutTransaction(migration migration) error {
+	_, err := m.db.Exec(migration.Statements)
+	if err != nil {
+		return m.recordMigrationFailure(
+			migration,
+			fmt.Errorf("migration '%s' failed and may have been applied in part: %w", migration.Name, err),
+			true,
+		)
+	}
+
+	_, err = m.db.Exec("INSERT INTO migrations_history (version, tstamp, direction, status, dirty) VALUES ($1, current_timestamp, $2, 'passed', false)", migration.Version, migration.Direction)
+	return err
+}
+
 func (helper *migrator) Up(newKey, oldKey *encryption.Key) error {
 	migrations, err := helper.Migrations()
 	if err != nil {
@@ -2,6 +2,8 @@ package migration_test
 
 import (
 	"database/sql"
+	"errors"
+	"fmt"
 	"io/fs"
 	"io/ioutil"
 	"math/rand"
@@ -114,6 +116,58 @@ var _ = Describe("Migration", func() {
 		})
 	})
 
+	Context("with a user of its own for the migrations", func() {
+		var runtimeDSN string
+
+		BeforeEach(func() {
+			_, err := db.Exec(`
+				CREATE ROLE some_runtime_user LOGIN;
+				REVOKE CREATE ON SCHEMA public FROM PUBLIC;
+			`)
+			Expect(err).NotTo(HaveOccurred())
+
+			runtimeDSN = fmt.Sprintf("host=/tmp user=some_runtime_user dbname=testdb sslmode=disable port=%d", postgresRunner.Port)
+		})
+
+		AfterEach(func() {
+			_, err := db.Exec(`
+				DROP OWNED BY some_runtime_user;
+				DROP ROLE some_runtime_user;
+			`)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("migrates as that user and grants the runtime user what it needs to run queries", func() {
+			runtimeDB, err := migration.NewOpenHelper("postgres", runtimeDSN, lockFactory, nil, nil).
+				WithMigrationDataSourceName(postgresRunner.DataSourceName()).
+				Open()
+			Expect(err).NotTo(HaveOccurred())
+
+			defer runtimeDB.Close()
+
+			By("leaving the schema to the migration user")
+			var owner string
+			err = db.QueryRow(`SELECT tableowner FROM pg_tables WHERE tablename = 'teams'`).Scan(&owner)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(owner).To(Equal("postgres"))
+
+			By("letting the runtime user run queries, including those creating and dropping partitions")
+			_, err = runtimeDB.Exec(`INSERT INTO teams (name, auth) VALUES ('some-team', '{}')`)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = runtimeDB.Exec(`SELECT drop_pipeline_build_events(1)`)
+			Expect(err).NotTo(HaveOccurred())
+
+			var events int
+			err = runtimeDB.QueryRow(`SELECT COUNT(*) FROM team_build_events_1`).Scan(&events)
+			Expect(err).NotTo(HaveOccurred())
+
+			By("not letting the runtime user change the schema")
+			_, err = runtimeDB.Exec(`CREATE TABLE some_table (id integer)`)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
 	Context("Upgrade", func() {
 		Context("old schema_migrations table exist", func() {
 			var dirty bool
@@ -328,6 +382,121 @@ var _ = Describe("Migration", func() {
 			})
 		})
 
+		Context("heavy migrations", func() {
+			var migrator migration.Migrator
+
+			BeforeEach(func() {
+				_, err := db.Exec(`
+					CREATE TABLE some_table (id integer);
+					INSERT INTO some_table SELECT generate_series(1, 1000);
+					ANALYZE some_table;
+				`)
+				Expect(err).NotTo(HaveOccurred())
+
+				SetupMigrationsHistoryTableToExistAtVersion(db, 1000)
+
+				migrator = migration.NewMigratorForMigrations(db, lockFactory, fstest.MapFS{
+					"1000_test_table_created.up.sql": &fstest.MapFile{
+						Data: []byte(`
+							CREATE TABLE some_table (id integer);
+						`),
+					},
+					"1001_test_table_rewritten.up.sql": &fstest.MapFile{
+						Data: []byte(`
+							-- heavy: some_table
+							ALTER TABLE some_table ALTER COLUMN id TYPE bigint;
+						`),
+					},
+				})
+			})
+
+			It("runs them when the tables are estimated to be within the limits", func() {
+				err := migrator.WithHeavyMigrationLimits(migration.HeavyMigrationLimits{
+					MaxRows: 10000,
+				}).Up(nil, nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				ExpectDatabaseMigrationVersionToEqual(migrator, 1001)
+			})
+
+			It("refuses to run them when the tables are estimated to exceed the limits", func() {
+				err := migrator.WithHeavyMigrationLimits(migration.HeavyMigrationLimits{
+					MaxRows: 100,
+				}).Up(nil, nil)
+
+				var heavyErr migration.HeavyMigrationsError
+				Expect(errors.As(err, &heavyErr)).To(BeTrue())
+				Expect(heavyErr.Estimates).To(HaveLen(1))
+				Expect(heavyErr.Estimates[0].Version).To(Equal(1001))
+				Expect(heavyErr.Estimates[0].Table).To(Equal("some_table"))
+				Expect(heavyErr.Estimates[0].Rows).To(Equal(int64(1000)))
+				Expect(heavyErr.Estimates[0].Bytes).To(BeNumerically(">", 0))
+
+				ExpectDatabaseMigrationVersionToEqual(migrator, 1000)
+			})
+
+			It("runs them anyway once they are confirmed", func() {
+				err := migrator.WithHeavyMigrationLimits(migration.HeavyMigrationLimits{
+					MaxRows: 100,
+					Confirm: true,
+				}).Up(nil, nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				ExpectDatabaseMigrationVersionToEqual(migrator, 1001)
+			})
+		})
+
+		Context("bundles", func() {
+			It("renders the pending migrations for applying them by hand", func() {
+				SetupMigrationsHistoryTableToExistAtVersion(db, 1000)
+
+				migrator := migration.NewMigratorForMigrations(db, lockFactory, fstest.MapFS{
+					"1000_test_table_created.up.sql": &fstest.MapFile{
+						Data: []byte(`
+							CREATE TABLE some_table (id integer);
+						`),
+					},
+					"1001_test_table_created.up.sql": &fstest.MapFile{
+						Data: []byte(`
+							CREATE TABLE some_other_table (id integer);
+						`),
+					},
+					"1001_test_table_created.down.sql": &fstest.MapFile{
+						Data: []byte(`
+							DROP TABLE some_other_table;
+						`),
+					},
+				})
+
+				bundle, err := migrator.Bundle(1001)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(bundle).To(ContainSubstring("from version 1000 to 1001"))
+				Expect(bundle).ToNot(ContainSubstring("some_table"))
+				Expect(bundle).ToNot(ContainSubstring("DROP TABLE"))
+				Expect(bundle).To(ContainSubstring("CREATE TABLE some_other_table (id integer);"))
+
+				By("applying the bundle")
+				_, err = db.Exec(bundle)
+				Expect(err).NotTo(HaveOccurred())
+
+				By("adopting the version the bundle left the database at")
+				ExpectDatabaseMigrationVersionToEqual(migrator, 1001)
+
+				err = migrator.Up(nil, nil)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("refuses to render Go migrations", func() {
+				migrator := migration.NewMigratorForMigrations(db, lockFactory, hackyRealMigrationsFS(
+					"1510262030_initial_schema.up.sql",
+					"1516643303_update_auth_providers.up.go",
+				))
+
+				_, err := migrator.Bundle(1516643303)
+				Expect(err).To(Equal(migration.GoMigrationError{Version: 1516643303}))
+			})
+		})
+
 		Context("golang migrations", func() {
 			It("runs a migration with Migrate", func() {
 				migrator := migration.NewMigratorForMigrations(db, lockFactory, hackyRealMigrationsFS(
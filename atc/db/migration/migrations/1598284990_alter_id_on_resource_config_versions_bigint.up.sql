@@ -1,3 +1,4 @@
+-- heavy: resource_config_versions
 
 ALTER TABLE resource_config_versions ALTER COLUMN id TYPE bigint;
 
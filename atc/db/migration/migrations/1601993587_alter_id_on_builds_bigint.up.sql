@@ -1,3 +1,4 @@
+-- heavy: builds
 
 ALTER TABLE builds ALTER COLUMN id TYPE bigint;
 
@@ -1,3 +1,4 @@
+-- heavy: build_events
 
 -- migrate each pipeline partition
 DO $$
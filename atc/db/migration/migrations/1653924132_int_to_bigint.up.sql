@@ -1,3 +1,4 @@
+-- heavy: builds, containers
 ALTER SEQUENCE resource_config_versions_id_seq AS bigint;
 
 ALTER TABLE build_comments
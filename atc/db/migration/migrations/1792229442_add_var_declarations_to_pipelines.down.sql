@@ -0,0 +1,2 @@
+ALTER TABLE pipelines
+    DROP COLUMN var_declarations;
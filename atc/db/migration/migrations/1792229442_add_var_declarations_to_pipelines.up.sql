@@ -0,0 +1,2 @@
+ALTER TABLE pipelines
+    ADD COLUMN var_declarations jsonb;
@@ -0,0 +1,17 @@
+CREATE TABLE pipeline_config_versions (
+    id serial PRIMARY KEY,
+    pipeline_id integer NOT NULL REFERENCES pipelines (id) ON DELETE CASCADE,
+    status text NOT NULL,
+    config text NOT NULL,
+    nonce text,
+    base_version bigint NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX pipeline_config_versions_pipeline_id
+    ON pipeline_config_versions (pipeline_id);
+
+-- a pipeline has at most one candidate config at a time
+CREATE UNIQUE INDEX pipeline_config_versions_pipeline_id_candidate_uniq
+    ON pipeline_config_versions (pipeline_id) WHERE status = 'candidate';
@@ -0,0 +1,4 @@
+DROP TABLE team_notifiers;
+
+ALTER TABLE pipelines
+  DROP COLUMN notifications;
@@ -0,0 +1,14 @@
+ALTER TABLE pipelines
+  ADD COLUMN notifications jsonb;
+
+CREATE TABLE team_notifiers (
+    id serial PRIMARY KEY,
+    team_id integer NOT NULL REFERENCES teams (id) ON DELETE CASCADE,
+    name text NOT NULL,
+    type text NOT NULL,
+    config text NOT NULL,
+    nonce text
+);
+
+CREATE UNIQUE INDEX team_notifiers_team_id_name_uniq
+    ON team_notifiers (team_id, name);
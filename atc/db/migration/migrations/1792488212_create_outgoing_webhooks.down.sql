@@ -0,0 +1,3 @@
+DROP TABLE outgoing_webhook_deliveries;
+
+DROP TABLE outgoing_webhooks;
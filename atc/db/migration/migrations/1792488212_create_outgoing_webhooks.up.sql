@@ -0,0 +1,33 @@
+-- team_id is NULL for webhooks which belong to the cluster
+CREATE TABLE outgoing_webhooks (
+    id serial PRIMARY KEY,
+    team_id integer REFERENCES teams (id) ON DELETE CASCADE,
+    name text NOT NULL,
+    url text NOT NULL,
+    events text[] NOT NULL,
+    secret text NOT NULL,
+    nonce text
+);
+
+CREATE UNIQUE INDEX outgoing_webhooks_team_id_name_uniq
+    ON outgoing_webhooks (COALESCE(team_id, 0), name);
+
+CREATE TABLE outgoing_webhook_deliveries (
+    id bigserial PRIMARY KEY,
+    webhook_id integer NOT NULL REFERENCES outgoing_webhooks (id) ON DELETE CASCADE,
+    event text NOT NULL,
+    payload jsonb NOT NULL,
+    status text NOT NULL DEFAULT 'pending',
+    attempts integer NOT NULL DEFAULT 0,
+    response_status integer,
+    last_error text,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    next_attempt_at timestamptz NOT NULL DEFAULT now(),
+    delivered_at timestamptz
+);
+
+CREATE INDEX outgoing_webhook_deliveries_webhook_id
+    ON outgoing_webhook_deliveries (webhook_id, id);
+
+CREATE INDEX outgoing_webhook_deliveries_pending
+    ON outgoing_webhook_deliveries (next_attempt_at) WHERE status = 'pending';
@@ -0,0 +1,5 @@
+CREATE TABLE build_provenance (
+    build_id integer PRIMARY KEY REFERENCES builds (id) ON DELETE CASCADE,
+    envelope jsonb NOT NULL,
+    created_at timestamp with time zone NOT NULL DEFAULT now()
+);
@@ -0,0 +1,2 @@
+ALTER TABLE volumes
+    DROP COLUMN content_digest;
@@ -0,0 +1,2 @@
+ALTER TABLE volumes
+    ADD COLUMN content_digest text;
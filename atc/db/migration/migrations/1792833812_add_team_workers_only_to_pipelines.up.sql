@@ -0,0 +1,2 @@
+ALTER TABLE pipelines
+    ADD COLUMN team_workers_only boolean NOT NULL DEFAULT false;
@@ -0,0 +1,7 @@
+CREATE TABLE team_worker_keys (
+    team_id integer NOT NULL REFERENCES teams (id) ON DELETE CASCADE,
+    key text NOT NULL
+);
+
+CREATE UNIQUE INDEX team_worker_keys_team_id_key_uniq
+    ON team_worker_keys (team_id, key);
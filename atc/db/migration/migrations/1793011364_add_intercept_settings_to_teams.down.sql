@@ -0,0 +1,3 @@
+ALTER TABLE teams
+    DROP COLUMN intercept_disabled,
+    DROP COLUMN record_intercepts;
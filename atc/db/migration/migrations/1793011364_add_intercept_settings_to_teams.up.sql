@@ -0,0 +1,3 @@
+ALTER TABLE teams
+    ADD COLUMN intercept_disabled boolean NOT NULL DEFAULT false,
+    ADD COLUMN record_intercepts boolean NOT NULL DEFAULT false;
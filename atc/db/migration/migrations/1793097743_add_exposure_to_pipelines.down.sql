@@ -0,0 +1,3 @@
+ALTER TABLE pipelines
+    DROP COLUMN public_jobs,
+    DROP COLUMN public_status_only;
@@ -0,0 +1,3 @@
+ALTER TABLE pipelines
+    ADD COLUMN public_jobs text[],
+    ADD COLUMN public_status_only boolean NOT NULL DEFAULT false;
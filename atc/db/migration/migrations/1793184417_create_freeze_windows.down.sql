@@ -0,0 +1 @@
+DROP TABLE freeze_windows;
@@ -0,0 +1,11 @@
+-- team_id is NULL for freeze windows which apply to the whole cluster
+CREATE TABLE freeze_windows (
+    id serial PRIMARY KEY,
+    team_id integer REFERENCES teams (id) ON DELETE CASCADE,
+    start_time timestamptz NOT NULL,
+    end_time timestamptz NOT NULL,
+    reason text NOT NULL DEFAULT '',
+    exempt_pipelines text[] NOT NULL DEFAULT '{}'
+);
+
+CREATE INDEX freeze_windows_end_time_idx ON freeze_windows (end_time);
@@ -0,0 +1,15 @@
+-- hourly rollups of the builds of each job, by the hour in which they
+-- finished; durations and queue times are in seconds
+CREATE TABLE job_build_stats (
+    job_id integer NOT NULL REFERENCES jobs (id) ON DELETE CASCADE,
+    bucket timestamptz NOT NULL,
+    succeeded integer NOT NULL DEFAULT 0,
+    failed integer NOT NULL DEFAULT 0,
+    errored integer NOT NULL DEFAULT 0,
+    aborted integer NOT NULL DEFAULT 0,
+    durations integer[] NOT NULL DEFAULT '{}',
+    queue_times integer[] NOT NULL DEFAULT '{}',
+    PRIMARY KEY (job_id, bucket)
+);
+
+CREATE INDEX job_build_stats_bucket_idx ON job_build_stats (bucket);
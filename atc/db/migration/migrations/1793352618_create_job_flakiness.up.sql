@@ -0,0 +1,14 @@
+-- latest_build_id is the latest completed build of the job when it was
+-- analyzed, so that jobs are only analyzed again once they have new builds
+CREATE TABLE job_flakiness (
+    job_id integer PRIMARY KEY REFERENCES jobs (id) ON DELETE CASCADE,
+    latest_build_id integer NOT NULL,
+    builds integer NOT NULL,
+    repeats integer NOT NULL,
+    flips integer NOT NULL,
+    score real NOT NULL,
+    flaky boolean NOT NULL,
+    analyzed_at timestamptz NOT NULL
+);
+
+CREATE INDEX job_flakiness_flaky_idx ON job_flakiness (job_id) WHERE flaky;
@@ -0,0 +1,10 @@
+-- durations are in nanoseconds and add up the time spent on each job of the
+-- pipeline scheduled during the tick
+CREATE TABLE pipeline_scheduling_stats (
+    pipeline_id integer PRIMARY KEY REFERENCES pipelines (id) ON DELETE CASCADE,
+    tick_started_at timestamptz NOT NULL,
+    jobs integer NOT NULL,
+    loading_versions bigint NOT NULL,
+    resolving_inputs bigint NOT NULL,
+    creating_builds bigint NOT NULL
+);
@@ -0,0 +1,3 @@
+-- interval keeps being set from the flags on startup, while the override set
+-- through the API survives restarts
+ALTER TABLE components ADD COLUMN interval_override text;
@@ -0,0 +1 @@
+DROP TABLE build_step_aborts;
@@ -0,0 +1,5 @@
+CREATE TABLE build_step_aborts (
+    build_id bigint NOT NULL REFERENCES builds (id) ON DELETE CASCADE,
+    plan_id text NOT NULL,
+    PRIMARY KEY (build_id, plan_id)
+);
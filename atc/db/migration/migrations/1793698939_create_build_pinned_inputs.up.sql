@@ -0,0 +1,8 @@
+CREATE TABLE build_pinned_inputs (
+    build_id bigint NOT NULL REFERENCES builds (id) ON DELETE CASCADE,
+    input_name text NOT NULL,
+    resource_id integer NOT NULL REFERENCES resources (id) ON DELETE CASCADE,
+    version_md5 text NOT NULL,
+    override_passed boolean NOT NULL DEFAULT false,
+    PRIMARY KEY (build_id, input_name)
+);
@@ -0,0 +1,5 @@
+CREATE TABLE build_vars (
+    build_id bigint PRIMARY KEY REFERENCES builds (id) ON DELETE CASCADE,
+    vars text NOT NULL,
+    nonce text
+);
@@ -0,0 +1,3 @@
+DROP INDEX jobs_serial_groups_team_scoped_serial_group_idx;
+
+ALTER TABLE jobs_serial_groups DROP COLUMN team_scoped;
@@ -0,0 +1,3 @@
+ALTER TABLE jobs_serial_groups ADD COLUMN team_scoped boolean NOT NULL DEFAULT false;
+
+CREATE INDEX jobs_serial_groups_team_scoped_serial_group_idx ON jobs_serial_groups (serial_group) WHERE team_scoped;
@@ -0,0 +1 @@
+DROP TABLE put_queue;
@@ -0,0 +1,9 @@
+CREATE TABLE put_queue (
+    id bigserial PRIMARY KEY,
+    queue text NOT NULL,
+    build_id bigint NOT NULL REFERENCES builds (id) ON DELETE CASCADE,
+    plan_id text NOT NULL,
+    UNIQUE (queue, build_id, plan_id)
+);
+
+CREATE INDEX put_queue_queue_id_idx ON put_queue (queue, id);
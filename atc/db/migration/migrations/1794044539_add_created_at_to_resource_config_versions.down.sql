@@ -0,0 +1 @@
+ALTER TABLE resource_config_versions DROP COLUMN created_at;
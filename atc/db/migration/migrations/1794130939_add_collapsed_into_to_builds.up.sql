@@ -0,0 +1 @@
+ALTER TABLE builds ADD COLUMN collapsed_into bigint REFERENCES builds (id) ON DELETE SET NULL;
@@ -0,0 +1 @@
+ALTER TABLE jobs ADD COLUMN worker_capacity_reached boolean NOT NULL DEFAULT false;
@@ -0,0 +1 @@
+ALTER TABLE builds ADD COLUMN retry_of bigint REFERENCES builds (id) ON DELETE SET NULL;
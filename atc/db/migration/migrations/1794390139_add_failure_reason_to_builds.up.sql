@@ -0,0 +1 @@
+ALTER TABLE builds ADD COLUMN failure_reason text;
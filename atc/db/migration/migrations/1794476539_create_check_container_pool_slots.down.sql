@@ -0,0 +1,3 @@
+ALTER TABLE containers DROP COLUMN check_container_pool_slot_id;
+
+DROP TABLE check_container_pool_slots;
@@ -0,0 +1,12 @@
+CREATE TABLE check_container_pool_slots (
+    id serial PRIMARY KEY,
+    worker_base_resource_type_id integer NOT NULL REFERENCES worker_base_resource_types (id) ON DELETE CASCADE,
+    team_id integer NOT NULL REFERENCES teams (id) ON DELETE CASCADE,
+    slot integer NOT NULL,
+    expires_at timestamp with time zone NOT NULL,
+    UNIQUE (worker_base_resource_type_id, team_id, slot)
+);
+
+ALTER TABLE containers ADD COLUMN check_container_pool_slot_id integer REFERENCES check_container_pool_slots (id) ON DELETE SET NULL;
+
+CREATE INDEX containers_check_container_pool_slot_id ON containers (check_container_pool_slot_id);
@@ -0,0 +1,3 @@
+DROP TABLE checks_in_flight;
+
+DROP TABLE check_limits;
@@ -0,0 +1,15 @@
+CREATE TABLE check_limits (
+    id boolean PRIMARY KEY DEFAULT true CHECK (id),
+    max_in_flight integer NOT NULL DEFAULT 0,
+    max_in_flight_per_worker integer NOT NULL DEFAULT 0
+);
+
+INSERT INTO check_limits DEFAULT VALUES;
+
+CREATE TABLE checks_in_flight (
+    id bigserial PRIMARY KEY,
+    worker_name text NOT NULL REFERENCES workers (name) ON DELETE CASCADE,
+    expires_at timestamp with time zone NOT NULL
+);
+
+CREATE INDEX checks_in_flight_worker_name_idx ON checks_in_flight (worker_name);
@@ -0,0 +1,12 @@
+CREATE TABLE queued_jobs (
+    id bigserial PRIMARY KEY,
+    queue text NOT NULL,
+    payload jsonb NOT NULL,
+    dead boolean NOT NULL DEFAULT false,
+    attempts integer NOT NULL DEFAULT 0,
+    visible_at timestamp with time zone NOT NULL DEFAULT now(),
+    last_error text,
+    created_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE INDEX queued_jobs_queue_visible_at_idx ON queued_jobs (queue, visible_at) WHERE NOT dead;
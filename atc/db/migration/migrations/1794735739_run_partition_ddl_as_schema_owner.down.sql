@@ -0,0 +1,4 @@
+DROP FUNCTION drop_pipeline_build_events(integer);
+
+ALTER FUNCTION on_team_insert() SECURITY INVOKER RESET search_path;
+ALTER FUNCTION on_pipeline_insert() SECURITY INVOKER RESET search_path;
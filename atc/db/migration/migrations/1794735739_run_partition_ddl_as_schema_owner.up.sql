@@ -0,0 +1,12 @@
+-- the build events partitions are created and dropped as pipelines and
+-- teams come and go, which the ATC may not be allowed to do itself when it
+-- runs its queries as a user other than the one owning the schema
+
+ALTER FUNCTION on_pipeline_insert() SECURITY DEFINER SET search_path FROM CURRENT;
+ALTER FUNCTION on_team_insert() SECURITY DEFINER SET search_path FROM CURRENT;
+
+CREATE OR REPLACE FUNCTION drop_pipeline_build_events(pipeline_id integer) RETURNS void AS $$
+BEGIN
+  EXECUTE format('DROP TABLE IF EXISTS pipeline_build_events_%s', pipeline_id);
+END;
+$$ LANGUAGE plpgsql SECURITY DEFINER SET search_path FROM CURRENT;
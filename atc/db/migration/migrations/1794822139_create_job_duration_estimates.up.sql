@@ -0,0 +1,9 @@
+-- rolling estimates of how long the builds of each job take, and each of
+-- their steps; the estimate of the build as a whole has an empty step name
+CREATE TABLE job_duration_estimates (
+    job_id integer NOT NULL REFERENCES jobs (id) ON DELETE CASCADE,
+    step_name text NOT NULL DEFAULT '',
+    duration double precision NOT NULL,
+    samples integer NOT NULL DEFAULT 1,
+    PRIMARY KEY (job_id, step_name)
+);
@@ -0,0 +1,16 @@
+-- the responses to requests made with an Idempotency-Key header, replayed
+-- when a request is retried with the same key; the status is null while the
+-- first request is still being handled
+CREATE TABLE idempotency_keys (
+    team_name text NOT NULL,
+    route text NOT NULL,
+    key text NOT NULL,
+    request_hash text NOT NULL,
+    status integer,
+    content_type text NOT NULL DEFAULT '',
+    body bytea,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    PRIMARY KEY (team_name, route, key)
+);
+
+CREATE INDEX idempotency_keys_created_at_idx ON idempotency_keys (created_at);
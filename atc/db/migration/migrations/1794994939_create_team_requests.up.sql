@@ -0,0 +1,18 @@
+-- requests by users for teams to be created, which an admin approves or
+-- rejects; a team name may only have one pending request at a time
+CREATE TABLE team_requests (
+    id serial PRIMARY KEY,
+    team_name text NOT NULL,
+    auth text NOT NULL,
+    reason text NOT NULL DEFAULT '',
+    status text NOT NULL DEFAULT 'pending',
+    requested_by text NOT NULL,
+    requested_at timestamp with time zone NOT NULL DEFAULT now(),
+    decided_by text,
+    decided_at timestamp with time zone,
+    comment text NOT NULL DEFAULT ''
+);
+
+CREATE UNIQUE INDEX team_requests_pending_team_name_uniq ON team_requests (team_name) WHERE status = 'pending';
+
+CREATE INDEX team_requests_requested_by_idx ON team_requests (requested_by);
@@ -0,0 +1,3 @@
+DROP TABLE user_favorite_pipelines;
+
+DROP TABLE user_pipeline_orderings;
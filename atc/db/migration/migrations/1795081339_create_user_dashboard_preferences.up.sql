@@ -0,0 +1,18 @@
+-- a user's own arrangement of the dashboard, keyed by their subject; removing
+-- a pipeline removes it from everyone's arrangement
+CREATE TABLE user_pipeline_orderings (
+    user_sub text NOT NULL,
+    pipeline_id integer NOT NULL REFERENCES pipelines (id) ON DELETE CASCADE,
+    ordering integer NOT NULL,
+    PRIMARY KEY (user_sub, pipeline_id)
+);
+
+CREATE INDEX user_pipeline_orderings_pipeline_id_idx ON user_pipeline_orderings (pipeline_id);
+
+CREATE TABLE user_favorite_pipelines (
+    user_sub text NOT NULL,
+    pipeline_id integer NOT NULL REFERENCES pipelines (id) ON DELETE CASCADE,
+    PRIMARY KEY (user_sub, pipeline_id)
+);
+
+CREATE INDEX user_favorite_pipelines_pipeline_id_idx ON user_favorite_pipelines (pipeline_id);
@@ -0,0 +1,3 @@
+DROP TABLE user_preferences;
+
+ALTER TABLE users DROP COLUMN claims;
@@ -0,0 +1,13 @@
+-- the claims a user last logged in with, from which the teams they belong to
+-- are worked out
+ALTER TABLE users ADD COLUMN claims text;
+
+-- arbitrary JSON values kept for a user by clients such as the web UI, keyed
+-- by the user's subject
+CREATE TABLE user_preferences (
+    user_sub text NOT NULL,
+    key text NOT NULL,
+    value text NOT NULL,
+    updated_at timestamp with time zone NOT NULL DEFAULT now(),
+    PRIMARY KEY (user_sub, key)
+);
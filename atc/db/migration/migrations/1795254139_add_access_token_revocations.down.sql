@@ -0,0 +1,7 @@
+DROP TABLE access_token_revocations;
+
+DROP INDEX access_tokens_sub_idx;
+DROP INDEX access_tokens_id_uniq;
+
+ALTER TABLE access_tokens DROP COLUMN created_at;
+ALTER TABLE access_tokens DROP COLUMN id;
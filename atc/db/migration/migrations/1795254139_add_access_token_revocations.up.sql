@@ -0,0 +1,17 @@
+-- sessions are listed and revoked by id, which keeps the tokens themselves
+-- out of the API
+ALTER TABLE access_tokens ADD COLUMN id bigserial NOT NULL;
+ALTER TABLE access_tokens ADD COLUMN created_at timestamp with time zone NOT NULL DEFAULT now();
+
+CREATE UNIQUE INDEX access_tokens_id_uniq ON access_tokens (id);
+CREATE INDEX access_tokens_sub_idx ON access_tokens (sub);
+
+-- tokens revoked before they expired, which the web nodes refuse even if they
+-- have the token's claims cached
+CREATE TABLE access_token_revocations (
+    token text PRIMARY KEY,
+    sub text NOT NULL,
+    expires_at timestamp with time zone,
+    revoked_at timestamp with time zone NOT NULL DEFAULT now(),
+    revoked_by text NOT NULL
+);
@@ -0,0 +1,8 @@
+-- consecutive failed logins of local users, kept in the database so that a
+-- user locked out by one web node is locked out by all of them
+CREATE TABLE login_failures (
+    username text PRIMARY KEY,
+    failures integer NOT NULL,
+    last_failed_at timestamp with time zone NOT NULL DEFAULT now(),
+    locked_until timestamp with time zone
+);
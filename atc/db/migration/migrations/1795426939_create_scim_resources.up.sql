@@ -0,0 +1,33 @@
+-- users and groups pushed by an identity provider through SCIM. Teams grant
+-- roles to the groups, which are matched to users logged in through the SCIM
+-- connector by external ID or verified email
+CREATE TABLE scim_users (
+    id text PRIMARY KEY,
+    user_name text NOT NULL,
+    external_id text,
+    display_name text,
+    emails text[] NOT NULL DEFAULT '{}',
+    active boolean NOT NULL DEFAULT true,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    updated_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX scim_users_user_name_uniq ON scim_users (lower(user_name));
+
+CREATE TABLE scim_groups (
+    id text PRIMARY KEY,
+    display_name text NOT NULL,
+    external_id text,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    updated_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX scim_groups_display_name_uniq ON scim_groups (lower(display_name));
+
+CREATE TABLE scim_group_members (
+    group_id text NOT NULL REFERENCES scim_groups (id) ON DELETE CASCADE,
+    user_id text NOT NULL REFERENCES scim_users (id) ON DELETE CASCADE,
+    PRIMARY KEY (group_id, user_id)
+);
+
+CREATE INDEX scim_group_members_user_id_idx ON scim_group_members (user_id);
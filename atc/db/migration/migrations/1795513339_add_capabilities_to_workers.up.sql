@@ -0,0 +1,2 @@
+ALTER TABLE workers
+    ADD COLUMN capabilities jsonb;
@@ -0,0 +1,7 @@
+ALTER TABLE containers DROP COLUMN warm_pool_slot_id;
+
+DROP TABLE warm_pool_slots;
+
+DROP TABLE warm_pool_templates;
+
+DROP TABLE job_warm_pools;
@@ -0,0 +1,29 @@
+CREATE TABLE job_warm_pools (
+    job_id integer PRIMARY KEY REFERENCES jobs (id) ON DELETE CASCADE,
+    size integer NOT NULL,
+    expiry interval NOT NULL
+);
+
+CREATE TABLE warm_pool_templates (
+    job_id integer NOT NULL REFERENCES job_warm_pools (job_id) ON DELETE CASCADE,
+    step_name text NOT NULL,
+    team_id integer NOT NULL REFERENCES teams (id) ON DELETE CASCADE,
+    key text NOT NULL,
+    spec jsonb NOT NULL,
+    PRIMARY KEY (job_id, step_name)
+);
+
+CREATE TABLE warm_pool_slots (
+    id serial PRIMARY KEY,
+    job_id integer NOT NULL,
+    step_name text NOT NULL,
+    key text NOT NULL,
+    expires_at timestamp with time zone NOT NULL,
+    FOREIGN KEY (job_id, step_name) REFERENCES warm_pool_templates (job_id, step_name) ON DELETE CASCADE
+);
+
+CREATE INDEX warm_pool_slots_job_id_step_name ON warm_pool_slots (job_id, step_name);
+
+ALTER TABLE containers ADD COLUMN warm_pool_slot_id integer REFERENCES warm_pool_slots (id) ON DELETE SET NULL;
+
+CREATE INDEX containers_warm_pool_slot_id ON containers (warm_pool_slot_id);
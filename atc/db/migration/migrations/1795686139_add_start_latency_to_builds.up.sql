@@ -0,0 +1 @@
+ALTER TABLE builds ADD COLUMN start_latency jsonb;
@@ -0,0 +1,2 @@
+ALTER TABLE teams
+    DROP COLUMN privileged_policy;
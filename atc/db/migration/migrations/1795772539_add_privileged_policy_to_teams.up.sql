@@ -0,0 +1,2 @@
+ALTER TABLE teams
+    ADD COLUMN privileged_policy text NOT NULL DEFAULT 'allow';
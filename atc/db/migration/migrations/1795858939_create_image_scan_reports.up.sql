@@ -0,0 +1,5 @@
+CREATE TABLE image_scan_reports (
+    digest text PRIMARY KEY,
+    vulnerabilities jsonb NOT NULL,
+    scanned_at timestamp with time zone NOT NULL DEFAULT now()
+);
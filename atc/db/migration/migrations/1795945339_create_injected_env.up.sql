@@ -0,0 +1,10 @@
+-- env injected into every task, either of a team or, with no team, of every
+-- team
+CREATE TABLE injected_env (
+    team_id integer REFERENCES teams (id) ON DELETE CASCADE,
+    name text NOT NULL,
+    value text NOT NULL
+);
+
+CREATE UNIQUE INDEX injected_env_cluster_name_uniq ON injected_env (name) WHERE team_id IS NULL;
+CREATE UNIQUE INDEX injected_env_team_name_uniq ON injected_env (team_id, name) WHERE team_id IS NOT NULL;
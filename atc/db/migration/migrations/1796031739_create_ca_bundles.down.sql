@@ -0,0 +1 @@
+DROP TABLE ca_bundles;
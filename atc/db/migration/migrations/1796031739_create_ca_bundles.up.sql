@@ -0,0 +1,9 @@
+-- CA certificates trusted by every container, either of a team or, with no
+-- team, of every team
+CREATE TABLE ca_bundles (
+    team_id integer REFERENCES teams (id) ON DELETE CASCADE,
+    certificates text NOT NULL
+);
+
+CREATE UNIQUE INDEX ca_bundles_cluster_uniq ON ca_bundles ((true)) WHERE team_id IS NULL;
+CREATE UNIQUE INDEX ca_bundles_team_uniq ON ca_bundles (team_id) WHERE team_id IS NOT NULL;
@@ -0,0 +1,4 @@
+ALTER TABLE teams
+    DROP COLUMN http_proxy,
+    DROP COLUMN https_proxy,
+    DROP COLUMN no_proxy;
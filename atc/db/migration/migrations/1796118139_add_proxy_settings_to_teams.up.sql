@@ -0,0 +1,4 @@
+ALTER TABLE teams
+    ADD COLUMN http_proxy text NOT NULL DEFAULT '',
+    ADD COLUMN https_proxy text NOT NULL DEFAULT '',
+    ADD COLUMN no_proxy text NOT NULL DEFAULT '';
@@ -0,0 +1,4 @@
+DROP INDEX builds_external_ref_idx;
+DROP INDEX builds_job_id_external_ref_uniq;
+
+ALTER TABLE builds DROP COLUMN external_ref;
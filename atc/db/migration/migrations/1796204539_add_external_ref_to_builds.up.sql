@@ -0,0 +1,4 @@
+ALTER TABLE builds ADD COLUMN external_ref text;
+
+CREATE UNIQUE INDEX builds_job_id_external_ref_uniq ON builds (job_id, external_ref) WHERE external_ref IS NOT NULL;
+CREATE INDEX builds_external_ref_idx ON builds (external_ref) WHERE external_ref IS NOT NULL;
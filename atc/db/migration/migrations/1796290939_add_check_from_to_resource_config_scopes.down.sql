@@ -0,0 +1 @@
+ALTER TABLE resource_config_scopes DROP COLUMN check_from;
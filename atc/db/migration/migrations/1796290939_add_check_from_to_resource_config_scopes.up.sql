@@ -0,0 +1 @@
+ALTER TABLE resource_config_scopes ADD COLUMN check_from jsonb;
@@ -0,0 +1,2 @@
+ALTER TABLE workers
+    ADD COLUMN disk_usage jsonb;
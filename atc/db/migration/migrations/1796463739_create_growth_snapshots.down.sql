@@ -0,0 +1 @@
+DROP TABLE growth_snapshots;
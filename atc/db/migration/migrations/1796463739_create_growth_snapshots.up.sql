@@ -0,0 +1,16 @@
+-- periodic counts of the rows of the busiest tables, either of a team or, with
+-- no team, of the whole cluster
+CREATE TABLE growth_snapshots (
+    taken_at timestamp with time zone NOT NULL,
+    team_id integer REFERENCES teams (id) ON DELETE CASCADE,
+    builds bigint NOT NULL,
+    build_events bigint NOT NULL,
+    build_events_bytes bigint NOT NULL,
+    versions bigint NOT NULL,
+    containers bigint NOT NULL,
+    volumes bigint NOT NULL,
+    database_bytes bigint
+);
+
+CREATE INDEX growth_snapshots_taken_at_idx ON growth_snapshots (taken_at);
+CREATE INDEX growth_snapshots_team_id_taken_at_idx ON growth_snapshots (team_id, taken_at);
@@ -0,0 +1 @@
+DROP TABLE firehose_outbox;
@@ -0,0 +1,10 @@
+-- events queued for the firehose exporter, which are deleted once they have
+-- been published; the team is kept by name so that the events of a deleted
+-- team are still published
+CREATE TABLE firehose_outbox (
+    id bigserial PRIMARY KEY,
+    team_name text,
+    event text NOT NULL,
+    data jsonb NOT NULL,
+    created_at timestamp with time zone NOT NULL DEFAULT now()
+);
@@ -0,0 +1,3 @@
+DROP TABLE log_drain_cursors;
+
+DROP TABLE log_drains;
@@ -0,0 +1,25 @@
+-- log drains forward the logs of a team's builds, or of the builds of one of
+-- its pipelines; the url is encrypted, as it may hold credentials
+CREATE TABLE log_drains (
+    id serial PRIMARY KEY,
+    team_id integer NOT NULL REFERENCES teams (id) ON DELETE CASCADE,
+    name text NOT NULL,
+    type text NOT NULL,
+    url text NOT NULL,
+    nonce text,
+    pipeline text,
+    failures integer NOT NULL DEFAULT 0,
+    last_error text,
+    next_attempt_at timestamptz NOT NULL DEFAULT now(),
+    created_at timestamptz NOT NULL DEFAULT now(),
+    UNIQUE (team_id, name)
+);
+
+-- how far each drain has got through the events of each build
+CREATE TABLE log_drain_cursors (
+    drain_id integer NOT NULL REFERENCES log_drains (id) ON DELETE CASCADE,
+    build_id bigint NOT NULL REFERENCES builds (id) ON DELETE CASCADE,
+    cursor integer NOT NULL,
+    completed boolean NOT NULL DEFAULT false,
+    PRIMARY KEY (drain_id, build_id)
+);
@@ -0,0 +1,14 @@
+-- how much each client still uses the deprecated parts of the API; param and
+-- team are empty rather than NULL so that they can be part of the key, and
+-- the team is kept by name so that the usage of a deleted team is still
+-- reported
+CREATE TABLE api_deprecation_usage (
+    route text NOT NULL,
+    param text NOT NULL DEFAULT '',
+    user_agent text NOT NULL,
+    team text NOT NULL DEFAULT '',
+    requests bigint NOT NULL,
+    first_seen timestamptz NOT NULL,
+    last_seen timestamptz NOT NULL,
+    PRIMARY KEY (route, param, user_agent, team)
+);
@@ -0,0 +1,179 @@
+// Package migrationtest is a harness for testing that migrations preserve the
+// data they migrate.
+//
+// A test opens the database at the version before the migration, loads
+// fixture data into it, migrates up (and back down) and asserts on what
+// became of the data:
+//
+//	harness := migrationtest.NewHarness(dataSourceName, 1794303739, 1794390139)
+//	db := harness.Open(migrationtest.Exec(`INSERT INTO teams (name, auth) VALUES ('main', '{}')`))
+//	defer harness.Close()
+//
+//	harness.Up()
+//	Expect(migrationtest.Rows(db, `SELECT name FROM teams`)).To(Equal([][]interface{}{{"main"}}))
+package migrationtest
+
+import (
+	"database/sql"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/concourse/concourse/atc/db/migration"
+	. "github.com/onsi/gomega"
+)
+
+// Fixture loads data into the database before it is migrated.
+type Fixture func(*sql.DB) error
+
+// Exec returns a Fixture which runs the statement.
+func Exec(statement string, args ...interface{}) Fixture {
+	return func(db *sql.DB) error {
+		_, err := db.Exec(statement, args...)
+		return err
+	}
+}
+
+// Harness migrates a database between the version before a migration and the
+// version of the migration.
+type Harness struct {
+	dataSourceName string
+	from           int
+	to             int
+
+	db *sql.DB
+}
+
+func NewHarness(dataSourceName string, from int, to int) *Harness {
+	return &Harness{
+		dataSourceName: dataSourceName,
+		from:           from,
+		to:             to,
+	}
+}
+
+// Open migrates the empty database to the version before the migration and
+// loads the fixtures into it.
+func (harness *Harness) Open(fixtures ...Fixture) *sql.DB {
+	db, err := harness.helper().OpenAtVersion(harness.from)
+	Expect(err).ToNot(HaveOccurred())
+
+	harness.db = db
+
+	for _, fixture := range fixtures {
+		Expect(fixture(db)).To(Succeed())
+	}
+
+	return db
+}
+
+// Up runs the migration.
+func (harness *Harness) Up() {
+	Expect(harness.helper().MigrateToVersion(harness.to)).To(Succeed())
+}
+
+// Down rolls the migration back.
+func (harness *Harness) Down() {
+	Expect(harness.helper().MigrateToVersion(harness.from)).To(Succeed())
+}
+
+func (harness *Harness) Close() {
+	if harness.db != nil {
+		Expect(harness.db.Close()).To(Succeed())
+	}
+}
+
+func (harness *Harness) helper() *migration.OpenHelper {
+	return migration.NewOpenHelper("postgres", harness.dataSourceName, nil, nil, nil)
+}
+
+// Rows returns the rows the query selects, with text columns as strings so
+// that they can be compared with literals.
+func Rows(db *sql.DB, query string, args ...interface{}) [][]interface{} {
+	rows, err := db.Query(query, args...)
+	Expect(err).ToNot(HaveOccurred())
+
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	Expect(err).ToNot(HaveOccurred())
+
+	result := [][]interface{}{}
+	for rows.Next() {
+		values := make([]interface{}, len(columns))
+		pointers := make([]interface{}, len(columns))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+
+		Expect(rows.Scan(pointers...)).To(Succeed())
+
+		for i, value := range values {
+			if bytes, ok := value.([]byte); ok {
+				values[i] = string(bytes)
+			}
+		}
+
+		result = append(result, values)
+	}
+
+	Expect(rows.Err()).ToNot(HaveOccurred())
+
+	return result
+}
+
+// UntestedMigrations returns the versions of the migrations newer than since
+// which aren't mentioned by any of the tests in the directory.
+func UntestedMigrations(testDir string, since int) ([]int, error) {
+	migrations, err := migration.NewMigrator(nil, nil).Migrations()
+	if err != nil {
+		return nil, err
+	}
+
+	tests, err := filepath.Glob(filepath.Join(testDir, "*_test.go"))
+	if err != nil {
+		return nil, err
+	}
+
+	var sources []string
+	for _, test := range tests {
+		source, err := os.ReadFile(test)
+		if err != nil {
+			return nil, err
+		}
+
+		sources = append(sources, string(source))
+	}
+
+	untested := map[int]bool{}
+	for _, m := range migrations {
+		if m.Version <= since {
+			continue
+		}
+
+		version := strconv.Itoa(m.Version)
+
+		tested := false
+		for _, source := range sources {
+			if strings.Contains(source, version) {
+				tested = true
+				break
+			}
+		}
+
+		if !tested {
+			untested[m.Version] = true
+		}
+	}
+
+	versions := []int{}
+	for version := range untested {
+		versions = append(versions, version)
+	}
+
+	sort.Ints(versions)
+
+	return versions, nil
+}
@@ -57,6 +57,7 @@ func (p *Parser) ParseFileToMigration(migrationName string) (migration, error) {
 
 	migrationContents = string(migrationBytes)
 	migration.Strategy = determineMigrationStrategy(migrationName, migrationContents)
+	migration.HeavyTables = heavyTables(migrationContents)
 
 	switch migration.Strategy {
 	case GoMigration:
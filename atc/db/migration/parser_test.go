@@ -34,6 +34,13 @@ var _ = Describe("Parser", func() {
 			"2000_some_go_migration.up.go": &fstest.MapFile{
 				Data: []byte(`
 func (m *Migrator) Up_2000() {}
+`),
+			},
+			"3000_some_heavy_migration.up.sql": &fstest.MapFile{
+				Data: []byte(`
+	-- heavy: builds, containers
+	ALTER TABLE builds ALTER COLUMN id TYPE bigint;
+	ALTER TABLE containers ALTER COLUMN build_id TYPE bigint;
 `),
 			},
 			"2000_some_go_migration.down.go": &fstest.MapFile{
@@ -76,6 +83,16 @@ func (m *Migrator) Down_2000() {}
 		})
 	})
 
+	It("parses the tables which heavy migrations rewrite", func() {
+		migration, err := parser.ParseFileToMigration("3000_some_heavy_migration.up.sql")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(migration.HeavyTables).To(Equal([]string{"builds", "containers"}))
+
+		migration, err = parser.ParseFileToMigration("1000_some_migration.up.sql")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(migration.HeavyTables).To(BeEmpty())
+	})
+
 	Context("Go migrations", func() {
 		It("returns the name of the migration function to run", func() {
 			migration, err := parser.ParseFileToMigration("2000_some_go_migration.up.go")
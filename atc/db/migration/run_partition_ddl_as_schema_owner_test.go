@@ -0,0 +1,82 @@
+package migration_test
+
+import (
+	"database/sql"
+
+	"github.com/concourse/concourse/atc/db/migration/migrationtest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Run partition DDL as the schema owner", func() {
+	const preMigrationVersion = 1794649339
+	const postMigrationVersion = 1794735739
+
+	var (
+		harness *migrationtest.Harness
+		db      *sql.DB
+	)
+
+	BeforeEach(func() {
+		harness = migrationtest.NewHarness(postgresRunner.DataSourceName(), preMigrationVersion, postMigrationVersion)
+
+		db = harness.Open(
+			migrationtest.Exec(`INSERT INTO teams (name, auth) VALUES ('some-team', '{}')`),
+			migrationtest.Exec(`CREATE TABLE pipeline_build_events_42 () INHERITS (build_events)`),
+		)
+
+		harness.Up()
+	})
+
+	AfterEach(func() {
+		harness.Close()
+	})
+
+	securityDefiner := func() [][]interface{} {
+		return migrationtest.Rows(db, `
+			SELECT proname, prosecdef
+			FROM pg_proc
+			WHERE proname IN ('on_pipeline_insert', 'on_team_insert', 'drop_pipeline_build_events')
+			ORDER BY proname
+		`)
+	}
+
+	It("creates the partitions as the owner of the functions", func() {
+		Expect(securityDefiner()).To(Equal([][]interface{}{
+			{"drop_pipeline_build_events", true},
+			{"on_pipeline_insert", true},
+			{"on_team_insert", true},
+		}))
+	})
+
+	It("drops the partitions of pipelines through a function", func() {
+		_, err := db.Exec(`SELECT drop_pipeline_build_events(42)`)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(migrationtest.Rows(db, `SELECT to_regclass('pipeline_build_events_42')::text`)).To(Equal([][]interface{}{
+			{nil},
+		}))
+	})
+
+	It("keeps creating the partitions of new teams", func() {
+		_, err := db.Exec(`INSERT INTO teams (name, auth) VALUES ('some-other-team', '{}')`)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(migrationtest.Rows(db, `SELECT to_regclass('team_build_events_2')::text`)).To(Equal([][]interface{}{
+			{"team_build_events_2"},
+		}))
+	})
+
+	Context("when rolled back", func() {
+		BeforeEach(func() {
+			harness.Down()
+		})
+
+		It("creates the partitions as whoever inserts the teams and pipelines again", func() {
+			Expect(securityDefiner()).To(Equal([][]interface{}{
+				{"on_pipeline_insert", false},
+				{"on_team_insert", false},
+			}))
+		})
+	})
+})
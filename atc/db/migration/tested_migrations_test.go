@@ -0,0 +1,18 @@
+package migration_test
+
+import (
+	"github.com/concourse/concourse/atc/db/migration/migrationtest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// migrations up to this version predate the requirement for tests
+const lastUntestedMigration = 1794649339
+
+var _ = Describe("Tested migrations", func() {
+	It("has a test for each new migration", func() {
+		untested, err := migrationtest.UntestedMigrations(".", lastUntestedMigration)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(untested).To(BeEmpty(), "new migrations must ship with a test in atc/db/migration, see the migrationtest package")
+	})
+})
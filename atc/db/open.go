@@ -11,9 +11,11 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc/db/dialect"
 	"github.com/concourse/concourse/atc/db/encryption"
 	"github.com/concourse/concourse/atc/db/lock"
 	"github.com/concourse/concourse/atc/db/migration"
+	"github.com/concourse/concourse/atc/faults"
 	multierror "github.com/hashicorp/go-multierror"
 	"github.com/lib/pq"
 )
@@ -62,9 +64,13 @@ type Tx interface {
 	EncryptionStrategy() encryption.Strategy
 }
 
-func Open(logger lager.Logger, driver, dsn string, newKey, oldKey *encryption.Key, name string, lockFactory lock.LockFactory) (Conn, error) {
+func Open(logger lager.Logger, driver, dsn string, newKey, oldKey *encryption.Key, name string, lockFactory lock.LockFactory, heavyLimits migration.HeavyMigrationLimits, migrationDSN string, listener Listener, d dialect.Dialect) (Conn, error) {
 	for {
-		sqlDB, err := migration.NewOpenHelper(driver, dsn, lockFactory, newKey, oldKey).Open()
+		sqlDB, err := migration.NewOpenHelper(driver, dsn, lockFactory, newKey, oldKey).
+			WithHeavyMigrationLimits(heavyLimits).
+			WithMigrationDataSourceName(migrationDSN).
+			WithDialect(d).
+			Open()
 		if err != nil {
 			if shouldRetry(err) {
 				logger.Error("failed-to-open-db-retrying", err)
@@ -75,13 +81,14 @@ func Open(logger lager.Logger, driver, dsn string, newKey, oldKey *encryption.Ke
 			return nil, err
 		}
 
-		return NewConn(name, sqlDB, dsn, oldKey, newKey), nil
+		return NewConn(name, sqlDB, listener, oldKey, newKey), nil
 	}
 }
 
-func NewConn(name string, sqlDB *sql.DB, dsn string, oldKey, newKey *encryption.Key) Conn {
-	listener := pq.NewDialListener(keepAliveDialer{}, dsn, time.Second, time.Minute, nil)
-
+// NewConn wraps sqlDB, listening for notifications with listener. It can
+// connect differently from sqlDB, as listening relies on the session, which a
+// pooler in transaction pooling mode doesn't keep.
+func NewConn(name string, sqlDB *sql.DB, listener Listener, oldKey, newKey *encryption.Key) Conn {
 	var strategy encryption.Strategy
 	if newKey != nil {
 		strategy = newKey
@@ -152,6 +159,10 @@ func Close(c io.Closer) {
 }
 
 func (db *db) Begin() (Tx, error) {
+	if err := faults.Inject(faults.DB); err != nil {
+		return nil, err
+	}
+
 	tx, err := db.DB.Begin()
 	if err != nil {
 		return nil, err
@@ -161,6 +172,10 @@ func (db *db) Begin() (Tx, error) {
 }
 
 func (db *db) Exec(query string, args ...interface{}) (sql.Result, error) {
+	if err := faults.Inject(faults.DB); err != nil {
+		return nil, err
+	}
+
 	defer GlobalConnectionTracker.Track().Release()
 	return db.DB.Exec(query, args...)
 }
@@ -171,17 +186,29 @@ func (db *db) Prepare(query string) (*sql.Stmt, error) {
 }
 
 func (db *db) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	if err := faults.Inject(faults.DB); err != nil {
+		return nil, err
+	}
+
 	defer GlobalConnectionTracker.Track().Release()
 	return db.DB.Query(query, args...)
 }
 
 // to conform to squirrel.Runner interface
 func (db *db) QueryRow(query string, args ...interface{}) squirrel.RowScanner {
+	if err := faults.Inject(faults.DB); err != nil {
+		return faultyRow{err}
+	}
+
 	defer GlobalConnectionTracker.Track().Release()
 	return db.DB.QueryRow(query, args...)
 }
 
 func (db *db) BeginTx(ctx context.Context, opts *sql.TxOptions) (Tx, error) {
+	if err := faults.Inject(faults.DB); err != nil {
+		return nil, err
+	}
+
 	tx, err := db.DB.BeginTx(ctx, opts)
 	if err != nil {
 		return nil, err
@@ -191,6 +218,10 @@ func (db *db) BeginTx(ctx context.Context, opts *sql.TxOptions) (Tx, error) {
 }
 
 func (db *db) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	if err := faults.Inject(faults.DB); err != nil {
+		return nil, err
+	}
+
 	defer GlobalConnectionTracker.Track().Release()
 	return db.DB.ExecContext(ctx, query, args...)
 }
@@ -201,16 +232,34 @@ func (db *db) PrepareContext(ctx context.Context, query string) (*sql.Stmt, erro
 }
 
 func (db *db) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	if err := faults.Inject(faults.DB); err != nil {
+		return nil, err
+	}
+
 	defer GlobalConnectionTracker.Track().Release()
 	return db.DB.QueryContext(ctx, query, args...)
 }
 
 // to conform to squirrel.Runner interface
 func (db *db) QueryRowContext(ctx context.Context, query string, args ...interface{}) squirrel.RowScanner {
+	if err := faults.Inject(faults.DB); err != nil {
+		return faultyRow{err}
+	}
+
 	defer GlobalConnectionTracker.Track().Release()
 	return db.DB.QueryRowContext(ctx, query, args...)
 }
 
+// faultyRow is the row of a query which failed to run because of an injected
+// fault.
+type faultyRow struct {
+	err error
+}
+
+func (row faultyRow) Scan(...interface{}) error {
+	return row.err
+}
+
 type dbTx struct {
 	*sql.Tx
 
@@ -0,0 +1,418 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
+	"github.com/lib/pq"
+)
+
+// OutgoingWebhookRepository manages the outgoing webhooks of teams and the
+// cluster, along with the deliveries of events to them.
+//
+// A teamID of 0 refers to the webhooks of the cluster, which receive the
+// events of every team.
+//
+//counterfeiter:generate . OutgoingWebhookRepository
+type OutgoingWebhookRepository interface {
+	Webhooks(teamID int) ([]atc.OutgoingWebhook, error)
+	SaveWebhook(teamID int, webhook atc.OutgoingWebhook) error
+	DeleteWebhook(teamID int, name string) (bool, error)
+
+	Deliveries(teamID int, name string, limit int) ([]atc.WebhookDelivery, bool, error)
+
+	PendingDeliveries(limit int) ([]PendingWebhookDelivery, error)
+	DeliverySucceeded(id int64, responseStatus int) error
+	DeliveryFailed(id int64, responseStatus int, deliveryErr string, retryAt *time.Time) error
+	DeleteDeliveriesBefore(before time.Time) error
+}
+
+// PendingWebhookDelivery is a delivery which is due to be attempted, along
+// with the webhook it is to be delivered to.
+type PendingWebhookDelivery struct {
+	ID       int64
+	Event    atc.WebhookEvent
+	Payload  json.RawMessage
+	Attempts int
+
+	URL    string
+	Secret string
+}
+
+type outgoingWebhookRepository struct {
+	conn Conn
+}
+
+func NewOutgoingWebhookRepository(conn Conn) OutgoingWebhookRepository {
+	return &outgoingWebhookRepository{
+		conn: conn,
+	}
+}
+
+func webhookTeamEq(teamID int) sq.Eq {
+	if teamID == 0 {
+		return sq.Eq{"team_id": nil}
+	}
+
+	return sq.Eq{"team_id": teamID}
+}
+
+func (repo *outgoingWebhookRepository) Webhooks(teamID int) ([]atc.OutgoingWebhook, error) {
+	rows, err := psql.Select("name", "url", "events").
+		From("outgoing_webhooks").
+		Where(webhookTeamEq(teamID)).
+		OrderBy("name").
+		RunWith(repo.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	webhooks := []atc.OutgoingWebhook{}
+	for rows.Next() {
+		var (
+			webhook atc.OutgoingWebhook
+			events  []string
+		)
+
+		err := rows.Scan(&webhook.Name, &webhook.URL, pq.Array(&events))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, event := range events {
+			webhook.Events = append(webhook.Events, atc.WebhookEvent(event))
+		}
+
+		webhooks = append(webhooks, webhook)
+	}
+
+	return webhooks, nil
+}
+
+// SaveWebhook creates or replaces the webhook with the same name. The secret
+// is encrypted.
+func (repo *outgoingWebhookRepository) SaveWebhook(teamID int, webhook atc.OutgoingWebhook) error {
+	encryptedSecret, nonce, err := repo.conn.EncryptionStrategy().Encrypt([]byte(webhook.Secret))
+	if err != nil {
+		return err
+	}
+
+	var events []string
+	for _, event := range webhook.Events {
+		events = append(events, string(event))
+	}
+
+	var team sql.NullInt64
+	if teamID != 0 {
+		team = sql.NullInt64{Int64: int64(teamID), Valid: true}
+	}
+
+	_, err = psql.Insert("outgoing_webhooks").
+		Columns("team_id", "name", "url", "events", "secret", "nonce").
+		Values(team, webhook.Name, webhook.URL, pq.Array(events), encryptedSecret, nonce).
+		Suffix(`
+			ON CONFLICT (COALESCE(team_id, 0), name) DO UPDATE SET
+				url = EXCLUDED.url,
+				events = EXCLUDED.events,
+				secret = EXCLUDED.secret,
+				nonce = EXCLUDED.nonce
+		`).
+		RunWith(repo.conn).
+		Exec()
+	return err
+}
+
+func (repo *outgoingWebhookRepository) DeleteWebhook(teamID int, name string) (bool, error) {
+	result, err := psql.Delete("outgoing_webhooks").
+		Where(webhookTeamEq(teamID)).
+		Where(sq.Eq{"name": name}).
+		RunWith(repo.conn).
+		Exec()
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// Deliveries returns the most recent deliveries to the webhook, newest
+// first.
+func (repo *outgoingWebhookRepository) Deliveries(teamID int, name string, limit int) ([]atc.WebhookDelivery, bool, error) {
+	var webhookID int
+	err := psql.Select("id").
+		From("outgoing_webhooks").
+		Where(webhookTeamEq(teamID)).
+		Where(sq.Eq{"name": name}).
+		RunWith(repo.conn).
+		QueryRow().
+		Scan(&webhookID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, false, nil
+		}
+
+		return nil, false, err
+	}
+
+	rows, err := psql.Select(
+		"id",
+		"event",
+		"status",
+		"attempts",
+		"response_status",
+		"last_error",
+		"created_at",
+		"next_attempt_at",
+		"delivered_at",
+	).
+		From("outgoing_webhook_deliveries").
+		Where(sq.Eq{"webhook_id": webhookID}).
+		OrderBy("id DESC").
+		Limit(uint64(limit)).
+		RunWith(repo.conn).
+		Query()
+	if err != nil {
+		return nil, false, err
+	}
+
+	defer Close(rows)
+
+	deliveries := []atc.WebhookDelivery{}
+	for rows.Next() {
+		var (
+			delivery       atc.WebhookDelivery
+			responseStatus sql.NullInt64
+			lastError      sql.NullString
+			createdAt      time.Time
+			nextAttemptAt  time.Time
+			deliveredAt    sql.NullTime
+		)
+
+		err := rows.Scan(
+			&delivery.ID,
+			&delivery.Event,
+			&delivery.Status,
+			&delivery.Attempts,
+			&responseStatus,
+			&lastError,
+			&createdAt,
+			&nextAttemptAt,
+			&deliveredAt,
+		)
+		if err != nil {
+			return nil, false, err
+		}
+
+		delivery.ResponseStatus = int(responseStatus.Int64)
+		delivery.Error = lastError.String
+		delivery.CreatedAt = createdAt.Unix()
+
+		if delivery.Status == atc.WebhookDeliveryPending {
+			delivery.NextAttemptAt = nextAttemptAt.Unix()
+		}
+
+		if deliveredAt.Valid {
+			delivery.DeliveredAt = deliveredAt.Time.Unix()
+		}
+
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, true, nil
+}
+
+// PendingDeliveries returns the pending deliveries which are due, oldest
+// first.
+func (repo *outgoingWebhookRepository) PendingDeliveries(limit int) ([]PendingWebhookDelivery, error) {
+	rows, err := psql.Select(
+		"d.id",
+		"d.event",
+		"d.payload",
+		"d.attempts",
+		"w.url",
+		"w.secret",
+		"w.nonce",
+	).
+		From("outgoing_webhook_deliveries d").
+		Join("outgoing_webhooks w ON w.id = d.webhook_id").
+		Where(sq.Eq{"d.status": atc.WebhookDeliveryPending}).
+		Where(sq.Expr("d.next_attempt_at <= now()")).
+		OrderBy("d.id").
+		Limit(uint64(limit)).
+		RunWith(repo.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	var deliveries []PendingWebhookDelivery
+	for rows.Next() {
+		var (
+			delivery PendingWebhookDelivery
+			payload  []byte
+			secret   string
+			nonce    sql.NullString
+		)
+
+		err := rows.Scan(
+			&delivery.ID,
+			&delivery.Event,
+			&payload,
+			&delivery.Attempts,
+			&delivery.URL,
+			&secret,
+			&nonce,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		var noncense *string
+		if nonce.Valid {
+			noncense = &nonce.String
+		}
+
+		decryptedSecret, err := repo.conn.EncryptionStrategy().Decrypt(secret, noncense)
+		if err != nil {
+			return nil, err
+		}
+
+		delivery.Payload = payload
+		delivery.Secret = string(decryptedSecret)
+
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, nil
+}
+
+func (repo *outgoingWebhookRepository) DeliverySucceeded(id int64, responseStatus int) error {
+	_, err := psql.Update("outgoing_webhook_deliveries").
+		Set("status", atc.WebhookDeliverySucceeded).
+		Set("attempts", sq.Expr("attempts + 1")).
+		Set("response_status", responseStatus).
+		Set("last_error", nil).
+		Set("delivered_at", sq.Expr("now()")).
+		Where(sq.Eq{"id": id}).
+		RunWith(repo.conn).
+		Exec()
+	return err
+}
+
+// DeliveryFailed records a failed attempt of the delivery. The delivery is
+// retried at retryAt, or marked as failed if retryAt is nil.
+func (repo *outgoingWebhookRepository) DeliveryFailed(id int64, responseStatus int, deliveryErr string, retryAt *time.Time) error {
+	var status sql.NullInt64
+	if responseStatus != 0 {
+		status = sql.NullInt64{Int64: int64(responseStatus), Valid: true}
+	}
+
+	update := psql.Update("outgoing_webhook_deliveries").
+		Set("attempts", sq.Expr("attempts + 1")).
+		Set("response_status", status).
+		Set("last_error", deliveryErr).
+		Where(sq.Eq{"id": id})
+
+	if retryAt != nil {
+		update = update.Set("next_attempt_at", *retryAt)
+	} else {
+		update = update.Set("status", atc.WebhookDeliveryFailed)
+	}
+
+	_, err := update.RunWith(repo.conn).Exec()
+	return err
+}
+
+// DeleteDeliveriesBefore deletes the succeeded and failed deliveries created
+// before the given time.
+func (repo *outgoingWebhookRepository) DeleteDeliveriesBefore(before time.Time) error {
+	_, err := psql.Delete("outgoing_webhook_deliveries").
+		Where(sq.NotEq{"status": atc.WebhookDeliveryPending}).
+		Where(sq.Lt{"created_at": before}).
+		RunWith(repo.conn).
+		Exec()
+	return err
+}
+
+// enqueueWebhookDeliveries queues deliveries of the event to each of the
+// team's and the cluster's webhooks which subscribe to it, and to the
+// firehose. The data is only built if there are any such webhooks or the
+// firehose is enabled.
+func enqueueWebhookDeliveries(tx Tx, teamID int, event atc.WebhookEvent, data func() (interface{}, error)) error {
+	rows, err := psql.Select("id").
+		From("outgoing_webhooks").
+		Where(sq.Or{
+			sq.Eq{"team_id": teamID},
+			sq.Eq{"team_id": nil},
+		}).
+		Where(sq.Expr("? = ANY(events)", string(event))).
+		RunWith(tx).
+		Query()
+	if err != nil {
+		return err
+	}
+
+	var webhookIDs []int
+	for rows.Next() {
+		var id int
+		err := rows.Scan(&id)
+		if err != nil {
+			Close(rows)
+			return err
+		}
+
+		webhookIDs = append(webhookIDs, id)
+	}
+
+	Close(rows)
+
+	if len(webhookIDs) == 0 && !atc.EnableFirehose {
+		return nil
+	}
+
+	payloadData, err := data()
+	if err != nil {
+		return err
+	}
+
+	err = enqueueFirehoseEvent(tx, teamID, event, payloadData)
+	if err != nil {
+		return err
+	}
+
+	if len(webhookIDs) == 0 {
+		return nil
+	}
+
+	payload, err := json.Marshal(atc.WebhookPayload{
+		Event: event,
+		Time:  time.Now().Unix(),
+		Data:  payloadData,
+	})
+	if err != nil {
+		return err
+	}
+
+	insert := psql.Insert("outgoing_webhook_deliveries").
+		Columns("webhook_id", "event", "payload")
+
+	for _, id := range webhookIDs {
+		insert = insert.Values(id, string(event), payload)
+	}
+
+	_, err = insert.RunWith(tx).Exec()
+	return err
+}
@@ -0,0 +1,210 @@
+package db_test
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("OutgoingWebhookRepository", func() {
+	var repository db.OutgoingWebhookRepository
+
+	BeforeEach(func() {
+		repository = db.NewOutgoingWebhookRepository(dbConn)
+	})
+
+	Describe("SaveWebhook", func() {
+		It("saves the team's and the cluster's webhooks separately", func() {
+			err := repository.SaveWebhook(defaultTeam.ID(), atc.OutgoingWebhook{
+				Name:   "some-webhook",
+				URL:    "https://example.com/team",
+				Secret: "team-secret",
+				Events: []atc.WebhookEvent{atc.WebhookEventBuildStarted},
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			err = repository.SaveWebhook(0, atc.OutgoingWebhook{
+				Name:   "some-webhook",
+				URL:    "https://example.com/cluster",
+				Secret: "cluster-secret",
+				Events: []atc.WebhookEvent{atc.WebhookEventWorkerStalled},
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			teamWebhooks, err := repository.Webhooks(defaultTeam.ID())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(teamWebhooks).To(Equal([]atc.OutgoingWebhook{
+				{
+					Name:   "some-webhook",
+					URL:    "https://example.com/team",
+					Events: []atc.WebhookEvent{atc.WebhookEventBuildStarted},
+				},
+			}))
+
+			clusterWebhooks, err := repository.Webhooks(0)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(clusterWebhooks).To(Equal([]atc.OutgoingWebhook{
+				{
+					Name:   "some-webhook",
+					URL:    "https://example.com/cluster",
+					Events: []atc.WebhookEvent{atc.WebhookEventWorkerStalled},
+				},
+			}))
+		})
+
+		It("replaces an existing webhook with the same name", func() {
+			webhook := atc.OutgoingWebhook{
+				Name:   "some-webhook",
+				URL:    "https://example.com/old",
+				Secret: "some-secret",
+				Events: []atc.WebhookEvent{atc.WebhookEventBuildStarted},
+			}
+
+			Expect(repository.SaveWebhook(defaultTeam.ID(), webhook)).To(Succeed())
+
+			webhook.URL = "https://example.com/new"
+			Expect(repository.SaveWebhook(defaultTeam.ID(), webhook)).To(Succeed())
+
+			webhooks, err := repository.Webhooks(defaultTeam.ID())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(webhooks).To(HaveLen(1))
+			Expect(webhooks[0].URL).To(Equal("https://example.com/new"))
+		})
+	})
+
+	Describe("DeleteWebhook", func() {
+		It("deletes the webhook", func() {
+			Expect(repository.SaveWebhook(defaultTeam.ID(), atc.OutgoingWebhook{
+				Name:   "some-webhook",
+				URL:    "https://example.com/hook",
+				Secret: "some-secret",
+				Events: []atc.WebhookEvent{atc.WebhookEventBuildStarted},
+			})).To(Succeed())
+
+			deleted, err := repository.DeleteWebhook(defaultTeam.ID(), "some-webhook")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(deleted).To(BeTrue())
+
+			deleted, err = repository.DeleteWebhook(defaultTeam.ID(), "some-webhook")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(deleted).To(BeFalse())
+		})
+	})
+
+	Describe("deliveries", func() {
+		BeforeEach(func() {
+			Expect(repository.SaveWebhook(defaultTeam.ID(), atc.OutgoingWebhook{
+				Name:   "team-webhook",
+				URL:    "https://example.com/team",
+				Secret: "team-secret",
+				Events: []atc.WebhookEvent{atc.WebhookEventBuildStarted, atc.WebhookEventBuildFinished},
+			})).To(Succeed())
+
+			Expect(repository.SaveWebhook(0, atc.OutgoingWebhook{
+				Name:   "cluster-webhook",
+				URL:    "https://example.com/cluster",
+				Secret: "cluster-secret",
+				Events: []atc.WebhookEvent{atc.WebhookEventBuildFinished},
+			})).To(Succeed())
+		})
+
+		Context("when a build starts and finishes", func() {
+			var build db.Build
+
+			BeforeEach(func() {
+				var err error
+				build, err = defaultJob.CreateBuild(defaultBuildCreatedBy)
+				Expect(err).ToNot(HaveOccurred())
+
+				started, err := build.Start(atc.Plan{})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(started).To(BeTrue())
+
+				Expect(build.Finish(db.BuildStatusSucceeded)).To(Succeed())
+			})
+
+			It("queues deliveries to the subscribed webhooks", func() {
+				deliveries, err := repository.PendingDeliveries(10)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(deliveries).To(HaveLen(3))
+
+				Expect(deliveries[0].Event).To(Equal(atc.WebhookEventBuildStarted))
+				Expect(deliveries[0].URL).To(Equal("https://example.com/team"))
+				Expect(deliveries[0].Secret).To(Equal("team-secret"))
+
+				var payload struct {
+					Event atc.WebhookEvent `json:"event"`
+					Data  atc.Build        `json:"data"`
+				}
+				Expect(json.Unmarshal(deliveries[0].Payload, &payload)).To(Succeed())
+				Expect(payload.Event).To(Equal(atc.WebhookEventBuildStarted))
+				Expect(payload.Data.ID).To(Equal(build.ID()))
+				Expect(payload.Data.JobName).To(Equal("some-job"))
+				Expect(payload.Data.Status).To(Equal(atc.StatusStarted))
+
+				var urls []string
+				for _, delivery := range deliveries[1:] {
+					Expect(delivery.Event).To(Equal(atc.WebhookEventBuildFinished))
+					urls = append(urls, delivery.URL)
+				}
+				Expect(urls).To(ConsistOf("https://example.com/team", "https://example.com/cluster"))
+			})
+
+			It("records the status of the deliveries", func() {
+				deliveries, err := repository.PendingDeliveries(10)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(repository.DeliverySucceeded(deliveries[0].ID, 200)).To(Succeed())
+
+				retryAt := time.Now().Add(time.Hour)
+				Expect(repository.DeliveryFailed(deliveries[1].ID, 502, "bad gateway", &retryAt)).To(Succeed())
+
+				pending, err := repository.PendingDeliveries(10)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(pending).To(HaveLen(1))
+
+				teamDeliveries, found, err := repository.Deliveries(defaultTeam.ID(), "team-webhook", 10)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(teamDeliveries).To(HaveLen(2))
+
+				Expect(teamDeliveries[1].Status).To(Equal(atc.WebhookDeliverySucceeded))
+				Expect(teamDeliveries[1].Attempts).To(Equal(1))
+				Expect(teamDeliveries[1].ResponseStatus).To(Equal(200))
+				Expect(teamDeliveries[1].DeliveredAt).ToNot(BeZero())
+			})
+
+			It("gives up on deliveries without a retry", func() {
+				deliveries, err := repository.PendingDeliveries(10)
+				Expect(err).ToNot(HaveOccurred())
+
+				for _, delivery := range deliveries {
+					Expect(repository.DeliveryFailed(delivery.ID, 0, "connection refused", nil)).To(Succeed())
+				}
+
+				pending, err := repository.PendingDeliveries(10)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(pending).To(BeEmpty())
+
+				clusterDeliveries, found, err := repository.Deliveries(0, "cluster-webhook", 10)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(clusterDeliveries).To(HaveLen(1))
+				Expect(clusterDeliveries[0].Status).To(Equal(atc.WebhookDeliveryFailed))
+				Expect(clusterDeliveries[0].Error).To(Equal("connection refused"))
+			})
+		})
+
+		Context("when the webhook does not exist", func() {
+			It("is not found", func() {
+				_, found, err := repository.Deliveries(defaultTeam.ID(), "bogus", 10)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+})
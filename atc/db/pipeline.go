@@ -31,6 +31,8 @@ import (
 // * nonce
 //
 // config must be encrypted, and thus must be added to atc/db/migration/encryption.go
+var ErrPipelineExistsInTeam = errors.New("team already has a pipeline with the same name and instance vars")
+
 var pipelineObjectTables = []string{
 	"jobs",
 	"resources",
@@ -61,8 +63,12 @@ type Pipeline interface {
 	ParentJobID() int
 	ParentBuildID() int
 	Groups() atc.GroupConfigs
+	VarDeclarations() atc.VarDeclarations
+	Notifications() atc.NotificationRules
 	VarSources() atc.VarSourceConfigs
 	Display() *atc.DisplayConfig
+	TeamWorkersOnly() bool
+	Exposure() atc.PipelineExposure
 	ConfigVersion() ConfigVersion
 	Config() (atc.Config, error)
 	Public() bool
@@ -111,6 +117,7 @@ type Pipeline interface {
 	Dashboard() ([]atc.JobSummary, error)
 
 	Expose() error
+	ExposeWith(atc.PipelineExposure) error
 	Hide() error
 
 	Paused() bool
@@ -120,6 +127,12 @@ type Pipeline interface {
 	Unpause() error
 
 	Archive() error
+	MoveToTeam(teamID int) error
+
+	StageConfig(atc.Config) (PipelineConfigVersion, error)
+	CandidateConfig() (PipelineConfigVersion, bool, error)
+	PromoteCandidateConfig() (bool, error)
+	DiscardCandidateConfig() (bool, error)
 
 	Destroy() error
 
@@ -129,23 +142,27 @@ type Pipeline interface {
 }
 
 type pipeline struct {
-	id            int
-	name          string
-	teamID        int
-	teamName      string
-	instanceVars  atc.InstanceVars
-	parentJobID   int
-	parentBuildID int
-	groups        atc.GroupConfigs
-	varSources    atc.VarSourceConfigs
-	display       *atc.DisplayConfig
-	configVersion ConfigVersion
-	paused        bool
-	pausedBy      string
-	pausedAt      time.Time
-	public        bool
-	archived      bool
-	lastUpdated   time.Time
+	id              int
+	name            string
+	teamID          int
+	teamName        string
+	instanceVars    atc.InstanceVars
+	parentJobID     int
+	parentBuildID   int
+	groups          atc.GroupConfigs
+	varDeclarations atc.VarDeclarations
+	notifications   atc.NotificationRules
+	varSources      atc.VarSourceConfigs
+	display         *atc.DisplayConfig
+	teamWorkersOnly bool
+	configVersion   ConfigVersion
+	paused          bool
+	pausedBy        string
+	pausedAt        time.Time
+	public          bool
+	exposure        atc.PipelineExposure
+	archived        bool
+	lastUpdated     time.Time
 
 	conn        Conn
 	lockFactory lock.LockFactory
@@ -158,6 +175,8 @@ var pipelinesQuery = psql.Select(`
 		p.id,
 		p.name,
 		p.groups,
+		p.var_declarations,
+		p.notifications,
 		p.var_sources,
 		p.display,
 		p.nonce,
@@ -172,10 +191,23 @@ var pipelinesQuery = psql.Select(`
 		p.parent_build_id,
 		p.instance_vars,
 		p.paused_by,
-		p.paused_at`).
+		p.paused_at,
+		p.team_workers_only,
+		p.public_jobs,
+		p.public_status_only`).
 	From("pipelines p").
 	LeftJoin("teams t ON p.team_id = t.id")
 
+// publicJobCondition matches the jobs, aliased as j, that are visible to
+// everyone because their pipeline, aliased as p, is exposed.
+var publicJobCondition = sq.And{
+	sq.Eq{"p.public": true},
+	sq.Or{
+		sq.Eq{"p.public_jobs": nil},
+		sq.Expr("j.name = ANY(p.public_jobs)"),
+	},
+}
+
 func newPipeline(conn Conn, lockFactory lock.LockFactory) *pipeline {
 	return &pipeline{
 		conn:        conn,
@@ -183,23 +215,27 @@ func newPipeline(conn Conn, lockFactory lock.LockFactory) *pipeline {
 	}
 }
 
-func (p *pipeline) ID() int                          { return p.id }
-func (p *pipeline) Name() string                     { return p.name }
-func (p *pipeline) TeamID() int                      { return p.teamID }
-func (p *pipeline) TeamName() string                 { return p.teamName }
-func (p *pipeline) ParentJobID() int                 { return p.parentJobID }
-func (p *pipeline) ParentBuildID() int               { return p.parentBuildID }
-func (p *pipeline) InstanceVars() atc.InstanceVars   { return p.instanceVars }
-func (p *pipeline) Groups() atc.GroupConfigs         { return p.groups }
-func (p *pipeline) VarSources() atc.VarSourceConfigs { return p.varSources }
-func (p *pipeline) Display() *atc.DisplayConfig      { return p.display }
-func (p *pipeline) ConfigVersion() ConfigVersion     { return p.configVersion }
-func (p *pipeline) Public() bool                     { return p.public }
-func (p *pipeline) Paused() bool                     { return p.paused }
-func (p *pipeline) PausedAt() time.Time              { return p.pausedAt }
-func (p *pipeline) PausedBy() string                 { return p.pausedBy }
-func (p *pipeline) Archived() bool                   { return p.archived }
-func (p *pipeline) LastUpdated() time.Time           { return p.lastUpdated }
+func (p *pipeline) ID() int                              { return p.id }
+func (p *pipeline) Name() string                         { return p.name }
+func (p *pipeline) TeamID() int                          { return p.teamID }
+func (p *pipeline) TeamName() string                     { return p.teamName }
+func (p *pipeline) ParentJobID() int                     { return p.parentJobID }
+func (p *pipeline) ParentBuildID() int                   { return p.parentBuildID }
+func (p *pipeline) InstanceVars() atc.InstanceVars       { return p.instanceVars }
+func (p *pipeline) Groups() atc.GroupConfigs             { return p.groups }
+func (p *pipeline) VarDeclarations() atc.VarDeclarations { return p.varDeclarations }
+func (p *pipeline) Notifications() atc.NotificationRules { return p.notifications }
+func (p *pipeline) VarSources() atc.VarSourceConfigs     { return p.varSources }
+func (p *pipeline) Display() *atc.DisplayConfig          { return p.display }
+func (p *pipeline) TeamWorkersOnly() bool                { return p.teamWorkersOnly }
+func (p *pipeline) ConfigVersion() ConfigVersion         { return p.configVersion }
+func (p *pipeline) Public() bool                         { return p.public }
+func (p *pipeline) Exposure() atc.PipelineExposure       { return p.exposure }
+func (p *pipeline) Paused() bool                         { return p.paused }
+func (p *pipeline) PausedAt() time.Time                  { return p.pausedAt }
+func (p *pipeline) PausedBy() string                     { return p.pausedBy }
+func (p *pipeline) Archived() bool                       { return p.archived }
+func (p *pipeline) LastUpdated() time.Time               { return p.lastUpdated }
 
 func (p *pipeline) CheckPaused() (bool, error) {
 	var paused bool
@@ -261,14 +297,20 @@ func (p *pipeline) Config() (atc.Config, error) {
 
 	config := atc.Config{
 		Groups:        p.Groups(),
+		Vars:          p.VarDeclarations(),
 		VarSources:    p.VarSources(),
 		Resources:     resources.Configs(),
 		ResourceTypes: resourceTypes.Configs(),
 		Prototypes:    prototypes.Configs(),
 		Jobs:          jobConfigs,
+		Notifications: p.Notifications(),
 		Display:       p.Display(),
 	}
 
+	if p.TeamWorkersOnly() {
+		config.Workers = &atc.WorkersConfig{TeamOnly: true}
+	}
+
 	return config, nil
 }
 
@@ -740,12 +782,15 @@ func (p *pipeline) archive(tx Tx) error {
 		}
 	}
 
-	return nil
+	_, err = discardCandidateConfig(tx, p.id)
+	return err
 }
 
 func (p *pipeline) Hide() error {
 	_, err := psql.Update("pipelines").
 		Set("public", false).
+		Set("public_jobs", nil).
+		Set("public_status_only", false).
 		Where(sq.Eq{
 			"id": p.id,
 		}).
@@ -756,8 +801,21 @@ func (p *pipeline) Hide() error {
 }
 
 func (p *pipeline) Expose() error {
+	return p.ExposeWith(atc.PipelineExposure{})
+}
+
+// ExposeWith exposes the pipeline, restricting what of it is visible to users
+// who are not members of its team.
+func (p *pipeline) ExposeWith(exposure atc.PipelineExposure) error {
+	var publicJobs interface{}
+	if len(exposure.Jobs) > 0 {
+		publicJobs = pq.Array(exposure.Jobs)
+	}
+
 	_, err := psql.Update("pipelines").
 		Set("public", true).
+		Set("public_jobs", publicJobs).
+		Set("public_status_only", exposure.StatusOnly).
 		Where(sq.Eq{
 			"id": p.id,
 		}).
@@ -1113,14 +1171,17 @@ func (p *pipeline) CreateStartedBuild(plan atc.Plan) (Build, error) {
 
 // Variables creates variables for this pipeline. If this pipeline has its own
 // var_sources, a vars.MultiVars containing all pipeline specific var_sources
-// plug the global variables, otherwise just return the global variables.
+// plug the global variables, otherwise just return the global variables. The
+// defaults of the pipeline's declared vars are used for any var which is not
+// found otherwise.
 func (p *pipeline) Variables(logger lager.Logger, globalSecrets creds.Secrets, varSourcePool creds.VarSourcePool) (vars.Variables, error) {
 	globalVars := creds.NewVariables(globalSecrets, p.TeamName(), p.Name(), false)
 	namedVarsMap := vars.NamedVariables{}
+	defaults := p.varDeclarations.Defaults()
 
 	// It's safe to add NamedVariables to allVars via an array here, because
 	// a map is passed by reference.
-	allVars := vars.NewMultiVars([]vars.Variables{namedVarsMap, globalVars})
+	allVars := vars.NewMultiVars([]vars.Variables{namedVarsMap, globalVars, defaults})
 
 	orderedVarSources, err := p.varSources.OrderByDependency()
 	if err != nil {
@@ -1150,9 +1211,9 @@ func (p *pipeline) Variables(logger lager.Logger, globalSecrets creds.Secrets, v
 		namedVarsMap[cm.Name] = creds.NewVariables(secrets, p.TeamName(), p.Name(), true)
 	}
 
-	// If there is no var_source from the pipeline, then just return the global
-	// vars.
-	if len(namedVarsMap) == 0 {
+	// If there is no var_source from the pipeline and no defaults, then just
+	// return the global vars.
+	if len(namedVarsMap) == 0 && len(defaults) == 0 {
 		return globalVars, nil
 	}
 
@@ -1196,6 +1257,123 @@ func (p *pipeline) SetParentIDs(jobID, buildID int) error {
 	return tx.Commit()
 }
 
+// MoveToTeam moves the pipeline to another team along with its builds, the
+// containers and volumes of its builds and the volumes of its task caches.
+// Resource versions and caches don't belong to a team, so they are kept as
+// they are. The pipeline is ordered after the team's pipelines, or after the
+// other instances of the pipeline in the team.
+func (p *pipeline) MoveToTeam(teamID int) error {
+	if teamID == p.teamID {
+		return nil
+	}
+
+	tx, err := p.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	var teamName string
+	err = psql.Select("name").
+		From("teams").
+		Where(sq.Eq{"id": teamID}).
+		RunWith(tx).
+		QueryRow().
+		Scan(&teamName)
+	if err != nil {
+		return err
+	}
+
+	var ordering, secondaryOrdering sql.NullInt64
+	err = psql.Select("max(ordering)", "max(secondary_ordering)").
+		From("pipelines").
+		Where(sq.Eq{
+			"team_id": teamID,
+			"name":    p.name,
+		}).
+		RunWith(tx).
+		QueryRow().
+		Scan(&ordering, &secondaryOrdering)
+	if err != nil {
+		return err
+	}
+
+	update := psql.Update("pipelines").
+		Set("team_id", teamID).
+		Set("last_updated", sq.Expr("now()"))
+
+	if ordering.Valid {
+		update = update.
+			Set("ordering", ordering.Int64).
+			Set("secondary_ordering", secondaryOrdering.Int64+1)
+	} else {
+		update = update.
+			Set("ordering", sq.Expr("(SELECT COALESCE(max(ordering), 0) + 1 FROM pipelines WHERE team_id = ?)", teamID)).
+			Set("secondary_ordering", 1)
+	}
+
+	_, err = update.
+		Where(sq.Eq{"id": p.id}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == pqUniqueViolationErrCode {
+			return ErrPipelineExistsInTeam
+		}
+
+		return err
+	}
+
+	_, err = psql.Update("builds").
+		Set("team_id", teamID).
+		Where(sq.Eq{"pipeline_id": p.id}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	_, err = psql.Update("volumes").
+		Set("team_id", teamID).
+		Where(sq.Or{
+			sq.Expr("container_id IN (SELECT id FROM containers WHERE meta_pipeline_id = ?)", p.id),
+			sq.Expr(`worker_task_cache_id IN (
+				SELECT wtc.id
+				FROM worker_task_caches wtc
+				JOIN task_caches tc ON tc.id = wtc.task_cache_id
+				JOIN jobs j ON j.id = tc.job_id
+				WHERE j.pipeline_id = ?
+			)`, p.id),
+		}).
+		Where(sq.NotEq{"team_id": nil}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	_, err = psql.Update("containers").
+		Set("team_id", teamID).
+		Where(sq.Eq{"meta_pipeline_id": p.id}).
+		Where(sq.NotEq{"team_id": nil}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	p.teamID = teamID
+	p.teamName = teamName
+
+	return nil
+}
+
 func getNewBuildNameForJob(tx Tx, jobName string, pipelineID int) (string, int, error) {
 	var buildName string
 	var jobID int
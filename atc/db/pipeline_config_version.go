@@ -0,0 +1,253 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
+)
+
+type PipelineConfigVersionStatus string
+
+const (
+	PipelineConfigVersionCandidate PipelineConfigVersionStatus = "candidate"
+	PipelineConfigVersionPromoted  PipelineConfigVersionStatus = "promoted"
+	PipelineConfigVersionDiscarded PipelineConfigVersionStatus = "discarded"
+)
+
+// PipelineConfigVersion is a config which was staged for a pipeline alongside
+// its active config. A candidate is based on the config version which was
+// active when it was staged, and can only be promoted while that version is
+// still active.
+type PipelineConfigVersion struct {
+	ID          int
+	Status      PipelineConfigVersionStatus
+	Config      atc.Config
+	BaseVersion ConfigVersion
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+var pipelineConfigVersionsQuery = psql.Select(
+	"v.id",
+	"v.status",
+	"v.config",
+	"v.nonce",
+	"v.base_version",
+	"v.created_at",
+	"v.updated_at",
+).From("pipeline_config_versions v")
+
+// StageConfig stages the config as the pipeline's candidate config, replacing
+// any existing candidate.
+func (p *pipeline) StageConfig(config atc.Config) (PipelineConfigVersion, error) {
+	tx, err := p.conn.Begin()
+	if err != nil {
+		return PipelineConfigVersion{}, err
+	}
+
+	defer Rollback(tx)
+
+	_, err = discardCandidateConfig(tx, p.id)
+	if err != nil {
+		return PipelineConfigVersion{}, err
+	}
+
+	payload, err := json.Marshal(config)
+	if err != nil {
+		return PipelineConfigVersion{}, err
+	}
+
+	encryptedPayload, nonce, err := p.conn.EncryptionStrategy().Encrypt(payload)
+	if err != nil {
+		return PipelineConfigVersion{}, err
+	}
+
+	var id int
+	err = psql.Insert("pipeline_config_versions").
+		SetMap(map[string]interface{}{
+			"pipeline_id":  p.id,
+			"status":       PipelineConfigVersionCandidate,
+			"config":       encryptedPayload,
+			"nonce":        nonce,
+			"base_version": sq.Expr("(SELECT version FROM pipelines WHERE id = ?)", p.id),
+		}).
+		Suffix("RETURNING id").
+		RunWith(tx).
+		QueryRow().
+		Scan(&id)
+	if err != nil {
+		return PipelineConfigVersion{}, err
+	}
+
+	candidate, err := p.scanPipelineConfigVersion(
+		pipelineConfigVersionsQuery.
+			Where(sq.Eq{"v.id": id}).
+			RunWith(tx).
+			QueryRow(),
+	)
+	if err != nil {
+		return PipelineConfigVersion{}, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return PipelineConfigVersion{}, err
+	}
+
+	return candidate, nil
+}
+
+// CandidateConfig returns the pipeline's candidate config, if one is staged.
+func (p *pipeline) CandidateConfig() (PipelineConfigVersion, bool, error) {
+	candidate, err := p.scanPipelineConfigVersion(
+		pipelineConfigVersionsQuery.
+			Where(sq.Eq{
+				"v.pipeline_id": p.id,
+				"v.status":      PipelineConfigVersionCandidate,
+			}).
+			RunWith(p.conn).
+			QueryRow(),
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return PipelineConfigVersion{}, false, nil
+		}
+
+		return PipelineConfigVersion{}, false, err
+	}
+
+	return candidate, true, nil
+}
+
+// PromoteCandidateConfig saves the candidate config as the pipeline's active
+// config in the same transaction as marking it as promoted. It fails with
+// ErrConfigComparisonFailed if the active config has changed since the
+// candidate was staged.
+func (p *pipeline) PromoteCandidateConfig() (bool, error) {
+	tx, err := p.conn.Begin()
+	if err != nil {
+		return false, err
+	}
+
+	defer Rollback(tx)
+
+	candidate, err := p.scanPipelineConfigVersion(
+		pipelineConfigVersionsQuery.
+			Where(sq.Eq{
+				"v.pipeline_id": p.id,
+				"v.status":      PipelineConfigVersionCandidate,
+			}).
+			Suffix("FOR UPDATE").
+			RunWith(tx).
+			QueryRow(),
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	nullID := sql.NullInt64{Valid: false}
+	_, _, err = savePipeline(
+		tx,
+		atc.PipelineRef{Name: p.name, InstanceVars: p.instanceVars},
+		candidate.Config,
+		candidate.BaseVersion,
+		true,
+		p.teamID,
+		nullID,
+		nullID,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	_, err = psql.Update("pipeline_config_versions").
+		Set("status", PipelineConfigVersionPromoted).
+		Set("updated_at", sq.Expr("now()")).
+		Where(sq.Eq{"id": candidate.ID}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return false, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// DiscardCandidateConfig discards the pipeline's candidate config, leaving the
+// active config as-is.
+func (p *pipeline) DiscardCandidateConfig() (bool, error) {
+	return discardCandidateConfig(p.conn, p.id)
+}
+
+func discardCandidateConfig(runner sq.Runner, pipelineID int) (bool, error) {
+	result, err := psql.Update("pipeline_config_versions").
+		Set("status", PipelineConfigVersionDiscarded).
+		Set("updated_at", sq.Expr("now()")).
+		Where(sq.Eq{
+			"pipeline_id": pipelineID,
+			"status":      PipelineConfigVersionCandidate,
+		}).
+		RunWith(runner).
+		Exec()
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected > 0, nil
+}
+
+func (p *pipeline) scanPipelineConfigVersion(row scannable) (PipelineConfigVersion, error) {
+	var (
+		version PipelineConfigVersion
+		config  string
+		nonce   sql.NullString
+	)
+
+	err := row.Scan(
+		&version.ID,
+		&version.Status,
+		&config,
+		&nonce,
+		&version.BaseVersion,
+		&version.CreatedAt,
+		&version.UpdatedAt,
+	)
+	if err != nil {
+		return PipelineConfigVersion{}, err
+	}
+
+	var noncense *string
+	if nonce.Valid {
+		noncense = &nonce.String
+	}
+
+	decryptedConfig, err := p.conn.EncryptionStrategy().Decrypt(config, noncense)
+	if err != nil {
+		return PipelineConfigVersion{}, err
+	}
+
+	err = json.Unmarshal(decryptedConfig, &version.Config)
+	if err != nil {
+		return PipelineConfigVersion{}, err
+	}
+
+	return version, nil
+}
@@ -2,7 +2,6 @@ package db
 
 import (
 	"database/sql"
-	"fmt"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/concourse/concourse/atc/db/lock"
@@ -133,7 +132,7 @@ func (p *pipelineLifecycle) RemoveBuildEventsForDeletedPipelines() error {
 	}
 
 	for _, id := range idsToDelete {
-		_, err = p.conn.Exec(fmt.Sprintf("DROP TABLE IF EXISTS pipeline_build_events_%d", id))
+		_, err = p.conn.Exec("SELECT drop_pipeline_build_events($1)", id)
 		if err != nil {
 			return err
 		}
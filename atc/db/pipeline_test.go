@@ -54,6 +54,9 @@ var _ = Describe("Pipeline", func() {
 			Display: &atc.DisplayConfig{
 				BackgroundImage: "background.jpg",
 			},
+			Workers: &atc.WorkersConfig{
+				TeamOnly: true,
+			},
 			Jobs: atc.JobConfigs{
 				{
 					Name: "job-name",
@@ -363,6 +366,77 @@ var _ = Describe("Pipeline", func() {
 		})
 	})
 
+	Describe("MoveToTeam", func() {
+		var (
+			otherTeam db.Team
+			build     db.Build
+		)
+
+		BeforeEach(func() {
+			var err error
+			otherTeam, err = teamFactory.CreateTeam(atc.Team{Name: "some-other-team"})
+			Expect(err).ToNot(HaveOccurred())
+
+			job, found, err := pipeline.Job("job-name")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			build, err = job.CreateBuild(defaultBuildCreatedBy)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("moves the pipeline and its builds to the team", func() {
+			Expect(pipeline.MoveToTeam(otherTeam.ID())).To(Succeed())
+			Expect(pipeline.TeamID()).To(Equal(otherTeam.ID()))
+			Expect(pipeline.TeamName()).To(Equal("some-other-team"))
+
+			_, found, err := team.Pipeline(atc.PipelineRef{Name: "fake-pipeline"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+
+			moved, found, err := otherTeam.Pipeline(atc.PipelineRef{Name: "fake-pipeline"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(moved.ID()).To(Equal(pipeline.ID()))
+
+			found, err = build.Reload()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(build.TeamID()).To(Equal(otherTeam.ID()))
+			Expect(build.TeamName()).To(Equal("some-other-team"))
+		})
+
+		It("orders the pipeline after the team's pipelines", func() {
+			_, _, err := otherTeam.SavePipeline(atc.PipelineRef{Name: "other-pipeline"}, atc.Config{}, db.ConfigVersion(0), false)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(pipeline.MoveToTeam(otherTeam.ID())).To(Succeed())
+
+			pipelines, err := otherTeam.Pipelines()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pipelines).To(HaveLen(2))
+			Expect(pipelines[0].Name()).To(Equal("other-pipeline"))
+			Expect(pipelines[1].Name()).To(Equal("fake-pipeline"))
+		})
+
+		Context("when the team has a pipeline with the same name", func() {
+			BeforeEach(func() {
+				_, _, err := otherTeam.SavePipeline(atc.PipelineRef{Name: "fake-pipeline"}, atc.Config{}, db.ConfigVersion(0), false)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("leaves the pipeline where it is", func() {
+				Expect(pipeline.MoveToTeam(otherTeam.ID())).To(Equal(db.ErrPipelineExistsInTeam))
+				Expect(pipeline.TeamID()).To(Equal(team.ID()))
+
+				found, err := build.Reload()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(build.TeamID()).To(Equal(team.ID()))
+			})
+		})
+	})
+
 	Describe("Unpause", func() {
 		JustBeforeEach(func() {
 			Expect(pipeline.Unpause()).To(Succeed())
@@ -2243,6 +2317,129 @@ var _ = Describe("Pipeline", func() {
 		})
 	})
 
+	Describe("candidate configs", func() {
+		var candidateConfig atc.Config
+
+		BeforeEach(func() {
+			candidateConfig = atc.Config{
+				Jobs: atc.JobConfigs{
+					{
+						Name: "some-candidate-job",
+					},
+				},
+			}
+		})
+
+		Describe("StageConfig", func() {
+			It("stages the config based on the active config version", func() {
+				candidate, err := pipeline.StageConfig(candidateConfig)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(candidate.Status).To(Equal(db.PipelineConfigVersionCandidate))
+				Expect(candidate.Config).To(Equal(candidateConfig))
+				Expect(candidate.BaseVersion).To(Equal(pipeline.ConfigVersion()))
+
+				found, err := pipeline.Reload()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(pipeline.Config()).To(Equal(pipelineConfig))
+			})
+
+			It("replaces an existing candidate", func() {
+				_, err := pipeline.StageConfig(pipelineConfig)
+				Expect(err).ToNot(HaveOccurred())
+
+				staged, err := pipeline.StageConfig(candidateConfig)
+				Expect(err).ToNot(HaveOccurred())
+
+				candidate, found, err := pipeline.CandidateConfig()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(candidate.ID).To(Equal(staged.ID))
+				Expect(candidate.Config).To(Equal(candidateConfig))
+			})
+		})
+
+		Describe("PromoteCandidateConfig", func() {
+			Context("when a candidate is staged", func() {
+				BeforeEach(func() {
+					_, err := pipeline.StageConfig(candidateConfig)
+					Expect(err).ToNot(HaveOccurred())
+				})
+
+				It("saves the candidate as the active config", func() {
+					promoted, err := pipeline.PromoteCandidateConfig()
+					Expect(err).ToNot(HaveOccurred())
+					Expect(promoted).To(BeTrue())
+
+					_, found, err := pipeline.Job("some-candidate-job")
+					Expect(err).ToNot(HaveOccurred())
+					Expect(found).To(BeTrue())
+
+					_, found, err = pipeline.CandidateConfig()
+					Expect(err).ToNot(HaveOccurred())
+					Expect(found).To(BeFalse())
+				})
+
+				Context("when the active config has changed since it was staged", func() {
+					BeforeEach(func() {
+						_, _, err := team.SavePipeline(atc.PipelineRef{Name: "fake-pipeline"}, pipelineConfig, pipeline.ConfigVersion(), false)
+						Expect(err).ToNot(HaveOccurred())
+					})
+
+					It("fails without promoting the candidate", func() {
+						_, err := pipeline.PromoteCandidateConfig()
+						Expect(err).To(Equal(db.ErrConfigComparisonFailed))
+
+						_, found, err := pipeline.Job("some-candidate-job")
+						Expect(err).ToNot(HaveOccurred())
+						Expect(found).To(BeFalse())
+
+						_, found, err = pipeline.CandidateConfig()
+						Expect(err).ToNot(HaveOccurred())
+						Expect(found).To(BeTrue())
+					})
+				})
+			})
+
+			Context("when no candidate is staged", func() {
+				It("returns false", func() {
+					promoted, err := pipeline.PromoteCandidateConfig()
+					Expect(err).ToNot(HaveOccurred())
+					Expect(promoted).To(BeFalse())
+				})
+			})
+		})
+
+		Describe("DiscardCandidateConfig", func() {
+			BeforeEach(func() {
+				_, err := pipeline.StageConfig(candidateConfig)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("discards the candidate", func() {
+				discarded, err := pipeline.DiscardCandidateConfig()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(discarded).To(BeTrue())
+
+				_, found, err := pipeline.CandidateConfig()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+
+				discarded, err = pipeline.DiscardCandidateConfig()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(discarded).To(BeFalse())
+			})
+
+			It("is discarded when the pipeline is archived", func() {
+				Expect(pipeline.Archive()).To(Succeed())
+
+				_, found, err := pipeline.CandidateConfig()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+
 	Context("Config", func() {
 		It("should return config correctly", func() {
 			Expect(pipeline.Config()).To(Equal(pipelineConfig))
@@ -0,0 +1,150 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// MinimumPostgresVersion is the oldest version of PostgreSQL the migrations
+// and queries are known to work with, as reported by server_version_num.
+const MinimumPostgresVersion = 110000
+
+// MinimumStatementTimeout is the shortest statement_timeout which leaves
+// migrations and garbage collection time to finish. A statement_timeout of
+// zero, meaning none, is fine too.
+const MinimumStatementTimeout = time.Hour
+
+// RequiredExtensions are the extensions the migrations rely on. The triggers
+// are written in PL/pgSQL, which is installed by default but can be dropped.
+var RequiredExtensions = []string{"plpgsql"}
+
+// PreflightRequirements are the requirements on the database which depend on
+// how the ATC is configured.
+type PreflightRequirements struct {
+	// Connections is how many connections the ATC opens at most.
+	Connections int
+}
+
+// PreflightError lists what is wrong with the database, each with what to do
+// about it.
+type PreflightError struct {
+	Problems []string
+}
+
+func (err PreflightError) Error() string {
+	return "database failed preflight checks:\n  - " + strings.Join(err.Problems, "\n  - ")
+}
+
+// Preflight checks the database is one Concourse can run against before
+// migrating it, so that startup fails with what to fix rather than partway
+// through a migration or later on.
+func Preflight(driver, dsn string, requirements PreflightRequirements) error {
+	sqlDB, err := sql.Open(driver, dsn)
+	if err != nil {
+		return err
+	}
+
+	defer sqlDB.Close()
+
+	var (
+		version             string
+		versionNum          int
+		encoding            string
+		maxConnections      int
+		reservedConnections int
+		statementTimeoutMS  int64
+	)
+
+	err = sqlDB.QueryRow(`
+		SELECT
+			current_setting('server_version'),
+			current_setting('server_version_num')::int,
+			current_setting('server_encoding'),
+			current_setting('max_connections')::int,
+			current_setting('superuser_reserved_connections')::int,
+			(SELECT setting::bigint FROM pg_settings WHERE name = 'statement_timeout')
+	`).Scan(&version, &versionNum, &encoding, &maxConnections, &reservedConnections, &statementTimeoutMS)
+	if err != nil {
+		return fmt.Errorf("read settings: %w", err)
+	}
+
+	var problems []string
+
+	if versionNum < MinimumPostgresVersion {
+		problems = append(problems, fmt.Sprintf(
+			"PostgreSQL %s is not supported; upgrade to PostgreSQL %d or later",
+			version,
+			MinimumPostgresVersion/10000,
+		))
+	}
+
+	for _, extension := range RequiredExtensions {
+		var installed bool
+		err = sqlDB.QueryRow(`SELECT EXISTS (SELECT 1 FROM pg_extension WHERE extname = $1)`, extension).Scan(&installed)
+		if err != nil {
+			return fmt.Errorf("check extension %s: %w", extension, err)
+		}
+
+		if !installed {
+			problems = append(problems, fmt.Sprintf(
+				"extension %s is not installed; install it with CREATE EXTENSION %s",
+				extension,
+				extension,
+			))
+		}
+	}
+
+	if encoding != "UTF8" {
+		problems = append(problems, fmt.Sprintf(
+			"the database is encoded in %s; recreate it with ENCODING 'UTF8'",
+			encoding,
+		))
+	}
+
+	// the version of the collation is only tracked from PostgreSQL 15 on
+	if versionNum >= 150000 {
+		var recorded, actual sql.NullString
+		err = sqlDB.QueryRow(`
+			SELECT datcollversion, pg_database_collation_actual_version(oid)
+			FROM pg_database
+			WHERE datname = current_database()
+		`).Scan(&recorded, &actual)
+		if err != nil {
+			return fmt.Errorf("check collation: %w", err)
+		}
+
+		if recorded.Valid && actual.Valid && recorded.String != actual.String {
+			problems = append(problems, fmt.Sprintf(
+				"the database was created with version %s of its collation, but the server now has version %s, which can leave indexes on text corrupt; run REINDEX DATABASE and then ALTER DATABASE ... REFRESH COLLATION VERSION",
+				recorded.String,
+				actual.String,
+			))
+		}
+	}
+
+	available := maxConnections - reservedConnections
+	if available < requirements.Connections {
+		problems = append(problems, fmt.Sprintf(
+			"max_connections allows for %d connections, but this ATC alone opens up to %d; raise max_connections to allow for every ATC, or lower --api-max-conns and --backend-max-conns",
+			available,
+			requirements.Connections,
+		))
+	}
+
+	statementTimeout := time.Duration(statementTimeoutMS) * time.Millisecond
+	if statementTimeout != 0 && statementTimeout < MinimumStatementTimeout {
+		problems = append(problems, fmt.Sprintf(
+			"statement_timeout is %s, which cuts off migrations and garbage collection; set it to 0 or at least %s for the user Concourse connects as",
+			statementTimeout,
+			MinimumStatementTimeout,
+		))
+	}
+
+	if len(problems) > 0 {
+		return PreflightError{Problems: problems}
+	}
+
+	return nil
+}
@@ -0,0 +1,79 @@
+package db_test
+
+import (
+	"errors"
+
+	"github.com/concourse/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Preflight", func() {
+	var requirements db.PreflightRequirements
+
+	BeforeEach(func() {
+		requirements = db.PreflightRequirements{
+			Connections: 10,
+		}
+	})
+
+	preflight := func() error {
+		return db.Preflight("postgres", postgresRunner.DataSourceName(), requirements)
+	}
+
+	problems := func() []string {
+		var preflightErr db.PreflightError
+		Expect(errors.As(preflight(), &preflightErr)).To(BeTrue())
+		return preflightErr.Problems
+	}
+
+	It("passes a database Concourse can run against", func() {
+		Expect(preflight()).To(Succeed())
+	})
+
+	Context("when the ATC needs more connections than the server allows", func() {
+		BeforeEach(func() {
+			requirements.Connections = 100000
+		})
+
+		It("fails saying so", func() {
+			Expect(problems()).To(ConsistOf(ContainSubstring("raise max_connections")))
+		})
+	})
+
+	Context("when statements time out too soon", func() {
+		BeforeEach(func() {
+			_, err := dbConn.Exec(`ALTER DATABASE testdb SET statement_timeout = '30s'`)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("fails saying so", func() {
+			Expect(problems()).To(ConsistOf(ContainSubstring("statement_timeout is 30s")))
+		})
+	})
+
+	Context("when statements don't time out", func() {
+		BeforeEach(func() {
+			_, err := dbConn.Exec(`ALTER DATABASE testdb SET statement_timeout = 0`)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("passes", func() {
+			Expect(preflight()).To(Succeed())
+		})
+	})
+
+	Context("when a required extension is missing", func() {
+		BeforeEach(func() {
+			db.RequiredExtensions = append(db.RequiredExtensions, "some_missing_extension")
+		})
+
+		AfterEach(func() {
+			db.RequiredExtensions = db.RequiredExtensions[:len(db.RequiredExtensions)-1]
+		})
+
+		It("fails saying how to install it", func() {
+			Expect(problems()).To(ConsistOf(ContainSubstring("CREATE EXTENSION some_missing_extension")))
+		})
+	})
+})
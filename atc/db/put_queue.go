@@ -0,0 +1,108 @@
+package db
+
+import (
+	"github.com/concourse/concourse/atc"
+)
+
+// PutQueue lines up the puts to a resource config which are serialized, so
+// that they run one at a time in the order they joined, whichever build or
+// pipeline they come from.
+//
+//counterfeiter:generate . PutQueue
+type PutQueue interface {
+	// Join puts the step of the build at the back of the queue. A step which
+	// joins again, e.g. once the build is resumed, keeps its place.
+	Join(queue string, buildID int, planID atc.PlanID) (PutQueueEntry, error)
+}
+
+//counterfeiter:generate . PutQueueEntry
+type PutQueueEntry interface {
+	// IsNext returns whether every step which joined the queue before this one
+	// has left it, or has had its build finish.
+	IsNext() (bool, error)
+
+	Leave() error
+}
+
+type putQueue struct {
+	conn Conn
+}
+
+func NewPutQueue(conn Conn) PutQueue {
+	return &putQueue{
+		conn: conn,
+	}
+}
+
+func (q *putQueue) Join(queue string, buildID int, planID atc.PlanID) (PutQueueEntry, error) {
+	tx, err := q.conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Rollback(tx)
+
+	// the steps of finished builds never got to leave the queue
+	_, err = tx.Exec(`
+		DELETE FROM put_queue q
+		USING builds b
+		WHERE q.queue = $1
+		AND b.id = q.build_id
+		AND b.completed
+	`, queue)
+	if err != nil {
+		return nil, err
+	}
+
+	var id int
+	err = tx.QueryRow(`
+		INSERT INTO put_queue (queue, build_id, plan_id)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (queue, build_id, plan_id) DO UPDATE SET queue = EXCLUDED.queue
+		RETURNING id
+	`, queue, buildID, string(planID)).Scan(&id)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return &putQueueEntry{
+		id:    id,
+		queue: queue,
+		conn:  q.conn,
+	}, nil
+}
+
+type putQueueEntry struct {
+	id    int
+	queue string
+	conn  Conn
+}
+
+func (e *putQueueEntry) IsNext() (bool, error) {
+	var next bool
+	err := e.conn.QueryRow(`
+		SELECT NOT EXISTS (
+			SELECT 1
+			FROM put_queue q
+			JOIN builds b ON b.id = q.build_id
+			WHERE q.queue = $1
+			AND q.id < $2
+			AND NOT b.completed
+		)
+	`, e.queue, e.id).Scan(&next)
+	if err != nil {
+		return false, err
+	}
+
+	return next, nil
+}
+
+func (e *putQueueEntry) Leave() error {
+	_, err := e.conn.Exec(`DELETE FROM put_queue WHERE id = $1`, e.id)
+	return err
+}
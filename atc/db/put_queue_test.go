@@ -0,0 +1,80 @@
+package db_test
+
+import (
+	"github.com/concourse/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PutQueue", func() {
+	var (
+		queue db.PutQueue
+
+		firstBuild  db.Build
+		secondBuild db.Build
+
+		first  db.PutQueueEntry
+		second db.PutQueueEntry
+	)
+
+	BeforeEach(func() {
+		queue = db.NewPutQueue(dbConn)
+
+		var err error
+		firstBuild, err = defaultJob.CreateBuild(defaultBuildCreatedBy)
+		Expect(err).ToNot(HaveOccurred())
+
+		secondBuild, err = defaultTeam.CreateOneOffBuild()
+		Expect(err).ToNot(HaveOccurred())
+
+		first, err = queue.Join("some-queue", firstBuild.ID(), "some-plan-id")
+		Expect(err).ToNot(HaveOccurred())
+
+		second, err = queue.Join("some-queue", secondBuild.ID(), "some-plan-id")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("lets the steps through in the order they joined", func() {
+		Expect(first.IsNext()).To(BeTrue())
+		Expect(second.IsNext()).To(BeFalse())
+	})
+
+	It("does not hold up the steps of other queues", func() {
+		other, err := queue.Join("some-other-queue", secondBuild.ID(), "some-other-plan-id")
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(other.IsNext()).To(BeTrue())
+	})
+
+	Context("when the first step leaves the queue", func() {
+		BeforeEach(func() {
+			Expect(first.Leave()).To(Succeed())
+		})
+
+		It("lets the next step through", func() {
+			Expect(second.IsNext()).To(BeTrue())
+		})
+	})
+
+	Context("when the build of the first step finishes without it leaving", func() {
+		BeforeEach(func() {
+			Expect(firstBuild.Finish(db.BuildStatusErrored)).To(Succeed())
+		})
+
+		It("lets the next step through", func() {
+			Expect(second.IsNext()).To(BeTrue())
+		})
+	})
+
+	Context("when a step joins the queue again", func() {
+		It("keeps its place", func() {
+			again, err := queue.Join("some-queue", secondBuild.ID(), "some-plan-id")
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(again.IsNext()).To(BeFalse())
+
+			Expect(first.Leave()).To(Succeed())
+			Expect(again.IsNext()).To(BeTrue())
+		})
+	})
+})
@@ -69,6 +69,9 @@ WITH RECURSIVE build_ids AS (
 	// arbitrary numbers based around what we observed to be reasonable
 	causalityMaxBuilds        = 5000
 	causalityMaxInputsOutputs = 25000
+
+	// how many versions are fetched at once when backfilling metadata
+	metadataFetchParallelism = 4
 )
 
 //counterfeiter:generate . Resource
@@ -107,6 +110,8 @@ type Resource interface {
 	Versions(page Page, versionFilter atc.Version) ([]atc.ResourceVersion, Pagination, bool, error)
 	FindVersion(filter atc.Version) (ResourceConfigVersion, bool, error) // Only used in tests!!
 	UpdateMetadata(atc.Version, ResourceConfigMetadataFields) (bool, error)
+	VersionByID(rcvID int) (atc.Version, bool, error)
+	VersionsWithoutMetadata() ([]atc.Version, error)
 
 	EnableVersion(rcvID int) error
 	DisableVersion(rcvID int) error
@@ -115,10 +120,21 @@ type Resource interface {
 	UnpinVersion() error
 
 	Causality(rcvID int, direction CausalityDirection) (atc.Causality, bool, error)
+	DownstreamImpact(rcvID int, acrossPipelines bool, page Page) ([]atc.ImpactedBuild, Pagination, bool, error)
 
 	SetResourceConfigScope(ResourceConfigScope) error
 
+	// PushVersions saves versions pushed to a resource in push mode, as if a
+	// check had found them.
+	PushVersions(SpanContext, []atc.Version) error
+
+	// ResetCheckFrom makes the next check of the resource start from the
+	// given version rather than the latest one, or from scratch if it's
+	// empty. It returns false if the resource has never been checked.
+	ResetCheckFrom(atc.Version) (bool, error)
+
 	CheckPlan(planFactory atc.PlanFactory, imagePlanner atc.ImagePlanner, from atc.Version, interval atc.CheckEvery, sourceDefaults atc.Source, skipInterval bool, skipIntervalRecursively bool) atc.Plan
+	MetadataPlan(planFactory atc.PlanFactory, imagePlanner atc.ImagePlanner, versions []atc.Version, sourceDefaults atc.Source) atc.Plan
 	CreateBuild(context.Context, bool, atc.Plan) (Build, bool, error)
 	CreateInMemoryBuild(context.Context, atc.Plan, util.SequenceGenerator) (Build, error)
 
@@ -277,6 +293,77 @@ func (r *resource) SetResourceConfigScope(scope ResourceConfigScope) error {
 	return nil
 }
 
+// PushVersions saves the versions to the resource's config scope. A resource
+// in push mode is never checked, so the first push finds or creates the
+// config and scope for it from its type and source, the way a check would.
+func (r *resource) PushVersions(spanContext SpanContext, versions []atc.Version) error {
+	if r.resourceConfigScopeID == 0 {
+		tx, err := r.conn.Begin()
+		if err != nil {
+			return err
+		}
+
+		defer Rollback(tx)
+
+		resourceConfig := &resourceConfig{
+			lockFactory: r.lockFactory,
+			conn:        r.conn,
+		}
+
+		err = findOrCreateResourceConfig(tx, resourceConfig, r.type_, r.config.Source, nil, true)
+		if err != nil {
+			return err
+		}
+
+		scope, err := findOrCreateResourceConfigScope(tx, r.conn, r.lockFactory, resourceConfig, &r.id)
+		if err != nil {
+			return err
+		}
+
+		err = setResourceConfigScopeForResource(tx, scope, sq.Eq{"id": r.id})
+		if err != nil {
+			return err
+		}
+
+		err = tx.Commit()
+		if err != nil {
+			return err
+		}
+
+		r.resourceConfigID = resourceConfig.ID()
+		r.resourceConfigScopeID = scope.ID()
+	}
+
+	return saveVersions(r.conn, r.resourceConfigScopeID, versions, spanContext)
+}
+
+func (r *resource) ResetCheckFrom(version atc.Version) (bool, error) {
+	if version == nil {
+		version = atc.Version{}
+	}
+
+	versionJSON, err := json.Marshal(version)
+	if err != nil {
+		return false, err
+	}
+
+	result, err := psql.Update("resource_config_scopes").
+		Set("check_from", string(versionJSON)).
+		Where(sq.Expr("id = (SELECT resource_config_scope_id FROM resources WHERE id = ?)", r.id)).
+		RunWith(r.conn).
+		Exec()
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected == 1, nil
+}
+
 func setResourceConfigScopeForResource(tx Tx, scope ResourceConfigScope, pred interface{}, args ...interface{}) error {
 	var resourceID int
 	err := psql.Update("resources").
@@ -328,6 +415,37 @@ func (r *resource) CheckPlan(planFactory atc.PlanFactory, imagePlanner atc.Image
 	return plan
 }
 
+// MetadataPlan fetches each of the versions with a get step which updates
+// the versions' metadata once it succeeds, without saving any new versions.
+func (r *resource) MetadataPlan(planFactory atc.PlanFactory, imagePlanner atc.ImagePlanner, versions []atc.Version, sourceDefaults atc.Source) atc.Plan {
+	var gets []atc.Plan
+	for _, version := range versions {
+		version := version
+
+		plan := planFactory.NewPlan(atc.GetPlan{
+			Name:    r.name,
+			Type:    r.type_,
+			Source:  sourceDefaults.Merge(r.config.Source),
+			Tags:    r.config.Tags,
+			Version: &version,
+
+			Resource: r.name,
+		})
+
+		plan.Get.TypeImage = imagePlanner.ImageForType(plan.ID, r.type_, r.config.Tags, false)
+		gets = append(gets, plan)
+	}
+
+	if len(gets) == 1 {
+		return gets[0]
+	}
+
+	return planFactory.NewPlan(atc.InParallelPlan{
+		Steps: gets,
+		Limit: metadataFetchParallelism,
+	})
+}
+
 // CreateBuild tries to create a check build for this resource. A new build
 // will only be created if there isn't an existing running check build for this
 // resource, or if the build is manuallyTriggered (in which case a new build
@@ -664,6 +782,74 @@ func (r *resource) Versions(page Page, versionFilter atc.Version) ([]atc.Resourc
 	return rvs, pagination, true, nil
 }
 
+// VersionByID returns the resource's version with the given ID.
+func (r *resource) VersionByID(rcvID int) (atc.Version, bool, error) {
+	var versionJSON string
+	err := psql.Select("v.version").
+		From("resource_config_versions v").
+		Where(sq.Eq{
+			"v.id":                       rcvID,
+			"v.resource_config_scope_id": r.resourceConfigScopeID,
+		}).
+		RunWith(r.conn).
+		QueryRow().
+		Scan(&versionJSON)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, false, nil
+		}
+
+		return nil, false, err
+	}
+
+	var version atc.Version
+	err = json.Unmarshal([]byte(versionJSON), &version)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return version, true, nil
+}
+
+// VersionsWithoutMetadata returns the resource's versions which have no
+// metadata, newest first.
+func (r *resource) VersionsWithoutMetadata() ([]atc.Version, error) {
+	rows, err := psql.Select("v.version").
+		From("resource_config_versions v").
+		Where(sq.Eq{"v.resource_config_scope_id": r.resourceConfigScopeID}).
+		Where(sq.Or{
+			sq.Eq{"v.metadata": nil},
+			sq.Expr("v.metadata IN ('null'::jsonb, '[]'::jsonb)"),
+		}).
+		OrderBy("v.check_order DESC").
+		RunWith(r.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	var versions []atc.Version
+	for rows.Next() {
+		var versionJSON string
+		err = rows.Scan(&versionJSON)
+		if err != nil {
+			return nil, err
+		}
+
+		var version atc.Version
+		err = json.Unmarshal([]byte(versionJSON), &version)
+		if err != nil {
+			return nil, err
+		}
+
+		versions = append(versions, version)
+	}
+
+	return versions, nil
+}
+
 func (r *resource) EnableVersion(rcvID int) error {
 	return r.toggleVersion(rcvID, true)
 }
@@ -8,6 +8,7 @@ import (
 type ResourceConfigCheckSessionLifecycle interface {
 	CleanInactiveResourceConfigCheckSessions() error
 	CleanExpiredResourceConfigCheckSessions() error
+	CleanExpiredCheckContainerPoolSlots() error
 }
 
 type resourceConfigCheckSessionLifecycle struct {
@@ -77,3 +78,12 @@ func (lifecycle resourceConfigCheckSessionLifecycle) CleanExpiredResourceConfigC
 
 	return err
 }
+
+func (lifecycle resourceConfigCheckSessionLifecycle) CleanExpiredCheckContainerPoolSlots() error {
+	_, err := psql.Delete("check_container_pool_slots").
+		Where(sq.Expr("expires_at < NOW()")).
+		RunWith(lifecycle.conn).
+		Exec()
+
+	return err
+}
@@ -254,4 +254,59 @@ var _ = Describe("ResourceConfigCheckSessionLifecycle", func() {
 			})
 		})
 	})
+
+	Describe("CleanExpiredCheckContainerPoolSlots", func() {
+		var ownerExpiries db.ContainerOwnerExpiries
+
+		createSlot := func() {
+			resourceConfig, err := resourceConfigFactory.FindOrCreateResourceConfig(
+				"some-base-resource-type",
+				atc.Source{"some": "source"},
+				nil,
+			)
+			Expect(err).ToNot(HaveOccurred())
+
+			owner := db.NewCheckContainerPoolContainerOwner(
+				resourceConfig.OriginBaseResourceType().ID,
+				defaultTeam.ID(),
+				0,
+				ownerExpiries,
+			)
+
+			tx, err := dbConn.Begin()
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = owner.Create(tx, defaultWorker.Name())
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(tx.Commit()).To(Succeed())
+		}
+
+		slots := func() int {
+			var count int
+			err := psql.Select("COUNT(*)").
+				From("check_container_pool_slots").
+				RunWith(dbConn).
+				QueryRow().
+				Scan(&count)
+			Expect(err).ToNot(HaveOccurred())
+			return count
+		}
+
+		It("keeps the slots which haven't expired", func() {
+			ownerExpiries = db.ContainerOwnerExpiries{Min: time.Minute, Max: time.Minute}
+			createSlot()
+
+			Expect(lifecycle.CleanExpiredCheckContainerPoolSlots()).To(Succeed())
+			Expect(slots()).To(Equal(1))
+		})
+
+		It("removes the expired slots", func() {
+			ownerExpiries = db.ContainerOwnerExpiries{Min: -time.Minute, Max: -time.Minute}
+			createSlot()
+
+			Expect(lifecycle.CleanExpiredCheckContainerPoolSlots()).To(Succeed())
+			Expect(slots()).To(BeZero())
+		})
+	})
 })
@@ -35,6 +35,14 @@ type ResourceConfigScope interface {
 	FindVersion(atc.Version) (ResourceConfigVersion, bool, error)
 	LatestVersion() (ResourceConfigVersion, bool, error)
 
+	// CheckFrom returns the version the next check has been reset to start
+	// from, if it has been. An empty version means to check from scratch.
+	CheckFrom() (atc.Version, bool, error)
+
+	// ClearCheckFrom clears the reset once a check has started from it,
+	// unless it has been reset again in the meantime.
+	ClearCheckFrom(atc.Version) error
+
 	AcquireResourceCheckingLock(
 		logger lager.Logger,
 	) (lock.Lock, bool, error)
@@ -187,6 +195,50 @@ func (r *resourceConfigScope) LatestVersion() (ResourceConfigVersion, bool, erro
 	return rcv, true, nil
 }
 
+func (r *resourceConfigScope) CheckFrom() (atc.Version, bool, error) {
+	var checkFrom sql.NullString
+	err := psql.Select("check_from").
+		From("resource_config_scopes").
+		Where(sq.Eq{"id": r.id}).
+		RunWith(r.conn).
+		QueryRow().
+		Scan(&checkFrom)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if !checkFrom.Valid {
+		return nil, false, nil
+	}
+
+	var version atc.Version
+	err = json.Unmarshal([]byte(checkFrom.String), &version)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return version, true, nil
+}
+
+func (r *resourceConfigScope) ClearCheckFrom(version atc.Version) error {
+	if version == nil {
+		version = atc.Version{}
+	}
+
+	versionJSON, err := json.Marshal(version)
+	if err != nil {
+		return err
+	}
+
+	_, err = psql.Update("resource_config_scopes").
+		Set("check_from", nil).
+		Where(sq.Eq{"id": r.id}).
+		Where(sq.Expr("check_from = ?::jsonb", string(versionJSON))).
+		RunWith(r.conn).
+		Exec()
+	return err
+}
+
 func (r *resourceConfigScope) AcquireResourceCheckingLock(
 	logger lager.Logger,
 ) (lock.Lock, bool, error) {
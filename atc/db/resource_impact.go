@@ -0,0 +1,283 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
+	"github.com/lib/pq"
+)
+
+// Versions are linked to the inputs of other builds by their content, i.e.
+// the resource config and version, rather than by resource, so that
+// resources with the same source in other pipelines are followed when
+// tracing across pipelines.
+const impactedBuildsQuery = `
+WITH RECURSIVE build_ids AS (
+		SELECT DISTINCT i.build_id
+		FROM build_resource_config_version_inputs i
+		JOIN resources ri ON ri.id = i.resource_id
+		WHERE i.version_md5 = $2
+		AND ri.resource_config_id = (SELECT resource_config_id FROM resources WHERE id = $1)
+		AND %[1]s
+	UNION
+		SELECT i.build_id
+		FROM build_ids bi
+		JOIN build_resource_config_version_outputs o ON o.build_id = bi.build_id
+		JOIN resources ro ON ro.id = o.resource_id
+		JOIN resources ri ON ri.resource_config_id = ro.resource_config_id
+		JOIN build_resource_config_version_inputs i ON i.resource_id = ri.id AND i.version_md5 = o.version_md5
+		WHERE %[1]s
+)
+SELECT build_id FROM build_ids
+LIMIT $4
+`
+
+const (
+	impactScopePipeline = `ri.pipeline_id = $3`
+	impactScopeTeam     = `ri.pipeline_id IN (SELECT id FROM pipelines WHERE team_id = $3)`
+)
+
+// DownstreamImpact returns the builds downstream of the resource version,
+// newest first, within the resource's pipeline or, when acrossPipelines is
+// set, within all of the team's pipelines.
+func (r *resource) DownstreamImpact(rcvID int, acrossPipelines bool, page Page) ([]atc.ImpactedBuild, Pagination, bool, error) {
+	tx, err := r.conn.Begin()
+	if err != nil {
+		return nil, Pagination{}, false, err
+	}
+
+	defer Rollback(tx) // everything is readonly, so no need to commit
+
+	var versionMD5 string
+	err = psql.Select("rcv.version_md5").
+		From("resource_config_versions rcv").
+		Join("resources r ON r.resource_config_scope_id = rcv.resource_config_scope_id").
+		Where(sq.Eq{
+			"rcv.id": rcvID,
+			"r.id":   r.id,
+		}).
+		RunWith(tx).
+		Scan(&versionMD5)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, Pagination{}, false, nil
+		}
+
+		return nil, Pagination{}, false, err
+	}
+
+	scope, scopeID := impactScopePipeline, r.pipelineID
+	if acrossPipelines {
+		scope, scopeID = impactScopeTeam, r.teamID
+	}
+
+	rows, err := tx.Query(fmt.Sprintf(impactedBuildsQuery, scope), r.id, versionMD5, scopeID, causalityMaxBuilds+1)
+	if err != nil {
+		return nil, Pagination{}, false, err
+	}
+
+	defer Close(rows)
+
+	var buildIDs []int
+	for rows.Next() {
+		var id int
+		err = rows.Scan(&id)
+		if err != nil {
+			return nil, Pagination{}, false, err
+		}
+
+		buildIDs = append(buildIDs, id)
+	}
+
+	if len(buildIDs) > causalityMaxBuilds {
+		return nil, Pagination{}, false, ErrTooManyBuilds
+	}
+
+	sort.Sort(sort.Reverse(sort.IntSlice(buildIDs)))
+
+	pageIDs, pagination := paginateIDs(buildIDs, page)
+
+	builds, err := impactedBuilds(tx, pageIDs)
+	if err != nil {
+		return nil, Pagination{}, false, err
+	}
+
+	err = impactedBuildVersions(tx, builds, r.id, versionMD5, buildIDs)
+	if err != nil {
+		return nil, Pagination{}, false, err
+	}
+
+	return builds, pagination, true, nil
+}
+
+// paginateIDs returns the page of the IDs, which must be sorted from newest
+// to oldest.
+func paginateIDs(ids []int, page Page) ([]int, Pagination) {
+	start, end := 0, len(ids)
+
+	if page.To != nil {
+		for start < end && ids[start] > *page.To {
+			start++
+		}
+	}
+
+	if page.From != nil {
+		for end > start && ids[end-1] < *page.From {
+			end--
+		}
+	}
+
+	if page.Limit > 0 && end-start > page.Limit {
+		if page.From != nil && page.To == nil {
+			// paging towards newer IDs, so keep the oldest ones
+			start = end - page.Limit
+		} else {
+			end = start + page.Limit
+		}
+	}
+
+	var pagination Pagination
+	if start > 0 {
+		pagination.Newer = &Page{From: NewIntPtr(ids[start-1]), Limit: page.Limit}
+	}
+
+	if end < len(ids) {
+		pagination.Older = &Page{To: NewIntPtr(ids[end]), Limit: page.Limit}
+	}
+
+	return ids[start:end], pagination
+}
+
+func impactedBuilds(tx Tx, buildIDs []int) ([]atc.ImpactedBuild, error) {
+	builds := []atc.ImpactedBuild{}
+	if len(buildIDs) == 0 {
+		return builds, nil
+	}
+
+	rows, err := psql.Select("b.id", "b.name", "b.status", "t.name", "p.id", "p.name", "p.instance_vars", "j.id", "j.name").
+		From("builds b").
+		Join("jobs j ON j.id = b.job_id").
+		Join("pipelines p ON p.id = j.pipeline_id").
+		Join("teams t ON t.id = p.team_id").
+		Where(sq.Expr("b.id = ANY(?)", pq.Array(buildIDs))).
+		OrderBy("b.id DESC").
+		RunWith(tx).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	for rows.Next() {
+		var (
+			build        atc.ImpactedBuild
+			instanceVars sql.NullString
+		)
+
+		err = rows.Scan(&build.ID, &build.Name, &build.Status, &build.TeamName, &build.PipelineID, &build.PipelineName, &instanceVars, &build.JobID, &build.JobName)
+		if err != nil {
+			return nil, err
+		}
+
+		if instanceVars.Valid {
+			err = json.Unmarshal([]byte(instanceVars.String), &build.PipelineInstanceVars)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		build.Inputs = []atc.ImpactedResourceVersion{}
+		build.Outputs = []atc.ImpactedResourceVersion{}
+
+		builds = append(builds, build)
+	}
+
+	return builds, nil
+}
+
+// impactedBuildVersions fills in the outputs of the builds and the inputs
+// which are part of the graph, i.e. the original version or an output of
+// any of the impacted builds.
+func impactedBuildVersions(tx Tx, builds []atc.ImpactedBuild, resourceID int, versionMD5 string, impactedBuildIDs []int) error {
+	if len(builds) == 0 {
+		return nil
+	}
+
+	byID := map[int]*atc.ImpactedBuild{}
+	pageIDs := make([]int, len(builds))
+	for i := range builds {
+		byID[builds[i].ID] = &builds[i]
+		pageIDs[i] = builds[i].ID
+	}
+
+	rows, err := tx.Query(`
+	SELECT DISTINCT o.build_id, rcv.id, r.id, r.name, rcv.version, 'output' AS type
+	FROM build_resource_config_version_outputs o
+	JOIN resources r ON r.id = o.resource_id
+	JOIN resource_config_versions rcv ON rcv.version_md5 = o.version_md5 AND rcv.resource_config_scope_id = r.resource_config_scope_id
+	WHERE o.build_id = ANY($1)
+UNION ALL
+	SELECT DISTINCT i.build_id, rcv.id, r.id, r.name, rcv.version, 'input' AS type
+	FROM build_resource_config_version_inputs i
+	JOIN resources r ON r.id = i.resource_id
+	JOIN resource_config_versions rcv ON rcv.version_md5 = i.version_md5 AND rcv.resource_config_scope_id = r.resource_config_scope_id
+	WHERE i.build_id = ANY($1)
+	AND (
+		(i.version_md5 = $3 AND r.resource_config_id = (SELECT resource_config_id FROM resources WHERE id = $2))
+		OR EXISTS (
+			SELECT 1
+			FROM build_resource_config_version_outputs o
+			JOIN resources ro ON ro.id = o.resource_id
+			WHERE o.build_id = ANY($4)
+			AND o.version_md5 = i.version_md5
+			AND ro.resource_config_id = r.resource_config_id
+		)
+	)
+	ORDER BY 1, 2
+	`, pq.Array(pageIDs), resourceID, versionMD5, pq.Array(impactedBuildIDs))
+	if err != nil {
+		return err
+	}
+
+	defer Close(rows)
+
+	for rows.Next() {
+		var (
+			buildID int
+			rv      atc.ImpactedResourceVersion
+			version string
+			typ     string
+		)
+
+		err = rows.Scan(&buildID, &rv.ID, &rv.ResourceID, &rv.ResourceName, &version, &typ)
+		if err != nil {
+			return err
+		}
+
+		err = json.Unmarshal([]byte(version), &rv.Version)
+		if err != nil {
+			return err
+		}
+
+		build, found := byID[buildID]
+		if !found {
+			continue
+		}
+
+		switch typ {
+		case "input":
+			build.Inputs = append(build.Inputs, rv)
+		case "output":
+			build.Outputs = append(build.Outputs, rv)
+		default:
+			return fmt.Errorf("unknown type: %v", typ)
+		}
+	}
+
+	return nil
+}
@@ -479,6 +479,177 @@ var _ = Describe("Resource", func() {
 		})
 	})
 
+	Describe("PushVersions", func() {
+		var resource db.Resource
+
+		BeforeEach(func() {
+			pipeline, _, err := defaultTeam.SavePipeline(
+				atc.PipelineRef{Name: "pipeline-with-pushed-resource"},
+				atc.Config{
+					Resources: atc.ResourceConfigs{
+						{
+							Name:   "some-pushed-resource",
+							Type:   defaultWorkerResourceType.Type,
+							Source: atc.Source{"some": "((pushed-repository))"},
+							Push:   &atc.PushConfig{Version: []string{"ref"}},
+						},
+					},
+					Jobs: atc.JobConfigs{
+						{
+							Name: "job-using-resource",
+							PlanSequence: []atc.Step{
+								{
+									Config: &atc.GetStep{
+										Name: "some-pushed-resource",
+									},
+								},
+							},
+						},
+					},
+				},
+				0,
+				false,
+			)
+			Expect(err).ToNot(HaveOccurred())
+
+			var found bool
+			resource, found, err = pipeline.Resource("some-pushed-resource")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+		})
+
+		It("saves the versions to a scope for the resource", func() {
+			Expect(resource.ResourceConfigScopeID()).To(BeZero())
+
+			err := resource.PushVersions(db.SpanContext{}, []atc.Version{{"ref": "v1"}, {"ref": "v2"}})
+			Expect(err).ToNot(HaveOccurred())
+
+			found, err := resource.Reload()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(resource.ResourceConfigScopeID()).ToNot(BeZero())
+
+			versions, _, found, err := resource.Versions(db.Page{Limit: 10}, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(versions).To(HaveLen(2))
+			Expect(versions[0].Version).To(Equal(atc.Version{"ref": "v2"}))
+			Expect(versions[1].Version).To(Equal(atc.Version{"ref": "v1"}))
+		})
+
+		It("keeps saving to the same scope", func() {
+			err := resource.PushVersions(db.SpanContext{}, []atc.Version{{"ref": "v1"}})
+			Expect(err).ToNot(HaveOccurred())
+
+			scopeID := resource.ResourceConfigScopeID()
+
+			err = resource.PushVersions(db.SpanContext{}, []atc.Version{{"ref": "v2"}})
+			Expect(err).ToNot(HaveOccurred())
+
+			found, err := resource.Reload()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(resource.ResourceConfigScopeID()).To(Equal(scopeID))
+
+			versions, _, _, err := resource.Versions(db.Page{Limit: 10}, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(versions).To(HaveLen(2))
+		})
+	})
+
+	Describe("ResetCheckFrom", func() {
+		var resource db.Resource
+
+		BeforeEach(func() {
+			pipeline, _, err := defaultTeam.SavePipeline(
+				atc.PipelineRef{Name: "pipeline-with-rewritten-history"},
+				atc.Config{
+					Resources: atc.ResourceConfigs{
+						{
+							Name:   "some-resource",
+							Type:   defaultWorkerResourceType.Type,
+							Source: atc.Source{"some": "rewritten-repository"},
+						},
+					},
+				},
+				0,
+				false,
+			)
+			Expect(err).ToNot(HaveOccurred())
+
+			var found bool
+			resource, found, err = pipeline.Resource("some-resource")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+		})
+
+		Context("when the resource has never been checked", func() {
+			It("returns false", func() {
+				reset, err := resource.ResetCheckFrom(atc.Version{"ref": "v1"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(reset).To(BeFalse())
+			})
+		})
+
+		Context("when the resource has been checked", func() {
+			var scope db.ResourceConfigScope
+
+			BeforeEach(func() {
+				resourceConfig, err := resourceConfigFactory.FindOrCreateResourceConfig(resource.Type(), resource.Source(), nil)
+				Expect(err).ToNot(HaveOccurred())
+
+				scope, err = resourceConfig.FindOrCreateScope(intptr(resource.ID()))
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(resource.SetResourceConfigScope(scope)).To(Succeed())
+			})
+
+			It("is not reset to begin with", func() {
+				_, reset, err := scope.CheckFrom()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(reset).To(BeFalse())
+			})
+
+			It("resets the scope to check from the version", func() {
+				reset, err := resource.ResetCheckFrom(atc.Version{"ref": "v1"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(reset).To(BeTrue())
+
+				version, reset, err := scope.CheckFrom()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(reset).To(BeTrue())
+				Expect(version).To(Equal(atc.Version{"ref": "v1"}))
+			})
+
+			It("resets the scope to check from scratch without a version", func() {
+				_, err := resource.ResetCheckFrom(nil)
+				Expect(err).ToNot(HaveOccurred())
+
+				version, reset, err := scope.CheckFrom()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(reset).To(BeTrue())
+				Expect(version).To(BeEmpty())
+			})
+
+			It("is cleared only if it has not been reset again", func() {
+				_, err := resource.ResetCheckFrom(atc.Version{"ref": "v2"})
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(scope.ClearCheckFrom(atc.Version{"ref": "v1"})).To(Succeed())
+
+				_, reset, err := scope.CheckFrom()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(reset).To(BeTrue())
+
+				Expect(scope.ClearCheckFrom(atc.Version{"ref": "v2"})).To(Succeed())
+
+				_, reset, err = scope.CheckFrom()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(reset).To(BeFalse())
+			})
+		})
+	})
+
 	Describe("CreateBuild", func() {
 		var ctx context.Context
 		var manuallyTriggered bool
@@ -1210,6 +1381,113 @@ var _ = Describe("Resource", func() {
 		})
 	})
 
+	Describe("VersionByID/VersionsWithoutMetadata", func() {
+		var scenario *dbtest.Scenario
+
+		BeforeEach(func() {
+			scenario = dbtest.Setup(
+				builder.WithPipeline(atc.Config{
+					Resources: atc.ResourceConfigs{
+						{
+							Name:   "some-resource",
+							Type:   "some-base-resource-type",
+							Source: atc.Source{"some": "repository"},
+						},
+						{
+							Name:   "some-other-resource",
+							Type:   "some-base-resource-type",
+							Source: atc.Source{"some": "other-repository"},
+						},
+					},
+				}),
+				builder.WithResourceVersions("some-resource", atc.Version{"v": "1"}, atc.Version{"v": "2"}, atc.Version{"v": "3"}),
+				builder.WithResourceVersions("some-other-resource", atc.Version{"v": "other"}),
+				builder.WithVersionMetadata("some-resource", atc.Version{"v": "2"}, db.ResourceConfigMetadataFields{
+					{Name: "some", Value: "metadata"},
+				}),
+			)
+		})
+
+		It("finds the resource's versions by ID", func() {
+			rcv := scenario.ResourceVersion("some-resource", atc.Version{"v": "1"})
+
+			version, found, err := scenario.Resource("some-resource").VersionByID(rcv.ID())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(version).To(Equal(atc.Version{"v": "1"}))
+		})
+
+		It("does not find versions of other resources", func() {
+			rcv := scenario.ResourceVersion("some-other-resource", atc.Version{"v": "other"})
+
+			_, found, err := scenario.Resource("some-resource").VersionByID(rcv.ID())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		It("returns the versions without metadata, newest first", func() {
+			versions, err := scenario.Resource("some-resource").VersionsWithoutMetadata()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(versions).To(Equal([]atc.Version{{"v": "3"}, {"v": "1"}}))
+		})
+	})
+
+	Describe("MetadataPlan", func() {
+		var resource db.Resource
+
+		BeforeEach(func() {
+			pipeline, _, err := defaultTeam.SavePipeline(atc.PipelineRef{Name: "pipeline-with-metadata"}, atc.Config{
+				Resources: atc.ResourceConfigs{{
+					Name:   "some-resource",
+					Type:   "some-base-resource-type",
+					Tags:   []string{"tag"},
+					Source: atc.Source{"some": "source"},
+				}},
+			}, 0, false)
+			Expect(err).ToNot(HaveOccurred())
+
+			var found bool
+			resource, found, err = pipeline.Resource("some-resource")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+		})
+
+		getPlan := func(id string, version atc.Version) atc.Plan {
+			return atc.Plan{
+				ID: atc.PlanID(id),
+				Get: &atc.GetPlan{
+					Name: "some-resource",
+					Type: "some-base-resource-type",
+					Source: atc.Source{
+						"some":        "source",
+						"source-test": "default",
+					},
+					Tags: atc.Tags{"tag"},
+					TypeImage: atc.TypeImage{
+						BaseType: "some-base-resource-type",
+					},
+					Version:  &version,
+					Resource: "some-resource",
+				},
+			}
+		}
+
+		It("fetches a single version with a get", func() {
+			plan := resource.MetadataPlan(atc.NewPlanFactory(0), atc.ResourceTypes{}, []atc.Version{{"v": "1"}}, atc.Source{"source-test": "default"})
+			Expect(plan).To(Equal(getPlan("1", atc.Version{"v": "1"})))
+		})
+
+		It("fetches many versions in parallel", func() {
+			plan := resource.MetadataPlan(atc.NewPlanFactory(0), atc.ResourceTypes{}, []atc.Version{{"v": "2"}, {"v": "1"}}, atc.Source{"source-test": "default"})
+			Expect(plan.InParallel).ToNot(BeNil())
+			Expect(plan.InParallel.Limit).To(BeNumerically(">", 0))
+			Expect(plan.InParallel.Steps).To(Equal([]atc.Plan{
+				getPlan("1", atc.Version{"v": "2"}),
+				getPlan("2", atc.Version{"v": "1"}),
+			}))
+		})
+	})
+
 	Describe("PinVersion/UnpinVersion", func() {
 		var (
 			scenario *dbtest.Scenario
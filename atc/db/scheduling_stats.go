@@ -0,0 +1,131 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+)
+
+// SchedulingStatsRepository keeps track of how long the scheduler spent on
+// each pipeline during the last tick in which any of its jobs were
+// scheduled.
+//
+//counterfeiter:generate . SchedulingStatsRepository
+type SchedulingStatsRepository interface {
+	RecordSchedulingTimings(pipelineID int, tick time.Time, timings SchedulingTimings) error
+
+	SchedulingStats() ([]atc.PipelineSchedulingStats, error)
+}
+
+// SchedulingTimings is the time spent in each phase of scheduling a job.
+type SchedulingTimings struct {
+	LoadingVersions time.Duration
+	ResolvingInputs time.Duration
+	CreatingBuilds  time.Duration
+}
+
+type schedulingStatsRepository struct {
+	conn Conn
+}
+
+func NewSchedulingStatsRepository(conn Conn) SchedulingStatsRepository {
+	return &schedulingStatsRepository{
+		conn: conn,
+	}
+}
+
+// RecordSchedulingTimings adds the timings of a job to the stats of its
+// pipeline for the given tick. The first job recorded for a newer tick
+// replaces the stats of the previous one, and jobs of an older tick which
+// finish late are ignored.
+func (repo *schedulingStatsRepository) RecordSchedulingTimings(pipelineID int, tick time.Time, timings SchedulingTimings) error {
+	_, err := repo.conn.Exec(`
+		INSERT INTO pipeline_scheduling_stats AS s (pipeline_id, tick_started_at, jobs, loading_versions, resolving_inputs, creating_builds)
+		VALUES ($1, $2, 1, $3, $4, $5)
+		ON CONFLICT (pipeline_id) DO UPDATE SET
+			jobs = CASE WHEN s.tick_started_at = EXCLUDED.tick_started_at THEN s.jobs + 1 ELSE 1 END,
+			loading_versions = CASE WHEN s.tick_started_at = EXCLUDED.tick_started_at THEN s.loading_versions ELSE 0 END + EXCLUDED.loading_versions,
+			resolving_inputs = CASE WHEN s.tick_started_at = EXCLUDED.tick_started_at THEN s.resolving_inputs ELSE 0 END + EXCLUDED.resolving_inputs,
+			creating_builds = CASE WHEN s.tick_started_at = EXCLUDED.tick_started_at THEN s.creating_builds ELSE 0 END + EXCLUDED.creating_builds,
+			tick_started_at = EXCLUDED.tick_started_at
+		WHERE s.tick_started_at <= EXCLUDED.tick_started_at
+	`, pipelineID, tick, timings.LoadingVersions, timings.ResolvingInputs, timings.CreatingBuilds)
+	return err
+}
+
+// SchedulingStats returns the stats of every pipeline, the ones which took
+// the longest to schedule first.
+func (repo *schedulingStatsRepository) SchedulingStats() ([]atc.PipelineSchedulingStats, error) {
+	rows, err := psql.Select(
+		"p.id",
+		"p.name",
+		"p.instance_vars",
+		"t.name",
+		"s.tick_started_at",
+		"s.jobs",
+		"s.loading_versions",
+		"s.resolving_inputs",
+		"s.creating_builds",
+	).
+		From("pipeline_scheduling_stats s").
+		Join("pipelines p ON p.id = s.pipeline_id").
+		Join("teams t ON t.id = p.team_id").
+		OrderBy("s.loading_versions + s.resolving_inputs + s.creating_builds DESC", "p.id").
+		RunWith(repo.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	stats := []atc.PipelineSchedulingStats{}
+	for rows.Next() {
+		var (
+			pipeline        atc.PipelineSchedulingStats
+			instanceVars    sql.NullString
+			tickStartedAt   time.Time
+			loadingVersions time.Duration
+			resolvingInputs time.Duration
+			creatingBuilds  time.Duration
+		)
+
+		err := rows.Scan(
+			&pipeline.PipelineID,
+			&pipeline.PipelineName,
+			&instanceVars,
+			&pipeline.TeamName,
+			&tickStartedAt,
+			&pipeline.Jobs,
+			&loadingVersions,
+			&resolvingInputs,
+			&creatingBuilds,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if instanceVars.Valid {
+			err = json.Unmarshal([]byte(instanceVars.String), &pipeline.PipelineInstanceVars)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		pipeline.TickStartedAt = tickStartedAt.Unix()
+		pipeline.LoadingVersions = milliseconds(loadingVersions)
+		pipeline.ResolvingInputs = milliseconds(resolvingInputs)
+		pipeline.CreatingBuilds = milliseconds(creatingBuilds)
+		pipeline.Total = milliseconds(loadingVersions + resolvingInputs + creatingBuilds)
+
+		stats = append(stats, pipeline)
+	}
+
+	return stats, rows.Err()
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
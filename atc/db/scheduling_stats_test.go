@@ -0,0 +1,92 @@
+package db_test
+
+import (
+	"time"
+
+	"github.com/concourse/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SchedulingStatsRepository", func() {
+	var (
+		repository db.SchedulingStatsRepository
+		tick       time.Time
+	)
+
+	BeforeEach(func() {
+		repository = db.NewSchedulingStatsRepository(dbConn)
+		tick = time.Now().Truncate(time.Second)
+	})
+
+	record := func(tick time.Time, timings db.SchedulingTimings) {
+		err := repository.RecordSchedulingTimings(defaultPipeline.ID(), tick, timings)
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	someTimings := db.SchedulingTimings{
+		LoadingVersions: time.Millisecond,
+		ResolvingInputs: 4 * time.Millisecond,
+		CreatingBuilds:  2 * time.Millisecond,
+	}
+
+	It("returns nothing when no pipeline has been scheduled", func() {
+		stats, err := repository.SchedulingStats()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(stats).To(BeEmpty())
+	})
+
+	Context("when the jobs of a pipeline are scheduled in the same tick", func() {
+		BeforeEach(func() {
+			record(tick, someTimings)
+			record(tick, someTimings)
+		})
+
+		It("adds up their timings", func() {
+			stats, err := repository.SchedulingStats()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(stats).To(HaveLen(1))
+
+			Expect(stats[0].PipelineID).To(Equal(defaultPipeline.ID()))
+			Expect(stats[0].PipelineName).To(Equal(defaultPipeline.Name()))
+			Expect(stats[0].TeamName).To(Equal(defaultTeam.Name()))
+			Expect(stats[0].TickStartedAt).To(Equal(tick.Unix()))
+			Expect(stats[0].Jobs).To(Equal(2))
+			Expect(stats[0].LoadingVersions).To(Equal(2.0))
+			Expect(stats[0].ResolvingInputs).To(Equal(8.0))
+			Expect(stats[0].CreatingBuilds).To(Equal(4.0))
+			Expect(stats[0].Total).To(Equal(14.0))
+		})
+
+		Context("when a job is scheduled in a later tick", func() {
+			BeforeEach(func() {
+				record(tick.Add(10*time.Second), someTimings)
+			})
+
+			It("replaces the timings of the previous tick", func() {
+				stats, err := repository.SchedulingStats()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(stats).To(HaveLen(1))
+
+				Expect(stats[0].TickStartedAt).To(Equal(tick.Add(10 * time.Second).Unix()))
+				Expect(stats[0].Jobs).To(Equal(1))
+				Expect(stats[0].Total).To(Equal(7.0))
+			})
+		})
+
+		Context("when a job of an earlier tick finishes late", func() {
+			BeforeEach(func() {
+				record(tick.Add(-10*time.Second), someTimings)
+			})
+
+			It("ignores it", func() {
+				stats, err := repository.SchedulingStats()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(stats).To(HaveLen(1))
+
+				Expect(stats[0].TickStartedAt).To(Equal(tick.Unix()))
+				Expect(stats[0].Jobs).To(Equal(2))
+			})
+		})
+	})
+})
@@ -0,0 +1,243 @@
+package db
+
+import (
+	"regexp"
+	"sort"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db/migration"
+	"github.com/lib/pq"
+)
+
+// SchemaReference describes the schema the migrations leave the database
+// with, so that it can be looked up without reading through the migrations.
+//
+// The tables, columns, foreign keys and indexes are read from the catalog
+// rather than parsed out of the migrations, as Go migrations can't be
+// parsed. The migrations are walked to tell which of them mention each table.
+//
+//counterfeiter:generate . SchemaReference
+type SchemaReference interface {
+	Schema() (atc.DBSchema, error)
+}
+
+type schemaReference struct {
+	conn Conn
+}
+
+func NewSchemaReference(conn Conn) SchemaReference {
+	return &schemaReference{
+		conn: conn,
+	}
+}
+
+var foreignKeyActions = map[string]string{
+	"a": "no action",
+	"r": "restrict",
+	"c": "cascade",
+	"n": "set null",
+	"d": "set default",
+}
+
+func (r *schemaReference) Schema() (atc.DBSchema, error) {
+	migrations, err := migration.NewMigrator(nil, nil).Migrations()
+	if err != nil {
+		return atc.DBSchema{}, err
+	}
+
+	tables, err := r.tables()
+	if err != nil {
+		return atc.DBSchema{}, err
+	}
+
+	err = r.loadForeignKeys(tables)
+	if err != nil {
+		return atc.DBSchema{}, err
+	}
+
+	err = r.loadIndexes(tables)
+	if err != nil {
+		return atc.DBSchema{}, err
+	}
+
+	schema := atc.DBSchema{
+		Tables: []atc.DBTable{},
+	}
+
+	for _, m := range migrations {
+		if m.Version > schema.Version {
+			schema.Version = m.Version
+		}
+	}
+
+	for _, table := range tables {
+		mention := regexp.MustCompile(`(?i)\b` + regexp.QuoteMeta(table.Name) + `\b`)
+
+		table.Migrations = []int{}
+		for _, m := range migrations {
+			if m.Direction == "up" && mention.MatchString(m.Statements) {
+				table.Migrations = append(table.Migrations, m.Version)
+			}
+		}
+
+		schema.Tables = append(schema.Tables, *table)
+	}
+
+	sort.Slice(schema.Tables, func(i, j int) bool {
+		return schema.Tables[i].Name < schema.Tables[j].Name
+	})
+
+	return schema, nil
+}
+
+// tables returns the tables with their columns. The partitions of the build
+// events tables inherit their parent's columns and are left out.
+func (r *schemaReference) tables() (map[string]*atc.DBTable, error) {
+	rows, err := r.conn.Query(`
+		SELECT c.relname, a.attname, format_type(a.atttypid, a.atttypmod), NOT a.attnotnull, COALESCE(pg_get_expr(d.adbin, d.adrelid), '')
+		FROM pg_class c
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		JOIN pg_attribute a ON a.attrelid = c.oid
+		LEFT JOIN pg_attrdef d ON d.adrelid = a.attrelid AND d.adnum = a.attnum
+		WHERE n.nspname = 'public'
+		AND c.relkind IN ('r', 'p')
+		AND NOT EXISTS (SELECT 1 FROM pg_inherits i WHERE i.inhrelid = c.oid)
+		AND a.attnum > 0
+		AND NOT a.attisdropped
+		ORDER BY c.relname, a.attnum
+	`)
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	tables := map[string]*atc.DBTable{}
+	for rows.Next() {
+		var (
+			tableName string
+			column    atc.DBColumn
+		)
+
+		err := rows.Scan(&tableName, &column.Name, &column.Type, &column.Nullable, &column.Default)
+		if err != nil {
+			return nil, err
+		}
+
+		table, found := tables[tableName]
+		if !found {
+			table = &atc.DBTable{
+				Name:        tableName,
+				ForeignKeys: []atc.DBForeignKey{},
+				Indexes:     []atc.DBIndex{},
+			}
+
+			tables[tableName] = table
+		}
+
+		table.Columns = append(table.Columns, column)
+	}
+
+	return tables, nil
+}
+
+func (r *schemaReference) loadForeignKeys(tables map[string]*atc.DBTable) error {
+	rows, err := r.conn.Query(`
+		SELECT src.relname, con.conname,
+			ARRAY(
+				SELECT a.attname
+				FROM unnest(con.conkey) WITH ORDINALITY k(attnum, n)
+				JOIN pg_attribute a ON a.attrelid = con.conrelid AND a.attnum = k.attnum
+				ORDER BY k.n
+			),
+			dst.relname,
+			ARRAY(
+				SELECT a.attname
+				FROM unnest(con.confkey) WITH ORDINALITY k(attnum, n)
+				JOIN pg_attribute a ON a.attrelid = con.confrelid AND a.attnum = k.attnum
+				ORDER BY k.n
+			),
+			con.confdeltype
+		FROM pg_constraint con
+		JOIN pg_class src ON src.oid = con.conrelid
+		JOIN pg_class dst ON dst.oid = con.confrelid
+		JOIN pg_namespace n ON n.oid = src.relnamespace
+		WHERE n.nspname = 'public'
+		AND con.contype = 'f'
+		ORDER BY src.relname, con.conname
+	`)
+	if err != nil {
+		return err
+	}
+
+	defer Close(rows)
+
+	for rows.Next() {
+		var (
+			tableName  string
+			foreignKey atc.DBForeignKey
+			onDelete   string
+		)
+
+		err := rows.Scan(
+			&tableName,
+			&foreignKey.Name,
+			pq.Array(&foreignKey.Columns),
+			&foreignKey.ReferencedTable,
+			pq.Array(&foreignKey.ReferencedColumns),
+			&onDelete,
+		)
+		if err != nil {
+			return err
+		}
+
+		table, found := tables[tableName]
+		if !found {
+			continue
+		}
+
+		foreignKey.OnDelete = foreignKeyActions[onDelete]
+
+		table.ForeignKeys = append(table.ForeignKeys, foreignKey)
+	}
+
+	return nil
+}
+
+func (r *schemaReference) loadIndexes(tables map[string]*atc.DBTable) error {
+	rows, err := r.conn.Query(`
+		SELECT t.relname, i.relname, ix.indisunique, ix.indisprimary, pg_get_indexdef(ix.indexrelid)
+		FROM pg_index ix
+		JOIN pg_class t ON t.oid = ix.indrelid
+		JOIN pg_class i ON i.oid = ix.indexrelid
+		JOIN pg_namespace n ON n.oid = t.relnamespace
+		WHERE n.nspname = 'public'
+		ORDER BY t.relname, i.relname
+	`)
+	if err != nil {
+		return err
+	}
+
+	defer Close(rows)
+
+	for rows.Next() {
+		var (
+			tableName string
+			index     atc.DBIndex
+		)
+
+		err := rows.Scan(&tableName, &index.Name, &index.Unique, &index.Primary, &index.Definition)
+		if err != nil {
+			return err
+		}
+
+		table, found := tables[tableName]
+		if !found {
+			continue
+		}
+
+		table.Indexes = append(table.Indexes, index)
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+package db_test
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/migration"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SchemaReference", func() {
+	var schema atc.DBSchema
+
+	BeforeEach(func() {
+		var err error
+		schema, err = db.NewSchemaReference(dbConn).Schema()
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	table := func(name string) atc.DBTable {
+		for _, t := range schema.Tables {
+			if t.Name == name {
+				return t
+			}
+		}
+
+		Fail("table not found: " + name)
+		return atc.DBTable{}
+	}
+
+	It("is at the version the migrations go up to", func() {
+		version, err := migration.NewMigrator(nil, nil).SupportedVersion()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(schema.Version).To(Equal(version))
+	})
+
+	It("describes the columns of the tables", func() {
+		Expect(table("teams").Columns).To(ContainElement(atc.DBColumn{
+			Name:     "name",
+			Type:     "text",
+			Nullable: false,
+		}))
+
+		Expect(table("pipelines").Columns).To(ContainElement(atc.DBColumn{
+			Name:     "id",
+			Type:     "integer",
+			Nullable: false,
+			Default:  "nextval('pipelines_id_seq'::regclass)",
+		}))
+	})
+
+	It("describes the foreign keys of the tables", func() {
+		Expect(table("pipelines").ForeignKeys).To(ContainElement(atc.DBForeignKey{
+			Name:              "pipelines_team_id_fkey",
+			Columns:           []string{"team_id"},
+			ReferencedTable:   "teams",
+			ReferencedColumns: []string{"id"},
+			OnDelete:          "cascade",
+		}))
+	})
+
+	It("describes the indexes of the tables", func() {
+		Expect(table("teams").Indexes).To(ContainElement(SatisfyAll(
+			HaveField("Primary", true),
+			HaveField("Unique", true),
+		)))
+	})
+
+	It("leaves out the partitions of the build events tables", func() {
+		for _, t := range schema.Tables {
+			Expect(t.Name).ToNot(MatchRegexp(`^pipeline_build_events_\d+$`))
+		}
+	})
+
+	It("lists the migrations which mention each table", func() {
+		Expect(table("queued_jobs").Migrations).To(Equal([]int{1794649339}))
+	})
+})
@@ -0,0 +1,578 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
+	"github.com/lib/pq"
+	uuid "github.com/nu7hatch/gouuid"
+)
+
+var (
+	ErrSCIMUserExists         = errors.New("a user with that userName already exists")
+	ErrSCIMGroupExists        = errors.New("a group with that displayName already exists")
+	ErrSCIMGroupMemberUnknown = errors.New("group members must be existing users")
+)
+
+// SCIMUser is a user provisioned by an identity provider.
+type SCIMUser struct {
+	ID          string
+	UserName    string
+	ExternalID  string
+	DisplayName string
+	Emails      []string
+	Active      bool
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// SCIMGroup is a group of SCIMUsers provisioned by an identity provider.
+type SCIMGroup struct {
+	ID          string
+	DisplayName string
+	ExternalID  string
+	Members     []SCIMGroupMember
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+type SCIMGroupMember struct {
+	UserID   string
+	UserName string
+}
+
+// SCIMRepository stores the users and groups an identity provider pushes
+// through SCIM. Usernames and group names are unique regardless of case.
+// Every change notifies the web nodes, which work out the groups of logged in
+// users from them.
+//
+//counterfeiter:generate . SCIMRepository
+type SCIMRepository interface {
+	// SCIMUsers returns every user, or only the one with the userName if it
+	// is given.
+	SCIMUsers(userName string) ([]SCIMUser, error)
+	SCIMUser(id string) (SCIMUser, bool, error)
+	CreateSCIMUser(user SCIMUser) (SCIMUser, error)
+	UpdateSCIMUser(user SCIMUser) (SCIMUser, bool, error)
+	DeleteSCIMUser(id string) (bool, error)
+
+	// SCIMGroups returns every group, or only the one with the displayName
+	// if it is given.
+	SCIMGroups(displayName string) ([]SCIMGroup, error)
+	SCIMGroup(id string) (SCIMGroup, bool, error)
+	CreateSCIMGroup(group SCIMGroup) (SCIMGroup, error)
+
+	// UpdateSCIMGroup replaces the group's name, external ID and members.
+	UpdateSCIMGroup(group SCIMGroup) (SCIMGroup, bool, error)
+	DeleteSCIMGroup(id string) (bool, error)
+
+	// SCIMGroupMemberships returns the names of the groups each active user
+	// belongs to, keyed by their SCIMEmailIdentity and SCIMExternalIDIdentity.
+	SCIMGroupMemberships() (map[string][]string, error)
+}
+
+// SCIMEmailIdentity identifies a SCIM user by one of their emails, or by
+// their userName, which identity providers set to the user's email.
+func SCIMEmailIdentity(email string) string {
+	return "email:" + strings.ToLower(email)
+}
+
+// SCIMExternalIDIdentity identifies a SCIM user by the ID the identity
+// provider knows them by.
+func SCIMExternalIDIdentity(externalID string) string {
+	return "external_id:" + externalID
+}
+
+type scimRepository struct {
+	conn Conn
+}
+
+func NewSCIMRepository(conn Conn) SCIMRepository {
+	return &scimRepository{
+		conn: conn,
+	}
+}
+
+var scimUsersQuery = psql.Select(
+	"id",
+	"user_name",
+	"COALESCE(external_id, '')",
+	"COALESCE(display_name, '')",
+	"emails",
+	"active",
+	"created_at",
+	"updated_at",
+).From("scim_users")
+
+var scimGroupsQuery = psql.Select(
+	"id",
+	"display_name",
+	"COALESCE(external_id, '')",
+	"created_at",
+	"updated_at",
+).From("scim_groups")
+
+func (repo *scimRepository) SCIMUsers(userName string) ([]SCIMUser, error) {
+	query := scimUsersQuery.OrderBy("created_at", "id")
+	if userName != "" {
+		query = query.Where(sq.Eq{"lower(user_name)": strings.ToLower(userName)})
+	}
+
+	rows, err := query.RunWith(repo.conn).Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	users := []SCIMUser{}
+	for rows.Next() {
+		user, err := scanSCIMUser(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		users = append(users, user)
+	}
+
+	return users, nil
+}
+
+func (repo *scimRepository) SCIMUser(id string) (SCIMUser, bool, error) {
+	user, err := scanSCIMUser(scimUsersQuery.
+		Where(sq.Eq{"id": id}).
+		RunWith(repo.conn).
+		QueryRow())
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return SCIMUser{}, false, nil
+		}
+
+		return SCIMUser{}, false, err
+	}
+
+	return user, true, nil
+}
+
+func (repo *scimRepository) CreateSCIMUser(user SCIMUser) (SCIMUser, error) {
+	id, err := uuid.NewV4()
+	if err != nil {
+		return SCIMUser{}, err
+	}
+
+	created, err := scanSCIMUser(psql.Insert("scim_users").
+		SetMap(map[string]interface{}{
+			"id":           id.String(),
+			"user_name":    user.UserName,
+			"external_id":  nullIfEmpty(user.ExternalID),
+			"display_name": nullIfEmpty(user.DisplayName),
+			"emails":       pq.Array(nonNilStrings(user.Emails)),
+			"active":       user.Active,
+		}).
+		Suffix("RETURNING id, user_name, COALESCE(external_id, ''), COALESCE(display_name, ''), emails, active, created_at, updated_at").
+		RunWith(repo.conn).
+		QueryRow())
+	if err != nil {
+		if isUniqueViolation(err) {
+			return SCIMUser{}, ErrSCIMUserExists
+		}
+
+		return SCIMUser{}, err
+	}
+
+	return created, repo.notify()
+}
+
+func (repo *scimRepository) UpdateSCIMUser(user SCIMUser) (SCIMUser, bool, error) {
+	updated, err := scanSCIMUser(psql.Update("scim_users").
+		SetMap(map[string]interface{}{
+			"user_name":    user.UserName,
+			"external_id":  nullIfEmpty(user.ExternalID),
+			"display_name": nullIfEmpty(user.DisplayName),
+			"emails":       pq.Array(nonNilStrings(user.Emails)),
+			"active":       user.Active,
+			"updated_at":   sq.Expr("now()"),
+		}).
+		Where(sq.Eq{"id": user.ID}).
+		Suffix("RETURNING id, user_name, COALESCE(external_id, ''), COALESCE(display_name, ''), emails, active, created_at, updated_at").
+		RunWith(repo.conn).
+		QueryRow())
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return SCIMUser{}, false, nil
+		}
+
+		if isUniqueViolation(err) {
+			return SCIMUser{}, false, ErrSCIMUserExists
+		}
+
+		return SCIMUser{}, false, err
+	}
+
+	return updated, true, repo.notify()
+}
+
+func (repo *scimRepository) DeleteSCIMUser(id string) (bool, error) {
+	return repo.delete("scim_users", id)
+}
+
+func (repo *scimRepository) SCIMGroups(displayName string) ([]SCIMGroup, error) {
+	query := scimGroupsQuery.OrderBy("created_at", "id")
+	if displayName != "" {
+		query = query.Where(sq.Eq{"lower(display_name)": strings.ToLower(displayName)})
+	}
+
+	rows, err := query.RunWith(repo.conn).Query()
+	if err != nil {
+		return nil, err
+	}
+
+	groups, err := scanSCIMGroups(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range groups {
+		groups[i].Members, err = repo.groupMembers(repo.conn, groups[i].ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return groups, nil
+}
+
+func (repo *scimRepository) SCIMGroup(id string) (SCIMGroup, bool, error) {
+	rows, err := scimGroupsQuery.
+		Where(sq.Eq{"id": id}).
+		RunWith(repo.conn).
+		Query()
+	if err != nil {
+		return SCIMGroup{}, false, err
+	}
+
+	groups, err := scanSCIMGroups(rows)
+	if err != nil {
+		return SCIMGroup{}, false, err
+	}
+
+	if len(groups) == 0 {
+		return SCIMGroup{}, false, nil
+	}
+
+	group := groups[0]
+	group.Members, err = repo.groupMembers(repo.conn, group.ID)
+	if err != nil {
+		return SCIMGroup{}, false, err
+	}
+
+	return group, true, nil
+}
+
+func (repo *scimRepository) CreateSCIMGroup(group SCIMGroup) (SCIMGroup, error) {
+	id, err := uuid.NewV4()
+	if err != nil {
+		return SCIMGroup{}, err
+	}
+
+	tx, err := repo.conn.Begin()
+	if err != nil {
+		return SCIMGroup{}, err
+	}
+
+	defer Rollback(tx)
+
+	_, err = psql.Insert("scim_groups").
+		Columns("id", "display_name", "external_id").
+		Values(id.String(), group.DisplayName, nullIfEmpty(group.ExternalID)).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		if isUniqueViolation(err) {
+			return SCIMGroup{}, ErrSCIMGroupExists
+		}
+
+		return SCIMGroup{}, err
+	}
+
+	err = repo.setGroupMembers(tx, id.String(), group.Members)
+	if err != nil {
+		return SCIMGroup{}, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return SCIMGroup{}, err
+	}
+
+	created, _, err := repo.SCIMGroup(id.String())
+	if err != nil {
+		return SCIMGroup{}, err
+	}
+
+	return created, repo.notify()
+}
+
+func (repo *scimRepository) UpdateSCIMGroup(group SCIMGroup) (SCIMGroup, bool, error) {
+	tx, err := repo.conn.Begin()
+	if err != nil {
+		return SCIMGroup{}, false, err
+	}
+
+	defer Rollback(tx)
+
+	result, err := psql.Update("scim_groups").
+		Set("display_name", group.DisplayName).
+		Set("external_id", nullIfEmpty(group.ExternalID)).
+		Set("updated_at", sq.Expr("now()")).
+		Where(sq.Eq{"id": group.ID}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		if isUniqueViolation(err) {
+			return SCIMGroup{}, false, ErrSCIMGroupExists
+		}
+
+		return SCIMGroup{}, false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return SCIMGroup{}, false, err
+	}
+
+	if affected == 0 {
+		return SCIMGroup{}, false, nil
+	}
+
+	_, err = psql.Delete("scim_group_members").
+		Where(sq.Eq{"group_id": group.ID}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return SCIMGroup{}, false, err
+	}
+
+	err = repo.setGroupMembers(tx, group.ID, group.Members)
+	if err != nil {
+		return SCIMGroup{}, false, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return SCIMGroup{}, false, err
+	}
+
+	updated, found, err := repo.SCIMGroup(group.ID)
+	if err != nil {
+		return SCIMGroup{}, false, err
+	}
+
+	return updated, found, repo.notify()
+}
+
+func (repo *scimRepository) DeleteSCIMGroup(id string) (bool, error) {
+	return repo.delete("scim_groups", id)
+}
+
+func (repo *scimRepository) SCIMGroupMemberships() (map[string][]string, error) {
+	rows, err := psql.Select("u.user_name", "u.external_id", "u.emails", "g.display_name").
+		From("scim_group_members m").
+		Join("scim_users u ON u.id = m.user_id").
+		Join("scim_groups g ON g.id = m.group_id").
+		Where(sq.Eq{"u.active": true}).
+		OrderBy("g.display_name").
+		RunWith(repo.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	memberships := map[string][]string{}
+	for rows.Next() {
+		var (
+			userName   string
+			externalID sql.NullString
+			emails     []string
+			groupName  string
+		)
+
+		err := rows.Scan(&userName, &externalID, pq.Array(&emails), &groupName)
+		if err != nil {
+			return nil, err
+		}
+
+		identities := []string{SCIMEmailIdentity(userName)}
+		for _, email := range emails {
+			identities = append(identities, SCIMEmailIdentity(email))
+		}
+
+		if externalID.String != "" {
+			identities = append(identities, SCIMExternalIDIdentity(externalID.String))
+		}
+
+		for _, identity := range identities {
+			if !containsString(memberships[identity], groupName) {
+				memberships[identity] = append(memberships[identity], groupName)
+			}
+		}
+	}
+
+	return memberships, nil
+}
+
+func (repo *scimRepository) delete(table string, id string) (bool, error) {
+	result, err := psql.Delete(table).
+		Where(sq.Eq{"id": id}).
+		RunWith(repo.conn).
+		Exec()
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	if affected == 0 {
+		return false, nil
+	}
+
+	return true, repo.notify()
+}
+
+func (repo *scimRepository) setGroupMembers(tx Tx, groupID string, members []SCIMGroupMember) error {
+	if len(members) == 0 {
+		return nil
+	}
+
+	insert := psql.Insert("scim_group_members").
+		Columns("group_id", "user_id")
+	for _, member := range members {
+		insert = insert.Values(groupID, member.UserID)
+	}
+
+	_, err := insert.
+		Suffix("ON CONFLICT DO NOTHING").
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == pqFKeyViolationErrCode {
+			return ErrSCIMGroupMemberUnknown
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+func (repo *scimRepository) groupMembers(runner sq.BaseRunner, groupID string) ([]SCIMGroupMember, error) {
+	rows, err := psql.Select("u.id", "u.user_name").
+		From("scim_group_members m").
+		Join("scim_users u ON u.id = m.user_id").
+		Where(sq.Eq{"m.group_id": groupID}).
+		OrderBy("u.user_name").
+		RunWith(runner).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	members := []SCIMGroupMember{}
+	for rows.Next() {
+		var member SCIMGroupMember
+		err := rows.Scan(&member.UserID, &member.UserName)
+		if err != nil {
+			return nil, err
+		}
+
+		members = append(members, member)
+	}
+
+	return members, nil
+}
+
+func (repo *scimRepository) notify() error {
+	return repo.conn.Bus().Notify(atc.SCIMChannel)
+}
+
+func scanSCIMUser(row scannable) (SCIMUser, error) {
+	var user SCIMUser
+	err := row.Scan(
+		&user.ID,
+		&user.UserName,
+		&user.ExternalID,
+		&user.DisplayName,
+		pq.Array(&user.Emails),
+		&user.Active,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+	)
+	if err != nil {
+		return SCIMUser{}, err
+	}
+
+	if user.Emails == nil {
+		user.Emails = []string{}
+	}
+
+	return user, nil
+}
+
+func scanSCIMGroups(rows *sql.Rows) ([]SCIMGroup, error) {
+	defer Close(rows)
+
+	groups := []SCIMGroup{}
+	for rows.Next() {
+		var group SCIMGroup
+		err := rows.Scan(&group.ID, &group.DisplayName, &group.ExternalID, &group.CreatedAt, &group.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		groups = append(groups, group)
+	}
+
+	return groups, nil
+}
+
+func isUniqueViolation(err error) bool {
+	pqErr, ok := err.(*pq.Error)
+	return ok && pqErr.Code.Name() == pqUniqueViolationErrCode
+}
+
+func nullIfEmpty(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+
+	return s
+}
+
+func nonNilStrings(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+
+	return s
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+
+	return false
+}
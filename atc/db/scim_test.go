@@ -0,0 +1,153 @@
+package db_test
+
+import (
+	"github.com/concourse/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SCIMRepository", func() {
+	var (
+		repository db.SCIMRepository
+
+		user db.SCIMUser
+	)
+
+	BeforeEach(func() {
+		repository = db.NewSCIMRepository(dbConn)
+
+		var err error
+		user, err = repository.CreateSCIMUser(db.SCIMUser{
+			UserName:   "Some-User",
+			ExternalID: "some-external-id",
+			Emails:     []string{"some-user@example.com"},
+			Active:     true,
+		})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	Describe("users", func() {
+		It("creates the user", func() {
+			Expect(user.ID).ToNot(BeEmpty())
+			Expect(user.CreatedAt).ToNot(BeZero())
+
+			found, exists, err := repository.SCIMUser(user.ID)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(exists).To(BeTrue())
+			Expect(found).To(Equal(user))
+		})
+
+		It("finds users by their userName regardless of case", func() {
+			users, err := repository.SCIMUsers("some-user")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(users).To(Equal([]db.SCIMUser{user}))
+
+			users, err = repository.SCIMUsers("other-user")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(users).To(BeEmpty())
+		})
+
+		It("does not allow the same userName twice", func() {
+			_, err := repository.CreateSCIMUser(db.SCIMUser{UserName: "some-user"})
+			Expect(err).To(Equal(db.ErrSCIMUserExists))
+		})
+
+		It("updates the user", func() {
+			user.DisplayName = "Some User"
+			user.Active = false
+
+			updated, found, err := repository.UpdateSCIMUser(user)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(updated.DisplayName).To(Equal("Some User"))
+			Expect(updated.Active).To(BeFalse())
+		})
+
+		It("deletes the user", func() {
+			deleted, err := repository.DeleteSCIMUser(user.ID)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(deleted).To(BeTrue())
+
+			_, found, err := repository.SCIMUser(user.ID)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+
+			deleted, err = repository.DeleteSCIMUser(user.ID)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(deleted).To(BeFalse())
+		})
+	})
+
+	Describe("groups", func() {
+		var group db.SCIMGroup
+
+		BeforeEach(func() {
+			var err error
+			group, err = repository.CreateSCIMGroup(db.SCIMGroup{
+				DisplayName: "Developers",
+				Members:     []db.SCIMGroupMember{{UserID: user.ID}},
+			})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("creates the group with its members", func() {
+			Expect(group.ID).ToNot(BeEmpty())
+			Expect(group.Members).To(Equal([]db.SCIMGroupMember{{UserID: user.ID, UserName: "Some-User"}}))
+
+			groups, err := repository.SCIMGroups("developers")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(groups).To(Equal([]db.SCIMGroup{group}))
+		})
+
+		It("does not allow the same displayName twice", func() {
+			_, err := repository.CreateSCIMGroup(db.SCIMGroup{DisplayName: "DEVELOPERS"})
+			Expect(err).To(Equal(db.ErrSCIMGroupExists))
+		})
+
+		It("does not allow members which are not users", func() {
+			_, err := repository.CreateSCIMGroup(db.SCIMGroup{
+				DisplayName: "Operators",
+				Members:     []db.SCIMGroupMember{{UserID: "bogus"}},
+			})
+			Expect(err).To(Equal(db.ErrSCIMGroupMemberUnknown))
+		})
+
+		It("replaces the group's members", func() {
+			group.DisplayName = "Devs"
+			group.Members = nil
+
+			updated, found, err := repository.UpdateSCIMGroup(group)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(updated.DisplayName).To(Equal("Devs"))
+			Expect(updated.Members).To(BeEmpty())
+		})
+
+		It("returns the groups of active users by userName, email and external ID", func() {
+			memberships, err := repository.SCIMGroupMemberships()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(memberships).To(Equal(map[string][]string{
+				"email:some-user":              {"Developers"},
+				"email:some-user@example.com":  {"Developers"},
+				"external_id:some-external-id": {"Developers"},
+			}))
+
+			user.Active = false
+			_, _, err = repository.UpdateSCIMUser(user)
+			Expect(err).ToNot(HaveOccurred())
+
+			memberships, err = repository.SCIMGroupMemberships()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(memberships).To(BeEmpty())
+		})
+
+		It("removes deleted users from the group", func() {
+			_, err := repository.DeleteSCIMUser(user.ID)
+			Expect(err).ToNot(HaveOccurred())
+
+			found, _, err := repository.SCIMGroup(group.ID)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found.Members).To(BeEmpty())
+		})
+	})
+})
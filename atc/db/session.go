@@ -0,0 +1,199 @@
+package db
+
+import (
+	"database/sql"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
+	"github.com/lib/pq"
+)
+
+// Session is an unexpired access token issued to a user.
+type Session struct {
+	ID        int
+	Token     string
+	Claims    Claims
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
+
+// SessionRepository lists users' sessions and revokes them. A revoked token
+// is deleted and recorded as revoked until it would have expired, and the web
+// nodes are notified so that they stop accepting it straight away.
+//
+//counterfeiter:generate . SessionRepository
+type SessionRepository interface {
+	// Sessions returns the user's unexpired sessions, newest first.
+	Sessions(sub string) ([]Session, error)
+
+	// RevokeSession returns false if the user has no such session.
+	RevokeSession(sub string, id int, revokedBy string) (bool, error)
+
+	// RevokeSessions revokes every one of the user's sessions, returning how
+	// many there were.
+	RevokeSessions(sub string, revokedBy string) (int, error)
+
+	// RevokedAccessTokens returns the tokens which have been revoked and have
+	// not yet expired.
+	RevokedAccessTokens() ([]string, error)
+}
+
+type sessionRepository struct {
+	conn Conn
+}
+
+func NewSessionRepository(conn Conn) SessionRepository {
+	return &sessionRepository{
+		conn: conn,
+	}
+}
+
+func (repo *sessionRepository) Sessions(sub string) ([]Session, error) {
+	rows, err := psql.Select("id", "token", "claims", "created_at", "expires_at").
+		From("access_tokens").
+		Where(sq.Eq{"sub": sub}).
+		Where(sq.Expr("expires_at > now()")).
+		OrderBy("id DESC").
+		RunWith(repo.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	sessions := []Session{}
+	for rows.Next() {
+		var (
+			session   Session
+			expiresAt pq.NullTime
+		)
+
+		err := rows.Scan(&session.ID, &session.Token, &session.Claims, &session.CreatedAt, &expiresAt)
+		if err != nil {
+			return nil, err
+		}
+
+		session.ExpiresAt = expiresAt.Time
+
+		sessions = append(sessions, session)
+	}
+
+	return sessions, nil
+}
+
+func (repo *sessionRepository) RevokeSession(sub string, id int, revokedBy string) (bool, error) {
+	revoked, err := repo.revoke(sq.Eq{"sub": sub, "id": id}, revokedBy)
+	if err != nil {
+		return false, err
+	}
+
+	return revoked > 0, nil
+}
+
+func (repo *sessionRepository) RevokeSessions(sub string, revokedBy string) (int, error) {
+	return repo.revoke(sq.Eq{"sub": sub}, revokedBy)
+}
+
+func (repo *sessionRepository) RevokedAccessTokens() ([]string, error) {
+	rows, err := psql.Select("token").
+		From("access_token_revocations").
+		Where(sq.Expr("expires_at > now()")).
+		RunWith(repo.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	tokens := []string{}
+	for rows.Next() {
+		var token string
+		err := rows.Scan(&token)
+		if err != nil {
+			return nil, err
+		}
+
+		tokens = append(tokens, token)
+	}
+
+	return tokens, nil
+}
+
+func (repo *sessionRepository) revoke(where sq.Eq, revokedBy string) (int, error) {
+	tx, err := repo.conn.Begin()
+	if err != nil {
+		return 0, err
+	}
+
+	defer Rollback(tx)
+
+	rows, err := psql.Delete("access_tokens").
+		Where(where).
+		Suffix("RETURNING token, sub, expires_at").
+		RunWith(tx).
+		Query()
+	if err != nil {
+		return 0, err
+	}
+
+	revoked, err := scanRevokedTokens(rows)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(revoked) == 0 {
+		return 0, nil
+	}
+
+	insert := psql.Insert("access_token_revocations").
+		Columns("token", "sub", "expires_at", "revoked_by")
+	for _, token := range revoked {
+		insert = insert.Values(token.token, token.sub, token.expiresAt, revokedBy)
+	}
+
+	_, err = insert.
+		Suffix("ON CONFLICT (token) DO NOTHING").
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return 0, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return 0, err
+	}
+
+	err = repo.conn.Bus().Notify(atc.AccessTokenRevocationChannel)
+	if err != nil {
+		return 0, err
+	}
+
+	return len(revoked), nil
+}
+
+type revokedToken struct {
+	token     string
+	sub       string
+	expiresAt pq.NullTime
+}
+
+func scanRevokedTokens(rows *sql.Rows) ([]revokedToken, error) {
+	defer Close(rows)
+
+	var revoked []revokedToken
+	for rows.Next() {
+		var token revokedToken
+		err := rows.Scan(&token.token, &token.sub, &token.expiresAt)
+		if err != nil {
+			return nil, err
+		}
+
+		revoked = append(revoked, token)
+	}
+
+	return revoked, nil
+}
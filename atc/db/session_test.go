@@ -0,0 +1,125 @@
+package db_test
+
+import (
+	"time"
+
+	"github.com/concourse/concourse/atc/db"
+	"gopkg.in/square/go-jose.v2/jwt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SessionRepository", func() {
+	var (
+		repository db.SessionRepository
+		factory    db.AccessTokenFactory
+		lifecycle  db.AccessTokenLifecycle
+	)
+
+	BeforeEach(func() {
+		repository = db.NewSessionRepository(dbConn)
+		factory = db.NewAccessTokenFactory(dbConn)
+		lifecycle = db.NewAccessTokenLifecycle(dbConn)
+
+		tomorrow := jwt.NewNumericDate(now().Add(24 * time.Hour))
+		yesterday := jwt.NewNumericDate(now().Add(-24 * time.Hour))
+
+		for token, claims := range map[string]jwt.Claims{
+			"some-token":    {Subject: "some-sub", Expiry: tomorrow},
+			"expired-token": {Subject: "some-sub", Expiry: yesterday},
+			"other-token":   {Subject: "other-sub", Expiry: tomorrow},
+		} {
+			Expect(factory.CreateAccessToken(token, db.Claims{Claims: claims})).To(Succeed())
+		}
+
+		Expect(factory.CreateAccessToken("newer-token", db.Claims{
+			Claims: jwt.Claims{Subject: "some-sub", Expiry: tomorrow},
+		})).To(Succeed())
+	})
+
+	sessionTokens := func(sub string) []string {
+		sessions, err := repository.Sessions(sub)
+		Expect(err).ToNot(HaveOccurred())
+
+		var tokens []string
+		for _, session := range sessions {
+			tokens = append(tokens, session.Token)
+		}
+
+		return tokens
+	}
+
+	Describe("Sessions", func() {
+		It("returns the user's unexpired sessions, newest first", func() {
+			sessions, err := repository.Sessions("some-sub")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(sessions).To(HaveLen(2))
+
+			Expect(sessions[0].Token).To(Equal("newer-token"))
+			Expect(sessions[0].Claims.Subject).To(Equal("some-sub"))
+			Expect(sessions[0].CreatedAt).ToNot(BeZero())
+			Expect(sessions[0].ExpiresAt).To(BeTemporally(">", now()))
+
+			Expect(sessions[1].Token).To(Equal("some-token"))
+			Expect(sessions[1].ID).To(BeNumerically("<", sessions[0].ID))
+		})
+	})
+
+	Describe("RevokeSession", func() {
+		It("deletes the token and remembers it was revoked", func() {
+			sessions, err := repository.Sessions("some-sub")
+			Expect(err).ToNot(HaveOccurred())
+
+			revoked, err := repository.RevokeSession("some-sub", sessions[1].ID, "some-admin")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(revoked).To(BeTrue())
+
+			_, found, err := factory.GetAccessToken("some-token")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+
+			Expect(sessionTokens("some-sub")).To(Equal([]string{"newer-token"}))
+			Expect(repository.RevokedAccessTokens()).To(Equal([]string{"some-token"}))
+		})
+
+		It("does not revoke another user's session", func() {
+			sessions, err := repository.Sessions("other-sub")
+			Expect(err).ToNot(HaveOccurred())
+
+			revoked, err := repository.RevokeSession("some-sub", sessions[0].ID, "some-user")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(revoked).To(BeFalse())
+
+			Expect(sessionTokens("other-sub")).To(Equal([]string{"other-token"}))
+			Expect(repository.RevokedAccessTokens()).To(BeEmpty())
+		})
+	})
+
+	Describe("RevokeSessions", func() {
+		It("revokes every one of the user's tokens", func() {
+			n, err := repository.RevokeSessions("some-sub", "some-admin")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(n).To(Equal(3))
+
+			Expect(sessionTokens("some-sub")).To(BeEmpty())
+			Expect(sessionTokens("other-sub")).To(Equal([]string{"other-token"}))
+
+			// the expired token is already refused, so only the others are listed
+			Expect(repository.RevokedAccessTokens()).To(ConsistOf("some-token", "newer-token"))
+		})
+
+		It("forgets revocations once the tokens would have expired", func() {
+			_, err := repository.RevokeSessions("some-sub", "some-admin")
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = lifecycle.RemoveExpiredAccessTokens(0)
+			Expect(err).ToNot(HaveOccurred())
+
+			var count int
+			err = dbConn.QueryRow(`SELECT count(*) FROM access_token_revocations`).Scan(&count)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(count).To(Equal(2))
+		})
+	})
+})
@@ -22,6 +22,7 @@ import (
 )
 
 var ErrConfigComparisonFailed = errors.New("comparison with existing config failed during save")
+var ErrTeamNameTaken = errors.New("a team with the same name already exists")
 
 type ErrPipelineNotFound atc.PipelineRef
 
@@ -53,9 +54,11 @@ type Team interface {
 	PublicPipelines() ([]Pipeline, error)
 	OrderPipelines([]string) error
 	OrderPipelinesWithinGroup(string, []atc.InstanceVars) error
+	BatchUpdatePipelines(action atc.PipelineBatchAction, pipelineIDs []int, user string) ([]int, error)
 
 	CreateOneOffBuild() (Build, error)
 	CreateStartedBuild(plan atc.Plan) (Build, error)
+	RunningOneOffBuilds() (int, error)
 
 	PrivateAndPublicBuilds(Page) ([]BuildForAPI, Pagination, error)
 	Builds(page Page) ([]BuildForAPI, Pagination, error)
@@ -78,6 +81,22 @@ type Team interface {
 	FindWorkersForResourceCache(rcId int) ([]Worker, error)
 
 	UpdateProviderAuth(auth atc.TeamAuth) error
+
+	SaveNotifier(atc.NotifierConfig) error
+	Notifiers() ([]atc.NotifierConfig, error)
+	DeleteNotifier(name string) (bool, error)
+
+	SetWorkerKeys([]string) error
+	WorkerKeys() ([]string, error)
+
+	SetInterceptSettings(atc.InterceptSettings) error
+	InterceptSettings() (atc.InterceptSettings, error)
+
+	SetPrivilegedSettings(atc.PrivilegedSettings) error
+	PrivilegedSettings() (atc.PrivilegedSettings, error)
+
+	SetProxySettings(atc.ProxySettings) error
+	ProxySettings() (atc.ProxySettings, error)
 }
 
 type team struct {
@@ -108,6 +127,8 @@ func (t *team) Delete() error {
 	return err
 }
 
+// Rename renames the team, returning ErrTeamNameTaken if another team has
+// the name, regardless of case.
 func (t *team) Rename(name string) error {
 	_, err := psql.Update("teams").
 		Set("name", name).
@@ -116,8 +137,17 @@ func (t *team) Rename(name string) error {
 		}).
 		RunWith(t.conn).
 		Exec()
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == pqUniqueViolationErrCode {
+			return ErrTeamNameTaken
+		}
 
-	return err
+		return err
+	}
+
+	t.name = name
+
+	return nil
 }
 
 func (t *team) Workers() ([]Worker, error) {
@@ -283,8 +313,13 @@ func (t *team) IsCheckContainer(handle string) (bool, error) {
 		Where(sq.Eq{
 			"handle": handle,
 		}).
-		Where(sq.NotEq{
-			"resource_config_check_session_id": nil,
+		Where(sq.Or{
+			sq.NotEq{
+				"resource_config_check_session_id": nil,
+			},
+			sq.NotEq{
+				"check_container_pool_slot_id": nil,
+			},
 		}).
 		RunWith(t.conn).
 		QueryRow().
@@ -318,6 +353,22 @@ func (t *team) IsContainerWithinTeam(handle string, isCheck bool) (bool, error)
 			RunWith(t.conn).
 			QueryRow().
 			Scan(&ok)
+		if err == sql.ErrNoRows {
+			// pooled check containers are shared by the resources of the team
+			// they were created for
+			err = psql.Select("1").
+				From("containers c").
+				Where(sq.Eq{
+					"c.team_id": t.id,
+					"c.handle":  handle,
+				}).
+				Where(sq.NotEq{
+					"c.check_container_pool_slot_id": nil,
+				}).
+				RunWith(t.conn).
+				QueryRow().
+				Scan(&ok)
+		}
 	} else {
 		err = psql.Select("1").
 			From("containers c").
@@ -438,6 +489,16 @@ func savePipeline(
 		return 0, false, err
 	}
 
+	varDeclarationsPayload, err := json.Marshal(config.Vars)
+	if err != nil {
+		return 0, false, err
+	}
+
+	notificationsPayload, err := json.Marshal(config.Notifications)
+	if err != nil {
+		return 0, false, err
+	}
+
 	varSourcesPayload, err := json.Marshal(config.VarSources)
 	if err != nil {
 		return 0, false, err
@@ -456,18 +517,21 @@ func savePipeline(
 	var pipelineID int
 	if !existingConfig {
 		values := map[string]interface{}{
-			"name":            pipelineRef.Name,
-			"groups":          groupsPayload,
-			"var_sources":     encryptedVarSourcesPayload,
-			"display":         displayPayload,
-			"nonce":           nonce,
-			"version":         sq.Expr("nextval('config_version_seq')"),
-			"paused":          initiallyPaused,
-			"last_updated":    sq.Expr("now()"),
-			"team_id":         teamID,
-			"parent_job_id":   jobID,
-			"parent_build_id": buildID,
-			"instance_vars":   instanceVars,
+			"name":              pipelineRef.Name,
+			"groups":            groupsPayload,
+			"var_declarations":  varDeclarationsPayload,
+			"notifications":     notificationsPayload,
+			"var_sources":       encryptedVarSourcesPayload,
+			"display":           displayPayload,
+			"nonce":             nonce,
+			"version":           sq.Expr("nextval('config_version_seq')"),
+			"paused":            initiallyPaused,
+			"last_updated":      sq.Expr("now()"),
+			"team_id":           teamID,
+			"parent_job_id":     jobID,
+			"parent_build_id":   buildID,
+			"instance_vars":     instanceVars,
+			"team_workers_only": config.Workers != nil && config.Workers.TeamOnly,
 		}
 		var ordering sql.NullInt64
 		var secondaryOrdering sql.NullInt64
@@ -503,8 +567,11 @@ func savePipeline(
 		q := psql.Update("pipelines").
 			Set("archived", false).
 			Set("groups", groupsPayload).
+			Set("var_declarations", varDeclarationsPayload).
+			Set("notifications", notificationsPayload).
 			Set("var_sources", encryptedVarSourcesPayload).
 			Set("display", displayPayload).
+			Set("team_workers_only", config.Workers != nil && config.Workers.TeamOnly).
 			Set("nonce", nonce).
 			Set("version", sq.Expr("nextval('config_version_seq')")).
 			Set("last_updated", sq.Expr("now()")).
@@ -602,6 +669,32 @@ func savePipeline(
 		return 0, false, err
 	}
 
+	err = enqueueWebhookDeliveries(tx, teamID, atc.WebhookEventPipelineSet, func() (interface{}, error) {
+		pipeline := atc.Pipeline{
+			ID:            pipelineID,
+			Name:          pipelineRef.Name,
+			InstanceVars:  pipelineRef.InstanceVars,
+			ParentBuildID: int(buildID.Int64),
+			ParentJobID:   int(jobID.Int64),
+		}
+
+		err := psql.Select("p.paused", "p.public", "t.name").
+			From("pipelines p").
+			Join("teams t ON t.id = p.team_id").
+			Where(sq.Eq{"p.id": pipelineID}).
+			RunWith(tx).
+			QueryRow().
+			Scan(&pipeline.Paused, &pipeline.Public, &pipeline.TeamName)
+		if err != nil {
+			return nil, err
+		}
+
+		return pipeline, nil
+	})
+	if err != nil {
+		return 0, false, err
+	}
+
 	return pipelineID, !existingConfig, nil
 }
 
@@ -906,9 +999,30 @@ func (t *team) CreateStartedBuild(plan atc.Plan) (Build, error) {
 	return build, nil
 }
 
+// RunningOneOffBuilds returns how many of the team's one-off builds have yet
+// to complete.
+func (t *team) RunningOneOffBuilds() (int, error) {
+	var count int
+	err := psql.Select("COUNT(*)").
+		From("builds").
+		Where(sq.Eq{
+			"team_id":     t.id,
+			"pipeline_id": nil,
+			"completed":   false,
+		}).
+		RunWith(t.conn).
+		QueryRow().
+		Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
 func (t *team) PrivateAndPublicBuilds(page Page) ([]BuildForAPI, Pagination, error) {
 	newBuildsQuery := buildsQuery.
-		Where(sq.Or{sq.Eq{"p.public": true}, sq.Eq{"t.id": t.id}})
+		Where(sq.Or{publicJobCondition, sq.Eq{"t.id": t.id}})
 
 	return getBuildsWithPagination(newBuildsQuery, minMaxIdQuery, page, t.conn, t.lockFactory, false)
 }
@@ -1222,13 +1336,44 @@ func saveJob(tx Tx, job atc.JobConfig, pipelineID int, groups []string) (int, er
 		return 0, err
 	}
 
+	err = saveJobWarmPool(tx, jobID, job.WarmPool)
+	if err != nil {
+		return 0, err
+	}
+
 	return jobID, nil
 }
 
-func registerSerialGroup(tx Tx, serialGroup string, jobID int) error {
+// saveJobWarmPool keeps the job's warm pool in line with its config. Removing
+// the warm pool removes its templates and slots, and the containers are then
+// collected as orphans.
+func saveJobWarmPool(tx Tx, jobID int, config *atc.WarmPoolConfig) error {
+	if config == nil {
+		_, err := psql.Delete("job_warm_pools").
+			Where(sq.Eq{"job_id": jobID}).
+			RunWith(tx).
+			Exec()
+		return err
+	}
+
+	expiry, err := config.ExpiryDuration()
+	if err != nil {
+		return err
+	}
+
+	_, err = psql.Insert("job_warm_pools").
+		Columns("job_id", "size", "expiry").
+		Values(jobID, config.Size, fmt.Sprintf("%d seconds", int(expiry.Seconds()))).
+		Suffix("ON CONFLICT (job_id) DO UPDATE SET size = EXCLUDED.size, expiry = EXCLUDED.expiry").
+		RunWith(tx).
+		Exec()
+	return err
+}
+
+func registerSerialGroup(tx Tx, serialGroup string, jobID int, teamScoped bool) error {
 	_, err := psql.Insert("jobs_serial_groups").
-		Columns("serial_group", "job_id").
-		Values(serialGroup, jobID).
+		Columns("serial_group", "job_id", "team_scoped").
+		Values(serialGroup, jobID, teamScoped).
 		RunWith(tx).
 		Exec()
 	return err
@@ -1320,19 +1465,21 @@ func (t *team) findContainer(whereClause sq.Sqlizer) (CreatingContainer, Created
 
 func scanPipeline(p *pipeline, scan scannable) error {
 	var (
-		groups        sql.NullString
-		varSources    sql.NullString
-		display       sql.NullString
-		nonce         sql.NullString
-		nonceStr      *string
-		lastUpdated   pq.NullTime
-		parentJobID   sql.NullInt64
-		parentBuildID sql.NullInt64
-		instanceVars  sql.NullString
-		pausedBy      sql.NullString
-		pausedAt      sql.NullTime
+		groups          sql.NullString
+		varDeclarations sql.NullString
+		notifications   sql.NullString
+		varSources      sql.NullString
+		display         sql.NullString
+		nonce           sql.NullString
+		nonceStr        *string
+		lastUpdated     pq.NullTime
+		parentJobID     sql.NullInt64
+		parentBuildID   sql.NullInt64
+		instanceVars    sql.NullString
+		pausedBy        sql.NullString
+		pausedAt        sql.NullTime
 	)
-	err := scan.Scan(&p.id, &p.name, &groups, &varSources, &display, &nonce, &p.configVersion, &p.teamID, &p.teamName, &p.paused, &p.public, &p.archived, &lastUpdated, &parentJobID, &parentBuildID, &instanceVars, &pausedBy, &pausedAt)
+	err := scan.Scan(&p.id, &p.name, &groups, &varDeclarations, &notifications, &varSources, &display, &nonce, &p.configVersion, &p.teamID, &p.teamName, &p.paused, &p.public, &p.archived, &lastUpdated, &parentJobID, &parentBuildID, &instanceVars, &pausedBy, &pausedAt, &p.teamWorkersOnly, pq.Array(&p.exposure.Jobs), &p.exposure.StatusOnly)
 	if err != nil {
 		return err
 	}
@@ -1351,6 +1498,26 @@ func scanPipeline(p *pipeline, scan scannable) error {
 		p.groups = pipelineGroups
 	}
 
+	if varDeclarations.Valid {
+		var pipelineVarDeclarations atc.VarDeclarations
+		err = json.Unmarshal([]byte(varDeclarations.String), &pipelineVarDeclarations)
+		if err != nil {
+			return err
+		}
+
+		p.varDeclarations = pipelineVarDeclarations
+	}
+
+	if notifications.Valid {
+		var pipelineNotifications atc.NotificationRules
+		err = json.Unmarshal([]byte(notifications.String), &pipelineNotifications)
+		if err != nil {
+			return err
+		}
+
+		p.notifications = pipelineNotifications
+	}
+
 	if nonce.Valid {
 		nonceStr = &nonce.String
 	}
@@ -1729,19 +1896,26 @@ func saveJobsAndSerialGroups(tx Tx, jobs atc.JobConfigs, groups atc.GroupConfigs
 
 		if len(job.SerialGroups) != 0 {
 			for _, sg := range job.SerialGroups {
-				err = registerSerialGroup(tx, sg, jobID)
+				err = registerSerialGroup(tx, sg, jobID, false)
 				if err != nil {
 					return nil, err
 				}
 			}
 		} else {
 			if job.Serial || job.RawMaxInFlight > 0 {
-				err = registerSerialGroup(tx, job.Name, jobID)
+				err = registerSerialGroup(tx, job.Name, jobID, false)
 				if err != nil {
 					return nil, err
 				}
 			}
 		}
+
+		for _, sg := range job.TeamSerialGroups {
+			err = registerSerialGroup(tx, sg, jobID, true)
+			if err != nil {
+				return nil, err
+			}
+		}
 	}
 
 	return jobNameToID, nil
@@ -20,6 +20,7 @@ type TeamFactory interface {
 	CreateDefaultTeamIfNotExists() (Team, error)
 	NotifyResourceScanner() error
 	NotifyCacher() error
+	WorkerKeys() ([]atc.TeamWorkerKeys, error)
 }
 
 type teamFactory struct {
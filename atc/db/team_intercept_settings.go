@@ -0,0 +1,37 @@
+package db
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
+)
+
+func (t *team) SetInterceptSettings(settings atc.InterceptSettings) error {
+	_, err := psql.Update("teams").
+		Set("intercept_disabled", settings.Disabled).
+		Set("record_intercepts", settings.RecordTranscripts).
+		Where(sq.Eq{"id": t.id}).
+		RunWith(t.conn).
+		Exec()
+	return err
+}
+
+func (t *team) InterceptSettings() (atc.InterceptSettings, error) {
+	var settings atc.InterceptSettings
+	err := psql.Select("intercept_disabled", "record_intercepts").
+		From("teams").
+		Where(sq.Eq{"id": t.id}).
+		RunWith(t.conn).
+		QueryRow().
+		Scan(&settings.Disabled, &settings.RecordTranscripts)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return atc.InterceptSettings{}, nil
+		}
+
+		return atc.InterceptSettings{}, err
+	}
+
+	return settings, nil
+}
@@ -0,0 +1,104 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
+)
+
+// SaveNotifier creates or replaces the team's notifier with the same name.
+// The config of a notifier is encrypted, as it typically contains
+// credentials.
+func (t *team) SaveNotifier(notifier atc.NotifierConfig) error {
+	payload, err := json.Marshal(notifier.Config)
+	if err != nil {
+		return err
+	}
+
+	encryptedPayload, nonce, err := t.conn.EncryptionStrategy().Encrypt(payload)
+	if err != nil {
+		return err
+	}
+
+	_, err = psql.Insert("team_notifiers").
+		Columns("team_id", "name", "type", "config", "nonce").
+		Values(t.id, notifier.Name, notifier.Type, encryptedPayload, nonce).
+		Suffix(`
+			ON CONFLICT (team_id, name) DO UPDATE SET
+				type = EXCLUDED.type,
+				config = EXCLUDED.config,
+				nonce = EXCLUDED.nonce
+		`).
+		RunWith(t.conn).
+		Exec()
+	return err
+}
+
+func (t *team) Notifiers() ([]atc.NotifierConfig, error) {
+	rows, err := psql.Select("name", "type", "config", "nonce").
+		From("team_notifiers").
+		Where(sq.Eq{"team_id": t.id}).
+		OrderBy("name").
+		RunWith(t.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	notifiers := []atc.NotifierConfig{}
+	for rows.Next() {
+		var (
+			notifier atc.NotifierConfig
+			config   string
+			nonce    sql.NullString
+		)
+
+		err := rows.Scan(&notifier.Name, &notifier.Type, &config, &nonce)
+		if err != nil {
+			return nil, err
+		}
+
+		var noncense *string
+		if nonce.Valid {
+			noncense = &nonce.String
+		}
+
+		decryptedConfig, err := t.conn.EncryptionStrategy().Decrypt(config, noncense)
+		if err != nil {
+			return nil, err
+		}
+
+		err = json.Unmarshal(decryptedConfig, &notifier.Config)
+		if err != nil {
+			return nil, err
+		}
+
+		notifiers = append(notifiers, notifier)
+	}
+
+	return notifiers, nil
+}
+
+func (t *team) DeleteNotifier(name string) (bool, error) {
+	result, err := psql.Delete("team_notifiers").
+		Where(sq.Eq{
+			"team_id": t.id,
+			"name":    name,
+		}).
+		RunWith(t.conn).
+		Exec()
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected > 0, nil
+}
@@ -0,0 +1,99 @@
+package db
+
+import (
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
+)
+
+// BatchUpdatePipelines applies the action to the given pipelines of the team
+// in a single transaction, returning the IDs of the pipelines which were
+// changed by it. Archived pipelines are left alone.
+func (t *team) BatchUpdatePipelines(action atc.PipelineBatchAction, pipelineIDs []int, user string) ([]int, error) {
+	update := psql.Update("pipelines").
+		Where(sq.Eq{
+			"team_id":  t.id,
+			"id":       pipelineIDs,
+			"archived": false,
+		})
+
+	switch action {
+	case atc.PipelineBatchPause:
+		update = update.
+			Set("paused", true).
+			Set("paused_at", time.Now()).
+			Set("paused_by", user).
+			Where(sq.Eq{"paused": false})
+	case atc.PipelineBatchUnpause:
+		update = update.
+			Set("paused", false).
+			Set("paused_by", nil).
+			Set("paused_at", nil).
+			Where(sq.Eq{"paused": true})
+	case atc.PipelineBatchExpose:
+		update = update.
+			Set("public", true).
+			Set("public_jobs", nil).
+			Set("public_status_only", false).
+			Where(sq.Or{
+				sq.Eq{"public": false},
+				sq.NotEq{"public_jobs": nil},
+				sq.Eq{"public_status_only": true},
+			})
+	case atc.PipelineBatchHide:
+		update = update.
+			Set("public", false).
+			Set("public_jobs", nil).
+			Set("public_status_only", false).
+			Where(sq.Eq{"public": true})
+	default:
+		return nil, fmt.Errorf("unknown pipeline batch action: %s", action)
+	}
+
+	tx, err := t.conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Rollback(tx)
+
+	rows, err := update.
+		Suffix("RETURNING id").
+		RunWith(tx).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	var changed []int
+	for rows.Next() {
+		var id int
+		err = rows.Scan(&id)
+		if err != nil {
+			Close(rows)
+			return nil, err
+		}
+
+		changed = append(changed, id)
+	}
+
+	Close(rows)
+
+	if action == atc.PipelineBatchUnpause {
+		for _, id := range changed {
+			err = requestScheduleForJobsInPipeline(tx, id)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return changed, nil
+}
@@ -0,0 +1,61 @@
+package db
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
+)
+
+func (t *team) SetPrivilegedSettings(settings atc.PrivilegedSettings) error {
+	_, err := psql.Update("teams").
+		Set("privileged_policy", settings.Policy).
+		Where(sq.Eq{"id": t.id}).
+		RunWith(t.conn).
+		Exec()
+	return err
+}
+
+func (t *team) PrivilegedSettings() (atc.PrivilegedSettings, error) {
+	return privilegedSettings(t.conn, sq.Eq{"id": t.id})
+}
+
+// PrivilegedPolicies looks up the privileged policies of teams by their
+// name, for checking the use of privileged containers.
+type PrivilegedPolicies struct {
+	conn Conn
+}
+
+func NewPrivilegedPolicies(conn Conn) *PrivilegedPolicies {
+	return &PrivilegedPolicies{conn: conn}
+}
+
+// PrivilegedPolicy returns the privileged policy of the team, allowing
+// privileged containers if the team does not exist.
+func (p *PrivilegedPolicies) PrivilegedPolicy(teamName string) (atc.PrivilegedPolicy, error) {
+	settings, err := privilegedSettings(p.conn, sq.Eq{"name": teamName})
+	if err != nil {
+		return "", err
+	}
+
+	return settings.Policy, nil
+}
+
+func privilegedSettings(conn Conn, where sq.Eq) (atc.PrivilegedSettings, error) {
+	var settings atc.PrivilegedSettings
+	err := psql.Select("privileged_policy").
+		From("teams").
+		Where(where).
+		RunWith(conn).
+		QueryRow().
+		Scan(&settings.Policy)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return atc.PrivilegedSettings{Policy: atc.PrivilegedPolicyAllow}, nil
+		}
+
+		return atc.PrivilegedSettings{}, err
+	}
+
+	return settings, nil
+}
@@ -0,0 +1,58 @@
+package db
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
+)
+
+func (t *team) SetProxySettings(settings atc.ProxySettings) error {
+	_, err := psql.Update("teams").
+		Set("http_proxy", settings.HTTPProxy).
+		Set("https_proxy", settings.HTTPSProxy).
+		Set("no_proxy", settings.NoProxy).
+		Where(sq.Eq{"id": t.id}).
+		RunWith(t.conn).
+		Exec()
+	return err
+}
+
+func (t *team) ProxySettings() (atc.ProxySettings, error) {
+	return proxySettings(t.conn, t.id)
+}
+
+// TeamProxies looks up the proxy settings of teams by their ID, for
+// configuring the containers of the teams.
+type TeamProxies struct {
+	conn Conn
+}
+
+func NewTeamProxies(conn Conn) *TeamProxies {
+	return &TeamProxies{conn: conn}
+}
+
+// ProxySettings returns the proxy settings of the team, which are empty if
+// the team does not exist.
+func (p *TeamProxies) ProxySettings(teamID int) (atc.ProxySettings, error) {
+	return proxySettings(p.conn, teamID)
+}
+
+func proxySettings(conn Conn, teamID int) (atc.ProxySettings, error) {
+	var settings atc.ProxySettings
+	err := psql.Select("http_proxy", "https_proxy", "no_proxy").
+		From("teams").
+		Where(sq.Eq{"id": teamID}).
+		RunWith(conn).
+		QueryRow().
+		Scan(&settings.HTTPProxy, &settings.HTTPSProxy, &settings.NoProxy)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return atc.ProxySettings{}, nil
+		}
+
+		return atc.ProxySettings{}, err
+	}
+
+	return settings, nil
+}
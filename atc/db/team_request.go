@@ -0,0 +1,297 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
+	"github.com/lib/pq"
+)
+
+var (
+	ErrRequestedTeamExists = errors.New("requested team already exists")
+	ErrTeamRequestPending  = errors.New("team has already been requested")
+	ErrTeamRequestDecided  = errors.New("team request has already been decided")
+)
+
+// TeamRequestRepository manages the requests by users for teams to be
+// created. Creating, approving and rejecting a request each notify the
+// cluster's webhooks.
+//
+//counterfeiter:generate . TeamRequestRepository
+type TeamRequestRepository interface {
+	// TeamRequests returns the requests made by the given user, or every
+	// request if requestedBy is empty, newest first.
+	TeamRequests(requestedBy string) ([]atc.TeamRequest, error)
+
+	CreateTeamRequest(request atc.TeamRequest) (atc.TeamRequest, error)
+
+	// ApproveTeamRequest creates the requested team along with approving the
+	// request. It returns false if there is no such request.
+	ApproveTeamRequest(id int, decidedBy string, comment string) (atc.TeamRequest, bool, error)
+
+	// RejectTeamRequest returns false if there is no such request.
+	RejectTeamRequest(id int, decidedBy string, comment string) (atc.TeamRequest, bool, error)
+}
+
+var teamRequestsQuery = psql.Select(
+	"id",
+	"team_name",
+	"auth",
+	"reason",
+	"status",
+	"requested_by",
+	"requested_at",
+	"decided_by",
+	"decided_at",
+	"comment",
+).
+	From("team_requests")
+
+type teamRequestRepository struct {
+	conn Conn
+}
+
+func NewTeamRequestRepository(conn Conn) TeamRequestRepository {
+	return &teamRequestRepository{
+		conn: conn,
+	}
+}
+
+func (repo *teamRequestRepository) TeamRequests(requestedBy string) ([]atc.TeamRequest, error) {
+	query := teamRequestsQuery
+	if requestedBy != "" {
+		query = query.Where(sq.Eq{"requested_by": requestedBy})
+	}
+
+	rows, err := query.
+		OrderBy("id DESC").
+		RunWith(repo.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	requests := []atc.TeamRequest{}
+	for rows.Next() {
+		request, err := scanTeamRequest(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		requests = append(requests, request)
+	}
+
+	return requests, nil
+}
+
+func (repo *teamRequestRepository) CreateTeamRequest(request atc.TeamRequest) (atc.TeamRequest, error) {
+	tx, err := repo.conn.Begin()
+	if err != nil {
+		return atc.TeamRequest{}, err
+	}
+
+	defer Rollback(tx)
+
+	var exists bool
+	err = tx.QueryRow(`
+		SELECT EXISTS (SELECT 1 FROM teams WHERE lower(name) = lower($1))
+	`, request.TeamName).Scan(&exists)
+	if err != nil {
+		return atc.TeamRequest{}, err
+	}
+
+	if exists {
+		return atc.TeamRequest{}, ErrRequestedTeamExists
+	}
+
+	auth, err := json.Marshal(request.Auth)
+	if err != nil {
+		return atc.TeamRequest{}, err
+	}
+
+	var requestedAt time.Time
+	err = psql.Insert("team_requests").
+		Columns("team_name", "auth", "reason", "requested_by").
+		Values(request.TeamName, auth, request.Reason, request.RequestedBy).
+		Suffix("RETURNING id, requested_at").
+		RunWith(tx).
+		QueryRow().
+		Scan(&request.ID, &requestedAt)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == pqUniqueViolationErrCode {
+			return atc.TeamRequest{}, ErrTeamRequestPending
+		}
+
+		return atc.TeamRequest{}, err
+	}
+
+	request.Status = atc.TeamRequestPending
+	request.RequestedAt = requestedAt.Unix()
+	request.DecidedBy = ""
+	request.DecidedAt = 0
+	request.Comment = ""
+
+	err = enqueueWebhookDeliveries(tx, 0, atc.WebhookEventTeamRequested, func() (interface{}, error) {
+		return request, nil
+	})
+	if err != nil {
+		return atc.TeamRequest{}, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return atc.TeamRequest{}, err
+	}
+
+	return request, nil
+}
+
+func (repo *teamRequestRepository) ApproveTeamRequest(id int, decidedBy string, comment string) (atc.TeamRequest, bool, error) {
+	return repo.decide(id, atc.TeamRequestApproved, decidedBy, comment, func(tx Tx, request atc.TeamRequest) error {
+		auth, err := json.Marshal(request.Auth)
+		if err != nil {
+			return err
+		}
+
+		_, err = psql.Insert("teams").
+			Columns("name", "auth").
+			Values(request.TeamName, auth).
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == pqUniqueViolationErrCode {
+				return ErrRequestedTeamExists
+			}
+
+			return err
+		}
+
+		return nil
+	})
+}
+
+func (repo *teamRequestRepository) RejectTeamRequest(id int, decidedBy string, comment string) (atc.TeamRequest, bool, error) {
+	return repo.decide(id, atc.TeamRequestRejected, decidedBy, comment, func(Tx, atc.TeamRequest) error {
+		return nil
+	})
+}
+
+// decide moves a pending request to the given status, doing whatever the
+// decision entails in the same transaction.
+func (repo *teamRequestRepository) decide(id int, status atc.TeamRequestStatus, decidedBy string, comment string, apply func(Tx, atc.TeamRequest) error) (atc.TeamRequest, bool, error) {
+	tx, err := repo.conn.Begin()
+	if err != nil {
+		return atc.TeamRequest{}, false, err
+	}
+
+	defer Rollback(tx)
+
+	request, err := scanTeamRequest(teamRequestsQuery.
+		Where(sq.Eq{"id": id}).
+		Suffix("FOR UPDATE").
+		RunWith(tx).
+		QueryRow())
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return atc.TeamRequest{}, false, nil
+		}
+
+		return atc.TeamRequest{}, false, err
+	}
+
+	if request.Status != atc.TeamRequestPending {
+		return atc.TeamRequest{}, false, ErrTeamRequestDecided
+	}
+
+	err = apply(tx, request)
+	if err != nil {
+		return atc.TeamRequest{}, false, err
+	}
+
+	var decidedAt time.Time
+	err = psql.Update("team_requests").
+		Set("status", string(status)).
+		Set("decided_by", decidedBy).
+		Set("decided_at", sq.Expr("now()")).
+		Set("comment", comment).
+		Where(sq.Eq{"id": id}).
+		Suffix("RETURNING decided_at").
+		RunWith(tx).
+		QueryRow().
+		Scan(&decidedAt)
+	if err != nil {
+		return atc.TeamRequest{}, false, err
+	}
+
+	request.Status = status
+	request.DecidedBy = decidedBy
+	request.DecidedAt = decidedAt.Unix()
+	request.Comment = comment
+
+	event := atc.WebhookEventTeamRequestApproved
+	if status == atc.TeamRequestRejected {
+		event = atc.WebhookEventTeamRequestRejected
+	}
+
+	err = enqueueWebhookDeliveries(tx, 0, event, func() (interface{}, error) {
+		return request, nil
+	})
+	if err != nil {
+		return atc.TeamRequest{}, false, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return atc.TeamRequest{}, false, err
+	}
+
+	return request, true, nil
+}
+
+func scanTeamRequest(row scannable) (atc.TeamRequest, error) {
+	var (
+		request     atc.TeamRequest
+		auth        string
+		status      string
+		requestedAt time.Time
+		decidedBy   sql.NullString
+		decidedAt   pq.NullTime
+	)
+
+	err := row.Scan(
+		&request.ID,
+		&request.TeamName,
+		&auth,
+		&request.Reason,
+		&status,
+		&request.RequestedBy,
+		&requestedAt,
+		&decidedBy,
+		&decidedAt,
+		&request.Comment,
+	)
+	if err != nil {
+		return atc.TeamRequest{}, err
+	}
+
+	err = json.Unmarshal([]byte(auth), &request.Auth)
+	if err != nil {
+		return atc.TeamRequest{}, err
+	}
+
+	request.Status = atc.TeamRequestStatus(status)
+	request.RequestedAt = requestedAt.Unix()
+	request.DecidedBy = decidedBy.String
+
+	if decidedAt.Valid {
+		request.DecidedAt = decidedAt.Time.Unix()
+	}
+
+	return request, nil
+}
@@ -0,0 +1,211 @@
+package db_test
+
+import (
+	"encoding/json"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TeamRequestRepository", func() {
+	var (
+		repository        db.TeamRequestRepository
+		webhookRepository db.OutgoingWebhookRepository
+
+		request atc.TeamRequest
+	)
+
+	BeforeEach(func() {
+		repository = db.NewTeamRequestRepository(dbConn)
+		webhookRepository = db.NewOutgoingWebhookRepository(dbConn)
+
+		Expect(webhookRepository.SaveWebhook(0, atc.OutgoingWebhook{
+			Name:   "cluster-webhook",
+			URL:    "https://example.com/cluster",
+			Secret: "cluster-secret",
+			Events: []atc.WebhookEvent{
+				atc.WebhookEventTeamRequested,
+				atc.WebhookEventTeamRequestApproved,
+				atc.WebhookEventTeamRequestRejected,
+			},
+		})).To(Succeed())
+
+		var err error
+		request, err = repository.CreateTeamRequest(atc.TeamRequest{
+			TeamName:    "some-team",
+			Auth:        atc.TeamAuth{"owner": {"users": {"local:some-user"}}},
+			Reason:      "for some-project",
+			RequestedBy: "some-user",
+		})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	deliveredEvents := func() []atc.WebhookEvent {
+		deliveries, err := webhookRepository.PendingDeliveries(10)
+		Expect(err).ToNot(HaveOccurred())
+
+		var events []atc.WebhookEvent
+		for _, delivery := range deliveries {
+			var payload struct {
+				Data atc.TeamRequest `json:"data"`
+			}
+			Expect(json.Unmarshal(delivery.Payload, &payload)).To(Succeed())
+			Expect(payload.Data.ID).To(Equal(request.ID))
+
+			events = append(events, delivery.Event)
+		}
+
+		return events
+	}
+
+	Describe("CreateTeamRequest", func() {
+		It("creates a pending request", func() {
+			Expect(request.ID).ToNot(BeZero())
+			Expect(request.Status).To(Equal(atc.TeamRequestPending))
+			Expect(request.RequestedAt).ToNot(BeZero())
+
+			requests, err := repository.TeamRequests("")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(requests).To(Equal([]atc.TeamRequest{request}))
+		})
+
+		It("notifies the cluster's webhooks", func() {
+			Expect(deliveredEvents()).To(Equal([]atc.WebhookEvent{atc.WebhookEventTeamRequested}))
+		})
+
+		It("does not allow requesting the same team again while it is pending", func() {
+			_, err := repository.CreateTeamRequest(atc.TeamRequest{
+				TeamName:    "some-team",
+				Auth:        atc.TeamAuth{"owner": {"users": {"local:other-user"}}},
+				RequestedBy: "other-user",
+			})
+			Expect(err).To(Equal(db.ErrTeamRequestPending))
+		})
+
+		It("does not allow requesting a team which exists", func() {
+			_, err := repository.CreateTeamRequest(atc.TeamRequest{
+				TeamName:    "Default-Team",
+				Auth:        atc.TeamAuth{"owner": {"users": {"local:other-user"}}},
+				RequestedBy: "other-user",
+			})
+			Expect(err).To(Equal(db.ErrRequestedTeamExists))
+		})
+	})
+
+	Describe("TeamRequests", func() {
+		var otherRequest atc.TeamRequest
+
+		BeforeEach(func() {
+			var err error
+			otherRequest, err = repository.CreateTeamRequest(atc.TeamRequest{
+				TeamName:    "other-team",
+				Auth:        atc.TeamAuth{"owner": {"users": {"local:other-user"}}},
+				RequestedBy: "other-user",
+			})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("returns every request, newest first", func() {
+			requests, err := repository.TeamRequests("")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(requests).To(Equal([]atc.TeamRequest{otherRequest, request}))
+		})
+
+		It("returns the requests made by a user", func() {
+			requests, err := repository.TeamRequests("other-user")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(requests).To(Equal([]atc.TeamRequest{otherRequest}))
+		})
+	})
+
+	Describe("ApproveTeamRequest", func() {
+		It("creates the team and approves the request", func() {
+			approved, found, err := repository.ApproveTeamRequest(request.ID, "some-admin", "welcome")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(approved.Status).To(Equal(atc.TeamRequestApproved))
+			Expect(approved.DecidedBy).To(Equal("some-admin"))
+			Expect(approved.DecidedAt).ToNot(BeZero())
+			Expect(approved.Comment).To(Equal("welcome"))
+
+			team, found, err := teamFactory.FindTeam("some-team")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(team.Auth()).To(Equal(request.Auth))
+
+			requests, err := repository.TeamRequests("some-user")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(requests).To(Equal([]atc.TeamRequest{approved}))
+
+			Expect(deliveredEvents()).To(Equal([]atc.WebhookEvent{
+				atc.WebhookEventTeamRequested,
+				atc.WebhookEventTeamRequestApproved,
+			}))
+		})
+
+		It("can only be decided once", func() {
+			_, _, err := repository.ApproveTeamRequest(request.ID, "some-admin", "")
+			Expect(err).ToNot(HaveOccurred())
+
+			_, _, err = repository.RejectTeamRequest(request.ID, "some-admin", "")
+			Expect(err).To(Equal(db.ErrTeamRequestDecided))
+		})
+
+		Context("when the team has been created since", func() {
+			BeforeEach(func() {
+				_, err := teamFactory.CreateTeam(atc.Team{Name: "some-team"})
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("leaves the request pending", func() {
+				_, _, err := repository.ApproveTeamRequest(request.ID, "some-admin", "")
+				Expect(err).To(Equal(db.ErrRequestedTeamExists))
+
+				requests, err := repository.TeamRequests("some-user")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(requests[0].Status).To(Equal(atc.TeamRequestPending))
+			})
+		})
+
+		Context("when the request does not exist", func() {
+			It("returns false", func() {
+				_, found, err := repository.ApproveTeamRequest(request.ID+1, "some-admin", "")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+
+	Describe("RejectTeamRequest", func() {
+		It("rejects the request without creating the team", func() {
+			rejected, found, err := repository.RejectTeamRequest(request.ID, "some-admin", "use an existing team")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(rejected.Status).To(Equal(atc.TeamRequestRejected))
+			Expect(rejected.Comment).To(Equal("use an existing team"))
+
+			_, found, err = teamFactory.FindTeam("some-team")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+
+			Expect(deliveredEvents()).To(Equal([]atc.WebhookEvent{
+				atc.WebhookEventTeamRequested,
+				atc.WebhookEventTeamRequestRejected,
+			}))
+		})
+
+		It("allows the team to be requested again", func() {
+			_, _, err := repository.RejectTeamRequest(request.ID, "some-admin", "")
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = repository.CreateTeamRequest(atc.TeamRequest{
+				TeamName:    "some-team",
+				Auth:        atc.TeamAuth{"owner": {"users": {"local:some-user"}}},
+				RequestedBy: "some-user",
+			})
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+})
@@ -74,6 +74,10 @@ var _ = Describe("Team", func() {
 			_, found, _ := teamFactory.FindTeam("oopsies")
 			Expect(found).To(BeTrue())
 		})
+
+		It("returns ErrTeamNameTaken when another team has the name", func() {
+			Expect(otherTeam.Rename("Oopsies")).To(Equal(db.ErrTeamNameTaken))
+		})
 	})
 
 	Describe("SaveWorker", func() {
@@ -1241,6 +1245,76 @@ var _ = Describe("Team", func() {
 		})
 	})
 
+	Describe("BatchUpdatePipelines", func() {
+		var (
+			pipeline1         db.Pipeline
+			pipeline2         db.Pipeline
+			otherTeamPipeline db.Pipeline
+		)
+
+		BeforeEach(func() {
+			var err error
+			pipeline1, _, err = team.SavePipeline(atc.PipelineRef{Name: "pipeline1"}, atc.Config{}, 0, false)
+			Expect(err).ToNot(HaveOccurred())
+			pipeline2, _, err = team.SavePipeline(atc.PipelineRef{Name: "pipeline2"}, atc.Config{}, 0, true)
+			Expect(err).ToNot(HaveOccurred())
+
+			otherTeamPipeline, _, err = otherTeam.SavePipeline(atc.PipelineRef{Name: "pipeline1"}, atc.Config{}, 0, false)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		reload := func(pipeline db.Pipeline) db.Pipeline {
+			found, err := pipeline.Reload()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			return pipeline
+		}
+
+		It("pauses the pipelines which are not paused yet", func() {
+			changed, err := team.BatchUpdatePipelines(atc.PipelineBatchPause, []int{pipeline1.ID(), pipeline2.ID()}, "some-user")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(changed).To(ConsistOf(pipeline1.ID()))
+
+			Expect(reload(pipeline1).Paused()).To(BeTrue())
+			Expect(reload(pipeline1).PausedBy()).To(Equal("some-user"))
+			Expect(reload(pipeline2).Paused()).To(BeTrue())
+		})
+
+		It("unpauses the paused pipelines", func() {
+			changed, err := team.BatchUpdatePipelines(atc.PipelineBatchUnpause, []int{pipeline1.ID(), pipeline2.ID()}, "")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(changed).To(ConsistOf(pipeline2.ID()))
+
+			Expect(reload(pipeline2).Paused()).To(BeFalse())
+		})
+
+		It("exposes and hides the pipelines", func() {
+			changed, err := team.BatchUpdatePipelines(atc.PipelineBatchExpose, []int{pipeline1.ID(), pipeline2.ID()}, "")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(changed).To(ConsistOf(pipeline1.ID(), pipeline2.ID()))
+			Expect(reload(pipeline1).Public()).To(BeTrue())
+
+			changed, err = team.BatchUpdatePipelines(atc.PipelineBatchHide, []int{pipeline1.ID()}, "")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(changed).To(ConsistOf(pipeline1.ID()))
+			Expect(reload(pipeline1).Public()).To(BeFalse())
+			Expect(reload(pipeline2).Public()).To(BeTrue())
+		})
+
+		It("does not touch other teams' pipelines", func() {
+			changed, err := team.BatchUpdatePipelines(atc.PipelineBatchPause, []int{otherTeamPipeline.ID()}, "some-user")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(changed).To(BeEmpty())
+
+			Expect(reload(otherTeamPipeline).Paused()).To(BeFalse())
+		})
+
+		It("rejects unknown actions", func() {
+			_, err := team.BatchUpdatePipelines("bogus", []int{pipeline1.ID()}, "")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
 	Describe("CreateOneOffBuild", func() {
 		var (
 			oneOffBuild db.Build
@@ -1262,6 +1336,34 @@ var _ = Describe("Team", func() {
 		})
 	})
 
+	Describe("RunningOneOffBuilds", func() {
+		It("counts the team's one-off builds which have not completed", func() {
+			count, err := team.RunningOneOffBuilds()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(count).To(BeZero())
+
+			running, err := team.CreateStartedBuild(atc.Plan{})
+			Expect(err).ToNot(HaveOccurred())
+
+			finished, err := team.CreateStartedBuild(atc.Plan{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(finished.Finish(db.BuildStatusSucceeded)).To(Succeed())
+
+			_, err = otherTeam.CreateStartedBuild(atc.Plan{})
+			Expect(err).ToNot(HaveOccurred())
+
+			count, err = team.RunningOneOffBuilds()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(count).To(Equal(1))
+
+			Expect(running.Finish(db.BuildStatusFailed)).To(Succeed())
+
+			count, err = team.RunningOneOffBuilds()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(count).To(BeZero())
+		})
+	})
+
 	Describe("CreateStartedBuild", func() {
 		var (
 			plan         atc.Plan
@@ -3987,6 +4089,51 @@ var _ = Describe("Team", func() {
 			})
 		})
 
+		Context("when the container is a pooled check container", func() {
+			var pooledContainer db.Container
+
+			BeforeEach(func() {
+				scenario := dbtest.Setup(
+					builder.WithWorker(atc.Worker{
+						ResourceTypes:   []atc.WorkerResourceType{defaultWorkerResourceType},
+						Name:            "some-default-worker",
+						GardenAddr:      "3.4.5.6:7777",
+						BaggageclaimURL: "7.8.9.10:7878",
+					}),
+				)
+
+				rc, err := resourceConfigFactory.FindOrCreateResourceConfig(defaultWorkerResourceType.Type, atc.Source{"some": "source"}, nil)
+				Expect(err).ToNot(HaveOccurred())
+
+				pooledContainer, err = scenario.Workers[0].CreateContainer(
+					db.NewCheckContainerPoolContainerOwner(
+						rc.OriginBaseResourceType().ID,
+						team.ID(),
+						0,
+						db.ContainerOwnerExpiries{Min: 5 * time.Minute, Max: 1 * time.Hour},
+					),
+					db.ContainerMetadata{},
+				)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("returns true", func() {
+				is, err := team.IsCheckContainer(pooledContainer.Handle())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(is).To(BeTrue())
+			})
+
+			It("belongs to the team it was created for", func() {
+				ok, err := team.IsContainerWithinTeam(pooledContainer.Handle(), true)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(ok).To(BeTrue())
+
+				ok, err = defaultTeam.IsContainerWithinTeam(pooledContainer.Handle(), true)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(ok).To(BeFalse())
+			})
+		})
+
 		Context("when the container is owned by a team", func() {
 			var createdContainer db.Container
 			var scenario *dbtest.Scenario
@@ -4164,4 +4311,207 @@ var _ = Describe("Team", func() {
 			})
 		})
 	})
+	Describe("Notifiers", func() {
+		BeforeEach(func() {
+			err := team.SaveNotifier(atc.NotifierConfig{
+				Name:   "some-notifier",
+				Type:   "webhook",
+				Config: map[string]interface{}{"url": "https://example.com/hook"},
+			})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("returns the team's notifiers with their config", func() {
+			notifiers, err := team.Notifiers()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(notifiers).To(Equal([]atc.NotifierConfig{
+				{
+					Name:   "some-notifier",
+					Type:   "webhook",
+					Config: map[string]interface{}{"url": "https://example.com/hook"},
+				},
+			}))
+
+			otherNotifiers, err := otherTeam.Notifiers()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(otherNotifiers).To(BeEmpty())
+		})
+
+		It("replaces a notifier with the same name", func() {
+			err := team.SaveNotifier(atc.NotifierConfig{
+				Name:   "some-notifier",
+				Type:   "slack",
+				Config: map[string]interface{}{"url": "https://hooks.slack.com/some-hook"},
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			notifiers, err := team.Notifiers()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(notifiers).To(HaveLen(1))
+			Expect(notifiers[0].Type).To(Equal("slack"))
+		})
+
+		It("deletes notifiers", func() {
+			deleted, err := team.DeleteNotifier("some-notifier")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(deleted).To(BeTrue())
+
+			notifiers, err := team.Notifiers()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(notifiers).To(BeEmpty())
+
+			deleted, err = team.DeleteNotifier("some-notifier")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(deleted).To(BeFalse())
+		})
+	})
+
+	Describe("WorkerKeys", func() {
+		BeforeEach(func() {
+			err := team.SetWorkerKeys([]string{"ssh-rsa key-b", "ssh-rsa key-a"})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("returns the team's worker keys", func() {
+			keys, err := team.WorkerKeys()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(keys).To(Equal([]string{"ssh-rsa key-a", "ssh-rsa key-b"}))
+
+			otherKeys, err := otherTeam.WorkerKeys()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(otherKeys).To(BeEmpty())
+		})
+
+		It("replaces the team's worker keys", func() {
+			err := team.SetWorkerKeys([]string{"ssh-rsa key-c"})
+			Expect(err).ToNot(HaveOccurred())
+
+			keys, err := team.WorkerKeys()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(keys).To(Equal([]string{"ssh-rsa key-c"}))
+		})
+
+		It("lists the worker keys of every team", func() {
+			err := otherTeam.SetWorkerKeys([]string{"ssh-rsa key-c"})
+			Expect(err).ToNot(HaveOccurred())
+
+			teamKeys, err := teamFactory.WorkerKeys()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(teamKeys).To(ContainElement(atc.TeamWorkerKeys{
+				Team: team.Name(),
+				Keys: []string{"ssh-rsa key-a", "ssh-rsa key-b"},
+			}))
+			Expect(teamKeys).To(ContainElement(atc.TeamWorkerKeys{
+				Team: otherTeam.Name(),
+				Keys: []string{"ssh-rsa key-c"},
+			}))
+		})
+	})
+
+	Describe("InterceptSettings", func() {
+		It("allows intercepting without recording by default", func() {
+			settings, err := team.InterceptSettings()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(settings).To(Equal(atc.InterceptSettings{}))
+		})
+
+		It("returns the team's saved settings", func() {
+			err := team.SetInterceptSettings(atc.InterceptSettings{
+				Disabled:          true,
+				RecordTranscripts: true,
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			settings, err := team.InterceptSettings()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(settings).To(Equal(atc.InterceptSettings{
+				Disabled:          true,
+				RecordTranscripts: true,
+			}))
+
+			otherSettings, err := otherTeam.InterceptSettings()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(otherSettings).To(Equal(atc.InterceptSettings{}))
+		})
+	})
+
+	Describe("PrivilegedSettings", func() {
+		It("allows privileged containers by default", func() {
+			settings, err := team.PrivilegedSettings()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(settings).To(Equal(atc.PrivilegedSettings{Policy: atc.PrivilegedPolicyAllow}))
+		})
+
+		It("returns the team's saved settings", func() {
+			err := team.SetPrivilegedSettings(atc.PrivilegedSettings{Policy: atc.PrivilegedPolicyDeny})
+			Expect(err).ToNot(HaveOccurred())
+
+			settings, err := team.PrivilegedSettings()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(settings).To(Equal(atc.PrivilegedSettings{Policy: atc.PrivilegedPolicyDeny}))
+
+			otherSettings, err := otherTeam.PrivilegedSettings()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(otherSettings).To(Equal(atc.PrivilegedSettings{Policy: atc.PrivilegedPolicyAllow}))
+		})
+
+		It("looks up the policies of teams by name", func() {
+			err := team.SetPrivilegedSettings(atc.PrivilegedSettings{Policy: atc.PrivilegedPolicyWarn})
+			Expect(err).ToNot(HaveOccurred())
+
+			policies := db.NewPrivilegedPolicies(dbConn)
+
+			privilegedPolicy, err := policies.PrivilegedPolicy(team.Name())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(privilegedPolicy).To(Equal(atc.PrivilegedPolicyWarn))
+
+			privilegedPolicy, err = policies.PrivilegedPolicy("bogus-team")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(privilegedPolicy).To(Equal(atc.PrivilegedPolicyAllow))
+		})
+	})
+
+	Describe("ProxySettings", func() {
+		It("has no proxies by default", func() {
+			settings, err := team.ProxySettings()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(settings).To(Equal(atc.ProxySettings{}))
+		})
+
+		It("returns the team's saved settings", func() {
+			err := team.SetProxySettings(atc.ProxySettings{
+				HTTPProxy:  "http://proxy:3128",
+				HTTPSProxy: "http://proxy:3129",
+				NoProxy:    "localhost",
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			settings, err := team.ProxySettings()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(settings).To(Equal(atc.ProxySettings{
+				HTTPProxy:  "http://proxy:3128",
+				HTTPSProxy: "http://proxy:3129",
+				NoProxy:    "localhost",
+			}))
+
+			otherSettings, err := otherTeam.ProxySettings()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(otherSettings).To(Equal(atc.ProxySettings{}))
+		})
+
+		It("looks up the settings of teams by ID", func() {
+			err := team.SetProxySettings(atc.ProxySettings{HTTPProxy: "http://proxy:3128"})
+			Expect(err).ToNot(HaveOccurred())
+
+			proxies := db.NewTeamProxies(dbConn)
+
+			settings, err := proxies.ProxySettings(team.ID())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(settings).To(Equal(atc.ProxySettings{HTTPProxy: "http://proxy:3128"}))
+
+			settings, err = proxies.ProxySettings(0)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(settings).To(Equal(atc.ProxySettings{}))
+		})
+	})
 })
@@ -0,0 +1,99 @@
+package db
+
+import (
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
+)
+
+// SetWorkerKeys replaces the SSH keys with which the team's workers may
+// register with the TSA.
+func (t *team) SetWorkerKeys(keys []string) error {
+	tx, err := t.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	_, err = psql.Delete("team_worker_keys").
+		Where(sq.Eq{"team_id": t.id}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		_, err = psql.Insert("team_worker_keys").
+			Columns("team_id", "key").
+			Values(t.id, key).
+			Suffix("ON CONFLICT DO NOTHING").
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (t *team) WorkerKeys() ([]string, error) {
+	rows, err := psql.Select("key").
+		From("team_worker_keys").
+		Where(sq.Eq{"team_id": t.id}).
+		OrderBy("key").
+		RunWith(t.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	keys := []string{}
+	for rows.Next() {
+		var key string
+		err := rows.Scan(&key)
+		if err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+// WorkerKeys returns the worker keys of every team that has any.
+func (factory *teamFactory) WorkerKeys() ([]atc.TeamWorkerKeys, error) {
+	rows, err := psql.Select("t.name", "k.key").
+		From("team_worker_keys k").
+		Join("teams t ON t.id = k.team_id").
+		OrderBy("t.name", "k.key").
+		RunWith(factory.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	teamKeys := []atc.TeamWorkerKeys{}
+	for rows.Next() {
+		var teamName, key string
+		err := rows.Scan(&teamName, &key)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(teamKeys) == 0 || teamKeys[len(teamKeys)-1].Team != teamName {
+			teamKeys = append(teamKeys, atc.TeamWorkerKeys{Team: teamName})
+		}
+
+		last := &teamKeys[len(teamKeys)-1]
+		last.Keys = append(last.Keys, key)
+	}
+
+	return teamKeys, nil
+}
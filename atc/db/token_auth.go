@@ -0,0 +1,175 @@
+package db
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"sync"
+	"time"
+
+	"github.com/concourse/concourse/atc/db/iam"
+	"github.com/lib/pq"
+)
+
+type tokenAuthDriver struct {
+	tokens iam.TokenSource
+}
+
+// SetupTokenAuthDriver registers a driver which connects with a token from
+// tokens as the password, so that each connection is made with a token which
+// hasn't expired yet.
+func SetupTokenAuthDriver(tokens iam.TokenSource, newDriverName string) {
+	for _, driverName := range sql.Drivers() {
+		if driverName == newDriverName {
+			return
+		}
+	}
+
+	sql.Register(newDriverName, &tokenAuthDriver{tokens: tokens})
+}
+
+func (d *tokenAuthDriver) Open(name string) (driver.Conn, error) {
+	dsn, err := iam.WithToken(name, d.tokens)
+	if err != nil {
+		return nil, err
+	}
+
+	return pq.DialOpen(keepAliveDialer{}, dsn)
+}
+
+// NewListener listens for notifications through dsn. With tokens, the
+// listener connects with a token as the password, and when the token is
+// refused, such as after it expired, connects again with a fresh one.
+func NewListener(dsn string, tokens iam.TokenSource) Listener {
+	if tokens == nil {
+		return pq.NewDialListener(keepAliveDialer{}, dsn, time.Second, time.Minute, nil)
+	}
+
+	listener := &tokenListener{
+		dsn:           dsn,
+		tokens:        tokens,
+		channels:      map[string]bool{},
+		notifications: make(chan *pq.Notification, 32),
+	}
+
+	listener.connect()
+
+	return listener
+}
+
+// tokenListener is a pq.Listener which is replaced when it fails to
+// authenticate, as a pq.Listener reconnects with the password it was created
+// with.
+type tokenListener struct {
+	sync.Mutex
+
+	dsn    string
+	tokens iam.TokenSource
+
+	listener   *pq.Listener
+	generation int
+	channels   map[string]bool
+	closed     bool
+
+	forwarding    sync.WaitGroup
+	notifications chan *pq.Notification
+}
+
+// connect replaces the current pq.Listener, if any, with one authenticating
+// with a fresh token. It must be called with the lock held.
+func (l *tokenListener) connect() {
+	dsn, err := iam.WithToken(l.dsn, l.tokens)
+	if err != nil {
+		// connecting fails to authenticate, which tries again with a token
+		dsn = l.dsn
+	}
+
+	l.generation++
+	generation := l.generation
+
+	listener := pq.NewDialListener(keepAliveDialer{}, dsn, time.Second, time.Minute, func(event pq.ListenerEventType, err error) {
+		if event == pq.ListenerEventConnectionAttemptFailed && isAuthenticationError(err) {
+			go l.reconnect(generation)
+		}
+	})
+
+	for channel := range l.channels {
+		// a pq.Listener listens on its channels once it's connected
+		_ = listener.Listen(channel)
+	}
+
+	if l.listener != nil {
+		_ = l.listener.Close()
+	}
+
+	l.listener = listener
+
+	l.forwarding.Add(1)
+	go func() {
+		defer l.forwarding.Done()
+
+		for notification := range listener.Notify {
+			l.notifications <- notification
+		}
+	}()
+}
+
+func (l *tokenListener) reconnect(generation int) {
+	l.Lock()
+	defer l.Unlock()
+
+	if l.closed || generation != l.generation {
+		return
+	}
+
+	l.connect()
+
+	// notifications may have been missed in between
+	l.notifications <- nil
+}
+
+func (l *tokenListener) Listen(channel string) error {
+	l.Lock()
+	defer l.Unlock()
+
+	err := l.listener.Listen(channel)
+	if err != nil {
+		return err
+	}
+
+	l.channels[channel] = true
+	return nil
+}
+
+func (l *tokenListener) Unlisten(channel string) error {
+	l.Lock()
+	defer l.Unlock()
+
+	err := l.listener.Unlisten(channel)
+	if err != nil {
+		return err
+	}
+
+	delete(l.channels, channel)
+	return nil
+}
+
+func (l *tokenListener) NotificationChannel() <-chan *pq.Notification {
+	return l.notifications
+}
+
+func (l *tokenListener) Close() error {
+	l.Lock()
+	l.closed = true
+	err := l.listener.Close()
+	l.Unlock()
+
+	l.forwarding.Wait()
+	close(l.notifications)
+
+	return err
+}
+
+func isAuthenticationError(err error) bool {
+	pqErr, ok := err.(*pq.Error)
+	return ok && pqErr.Code.Class() == "28"
+}
@@ -10,6 +10,7 @@ type user struct {
 	name      string
 	connector string
 	lastLogin time.Time
+	claims    Claims
 }
 
 //counterfeiter:generate . User
@@ -19,6 +20,9 @@ type User interface {
 	Name() string
 	Connector() string
 	LastLogin() time.Time
+
+	// Claims returns the claims the user last logged in with.
+	Claims() Claims
 }
 
 func (u user) ID() int              { return u.id }
@@ -26,3 +30,4 @@ func (u user) Sub() string          { return u.sub }
 func (u user) Name() string         { return u.name }
 func (u user) Connector() string    { return u.connector }
 func (u user) LastLogin() time.Time { return u.lastLogin }
+func (u user) Claims() Claims       { return u.claims }
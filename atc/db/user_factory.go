@@ -1,6 +1,8 @@
 package db
 
 import (
+	"database/sql"
+	"encoding/json"
 	"time"
 
 	sq "github.com/Masterminds/squirrel"
@@ -8,11 +10,17 @@ import (
 
 //counterfeiter:generate . UserFactory
 type UserFactory interface {
-	CreateOrUpdateUser(username, connector, sub string) error
+	// CreateOrUpdateUser records a login, along with the claims the user
+	// logged in with.
+	CreateOrUpdateUser(username, connector, sub string, claims Claims) error
 	GetAllUsers() ([]User, error)
 	GetAllUsersByLoginDate(LastLogin time.Time) ([]User, error)
+	GetUser(id int) (User, bool, error)
 }
 
+var usersQuery = psql.Select("id", "sub", "username", "connector", "last_login", "claims").
+	From("users")
+
 type userFactory struct {
 	conn Conn
 }
@@ -23,7 +31,12 @@ func NewUserFactory(conn Conn) UserFactory {
 	}
 }
 
-func (f *userFactory) CreateOrUpdateUser(username, connector, sub string) error {
+func (f *userFactory) CreateOrUpdateUser(username, connector, sub string, claims Claims) error {
+	rawClaims, err := json.Marshal(claims)
+	if err != nil {
+		return err
+	}
+
 	tx, err := f.conn.Begin()
 
 	if err != nil {
@@ -32,13 +45,14 @@ func (f *userFactory) CreateOrUpdateUser(username, connector, sub string) error
 	defer Rollback(tx)
 
 	builder := psql.Insert("users").
-		Columns("username", "connector", "sub").
-		Values(username, connector, sub)
+		Columns("username", "connector", "sub", "claims").
+		Values(username, connector, sub, rawClaims)
 
 	_, err = builder.Suffix(`ON CONFLICT (sub) DO UPDATE SET
 					username = EXCLUDED.username,
 					connector = EXCLUDED.connector,
 					sub = EXCLUDED.sub,
+					claims = EXCLUDED.claims,
 					last_login = now()`).
 		RunWith(tx).
 		Exec()
@@ -55,8 +69,7 @@ func (f *userFactory) CreateOrUpdateUser(username, connector, sub string) error
 }
 
 func (f *userFactory) GetAllUsers() ([]User, error) {
-	rows, err := psql.Select("id", "username", "connector", "last_login").
-		From("users").
+	rows, err := usersQuery.
 		RunWith(f.conn).
 		Query()
 
@@ -66,24 +79,11 @@ func (f *userFactory) GetAllUsers() ([]User, error) {
 
 	defer Close(rows)
 
-	var users []User
-
-	for rows.Next() {
-		var currUser user
-		err = rows.Scan(&currUser.id, &currUser.name, &currUser.connector, &currUser.lastLogin)
-
-		if err != nil {
-			return nil, err
-		}
-
-		users = append(users, currUser)
-	}
-	return users, nil
+	return scanUsers(rows)
 }
 
 func (f *userFactory) GetAllUsersByLoginDate(lastLogin time.Time) ([]User, error) {
-	rows, err := psql.Select("id", "username", "connector", "last_login").
-		From("users").
+	rows, err := usersQuery.
 		Where(sq.GtOrEq{"last_login": lastLogin}).
 		RunWith(f.conn).
 		Query()
@@ -94,17 +94,57 @@ func (f *userFactory) GetAllUsersByLoginDate(lastLogin time.Time) ([]User, error
 
 	defer Close(rows)
 
+	return scanUsers(rows)
+}
+
+func (f *userFactory) GetUser(id int) (User, bool, error) {
+	rows, err := usersQuery.
+		Where(sq.Eq{"id": id}).
+		RunWith(f.conn).
+		Query()
+	if err != nil {
+		return nil, false, err
+	}
+
+	defer Close(rows)
+
+	users, err := scanUsers(rows)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if len(users) == 0 {
+		return nil, false, nil
+	}
+
+	return users[0], true, nil
+}
+
+func scanUsers(rows *sql.Rows) ([]User, error) {
 	var users []User
 
 	for rows.Next() {
-		var currUser user
-		err = rows.Scan(&currUser.id, &currUser.name, &currUser.connector, &currUser.lastLogin)
+		var (
+			currUser  user
+			rawClaims sql.NullString
+		)
 
+		err := rows.Scan(&currUser.id, &currUser.sub, &currUser.name, &currUser.connector, &currUser.lastLogin, &rawClaims)
 		if err != nil {
 			return nil, err
 		}
 
+		// users who have not logged in since claims started being recorded
+		// have none
+		if rawClaims.Valid {
+			err = json.Unmarshal([]byte(rawClaims.String), &currUser.claims)
+			if err != nil {
+				return nil, err
+			}
+		}
+
 		users = append(users, currUser)
 	}
+
 	return users, nil
 }
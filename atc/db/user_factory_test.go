@@ -17,9 +17,19 @@ var _ = Describe("User Factory", func() {
 		users []db.User
 	)
 
+	claims := func(connector string, groups ...string) db.Claims {
+		return db.Claims{
+			FederatedClaims: db.FederatedClaims{Connector: connector},
+			RawClaims: map[string]interface{}{
+				"federated_claims": map[string]interface{}{"connector_id": connector},
+				"groups":           groups,
+			},
+		}
+	}
+
 	JustBeforeEach(func() {
 		err = userFactory.CreateOrUpdateUser("test", "github",
-			base64.StdEncoding.EncodeToString([]byte("test"+"github")))
+			base64.StdEncoding.EncodeToString([]byte("test"+"github")), claims("github", "some-group"))
 		Expect(err).ToNot(HaveOccurred())
 
 		users, err = userFactory.GetAllUsers()
@@ -32,12 +42,18 @@ var _ = Describe("User Factory", func() {
 			Expect(users[0].Name()).To(Equal("test"))
 			Expect(users[0].LastLogin()).Should(BeTemporally("~", time.Now(), time.Second*20))
 		})
+
+		It("records the user's subject and claims", func() {
+			Expect(users[0].Sub()).To(Equal(base64.StdEncoding.EncodeToString([]byte("test" + "github"))))
+			Expect(users[0].Claims().Connector).To(Equal("github"))
+			Expect(users[0].Claims().RawClaims["groups"]).To(Equal([]interface{}{"some-group"}))
+		})
 	})
 
 	Context("when username exists but with different connector", func() {
 		BeforeEach(func() {
 			err = userFactory.CreateOrUpdateUser("test", "basic",
-				base64.StdEncoding.EncodeToString([]byte("test"+"basic")))
+				base64.StdEncoding.EncodeToString([]byte("test"+"basic")), claims("basic"))
 			Expect(err).ToNot(HaveOccurred())
 		})
 
@@ -52,7 +68,7 @@ var _ = Describe("User Factory", func() {
 
 		BeforeEach(func() {
 			err = userFactory.CreateOrUpdateUser("test", "github",
-				base64.StdEncoding.EncodeToString([]byte("test"+"github")))
+				base64.StdEncoding.EncodeToString([]byte("test"+"github")), claims("github", "other-group"))
 			Expect(err).ToNot(HaveOccurred())
 
 			users, err = userFactory.GetAllUsers()
@@ -71,5 +87,25 @@ var _ = Describe("User Factory", func() {
 		It("Update the last_login time", func() {
 			Expect(users[0].LastLogin()).NotTo(Equal(previousLastLogin))
 		})
+
+		It("Updates the claims", func() {
+			Expect(users[0].Claims().RawClaims["groups"]).To(Equal([]interface{}{"some-group"}))
+		})
+	})
+
+	Describe("GetUser", func() {
+		It("finds the user by id", func() {
+			user, found, err := userFactory.GetUser(users[0].ID())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(user.Name()).To(Equal("test"))
+			Expect(user.Sub()).To(Equal(users[0].Sub()))
+		})
+
+		It("returns false when there is no such user", func() {
+			_, found, err := userFactory.GetUser(users[0].ID() + 1)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
 	})
 })
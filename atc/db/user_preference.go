@@ -0,0 +1,77 @@
+package db
+
+import (
+	"encoding/json"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// UserPreferenceRepository keeps arbitrary JSON values for each user, such as
+// the web UI's settings, so that they follow the user between browsers. Users
+// are identified by their subject.
+//
+//counterfeiter:generate . UserPreferenceRepository
+type UserPreferenceRepository interface {
+	UserPreferences(userSub string) (map[string]json.RawMessage, error)
+	SetUserPreference(userSub string, key string, value json.RawMessage) error
+	DeleteUserPreference(userSub string, key string) error
+}
+
+type userPreferenceRepository struct {
+	conn Conn
+}
+
+func NewUserPreferenceRepository(conn Conn) UserPreferenceRepository {
+	return &userPreferenceRepository{
+		conn: conn,
+	}
+}
+
+func (repo *userPreferenceRepository) UserPreferences(userSub string) (map[string]json.RawMessage, error) {
+	rows, err := psql.Select("key", "value").
+		From("user_preferences").
+		Where(sq.Eq{"user_sub": userSub}).
+		RunWith(repo.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	preferences := map[string]json.RawMessage{}
+	for rows.Next() {
+		var key, value string
+		err := rows.Scan(&key, &value)
+		if err != nil {
+			return nil, err
+		}
+
+		preferences[key] = json.RawMessage(value)
+	}
+
+	return preferences, nil
+}
+
+func (repo *userPreferenceRepository) SetUserPreference(userSub string, key string, value json.RawMessage) error {
+	_, err := psql.Insert("user_preferences").
+		Columns("user_sub", "key", "value").
+		Values(userSub, key, string(value)).
+		Suffix(`ON CONFLICT (user_sub, key) DO UPDATE SET
+			value = EXCLUDED.value,
+			updated_at = now()`).
+		RunWith(repo.conn).
+		Exec()
+	return err
+}
+
+func (repo *userPreferenceRepository) DeleteUserPreference(userSub string, key string) error {
+	_, err := psql.Delete("user_preferences").
+		Where(sq.Eq{
+			"user_sub": userSub,
+			"key":      key,
+		}).
+		RunWith(repo.conn).
+		Exec()
+	return err
+}
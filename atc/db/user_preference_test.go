@@ -0,0 +1,53 @@
+package db_test
+
+import (
+	"encoding/json"
+
+	"github.com/concourse/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("UserPreferenceRepository", func() {
+	var repository db.UserPreferenceRepository
+
+	BeforeEach(func() {
+		repository = db.NewUserPreferenceRepository(dbConn)
+	})
+
+	It("keeps each user's preferences", func() {
+		Expect(repository.SetUserPreference("some-sub", "theme", json.RawMessage(`"dark"`))).To(Succeed())
+		Expect(repository.SetUserPreference("some-sub", "highlight", json.RawMessage(`{"enabled":true}`))).To(Succeed())
+		Expect(repository.SetUserPreference("other-sub", "theme", json.RawMessage(`"light"`))).To(Succeed())
+
+		preferences, err := repository.UserPreferences("some-sub")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(preferences).To(HaveLen(2))
+		Expect(preferences["theme"]).To(MatchJSON(`"dark"`))
+		Expect(preferences["highlight"]).To(MatchJSON(`{"enabled":true}`))
+
+		preferences, err = repository.UserPreferences("other-sub")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(preferences).To(HaveLen(1))
+		Expect(preferences["theme"]).To(MatchJSON(`"light"`))
+	})
+
+	It("replaces a preference which is set again", func() {
+		Expect(repository.SetUserPreference("some-sub", "theme", json.RawMessage(`"dark"`))).To(Succeed())
+		Expect(repository.SetUserPreference("some-sub", "theme", json.RawMessage(`"light"`))).To(Succeed())
+
+		preferences, err := repository.UserPreferences("some-sub")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(preferences).To(HaveLen(1))
+		Expect(preferences["theme"]).To(MatchJSON(`"light"`))
+	})
+
+	It("deletes a preference", func() {
+		Expect(repository.SetUserPreference("some-sub", "theme", json.RawMessage(`"dark"`))).To(Succeed())
+		Expect(repository.DeleteUserPreference("some-sub", "theme")).To(Succeed())
+
+		preferences, err := repository.UserPreferences("some-sub")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(preferences).To(BeEmpty())
+	})
+})
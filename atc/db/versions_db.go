@@ -274,47 +274,27 @@ func (versions VersionsDB) NextEveryVersion(ctx context.Context, jobID int, reso
 
 	defer tx.Rollback()
 
-	var checkOrder int
-	err = tx.QueryRowContext(ctx, `
-		SELECT rcv.check_order
-		FROM resource_config_versions rcv
-		CROSS JOIN LATERAL (
-			SELECT i.build_id
-			FROM build_resource_config_version_inputs i
-			CROSS JOIN LATERAL (
-				SELECT b.id
-				FROM builds b
-				WHERE b.job_id = $1
-				AND i.build_id = b.id
-				LIMIT 1
-			) AS build
-			WHERE i.resource_id = $2
-			AND i.version_md5 = rcv.version_md5
-			LIMIT 1
-		) AS inputs
-		WHERE rcv.resource_config_scope_id = (SELECT resource_config_scope_id FROM resources WHERE id = $2)
-		ORDER BY rcv.check_order DESC
-		LIMIT 1;`, jobID, resourceID).Scan(&checkOrder)
+	checkOrder, used, err := versions.latestUsedCheckOrder(ctx, tx, jobID, resourceID)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			version, found, err := versions.latestVersionOfResource(ctx, tx, resourceID)
-			if err != nil {
-				return "", false, false, err
-			}
+		return "", false, false, err
+	}
 
-			if !found {
-				return "", false, false, nil
-			}
+	if !used {
+		version, found, err := versions.latestVersionOfResource(ctx, tx, resourceID)
+		if err != nil {
+			return "", false, false, err
+		}
 
-			err = tx.Commit()
-			if err != nil {
-				return "", false, false, err
-			}
+		if !found {
+			return "", false, false, nil
+		}
 
-			return version, false, true, nil
+		err = tx.Commit()
+		if err != nil {
+			return "", false, false, err
 		}
 
-		return "", false, false, err
+		return version, false, true, nil
 	}
 
 	var nextVersion ResourceVersion
@@ -352,20 +332,122 @@ func (versions VersionsDB) NextEveryVersion(ctx context.Context, jobID int, reso
 		return nextVersion, hasNext, true, nil
 	}
 
-	err = psql.Select("rcv.version_md5").
+	nextVersion, found, err := versions.latestVersionUpTo(ctx, tx, resourceID, checkOrder)
+	if err != nil {
+		return "", false, false, err
+	}
+
+	if !found {
+		return "", false, false, nil
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return "", false, false, err
+	}
+
+	return nextVersion, false, true, nil
+}
+
+// NextSampledVersion returns the latest version of the window of versions
+// following the latest version used by the job, once the window is over.
+// Until then, the version used by the job is returned again, along with there
+// being a next version so that the job gets scheduled again.
+func (versions VersionsDB) NextSampledVersion(ctx context.Context, jobID int, resourceID int, sampling atc.VersionSampling) (ResourceVersion, bool, bool, error) {
+	tx, err := versions.conn.Begin()
+	if err != nil {
+		return "", false, false, err
+	}
+
+	defer tx.Rollback()
+
+	checkOrder, used, err := versions.latestUsedCheckOrder(ctx, tx, jobID, resourceID)
+	if err != nil {
+		return "", false, false, err
+	}
+
+	if !used {
+		version, found, err := versions.latestVersionOfResource(ctx, tx, resourceID)
+		if err != nil {
+			return "", false, false, err
+		}
+
+		if !found {
+			return "", false, false, nil
+		}
+
+		err = tx.Commit()
+		if err != nil {
+			return "", false, false, err
+		}
+
+		return version, false, true, nil
+	}
+
+	var windowStart time.Time
+	err = psql.Select("rcv.created_at").
 		From("resource_config_versions rcv").
 		Where(sq.Expr("rcv.resource_config_scope_id = (SELECT resource_config_scope_id FROM resources WHERE id = ?)", resourceID)).
 		Where(sq.Expr("NOT EXISTS (SELECT 1 FROM resource_disabled_versions WHERE resource_id = ? AND version_md5 = rcv.version_md5)", resourceID)).
-		Where(sq.LtOrEq{"rcv.check_order": checkOrder}).
-		OrderBy("rcv.check_order DESC").
+		Where(sq.Gt{"rcv.check_order": checkOrder}).
+		OrderBy("rcv.check_order ASC").
 		Limit(1).
 		RunWith(tx).
 		QueryRowContext(ctx).
-		Scan(&nextVersion)
-	if err != nil {
-		if err == sql.ErrNoRows {
+		Scan(&windowStart)
+	if err != nil && err != sql.ErrNoRows {
+		return "", false, false, err
+	}
+
+	// no new versions means there's no window to wait for
+	hasNewVersions := err == nil
+	windowEnd := sampling.WindowEnd(windowStart)
+
+	if !hasNewVersions || time.Now().Before(windowEnd) {
+		version, found, err := versions.latestVersionUpTo(ctx, tx, resourceID, checkOrder)
+		if err != nil {
+			return "", false, false, err
+		}
+
+		if !found {
 			return "", false, false, nil
 		}
+
+		err = tx.Commit()
+		if err != nil {
+			return "", false, false, err
+		}
+
+		return version, hasNewVersions, true, nil
+	}
+
+	var sampledVersion ResourceVersion
+	var sampledCheckOrder int
+	err = psql.Select("rcv.version_md5", "rcv.check_order").
+		From("resource_config_versions rcv").
+		Where(sq.Expr("rcv.resource_config_scope_id = (SELECT resource_config_scope_id FROM resources WHERE id = ?)", resourceID)).
+		Where(sq.Expr("NOT EXISTS (SELECT 1 FROM resource_disabled_versions WHERE resource_id = ? AND version_md5 = rcv.version_md5)", resourceID)).
+		Where(sq.Gt{"rcv.check_order": checkOrder}).
+		Where(sq.Lt{"rcv.created_at": windowEnd}).
+		OrderBy("rcv.check_order DESC").
+		Limit(1).
+		RunWith(tx).
+		QueryRowContext(ctx).
+		Scan(&sampledVersion, &sampledCheckOrder)
+	if err != nil {
+		return "", false, false, err
+	}
+
+	var hasNext bool
+	err = tx.QueryRowContext(ctx, `
+		SELECT EXISTS (
+			SELECT 1
+			FROM resource_config_versions rcv
+			WHERE rcv.resource_config_scope_id = (SELECT resource_config_scope_id FROM resources WHERE id = $1)
+			AND NOT EXISTS (SELECT 1 FROM resource_disabled_versions WHERE resource_id = $1 AND version_md5 = rcv.version_md5)
+			AND rcv.check_order > $2
+		)`, resourceID, sampledCheckOrder).Scan(&hasNext)
+	if err != nil {
 		return "", false, false, err
 	}
 
@@ -374,7 +456,65 @@ func (versions VersionsDB) NextEveryVersion(ctx context.Context, jobID int, reso
 		return "", false, false, err
 	}
 
-	return nextVersion, false, true, nil
+	return sampledVersion, hasNext, true, nil
+}
+
+// latestUsedCheckOrder returns the check order of the latest version of the
+// resource used as an input by any build of the job.
+func (versions VersionsDB) latestUsedCheckOrder(ctx context.Context, tx Tx, jobID int, resourceID int) (int, bool, error) {
+	var checkOrder int
+	err := tx.QueryRowContext(ctx, `
+		SELECT rcv.check_order
+		FROM resource_config_versions rcv
+		CROSS JOIN LATERAL (
+			SELECT i.build_id
+			FROM build_resource_config_version_inputs i
+			CROSS JOIN LATERAL (
+				SELECT b.id
+				FROM builds b
+				WHERE b.job_id = $1
+				AND i.build_id = b.id
+				LIMIT 1
+			) AS build
+			WHERE i.resource_id = $2
+			AND i.version_md5 = rcv.version_md5
+			LIMIT 1
+		) AS inputs
+		WHERE rcv.resource_config_scope_id = (SELECT resource_config_scope_id FROM resources WHERE id = $2)
+		ORDER BY rcv.check_order DESC
+		LIMIT 1;`, jobID, resourceID).Scan(&checkOrder)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, false, nil
+		}
+		return 0, false, err
+	}
+
+	return checkOrder, true, nil
+}
+
+// latestVersionUpTo returns the latest enabled version up to and including
+// the check order.
+func (versions VersionsDB) latestVersionUpTo(ctx context.Context, tx Tx, resourceID int, checkOrder int) (ResourceVersion, bool, error) {
+	var version ResourceVersion
+	err := psql.Select("rcv.version_md5").
+		From("resource_config_versions rcv").
+		Where(sq.Expr("rcv.resource_config_scope_id = (SELECT resource_config_scope_id FROM resources WHERE id = ?)", resourceID)).
+		Where(sq.Expr("NOT EXISTS (SELECT 1 FROM resource_disabled_versions WHERE resource_id = ? AND version_md5 = rcv.version_md5)", resourceID)).
+		Where(sq.LtOrEq{"rcv.check_order": checkOrder}).
+		OrderBy("rcv.check_order DESC").
+		Limit(1).
+		RunWith(tx).
+		QueryRowContext(ctx).
+		Scan(&version)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", false, nil
+		}
+		return "", false, err
+	}
+
+	return version, true, nil
 }
 
 func (versions VersionsDB) LatestBuildPipes(ctx context.Context, buildID int) (map[int]BuildCursor, error) {
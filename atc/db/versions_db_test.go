@@ -3,6 +3,7 @@ package db_test
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -763,4 +764,158 @@ var _ = Describe("VersionsDB", func() {
 			})
 		})
 	})
+
+	Describe("NextSampledVersion", func() {
+		var (
+			scenario *dbtest.Scenario
+			sampling atc.VersionSampling
+
+			version db.ResourceVersion
+			hasNext bool
+			found   bool
+		)
+
+		foundAt := func(v atc.Version, at time.Time) {
+			_, err := dbConn.Exec(`UPDATE resource_config_versions SET created_at = $1 WHERE version_md5 = $2`, at, convertToMD5(v))
+			Expect(err).ToNot(HaveOccurred())
+		}
+
+		BeforeEach(func() {
+			sampling = atc.VersionSampling{Every: 10 * time.Minute}
+
+			scenario = dbtest.Setup(
+				builder.WithPipeline(atc.Config{
+					Jobs: atc.JobConfigs{
+						{
+							Name: "some-job",
+							PlanSequence: []atc.Step{
+								{
+									Config: &atc.GetStep{
+										Name: "some-resource",
+									},
+								},
+							},
+						},
+					},
+					Resources: atc.ResourceConfigs{
+						{
+							Name:   "some-resource",
+							Type:   dbtest.BaseResourceType,
+							Source: atc.Source{"some": "source"},
+						},
+					},
+				}),
+				builder.WithResourceVersions(
+					"some-resource",
+					atc.Version{"v": "1"},
+					atc.Version{"v": "2"},
+					atc.Version{"v": "3"},
+					atc.Version{"v": "4"},
+				),
+			)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			version, hasNext, found, err = vdb.NextSampledVersion(
+				ctx,
+				scenario.Job("some-job").ID(),
+				scenario.Resource("some-resource").ID(),
+				sampling,
+			)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		Context("when the job has not used any version", func() {
+			It("returns the latest version", func() {
+				Expect(found).To(BeTrue())
+				Expect(string(version)).To(Equal(convertToMD5(atc.Version{"v": "4"})))
+				Expect(hasNext).To(BeFalse())
+			})
+		})
+
+		Context("when the job has used a version", func() {
+			BeforeEach(func() {
+				var build db.Build
+				scenario.Run(
+					builder.WithJobBuild(&build, "some-job", dbtest.JobInputs{
+						{
+							Name:    "some-resource",
+							Version: atc.Version{"v": "1"},
+						},
+					}, dbtest.JobOutputs{}),
+				)
+			})
+
+			Context("when the window of the versions after it is over", func() {
+				BeforeEach(func() {
+					foundAt(atc.Version{"v": "2"}, time.Now().Add(-2*time.Hour))
+					foundAt(atc.Version{"v": "3"}, time.Now().Add(-2*time.Hour+5*time.Minute))
+					foundAt(atc.Version{"v": "4"}, time.Now().Add(-time.Hour))
+				})
+
+				It("returns the latest version within the window", func() {
+					Expect(found).To(BeTrue())
+					Expect(string(version)).To(Equal(convertToMD5(atc.Version{"v": "3"})))
+				})
+
+				It("has a next version", func() {
+					Expect(hasNext).To(BeTrue())
+				})
+
+				Context("when sampling the latest version per day", func() {
+					BeforeEach(func() {
+						sampling = atc.VersionSampling{LatestPer: atc.VersionPeriodDay}
+
+						yesterday := time.Now().UTC().AddDate(0, 0, -1)
+						foundAt(atc.Version{"v": "2"}, yesterday.Truncate(24*time.Hour).Add(time.Hour))
+						foundAt(atc.Version{"v": "3"}, yesterday.Truncate(24*time.Hour).Add(2*time.Hour))
+						foundAt(atc.Version{"v": "4"}, time.Now())
+					})
+
+					It("returns the latest version of the day", func() {
+						Expect(string(version)).To(Equal(convertToMD5(atc.Version{"v": "3"})))
+						Expect(hasNext).To(BeTrue())
+					})
+				})
+			})
+
+			Context("when the window of the versions after it is still open", func() {
+				BeforeEach(func() {
+					foundAt(atc.Version{"v": "2"}, time.Now().Add(-5*time.Minute))
+					foundAt(atc.Version{"v": "3"}, time.Now().Add(-time.Minute))
+					foundAt(atc.Version{"v": "4"}, time.Now())
+				})
+
+				It("returns the version used", func() {
+					Expect(found).To(BeTrue())
+					Expect(string(version)).To(Equal(convertToMD5(atc.Version{"v": "1"})))
+				})
+
+				It("has a next version, to be scheduled again", func() {
+					Expect(hasNext).To(BeTrue())
+				})
+			})
+		})
+
+		Context("when the job has used the latest version", func() {
+			BeforeEach(func() {
+				var build db.Build
+				scenario.Run(
+					builder.WithJobBuild(&build, "some-job", dbtest.JobInputs{
+						{
+							Name:    "some-resource",
+							Version: atc.Version{"v": "4"},
+						},
+					}, dbtest.JobOutputs{}),
+				)
+			})
+
+			It("returns it without a next version", func() {
+				Expect(found).To(BeTrue())
+				Expect(string(version)).To(Equal(convertToMD5(atc.Version{"v": "4"})))
+				Expect(hasNext).To(BeFalse())
+			})
+		})
+	})
 })
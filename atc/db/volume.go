@@ -160,6 +160,8 @@ type CreatedVolume interface {
 	InitializeResourceCache(ResourceCache) error
 	InitializeStreamedResourceCache(ResourceCache, string) error
 	GetResourceCacheID() int
+	ContentDigest() (string, bool, error)
+	SetContentDigest(string) error
 	InitializeArtifact(name string, buildID int) (WorkerArtifact, error)
 	InitializeTaskCache(jobID int, stepName string, path string) error
 
@@ -487,6 +489,49 @@ func (volume *createdVolume) GetResourceCacheID() int {
 	return volume.resourceCacheID
 }
 
+// ContentDigest returns the digest of the volume's content, as recorded the
+// first time it was streamed out of or into the volume.
+func (volume *createdVolume) ContentDigest() (string, bool, error) {
+	var digest sql.NullString
+	err := psql.Select("content_digest").
+		From("volumes").
+		Where(sq.Eq{"id": volume.id}).
+		RunWith(volume.conn).
+		QueryRow().
+		Scan(&digest)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", false, ErrVolumeMissing
+		}
+
+		return "", false, err
+	}
+
+	return digest.String, digest.Valid, nil
+}
+
+func (volume *createdVolume) SetContentDigest(digest string) error {
+	rows, err := psql.Update("volumes").
+		Set("content_digest", digest).
+		Where(sq.Eq{"id": volume.id}).
+		RunWith(volume.conn).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	affected, err := rows.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return ErrVolumeMissing
+	}
+
+	return nil
+}
+
 func (volume *createdVolume) InitializeArtifact(name string, buildID int) (WorkerArtifact, error) {
 	return initializeArtifact(volume.conn, volume.id, name, buildID)
 }
@@ -0,0 +1,276 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
+)
+
+// WarmPoolTemplate is how to create the warm containers for one of a job's
+// tasks. The task's builds record it, so that the containers match what the
+// next build will need.
+type WarmPoolTemplate struct {
+	JobID    int
+	StepName string
+	TeamID   int
+
+	// Key identifies the containers created from the template. It changes
+	// whenever anything that goes into them does.
+	Key  string
+	Spec json.RawMessage
+
+	// Size and Expiry come from the job's warm pool config. Ready is how many
+	// containers created from the template are ready or being created.
+	Size   int
+	Expiry time.Duration
+	Ready  int
+}
+
+// WarmPoolRepository keeps track of the containers created ahead of the
+// builds of jobs with warm pools.
+//
+//counterfeiter:generate . WarmPoolRepository
+type WarmPoolRepository interface {
+	// SaveTemplate replaces the template for the job's task. It does nothing
+	// if the job does not have a warm pool.
+	SaveTemplate(template WarmPoolTemplate) error
+
+	// Templates returns the templates of the active jobs with warm pools,
+	// leaving out paused jobs and pipelines.
+	Templates() ([]WarmPoolTemplate, error)
+
+	// CreateSlot returns a new slot for a container created from the
+	// template. The slot expires after the job's warm pool expiry.
+	CreateSlot(template WarmPoolTemplate) (int, error)
+
+	// ClaimContainer hands a ready container created from the template with
+	// the given key over to a step of a build. It returns false if there is
+	// no such container.
+	ClaimContainer(jobID int, stepName string, key string, buildID int, planID atc.PlanID, metadata ContainerMetadata) (bool, error)
+
+	// RemoveStaleSlots removes the slots which have expired, which were
+	// created from an older template, or whose job is no longer warmed. Their
+	// containers are then collected as orphans.
+	RemoveStaleSlots() (int, error)
+}
+
+type warmPoolRepository struct {
+	conn Conn
+}
+
+func NewWarmPoolRepository(conn Conn) WarmPoolRepository {
+	return &warmPoolRepository{
+		conn: conn,
+	}
+}
+
+func (repo *warmPoolRepository) SaveTemplate(template WarmPoolTemplate) error {
+	_, err := repo.conn.Exec(`
+		INSERT INTO warm_pool_templates (job_id, step_name, team_id, key, spec)
+		SELECT $1, $2, $3, $4, $5
+		FROM job_warm_pools
+		WHERE job_id = $1
+		ON CONFLICT (job_id, step_name) DO UPDATE SET
+			team_id = EXCLUDED.team_id,
+			key = EXCLUDED.key,
+			spec = EXCLUDED.spec
+	`, template.JobID, template.StepName, template.TeamID, template.Key, []byte(template.Spec))
+	return err
+}
+
+func (repo *warmPoolRepository) Templates() ([]WarmPoolTemplate, error) {
+	rows, err := psql.Select(
+		"t.job_id",
+		"t.step_name",
+		"t.team_id",
+		"t.key",
+		"t.spec",
+		"wp.size",
+		"EXTRACT(EPOCH FROM wp.expiry)::bigint",
+		`(
+			SELECT COUNT(*)
+			FROM warm_pool_slots s
+			JOIN containers c ON c.warm_pool_slot_id = s.id
+			WHERE s.job_id = t.job_id
+			AND s.step_name = t.step_name
+			AND s.key = t.key
+			AND s.expires_at > NOW()
+			AND c.state IN ('creating', 'created')
+		)`,
+	).
+		From("warm_pool_templates t").
+		Join("job_warm_pools wp ON wp.job_id = t.job_id").
+		Join("jobs j ON j.id = t.job_id").
+		Join("pipelines p ON p.id = j.pipeline_id").
+		Where(warmedJob).
+		OrderBy("t.job_id", "t.step_name").
+		RunWith(repo.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	var templates []WarmPoolTemplate
+	for rows.Next() {
+		var (
+			template WarmPoolTemplate
+			spec     []byte
+			expiry   int64
+		)
+
+		err := rows.Scan(&template.JobID, &template.StepName, &template.TeamID, &template.Key, &spec, &template.Size, &expiry, &template.Ready)
+		if err != nil {
+			return nil, err
+		}
+
+		template.Spec = spec
+		template.Expiry = time.Duration(expiry) * time.Second
+
+		templates = append(templates, template)
+	}
+
+	return templates, nil
+}
+
+func (repo *warmPoolRepository) CreateSlot(template WarmPoolTemplate) (int, error) {
+	var id int
+	err := psql.Insert("warm_pool_slots").
+		SetMap(map[string]interface{}{
+			"job_id":     template.JobID,
+			"step_name":  template.StepName,
+			"key":        template.Key,
+			"expires_at": sq.Expr(fmt.Sprintf("NOW() + '%d seconds'::interval", int(template.Expiry.Seconds()))),
+		}).
+		Suffix("RETURNING id").
+		RunWith(repo.conn).
+		QueryRow().
+		Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (repo *warmPoolRepository) ClaimContainer(jobID int, stepName string, key string, buildID int, planID atc.PlanID, metadata ContainerMetadata) (bool, error) {
+	tx, err := repo.conn.Begin()
+	if err != nil {
+		return false, err
+	}
+
+	defer Rollback(tx)
+
+	var containerID, slotID int
+	err = psql.Select("c.id", "s.id").
+		From("warm_pool_slots s").
+		Join("containers c ON c.warm_pool_slot_id = s.id").
+		Join("workers w ON w.name = c.worker_name").
+		Where(sq.Eq{
+			"s.job_id":    jobID,
+			"s.step_name": stepName,
+			"s.key":       key,
+			"c.state":     atc.ContainerStateCreated,
+			"w.state":     string(WorkerStateRunning),
+		}).
+		Where(sq.Expr("s.expires_at > NOW()")).
+		// the step may already have a container, e.g. if the ATC restarted
+		// while it was running
+		Where(sq.Expr(`NOT EXISTS (
+			SELECT 1
+			FROM containers
+			WHERE build_id = ?
+			AND plan_id = ?
+		)`, buildID, planID)).
+		OrderBy("s.expires_at DESC").
+		Limit(1).
+		Suffix("FOR UPDATE OF c SKIP LOCKED").
+		RunWith(tx).
+		QueryRow().
+		Scan(&containerID, &slotID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	claim := metadata.SQLMap()
+	claim["build_id"] = buildID
+	claim["plan_id"] = planID
+	claim["warm_pool_slot_id"] = nil
+
+	_, err = psql.Update("containers").
+		SetMap(claim).
+		Where(sq.Eq{"id": containerID}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return false, err
+	}
+
+	_, err = psql.Delete("warm_pool_slots").
+		Where(sq.Eq{"id": slotID}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return false, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (repo *warmPoolRepository) RemoveStaleSlots() (int, error) {
+	result, err := repo.conn.Exec(`
+		DELETE FROM warm_pool_slots s
+		WHERE s.expires_at <= NOW()
+		OR NOT EXISTS (
+			SELECT 1
+			FROM warm_pool_templates t
+			JOIN job_warm_pools wp ON wp.job_id = t.job_id
+			JOIN jobs j ON j.id = t.job_id
+			JOIN pipelines p ON p.id = j.pipeline_id
+			WHERE t.job_id = s.job_id
+			AND t.step_name = s.step_name
+			AND t.key = s.key
+			AND j.active
+			AND NOT j.paused
+			AND NOT p.paused
+			AND NOT p.archived
+		)
+		OR NOT EXISTS (
+			SELECT 1
+			FROM containers c
+			WHERE c.warm_pool_slot_id = s.id
+			AND c.state IN ('creating', 'created')
+		)
+	`)
+	if err != nil {
+		return 0, err
+	}
+
+	removed, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(removed), nil
+}
+
+var warmedJob = sq.Eq{
+	"j.active":   true,
+	"j.paused":   false,
+	"p.paused":   false,
+	"p.archived": false,
+}
@@ -0,0 +1,186 @@
+package db_test
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("WarmPoolRepository", func() {
+	var (
+		repository db.WarmPoolRepository
+		template   db.WarmPoolTemplate
+	)
+
+	setWarmPool := func(warmPool *atc.WarmPoolConfig) {
+		config := defaultPipelineConfig
+		config.Jobs = append(atc.JobConfigs{}, defaultPipelineConfig.Jobs...)
+		config.Jobs[0].WarmPool = warmPool
+
+		pipeline, _, err := defaultTeam.SavePipeline(defaultPipelineRef, config, defaultPipeline.ConfigVersion(), false)
+		Expect(err).ToNot(HaveOccurred())
+
+		defaultPipeline = pipeline
+	}
+
+	warmContainer := func(template db.WarmPoolTemplate) db.CreatedContainer {
+		slotID, err := repository.CreateSlot(template)
+		Expect(err).ToNot(HaveOccurred())
+
+		creating, err := defaultWorker.CreateContainer(
+			db.NewWarmPoolContainerOwner(slotID, template.TeamID),
+			db.ContainerMetadata{Type: db.ContainerTypeTask, StepName: template.StepName, JobID: template.JobID},
+		)
+		Expect(err).ToNot(HaveOccurred())
+
+		created, err := creating.Created()
+		Expect(err).ToNot(HaveOccurred())
+
+		return created
+	}
+
+	BeforeEach(func() {
+		repository = db.NewWarmPoolRepository(dbConn)
+
+		template = db.WarmPoolTemplate{
+			JobID:    defaultJob.ID(),
+			StepName: "some-task",
+			TeamID:   defaultTeam.ID(),
+			Key:      "some-key",
+			Spec:     json.RawMessage(`{"some":"spec"}`),
+		}
+	})
+
+	Context("when the job has a warm pool", func() {
+		BeforeEach(func() {
+			setWarmPool(&atc.WarmPoolConfig{Size: 2, Expiry: "10m"})
+
+			err := repository.SaveTemplate(template)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("returns the template along with the job's warm pool config", func() {
+			templates, err := repository.Templates()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(templates).To(HaveLen(1))
+
+			Expect(templates[0].Key).To(Equal("some-key"))
+			Expect(templates[0].Spec).To(MatchJSON(`{"some":"spec"}`))
+			Expect(templates[0].Size).To(Equal(2))
+			Expect(templates[0].Expiry).To(Equal(10 * time.Minute))
+			Expect(templates[0].Ready).To(BeZero())
+		})
+
+		Context("when containers have been created from the template", func() {
+			var container db.CreatedContainer
+
+			BeforeEach(func() {
+				templates, err := repository.Templates()
+				Expect(err).ToNot(HaveOccurred())
+
+				container = warmContainer(templates[0])
+			})
+
+			It("counts them as ready", func() {
+				templates, err := repository.Templates()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(templates[0].Ready).To(Equal(1))
+			})
+
+			It("hands one over to a build", func() {
+				build, err := defaultJob.CreateBuild(defaultBuildCreatedBy)
+				Expect(err).ToNot(HaveOccurred())
+
+				claimed, err := repository.ClaimContainer(defaultJob.ID(), "some-task", "some-key", build.ID(), "some-plan", db.ContainerMetadata{BuildID: build.ID()})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(claimed).To(BeTrue())
+
+				_, owned, err := defaultWorker.FindContainer(db.NewBuildStepContainerOwner(build.ID(), "some-plan", defaultTeam.ID()))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(owned).ToNot(BeNil())
+				Expect(owned.Handle()).To(Equal(container.Handle()))
+				Expect(owned.Metadata().BuildID).To(Equal(build.ID()))
+
+				templates, err := repository.Templates()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(templates[0].Ready).To(BeZero())
+
+				claimed, err = repository.ClaimContainer(defaultJob.ID(), "some-task", "some-key", build.ID(), "other-plan", db.ContainerMetadata{})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(claimed).To(BeFalse())
+			})
+
+			It("does not hand over a container created from another template", func() {
+				claimed, err := repository.ClaimContainer(defaultJob.ID(), "some-task", "other-key", 1, "some-plan", db.ContainerMetadata{})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(claimed).To(BeFalse())
+			})
+
+			Context("when the template changes", func() {
+				BeforeEach(func() {
+					template.Key = "other-key"
+					err := repository.SaveTemplate(template)
+					Expect(err).ToNot(HaveOccurred())
+				})
+
+				It("removes the slots created from the old one", func() {
+					removed, err := repository.RemoveStaleSlots()
+					Expect(err).ToNot(HaveOccurred())
+					Expect(removed).To(Equal(1))
+
+					_, created, _, err := db.NewContainerRepository(dbConn).FindOrphanedContainers()
+					Expect(err).ToNot(HaveOccurred())
+					Expect(created).To(HaveLen(1))
+					Expect(created[0].Handle()).To(Equal(container.Handle()))
+				})
+			})
+
+			Context("when the job's warm pool is removed", func() {
+				BeforeEach(func() {
+					setWarmPool(nil)
+				})
+
+				It("forgets the templates and slots", func() {
+					templates, err := repository.Templates()
+					Expect(err).ToNot(HaveOccurred())
+					Expect(templates).To(BeEmpty())
+
+					_, created, _, err := db.NewContainerRepository(dbConn).FindOrphanedContainers()
+					Expect(err).ToNot(HaveOccurred())
+					Expect(created).To(HaveLen(1))
+				})
+			})
+
+			Context("when the job is paused", func() {
+				BeforeEach(func() {
+					Expect(defaultJob.Pause("some-user")).To(Succeed())
+				})
+
+				It("removes the slots", func() {
+					templates, err := repository.Templates()
+					Expect(err).ToNot(HaveOccurred())
+					Expect(templates).To(BeEmpty())
+
+					removed, err := repository.RemoveStaleSlots()
+					Expect(err).ToNot(HaveOccurred())
+					Expect(removed).To(Equal(1))
+				})
+			})
+		})
+	})
+
+	Context("when the job does not have a warm pool", func() {
+		It("does not save the template", func() {
+			err := repository.SaveTemplate(template)
+			Expect(err).ToNot(HaveOccurred())
+
+			templates, err := repository.Templates()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(templates).To(BeEmpty())
+		})
+	})
+})
@@ -63,12 +63,22 @@ type Worker interface {
 	ActiveVolumes() int
 	ResourceTypes() []atc.WorkerResourceType
 	Platform() string
+	Runtime() string
 	Tags() []string
 	TeamID() int
 	TeamName() string
 	StartTime() time.Time
 	ExpiresAt() time.Time
 	Ephemeral() bool
+	Certificate() *atc.WorkerCertificate
+
+	// Capabilities returns nil if the worker did not advertise its
+	// capabilities when it registered.
+	Capabilities() *atc.WorkerCapabilities
+
+	// DiskUsage returns nil if the worker did not report its disk usage in
+	// its last heartbeat.
+	DiskUsage() *atc.WorkerDiskUsage
 
 	Reload() (bool, error)
 
@@ -101,6 +111,7 @@ type worker struct {
 	activeTasks      int
 	resourceTypes    []atc.WorkerResourceType
 	platform         string
+	runtime          string
 	tags             []string
 	teamID           int
 	teamName         string
@@ -108,6 +119,9 @@ type worker struct {
 	expiresAt        time.Time
 	certsPath        *string
 	ephemeral        bool
+	certificate      *atc.WorkerCertificate
+	capabilities     *atc.WorkerCapabilities
+	diskUsage        *atc.WorkerDiskUsage
 }
 
 func (worker *worker) Name() string             { return worker.name }
@@ -124,10 +138,14 @@ func (worker *worker) ActiveContainers() int                   { return worker.a
 func (worker *worker) ActiveVolumes() int                      { return worker.activeVolumes }
 func (worker *worker) ResourceTypes() []atc.WorkerResourceType { return worker.resourceTypes }
 func (worker *worker) Platform() string                        { return worker.platform }
+func (worker *worker) Runtime() string                         { return worker.runtime }
 func (worker *worker) Tags() []string                          { return worker.tags }
 func (worker *worker) TeamID() int                             { return worker.teamID }
 func (worker *worker) TeamName() string                        { return worker.teamName }
 func (worker *worker) Ephemeral() bool                         { return worker.ephemeral }
+func (worker *worker) Certificate() *atc.WorkerCertificate     { return worker.certificate }
+func (worker *worker) Capabilities() *atc.WorkerCapabilities   { return worker.capabilities }
+func (worker *worker) DiskUsage() *atc.WorkerDiskUsage         { return worker.diskUsage }
 
 func (worker *worker) StartTime() time.Time { return worker.startTime }
 func (worker *worker) ExpiresAt() time.Time { return worker.expiresAt }
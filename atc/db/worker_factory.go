@@ -50,12 +50,16 @@ var workersQuery = psql.Select(`
 		w.active_volumes,
 		w.resource_types,
 		w.platform,
+		w.runtime,
 		w.tags,
 		t.name,
 		w.team_id,
 		w.start_time,
 		w.expires,
-		w.ephemeral
+		w.ephemeral,
+		w.certificate,
+		w.capabilities,
+		w.disk_usage
 	`).
 	From("workers w").
 	LeftJoin("teams t ON w.team_id = t.id")
@@ -161,12 +165,16 @@ func scanWorker(worker *worker, row scannable) error {
 		noProxy       sql.NullString
 		resourceTypes []byte
 		platform      sql.NullString
+		runtime       sql.NullString
 		tags          []byte
 		teamName      sql.NullString
 		teamID        sql.NullInt64
 		startTime     pq.NullTime
 		expiresAt     pq.NullTime
 		ephemeral     sql.NullBool
+		certificate   sql.NullString
+		capabilities  sql.NullString
+		diskUsage     sql.NullString
 	)
 
 	err := row.Scan(
@@ -183,12 +191,16 @@ func scanWorker(worker *worker, row scannable) error {
 		&worker.activeVolumes,
 		&resourceTypes,
 		&platform,
+		&runtime,
 		&tags,
 		&teamName,
 		&teamID,
 		&startTime,
 		&expiresAt,
 		&ephemeral,
+		&certificate,
+		&capabilities,
+		&diskUsage,
 	)
 	if err != nil {
 		return err
@@ -238,10 +250,35 @@ func scanWorker(worker *worker, row scannable) error {
 		worker.platform = platform.String
 	}
 
+	if runtime.Valid {
+		worker.runtime = runtime.String
+	}
+
 	if ephemeral.Valid {
 		worker.ephemeral = ephemeral.Bool
 	}
 
+	if certificate.Valid {
+		err = json.Unmarshal([]byte(certificate.String), &worker.certificate)
+		if err != nil {
+			return err
+		}
+	}
+
+	if capabilities.Valid {
+		err = json.Unmarshal([]byte(capabilities.String), &worker.capabilities)
+		if err != nil {
+			return err
+		}
+	}
+
+	if diskUsage.Valid {
+		err = json.Unmarshal([]byte(diskUsage.String), &worker.diskUsage)
+		if err != nil {
+			return err
+		}
+	}
+
 	err = json.Unmarshal(resourceTypes, &worker.resourceTypes)
 	if err != nil {
 		return err
@@ -279,10 +316,16 @@ func (f *workerFactory) HeartbeatWorker(atcWorker atc.Worker, ttl time.Duration)
 		return nil, err
 	}
 
+	diskUsage, err := marshalDiskUsage(atcWorker.DiskUsage)
+	if err != nil {
+		return nil, err
+	}
+
 	_, err = psql.Update("workers").
 		Set("expires", sq.Expr(expires)).
 		Set("active_containers", atcWorker.ActiveContainers).
 		Set("active_volumes", atcWorker.ActiveVolumes).
+		Set("disk_usage", diskUsage).
 		Set("state", sq.Expr("("+cSQL+")")).
 		Where(sq.Eq{"name": atcWorker.Name}).
 		RunWith(tx).
@@ -365,6 +408,20 @@ func (f *workerFactory) BuildContainersCountPerWorker() (map[string]int, error)
 	return f.cache.WorkerContainerCounts()
 }
 
+func marshalDiskUsage(diskUsage *atc.WorkerDiskUsage) (*string, error) {
+	if diskUsage == nil {
+		return nil, nil
+	}
+
+	payload, err := json.Marshal(diskUsage)
+	if err != nil {
+		return nil, err
+	}
+
+	diskUsagePayload := string(payload)
+	return &diskUsagePayload, nil
+}
+
 func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, conn Conn) (Worker, error) {
 	resourceTypes, err := json.Marshal(atcWorker.ResourceTypes)
 	if err != nil {
@@ -395,6 +452,33 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 		workerVersion = &atcWorker.Version
 	}
 
+	var certificate *string
+	if atcWorker.Certificate != nil {
+		payload, err := json.Marshal(atcWorker.Certificate)
+		if err != nil {
+			return nil, err
+		}
+
+		certificatePayload := string(payload)
+		certificate = &certificatePayload
+	}
+
+	var capabilities *string
+	if atcWorker.Capabilities != nil {
+		payload, err := json.Marshal(atcWorker.Capabilities)
+		if err != nil {
+			return nil, err
+		}
+
+		capabilitiesPayload := string(payload)
+		capabilities = &capabilitiesPayload
+	}
+
+	diskUsage, err := marshalDiskUsage(atcWorker.DiskUsage)
+	if err != nil {
+		return nil, err
+	}
+
 	values := []interface{}{
 		atcWorker.GardenAddr,
 		atcWorker.ActiveContainers,
@@ -402,6 +486,7 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 		resourceTypes,
 		tags,
 		atcWorker.Platform,
+		atcWorker.Runtime,
 		atcWorker.BaggageclaimURL,
 		atcWorker.CertsPath,
 		atcWorker.HTTPProxyURL,
@@ -412,6 +497,9 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 		string(workerState),
 		teamID,
 		atcWorker.Ephemeral,
+		certificate,
+		capabilities,
+		diskUsage,
 	}
 
 	conflictValues := values
@@ -433,6 +521,7 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 			"resource_types",
 			"tags",
 			"platform",
+			"runtime",
 			"baggageclaim_url",
 			"certs_path",
 			"http_proxy_url",
@@ -443,6 +532,9 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 			"state",
 			"team_id",
 			"ephemeral",
+			"certificate",
+			"capabilities",
+			"disk_usage",
 		).
 		Values(append([]interface{}{
 			sq.Expr(expires),
@@ -458,6 +550,7 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 				resource_types = ?,
 				tags = ?,
 				platform = ?,
+				runtime = ?,
 				baggageclaim_url = ?,
 				certs_path = ?,
 				http_proxy_url = ?,
@@ -467,7 +560,10 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 				version = ?,
 				state = ?,
 				team_id = ?,
-				ephemeral = ?
+				ephemeral = ?,
+				certificate = ?,
+				capabilities = ?,
+				disk_usage = ?
 			WHERE `+matchTeamUpsert,
 			conflictValues...,
 		).
@@ -505,11 +601,15 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 		activeVolumes:    atcWorker.ActiveVolumes,
 		resourceTypes:    atcWorker.ResourceTypes,
 		platform:         atcWorker.Platform,
+		runtime:          atcWorker.Runtime,
 		tags:             atcWorker.Tags,
 		teamName:         atcWorker.Team,
 		teamID:           workerTeamID,
 		startTime:        time.Unix(atcWorker.StartTime, 0),
 		ephemeral:        atcWorker.Ephemeral,
+		certificate:      atcWorker.Certificate,
+		capabilities:     atcWorker.Capabilities,
+		diskUsage:        atcWorker.DiskUsage,
 		conn:             conn,
 	}
 
@@ -44,6 +44,7 @@ var _ = Describe("WorkerFactory", func() {
 				},
 			},
 			Platform:  "some-platform",
+			Runtime:   "containerd",
 			Tags:      atc.Tags{"some", "tags"},
 			Name:      "some-name",
 			StartTime: 1565367209,
@@ -85,6 +86,85 @@ var _ = Describe("WorkerFactory", func() {
 				Expect(worker.ResourceTypes()).To(Equal(atcWorker.ResourceTypes))
 			})
 
+			It("saves the runtime", func() {
+				worker, found, err := workerFactory.GetWorker(atcWorker.Name)
+				Expect(found).To(BeTrue())
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(worker.Runtime()).To(Equal("containerd"))
+			})
+
+			It("saves the certificate", func() {
+				atcWorker.Certificate = &atc.WorkerCertificate{
+					SerialNumber: "1a2b",
+					Subject:      "CN=some-name",
+					Issuer:       "CN=some-ca",
+					NotBefore:    1565367209,
+					NotAfter:     1565453609,
+					Fingerprint:  "some-fingerprint",
+				}
+
+				_, err := workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+				Expect(err).NotTo(HaveOccurred())
+
+				found, err := worker.Reload()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				Expect(worker.Certificate()).To(Equal(atcWorker.Certificate))
+			})
+
+			It("saves the capabilities", func() {
+				atcWorker.Capabilities = &atc.WorkerCapabilities{
+					StreamingCompression: []string{atc.StreamingCompressionGzip},
+					P2PStreaming:         true,
+					MaxImageSize:         1024,
+				}
+
+				_, err := workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+				Expect(err).NotTo(HaveOccurred())
+
+				found, err := worker.Reload()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				Expect(worker.Capabilities()).To(Equal(atcWorker.Capabilities))
+			})
+
+			It("forgets the capabilities of a worker which no longer advertises them", func() {
+				atcWorker.Capabilities = &atc.WorkerCapabilities{P2PStreaming: true}
+
+				_, err := workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+				Expect(err).NotTo(HaveOccurred())
+
+				atcWorker.Capabilities = nil
+
+				_, err = workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+				Expect(err).NotTo(HaveOccurred())
+
+				found, err := worker.Reload()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				Expect(worker.Capabilities()).To(BeNil())
+			})
+
+			It("saves the disk usage", func() {
+				atcWorker.DiskUsage = &atc.WorkerDiskUsage{
+					TotalBytes: 1024,
+					UsedBytes:  512,
+				}
+
+				_, err := workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+				Expect(err).NotTo(HaveOccurred())
+
+				found, err := worker.Reload()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				Expect(worker.DiskUsage()).To(Equal(atcWorker.DiskUsage))
+			})
+
 			It("removes old worker resource type", func() {
 				atcWorker.ResourceTypes = []atc.WorkerResourceType{
 					{
@@ -436,6 +516,23 @@ var _ = Describe("WorkerFactory", func() {
 				Expect(*foundWorker.BaggageclaimURL()).To(Equal("some-bc-url"))
 			})
 
+			It("updates the disk usage", func() {
+				atcWorker.DiskUsage = &atc.WorkerDiskUsage{
+					TotalBytes: 1024,
+					UsedBytes:  900,
+				}
+
+				foundWorker, err := workerFactory.HeartbeatWorker(atcWorker, ttl)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(foundWorker.DiskUsage()).To(Equal(atcWorker.DiskUsage))
+
+				atcWorker.DiskUsage = nil
+
+				foundWorker, err = workerFactory.HeartbeatWorker(atcWorker, ttl)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(foundWorker.DiskUsage()).To(BeNil())
+			})
+
 			Context("when the current state is landing", func() {
 				BeforeEach(func() {
 					atcWorker.State = string(db.WorkerStateLanding)
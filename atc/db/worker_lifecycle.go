@@ -4,6 +4,7 @@ import (
 	"database/sql"
 
 	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
 )
 
 //counterfeiter:generate . WorkerLifecycle
@@ -45,25 +46,78 @@ func (lifecycle *workerLifecycle) DeleteUnresponsiveEphemeralWorkers() ([]string
 }
 
 func (lifecycle *workerLifecycle) StallUnresponsiveWorkers() ([]string, error) {
-	query, args, err := psql.Update("workers").
+	tx, err := lifecycle.conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Rollback(tx)
+
+	rows, err := psql.Update("workers w").
 		SetMap(map[string]interface{}{
 			"state":   string(WorkerStateStalled),
 			"expires": nil,
 		}).
 		Where(sq.Eq{"state": string(WorkerStateRunning)}).
 		Where(sq.Expr("expires < NOW()")).
-		Suffix("RETURNING name").
-		ToSql()
+		Suffix("RETURNING w.name, w.team_id, (SELECT t.name FROM teams t WHERE t.id = w.team_id)").
+		RunWith(tx).
+		Query()
 	if err != nil {
-		return []string{}, err
+		return nil, err
 	}
 
-	rows, err := lifecycle.conn.Query(query, args...)
+	type stalledWorker struct {
+		Name  string `json:"name"`
+		Team  string `json:"team,omitempty"`
+		State string `json:"state"`
+
+		teamID int
+	}
+
+	var stalledWorkers []stalledWorker
+	for rows.Next() {
+		var (
+			worker   stalledWorker
+			teamID   sql.NullInt64
+			teamName sql.NullString
+		)
+
+		err = rows.Scan(&worker.Name, &teamID, &teamName)
+		if err != nil {
+			Close(rows)
+			return nil, err
+		}
+
+		worker.Team = teamName.String
+		worker.State = string(WorkerStateStalled)
+		worker.teamID = int(teamID.Int64)
+
+		stalledWorkers = append(stalledWorkers, worker)
+	}
+
+	Close(rows)
+
+	var workerNames []string
+	for _, worker := range stalledWorkers {
+		worker := worker
+
+		err = enqueueWebhookDeliveries(tx, worker.teamID, atc.WebhookEventWorkerStalled, func() (interface{}, error) {
+			return worker, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		workerNames = append(workerNames, worker.Name)
+	}
+
+	err = tx.Commit()
 	if err != nil {
 		return nil, err
 	}
 
-	return workersAffected(rows)
+	return workerNames, nil
 }
 
 func (lifecycle *workerLifecycle) DeleteFinishedRetiringWorkers() ([]string, error) {
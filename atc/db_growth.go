@@ -0,0 +1,20 @@
+package atc
+
+// DBGrowthSnapshot is how much a team had of what grows the database the
+// most at some point in time or, when Team is empty, how much the whole
+// cluster had. The build events are estimated from the statistics of the
+// tables they're in rather than counted.
+type DBGrowthSnapshot struct {
+	TakenAt          int64  `json:"taken_at"`
+	Team             string `json:"team,omitempty"`
+	Builds           int64  `json:"builds"`
+	BuildEvents      int64  `json:"build_events"`
+	BuildEventsBytes int64  `json:"build_events_bytes"`
+	Versions         int64  `json:"versions"`
+	Containers       int64  `json:"containers"`
+	Volumes          int64  `json:"volumes"`
+
+	// DatabaseBytes is the size of the whole database, so it's only set for
+	// the cluster.
+	DatabaseBytes int64 `json:"database_bytes,omitempty"`
+}
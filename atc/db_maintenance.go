@@ -0,0 +1,41 @@
+package atc
+
+// DBMaintenance is how bloated the busiest tables of the database and their
+// indexes are, and what should be done about it.
+type DBMaintenance struct {
+	Tables []DBTableHealth       `json:"tables"`
+	Advice []DBMaintenanceAdvice `json:"advice"`
+}
+
+// DBTableHealth describes a table, including its partitions, if any. Only
+// the indexes of the table itself are listed, not those of its partitions.
+type DBTableHealth struct {
+	Name       string          `json:"name"`
+	Partitions int             `json:"partitions,omitempty"`
+	LiveTuples int64           `json:"live_tuples"`
+	DeadTuples int64           `json:"dead_tuples"`
+	Bytes      int64           `json:"bytes"`
+	LastVacuum int64           `json:"last_vacuum,omitempty"`
+	Indexes    []DBIndexHealth `json:"indexes"`
+}
+
+// DBIndexHealth describes an index. BloatBytes is an estimate of how much
+// smaller the index would be if it were rebuilt.
+type DBIndexHealth struct {
+	Name       string `json:"name"`
+	Table      string `json:"table"`
+	Bytes      int64  `json:"bytes"`
+	BloatBytes int64  `json:"bloat_bytes"`
+}
+
+const (
+	DBMaintenanceVacuum  = "vacuum"
+	DBMaintenanceReindex = "reindex"
+)
+
+// DBMaintenanceAdvice is a table to vacuum or an index to rebuild, and why.
+type DBMaintenanceAdvice struct {
+	Action   string `json:"action"`
+	Relation string `json:"relation"`
+	Reason   string `json:"reason"`
+}
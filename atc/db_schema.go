@@ -0,0 +1,40 @@
+package atc
+
+// DBSchema describes the tables of the database as the migrations up to
+// Version leave them.
+type DBSchema struct {
+	Version int       `json:"version"`
+	Tables  []DBTable `json:"tables"`
+}
+
+// DBTable describes a table. Migrations lists the versions of the SQL
+// migrations which mention the table.
+type DBTable struct {
+	Name        string         `json:"name"`
+	Columns     []DBColumn     `json:"columns"`
+	ForeignKeys []DBForeignKey `json:"foreign_keys"`
+	Indexes     []DBIndex      `json:"indexes"`
+	Migrations  []int          `json:"migrations"`
+}
+
+type DBColumn struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Nullable bool   `json:"nullable"`
+	Default  string `json:"default,omitempty"`
+}
+
+type DBForeignKey struct {
+	Name              string   `json:"name"`
+	Columns           []string `json:"columns"`
+	ReferencedTable   string   `json:"referenced_table"`
+	ReferencedColumns []string `json:"referenced_columns"`
+	OnDelete          string   `json:"on_delete"`
+}
+
+type DBIndex struct {
+	Name       string `json:"name"`
+	Unique     bool   `json:"unique"`
+	Primary    bool   `json:"primary"`
+	Definition string `json:"definition"`
+}
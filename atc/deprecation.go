@@ -0,0 +1,44 @@
+package atc
+
+// APIDeprecation marks an endpoint of the API as deprecated or, when Param is
+// set, one of its query parameters. Responses to requests which use it carry
+// a Deprecation header, and a Sunset header once a date has been set for its
+// removal.
+type APIDeprecation struct {
+	Route string `json:"route"`
+	Param string `json:"param,omitempty"`
+
+	// Replacement says what to use instead.
+	Replacement string `json:"replacement"`
+
+	// Sunset is the date, as YYYY-MM-DD, after which it may be removed.
+	Sunset string `json:"sunset,omitempty"`
+}
+
+// APIDeprecations are the deprecated parts of the API.
+var APIDeprecations = []APIDeprecation{
+	{
+		Route:       MainJobBadge,
+		Replacement: "GET /api/v1/teams/main/pipelines/:pipeline_name/jobs/:job_name/badge",
+	},
+}
+
+// DeprecationUsage is how much a client used a deprecated part of the API:
+// clients are told apart by their user agent, and by the team named by the
+// routes they requested, if any.
+type DeprecationUsage struct {
+	Route     string `json:"route"`
+	Param     string `json:"param,omitempty"`
+	UserAgent string `json:"user_agent"`
+	Team      string `json:"team,omitempty"`
+	Requests  int64  `json:"requests"`
+	FirstSeen int64  `json:"first_seen"`
+	LastSeen  int64  `json:"last_seen"`
+}
+
+// DeprecationReport lists the deprecated parts of the API, along with the
+// clients which still use them, most recently seen first.
+type DeprecationReport struct {
+	Deprecations []APIDeprecation   `json:"deprecations"`
+	Usage        []DeprecationUsage `json:"usage"`
+}
@@ -0,0 +1,13 @@
+package deprecation_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestDeprecation(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Deprecation Suite")
+}
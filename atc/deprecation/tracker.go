@@ -0,0 +1,173 @@
+// Package deprecation keeps track of the clients which still use the
+// deprecated parts of the API.
+package deprecation
+
+import (
+	"os"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/tedsuo/ifrit"
+)
+
+const (
+	// maxPendingClients bounds how many clients are tracked between flushes,
+	// so that a client making up user agents can't exhaust our memory.
+	// Usage by any further clients is dropped until the next flush.
+	maxPendingClients = 1000
+
+	// maxUserAgentLength is how much of each user agent is kept.
+	maxUserAgentLength = 256
+
+	// Retention is how long a client is remembered after it was last seen.
+	Retention = 30 * 24 * time.Hour
+)
+
+type client struct {
+	route     string
+	param     string
+	userAgent string
+	team      string
+}
+
+// Tracker counts requests which use deprecated parts of the API in memory,
+// and periodically flushes them to the database, so that requests aren't
+// slowed down by a write each.
+type Tracker struct {
+	repository db.DeprecationUsageRepository
+	clock      func() time.Time
+
+	pendingL sync.Mutex
+	pending  map[client]*atc.DeprecationUsage
+}
+
+func NewTracker(repository db.DeprecationUsageRepository) *Tracker {
+	return &Tracker{
+		repository: repository,
+		clock:      time.Now,
+		pending:    map[client]*atc.DeprecationUsage{},
+	}
+}
+
+// Track counts a request using the deprecation, made by a client with the
+// given user agent to a route of the given team, if any.
+func (tracker *Tracker) Track(deprecation atc.APIDeprecation, userAgent string, team string) {
+	if len(userAgent) > maxUserAgentLength {
+		userAgent = userAgent[:maxUserAgentLength]
+	}
+
+	key := client{
+		route:     deprecation.Route,
+		param:     deprecation.Param,
+		userAgent: userAgent,
+		team:      team,
+	}
+
+	now := tracker.clock().Unix()
+
+	tracker.pendingL.Lock()
+	defer tracker.pendingL.Unlock()
+
+	usage, found := tracker.pending[key]
+	if !found {
+		if len(tracker.pending) >= maxPendingClients {
+			return
+		}
+
+		usage = &atc.DeprecationUsage{
+			Route:     key.route,
+			Param:     key.param,
+			UserAgent: key.userAgent,
+			Team:      key.team,
+			FirstSeen: now,
+		}
+
+		tracker.pending[key] = usage
+	}
+
+	usage.Requests++
+	usage.LastSeen = now
+}
+
+// Flush records the usage tracked since the last flush. If it fails, the
+// usage is kept for the next one.
+func (tracker *Tracker) Flush() error {
+	tracker.pendingL.Lock()
+	pending := tracker.pending
+	tracker.pending = map[client]*atc.DeprecationUsage{}
+	tracker.pendingL.Unlock()
+
+	if len(pending) == 0 {
+		return nil
+	}
+
+	usage := make([]atc.DeprecationUsage, 0, len(pending))
+	for _, u := range pending {
+		usage = append(usage, *u)
+	}
+
+	err := tracker.repository.RecordUsage(usage)
+	if err != nil {
+		tracker.requeue(pending)
+		return err
+	}
+
+	return nil
+}
+
+func (tracker *Tracker) requeue(pending map[client]*atc.DeprecationUsage) {
+	tracker.pendingL.Lock()
+	defer tracker.pendingL.Unlock()
+
+	for key, usage := range pending {
+		existing, found := tracker.pending[key]
+		if !found {
+			if len(tracker.pending) >= maxPendingClients {
+				continue
+			}
+
+			tracker.pending[key] = usage
+			continue
+		}
+
+		existing.Requests += usage.Requests
+		existing.FirstSeen = usage.FirstSeen
+	}
+}
+
+// Runner flushes the tracked usage every interval, and once more when
+// signalled to exit. It also forgets the clients which haven't been seen in
+// a while.
+func (tracker *Tracker) Runner(logger lager.Logger, interval time.Duration) ifrit.Runner {
+	return ifrit.RunFunc(func(signals <-chan os.Signal, ready chan<- struct{}) error {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		close(ready)
+
+		for {
+			select {
+			case <-signals:
+				tracker.flush(logger)
+				return nil
+			case <-ticker.C:
+				tracker.flush(logger)
+
+				err := tracker.repository.PruneUsage(tracker.clock().Add(-Retention))
+				if err != nil {
+					logger.Error("failed-to-prune-usage", err)
+				}
+			}
+		}
+	})
+}
+
+func (tracker *Tracker) flush(logger lager.Logger) {
+	err := tracker.Flush()
+	if err != nil {
+		logger.Error("failed-to-flush-usage", err)
+	}
+}
@@ -0,0 +1,88 @@
+package deprecation_test
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/deprecation"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Tracker", func() {
+	var (
+		fakeRepository *dbfakes.FakeDeprecationUsageRepository
+		tracker        *deprecation.Tracker
+
+		badge = atc.APIDeprecation{Route: atc.MainJobBadge}
+	)
+
+	BeforeEach(func() {
+		fakeRepository = new(dbfakes.FakeDeprecationUsageRepository)
+		tracker = deprecation.NewTracker(fakeRepository)
+	})
+
+	It("does not record anything if nothing was tracked", func() {
+		Expect(tracker.Flush()).To(Succeed())
+		Expect(fakeRepository.RecordUsageCallCount()).To(BeZero())
+	})
+
+	It("records the requests of each client", func() {
+		tracker.Track(badge, "some-agent", "")
+		tracker.Track(badge, "some-agent", "")
+		tracker.Track(badge, "some-agent", "some-team")
+
+		Expect(tracker.Flush()).To(Succeed())
+		Expect(fakeRepository.RecordUsageCallCount()).To(Equal(1))
+
+		usage := fakeRepository.RecordUsageArgsForCall(0)
+		Expect(usage).To(HaveLen(2))
+
+		requests := map[string]int64{}
+		for _, u := range usage {
+			Expect(u.Route).To(Equal(atc.MainJobBadge))
+			Expect(u.UserAgent).To(Equal("some-agent"))
+			Expect(u.FirstSeen).ToNot(BeZero())
+			Expect(u.LastSeen).To(BeNumerically(">=", u.FirstSeen))
+			requests[u.Team] = u.Requests
+		}
+
+		Expect(requests).To(Equal(map[string]int64{"": 2, "some-team": 1}))
+	})
+
+	It("starts over after flushing", func() {
+		tracker.Track(badge, "some-agent", "")
+		Expect(tracker.Flush()).To(Succeed())
+
+		Expect(tracker.Flush()).To(Succeed())
+		Expect(fakeRepository.RecordUsageCallCount()).To(Equal(1))
+	})
+
+	It("truncates long user agents", func() {
+		tracker.Track(badge, strings.Repeat("a", 1000), "")
+		Expect(tracker.Flush()).To(Succeed())
+
+		usage := fakeRepository.RecordUsageArgsForCall(0)
+		Expect(usage[0].UserAgent).To(HaveLen(256))
+	})
+
+	Context("when recording fails", func() {
+		BeforeEach(func() {
+			fakeRepository.RecordUsageReturnsOnCall(0, errors.New("nope"))
+		})
+
+		It("keeps the usage for the next flush", func() {
+			tracker.Track(badge, "some-agent", "")
+			Expect(tracker.Flush()).To(MatchError("nope"))
+
+			tracker.Track(badge, "some-agent", "")
+			Expect(tracker.Flush()).To(Succeed())
+
+			usage := fakeRepository.RecordUsageArgsForCall(1)
+			Expect(usage).To(HaveLen(1))
+			Expect(usage[0].Requests).To(Equal(int64(2)))
+		})
+	})
+})
@@ -0,0 +1,257 @@
+package engine
+
+import (
+	"fmt"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/event"
+)
+
+// EventFlowControl protects the database from builds which produce a lot of
+// events, by saving them in batches and throttling the steps writing logs
+// too fast.
+type EventFlowControl struct {
+	// BufferSize is how many bytes of logs are buffered for each build
+	// before its events are saved. Writing more output blocks until they
+	// are. A BufferSize of 0 saves every event as it comes.
+	BufferSize int
+
+	// FlushInterval is how often buffered events are saved otherwise. With
+	// a FlushInterval of 0 they're only saved once the buffer is full.
+	FlushInterval time.Duration
+
+	// MaxLogRate is how many bytes of logs per second a step may write
+	// before the rest of its output for that second is dropped. A MaxLogRate
+	// of 0 means no limit.
+	MaxLogRate int
+}
+
+// BufferedEventBuild saves the events of a build in batches. Events are
+// buffered in the order they come, so they keep it once saved.
+type BufferedEventBuild struct {
+	db.Build
+
+	logger      lager.Logger
+	clock       clock.Clock
+	flowControl EventFlowControl
+
+	lock         sync.Mutex
+	pending      []atc.Event
+	pendingBytes int
+	throttles    map[event.OriginID]*logThrottle
+	closed       bool
+
+	// flushErr is why the last periodic flush failed. It is returned by
+	// whichever of SaveEvent or Close is called next, since nothing is
+	// waiting on the flush itself.
+	flushErr error
+
+	stop chan struct{}
+}
+
+// logThrottle counts the bytes of logs a step wrote within the current
+// second.
+type logThrottle struct {
+	windowStart time.Time
+	bytes       int
+	dropped     bool
+}
+
+func NewBufferedEventBuild(logger lager.Logger, build db.Build, flowControl EventFlowControl, clock clock.Clock) *BufferedEventBuild {
+	buffered := &BufferedEventBuild{
+		Build: build,
+
+		logger:      logger,
+		clock:       clock,
+		flowControl: flowControl,
+
+		throttles: map[event.OriginID]*logThrottle{},
+
+		stop: make(chan struct{}),
+	}
+
+	if flowControl.FlushInterval > 0 {
+		go buffered.flushPeriodically()
+	}
+
+	return buffered
+}
+
+func (b *BufferedEventBuild) SaveEvent(ev atc.Event) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if b.closed {
+		return b.Build.SaveEvent(ev)
+	}
+
+	log, isLog := ev.(event.Log)
+	if !isLog {
+		b.pending = append(b.pending, ev)
+		return b.takeFlushErr()
+	}
+
+	for _, ev := range b.throttle(log) {
+		b.buffer(ev)
+	}
+
+	if b.pendingBytes >= b.flowControl.BufferSize {
+		err := b.flush()
+		if err != nil {
+			return err
+		}
+	}
+
+	return b.takeFlushErr()
+}
+
+// Close saves the buffered events. Events saved afterwards are saved right
+// away.
+func (b *BufferedEventBuild) Close() error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if b.closed {
+		return nil
+	}
+
+	b.closed = true
+	close(b.stop)
+
+	err := b.flush()
+	if err != nil {
+		return err
+	}
+
+	return b.takeFlushErr()
+}
+
+func (b *BufferedEventBuild) flushPeriodically() {
+	ticker := b.clock.NewTicker(b.flowControl.FlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-b.stop:
+			return
+
+		case <-ticker.C():
+			b.lock.Lock()
+			err := b.flush()
+			if err != nil {
+				b.flushErr = err
+			}
+			b.lock.Unlock()
+
+			if err != nil {
+				b.logger.Error("failed-to-save-buffered-events", err)
+			}
+		}
+	}
+}
+
+// buffer adds the log to the pending events, appending it to the previous
+// one when they have the same origin.
+func (b *BufferedEventBuild) buffer(log event.Log) {
+	b.pendingBytes += len(log.Payload)
+
+	if len(b.pending) > 0 {
+		last, isLog := b.pending[len(b.pending)-1].(event.Log)
+		if isLog && last.Origin == log.Origin {
+			last.Payload += log.Payload
+			b.pending[len(b.pending)-1] = last
+			return
+		}
+	}
+
+	b.pending = append(b.pending, log)
+}
+
+func (b *BufferedEventBuild) flush() error {
+	if len(b.pending) == 0 {
+		return nil
+	}
+
+	events := b.pending
+	b.pending = nil
+	b.pendingBytes = 0
+
+	return b.Build.SaveEvents(events)
+}
+
+// takeFlushErr returns the error of the last periodic flush, if it failed,
+// and forgets it so that it is only returned once.
+func (b *BufferedEventBuild) takeFlushErr() error {
+	err := b.flushErr
+	b.flushErr = nil
+	return err
+}
+
+// throttle drops whatever goes over the log rate of the step within the
+// current second, and tells the first time it does.
+func (b *BufferedEventBuild) throttle(log event.Log) []event.Log {
+	if b.flowControl.MaxLogRate == 0 {
+		return []event.Log{log}
+	}
+
+	now := b.clock.Now()
+
+	throttle, found := b.throttles[log.Origin.ID]
+	if !found || now.Sub(throttle.windowStart) >= time.Second {
+		throttle = &logThrottle{windowStart: now}
+		b.throttles[log.Origin.ID] = throttle
+	}
+
+	allowed := b.flowControl.MaxLogRate - throttle.bytes
+	if len(log.Payload) <= allowed {
+		throttle.bytes += len(log.Payload)
+		return []event.Log{log}
+	}
+
+	logs := []event.Log{}
+	if allowed > 0 {
+		log.Payload = truncateUTF8(log.Payload, allowed)
+		throttle.bytes += len(log.Payload)
+		logs = append(logs, log)
+	}
+
+	if !throttle.dropped {
+		throttle.dropped = true
+
+		logs = append(logs, event.Log{
+			Time: now.Unix(),
+			Payload: fmt.Sprintf(
+				"\n[output throttled: more than %d bytes written within a second, the rest of it was dropped]\n",
+				b.flowControl.MaxLogRate,
+			),
+			Origin: event.Origin{
+				Source: event.OriginSourceStderr,
+				ID:     log.Origin.ID,
+			},
+		})
+	}
+
+	return logs
+}
+
+// truncateUTF8 cuts the text down to the size without leaving part of a
+// multi-byte character at the end.
+func truncateUTF8(text string, size int) string {
+	text = text[:size]
+	for i := 1; i < utf8.UTFMax && len(text) > 0; i++ {
+		r, width := utf8.DecodeLastRuneInString(text)
+		if r != utf8.RuneError || width > 1 {
+			break
+		}
+
+		text = text[:len(text)-1]
+	}
+
+	return text
+}
@@ -0,0 +1,203 @@
+package engine_test
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	. "github.com/concourse/concourse/atc/engine"
+	"github.com/concourse/concourse/atc/event"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("BufferedEventBuild", func() {
+	var (
+		fakeBuild   *dbfakes.FakeBuild
+		fakeClock   *fakeclock.FakeClock
+		flowControl EventFlowControl
+
+		buffered *BufferedEventBuild
+
+		stdout event.Origin
+		stderr event.Origin
+	)
+
+	BeforeEach(func() {
+		fakeBuild = new(dbfakes.FakeBuild)
+		fakeClock = fakeclock.NewFakeClock(time.Unix(100, 0))
+
+		flowControl = EventFlowControl{
+			BufferSize:    10,
+			FlushInterval: time.Second,
+		}
+
+		stdout = event.Origin{Source: event.OriginSourceStdout, ID: "some-plan"}
+		stderr = event.Origin{Source: event.OriginSourceStderr, ID: "some-plan"}
+	})
+
+	JustBeforeEach(func() {
+		buffered = NewBufferedEventBuild(lagertest.NewTestLogger("test"), fakeBuild, flowControl, fakeClock)
+	})
+
+	AfterEach(func() {
+		buffered.Close()
+	})
+
+	logEvent := func(origin event.Origin, payload string) event.Log {
+		return event.Log{Time: 100, Origin: origin, Payload: payload}
+	}
+
+	savedEvents := func() []atc.Event {
+		events := []atc.Event{}
+		for i := 0; i < fakeBuild.SaveEventsCallCount(); i++ {
+			events = append(events, fakeBuild.SaveEventsArgsForCall(i)...)
+		}
+
+		return events
+	}
+
+	It("buffers logs until the buffer is full", func() {
+		Expect(buffered.SaveEvent(logEvent(stdout, "hello "))).To(Succeed())
+		Expect(fakeBuild.SaveEventsCallCount()).To(BeZero())
+
+		Expect(buffered.SaveEvent(logEvent(stdout, "world"))).To(Succeed())
+		Expect(fakeBuild.SaveEventsCallCount()).To(Equal(1))
+		Expect(fakeBuild.SaveEventsArgsForCall(0)).To(Equal([]atc.Event{
+			logEvent(stdout, "hello world"),
+		}))
+	})
+
+	It("keeps logs of different origins apart", func() {
+		Expect(buffered.SaveEvent(logEvent(stdout, "out"))).To(Succeed())
+		Expect(buffered.SaveEvent(logEvent(stderr, "err"))).To(Succeed())
+		Expect(buffered.Close()).To(Succeed())
+
+		Expect(savedEvents()).To(Equal([]atc.Event{
+			logEvent(stdout, "out"),
+			logEvent(stderr, "err"),
+		}))
+	})
+
+	It("buffers other events along with the logs, in order", func() {
+		Expect(buffered.SaveEvent(logEvent(stdout, "done"))).To(Succeed())
+		Expect(buffered.SaveEvent(event.FinishTask{ExitStatus: 0})).To(Succeed())
+		Expect(fakeBuild.SaveEventsCallCount()).To(BeZero())
+
+		Expect(buffered.Close()).To(Succeed())
+
+		Expect(fakeBuild.SaveEventsCallCount()).To(Equal(1))
+		Expect(fakeBuild.SaveEventsArgsForCall(0)).To(Equal([]atc.Event{
+			logEvent(stdout, "done"),
+			event.FinishTask{ExitStatus: 0},
+		}))
+	})
+
+	It("saves buffered events periodically", func() {
+		Expect(buffered.SaveEvent(logEvent(stdout, "hi"))).To(Succeed())
+
+		fakeClock.WaitForWatcherAndIncrement(time.Second)
+
+		Eventually(fakeBuild.SaveEventsCallCount).Should(Equal(1))
+		Expect(fakeBuild.SaveEventsArgsForCall(0)).To(Equal([]atc.Event{
+			logEvent(stdout, "hi"),
+		}))
+	})
+
+	Context("when saving buffered events periodically fails", func() {
+		disaster := errors.New("nope")
+
+		JustBeforeEach(func() {
+			fakeBuild.SaveEventsReturnsOnCall(0, disaster)
+
+			Expect(buffered.SaveEvent(event.Status{Status: atc.StatusSucceeded})).To(Succeed())
+
+			fakeClock.WaitForWatcherAndIncrement(time.Second)
+			Eventually(fakeBuild.SaveEventsCallCount).Should(Equal(1))
+		})
+
+		It("returns the error from the next SaveEvent, once", func() {
+			Expect(buffered.SaveEvent(logEvent(stdout, "hi"))).To(Equal(disaster))
+
+			Expect(buffered.SaveEvent(logEvent(stdout, "hi"))).To(Succeed())
+		})
+
+		It("returns the error from Close", func() {
+			Expect(buffered.Close()).To(Equal(disaster))
+		})
+	})
+
+	Context("when saving events fails once the buffer is full", func() {
+		BeforeEach(func() {
+			fakeBuild.SaveEventsReturns(errors.New("nope"))
+		})
+
+		It("returns the error", func() {
+			Expect(buffered.SaveEvent(logEvent(stdout, strings.Repeat("x", 10)))).To(MatchError("nope"))
+		})
+	})
+
+	Context("once closed", func() {
+		JustBeforeEach(func() {
+			Expect(buffered.SaveEvent(logEvent(stdout, "hi"))).To(Succeed())
+			Expect(buffered.Close()).To(Succeed())
+		})
+
+		It("saves the buffered logs", func() {
+			Expect(savedEvents()).To(Equal([]atc.Event{logEvent(stdout, "hi")}))
+		})
+
+		It("saves events right away", func() {
+			Expect(buffered.SaveEvent(logEvent(stdout, "late"))).To(Succeed())
+			Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+			Expect(fakeBuild.SaveEventArgsForCall(0)).To(Equal(logEvent(stdout, "late")))
+		})
+	})
+
+	Context("with a max log rate", func() {
+		BeforeEach(func() {
+			flowControl.BufferSize = 1024
+			flowControl.MaxLogRate = 8
+		})
+
+		It("drops the output going over the rate and says so once", func() {
+			Expect(buffered.SaveEvent(logEvent(stdout, "12345"))).To(Succeed())
+			Expect(buffered.SaveEvent(logEvent(stdout, "67890"))).To(Succeed())
+			Expect(buffered.SaveEvent(logEvent(stdout, "dropped"))).To(Succeed())
+			Expect(buffered.Close()).To(Succeed())
+
+			events := savedEvents()
+			Expect(events).To(HaveLen(2))
+			Expect(events[0]).To(Equal(logEvent(stdout, "12345678")))
+
+			notice, ok := events[1].(event.Log)
+			Expect(ok).To(BeTrue())
+			Expect(notice.Origin).To(Equal(stderr))
+			Expect(notice.Payload).To(ContainSubstring("output throttled"))
+		})
+
+		It("lets output through again the next second", func() {
+			Expect(buffered.SaveEvent(logEvent(stdout, strings.Repeat("x", 10)))).To(Succeed())
+
+			fakeClock.Increment(time.Second)
+
+			Expect(buffered.SaveEvent(logEvent(stdout, "more"))).To(Succeed())
+			Expect(buffered.Close()).To(Succeed())
+
+			events := savedEvents()
+			Expect(events).To(HaveLen(3))
+			Expect(events[2]).To(Equal(logEvent(stdout, "more")))
+		})
+
+		It("does not cut multi-byte characters in half", func() {
+			Expect(buffered.SaveEvent(logEvent(stdout, "1234567☃"))).To(Succeed())
+			Expect(buffered.Close()).To(Succeed())
+
+			Expect(savedEvents()[0]).To(Equal(logEvent(stdout, "1234567")))
+		})
+	})
+})
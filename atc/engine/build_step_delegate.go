@@ -258,36 +258,63 @@ func (delegate *buildStepDelegate) Errored(logger lager.Logger, message string)
 	}
 }
 
+// Skipped saves a skipped event for each step of the plan, none of which
+// will run.
+func (delegate *buildStepDelegate) Skipped(logger lager.Logger, plan atc.Plan) {
+	now := delegate.clock.Now().Unix()
+
+	plan.Each(func(p *atc.Plan) {
+		err := delegate.build.SaveEvent(event.Skipped{
+			Origin: event.Origin{
+				ID: event.OriginID(p.ID),
+			},
+			Time: now,
+		})
+		if err != nil {
+			logger.Error("failed-to-save-skipped-event", err)
+		}
+	})
+
+	logger.Info("skipped")
+}
+
 func (delegate *buildStepDelegate) FetchImage(
 	ctx context.Context,
 	getPlan atc.Plan,
 	checkPlan *atc.Plan,
 	privileged bool,
 ) (runtime.ImageSpec, db.ResourceCache, error) {
-	err := delegate.checkImagePolicy(getPlan.Get.Source, getPlan.Get.Type, privileged)
+	err := delegate.checkImagePolicy(getPlan.Get.Source, getPlan.Get.Type, getPlan.Get.Version, privileged)
 	if err != nil {
 		return runtime.ImageSpec{}, nil, err
 	}
 
+	ctx, fetched := runtime.StartPhase(ctx, atc.StartLatencyImageFetch)
+	defer fetched()
+
 	fetchState := delegate.state.NewLocalScope()
 
 	if checkPlan != nil {
 		ok, err := fetchState.Run(ctx, *checkPlan)
 		if err != nil {
+			exec.RecordFailure(ctx, atc.FailureReasonImageFetchFailed)
 			return runtime.ImageSpec{}, nil, err
 		}
 
 		if !ok {
+			exec.RecordFailure(ctx, atc.FailureReasonImageFetchFailed)
 			return runtime.ImageSpec{}, nil, fmt.Errorf("image check failed")
 		}
 	}
 
 	ok, err := fetchState.Run(ctx, getPlan)
 	if err != nil {
+		exec.RecordFailure(ctx, atc.FailureReasonImageFetchFailed)
 		return runtime.ImageSpec{}, nil, err
 	}
 
 	if !ok {
+		exec.RecordFailure(ctx, atc.FailureReasonImageFetchFailed)
 		return runtime.ImageSpec{}, nil, fmt.Errorf("image fetching failed")
 	}
 
@@ -296,6 +323,11 @@ func (delegate *buildStepDelegate) FetchImage(
 		return runtime.ImageSpec{}, nil, fmt.Errorf("get did not return a result")
 	}
 
+	err = delegate.checkImageScanPolicy(getPlan.Get.Source, getPlan.Get.Type, result.ResourceCache.Version())
+	if err != nil {
+		return runtime.ImageSpec{}, nil, err
+	}
+
 	err = delegate.build.SaveImageResourceVersion(result.ResourceCache)
 	if err != nil {
 		return runtime.ImageSpec{}, nil, fmt.Errorf("save image version: %w", err)
@@ -375,7 +407,7 @@ func (delegate *buildStepDelegate) ConstructAcrossSubsteps(templateBytes []byte,
 	return substeps, nil
 }
 
-func (delegate *buildStepDelegate) checkImagePolicy(imageSource atc.Source, imageType string, privileged bool) error {
+func (delegate *buildStepDelegate) checkImagePolicy(imageSource atc.Source, imageType string, imageVersion *atc.Version, privileged bool) error {
 	if !delegate.policyChecker.ShouldCheckAction(policy.ActionUseImage) {
 		return nil
 	}
@@ -385,14 +417,48 @@ func (delegate *buildStepDelegate) checkImagePolicy(imageSource atc.Source, imag
 		return fmt.Errorf("redact source: %w", err)
 	}
 
+	data := map[string]interface{}{
+		"image_type":   imageType,
+		"image_source": redactedSource,
+		"privileged":   privileged,
+	}
+
+	if imageVersion != nil {
+		data["image_version"] = *imageVersion
+	}
+
+	return delegate.checkPolicy(policy.PolicyCheckInput{
+		Action:       policy.ActionUseImage,
+		Team:         delegate.build.TeamName(),
+		Pipeline:     delegate.build.PipelineName(),
+		InstanceVars: delegate.build.PipelineRef().InstanceVars,
+		Job:          delegate.build.JobName(),
+		Data:         data,
+	})
+}
+
+// checkImageScanPolicy checks the fetched image once its version is known.
+// Only images pinned to a digest can be scanned.
+func (delegate *buildStepDelegate) checkImageScanPolicy(imageSource atc.Source, imageType string, imageVersion atc.Version) error {
+	if imageVersion["digest"] == "" || !delegate.policyChecker.ShouldCheckAction(policy.ActionScanImage) {
+		return nil
+	}
+
+	redactedSource, err := delegate.redactImageSource(imageSource)
+	if err != nil {
+		return fmt.Errorf("redact source: %w", err)
+	}
+
 	return delegate.checkPolicy(policy.PolicyCheckInput{
-		Action:   policy.ActionUseImage,
-		Team:     delegate.build.TeamName(),
-		Pipeline: delegate.build.PipelineName(),
+		Action:       policy.ActionScanImage,
+		Team:         delegate.build.TeamName(),
+		Pipeline:     delegate.build.PipelineName(),
+		InstanceVars: delegate.build.PipelineRef().InstanceVars,
+		Job:          delegate.build.JobName(),
 		Data: map[string]interface{}{
-			"image_type":   imageType,
-			"image_source": redactedSource,
-			"privileged":   privileged,
+			"image_type":    imageType,
+			"image_source":  redactedSource,
+			"image_version": imageVersion,
 		},
 	})
 }
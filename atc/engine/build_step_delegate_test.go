@@ -101,6 +101,7 @@ var _ = Describe("BuildStepDelegate", func() {
 		var fakeResourceCache *dbfakes.FakeResourceCache
 
 		var privileged bool
+		var fetchedVersion atc.Version
 
 		var imageSpec runtime.ImageSpec
 		var fetchErr error
@@ -148,6 +149,7 @@ var _ = Describe("BuildStepDelegate", func() {
 
 				fakeResourceCache = new(dbfakes.FakeResourceCache)
 				fakeResourceCache.IDReturns(123)
+				fakeResourceCache.VersionReturns(fetchedVersion)
 				volume = runtimetest.NewVolume("image-handle")
 
 				step := new(execfakes.FakeStep)
@@ -165,7 +167,7 @@ var _ = Describe("BuildStepDelegate", func() {
 			parentRunState = exec.NewRunState(stepper, nil, true)
 
 			privileged = false
-
+			fetchedVersion = nil
 		})
 
 		JustBeforeEach(func() {
@@ -337,9 +339,10 @@ var _ = Describe("BuildStepDelegate", func() {
 							Team:     "some-team",
 							Pipeline: "some-pipeline",
 							Data: map[string]interface{}{
-								"image_type":   "docker",
-								"image_source": atc.Source{"some": "((source-var))"},
-								"privileged":   false,
+								"image_type":    "docker",
+								"image_source":  atc.Source{"some": "((source-var))"},
+								"image_version": atc.Version{"some": "version"},
+								"privileged":    false,
 							},
 						}))
 					})
@@ -359,9 +362,10 @@ var _ = Describe("BuildStepDelegate", func() {
 								Team:     "some-team",
 								Pipeline: "some-pipeline",
 								Data: map[string]interface{}{
-									"image_type":   "docker",
-									"image_source": atc.Source{"some": "((redacted))"},
-									"privileged":   false,
+									"image_type":    "docker",
+									"image_source":  atc.Source{"some": "((redacted))"},
+									"image_version": atc.Version{"some": "version"},
+									"privileged":    false,
 								},
 							}))
 						})
@@ -380,9 +384,10 @@ var _ = Describe("BuildStepDelegate", func() {
 								Team:     "some-team",
 								Pipeline: "some-pipeline",
 								Data: map[string]interface{}{
-									"image_type":   "docker",
-									"image_source": atc.Source{"some": "((source-var))"},
-									"privileged":   true,
+									"image_type":    "docker",
+									"image_source":  atc.Source{"some": "((source-var))"},
+									"image_version": atc.Version{"some": "version"},
+									"privileged":    true,
 								},
 							}))
 						})
@@ -391,6 +396,61 @@ var _ = Describe("BuildStepDelegate", func() {
 			})
 		})
 
+		Describe("image scanning", func() {
+			var fakeCheckResult *policyfakes.FakePolicyCheckResult
+
+			BeforeEach(func() {
+				fakeBuild.TeamNameReturns("some-team")
+				fakeBuild.PipelineNameReturns("some-pipeline")
+
+				fakeCheckResult = new(policyfakes.FakePolicyCheckResult)
+				fakeCheckResult.AllowedReturns(true)
+				fakePolicyChecker.CheckReturns(fakeCheckResult, nil)
+				fakePolicyChecker.ShouldCheckActionStub = func(action string) bool {
+					return action == policy.ActionScanImage
+				}
+			})
+
+			It("does not scan images which are not pinned to a digest", func() {
+				Expect(fetchErr).ToNot(HaveOccurred())
+				Expect(fakePolicyChecker.CheckCallCount()).To(Equal(0))
+			})
+
+			Context("when the fetched image is pinned to a digest", func() {
+				BeforeEach(func() {
+					fetchedVersion = atc.Version{"digest": "sha256:abc"}
+				})
+
+				It("checks the fetched version", func() {
+					Expect(fetchErr).ToNot(HaveOccurred())
+					Expect(fakePolicyChecker.CheckCallCount()).To(Equal(1))
+					Expect(fakePolicyChecker.CheckArgsForCall(0)).To(Equal(policy.PolicyCheckInput{
+						Action:   policy.ActionScanImage,
+						Team:     "some-team",
+						Pipeline: "some-pipeline",
+						Data: map[string]interface{}{
+							"image_type":    "docker",
+							"image_source":  atc.Source{"some": "((source-var))"},
+							"image_version": atc.Version{"digest": "sha256:abc"},
+						},
+					}))
+				})
+
+				Context("when the image is blocked", func() {
+					BeforeEach(func() {
+						fakeCheckResult.AllowedReturns(false)
+						fakeCheckResult.ShouldBlockReturns(true)
+						fakeCheckResult.MessagesReturns([]string{"image has vulnerabilities"})
+					})
+
+					It("fails without recording the image", func() {
+						Expect(fetchErr).To(MatchError(ContainSubstring("image has vulnerabilities")))
+						Expect(fakeBuild.SaveImageResourceVersionCallCount()).To(Equal(0))
+					})
+				})
+			})
+		})
+
 		Context("when there is no check plan", func() {
 			BeforeEach(func() {
 				expectedCheckPlan = nil
@@ -783,6 +843,36 @@ var _ = Describe("BuildStepDelegate", func() {
 		})
 	})
 
+	Describe("Skipped", func() {
+		JustBeforeEach(func() {
+			delegate.Skipped(logger, atc.Plan{
+				ID: "some-skipped-plan",
+				OnSuccess: &atc.OnSuccessPlan{
+					Step: atc.Plan{ID: "some-step", Task: &atc.TaskPlan{Name: "some-task"}},
+					Next: atc.Plan{ID: "some-hook", Task: &atc.TaskPlan{Name: "some-hook"}},
+				},
+			})
+		})
+
+		It("saves an event for each step of the plan", func() {
+			Expect(fakeBuild.SaveEventCallCount()).To(Equal(3))
+
+			var origins []event.OriginID
+			for i := 0; i < fakeBuild.SaveEventCallCount(); i++ {
+				skipped, ok := fakeBuild.SaveEventArgsForCall(i).(event.Skipped)
+				Expect(ok).To(BeTrue())
+				Expect(skipped.Time).To(Equal(now.Unix()))
+				origins = append(origins, skipped.Origin.ID)
+			}
+
+			Expect(origins).To(ConsistOf(
+				event.OriginID("some-skipped-plan"),
+				event.OriginID("some-step"),
+				event.OriginID("some-hook"),
+			))
+		})
+	})
+
 	Describe("No line buffer without secrets redaction", func() {
 		var runState exec.RunState
 
@@ -3,6 +3,7 @@ package engine
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"strconv"
 	"strings"
 
@@ -40,6 +41,7 @@ func NewStepperFactory(
 	policyChecker policy.Checker,
 	dbWorkerFactory db.WorkerFactory,
 	lockFactory lock.LockFactory,
+	injectedEnv db.InjectedEnvRepository,
 ) StepperFactory {
 	return &stepperFactory{
 		coreFactory:     coreFactory,
@@ -48,6 +50,7 @@ func NewStepperFactory(
 		policyChecker:   policyChecker,
 		dbWorkerFactory: dbWorkerFactory,
 		lockFactory:     lockFactory,
+		injectedEnv:     injectedEnv,
 	}
 }
 
@@ -58,6 +61,11 @@ type stepperFactory struct {
 	policyChecker   policy.Checker
 	dbWorkerFactory db.WorkerFactory
 	lockFactory     lock.LockFactory
+	injectedEnv     db.InjectedEnvRepository
+
+	// teamWorkersOnly is set per build when its pipeline may only run on the
+	// team's own workers.
+	teamWorkersOnly bool
 }
 
 func (factory *stepperFactory) StepperForBuild(build db.Build) (exec.Stepper, error) {
@@ -65,8 +73,21 @@ func (factory *stepperFactory) StepperForBuild(build db.Build) (exec.Stepper, er
 		return nil, errors.New("schema not supported")
 	}
 
+	buildFactory := *factory
+
+	if build.PipelineID() != 0 {
+		pipeline, found, err := build.Pipeline()
+		if err != nil {
+			return nil, fmt.Errorf("get pipeline: %w", err)
+		}
+
+		if found {
+			buildFactory.teamWorkersOnly = pipeline.TeamWorkersOnly()
+		}
+	}
+
 	return func(plan atc.Plan) exec.Step {
-		return factory.buildStep(build, plan)
+		return buildFactory.buildStep(build, plan)
 	}, nil
 }
 
@@ -78,6 +99,7 @@ func (factory *stepperFactory) buildDelegateFactory(build db.Build, plan atc.Pla
 		policyChecker:   factory.policyChecker,
 		dbWorkerFactory: factory.dbWorkerFactory,
 		lockFactory:     factory.lockFactory,
+		injectedEnv:     factory.injectedEnv,
 	}
 }
 
@@ -102,6 +124,10 @@ func (factory *stepperFactory) buildStep(build db.Build, plan atc.Plan) exec.Ste
 		return factory.buildTryStep(build, plan)
 	}
 
+	if plan.When != nil {
+		return factory.buildWhenStep(build, plan)
+	}
+
 	if plan.OnAbort != nil {
 		return factory.buildOnAbortStep(build, plan)
 	}
@@ -123,31 +149,31 @@ func (factory *stepperFactory) buildStep(build db.Build, plan atc.Plan) exec.Ste
 	}
 
 	if plan.Run != nil {
-		return factory.buildRunStep(build, plan)
+		return exec.Abortable(plan.ID, factory.buildRunStep(build, plan))
 	}
 
 	if plan.Task != nil {
-		return factory.buildTaskStep(build, plan)
+		return exec.Abortable(plan.ID, factory.buildTaskStep(build, plan))
 	}
 
 	if plan.SetPipeline != nil {
-		return factory.buildSetPipelineStep(build, plan)
+		return exec.Abortable(plan.ID, factory.buildSetPipelineStep(build, plan))
 	}
 
 	if plan.LoadVar != nil {
-		return factory.buildLoadVarStep(build, plan)
+		return exec.Abortable(plan.ID, factory.buildLoadVarStep(build, plan))
 	}
 
 	if plan.Check != nil {
-		return factory.buildCheckStep(build, plan)
+		return exec.Abortable(plan.ID, factory.buildCheckStep(build, plan))
 	}
 
 	if plan.Get != nil {
-		return factory.buildGetStep(build, plan)
+		return exec.Abortable(plan.ID, factory.buildGetStep(build, plan))
 	}
 
 	if plan.Put != nil {
-		return factory.buildPutStep(build, plan)
+		return exec.Abortable(plan.ID, factory.buildPutStep(build, plan))
 	}
 
 	if plan.Retry != nil {
@@ -221,6 +247,13 @@ func (factory *stepperFactory) buildTryStep(build db.Build, plan atc.Plan) exec.
 	return exec.Try(step)
 }
 
+func (factory *stepperFactory) buildWhenStep(build db.Build, plan atc.Plan) exec.Step {
+	innerPlan := plan.When.Step
+	innerPlan.Attempts = plan.Attempts
+	step := factory.buildStep(build, innerPlan)
+	return exec.Conditional(*plan.When, step, factory.buildDelegateFactory(build, plan))
+}
+
 func (factory *stepperFactory) buildOnAbortStep(build db.Build, plan atc.Plan) exec.Step {
 	plan.OnAbort.Step.Attempts = plan.Attempts
 	step := factory.buildStep(build, plan.OnAbort.Step)
@@ -481,6 +514,7 @@ func (factory *stepperFactory) stepMetadata(
 		PipelineName:         build.PipelineName(),
 		PipelineInstanceVars: build.PipelineInstanceVars(),
 		ExternalURL:          externalURL,
+		TeamWorkersOnly:      factory.teamWorkersOnly,
 	}
 	if exposeBuildCreatedBy && build.CreatedBy() != nil {
 		meta.CreatedBy = *build.CreatedBy()
@@ -42,6 +42,7 @@ var _ = Describe("Builder", func() {
 				fakePolicyChecker,
 				fakeWorkerFactory,
 				fakeLockFactory,
+				new(dbfakes.FakeInjectedEnvRepository),
 			)
 
 			planFactory = atc.NewPlanFactory(123)
@@ -548,6 +549,17 @@ var _ = Describe("Builder", func() {
 								BuildName:            "42",
 							}))
 						})
+
+						Context("when the pipeline may only run on team workers", func() {
+							BeforeEach(func() {
+								fakePipeline.TeamWorkersOnlyReturns(true)
+							})
+
+							It("restricts the task to team workers", func() {
+								_, stepMetadata, _, _ := fakeCoreStepFactory.TaskStepArgsForCall(0)
+								Expect(stepMetadata.TeamWorkersOnly).To(BeTrue())
+							})
+						})
 					})
 
 					Context("that contains a run step", func() {
@@ -861,6 +873,27 @@ var _ = Describe("Builder", func() {
 						}))
 					})
 				})
+
+				Context("running when steps", func() {
+					var inputPlan atc.Plan
+
+					BeforeEach(func() {
+						inputPlan = planFactory.NewPlan(atc.GetPlan{
+							Name: "some-input",
+						})
+
+						expectedPlan = planFactory.NewPlan(atc.WhenPlan{
+							Step:      inputPlan,
+							Condition: "((.:flag))",
+						})
+					})
+
+					It("constructs the step correctly", func() {
+						Expect(fakeCoreStepFactory.GetStepCallCount()).To(Equal(1))
+						plan, _, _, _ := fakeCoreStepFactory.GetStepArgsForCall(0)
+						Expect(plan).To(Equal(inputPlan))
+					})
+				})
 			})
 		})
 	})
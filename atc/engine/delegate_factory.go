@@ -17,18 +17,19 @@ type DelegateFactory struct {
 	policyChecker   policy.Checker
 	dbWorkerFactory db.WorkerFactory
 	lockFactory     lock.LockFactory
+	injectedEnv     db.InjectedEnvRepository
 }
 
 func (delegate DelegateFactory) GetDelegate(state exec.RunState) exec.GetDelegate {
-	return NewGetDelegate(delegate.build, delegate.plan.ID, state, clock.NewClock(), delegate.policyChecker)
+	return NewGetDelegate(delegate.build, delegate.plan.ID, delegate.stepName(), state, clock.NewClock(), delegate.policyChecker)
 }
 
 func (delegate DelegateFactory) PutDelegate(state exec.RunState) exec.PutDelegate {
-	return NewPutDelegate(delegate.build, delegate.plan.ID, state, clock.NewClock(), delegate.policyChecker)
+	return NewPutDelegate(delegate.build, delegate.plan.ID, delegate.stepName(), state, clock.NewClock(), delegate.policyChecker)
 }
 
 func (delegate DelegateFactory) TaskDelegate(state exec.RunState) exec.TaskDelegate {
-	return NewTaskDelegate(delegate.build, delegate.plan.ID, state, clock.NewClock(), delegate.policyChecker, delegate.dbWorkerFactory, delegate.lockFactory)
+	return NewTaskDelegate(delegate.build, delegate.plan.ID, delegate.stepName(), state, clock.NewClock(), delegate.policyChecker, delegate.dbWorkerFactory, delegate.lockFactory, delegate.injectedEnv)
 }
 
 func (delegate DelegateFactory) RunDelegate(state exec.RunState) exec.RunDelegate {
@@ -46,3 +47,18 @@ func (delegate DelegateFactory) BuildStepDelegate(state exec.RunState) exec.Buil
 func (delegate DelegateFactory) SetPipelineStepDelegate(state exec.RunState) exec.SetPipelineStepDelegate {
 	return NewSetPipelineStepDelegate(delegate.build, delegate.plan.ID, state, clock.NewClock(), delegate.policyChecker)
 }
+
+// stepName is the name of the get, put or task step the delegates are for,
+// which their durations are estimated by.
+func (delegate DelegateFactory) stepName() string {
+	switch {
+	case delegate.plan.Get != nil:
+		return delegate.plan.Get.Name
+	case delegate.plan.Put != nil:
+		return delegate.plan.Put.Name
+	case delegate.plan.Task != nil:
+		return delegate.plan.Task.Name
+	default:
+		return ""
+	}
+}
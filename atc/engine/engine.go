@@ -6,6 +6,7 @@ import (
 	"sync"
 	"time"
 
+	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagerctx"
 	"github.com/concourse/concourse/atc"
@@ -15,19 +16,48 @@ import (
 	"github.com/concourse/concourse/atc/event"
 	"github.com/concourse/concourse/atc/exec"
 	"github.com/concourse/concourse/atc/metric"
+	"github.com/concourse/concourse/atc/runtime"
 	"github.com/concourse/concourse/atc/util"
 	"github.com/concourse/concourse/tracing"
 )
 
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
 
+// BuildNotifier is told about each build, other than check builds, when the
+// engine starts running it and once it has finished.
+//
+//counterfeiter:generate . BuildNotifier
+type BuildNotifier interface {
+	BuildStarted(lager.Logger, db.Build)
+	BuildFinished(lager.Logger, db.Build)
+}
+
+// BuildNotifiers tells each of the notifiers in turn.
+type BuildNotifiers []BuildNotifier
+
+func (notifiers BuildNotifiers) BuildStarted(logger lager.Logger, build db.Build) {
+	for _, notifier := range notifiers {
+		notifier.BuildStarted(logger, build)
+	}
+}
+
+func (notifiers BuildNotifiers) BuildFinished(logger lager.Logger, build db.Build) {
+	for _, notifier := range notifiers {
+		notifier.BuildFinished(logger, build)
+	}
+}
+
 func NewEngine(
 	stepperFactory StepperFactory,
 	secrets creds.Secrets,
 	varSourcePool creds.VarSourcePool,
+	buildNotifier BuildNotifier,
+	flowControl EventFlowControl,
 ) Engine {
 	return Engine{
 		stepperFactory: stepperFactory,
+		buildNotifier:  buildNotifier,
+		flowControl:    flowControl,
 		release:        make(chan bool),
 		trackedStates:  new(sync.Map),
 		waitGroup:      new(sync.WaitGroup),
@@ -39,6 +69,8 @@ func NewEngine(
 
 type Engine struct {
 	stepperFactory StepperFactory
+	buildNotifier  BuildNotifier
+	flowControl    EventFlowControl
 	release        chan bool
 	trackedStates  *sync.Map
 	waitGroup      *sync.WaitGroup
@@ -66,6 +98,8 @@ func (engine Engine) NewBuild(build db.Build) builds.Runnable {
 		engine.stepperFactory,
 		engine.globalSecrets,
 		engine.varSourcePool,
+		engine.buildNotifier,
+		engine.flowControl,
 		engine.release,
 		engine.trackedStates,
 		engine.waitGroup,
@@ -77,6 +111,8 @@ func NewBuild(
 	builder StepperFactory,
 	globalSecrets creds.Secrets,
 	varSourcePool creds.VarSourcePool,
+	buildNotifier BuildNotifier,
+	flowControl EventFlowControl,
 	release chan bool,
 	trackedStates *sync.Map,
 	waitGroup *sync.WaitGroup,
@@ -88,9 +124,14 @@ func NewBuild(
 		globalSecrets: globalSecrets,
 		varSourcePool: varSourcePool,
 
+		buildNotifier: buildNotifier,
+		flowControl:   flowControl,
+
 		release:       release,
 		trackedStates: trackedStates,
 		waitGroup:     waitGroup,
+
+		failures: exec.NewFailureRecorder(),
 	}
 }
 
@@ -101,9 +142,14 @@ type engineBuild struct {
 	globalSecrets creds.Secrets
 	varSourcePool creds.VarSourcePool
 
+	buildNotifier BuildNotifier
+	flowControl   EventFlowControl
+
 	release       chan bool
 	trackedStates *sync.Map
 	waitGroup     *sync.WaitGroup
+
+	failures *exec.FailureRecorder
 }
 
 func (b *engineBuild) Run(ctx context.Context) {
@@ -144,7 +190,10 @@ func (b *engineBuild) Run(ctx context.Context) {
 	ctx, span := tracing.StartSpanFollowing(ctx, b.build, "build", b.build.TracingAttrs())
 	defer span.End()
 
-	stepper, err := b.builder.StepperForBuild(b.build)
+	eventBuild, saveBufferedEvents := b.eventBuild(logger)
+	defer saveBufferedEvents()
+
+	stepper, err := b.builder.StepperForBuild(eventBuild)
 	if err != nil {
 		logger.Error("failed-to-construct-build-stepper", err)
 
@@ -199,6 +248,29 @@ func (b *engineBuild) Run(ctx context.Context) {
 		}()
 	}
 
+	stepNotifier, err := b.build.StepAbortNotifier()
+	if err != nil {
+		logger.Error("failed-to-listen-for-step-aborts", err)
+		return
+	}
+
+	if stepNotifier != nil {
+		defer stepNotifier.Close()
+
+		aborter := exec.NewStepAborter()
+		ctx = exec.WithStepAborter(ctx, aborter)
+
+		noleak := make(chan bool)
+		defer close(noleak)
+
+		go b.abortSteps(logger, stepNotifier, aborter, noleak)
+	}
+
+	ctx = exec.WithFailureRecorder(ctx, b.failures)
+	ctx = runtime.WithStartLatencyRecorder(ctx, runtime.NewStartLatencyRecorder(clock.NewClock(), func(phases map[atc.StartLatencyPhase]time.Duration) {
+		b.saveStartLatency(logger, phases)
+	}))
+
 	var succeeded bool
 	var runErr error
 
@@ -226,12 +298,64 @@ func (b *engineBuild) Run(ctx context.Context) {
 			return
 		}
 
+		saveBufferedEvents()
+
 		// An in-memory build only generates a real build id once start to run,
 		// so let's update logger with the latest lager data.
 		b.finish(logger.Session("finish").WithData(b.build.LagerData()), runErr, succeeded)
 	}
 }
 
+// abortSteps aborts the steps asked to be aborted while they're running. Steps
+// which aren't running by then are left alone.
+func (b *engineBuild) abortSteps(logger lager.Logger, notifier db.Notifier, aborter *exec.StepAborter, noleak <-chan bool) {
+	handled := map[atc.PlanID]bool{}
+
+	for {
+		select {
+		case <-noleak:
+			return
+
+		case <-notifier.Notify():
+			planIDs, err := b.build.AbortedSteps()
+			if err != nil {
+				logger.Error("failed-to-get-aborted-steps", err)
+				continue
+			}
+
+			for _, planID := range planIDs {
+				if handled[planID] {
+					continue
+				}
+
+				handled[planID] = true
+
+				if aborter.Abort(planID) {
+					logger.Info("aborting-step", lager.Data{"plan-id": planID})
+				}
+			}
+		}
+	}
+}
+
+// eventBuild returns the build which the steps save their events to. Unless
+// flow control is disabled, it buffers their events until the returned
+// function is called. Check builds don't produce enough events to need it.
+func (b *engineBuild) eventBuild(logger lager.Logger) (db.Build, func()) {
+	if b.flowControl.BufferSize == 0 || b.build.Name() == db.CheckBuildName {
+		return b.build, func() {}
+	}
+
+	buffered := NewBufferedEventBuild(logger.Session("events"), b.build, b.flowControl, clock.NewClock())
+
+	return buffered, func() {
+		err := buffered.Close()
+		if err != nil {
+			logger.Error("failed-to-save-buffered-events", err)
+		}
+	}
+}
+
 func (b *engineBuild) buildStepErrored(logger lager.Logger, message string) {
 	err := b.build.SaveEvent(event.Error{
 		Message: message,
@@ -251,19 +375,111 @@ func (b *engineBuild) finish(logger lager.Logger, err error, succeeded bool) {
 		logger.Info("aborted")
 
 	} else if err != nil {
+		if exec.IsWorkerError(err) {
+			b.saveFailureReason(logger, atc.FailureReasonWorkerLost)
+		} else {
+			b.saveFailureReason(logger, b.failures.Reason())
+		}
+
 		b.saveStatus(logger, atc.StatusErrored)
 		logger.Info("errored", lager.Data{"error": err.Error()})
 
+		if exec.IsWorkerError(err) {
+			b.retryAfterWorkerError(logger)
+		}
+
 	} else if succeeded {
 		b.saveStatus(logger, atc.StatusSucceeded)
 		logger.Info("succeeded")
 
 	} else {
+		b.saveFailureReason(logger, b.failures.Reason())
 		b.saveStatus(logger, atc.StatusFailed)
 		logger.Info("failed")
 	}
 }
 
+// saveFailureReason records why the build didn't succeed, as told by its
+// steps. Aborted builds are given theirs when they finish.
+func (b *engineBuild) saveFailureReason(logger lager.Logger, reason atc.FailureReason) {
+	if reason == "" {
+		return
+	}
+
+	if err := b.build.SetFailureReason(reason); err != nil {
+		logger.Error("failed-to-save-failure-reason", err)
+	}
+}
+
+// saveStartLatency records how long the build took to start its first task
+// process, and what it spent that time on.
+func (b *engineBuild) saveStartLatency(logger lager.Logger, phases map[atc.StartLatencyPhase]time.Duration) {
+	latency := atc.BuildStartLatency{
+		Scheduling:        b.build.StartTime().Sub(b.build.CreateTime()).Milliseconds(),
+		InputFetch:        phases[atc.StartLatencyInputFetch].Milliseconds(),
+		ImageFetch:        phases[atc.StartLatencyImageFetch].Milliseconds(),
+		ContainerCreation: phases[atc.StartLatencyContainerCreation].Milliseconds(),
+		VolumeStreaming:   phases[atc.StartLatencyVolumeStreaming].Milliseconds(),
+		Total:             time.Since(b.build.CreateTime()).Milliseconds(),
+	}
+
+	// a build which was resumed after the ATC restarted has already started
+	// its first task
+	saved, err := b.build.SetStartLatency(latency)
+	if err != nil {
+		logger.Error("failed-to-save-start-latency", err)
+		return
+	}
+
+	if saved {
+		metric.BuildStartLatency{
+			Build:   b.build,
+			Latency: latency,
+		}.Emit(logger)
+	}
+}
+
+// retryAfterWorkerError reruns a job's build which errored because of its
+// workers, as many times as the job allows.
+func (b *engineBuild) retryAfterWorkerError(logger lager.Logger) {
+	if b.build.JobID() == 0 {
+		return
+	}
+
+	job, found, err := b.build.Job()
+	if err != nil {
+		logger.Error("failed-to-get-job", err)
+		return
+	}
+
+	if !found {
+		return
+	}
+
+	config, err := job.Config()
+	if err != nil {
+		logger.Error("failed-to-get-job-config", err)
+		return
+	}
+
+	if config.RetryOnWorkerError == 0 {
+		return
+	}
+
+	retryBuild, retried, err := job.RetryBuild(b.build, config.RetryOnWorkerError)
+	if err != nil {
+		logger.Error("failed-to-retry-build", err)
+		return
+	}
+
+	if !retried {
+		logger.Info("out-of-worker-error-retries")
+		return
+	}
+
+	logger.Info("retrying-build", lager.Data{"retry-build-id": retryBuild.ID()})
+}
+
 func (b *engineBuild) saveStatus(logger lager.Logger, status atc.BuildStatus) {
 	if err := b.build.Finish(db.BuildStatus(status)); err != nil {
 		logger.Error("failed-to-finish-build", err)
@@ -275,6 +491,8 @@ func (b *engineBuild) trackStarted(logger lager.Logger) {
 		metric.BuildStarted{
 			Build: b.build,
 		}.Emit(logger)
+
+		b.buildNotifier.BuildStarted(logger, b.build)
 	}
 }
 
@@ -295,6 +513,8 @@ func (b *engineBuild) trackFinished(logger lager.Logger) {
 			metric.BuildFinished{
 				Build: b.build,
 			}.Emit(logger)
+
+			b.buildNotifier.BuildFinished(logger, b.build)
 		}
 	}
 }
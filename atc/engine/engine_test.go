@@ -21,6 +21,9 @@ import (
 	"github.com/concourse/concourse/atc/event"
 	"github.com/concourse/concourse/atc/exec"
 	"github.com/concourse/concourse/atc/exec/execfakes"
+	"github.com/concourse/concourse/atc/runtime"
+	"github.com/concourse/concourse/atc/worker/gardenruntime/transport"
+	"github.com/concourse/concourse/tracing"
 	"github.com/concourse/concourse/vars"
 
 	. "github.com/onsi/ginkgo"
@@ -34,6 +37,8 @@ var _ = Describe("Engine", func() {
 
 		fakeGlobalCreds   *credsfakes.FakeSecrets
 		fakeVarSourcePool *credsfakes.FakeVarSourcePool
+
+		fakeBuildNotifier *enginefakes.FakeBuildNotifier
 	)
 
 	BeforeEach(func() {
@@ -44,6 +49,8 @@ var _ = Describe("Engine", func() {
 
 		fakeGlobalCreds = new(credsfakes.FakeSecrets)
 		fakeVarSourcePool = new(credsfakes.FakeVarSourcePool)
+
+		fakeBuildNotifier = new(enginefakes.FakeBuildNotifier)
 	})
 
 	Describe("NewBuild", func() {
@@ -53,7 +60,7 @@ var _ = Describe("Engine", func() {
 		)
 
 		BeforeEach(func() {
-			engine = NewEngine(fakeStepperFactory, fakeGlobalCreds, fakeVarSourcePool)
+			engine = NewEngine(fakeStepperFactory, fakeGlobalCreds, fakeVarSourcePool, fakeBuildNotifier, EventFlowControl{})
 		})
 
 		JustBeforeEach(func() {
@@ -83,6 +90,8 @@ var _ = Describe("Engine", func() {
 				fakeStepperFactory,
 				fakeGlobalCreds,
 				fakeVarSourcePool,
+				fakeBuildNotifier,
+				EventFlowControl{},
 				release,
 				trackedStates,
 				waitGroup,
@@ -236,6 +245,47 @@ var _ = Describe("Engine", func() {
 									})
 								})
 
+								Context("when a step is aborted", func() {
+									var fakeStepNotifier *dbfakes.FakeNotifier
+
+									BeforeEach(func() {
+										stepAborts := make(chan struct{})
+										running := make(chan struct{})
+
+										fakeStepNotifier = new(dbfakes.FakeNotifier)
+										fakeStepNotifier.NotifyReturns(stepAborts)
+										fakeBuild.StepAbortNotifierReturns(fakeStepNotifier, nil)
+										fakeBuild.AbortedStepsReturns([]atc.PlanID{"build-plan"}, nil)
+
+										go func() {
+											<-running
+											stepAborts <- struct{}{}
+										}()
+
+										stuckStep := new(execfakes.FakeStep)
+										stuckStep.RunStub = func(ctx context.Context, state exec.RunState) (bool, error) {
+											close(running)
+											<-ctx.Done()
+											return false, ctx.Err()
+										}
+
+										fakeStep.RunStub = func(ctx context.Context, state exec.RunState) (bool, error) {
+											return exec.Abortable("build-plan", stuckStep).Run(ctx, state)
+										}
+									})
+
+									It("fails the build rather than aborting it", func() {
+										waitGroup.Wait()
+										Expect(fakeBuild.FinishCallCount()).To(Equal(1))
+										Expect(fakeBuild.FinishArgsForCall(0)).To(Equal(db.BuildStatusFailed))
+									})
+
+									It("closes the step abort notifier", func() {
+										waitGroup.Wait()
+										Expect(fakeStepNotifier.CloseCallCount()).To(Equal(1))
+									})
+								})
+
 								Context("when the build finishes successfully", func() {
 									BeforeEach(func() {
 										fakeStep.RunReturns(true, nil)
@@ -246,6 +296,39 @@ var _ = Describe("Engine", func() {
 										Expect(fakeBuild.FinishCallCount()).To(Equal(1))
 										Expect(fakeBuild.FinishArgsForCall(0)).To(Equal(db.BuildStatusSucceeded))
 									})
+
+									It("notifies that the build started", func() {
+										waitGroup.Wait()
+										Expect(fakeBuildNotifier.BuildStartedCallCount()).To(Equal(1))
+
+										_, build := fakeBuildNotifier.BuildStartedArgsForCall(0)
+										Expect(build).To(Equal(fakeBuild))
+									})
+
+									Context("when the build is no longer running", func() {
+										BeforeEach(func() {
+											fakeBuild.TracingAttrsReturns(tracing.Attrs{})
+											fakeBuild.FinishCalls(func(db.BuildStatus) error {
+												fakeBuild.IsRunningReturns(false)
+												return nil
+											})
+										})
+
+										It("notifies that the build finished", func() {
+											waitGroup.Wait()
+											Expect(fakeBuildNotifier.BuildFinishedCallCount()).To(Equal(1))
+
+											_, build := fakeBuildNotifier.BuildFinishedArgsForCall(0)
+											Expect(build).To(Equal(fakeBuild))
+										})
+									})
+
+									Context("when the build is still running", func() {
+										It("does not notify", func() {
+											waitGroup.Wait()
+											Expect(fakeBuildNotifier.BuildFinishedCallCount()).To(BeZero())
+										})
+									})
 								})
 
 								Context("when the build finishes woefully", func() {
@@ -258,6 +341,51 @@ var _ = Describe("Engine", func() {
 										Expect(fakeBuild.FinishCallCount()).To(Equal(1))
 										Expect(fakeBuild.FinishArgsForCall(0)).To(Equal(db.BuildStatusFailed))
 									})
+
+									It("does not record a failure reason the steps didn't give", func() {
+										waitGroup.Wait()
+										Expect(fakeBuild.SetFailureReasonCallCount()).To(BeZero())
+									})
+
+									Context("when a step records why it failed", func() {
+										BeforeEach(func() {
+											fakeStep.RunStub = func(ctx context.Context, state exec.RunState) (bool, error) {
+												exec.RecordFailure(ctx, atc.FailureReasonTaskFailed)
+												return false, nil
+											}
+										})
+
+										It("records the failure reason of the step", func() {
+											waitGroup.Wait()
+											Expect(fakeBuild.SetFailureReasonCallCount()).To(Equal(1))
+											Expect(fakeBuild.SetFailureReasonArgsForCall(0)).To(Equal(atc.FailureReasonTaskFailed))
+										})
+									})
+
+									Context("when a task process starts", func() {
+										var createTime time.Time
+
+										BeforeEach(func() {
+											createTime = time.Now().Add(-time.Minute)
+											fakeBuild.CreateTimeReturns(createTime)
+											fakeBuild.StartTimeReturns(createTime.Add(2 * time.Second))
+											fakeBuild.SetStartLatencyReturns(true, nil)
+
+											fakeStep.RunStub = func(ctx context.Context, state exec.RunState) (bool, error) {
+												runtime.RecordTaskStarted(ctx)
+												return false, nil
+											}
+										})
+
+										It("records how long the build took to start it", func() {
+											waitGroup.Wait()
+											Expect(fakeBuild.SetStartLatencyCallCount()).To(Equal(1))
+
+											latency := fakeBuild.SetStartLatencyArgsForCall(0)
+											Expect(latency.Scheduling).To(Equal(int64(2000)))
+											Expect(latency.Total).To(BeNumerically(">=", 60000))
+										})
+									})
 								})
 
 								Context("when the build finishes with error", func() {
@@ -271,6 +399,69 @@ var _ = Describe("Engine", func() {
 											Expect(fakeBuild.FinishCallCount()).To(Equal(1))
 											Expect(fakeBuild.FinishArgsForCall(0)).To(Equal(db.BuildStatusErrored))
 										})
+
+										It("does not retry the build", func() {
+											waitGroup.Wait()
+											Expect(fakeBuild.JobCallCount()).To(BeZero())
+										})
+									})
+
+									Context("when the error is due to the workers", func() {
+										var fakeJob *dbfakes.FakeJob
+
+										BeforeEach(func() {
+											fakeStep.RunReturns(false, transport.WorkerMissingError{WorkerName: "some-worker"})
+
+											fakeJob = new(dbfakes.FakeJob)
+											fakeJob.ConfigReturns(atc.JobConfig{RetryOnWorkerError: 2}, nil)
+											fakeJob.RetryBuildReturns(new(dbfakes.FakeBuild), true, nil)
+
+											fakeBuild.JobIDReturns(42)
+											fakeBuild.JobReturns(fakeJob, true, nil)
+										})
+
+										It("finishes the build", func() {
+											waitGroup.Wait()
+											Expect(fakeBuild.FinishCallCount()).To(Equal(1))
+											Expect(fakeBuild.FinishArgsForCall(0)).To(Equal(db.BuildStatusErrored))
+										})
+
+										It("records that the build lost its worker", func() {
+											waitGroup.Wait()
+											Expect(fakeBuild.SetFailureReasonCallCount()).To(Equal(1))
+											Expect(fakeBuild.SetFailureReasonArgsForCall(0)).To(Equal(atc.FailureReasonWorkerLost))
+										})
+
+										It("retries the build as many times as the job allows", func() {
+											waitGroup.Wait()
+											Expect(fakeJob.RetryBuildCallCount()).To(Equal(1))
+
+											retriedBuild, maxRetries := fakeJob.RetryBuildArgsForCall(0)
+											Expect(retriedBuild).To(Equal(fakeBuild))
+											Expect(maxRetries).To(Equal(2))
+										})
+
+										Context("when the job does not retry its builds", func() {
+											BeforeEach(func() {
+												fakeJob.ConfigReturns(atc.JobConfig{}, nil)
+											})
+
+											It("does not retry the build", func() {
+												waitGroup.Wait()
+												Expect(fakeJob.RetryBuildCallCount()).To(BeZero())
+											})
+										})
+
+										Context("when the build is not a job build", func() {
+											BeforeEach(func() {
+												fakeBuild.JobIDReturns(0)
+											})
+
+											It("does not retry the build", func() {
+												waitGroup.Wait()
+												Expect(fakeBuild.JobCallCount()).To(BeZero())
+											})
+										})
 									})
 
 									Context("when the error is retryable", func() {
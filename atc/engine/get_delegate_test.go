@@ -57,7 +57,7 @@ var _ = Describe("GetDelegate", func() {
 
 		fakePolicyChecker = new(policyfakes.FakeChecker)
 
-		delegate = engine.NewGetDelegate(fakeBuild, "some-plan-id", state, fakeClock, fakePolicyChecker)
+		delegate = engine.NewGetDelegate(fakeBuild, "some-plan-id", "some-step", state, fakeClock, fakePolicyChecker)
 	})
 
 	Describe("Finished", func() {
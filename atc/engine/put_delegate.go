@@ -17,6 +17,7 @@ import (
 func NewPutDelegate(
 	build db.Build,
 	planID atc.PlanID,
+	stepName string,
 	state exec.RunState,
 	clock clock.Clock,
 	policyChecker policy.Checker,
@@ -27,6 +28,7 @@ func NewPutDelegate(
 		eventOrigin: event.Origin{ID: event.OriginID(planID)},
 		build:       build,
 		clock:       clock,
+		duration:    &stepDuration{build: build, stepName: stepName},
 	}
 }
 
@@ -36,6 +38,7 @@ type putDelegate struct {
 	build       db.Build
 	eventOrigin event.Origin
 	clock       clock.Clock
+	duration    *stepDuration
 }
 
 func (d *putDelegate) Initializing(logger lager.Logger) {
@@ -53,8 +56,9 @@ func (d *putDelegate) Initializing(logger lager.Logger) {
 
 func (d *putDelegate) Starting(logger lager.Logger) {
 	err := d.build.SaveEvent(event.StartPut{
-		Time:   time.Now().Unix(),
-		Origin: d.eventOrigin,
+		Time:              time.Now().Unix(),
+		Origin:            d.eventOrigin,
+		EstimatedDuration: d.duration.start(logger, d.clock.Now()),
 	})
 	if err != nil {
 		logger.Error("failed-to-save-start-put-event", err)
@@ -81,6 +85,8 @@ func (d *putDelegate) Finished(logger lager.Logger, exitStatus exec.ExitStatus,
 		return
 	}
 
+	d.duration.finish(logger, d.clock.Now(), exitStatus == 0)
+
 	logger.Info("finished", lager.Data{"exit-status": exitStatus, "version-info": info})
 }
 
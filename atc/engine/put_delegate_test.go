@@ -53,7 +53,7 @@ var _ = Describe("PutDelegate", func() {
 
 		fakePolicyChecker = new(policyfakes.FakeChecker)
 
-		delegate = engine.NewPutDelegate(fakeBuild, "some-plan-id", state, fakeClock, fakePolicyChecker)
+		delegate = engine.NewPutDelegate(fakeBuild, "some-plan-id", "some-step", state, fakeClock, fakePolicyChecker)
 	})
 
 	Describe("Finished", func() {
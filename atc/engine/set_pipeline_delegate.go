@@ -55,9 +55,11 @@ func (delegate *setPipelineStepDelegate) CheckRunSetPipelinePolicy(atcConfig *at
 	}
 
 	return delegate.checkPolicy(policy.PolicyCheckInput{
-		Action:   policy.ActionRunSetPipeline,
-		Team:     delegate.build.TeamName(),
-		Pipeline: delegate.build.PipelineName(),
-		Data:     atcConfig,
+		Action:       policy.ActionRunSetPipeline,
+		Team:         delegate.build.TeamName(),
+		Pipeline:     delegate.build.PipelineName(),
+		InstanceVars: delegate.build.PipelineRef().InstanceVars,
+		Job:          delegate.build.JobName(),
+		Data:         atcConfig,
 	})
 }
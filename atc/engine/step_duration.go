@@ -0,0 +1,53 @@
+package engine
+
+import (
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/db"
+)
+
+// stepDuration times a step of a build, to tell how long the step is
+// expected to take when it starts and to refine the estimate when it
+// succeeds. Steps without a name aren't timed, as the estimate of the build
+// as a whole goes by the empty name.
+type stepDuration struct {
+	build    db.Build
+	stepName string
+	started  time.Time
+}
+
+// start returns how long the step is expected to take in seconds, or zero if
+// that isn't known.
+func (s *stepDuration) start(logger lager.Logger, now time.Time) int64 {
+	if s.stepName == "" {
+		return 0
+	}
+
+	s.started = now
+
+	duration, found, err := s.build.StepDurationEstimate(s.stepName)
+	if err != nil {
+		logger.Error("failed-to-get-step-duration-estimate", err)
+		return 0
+	}
+
+	if !found {
+		return 0
+	}
+
+	return int64(duration / time.Second)
+}
+
+// finish adds how long the step took to its estimate. Steps which failed are
+// left out, as they may have been cut short.
+func (s *stepDuration) finish(logger lager.Logger, now time.Time, succeeded bool) {
+	if !succeeded || s.started.IsZero() {
+		return
+	}
+
+	err := s.build.SaveStepDuration(s.stepName, now.Sub(s.started))
+	if err != nil {
+		logger.Error("failed-to-save-step-duration", err)
+	}
+}
@@ -22,6 +22,7 @@ type coreStepFactory struct {
 	buildFactory          db.BuildFactory
 	resourceCacheFactory  db.ResourceCacheFactory
 	resourceConfigFactory db.ResourceConfigFactory
+	putQueue              db.PutQueue
 	defaultLimits         atc.ContainerLimits
 	strategy              worker.PlacementStrategy
 	noInputStrategy       worker.PlacementStrategy
@@ -29,6 +30,12 @@ type coreStepFactory struct {
 	defaultGetTimeout     time.Duration
 	defaultPutTimeout     time.Duration
 	defaultTaskTimeout    time.Duration
+
+	checkContainerPoolSize int
+	checkSlots             db.CheckSlots
+	nativeResources        resource.NativeResources
+
+	warmPools db.WarmPoolRepository
 }
 
 func NewCoreStepFactory(
@@ -39,6 +46,7 @@ func NewCoreStepFactory(
 	buildFactory db.BuildFactory,
 	resourceCacheFactory db.ResourceCacheFactory,
 	resourceConfigFactory db.ResourceConfigFactory,
+	putQueue db.PutQueue,
 	defaultLimits atc.ContainerLimits,
 	strategy worker.PlacementStrategy,
 	noInputStrategy worker.PlacementStrategy,
@@ -46,6 +54,10 @@ func NewCoreStepFactory(
 	defaultGetTimeout time.Duration,
 	defaultPutTimeout time.Duration,
 	defaultTaskTimeout time.Duration,
+	checkContainerPoolSize int,
+	checkSlots db.CheckSlots,
+	nativeResources resource.NativeResources,
+	warmPools db.WarmPoolRepository,
 ) CoreStepFactory {
 	return &coreStepFactory{
 		pool:                  pool,
@@ -55,6 +67,7 @@ func NewCoreStepFactory(
 		buildFactory:          buildFactory,
 		resourceCacheFactory:  resourceCacheFactory,
 		resourceConfigFactory: resourceConfigFactory,
+		putQueue:              putQueue,
 		defaultLimits:         defaultLimits,
 		strategy:              strategy,
 		noInputStrategy:       noInputStrategy,
@@ -62,6 +75,12 @@ func NewCoreStepFactory(
 		defaultGetTimeout:     defaultGetTimeout,
 		defaultPutTimeout:     defaultPutTimeout,
 		defaultTaskTimeout:    defaultTaskTimeout,
+
+		checkContainerPoolSize: checkContainerPoolSize,
+		checkSlots:             checkSlots,
+		nativeResources:        nativeResources,
+
+		warmPools: warmPools,
 	}
 }
 
@@ -109,6 +128,7 @@ func (factory *coreStepFactory) PutStep(
 		factory.strategy,
 		factory.pool,
 		delegateFactory,
+		factory.putQueue,
 		factory.defaultPutTimeout,
 	)
 
@@ -137,6 +157,9 @@ func (factory *coreStepFactory) CheckStep(
 		factory.pool,
 		delegateFactory,
 		factory.defaultCheckTimeout,
+		factory.checkContainerPoolSize,
+		factory.checkSlots,
+		factory.nativeResources,
 	)
 
 	checkStep = exec.LogError(checkStep, delegateFactory)
@@ -185,6 +208,7 @@ func (factory *coreStepFactory) TaskStep(
 		factory.strategy,
 		factory.pool,
 		factory.streamer,
+		factory.warmPools,
 		delegateFactory,
 		factory.defaultTaskTimeout,
 	)
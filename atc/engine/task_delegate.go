@@ -18,42 +18,73 @@ import (
 func NewTaskDelegate(
 	build db.Build,
 	planID atc.PlanID,
+	stepName string,
 	state exec.RunState,
 	clock clock.Clock,
 	policyChecker policy.Checker,
 	dbWorkerFactory db.WorkerFactory,
 	lockFactory lock.LockFactory,
+	injectedEnv db.InjectedEnvRepository,
 ) exec.TaskDelegate {
 	return &taskDelegate{
-		BuildStepDelegate: NewBuildStepDelegate(build, planID, state, clock, policyChecker),
+		buildStepDelegate: NewBuildStepDelegate(build, planID, state, clock, policyChecker),
 
 		eventOrigin: event.Origin{ID: event.OriginID(planID)},
 		planID:      planID,
 		build:       build,
 		clock:       clock,
+		duration:    &stepDuration{build: build, stepName: stepName},
 
 		dbWorkerFactory: dbWorkerFactory,
 		lockFactory:     lockFactory,
+		injectedEnv:     injectedEnv,
 	}
 }
 
 type taskDelegate struct {
-	exec.BuildStepDelegate
+	*buildStepDelegate
 
 	planID      atc.PlanID
 	config      atc.TaskConfig
 	build       db.Build
 	eventOrigin event.Origin
 	clock       clock.Clock
+	duration    *stepDuration
 
 	dbWorkerFactory db.WorkerFactory
 	lockFactory     lock.LockFactory
+	injectedEnv     db.InjectedEnvRepository
+}
+
+// InjectedEnv returns the env configured by operators for every task run by
+// the build's team, with the team's values overriding the cluster's.
+func (d *taskDelegate) InjectedEnv() (atc.TaskEnv, error) {
+	return d.injectedEnv.TaskEnv(d.build.TeamID())
 }
 
 func (d *taskDelegate) SetTaskConfig(config atc.TaskConfig) {
 	d.config = config
 }
 
+func (d *taskDelegate) CheckRunPrivilegedTaskPolicy(name string, config atc.TaskConfig) error {
+	if !d.policyChecker.ShouldCheckAction(policy.ActionRunPrivilegedTask) {
+		return nil
+	}
+
+	return d.checkPolicy(policy.PolicyCheckInput{
+		Action:       policy.ActionRunPrivilegedTask,
+		Team:         d.build.TeamName(),
+		Pipeline:     d.build.PipelineName(),
+		InstanceVars: d.build.PipelineRef().InstanceVars,
+		Job:          d.build.JobName(),
+		Data: map[string]interface{}{
+			"task":     name,
+			"platform": config.Platform,
+			"run_path": config.Run.Path,
+		},
+	})
+}
+
 func (d *taskDelegate) Initializing(logger lager.Logger) {
 	err := d.build.SaveEvent(event.InitializeTask{
 		Origin:     d.eventOrigin,
@@ -70,9 +101,10 @@ func (d *taskDelegate) Initializing(logger lager.Logger) {
 
 func (d *taskDelegate) Starting(logger lager.Logger) {
 	err := d.build.SaveEvent(event.StartTask{
-		Origin:     d.eventOrigin,
-		Time:       d.clock.Now().Unix(),
-		TaskConfig: event.ShadowTaskConfig(d.config),
+		Origin:            d.eventOrigin,
+		Time:              d.clock.Now().Unix(),
+		TaskConfig:        event.ShadowTaskConfig(d.config),
+		EstimatedDuration: d.duration.start(logger, d.clock.Now()),
 	})
 	if err != nil {
 		logger.Error("failed-to-save-initialize-task-event", err)
@@ -100,6 +132,8 @@ func (d *taskDelegate) Finished(
 		return
 	}
 
+	d.duration.finish(logger, d.clock.Now(), exitStatus == 0)
+
 	logger.Info("finished", lager.Data{"exit-status": exitStatus})
 }
 
@@ -139,7 +173,7 @@ func (d *taskDelegate) FetchImage(
 		return runtime.ImageSpec{}, err
 	}
 
-	imageSpec, _, err := d.BuildStepDelegate.FetchImage(ctx, getPlan, checkPlan, privileged)
+	imageSpec, _, err := d.buildStepDelegate.FetchImage(ctx, getPlan, checkPlan, privileged)
 	if err != nil {
 		return runtime.ImageSpec{}, err
 	}
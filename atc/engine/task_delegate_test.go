@@ -3,6 +3,7 @@ package engine
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"time"
 
 	. "github.com/onsi/ginkgo"
@@ -16,6 +17,7 @@ import (
 	"github.com/concourse/concourse/atc/event"
 	"github.com/concourse/concourse/atc/exec"
 	"github.com/concourse/concourse/atc/exec/execfakes"
+	"github.com/concourse/concourse/atc/policy"
 	"github.com/concourse/concourse/atc/policy/policyfakes"
 	"github.com/concourse/concourse/atc/runtime"
 	"github.com/concourse/concourse/atc/runtime/runtimetest"
@@ -35,6 +37,7 @@ var _ = Describe("TaskDelegate", func() {
 		fakePolicyChecker *policyfakes.FakeChecker
 		fakeWorkerFactory *dbfakes.FakeWorkerFactory
 		fakeLockFactory   *lockfakes.FakeLockFactory
+		fakeInjectedEnv   *dbfakes.FakeInjectedEnvRepository
 
 		state exec.RunState
 
@@ -60,8 +63,9 @@ var _ = Describe("TaskDelegate", func() {
 		fakePolicyChecker = new(policyfakes.FakeChecker)
 		fakeWorkerFactory = new(dbfakes.FakeWorkerFactory)
 		fakeLockFactory = new(lockfakes.FakeLockFactory)
+		fakeInjectedEnv = new(dbfakes.FakeInjectedEnvRepository)
 
-		delegate = NewTaskDelegate(fakeBuild, planID, state, fakeClock, fakePolicyChecker, fakeWorkerFactory, fakeLockFactory).(*taskDelegate)
+		delegate = NewTaskDelegate(fakeBuild, planID, "some-step", state, fakeClock, fakePolicyChecker, fakeWorkerFactory, fakeLockFactory, fakeInjectedEnv).(*taskDelegate)
 
 		delegate.SetTaskConfig(atc.TaskConfig{
 			Platform: "some-platform",
@@ -132,6 +136,19 @@ var _ = Describe("TaskDelegate", func() {
 				}
 			}`))
 		})
+
+		Context("when the step has a duration estimate", func() {
+			BeforeEach(func() {
+				fakeBuild.StepDurationEstimateReturns(90*time.Second, true, nil)
+			})
+
+			It("says how long the step is expected to take", func() {
+				Expect(fakeBuild.StepDurationEstimateArgsForCall(0)).To(Equal("some-step"))
+
+				startTask := fakeBuild.SaveEventArgsForCall(0).(event.StartTask)
+				Expect(startTask.EstimatedDuration).To(Equal(int64(90)))
+			})
+		})
 	})
 
 	Describe("Finished", func() {
@@ -144,6 +161,139 @@ var _ = Describe("TaskDelegate", func() {
 			event := fakeBuild.SaveEventArgsForCall(0)
 			Expect(event.EventType()).To(Equal(atc.EventType("finish-task")))
 		})
+
+		It("doesn't time a step which never started", func() {
+			Expect(fakeBuild.SaveStepDurationCallCount()).To(BeZero())
+		})
+
+		Context("when the step started", func() {
+			BeforeEach(func() {
+				delegate.Starting(logger)
+				fakeClock.Increment(time.Minute)
+			})
+
+			Context("when it succeeded", func() {
+				BeforeEach(func() {
+					exitStatus = 0
+				})
+
+				It("saves how long it took", func() {
+					Expect(fakeBuild.SaveStepDurationCallCount()).To(Equal(1))
+
+					stepName, duration := fakeBuild.SaveStepDurationArgsForCall(0)
+					Expect(stepName).To(Equal("some-step"))
+					Expect(duration).To(Equal(time.Minute))
+				})
+			})
+
+			Context("when it failed", func() {
+				BeforeEach(func() {
+					exitStatus = 1
+				})
+
+				It("doesn't save how long it took", func() {
+					Expect(fakeBuild.SaveStepDurationCallCount()).To(BeZero())
+				})
+			})
+		})
+	})
+
+	Describe("InjectedEnv", func() {
+		BeforeEach(func() {
+			fakeBuild.TeamIDReturns(42)
+			fakeInjectedEnv.TaskEnvReturns(atc.TaskEnv{"HTTP_PROXY": "http://proxy:3128"}, nil)
+		})
+
+		It("returns the env injected into the build's team's tasks", func() {
+			env, err := delegate.InjectedEnv()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(env).To(Equal(atc.TaskEnv{"HTTP_PROXY": "http://proxy:3128"}))
+			Expect(fakeInjectedEnv.TaskEnvArgsForCall(0)).To(Equal(42))
+		})
+
+		Context("when getting the env fails", func() {
+			BeforeEach(func() {
+				fakeInjectedEnv.TaskEnvReturns(nil, errors.New("nope"))
+			})
+
+			It("returns the error", func() {
+				_, err := delegate.InjectedEnv()
+				Expect(err).To(MatchError("nope"))
+			})
+		})
+	})
+
+	Describe("CheckRunPrivilegedTaskPolicy", func() {
+		var checkErr error
+
+		BeforeEach(func() {
+			fakeBuild.TeamNameReturns("some-team")
+			fakeBuild.PipelineNameReturns("some-pipeline")
+			fakeBuild.PipelineRefReturns(atc.PipelineRef{
+				Name:         "some-pipeline",
+				InstanceVars: atc.InstanceVars{"env": "prod"},
+			})
+			fakeBuild.JobNameReturns("some-job")
+		})
+
+		JustBeforeEach(func() {
+			checkErr = delegate.CheckRunPrivilegedTaskPolicy("some-task", atc.TaskConfig{
+				Platform: "linux",
+				Run:      atc.TaskRunConfig{Path: "some-path"},
+			})
+		})
+
+		Context("when the action is not checked", func() {
+			BeforeEach(func() {
+				fakePolicyChecker.ShouldCheckActionReturns(false)
+			})
+
+			It("does not check the policy", func() {
+				Expect(checkErr).ToNot(HaveOccurred())
+				Expect(fakePolicyChecker.CheckCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when the action is checked", func() {
+			var fakeResult *policyfakes.FakePolicyCheckResult
+
+			BeforeEach(func() {
+				fakePolicyChecker.ShouldCheckActionReturns(true)
+
+				fakeResult = new(policyfakes.FakePolicyCheckResult)
+				fakeResult.AllowedReturns(true)
+				fakePolicyChecker.CheckReturns(fakeResult, nil)
+			})
+
+			It("checks the task along with where it runs", func() {
+				Expect(checkErr).ToNot(HaveOccurred())
+				Expect(fakePolicyChecker.ShouldCheckActionArgsForCall(0)).To(Equal(policy.ActionRunPrivilegedTask))
+				Expect(fakePolicyChecker.CheckArgsForCall(0)).To(Equal(policy.PolicyCheckInput{
+					Action:       policy.ActionRunPrivilegedTask,
+					Team:         "some-team",
+					Pipeline:     "some-pipeline",
+					InstanceVars: atc.InstanceVars{"env": "prod"},
+					Job:          "some-job",
+					Data: map[string]interface{}{
+						"task":     "some-task",
+						"platform": "linux",
+						"run_path": "some-path",
+					},
+				}))
+			})
+
+			Context("when the check blocks the task", func() {
+				BeforeEach(func() {
+					fakeResult.AllowedReturns(false)
+					fakeResult.ShouldBlockReturns(true)
+					fakeResult.MessagesReturns([]string{"no privileged tasks in prod"})
+				})
+
+				It("fails", func() {
+					Expect(checkErr).To(MatchError(ContainSubstring("no privileged tasks in prod")))
+				})
+			})
+		})
 	})
 
 	Describe("FetchImage", func() {
@@ -189,7 +339,7 @@ var _ = Describe("TaskDelegate", func() {
 			}
 
 			runState := exec.NewRunState(stepper, nil, false)
-			delegate = NewTaskDelegate(fakeBuild, planID, runState, fakeClock, fakePolicyChecker, fakeWorkerFactory, fakeLockFactory)
+			delegate = NewTaskDelegate(fakeBuild, planID, "some-step", runState, fakeClock, fakePolicyChecker, fakeWorkerFactory, fakeLockFactory, fakeInjectedEnv)
 
 			imageResource = atc.ImageResource{
 				Type:   "docker",
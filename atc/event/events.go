@@ -79,18 +79,26 @@ type StartTask struct {
 	Time       int64      `json:"time"`
 	Origin     Origin     `json:"origin"`
 	TaskConfig TaskConfig `json:"config"`
+
+	// EstimatedDuration is how long the step is expected to take in
+	// seconds, going by the previous builds of its job, if known.
+	EstimatedDuration int64 `json:"estimated_duration,omitempty"`
 }
 
 func (StartTask) EventType() atc.EventType  { return EventTypeStartTask }
-func (StartTask) Version() atc.EventVersion { return "5.0" }
+func (StartTask) Version() atc.EventVersion { return "5.1" }
 
 type Status struct {
 	Status atc.BuildStatus `json:"status"`
 	Time   int64           `json:"time"`
+
+	// EstimatedDuration is set when a build of a job starts, to how long it
+	// is expected to take in seconds, if known.
+	EstimatedDuration int64 `json:"estimated_duration,omitempty"`
 }
 
 func (Status) EventType() atc.EventType  { return EventTypeStatus }
-func (Status) Version() atc.EventVersion { return "1.0" }
+func (Status) Version() atc.EventVersion { return "1.1" }
 
 type WaitingForWorker struct {
 	Time   int64  `json:"time"`
@@ -153,6 +161,7 @@ func (id OriginID) String() string {
 type OriginSource string
 
 const (
+	OriginSourceStdin  OriginSource = "stdin"
 	OriginSourceStdout OriginSource = "stdout"
 	OriginSourceStderr OriginSource = "stderr"
 )
@@ -177,10 +186,12 @@ func (InitializeGet) Version() atc.EventVersion { return "2.0" }
 type StartGet struct {
 	Origin Origin `json:"origin"`
 	Time   int64  `json:"time,omitempty"`
+
+	EstimatedDuration int64 `json:"estimated_duration,omitempty"`
 }
 
 func (StartGet) EventType() atc.EventType  { return EventTypeStartGet }
-func (StartGet) Version() atc.EventVersion { return "1.0" }
+func (StartGet) Version() atc.EventVersion { return "1.1" }
 
 type FinishGet struct {
 	Origin          Origin              `json:"origin"`
@@ -204,10 +215,12 @@ func (InitializePut) Version() atc.EventVersion { return "2.0" }
 type StartPut struct {
 	Origin Origin `json:"origin"`
 	Time   int64  `json:"time,omitempty"`
+
+	EstimatedDuration int64 `json:"estimated_duration,omitempty"`
 }
 
 func (StartPut) EventType() atc.EventType  { return EventTypeStartPut }
-func (StartPut) Version() atc.EventVersion { return "1.0" }
+func (StartPut) Version() atc.EventVersion { return "1.1" }
 
 type FinishPut struct {
 	Origin          Origin              `json:"origin"`
@@ -279,3 +292,22 @@ type AcrossSubsteps struct {
 
 func (AcrossSubsteps) EventType() atc.EventType  { return EventTypeAcrossSubsteps }
 func (AcrossSubsteps) Version() atc.EventVersion { return "1.0" }
+
+type InterceptLog struct {
+	Time      int64        `json:"time"`
+	User      string       `json:"user"`
+	Container string       `json:"container"`
+	Source    OriginSource `json:"source"`
+	Payload   string       `json:"payload"`
+}
+
+func (InterceptLog) EventType() atc.EventType  { return EventTypeInterceptLog }
+func (InterceptLog) Version() atc.EventVersion { return "1.0" }
+
+type Skipped struct {
+	Origin Origin `json:"origin"`
+	Time   int64  `json:"time"`
+}
+
+func (Skipped) EventType() atc.EventType  { return EventTypeSkipped }
+func (Skipped) Version() atc.EventVersion { return "1.0" }
@@ -55,6 +55,8 @@ func init() {
 	RegisterEvent(ImageCheck{})
 	RegisterEvent(ImageGet{})
 	RegisterEvent(AcrossSubsteps{})
+	RegisterEvent(InterceptLog{})
+	RegisterEvent(Skipped{})
 
 	// deprecated:
 	RegisterEvent(InitializeV10{})
@@ -62,6 +62,9 @@ const (
 	// finished step
 	EventTypeFinish atc.EventType = "finish"
 
+	// step skipped, as its 'when' condition was false
+	EventTypeSkipped atc.EventType = "skipped"
+
 	// error occurred
 	EventTypeError atc.EventType = "error"
 
@@ -73,4 +76,7 @@ const (
 
 	// across step substeps (sent dynamically as of Concourse 7.4)
 	EventTypeAcrossSubsteps atc.EventType = "across-substeps"
+
+	// input to or output from a process run by intercepting a container
+	EventTypeInterceptLog atc.EventType = "intercept-log"
 )
@@ -0,0 +1,227 @@
+// Package eventv2 defines version 2 of the schema of build events, a typed
+// and documented alternative to the envelopes the build event stream has
+// always emitted, whose payloads change shape whenever an event's version is
+// bumped.
+//
+// The schema comes with compatibility guarantees: within a major version,
+// events only ever gain new types and new optional fields. Fields are never
+// removed, renamed, or given a different meaning. Consumers must therefore
+// ignore the types and fields they don't know. A change which can't be made
+// within those rules is a new major version, served alongside this one.
+//
+// Legacy events are translated as they are streamed. Events which have no
+// typed counterpart, such as those saved by very old versions of Concourse,
+// are passed through as they are, with the 'legacy' type.
+package eventv2
+
+import (
+	"encoding/json"
+
+	"github.com/concourse/concourse/atc"
+)
+
+// SchemaVersion is the version of the schema defined by this package. Its
+// minor version is bumped whenever a type or field is added.
+const SchemaVersion = "2.0"
+
+// SchemaMajorVersion is the major version of the schema, by which clients
+// ask for it.
+const SchemaMajorVersion = "2"
+
+type Type string
+
+const (
+	// a step or the build logged something
+	TypeLog Type = "log"
+
+	// the status of the build changed
+	TypeStatus Type = "status"
+
+	// an error occurred, either in a step or in the build
+	TypeError Type = "error"
+
+	// a step is initializing, e.g. fetching its image
+	TypeStepInitialized Type = "step-initialized"
+
+	// a step started running
+	TypeStepStarted Type = "step-started"
+
+	// a step finished running
+	TypeStepFinished Type = "step-finished"
+
+	// a step was skipped, as its 'when' condition was false
+	TypeStepSkipped Type = "step-skipped"
+
+	// a set_pipeline step found whether the pipeline changed
+	TypeSetPipelineChanged Type = "set-pipeline-changed"
+
+	// a step is waiting for a worker to run on
+	TypeWaitingForWorker Type = "waiting-for-worker"
+
+	// a step selected the worker to run on
+	TypeSelectedWorker Type = "selected-worker"
+
+	// a step is streaming a volume from another worker
+	TypeStreamingVolume Type = "streaming-volume"
+
+	// a step is waiting for another step to stream a volume to its worker
+	TypeWaitingForStreamedVolume Type = "waiting-for-streamed-volume"
+
+	// a step is checking for the version of its image
+	TypeImageCheck Type = "image-check"
+
+	// a step is fetching its image
+	TypeImageGet Type = "image-get"
+
+	// an across step expanded into its substeps
+	TypeAcrossSubsteps Type = "across-substeps"
+
+	// a process run by intercepting a container logged something
+	TypeInterceptLog Type = "intercept-log"
+
+	// a legacy event with no typed counterpart
+	TypeLegacy Type = "legacy"
+)
+
+// StepKind is the kind of step an event is about, for the steps whose
+// events used to be specific to them.
+type StepKind string
+
+const (
+	StepKindTask  StepKind = "task"
+	StepKindGet   StepKind = "get"
+	StepKindPut   StepKind = "put"
+	StepKindCheck StepKind = "check"
+)
+
+// Event is a build event. Its type says which of the payload fields is set;
+// events of some types, such as 'step-skipped', have no payload at all.
+type Event struct {
+	Type Type `json:"type"`
+
+	// Time is when the event happened, in unix seconds, if known.
+	Time int64 `json:"time,omitempty"`
+
+	// Step is the step the event is about, and is omitted for events about
+	// the build as a whole.
+	Step *Step `json:"step,omitempty"`
+
+	Log                *Log                `json:"log,omitempty"`
+	Status             *Status             `json:"status,omitempty"`
+	Error              *Error              `json:"error,omitempty"`
+	Initialized        *StepInitialized    `json:"initialized,omitempty"`
+	Started            *StepStarted        `json:"started,omitempty"`
+	Finished           *StepFinished       `json:"finished,omitempty"`
+	SetPipelineChanged *SetPipelineChanged `json:"set_pipeline_changed,omitempty"`
+	Worker             *Worker             `json:"worker,omitempty"`
+	Volume             *Volume             `json:"volume,omitempty"`
+	Image              *Image              `json:"image,omitempty"`
+	Across             *Across             `json:"across,omitempty"`
+	InterceptLog       *InterceptLog       `json:"intercept_log,omitempty"`
+	Legacy             *Legacy             `json:"legacy,omitempty"`
+}
+
+type Step struct {
+	// ID is the ID of the step's plan.
+	ID string `json:"id"`
+
+	Kind StepKind `json:"kind,omitempty"`
+}
+
+type Log struct {
+	// Stream is where the output came from: 'stdout' or 'stderr', or empty
+	// for the logs of Concourse itself.
+	Stream  string `json:"stream,omitempty"`
+	Payload string `json:"payload"`
+}
+
+type Status struct {
+	Status atc.BuildStatus `json:"status"`
+
+	// EstimatedDuration is how long the build is expected to take in
+	// seconds, if known.
+	EstimatedDuration int64 `json:"estimated_duration,omitempty"`
+}
+
+type Error struct {
+	Message string `json:"message"`
+}
+
+type StepInitialized struct {
+	// Task is the config of a task step.
+	Task *TaskConfig `json:"task,omitempty"`
+
+	// Check is the name of what a check step is checking.
+	Check string `json:"check,omitempty"`
+}
+
+type StepStarted struct {
+	// Task is the config of a task step.
+	Task *TaskConfig `json:"task,omitempty"`
+
+	// EstimatedDuration is how long the step is expected to take in
+	// seconds, if known.
+	EstimatedDuration int64 `json:"estimated_duration,omitempty"`
+}
+
+type StepFinished struct {
+	Succeeded bool `json:"succeeded"`
+
+	// ExitStatus is the exit status of the process run by a task, get or
+	// put step.
+	ExitStatus *int `json:"exit_status,omitempty"`
+
+	// Version and Metadata are those fetched by a get step or created by a
+	// put step.
+	Version  atc.Version         `json:"version,omitempty"`
+	Metadata []atc.MetadataField `json:"metadata,omitempty"`
+}
+
+type TaskConfig struct {
+	Platform string   `json:"platform,omitempty"`
+	Image    string   `json:"image,omitempty"`
+	Path     string   `json:"path"`
+	Args     []string `json:"args,omitempty"`
+	Dir      string   `json:"dir,omitempty"`
+	Inputs   []string `json:"inputs,omitempty"`
+}
+
+type SetPipelineChanged struct {
+	Changed bool `json:"changed"`
+}
+
+type Worker struct {
+	Name string `json:"name"`
+}
+
+type Volume struct {
+	Handle       string `json:"handle"`
+	SourceWorker string `json:"source_worker,omitempty"`
+	DestWorker   string `json:"dest_worker"`
+}
+
+type Image struct {
+	// Plan is the public plan of the step checking for or fetching the
+	// image.
+	Plan json.RawMessage `json:"plan,omitempty"`
+}
+
+type Across struct {
+	// Substeps are the public plans of the substeps.
+	Substeps []json.RawMessage `json:"substeps"`
+}
+
+type InterceptLog struct {
+	User      string `json:"user"`
+	Container string `json:"container"`
+	Stream    string `json:"stream"`
+	Payload   string `json:"payload"`
+}
+
+// Legacy is an event as it was saved, for the events which have no typed
+// counterpart.
+type Legacy struct {
+	Event   atc.EventType    `json:"event"`
+	Version atc.EventVersion `json:"version"`
+	Data    json.RawMessage  `json:"data,omitempty"`
+}
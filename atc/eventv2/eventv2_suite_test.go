@@ -0,0 +1,13 @@
+package eventv2_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestEventV2(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Event V2 Suite")
+}
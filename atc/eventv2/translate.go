@@ -0,0 +1,360 @@
+package eventv2
+
+import (
+	"encoding/json"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/event"
+)
+
+// Translate translates a legacy event into its typed counterpart. Events
+// which can't be translated, being of unknown types or versions or even
+// malformed, are passed through with the 'legacy' type, so that a consumer
+// never misses an event.
+func Translate(envelope event.Envelope) Event {
+	var data json.RawMessage
+	if envelope.Data != nil {
+		data = *envelope.Data
+	}
+
+	legacy, err := parse(envelope, data)
+	if err != nil {
+		return legacyEvent(envelope, data)
+	}
+
+	translated, ok := translate(legacy)
+	if !ok {
+		return legacyEvent(envelope, data)
+	}
+
+	return translated
+}
+
+// parse parses a legacy event. The generic step events share their type and
+// version with events from long ago, which the parser prefers, so they're
+// parsed here instead.
+func parse(envelope event.Envelope, data json.RawMessage) (atc.Event, error) {
+	switch {
+	case envelope.Event == event.EventTypeInitialize && event.Initialize{}.Version().IsCompatibleWith(envelope.Version):
+		var e event.Initialize
+		err := json.Unmarshal(data, &e)
+		return e, err
+
+	case envelope.Event == event.EventTypeStart && event.Start{}.Version().IsCompatibleWith(envelope.Version):
+		var e event.Start
+		err := json.Unmarshal(data, &e)
+		return e, err
+
+	case envelope.Event == event.EventTypeFinish && event.Finish{}.Version().IsCompatibleWith(envelope.Version):
+		var e event.Finish
+		err := json.Unmarshal(data, &e)
+		return e, err
+	}
+
+	return event.ParseEvent(envelope.Version, envelope.Event, data)
+}
+
+func translate(legacy atc.Event) (Event, bool) {
+	switch e := legacy.(type) {
+	case event.Log:
+		return Event{
+			Type: TypeLog,
+			Time: e.Time,
+			Step: step(e.Origin, ""),
+			Log: &Log{
+				Stream:  string(e.Origin.Source),
+				Payload: e.Payload,
+			},
+		}, true
+
+	case event.Status:
+		return Event{
+			Type: TypeStatus,
+			Time: e.Time,
+			Status: &Status{
+				Status:            e.Status,
+				EstimatedDuration: e.EstimatedDuration,
+			},
+		}, true
+
+	case event.Error:
+		return Event{
+			Type:  TypeError,
+			Time:  e.Time,
+			Step:  step(e.Origin, ""),
+			Error: &Error{Message: e.Message},
+		}, true
+
+	case event.InitializeTask:
+		return Event{
+			Type:        TypeStepInitialized,
+			Time:        e.Time,
+			Step:        step(e.Origin, StepKindTask),
+			Initialized: &StepInitialized{Task: taskConfig(e.TaskConfig)},
+		}, true
+
+	case event.StartTask:
+		return Event{
+			Type: TypeStepStarted,
+			Time: e.Time,
+			Step: step(e.Origin, StepKindTask),
+			Started: &StepStarted{
+				Task:              taskConfig(e.TaskConfig),
+				EstimatedDuration: e.EstimatedDuration,
+			},
+		}, true
+
+	case event.FinishTask:
+		return Event{
+			Type:     TypeStepFinished,
+			Time:     e.Time,
+			Step:     step(e.Origin, StepKindTask),
+			Finished: exited(e.ExitStatus),
+		}, true
+
+	case event.InitializeCheck:
+		return Event{
+			Type:        TypeStepInitialized,
+			Time:        e.Time,
+			Step:        step(e.Origin, StepKindCheck),
+			Initialized: &StepInitialized{Check: e.Name},
+		}, true
+
+	case event.InitializeGet:
+		return Event{
+			Type:        TypeStepInitialized,
+			Time:        e.Time,
+			Step:        step(e.Origin, StepKindGet),
+			Initialized: &StepInitialized{},
+		}, true
+
+	case event.StartGet:
+		return Event{
+			Type:    TypeStepStarted,
+			Time:    e.Time,
+			Step:    step(e.Origin, StepKindGet),
+			Started: &StepStarted{EstimatedDuration: e.EstimatedDuration},
+		}, true
+
+	case event.FinishGet:
+		finished := exited(e.ExitStatus)
+		finished.Version = e.FetchedVersion
+		finished.Metadata = e.FetchedMetadata
+
+		return Event{
+			Type:     TypeStepFinished,
+			Time:     e.Time,
+			Step:     step(e.Origin, StepKindGet),
+			Finished: finished,
+		}, true
+
+	case event.InitializePut:
+		return Event{
+			Type:        TypeStepInitialized,
+			Time:        e.Time,
+			Step:        step(e.Origin, StepKindPut),
+			Initialized: &StepInitialized{},
+		}, true
+
+	case event.StartPut:
+		return Event{
+			Type:    TypeStepStarted,
+			Time:    e.Time,
+			Step:    step(e.Origin, StepKindPut),
+			Started: &StepStarted{EstimatedDuration: e.EstimatedDuration},
+		}, true
+
+	case event.FinishPut:
+		finished := exited(e.ExitStatus)
+		finished.Version = e.CreatedVersion
+		finished.Metadata = e.CreatedMetadata
+
+		return Event{
+			Type:     TypeStepFinished,
+			Time:     e.Time,
+			Step:     step(e.Origin, StepKindPut),
+			Finished: finished,
+		}, true
+
+	case event.Initialize:
+		return Event{
+			Type:        TypeStepInitialized,
+			Time:        e.Time,
+			Step:        step(e.Origin, ""),
+			Initialized: &StepInitialized{},
+		}, true
+
+	case event.Start:
+		return Event{
+			Type:    TypeStepStarted,
+			Time:    e.Time,
+			Step:    step(e.Origin, ""),
+			Started: &StepStarted{},
+		}, true
+
+	case event.Finish:
+		return Event{
+			Type:     TypeStepFinished,
+			Time:     e.Time,
+			Step:     step(e.Origin, ""),
+			Finished: &StepFinished{Succeeded: e.Succeeded},
+		}, true
+
+	case event.Skipped:
+		return Event{
+			Type: TypeStepSkipped,
+			Time: e.Time,
+			Step: step(e.Origin, ""),
+		}, true
+
+	case event.SetPipelineChanged:
+		return Event{
+			Type:               TypeSetPipelineChanged,
+			Step:               step(e.Origin, ""),
+			SetPipelineChanged: &SetPipelineChanged{Changed: e.Changed},
+		}, true
+
+	case event.WaitingForWorker:
+		return Event{
+			Type: TypeWaitingForWorker,
+			Time: e.Time,
+			Step: step(e.Origin, ""),
+		}, true
+
+	case event.SelectedWorker:
+		return Event{
+			Type:   TypeSelectedWorker,
+			Time:   e.Time,
+			Step:   step(e.Origin, ""),
+			Worker: &Worker{Name: e.WorkerName},
+		}, true
+
+	case event.StreamingVolume:
+		return Event{
+			Type: TypeStreamingVolume,
+			Time: e.Time,
+			Step: step(e.Origin, ""),
+			Volume: &Volume{
+				Handle:       e.Volume,
+				SourceWorker: e.SourceWorker,
+				DestWorker:   e.DestWorker,
+			},
+		}, true
+
+	case event.WaitingForStreamedVolume:
+		return Event{
+			Type: TypeWaitingForStreamedVolume,
+			Time: e.Time,
+			Step: step(e.Origin, ""),
+			Volume: &Volume{
+				Handle:     e.Volume,
+				DestWorker: e.DestWorker,
+			},
+		}, true
+
+	case event.ImageCheck:
+		return Event{
+			Type:  TypeImageCheck,
+			Time:  e.Time,
+			Step:  step(e.Origin, ""),
+			Image: &Image{Plan: raw(e.PublicPlan)},
+		}, true
+
+	case event.ImageGet:
+		return Event{
+			Type:  TypeImageGet,
+			Time:  e.Time,
+			Step:  step(e.Origin, ""),
+			Image: &Image{Plan: raw(e.PublicPlan)},
+		}, true
+
+	case event.AcrossSubsteps:
+		substeps := []json.RawMessage{}
+		for _, substep := range e.Substeps {
+			substeps = append(substeps, raw(substep))
+		}
+
+		return Event{
+			Type:   TypeAcrossSubsteps,
+			Time:   e.Time,
+			Step:   step(e.Origin, ""),
+			Across: &Across{Substeps: substeps},
+		}, true
+
+	case event.InterceptLog:
+		return Event{
+			Type: TypeInterceptLog,
+			Time: e.Time,
+			InterceptLog: &InterceptLog{
+				User:      e.User,
+				Container: e.Container,
+				Stream:    string(e.Source),
+				Payload:   e.Payload,
+			},
+		}, true
+	}
+
+	// older versions of the events above, which are only found in the
+	// events of builds from long ago
+	return Event{}, false
+}
+
+func legacyEvent(envelope event.Envelope, data json.RawMessage) Event {
+	if len(data) > 0 && !json.Valid(data) {
+		// pass malformed data through as a string, which can be encoded
+		data, _ = json.Marshal(string(data))
+	}
+
+	return Event{
+		Type: TypeLegacy,
+		Legacy: &Legacy{
+			Event:   envelope.Event,
+			Version: envelope.Version,
+			Data:    data,
+		},
+	}
+}
+
+// step returns the step an event originated from, or nil when it's about
+// the build as a whole.
+func step(origin event.Origin, kind StepKind) *Step {
+	if origin.ID == "" {
+		return nil
+	}
+
+	return &Step{
+		ID:   origin.ID.String(),
+		Kind: kind,
+	}
+}
+
+func exited(exitStatus int) *StepFinished {
+	return &StepFinished{
+		Succeeded:  exitStatus == 0,
+		ExitStatus: &exitStatus,
+	}
+}
+
+func taskConfig(config event.TaskConfig) *TaskConfig {
+	var inputs []string
+	for _, input := range config.Inputs {
+		inputs = append(inputs, input.Name)
+	}
+
+	return &TaskConfig{
+		Platform: config.Platform,
+		Image:    config.Image,
+		Path:     config.Run.Path,
+		Args:     config.Run.Args,
+		Dir:      config.Run.Dir,
+		Inputs:   inputs,
+	}
+}
+
+func raw(message *json.RawMessage) json.RawMessage {
+	if message == nil {
+		return nil
+	}
+
+	return *message
+}
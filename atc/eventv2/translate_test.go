@@ -0,0 +1,173 @@
+package eventv2_test
+
+import (
+	"encoding/json"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/event"
+	"github.com/concourse/concourse/atc/eventv2"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func envelope(e atc.Event) event.Envelope {
+	payload, err := json.Marshal(e)
+	Expect(err).ToNot(HaveOccurred())
+
+	return event.Envelope{
+		Data:    (*json.RawMessage)(&payload),
+		Event:   e.EventType(),
+		Version: e.Version(),
+	}
+}
+
+var _ = Describe("Translate", func() {
+	It("translates logs, taking the stream from their origin", func() {
+		translated := eventv2.Translate(envelope(event.Log{
+			Time:    42,
+			Origin:  event.Origin{ID: "some-plan", Source: event.OriginSourceStderr},
+			Payload: "oh no\n",
+		}))
+		Expect(translated).To(Equal(eventv2.Event{
+			Type: eventv2.TypeLog,
+			Time: 42,
+			Step: &eventv2.Step{ID: "some-plan"},
+			Log: &eventv2.Log{
+				Stream:  "stderr",
+				Payload: "oh no\n",
+			},
+		}))
+	})
+
+	It("omits the step of events about the build as a whole", func() {
+		translated := eventv2.Translate(envelope(event.Error{
+			Message: "disaster",
+			Time:    42,
+		}))
+		Expect(translated).To(Equal(eventv2.Event{
+			Type:  eventv2.TypeError,
+			Time:  42,
+			Error: &eventv2.Error{Message: "disaster"},
+		}))
+	})
+
+	It("translates the status of the build", func() {
+		translated := eventv2.Translate(envelope(event.Status{
+			Status:            atc.StatusStarted,
+			Time:              42,
+			EstimatedDuration: 60,
+		}))
+		Expect(translated).To(Equal(eventv2.Event{
+			Type: eventv2.TypeStatus,
+			Time: 42,
+			Status: &eventv2.Status{
+				Status:            atc.StatusStarted,
+				EstimatedDuration: 60,
+			},
+		}))
+	})
+
+	It("translates the step specific events into step events of the step's kind", func() {
+		translated := eventv2.Translate(envelope(event.StartTask{
+			Time:   42,
+			Origin: event.Origin{ID: "some-plan"},
+			TaskConfig: event.TaskConfig{
+				Platform: "linux",
+				Run:      event.TaskRunConfig{Path: "make", Args: []string{"test"}},
+				Inputs:   []event.TaskInputConfig{{Name: "source", Path: "src"}},
+			},
+		}))
+		Expect(translated).To(Equal(eventv2.Event{
+			Type: eventv2.TypeStepStarted,
+			Time: 42,
+			Step: &eventv2.Step{ID: "some-plan", Kind: eventv2.StepKindTask},
+			Started: &eventv2.StepStarted{
+				Task: &eventv2.TaskConfig{
+					Platform: "linux",
+					Path:     "make",
+					Args:     []string{"test"},
+					Inputs:   []string{"source"},
+				},
+			},
+		}))
+	})
+
+	It("finishes steps with a non-zero exit status as failed", func() {
+		translated := eventv2.Translate(envelope(event.FinishGet{
+			Time:           42,
+			Origin:         event.Origin{ID: "some-plan"},
+			ExitStatus:     1,
+			FetchedVersion: atc.Version{"ref": "abc"},
+		}))
+
+		exitStatus := 1
+		Expect(translated).To(Equal(eventv2.Event{
+			Type: eventv2.TypeStepFinished,
+			Time: 42,
+			Step: &eventv2.Step{ID: "some-plan", Kind: eventv2.StepKindGet},
+			Finished: &eventv2.StepFinished{
+				Succeeded:  false,
+				ExitStatus: &exitStatus,
+				Version:    atc.Version{"ref": "abc"},
+			},
+		}))
+	})
+
+	It("translates the generic step events without a kind", func() {
+		translated := eventv2.Translate(envelope(event.Finish{
+			Time:      42,
+			Origin:    event.Origin{ID: "some-plan"},
+			Succeeded: true,
+		}))
+		Expect(translated).To(Equal(eventv2.Event{
+			Type:     eventv2.TypeStepFinished,
+			Time:     42,
+			Step:     &eventv2.Step{ID: "some-plan"},
+			Finished: &eventv2.StepFinished{Succeeded: true},
+		}))
+	})
+
+	It("passes events of unknown types through as legacy events", func() {
+		data := json.RawMessage(`{"some":"thing"}`)
+		translated := eventv2.Translate(event.Envelope{
+			Data:    &data,
+			Event:   "some-future-event",
+			Version: "1.0",
+		})
+		Expect(translated).To(Equal(eventv2.Event{
+			Type: eventv2.TypeLegacy,
+			Legacy: &eventv2.Legacy{
+				Event:   "some-future-event",
+				Version: "1.0",
+				Data:    data,
+			},
+		}))
+	})
+
+	It("passes events of deprecated versions through as legacy events", func() {
+		data := json.RawMessage(`{"payload":"hello","origin":{"type":"run","name":"some-task"}}`)
+		translated := eventv2.Translate(event.Envelope{
+			Data:    &data,
+			Event:   event.EventTypeLog,
+			Version: "1.0",
+		})
+		Expect(translated.Type).To(Equal(eventv2.TypeLegacy))
+		Expect(translated.Legacy.Event).To(Equal(event.EventTypeLog))
+		Expect(translated.Legacy.Version).To(Equal(atc.EventVersion("1.0")))
+	})
+
+	It("passes malformed events through as legacy events", func() {
+		data := json.RawMessage(`{"payload":`)
+		translated := eventv2.Translate(event.Envelope{
+			Data:    &data,
+			Event:   event.EventTypeLog,
+			Version: "5.1",
+		})
+
+		Expect(translated.Type).To(Equal(eventv2.TypeLegacy))
+		Expect(translated.Legacy.Data).To(MatchJSON(`"{\"payload\":"`))
+
+		_, err := json.Marshal(translated)
+		Expect(err).ToNot(HaveOccurred())
+	})
+})
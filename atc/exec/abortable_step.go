@@ -0,0 +1,108 @@
+package exec
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/concourse/concourse/atc"
+)
+
+// StepAborter aborts the running steps of a build one at a time.
+type StepAborter struct {
+	lock    sync.Mutex
+	running map[atc.PlanID]context.CancelFunc
+	aborted map[atc.PlanID]bool
+}
+
+func NewStepAborter() *StepAborter {
+	return &StepAborter{
+		running: map[atc.PlanID]context.CancelFunc{},
+		aborted: map[atc.PlanID]bool{},
+	}
+}
+
+type stepAborterKey struct{}
+
+// WithStepAborter makes the abortable steps run with the context abortable
+// through the aborter.
+func WithStepAborter(ctx context.Context, aborter *StepAborter) context.Context {
+	return context.WithValue(ctx, stepAborterKey{}, aborter)
+}
+
+// Abort aborts the step if it's running, returning whether it was.
+func (aborter *StepAborter) Abort(planID atc.PlanID) bool {
+	aborter.lock.Lock()
+	defer aborter.lock.Unlock()
+
+	cancel, running := aborter.running[planID]
+	if !running {
+		return false
+	}
+
+	aborter.aborted[planID] = true
+	cancel()
+
+	return true
+}
+
+func (aborter *StepAborter) started(planID atc.PlanID, cancel context.CancelFunc) {
+	aborter.lock.Lock()
+	defer aborter.lock.Unlock()
+
+	aborter.running[planID] = cancel
+}
+
+func (aborter *StepAborter) finished(planID atc.PlanID) bool {
+	aborter.lock.Lock()
+	defer aborter.lock.Unlock()
+
+	aborted := aborter.aborted[planID]
+
+	delete(aborter.running, planID)
+	delete(aborter.aborted, planID)
+
+	return aborted
+}
+
+// AbortableStep lets a step be aborted on its own through the StepAborter of
+// the context it runs with.
+type AbortableStep struct {
+	planID atc.PlanID
+	step   Step
+}
+
+// Abortable constructs an AbortableStep.
+func Abortable(planID atc.PlanID, step Step) AbortableStep {
+	return AbortableStep{
+		planID: planID,
+		step:   step,
+	}
+}
+
+// Run invokes the nested step.
+//
+// If the nested step is aborted, it is interrupted and the AbortableStep
+// returns false once the nested step exits (ignoring the nested step's
+// error), so that the rest of the plan carries on as if the step had failed.
+// Aborting the whole build is left as is.
+func (step AbortableStep) Run(ctx context.Context, state RunState) (bool, error) {
+	aborter, found := ctx.Value(stepAborterKey{}).(*StepAborter)
+	if !found {
+		return step.step.Run(ctx, state)
+	}
+
+	stepCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	aborter.started(step.planID, cancel)
+
+	ok, err := step.step.Run(stepCtx, state)
+
+	aborted := aborter.finished(step.planID)
+	if aborted && ctx.Err() == nil && errors.Is(err, context.Canceled) {
+		return false, nil
+	}
+
+	return ok, err
+}
@@ -0,0 +1,106 @@
+package exec_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/exec/execfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Abortable Step", func() {
+	var (
+		ctx    context.Context
+		cancel func()
+
+		aborter  *StepAborter
+		fakeStep *execfakes.FakeStep
+		state    *execfakes.FakeRunState
+
+		stepOk  bool
+		stepErr error
+	)
+
+	BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+
+		aborter = NewStepAborter()
+		fakeStep = new(execfakes.FakeStep)
+		state = new(execfakes.FakeRunState)
+	})
+
+	AfterEach(func() {
+		cancel()
+	})
+
+	JustBeforeEach(func() {
+		stepOk, stepErr = Abortable("some-plan-id", fakeStep).Run(WithStepAborter(ctx, aborter), state)
+	})
+
+	Context("when the step runs to the end", func() {
+		BeforeEach(func() {
+			fakeStep.RunReturns(true, nil)
+		})
+
+		It("returns its result", func() {
+			Expect(stepOk).To(BeTrue())
+			Expect(stepErr).ToNot(HaveOccurred())
+		})
+
+		It("can no longer be aborted", func() {
+			Expect(aborter.Abort("some-plan-id")).To(BeFalse())
+		})
+	})
+
+	Context("when the step is aborted while running", func() {
+		var aborted bool
+
+		BeforeEach(func() {
+			fakeStep.RunStub = func(ctx context.Context, state RunState) (bool, error) {
+				aborted = aborter.Abort("some-plan-id")
+				<-ctx.Done()
+				return false, ctx.Err()
+			}
+		})
+
+		It("interrupts the step", func() {
+			Expect(aborted).To(BeTrue())
+		})
+
+		It("fails without an error", func() {
+			Expect(stepOk).To(BeFalse())
+			Expect(stepErr).ToNot(HaveOccurred())
+		})
+	})
+
+	Context("when another step is aborted", func() {
+		BeforeEach(func() {
+			fakeStep.RunStub = func(ctx context.Context, state RunState) (bool, error) {
+				Expect(aborter.Abort(atc.PlanID("some-other-plan-id"))).To(BeFalse())
+				return true, ctx.Err()
+			}
+		})
+
+		It("leaves the step be", func() {
+			Expect(stepOk).To(BeTrue())
+			Expect(stepErr).ToNot(HaveOccurred())
+		})
+	})
+
+	Context("when the whole build is aborted", func() {
+		BeforeEach(func() {
+			fakeStep.RunStub = func(ctx context.Context, state RunState) (bool, error) {
+				cancel()
+				<-ctx.Done()
+				return false, ctx.Err()
+			}
+		})
+
+		It("returns the error", func() {
+			Expect(errors.Is(stepErr, context.Canceled)).To(BeTrue())
+		})
+	})
+})
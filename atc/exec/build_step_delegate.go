@@ -31,6 +31,7 @@ type BuildStepDelegate interface {
 	Starting(lager.Logger)
 	Finished(lager.Logger, bool)
 	Errored(lager.Logger, string)
+	Skipped(lager.Logger, atc.Plan)
 
 	BeforeSelectWorker(lager.Logger) error
 	WaitingForWorker(lager.Logger)
@@ -29,8 +29,14 @@ type CheckStep struct {
 	delegateFactory       CheckDelegateFactory
 	workerPool            Pool
 	defaultCheckTimeout   time.Duration
+
+	checkContainerPoolSize int
+	checkSlots             db.CheckSlots
+	nativeResources        resource.NativeResources
 }
 
+var CheckSlotInterval = 1 * time.Second
+
 //counterfeiter:generate . CheckDelegateFactory
 type CheckDelegateFactory interface {
 	CheckDelegate(state RunState) CheckDelegate
@@ -59,6 +65,9 @@ func NewCheckStep(
 	pool Pool,
 	delegateFactory CheckDelegateFactory,
 	defaultCheckTimeout time.Duration,
+	checkContainerPoolSize int,
+	checkSlots db.CheckSlots,
+	nativeResources resource.NativeResources,
 ) Step {
 	return &CheckStep{
 		planID:                planID,
@@ -70,6 +79,10 @@ func NewCheckStep(
 		strategy:              strategy,
 		delegateFactory:       delegateFactory,
 		defaultCheckTimeout:   defaultCheckTimeout,
+
+		checkContainerPoolSize: checkContainerPoolSize,
+		checkSlots:             checkSlots,
+		nativeResources:        nativeResources,
 	}
 }
 
@@ -158,15 +171,29 @@ func (step *CheckStep) run(ctx context.Context, state RunState, delegate CheckDe
 			}
 		}()
 
+		var resetFrom atc.Version
+		var reset bool
+
 		fromVersion := step.plan.FromVersion
 		if fromVersion == nil {
-			latestVersion, found, err := scope.LatestVersion()
+			resetFrom, reset, err = scope.CheckFrom()
 			if err != nil {
-				return false, fmt.Errorf("get latest version: %w", err)
+				return false, fmt.Errorf("get check from version: %w", err)
 			}
 
-			if found {
-				fromVersion = atc.Version(latestVersion.Version())
+			if reset {
+				if len(resetFrom) > 0 {
+					fromVersion = resetFrom
+				}
+			} else {
+				latestVersion, found, err := scope.LatestVersion()
+				if err != nil {
+					return false, fmt.Errorf("get latest version: %w", err)
+				}
+
+				if found {
+					fromVersion = atc.Version(latestVersion.Version())
+				}
 			}
 		}
 
@@ -186,12 +213,14 @@ func (step *CheckStep) run(ctx context.Context, state RunState, delegate CheckDe
 		versions, processResult, runErr := step.runCheck(ctx, logger, delegate, imageSpec, resourceConfig, source, fromVersion)
 		if runErr != nil || processResult.ExitStatus != 0 {
 			metric.Metrics.ChecksFinishedWithError.Inc()
+			RecordFailure(ctx, atc.FailureReasonResourceCheckFailed)
 
 			if _, err := delegate.UpdateScopeLastCheckEndTime(scope, false); err != nil {
 				return false, fmt.Errorf("update check end time: %w", err)
 			}
 
 			if errors.Is(runErr, context.DeadlineExceeded) {
+				RecordFailure(ctx, atc.FailureReasonTimedOut)
 				delegate.Errored(logger, TimeoutLogMessage)
 				return false, nil
 			}
@@ -211,6 +240,13 @@ func (step *CheckStep) run(ctx context.Context, state RunState, delegate CheckDe
 			return false, fmt.Errorf("save versions: %w", err)
 		}
 
+		if reset {
+			err = scope.ClearCheckFrom(resetFrom)
+			if err != nil {
+				return false, fmt.Errorf("clear check from version: %w", err)
+			}
+		}
+
 		if len(versions) > 0 {
 			state.StoreResult(step.planID, versions[len(versions)-1])
 		}
@@ -244,9 +280,19 @@ func (step *CheckStep) runCheck(
 	source atc.Source,
 	fromVersion atc.Version,
 ) ([]atc.Version, runtime.ProcessResult, error) {
+	if native, found := step.nativeResource(); found {
+		versions, processResult, err := step.runNativeCheck(ctx, logger, delegate, native, source, fromVersion)
+		if !errors.Is(err, resource.ErrUnsupportedSource) {
+			return versions, processResult, err
+		}
+
+		logger.Debug("source-not-supported-natively")
+	}
+
 	workerSpec := worker.Spec{
-		Tags:   step.plan.Tags,
-		TeamID: step.metadata.TeamID,
+		Tags:            step.plan.Tags,
+		TeamID:          step.metadata.TeamID,
+		TeamWorkersOnly: step.metadata.TeamWorkersOnly,
 
 		// Used to filter out non-Linux workers, simply because they don't support
 		// base resource types
@@ -264,6 +310,22 @@ func (step *CheckStep) runCheck(
 
 		CertsBindMount: true,
 	}
+
+	containerMetadata := step.containerMetadata
+
+	pooled := step.usesCheckContainerPool()
+	if pooled {
+		// the container is shared by the checks of other resources, so
+		// nothing about this one goes into it
+		containerSpec.JobID = 0
+		containerSpec.Env = nil
+
+		containerMetadata = db.ContainerMetadata{
+			Type:             db.ContainerTypeCheck,
+			WorkingDirectory: step.containerMetadata.WorkingDirectory,
+		}
+	}
+
 	tracing.Inject(ctx, &containerSpec)
 
 	containerOwner := step.containerOwner(delegate, resourceConfig)
@@ -275,8 +337,9 @@ func (step *CheckStep) runCheck(
 
 	strategy := step.strategy
 	if step.plan.IsResourceCheck() {
-		// Resource check containers should be placed randomly. Refer to issue #3251.
-		strategy = nil
+		// Resource check containers should be spread over the workers rather
+		// than placed like build containers. Refer to issue #3251.
+		strategy = worker.NewFewestChecksInFlightStrategy(step.checkSlots)
 	}
 	worker, err := step.workerPool.FindOrSelectWorker(ctx, containerOwner, containerSpec, workerSpec, strategy, delegate)
 	if err != nil {
@@ -285,6 +348,20 @@ func (step *CheckStep) runCheck(
 
 	delegate.SelectedWorker(logger, worker.Name())
 
+	if step.plan.IsResourceCheck() {
+		slot, err := step.waitForCheckSlot(ctx, logger, delegate, worker.Name())
+		if err != nil {
+			return nil, runtime.ProcessResult{}, err
+		}
+
+		defer func() {
+			err := slot.Release()
+			if err != nil {
+				logger.Error("failed-to-release-check-slot", err)
+			}
+		}()
+	}
+
 	defer func() {
 		step.workerPool.ReleaseWorker(
 			logger,
@@ -301,16 +378,129 @@ func (step *CheckStep) runCheck(
 
 	defer cancel()
 
-	container, _, err := worker.FindOrCreateContainer(ctx, containerOwner, step.containerMetadata, containerSpec, delegate)
+	container, _, err := worker.FindOrCreateContainer(ctx, containerOwner, containerMetadata, containerSpec, delegate)
 	if err != nil {
 		return nil, runtime.ProcessResult{}, err
 	}
 
 	delegate.Starting(logger)
-	return resource.Resource{
+
+	checkResource := resource.Resource{
 		Source:  source,
 		Version: fromVersion,
-	}.Check(ctx, container, delegate.Stderr())
+	}
+
+	if pooled {
+		dir := resource.ResourcesDir(fmt.Sprintf("check-%d", resourceConfig.ID()))
+		return checkResource.CheckIsolated(ctx, container, dir, step.metadata.Env(), delegate.Stderr())
+	}
+
+	return checkResource.Check(ctx, container, delegate.Stderr())
+}
+
+// nativeResource returns the native implementation of the resource type, if
+// it is a base resource type with one.
+func (step *CheckStep) nativeResource() (resource.NativeResource, bool) {
+	if step.plan.TypeImage.GetPlan != nil {
+		return nil, false
+	}
+
+	return step.nativeResources.Lookup(step.plan.TypeImage.BaseType)
+}
+
+// runNativeCheck runs the check in the ATC, without a container. A failed
+// check is reported like a check script exiting non-zero, with the error
+// written to the check's output.
+func (step *CheckStep) runNativeCheck(
+	ctx context.Context,
+	logger lager.Logger,
+	delegate CheckDelegate,
+	native resource.NativeResource,
+	source atc.Source,
+	fromVersion atc.Version,
+) ([]atc.Version, runtime.ProcessResult, error) {
+	ctx, cancel, err := MaybeTimeout(ctx, step.plan.Timeout, step.defaultCheckTimeout)
+	if err != nil {
+		return nil, runtime.ProcessResult{}, err
+	}
+
+	defer cancel()
+
+	versions, err := native.Check(ctx, source, fromVersion)
+	if errors.Is(err, resource.ErrUnsupportedSource) {
+		return nil, runtime.ProcessResult{}, err
+	}
+
+	logger.Debug("checked-natively", lager.Data{"type": step.plan.TypeImage.BaseType})
+
+	delegate.Starting(logger)
+
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, runtime.ProcessResult{}, ctx.Err()
+		}
+
+		fmt.Fprintln(delegate.Stderr(), err)
+		return nil, runtime.ProcessResult{ExitStatus: 1}, nil
+	}
+
+	return versions, runtime.ProcessResult{}, nil
+}
+
+// waitForCheckSlot waits until the check can run on the worker without going
+// over the limits on the checks in flight. The checks of all the ATCs share
+// the limits, so that the checks which come due at the same time don't all
+// land on the workers at once.
+func (step *CheckStep) waitForCheckSlot(ctx context.Context, logger lager.Logger, delegate CheckDelegate, workerName string) (db.CheckSlot, error) {
+	// the slot is freed eventually even if the ATC goes away mid-check
+	expiry := step.defaultCheckTimeout
+	if expiry == 0 {
+		expiry = time.Hour
+	}
+
+	slot, acquired, err := step.checkSlots.Acquire(workerName, expiry)
+	if err != nil {
+		return nil, err
+	}
+
+	if acquired {
+		return slot, nil
+	}
+
+	logger.Debug("waiting-for-check-slot", lager.Data{"worker": workerName})
+
+	fmt.Fprintln(delegate.Stderr(), "\x1b[1;36mINFO: waiting for other checks on the worker to finish\x1b[0m")
+	fmt.Fprintln(delegate.Stderr(), "")
+
+	ticker := time.NewTicker(CheckSlotInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+
+		case <-ticker.C:
+			slot, acquired, err := step.checkSlots.Acquire(workerName, expiry)
+			if err != nil {
+				return nil, err
+			}
+
+			if acquired {
+				return slot, nil
+			}
+		}
+	}
+}
+
+// usesCheckContainerPool returns whether the check runs in one of the pooled
+// check containers shared by the resources of the base resource type. Only
+// resource checks of base resource types share containers, as the images of
+// custom resource types are specific to each pipeline.
+func (step *CheckStep) usesCheckContainerPool() bool {
+	return step.checkContainerPoolSize > 0 &&
+		step.plan.IsResourceCheck() &&
+		step.plan.TypeImage.GetPlan == nil
 }
 
 func (step *CheckStep) containerOwner(delegate CheckDelegate, resourceConfig db.ResourceConfig) db.ContainerOwner {
@@ -318,6 +508,20 @@ func (step *CheckStep) containerOwner(delegate CheckDelegate, resourceConfig db.
 		return delegate.ContainerOwner(step.planID)
 	}
 
+	if step.usesCheckContainerPool() {
+		// each resource config sticks to a slot of the pool, so that
+		// whatever its checks cache is usually there the next time
+		return db.NewCheckContainerPoolContainerOwner(
+			resourceConfig.OriginBaseResourceType().ID,
+			step.metadata.TeamID,
+			resourceConfig.ID()%step.checkContainerPoolSize,
+			db.ContainerOwnerExpiries{
+				Min: 30 * time.Minute,
+				Max: 1 * time.Hour,
+			},
+		)
+	}
+
 	expires := db.ContainerOwnerExpiries{
 		Min: 5 * time.Minute,
 		Max: 1 * time.Hour,
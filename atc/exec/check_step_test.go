@@ -16,6 +16,7 @@ import (
 	"github.com/concourse/concourse/atc/exec"
 	"github.com/concourse/concourse/atc/exec/execfakes"
 	"github.com/concourse/concourse/atc/resource"
+	"github.com/concourse/concourse/atc/resource/resourcefakes"
 	"github.com/concourse/concourse/atc/runtime"
 	"github.com/concourse/concourse/atc/runtime/runtimetest"
 	"github.com/concourse/concourse/atc/worker"
@@ -42,6 +43,10 @@ var _ = Describe("CheckStep", func() {
 		fakeDelegateFactory       *execfakes.FakeCheckDelegateFactory
 		spanCtx                   context.Context
 		defaultTimeout            time.Duration = 0
+		checkContainerPoolSize    int
+		fakeCheckSlots            *dbfakes.FakeCheckSlots
+		fakeCheckSlot             *dbfakes.FakeCheckSlot
+		nativeResources           resource.NativeResources
 
 		fakePool        *execfakes.FakePool
 		chosenWorker    *runtimetest.Worker
@@ -66,6 +71,12 @@ var _ = Describe("CheckStep", func() {
 		ctx, cancel = context.WithCancel(context.Background())
 
 		planID = "some-plan-id"
+		checkContainerPoolSize = 0
+		nativeResources = nil
+
+		fakeCheckSlot = new(dbfakes.FakeCheckSlot)
+		fakeCheckSlots = new(dbfakes.FakeCheckSlots)
+		fakeCheckSlots.AcquireReturns(fakeCheckSlot, true, nil)
 
 		runState = exec.NewRunState(noopStepper, vars.StaticVariables{"source-var": "super-secret-source"}, false)
 		fakeDelegateFactory = new(execfakes.FakeCheckDelegateFactory)
@@ -161,6 +172,9 @@ var _ = Describe("CheckStep", func() {
 			fakePool,
 			fakeDelegateFactory,
 			defaultTimeout,
+			checkContainerPoolSize,
+			fakeCheckSlots,
+			nativeResources,
 		)
 
 		stepOk, stepErr = checkStep.Run(ctx, runState)
@@ -256,6 +270,46 @@ var _ = Describe("CheckStep", func() {
 				It("finds the latest version itself - it's a strong, independent check step who dont need no plan", func() {
 					Expect(invokedResource.Version).To(Equal(atc.Version{"latest": "version"}))
 				})
+
+				It("does not clear the check from version", func() {
+					Expect(fakeResourceConfigScope.ClearCheckFromCallCount()).To(Equal(0))
+				})
+
+				Context("when the check has been reset to a version", func() {
+					BeforeEach(func() {
+						fakeResourceConfigScope.CheckFromReturns(atc.Version{"reset": "version"}, true, nil)
+					})
+
+					It("checks from that version instead of the latest", func() {
+						Expect(invokedResource.Version).To(Equal(atc.Version{"reset": "version"}))
+					})
+
+					It("clears the reset once the versions are saved", func() {
+						Expect(fakeResourceConfigScope.SaveVersionsCallCount()).To(Equal(1))
+						Expect(fakeResourceConfigScope.ClearCheckFromCallCount()).To(Equal(1))
+						Expect(fakeResourceConfigScope.ClearCheckFromArgsForCall(0)).To(Equal(atc.Version{"reset": "version"}))
+					})
+				})
+
+				Context("when the check has been reset to start from scratch", func() {
+					BeforeEach(func() {
+						fakeResourceConfigScope.CheckFromReturns(atc.Version{}, true, nil)
+					})
+
+					It("checks without a version", func() {
+						Expect(invokedResource.Version).To(BeNil())
+					})
+				})
+
+				Context("when getting the check from version fails", func() {
+					BeforeEach(func() {
+						fakeResourceConfigScope.CheckFromReturns(nil, false, errors.New("nope"))
+					})
+
+					It("errors", func() {
+						Expect(stepErr).To(MatchError(ContainSubstring("get check from version")))
+					})
+				})
 			})
 
 			Describe("worker selection", func() {
@@ -449,9 +503,51 @@ var _ = Describe("CheckStep", func() {
 						fakePool.FindOrSelectWorkerReturns(chosenWorker, nil)
 					})
 
-					It("should use nil(random) strategy", func() {
+					It("places the check on the worker with the fewest checks in flight", func() {
 						_, _, _, _, expectedStrategy, _ := fakePool.FindOrSelectWorkerArgsForCall(0)
-						Expect(expectedStrategy).To(BeNil())
+						Expect(expectedStrategy).To(Equal(worker.NewFewestChecksInFlightStrategy(fakeCheckSlots)))
+					})
+
+					It("takes up a check slot on the worker while the check runs", func() {
+						Expect(fakeCheckSlots.AcquireCallCount()).To(Equal(1))
+						workerName, _ := fakeCheckSlots.AcquireArgsForCall(0)
+						Expect(workerName).To(Equal("worker"))
+
+						Expect(chosenContainer.RunningProcesses()).To(HaveLen(1))
+						Expect(fakeCheckSlot.ReleaseCallCount()).To(Equal(1))
+					})
+
+					Context("when the worker has too many checks in flight", func() {
+						BeforeEach(func() {
+							exec.CheckSlotInterval = 10 * time.Millisecond
+
+							fakeCheckSlots.AcquireReturnsOnCall(0, nil, false, nil)
+							fakeCheckSlots.AcquireReturnsOnCall(1, nil, false, nil)
+						})
+
+						It("waits for a slot before running the check", func() {
+							Expect(fakeCheckSlots.AcquireCallCount()).To(Equal(3))
+							Expect(fakeStderr.(*bytes.Buffer).String()).To(ContainSubstring("waiting for other checks on the worker to finish"))
+							Expect(stepErr).ToNot(HaveOccurred())
+							Expect(chosenContainer.RunningProcesses()).To(HaveLen(1))
+							Expect(fakeCheckSlot.ReleaseCallCount()).To(Equal(1))
+						})
+
+						Context("when the build is aborted while waiting", func() {
+							BeforeEach(func() {
+								fakeDelegate.StartSpanReturns(ctx, tracing.NoopSpan)
+
+								fakeCheckSlots.AcquireStub = func(string, time.Duration) (db.CheckSlot, bool, error) {
+									cancel()
+									return nil, false, nil
+								}
+							})
+
+							It("does not run the check", func() {
+								Expect(stepErr).To(MatchError(ContainSubstring(context.Canceled.Error())))
+								Expect(chosenContainer.RunningProcesses()).To(BeEmpty())
+							})
+						})
 					})
 
 					It("points the resource or resource type to the scope", func() {
@@ -470,6 +566,87 @@ var _ = Describe("CheckStep", func() {
 						Expect(scope).To(Equal(fakeResourceConfigScope))
 						Expect(nestedStep).To(BeFalse())
 					})
+
+					Context("when the check containers are pooled", func() {
+						BeforeEach(func() {
+							checkContainerPoolSize = 4
+
+							stepMetadata.PipelineName = "some-pipeline"
+
+							expectedOwner = db.NewCheckContainerPoolContainerOwner(
+								502,
+								stepMetadata.TeamID,
+								501%4,
+								db.ContainerOwnerExpiries{Min: 30 * time.Minute, Max: 1 * time.Hour},
+							)
+
+							chosenWorker = runtimetest.NewWorker("worker").
+								WithContainer(
+									expectedOwner,
+									runtimetest.NewContainer().WithProcess(
+										runtime.ProcessSpec{
+											Path: "/opt/resource/check",
+											Dir:  "/tmp/build/check-501",
+											Env: []string{
+												"BUILD_ID=678",
+												"BUILD_TEAM_ID=345",
+												"BUILD_PIPELINE_NAME=some-pipeline",
+												"TMPDIR=/tmp/build/check-501",
+											},
+										},
+										runtimetest.ProcessStub{},
+									),
+									nil,
+								)
+							chosenContainer = chosenWorker.Containers[0]
+							fakePool.FindOrSelectWorkerReturns(chosenWorker, nil)
+						})
+
+						It("runs the check in its own process in the pooled container", func() {
+							Expect(stepErr).ToNot(HaveOccurred())
+							Expect(chosenContainer.RunningProcesses()).To(HaveLen(1))
+						})
+
+						It("leaves the resource's environment out of the pooled container", func() {
+							Expect(chosenContainer.Spec.Env).To(BeEmpty())
+						})
+
+						Context("when the resource uses a custom resource type", func() {
+							BeforeEach(func() {
+								checkPlan.TypeImage.GetPlan = &atc.Plan{
+									ID:  "some-get-plan",
+									Get: &atc.GetPlan{Type: "some-base-type"},
+								}
+
+								fakeDelegate.FetchImageReturns(runtime.ImageSpec{}, nil, nil)
+
+								expectedOwner = db.NewResourceConfigCheckSessionContainerOwner(
+									501,
+									502,
+									db.ContainerOwnerExpiries{Min: 5 * time.Minute, Max: 1 * time.Hour},
+								)
+
+								chosenWorker = runtimetest.NewWorker("worker").
+									WithContainer(
+										expectedOwner,
+										runtimetest.NewContainer().WithProcess(
+											runtime.ProcessSpec{
+												Path: "/opt/resource/check",
+											},
+											runtimetest.ProcessStub{},
+										),
+										nil,
+									)
+								chosenContainer = chosenWorker.Containers[0]
+								fakePool.FindOrSelectWorkerReturns(chosenWorker, nil)
+							})
+
+							It("gives the resource its own check container", func() {
+								Expect(stepErr).ToNot(HaveOccurred())
+								Expect(chosenContainer.RunningProcesses()).To(HaveLen(1))
+							})
+						})
+					})
 				})
 
 				Context("when the plan is nested", func() {
@@ -758,6 +935,85 @@ var _ = Describe("CheckStep", func() {
 					Expect(errors.Is(stepErr, expectedErr)).To(BeTrue())
 				})
 			})
+
+			Context("when the base resource type has a native implementation", func() {
+				var fakeNative *resourcefakes.FakeNativeResource
+
+				BeforeEach(func() {
+					checkPlan.FromVersion = atc.Version{"from": "version"}
+
+					fakeNative = new(resourcefakes.FakeNativeResource)
+					fakeNative.CheckReturns([]atc.Version{{"version": "1"}}, nil)
+
+					nativeResources = resource.NativeResources{"some-base-type": fakeNative}
+				})
+
+				It("checks natively", func() {
+					Expect(fakeNative.CheckCallCount()).To(Equal(1))
+					_, source, from := fakeNative.CheckArgsForCall(0)
+					Expect(source).To(Equal(atc.Source{"some": "super-secret-source"}))
+					Expect(from).To(Equal(atc.Version{"from": "version"}))
+				})
+
+				It("does not use a container", func() {
+					Expect(fakePool.FindOrSelectWorkerCallCount()).To(Equal(0))
+					Expect(chosenContainer.RunningProcesses()).To(BeEmpty())
+				})
+
+				It("saves the versions", func() {
+					Expect(stepOk).To(BeTrue())
+
+					_, versions := fakeResourceConfigScope.SaveVersionsArgsForCall(0)
+					Expect(versions).To(Equal([]atc.Version{{"version": "1"}}))
+				})
+
+				Context("when the check fails", func() {
+					BeforeEach(func() {
+						fakeNative.CheckReturns(nil, errors.New("registry is down"))
+					})
+
+					It("writes the error to stderr and fails the check", func() {
+						Expect(stepErr).ToNot(HaveOccurred())
+						Expect(stepOk).To(BeFalse())
+						Expect(fakeStderr.(*bytes.Buffer).String()).To(ContainSubstring("registry is down"))
+
+						Expect(fakeDelegate.FinishedCallCount()).To(Equal(1))
+						_, succeeded := fakeDelegate.FinishedArgsForCall(0)
+						Expect(succeeded).To(BeFalse())
+					})
+				})
+
+				Context("when the source is not supported natively", func() {
+					BeforeEach(func() {
+						fakeNative.CheckReturns(nil, resource.ErrUnsupportedSource)
+					})
+
+					It("checks in a container", func() {
+						Expect(stepOk).To(BeTrue())
+						Expect(fakePool.FindOrSelectWorkerCallCount()).To(Equal(1))
+						Expect(invokedResource.Version).To(Equal(atc.Version{"from": "version"}))
+					})
+				})
+
+				Context("when the resource uses a custom resource type", func() {
+					BeforeEach(func() {
+						checkPlan.TypeImage.GetPlan = &atc.Plan{
+							ID: "1/image-get",
+							Get: &atc.GetPlan{
+								Name:   "some-custom-type",
+								Type:   "some-base-type",
+								Source: atc.Source{"some": "custom-source"},
+							},
+						}
+						fakeDelegate.FetchImageReturns(runtime.ImageSpec{ImageArtifact: runtimetest.NewVolume("some-image")}, nil, nil)
+					})
+
+					It("checks in a container", func() {
+						Expect(fakeNative.CheckCallCount()).To(Equal(0))
+						Expect(fakePool.FindOrSelectWorkerCallCount()).To(Equal(1))
+					})
+				})
+			})
 		})
 	})
 
@@ -0,0 +1,50 @@
+package exec
+
+import (
+	"context"
+	"fmt"
+
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
+)
+
+// ConditionalStep runs the nested step only if its condition holds.
+type ConditionalStep struct {
+	plan            atc.WhenPlan
+	step            Step
+	delegateFactory BuildStepDelegateFactory
+}
+
+// Conditional constructs a ConditionalStep.
+func Conditional(plan atc.WhenPlan, step Step, delegateFactory BuildStepDelegateFactory) Step {
+	return ConditionalStep{
+		plan:            plan,
+		step:            step,
+		delegateFactory: delegateFactory,
+	}
+}
+
+// Run evaluates the condition against the build's vars, which include any
+// loaded by a prior load_var step.
+//
+// If the condition is true, the nested step is run and its result returned.
+// Otherwise the nested step is recorded as skipped and the ConditionalStep
+// succeeds.
+func (step ConditionalStep) Run(ctx context.Context, state RunState) (bool, error) {
+	run, err := creds.NewBool(state, step.plan.Condition).Evaluate()
+	if err != nil {
+		return false, fmt.Errorf("evaluate condition: %w", err)
+	}
+
+	if run {
+		return step.step.Run(ctx, state)
+	}
+
+	logger := lagerctx.FromContext(ctx)
+
+	delegate := step.delegateFactory.BuildStepDelegate(state)
+	delegate.Skipped(logger, step.plan.Step)
+
+	return true, nil
+}
@@ -0,0 +1,128 @@
+package exec_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/exec/execfakes"
+	"github.com/concourse/concourse/vars"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Conditional Step", func() {
+	var (
+		ctx context.Context
+
+		fakeStep            *execfakes.FakeStep
+		fakeDelegate        *execfakes.FakeBuildStepDelegate
+		fakeDelegateFactory *execfakes.FakeBuildStepDelegateFactory
+
+		state RunState
+
+		plan atc.WhenPlan
+
+		stepOk  bool
+		stepErr error
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		fakeStep = new(execfakes.FakeStep)
+		fakeStep.RunReturns(true, nil)
+
+		fakeDelegate = new(execfakes.FakeBuildStepDelegate)
+		fakeDelegateFactory = new(execfakes.FakeBuildStepDelegateFactory)
+		fakeDelegateFactory.BuildStepDelegateReturns(fakeDelegate)
+
+		state = NewRunState(noopStepper, vars.StaticVariables{"flag": "false"}, false)
+
+		plan = atc.WhenPlan{
+			Step: atc.Plan{
+				ID:   "some-step",
+				Task: &atc.TaskPlan{Name: "publish"},
+			},
+			Condition: true,
+		}
+	})
+
+	JustBeforeEach(func() {
+		stepOk, stepErr = Conditional(plan, fakeStep, fakeDelegateFactory).Run(ctx, state)
+	})
+
+	Context("when the condition is true", func() {
+		It("runs the step", func() {
+			Expect(fakeStep.RunCallCount()).To(Equal(1))
+			Expect(fakeDelegate.SkippedCallCount()).To(BeZero())
+		})
+
+		Context("when the step fails", func() {
+			BeforeEach(func() {
+				fakeStep.RunReturns(false, nil)
+			})
+
+			It("fails", func() {
+				Expect(stepErr).ToNot(HaveOccurred())
+				Expect(stepOk).To(BeFalse())
+			})
+		})
+
+		Context("when the step errors", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakeStep.RunReturns(false, disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(stepErr).To(Equal(disaster))
+			})
+		})
+	})
+
+	Context("when the condition is a var set by a prior step", func() {
+		BeforeEach(func() {
+			plan.Condition = "((.:publish))"
+			state.AddLocalVar("publish", "true", false)
+		})
+
+		It("runs the step", func() {
+			Expect(fakeStep.RunCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when the condition is false", func() {
+		BeforeEach(func() {
+			plan.Condition = "((flag))"
+		})
+
+		It("does not run the step", func() {
+			Expect(fakeStep.RunCallCount()).To(BeZero())
+		})
+
+		It("records the step as skipped", func() {
+			Expect(fakeDelegate.SkippedCallCount()).To(Equal(1))
+			_, skipped := fakeDelegate.SkippedArgsForCall(0)
+			Expect(skipped).To(Equal(plan.Step))
+		})
+
+		It("succeeds", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(stepOk).To(BeTrue())
+		})
+	})
+
+	Context("when the condition cannot be evaluated", func() {
+		BeforeEach(func() {
+			plan.Condition = "((.:missing))"
+		})
+
+		It("errors without running the step", func() {
+			Expect(stepErr).To(MatchError(ContainSubstring("evaluate condition")))
+			Expect(fakeStep.RunCallCount()).To(BeZero())
+		})
+	})
+})
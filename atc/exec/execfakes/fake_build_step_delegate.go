@@ -94,6 +94,12 @@ type FakeBuildStepDelegate struct {
 		arg1 lager.Logger
 		arg2 string
 	}
+	SkippedStub        func(lager.Logger, atc.Plan)
+	skippedMutex       sync.RWMutex
+	skippedArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 atc.Plan
+	}
 	StartSpanStub        func(context.Context, string, tracing.Attrs) (context.Context, trace.Span)
 	startSpanMutex       sync.RWMutex
 	startSpanArgsForCall []struct {
@@ -562,6 +568,39 @@ func (fake *FakeBuildStepDelegate) SelectedWorkerArgsForCall(i int) (lager.Logge
 	return argsForCall.arg1, argsForCall.arg2
 }
 
+func (fake *FakeBuildStepDelegate) Skipped(arg1 lager.Logger, arg2 atc.Plan) {
+	fake.skippedMutex.Lock()
+	fake.skippedArgsForCall = append(fake.skippedArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 atc.Plan
+	}{arg1, arg2})
+	stub := fake.SkippedStub
+	fake.recordInvocation("Skipped", []interface{}{arg1, arg2})
+	fake.skippedMutex.Unlock()
+	if stub != nil {
+		fake.SkippedStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeBuildStepDelegate) SkippedCallCount() int {
+	fake.skippedMutex.RLock()
+	defer fake.skippedMutex.RUnlock()
+	return len(fake.skippedArgsForCall)
+}
+
+func (fake *FakeBuildStepDelegate) SkippedCalls(stub func(lager.Logger, atc.Plan)) {
+	fake.skippedMutex.Lock()
+	defer fake.skippedMutex.Unlock()
+	fake.SkippedStub = stub
+}
+
+func (fake *FakeBuildStepDelegate) SkippedArgsForCall(i int) (lager.Logger, atc.Plan) {
+	fake.skippedMutex.RLock()
+	defer fake.skippedMutex.RUnlock()
+	argsForCall := fake.skippedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
 func (fake *FakeBuildStepDelegate) StartSpan(arg1 context.Context, arg2 string, arg3 tracing.Attrs) (context.Context, trace.Span) {
 	fake.startSpanMutex.Lock()
 	ret, specificReturn := fake.startSpanReturnsOnCall[len(fake.startSpanArgsForCall)]
@@ -886,6 +925,8 @@ func (fake *FakeBuildStepDelegate) Invocations() map[string][][]interface{} {
 	defer fake.initializingMutex.RUnlock()
 	fake.selectedWorkerMutex.RLock()
 	defer fake.selectedWorkerMutex.RUnlock()
+	fake.skippedMutex.RLock()
+	defer fake.skippedMutex.RUnlock()
 	fake.startSpanMutex.RLock()
 	defer fake.startSpanMutex.RUnlock()
 	fake.startingMutex.RLock()
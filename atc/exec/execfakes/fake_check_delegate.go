@@ -119,6 +119,12 @@ type FakeCheckDelegate struct {
 		arg1 lager.Logger
 		arg2 string
 	}
+	SkippedStub        func(lager.Logger, atc.Plan)
+	skippedMutex       sync.RWMutex
+	skippedArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 atc.Plan
+	}
 	StartSpanStub        func(context.Context, string, tracing.Attrs) (context.Context, trace.Span)
 	startSpanMutex       sync.RWMutex
 	startSpanArgsForCall []struct {
@@ -758,6 +764,39 @@ func (fake *FakeCheckDelegate) SelectedWorkerArgsForCall(i int) (lager.Logger, s
 	return argsForCall.arg1, argsForCall.arg2
 }
 
+func (fake *FakeCheckDelegate) Skipped(arg1 lager.Logger, arg2 atc.Plan) {
+	fake.skippedMutex.Lock()
+	fake.skippedArgsForCall = append(fake.skippedArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 atc.Plan
+	}{arg1, arg2})
+	stub := fake.SkippedStub
+	fake.recordInvocation("Skipped", []interface{}{arg1, arg2})
+	fake.skippedMutex.Unlock()
+	if stub != nil {
+		fake.SkippedStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeCheckDelegate) SkippedCallCount() int {
+	fake.skippedMutex.RLock()
+	defer fake.skippedMutex.RUnlock()
+	return len(fake.skippedArgsForCall)
+}
+
+func (fake *FakeCheckDelegate) SkippedCalls(stub func(lager.Logger, atc.Plan)) {
+	fake.skippedMutex.Lock()
+	defer fake.skippedMutex.Unlock()
+	fake.SkippedStub = stub
+}
+
+func (fake *FakeCheckDelegate) SkippedArgsForCall(i int) (lager.Logger, atc.Plan) {
+	fake.skippedMutex.RLock()
+	defer fake.skippedMutex.RUnlock()
+	argsForCall := fake.skippedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
 func (fake *FakeCheckDelegate) StartSpan(arg1 context.Context, arg2 string, arg3 tracing.Attrs) (context.Context, trace.Span) {
 	fake.startSpanMutex.Lock()
 	ret, specificReturn := fake.startSpanReturnsOnCall[len(fake.startSpanArgsForCall)]
@@ -1287,6 +1326,8 @@ func (fake *FakeCheckDelegate) Invocations() map[string][][]interface{} {
 	defer fake.pointToCheckedConfigMutex.RUnlock()
 	fake.selectedWorkerMutex.RLock()
 	defer fake.selectedWorkerMutex.RUnlock()
+	fake.skippedMutex.RLock()
+	defer fake.skippedMutex.RUnlock()
 	fake.startSpanMutex.RLock()
 	defer fake.startSpanMutex.RUnlock()
 	fake.startingMutex.RLock()
@@ -91,6 +91,12 @@ type FakeGetDelegate struct {
 		arg1 lager.Logger
 		arg2 string
 	}
+	SkippedStub        func(lager.Logger, atc.Plan)
+	skippedMutex       sync.RWMutex
+	skippedArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 atc.Plan
+	}
 	StartSpanStub        func(context.Context, string, tracing.Attrs) (context.Context, trace.Span)
 	startSpanMutex       sync.RWMutex
 	startSpanArgsForCall []struct {
@@ -539,6 +545,39 @@ func (fake *FakeGetDelegate) SelectedWorkerArgsForCall(i int) (lager.Logger, str
 	return argsForCall.arg1, argsForCall.arg2
 }
 
+func (fake *FakeGetDelegate) Skipped(arg1 lager.Logger, arg2 atc.Plan) {
+	fake.skippedMutex.Lock()
+	fake.skippedArgsForCall = append(fake.skippedArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 atc.Plan
+	}{arg1, arg2})
+	stub := fake.SkippedStub
+	fake.recordInvocation("Skipped", []interface{}{arg1, arg2})
+	fake.skippedMutex.Unlock()
+	if stub != nil {
+		fake.SkippedStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeGetDelegate) SkippedCallCount() int {
+	fake.skippedMutex.RLock()
+	defer fake.skippedMutex.RUnlock()
+	return len(fake.skippedArgsForCall)
+}
+
+func (fake *FakeGetDelegate) SkippedCalls(stub func(lager.Logger, atc.Plan)) {
+	fake.skippedMutex.Lock()
+	defer fake.skippedMutex.Unlock()
+	fake.SkippedStub = stub
+}
+
+func (fake *FakeGetDelegate) SkippedArgsForCall(i int) (lager.Logger, atc.Plan) {
+	fake.skippedMutex.RLock()
+	defer fake.skippedMutex.RUnlock()
+	argsForCall := fake.skippedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
 func (fake *FakeGetDelegate) StartSpan(arg1 context.Context, arg2 string, arg3 tracing.Attrs) (context.Context, trace.Span) {
 	fake.startSpanMutex.Lock()
 	ret, specificReturn := fake.startSpanReturnsOnCall[len(fake.startSpanArgsForCall)]
@@ -897,6 +936,8 @@ func (fake *FakeGetDelegate) Invocations() map[string][][]interface{} {
 	defer fake.resourceCacheUserMutex.RUnlock()
 	fake.selectedWorkerMutex.RLock()
 	defer fake.selectedWorkerMutex.RUnlock()
+	fake.skippedMutex.RLock()
+	defer fake.skippedMutex.RUnlock()
 	fake.startSpanMutex.RLock()
 	defer fake.startSpanMutex.RUnlock()
 	fake.startingMutex.RLock()
@@ -111,6 +111,12 @@ type FakeSetPipelineStepDelegate struct {
 		arg1 lager.Logger
 		arg2 bool
 	}
+	SkippedStub        func(lager.Logger, atc.Plan)
+	skippedMutex       sync.RWMutex
+	skippedArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 atc.Plan
+	}
 	StartSpanStub        func(context.Context, string, tracing.Attrs) (context.Context, trace.Span)
 	startSpanMutex       sync.RWMutex
 	startSpanArgsForCall []struct {
@@ -673,6 +679,39 @@ func (fake *FakeSetPipelineStepDelegate) SetPipelineChangedArgsForCall(i int) (l
 	return argsForCall.arg1, argsForCall.arg2
 }
 
+func (fake *FakeSetPipelineStepDelegate) Skipped(arg1 lager.Logger, arg2 atc.Plan) {
+	fake.skippedMutex.Lock()
+	fake.skippedArgsForCall = append(fake.skippedArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 atc.Plan
+	}{arg1, arg2})
+	stub := fake.SkippedStub
+	fake.recordInvocation("Skipped", []interface{}{arg1, arg2})
+	fake.skippedMutex.Unlock()
+	if stub != nil {
+		fake.SkippedStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeSetPipelineStepDelegate) SkippedCallCount() int {
+	fake.skippedMutex.RLock()
+	defer fake.skippedMutex.RUnlock()
+	return len(fake.skippedArgsForCall)
+}
+
+func (fake *FakeSetPipelineStepDelegate) SkippedCalls(stub func(lager.Logger, atc.Plan)) {
+	fake.skippedMutex.Lock()
+	defer fake.skippedMutex.Unlock()
+	fake.SkippedStub = stub
+}
+
+func (fake *FakeSetPipelineStepDelegate) SkippedArgsForCall(i int) (lager.Logger, atc.Plan) {
+	fake.skippedMutex.RLock()
+	defer fake.skippedMutex.RUnlock()
+	argsForCall := fake.skippedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
 func (fake *FakeSetPipelineStepDelegate) StartSpan(arg1 context.Context, arg2 string, arg3 tracing.Attrs) (context.Context, trace.Span) {
 	fake.startSpanMutex.Lock()
 	ret, specificReturn := fake.startSpanReturnsOnCall[len(fake.startSpanArgsForCall)]
@@ -1001,6 +1040,8 @@ func (fake *FakeSetPipelineStepDelegate) Invocations() map[string][][]interface{
 	defer fake.selectedWorkerMutex.RUnlock()
 	fake.setPipelineChangedMutex.RLock()
 	defer fake.setPipelineChangedMutex.RUnlock()
+	fake.skippedMutex.RLock()
+	defer fake.skippedMutex.RUnlock()
 	fake.startSpanMutex.RLock()
 	defer fake.startSpanMutex.RUnlock()
 	fake.startingMutex.RLock()
@@ -11,6 +11,19 @@ import (
 )
 
 type FakeStreamer struct {
+	StreamStub        func(context.Context, runtime.Artifact, runtime.Volume) error
+	streamMutex       sync.RWMutex
+	streamArgsForCall []struct {
+		arg1 context.Context
+		arg2 runtime.Artifact
+		arg3 runtime.Volume
+	}
+	streamReturns struct {
+		result1 error
+	}
+	streamReturnsOnCall map[int]struct {
+		result1 error
+	}
 	StreamFileStub        func(context.Context, runtime.Artifact, string) (io.ReadCloser, error)
 	streamFileMutex       sync.RWMutex
 	streamFileArgsForCall []struct {
@@ -30,6 +43,69 @@ type FakeStreamer struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeStreamer) Stream(arg1 context.Context, arg2 runtime.Artifact, arg3 runtime.Volume) error {
+	fake.streamMutex.Lock()
+	ret, specificReturn := fake.streamReturnsOnCall[len(fake.streamArgsForCall)]
+	fake.streamArgsForCall = append(fake.streamArgsForCall, struct {
+		arg1 context.Context
+		arg2 runtime.Artifact
+		arg3 runtime.Volume
+	}{arg1, arg2, arg3})
+	stub := fake.StreamStub
+	fakeReturns := fake.streamReturns
+	fake.recordInvocation("Stream", []interface{}{arg1, arg2, arg3})
+	fake.streamMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeStreamer) StreamCallCount() int {
+	fake.streamMutex.RLock()
+	defer fake.streamMutex.RUnlock()
+	return len(fake.streamArgsForCall)
+}
+
+func (fake *FakeStreamer) StreamCalls(stub func(context.Context, runtime.Artifact, runtime.Volume) error) {
+	fake.streamMutex.Lock()
+	defer fake.streamMutex.Unlock()
+	fake.StreamStub = stub
+}
+
+func (fake *FakeStreamer) StreamArgsForCall(i int) (context.Context, runtime.Artifact, runtime.Volume) {
+	fake.streamMutex.RLock()
+	defer fake.streamMutex.RUnlock()
+	argsForCall := fake.streamArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeStreamer) StreamReturns(result1 error) {
+	fake.streamMutex.Lock()
+	defer fake.streamMutex.Unlock()
+	fake.StreamStub = nil
+	fake.streamReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStreamer) StreamReturnsOnCall(i int, result1 error) {
+	fake.streamMutex.Lock()
+	defer fake.streamMutex.Unlock()
+	fake.StreamStub = nil
+	if fake.streamReturnsOnCall == nil {
+		fake.streamReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.streamReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeStreamer) StreamFile(arg1 context.Context, arg2 runtime.Artifact, arg3 string) (io.ReadCloser, error) {
 	fake.streamFileMutex.Lock()
 	ret, specificReturn := fake.streamFileReturnsOnCall[len(fake.streamFileArgsForCall)]
@@ -99,6 +175,8 @@ func (fake *FakeStreamer) StreamFileReturnsOnCall(i int, result1 io.ReadCloser,
 func (fake *FakeStreamer) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.streamMutex.RLock()
+	defer fake.streamMutex.RUnlock()
 	fake.streamFileMutex.RLock()
 	defer fake.streamFileMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
@@ -26,6 +26,18 @@ type FakeTaskDelegate struct {
 	beforeSelectWorkerReturnsOnCall map[int]struct {
 		result1 error
 	}
+	CheckRunPrivilegedTaskPolicyStub        func(string, atc.TaskConfig) error
+	checkRunPrivilegedTaskPolicyMutex       sync.RWMutex
+	checkRunPrivilegedTaskPolicyArgsForCall []struct {
+		arg1 string
+		arg2 atc.TaskConfig
+	}
+	checkRunPrivilegedTaskPolicyReturns struct {
+		result1 error
+	}
+	checkRunPrivilegedTaskPolicyReturnsOnCall map[int]struct {
+		result1 error
+	}
 	ErroredStub        func(lager.Logger, string)
 	erroredMutex       sync.RWMutex
 	erroredArgsForCall []struct {
@@ -61,6 +73,18 @@ type FakeTaskDelegate struct {
 	initializingArgsForCall []struct {
 		arg1 lager.Logger
 	}
+	InjectedEnvStub        func() (atc.TaskEnv, error)
+	injectedEnvMutex       sync.RWMutex
+	injectedEnvArgsForCall []struct {
+	}
+	injectedEnvReturns struct {
+		result1 atc.TaskEnv
+		result2 error
+	}
+	injectedEnvReturnsOnCall map[int]struct {
+		result1 atc.TaskEnv
+		result2 error
+	}
 	SelectedWorkerStub        func(lager.Logger, string)
 	selectedWorkerMutex       sync.RWMutex
 	selectedWorkerArgsForCall []struct {
@@ -72,6 +96,12 @@ type FakeTaskDelegate struct {
 	setTaskConfigArgsForCall []struct {
 		arg1 atc.TaskConfig
 	}
+	SkippedStub        func(lager.Logger, atc.Plan)
+	skippedMutex       sync.RWMutex
+	skippedArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 atc.Plan
+	}
 	StartSpanStub        func(context.Context, string, tracing.Attrs) (context.Context, trace.Span)
 	startSpanMutex       sync.RWMutex
 	startSpanArgsForCall []struct {
@@ -197,6 +227,68 @@ func (fake *FakeTaskDelegate) BeforeSelectWorkerReturnsOnCall(i int, result1 err
 	}{result1}
 }
 
+func (fake *FakeTaskDelegate) CheckRunPrivilegedTaskPolicy(arg1 string, arg2 atc.TaskConfig) error {
+	fake.checkRunPrivilegedTaskPolicyMutex.Lock()
+	ret, specificReturn := fake.checkRunPrivilegedTaskPolicyReturnsOnCall[len(fake.checkRunPrivilegedTaskPolicyArgsForCall)]
+	fake.checkRunPrivilegedTaskPolicyArgsForCall = append(fake.checkRunPrivilegedTaskPolicyArgsForCall, struct {
+		arg1 string
+		arg2 atc.TaskConfig
+	}{arg1, arg2})
+	stub := fake.CheckRunPrivilegedTaskPolicyStub
+	fakeReturns := fake.checkRunPrivilegedTaskPolicyReturns
+	fake.recordInvocation("CheckRunPrivilegedTaskPolicy", []interface{}{arg1, arg2})
+	fake.checkRunPrivilegedTaskPolicyMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeTaskDelegate) CheckRunPrivilegedTaskPolicyCallCount() int {
+	fake.checkRunPrivilegedTaskPolicyMutex.RLock()
+	defer fake.checkRunPrivilegedTaskPolicyMutex.RUnlock()
+	return len(fake.checkRunPrivilegedTaskPolicyArgsForCall)
+}
+
+func (fake *FakeTaskDelegate) CheckRunPrivilegedTaskPolicyCalls(stub func(string, atc.TaskConfig) error) {
+	fake.checkRunPrivilegedTaskPolicyMutex.Lock()
+	defer fake.checkRunPrivilegedTaskPolicyMutex.Unlock()
+	fake.CheckRunPrivilegedTaskPolicyStub = stub
+}
+
+func (fake *FakeTaskDelegate) CheckRunPrivilegedTaskPolicyArgsForCall(i int) (string, atc.TaskConfig) {
+	fake.checkRunPrivilegedTaskPolicyMutex.RLock()
+	defer fake.checkRunPrivilegedTaskPolicyMutex.RUnlock()
+	argsForCall := fake.checkRunPrivilegedTaskPolicyArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeTaskDelegate) CheckRunPrivilegedTaskPolicyReturns(result1 error) {
+	fake.checkRunPrivilegedTaskPolicyMutex.Lock()
+	defer fake.checkRunPrivilegedTaskPolicyMutex.Unlock()
+	fake.CheckRunPrivilegedTaskPolicyStub = nil
+	fake.checkRunPrivilegedTaskPolicyReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTaskDelegate) CheckRunPrivilegedTaskPolicyReturnsOnCall(i int, result1 error) {
+	fake.checkRunPrivilegedTaskPolicyMutex.Lock()
+	defer fake.checkRunPrivilegedTaskPolicyMutex.Unlock()
+	fake.CheckRunPrivilegedTaskPolicyStub = nil
+	if fake.checkRunPrivilegedTaskPolicyReturnsOnCall == nil {
+		fake.checkRunPrivilegedTaskPolicyReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.checkRunPrivilegedTaskPolicyReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeTaskDelegate) Errored(arg1 lager.Logger, arg2 string) {
 	fake.erroredMutex.Lock()
 	fake.erroredArgsForCall = append(fake.erroredArgsForCall, struct {
@@ -364,6 +456,62 @@ func (fake *FakeTaskDelegate) InitializingArgsForCall(i int) lager.Logger {
 	return argsForCall.arg1
 }
 
+func (fake *FakeTaskDelegate) InjectedEnv() (atc.TaskEnv, error) {
+	fake.injectedEnvMutex.Lock()
+	ret, specificReturn := fake.injectedEnvReturnsOnCall[len(fake.injectedEnvArgsForCall)]
+	fake.injectedEnvArgsForCall = append(fake.injectedEnvArgsForCall, struct {
+	}{})
+	stub := fake.InjectedEnvStub
+	fakeReturns := fake.injectedEnvReturns
+	fake.recordInvocation("InjectedEnv", []interface{}{})
+	fake.injectedEnvMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTaskDelegate) InjectedEnvCallCount() int {
+	fake.injectedEnvMutex.RLock()
+	defer fake.injectedEnvMutex.RUnlock()
+	return len(fake.injectedEnvArgsForCall)
+}
+
+func (fake *FakeTaskDelegate) InjectedEnvCalls(stub func() (atc.TaskEnv, error)) {
+	fake.injectedEnvMutex.Lock()
+	defer fake.injectedEnvMutex.Unlock()
+	fake.InjectedEnvStub = stub
+}
+
+func (fake *FakeTaskDelegate) InjectedEnvReturns(result1 atc.TaskEnv, result2 error) {
+	fake.injectedEnvMutex.Lock()
+	defer fake.injectedEnvMutex.Unlock()
+	fake.InjectedEnvStub = nil
+	fake.injectedEnvReturns = struct {
+		result1 atc.TaskEnv
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTaskDelegate) InjectedEnvReturnsOnCall(i int, result1 atc.TaskEnv, result2 error) {
+	fake.injectedEnvMutex.Lock()
+	defer fake.injectedEnvMutex.Unlock()
+	fake.InjectedEnvStub = nil
+	if fake.injectedEnvReturnsOnCall == nil {
+		fake.injectedEnvReturnsOnCall = make(map[int]struct {
+			result1 atc.TaskEnv
+			result2 error
+		})
+	}
+	fake.injectedEnvReturnsOnCall[i] = struct {
+		result1 atc.TaskEnv
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTaskDelegate) SelectedWorker(arg1 lager.Logger, arg2 string) {
 	fake.selectedWorkerMutex.Lock()
 	fake.selectedWorkerArgsForCall = append(fake.selectedWorkerArgsForCall, struct {
@@ -429,6 +577,39 @@ func (fake *FakeTaskDelegate) SetTaskConfigArgsForCall(i int) atc.TaskConfig {
 	return argsForCall.arg1
 }
 
+func (fake *FakeTaskDelegate) Skipped(arg1 lager.Logger, arg2 atc.Plan) {
+	fake.skippedMutex.Lock()
+	fake.skippedArgsForCall = append(fake.skippedArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 atc.Plan
+	}{arg1, arg2})
+	stub := fake.SkippedStub
+	fake.recordInvocation("Skipped", []interface{}{arg1, arg2})
+	fake.skippedMutex.Unlock()
+	if stub != nil {
+		fake.SkippedStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeTaskDelegate) SkippedCallCount() int {
+	fake.skippedMutex.RLock()
+	defer fake.skippedMutex.RUnlock()
+	return len(fake.skippedArgsForCall)
+}
+
+func (fake *FakeTaskDelegate) SkippedCalls(stub func(lager.Logger, atc.Plan)) {
+	fake.skippedMutex.Lock()
+	defer fake.skippedMutex.Unlock()
+	fake.SkippedStub = stub
+}
+
+func (fake *FakeTaskDelegate) SkippedArgsForCall(i int) (lager.Logger, atc.Plan) {
+	fake.skippedMutex.RLock()
+	defer fake.skippedMutex.RUnlock()
+	argsForCall := fake.skippedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
 func (fake *FakeTaskDelegate) StartSpan(arg1 context.Context, arg2 string, arg3 tracing.Attrs) (context.Context, trace.Span) {
 	fake.startSpanMutex.Lock()
 	ret, specificReturn := fake.startSpanReturnsOnCall[len(fake.startSpanArgsForCall)]
@@ -737,6 +918,10 @@ func (fake *FakeTaskDelegate) WaitingForWorkerArgsForCall(i int) lager.Logger {
 func (fake *FakeTaskDelegate) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.injectedEnvMutex.RLock()
+	defer fake.injectedEnvMutex.RUnlock()
+	fake.checkRunPrivilegedTaskPolicyMutex.RLock()
+	defer fake.checkRunPrivilegedTaskPolicyMutex.RUnlock()
 	fake.beforeSelectWorkerMutex.RLock()
 	defer fake.beforeSelectWorkerMutex.RUnlock()
 	fake.erroredMutex.RLock()
@@ -751,6 +936,8 @@ func (fake *FakeTaskDelegate) Invocations() map[string][][]interface{} {
 	defer fake.selectedWorkerMutex.RUnlock()
 	fake.setTaskConfigMutex.RLock()
 	defer fake.setTaskConfigMutex.RUnlock()
+	fake.skippedMutex.RLock()
+	defer fake.skippedMutex.RUnlock()
 	fake.startSpanMutex.RLock()
 	defer fake.startSpanMutex.RUnlock()
 	fake.startingMutex.RLock()
@@ -0,0 +1,53 @@
+package exec
+
+import (
+	"context"
+	"sync"
+
+	"github.com/concourse/concourse/atc"
+)
+
+// FailureRecorder keeps track of why the steps of a build didn't succeed, so
+// that the build can be given a reason once it fails or errors.
+type FailureRecorder struct {
+	lock   sync.Mutex
+	reason atc.FailureReason
+}
+
+func NewFailureRecorder() *FailureRecorder {
+	return &FailureRecorder{}
+}
+
+type failureRecorderKey struct{}
+
+// WithFailureRecorder makes the steps record their failures through the
+// recorder.
+func WithFailureRecorder(ctx context.Context, recorder *FailureRecorder) context.Context {
+	return context.WithValue(ctx, failureRecorderKey{}, recorder)
+}
+
+// Reason returns the reason of the last failure recorded.
+func (recorder *FailureRecorder) Reason() atc.FailureReason {
+	recorder.lock.Lock()
+	defer recorder.lock.Unlock()
+
+	return recorder.reason
+}
+
+func (recorder *FailureRecorder) record(reason atc.FailureReason) {
+	recorder.lock.Lock()
+	defer recorder.lock.Unlock()
+
+	recorder.reason = reason
+}
+
+// RecordFailure records the reason a step didn't succeed with the
+// FailureRecorder of the context, if any.
+func RecordFailure(ctx context.Context, reason atc.FailureReason) {
+	recorder, found := ctx.Value(failureRecorderKey{}).(*FailureRecorder)
+	if !found {
+		return
+	}
+
+	recorder.record(reason)
+}
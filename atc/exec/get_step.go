@@ -125,7 +125,10 @@ func (step *GetStep) Run(ctx context.Context, state RunState) (bool, error) {
 		"resource": step.plan.Resource,
 	})
 
+	ctx, fetched := runtime.StartPhase(ctx, atc.StartLatencyInputFetch)
 	ok, err := step.run(ctx, state, delegate)
+	fetched()
+
 	tracing.End(span, err)
 
 	return ok, err
@@ -150,8 +153,9 @@ func (step *GetStep) run(ctx context.Context, state RunState, delegate GetDelega
 	}
 
 	workerSpec := worker.Spec{
-		Tags:   step.plan.Tags,
-		TeamID: step.metadata.TeamID,
+		Tags:            step.plan.Tags,
+		TeamID:          step.metadata.TeamID,
+		TeamWorkersOnly: step.metadata.TeamWorkersOnly,
 
 		// Used to filter out non-Linux workers, simply because they don't support
 		// base resource types
@@ -225,6 +229,7 @@ func (step *GetStep) run(ctx context.Context, state RunState, delegate GetDelega
 	)
 	if err != nil {
 		if errors.Is(err, context.DeadlineExceeded) {
+			RecordFailure(ctx, atc.FailureReasonTimedOut)
 			delegate.Errored(logger, TimeoutLogMessage)
 			return false, nil
 		}
@@ -481,7 +486,9 @@ func (step *GetStep) performGetAndInitCache(
 
 	defer cancel()
 
-	container, mounts, err := worker.FindOrCreateContainer(ctx, containerOwner, step.containerMetadata, containerSpec, delegate)
+	createCtx, created := runtime.StartPhase(ctx, atc.StartLatencyContainerCreation)
+	container, mounts, err := worker.FindOrCreateContainer(createCtx, containerOwner, step.containerMetadata, containerSpec, delegate)
+	created()
 	if err != nil {
 		logger.Error("failed-to-create-container", err)
 		return nil, resource.VersionResult{}, runtime.ProcessResult{}, err
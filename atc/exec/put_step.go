@@ -2,7 +2,10 @@ package exec
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"time"
 
@@ -46,6 +49,8 @@ type PutDelegate interface {
 	SaveOutput(lager.Logger, atc.PutPlan, atc.Source, db.ResourceCache, resource.VersionResult)
 }
 
+var PutQueueInterval = 5 * time.Second
+
 // PutStep produces a resource version using preconfigured params and any data
 // available in the worker.ArtifactRepository.
 type PutStep struct {
@@ -56,6 +61,7 @@ type PutStep struct {
 	strategy          worker.PlacementStrategy
 	workerPool        Pool
 	delegateFactory   PutDelegateFactory
+	putQueue          db.PutQueue
 	defaultPutTimeout time.Duration
 }
 
@@ -67,6 +73,7 @@ func NewPutStep(
 	strategy worker.PlacementStrategy,
 	workerPool Pool,
 	delegateFactory PutDelegateFactory,
+	putQueue db.PutQueue,
 	defaultPutTimeout time.Duration,
 ) Step {
 	return &PutStep{
@@ -77,6 +84,7 @@ func NewPutStep(
 		workerPool:        workerPool,
 		strategy:          strategy,
 		delegateFactory:   delegateFactory,
+		putQueue:          putQueue,
 		defaultPutTimeout: defaultPutTimeout,
 	}
 }
@@ -89,6 +97,10 @@ func NewPutStep(
 //
 // The resource's put script is then invoked. If the context is canceled, the
 // script will be interrupted.
+//
+// If the puts to the resource are serialized, the step first waits for the
+// puts to the same resource config which came before it, from any build, to
+// finish.
 func (step *PutStep) Run(ctx context.Context, state RunState) (bool, error) {
 	delegate := step.delegateFactory.PutDelegate(state)
 	ctx, span := delegate.StartSpan(ctx, "put", tracing.Attrs{
@@ -141,9 +153,24 @@ func (step *PutStep) run(ctx context.Context, state RunState, delegate PutDelega
 		return false, err
 	}
 
+	if step.plan.SerializePuts {
+		entry, err := step.waitForTurn(ctx, logger, delegate, source)
+		if err != nil {
+			return false, err
+		}
+
+		defer func() {
+			err := entry.Leave()
+			if err != nil {
+				logger.Error("failed-to-leave-put-queue", err)
+			}
+		}()
+	}
+
 	workerSpec := worker.Spec{
-		Tags:   step.plan.Tags,
-		TeamID: step.metadata.TeamID,
+		Tags:            step.plan.Tags,
+		TeamID:          step.metadata.TeamID,
+		TeamWorkersOnly: step.metadata.TeamWorkersOnly,
 
 		// Used to filter out non-Linux workers, simply because they don't support
 		// base resource types
@@ -210,7 +237,9 @@ func (step *PutStep) run(ctx context.Context, state RunState, delegate PutDelega
 
 	defer cancel()
 
-	container, _, err := worker.FindOrCreateContainer(ctx, owner, step.containerMetadata, containerSpec, delegate)
+	createCtx, created := runtime.StartPhase(ctx, atc.StartLatencyContainerCreation)
+	container, _, err := worker.FindOrCreateContainer(createCtx, owner, step.containerMetadata, containerSpec, delegate)
+	created()
 	if err != nil {
 		return false, err
 	}
@@ -222,6 +251,7 @@ func (step *PutStep) run(ctx context.Context, state RunState, delegate PutDelega
 	}.Put(ctx, container, delegate.Stderr())
 	if err != nil {
 		if errors.Is(err, context.DeadlineExceeded) {
+			RecordFailure(ctx, atc.FailureReasonTimedOut)
 			delegate.Errored(logger, TimeoutLogMessage)
 			return false, nil
 		}
@@ -246,3 +276,73 @@ func (step *PutStep) run(ctx context.Context, state RunState, delegate PutDelega
 
 	return true, nil
 }
+
+// waitForTurn joins the queue of puts to the resource config and waits until
+// the step is next in line.
+func (step *PutStep) waitForTurn(ctx context.Context, logger lager.Logger, delegate PutDelegate, source atc.Source) (db.PutQueueEntry, error) {
+	queue, err := putQueueName(step.plan.Type, source)
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := step.putQueue.Join(queue, step.metadata.BuildID, step.planID)
+	if err != nil {
+		return nil, err
+	}
+
+	next, err := entry.IsNext()
+	if err != nil {
+		return nil, err
+	}
+
+	if next {
+		return entry, nil
+	}
+
+	logger.Debug("waiting-for-other-puts", lager.Data{"queue": queue})
+
+	fmt.Fprintln(delegate.Stderr(), "\x1b[1;36mINFO: waiting for other puts to the resource to finish\x1b[0m")
+	fmt.Fprintln(delegate.Stderr(), "")
+
+	ticker := time.NewTicker(PutQueueInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			err := entry.Leave()
+			if err != nil {
+				logger.Error("failed-to-leave-put-queue", err)
+			}
+
+			return nil, ctx.Err()
+
+		case <-ticker.C:
+			next, err := entry.IsNext()
+			if err != nil {
+				return nil, err
+			}
+
+			if next {
+				return entry, nil
+			}
+		}
+	}
+}
+
+// putQueueName identifies the resource config the puts go to, without
+// revealing its source.
+func putQueueName(resourceType string, source atc.Source) (string, error) {
+	config, err := json.Marshal(struct {
+		Type   string     `json:"type"`
+		Source atc.Source `json:"source"`
+	}{
+		Type:   resourceType,
+		Source: source,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%x", sha256.Sum256(config)), nil
+}
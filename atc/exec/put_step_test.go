@@ -35,6 +35,8 @@ var _ = Describe("PutStep", func() {
 		fakeDelegateFactory *execfakes.FakePutDelegateFactory
 
 		fakePool        *execfakes.FakePool
+		fakePutQueue    *dbfakes.FakePutQueue
+		fakeQueueEntry  *dbfakes.FakePutQueueEntry
 		chosenWorker    *runtimetest.Worker
 		chosenContainer *runtimetest.WorkerContainer
 
@@ -106,6 +108,11 @@ var _ = Describe("PutStep", func() {
 		fakePool = new(execfakes.FakePool)
 		fakePool.FindOrSelectWorkerReturns(chosenWorker, nil)
 
+		fakeQueueEntry = new(dbfakes.FakePutQueueEntry)
+		fakeQueueEntry.IsNextReturns(true, nil)
+		fakePutQueue = new(dbfakes.FakePutQueue)
+		fakePutQueue.JoinReturns(fakeQueueEntry, nil)
+
 		fakeDelegate = new(execfakes.FakePutDelegate)
 		stdoutBuf = gbytes.NewBuffer()
 		stderrBuf = gbytes.NewBuffer()
@@ -162,6 +169,7 @@ var _ = Describe("PutStep", func() {
 			nil,
 			fakePool,
 			fakeDelegateFactory,
+			fakePutQueue,
 			defaultPutTimeout,
 		)
 
@@ -503,6 +511,77 @@ var _ = Describe("PutStep", func() {
 		})
 	})
 
+	It("does not wait for other puts to the resource", func() {
+		Expect(fakePutQueue.JoinCallCount()).To(BeZero())
+	})
+
+	Context("when the puts to the resource are serialized", func() {
+		BeforeEach(func() {
+			putPlan.SerializePuts = true
+		})
+
+		It("joins the queue of the evaluated resource config", func() {
+			Expect(fakePutQueue.JoinCallCount()).To(Equal(1))
+			queue, buildID, joinedPlanID := fakePutQueue.JoinArgsForCall(0)
+			Expect(queue).ToNot(BeEmpty())
+			Expect(queue).ToNot(ContainSubstring("super-secret-source"))
+			Expect(buildID).To(Equal(42))
+			Expect(joinedPlanID).To(Equal(planID))
+		})
+
+		It("runs the put and leaves the queue", func() {
+			Expect(stepOk).To(BeTrue())
+			Expect(fakeDelegate.FinishedCallCount()).To(Equal(1))
+			Expect(fakeQueueEntry.LeaveCallCount()).To(Equal(1))
+		})
+
+		Context("when other puts are ahead in the queue", func() {
+			BeforeEach(func() {
+				exec.PutQueueInterval = 10 * time.Millisecond
+
+				fakeQueueEntry.IsNextReturnsOnCall(0, false, nil)
+				fakeQueueEntry.IsNextReturnsOnCall(1, false, nil)
+			})
+
+			It("waits for its turn before selecting a worker", func() {
+				Expect(fakeQueueEntry.IsNextCallCount()).To(Equal(3))
+				Expect(stderrBuf).To(gbytes.Say("waiting for other puts to the resource to finish"))
+				Expect(fakePool.FindOrSelectWorkerCallCount()).To(Equal(1))
+				Expect(stepOk).To(BeTrue())
+			})
+
+			Context("when the build is aborted while waiting", func() {
+				BeforeEach(func() {
+					fakeDelegate.StartSpanReturns(ctx, tracing.NoopSpan)
+
+					fakeQueueEntry.IsNextStub = func() (bool, error) {
+						cancel()
+						return false, nil
+					}
+				})
+
+				It("leaves the queue without running the put", func() {
+					Expect(stepErr).To(Equal(context.Canceled))
+					Expect(fakePool.FindOrSelectWorkerCallCount()).To(BeZero())
+					Expect(fakeQueueEntry.LeaveCallCount()).To(Equal(1))
+				})
+			})
+		})
+
+		Context("when joining the queue fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakePutQueue.JoinReturns(nil, disaster)
+			})
+
+			It("returns the error without running the put", func() {
+				Expect(stepErr).To(Equal(disaster))
+				Expect(fakePool.FindOrSelectWorkerCallCount()).To(BeZero())
+			})
+		})
+	})
+
 	Context("when the plan specifies a timeout", func() {
 		BeforeEach(func() {
 			putPlan.Timeout = "1ms"
@@ -11,6 +11,7 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc/worker"
 	"github.com/concourse/concourse/atc/worker/gardenruntime/transport"
 )
 
@@ -56,16 +57,29 @@ func (step RetryErrorStep) Run(ctx context.Context, state RunState) (bool, error
 }
 
 func (step RetryErrorStep) toRetry(logger lager.Logger, err error) bool {
-	var urlError *url.Error
-	var netError net.Error
-	if errors.As(err, &transport.WorkerMissingError{}) || errors.As(err, &transport.WorkerUnreachableError{}) || errors.As(err, &urlError) {
+	if isWorkerUnreachable(err) {
 		logger.Debug("retry-error",
 			lager.Data{"err_type": reflect.TypeOf(err).String(), "err": err.Error()})
 		return true
-	} else if errors.As(err, &netError) || regexp.MustCompile(`worker .+ disappeared`).MatchString(err.Error()) {
-		logger.Debug("retry-error",
-			lager.Data{"err_type": reflect.TypeOf(err).String(), "err": err})
-		return true
 	}
 	return false
 }
+
+// IsWorkerError returns whether a step errored because of the workers it ran
+// on, e.g. its worker disappeared or a volume failed to stream between
+// workers, rather than because of the step itself.
+func IsWorkerError(err error) bool {
+	return isWorkerUnreachable(err) ||
+		errors.As(err, &worker.VolumeDigestMismatchError{}) ||
+		errors.As(err, &worker.StreamingResourceCacheNotFoundError{})
+}
+
+func isWorkerUnreachable(err error) bool {
+	var urlError *url.Error
+	var netError net.Error
+	return errors.As(err, &transport.WorkerMissingError{}) ||
+		errors.As(err, &transport.WorkerUnreachableError{}) ||
+		errors.As(err, &urlError) ||
+		errors.As(err, &netError) ||
+		regexp.MustCompile(`worker .+ disappeared`).MatchString(err.Error())
+}
@@ -10,6 +10,7 @@ import (
 	. "github.com/concourse/concourse/atc/exec"
 	"github.com/concourse/concourse/atc/exec/build"
 	"github.com/concourse/concourse/atc/exec/execfakes"
+	"github.com/concourse/concourse/atc/worker"
 	"github.com/concourse/concourse/atc/worker/gardenruntime/transport"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -163,4 +164,20 @@ var _ = Describe("RetryErrorStep", func() {
 			})
 		})
 	})
+
+	Describe("IsWorkerError", func() {
+		It("is true for errors reaching the worker", func() {
+			Expect(IsWorkerError(transport.WorkerMissingError{WorkerName: "some-worker"})).To(BeTrue())
+			Expect(IsWorkerError(fmt.Errorf("wrapped: %w", transport.WorkerUnreachableError{WorkerName: "some-worker"}))).To(BeTrue())
+		})
+
+		It("is true for errors streaming volumes between workers", func() {
+			Expect(IsWorkerError(worker.VolumeDigestMismatchError{Handle: "some-handle"})).To(BeTrue())
+			Expect(IsWorkerError(worker.StreamingResourceCacheNotFoundError{Handle: "some-handle"})).To(BeTrue())
+		})
+
+		It("is false for any other error", func() {
+			Expect(IsWorkerError(errors.New("disaster"))).To(BeFalse())
+		})
+	})
 })
@@ -315,6 +315,15 @@ func (s setPipelineSource) FetchPipelineConfig() (atc.Config, error) {
 		return atc.Config{}, err
 	}
 
+	missing, err := atcConfig.Vars.Missing(vars.NewMultiVars(staticVars))
+	if err != nil {
+		return atc.Config{}, err
+	}
+
+	if len(missing) > 0 {
+		return atc.Config{}, fmt.Errorf("missing required vars: %s", strings.Join(missing, ", "))
+	}
+
 	return atcConfig, nil
 }
 
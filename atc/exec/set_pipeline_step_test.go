@@ -272,6 +272,48 @@ jobs:
 			})
 		})
 
+		Context("when pipeline declares required vars", func() {
+			BeforeEach(func() {
+				fakeTeam.PipelineReturns(nil, false, nil)
+				fakeBuild.SavePipelineReturns(fakePipeline, true, nil)
+			})
+
+			Context("when they are not provided", func() {
+				BeforeEach(func() {
+					fakeStreamer.StreamFileReturns(&fakeReadCloser{str: pipelineContent + `
+vars:
+- name: branch
+  required: true
+- name: commit
+  required: true
+`}, nil)
+				})
+
+				It("should fail with the names of the missing vars", func() {
+					Expect(stepErr).To(MatchError("missing required vars: commit"))
+				})
+
+				It("should not save the pipeline", func() {
+					Expect(fakeBuild.SavePipelineCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when they are provided as instance vars", func() {
+				BeforeEach(func() {
+					fakeStreamer.StreamFileReturns(&fakeReadCloser{str: pipelineContent + `
+vars:
+- name: branch
+  required: true
+`}, nil)
+				})
+
+				It("should save the pipeline", func() {
+					Expect(stepErr).ToNot(HaveOccurred())
+					Expect(fakeBuild.SavePipelineCallCount()).To(Equal(1))
+				})
+			})
+		})
+
 		Context("when pipeline file is good", func() {
 			BeforeEach(func() {
 				fakeStreamer.StreamFileReturns(&fakeReadCloser{str: pipelineContent}, nil)
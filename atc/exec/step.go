@@ -82,5 +82,6 @@ type Pool interface {
 //go:generate counterfeiter . Streamer
 
 type Streamer interface {
+	Stream(ctx context.Context, src runtime.Artifact, dst runtime.Volume) error
 	StreamFile(ctx context.Context, artifact runtime.Artifact, path string) (io.ReadCloser, error)
 }
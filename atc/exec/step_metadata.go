@@ -17,6 +17,9 @@ type StepMetadata struct {
 	PipelineInstanceVars map[string]interface{}
 	ExternalURL          string
 	CreatedBy            string
+
+	// TeamWorkersOnly restricts the step to the team's own workers.
+	TeamWorkersOnly bool
 }
 
 func (metadata StepMetadata) Env() []string {
@@ -15,6 +15,7 @@ import (
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/exec/build"
 	"github.com/concourse/concourse/atc/runtime"
+	"github.com/concourse/concourse/atc/warmpool"
 	"github.com/concourse/concourse/atc/worker"
 	"github.com/concourse/concourse/tracing"
 	"github.com/concourse/concourse/vars"
@@ -67,6 +68,8 @@ type TaskDelegate interface {
 	Stderr() io.Writer
 
 	SetTaskConfig(config atc.TaskConfig)
+	CheckRunPrivilegedTaskPolicy(name string, config atc.TaskConfig) error
+	InjectedEnv() (atc.TaskEnv, error)
 
 	Initializing(lager.Logger)
 	Starting(lager.Logger)
@@ -91,6 +94,7 @@ type TaskStep struct {
 	strategy           worker.PlacementStrategy
 	workerPool         Pool
 	streamer           Streamer
+	warmPools          db.WarmPoolRepository
 	delegateFactory    TaskDelegateFactory
 	defaultTaskTimeout time.Duration
 }
@@ -104,6 +108,7 @@ func NewTaskStep(
 	strategy worker.PlacementStrategy,
 	workerPool Pool,
 	streamer Streamer,
+	warmPools db.WarmPoolRepository,
 	delegateFactory TaskDelegateFactory,
 	defaultTaskTimeout time.Duration,
 ) Step {
@@ -116,6 +121,7 @@ func NewTaskStep(
 		strategy:           strategy,
 		workerPool:         workerPool,
 		streamer:           streamer,
+		warmPools:          warmPools,
 		delegateFactory:    delegateFactory,
 		defaultTaskTimeout: defaultTaskTimeout,
 	}
@@ -226,6 +232,13 @@ func (step *TaskStep) run(ctx context.Context, state RunState, delegate TaskDele
 		config.Limits.Memory = step.defaultLimits.Memory
 	}
 
+	if step.plan.Privileged {
+		err = delegate.CheckRunPrivilegedTaskPolicy(step.plan.Name, config)
+		if err != nil {
+			return false, err
+		}
+	}
+
 	delegate.Initializing(logger)
 
 	imageSpec, err := step.imageSpec(ctx, logger, state, delegate, config)
@@ -233,10 +246,20 @@ func (step *TaskStep) run(ctx context.Context, state RunState, delegate TaskDele
 		return false, err
 	}
 
-	containerSpec, err := step.containerSpec(logger, state, imageSpec, config, step.containerMetadata)
+	injectedEnv, err := delegate.InjectedEnv()
+	if err != nil {
+		return false, fmt.Errorf("get injected env: %w", err)
+	}
+
+	containerSpec, err := step.containerSpec(logger, state, imageSpec, config, injectedEnv, step.containerMetadata)
 	if err != nil {
 		return false, err
 	}
+
+	// the template must be taken before the trace is injected, as the trace
+	// differs from build to build
+	claimed := step.claimWarmContainer(logger, containerSpec, config)
+
 	tracing.Inject(ctx, &containerSpec)
 
 	owner := db.NewBuildStepContainerOwner(step.metadata.BuildID, step.planID, step.metadata.TeamID)
@@ -277,30 +300,44 @@ func (step *TaskStep) run(ctx context.Context, state RunState, delegate TaskDele
 
 	delegate.SelectedWorker(logger, worker.Name())
 
-	container, volumeMounts, err := worker.FindOrCreateContainer(ctx, owner, step.containerMetadata, containerSpec, delegate)
+	createCtx, created := runtime.StartPhase(ctx, atc.StartLatencyContainerCreation)
+	container, volumeMounts, err := worker.FindOrCreateContainer(createCtx, owner, step.containerMetadata, containerSpec, delegate)
+	created()
 	if err != nil {
 		return false, err
 	}
 
+	processSpec := runtime.ProcessSpec{
+		ID:   taskProcessID,
+		Path: config.Run.Path,
+		Args: config.Run.Args,
+		Dir:  resolvePath(step.containerMetadata.WorkingDirectory, config.Run.Dir),
+		User: config.Run.User,
+		// Guardian sets the default TTY window size to width: 80, height: 24,
+		// which creates ANSI control sequences that do not work with other window sizes
+		TTY: &runtime.TTYSpec{
+			WindowSize: runtime.WindowSize{
+				Columns: 500,
+				Rows:    500,
+			},
+		},
+	}
+
+	warm, err := step.fillWarmContainer(ctx, logger, container, volumeMounts, containerSpec, claimed)
+	if err != nil {
+		return false, err
+	}
+
+	if warm {
+		// the warm container was created without the build's env
+		processSpec.Env = containerSpec.Env
+	}
+
 	delegate.Starting(logger)
 	process, err := attachOrRun(
 		ctx,
 		container,
-		runtime.ProcessSpec{
-			ID:   taskProcessID,
-			Path: config.Run.Path,
-			Args: config.Run.Args,
-			Dir:  resolvePath(step.containerMetadata.WorkingDirectory, config.Run.Dir),
-			User: config.Run.User,
-			// Guardian sets the default TTY window size to width: 80, height: 24,
-			// which creates ANSI control sequences that do not work with other window sizes
-			TTY: &runtime.TTYSpec{
-				WindowSize: runtime.WindowSize{
-					Columns: 500,
-					Rows:    500,
-				},
-			},
-		},
+		processSpec,
 		runtime.ProcessIO{
 			Stdout: delegate.Stdout(),
 			Stderr: delegate.Stderr(),
@@ -310,6 +347,8 @@ func (step *TaskStep) run(ctx context.Context, state RunState, delegate TaskDele
 		return false, err
 	}
 
+	runtime.RecordTaskStarted(ctx)
+
 	result, runErr := process.Wait(ctx)
 
 	step.registerOutputs(logger, repository, config, volumeMounts, step.containerMetadata)
@@ -323,6 +362,7 @@ func (step *TaskStep) run(ctx context.Context, state RunState, delegate TaskDele
 
 	if runErr != nil {
 		if errors.Is(runErr, context.DeadlineExceeded) {
+			RecordFailure(ctx, atc.FailureReasonTimedOut)
 			delegate.Errored(logger, TimeoutLogMessage)
 			return false, nil
 		}
@@ -330,10 +370,108 @@ func (step *TaskStep) run(ctx context.Context, state RunState, delegate TaskDele
 		return false, runErr
 	}
 
+	if result.ExitStatus != 0 {
+		RecordFailure(ctx, atc.FailureReasonTaskFailed)
+	}
+
 	delegate.Finished(logger, ExitStatus(result.ExitStatus))
 	return result.ExitStatus == 0, nil
 }
 
+// claimWarmContainer records the template of the task's container for the
+// job's warm pool, and hands a container created from it over to the step if
+// one is ready.
+func (step *TaskStep) claimWarmContainer(logger lager.Logger, containerSpec runtime.ContainerSpec, config atc.TaskConfig) bool {
+	if !step.plan.WarmPool || step.plan.ImageArtifactName != "" || step.warmPools == nil {
+		return false
+	}
+
+	template, ok := warmpool.NewTemplate(containerSpec, config.Params.Env(), step.workerSpec(config), step.containerMetadata)
+	if !ok {
+		return false
+	}
+
+	dbTemplate, err := template.DBTemplate()
+	if err != nil {
+		logger.Error("failed-to-encode-warm-pool-template", err)
+		return false
+	}
+
+	err = step.warmPools.SaveTemplate(dbTemplate)
+	if err != nil {
+		logger.Error("failed-to-save-warm-pool-template", err)
+		return false
+	}
+
+	claimed, err := step.warmPools.ClaimContainer(
+		dbTemplate.JobID,
+		dbTemplate.StepName,
+		dbTemplate.Key,
+		step.metadata.BuildID,
+		step.planID,
+		step.containerMetadata,
+	)
+	if err != nil {
+		logger.Error("failed-to-claim-warm-container", err)
+		return false
+	}
+
+	if claimed {
+		logger.Info("claimed-warm-container")
+	}
+
+	return claimed
+}
+
+// fillWarmContainer streams the task's inputs into a warm container, which
+// was created with empty volumes in their place. It returns whether the
+// container came from a warm pool.
+func (step *TaskStep) fillWarmContainer(ctx context.Context, logger lager.Logger, container runtime.Container, volumeMounts []runtime.VolumeMount, containerSpec runtime.ContainerSpec, claimed bool) (bool, error) {
+	properties, err := container.Properties()
+	if err != nil {
+		return false, err
+	}
+
+	inputs, found := properties[warmpool.InputsProperty]
+	if !found && !claimed {
+		return false, nil
+	}
+
+	if inputs == warmpool.InputsStreamed {
+		return true, nil
+	}
+
+	for _, input := range containerSpec.Inputs {
+		destination := filepath.Clean(input.DestinationPath)
+
+		var volume runtime.Volume
+		for _, mount := range volumeMounts {
+			if filepath.Clean(mount.MountPath) == destination {
+				volume = mount.Volume
+				break
+			}
+		}
+
+		if volume == nil {
+			return false, fmt.Errorf("warm container has no volume for input at %s", destination)
+		}
+
+		logger.Debug("streaming-input-into-warm-container", lager.Data{"path": destination})
+
+		err := step.streamer.Stream(ctx, input.Artifact, volume)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	err = container.SetProperty(warmpool.InputsProperty, warmpool.InputsStreamed)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
 func attachOrRun(ctx context.Context, container runtime.Container, spec runtime.ProcessSpec, io runtime.ProcessIO) (runtime.Process, error) {
 	process, err := container.Attach(ctx, spec.ID, io)
 	if err == nil {
@@ -408,8 +546,16 @@ func (step *TaskStep) containerInputs(logger lager.Logger, repository *build.Rep
 	return inputs, nil
 }
 
-func (step *TaskStep) containerSpec(logger lager.Logger, state RunState, imageSpec runtime.ImageSpec, config atc.TaskConfig, metadata db.ContainerMetadata) (runtime.ContainerSpec, error) {
+func (step *TaskStep) containerSpec(logger lager.Logger, state RunState, imageSpec runtime.ImageSpec, config atc.TaskConfig, injectedEnv atc.TaskEnv, metadata db.ContainerMetadata) (runtime.ContainerSpec, error) {
 	env := step.metadata.TaskEnv()
+
+	// the task's own params take precedence over the injected env
+	for name, value := range injectedEnv {
+		if _, found := config.Params[name]; !found {
+			env = append(env, name+"="+value)
+		}
+	}
+
 	env = append(env, config.Params.Env()...)
 
 	containerSpec := runtime.ContainerSpec{
@@ -451,9 +597,10 @@ func (step *TaskStep) containerSpec(logger lager.Logger, state RunState, imageSp
 
 func (step *TaskStep) workerSpec(config atc.TaskConfig) worker.Spec {
 	return worker.Spec{
-		Platform: config.Platform,
-		Tags:     step.plan.Tags,
-		TeamID:   step.metadata.TeamID,
+		Platform:        config.Platform,
+		Tags:            step.plan.Tags,
+		TeamID:          step.metadata.TeamID,
+		TeamWorkersOnly: step.metadata.TeamWorkersOnly,
 	}
 }
 
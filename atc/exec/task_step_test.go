@@ -8,11 +8,13 @@ import (
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
 	"github.com/concourse/concourse/atc/exec"
 	"github.com/concourse/concourse/atc/exec/build"
 	"github.com/concourse/concourse/atc/exec/execfakes"
 	"github.com/concourse/concourse/atc/runtime"
 	"github.com/concourse/concourse/atc/runtime/runtimetest"
+	"github.com/concourse/concourse/atc/warmpool"
 	"github.com/concourse/concourse/atc/worker"
 	"github.com/concourse/concourse/tracing"
 	"github.com/concourse/concourse/vars"
@@ -28,11 +30,14 @@ var _ = Describe("TaskStep", func() {
 		ctx    context.Context
 		cancel func()
 
+		failures *exec.FailureRecorder
+
 		stdoutBuf *gbytes.Buffer
 		stderrBuf *gbytes.Buffer
 
-		fakePool     *execfakes.FakePool
-		fakeStreamer *execfakes.FakeStreamer
+		fakePool      *execfakes.FakePool
+		fakeStreamer  *execfakes.FakeStreamer
+		fakeWarmPools *dbfakes.FakeWarmPoolRepository
 
 		fakeDelegate *execfakes.FakeTaskDelegate
 
@@ -72,10 +77,14 @@ var _ = Describe("TaskStep", func() {
 	BeforeEach(func() {
 		ctx, cancel = context.WithCancel(context.Background())
 
+		failures = exec.NewFailureRecorder()
+		ctx = exec.WithFailureRecorder(ctx, failures)
+
 		stdoutBuf = gbytes.NewBuffer()
 		stderrBuf = gbytes.NewBuffer()
 
 		fakeStreamer = new(execfakes.FakeStreamer)
+		fakeWarmPools = new(dbfakes.FakeWarmPoolRepository)
 
 		fakeDelegate = new(execfakes.FakeTaskDelegate)
 		fakeDelegate.StdoutReturns(stdoutBuf)
@@ -119,6 +128,7 @@ var _ = Describe("TaskStep", func() {
 			nil,
 			fakePool,
 			fakeStreamer,
+			fakeWarmPools,
 			fakeDelegateFactory,
 			defaultTaskTimeout,
 		)
@@ -181,6 +191,35 @@ var _ = Describe("TaskStep", func() {
 			Expect(chosenContainer.Spec.Env).To(ConsistOf("ATC_EXTERNAL_URL=http://foo.bar", "SECURE=secret-task-param"))
 		})
 
+		Context("when env is injected into the team's tasks", func() {
+			BeforeEach(func() {
+				fakeDelegate.InjectedEnvReturns(atc.TaskEnv{
+					"HTTP_PROXY": "http://proxy:3128",
+					"SECURE":     "injected",
+				}, nil)
+			})
+
+			It("includes the injected env, overridden by the task's params", func() {
+				Expect(chosenContainer.Spec.Env).To(ConsistOf(
+					"ATC_EXTERNAL_URL=http://foo.bar",
+					"HTTP_PROXY=http://proxy:3128",
+					"SECURE=secret-task-param",
+				))
+			})
+		})
+
+		Context("when getting the injected env fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakeDelegate.InjectedEnvReturns(nil, disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(errors.Is(stepErr, disaster)).To(BeTrue())
+			})
+		})
+
 		Context("before running the task", func() {
 			BeforeEach(func() {
 				chosenContainer.ProcessDefs[0].Stub.Do = func(_ context.Context, _ *runtimetest.Process) error {
@@ -256,6 +295,28 @@ var _ = Describe("TaskStep", func() {
 			It("marks the container's image spec as privileged", func() {
 				Expect(chosenContainer.Spec.ImageSpec.Privileged).To(BeTrue())
 			})
+
+			It("checks the policy for running privileged tasks", func() {
+				Expect(fakeDelegate.CheckRunPrivilegedTaskPolicyCallCount()).To(Equal(1))
+				name, config := fakeDelegate.CheckRunPrivilegedTaskPolicyArgsForCall(0)
+				Expect(name).To(Equal(taskPlan.Name))
+				Expect(config.Run).To(Equal(taskPlan.Config.Run))
+			})
+
+			Context("when the policy check fails", func() {
+				BeforeEach(func() {
+					fakeDelegate.CheckRunPrivilegedTaskPolicyReturns(errors.New("policy-check-error"))
+				})
+
+				It("errors without running the task", func() {
+					Expect(stepErr).To(MatchError("policy-check-error"))
+					Expect(fakeDelegate.InitializingCallCount()).To(BeZero())
+				})
+			})
+		})
+
+		It("does not check the policy for running privileged tasks", func() {
+			Expect(fakeDelegate.CheckRunPrivilegedTaskPolicyCallCount()).To(BeZero())
 		})
 
 		It("uses the correct container limits", func() {
@@ -304,6 +365,10 @@ var _ = Describe("TaskStep", func() {
 				Expect(status).To(Equal(exec.TimeoutLogMessage))
 			})
 
+			It("records that the task timed out", func() {
+				Expect(failures.Reason()).To(Equal(atc.FailureReasonTimedOut))
+			})
+
 			Context("when the timeout is bogus", func() {
 				BeforeEach(func() {
 					taskPlan.Timeout = "bogus"
@@ -833,6 +898,99 @@ var _ = Describe("TaskStep", func() {
 			})
 		})
 
+		Context("when the task's job has a warm pool", func() {
+			var input *runtimetest.Volume
+			var inputMount *runtimetest.Volume
+
+			BeforeEach(func() {
+				taskPlan.WarmPool = true
+				taskPlan.Config.RootfsURI = "some-image"
+				taskPlan.Config.Inputs = []atc.TaskInputConfig{{Name: "some-input"}}
+
+				input = runtimetest.NewVolume("some-input")
+				repo.RegisterArtifact("some-input", input, false)
+
+				inputMount = runtimetest.NewVolume("some-input-mount")
+				chosenWorker.Containers[0].Mounts = []runtime.VolumeMount{
+					{Volume: inputMount, MountPath: "some-artifact-root/some-input/"},
+				}
+			})
+
+			It("records the template of the task's container", func() {
+				Expect(fakeWarmPools.SaveTemplateCallCount()).To(Equal(1))
+				template := fakeWarmPools.SaveTemplateArgsForCall(0)
+				Expect(template.JobID).To(Equal(stepMetadata.JobID))
+				Expect(template.StepName).To(Equal("some-task"))
+				Expect(template.TeamID).To(Equal(stepMetadata.TeamID))
+				Expect(template.Key).ToNot(BeEmpty())
+			})
+
+			It("tries to claim a warm container for the step", func() {
+				Expect(fakeWarmPools.ClaimContainerCallCount()).To(Equal(1))
+				jobID, stepName, key, buildID, claimPlanID, metadata := fakeWarmPools.ClaimContainerArgsForCall(0)
+				Expect(jobID).To(Equal(stepMetadata.JobID))
+				Expect(stepName).To(Equal("some-task"))
+				Expect(key).To(Equal(fakeWarmPools.SaveTemplateArgsForCall(0).Key))
+				Expect(buildID).To(Equal(stepMetadata.BuildID))
+				Expect(claimPlanID).To(Equal(planID))
+				Expect(metadata).To(Equal(containerMetadata))
+			})
+
+			Context("when a warm container is claimed", func() {
+				BeforeEach(func() {
+					fakeWarmPools.ClaimContainerReturns(true, nil)
+
+					chosenContainer.ProcessDefs[0].Spec.Env = []string{"ATC_EXTERNAL_URL=http://foo.bar", "SECURE=secret-task-param"}
+				})
+
+				It("streams the inputs into the container", func() {
+					Expect(stepErr).ToNot(HaveOccurred())
+
+					Expect(fakeStreamer.StreamCallCount()).To(Equal(1))
+					_, src, dst := fakeStreamer.StreamArgsForCall(0)
+					Expect(src).To(Equal(input))
+					Expect(dst).To(Equal(inputMount))
+
+					Expect(chosenContainer.Props).To(HaveKeyWithValue(warmpool.InputsProperty, warmpool.InputsStreamed))
+				})
+
+				It("runs the task with the build's env", func() {
+					Expect(stepErr).ToNot(HaveOccurred())
+					Expect(chosenContainer.RunningProcesses()).To(HaveLen(1))
+				})
+
+				Context("when streaming an input fails", func() {
+					BeforeEach(func() {
+						fakeStreamer.StreamReturns(errors.New("nope"))
+					})
+
+					It("errors", func() {
+						Expect(stepErr).To(MatchError("nope"))
+					})
+				})
+			})
+
+			Context("when no warm container is ready", func() {
+				It("runs the task in a new container", func() {
+					Expect(stepErr).ToNot(HaveOccurred())
+					Expect(fakeStreamer.StreamCallCount()).To(BeZero())
+					Expect(chosenContainer.RunningProcesses()).To(HaveLen(1))
+				})
+			})
+
+			Context("when the image is an artifact of the build", func() {
+				BeforeEach(func() {
+					taskPlan.ImageArtifactName = "some-image-artifact"
+					repo.RegisterArtifact("some-image-artifact", runtimetest.NewVolume("some-image-artifact"), false)
+				})
+
+				It("does not use the warm pool", func() {
+					Expect(fakeWarmPools.SaveTemplateCallCount()).To(BeZero())
+					Expect(fakeWarmPools.ClaimContainerCallCount()).To(BeZero())
+				})
+			})
+		})
+
 		Context("when a run dir and user are specified", func() {
 			BeforeEach(func() {
 				taskPlan.Config.Run.Dir = "/some/dir"
@@ -866,6 +1024,10 @@ var _ = Describe("TaskStep", func() {
 				_, status := fakeDelegate.FinishedArgsForCall(0)
 				Expect(status).To(Equal(exec.ExitStatus(1)))
 			})
+
+			It("records that the task failed", func() {
+				Expect(failures.Reason()).To(Equal(atc.FailureReasonTaskFailed))
+			})
 		})
 
 		Context("when running the task fails", func() {
@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"time"
+
+	"github.com/concourse/concourse/atc"
 )
 
 // TimeoutStep applies a fixed timeout to a step's Run.
@@ -40,6 +42,7 @@ func (ts *TimeoutStep) Run(ctx context.Context, state RunState) (bool, error) {
 
 	ok, err := ts.step.Run(timeoutCtx, state)
 	if errors.Is(err, context.DeadlineExceeded) {
+		RecordFailure(ctx, atc.FailureReasonTimedOut)
 		return false, nil
 	}
 
@@ -0,0 +1,31 @@
+// Package faults injects faults into the db, worker and lock packages so that
+// their failure paths can be exercised by tests.
+//
+// Faults are only ever injected in binaries built with the "faults" build tag.
+// They are then configured per point through environment variables, which
+// are read on every injection so that tests can change them as they go:
+//
+//	CONCOURSE_FAULTS_<POINT>_LATENCY     delay each call by the duration
+//	CONCOURSE_FAULTS_<POINT>_FAIL_EVERY  fail every nth call
+//
+// where <POINT> is one of DB, WORKER or LOCK.
+package faults
+
+import "errors"
+
+// Point is a place in the code where faults can be injected.
+type Point string
+
+const (
+	// DB is hit on each query of a connection, and fails like a dropped
+	// connection.
+	DB Point = "DB"
+
+	// Worker is hit each time a worker is selected for a container.
+	Worker Point = "WORKER"
+
+	// Lock is hit on each lock acquisition.
+	Lock Point = "LOCK"
+)
+
+var ErrInjected = errors.New("injected fault")
@@ -0,0 +1,12 @@
+//go:build !faults
+// +build !faults
+
+package faults
+
+// Inject does nothing without the "faults" build tag.
+func Inject(Point) error {
+	return nil
+}
+
+// Reset does nothing without the "faults" build tag.
+func Reset() {}
@@ -0,0 +1,54 @@
+//go:build faults
+// +build faults
+
+package faults
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+var (
+	callsLock sync.Mutex
+	calls     = map[Point]int{}
+)
+
+// Inject delays the call by the configured latency, and returns ErrInjected
+// if the call is one which is due to fail.
+func Inject(point Point) error {
+	latency, err := time.ParseDuration(os.Getenv(envVar(point, "LATENCY")))
+	if err == nil {
+		time.Sleep(latency)
+	}
+
+	failEvery, err := strconv.Atoi(os.Getenv(envVar(point, "FAIL_EVERY")))
+	if err != nil || failEvery <= 0 {
+		return nil
+	}
+
+	callsLock.Lock()
+	calls[point]++
+	call := calls[point]
+	callsLock.Unlock()
+
+	if call%failEvery != 0 {
+		return nil
+	}
+
+	return fmt.Errorf("%w: %s call %d", ErrInjected, point, call)
+}
+
+// Reset forgets the calls made so far, so that the next call of each point is
+// counted as the first one.
+func Reset() {
+	callsLock.Lock()
+	calls = map[Point]int{}
+	callsLock.Unlock()
+}
+
+func envVar(point Point, setting string) string {
+	return fmt.Sprintf("CONCOURSE_FAULTS_%s_%s", point, setting)
+}
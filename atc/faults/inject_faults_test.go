@@ -0,0 +1,52 @@
+//go:build faults
+// +build faults
+
+package faults_test
+
+import (
+	"errors"
+	"os"
+	"time"
+
+	"github.com/concourse/concourse/atc/faults"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Inject", func() {
+	BeforeEach(func() {
+		faults.Reset()
+	})
+
+	AfterEach(func() {
+		os.Unsetenv("CONCOURSE_FAULTS_DB_FAIL_EVERY")
+		os.Unsetenv("CONCOURSE_FAULTS_LOCK_LATENCY")
+	})
+
+	It("injects nothing unless configured to", func() {
+		for i := 0; i < 10; i++ {
+			Expect(faults.Inject(faults.DB)).To(Succeed())
+		}
+	})
+
+	It("fails every nth call of the point", func() {
+		os.Setenv("CONCOURSE_FAULTS_DB_FAIL_EVERY", "3")
+
+		Expect(faults.Inject(faults.DB)).To(Succeed())
+		Expect(faults.Inject(faults.DB)).To(Succeed())
+
+		err := faults.Inject(faults.DB)
+		Expect(errors.Is(err, faults.ErrInjected)).To(BeTrue())
+
+		Expect(faults.Inject(faults.Lock)).To(Succeed())
+		Expect(faults.Inject(faults.DB)).To(Succeed())
+	})
+
+	It("delays each call by the latency", func() {
+		os.Setenv("CONCOURSE_FAULTS_LOCK_LATENCY", "50ms")
+
+		start := time.Now()
+		Expect(faults.Inject(faults.Lock)).To(Succeed())
+		Expect(time.Since(start)).To(BeNumerically(">=", 50*time.Millisecond))
+	})
+})
@@ -0,0 +1,26 @@
+//go:build !faults
+// +build !faults
+
+package faults_test
+
+import (
+	"os"
+
+	"github.com/concourse/concourse/atc/faults"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Inject", func() {
+	BeforeEach(func() {
+		os.Setenv("CONCOURSE_FAULTS_DB_FAIL_EVERY", "1")
+	})
+
+	AfterEach(func() {
+		os.Unsetenv("CONCOURSE_FAULTS_DB_FAIL_EVERY")
+	})
+
+	It("never injects faults without the build tag", func() {
+		Expect(faults.Inject(faults.DB)).To(Succeed())
+	})
+})
@@ -0,0 +1,19 @@
+package atc
+
+import "encoding/json"
+
+// EnableFirehose is set when a firehose exporter is configured. Events are
+// only queued for the firehose while it is, so that they don't pile up with
+// nothing to publish them.
+var EnableFirehose bool
+
+// FirehoseEvent is an event published by the firehose exporter: any of the
+// events outgoing webhooks can subscribe to, with the same data. Events are
+// published at least once, so consumers should deduplicate them by ID.
+type FirehoseEvent struct {
+	ID    int64           `json:"id"`
+	Team  string          `json:"team,omitempty"`
+	Event WebhookEvent    `json:"event"`
+	Time  int64           `json:"time"`
+	Data  json.RawMessage `json:"data"`
+}
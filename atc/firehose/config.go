@@ -0,0 +1,82 @@
+// Package firehose publishes the events of builds, pipelines, workers and
+// team requests to Kafka or NATS, for organizations which centralize their
+// CI telemetry.
+//
+// Events are queued in an outbox table in the same transaction as the change
+// they're about, and only deleted from it once they have been published, so
+// each event is published at least once.
+package firehose
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/concourse/flag"
+)
+
+type Config struct {
+	KafkaRESTURL flag.URL          `long:"firehose-kafka-rest-url" description:"URL of a Kafka REST Proxy to publish events to Kafka through. Basic auth credentials may be given in the URL."`
+	NATSURL      flag.URL          `long:"firehose-nats-url" description:"URL of a NATS server to publish events to, with the nats:// or, for TLS, tls:// scheme. A user and password, or a token as the user, may be given in the URL."`
+	Topic        string            `long:"firehose-topic" default:"concourse.events" description:"Kafka topic or NATS subject to publish events to."`
+	TeamTopics   map[string]string `long:"firehose-team-topic" value-name:"TEAM:TOPIC" description:"Kafka topic or NATS subject to publish the events of a team to, instead of the default one. Can be specified multiple times."`
+	Interval     time.Duration     `long:"firehose-interval" default:"5s" description:"Interval on which queued events are published."`
+}
+
+// IsConfigured returns whether events are to be published anywhere.
+func (config Config) IsConfigured() bool {
+	return config.KafkaRESTURL.URL != nil || config.NATSURL.URL != nil
+}
+
+func (config Config) Validate() error {
+	if config.KafkaRESTURL.URL != nil && config.NATSURL.URL != nil {
+		return errors.New("cannot specify both --firehose-kafka-rest-url and --firehose-nats-url")
+	}
+
+	if config.KafkaRESTURL.URL != nil {
+		scheme := config.KafkaRESTURL.URL.Scheme
+		if scheme != "http" && scheme != "https" {
+			return errors.New("--firehose-kafka-rest-url must be an http or https URL")
+		}
+	}
+
+	if config.NATSURL.URL != nil {
+		scheme := config.NATSURL.URL.Scheme
+		if scheme != "nats" && scheme != "tls" {
+			return errors.New("--firehose-nats-url must be a nats or tls URL")
+		}
+	}
+
+	if config.IsConfigured() && config.Topic == "" {
+		return errors.New("--firehose-topic must not be empty")
+	}
+
+	return nil
+}
+
+// TopicFor returns the topic to publish the events of the team to. The
+// events of the cluster, which have no team, go to the default topic.
+func (config Config) TopicFor(team string) string {
+	if topic, found := config.TeamTopics[team]; found && team != "" {
+		return topic
+	}
+
+	return config.Topic
+}
+
+// Publisher returns the publisher for wherever events are to be published.
+func (config Config) Publisher() (Publisher, error) {
+	switch {
+	case config.KafkaRESTURL.URL != nil:
+		return NewKafkaRESTPublisher(config.KafkaRESTURL.URL, &http.Client{
+			Transport: http.DefaultTransport,
+			Timeout:   30 * time.Second,
+		}), nil
+
+	case config.NATSURL.URL != nil:
+		return NewNATSPublisher(config.NATSURL.URL, 30*time.Second), nil
+	}
+
+	return nil, fmt.Errorf("firehose is not configured")
+}
@@ -0,0 +1,65 @@
+package firehose_test
+
+import (
+	"net/url"
+
+	"github.com/concourse/concourse/atc/firehose"
+	"github.com/concourse/flag"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func parseURL(raw string) flag.URL {
+	u, err := url.Parse(raw)
+	Expect(err).ToNot(HaveOccurred())
+	return flag.URL{URL: u}
+}
+
+var _ = Describe("Config", func() {
+	var config firehose.Config
+
+	BeforeEach(func() {
+		config = firehose.Config{Topic: "events"}
+	})
+
+	Describe("Validate", func() {
+		It("accepts no destination, as the firehose is optional", func() {
+			Expect(config.IsConfigured()).To(BeFalse())
+			Expect(config.Validate()).To(Succeed())
+		})
+
+		It("accepts a Kafka REST Proxy", func() {
+			config.KafkaRESTURL = parseURL("https://kafka-rest:8082")
+			Expect(config.IsConfigured()).To(BeTrue())
+			Expect(config.Validate()).To(Succeed())
+		})
+
+		It("accepts a NATS server", func() {
+			config.NATSURL = parseURL("tls://nats:4222")
+			Expect(config.Validate()).To(Succeed())
+		})
+
+		It("rejects both at once", func() {
+			config.KafkaRESTURL = parseURL("https://kafka-rest:8082")
+			config.NATSURL = parseURL("nats://nats:4222")
+			Expect(config.Validate()).To(MatchError(ContainSubstring("cannot specify both")))
+		})
+
+		It("rejects URLs of the wrong scheme", func() {
+			config.NATSURL = parseURL("https://nats:4222")
+			Expect(config.Validate()).To(MatchError(ContainSubstring("must be a nats or tls URL")))
+		})
+	})
+
+	Describe("TopicFor", func() {
+		BeforeEach(func() {
+			config.TeamTopics = map[string]string{"some-team": "some-team-events"}
+		})
+
+		It("returns the topic of the team, or the default one", func() {
+			Expect(config.TopicFor("some-team")).To(Equal("some-team-events"))
+			Expect(config.TopicFor("other-team")).To(Equal("events"))
+			Expect(config.TopicFor("")).To(Equal("events"))
+		})
+	})
+})
@@ -0,0 +1,117 @@
+package firehose
+
+import (
+	"context"
+	"encoding/json"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc/db"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+
+// Message is a message to publish, keyed so that the messages with the same
+// key, i.e. the events of a team, end up in the same Kafka partition.
+type Message struct {
+	Key   string
+	Value []byte
+}
+
+// Publisher publishes messages to a topic, returning only once they have
+// been accepted.
+//
+//counterfeiter:generate . Publisher
+type Publisher interface {
+	Publish(ctx context.Context, topic string, messages []Message) error
+}
+
+const batchSize = 500
+
+// Exporter publishes the events queued in the outbox, in the order they were
+// queued, and deletes them once they have been published.
+type Exporter struct {
+	outbox    db.FirehoseOutbox
+	publisher Publisher
+	config    Config
+}
+
+func NewExporter(outbox db.FirehoseOutbox, publisher Publisher, config Config) *Exporter {
+	return &Exporter{
+		outbox:    outbox,
+		publisher: publisher,
+		config:    config,
+	}
+}
+
+// Run publishes every queued event. When publishing fails, the events
+// published so far are deleted from the outbox and the rest are retried on
+// the next run, so that events are published in order.
+func (exporter *Exporter) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx).Session("firehose-exporter")
+
+	logger.Debug("start")
+	defer logger.Debug("done")
+
+	for {
+		events, err := exporter.outbox.Pending(batchSize)
+		if err != nil {
+			logger.Error("failed-to-get-pending-events", err)
+			return err
+		}
+
+		var (
+			published  []int64
+			publishErr error
+		)
+
+		for start := 0; start < len(events); {
+			topic := exporter.config.TopicFor(events[start].Team)
+
+			var (
+				ids      []int64
+				messages []Message
+			)
+
+			end := start
+			for ; end < len(events) && exporter.config.TopicFor(events[end].Team) == topic; end++ {
+				value, err := json.Marshal(events[end])
+				if err != nil {
+					logger.Error("failed-to-encode-event", err)
+					return err
+				}
+
+				ids = append(ids, events[end].ID)
+				messages = append(messages, Message{
+					Key:   events[end].Team,
+					Value: value,
+				})
+			}
+
+			publishErr = exporter.publisher.Publish(ctx, topic, messages)
+			if publishErr != nil {
+				logger.Error("failed-to-publish-events", publishErr, lager.Data{"topic": topic})
+				break
+			}
+
+			published = append(published, ids...)
+			start = end
+		}
+
+		err = exporter.outbox.Delete(published)
+		if err != nil {
+			// the events will be published again, which consumers must be
+			// prepared for anyway
+			logger.Error("failed-to-delete-published-events", err)
+			return err
+		}
+
+		if publishErr != nil {
+			return publishErr
+		}
+
+		if len(events) < batchSize {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package firehose_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/firehose"
+	"github.com/concourse/concourse/atc/firehose/firehosefakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Exporter", func() {
+	var (
+		fakeOutbox    *dbfakes.FakeFirehoseOutbox
+		fakePublisher *firehosefakes.FakePublisher
+		config        firehose.Config
+
+		events []atc.FirehoseEvent
+
+		runErr error
+	)
+
+	BeforeEach(func() {
+		fakeOutbox = new(dbfakes.FakeFirehoseOutbox)
+		fakePublisher = new(firehosefakes.FakePublisher)
+
+		config = firehose.Config{
+			Topic:      "events",
+			TeamTopics: map[string]string{"team-b": "team-b-events"},
+		}
+
+		events = []atc.FirehoseEvent{
+			{ID: 1, Team: "team-a", Event: atc.WebhookEventBuildStarted, Time: 10, Data: json.RawMessage(`{"id":1}`)},
+			{ID: 2, Team: "team-a", Event: atc.WebhookEventBuildFinished, Time: 11, Data: json.RawMessage(`{"id":1}`)},
+			{ID: 3, Team: "team-b", Event: atc.WebhookEventPipelineSet, Time: 12, Data: json.RawMessage(`{"id":2}`)},
+			{ID: 4, Event: atc.WebhookEventTeamRequested, Time: 13, Data: json.RawMessage(`{"id":3}`)},
+		}
+
+		fakeOutbox.PendingReturns(events, nil)
+	})
+
+	JustBeforeEach(func() {
+		runErr = firehose.NewExporter(fakeOutbox, fakePublisher, config).Run(context.TODO())
+	})
+
+	It("publishes the events in order, to the topic of their team", func() {
+		Expect(runErr).ToNot(HaveOccurred())
+		Expect(fakePublisher.PublishCallCount()).To(Equal(3))
+
+		_, topic, messages := fakePublisher.PublishArgsForCall(0)
+		Expect(topic).To(Equal("events"))
+		Expect(messages).To(HaveLen(2))
+		Expect(messages[0].Key).To(Equal("team-a"))
+		Expect(messages[0].Value).To(MatchJSON(`{"id":1,"team":"team-a","event":"build_started","time":10,"data":{"id":1}}`))
+
+		_, topic, messages = fakePublisher.PublishArgsForCall(1)
+		Expect(topic).To(Equal("team-b-events"))
+		Expect(messages).To(HaveLen(1))
+
+		_, topic, messages = fakePublisher.PublishArgsForCall(2)
+		Expect(topic).To(Equal("events"))
+		Expect(messages).To(HaveLen(1))
+		Expect(messages[0].Key).To(BeEmpty())
+	})
+
+	It("deletes the published events", func() {
+		Expect(fakeOutbox.DeleteCallCount()).To(Equal(1))
+		Expect(fakeOutbox.DeleteArgsForCall(0)).To(Equal([]int64{1, 2, 3, 4}))
+	})
+
+	Context("when publishing fails", func() {
+		BeforeEach(func() {
+			fakePublisher.PublishReturnsOnCall(1, errors.New("broker down"))
+		})
+
+		It("deletes only the events published before, leaving the rest for the next run", func() {
+			Expect(runErr).To(MatchError("broker down"))
+			Expect(fakePublisher.PublishCallCount()).To(Equal(2))
+
+			Expect(fakeOutbox.DeleteCallCount()).To(Equal(1))
+			Expect(fakeOutbox.DeleteArgsForCall(0)).To(Equal([]int64{1, 2}))
+		})
+	})
+
+	Context("when there are no events", func() {
+		BeforeEach(func() {
+			fakeOutbox.PendingReturns(nil, nil)
+		})
+
+		It("publishes nothing", func() {
+			Expect(runErr).ToNot(HaveOccurred())
+			Expect(fakePublisher.PublishCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when getting the events fails", func() {
+		BeforeEach(func() {
+			fakeOutbox.PendingReturns(nil, errors.New("nope"))
+		})
+
+		It("returns the error", func() {
+			Expect(runErr).To(MatchError("nope"))
+			Expect(fakePublisher.PublishCallCount()).To(BeZero())
+		})
+	})
+})
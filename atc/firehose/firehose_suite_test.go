@@ -0,0 +1,13 @@
+package firehose_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestFirehose(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Firehose Suite")
+}
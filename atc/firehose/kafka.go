@@ -0,0 +1,104 @@
+package firehose
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	kafkaRecordsContentType = "application/vnd.kafka.json.v2+json"
+	kafkaResponseAccept     = "application/vnd.kafka.v2+json"
+)
+
+type kafkaRESTPublisher struct {
+	url    *url.URL
+	client *http.Client
+}
+
+// NewKafkaRESTPublisher returns a publisher which produces records to Kafka
+// through the v2 API of a Kafka REST Proxy.
+func NewKafkaRESTPublisher(url *url.URL, client *http.Client) Publisher {
+	return &kafkaRESTPublisher{
+		url:    url,
+		client: client,
+	}
+}
+
+type kafkaRecords struct {
+	Records []kafkaRecord `json:"records"`
+}
+
+type kafkaRecord struct {
+	Key   string          `json:"key,omitempty"`
+	Value json.RawMessage `json:"value"`
+}
+
+type kafkaOffsets struct {
+	Offsets []struct {
+		ErrorCode *int   `json:"error_code"`
+		Error     string `json:"error"`
+	} `json:"offsets"`
+}
+
+func (publisher *kafkaRESTPublisher) Publish(ctx context.Context, topic string, messages []Message) error {
+	records := kafkaRecords{}
+	for _, message := range messages {
+		records.Records = append(records.Records, kafkaRecord{
+			Key:   message.Key,
+			Value: message.Value,
+		})
+	}
+
+	body, err := json.Marshal(records)
+	if err != nil {
+		return err
+	}
+
+	topicURL := *publisher.url
+	topicURL.User = nil
+	topicURL.Path = strings.TrimSuffix(topicURL.Path, "/") + "/topics/" + url.PathEscape(topic)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, topicURL.String(), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", kafkaRecordsContentType)
+	req.Header.Set("Accept", kafkaResponseAccept)
+
+	if user := publisher.url.User; user != nil {
+		password, _ := user.Password()
+		req.SetBasicAuth(user.Username(), password)
+	}
+
+	resp, err := publisher.client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected response status: %s", resp.Status)
+	}
+
+	// records are produced individually, so some of them may have failed
+	var offsets kafkaOffsets
+	err = json.NewDecoder(resp.Body).Decode(&offsets)
+	if err != nil {
+		return fmt.Errorf("malformed response: %w", err)
+	}
+
+	for i, offset := range offsets.Offsets {
+		if offset.ErrorCode != nil || offset.Error != "" {
+			return fmt.Errorf("record %d was not produced: %s", i, offset.Error)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+package firehose_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/concourse/concourse/atc/firehose"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("KafkaRESTPublisher", func() {
+	var (
+		server     *ghttp.Server
+		publisher  firehose.Publisher
+		publishErr error
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+
+		proxyURL, err := url.Parse(server.URL())
+		Expect(err).ToNot(HaveOccurred())
+		proxyURL.User = url.UserPassword("some-user", "some-password")
+
+		publisher = firehose.NewKafkaRESTPublisher(proxyURL, http.DefaultClient)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	JustBeforeEach(func() {
+		publishErr = publisher.Publish(context.TODO(), "some.topic", []firehose.Message{
+			{Key: "some-team", Value: []byte(`{"id":1}`)},
+			{Value: []byte(`{"id":2}`)},
+		})
+	})
+
+	Context("when the records are produced", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest("POST", "/topics/some.topic"),
+				ghttp.VerifyBasicAuth("some-user", "some-password"),
+				ghttp.VerifyContentType("application/vnd.kafka.json.v2+json"),
+				func(w http.ResponseWriter, r *http.Request) {
+					body, err := io.ReadAll(r.Body)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(body).To(MatchJSON(`{"records":[{"key":"some-team","value":{"id":1}},{"value":{"id":2}}]}`))
+				},
+				ghttp.RespondWith(http.StatusOK, `{"offsets":[{"partition":0,"offset":1},{"partition":0,"offset":2}]}`),
+			))
+		})
+
+		It("succeeds", func() {
+			Expect(publishErr).ToNot(HaveOccurred())
+			Expect(server.ReceivedRequests()).To(HaveLen(1))
+		})
+	})
+
+	Context("when a record fails to be produced", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(ghttp.RespondWith(http.StatusOK, `{"offsets":[{"partition":0,"offset":1},{"error_code":50002,"error":"leader not available"}]}`))
+		})
+
+		It("fails", func() {
+			Expect(publishErr).To(MatchError("record 1 was not produced: leader not available"))
+		})
+	})
+
+	Context("when the proxy responds with an error", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(ghttp.RespondWith(http.StatusNotFound, `{"error_code":40401,"message":"Topic not found."}`))
+		})
+
+		It("fails", func() {
+			Expect(publishErr).To(MatchError(ContainSubstring("unexpected response status: 404")))
+		})
+	})
+})
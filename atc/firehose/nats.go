@@ -0,0 +1,165 @@
+package firehose
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+)
+
+type natsPublisher struct {
+	url     *url.URL
+	timeout time.Duration
+}
+
+// NewNATSPublisher returns a publisher which publishes messages to a NATS
+// server. It speaks just enough of the NATS protocol to connect, publish,
+// and wait for the server to have processed what was published.
+//
+// Plain NATS doesn't keep messages which no one subscribes to, so for them
+// not to be lost, the subjects should be captured by a JetStream stream.
+func NewNATSPublisher(url *url.URL, timeout time.Duration) Publisher {
+	return &natsPublisher{
+		url:     url,
+		timeout: timeout,
+	}
+}
+
+type natsInfo struct {
+	TLSRequired bool  `json:"tls_required"`
+	MaxPayload  int64 `json:"max_payload"`
+}
+
+type natsConnect struct {
+	Verbose   bool   `json:"verbose"`
+	Pedantic  bool   `json:"pedantic"`
+	Name      string `json:"name"`
+	Lang      string `json:"lang"`
+	User      string `json:"user,omitempty"`
+	Pass      string `json:"pass,omitempty"`
+	AuthToken string `json:"auth_token,omitempty"`
+}
+
+func (publisher *natsPublisher) Publish(ctx context.Context, subject string, messages []Message) error {
+	if subject == "" || strings.ContainsAny(subject, " \t\r\n") {
+		return fmt.Errorf("invalid subject '%s'", subject)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, publisher.timeout)
+	defer cancel()
+
+	dialer := &net.Dialer{}
+	conn, err := dialer.DialContext(ctx, "tcp", publisher.url.Host)
+	if err != nil {
+		return err
+	}
+
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		err = conn.SetDeadline(deadline)
+		if err != nil {
+			return err
+		}
+	}
+
+	reader := bufio.NewReader(conn)
+
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return err
+	}
+
+	if !strings.HasPrefix(line, "INFO ") {
+		return fmt.Errorf("unexpected greeting: %s", strings.TrimSpace(line))
+	}
+
+	var info natsInfo
+	err = json.Unmarshal([]byte(strings.TrimPrefix(line, "INFO ")), &info)
+	if err != nil {
+		return fmt.Errorf("malformed server info: %w", err)
+	}
+
+	var rw net.Conn = conn
+	if publisher.url.Scheme == "tls" {
+		tlsConn := tls.Client(conn, &tls.Config{ServerName: publisher.url.Hostname()})
+
+		err = tlsConn.HandshakeContext(ctx)
+		if err != nil {
+			return err
+		}
+
+		rw = tlsConn
+		reader = bufio.NewReader(tlsConn)
+	} else if info.TLSRequired {
+		return errors.New("server requires TLS, which is used with a tls:// URL")
+	}
+
+	connect := natsConnect{
+		Name: "concourse-firehose",
+		Lang: "go",
+	}
+
+	if user := publisher.url.User; user != nil {
+		if password, set := user.Password(); set {
+			connect.User = user.Username()
+			connect.Pass = password
+		} else {
+			connect.AuthToken = user.Username()
+		}
+	}
+
+	connectPayload, err := json.Marshal(connect)
+	if err != nil {
+		return err
+	}
+
+	writer := bufio.NewWriter(rw)
+	fmt.Fprintf(writer, "CONNECT %s\r\n", connectPayload)
+
+	for _, message := range messages {
+		if info.MaxPayload > 0 && int64(len(message.Value)) > info.MaxPayload {
+			return fmt.Errorf("message of %d bytes exceeds the server's maximum payload of %d bytes", len(message.Value), info.MaxPayload)
+		}
+
+		fmt.Fprintf(writer, "PUB %s %d\r\n", subject, len(message.Value))
+		writer.Write(message.Value)
+		writer.WriteString("\r\n")
+	}
+
+	// the server answers a PING only once it has processed everything sent
+	// before it, and reports any error before
+	writer.WriteString("PING\r\n")
+
+	err = writer.Flush()
+	if err != nil {
+		return err
+	}
+
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return err
+		}
+
+		line = strings.TrimSpace(line)
+
+		switch {
+		case line == "PONG":
+			return nil
+		case strings.HasPrefix(line, "-ERR"):
+			return fmt.Errorf("server error: %s", strings.TrimSpace(strings.TrimPrefix(line, "-ERR")))
+		case line == "PING":
+			_, err := rw.Write([]byte("PONG\r\n"))
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package firehose_test
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/concourse/concourse/atc/firehose"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("NATSPublisher", func() {
+	var (
+		listener  net.Listener
+		received  chan string
+		reply     string
+		publisher firehose.Publisher
+
+		publishErr error
+	)
+
+	BeforeEach(func() {
+		var err error
+		listener, err = net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).ToNot(HaveOccurred())
+
+		received = make(chan string, 10)
+		reply = "PONG"
+
+		serverURL := &url.URL{Scheme: "nats", Host: listener.Addr().String(), User: url.User("some-token")}
+		publisher = firehose.NewNATSPublisher(serverURL, 10*time.Second)
+	})
+
+	AfterEach(func() {
+		listener.Close()
+	})
+
+	JustBeforeEach(func() {
+		go func() {
+			defer GinkgoRecover()
+
+			conn, err := listener.Accept()
+			Expect(err).ToNot(HaveOccurred())
+
+			defer conn.Close()
+
+			fmt.Fprintf(conn, "INFO {\"max_payload\":1048576}\r\n")
+
+			reader := bufio.NewReader(conn)
+			for {
+				line, err := reader.ReadString('\n')
+				if err != nil {
+					return
+				}
+
+				line = strings.TrimSpace(line)
+				if strings.HasPrefix(line, "PUB ") {
+					payload, err := reader.ReadString('\n')
+					Expect(err).ToNot(HaveOccurred())
+					line += " " + strings.TrimSpace(payload)
+				}
+
+				received <- line
+
+				if line == "PING" {
+					fmt.Fprintf(conn, "%s\r\n", reply)
+				}
+			}
+		}()
+
+		publishErr = publisher.Publish(context.TODO(), "some.subject", []firehose.Message{
+			{Key: "some-team", Value: []byte(`{"id":1}`)},
+			{Value: []byte(`{"id":2}`)},
+		})
+	})
+
+	It("connects, publishes the messages and waits for the server to process them", func() {
+		Expect(publishErr).ToNot(HaveOccurred())
+
+		Expect(<-received).To(And(HavePrefix("CONNECT "), ContainSubstring(`"auth_token":"some-token"`)))
+		Expect(<-received).To(Equal(`PUB some.subject 8 {"id":1}`))
+		Expect(<-received).To(Equal(`PUB some.subject 8 {"id":2}`))
+		Expect(<-received).To(Equal("PING"))
+	})
+
+	Context("when the server reports an error", func() {
+		BeforeEach(func() {
+			reply = "-ERR 'Permissions Violation for Publish to some.subject'"
+		})
+
+		It("fails", func() {
+			Expect(publishErr).To(MatchError("server error: 'Permissions Violation for Publish to some.subject'"))
+		})
+	})
+})
@@ -0,0 +1,49 @@
+// Package flakiness flags jobs whose builds succeed and fail in turn without
+// their inputs changing.
+package flakiness
+
+import (
+	"context"
+
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc/db"
+)
+
+// DefaultCriteria analyzes the last 50 builds of each job, and flags it as
+// flaky if at least a fifth of its builds with unchanged inputs had another
+// outcome than the build before them, as long as that happened at least
+// twice.
+var DefaultCriteria = db.FlakinessCriteria{
+	Builds:   50,
+	MinScore: 0.2,
+	MinFlips: 2,
+}
+
+type Analyzer struct {
+	repository db.FlakinessRepository
+	criteria   db.FlakinessCriteria
+}
+
+func NewAnalyzer(repository db.FlakinessRepository, criteria db.FlakinessCriteria) *Analyzer {
+	return &Analyzer{
+		repository: repository,
+		criteria:   criteria,
+	}
+}
+
+// Run analyzes the jobs which have had new builds since they were last
+// analyzed.
+func (analyzer *Analyzer) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx).Session("flakiness-analyzer")
+
+	logger.Debug("start")
+	defer logger.Debug("done")
+
+	err := analyzer.repository.AnalyzeFlakiness(analyzer.criteria)
+	if err != nil {
+		logger.Error("failed-to-analyze-flakiness", err)
+		return err
+	}
+
+	return nil
+}
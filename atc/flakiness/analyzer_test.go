@@ -0,0 +1,46 @@
+package flakiness_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/flakiness"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Analyzer", func() {
+	var (
+		fakeRepository *dbfakes.FakeFlakinessRepository
+		criteria       db.FlakinessCriteria
+
+		runErr error
+	)
+
+	BeforeEach(func() {
+		fakeRepository = new(dbfakes.FakeFlakinessRepository)
+		criteria = db.FlakinessCriteria{Builds: 10, MinScore: 0.5, MinFlips: 3}
+	})
+
+	JustBeforeEach(func() {
+		runErr = flakiness.NewAnalyzer(fakeRepository, criteria).Run(context.TODO())
+	})
+
+	It("analyzes the jobs with the criteria", func() {
+		Expect(runErr).ToNot(HaveOccurred())
+		Expect(fakeRepository.AnalyzeFlakinessCallCount()).To(Equal(1))
+		Expect(fakeRepository.AnalyzeFlakinessArgsForCall(0)).To(Equal(criteria))
+	})
+
+	Context("when analyzing fails", func() {
+		BeforeEach(func() {
+			fakeRepository.AnalyzeFlakinessReturns(errors.New("nope"))
+		})
+
+		It("returns the error", func() {
+			Expect(runErr).To(MatchError("nope"))
+		})
+	})
+})
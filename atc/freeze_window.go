@@ -0,0 +1,31 @@
+package atc
+
+import "errors"
+
+// FreezeWindow is a period of time during which the scheduler does not start
+// any new builds, e.g. for a change freeze. Builds which become pending
+// during the window are started once it is over.
+//
+// A freeze window belongs either to a team, freezing the team's pipelines,
+// or to the cluster, freezing the pipelines of every team. Pipelines named
+// in ExemptPipelines keep running as usual.
+type FreezeWindow struct {
+	ID              int      `json:"id"`
+	TeamName        string   `json:"team_name,omitempty"`
+	StartTime       int64    `json:"start_time"`
+	EndTime         int64    `json:"end_time"`
+	Reason          string   `json:"reason,omitempty"`
+	ExemptPipelines []string `json:"exempt_pipelines,omitempty"`
+}
+
+func (window FreezeWindow) Validate() error {
+	if window.StartTime == 0 || window.EndTime == 0 {
+		return errors.New("missing start_time or end_time")
+	}
+
+	if window.EndTime <= window.StartTime {
+		return errors.New("end_time must be after start_time")
+	}
+
+	return nil
+}
@@ -0,0 +1,36 @@
+package gc
+
+import (
+	"context"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc/db"
+)
+
+type idempotencyKeysCollector struct {
+	repository db.IdempotencyKeyRepository
+	ttl        time.Duration
+}
+
+func NewIdempotencyKeysCollector(repository db.IdempotencyKeyRepository, ttl time.Duration) *idempotencyKeysCollector {
+	return &idempotencyKeysCollector{
+		repository: repository,
+		ttl:        ttl,
+	}
+}
+
+func (c *idempotencyKeysCollector) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx).Session("idempotency-keys-collector")
+
+	logger.Debug("start")
+	defer logger.Debug("done")
+
+	_, err := c.repository.RemoveExpiredIdempotencyKeys(c.ttl)
+	if err != nil {
+		logger.Error("failed-to-remove-expired-idempotency-keys", err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,44 @@
+package gc_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/gc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("IdempotencyKeysCollector", func() {
+	var collector GcCollector
+	var fakeRepository *dbfakes.FakeIdempotencyKeyRepository
+
+	BeforeEach(func() {
+		fakeRepository = new(dbfakes.FakeIdempotencyKeyRepository)
+
+		collector = gc.NewIdempotencyKeysCollector(fakeRepository, 24*time.Hour)
+	})
+
+	Describe("Run", func() {
+		It("removes the idempotency keys older than the ttl", func() {
+			err := collector.Run(context.TODO())
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeRepository.RemoveExpiredIdempotencyKeysCallCount()).To(Equal(1))
+			Expect(fakeRepository.RemoveExpiredIdempotencyKeysArgsForCall(0)).To(Equal(24 * time.Hour))
+		})
+
+		Context("when removing them fails", func() {
+			BeforeEach(func() {
+				fakeRepository.RemoveExpiredIdempotencyKeysReturns(0, errors.New("disaster"))
+			})
+
+			It("returns the error", func() {
+				err := collector.Run(context.TODO())
+				Expect(err).To(MatchError("disaster"))
+			})
+		})
+	})
+})
@@ -0,0 +1,97 @@
+package gc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/jobqueue"
+)
+
+// DestroyQueue is the job queue the containers and volumes which workers no
+// longer have are removed from the database through.
+const DestroyQueue = "gc-destroy"
+
+const (
+	destroyContainers = "containers"
+	destroyVolumes    = "volumes"
+)
+
+type destroyJob struct {
+	WorkerName string   `json:"worker_name"`
+	Kind       string   `json:"kind"`
+	Handles    []string `json:"handles"`
+}
+
+type queuedDestroyer struct {
+	queue     db.JobQueue
+	destroyer Destroyer
+}
+
+// NewQueuedDestroyer returns a Destroyer which, instead of removing the
+// destroyed containers and volumes from the database as the workers report
+// them, queues their removal to be done by the handler of the DestroyQueue.
+// Removals which fail are then retried, by whichever ATC is around.
+func NewQueuedDestroyer(queue db.JobQueue, destroyer Destroyer) Destroyer {
+	return &queuedDestroyer{
+		queue:     queue,
+		destroyer: destroyer,
+	}
+}
+
+func (d *queuedDestroyer) DestroyContainers(workerName string, currentHandles []string) error {
+	return d.enqueue(workerName, destroyContainers, currentHandles)
+}
+
+func (d *queuedDestroyer) DestroyVolumes(workerName string, currentHandles []string) error {
+	return d.enqueue(workerName, destroyVolumes, currentHandles)
+}
+
+func (d *queuedDestroyer) FindDestroyingVolumesForGc(workerName string) ([]string, error) {
+	return d.destroyer.FindDestroyingVolumesForGc(workerName)
+}
+
+func (d *queuedDestroyer) enqueue(workerName string, kind string, currentHandles []string) error {
+	if workerName == "" {
+		return errors.New("worker-name-must-be-provided")
+	}
+
+	if currentHandles == nil {
+		return nil
+	}
+
+	return d.queue.Enqueue(DestroyQueue, destroyJob{
+		WorkerName: workerName,
+		Kind:       kind,
+		Handles:    currentHandles,
+	})
+}
+
+// NewDestroyJobHandler returns the handler of the jobs of the DestroyQueue.
+func NewDestroyJobHandler(destroyer Destroyer) jobqueue.Handler {
+	return func(ctx context.Context, payload json.RawMessage) error {
+		var job destroyJob
+		err := json.Unmarshal(payload, &job)
+		if err != nil {
+			return err
+		}
+
+		// an empty list of handles means the worker has none left, so it
+		// mustn't turn into a nil one
+		handles := job.Handles
+		if handles == nil {
+			handles = []string{}
+		}
+
+		switch job.Kind {
+		case destroyContainers:
+			return destroyer.DestroyContainers(job.WorkerName, handles)
+		case destroyVolumes:
+			return destroyer.DestroyVolumes(job.WorkerName, handles)
+		default:
+			return fmt.Errorf("unknown kind of destroy job: %s", job.Kind)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package gc_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/gc"
+	"github.com/concourse/concourse/atc/gc/gcfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("QueuedDestroyer", func() {
+	var (
+		fakeQueue     *dbfakes.FakeJobQueue
+		fakeDestroyer *gcfakes.FakeDestroyer
+		destroyer     gc.Destroyer
+	)
+
+	BeforeEach(func() {
+		fakeQueue = new(dbfakes.FakeJobQueue)
+		fakeDestroyer = new(gcfakes.FakeDestroyer)
+
+		destroyer = gc.NewQueuedDestroyer(fakeQueue, fakeDestroyer)
+	})
+
+	// handle runs the job which was enqueued through the handler of the
+	// destroy queue
+	handle := func() error {
+		Expect(fakeQueue.EnqueueCallCount()).To(Equal(1))
+		queue, payload := fakeQueue.EnqueueArgsForCall(0)
+		Expect(queue).To(Equal(gc.DestroyQueue))
+
+		data, err := json.Marshal(payload)
+		Expect(err).ToNot(HaveOccurred())
+
+		return gc.NewDestroyJobHandler(fakeDestroyer)(context.TODO(), data)
+	}
+
+	It("queues the removal of the destroyed containers", func() {
+		Expect(destroyer.DestroyContainers("some-worker", []string{"some-handle"})).To(Succeed())
+		Expect(fakeDestroyer.DestroyContainersCallCount()).To(BeZero())
+
+		Expect(handle()).To(Succeed())
+
+		Expect(fakeDestroyer.DestroyContainersCallCount()).To(Equal(1))
+		workerName, handles := fakeDestroyer.DestroyContainersArgsForCall(0)
+		Expect(workerName).To(Equal("some-worker"))
+		Expect(handles).To(Equal([]string{"some-handle"}))
+	})
+
+	It("queues the removal of the destroyed volumes", func() {
+		Expect(destroyer.DestroyVolumes("some-worker", []string{})).To(Succeed())
+
+		Expect(handle()).To(Succeed())
+
+		Expect(fakeDestroyer.DestroyVolumesCallCount()).To(Equal(1))
+		workerName, handles := fakeDestroyer.DestroyVolumesArgsForCall(0)
+		Expect(workerName).To(Equal("some-worker"))
+		Expect(handles).To(BeEmpty())
+		Expect(handles).ToNot(BeNil())
+	})
+
+	It("fails the job when the removal fails, so that it is retried", func() {
+		fakeDestroyer.DestroyContainersReturns(errors.New("nope"))
+
+		Expect(destroyer.DestroyContainers("some-worker", []string{"some-handle"})).To(Succeed())
+		Expect(handle()).To(MatchError("nope"))
+	})
+
+	It("queues nothing without handles", func() {
+		Expect(destroyer.DestroyContainers("some-worker", nil)).To(Succeed())
+		Expect(fakeQueue.EnqueueCallCount()).To(BeZero())
+	})
+
+	It("requires a worker name", func() {
+		Expect(destroyer.DestroyContainers("", []string{"some-handle"})).ToNot(Succeed())
+		Expect(fakeQueue.EnqueueCallCount()).To(BeZero())
+	})
+})
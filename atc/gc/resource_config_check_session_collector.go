@@ -49,5 +49,11 @@ func (rccsc *resourceConfigCheckSessionCollector) Run(ctx context.Context) error
 		logger.Error("failed-to-clean-up-inactive-resource-config-check-sessions", err)
 	}
 
+	err = rccsc.configCheckSessionLifecycle.CleanExpiredCheckContainerPoolSlots()
+	if err != nil {
+		errs = multierror.Append(errs, err)
+		logger.Error("failed-to-clean-up-expired-check-container-pool-slots", err)
+	}
+
 	return errs
 }
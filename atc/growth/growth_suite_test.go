@@ -0,0 +1,13 @@
+package growth_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestGrowth(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Growth Suite")
+}
@@ -0,0 +1,58 @@
+// Package growth periodically snapshots what grows the database the most, so
+// that operators can see how quickly each team is growing it.
+package growth
+
+import (
+	"context"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc/db"
+)
+
+type Config struct {
+	Retention time.Duration `long:"db-growth-retention" default:"2160h" description:"How long snapshots of the growth of the database are kept for. 0 means they are kept forever."`
+}
+
+// Snapshotter snapshots every team and the cluster as a whole, and prunes the
+// snapshots which have outlived the retention.
+type Snapshotter struct {
+	repository db.GrowthRepository
+	config     Config
+	clock      func() time.Time
+}
+
+func NewSnapshotter(repository db.GrowthRepository, config Config) *Snapshotter {
+	return &Snapshotter{
+		repository: repository,
+		config:     config,
+		clock:      time.Now,
+	}
+}
+
+func (snapshotter *Snapshotter) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx).Session("growth-snapshotter")
+
+	logger.Debug("start")
+	defer logger.Debug("done")
+
+	now := snapshotter.clock()
+
+	err := snapshotter.repository.TakeSnapshot(now)
+	if err != nil {
+		logger.Error("failed-to-take-snapshot", err)
+		return err
+	}
+
+	if snapshotter.config.Retention == 0 {
+		return nil
+	}
+
+	err = snapshotter.repository.PruneSnapshots(now.Add(-snapshotter.config.Retention))
+	if err != nil {
+		logger.Error("failed-to-prune-snapshots", err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package growth_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/growth"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Snapshotter", func() {
+	var (
+		fakeRepository *dbfakes.FakeGrowthRepository
+		config         growth.Config
+
+		runErr error
+	)
+
+	BeforeEach(func() {
+		fakeRepository = new(dbfakes.FakeGrowthRepository)
+		config = growth.Config{Retention: 24 * time.Hour}
+	})
+
+	JustBeforeEach(func() {
+		runErr = growth.NewSnapshotter(fakeRepository, config).Run(context.TODO())
+	})
+
+	It("takes a snapshot and prunes those older than the retention", func() {
+		Expect(runErr).ToNot(HaveOccurred())
+
+		Expect(fakeRepository.TakeSnapshotCallCount()).To(Equal(1))
+		takenAt := fakeRepository.TakeSnapshotArgsForCall(0)
+		Expect(takenAt).To(BeTemporally("~", time.Now(), time.Minute))
+
+		Expect(fakeRepository.PruneSnapshotsCallCount()).To(Equal(1))
+		Expect(fakeRepository.PruneSnapshotsArgsForCall(0)).To(Equal(takenAt.Add(-24 * time.Hour)))
+	})
+
+	Context("when snapshots are kept forever", func() {
+		BeforeEach(func() {
+			config.Retention = 0
+		})
+
+		It("does not prune them", func() {
+			Expect(runErr).ToNot(HaveOccurred())
+			Expect(fakeRepository.TakeSnapshotCallCount()).To(Equal(1))
+			Expect(fakeRepository.PruneSnapshotsCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when taking the snapshot fails", func() {
+		BeforeEach(func() {
+			fakeRepository.TakeSnapshotReturns(errors.New("nope"))
+		})
+
+		It("returns the error without pruning", func() {
+			Expect(runErr).To(MatchError("nope"))
+			Expect(fakeRepository.PruneSnapshotsCallCount()).To(BeZero())
+		})
+	})
+})
@@ -0,0 +1,46 @@
+package atc
+
+// HealthStatus is how well a part of the cluster is doing.
+type HealthStatus string
+
+const (
+	HealthStatusOK       HealthStatus = "ok"
+	HealthStatusDegraded HealthStatus = "degraded"
+	HealthStatusFailing  HealthStatus = "failing"
+)
+
+// Worse returns whichever of the two statuses is the worse.
+func (status HealthStatus) Worse(other HealthStatus) HealthStatus {
+	rank := map[HealthStatus]int{
+		HealthStatusOK:       0,
+		HealthStatusDegraded: 1,
+		HealthStatusFailing:  2,
+	}
+
+	if rank[other] > rank[status] {
+		return other
+	}
+
+	return status
+}
+
+// ClusterHealth is the outcome of checking the dependencies of the cluster.
+// Its status is the worst of its checks'.
+type ClusterHealth struct {
+	Status    HealthStatus  `json:"status"`
+	CheckedAt int64         `json:"checked_at"`
+	Checks    []HealthCheck `json:"checks"`
+}
+
+// HealthCheck is the outcome of checking one of the dependencies of the
+// cluster, such as the database or the credential manager.
+type HealthCheck struct {
+	Name   string       `json:"name"`
+	Status HealthStatus `json:"status"`
+
+	// DurationMS is how long the check took, in milliseconds.
+	DurationMS int64 `json:"duration_ms"`
+
+	Error   string      `json:"error,omitempty"`
+	Details interface{} `json:"details,omitempty"`
+}
@@ -0,0 +1,262 @@
+// Package health checks the dependencies of the cluster, for load balancers
+// and alerting to tell whether a web node can do its job.
+package health
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/lock"
+)
+
+// CheckTimeout is how long each check is given before it is considered to
+// be failing.
+const CheckTimeout = 10 * time.Second
+
+// DatabaseLatencyThreshold is how long the database can take to answer
+// before it is considered to be degraded.
+const DatabaseLatencyThreshold = time.Second
+
+// Checker checks the dependencies of the cluster. The outcome is cached, so
+// that probes hitting every web node do not add load to the dependencies.
+type Checker struct {
+	conn          db.Conn
+	lockFactory   lock.LockFactory
+	workerFactory db.WorkerFactory
+	credsManagers creds.Managers
+	clock         clock.Clock
+	cacheDuration time.Duration
+
+	cacheLock sync.Mutex
+	cached    *atc.ClusterHealth
+	cachedAt  time.Time
+}
+
+func NewChecker(
+	conn db.Conn,
+	lockFactory lock.LockFactory,
+	workerFactory db.WorkerFactory,
+	credsManagers creds.Managers,
+	clock clock.Clock,
+	cacheDuration time.Duration,
+) *Checker {
+	return &Checker{
+		conn:          conn,
+		lockFactory:   lockFactory,
+		workerFactory: workerFactory,
+		credsManagers: credsManagers,
+		clock:         clock,
+		cacheDuration: cacheDuration,
+	}
+}
+
+type checkFunc func(context.Context) (atc.HealthStatus, interface{}, error)
+
+// Check runs the checks at the same time, unless they were run within the
+// cache duration.
+func (checker *Checker) Check(ctx context.Context) atc.ClusterHealth {
+	checker.cacheLock.Lock()
+	defer checker.cacheLock.Unlock()
+
+	if checker.cached != nil && checker.clock.Since(checker.cachedAt) < checker.cacheDuration {
+		return *checker.cached
+	}
+
+	checks := []struct {
+		name string
+		run  checkFunc
+	}{
+		{"database", checker.checkDatabase},
+		{"locks", checker.checkLocks},
+		{"workers", checker.checkWorkers},
+		{"credential_managers", checker.checkCredsManagers},
+	}
+
+	health := atc.ClusterHealth{
+		Status:    atc.HealthStatusOK,
+		CheckedAt: checker.clock.Now().Unix(),
+		Checks:    make([]atc.HealthCheck, len(checks)),
+	}
+
+	wg := new(sync.WaitGroup)
+	for i, check := range checks {
+		wg.Add(1)
+		go func(i int, name string, run checkFunc) {
+			defer wg.Done()
+			health.Checks[i] = checker.run(ctx, name, run)
+		}(i, check.name, check.run)
+	}
+
+	wg.Wait()
+
+	for _, check := range health.Checks {
+		health.Status = health.Status.Worse(check.Status)
+	}
+
+	checker.cached = &health
+	checker.cachedAt = checker.clock.Now()
+
+	return health
+}
+
+type checkResult struct {
+	status  atc.HealthStatus
+	details interface{}
+	err     error
+}
+
+func (checker *Checker) run(ctx context.Context, name string, run checkFunc) atc.HealthCheck {
+	ctx, cancel := context.WithTimeout(ctx, CheckTimeout)
+	defer cancel()
+
+	start := checker.clock.Now()
+
+	done := make(chan checkResult, 1)
+	go func() {
+		status, details, err := run(ctx)
+		done <- checkResult{status, details, err}
+	}()
+
+	var result checkResult
+	select {
+	case result = <-done:
+	case <-ctx.Done():
+		result.err = fmt.Errorf("timed out after %s", CheckTimeout)
+	}
+
+	check := atc.HealthCheck{
+		Name:       name,
+		Status:     result.status,
+		DurationMS: checker.clock.Since(start).Milliseconds(),
+		Details:    result.details,
+	}
+
+	if result.err != nil {
+		check.Status = atc.HealthStatusFailing
+		check.Error = result.err.Error()
+	}
+
+	return check
+}
+
+type databaseDetails struct {
+	LatencyMS int64 `json:"latency_ms"`
+}
+
+func (checker *Checker) checkDatabase(context.Context) (atc.HealthStatus, interface{}, error) {
+	start := checker.clock.Now()
+
+	err := checker.conn.Ping()
+	if err != nil {
+		return atc.HealthStatusFailing, nil, err
+	}
+
+	latency := checker.clock.Since(start)
+
+	status := atc.HealthStatusOK
+	if latency > DatabaseLatencyThreshold {
+		status = atc.HealthStatusDegraded
+	}
+
+	return status, databaseDetails{LatencyMS: latency.Milliseconds()}, nil
+}
+
+// checkLocks acquires a lock of its own. Another web node holding it at the
+// same time still shows that locks can be acquired.
+func (checker *Checker) checkLocks(ctx context.Context) (atc.HealthStatus, interface{}, error) {
+	logger := lagerctx.FromContext(ctx).Session("check-locks")
+
+	healthLock, acquired, err := checker.lockFactory.Acquire(logger, lock.NewTaskLockID("health-check"))
+	if err != nil {
+		return atc.HealthStatusFailing, nil, err
+	}
+
+	if acquired {
+		err = healthLock.Release()
+		if err != nil {
+			return atc.HealthStatusFailing, nil, err
+		}
+	}
+
+	return atc.HealthStatusOK, nil, nil
+}
+
+type workersDetails struct {
+	// Running is how many workers are running on each platform.
+	Running map[string]int `json:"running"`
+
+	Stalled int `json:"stalled"`
+}
+
+// checkWorkers fails if there are no running workers, as builds cannot run
+// without them, and is degraded if any have stalled.
+func (checker *Checker) checkWorkers(context.Context) (atc.HealthStatus, interface{}, error) {
+	workers, err := checker.workerFactory.Workers()
+	if err != nil {
+		return atc.HealthStatusFailing, nil, err
+	}
+
+	details := workersDetails{
+		Running: map[string]int{},
+	}
+
+	for _, worker := range workers {
+		switch worker.State() {
+		case db.WorkerStateRunning:
+			details.Running[worker.Platform()]++
+		case db.WorkerStateStalled:
+			details.Stalled++
+		}
+	}
+
+	if len(details.Running) == 0 {
+		return atc.HealthStatusFailing, details, fmt.Errorf("no running workers")
+	}
+
+	if details.Stalled > 0 {
+		return atc.HealthStatusDegraded, details, nil
+	}
+
+	return atc.HealthStatusOK, details, nil
+}
+
+// checkCredsManagers asks each of the configured credential managers whether
+// it can be reached.
+func (checker *Checker) checkCredsManagers(context.Context) (atc.HealthStatus, interface{}, error) {
+	var names []string
+	for name, manager := range checker.credsManagers {
+		if manager.IsConfigured() {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	details := map[string]atc.HealthStatus{}
+
+	var failed []string
+	for _, name := range names {
+		response, err := checker.credsManagers[name].Health()
+		if err != nil || (response != nil && response.Error != "") {
+			details[name] = atc.HealthStatusFailing
+			failed = append(failed, name)
+			continue
+		}
+
+		details[name] = atc.HealthStatusOK
+	}
+
+	if len(failed) > 0 {
+		return atc.HealthStatusFailing, details, fmt.Errorf("unhealthy credential managers: %v", failed)
+	}
+
+	return atc.HealthStatusOK, details, nil
+}
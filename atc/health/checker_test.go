@@ -0,0 +1,209 @@
+package health_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/db/lock/lockfakes"
+	"github.com/concourse/concourse/atc/health"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type fakeManager struct {
+	creds.Manager
+
+	health *creds.HealthResponse
+	err    error
+}
+
+func (manager fakeManager) IsConfigured() bool { return true }
+
+func (manager fakeManager) Health() (*creds.HealthResponse, error) {
+	return manager.health, manager.err
+}
+
+var _ = Describe("Checker", func() {
+	var (
+		fakeConn          *dbfakes.FakeConn
+		fakeLockFactory   *lockfakes.FakeLockFactory
+		fakeLock          *lockfakes.FakeLock
+		fakeWorkerFactory *dbfakes.FakeWorkerFactory
+		credsManagers     creds.Managers
+		fakeClock         *fakeclock.FakeClock
+
+		checker *health.Checker
+		result  atc.ClusterHealth
+	)
+
+	newWorker := func(platform string, state db.WorkerState) db.Worker {
+		worker := new(dbfakes.FakeWorker)
+		worker.PlatformReturns(platform)
+		worker.StateReturns(state)
+		return worker
+	}
+
+	check := func(name string) atc.HealthCheck {
+		for _, check := range result.Checks {
+			if check.Name == name {
+				return check
+			}
+		}
+
+		Fail("no check named " + name)
+		return atc.HealthCheck{}
+	}
+
+	BeforeEach(func() {
+		fakeConn = new(dbfakes.FakeConn)
+		fakeLockFactory = new(lockfakes.FakeLockFactory)
+		fakeLock = new(lockfakes.FakeLock)
+		fakeLockFactory.AcquireReturns(fakeLock, true, nil)
+		fakeWorkerFactory = new(dbfakes.FakeWorkerFactory)
+		fakeWorkerFactory.WorkersReturns([]db.Worker{
+			newWorker("linux", db.WorkerStateRunning),
+			newWorker("linux", db.WorkerStateRunning),
+			newWorker("darwin", db.WorkerStateRunning),
+		}, nil)
+		credsManagers = creds.Managers{
+			"vault": fakeManager{health: &creds.HealthResponse{Method: "/v1/sys/health"}},
+		}
+		fakeClock = fakeclock.NewFakeClock(time.Unix(123, 456))
+
+		checker = health.NewChecker(fakeConn, fakeLockFactory, fakeWorkerFactory, credsManagers, fakeClock, 5*time.Second)
+	})
+
+	JustBeforeEach(func() {
+		result = checker.Check(context.Background())
+	})
+
+	It("is ok when every check is", func() {
+		Expect(result.Status).To(Equal(atc.HealthStatusOK))
+		Expect(result.CheckedAt).To(Equal(int64(123)))
+		Expect(result.Checks).To(HaveLen(4))
+
+		for _, check := range result.Checks {
+			Expect(check.Status).To(Equal(atc.HealthStatusOK), check.Name)
+			Expect(check.Error).To(BeEmpty(), check.Name)
+		}
+	})
+
+	It("pings the database", func() {
+		Expect(fakeConn.PingCallCount()).To(Equal(1))
+	})
+
+	It("acquires and releases a lock", func() {
+		Expect(fakeLockFactory.AcquireCallCount()).To(Equal(1))
+		Expect(fakeLock.ReleaseCallCount()).To(Equal(1))
+	})
+
+	It("counts the running workers by platform", func() {
+		details, err := json.Marshal(check("workers").Details)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(details).To(MatchJSON(`{"running":{"linux":2,"darwin":1},"stalled":0}`))
+	})
+
+	Context("when the database cannot be reached", func() {
+		BeforeEach(func() {
+			fakeConn.PingReturns(errors.New("connection refused"))
+		})
+
+		It("is failing", func() {
+			Expect(result.Status).To(Equal(atc.HealthStatusFailing))
+			Expect(check("database").Status).To(Equal(atc.HealthStatusFailing))
+			Expect(check("database").Error).To(Equal("connection refused"))
+		})
+	})
+
+	Context("when the database is slow to answer", func() {
+		BeforeEach(func() {
+			fakeConn.PingStub = func() error {
+				fakeClock.Increment(2 * time.Second)
+				return nil
+			}
+		})
+
+		It("is degraded", func() {
+			Expect(result.Status).To(Equal(atc.HealthStatusDegraded))
+			Expect(check("database").Status).To(Equal(atc.HealthStatusDegraded))
+		})
+	})
+
+	Context("when the lock is held elsewhere", func() {
+		BeforeEach(func() {
+			fakeLockFactory.AcquireReturns(nil, false, nil)
+		})
+
+		It("is ok", func() {
+			Expect(check("locks").Status).To(Equal(atc.HealthStatusOK))
+		})
+	})
+
+	Context("when locks cannot be acquired", func() {
+		BeforeEach(func() {
+			fakeLockFactory.AcquireReturns(nil, false, errors.New("nope"))
+		})
+
+		It("is failing", func() {
+			Expect(result.Status).To(Equal(atc.HealthStatusFailing))
+			Expect(check("locks").Error).To(Equal("nope"))
+		})
+	})
+
+	Context("when there are no running workers", func() {
+		BeforeEach(func() {
+			fakeWorkerFactory.WorkersReturns([]db.Worker{
+				newWorker("linux", db.WorkerStateLanded),
+			}, nil)
+		})
+
+		It("is failing", func() {
+			Expect(result.Status).To(Equal(atc.HealthStatusFailing))
+			Expect(check("workers").Error).To(Equal("no running workers"))
+		})
+	})
+
+	Context("when a worker has stalled", func() {
+		BeforeEach(func() {
+			fakeWorkerFactory.WorkersReturns([]db.Worker{
+				newWorker("linux", db.WorkerStateRunning),
+				newWorker("linux", db.WorkerStateStalled),
+			}, nil)
+		})
+
+		It("is degraded", func() {
+			Expect(result.Status).To(Equal(atc.HealthStatusDegraded))
+			Expect(check("workers").Status).To(Equal(atc.HealthStatusDegraded))
+		})
+	})
+
+	Context("when a credential manager is unhealthy", func() {
+		BeforeEach(func() {
+			credsManagers["vault"] = fakeManager{health: &creds.HealthResponse{Error: "sealed"}}
+		})
+
+		It("is failing", func() {
+			Expect(result.Status).To(Equal(atc.HealthStatusFailing))
+			Expect(check("credential_managers").Error).To(Equal("unhealthy credential managers: [vault]"))
+		})
+	})
+
+	Describe("caching", func() {
+		It("reuses the outcome within the cache duration", func() {
+			fakeClock.Increment(4 * time.Second)
+			checker.Check(context.Background())
+			Expect(fakeConn.PingCallCount()).To(Equal(1))
+
+			fakeClock.Increment(time.Second)
+			checker.Check(context.Background())
+			Expect(fakeConn.PingCallCount()).To(Equal(2))
+		})
+	})
+})
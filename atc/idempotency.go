@@ -0,0 +1,11 @@
+package atc
+
+const (
+	// IdempotencyKeyHeader makes retrying a request which triggers a build or
+	// sets a pipeline safe: the response to the first request made with a
+	// key is replayed to the ones retrying it, instead of doing it again.
+	IdempotencyKeyHeader = "Idempotency-Key"
+
+	// IdempotentReplayedHeader is set on the responses which are replayed.
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+)
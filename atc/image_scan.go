@@ -0,0 +1,19 @@
+package atc
+
+import "time"
+
+// ImageScanReport is what a scanning service found in an image, identified by
+// its digest.
+type ImageScanReport struct {
+	Digest          string               `json:"digest"`
+	Vulnerabilities []ImageVulnerability `json:"vulnerabilities"`
+	ScannedAt       time.Time            `json:"scanned_at"`
+}
+
+type ImageVulnerability struct {
+	ID           string `json:"id"`
+	Severity     string `json:"severity"`
+	Package      string `json:"package,omitempty"`
+	Version      string `json:"version,omitempty"`
+	FixedVersion string `json:"fixed_version,omitempty"`
+}
@@ -6,4 +6,5 @@ type Info struct {
 	FeatureFlags  map[string]bool `json:"feature_flags"`
 	ExternalURL   string          `json:"external_url,omitempty"`
 	ClusterName   string          `json:"cluster_name,omitempty"`
+	Banner        string          `json:"banner,omitempty"`
 }
@@ -0,0 +1,28 @@
+package atc
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+)
+
+var envNameRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// ValidateInjectedEnv validates the env operators inject into every task of a
+// team or of the cluster, such as proxy settings or the path to a CA bundle.
+func ValidateInjectedEnv(env TaskEnv) error {
+	var names []string
+	for name := range env {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		if !envNameRegexp.MatchString(name) {
+			return fmt.Errorf("invalid env var name '%s'", name)
+		}
+	}
+
+	return nil
+}
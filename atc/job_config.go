@@ -1,5 +1,11 @@
 package atc
 
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
 type JobConfig struct {
 	Name    string `json:"name"`
 	OldName string `json:"old_name,omitempty"`
@@ -9,17 +15,24 @@ type JobConfig struct {
 	Serial               bool     `json:"serial,omitempty"`
 	Interruptible        bool     `json:"interruptible,omitempty"`
 	SerialGroups         []string `json:"serial_groups,omitempty"`
+	TeamSerialGroups     []string `json:"team_serial_groups,omitempty"`
 	RawMaxInFlight       int      `json:"max_in_flight,omitempty"`
 	BuildLogsToRetain    int      `json:"build_logs_to_retain,omitempty"`
+	DeduplicateBuilds    bool     `json:"deduplicate_builds,omitempty"`
+	RetryOnWorkerError   int      `json:"retry_on_worker_error,omitempty"`
 
 	BuildLogRetention *BuildLogRetention `json:"build_log_retention,omitempty"`
 
+	WarmPool *WarmPoolConfig `json:"warm_pool,omitempty"`
+
 	OnSuccess *Step `json:"on_success,omitempty"`
 	OnFailure *Step `json:"on_failure,omitempty"`
 	OnAbort   *Step `json:"on_abort,omitempty"`
 	OnError   *Step `json:"on_error,omitempty"`
 	Ensure    *Step `json:"ensure,omitempty"`
 
+	Notifications NotificationRules `json:"notifications,omitempty"`
+
 	PlanSequence []Step `json:"plan"`
 }
 
@@ -29,6 +42,52 @@ type BuildLogRetention struct {
 	Days                   int `json:"days,omitempty"`
 }
 
+// DefaultWarmPoolExpiry is how long a warm container is kept ready when the
+// job does not say otherwise.
+const DefaultWarmPoolExpiry = 30 * time.Minute
+
+// WarmPoolConfig keeps containers for a job's tasks created ahead of its
+// builds, so that the tasks can start straight away.
+type WarmPoolConfig struct {
+	// Size is how many containers are kept ready for each of the job's tasks.
+	Size int `json:"size"`
+
+	// Expiry is how long a container is kept ready before it is replaced.
+	Expiry string `json:"expiry,omitempty"`
+}
+
+var ErrInvalidWarmPoolSize = errors.New("size must be at least 1")
+
+func (config WarmPoolConfig) ExpiryDuration() (time.Duration, error) {
+	if config.Expiry == "" {
+		return DefaultWarmPoolExpiry, nil
+	}
+
+	expiry, err := time.ParseDuration(config.Expiry)
+	if err != nil {
+		return 0, err
+	}
+
+	if expiry <= 0 {
+		return 0, errors.New("expiry must be positive")
+	}
+
+	return expiry, nil
+}
+
+func (config WarmPoolConfig) Validate() error {
+	if config.Size < 1 {
+		return ErrInvalidWarmPoolSize
+	}
+
+	_, err := config.ExpiryDuration()
+	if err != nil {
+		return fmt.Errorf("invalid expiry: %w", err)
+	}
+
+	return nil
+}
+
 func (config JobConfig) Step() Step {
 	return Step{Config: config.StepConfig()}
 }
@@ -77,7 +136,7 @@ func (config JobConfig) StepConfig() StepConfig {
 }
 
 func (config JobConfig) MaxInFlight() int {
-	if config.Serial || len(config.SerialGroups) > 0 {
+	if config.Serial || len(config.SerialGroups) > 0 || len(config.TeamSerialGroups) > 0 {
 		return 1
 	}
 
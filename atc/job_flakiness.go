@@ -0,0 +1,24 @@
+package atc
+
+// JobFlakiness tells how often the recent builds of a job changed between
+// succeeding and failing while their inputs stayed the same, which points to
+// a job whose outcome depends on something other than its inputs.
+type JobFlakiness struct {
+	JobName              string       `json:"job_name"`
+	PipelineName         string       `json:"pipeline_name"`
+	PipelineInstanceVars InstanceVars `json:"pipeline_instance_vars,omitempty"`
+	TeamName             string       `json:"team_name"`
+
+	// Builds is the number of succeeded and failed builds analyzed. Repeats
+	// is how many of them ran with the same inputs as the build before them,
+	// and Flips how many of those had a different outcome.
+	Builds  int `json:"builds"`
+	Repeats int `json:"repeats"`
+	Flips   int `json:"flips"`
+
+	// Score is the ratio of flips to repeats, from 0 to 1.
+	Score float64 `json:"score"`
+	Flaky bool    `json:"flaky"`
+
+	AnalyzedAt int64 `json:"analyzed_at"`
+}
@@ -0,0 +1,54 @@
+package atc
+
+// JobStatistics summarizes the builds of a job which finished within a
+// window of time. All durations are in seconds.
+type JobStatistics struct {
+	JobName string `json:"job_name"`
+
+	// Since is the start of the window, as a unix timestamp.
+	Since int64 `json:"since"`
+
+	Builds    int `json:"builds"`
+	Succeeded int `json:"succeeded"`
+	Failed    int `json:"failed"`
+	Errored   int `json:"errored"`
+	Aborted   int `json:"aborted"`
+
+	// SuccessRate is the ratio of succeeded builds to all builds which were
+	// not aborted.
+	SuccessRate float64 `json:"success_rate"`
+
+	// Duration covers builds which succeeded or failed, QueueTime the time
+	// from creating to starting any build which started.
+	Duration  StatisticsPercentiles `json:"duration"`
+	QueueTime StatisticsPercentiles `json:"queue_time"`
+
+	DurationObjective *DurationObjective `json:"duration_objective,omitempty"`
+
+	Trend []JobStatisticsInterval `json:"trend,omitempty"`
+}
+
+type StatisticsPercentiles struct {
+	P50 float64 `json:"p50"`
+	P90 float64 `json:"p90"`
+	P99 float64 `json:"p99"`
+}
+
+// DurationObjective tracks how many builds finished within a maximum
+// duration, e.g. the one given by a team's SLO.
+type DurationObjective struct {
+	MaxDuration int64   `json:"max_duration"`
+	Attained    float64 `json:"attained"`
+}
+
+// JobStatisticsInterval summarizes the builds which finished within one
+// interval of a window, so that trends can be followed over time.
+type JobStatisticsInterval struct {
+	Start int64 `json:"start"`
+
+	Builds      int     `json:"builds"`
+	SuccessRate float64 `json:"success_rate"`
+
+	Duration  StatisticsPercentiles `json:"duration"`
+	QueueTime StatisticsPercentiles `json:"queue_time"`
+}
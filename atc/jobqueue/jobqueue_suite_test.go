@@ -0,0 +1,13 @@
+package jobqueue_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestJobQueue(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Job Queue Suite")
+}
@@ -0,0 +1,146 @@
+// Package jobqueue works through the queues of background work kept in the
+// database.
+package jobqueue
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/metric"
+)
+
+const (
+	// MaxAttempts is the number of attempts of a job before it is marked as
+	// dead.
+	MaxAttempts = 10
+
+	// VisibilityTimeout is how long a job is leased for. A job which isn't
+	// handled by then, e.g. because the ATC handling it went away, is handed
+	// out again.
+	VisibilityTimeout = 5 * time.Minute
+
+	initialBackoff = 10 * time.Second
+	maxBackoff     = time.Hour
+
+	batchSize = 50
+
+	// deadJobRetention is how long dead jobs are kept around for them to be
+	// looked into.
+	deadJobRetention = 7 * 24 * time.Hour
+)
+
+// Handler does the work of a job. A job whose handler returns an error is
+// retried with backoff.
+type Handler func(ctx context.Context, payload json.RawMessage) error
+
+type Processor struct {
+	queue   db.JobQueue
+	name    string
+	handler Handler
+	clock   func() time.Time
+}
+
+func NewProcessor(queue db.JobQueue, name string, handler Handler) *Processor {
+	return &Processor{
+		queue:   queue,
+		name:    name,
+		handler: handler,
+		clock:   time.Now,
+	}
+}
+
+// Run handles the jobs of the queue which are due, and cleans up old dead
+// jobs.
+func (processor *Processor) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx).Session("job-queue-processor", lager.Data{
+		"queue": processor.name,
+	})
+
+	logger.Debug("start")
+	defer logger.Debug("done")
+
+	jobs, err := processor.queue.Lease(processor.name, batchSize, VisibilityTimeout)
+	if err != nil {
+		logger.Error("failed-to-lease-jobs", err)
+		return err
+	}
+
+	wg := new(sync.WaitGroup)
+	for _, job := range jobs {
+		wg.Add(1)
+
+		go func(job db.QueuedJob) {
+			defer wg.Done()
+
+			processor.handle(ctx, logger.Session("handle", lager.Data{
+				"job": job.ID,
+			}), job)
+		}(job)
+	}
+
+	wg.Wait()
+
+	err = processor.queue.DeleteDeadJobsBefore(processor.name, processor.clock().Add(-deadJobRetention))
+	if err != nil {
+		logger.Error("failed-to-delete-old-dead-jobs", err)
+		return err
+	}
+
+	stats, err := processor.queue.Stats(processor.name)
+	if err != nil {
+		logger.Error("failed-to-get-stats", err)
+		return err
+	}
+
+	metric.JobQueueJobs{
+		Queue:   processor.name,
+		Pending: stats.Pending,
+		Dead:    stats.Dead,
+	}.Emit(logger)
+
+	return nil
+}
+
+func (processor *Processor) handle(ctx context.Context, logger lager.Logger, job db.QueuedJob) {
+	err := processor.handler(lagerctx.NewContext(ctx, logger), job.Payload)
+	if err == nil {
+		err = processor.queue.Complete(job.ID)
+		if err != nil {
+			logger.Error("failed-to-complete-job", err)
+		}
+
+		return
+	}
+
+	logger.Info("job-attempt-failed", lager.Data{"error": err.Error(), "attempts": job.Attempts})
+
+	var retryAt *time.Time
+	if job.Attempts < MaxAttempts {
+		at := processor.clock().Add(Backoff(job.Attempts))
+		retryAt = &at
+	}
+
+	err = processor.queue.Fail(job.ID, err.Error(), retryAt)
+	if err != nil {
+		logger.Error("failed-to-mark-job-as-failed", err)
+	}
+}
+
+// Backoff returns how long to wait before retrying a job after the given
+// number of failed attempts, doubling each time up to an hour.
+func Backoff(attempts int) time.Duration {
+	backoff := initialBackoff
+	for i := 1; i < attempts; i++ {
+		backoff *= 2
+		if backoff >= maxBackoff {
+			return maxBackoff
+		}
+	}
+
+	return backoff
+}
@@ -0,0 +1,126 @@
+package jobqueue_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/jobqueue"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Processor", func() {
+	var (
+		fakeQueue *dbfakes.FakeJobQueue
+		job       db.QueuedJob
+
+		handlerErr error
+		handled    []json.RawMessage
+
+		runErr error
+	)
+
+	BeforeEach(func() {
+		fakeQueue = new(dbfakes.FakeJobQueue)
+
+		job = db.QueuedJob{
+			ID:       42,
+			Payload:  json.RawMessage(`{"some":"payload"}`),
+			Attempts: 1,
+		}
+
+		fakeQueue.LeaseReturns([]db.QueuedJob{job}, nil)
+
+		handlerErr = nil
+		handled = nil
+	})
+
+	JustBeforeEach(func() {
+		handler := func(ctx context.Context, payload json.RawMessage) error {
+			handled = append(handled, payload)
+			return handlerErr
+		}
+
+		runErr = jobqueue.NewProcessor(fakeQueue, "some-queue", handler).Run(context.TODO())
+	})
+
+	It("leases the jobs of its queue", func() {
+		Expect(fakeQueue.LeaseCallCount()).To(Equal(1))
+		queue, _, timeout := fakeQueue.LeaseArgsForCall(0)
+		Expect(queue).To(Equal("some-queue"))
+		Expect(timeout).To(Equal(jobqueue.VisibilityTimeout))
+	})
+
+	Context("when the job is handled", func() {
+		It("completes it", func() {
+			Expect(runErr).ToNot(HaveOccurred())
+			Expect(handled).To(ConsistOf(MatchJSON(`{"some":"payload"}`)))
+
+			Expect(fakeQueue.CompleteCallCount()).To(Equal(1))
+			Expect(fakeQueue.CompleteArgsForCall(0)).To(Equal(int64(42)))
+			Expect(fakeQueue.FailCallCount()).To(BeZero())
+		})
+
+		It("cleans up old dead jobs", func() {
+			Expect(fakeQueue.DeleteDeadJobsBeforeCallCount()).To(Equal(1))
+			queue, before := fakeQueue.DeleteDeadJobsBeforeArgsForCall(0)
+			Expect(queue).To(Equal("some-queue"))
+			Expect(before).To(BeTemporally("~", time.Now().Add(-7*24*time.Hour), time.Minute))
+		})
+	})
+
+	Context("when the job fails to be handled", func() {
+		BeforeEach(func() {
+			handlerErr = errors.New("nope")
+		})
+
+		It("retries it with backoff", func() {
+			Expect(runErr).ToNot(HaveOccurred())
+			Expect(fakeQueue.CompleteCallCount()).To(BeZero())
+
+			Expect(fakeQueue.FailCallCount()).To(Equal(1))
+			id, jobErr, retryAt := fakeQueue.FailArgsForCall(0)
+			Expect(id).To(Equal(int64(42)))
+			Expect(jobErr).To(Equal("nope"))
+			Expect(retryAt).ToNot(BeNil())
+			Expect(*retryAt).To(BeTemporally("~", time.Now().Add(jobqueue.Backoff(1)), time.Minute))
+		})
+
+		Context("for the last time", func() {
+			BeforeEach(func() {
+				job.Attempts = jobqueue.MaxAttempts
+				fakeQueue.LeaseReturns([]db.QueuedJob{job}, nil)
+			})
+
+			It("marks it as dead", func() {
+				Expect(fakeQueue.FailCallCount()).To(Equal(1))
+				_, _, retryAt := fakeQueue.FailArgsForCall(0)
+				Expect(retryAt).To(BeNil())
+			})
+		})
+	})
+
+	Context("when leasing fails", func() {
+		BeforeEach(func() {
+			fakeQueue.LeaseReturns(nil, errors.New("nope"))
+		})
+
+		It("returns the error", func() {
+			Expect(runErr).To(MatchError("nope"))
+			Expect(handled).To(BeEmpty())
+		})
+	})
+})
+
+var _ = Describe("Backoff", func() {
+	It("doubles up to an hour", func() {
+		Expect(jobqueue.Backoff(1)).To(Equal(10 * time.Second))
+		Expect(jobqueue.Backoff(2)).To(Equal(20 * time.Second))
+		Expect(jobqueue.Backoff(3)).To(Equal(40 * time.Second))
+		Expect(jobqueue.Backoff(20)).To(Equal(time.Hour))
+	})
+})
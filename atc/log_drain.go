@@ -0,0 +1,126 @@
+package atc
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+type LogDrainType string
+
+const (
+	LogDrainTypeSyslog  LogDrainType = "syslog"
+	LogDrainTypeFluentd LogDrainType = "fluentd"
+	LogDrainTypeHTTP    LogDrainType = "http"
+)
+
+var LogDrainTypes = []LogDrainType{
+	LogDrainTypeSyslog,
+	LogDrainTypeFluentd,
+	LogDrainTypeHTTP,
+}
+
+// LogDrain forwards the logs of a team's builds, or of the builds of one of
+// its pipelines, as they are written.
+//
+// Syslog drains send each line to a tcp://, udp:// or tls:// URL. Fluentd
+// drains POST batches of records to the HTTP input of Fluentd or Fluent Bit,
+// the path of the URL being the tag. HTTP drains POST batches of records to
+// any http:// or https:// URL. Batches are JSON arrays of LogDrainRecords.
+type LogDrain struct {
+	Name     string       `json:"name"`
+	Type     LogDrainType `json:"type"`
+	URL      string       `json:"url"`
+	Pipeline string       `json:"pipeline,omitempty"`
+
+	// Failures is how many times in a row forwarding logs to the drain has
+	// failed, and LastError the error it last failed with. They're ignored
+	// when setting a drain.
+	Failures  int    `json:"failures,omitempty"`
+	LastError string `json:"last_error,omitempty"`
+}
+
+func (drain LogDrain) Validate() error {
+	drainURL, err := url.Parse(drain.URL)
+	if err != nil {
+		return fmt.Errorf("invalid url: %w", err)
+	}
+
+	switch drain.Type {
+	case LogDrainTypeSyslog:
+		if drainURL.Scheme != "tcp" && drainURL.Scheme != "udp" && drainURL.Scheme != "tls" {
+			return errors.New("url of a syslog drain must be a tcp, udp or tls URL")
+		}
+
+		if drainURL.Port() == "" {
+			return errors.New("url of a syslog drain must include a port")
+		}
+
+	case LogDrainTypeFluentd:
+		if drainURL.Scheme != "http" && drainURL.Scheme != "https" {
+			return errors.New("url of a fluentd drain must be an http or https URL")
+		}
+
+		if strings.Trim(drainURL.Path, "/") == "" {
+			return errors.New("url of a fluentd drain must have the tag as its path")
+		}
+
+	case LogDrainTypeHTTP:
+		if drainURL.Scheme != "http" && drainURL.Scheme != "https" {
+			return errors.New("url must be an http or https URL")
+		}
+
+	default:
+		var types []string
+		for _, t := range LogDrainTypes {
+			types = append(types, string(t))
+		}
+
+		return fmt.Errorf("unknown type '%s' (must be one of %s)", drain.Type, strings.Join(types, ", "))
+	}
+
+	return nil
+}
+
+// LogDrainRecord is a line of a build's logs, or an error the build ran
+// into, as forwarded by log drains.
+type LogDrainRecord struct {
+	Time     int64  `json:"time"`
+	Team     string `json:"team"`
+	Pipeline string `json:"pipeline,omitempty"`
+	Job      string `json:"job,omitempty"`
+	Build    string `json:"build"`
+	BuildID  int    `json:"build_id"`
+
+	// Step is the ID of the plan of the step which logged the line, and
+	// Stream where it came from: 'stdout' or 'stderr', or empty for the logs
+	// of Concourse itself.
+	Step   string `json:"step,omitempty"`
+	Stream string `json:"stream,omitempty"`
+
+	Message string `json:"message"`
+	Error   bool   `json:"error,omitempty"`
+}
+
+// Tag identifies the build the record came from, in the format of the tags
+// of the syslog drainer.
+func (record LogDrainRecord) Tag() string {
+	segments := []string{record.Team}
+
+	if record.Pipeline != "" {
+		segments = append(segments, record.Pipeline)
+	}
+
+	if record.Job != "" {
+		segments = append(segments, record.Job)
+	}
+
+	segments = append(segments, record.Build)
+
+	if record.Step != "" {
+		segments = append(segments, record.Step)
+	}
+
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,197 @@
+// Package logdrain forwards the logs of builds to the log drains of their
+// teams.
+package logdrain
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/syslog"
+	"github.com/concourse/concourse/atc/webhooks"
+)
+
+const (
+	// buildsPerRun is how many builds each drain gets through per run, at
+	// most.
+	buildsPerRun = 10
+
+	// recordsPerRequest is how many records are POSTed at once to fluentd
+	// and http drains.
+	recordsPerRequest = 1000
+)
+
+type Drainer struct {
+	repository db.LogDrainRepository
+	client     *http.Client
+	hostname   string
+	caCerts    []string
+	clock      func() time.Time
+}
+
+// NewDrainer returns a drainer which sends logs to syslog drains with the
+// given hostname, verifying the certs of tls drains with the given CA certs
+// along with the system's.
+func NewDrainer(repository db.LogDrainRepository, client *http.Client, hostname string, caCerts []string) *Drainer {
+	return &Drainer{
+		repository: repository,
+		client:     client,
+		hostname:   hostname,
+		caCerts:    caCerts,
+		clock:      time.Now,
+	}
+}
+
+// Run forwards the logs of each drain which is due. A drain which fails is
+// retried with backoff, from where it left off.
+func (drainer *Drainer) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx).Session("log-drainer")
+
+	logger.Debug("start")
+	defer logger.Debug("done")
+
+	drains, err := drainer.repository.PendingLogs(buildsPerRun)
+	if err != nil {
+		logger.Error("failed-to-get-pending-logs", err)
+		return err
+	}
+
+	wg := new(sync.WaitGroup)
+	for _, drain := range drains {
+		wg.Add(1)
+
+		go func(drain db.PendingLogDrain) {
+			defer wg.Done()
+
+			drainer.drain(ctx, logger.Session("drain", lager.Data{
+				"drain": drain.ID,
+				"type":  drain.Type,
+			}), drain)
+		}(drain)
+	}
+
+	wg.Wait()
+
+	err = drainer.repository.PruneCursors(drainer.clock().Add(-db.LogDrainWindow))
+	if err != nil {
+		logger.Error("failed-to-prune-cursors", err)
+		return err
+	}
+
+	return nil
+}
+
+func (drainer *Drainer) drain(ctx context.Context, logger lager.Logger, drain db.PendingLogDrain) {
+	err := drainer.send(ctx, drain)
+	if err != nil {
+		logger.Info("failed-to-forward-logs", lager.Data{"error": err.Error()})
+
+		retryAt := drainer.clock().Add(webhooks.Backoff(drain.Failures + 1))
+
+		err = drainer.repository.DrainFailed(drain.ID, err.Error(), retryAt)
+		if err != nil {
+			logger.Error("failed-to-mark-drain-as-failed", err)
+		}
+
+		return
+	}
+
+	err = drainer.repository.LogsDrained(drain.ID, drain.Builds)
+	if err != nil {
+		logger.Error("failed-to-mark-logs-as-drained", err)
+	}
+}
+
+func (drainer *Drainer) send(ctx context.Context, drain db.PendingLogDrain) error {
+	if len(drain.Records) == 0 {
+		return nil
+	}
+
+	drainURL, err := url.Parse(drain.URL)
+	if err != nil {
+		return err
+	}
+
+	switch drain.Type {
+	case atc.LogDrainTypeSyslog:
+		return drainer.sendSyslog(drainURL, drain.Records)
+	case atc.LogDrainTypeFluentd, atc.LogDrainTypeHTTP:
+		return drainer.post(ctx, drainURL, drain.Records)
+	}
+
+	return fmt.Errorf("unknown log drain type '%s'", drain.Type)
+}
+
+func (drainer *Drainer) sendSyslog(drainURL *url.URL, records []atc.LogDrainRecord) error {
+	sl, err := syslog.Dial(drainURL.Scheme, drainURL.Host, drainer.caCerts)
+	if err != nil {
+		return err
+	}
+
+	// ignore any errors coming from syslog.Close()
+	defer db.Close(sl)
+
+	for _, record := range records {
+		err := sl.Write(
+			drainer.hostname,
+			record.Tag(),
+			time.Unix(record.Time, 0),
+			record.Message,
+			strconv.Itoa(record.BuildID),
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// post POSTs the records as JSON arrays, which is what the HTTP input of
+// Fluentd and Fluent Bit expects for a batch of records.
+func (drainer *Drainer) post(ctx context.Context, drainURL *url.URL, records []atc.LogDrainRecord) error {
+	for len(records) > 0 {
+		batch := records
+		if len(batch) > recordsPerRequest {
+			batch = batch[:recordsPerRequest]
+		}
+
+		records = records[len(batch):]
+
+		payload, err := json.Marshal(batch)
+		if err != nil {
+			return err
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, drainURL.String(), bytes.NewReader(payload))
+		if err != nil {
+			return err
+		}
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("User-Agent", "Concourse-Log-Drain")
+
+		resp, err := drainer.client.Do(req)
+		if err != nil {
+			return err
+		}
+
+		resp.Body.Close()
+
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			return fmt.Errorf("unexpected response status: %s", resp.Status)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,216 @@
+package logdrain_test
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/logdrain"
+	"github.com/concourse/concourse/atc/webhooks"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Drainer", func() {
+	var (
+		fakeRepository *dbfakes.FakeLogDrainRepository
+		drain          db.PendingLogDrain
+		pendingErr     error
+
+		runErr error
+	)
+
+	BeforeEach(func() {
+		fakeRepository = new(dbfakes.FakeLogDrainRepository)
+		pendingErr = nil
+
+		drain = db.PendingLogDrain{
+			ID:   42,
+			Type: atc.LogDrainTypeHTTP,
+			Builds: []db.DrainedBuild{
+				{ID: 1, Cursor: 3, Completed: true},
+			},
+			Records: []atc.LogDrainRecord{
+				{
+					Time:     1,
+					Team:     "some-team",
+					Pipeline: "some-pipeline",
+					Job:      "some-job",
+					Build:    "1",
+					BuildID:  1,
+					Step:     "some-step",
+					Stream:   "stdout",
+					Message:  "hello\n",
+				},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		fakeRepository.PendingLogsReturns([]db.PendingLogDrain{drain}, pendingErr)
+
+		runErr = logdrain.NewDrainer(fakeRepository, http.DefaultClient, "some-host", nil).Run(context.TODO())
+	})
+
+	Context("with an http drain", func() {
+		var server *ghttp.Server
+
+		BeforeEach(func() {
+			server = ghttp.NewServer()
+			drain.URL = server.URL() + "/logs"
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		Context("when the drain accepts the logs", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/logs"),
+					ghttp.VerifyJSON(`[{
+						"time": 1,
+						"team": "some-team",
+						"pipeline": "some-pipeline",
+						"job": "some-job",
+						"build": "1",
+						"build_id": 1,
+						"step": "some-step",
+						"stream": "stdout",
+						"message": "hello\n"
+					}]`),
+					ghttp.RespondWith(http.StatusOK, nil),
+				))
+			})
+
+			It("moves the drain's cursors forward", func() {
+				Expect(runErr).NotTo(HaveOccurred())
+				Expect(server.ReceivedRequests()).To(HaveLen(1))
+
+				Expect(fakeRepository.LogsDrainedCallCount()).To(Equal(1))
+				id, builds := fakeRepository.LogsDrainedArgsForCall(0)
+				Expect(id).To(Equal(42))
+				Expect(builds).To(Equal(drain.Builds))
+			})
+
+			It("prunes the cursors of old builds", func() {
+				Expect(fakeRepository.PruneCursorsCallCount()).To(Equal(1))
+				Expect(fakeRepository.PruneCursorsArgsForCall(0)).To(BeTemporally("~", time.Now().Add(-db.LogDrainWindow), time.Minute))
+			})
+		})
+
+		Context("when the drain responds with an error", func() {
+			BeforeEach(func() {
+				drain.Failures = 2
+				server.AppendHandlers(ghttp.RespondWith(http.StatusBadGateway, nil))
+			})
+
+			It("backs off without moving the cursors forward", func() {
+				Expect(fakeRepository.LogsDrainedCallCount()).To(BeZero())
+				Expect(fakeRepository.DrainFailedCallCount()).To(Equal(1))
+
+				id, drainErr, retryAt := fakeRepository.DrainFailedArgsForCall(0)
+				Expect(id).To(Equal(42))
+				Expect(drainErr).To(ContainSubstring("502"))
+				Expect(retryAt).To(BeTemporally("~", time.Now().Add(webhooks.Backoff(3)), time.Minute))
+			})
+		})
+
+		Context("when there are many records", func() {
+			BeforeEach(func() {
+				for i := 0; i < 1500; i++ {
+					drain.Records = append(drain.Records, drain.Records[0])
+				}
+
+				server.AppendHandlers(
+					ghttp.RespondWith(http.StatusOK, nil),
+					ghttp.RespondWith(http.StatusOK, nil),
+				)
+			})
+
+			It("posts them in batches", func() {
+				Expect(runErr).NotTo(HaveOccurred())
+				Expect(server.ReceivedRequests()).To(HaveLen(2))
+				Expect(fakeRepository.LogsDrainedCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("when there are no new records", func() {
+			BeforeEach(func() {
+				drain.Records = nil
+			})
+
+			It("only moves the cursors forward", func() {
+				Expect(server.ReceivedRequests()).To(BeEmpty())
+				Expect(fakeRepository.LogsDrainedCallCount()).To(Equal(1))
+			})
+		})
+	})
+
+	Context("with a syslog drain", func() {
+		var (
+			listener net.Listener
+			received chan string
+		)
+
+		BeforeEach(func() {
+			var err error
+			listener, err = net.Listen("tcp", "127.0.0.1:0")
+			Expect(err).NotTo(HaveOccurred())
+
+			received = make(chan string, 10)
+
+			go func() {
+				defer GinkgoRecover()
+
+				conn, err := listener.Accept()
+				if err != nil {
+					return
+				}
+
+				defer conn.Close()
+
+				scanner := bufio.NewScanner(conn)
+				for scanner.Scan() {
+					received <- scanner.Text()
+				}
+			}()
+
+			drain.Type = atc.LogDrainTypeSyslog
+			drain.URL = "tcp://" + listener.Addr().String()
+		})
+
+		AfterEach(func() {
+			listener.Close()
+		})
+
+		It("sends each record as a message tagged with its build and step", func() {
+			Expect(runErr).NotTo(HaveOccurred())
+
+			var message string
+			Eventually(received).Should(Receive(&message))
+			Expect(message).To(ContainSubstring("some-host some-team/some-pipeline/some-job/1/some-step"))
+			Expect(message).To(HaveSuffix("hello "))
+
+			Expect(fakeRepository.LogsDrainedCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when getting the pending logs fails", func() {
+		BeforeEach(func() {
+			pendingErr = errors.New("nope")
+		})
+
+		It("returns the error", func() {
+			Expect(runErr).To(MatchError("nope"))
+			Expect(fakeRepository.LogsDrainedCallCount()).To(BeZero())
+		})
+	})
+})
@@ -0,0 +1,104 @@
+// Package maintenance tracks how bloated the busiest tables of the database
+// are and, when enabled, vacuums them and rebuilds their indexes while it's
+// quiet.
+package maintenance
+
+import (
+	"context"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/metric"
+)
+
+type Config struct {
+	Auto   bool   `long:"db-auto-maintenance" description:"Vacuum the busiest tables and rebuild their bloated indexes as advised, within --db-maintenance-window."`
+	Window Window `long:"db-maintenance-window" default:"02:00-05:00" description:"The time of day, in UTC, within which the database is quiet enough to be maintained, as HH:MM-HH:MM."`
+}
+
+// Advisor emits the dead tuples and index bloat of the maintained tables as
+// metrics and, when automatic maintenance is enabled and within the window,
+// carries out the advice on maintaining them.
+type Advisor struct {
+	repository db.MaintenanceRepository
+	config     Config
+	clock      func() time.Time
+}
+
+func NewAdvisor(repository db.MaintenanceRepository, config Config) *Advisor {
+	return &Advisor{
+		repository: repository,
+		config:     config,
+		clock:      time.Now,
+	}
+}
+
+// Run takes stock of the maintained tables, and maintains them one at a time
+// for as long as the window is open. Advice which fails to be carried out is
+// logged and tried again on the next run, as long as it's still advised.
+func (advisor *Advisor) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx).Session("maintenance-advisor")
+
+	logger.Debug("start")
+	defer logger.Debug("done")
+
+	maintenance, err := advisor.repository.Maintenance()
+	if err != nil {
+		logger.Error("failed-to-get-maintenance", err)
+		return err
+	}
+
+	for _, table := range maintenance.Tables {
+		var bloat int64
+		for _, index := range table.Indexes {
+			bloat += index.BloatBytes
+		}
+
+		metric.DatabaseTableMaintenance{
+			Table:           table.Name,
+			DeadTuples:      table.DeadTuples,
+			IndexBloatBytes: bloat,
+		}.Emit(logger)
+	}
+
+	if !advisor.config.Auto {
+		return nil
+	}
+
+	for _, advice := range maintenance.Advice {
+		// vacuuming can take a while, so the window may have closed since
+		if ctx.Err() != nil || !advisor.config.Window.Contains(advisor.clock()) {
+			return nil
+		}
+
+		advisor.maintain(logger, advice)
+	}
+
+	return nil
+}
+
+func (advisor *Advisor) maintain(logger lager.Logger, advice atc.DBMaintenanceAdvice) {
+	logger = logger.Session(advice.Action, lager.Data{
+		"relation": advice.Relation,
+		"reason":   advice.Reason,
+	})
+
+	logger.Info("start")
+
+	var err error
+	switch advice.Action {
+	case atc.DBMaintenanceVacuum:
+		err = advisor.repository.Vacuum(advice.Relation)
+	case atc.DBMaintenanceReindex:
+		err = advisor.repository.Reindex(advice.Relation)
+	}
+	if err != nil {
+		logger.Error("failed", err)
+		return
+	}
+
+	logger.Info("done")
+}
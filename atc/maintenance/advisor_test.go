@@ -0,0 +1,122 @@
+package maintenance_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/maintenance"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Advisor", func() {
+	var (
+		fakeRepository *dbfakes.FakeMaintenanceRepository
+		config         maintenance.Config
+
+		runErr error
+	)
+
+	openWindow := func() maintenance.Window {
+		now := time.Now().UTC()
+		sinceMidnight := time.Duration(now.Hour())*time.Hour + time.Duration(now.Minute())*time.Minute
+		return maintenance.Window{
+			Start: sinceMidnight - time.Hour,
+			End:   sinceMidnight + time.Hour,
+		}
+	}
+
+	BeforeEach(func() {
+		fakeRepository = new(dbfakes.FakeMaintenanceRepository)
+		fakeRepository.MaintenanceReturns(atc.DBMaintenance{
+			Tables: []atc.DBTableHealth{
+				{
+					Name:       "build_events",
+					DeadTuples: 50000,
+					Indexes: []atc.DBIndexHealth{
+						{Name: "build_events_build_id_idx", BloatBytes: 1000},
+					},
+				},
+			},
+			Advice: []atc.DBMaintenanceAdvice{
+				{Action: atc.DBMaintenanceVacuum, Relation: "pipeline_build_events_1"},
+				{Action: atc.DBMaintenanceReindex, Relation: "builds_pkey"},
+			},
+		}, nil)
+
+		config = maintenance.Config{}
+	})
+
+	JustBeforeEach(func() {
+		runErr = maintenance.NewAdvisor(fakeRepository, config).Run(context.TODO())
+	})
+
+	Context("when automatic maintenance is disabled", func() {
+		It("only takes stock", func() {
+			Expect(runErr).ToNot(HaveOccurred())
+			Expect(fakeRepository.MaintenanceCallCount()).To(Equal(1))
+			Expect(fakeRepository.VacuumCallCount()).To(BeZero())
+			Expect(fakeRepository.ReindexCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when automatic maintenance is enabled", func() {
+		BeforeEach(func() {
+			config.Auto = true
+		})
+
+		Context("within the window", func() {
+			BeforeEach(func() {
+				config.Window = openWindow()
+			})
+
+			It("carries out the advice", func() {
+				Expect(runErr).ToNot(HaveOccurred())
+				Expect(fakeRepository.VacuumCallCount()).To(Equal(1))
+				Expect(fakeRepository.VacuumArgsForCall(0)).To(Equal("pipeline_build_events_1"))
+				Expect(fakeRepository.ReindexCallCount()).To(Equal(1))
+				Expect(fakeRepository.ReindexArgsForCall(0)).To(Equal("builds_pkey"))
+			})
+
+			Context("when some advice fails to be carried out", func() {
+				BeforeEach(func() {
+					fakeRepository.VacuumReturns(errors.New("canceling statement due to lock timeout"))
+				})
+
+				It("carries out the rest", func() {
+					Expect(runErr).ToNot(HaveOccurred())
+					Expect(fakeRepository.ReindexCallCount()).To(Equal(1))
+				})
+			})
+		})
+
+		Context("outside of the window", func() {
+			BeforeEach(func() {
+				window := openWindow()
+				config.Window = maintenance.Window{
+					Start: window.End,
+					End:   window.End + time.Hour,
+				}
+			})
+
+			It("leaves the tables alone", func() {
+				Expect(runErr).ToNot(HaveOccurred())
+				Expect(fakeRepository.VacuumCallCount()).To(BeZero())
+				Expect(fakeRepository.ReindexCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Context("when taking stock fails", func() {
+		BeforeEach(func() {
+			fakeRepository.MaintenanceReturns(atc.DBMaintenance{}, errors.New("disaster"))
+		})
+
+		It("returns the error", func() {
+			Expect(runErr).To(MatchError("disaster"))
+		})
+	})
+})
@@ -0,0 +1,55 @@
+package maintenance
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Window is a time of day, in UTC, within which the database is quiet enough
+// to be maintained, e.g. 02:00-05:00. It may span midnight, e.g. 23:00-01:00.
+type Window struct {
+	Start time.Duration
+	End   time.Duration
+}
+
+func (window *Window) UnmarshalFlag(value string) error {
+	start, end, found := strings.Cut(value, "-")
+	if !found {
+		return fmt.Errorf("invalid maintenance window '%s': expected HH:MM-HH:MM", value)
+	}
+
+	var err error
+	window.Start, err = parseTimeOfDay(start)
+	if err != nil {
+		return fmt.Errorf("invalid maintenance window '%s': %w", value, err)
+	}
+
+	window.End, err = parseTimeOfDay(end)
+	if err != nil {
+		return fmt.Errorf("invalid maintenance window '%s': %w", value, err)
+	}
+
+	return nil
+}
+
+func parseTimeOfDay(value string) (time.Duration, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(value))
+	if err != nil {
+		return 0, err
+	}
+
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// Contains is whether t falls within the window.
+func (window Window) Contains(t time.Time) bool {
+	t = t.UTC()
+	sinceMidnight := t.Sub(time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC))
+
+	if window.Start <= window.End {
+		return sinceMidnight >= window.Start && sinceMidnight < window.End
+	}
+
+	return sinceMidnight >= window.Start || sinceMidnight < window.End
+}
@@ -0,0 +1,51 @@
+package maintenance_test
+
+import (
+	"time"
+
+	"github.com/concourse/concourse/atc/maintenance"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Window", func() {
+	at := func(hour, minute int) time.Time {
+		return time.Date(2022, 8, 1, hour, minute, 0, 0, time.UTC)
+	}
+
+	It("parses a time of day range", func() {
+		var window maintenance.Window
+		Expect(window.UnmarshalFlag("02:00-05:30")).To(Succeed())
+		Expect(window).To(Equal(maintenance.Window{
+			Start: 2 * time.Hour,
+			End:   5*time.Hour + 30*time.Minute,
+		}))
+	})
+
+	It("rejects anything else", func() {
+		var window maintenance.Window
+		Expect(window.UnmarshalFlag("02:00")).ToNot(Succeed())
+		Expect(window.UnmarshalFlag("2am-5am")).ToNot(Succeed())
+	})
+
+	It("contains the times within it", func() {
+		window := maintenance.Window{Start: 2 * time.Hour, End: 5 * time.Hour}
+		Expect(window.Contains(at(1, 59))).To(BeFalse())
+		Expect(window.Contains(at(2, 0))).To(BeTrue())
+		Expect(window.Contains(at(4, 59))).To(BeTrue())
+		Expect(window.Contains(at(5, 0))).To(BeFalse())
+	})
+
+	It("can span midnight", func() {
+		window := maintenance.Window{Start: 23 * time.Hour, End: time.Hour}
+		Expect(window.Contains(at(22, 59))).To(BeFalse())
+		Expect(window.Contains(at(23, 30))).To(BeTrue())
+		Expect(window.Contains(at(0, 30))).To(BeTrue())
+		Expect(window.Contains(at(1, 0))).To(BeFalse())
+	})
+
+	It("compares in UTC", func() {
+		window := maintenance.Window{Start: 2 * time.Hour, End: 5 * time.Hour}
+		Expect(window.Contains(at(3, 0).In(time.FixedZone("UTC+10", 10*60*60)))).To(BeTrue())
+	})
+})
@@ -61,7 +61,7 @@ func (config *NewRelicConfig) IsConfigured() bool {
 
 func (config *NewRelicConfig) NewEmitter(_ map[string]string) (metric.Emitter, error) {
 	client := &http.Client{
-		Transport: &http.Transport{Proxy: http.ProxyFromEnvironment},
+		Transport: http.DefaultTransport,
 		Timeout:   time.Minute,
 	}
 
@@ -103,6 +103,7 @@ func (emitter *NewRelicEmitter) Emit(logger lager.Logger, event metric.Event) {
 		"worker unknown containers",
 		"worker unknown volumes",
 		"volumes streamed",
+		"volume digest mismatches",
 		"get step cache hits",
 		"streamed resource caches":
 		emitter.NewRelicBatch = append(emitter.NewRelicBatch, emitter.transformToNewRelicEvent(event, ""))
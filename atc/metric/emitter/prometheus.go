@@ -37,13 +37,15 @@ type PrometheusEmitter struct {
 	stepsWaiting         *prometheus.GaugeVec
 	stepsWaitingDuration *prometheus.HistogramVec
 
-	buildDurationsVec *prometheus.HistogramVec
-	buildsAborted     prometheus.Counter
-	buildsErrored     prometheus.Counter
-	buildsFailed      prometheus.Counter
-	buildsFinished    prometheus.Counter
-	buildsFinishedVec *prometheus.CounterVec
-	buildsSucceeded   prometheus.Counter
+	buildDurationsVec      *prometheus.HistogramVec
+	buildsAborted          prometheus.Counter
+	buildsErrored          prometheus.Counter
+	buildsFailed           prometheus.Counter
+	buildsFinished         prometheus.Counter
+	buildsFinishedVec      *prometheus.CounterVec
+	buildsFailureReasonVec *prometheus.CounterVec
+	buildsSucceeded        prometheus.Counter
+	buildStartLatencyVec   *prometheus.HistogramVec
 
 	gcBuildCollectorDuration                      prometheus.Histogram
 	gcWorkerCollectorDuration                     prometheus.Histogram
@@ -76,13 +78,17 @@ type PrometheusEmitter struct {
 
 	checksEnqueued prometheus.Counter
 
-	volumesStreamed prometheus.Counter
+	volumesStreamed        prometheus.Counter
+	volumeDigestMismatches prometheus.Counter
 
 	getStepCacheHits       prometheus.Counter
 	streamedResourceCaches prometheus.Counter
 
 	workerContainers                   *prometheus.GaugeVec
 	workerUnknownContainers            *prometheus.GaugeVec
+	jobQueueJobs                       *prometheus.GaugeVec
+	databaseDeadTuples                 *prometheus.GaugeVec
+	databaseIndexBloat                 *prometheus.GaugeVec
 	workerVolumes                      *prometheus.GaugeVec
 	workerUnknownVolumes               *prometheus.GaugeVec
 	workerTasks                        *prometheus.GaugeVec
@@ -354,6 +360,18 @@ func (config *PrometheusConfig) NewEmitter(attributes map[string]string) (metric
 	)
 	prometheus.MustRegister(buildsFinishedVec)
 
+	buildsFailureReasonVec := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace:   "concourse",
+			Subsystem:   "builds",
+			Name:        "failure_reasons_total",
+			Help:        "Count of builds which didn't succeed by the reason why.",
+			ConstLabels: attributes,
+		},
+		[]string{"team", "pipeline", "job", "reason"},
+	)
+	prometheus.MustRegister(buildsFailureReasonVec)
+
 	buildDurationsVec := prometheus.NewHistogramVec(
 		prometheus.HistogramOpts{
 			Namespace:   "concourse",
@@ -367,6 +385,19 @@ func (config *PrometheusConfig) NewEmitter(attributes map[string]string) (metric
 	)
 	prometheus.MustRegister(buildDurationsVec)
 
+	buildStartLatencyVec := prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace:   "concourse",
+			Subsystem:   "builds",
+			Name:        "start_latency_seconds",
+			Help:        "Time from a build being created to its first task process starting in seconds, by phase.",
+			ConstLabels: attributes,
+			Buckets:     []float64{0.1, 0.5, 1, 5, 10, 30, 60, 120, 300, 600, 1800},
+		},
+		[]string{"team", "pipeline", "job", "phase"},
+	)
+	prometheus.MustRegister(buildStartLatencyVec)
+
 	checkBuildsFinished := prometheus.NewCounter(prometheus.CounterOpts{
 		Namespace:   "concourse",
 		Subsystem:   "builds",
@@ -437,6 +468,42 @@ func (config *PrometheusConfig) NewEmitter(attributes map[string]string) (metric
 	)
 	prometheus.MustRegister(workerUnknownContainers)
 
+	jobQueueJobs := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   "concourse",
+			Subsystem:   "job_queue",
+			Name:        "jobs",
+			Help:        "Number of jobs per queue of background work, by whether they are pending or dead",
+			ConstLabels: attributes,
+		},
+		[]string{"queue", "state"},
+	)
+	prometheus.MustRegister(jobQueueJobs)
+
+	databaseDeadTuples := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   "concourse",
+			Subsystem:   "db",
+			Name:        "dead_tuples",
+			Help:        "Number of dead tuples in each of the busiest tables, including their partitions",
+			ConstLabels: attributes,
+		},
+		[]string{"table"},
+	)
+	prometheus.MustRegister(databaseDeadTuples)
+
+	databaseIndexBloat := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   "concourse",
+			Subsystem:   "db",
+			Name:        "index_bloat_bytes",
+			Help:        "Estimated bytes of bloat in the indexes of each of the busiest tables",
+			ConstLabels: attributes,
+		},
+		[]string{"table"},
+	)
+	prometheus.MustRegister(databaseIndexBloat)
+
 	workerVolumes := prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Namespace:   "concourse",
@@ -564,6 +631,17 @@ func (config *PrometheusConfig) NewEmitter(attributes map[string]string) (metric
 	)
 	prometheus.MustRegister(volumesStreamed)
 
+	volumeDigestMismatches := prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace:   "concourse",
+			Subsystem:   "volumes",
+			Name:        "volume_digest_mismatches",
+			Help:        "Total number of volumes whose content did not match their recorded digest when streamed",
+			ConstLabels: attributes,
+		},
+	)
+	prometheus.MustRegister(volumeDigestMismatches)
+
 	workerOrphanedVolumesToBeCollected := prometheus.NewCounter(
 		prometheus.CounterOpts{
 			Namespace:   "concourse",
@@ -837,13 +915,15 @@ func (config *PrometheusConfig) NewEmitter(attributes map[string]string) (metric
 		gcContainerCollectorDuration:                  gcContainerCollectorDuration,
 		gcVolumeCollectorDuration:                     gcVolumeCollectorDuration,
 
-		buildDurationsVec: buildDurationsVec,
-		buildsAborted:     buildsAborted,
-		buildsErrored:     buildsErrored,
-		buildsFailed:      buildsFailed,
-		buildsFinished:    buildsFinished,
-		buildsFinishedVec: buildsFinishedVec,
-		buildsSucceeded:   buildsSucceeded,
+		buildDurationsVec:      buildDurationsVec,
+		buildsAborted:          buildsAborted,
+		buildsErrored:          buildsErrored,
+		buildsFailed:           buildsFailed,
+		buildsFinished:         buildsFinished,
+		buildsFinishedVec:      buildsFinishedVec,
+		buildsFailureReasonVec: buildsFailureReasonVec,
+		buildsSucceeded:        buildsSucceeded,
+		buildStartLatencyVec:   buildStartLatencyVec,
 
 		checkBuildsAborted:   checkBuildsAborted,
 		checkBuildsErrored:   checkBuildsErrored,
@@ -874,10 +954,14 @@ func (config *PrometheusConfig) NewEmitter(attributes map[string]string) (metric
 		workerVolumes:                      workerVolumes,
 		workerTasks:                        workerTasks,
 		workerUnknownContainers:            workerUnknownContainers,
+		jobQueueJobs:                       jobQueueJobs,
+		databaseDeadTuples:                 databaseDeadTuples,
+		databaseIndexBloat:                 databaseIndexBloat,
 		workerUnknownVolumes:               workerUnknownVolumes,
 		workerOrphanedVolumesToBeCollected: workerOrphanedVolumesToBeCollected,
 
-		volumesStreamed: volumesStreamed,
+		volumesStreamed:        volumesStreamed,
+		volumeDigestMismatches: volumeDigestMismatches,
 
 		getStepCacheHits:       getStepCacheHits,
 		streamedResourceCaches: streamedResourceCaches,
@@ -943,6 +1027,13 @@ func (emitter *PrometheusEmitter) Emit(logger lager.Logger, event metric.Event)
 			).Observe(event.Value)
 	case "build finished":
 		emitter.buildFinishedMetrics(logger, event)
+	case "build start latency":
+		emitter.buildStartLatencyVec.WithLabelValues(
+			event.Attributes["team_name"],
+			event.Attributes["pipeline"],
+			event.Attributes["job"],
+			event.Attributes["phase"],
+		).Observe(event.Value / 1000)
 	case "worker containers":
 		// update last seen counters, used to gc stale timeseries
 		emitter.updateLastSeen(event)
@@ -969,6 +1060,12 @@ func (emitter *PrometheusEmitter) Emit(logger lager.Logger, event metric.Event)
 		emitter.workerUnknownContainersMetric(logger, event)
 	case "worker unknown volumes":
 		emitter.workerUnknownVolumesMetric(logger, event)
+	case "job queue jobs":
+		emitter.jobQueueJobsMetric(logger, event)
+	case "database dead tuples":
+		emitter.databaseTableMetric(logger, emitter.databaseDeadTuples, event)
+	case "database index bloat (bytes)":
+		emitter.databaseTableMetric(logger, emitter.databaseIndexBloat, event)
 	case "worker tasks":
 		// update last seen counters, used to gc stale timeseries
 		emitter.updateLastSeen(event)
@@ -1011,6 +1108,8 @@ func (emitter *PrometheusEmitter) Emit(logger lager.Logger, event metric.Event)
 		emitter.checksEnqueued.Add(event.Value)
 	case "volumes streamed":
 		emitter.volumesStreamed.Add(event.Value)
+	case "volume digest mismatches":
+		emitter.volumeDigestMismatches.Add(event.Value)
 	case "get step cache hits":
 		emitter.getStepCacheHits.Add(event.Value)
 	case "streamed resource caches":
@@ -1091,6 +1190,11 @@ func (emitter *PrometheusEmitter) buildFinishedMetrics(logger lager.Logger, even
 		emitter.buildsErrored.Inc()
 	}
 
+	// concourse_builds_failure_reasons_total
+	if reason, exists := event.Attributes["failure_reason"]; exists {
+		emitter.buildsFailureReasonVec.WithLabelValues(team, pipeline, job, reason).Inc()
+	}
+
 	// seconds are the standard prometheus base unit for time
 	duration := event.Value / 1000
 	emitter.buildDurationsVec.WithLabelValues(team, pipeline, job).Observe(duration)
@@ -1184,6 +1288,32 @@ func (emitter *PrometheusEmitter) workerUnknownContainersMetric(logger lager.Log
 	emitter.workerUnknownContainers.With(emitter.workerContainersLabels[worker][key]).Set(event.Value)
 }
 
+func (emitter *PrometheusEmitter) jobQueueJobsMetric(logger lager.Logger, event metric.Event) {
+	queue, exists := event.Attributes["queue"]
+	if !exists {
+		logger.Error("failed-to-find-queue-in-event", fmt.Errorf("expected queue to exist in event.Attributes"))
+		return
+	}
+
+	state, exists := event.Attributes["state"]
+	if !exists {
+		logger.Error("failed-to-find-state-in-event", fmt.Errorf("expected state to exist in event.Attributes"))
+		return
+	}
+
+	emitter.jobQueueJobs.WithLabelValues(queue, state).Set(event.Value)
+}
+
+func (emitter *PrometheusEmitter) databaseTableMetric(logger lager.Logger, gauge *prometheus.GaugeVec, event metric.Event) {
+	table, exists := event.Attributes["table"]
+	if !exists {
+		logger.Error("failed-to-find-table-in-event", fmt.Errorf("expected table to exist in event.Attributes"))
+		return
+	}
+
+	gauge.WithLabelValues(table).Set(event.Value)
+}
+
 func (emitter *PrometheusEmitter) workerVolumesMetric(logger lager.Logger, event metric.Event) {
 	worker, exists := event.Attributes["worker"]
 	if !exists {
@@ -8,6 +8,7 @@ import (
 	"github.com/concourse/concourse/atc/db/lock"
 
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 )
 
@@ -245,6 +246,61 @@ func (event WorkerUnknownContainers) Emit(logger lager.Logger) {
 	)
 }
 
+type JobQueueJobs struct {
+	Queue   string
+	Pending int
+	Dead    int
+}
+
+func (event JobQueueJobs) Emit(logger lager.Logger) {
+	for state, jobs := range map[string]int{
+		"pending": event.Pending,
+		"dead":    event.Dead,
+	} {
+		Metrics.emit(
+			logger.Session("job-queue-jobs"),
+			Event{
+				Name:  "job queue jobs",
+				Value: float64(jobs),
+				Attributes: map[string]string{
+					"queue": event.Queue,
+					"state": state,
+				},
+			},
+		)
+	}
+}
+
+type DatabaseTableMaintenance struct {
+	Table           string
+	DeadTuples      int64
+	IndexBloatBytes int64
+}
+
+func (event DatabaseTableMaintenance) Emit(logger lager.Logger) {
+	Metrics.emit(
+		logger.Session("database-dead-tuples"),
+		Event{
+			Name:  "database dead tuples",
+			Value: float64(event.DeadTuples),
+			Attributes: map[string]string{
+				"table": event.Table,
+			},
+		},
+	)
+
+	Metrics.emit(
+		logger.Session("database-index-bloat"),
+		Event{
+			Name:  "database index bloat (bytes)",
+			Value: float64(event.IndexBloatBytes),
+			Attributes: map[string]string{
+				"table": event.Table,
+			},
+		},
+	)
+}
+
 type WorkerVolumes struct {
 	WorkerName string
 	Platform   string
@@ -450,6 +506,10 @@ func (event BuildFinished) Emit(logger lager.Logger) {
 	attrs := event.Build.TracingAttrs()
 	attrs["build_status"] = event.Build.Status().String()
 
+	if event.Build.FailureReason() != "" {
+		attrs["failure_reason"] = string(event.Build.FailureReason())
+	}
+
 	Metrics.emit(
 		logger.Session("build-finished"),
 		Event{
@@ -460,6 +520,34 @@ func (event BuildFinished) Emit(logger lager.Logger) {
 	)
 }
 
+// BuildStartLatency is emitted once a build's first task process starts,
+// with an event for each phase it took to get there and one for the total.
+type BuildStartLatency struct {
+	Build   db.Build
+	Latency atc.BuildStartLatency
+}
+
+func (event BuildStartLatency) Emit(logger lager.Logger) {
+	logger = logger.Session("build-start-latency")
+
+	phases := event.Latency.Phases()
+	phases["total"] = event.Latency.Total
+
+	for phase, value := range phases {
+		attrs := event.Build.TracingAttrs()
+		attrs["phase"] = string(phase)
+
+		Metrics.emit(
+			logger,
+			Event{
+				Name:       "build start latency",
+				Value:      float64(value),
+				Attributes: attrs,
+			},
+		)
+	}
+}
+
 func ms(duration time.Duration) float64 {
 	return float64(duration) / 1000000
 }
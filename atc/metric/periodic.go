@@ -75,6 +75,14 @@ func tick(logger lager.Logger, m *Monitor) {
 		},
 	)
 
+	m.emit(
+		logger.Session("volume-digest-mismatches"),
+		Event{
+			Name:  "volume digest mismatches",
+			Value: m.VolumeDigestMismatches.Delta(),
+		},
+	)
+
 	m.emit(
 		logger.Session("get-step-cache-hits"),
 		Event{
@@ -0,0 +1,104 @@
+package atc
+
+import (
+	"fmt"
+	"strings"
+)
+
+type NotificationEvent string
+
+const (
+	NotificationEventFailure  NotificationEvent = "failure"
+	NotificationEventError    NotificationEvent = "error"
+	NotificationEventAbort    NotificationEvent = "abort"
+	NotificationEventRecovery NotificationEvent = "recovery"
+)
+
+var NotificationEvents = []NotificationEvent{
+	NotificationEventFailure,
+	NotificationEventError,
+	NotificationEventAbort,
+	NotificationEventRecovery,
+}
+
+// NotificationRule sends a notification to each of the team's notifiers
+// named by Notify when a build finishes with one of the events.
+//
+// 'recovery' is the first successful build after a build which did not
+// succeed.
+type NotificationRule struct {
+	On     []NotificationEvent `json:"on"`
+	Notify []string            `json:"notify"`
+}
+
+func (rule NotificationRule) Validate() error {
+	if len(rule.On) == 0 {
+		return fmt.Errorf("no events specified")
+	}
+
+	for _, event := range rule.On {
+		known := false
+		for _, e := range NotificationEvents {
+			if event == e {
+				known = true
+				break
+			}
+		}
+
+		if !known {
+			var events []string
+			for _, e := range NotificationEvents {
+				events = append(events, string(e))
+			}
+
+			return fmt.Errorf("unknown event '%s' (must be one of %s)", event, strings.Join(events, ", "))
+		}
+	}
+
+	if len(rule.Notify) == 0 {
+		return fmt.Errorf("no notifiers specified")
+	}
+
+	return nil
+}
+
+type NotificationRules []NotificationRule
+
+// Notifiers returns the names of the notifiers which are notified of the
+// event, without duplicates.
+func (rules NotificationRules) Notifiers(event NotificationEvent) []string {
+	var names []string
+	seen := map[string]bool{}
+
+	for _, rule := range rules {
+		for _, e := range rule.On {
+			if e != event {
+				continue
+			}
+
+			for _, name := range rule.Notify {
+				if !seen[name] {
+					seen[name] = true
+					names = append(names, name)
+				}
+			}
+		}
+	}
+
+	return names
+}
+
+// NotifierConfig configures a team's sender of notifications, e.g. a Slack
+// webhook. The config is specific to the type of notifier.
+type NotifierConfig struct {
+	Name   string                 `json:"name"`
+	Type   string                 `json:"type"`
+	Config map[string]interface{} `json:"config,omitempty"`
+}
+
+// Notifier is a team's notifier as returned by the API. Its config is not
+// included, as it typically contains credentials.
+type Notifier struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
@@ -0,0 +1,81 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+)
+
+type EmailConfig struct {
+	Host     string   `json:"host"`
+	Port     int      `json:"port,omitempty"`
+	Username string   `json:"username,omitempty"`
+	Password string   `json:"password,omitempty"`
+	From     string   `json:"from"`
+	To       []string `json:"to"`
+}
+
+// EmailSender sends notifications by email through an SMTP server.
+type EmailSender struct {
+	Config EmailConfig
+
+	sendMail func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+}
+
+func NewEmailSender(config map[string]interface{}) (Sender, error) {
+	var emailConfig EmailConfig
+	err := decodeConfig(config, &emailConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	if emailConfig.Host == "" {
+		return nil, errors.New("missing host")
+	}
+
+	if emailConfig.From == "" {
+		return nil, errors.New("missing from")
+	}
+
+	if len(emailConfig.To) == 0 {
+		return nil, errors.New("missing to")
+	}
+
+	if emailConfig.Port == 0 {
+		emailConfig.Port = 587
+	}
+
+	return EmailSender{Config: emailConfig, sendMail: smtp.SendMail}, nil
+}
+
+func (sender EmailSender) Send(ctx context.Context, notification Notification) error {
+	var auth smtp.Auth
+	if sender.Config.Username != "" {
+		auth = smtp.PlainAuth("", sender.Config.Username, sender.Config.Password, sender.Config.Host)
+	}
+
+	addr := net.JoinHostPort(sender.Config.Host, strconv.Itoa(sender.Config.Port))
+
+	return sender.sendMail(addr, auth, sender.Config.From, sender.Config.To, sender.message(notification))
+}
+
+func (sender EmailSender) message(notification Notification) []byte {
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", sender.Config.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(sender.Config.To, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", notification.Summary())
+	fmt.Fprintf(&msg, "Content-Type: text/plain; charset=UTF-8\r\n")
+	fmt.Fprintf(&msg, "\r\n")
+	fmt.Fprintf(&msg, "%s\r\n", notification.Summary())
+
+	if notification.URL != "" {
+		fmt.Fprintf(&msg, "\r\n%s\r\n", notification.URL)
+	}
+
+	return msg.Bytes()
+}
@@ -0,0 +1,182 @@
+package notify
+
+import (
+	"context"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+// recoveryLookback is the number of preceding builds of a job which are
+// searched for the last completed build when determining whether a
+// successful build is a recovery.
+const recoveryLookback = 10
+
+const sendTimeout = time.Minute
+
+// BuildNotifier sends notifications about finished job builds to the team's
+// notifiers named by the matching notification rules of the pipeline and
+// job.
+type BuildNotifier struct {
+	teamFactory db.TeamFactory
+	externalURL string
+}
+
+func NewBuildNotifier(teamFactory db.TeamFactory, externalURL string) *BuildNotifier {
+	return &BuildNotifier{
+		teamFactory: teamFactory,
+		externalURL: externalURL,
+	}
+}
+
+// BuildStarted does nothing, as notifications are only sent for finished
+// builds.
+func (notifier *BuildNotifier) BuildStarted(lager.Logger, db.Build) {}
+
+// BuildFinished determines the notifications for the finished build and
+// sends them in the background, so that slow or unreachable notifiers do not
+// hold up the build.
+func (notifier *BuildNotifier) BuildFinished(logger lager.Logger, build db.Build) {
+	if build.JobID() == 0 {
+		return
+	}
+
+	logger = logger.Session("notify")
+
+	job, found, err := build.Job()
+	if err != nil {
+		logger.Error("failed-to-get-job", err)
+		return
+	}
+
+	if !found {
+		return
+	}
+
+	event, ok, err := notifier.event(build, job)
+	if err != nil {
+		logger.Error("failed-to-determine-event", err)
+		return
+	}
+
+	if !ok {
+		return
+	}
+
+	pipeline, found, err := build.Pipeline()
+	if err != nil {
+		logger.Error("failed-to-get-pipeline", err)
+		return
+	}
+
+	if !found {
+		return
+	}
+
+	jobConfig, err := job.Config()
+	if err != nil {
+		logger.Error("failed-to-get-job-config", err)
+		return
+	}
+
+	rules := append(atc.NotificationRules{}, pipeline.Notifications()...)
+	rules = append(rules, jobConfig.Notifications...)
+
+	names := rules.Notifiers(event)
+	if len(names) == 0 {
+		return
+	}
+
+	teamNotifiers, err := notifier.teamFactory.GetByID(build.TeamID()).Notifiers()
+	if err != nil {
+		logger.Error("failed-to-get-team-notifiers", err)
+		return
+	}
+
+	configs := map[string]atc.NotifierConfig{}
+	for _, config := range teamNotifiers {
+		configs[config.Name] = config
+	}
+
+	notification := Notification{
+		Event:                event,
+		TeamName:             build.TeamName(),
+		PipelineName:         build.PipelineName(),
+		PipelineInstanceVars: build.PipelineInstanceVars(),
+		JobName:              build.JobName(),
+		BuildID:              build.ID(),
+		BuildName:            build.Name(),
+		Status:               atc.BuildStatus(build.Status()),
+		URL:                  atc.JobBuildURL(notifier.externalURL, build.TeamName(), build.PipelineRef(), build.JobName(), build.Name()),
+	}
+
+	for _, name := range names {
+		config, found := configs[name]
+		if !found {
+			logger.Info("notifier-not-found", lager.Data{"notifier": name})
+			continue
+		}
+
+		sender, err := NewSender(config)
+		if err != nil {
+			logger.Error("failed-to-create-sender", err, lager.Data{"notifier": name})
+			continue
+		}
+
+		go func(name string, sender Sender) {
+			ctx, cancel := context.WithTimeout(context.Background(), sendTimeout)
+			defer cancel()
+
+			err := sender.Send(ctx, notification)
+			if err != nil {
+				logger.Error("failed-to-send-notification", err, lager.Data{"notifier": name})
+				return
+			}
+
+			logger.Debug("sent-notification", lager.Data{"notifier": name})
+		}(name, sender)
+	}
+}
+
+func (notifier *BuildNotifier) event(build db.Build, job db.Job) (atc.NotificationEvent, bool, error) {
+	switch build.Status() {
+	case db.BuildStatusFailed:
+		return atc.NotificationEventFailure, true, nil
+	case db.BuildStatusErrored:
+		return atc.NotificationEventError, true, nil
+	case db.BuildStatusAborted:
+		return atc.NotificationEventAbort, true, nil
+	case db.BuildStatusSucceeded:
+		recovered, err := notifier.recovered(build, job)
+		if err != nil {
+			return "", false, err
+		}
+
+		return atc.NotificationEventRecovery, recovered, nil
+	default:
+		return "", false, nil
+	}
+}
+
+// recovered returns true if the last completed build before the build did
+// not succeed.
+func (notifier *BuildNotifier) recovered(build db.Build, job db.Job) (bool, error) {
+	to := build.ID() - 1
+
+	previousBuilds, _, err := job.Builds(db.Page{To: &to, Limit: recoveryLookback})
+	if err != nil {
+		return false, err
+	}
+
+	for _, previousBuild := range previousBuilds {
+		if previousBuild.IsRunning() {
+			continue
+		}
+
+		return previousBuild.Status() != db.BuildStatusSucceeded, nil
+	}
+
+	return false, nil
+}
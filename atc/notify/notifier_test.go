@@ -0,0 +1,161 @@
+package notify_test
+
+import (
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/notify"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("BuildNotifier", func() {
+	var (
+		server *ghttp.Server
+
+		fakeTeamFactory *dbfakes.FakeTeamFactory
+		fakeTeam        *dbfakes.FakeTeam
+		fakePipeline    *dbfakes.FakePipeline
+		fakeJob         *dbfakes.FakeJob
+		fakeBuild       *dbfakes.FakeBuild
+
+		notifier *notify.BuildNotifier
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		server.AllowUnhandledRequests = true
+
+		fakeTeam = new(dbfakes.FakeTeam)
+		fakeTeam.NotifiersReturns([]atc.NotifierConfig{
+			{
+				Name:   "some-webhook",
+				Type:   "webhook",
+				Config: map[string]interface{}{"url": server.URL()},
+			},
+		}, nil)
+
+		fakeTeamFactory = new(dbfakes.FakeTeamFactory)
+		fakeTeamFactory.GetByIDReturns(fakeTeam)
+
+		fakePipeline = new(dbfakes.FakePipeline)
+		fakePipeline.NotificationsReturns(atc.NotificationRules{
+			{On: []atc.NotificationEvent{atc.NotificationEventFailure}, Notify: []string{"some-webhook"}},
+		})
+
+		fakeJob = new(dbfakes.FakeJob)
+		fakeJob.ConfigReturns(atc.JobConfig{
+			Name: "some-job",
+			Notifications: atc.NotificationRules{
+				{On: []atc.NotificationEvent{atc.NotificationEventRecovery}, Notify: []string{"some-webhook"}},
+			},
+		}, nil)
+
+		fakeBuild = new(dbfakes.FakeBuild)
+		fakeBuild.IDReturns(42)
+		fakeBuild.NameReturns("7")
+		fakeBuild.TeamIDReturns(1)
+		fakeBuild.TeamNameReturns("some-team")
+		fakeBuild.JobIDReturns(2)
+		fakeBuild.JobNameReturns("some-job")
+		fakeBuild.PipelineNameReturns("some-pipeline")
+		fakeBuild.PipelineRefReturns(atc.PipelineRef{Name: "some-pipeline"})
+		fakeBuild.JobReturns(fakeJob, true, nil)
+		fakeBuild.PipelineReturns(fakePipeline, true, nil)
+
+		notifier = notify.NewBuildNotifier(fakeTeamFactory, "https://example.com")
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	JustBeforeEach(func() {
+		notifier.BuildFinished(lagertest.NewTestLogger("test"), fakeBuild)
+	})
+
+	Context("when the build failed", func() {
+		BeforeEach(func() {
+			fakeBuild.StatusReturns(db.BuildStatusFailed)
+		})
+
+		It("notifies the team's notifier named by the pipeline's rule", func() {
+			Eventually(server.ReceivedRequests).Should(HaveLen(1))
+			Expect(fakeTeamFactory.GetByIDArgsForCall(0)).To(Equal(1))
+		})
+	})
+
+	Context("when the build errored", func() {
+		BeforeEach(func() {
+			fakeBuild.StatusReturns(db.BuildStatusErrored)
+		})
+
+		It("does not notify without a matching rule", func() {
+			Consistently(server.ReceivedRequests).Should(BeEmpty())
+			Expect(fakeTeam.NotifiersCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when the build succeeded", func() {
+		var previousBuild *dbfakes.FakeBuildForAPI
+
+		BeforeEach(func() {
+			fakeBuild.StatusReturns(db.BuildStatusSucceeded)
+
+			previousBuild = new(dbfakes.FakeBuildForAPI)
+			fakeJob.BuildsReturns([]db.BuildForAPI{previousBuild}, db.Pagination{}, nil)
+		})
+
+		It("looks for the builds before it", func() {
+			Expect(fakeJob.BuildsCallCount()).To(Equal(1))
+			Expect(*fakeJob.BuildsArgsForCall(0).To).To(Equal(41))
+		})
+
+		Context("when the previous build failed", func() {
+			BeforeEach(func() {
+				previousBuild.StatusReturns(db.BuildStatusFailed)
+			})
+
+			It("notifies of the recovery", func() {
+				Eventually(server.ReceivedRequests).Should(HaveLen(1))
+			})
+		})
+
+		Context("when the previous build succeeded", func() {
+			BeforeEach(func() {
+				previousBuild.StatusReturns(db.BuildStatusSucceeded)
+			})
+
+			It("does not notify", func() {
+				Consistently(server.ReceivedRequests).Should(BeEmpty())
+			})
+		})
+	})
+
+	Context("when the build is not a job build", func() {
+		BeforeEach(func() {
+			fakeBuild.JobIDReturns(0)
+			fakeBuild.StatusReturns(db.BuildStatusFailed)
+		})
+
+		It("does not notify", func() {
+			Expect(fakeBuild.JobCallCount()).To(Equal(0))
+			Consistently(server.ReceivedRequests).Should(BeEmpty())
+		})
+	})
+
+	Context("when a rule names a notifier the team does not have", func() {
+		BeforeEach(func() {
+			fakeBuild.StatusReturns(db.BuildStatusFailed)
+			fakePipeline.NotificationsReturns(atc.NotificationRules{
+				{On: []atc.NotificationEvent{atc.NotificationEventFailure}, Notify: []string{"missing", "some-webhook"}},
+			})
+		})
+
+		It("still notifies the notifiers which exist", func() {
+			Eventually(server.ReceivedRequests).Should(HaveLen(1))
+		})
+	})
+})
@@ -0,0 +1,142 @@
+// Package notify sends notifications about finished builds to the senders
+// configured by each team, according to the notification rules of their
+// pipelines and jobs.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+
+// Notification describes a finished build which matched a notification rule.
+type Notification struct {
+	Event atc.NotificationEvent `json:"event"`
+
+	TeamName             string           `json:"team"`
+	PipelineName         string           `json:"pipeline"`
+	PipelineInstanceVars atc.InstanceVars `json:"pipeline_instance_vars,omitempty"`
+	JobName              string           `json:"job"`
+	BuildID              int              `json:"build_id"`
+	BuildName            string           `json:"build"`
+	Status               atc.BuildStatus  `json:"status"`
+
+	URL string `json:"url"`
+}
+
+// Summary is a one-line human readable description of the notification.
+func (n Notification) Summary() string {
+	pipelineRef := atc.PipelineRef{Name: n.PipelineName, InstanceVars: n.PipelineInstanceVars}
+
+	var outcome string
+	switch n.Event {
+	case atc.NotificationEventRecovery:
+		outcome = "recovered"
+	case atc.NotificationEventAbort:
+		outcome = "was aborted"
+	case atc.NotificationEventError:
+		outcome = "errored"
+	default:
+		outcome = "failed"
+	}
+
+	return fmt.Sprintf("%s/%s/%s #%s %s", n.TeamName, pipelineRef.String(), n.JobName, n.BuildName, outcome)
+}
+
+//counterfeiter:generate . Sender
+type Sender interface {
+	Send(context.Context, Notification) error
+}
+
+// SenderFactory creates a sender from the config of a team's notifier.
+type SenderFactory func(config map[string]interface{}) (Sender, error)
+
+var senderFactories = map[string]SenderFactory{
+	"slack":   NewSlackSender,
+	"webhook": NewWebhookSender,
+	"email":   NewEmailSender,
+}
+
+// Types returns the supported types of notifiers.
+func Types() []string {
+	var types []string
+	for t := range senderFactories {
+		types = append(types, t)
+	}
+
+	sort.Strings(types)
+
+	return types
+}
+
+// NewSender creates the sender for a team's notifier.
+func NewSender(notifier atc.NotifierConfig) (Sender, error) {
+	factory, found := senderFactories[notifier.Type]
+	if !found {
+		return nil, fmt.Errorf("unknown notifier type '%s'", notifier.Type)
+	}
+
+	return factory(notifier.Config)
+}
+
+var httpClient = &http.Client{
+	Transport: http.DefaultTransport,
+	Timeout:   10 * time.Second,
+}
+
+// decodeConfig decodes a notifier's config into the sender specific config,
+// rejecting unknown fields so that typos are not silently ignored.
+func decodeConfig(config map[string]interface{}, dest interface{}) error {
+	payload, err := json.Marshal(config)
+	if err != nil {
+		return err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	decoder.DisallowUnknownFields()
+
+	err = decoder.Decode(dest)
+	if err != nil {
+		return fmt.Errorf("invalid config: %w", err)
+	}
+
+	return nil
+}
+
+func postJSON(ctx context.Context, url string, headers map[string]string, body interface{}) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected response status: %s", resp.Status)
+	}
+
+	return nil
+}
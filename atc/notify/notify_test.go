@@ -0,0 +1,129 @@
+package notify_test
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/notify"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Senders", func() {
+	var (
+		server       *ghttp.Server
+		notification notify.Notification
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+
+		notification = notify.Notification{
+			Event:        atc.NotificationEventFailure,
+			TeamName:     "some-team",
+			PipelineName: "some-pipeline",
+			JobName:      "some-job",
+			BuildID:      42,
+			BuildName:    "7",
+			Status:       atc.StatusFailed,
+			URL:          "https://example.com/builds/42",
+		}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("NewSender", func() {
+		It("errors for an unknown type", func() {
+			_, err := notify.NewSender(atc.NotifierConfig{Name: "some-notifier", Type: "pigeon"})
+			Expect(err).To(MatchError("unknown notifier type 'pigeon'"))
+		})
+
+		It("errors for unknown config fields", func() {
+			_, err := notify.NewSender(atc.NotifierConfig{
+				Name:   "some-notifier",
+				Type:   "webhook",
+				Config: map[string]interface{}{"url": server.URL(), "uri": "typo"},
+			})
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("errors when an email notifier has no recipients", func() {
+			_, err := notify.NewSender(atc.NotifierConfig{
+				Name:   "some-notifier",
+				Type:   "email",
+				Config: map[string]interface{}{"host": "smtp.example.com", "from": "ci@example.com"},
+			})
+			Expect(err).To(MatchError("missing to"))
+		})
+	})
+
+	Describe("webhook", func() {
+		It("posts the notification as JSON with the configured headers", func() {
+			server.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest("POST", "/hook"),
+				ghttp.VerifyHeaderKV("Authorization", "Bearer some-token"),
+				ghttp.VerifyJSON(`{
+					"event": "failure",
+					"team": "some-team",
+					"pipeline": "some-pipeline",
+					"job": "some-job",
+					"build_id": 42,
+					"build": "7",
+					"status": "failed",
+					"url": "https://example.com/builds/42"
+				}`),
+			))
+
+			sender, err := notify.NewSender(atc.NotifierConfig{
+				Name: "some-notifier",
+				Type: "webhook",
+				Config: map[string]interface{}{
+					"url":     server.URL() + "/hook",
+					"headers": map[string]interface{}{"Authorization": "Bearer some-token"},
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(sender.Send(context.Background(), notification)).To(Succeed())
+			Expect(server.ReceivedRequests()).To(HaveLen(1))
+		})
+
+		It("errors when the response is not successful", func() {
+			server.AppendHandlers(ghttp.RespondWith(http.StatusInternalServerError, nil))
+
+			sender, err := notify.NewSender(atc.NotifierConfig{
+				Name:   "some-notifier",
+				Type:   "webhook",
+				Config: map[string]interface{}{"url": server.URL()},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(sender.Send(context.Background(), notification)).To(MatchError(ContainSubstring("500")))
+		})
+	})
+
+	Describe("slack", func() {
+		It("posts a message linking to the build", func() {
+			server.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest("POST", "/slack"),
+				ghttp.VerifyJSON(`{
+					"channel": "#ci",
+					"text": "<https://example.com/builds/42|some-team/some-pipeline/some-job #7 failed>"
+				}`),
+			))
+
+			sender, err := notify.NewSender(atc.NotifierConfig{
+				Name:   "some-notifier",
+				Type:   "slack",
+				Config: map[string]interface{}{"url": server.URL() + "/slack", "channel": "#ci"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(sender.Send(context.Background(), notification)).To(Succeed())
+		})
+	})
+})
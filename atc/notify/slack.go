@@ -0,0 +1,48 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+type SlackConfig struct {
+	URL     string `json:"url"`
+	Channel string `json:"channel,omitempty"`
+}
+
+// SlackSender posts notifications to a Slack incoming webhook.
+type SlackSender struct {
+	Config SlackConfig
+}
+
+func NewSlackSender(config map[string]interface{}) (Sender, error) {
+	var slackConfig SlackConfig
+	err := decodeConfig(config, &slackConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	if slackConfig.URL == "" {
+		return nil, errors.New("missing url")
+	}
+
+	return SlackSender{Config: slackConfig}, nil
+}
+
+type slackMessage struct {
+	Channel string `json:"channel,omitempty"`
+	Text    string `json:"text"`
+}
+
+func (sender SlackSender) Send(ctx context.Context, notification Notification) error {
+	text := notification.Summary()
+	if notification.URL != "" {
+		text = fmt.Sprintf("<%s|%s>", notification.URL, text)
+	}
+
+	return postJSON(ctx, sender.Config.URL, nil, slackMessage{
+		Channel: sender.Config.Channel,
+		Text:    text,
+	})
+}
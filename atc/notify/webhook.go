@@ -0,0 +1,34 @@
+package notify
+
+import (
+	"context"
+	"errors"
+)
+
+type WebhookConfig struct {
+	URL     string            `json:"url"`
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+// WebhookSender posts each notification as JSON to a URL.
+type WebhookSender struct {
+	Config WebhookConfig
+}
+
+func NewWebhookSender(config map[string]interface{}) (Sender, error) {
+	var webhookConfig WebhookConfig
+	err := decodeConfig(config, &webhookConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	if webhookConfig.URL == "" {
+		return nil, errors.New("missing url")
+	}
+
+	return WebhookSender{Config: webhookConfig}, nil
+}
+
+func (sender WebhookSender) Send(ctx context.Context, notification Notification) error {
+	return postJSON(ctx, sender.Config.URL, sender.Config.Headers, notification)
+}
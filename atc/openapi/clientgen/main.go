@@ -0,0 +1,214 @@
+// clientgen generates the methods of go-concourse/apiclient from atc.Routes
+// and the operations the OpenAPI document is described by, so that the client
+// covers every route the ATC serves.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/openapi"
+	"github.com/tedsuo/rata"
+)
+
+func main() {
+	output := flag.String("o", "operations.go", "file to write the client's methods to")
+	flag.Parse()
+
+	source, err := generate()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	err = ioutil.WriteFile(*output, source, 0644)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func generate() ([]byte, error) {
+	g := &generator{
+		imports: map[string]bool{
+			"context":                            true,
+			"github.com/concourse/concourse/atc": true,
+			"github.com/tedsuo/rata":             true,
+		},
+	}
+
+	for _, route := range atc.Routes {
+		op, found := openapi.Operations[route.Name]
+		if !found {
+			return nil, fmt.Errorf("route %s is not described by an operation", route.Name)
+		}
+
+		if op.WebSocket {
+			continue
+		}
+
+		g.method(route, op)
+	}
+
+	var std, others []string
+	for path := range g.imports {
+		if strings.Contains(path, ".") {
+			others = append(others, path)
+		} else {
+			std = append(std, path)
+		}
+	}
+
+	sort.Strings(std)
+	sort.Strings(others)
+
+	source := &bytes.Buffer{}
+	fmt.Fprintln(source, "// Code generated by clientgen. DO NOT EDIT.")
+	fmt.Fprintln(source)
+	fmt.Fprintln(source, "package apiclient")
+	fmt.Fprintln(source)
+	fmt.Fprintln(source, "import (")
+	for _, path := range std {
+		fmt.Fprintf(source, "\t%q\n", path)
+	}
+	fmt.Fprintln(source)
+	for _, path := range others {
+		fmt.Fprintf(source, "\t%q\n", path)
+	}
+	fmt.Fprintln(source, ")")
+	source.Write(g.methods.Bytes())
+
+	return format.Source(source.Bytes())
+}
+
+type generator struct {
+	imports map[string]bool
+	methods bytes.Buffer
+}
+
+func (g *generator) method(route rata.Route, op openapi.Operation) {
+	_, params := openapi.Path(route.Path)
+
+	args := []string{"ctx context.Context"}
+	var paramsExpr []string
+	for _, param := range params {
+		args = append(args, identifier(param)+" string")
+		paramsExpr = append(paramsExpr, fmt.Sprintf("%q: %s", param, identifier(param)))
+	}
+
+	bodyExpr := "nil"
+	switch {
+	case op.Request != nil && isText(op.RequestContentType):
+		args = append(args, "body "+g.typeExpr(reflect.TypeOf(op.Request)))
+		bodyExpr = "textBody(string(body))"
+	case op.Request != nil:
+		// YAML bodies are sent as JSON, of which YAML is a superset
+		args = append(args, "body "+g.typeExpr(reflect.TypeOf(op.Request)))
+		bodyExpr = "jsonBody(body)"
+	case op.RequestContentType != "":
+		g.imports["io"] = true
+		args = append(args, "body io.Reader")
+		bodyExpr = fmt.Sprintf("rawBody(body, %q)", op.RequestContentType)
+	}
+
+	args = append(args, "opts ...RequestOption")
+
+	call := fmt.Sprintf("ctx, atc.%s, rata.Params{%s}, %s", route.Name, strings.Join(paramsExpr, ", "), bodyExpr)
+
+	w := &g.methods
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "// %s calls %s %s.\n", route.Name, route.Method, route.Path)
+	if op.Summary != "" {
+		fmt.Fprintln(w, "//")
+		fmt.Fprintf(w, "// %s.\n", op.Summary)
+	}
+
+	signature := fmt.Sprintf("func (c *Client) %s(%s)", route.Name, strings.Join(args, ", "))
+
+	switch {
+	case op.Response != nil && op.ResponseContentType == "":
+		resultType := g.typeExpr(reflect.TypeOf(op.Response))
+		fmt.Fprintf(w, "%s (%s, error) {\n", signature, resultType)
+		fmt.Fprintf(w, "\tvar result %s\n", resultType)
+		fmt.Fprintf(w, "\terr := c.sendJSON(%s, &result, opts)\n", call)
+		fmt.Fprintln(w, "\treturn result, err")
+
+	case op.Response != nil && isText(op.ResponseContentType):
+		resultType := g.typeExpr(reflect.TypeOf(op.Response))
+		fmt.Fprintf(w, "%s (%s, error) {\n", signature, resultType)
+		fmt.Fprintf(w, "\ttext, err := c.sendText(%s, opts)\n", call)
+		fmt.Fprintf(w, "\treturn %s(text), err\n", resultType)
+
+	case op.ResponseContentType != "":
+		g.imports["io"] = true
+		fmt.Fprintf(w, "%s (io.ReadCloser, error) {\n", signature)
+		fmt.Fprintf(w, "\treturn c.sendStream(%s, opts)\n", call)
+
+	default:
+		fmt.Fprintf(w, "%s error {\n", signature)
+		fmt.Fprintf(w, "\treturn c.sendJSON(%s, nil, opts)\n", call)
+	}
+
+	fmt.Fprintln(w, "}")
+}
+
+// typeExpr returns the Go expression of the type, importing the packages of
+// the named types it refers to.
+func (g *generator) typeExpr(t reflect.Type) string {
+	if t.Name() != "" {
+		if t.PkgPath() == "" {
+			return t.Name()
+		}
+
+		g.imports[t.PkgPath()] = true
+
+		return t.PkgPath()[strings.LastIndex(t.PkgPath(), "/")+1:] + "." + t.Name()
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		return "*" + g.typeExpr(t.Elem())
+	case reflect.Slice:
+		return "[]" + g.typeExpr(t.Elem())
+	case reflect.Array:
+		return fmt.Sprintf("[%d]%s", t.Len(), g.typeExpr(t.Elem()))
+	case reflect.Map:
+		return "map[" + g.typeExpr(t.Key()) + "]" + g.typeExpr(t.Elem())
+	case reflect.Interface:
+		return "interface{}"
+	default:
+		panic(fmt.Sprintf("no expression for type %s", t))
+	}
+}
+
+func isText(contentType string) bool {
+	return strings.HasPrefix(contentType, "text/plain")
+}
+
+// identifier converts a path parameter such as 'resource_config_version_id'
+// to an argument name such as 'resourceConfigVersionID'.
+func identifier(param string) string {
+	words := strings.Split(param, "_")
+	for i, word := range words {
+		switch {
+		case word == "id":
+			words[i] = "ID"
+		case i > 0:
+			words[i] = strings.ToUpper(word[:1]) + word[1:]
+		}
+	}
+
+	if words[0] == "ID" {
+		words[0] = "id"
+	}
+
+	return strings.Join(words, "")
+}
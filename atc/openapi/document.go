@@ -0,0 +1,281 @@
+// Package openapi describes the API of the ATC as an OpenAPI document, which
+// is derived from the routes of the API and the Go types they exchange so
+// that it can't fall behind them.
+package openapi
+
+import (
+	"strings"
+
+	"github.com/concourse/concourse/atc"
+)
+
+type Document struct {
+	OpenAPI    string                `json:"openapi"`
+	Info       Info                  `json:"info"`
+	Servers    []Server              `json:"servers,omitempty"`
+	Paths      map[string]PathItem   `json:"paths"`
+	Components Components            `json:"components"`
+	Security   []map[string][]string `json:"security,omitempty"`
+}
+
+type Info struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+type Server struct {
+	URL string `json:"url"`
+}
+
+// PathItem holds the operations on a path by their lowercased method.
+type PathItem map[string]*OperationObject
+
+type OperationObject struct {
+	OperationID string               `json:"operationId"`
+	Summary     string               `json:"summary,omitempty"`
+	Tags        []string             `json:"tags,omitempty"`
+	Parameters  []Parameter          `json:"parameters,omitempty"`
+	RequestBody *RequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*Response `json:"responses"`
+	Deprecated  bool                 `json:"deprecated,omitempty"`
+}
+
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Deprecated  bool    `json:"deprecated,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+type RequestBody struct {
+	Required bool                 `json:"required"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+type Components struct {
+	Schemas         map[string]*Schema        `json:"schemas"`
+	SecuritySchemes map[string]SecurityScheme `json:"securitySchemes"`
+}
+
+type SecurityScheme struct {
+	Type   string `json:"type"`
+	Scheme string `json:"scheme"`
+}
+
+const contentTypeJSON = "application/json"
+
+// NewDocument describes every route of atc.Routes. Requests are authorized
+// with the bearer token fly logs in with.
+func NewDocument(version string) Document {
+	schemas := newSchemas()
+
+	doc := Document{
+		OpenAPI: "3.0.3",
+		Info: Info{
+			Title:   "Concourse",
+			Version: version,
+		},
+		Paths: map[string]PathItem{},
+		Components: Components{
+			Schemas: schemas.components,
+			SecuritySchemes: map[string]SecurityScheme{
+				"bearer": {Type: "http", Scheme: "bearer"},
+			},
+		},
+		Security: []map[string][]string{
+			{},
+			{"bearer": {}},
+		},
+	}
+
+	for _, route := range atc.Routes {
+		path, params := Path(route.Path)
+
+		op := Operations[route.Name]
+
+		object := &OperationObject{
+			OperationID: route.Name,
+			Summary:     op.Summary,
+			Tags:        []string{tag(route.Path)},
+			Responses:   map[string]*Response{},
+		}
+
+		for _, param := range params {
+			object.Parameters = append(object.Parameters, Parameter{
+				Name:     param,
+				In:       "path",
+				Required: true,
+				Schema:   &Schema{Type: "string"},
+			})
+		}
+
+		if strings.Contains(route.Path, ":pipeline_name") {
+			object.Parameters = append(object.Parameters, Parameter{
+				Name:        "vars",
+				In:          "query",
+				Description: "The instance vars of the pipeline as a JSON object",
+				Schema:      &Schema{Type: "string"},
+			})
+		}
+
+		for _, query := range op.Query {
+			object.Parameters = append(object.Parameters, Parameter{
+				Name:   query,
+				In:     "query",
+				Schema: &Schema{Type: "string"},
+			})
+		}
+
+		if op.Paginated {
+			for _, query := range []string{atc.PaginationQueryFrom, atc.PaginationQueryTo, atc.PaginationQueryLimit} {
+				object.Parameters = append(object.Parameters, Parameter{
+					Name:   query,
+					In:     "query",
+					Schema: &Schema{Type: "integer"},
+				})
+			}
+		}
+
+		if op.Idempotent {
+			object.Parameters = append(object.Parameters, Parameter{
+				Name:        atc.IdempotencyKeyHeader,
+				In:          "header",
+				Description: "Replays the response to the first request made with the key instead of doing it again",
+				Schema:      &Schema{Type: "string"},
+			})
+		}
+
+		deprecate(object, route.Name)
+
+		if op.Request != nil || op.RequestContentType != "" {
+			object.RequestBody = &RequestBody{
+				Required: true,
+				Content:  content(schemas, op.Request, op.RequestContentType),
+			}
+		}
+
+		switch {
+		case op.WebSocket:
+			object.Responses["101"] = &Response{Description: "Switched to a WebSocket"}
+		case op.Response != nil || op.ResponseContentType != "":
+			object.Responses["200"] = &Response{
+				Description: "OK",
+				Content:     content(schemas, op.Response, op.ResponseContentType),
+			}
+		default:
+			object.Responses["2XX"] = &Response{Description: "OK"}
+		}
+
+		item, found := doc.Paths[path]
+		if !found {
+			item = PathItem{}
+			doc.Paths[path] = item
+		}
+
+		item[strings.ToLower(route.Method)] = object
+	}
+
+	return doc
+}
+
+// deprecate marks the operation, or those of its query parameters, which are
+// deprecated.
+func deprecate(object *OperationObject, route string) {
+	for _, deprecation := range atc.APIDeprecations {
+		if deprecation.Route != route {
+			continue
+		}
+
+		if deprecation.Param == "" {
+			object.Deprecated = true
+			continue
+		}
+
+		found := false
+		for i, param := range object.Parameters {
+			if param.In == "query" && param.Name == deprecation.Param {
+				object.Parameters[i].Deprecated = true
+				found = true
+			}
+		}
+
+		if !found {
+			object.Parameters = append(object.Parameters, Parameter{
+				Name:       deprecation.Param,
+				In:         "query",
+				Deprecated: true,
+				Schema:     &Schema{Type: "string"},
+			})
+		}
+	}
+}
+
+// Path converts a route's path to an OpenAPI path, returning the names of
+// its parameters in order.
+func Path(routePath string) (string, []string) {
+	var params []string
+
+	segments := strings.Split(routePath, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") {
+			params = append(params, segment[1:])
+			segments[i] = "{" + segment[1:] + "}"
+		}
+	}
+
+	return strings.Join(segments, "/"), params
+}
+
+// tag groups a route by the collection whose member it acts on, such as
+// 'jobs' for the routes of a job, or by the first segment of its path if it
+// doesn't act on a member of any.
+func tag(routePath string) string {
+	segments := strings.Split(strings.TrimPrefix(routePath, "/api/v1/"), "/")
+
+	tag := segments[0]
+	for i := 1; i < len(segments); i++ {
+		if strings.HasPrefix(segments[i], ":") {
+			tag = segments[i-1]
+		}
+	}
+
+	return tag
+}
+
+func content(schemas *schemas, value interface{}, contentType string) map[string]MediaType {
+	if contentType == "" {
+		contentType = contentTypeJSON
+	}
+
+	schema := &Schema{}
+	switch {
+	case value != nil:
+		schema = schemas.schemaFor(value)
+	case contentType == contentTypeOctetStream:
+		schema = &Schema{Type: "string", Format: "binary"}
+	case strings.HasPrefix(contentType, "text/") || contentType == contentTypeXML || contentType == contentTypeSVG:
+		schema = &Schema{Type: "string"}
+	}
+
+	media := map[string]MediaType{
+		contentType: {Schema: schema},
+	}
+
+	// configs are YAML, of which JSON is a subset
+	if contentType == contentTypeYAML {
+		media[contentTypeJSON] = MediaType{Schema: schema}
+	}
+
+	return media
+}
@@ -0,0 +1,146 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/openapi"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Document", func() {
+	var doc openapi.Document
+
+	BeforeEach(func() {
+		doc = openapi.NewDocument("1.2.3")
+	})
+
+	It("describes every route", func() {
+		for _, route := range atc.Routes {
+			Expect(openapi.Operations).To(HaveKey(route.Name))
+
+			path, _ := openapi.Path(route.Path)
+			Expect(doc.Paths).To(HaveKey(path))
+
+			op := doc.Paths[path][strings.ToLower(route.Method)]
+			Expect(op).ToNot(BeNil(), route.Name)
+			Expect(op.OperationID).To(Equal(route.Name))
+			Expect(op.Summary).ToNot(BeEmpty(), route.Name)
+		}
+	})
+
+	It("describes only routes which exist", func() {
+		names := map[string]bool{}
+		for _, route := range atc.Routes {
+			names[route.Name] = true
+		}
+
+		for name := range openapi.Operations {
+			Expect(names).To(HaveKey(name))
+		}
+	})
+
+	It("has the version", func() {
+		Expect(doc.OpenAPI).To(Equal("3.0.3"))
+		Expect(doc.Info.Version).To(Equal("1.2.3"))
+	})
+
+	It("takes the parameters of the path", func() {
+		op := doc.Paths["/api/v1/teams/{team_name}/pipelines/{pipeline_name}/jobs/{job_name}"]["get"]
+		Expect(op.Tags).To(Equal([]string{"jobs"}))
+
+		Expect(op.Parameters).To(Equal([]openapi.Parameter{
+			{Name: "team_name", In: "path", Required: true, Schema: &openapi.Schema{Type: "string"}},
+			{Name: "pipeline_name", In: "path", Required: true, Schema: &openapi.Schema{Type: "string"}},
+			{Name: "job_name", In: "path", Required: true, Schema: &openapi.Schema{Type: "string"}},
+			{Name: "vars", In: "query", Description: "The instance vars of the pipeline as a JSON object", Schema: &openapi.Schema{Type: "string"}},
+		}))
+	})
+
+	It("takes the pagination parameters of paginated routes", func() {
+		op := doc.Paths["/api/v1/builds"]["get"]
+
+		var names []string
+		for _, param := range op.Parameters {
+			names = append(names, param.Name)
+		}
+
+		Expect(names).To(Equal([]string{"ref", "from", "to", "limit"}))
+	})
+
+	It("takes the idempotency key of idempotent routes", func() {
+		op := doc.Paths["/api/v1/teams/{team_name}/pipelines/{pipeline_name}/jobs/{job_name}/builds"]["post"]
+
+		Expect(op.Parameters).To(ContainElement(openapi.Parameter{
+			Name:        "Idempotency-Key",
+			In:          "header",
+			Description: "Replays the response to the first request made with the key instead of doing it again",
+			Schema:      &openapi.Schema{Type: "string"},
+		}))
+	})
+
+	It("marks the deprecated routes", func() {
+		Expect(doc.Paths["/api/v1/pipelines/{pipeline_name}/jobs/{job_name}/badge"]["get"].Deprecated).To(BeTrue())
+		Expect(doc.Paths["/api/v1/teams/{team_name}/pipelines/{pipeline_name}/jobs/{job_name}/badge"]["get"].Deprecated).To(BeFalse())
+	})
+
+	It("refers to the schemas of the types exchanged", func() {
+		op := doc.Paths["/api/v1/builds/{build_id}"]["get"]
+		Expect(op.Responses["200"].Content["application/json"].Schema).To(Equal(&openapi.Schema{
+			Ref: "#/components/schemas/Build",
+		}))
+
+		build := doc.Components.Schemas["Build"]
+		Expect(build.Type).To(Equal("object"))
+		Expect(build.Properties["id"]).To(Equal(&openapi.Schema{Type: "integer", Format: "int64"}))
+		Expect(build.Properties["status"]).To(Equal(&openapi.Schema{Type: "string"}))
+		Expect(build.Required).To(ContainElement("id"))
+		Expect(build.Required).ToNot(ContainElement("comment"))
+	})
+
+	It("describes bodies which aren't JSON", func() {
+		op := doc.Paths["/api/v1/teams/{team_name}/artifacts/{artifact_id}"]["get"]
+		Expect(op.Responses["200"].Content).To(Equal(map[string]openapi.MediaType{
+			"application/octet-stream": {Schema: &openapi.Schema{Type: "string", Format: "binary"}},
+		}))
+	})
+
+	It("only refers to schemas it has", func() {
+		payload, err := json.Marshal(doc)
+		Expect(err).ToNot(HaveOccurred())
+
+		var refs []string
+		collectRefs(json.RawMessage(payload), &refs)
+		Expect(refs).ToNot(BeEmpty())
+
+		for _, ref := range refs {
+			Expect(doc.Components.Schemas).To(HaveKey(strings.TrimPrefix(ref, "#/components/schemas/")))
+		}
+	})
+})
+
+func collectRefs(payload json.RawMessage, refs *[]string) {
+	var object map[string]json.RawMessage
+	if json.Unmarshal(payload, &object) == nil {
+		for key, value := range object {
+			var ref string
+			if key == "$ref" && json.Unmarshal(value, &ref) == nil {
+				*refs = append(*refs, ref)
+			} else {
+				collectRefs(value, refs)
+			}
+		}
+
+		return
+	}
+
+	var array []json.RawMessage
+	if json.Unmarshal(payload, &array) == nil {
+		for _, value := range array {
+			collectRefs(value, refs)
+		}
+	}
+}
@@ -0,0 +1,900 @@
+package openapi
+
+import (
+	"encoding/json"
+
+	"github.com/concourse/concourse/atc"
+)
+
+// Operation describes what a route of the API exchanges. Request and
+// Response are values of the types encoded as JSON, and are nil when the
+// route takes or returns nothing worth describing. Routes which exchange
+// something other than JSON say so with a content type.
+type Operation struct {
+	Summary string
+
+	Request            interface{}
+	RequestContentType string
+
+	Response            interface{}
+	ResponseContentType string
+
+	// Query lists the query parameters the route takes, besides the
+	// instance vars of the pipeline and the pagination parameters.
+	Query []string
+
+	// Paginated routes take the 'from', 'to' and 'limit' query parameters,
+	// and link to the previous and next page in the Link header.
+	Paginated bool
+
+	// WebSocket routes are upgraded to a WebSocket connection.
+	WebSocket bool
+
+	// Idempotent routes replay the response to the first request made with
+	// an Idempotency-Key header to the requests retrying it.
+	Idempotent bool
+}
+
+const (
+	contentTypeText        = "text/plain"
+	contentTypeYAML        = "application/x-yaml"
+	contentTypeXML         = "application/xml"
+	contentTypeSVG         = "image/svg+xml"
+	contentTypeOctetStream = "application/octet-stream"
+	contentTypeEventStream = "text/event-stream"
+	contentTypeJSONSchema  = "application/schema+json"
+)
+
+// Operations describes every route of atc.Routes by name.
+var Operations = map[string]Operation{
+	atc.SaveConfig: {
+		Summary:            "Set the config of a pipeline, creating the pipeline if it doesn't exist",
+		Request:            atc.Config{},
+		RequestContentType: contentTypeYAML,
+		Response:           atc.SaveConfigResponse{},
+		Idempotent:         true,
+	},
+	atc.GetConfig: {
+		Summary:  "Get the config of a pipeline",
+		Response: atc.ConfigResponse{},
+	},
+	atc.ValidateConfig: {
+		Summary:            "Validate a pipeline config without saving it",
+		Request:            atc.Config{},
+		RequestContentType: contentTypeYAML,
+		Response:           atc.ValidateConfigResponse{},
+	},
+	atc.ReportConfigVars: {
+		Summary:            "Report where each var of a pipeline config would be resolved from",
+		Request:            atc.Config{},
+		RequestContentType: contentTypeYAML,
+		Response:           []atc.VarReport{},
+	},
+	atc.StageConfig: {
+		Summary:            "Stage a candidate config for a pipeline",
+		Request:            atc.Config{},
+		RequestContentType: contentTypeYAML,
+		Response:           atc.SaveConfigResponse{},
+	},
+	atc.PromoteCandidateConfig: {
+		Summary: "Promote the candidate config of a pipeline to its config",
+	},
+	atc.DiscardCandidateConfig: {
+		Summary: "Discard the candidate config of a pipeline",
+	},
+	atc.GetConfigSchema: {
+		Summary:             "Get the JSON schema of a config",
+		ResponseContentType: contentTypeJSONSchema,
+	},
+
+	atc.CreateBuild: {
+		Summary:  "Run a one-off build of a plan",
+		Request:  atc.Plan{},
+		Response: atc.Build{},
+	},
+	atc.RunTask: {
+		Summary:  "Run a task as a one-off build",
+		Request:  atc.RunTaskRequest{},
+		Response: atc.Build{},
+	},
+	atc.ListBuilds: {
+		Summary:   "List builds",
+		Response:  []atc.Build{},
+		Query:     []string{atc.BuildsQueryExternalRef},
+		Paginated: true,
+	},
+	atc.GetBuild: {
+		Summary:  "Get a build",
+		Response: atc.Build{},
+	},
+	atc.GetBuildPlan: {
+		Summary:  "Get the plan of a build",
+		Response: atc.PublicBuildPlan{},
+	},
+	atc.GetBuildSteps: {
+		Summary:  "Get the status of each step of a build",
+		Response: atc.BuildSteps{},
+	},
+	atc.GetBuildProvenance: {
+		Summary: "Get the provenance attestation of a build",
+	},
+	atc.BuildEvents: {
+		Summary:             "Stream the events of a build, typed by the given version of the event schema",
+		ResponseContentType: contentTypeEventStream,
+		Query:               []string{"schema"},
+	},
+	atc.BuildResources: {
+		Summary:  "List the inputs and outputs of a build",
+		Response: atc.BuildInputsOutputs{},
+	},
+	atc.AbortBuild: {
+		Summary: "Abort a build",
+	},
+	atc.AbortBuildStep: {
+		Summary: "Abort a step of a build",
+	},
+	atc.GetBuildPreparation: {
+		Summary:  "Get what a pending build is waiting for",
+		Response: atc.BuildPreparation{},
+	},
+	atc.ListBuildArtifacts: {
+		Summary:  "List the artifacts of a build",
+		Response: []atc.WorkerArtifact{},
+	},
+	atc.ListBuildContainers: {
+		Summary:  "List the containers of a build",
+		Response: []atc.Container{},
+	},
+	atc.ListBuildVolumes: {
+		Summary:  "List the volumes of a build",
+		Response: []atc.Volume{},
+	},
+	atc.SetBuildComment: {
+		Summary: "Set the comment of a build",
+		Request: atc.SetBuildCommentBody{},
+	},
+
+	atc.ListAllJobs: {
+		Summary:  "List the jobs of every visible pipeline",
+		Response: []atc.JobSummary{},
+	},
+	atc.ListJobs: {
+		Summary:  "List the jobs of a pipeline",
+		Response: []atc.JobSummary{},
+	},
+	atc.GetJob: {
+		Summary:  "Get a job",
+		Response: atc.Job{},
+	},
+	atc.ListJobBuilds: {
+		Summary:   "List the builds of a job",
+		Response:  []atc.Build{},
+		Paginated: true,
+	},
+	atc.CreateJobBuild: {
+		Summary:    "Trigger a build of a job",
+		Request:    atc.CreateJobBuildRequestBody{},
+		Response:   atc.Build{},
+		Idempotent: true,
+	},
+	atc.RerunJobBuild: {
+		Summary:    "Rerun a build of a job with the same inputs",
+		Response:   atc.Build{},
+		Idempotent: true,
+	},
+	atc.ListJobInputs: {
+		Summary:  "List the inputs the next build of a job would run with",
+		Response: []atc.BuildInput{},
+	},
+	atc.GetJobBuild: {
+		Summary:  "Get a build of a job by name",
+		Response: atc.Build{},
+	},
+	atc.PauseJob: {
+		Summary: "Pause a job",
+	},
+	atc.UnpauseJob: {
+		Summary: "Unpause a job",
+	},
+	atc.ScheduleJob: {
+		Summary: "Schedule a job",
+	},
+	atc.JobBadge: {
+		Summary:             "Get a badge with the status of a job",
+		ResponseContentType: contentTypeSVG,
+		Query:               []string{"title", "format"},
+	},
+	atc.MainJobBadge: {
+		Summary:             "Get a badge with the status of a job of the main team",
+		ResponseContentType: contentTypeSVG,
+		Query:               []string{"title", "format"},
+	},
+	atc.ListJobStatistics: {
+		Summary:  "Get the statistics of each job of a pipeline",
+		Response: []atc.JobStatistics{},
+	},
+	atc.GetJobStatistics: {
+		Summary:  "Get the statistics of a job",
+		Response: atc.JobStatistics{},
+	},
+	atc.ListJobFlakiness: {
+		Summary:  "Get the flakiness of each job of a pipeline",
+		Response: []atc.JobFlakiness{},
+	},
+	atc.ListFlakyJobs: {
+		Summary:  "List the flakiest jobs of a team",
+		Response: []atc.JobFlakiness{},
+	},
+	atc.ListTeamBuildQueue: {
+		Summary:  "List the queued builds of a team with when they are expected to run",
+		Response: []atc.QueuedBuild{},
+	},
+	atc.ClearTaskCache: {
+		Summary:  "Clear the caches of a task of a job",
+		Response: atc.ClearTaskCacheResponse{},
+	},
+
+	atc.ListAllPipelines: {
+		Summary:  "List every visible pipeline",
+		Response: []atc.Pipeline{},
+	},
+	atc.ListPipelines: {
+		Summary:  "List the pipelines of a team",
+		Response: []atc.Pipeline{},
+	},
+	atc.GetPipeline: {
+		Summary:  "Get a pipeline",
+		Response: atc.Pipeline{},
+	},
+	atc.DeletePipeline: {
+		Summary: "Delete a pipeline",
+	},
+	atc.OrderPipelines: {
+		Summary: "Order the pipelines of a team by name",
+		Request: []string{},
+	},
+	atc.PausePipelines: {
+		Summary:  "Pause the pipelines of a team matching a pattern",
+		Response: []atc.PipelineBatchResult{},
+		Query:    []string{"pattern"},
+	},
+	atc.UnpausePipelines: {
+		Summary:  "Unpause the pipelines of a team matching a pattern",
+		Response: []atc.PipelineBatchResult{},
+		Query:    []string{"pattern"},
+	},
+	atc.ExposePipelines: {
+		Summary:  "Expose the pipelines of a team matching a pattern",
+		Response: []atc.PipelineBatchResult{},
+		Query:    []string{"pattern"},
+	},
+	atc.HidePipelines: {
+		Summary:  "Hide the pipelines of a team matching a pattern",
+		Response: []atc.PipelineBatchResult{},
+		Query:    []string{"pattern"},
+	},
+	atc.CheckPipelines: {
+		Summary:  "Check the resources of the pipelines of a team matching a pattern",
+		Response: []atc.PipelineBatchResult{},
+		Query:    []string{"pattern"},
+	},
+	atc.OrderPipelinesWithinGroup: {
+		Summary: "Order the instances of a pipeline by their instance vars",
+		Request: []atc.InstanceVars{},
+	},
+	atc.PausePipeline: {
+		Summary: "Pause a pipeline",
+	},
+	atc.ArchivePipeline: {
+		Summary: "Archive a pipeline",
+	},
+	atc.UnpausePipeline: {
+		Summary: "Unpause a pipeline",
+	},
+	atc.ExposePipeline: {
+		Summary: "Expose a pipeline",
+		Request: atc.PipelineExposure{},
+	},
+	atc.HidePipeline: {
+		Summary: "Hide a pipeline",
+	},
+	atc.GetVersionsDB: {
+		Summary:  "Get the versions of a pipeline as the scheduler sees them",
+		Response: atc.DebugVersionsDB{},
+	},
+	atc.RenamePipeline: {
+		Summary:  "Rename a pipeline",
+		Request:  atc.RenameRequest{},
+		Response: atc.SaveConfigResponse{},
+	},
+	atc.MovePipeline: {
+		Summary: "Move a pipeline, with its builds and caches, to another team",
+		Request: atc.MovePipelineRequest{},
+	},
+	atc.ListPipelineBuilds: {
+		Summary:   "List the builds of a pipeline",
+		Response:  []atc.Build{},
+		Paginated: true,
+	},
+	atc.CreatePipelineBuild: {
+		Summary:  "Run a one-off build of a plan in a pipeline",
+		Request:  atc.Plan{},
+		Response: atc.Build{},
+	},
+	atc.PipelineBadge: {
+		Summary:             "Get a badge with the status of a pipeline",
+		ResponseContentType: contentTypeSVG,
+		Query:               []string{"title", "format"},
+	},
+	atc.GetPipelineGraph: {
+		Summary:  "Get how the jobs and resources of a pipeline depend on each other",
+		Response: atc.PipelineGraph{},
+		Query:    []string{"format"},
+	},
+
+	atc.ListAllResources: {
+		Summary:  "List the resources of every visible pipeline",
+		Response: []atc.Resource{},
+	},
+	atc.ListResources: {
+		Summary:  "List the resources of a pipeline",
+		Response: []atc.Resource{},
+	},
+	atc.ListSharedForResource: {
+		Summary:  "List the resources and resource types sharing a resource's versions",
+		Response: atc.ResourcesAndTypes{},
+	},
+	atc.ListSharedForResourceType: {
+		Summary:  "List the resources and resource types sharing a resource type's versions",
+		Response: atc.ResourcesAndTypes{},
+	},
+	atc.ListResourceTypes: {
+		Summary:  "List the resource types of a pipeline",
+		Response: atc.ResourceTypes{},
+	},
+	atc.GetResource: {
+		Summary:  "Get a resource",
+		Response: atc.Resource{},
+	},
+	atc.CheckResource: {
+		Summary:  "Check a resource for new versions",
+		Request:  atc.CheckRequestBody{},
+		Response: atc.Build{},
+	},
+	atc.CheckResourceWebHook: {
+		Summary:  "Check a resource for new versions from a webhook",
+		Response: atc.Build{},
+		Query:    []string{"webhook_token"},
+	},
+	atc.CheckResourceType: {
+		Summary:  "Check a resource type for new versions",
+		Request:  atc.CheckRequestBody{},
+		Response: atc.Build{},
+	},
+	atc.CheckPrototype: {
+		Summary:  "Check a prototype for new versions",
+		Request:  atc.CheckRequestBody{},
+		Response: atc.Build{},
+	},
+	atc.ClearResourceCache: {
+		Summary:  "Clear the caches of a resource",
+		Request:  atc.VersionDeleteBody{},
+		Response: atc.ClearResourceCacheResponse{},
+	},
+	atc.ListResourceVersions: {
+		Summary:   "List the versions of a resource",
+		Response:  []atc.ResourceVersion{},
+		Paginated: true,
+	},
+	atc.ClearResourceVersions: {
+		Summary:  "Clear the versions of a resource",
+		Response: atc.ClearVersionsResponse{},
+	},
+	atc.PushResourceVersions: {
+		Summary:  "Push versions to a resource in push mode",
+		Request:  []atc.Version{},
+		Response: atc.PushVersionsResponse{},
+	},
+	atc.ClearResourceTypeVersions: {
+		Summary:  "Clear the versions of a resource type",
+		Response: atc.ClearVersionsResponse{},
+	},
+	atc.GetResourceVersion: {
+		Summary:  "Get a version of a resource",
+		Response: atc.ResourceVersion{},
+	},
+	atc.EnableResourceVersion: {
+		Summary: "Enable a version of a resource",
+	},
+	atc.DisableResourceVersion: {
+		Summary: "Disable a version of a resource",
+	},
+	atc.PinResourceVersion: {
+		Summary: "Pin a resource to a version",
+	},
+	atc.UnpinResource: {
+		Summary: "Unpin a resource",
+	},
+	atc.SetPinCommentOnResource: {
+		Summary: "Set the comment on the pinned version of a resource",
+		Request: atc.SetPinCommentRequestBody{},
+	},
+	atc.ResetResourceCheckFrom: {
+		Summary: "Reset the version the next check of a resource starts from",
+		Request: atc.ResetCheckFromRequestBody{},
+	},
+	atc.ListBuildsWithVersionAsInput: {
+		Summary:  "List the builds which used a version of a resource as an input",
+		Response: []atc.Build{},
+	},
+	atc.ListBuildsWithVersionAsOutput: {
+		Summary:  "List the builds which produced a version of a resource",
+		Response: []atc.Build{},
+	},
+	atc.GetDownstreamResourceCausality: {
+		Summary:  "Get the builds and versions which descend from a version of a resource",
+		Response: atc.Causality{},
+	},
+	atc.GetUpstreamResourceCausality: {
+		Summary:  "Get the builds and versions a version of a resource descends from",
+		Response: atc.Causality{},
+	},
+	atc.GetResourceVersionImpact: {
+		Summary:   "List the builds a version of a resource went on to affect",
+		Response:  []atc.ImpactedBuild{},
+		Paginated: true,
+	},
+	atc.FetchResourceVersionMetadata: {
+		Summary:  "Fetch the metadata of a version of a resource",
+		Response: atc.Build{},
+	},
+	atc.BackfillResourceMetadata: {
+		Summary:  "Fetch the metadata of the versions of a resource which have none",
+		Response: atc.Build{},
+	},
+
+	atc.GetCC: {
+		Summary:             "Get the status of a team's jobs for CCMenu",
+		ResponseContentType: contentTypeXML,
+	},
+
+	atc.ListWorkers: {
+		Summary:  "List the workers",
+		Response: []atc.Worker{},
+	},
+	atc.RegisterWorker: {
+		Summary:  "Register a worker",
+		Request:  atc.Worker{},
+		Response: atc.Worker{},
+		Query:    []string{"ttl"},
+	},
+	atc.LandWorker: {
+		Summary: "Land a worker",
+	},
+	atc.RetireWorker: {
+		Summary: "Retire a worker",
+	},
+	atc.PruneWorker: {
+		Summary: "Prune a stalled worker",
+	},
+	atc.HeartbeatWorker: {
+		Summary:  "Heartbeat a worker",
+		Request:  atc.Worker{},
+		Response: atc.Worker{},
+		Query:    []string{"ttl"},
+	},
+	atc.DeleteWorker: {
+		Summary: "Delete a worker",
+	},
+	atc.ListWorkerKeys: {
+		Summary:  "List the worker keys of every team",
+		Response: []atc.TeamWorkerKeys{},
+	},
+
+	atc.GetLogLevel: {
+		Summary:             "Get the log level",
+		Response:            atc.LogLevelInfo,
+		ResponseContentType: contentTypeText,
+	},
+	atc.SetLogLevel: {
+		Summary:            "Set the log level",
+		Request:            atc.LogLevelInfo,
+		RequestContentType: contentTypeText,
+	},
+	atc.GetRequestLog: {
+		Summary:  "Get how API requests are logged",
+		Response: atc.RequestLogSettings{},
+	},
+	atc.SetRequestLog: {
+		Summary: "Change how API requests are logged",
+		Request: atc.RequestLogSettings{},
+	},
+
+	atc.DownloadCLI: {
+		Summary:             "Download fly",
+		ResponseContentType: contentTypeOctetStream,
+		Query:               []string{"platform", "arch"},
+	},
+	atc.GetInfo: {
+		Summary:  "Get the version of Concourse and its enabled features",
+		Response: atc.Info{},
+	},
+	atc.GetInfoCreds: {
+		Summary:  "Get the configured credential managers",
+		Response: map[string]interface{}{},
+	},
+	atc.GetHealth: {
+		Summary:  "Check the health of the cluster's dependencies",
+		Response: atc.ClusterHealth{},
+	},
+
+	atc.GetUser: {
+		Summary:  "Get the user making the request",
+		Response: atc.UserInfo{},
+	},
+	atc.ListActiveUsersSince: {
+		Summary:  "List the users who logged in since a time",
+		Response: []atc.User{},
+		Query:    []string{"since"},
+	},
+	atc.GetDashboardPreferences: {
+		Summary:  "Get the user's arrangement of the dashboard",
+		Response: atc.DashboardPreferences{},
+	},
+	atc.OrderDashboardPipelines: {
+		Summary: "Order the user's pipelines on the dashboard by ID",
+		Request: []int{},
+	},
+	atc.FavoritePipeline: {
+		Summary: "Favorite a pipeline for the user",
+	},
+	atc.UnfavoritePipeline: {
+		Summary: "Unfavorite a pipeline for the user",
+	},
+	atc.GetUserPreferences: {
+		Summary:  "Get the preferences kept for the user",
+		Response: map[string]json.RawMessage{},
+	},
+	atc.SetUserPreference: {
+		Summary: "Keep a JSON value as one of the user's preferences",
+		Request: json.RawMessage{},
+	},
+	atc.DeleteUserPreference: {
+		Summary: "Delete one of the user's preferences",
+	},
+	atc.ListSessions: {
+		Summary:  "List the user's active sessions",
+		Response: []atc.Session{},
+	},
+	atc.RevokeSession: {
+		Summary: "Revoke one of the user's sessions",
+	},
+	atc.ListUserSessions: {
+		Summary:  "List a user's active sessions",
+		Response: []atc.Session{},
+	},
+	atc.RevokeUserSessions: {
+		Summary: "Revoke every one of a user's sessions",
+	},
+
+	atc.ListDestroyingContainers: {
+		Summary:  "List the handles of a worker's containers to destroy",
+		Response: []string{},
+		Query:    []string{"worker_name"},
+	},
+	atc.ReportWorkerContainers: {
+		Summary: "Report the handles of a worker's containers",
+		Request: []string{},
+		Query:   []string{"worker_name"},
+	},
+	atc.ListContainers: {
+		Summary:  "List the containers of a team",
+		Response: []atc.Container{},
+	},
+	atc.GetContainer: {
+		Summary:  "Get a container",
+		Response: atc.Container{},
+	},
+	atc.HijackContainer: {
+		Summary:   "Run a process in a container",
+		WebSocket: true,
+	},
+	atc.ListContainerProcesses: {
+		Summary:  "List the processes running in a container",
+		Response: []atc.ContainerProcess{},
+	},
+
+	atc.ListVolumes: {
+		Summary:  "List the volumes of a team",
+		Response: []atc.Volume{},
+	},
+	atc.ListDestroyingVolumes: {
+		Summary:  "List the handles of a worker's volumes to destroy",
+		Response: []string{},
+		Query:    []string{"worker_name"},
+	},
+	atc.ReportWorkerVolumes: {
+		Summary: "Report the handles of a worker's volumes",
+		Request: []string{},
+		Query:   []string{"worker_name"},
+	},
+
+	atc.ListTeams: {
+		Summary:  "List the teams",
+		Response: []atc.Team{},
+	},
+	atc.GetTeam: {
+		Summary:  "Get a team",
+		Response: atc.Team{},
+	},
+	atc.SetTeam: {
+		Summary:  "Set the auth of a team, creating the team if it doesn't exist",
+		Request:  atc.Team{},
+		Response: atc.Team{},
+	},
+	atc.RenameTeam: {
+		Summary:  "Rename a team",
+		Request:  atc.RenameRequest{},
+		Response: atc.SaveConfigResponse{},
+	},
+	atc.DestroyTeam: {
+		Summary: "Destroy a team",
+	},
+	atc.ListTeamBuilds: {
+		Summary:   "List the builds of a team",
+		Response:  []atc.Build{},
+		Paginated: true,
+	},
+	atc.ListTeamUsers: {
+		Summary:  "List the users who have logged in with a role in a team",
+		Response: []atc.User{},
+		Query:    []string{"since"},
+	},
+	atc.GetTeamWorkerKeys: {
+		Summary:  "Get the keys a team's workers register with",
+		Response: []string{},
+	},
+	atc.SetTeamWorkerKeys: {
+		Summary: "Set the keys a team's workers register with",
+		Request: []string{},
+	},
+	atc.GetTeamInterceptSettings: {
+		Summary:  "Get whether a team's members may intercept containers",
+		Response: atc.InterceptSettings{},
+	},
+	atc.SetTeamInterceptSettings: {
+		Summary: "Set whether a team's members may intercept containers",
+		Request: atc.InterceptSettings{},
+	},
+	atc.GetTeamPrivilegedSettings: {
+		Summary:  "Get whether a team's tasks and resource types may be privileged",
+		Response: atc.PrivilegedSettings{},
+	},
+	atc.SetTeamPrivilegedSettings: {
+		Summary: "Set whether a team's tasks and resource types may be privileged",
+		Request: atc.PrivilegedSettings{},
+	},
+	atc.GetTeamProxySettings: {
+		Summary:  "Get the proxies through which a team's containers egress",
+		Response: atc.ProxySettings{},
+	},
+	atc.SetTeamProxySettings: {
+		Summary: "Set the proxies through which a team's containers egress",
+		Request: atc.ProxySettings{},
+	},
+	atc.ListNotifiers: {
+		Summary:  "List the notifiers of a team",
+		Response: []atc.Notifier{},
+	},
+	atc.SetNotifier: {
+		Summary:  "Set a notifier of a team",
+		Request:  atc.NotifierConfig{},
+		Response: atc.SaveConfigResponse{},
+	},
+	atc.DestroyNotifier: {
+		Summary: "Destroy a notifier of a team",
+	},
+
+	atc.ListTeamWebhooks: {
+		Summary:  "List the outgoing webhooks of a team",
+		Response: []atc.OutgoingWebhook{},
+	},
+	atc.SetTeamWebhook: {
+		Summary:  "Set an outgoing webhook of a team",
+		Request:  atc.OutgoingWebhook{},
+		Response: atc.SaveConfigResponse{},
+	},
+	atc.DestroyTeamWebhook: {
+		Summary: "Destroy an outgoing webhook of a team",
+	},
+	atc.ListTeamWebhookDeliveries: {
+		Summary:  "List the latest deliveries of an outgoing webhook of a team",
+		Response: []atc.WebhookDelivery{},
+		Query:    []string{"limit"},
+	},
+	atc.ListClusterWebhooks: {
+		Summary:  "List the outgoing webhooks of the cluster",
+		Response: []atc.OutgoingWebhook{},
+	},
+	atc.SetClusterWebhook: {
+		Summary:  "Set an outgoing webhook of the cluster",
+		Request:  atc.OutgoingWebhook{},
+		Response: atc.SaveConfigResponse{},
+	},
+	atc.DestroyClusterWebhook: {
+		Summary: "Destroy an outgoing webhook of the cluster",
+	},
+	atc.ListClusterWebhookDeliveries: {
+		Summary:  "List the latest deliveries of an outgoing webhook of the cluster",
+		Response: []atc.WebhookDelivery{},
+		Query:    []string{"limit"},
+	},
+
+	atc.ListTeamLogDrains: {
+		Summary:  "List the log drains of a team",
+		Response: []atc.LogDrain{},
+	},
+	atc.SetTeamLogDrain: {
+		Summary:  "Set a log drain of a team",
+		Request:  atc.LogDrain{},
+		Response: atc.SaveConfigResponse{},
+	},
+	atc.DestroyTeamLogDrain: {
+		Summary: "Destroy a log drain of a team",
+	},
+
+	atc.ListTeamFreezeWindows: {
+		Summary:  "List the freeze windows of a team",
+		Response: []atc.FreezeWindow{},
+	},
+	atc.CreateTeamFreezeWindow: {
+		Summary:  "Create a freeze window for a team",
+		Request:  atc.FreezeWindow{},
+		Response: atc.FreezeWindow{},
+	},
+	atc.DestroyTeamFreezeWindow: {
+		Summary: "Destroy a freeze window of a team",
+	},
+	atc.ListClusterFreezeWindows: {
+		Summary:  "List the freeze windows of the cluster",
+		Response: []atc.FreezeWindow{},
+	},
+	atc.CreateClusterFreezeWindow: {
+		Summary:  "Create a freeze window for the cluster",
+		Request:  atc.FreezeWindow{},
+		Response: atc.FreezeWindow{},
+	},
+	atc.DestroyClusterFreezeWindow: {
+		Summary: "Destroy a freeze window of the cluster",
+	},
+	atc.GetTeamInjectedEnv: {
+		Summary:  "Get the env injected into every task of a team",
+		Response: atc.TaskEnv{},
+	},
+	atc.SetTeamInjectedEnv: {
+		Summary: "Set the env injected into every task of a team",
+		Request: atc.TaskEnv{},
+	},
+	atc.GetClusterInjectedEnv: {
+		Summary:  "Get the env injected into every task of every team",
+		Response: atc.TaskEnv{},
+	},
+	atc.SetClusterInjectedEnv: {
+		Summary: "Set the env injected into every task of every team",
+		Request: atc.TaskEnv{},
+	},
+	atc.GetTeamCABundle: {
+		Summary:  "Get the CA certificates trusted by every container of a team",
+		Response: atc.CABundle{},
+	},
+	atc.SetTeamCABundle: {
+		Summary: "Set the CA certificates trusted by every container of a team",
+		Request: atc.CABundle{},
+	},
+	atc.GetClusterCABundle: {
+		Summary:  "Get the CA certificates trusted by every container of every team",
+		Response: atc.CABundle{},
+	},
+	atc.SetClusterCABundle: {
+		Summary: "Set the CA certificates trusted by every container of every team",
+		Request: atc.CABundle{},
+	},
+
+	atc.ListTeamRequests: {
+		Summary:  "List the requests for teams to be created",
+		Response: []atc.TeamRequest{},
+	},
+	atc.CreateTeamRequest: {
+		Summary:  "Request a team to be created",
+		Request:  atc.TeamRequest{},
+		Response: atc.TeamRequest{},
+	},
+	atc.ApproveTeamRequest: {
+		Summary:  "Approve a team request, creating the team",
+		Request:  atc.TeamRequestDecision{},
+		Response: atc.TeamRequest{},
+	},
+	atc.RejectTeamRequest: {
+		Summary:  "Reject a team request",
+		Request:  atc.TeamRequestDecision{},
+		Response: atc.TeamRequest{},
+	},
+
+	atc.GetSchedulerProfile: {
+		Summary:  "Get how long the last scheduling of each pipeline took",
+		Response: []atc.PipelineSchedulingStats{},
+	},
+	atc.GetDBSchema: {
+		Summary:  "Describe the schema of the database",
+		Response: atc.DBSchema{},
+	},
+	atc.GetDBMaintenance: {
+		Summary:  "Describe the bloat of the busiest tables and how to maintain them",
+		Response: atc.DBMaintenance{},
+	},
+
+	atc.GetDBGrowth: {
+		Summary:  "List snapshots of the growth of the database, of a team or of the whole cluster",
+		Response: []atc.DBGrowthSnapshot{},
+		Query:    []string{"team", "since"},
+	},
+
+	atc.GetAutoscalingSignal: {
+		Summary:  "Get the demand for workers from the builds waiting to start",
+		Response: atc.AutoscalingSignal{},
+	},
+
+	atc.GetDeprecationReport: {
+		Summary:  "List the deprecated parts of the API and the clients still using them",
+		Response: atc.DeprecationReport{},
+	},
+
+	atc.GetClusterOverview: {
+		Summary:  "Describe what the workers, teams and busiest pipelines are using",
+		Response: atc.ClusterOverview{},
+	},
+
+	atc.ListComponents: {
+		Summary:  "List the components and when they last ran",
+		Response: []atc.ComponentStatus{},
+	},
+	atc.SetComponentInterval: {
+		Summary: "Set how often a component runs",
+		Request: atc.SetComponentIntervalRequest{},
+	},
+	atc.ResetComponentInterval: {
+		Summary: "Reset how often a component runs to its default",
+	},
+	atc.PauseComponent: {
+		Summary: "Pause a component",
+	},
+	atc.UnpauseComponent: {
+		Summary: "Unpause a component",
+	},
+
+	atc.CreateArtifact: {
+		Summary:            "Upload an artifact as a gzipped tarball",
+		RequestContentType: contentTypeOctetStream,
+		Response:           atc.WorkerArtifact{},
+	},
+	atc.GetArtifact: {
+		Summary:             "Download an artifact as a gzipped tarball",
+		ResponseContentType: contentTypeOctetStream,
+	},
+
+	atc.GetWall: {
+		Summary:  "Get the message on the wall",
+		Response: atc.Wall{},
+	},
+	atc.SetWall: {
+		Summary: "Set the message on the wall",
+		Request: atc.Wall{},
+	},
+	atc.ClearWall: {
+		Summary: "Clear the message on the wall",
+	},
+
+	atc.GetOpenAPI: {
+		Summary: "Get this OpenAPI document",
+	},
+}
@@ -0,0 +1,184 @@
+package openapi
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Schema is an OpenAPI schema object, as far as the schemas of the Go types
+// the API exchanges need.
+type Schema struct {
+	Ref string `json:"$ref,omitempty"`
+
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+}
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	rawMessageType    = reflect.TypeOf(json.RawMessage{})
+	marshalerType     = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// schemas derives the schemas of Go types the way encoding/json encodes them.
+// Named structs are added to the components and referred to, which also
+// covers types which refer to themselves, such as plans.
+type schemas struct {
+	components map[string]*Schema
+}
+
+func newSchemas() *schemas {
+	return &schemas{
+		components: map[string]*Schema{},
+	}
+}
+
+// schemaFor returns the schema of the type of value.
+func (s *schemas) schemaFor(value interface{}) *Schema {
+	return s.schema(reflect.TypeOf(value))
+}
+
+func (s *schemas) schema(t reflect.Type) *Schema {
+	switch {
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case t == rawMessageType:
+		return &Schema{}
+	case t.Implements(marshalerType) || reflect.PtrTo(t).Implements(marshalerType):
+		// encoded however the type sees fit
+		return &Schema{}
+	case t.Implements(textMarshalerType) || reflect.PtrTo(t).Implements(textMarshalerType):
+		return &Schema{Type: "string"}
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		schema := s.schema(t.Elem())
+		if schema.Ref != "" {
+			return schema
+		}
+
+		schema.Nullable = true
+		return schema
+
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+
+	case reflect.String:
+		return &Schema{Type: "string"}
+
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+
+		return &Schema{Type: "array", Items: s.schema(t.Elem())}
+
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: s.schema(t.Elem())}
+
+	case reflect.Struct:
+		if t.Name() == "" {
+			return s.object(t)
+		}
+
+		name := componentName(t)
+		if _, found := s.components[name]; !found {
+			// registered before its fields, which may refer back to it
+			s.components[name] = &Schema{}
+			*s.components[name] = *s.object(t)
+		}
+
+		return &Schema{Ref: "#/components/schemas/" + name}
+
+	default:
+		// interfaces, which may hold anything
+		return &Schema{}
+	}
+}
+
+func (s *schemas) object(t reflect.Type) *Schema {
+	object := &Schema{
+		Type:       "object",
+		Properties: map[string]*Schema{},
+	}
+
+	s.addFields(object, t)
+
+	return object
+}
+
+// addFields adds the fields of the struct to object, including those of the
+// structs it embeds without a name of their own.
+func (s *schemas) addFields(object *Schema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" && !field.Anonymous {
+			continue
+		}
+
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		name, options := tag, ""
+		if comma := strings.Index(tag, ","); comma != -1 {
+			name, options = tag[:comma], tag[comma:]
+		}
+
+		fieldType := field.Type
+		if field.Anonymous && name == "" {
+			if fieldType.Kind() == reflect.Ptr {
+				fieldType = fieldType.Elem()
+			}
+
+			if fieldType.Kind() == reflect.Struct {
+				s.addFields(object, fieldType)
+				continue
+			}
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		object.Properties[name] = s.schema(field.Type)
+
+		if !strings.Contains(options, ",omitempty") {
+			object.Required = append(object.Required, name)
+		}
+	}
+}
+
+// componentName names the schema of a named type after it, prefixed with its
+// package unless it's one of the atc package.
+func componentName(t reflect.Type) string {
+	pkg := t.PkgPath()
+	if pkg == "github.com/concourse/concourse/atc" {
+		return t.Name()
+	}
+
+	return pkg[strings.LastIndex(pkg, "/")+1:] + "." + t.Name()
+}
@@ -0,0 +1,117 @@
+package atc
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+type WebhookEvent string
+
+const (
+	WebhookEventBuildStarted  WebhookEvent = "build_started"
+	WebhookEventBuildFinished WebhookEvent = "build_finished"
+	WebhookEventPipelineSet   WebhookEvent = "pipeline_set"
+	WebhookEventWorkerStalled WebhookEvent = "worker_stalled"
+
+	WebhookEventTeamRequested       WebhookEvent = "team_requested"
+	WebhookEventTeamRequestApproved WebhookEvent = "team_request_approved"
+	WebhookEventTeamRequestRejected WebhookEvent = "team_request_rejected"
+)
+
+var WebhookEvents = []WebhookEvent{
+	WebhookEventBuildStarted,
+	WebhookEventBuildFinished,
+	WebhookEventPipelineSet,
+	WebhookEventWorkerStalled,
+	WebhookEventTeamRequested,
+	WebhookEventTeamRequestApproved,
+	WebhookEventTeamRequestRejected,
+}
+
+// OutgoingWebhook POSTs a JSON payload to URL for each of its events. The
+// payload is signed with Secret, and the signature sent in the
+// X-Concourse-Signature-256 header.
+//
+// An outgoing webhook belongs either to a team, receiving the events of the
+// team's builds and pipelines, or to the cluster, receiving all events.
+type OutgoingWebhook struct {
+	Name   string         `json:"name"`
+	URL    string         `json:"url"`
+	Secret string         `json:"secret,omitempty"`
+	Events []WebhookEvent `json:"events"`
+}
+
+func (webhook OutgoingWebhook) Validate() error {
+	hookURL, err := url.Parse(webhook.URL)
+	if err != nil {
+		return fmt.Errorf("invalid url: %w", err)
+	}
+
+	if hookURL.Scheme != "http" && hookURL.Scheme != "https" {
+		return errors.New("url must be an http or https URL")
+	}
+
+	if webhook.Secret == "" {
+		return errors.New("missing secret")
+	}
+
+	if len(webhook.Events) == 0 {
+		return errors.New("no events specified")
+	}
+
+	for _, event := range webhook.Events {
+		known := false
+		for _, e := range WebhookEvents {
+			if event == e {
+				known = true
+				break
+			}
+		}
+
+		if !known {
+			var events []string
+			for _, e := range WebhookEvents {
+				events = append(events, string(e))
+			}
+
+			return fmt.Errorf("unknown event '%s' (must be one of %s)", event, strings.Join(events, ", "))
+		}
+	}
+
+	return nil
+}
+
+// WebhookPayload is the body POSTed by an outgoing webhook. Data depends on
+// the event: a Build for build events, a Pipeline for pipeline_set, the
+// worker's name, team and state for worker_stalled and a TeamRequest for team
+// request events, which are only sent to the cluster's webhooks.
+type WebhookPayload struct {
+	Event WebhookEvent `json:"event"`
+	Time  int64        `json:"time"`
+	Data  interface{}  `json:"data"`
+}
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliverySucceeded WebhookDeliveryStatus = "succeeded"
+	WebhookDeliveryFailed    WebhookDeliveryStatus = "failed"
+)
+
+// WebhookDelivery is the delivery of an event to an outgoing webhook. Failed
+// attempts are retried with backoff until the delivery succeeds or it runs
+// out of attempts and is marked as failed.
+type WebhookDelivery struct {
+	ID             int64                 `json:"id"`
+	Event          WebhookEvent          `json:"event"`
+	Status         WebhookDeliveryStatus `json:"status"`
+	Attempts       int                   `json:"attempts"`
+	ResponseStatus int                   `json:"response_status,omitempty"`
+	Error          string                `json:"error,omitempty"`
+	CreatedAt      int64                 `json:"created_at"`
+	NextAttemptAt  int64                 `json:"next_attempt_at,omitempty"`
+	DeliveredAt    int64                 `json:"delivered_at,omitempty"`
+}
@@ -10,4 +10,6 @@ const (
 	PaginationQueryLimit      = "limit"
 	PaginationWebLimit        = 100
 	PaginationAPIDefaultLimit = 100
+
+	BuildsQueryExternalRef = "ref"
 )
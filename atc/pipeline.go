@@ -12,20 +12,51 @@ import (
 )
 
 type Pipeline struct {
-	ID            int            `json:"id"`
-	Name          string         `json:"name"`
-	InstanceVars  InstanceVars   `json:"instance_vars,omitempty"`
-	Paused        bool           `json:"paused"`
-	PausedBy      string         `json:"paused_by,omitempty"`
-	PausedAt      int64          `json:"paused_at,omitempty"`
-	Public        bool           `json:"public"`
-	Archived      bool           `json:"archived"`
-	Groups        GroupConfigs   `json:"groups,omitempty"`
-	TeamName      string         `json:"team_name"`
-	Display       *DisplayConfig `json:"display,omitempty"`
-	ParentBuildID int            `json:"parent_build_id,omitempty"`
-	ParentJobID   int            `json:"parent_job_id,omitempty"`
-	LastUpdated   int64          `json:"last_updated,omitempty"`
+	ID            int               `json:"id"`
+	Name          string            `json:"name"`
+	InstanceVars  InstanceVars      `json:"instance_vars,omitempty"`
+	Paused        bool              `json:"paused"`
+	PausedBy      string            `json:"paused_by,omitempty"`
+	PausedAt      int64             `json:"paused_at,omitempty"`
+	Public        bool              `json:"public"`
+	Exposure      *PipelineExposure `json:"exposure,omitempty"`
+	Archived      bool              `json:"archived"`
+	Groups        GroupConfigs      `json:"groups,omitempty"`
+	TeamName      string            `json:"team_name"`
+	Display       *DisplayConfig    `json:"display,omitempty"`
+	ParentBuildID int               `json:"parent_build_id,omitempty"`
+	ParentJobID   int               `json:"parent_job_id,omitempty"`
+	LastUpdated   int64             `json:"last_updated,omitempty"`
+}
+
+// PipelineExposure restricts what of an exposed pipeline is visible to users
+// who are not members of its team.
+type PipelineExposure struct {
+	// Jobs, if any, are the only jobs of the pipeline that are visible.
+	Jobs []string `json:"jobs,omitempty"`
+
+	// StatusOnly hides the logs of every build, leaving only their statuses.
+	StatusOnly bool `json:"status_only,omitempty"`
+}
+
+// Restricted returns whether anything less than the whole pipeline is exposed.
+func (exposure PipelineExposure) Restricted() bool {
+	return len(exposure.Jobs) > 0 || exposure.StatusOnly
+}
+
+// JobExposed returns whether the named job is visible.
+func (exposure PipelineExposure) JobExposed(jobName string) bool {
+	if len(exposure.Jobs) == 0 {
+		return true
+	}
+
+	for _, name := range exposure.Jobs {
+		if name == jobName {
+			return true
+		}
+	}
+
+	return false
 }
 
 func (p Pipeline) Ref() PipelineRef {
@@ -35,10 +66,37 @@ func (p Pipeline) Ref() PipelineRef {
 	}
 }
 
+// PipelineBatchAction is applied to many of a team's pipelines at once.
+type PipelineBatchAction string
+
+const (
+	PipelineBatchPause   PipelineBatchAction = "pause"
+	PipelineBatchUnpause PipelineBatchAction = "unpause"
+	PipelineBatchExpose  PipelineBatchAction = "expose"
+	PipelineBatchHide    PipelineBatchAction = "hide"
+	PipelineBatchCheck   PipelineBatchAction = "check"
+)
+
+// PipelineBatchResult reports the outcome of a batch action on one of the
+// pipelines it matched.
+type PipelineBatchResult struct {
+	Pipeline PipelineRef `json:"pipeline"`
+
+	// Changed is false if the pipeline was already in the requested state.
+	Changed bool `json:"changed"`
+
+	Error string `json:"error,omitempty"`
+}
+
 type RenameRequest struct {
 	NewName string `json:"name"`
 }
 
+// MovePipelineRequest names the team a pipeline is to be moved to.
+type MovePipelineRequest struct {
+	Team string `json:"team"`
+}
+
 type InstanceVars map[string]interface{}
 
 func (iv InstanceVars) String() string {
@@ -0,0 +1,208 @@
+package atc
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gobwas/glob"
+)
+
+const (
+	PipelineGraphJob      = "job"
+	PipelineGraphResource = "resource"
+)
+
+const (
+	// PipelineGraphInput goes from a resource to a job which gets it.
+	PipelineGraphInput = "input"
+
+	// PipelineGraphOutput goes from a job to a resource it puts to.
+	PipelineGraphOutput = "output"
+
+	// PipelineGraphPassed goes from a job to a job which only gets the
+	// versions of Resource which passed through it.
+	PipelineGraphPassed = "passed"
+)
+
+// PipelineGraph is how the jobs and resources of a pipeline depend on each
+// other, as drawn by the web UI.
+type PipelineGraph struct {
+	Nodes []PipelineGraphNode `json:"nodes"`
+	Edges []PipelineGraphEdge `json:"edges"`
+}
+
+type PipelineGraphNode struct {
+	ID           string   `json:"id"`
+	Type         string   `json:"type"`
+	Name         string   `json:"name"`
+	ResourceType string   `json:"resource_type,omitempty"`
+	Groups       []string `json:"groups,omitempty"`
+}
+
+type PipelineGraphEdge struct {
+	From     string `json:"from"`
+	To       string `json:"to"`
+	Type     string `json:"type"`
+	Resource string `json:"resource,omitempty"`
+	Trigger  bool   `json:"trigger,omitempty"`
+}
+
+func pipelineGraphJobID(name string) string {
+	return PipelineGraphJob + ":" + name
+}
+
+func pipelineGraphResourceID(name string) string {
+	return PipelineGraphResource + ":" + name
+}
+
+// NewPipelineGraph computes the graph of the pipeline from its config. Jobs
+// are in the groups whose jobs match them, and resources are in the groups
+// listing them or with a job which gets or puts them.
+func NewPipelineGraph(config Config) PipelineGraph {
+	graph := PipelineGraph{
+		Nodes: []PipelineGraphNode{},
+		Edges: []PipelineGraphEdge{},
+	}
+
+	seen := map[PipelineGraphEdge]bool{}
+	addEdge := func(edge PipelineGraphEdge) {
+		if !seen[edge] {
+			seen[edge] = true
+			graph.Edges = append(graph.Edges, edge)
+		}
+	}
+
+	jobResources := map[string][]string{}
+	for _, job := range config.Jobs {
+		jobID := pipelineGraphJobID(job.Name)
+
+		for _, input := range job.Inputs() {
+			jobResources[job.Name] = append(jobResources[job.Name], input.Resource)
+
+			addEdge(PipelineGraphEdge{
+				From:    pipelineGraphResourceID(input.Resource),
+				To:      jobID,
+				Type:    PipelineGraphInput,
+				Trigger: input.Trigger,
+			})
+
+			for _, passed := range input.Passed {
+				addEdge(PipelineGraphEdge{
+					From:     pipelineGraphJobID(passed),
+					To:       jobID,
+					Type:     PipelineGraphPassed,
+					Resource: input.Resource,
+					Trigger:  input.Trigger,
+				})
+			}
+		}
+
+		for _, output := range job.Outputs() {
+			jobResources[job.Name] = append(jobResources[job.Name], output.Resource)
+
+			addEdge(PipelineGraphEdge{
+				From: jobID,
+				To:   pipelineGraphResourceID(output.Resource),
+				Type: PipelineGraphOutput,
+			})
+		}
+	}
+
+	jobGroups := map[string][]string{}
+	resourceGroups := map[string][]string{}
+	for _, group := range config.Groups {
+		jobsInGroup := map[string]bool{}
+		resourcesInGroup := map[string]bool{}
+		for _, resource := range group.Resources {
+			resourcesInGroup[resource] = true
+		}
+
+		for _, jobGlob := range group.Jobs {
+			g, err := glob.Compile(jobGlob)
+			if err != nil {
+				continue
+			}
+
+			for _, job := range config.Jobs {
+				if !g.Match(job.Name) || jobsInGroup[job.Name] {
+					continue
+				}
+
+				jobsInGroup[job.Name] = true
+				jobGroups[job.Name] = append(jobGroups[job.Name], group.Name)
+
+				for _, resource := range jobResources[job.Name] {
+					resourcesInGroup[resource] = true
+				}
+			}
+		}
+
+		for _, resource := range config.Resources {
+			if resourcesInGroup[resource.Name] {
+				resourceGroups[resource.Name] = append(resourceGroups[resource.Name], group.Name)
+			}
+		}
+	}
+
+	for _, job := range config.Jobs {
+		graph.Nodes = append(graph.Nodes, PipelineGraphNode{
+			ID:     pipelineGraphJobID(job.Name),
+			Type:   PipelineGraphJob,
+			Name:   job.Name,
+			Groups: jobGroups[job.Name],
+		})
+	}
+
+	for _, resource := range config.Resources {
+		graph.Nodes = append(graph.Nodes, PipelineGraphNode{
+			ID:           pipelineGraphResourceID(resource.Name),
+			Type:         PipelineGraphResource,
+			Name:         resource.Name,
+			ResourceType: resource.Type,
+			Groups:       resourceGroups[resource.Name],
+		})
+	}
+
+	return graph
+}
+
+// DOT renders the graph in the DOT language of Graphviz. Jobs are boxes and
+// resources ellipses. Inputs which don't trigger the job are dashed, and jobs
+// depending on each other through passed constraints are joined in grey.
+func (graph PipelineGraph) DOT(name string) string {
+	var dot strings.Builder
+
+	fmt.Fprintf(&dot, "digraph %q {\n", name)
+	dot.WriteString("\trankdir=LR;\n")
+
+	for _, node := range graph.Nodes {
+		shape := "box"
+		if node.Type == PipelineGraphResource {
+			shape = "ellipse"
+		}
+
+		fmt.Fprintf(&dot, "\t%q [label=%q, shape=%s];\n", node.ID, node.Name, shape)
+	}
+
+	for _, edge := range graph.Edges {
+		var attrs []string
+
+		if edge.Type == PipelineGraphPassed {
+			attrs = append(attrs, fmt.Sprintf("label=%q", edge.Resource), "color=grey", "fontcolor=grey")
+		}
+
+		if edge.Type != PipelineGraphOutput && !edge.Trigger {
+			attrs = append(attrs, "style=dashed")
+		}
+
+		if len(attrs) == 0 {
+			fmt.Fprintf(&dot, "\t%q -> %q;\n", edge.From, edge.To)
+		} else {
+			fmt.Fprintf(&dot, "\t%q -> %q [%s];\n", edge.From, edge.To, strings.Join(attrs, ", "))
+		}
+	}
+
+	dot.WriteString("}\n")
+
+	return dot.String()
+}
@@ -0,0 +1,93 @@
+package atc_test
+
+import (
+	"github.com/concourse/concourse/atc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PipelineGraph", func() {
+	var config atc.Config
+
+	BeforeEach(func() {
+		config = atc.Config{
+			Groups: atc.GroupConfigs{
+				{Name: "build", Jobs: []string{"unit"}},
+				{Name: "ship", Jobs: []string{"ship-*"}, Resources: []string{"version"}},
+			},
+			Resources: atc.ResourceConfigs{
+				{Name: "repo", Type: "git"},
+				{Name: "release", Type: "s3"},
+				{Name: "version", Type: "semver"},
+			},
+			Jobs: atc.JobConfigs{
+				{
+					Name: "unit",
+					PlanSequence: []atc.Step{
+						{Config: &atc.GetStep{Name: "repo", Trigger: true}},
+					},
+				},
+				{
+					Name: "ship-it",
+					PlanSequence: []atc.Step{
+						{
+							Config: &atc.InParallelStep{
+								Config: atc.InParallelConfig{
+									Steps: []atc.Step{
+										{Config: &atc.GetStep{Name: "repo", Passed: []string{"unit"}}},
+										{Config: &atc.GetStep{Name: "other-repo", Resource: "repo", Passed: []string{"unit"}}},
+									},
+								},
+							},
+						},
+						{Config: &atc.PutStep{Name: "release"}},
+					},
+				},
+			},
+		}
+	})
+
+	Describe("NewPipelineGraph", func() {
+		It("has a node for each job and resource", func() {
+			graph := atc.NewPipelineGraph(config)
+
+			Expect(graph.Nodes).To(Equal([]atc.PipelineGraphNode{
+				{ID: "job:unit", Type: "job", Name: "unit", Groups: []string{"build"}},
+				{ID: "job:ship-it", Type: "job", Name: "ship-it", Groups: []string{"ship"}},
+				{ID: "resource:repo", Type: "resource", Name: "repo", ResourceType: "git", Groups: []string{"build", "ship"}},
+				{ID: "resource:release", Type: "resource", Name: "release", ResourceType: "s3", Groups: []string{"ship"}},
+				{ID: "resource:version", Type: "resource", Name: "version", ResourceType: "semver", Groups: []string{"ship"}},
+			}))
+		})
+
+		It("has an edge for each input, output and passed constraint", func() {
+			graph := atc.NewPipelineGraph(config)
+
+			Expect(graph.Edges).To(Equal([]atc.PipelineGraphEdge{
+				{From: "resource:repo", To: "job:unit", Type: "input", Trigger: true},
+				{From: "resource:repo", To: "job:ship-it", Type: "input"},
+				{From: "job:unit", To: "job:ship-it", Type: "passed", Resource: "repo"},
+				{From: "job:ship-it", To: "resource:release", Type: "output"},
+			}))
+		})
+	})
+
+	Describe("DOT", func() {
+		It("renders the graph for Graphviz", func() {
+			Expect(atc.NewPipelineGraph(config).DOT("some-pipeline")).To(Equal(`digraph "some-pipeline" {
+	rankdir=LR;
+	"job:unit" [label="unit", shape=box];
+	"job:ship-it" [label="ship-it", shape=box];
+	"resource:repo" [label="repo", shape=ellipse];
+	"resource:release" [label="release", shape=ellipse];
+	"resource:version" [label="version", shape=ellipse];
+	"resource:repo" -> "job:unit";
+	"resource:repo" -> "job:ship-it" [style=dashed];
+	"job:unit" -> "job:ship-it" [label="repo", color=grey, fontcolor=grey, style=dashed];
+	"job:ship-it" -> "resource:release";
+}
+`))
+		})
+	})
+})
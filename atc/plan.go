@@ -25,6 +25,7 @@ type Plan struct {
 	Try     *TryPlan     `json:"try,omitempty"`
 	Timeout *TimeoutPlan `json:"timeout,omitempty"`
 	Retry   *RetryPlan   `json:"retry,omitempty"`
+	When    *WhenPlan    `json:"when,omitempty"`
 
 	// used for 'fly execute'
 	ArtifactInput  *ArtifactInputPlan  `json:"artifact_input,omitempty"`
@@ -84,6 +85,10 @@ func (plan *Plan) Each(f func(*Plan)) {
 		plan.Timeout.Step.Each(f)
 	}
 
+	if plan.When != nil {
+		plan.When.Step.Each(f)
+	}
+
 	if plan.Retry != nil {
 		for i, p := range *plan.Retry {
 			p.Each(f)
@@ -170,6 +175,11 @@ type TimeoutPlan struct {
 	Duration string `json:"duration"`
 }
 
+type WhenPlan struct {
+	Step      Plan        `json:"step"`
+	Condition interface{} `json:"condition"`
+}
+
 type TryPlan struct {
 	Step Plan `json:"step"`
 }
@@ -260,6 +270,10 @@ type PutPlan struct {
 
 	// If or not expose BUILD_CREATED_BY to build metadata
 	ExposeBuildCreatedBy bool `json:"expose_build_created_by,omitempty"`
+
+	// Whether to wait for other puts to the same resource config, from any
+	// build, to finish before running.
+	SerializePuts bool `json:"serialize_puts,omitempty"`
 }
 
 type CheckPlan struct {
@@ -349,6 +363,10 @@ type TaskPlan struct {
 	// If set, the check plan for the image will be forced to run and not respect
 	// the checking interval
 	CheckSkipInterval bool `json:"check_skip_interval,omitempty"`
+
+	// If set, the task's job has a warm pool; the task records how to create
+	// its container and claims one created ahead of time if there is one.
+	WarmPool bool `json:"warm_pool,omitempty"`
 }
 
 type RunPlan struct {
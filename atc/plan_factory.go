@@ -61,6 +61,8 @@ func (factory PlanFactory) NewPlan(step PlanConfig) Plan {
 		plan.Timeout = &t
 	case RetryPlan:
 		plan.Retry = &t
+	case WhenPlan:
+		plan.When = &t
 	case ArtifactInputPlan:
 		plan.ArtifactInput = &t
 	case ArtifactOutputPlan:
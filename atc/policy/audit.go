@@ -0,0 +1,34 @@
+package policy
+
+// DecisionAuditor records the decisions made by policy checks.
+//
+//counterfeiter:generate . DecisionAuditor
+type DecisionAuditor interface {
+	AuditPolicyDecision(input PolicyCheckInput, result PolicyCheckResult)
+}
+
+// NewAuditingChecker returns a Checker which records the decision of every
+// policy check made through the given Checker.
+func NewAuditingChecker(checker Checker, auditor DecisionAuditor) Checker {
+	return &auditingChecker{
+		Checker: checker,
+		auditor: auditor,
+	}
+}
+
+type auditingChecker struct {
+	Checker
+
+	auditor DecisionAuditor
+}
+
+func (c *auditingChecker) Check(input PolicyCheckInput) (PolicyCheckResult, error) {
+	result, err := c.Checker.Check(input)
+	if err != nil {
+		return nil, err
+	}
+
+	c.auditor.AuditPolicyDecision(input, result)
+
+	return result, nil
+}
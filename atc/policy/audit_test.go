@@ -0,0 +1,60 @@
+package policy_test
+
+import (
+	"errors"
+
+	"github.com/concourse/concourse/atc/policy"
+	"github.com/concourse/concourse/atc/policy/policyfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Auditing checker", func() {
+	var (
+		fakeChecker *policyfakes.FakeChecker
+		fakeAuditor *policyfakes.FakeDecisionAuditor
+		checker     policy.Checker
+		input       policy.PolicyCheckInput
+	)
+
+	BeforeEach(func() {
+		fakeChecker = new(policyfakes.FakeChecker)
+		fakeAuditor = new(policyfakes.FakeDecisionAuditor)
+		checker = policy.NewAuditingChecker(fakeChecker, fakeAuditor)
+
+		input = policy.PolicyCheckInput{Action: "HijackContainer", User: "some-user"}
+	})
+
+	It("filters actions as the checker does", func() {
+		fakeChecker.ShouldCheckActionReturns(true)
+		Expect(checker.ShouldCheckAction("HijackContainer")).To(BeTrue())
+		Expect(fakeChecker.ShouldCheckActionArgsForCall(0)).To(Equal("HijackContainer"))
+	})
+
+	It("records the decision of each check", func() {
+		fakeResult := new(policyfakes.FakePolicyCheckResult)
+		fakeChecker.CheckReturns(fakeResult, nil)
+
+		result, err := checker.Check(input)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(fakeResult))
+
+		Expect(fakeAuditor.AuditPolicyDecisionCallCount()).To(Equal(1))
+		auditedInput, auditedResult := fakeAuditor.AuditPolicyDecisionArgsForCall(0)
+		Expect(auditedInput).To(Equal(input))
+		Expect(auditedResult).To(Equal(fakeResult))
+	})
+
+	Context("when the check fails", func() {
+		BeforeEach(func() {
+			fakeChecker.CheckReturns(nil, errors.New("nope"))
+		})
+
+		It("returns the error without recording a decision", func() {
+			_, err := checker.Check(input)
+			Expect(err).To(MatchError("nope"))
+			Expect(fakeAuditor.AuditPolicyDecisionCallCount()).To(BeZero())
+		})
+	})
+})
@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
 	"github.com/jessevdk/go-flags"
 )
 
@@ -12,6 +13,8 @@ import (
 
 const ActionUseImage = "UseImage"
 const ActionRunSetPipeline = "SetPipeline"
+const ActionRunPrivilegedTask = "RunPrivilegedTask"
+const ActionScanImage = "ScanImage"
 
 type PolicyCheckNotPass struct {
 	Messages []string
@@ -33,16 +36,18 @@ type Filter struct {
 }
 
 type PolicyCheckInput struct {
-	Service        string      `json:"service"`
-	ClusterName    string      `json:"cluster_name"`
-	ClusterVersion string      `json:"cluster_version"`
-	HttpMethod     string      `json:"http_method,omitempty"`
-	Action         string      `json:"action"`
-	User           string      `json:"user,omitempty"`
-	Team           string      `json:"team,omitempty"`
-	Roles          []string    `json:"roles,omitempty"`
-	Pipeline       string      `json:"pipeline,omitempty"`
-	Data           interface{} `json:"data,omitempty"`
+	Service        string           `json:"service"`
+	ClusterName    string           `json:"cluster_name"`
+	ClusterVersion string           `json:"cluster_version"`
+	HttpMethod     string           `json:"http_method,omitempty"`
+	Action         string           `json:"action"`
+	User           string           `json:"user,omitempty"`
+	Team           string           `json:"team,omitempty"`
+	Roles          []string         `json:"roles,omitempty"`
+	Pipeline       string           `json:"pipeline,omitempty"`
+	InstanceVars   atc.InstanceVars `json:"instance_vars,omitempty"`
+	Job            string           `json:"job,omitempty"`
+	Data           interface{}      `json:"data,omitempty"`
 }
 
 //counterfeiter:generate . PolicyCheckResult
@@ -54,6 +59,7 @@ type PolicyCheckResult interface {
 
 type internalPolicyCheckResult struct {
 	allowed  bool
+	warn     bool
 	messages []string
 }
 
@@ -62,13 +68,32 @@ func (r internalPolicyCheckResult) Allowed() bool {
 }
 
 func (r internalPolicyCheckResult) ShouldBlock() bool {
-	return !r.allowed
+	return !r.allowed && !r.warn
 }
 
 func (r internalPolicyCheckResult) Messages() []string {
 	return r.messages
 }
 
+// withWarnings adds warnings, which do not block on their own, to the result
+// of a check.
+func withWarnings(result PolicyCheckResult, warnings []string) PolicyCheckResult {
+	if len(warnings) == 0 {
+		return result
+	}
+
+	var messages []string
+	if !result.Allowed() {
+		messages = append(messages, result.Messages()...)
+	}
+
+	return internalPolicyCheckResult{
+		allowed:  false,
+		warn:     !result.ShouldBlock(),
+		messages: append(messages, warnings...),
+	}
+}
+
 // PassedPolicyCheck creates a generic passed check
 func PassedPolicyCheck() PolicyCheckResult {
 	return internalPolicyCheckResult{
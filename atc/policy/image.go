@@ -0,0 +1,318 @@
+package policy
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/concourse/concourse/atc"
+)
+
+// ImagePolicy restricts the images which tasks and resource types may use.
+// It only applies to images of the registry-image and docker-image types, as
+// the repository of any other type of image cannot be known.
+type ImagePolicy struct {
+	AllowedRegistries []string `long:"image-policy-allowed-registry" description:"Pattern of the registries or repositories images may come from, such as 'registry.example.com' or 'docker.io/library/*'. A pattern also allows anything within what it matches. Can be specified multiple times. If not specified, images may come from anywhere."`
+	RequireDigest     bool     `long:"image-policy-require-digest" description:"Require images to be pinned to a digest, either in their repository, their source or their version."`
+	BlockLatest       bool     `long:"image-policy-block-latest" description:"Disallow images using the 'latest' tag, including images with no tag, unless they are pinned to a digest."`
+}
+
+func (policy ImagePolicy) IsConfigured() bool {
+	return len(policy.AllowedRegistries) > 0 || policy.RequireDigest || policy.BlockLatest
+}
+
+var registryImageTypes = map[string]bool{
+	"registry-image": true,
+	"docker-image":   true,
+}
+
+// ImageRef is a reference to an image in a registry, such as
+// docker.io/library/busybox:latest.
+type ImageRef struct {
+	// Name is the registry and repository of the image, such as
+	// docker.io/library/busybox.
+	Name   string
+	Tag    string
+	Digest string
+}
+
+func (ref ImageRef) String() string {
+	if ref.Digest != "" {
+		return ref.Name + "@" + ref.Digest
+	}
+
+	return ref.Name + ":" + ref.Tag
+}
+
+// ParseImageRef works out the image of a registry-image or docker-image
+// source. It returns false if the source has no repository, or if it has not
+// been interpolated yet.
+func ParseImageRef(source atc.Source, version atc.Version) (ImageRef, bool) {
+	repository, ok := source["repository"].(string)
+	if !ok || repository == "" || strings.Contains(repository, "((") {
+		return ImageRef{}, false
+	}
+
+	var ref ImageRef
+
+	if i := strings.Index(repository, "@"); i != -1 {
+		ref.Digest = repository[i+1:]
+		repository = repository[:i]
+	}
+
+	if i := strings.LastIndex(repository, ":"); i > strings.LastIndex(repository, "/") {
+		ref.Tag = repository[i+1:]
+		repository = repository[:i]
+	}
+
+	if tag, ok := source["tag"].(string); ok && tag != "" {
+		ref.Tag = tag
+	}
+
+	if digest, ok := source["digest"].(string); ok && digest != "" {
+		ref.Digest = digest
+	}
+
+	if digest := version["digest"]; digest != "" {
+		ref.Digest = digest
+	}
+
+	if ref.Tag == "" {
+		ref.Tag = "latest"
+	}
+
+	parts := strings.SplitN(repository, "/", 2)
+	if len(parts) == 1 || !(strings.ContainsAny(parts[0], ".:") || parts[0] == "localhost") {
+		if len(parts) == 1 {
+			repository = "library/" + repository
+		}
+
+		repository = "docker.io/" + repository
+	}
+
+	ref.Name = repository
+
+	return ref, true
+}
+
+// Violations returns what is wrong with the given image.
+func (policy ImagePolicy) Violations(ref ImageRef) []string {
+	var violations []string
+
+	if len(policy.AllowedRegistries) > 0 && !policy.allows(ref.Name) {
+		violations = append(violations, fmt.Sprintf(
+			"image '%s' is not from an allowed registry (allowed: %s)",
+			ref,
+			strings.Join(policy.AllowedRegistries, ", "),
+		))
+	}
+
+	if policy.RequireDigest && ref.Digest == "" {
+		violations = append(violations, fmt.Sprintf("image '%s' must be pinned to a digest", ref))
+	}
+
+	if policy.BlockLatest && ref.Digest == "" && ref.Tag == "latest" {
+		violations = append(violations, fmt.Sprintf("image '%s' must not use the 'latest' tag", ref))
+	}
+
+	return violations
+}
+
+// allows returns whether the name, or any of its parents, matches one of the
+// allowed patterns.
+func (policy ImagePolicy) allows(name string) bool {
+	parts := strings.Split(name, "/")
+
+	for _, pattern := range policy.AllowedRegistries {
+		for i := 1; i <= len(parts); i++ {
+			matched, err := path.Match(pattern, strings.Join(parts[:i], "/"))
+			if err == nil && matched {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (policy ImagePolicy) checkImage(subject string, imageType string, source atc.Source, version atc.Version) []string {
+	if !registryImageTypes[imageType] {
+		return nil
+	}
+
+	ref, ok := ParseImageRef(source, version)
+	if !ok {
+		return nil
+	}
+
+	var messages []string
+	for _, violation := range policy.Violations(ref) {
+		if subject != "" {
+			violation = subject + ": " + violation
+		}
+
+		messages = append(messages, violation)
+	}
+
+	return messages
+}
+
+// ConfigViolations returns what is wrong with the images of the resource
+// types, tasks and task image resources of a pipeline. Images which are
+// only known once the pipeline runs, such as those of task files or those
+// with vars, are left to be checked when they are fetched.
+func (policy ImagePolicy) ConfigViolations(config atc.Config) []string {
+	var violations []string
+
+	for _, resourceType := range config.ResourceTypes {
+		violations = append(violations, policy.checkImage(
+			fmt.Sprintf("resource type '%s'", resourceType.Name),
+			resourceType.Type,
+			resourceType.Source,
+			nil,
+		)...)
+	}
+
+	imageArtifacts := map[string]bool{}
+	for _, job := range config.Jobs {
+		_ = job.StepConfig().Visit(atc.StepRecursor{
+			OnTask: func(step *atc.TaskStep) error {
+				if step.ImageArtifactName != "" {
+					imageArtifacts[step.ImageArtifactName] = true
+				}
+
+				if step.Config != nil && step.Config.ImageResource != nil {
+					image := step.Config.ImageResource
+					violations = append(violations, policy.checkImage(
+						fmt.Sprintf("task '%s' in job '%s'", step.Name, job.Name),
+						image.Type,
+						image.Source,
+						image.Version,
+					)...)
+				}
+
+				return nil
+			},
+		})
+	}
+
+	var names []string
+	for name := range imageArtifacts {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		resource, found := config.Resources.Lookup(name)
+		if !found {
+			continue
+		}
+
+		violations = append(violations, policy.checkImage(
+			fmt.Sprintf("resource '%s'", resource.Name),
+			resource.Type,
+			resource.Source,
+			nil,
+		)...)
+	}
+
+	return violations
+}
+
+// NewImagePolicyChecker returns a Checker which checks the images used when
+// pipelines are set and when images are fetched against the image policy,
+// before passing on to the given Checker.
+func NewImagePolicyChecker(policy ImagePolicy, checker Checker) Checker {
+	if !policy.IsConfigured() {
+		return checker
+	}
+
+	return &imagePolicyChecker{
+		policy:  policy,
+		checker: checker,
+	}
+}
+
+type imagePolicyChecker struct {
+	policy  ImagePolicy
+	checker Checker
+}
+
+func isImagePolicyAction(action string) bool {
+	return action == ActionUseImage || action == ActionRunSetPipeline || action == atc.SaveConfig || action == atc.StageConfig
+}
+
+func (c *imagePolicyChecker) ShouldCheckHttpMethod(method string) bool {
+	return c.checker.ShouldCheckHttpMethod(method)
+}
+
+func (c *imagePolicyChecker) ShouldCheckAction(action string) bool {
+	return isImagePolicyAction(action) || c.checker.ShouldCheckAction(action)
+}
+
+func (c *imagePolicyChecker) ShouldSkipAction(action string) bool {
+	return !isImagePolicyAction(action) && c.checker.ShouldSkipAction(action)
+}
+
+func (c *imagePolicyChecker) Check(input PolicyCheckInput) (PolicyCheckResult, error) {
+	if isImagePolicyAction(input.Action) {
+		violations, err := c.violations(input)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(violations) > 0 {
+			return internalPolicyCheckResult{
+				allowed:  false,
+				messages: violations,
+			}, nil
+		}
+
+		// The action is only checked here because of the image policy, so
+		// only pass it on if the agent was asked to check it.
+		if c.checker.ShouldSkipAction(input.Action) ||
+			!(c.checker.ShouldCheckAction(input.Action) || c.checker.ShouldCheckHttpMethod(input.HttpMethod)) {
+			return PassedPolicyCheck(), nil
+		}
+	}
+
+	return c.checker.Check(input)
+}
+
+type useImageData struct {
+	ImageType    string      `json:"image_type"`
+	ImageSource  atc.Source  `json:"image_source"`
+	ImageVersion atc.Version `json:"image_version"`
+}
+
+func (c *imagePolicyChecker) violations(input PolicyCheckInput) ([]string, error) {
+	if input.Data == nil {
+		return nil, nil
+	}
+
+	payload, err := json.Marshal(input.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Action == ActionUseImage {
+		var data useImageData
+		err = json.Unmarshal(payload, &data)
+		if err != nil {
+			return nil, fmt.Errorf("decode image: %w", err)
+		}
+
+		return c.policy.checkImage("", data.ImageType, data.ImageSource, data.ImageVersion), nil
+	}
+
+	var config atc.Config
+	err = json.Unmarshal(payload, &config)
+	if err != nil {
+		return nil, fmt.Errorf("decode pipeline config: %w", err)
+	}
+
+	return c.policy.ConfigViolations(config), nil
+}
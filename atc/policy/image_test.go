@@ -0,0 +1,261 @@
+package policy_test
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/policy"
+	"github.com/concourse/concourse/atc/policy/policyfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Image policy", func() {
+	DescribeTable("ParseImageRef",
+		func(source atc.Source, version atc.Version, expected policy.ImageRef) {
+			ref, ok := policy.ParseImageRef(source, version)
+			Expect(ok).To(BeTrue())
+			Expect(ref).To(Equal(expected))
+		},
+		Entry("an official image",
+			atc.Source{"repository": "busybox"}, nil,
+			policy.ImageRef{Name: "docker.io/library/busybox", Tag: "latest"},
+		),
+		Entry("a docker hub image with a tag",
+			atc.Source{"repository": "concourse/concourse", "tag": "7.0"}, nil,
+			policy.ImageRef{Name: "docker.io/concourse/concourse", Tag: "7.0"},
+		),
+		Entry("an image with the tag in its repository",
+			atc.Source{"repository": "registry.example.com:5000/some/image:1.2"}, nil,
+			policy.ImageRef{Name: "registry.example.com:5000/some/image", Tag: "1.2"},
+		),
+		Entry("an image with the digest in its repository",
+			atc.Source{"repository": "localhost/image@sha256:abc"}, nil,
+			policy.ImageRef{Name: "localhost/image", Tag: "latest", Digest: "sha256:abc"},
+		),
+		Entry("an image with the digest in its version",
+			atc.Source{"repository": "gcr.io/some/image"}, atc.Version{"digest": "sha256:abc"},
+			policy.ImageRef{Name: "gcr.io/some/image", Tag: "latest", Digest: "sha256:abc"},
+		),
+	)
+
+	It("does not parse sources which have not been interpolated", func() {
+		_, ok := policy.ParseImageRef(atc.Source{"repository": "((registry))/image"}, nil)
+		Expect(ok).To(BeFalse())
+	})
+
+	Describe("Violations", func() {
+		var imagePolicy policy.ImagePolicy
+
+		BeforeEach(func() {
+			imagePolicy = policy.ImagePolicy{
+				AllowedRegistries: []string{"registry.example.com", "docker.io/library/*"},
+				RequireDigest:     true,
+				BlockLatest:       true,
+			}
+		})
+
+		It("allows images from an allowed registry pinned to a digest", func() {
+			Expect(imagePolicy.Violations(policy.ImageRef{
+				Name:   "registry.example.com/some/image",
+				Tag:    "latest",
+				Digest: "sha256:abc",
+			})).To(BeEmpty())
+
+			Expect(imagePolicy.Violations(policy.ImageRef{
+				Name:   "docker.io/library/busybox",
+				Tag:    "1.36",
+				Digest: "sha256:abc",
+			})).To(BeEmpty())
+		})
+
+		It("disallows images from other registries", func() {
+			Expect(imagePolicy.Violations(policy.ImageRef{
+				Name:   "docker.io/someone/image",
+				Tag:    "1.0",
+				Digest: "sha256:abc",
+			})).To(ConsistOf(
+				"image 'docker.io/someone/image@sha256:abc' is not from an allowed registry (allowed: registry.example.com, docker.io/library/*)",
+			))
+		})
+
+		It("disallows images which are not pinned to a digest or use latest", func() {
+			Expect(imagePolicy.Violations(policy.ImageRef{
+				Name: "registry.example.com/some/image",
+				Tag:  "latest",
+			})).To(ConsistOf(
+				"image 'registry.example.com/some/image:latest' must be pinned to a digest",
+				"image 'registry.example.com/some/image:latest' must not use the 'latest' tag",
+			))
+		})
+	})
+
+	Describe("ConfigViolations", func() {
+		It("checks the resource types, task images and resources used as task images", func() {
+			imagePolicy := policy.ImagePolicy{BlockLatest: true}
+
+			config := atc.Config{
+				ResourceTypes: atc.ResourceTypes{
+					{Name: "some-type", Type: "registry-image", Source: atc.Source{"repository": "some/type"}},
+					{Name: "pinned-type", Type: "registry-image", Source: atc.Source{"repository": "some/type", "tag": "1.0"}},
+				},
+				Resources: atc.ResourceConfigs{
+					{Name: "task-image", Type: "registry-image", Source: atc.Source{"repository": "some/image"}},
+					{Name: "pushed-image", Type: "registry-image", Source: atc.Source{"repository": "some/other-image"}},
+				},
+				Jobs: atc.JobConfigs{
+					{
+						Name: "some-job",
+						PlanSequence: []atc.Step{
+							{
+								Config: &atc.TaskStep{
+									Name: "inline",
+									Config: &atc.TaskConfig{
+										ImageResource: &atc.ImageResource{
+											Type:   "registry-image",
+											Source: atc.Source{"repository": "busybox"},
+										},
+									},
+								},
+							},
+							{
+								Config: &atc.TaskStep{
+									Name:              "from-artifact",
+									ImageArtifactName: "task-image",
+								},
+							},
+						},
+					},
+				},
+			}
+
+			Expect(imagePolicy.ConfigViolations(config)).To(Equal([]string{
+				"resource type 'some-type': image 'docker.io/some/type:latest' must not use the 'latest' tag",
+				"task 'inline' in job 'some-job': image 'docker.io/library/busybox:latest' must not use the 'latest' tag",
+				"resource 'task-image': image 'docker.io/some/image:latest' must not use the 'latest' tag",
+			}))
+		})
+	})
+
+	Describe("NewImagePolicyChecker", func() {
+		var (
+			fakeChecker *policyfakes.FakeChecker
+			checker     policy.Checker
+		)
+
+		BeforeEach(func() {
+			fakeChecker = new(policyfakes.FakeChecker)
+			fakeChecker.ShouldSkipActionReturns(true)
+		})
+
+		It("returns the checker as it is when no image policy is configured", func() {
+			Expect(policy.NewImagePolicyChecker(policy.ImagePolicy{}, fakeChecker)).To(BeIdenticalTo(fakeChecker))
+		})
+
+		Context("when an image policy is configured", func() {
+			BeforeEach(func() {
+				checker = policy.NewImagePolicyChecker(policy.ImagePolicy{RequireDigest: true}, fakeChecker)
+			})
+
+			It("checks the actions which use images", func() {
+				Expect(checker.ShouldCheckAction(policy.ActionUseImage)).To(BeTrue())
+				Expect(checker.ShouldSkipAction(policy.ActionUseImage)).To(BeFalse())
+				Expect(checker.ShouldCheckAction(atc.SaveConfig)).To(BeTrue())
+				Expect(checker.ShouldSkipAction(atc.SaveConfig)).To(BeFalse())
+				Expect(checker.ShouldCheckAction(atc.StageConfig)).To(BeTrue())
+				Expect(checker.ShouldSkipAction(atc.StageConfig)).To(BeFalse())
+				Expect(checker.ShouldCheckAction(policy.ActionRunSetPipeline)).To(BeTrue())
+
+				Expect(checker.ShouldCheckAction(atc.GetConfig)).To(BeFalse())
+				Expect(checker.ShouldSkipAction(atc.GetConfig)).To(BeTrue())
+			})
+
+			It("blocks images which violate the policy", func() {
+				result, err := checker.Check(policy.PolicyCheckInput{
+					Action: policy.ActionUseImage,
+					Data: map[string]interface{}{
+						"image_type":   "registry-image",
+						"image_source": atc.Source{"repository": "busybox"},
+						"privileged":   false,
+					},
+				})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result.Allowed()).To(BeFalse())
+				Expect(result.ShouldBlock()).To(BeTrue())
+				Expect(result.Messages()).To(ConsistOf("image 'docker.io/library/busybox:latest' must be pinned to a digest"))
+				Expect(fakeChecker.CheckCallCount()).To(BeZero())
+			})
+
+			It("allows images which follow the policy without asking the agent when it skips the action", func() {
+				result, err := checker.Check(policy.PolicyCheckInput{
+					Action: policy.ActionUseImage,
+					Data: map[string]interface{}{
+						"image_type":    "registry-image",
+						"image_source":  atc.Source{"repository": "busybox"},
+						"image_version": atc.Version{"digest": "sha256:abc"},
+					},
+				})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result.Allowed()).To(BeTrue())
+				Expect(fakeChecker.CheckCallCount()).To(BeZero())
+			})
+
+			It("blocks pipelines with images which violate the policy", func() {
+				result, err := checker.Check(policy.PolicyCheckInput{
+					Action:     atc.SaveConfig,
+					HttpMethod: "PUT",
+					Data: map[string]interface{}{
+						"resource_types": []interface{}{
+							map[string]interface{}{
+								"name":   "some-type",
+								"type":   "registry-image",
+								"source": map[string]interface{}{"repository": "some/type"},
+							},
+						},
+					},
+				})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result.Allowed()).To(BeFalse())
+				Expect(result.Messages()).To(ConsistOf("resource type 'some-type': image 'docker.io/some/type:latest' must be pinned to a digest"))
+			})
+
+			It("blocks staged pipelines with images which violate the policy", func() {
+				result, err := checker.Check(policy.PolicyCheckInput{
+					Action:     atc.StageConfig,
+					HttpMethod: "PUT",
+					Data: map[string]interface{}{
+						"resource_types": []interface{}{
+							map[string]interface{}{
+								"name":   "some-type",
+								"type":   "registry-image",
+								"source": map[string]interface{}{"repository": "some/type"},
+							},
+						},
+					},
+				})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result.Allowed()).To(BeFalse())
+				Expect(result.Messages()).To(ConsistOf("resource type 'some-type': image 'docker.io/some/type:latest' must be pinned to a digest"))
+			})
+
+			Context("when the agent checks the action too", func() {
+				BeforeEach(func() {
+					fakeChecker.ShouldSkipActionReturns(false)
+					fakeChecker.ShouldCheckActionReturns(true)
+					fakeChecker.CheckReturns(policy.PassedPolicyCheck(), nil)
+				})
+
+				It("passes images which follow the policy on to the agent", func() {
+					_, err := checker.Check(policy.PolicyCheckInput{
+						Action: policy.ActionUseImage,
+						Data: map[string]interface{}{
+							"image_type":   "some-custom-type",
+							"image_source": atc.Source{"repository": "busybox"},
+						},
+					})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(fakeChecker.CheckCallCount()).To(Equal(1))
+				})
+			})
+		})
+	})
+})
@@ -0,0 +1,305 @@
+package imagescan
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/policy"
+)
+
+// Config configures submitting the digests of images to a scanning service
+// before tasks and resources use them. The service, such as an adapter in
+// front of Clair or Trivy, is POSTed the image as JSON:
+//
+//	{"image": "docker.io/library/busybox", "digest": "sha256:..."}
+//
+// and is expected to return what it found in it:
+//
+//	{"vulnerabilities": [{"id": "CVE-...", "severity": "HIGH", "package": "...", "version": "...", "fixed_version": "..."}]}
+type Config struct {
+	URL           string        `long:"image-scan-url" description:"URL of a scanning service to submit the digests of task and resource images to before they are used."`
+	BlockSeverity string        `long:"image-scan-block-severity" description:"Block builds using images with vulnerabilities of at least this severity (low, medium, high or critical). Builds using images with less severe vulnerabilities are annotated with a warning. If not specified, builds are only annotated."`
+	FailClosed    bool          `long:"image-scan-fail-closed" description:"Block builds using images which could not be scanned, rather than annotating them with a warning."`
+	Timeout       time.Duration `long:"image-scan-timeout" default:"1m" description:"Image scanning request timeout."`
+	CacheDuration time.Duration `long:"image-scan-cache-duration" default:"24h" description:"How long the results of scanning an image digest are reused for."`
+}
+
+func (config Config) IsConfigured() bool {
+	return config.URL != ""
+}
+
+var severities = map[string]int{
+	"UNKNOWN":    0,
+	"NEGLIGIBLE": 1,
+	"LOW":        1,
+	"MEDIUM":     2,
+	"HIGH":       3,
+	"CRITICAL":   4,
+	"DEFCON1":    4,
+}
+
+func severity(name string) int {
+	return severities[strings.ToUpper(name)]
+}
+
+// NewChecker returns a Checker which scans the images fetched for tasks and
+// resources, before passing on to the given Checker.
+func NewChecker(logger lager.Logger, config Config, cache db.ImageScanCache, checker policy.Checker) (policy.Checker, error) {
+	if !config.IsConfigured() {
+		return checker, nil
+	}
+
+	blockSeverity := 0
+	if config.BlockSeverity != "" {
+		var found bool
+		blockSeverity, found = severities[strings.ToUpper(config.BlockSeverity)]
+		if !found || blockSeverity == 0 {
+			return nil, fmt.Errorf("unknown image scan block severity: %s", config.BlockSeverity)
+		}
+	}
+
+	return &scanChecker{
+		logger:        logger,
+		config:        config,
+		blockSeverity: blockSeverity,
+		cache:         cache,
+		client:        &http.Client{Timeout: config.Timeout},
+		checker:       checker,
+	}, nil
+}
+
+type scanChecker struct {
+	logger        lager.Logger
+	config        Config
+	blockSeverity int
+	cache         db.ImageScanCache
+	client        *http.Client
+	checker       policy.Checker
+}
+
+func (c *scanChecker) ShouldCheckHttpMethod(method string) bool {
+	return c.checker.ShouldCheckHttpMethod(method)
+}
+
+func (c *scanChecker) ShouldCheckAction(action string) bool {
+	return action == policy.ActionScanImage || c.checker.ShouldCheckAction(action)
+}
+
+func (c *scanChecker) ShouldSkipAction(action string) bool {
+	return action != policy.ActionScanImage && c.checker.ShouldSkipAction(action)
+}
+
+type result struct {
+	allowed     bool
+	shouldBlock bool
+	messages    []string
+}
+
+func (r result) Allowed() bool      { return r.allowed }
+func (r result) ShouldBlock() bool  { return r.shouldBlock }
+func (r result) Messages() []string { return r.messages }
+
+type scanImageData struct {
+	ImageSource  atc.Source  `json:"image_source"`
+	ImageVersion atc.Version `json:"image_version"`
+}
+
+func (c *scanChecker) Check(input policy.PolicyCheckInput) (policy.PolicyCheckResult, error) {
+	if input.Action != policy.ActionScanImage {
+		return c.checker.Check(input)
+	}
+
+	payload, err := json.Marshal(input.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	var data scanImageData
+	err = json.Unmarshal(payload, &data)
+	if err != nil {
+		return nil, fmt.Errorf("decode image: %w", err)
+	}
+
+	scanned := result{allowed: true}
+
+	ref, ok := policy.ParseImageRef(data.ImageSource, data.ImageVersion)
+	if ok && ref.Digest != "" {
+		scanned = c.scan(ref)
+		if scanned.shouldBlock {
+			return scanned, nil
+		}
+	}
+
+	// The action is only checked here because of image scanning, so only pass
+	// it on if the agent was asked to check it.
+	if c.checker.ShouldSkipAction(input.Action) ||
+		!(c.checker.ShouldCheckAction(input.Action) || c.checker.ShouldCheckHttpMethod(input.HttpMethod)) {
+		if scanned.allowed {
+			return policy.PassedPolicyCheck(), nil
+		}
+
+		return scanned, nil
+	}
+
+	checked, err := c.checker.Check(input)
+	if err != nil {
+		return nil, err
+	}
+
+	if scanned.allowed {
+		return checked, nil
+	}
+
+	var messages []string
+	if !checked.Allowed() {
+		messages = append(messages, checked.Messages()...)
+	}
+
+	return result{
+		allowed:     false,
+		shouldBlock: checked.ShouldBlock(),
+		messages:    append(messages, scanned.messages...),
+	}, nil
+}
+
+// scan works out whether the image is blocked, annotated with a warning, or
+// allowed, scanning it if it has not been scanned recently.
+func (c *scanChecker) scan(ref policy.ImageRef) result {
+	logger := c.logger.Session("scan", lager.Data{"image": ref.String()})
+
+	report, found, err := c.cache.FindReport(ref.Digest, c.config.CacheDuration)
+	if err != nil {
+		logger.Error("failed-to-find-report", err)
+	}
+
+	if !found {
+		report, err = c.submit(ref)
+		if err != nil {
+			logger.Error("failed-to-scan", err)
+
+			return result{
+				allowed:     false,
+				shouldBlock: c.config.FailClosed,
+				messages:    []string{fmt.Sprintf("image '%s' could not be scanned: %s", ref, err)},
+			}
+		}
+
+		err = c.cache.SaveReport(report)
+		if err != nil {
+			logger.Error("failed-to-save-report", err)
+		}
+	}
+
+	if len(report.Vulnerabilities) == 0 {
+		return result{allowed: true}
+	}
+
+	var blocking []string
+	counts := map[string]int{}
+	for _, vulnerability := range report.Vulnerabilities {
+		counts[strings.ToUpper(vulnerability.Severity)]++
+
+		if c.blockSeverity > 0 && severity(vulnerability.Severity) >= c.blockSeverity {
+			blocking = append(blocking, fmt.Sprintf("%s (%s)", vulnerability.ID, strings.ToUpper(vulnerability.Severity)))
+		}
+	}
+
+	if len(blocking) > 0 {
+		return result{
+			allowed:     false,
+			shouldBlock: true,
+			messages: []string{fmt.Sprintf(
+				"image '%s' has vulnerabilities of at least %s severity: %s",
+				ref,
+				strings.ToUpper(c.config.BlockSeverity),
+				strings.Join(blocking, ", "),
+			)},
+		}
+	}
+
+	var names []string
+	for name := range counts {
+		names = append(names, name)
+	}
+
+	sort.Slice(names, func(i, j int) bool {
+		if severity(names[i]) != severity(names[j]) {
+			return severity(names[i]) > severity(names[j])
+		}
+
+		return names[i] < names[j]
+	})
+
+	var summary []string
+	for _, name := range names {
+		summary = append(summary, fmt.Sprintf("%d %s", counts[name], name))
+	}
+
+	return result{
+		allowed:     false,
+		shouldBlock: false,
+		messages: []string{fmt.Sprintf(
+			"image '%s' has %d vulnerabilities: %s",
+			ref,
+			len(report.Vulnerabilities),
+			strings.Join(summary, ", "),
+		)},
+	}
+}
+
+type scanRequest struct {
+	Image  string `json:"image"`
+	Digest string `json:"digest"`
+}
+
+type scanResponse struct {
+	Vulnerabilities []atc.ImageVulnerability `json:"vulnerabilities"`
+}
+
+func (c *scanChecker) submit(ref policy.ImageRef) (atc.ImageScanReport, error) {
+	payload, err := json.Marshal(scanRequest{
+		Image:  ref.Name,
+		Digest: ref.Digest,
+	})
+	if err != nil {
+		return atc.ImageScanReport{}, err
+	}
+
+	resp, err := c.client.Post(c.config.URL, "application/json", bytes.NewBuffer(payload))
+	if err != nil {
+		return atc.ImageScanReport{}, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return atc.ImageScanReport{}, fmt.Errorf("scanning service returned status: %d", resp.StatusCode)
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return atc.ImageScanReport{}, err
+	}
+
+	var response scanResponse
+	err = json.Unmarshal(body, &response)
+	if err != nil {
+		return atc.ImageScanReport{}, fmt.Errorf("parsing scan report: %w", err)
+	}
+
+	return atc.ImageScanReport{
+		Digest:          ref.Digest,
+		Vulnerabilities: response.Vulnerabilities,
+		ScannedAt:       time.Now(),
+	}, nil
+}
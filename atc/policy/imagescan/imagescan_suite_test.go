@@ -0,0 +1,13 @@
+package imagescan_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestImageScan(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Image Scan Suite")
+}
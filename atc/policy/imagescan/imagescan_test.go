@@ -0,0 +1,243 @@
+package imagescan_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/policy"
+	"github.com/concourse/concourse/atc/policy/imagescan"
+	"github.com/concourse/concourse/atc/policy/policyfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Image scanning", func() {
+	var (
+		logger      = lagertest.NewTestLogger("imagescan-test")
+		fakeScanner *httptest.Server
+		response    string
+		status      int
+		requests    []map[string]string
+
+		config      imagescan.Config
+		fakeCache   *dbfakes.FakeImageScanCache
+		fakeChecker *policyfakes.FakeChecker
+		checker     policy.Checker
+
+		input  policy.PolicyCheckInput
+		result policy.PolicyCheckResult
+		err    error
+	)
+
+	BeforeEach(func() {
+		response = `{"vulnerabilities": []}`
+		status = http.StatusOK
+		requests = nil
+
+		fakeScanner = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, err := ioutil.ReadAll(r.Body)
+			Expect(err).ToNot(HaveOccurred())
+
+			var request map[string]string
+			Expect(json.Unmarshal(body, &request)).To(Succeed())
+			requests = append(requests, request)
+
+			w.WriteHeader(status)
+			fmt.Fprint(w, response)
+		}))
+
+		config = imagescan.Config{
+			URL:           fakeScanner.URL,
+			BlockSeverity: "high",
+			Timeout:       2 * time.Second,
+			CacheDuration: time.Hour,
+		}
+
+		fakeCache = new(dbfakes.FakeImageScanCache)
+
+		fakeChecker = new(policyfakes.FakeChecker)
+		fakeChecker.ShouldSkipActionReturns(true)
+
+		input = policy.PolicyCheckInput{
+			Action: policy.ActionScanImage,
+			Team:   "some-team",
+			Data: map[string]interface{}{
+				"image_type":    "registry-image",
+				"image_source":  atc.Source{"repository": "busybox"},
+				"image_version": atc.Version{"digest": "sha256:abc"},
+			},
+		}
+	})
+
+	AfterEach(func() {
+		fakeScanner.Close()
+	})
+
+	JustBeforeEach(func() {
+		checker, err = imagescan.NewChecker(logger, config, fakeCache, fakeChecker)
+		Expect(err).ToNot(HaveOccurred())
+
+		result, err = checker.Check(input)
+	})
+
+	It("submits the image digest to the scanning service", func() {
+		Expect(err).ToNot(HaveOccurred())
+		Expect(requests).To(Equal([]map[string]string{
+			{"image": "docker.io/library/busybox", "digest": "sha256:abc"},
+		}))
+	})
+
+	It("caches the report", func() {
+		Expect(fakeCache.FindReportCallCount()).To(Equal(1))
+		digest, maxAge := fakeCache.FindReportArgsForCall(0)
+		Expect(digest).To(Equal("sha256:abc"))
+		Expect(maxAge).To(Equal(time.Hour))
+
+		Expect(fakeCache.SaveReportCallCount()).To(Equal(1))
+		Expect(fakeCache.SaveReportArgsForCall(0).Digest).To(Equal("sha256:abc"))
+	})
+
+	It("allows images without vulnerabilities", func() {
+		Expect(result.Allowed()).To(BeTrue())
+		Expect(fakeChecker.CheckCallCount()).To(BeZero())
+	})
+
+	Context("when the image was scanned recently", func() {
+		BeforeEach(func() {
+			fakeCache.FindReportReturns(atc.ImageScanReport{
+				Digest: "sha256:abc",
+				Vulnerabilities: []atc.ImageVulnerability{
+					{ID: "CVE-1", Severity: "Critical"},
+				},
+			}, true, nil)
+		})
+
+		It("uses the cached report without scanning it again", func() {
+			Expect(requests).To(BeEmpty())
+			Expect(fakeCache.SaveReportCallCount()).To(BeZero())
+			Expect(result.ShouldBlock()).To(BeTrue())
+		})
+	})
+
+	Context("when the image has vulnerabilities of the blocking severity", func() {
+		BeforeEach(func() {
+			response = `{"vulnerabilities": [
+				{"id": "CVE-1", "severity": "CRITICAL"},
+				{"id": "CVE-2", "severity": "HIGH"},
+				{"id": "CVE-3", "severity": "LOW"}
+			]}`
+		})
+
+		It("blocks it", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.Allowed()).To(BeFalse())
+			Expect(result.ShouldBlock()).To(BeTrue())
+			Expect(result.Messages()).To(ConsistOf(
+				"image 'docker.io/library/busybox@sha256:abc' has vulnerabilities of at least HIGH severity: CVE-1 (CRITICAL), CVE-2 (HIGH)",
+			))
+		})
+	})
+
+	Context("when the image only has less severe vulnerabilities", func() {
+		BeforeEach(func() {
+			response = `{"vulnerabilities": [
+				{"id": "CVE-1", "severity": "MEDIUM"},
+				{"id": "CVE-2", "severity": "LOW"},
+				{"id": "CVE-3", "severity": "LOW"}
+			]}`
+		})
+
+		It("annotates it without blocking", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.Allowed()).To(BeFalse())
+			Expect(result.ShouldBlock()).To(BeFalse())
+			Expect(result.Messages()).To(ConsistOf(
+				"image 'docker.io/library/busybox@sha256:abc' has 3 vulnerabilities: 1 MEDIUM, 2 LOW",
+			))
+		})
+	})
+
+	Context("when the image is not pinned to a digest", func() {
+		BeforeEach(func() {
+			input.Data = map[string]interface{}{
+				"image_type":   "registry-image",
+				"image_source": atc.Source{"repository": "busybox"},
+			}
+		})
+
+		It("does not scan it", func() {
+			Expect(requests).To(BeEmpty())
+			Expect(result.Allowed()).To(BeTrue())
+		})
+	})
+
+	Context("when the scanning service fails", func() {
+		BeforeEach(func() {
+			status = http.StatusInternalServerError
+		})
+
+		It("annotates the image without blocking", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.ShouldBlock()).To(BeFalse())
+			Expect(result.Messages()).To(ConsistOf(
+				"image 'docker.io/library/busybox@sha256:abc' could not be scanned: scanning service returned status: 500",
+			))
+			Expect(fakeCache.SaveReportCallCount()).To(BeZero())
+		})
+
+		Context("when configured to fail closed", func() {
+			BeforeEach(func() {
+				config.FailClosed = true
+			})
+
+			It("blocks the image", func() {
+				Expect(result.ShouldBlock()).To(BeTrue())
+			})
+		})
+	})
+
+	Context("when the agent checks the action too", func() {
+		BeforeEach(func() {
+			fakeChecker.ShouldSkipActionReturns(false)
+			fakeChecker.ShouldCheckActionReturns(true)
+			fakeChecker.CheckReturns(policy.PassedPolicyCheck(), nil)
+		})
+
+		It("passes it on", func() {
+			Expect(fakeChecker.CheckCallCount()).To(Equal(1))
+			Expect(result.Allowed()).To(BeTrue())
+		})
+	})
+
+	Context("when the action is not an image scan", func() {
+		BeforeEach(func() {
+			input.Action = policy.ActionUseImage
+			fakeChecker.CheckReturns(nil, errors.New("from the agent"))
+		})
+
+		It("passes it on", func() {
+			Expect(err).To(MatchError("from the agent"))
+			Expect(requests).To(BeEmpty())
+		})
+	})
+
+	It("errors on an unknown block severity", func() {
+		_, err := imagescan.NewChecker(logger, imagescan.Config{URL: "http://example.com", BlockSeverity: "scary"}, fakeCache, fakeChecker)
+		Expect(err).To(MatchError("unknown image scan block severity: scary"))
+	})
+
+	It("returns the checker as it is when not configured", func() {
+		unconfigured, err := imagescan.NewChecker(logger, imagescan.Config{}, fakeCache, fakeChecker)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(unconfigured).To(BeIdenticalTo(fakeChecker))
+	})
+})
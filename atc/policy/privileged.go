@@ -0,0 +1,186 @@
+package policy
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/concourse/concourse/atc"
+)
+
+//counterfeiter:generate . PrivilegedPolicies
+
+// PrivilegedPolicies looks up how each team treats privileged containers.
+type PrivilegedPolicies interface {
+	PrivilegedPolicy(teamName string) (atc.PrivilegedPolicy, error)
+}
+
+// NewPrivilegedPolicyChecker returns a Checker which checks the privileged
+// tasks and resource types of pipelines when they are set, and privileged
+// containers when they are created, against the privileged policy of their
+// team, before passing on to the given Checker. Teams which deny privileged
+// containers are blocked, while teams which warn about them are only warned.
+func NewPrivilegedPolicyChecker(policies PrivilegedPolicies, checker Checker) Checker {
+	return &privilegedPolicyChecker{
+		policies: policies,
+		checker:  checker,
+	}
+}
+
+type privilegedPolicyChecker struct {
+	policies PrivilegedPolicies
+	checker  Checker
+}
+
+func isPrivilegedPolicyAction(action string) bool {
+	return action == ActionRunPrivilegedTask || action == ActionUseImage || action == ActionRunSetPipeline || action == atc.SaveConfig || action == atc.StageConfig
+}
+
+func (c *privilegedPolicyChecker) ShouldCheckHttpMethod(method string) bool {
+	return c.checker.ShouldCheckHttpMethod(method)
+}
+
+func (c *privilegedPolicyChecker) ShouldCheckAction(action string) bool {
+	return isPrivilegedPolicyAction(action) || c.checker.ShouldCheckAction(action)
+}
+
+func (c *privilegedPolicyChecker) ShouldSkipAction(action string) bool {
+	return !isPrivilegedPolicyAction(action) && c.checker.ShouldSkipAction(action)
+}
+
+func (c *privilegedPolicyChecker) Check(input PolicyCheckInput) (PolicyCheckResult, error) {
+	if !isPrivilegedPolicyAction(input.Action) {
+		return c.checker.Check(input)
+	}
+
+	uses, err := privilegedUses(input)
+	if err != nil {
+		return nil, err
+	}
+
+	var warnings []string
+	if len(uses) > 0 {
+		privilegedPolicy, err := c.policies.PrivilegedPolicy(input.Team)
+		if err != nil {
+			return nil, fmt.Errorf("find privileged policy: %w", err)
+		}
+
+		switch privilegedPolicy {
+		case atc.PrivilegedPolicyDeny:
+			return internalPolicyCheckResult{
+				allowed:  false,
+				messages: privilegedMessages(uses, "team '%s' does not allow privileged containers", input.Team),
+			}, nil
+		case atc.PrivilegedPolicyWarn:
+			warnings = privilegedMessages(uses, "team '%s' warns about privileged containers", input.Team)
+		}
+	}
+
+	// The action may only be checked here because of the privileged policy, so
+	// only pass it on if the agent was asked to check it.
+	if c.checker.ShouldSkipAction(input.Action) ||
+		!(c.checker.ShouldCheckAction(input.Action) || c.checker.ShouldCheckHttpMethod(input.HttpMethod)) {
+		if len(warnings) > 0 {
+			return internalPolicyCheckResult{
+				allowed:  false,
+				warn:     true,
+				messages: warnings,
+			}, nil
+		}
+
+		return PassedPolicyCheck(), nil
+	}
+
+	result, err := c.checker.Check(input)
+	if err != nil {
+		return nil, err
+	}
+
+	return withWarnings(result, warnings), nil
+}
+
+func privilegedMessages(uses []string, format string, team string) []string {
+	prefix := fmt.Sprintf(format, team)
+
+	messages := make([]string, len(uses))
+	for i, use := range uses {
+		messages[i] = prefix + ": " + use
+	}
+
+	return messages
+}
+
+type privilegedData struct {
+	Task       string `json:"task"`
+	ImageType  string `json:"image_type"`
+	Privileged bool   `json:"privileged"`
+}
+
+// privilegedUses returns what is privileged in the input of the action.
+func privilegedUses(input PolicyCheckInput) ([]string, error) {
+	if input.Data == nil {
+		return nil, nil
+	}
+
+	payload, err := json.Marshal(input.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	switch input.Action {
+	case ActionRunPrivilegedTask:
+		var data privilegedData
+		err = json.Unmarshal(payload, &data)
+		if err != nil {
+			return nil, fmt.Errorf("decode task: %w", err)
+		}
+
+		return []string{fmt.Sprintf("task '%s' is privileged", data.Task)}, nil
+
+	case ActionUseImage:
+		var data privilegedData
+		err = json.Unmarshal(payload, &data)
+		if err != nil {
+			return nil, fmt.Errorf("decode image: %w", err)
+		}
+
+		if !data.Privileged {
+			return nil, nil
+		}
+
+		return []string{fmt.Sprintf("image of type '%s' is privileged", data.ImageType)}, nil
+	}
+
+	var config atc.Config
+	err = json.Unmarshal(payload, &config)
+	if err != nil {
+		return nil, fmt.Errorf("decode pipeline config: %w", err)
+	}
+
+	return ConfigPrivilegedUses(config), nil
+}
+
+// ConfigPrivilegedUses returns the privileged resource types and tasks of a
+// pipeline.
+func ConfigPrivilegedUses(config atc.Config) []string {
+	var uses []string
+
+	for _, resourceType := range config.ResourceTypes {
+		if resourceType.Privileged {
+			uses = append(uses, fmt.Sprintf("resource type '%s' is privileged", resourceType.Name))
+		}
+	}
+
+	for _, job := range config.Jobs {
+		_ = job.StepConfig().Visit(atc.StepRecursor{
+			OnTask: func(step *atc.TaskStep) error {
+				if step.Privileged {
+					uses = append(uses, fmt.Sprintf("task '%s' in job '%s' is privileged", step.Name, job.Name))
+				}
+
+				return nil
+			},
+		})
+	}
+
+	return uses
+}
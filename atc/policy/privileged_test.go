@@ -0,0 +1,224 @@
+package policy_test
+
+import (
+	"errors"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/policy"
+	"github.com/concourse/concourse/atc/policy/policyfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Privileged policy", func() {
+	Describe("ConfigPrivilegedUses", func() {
+		It("returns the privileged resource types and tasks", func() {
+			config := atc.Config{
+				ResourceTypes: atc.ResourceTypes{
+					{Name: "some-type", Type: "registry-image", Privileged: true},
+					{Name: "other-type", Type: "registry-image"},
+				},
+				Jobs: atc.JobConfigs{
+					{
+						Name: "some-job",
+						PlanSequence: []atc.Step{
+							{Config: &atc.TaskStep{Name: "privileged-task", Privileged: true}},
+							{Config: &atc.TaskStep{Name: "unprivileged-task"}},
+						},
+					},
+				},
+			}
+
+			Expect(policy.ConfigPrivilegedUses(config)).To(Equal([]string{
+				"resource type 'some-type' is privileged",
+				"task 'privileged-task' in job 'some-job' is privileged",
+			}))
+		})
+	})
+
+	Describe("NewPrivilegedPolicyChecker", func() {
+		var (
+			fakePolicies *policyfakes.FakePrivilegedPolicies
+			fakeChecker  *policyfakes.FakeChecker
+			checker      policy.Checker
+
+			input  policy.PolicyCheckInput
+			result policy.PolicyCheckResult
+			err    error
+		)
+
+		BeforeEach(func() {
+			fakePolicies = new(policyfakes.FakePrivilegedPolicies)
+			fakePolicies.PrivilegedPolicyReturns(atc.PrivilegedPolicyAllow, nil)
+
+			fakeChecker = new(policyfakes.FakeChecker)
+			fakeChecker.ShouldSkipActionReturns(true)
+
+			checker = policy.NewPrivilegedPolicyChecker(fakePolicies, fakeChecker)
+
+			input = policy.PolicyCheckInput{
+				Action: policy.ActionRunPrivilegedTask,
+				Team:   "some-team",
+				Data:   map[string]interface{}{"task": "some-task"},
+			}
+		})
+
+		JustBeforeEach(func() {
+			result, err = checker.Check(input)
+		})
+
+		It("checks the actions which may use privileged containers", func() {
+			for _, action := range []string{policy.ActionRunPrivilegedTask, policy.ActionUseImage, policy.ActionRunSetPipeline, atc.SaveConfig, atc.StageConfig} {
+				Expect(checker.ShouldCheckAction(action)).To(BeTrue())
+				Expect(checker.ShouldSkipAction(action)).To(BeFalse())
+			}
+
+			Expect(checker.ShouldCheckAction(atc.GetConfig)).To(BeFalse())
+			Expect(checker.ShouldSkipAction(atc.GetConfig)).To(BeTrue())
+		})
+
+		Context("when the team allows privileged containers", func() {
+			It("passes", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result.Allowed()).To(BeTrue())
+				Expect(fakePolicies.PrivilegedPolicyArgsForCall(0)).To(Equal("some-team"))
+				Expect(fakeChecker.CheckCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when the team denies privileged containers", func() {
+			BeforeEach(func() {
+				fakePolicies.PrivilegedPolicyReturns(atc.PrivilegedPolicyDeny, nil)
+			})
+
+			It("blocks privileged tasks", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result.Allowed()).To(BeFalse())
+				Expect(result.ShouldBlock()).To(BeTrue())
+				Expect(result.Messages()).To(ConsistOf("team 'some-team' does not allow privileged containers: task 'some-task' is privileged"))
+			})
+
+			Context("when an image is not privileged", func() {
+				BeforeEach(func() {
+					input.Action = policy.ActionUseImage
+					input.Data = map[string]interface{}{"image_type": "registry-image", "privileged": false}
+				})
+
+				It("passes without looking up the policy", func() {
+					Expect(err).ToNot(HaveOccurred())
+					Expect(result.Allowed()).To(BeTrue())
+					Expect(fakePolicies.PrivilegedPolicyCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when an image is privileged", func() {
+				BeforeEach(func() {
+					input.Action = policy.ActionUseImage
+					input.Data = map[string]interface{}{"image_type": "registry-image", "privileged": true}
+				})
+
+				It("blocks it", func() {
+					Expect(err).ToNot(HaveOccurred())
+					Expect(result.ShouldBlock()).To(BeTrue())
+					Expect(result.Messages()).To(ConsistOf("team 'some-team' does not allow privileged containers: image of type 'registry-image' is privileged"))
+				})
+			})
+
+			Context("when a pipeline has privileged resource types", func() {
+				BeforeEach(func() {
+					input.Action = atc.SaveConfig
+					input.Data = map[string]interface{}{
+						"resource_types": []interface{}{
+							map[string]interface{}{"name": "some-type", "type": "registry-image", "privileged": true},
+						},
+					}
+				})
+
+				It("blocks it", func() {
+					Expect(err).ToNot(HaveOccurred())
+					Expect(result.ShouldBlock()).To(BeTrue())
+					Expect(result.Messages()).To(ConsistOf("team 'some-team' does not allow privileged containers: resource type 'some-type' is privileged"))
+				})
+			})
+
+			Context("when a privileged pipeline config is staged", func() {
+				BeforeEach(func() {
+					input.Action = atc.StageConfig
+					input.Data = map[string]interface{}{
+						"resource_types": []interface{}{
+							map[string]interface{}{"name": "some-type", "type": "registry-image", "privileged": true},
+						},
+					}
+				})
+
+				It("blocks it", func() {
+					Expect(err).ToNot(HaveOccurred())
+					Expect(result.ShouldBlock()).To(BeTrue())
+					Expect(result.Messages()).To(ConsistOf("team 'some-team' does not allow privileged containers: resource type 'some-type' is privileged"))
+				})
+			})
+		})
+
+		Context("when the team warns about privileged containers", func() {
+			BeforeEach(func() {
+				fakePolicies.PrivilegedPolicyReturns(atc.PrivilegedPolicyWarn, nil)
+			})
+
+			It("warns without blocking", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result.Allowed()).To(BeFalse())
+				Expect(result.ShouldBlock()).To(BeFalse())
+				Expect(result.Messages()).To(ConsistOf("team 'some-team' warns about privileged containers: task 'some-task' is privileged"))
+			})
+
+			Context("when the agent checks the action too", func() {
+				BeforeEach(func() {
+					fakeChecker.ShouldSkipActionReturns(false)
+					fakeChecker.ShouldCheckActionReturns(true)
+				})
+
+				Context("when the agent passes it", func() {
+					BeforeEach(func() {
+						fakeChecker.CheckReturns(policy.PassedPolicyCheck(), nil)
+					})
+
+					It("only warns", func() {
+						Expect(err).ToNot(HaveOccurred())
+						Expect(fakeChecker.CheckCallCount()).To(Equal(1))
+						Expect(result.ShouldBlock()).To(BeFalse())
+						Expect(result.Messages()).To(ConsistOf("team 'some-team' warns about privileged containers: task 'some-task' is privileged"))
+					})
+				})
+
+				Context("when the agent blocks it", func() {
+					BeforeEach(func() {
+						fakeResult := new(policyfakes.FakePolicyCheckResult)
+						fakeResult.AllowedReturns(false)
+						fakeResult.ShouldBlockReturns(true)
+						fakeResult.MessagesReturns([]string{"no way"})
+						fakeChecker.CheckReturns(fakeResult, nil)
+					})
+
+					It("blocks with both messages", func() {
+						Expect(err).ToNot(HaveOccurred())
+						Expect(result.ShouldBlock()).To(BeTrue())
+						Expect(result.Messages()).To(Equal([]string{
+							"no way",
+							"team 'some-team' warns about privileged containers: task 'some-task' is privileged",
+						}))
+					})
+				})
+			})
+		})
+
+		Context("when looking up the policy fails", func() {
+			BeforeEach(func() {
+				fakePolicies.PrivilegedPolicyReturns("", errors.New("nope"))
+			})
+
+			It("errors", func() {
+				Expect(err).To(MatchError(ContainSubstring("nope")))
+			})
+		})
+	})
+})
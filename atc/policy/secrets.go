@@ -0,0 +1,226 @@
+package policy
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/concourse/concourse/atc"
+)
+
+const (
+	SecretDetectionOff    = "off"
+	SecretDetectionWarn   = "warn"
+	SecretDetectionReject = "reject"
+)
+
+// SecretDetection looks for literal secrets in pipeline configs when they are
+// set, as secrets should come from a credential manager rather than be
+// stored with the config.
+type SecretDetection struct {
+	Mode       string  `long:"secret-detection" default:"off" choice:"off" choice:"warn" choice:"reject" description:"Look for literal secrets, such as private keys, known token formats and high-entropy strings, in pipeline configs when they are set. Either warn about them, or reject the config."`
+	MinEntropy float64 `long:"secret-detection-min-entropy" default:"4.5" description:"Shannon entropy, in bits per character, at which strings of at least 20 characters are considered secrets."`
+}
+
+func (detection SecretDetection) IsConfigured() bool {
+	return detection.Mode == SecretDetectionWarn || detection.Mode == SecretDetectionReject
+}
+
+var secretFormats = []struct {
+	name    string
+	pattern *regexp.Regexp
+}{
+	{"private key", regexp.MustCompile(`-----BEGIN ([A-Z0-9]+ )*PRIVATE KEY( BLOCK)?-----`)},
+	{"AWS access key", regexp.MustCompile(`\b(AKIA|ASIA)[0-9A-Z]{16}\b`)},
+	{"GitHub token", regexp.MustCompile(`\b(gh[pousr]_[A-Za-z0-9]{36,}|github_pat_[A-Za-z0-9_]{22,})\b`)},
+	{"GitLab token", regexp.MustCompile(`\bglpat-[A-Za-z0-9_-]{20,}\b`)},
+	{"Slack token", regexp.MustCompile(`\bxox[abposr]-[A-Za-z0-9-]{10,}\b`)},
+	{"Google API key", regexp.MustCompile(`\bAIza[0-9A-Za-z_-]{35}\b`)},
+	{"Stripe key", regexp.MustCompile(`\b[sr]k_live_[0-9A-Za-z]{24,}\b`)},
+	{"JSON web token", regexp.MustCompile(`\beyJ[A-Za-z0-9_-]{10,}\.eyJ[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]{10,}`)},
+}
+
+const minSecretLength = 20
+
+// DetectSecrets returns where the config, decoded from JSON or YAML, seems
+// to contain literal secrets. Strings with vars are left alone, as their
+// values come from a credential manager.
+func (detection SecretDetection) DetectSecrets(config interface{}) []string {
+	var found []string
+	detection.walk("", config, &found)
+	return found
+}
+
+func (detection SecretDetection) walk(path string, value interface{}, found *[]string) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			detection.walk(joinPath(path, key), v[key], found)
+		}
+
+	case []interface{}:
+		for i, element := range v {
+			// refer to named things, such as resources and jobs, by name
+			segment := fmt.Sprintf("[%d]", i)
+			if named, ok := element.(map[string]interface{}); ok {
+				if name, ok := named["name"].(string); ok && name != "" {
+					segment = "." + name
+				}
+			}
+
+			detection.walk(path+segment, element, found)
+		}
+
+	case string:
+		if kind := detection.secretKind(v); kind != "" {
+			*found = append(*found, fmt.Sprintf("%s looks like a literal %s", path, kind))
+		}
+	}
+}
+
+func joinPath(path string, key string) string {
+	if path == "" {
+		return key
+	}
+
+	return path + "." + key
+}
+
+func (detection SecretDetection) secretKind(value string) string {
+	if strings.Contains(value, "((") {
+		return ""
+	}
+
+	for _, format := range secretFormats {
+		if format.pattern.MatchString(value) {
+			return format.name
+		}
+	}
+
+	for _, word := range strings.Fields(value) {
+		if len(word) >= minSecretLength && !strings.Contains(word, "://") && entropy(word) >= detection.MinEntropy {
+			return "secret (high-entropy string)"
+		}
+	}
+
+	return ""
+}
+
+// entropy is the Shannon entropy of the string, in bits per character.
+func entropy(s string) float64 {
+	counts := map[rune]int{}
+	for _, r := range s {
+		counts[r]++
+	}
+
+	total := float64(len([]rune(s)))
+
+	var bits float64
+	for _, count := range counts {
+		p := float64(count) / total
+		bits -= p * math.Log2(p)
+	}
+
+	return bits
+}
+
+// NewSecretDetectionChecker returns a Checker which looks for literal secrets
+// in pipeline configs when they are set, before passing on to the given
+// Checker.
+func NewSecretDetectionChecker(detection SecretDetection, checker Checker) Checker {
+	if !detection.IsConfigured() {
+		return checker
+	}
+
+	return &secretDetectionChecker{
+		detection: detection,
+		checker:   checker,
+	}
+}
+
+type secretDetectionChecker struct {
+	detection SecretDetection
+	checker   Checker
+}
+
+func isSecretDetectionAction(action string) bool {
+	return action == ActionRunSetPipeline || action == atc.SaveConfig || action == atc.StageConfig
+}
+
+func (c *secretDetectionChecker) ShouldCheckHttpMethod(method string) bool {
+	return c.checker.ShouldCheckHttpMethod(method)
+}
+
+func (c *secretDetectionChecker) ShouldCheckAction(action string) bool {
+	return isSecretDetectionAction(action) || c.checker.ShouldCheckAction(action)
+}
+
+func (c *secretDetectionChecker) ShouldSkipAction(action string) bool {
+	return !isSecretDetectionAction(action) && c.checker.ShouldSkipAction(action)
+}
+
+func (c *secretDetectionChecker) Check(input PolicyCheckInput) (PolicyCheckResult, error) {
+	if !isSecretDetectionAction(input.Action) {
+		return c.checker.Check(input)
+	}
+
+	var warnings []string
+	if input.Data != nil {
+		payload, err := json.Marshal(input.Data)
+		if err != nil {
+			return nil, err
+		}
+
+		var config interface{}
+		err = json.Unmarshal(payload, &config)
+		if err != nil {
+			return nil, fmt.Errorf("decode pipeline config: %w", err)
+		}
+
+		found := c.detection.DetectSecrets(config)
+		if len(found) > 0 {
+			found = append(found, "use a credential manager and refer to secrets with ((vars)) instead")
+
+			if c.detection.Mode == SecretDetectionReject {
+				return internalPolicyCheckResult{
+					allowed:  false,
+					messages: found,
+				}, nil
+			}
+
+			warnings = found
+		}
+	}
+
+	// The action may only be checked here because of secret detection, so
+	// only pass it on if the agent was asked to check it.
+	if c.checker.ShouldSkipAction(input.Action) ||
+		!(c.checker.ShouldCheckAction(input.Action) || c.checker.ShouldCheckHttpMethod(input.HttpMethod)) {
+		if len(warnings) > 0 {
+			return internalPolicyCheckResult{
+				allowed:  false,
+				warn:     true,
+				messages: warnings,
+			}, nil
+		}
+
+		return PassedPolicyCheck(), nil
+	}
+
+	result, err := c.checker.Check(input)
+	if err != nil {
+		return nil, err
+	}
+
+	return withWarnings(result, warnings), nil
+}
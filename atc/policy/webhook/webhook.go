@@ -0,0 +1,121 @@
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+
+	"github.com/concourse/concourse/atc/policy"
+	"github.com/concourse/concourse/atc/webhooks"
+)
+
+// WebhookConfig configures an agent which POSTs the context of each action
+// as JSON to a URL, and expects a decision in return:
+//
+//	{"allowed": false, "block": true, "reasons": ["..."]}
+//
+// If block is left out, actions which are not allowed are blocked.
+type WebhookConfig struct {
+	URL     string        `long:"policy-webhook-url" description:"URL to POST the context of actions to for a policy decision."`
+	Secret  string        `long:"policy-webhook-secret" description:"Secret to sign the requests to the policy webhook with. The signature is sent in the X-Concourse-Signature-256 header."`
+	Timeout time.Duration `long:"policy-webhook-timeout" default:"5s" description:"Policy webhook request timeout."`
+}
+
+func init() {
+	policy.RegisterAgent(&WebhookConfig{})
+}
+
+func (c *WebhookConfig) Description() string { return "Webhook" }
+func (c *WebhookConfig) IsConfigured() bool  { return c.URL != "" }
+
+func (c *WebhookConfig) NewAgent(logger lager.Logger) (policy.Agent, error) {
+	return webhook{
+		config: *c,
+		logger: logger,
+		client: &http.Client{Timeout: c.Timeout},
+	}, nil
+}
+
+type webhook struct {
+	config WebhookConfig
+	logger lager.Logger
+	client *http.Client
+}
+
+type decision struct {
+	Allowed *bool    `json:"allowed"`
+	Block   *bool    `json:"block"`
+	Reasons []string `json:"reasons"`
+}
+
+type result struct {
+	allowed     bool
+	shouldBlock bool
+	messages    []string
+}
+
+func (r result) Allowed() bool      { return r.allowed }
+func (r result) ShouldBlock() bool  { return r.shouldBlock }
+func (r result) Messages() []string { return r.messages }
+
+func (c webhook) Check(input policy.PolicyCheckInput) (policy.PolicyCheckResult, error) {
+	payload, err := json.Marshal(input)
+	if err != nil {
+		return nil, err
+	}
+
+	c.logger.Debug("webhook-check", lager.Data{"input": string(payload)})
+
+	req, err := http.NewRequest("POST", c.config.URL, bytes.NewBuffer(payload))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	if c.config.Secret != "" {
+		req.Header.Set(webhooks.SignatureHeader, webhooks.Sign(c.config.Secret, payload))
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("policy webhook returned status: %d", resp.StatusCode)
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("policy webhook returned no response: %w", err)
+	}
+
+	var response decision
+	err = json.Unmarshal(body, &response)
+	if err != nil {
+		return nil, fmt.Errorf("parsing policy webhook decision: %w", err)
+	}
+
+	if response.Allowed == nil {
+		return nil, fmt.Errorf("policy webhook decision has no 'allowed' key")
+	}
+
+	shouldBlock := !*response.Allowed
+	if response.Block != nil {
+		shouldBlock = *response.Block
+	}
+
+	return result{
+		allowed:     *response.Allowed,
+		shouldBlock: shouldBlock,
+		messages:    response.Reasons,
+	}, nil
+}
@@ -0,0 +1,156 @@
+package webhook_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc/policy"
+	"github.com/concourse/concourse/atc/policy/webhook"
+	"github.com/concourse/concourse/atc/webhooks"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Webhook Policy Agent", func() {
+	var (
+		logger      = lagertest.NewTestLogger("webhook-test")
+		fakeWebhook *httptest.Server
+		secret      string
+		response    string
+		status      int
+		received    *http.Request
+		body        []byte
+
+		agent policy.Agent
+	)
+
+	BeforeEach(func() {
+		secret = ""
+		response = `{"allowed": true}`
+		status = http.StatusOK
+
+		fakeWebhook = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			received = r
+
+			var err error
+			body, err = ioutil.ReadAll(r.Body)
+			Expect(err).ToNot(HaveOccurred())
+
+			w.WriteHeader(status)
+			fmt.Fprint(w, response)
+		}))
+	})
+
+	AfterEach(func() {
+		fakeWebhook.Close()
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		agent, err = (&webhook.WebhookConfig{
+			URL:     fakeWebhook.URL,
+			Secret:  secret,
+			Timeout: 2 * time.Second,
+		}).NewAgent(logger)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	check := func() (policy.PolicyCheckResult, error) {
+		return agent.Check(policy.PolicyCheckInput{
+			Action:   "CreateJobBuild",
+			Team:     "some-team",
+			Pipeline: "some-pipeline",
+			Job:      "some-job",
+		})
+	}
+
+	It("posts the context of the action", func() {
+		_, err := check()
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(received.Method).To(Equal("POST"))
+		Expect(received.Header.Get("Content-Type")).To(Equal("application/json"))
+		Expect(received.Header.Get(webhooks.SignatureHeader)).To(BeEmpty())
+
+		var input policy.PolicyCheckInput
+		Expect(json.Unmarshal(body, &input)).To(Succeed())
+		Expect(input.Action).To(Equal("CreateJobBuild"))
+		Expect(input.Job).To(Equal("some-job"))
+	})
+
+	Context("when a secret is configured", func() {
+		BeforeEach(func() {
+			secret = "some-secret"
+		})
+
+		It("signs the request", func() {
+			_, err := check()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(received.Header.Get(webhooks.SignatureHeader)).To(Equal(webhooks.Sign("some-secret", body)))
+		})
+	})
+
+	Context("when the action is allowed", func() {
+		It("allows it", func() {
+			result, err := check()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.Allowed()).To(BeTrue())
+			Expect(result.ShouldBlock()).To(BeFalse())
+		})
+	})
+
+	Context("when the action is not allowed", func() {
+		BeforeEach(func() {
+			response = `{"allowed": false, "reasons": ["prod jobs are triggered by the release team"]}`
+		})
+
+		It("blocks it with the reasons", func() {
+			result, err := check()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.Allowed()).To(BeFalse())
+			Expect(result.ShouldBlock()).To(BeTrue())
+			Expect(result.Messages()).To(ConsistOf("prod jobs are triggered by the release team"))
+		})
+
+		Context("when the webhook asks not to block it", func() {
+			BeforeEach(func() {
+				response = `{"allowed": false, "block": false}`
+			})
+
+			It("does not block it", func() {
+				result, err := check()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result.Allowed()).To(BeFalse())
+				Expect(result.ShouldBlock()).To(BeFalse())
+			})
+		})
+	})
+
+	Context("when the decision has no allowed key", func() {
+		BeforeEach(func() {
+			response = `{}`
+		})
+
+		It("errors", func() {
+			_, err := check()
+			Expect(err).To(MatchError("policy webhook decision has no 'allowed' key"))
+		})
+	})
+
+	Context("when the webhook fails", func() {
+		BeforeEach(func() {
+			status = http.StatusInternalServerError
+		})
+
+		It("errors", func() {
+			_, err := check()
+			Expect(err).To(MatchError("policy webhook returned status: 500"))
+		})
+	})
+})
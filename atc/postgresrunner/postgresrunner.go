@@ -14,6 +14,7 @@ import (
 	"code.cloudfoundry.org/lager/lagertest"
 
 	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dialect"
 	"github.com/concourse/concourse/atc/db/migration"
 	"github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -178,6 +179,10 @@ func (runner *Runner) openConn(dbName string) db.Conn {
 		nil,
 		"postgresrunner",
 		nil,
+		migration.HeavyMigrationLimits{},
+		"",
+		db.NewListener(runner.dataSourceName(dbName), nil),
+		dialect.Postgres,
 	)
 	Expect(err).NotTo(HaveOccurred())
 
@@ -0,0 +1,120 @@
+package provenance
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// PayloadType is the type of the payload of provenance envelopes.
+const PayloadType = "application/vnd.in-toto+json"
+
+// Envelope is a DSSE envelope, see
+// https://github.com/secure-systems-lab/dsse.
+type Envelope struct {
+	PayloadType string      `json:"payloadType"`
+	Payload     string      `json:"payload"`
+	Signatures  []Signature `json:"signatures"`
+}
+
+type Signature struct {
+	KeyID string `json:"keyid"`
+	Sig   string `json:"sig"`
+}
+
+var ErrInvalidSignature = errors.New("invalid signature")
+
+// KeyID identifies the public key, as the sha256 of its DER encoding.
+func KeyID(key *rsa.PublicKey) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(der)
+
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// Sign wraps the statement in an envelope signed with the key.
+func Sign(statement Statement, key *rsa.PrivateKey) (Envelope, error) {
+	payload, err := json.Marshal(statement)
+	if err != nil {
+		return Envelope{}, err
+	}
+
+	keyID, err := KeyID(&key.PublicKey)
+	if err != nil {
+		return Envelope{}, err
+	}
+
+	digest := sha256.Sum256(pae(PayloadType, payload))
+
+	sig, err := rsa.SignPKCS1v15(nil, key, crypto.SHA256, digest[:])
+	if err != nil {
+		return Envelope{}, err
+	}
+
+	return Envelope{
+		PayloadType: PayloadType,
+		Payload:     base64.StdEncoding.EncodeToString(payload),
+		Signatures: []Signature{
+			{
+				KeyID: keyID,
+				Sig:   base64.StdEncoding.EncodeToString(sig),
+			},
+		},
+	}, nil
+}
+
+// Verify checks that the envelope was signed with the key and returns the
+// statement within.
+func Verify(envelope Envelope, key *rsa.PublicKey) (Statement, error) {
+	if envelope.PayloadType != PayloadType {
+		return Statement{}, fmt.Errorf("unexpected payload type '%s'", envelope.PayloadType)
+	}
+
+	payload, err := base64.StdEncoding.DecodeString(envelope.Payload)
+	if err != nil {
+		return Statement{}, err
+	}
+
+	digest := sha256.Sum256(pae(envelope.PayloadType, payload))
+
+	verified := false
+	for _, signature := range envelope.Signatures {
+		sig, err := base64.StdEncoding.DecodeString(signature.Sig)
+		if err != nil {
+			continue
+		}
+
+		if rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], sig) == nil {
+			verified = true
+			break
+		}
+	}
+
+	if !verified {
+		return Statement{}, ErrInvalidSignature
+	}
+
+	var statement Statement
+	err = json.Unmarshal(payload, &statement)
+	if err != nil {
+		return Statement{}, err
+	}
+
+	return statement, nil
+}
+
+// pae is the pre-authentication encoding of the payload, which is what is
+// actually signed.
+func pae(payloadType string, payload []byte) []byte {
+	return []byte(fmt.Sprintf("DSSEv1 %d %s %d %s", len(payloadType), payloadType, len(payload), payload))
+}
@@ -0,0 +1,74 @@
+package provenance_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+
+	"github.com/concourse/concourse/atc/provenance"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Envelope", func() {
+	var (
+		key       *rsa.PrivateKey
+		statement provenance.Statement
+	)
+
+	BeforeEach(func() {
+		var err error
+		key, err = rsa.GenerateKey(rand.Reader, 2048)
+		Expect(err).ToNot(HaveOccurred())
+
+		statement = provenance.Statement{
+			Type:          provenance.StatementType,
+			PredicateType: provenance.PredicateType,
+			Subject: []provenance.Subject{
+				{Name: "some-image", Digest: map[string]string{"sha256": "abcd"}},
+			},
+			Predicate: provenance.Predicate{
+				Builder:   provenance.Builder{ID: "https://example.com"},
+				BuildType: provenance.BuildType,
+				Materials: []provenance.Material{},
+			},
+		}
+	})
+
+	It("signs the statement so that it can be verified", func() {
+		envelope, err := provenance.Sign(statement, key)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(envelope.PayloadType).To(Equal(provenance.PayloadType))
+		Expect(envelope.Signatures).To(HaveLen(1))
+
+		keyID, err := provenance.KeyID(&key.PublicKey)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(envelope.Signatures[0].KeyID).To(Equal(keyID))
+
+		verified, err := provenance.Verify(envelope, &key.PublicKey)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(verified.Subject).To(Equal(statement.Subject))
+	})
+
+	It("rejects a tampered payload", func() {
+		envelope, err := provenance.Sign(statement, key)
+		Expect(err).ToNot(HaveOccurred())
+
+		envelope.Payload = base64.StdEncoding.EncodeToString([]byte(`{"_type":"forged"}`))
+
+		_, err = provenance.Verify(envelope, &key.PublicKey)
+		Expect(err).To(Equal(provenance.ErrInvalidSignature))
+	})
+
+	It("rejects an envelope signed with another key", func() {
+		otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		Expect(err).ToNot(HaveOccurred())
+
+		envelope, err := provenance.Sign(statement, otherKey)
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = provenance.Verify(envelope, &key.PublicKey)
+		Expect(err).To(Equal(provenance.ErrInvalidSignature))
+	})
+})
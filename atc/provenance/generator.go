@@ -0,0 +1,190 @@
+// Package provenance generates signed in-toto/SLSA provenance for job
+// builds, describing what was built from which inputs, with which images and
+// on which workers.
+package provenance
+
+import (
+	"crypto/rsa"
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+// Generator generates the provenance of each finished job build and stores
+// it with the build.
+type Generator struct {
+	externalURL string
+	key         *rsa.PrivateKey
+}
+
+func NewGenerator(externalURL string, key *rsa.PrivateKey) *Generator {
+	return &Generator{
+		externalURL: externalURL,
+		key:         key,
+	}
+}
+
+// BuildStarted does nothing, as provenance is only generated once the build
+// has finished.
+func (generator *Generator) BuildStarted(lager.Logger, db.Build) {}
+
+func (generator *Generator) BuildFinished(logger lager.Logger, build db.Build) {
+	if build.JobID() == 0 {
+		return
+	}
+
+	logger = logger.Session("provenance")
+
+	statement, err := generator.Statement(build)
+	if err != nil {
+		logger.Error("failed-to-generate-statement", err)
+		return
+	}
+
+	envelope, err := Sign(statement, generator.key)
+	if err != nil {
+		logger.Error("failed-to-sign-statement", err)
+		return
+	}
+
+	payload, err := json.Marshal(envelope)
+	if err != nil {
+		logger.Error("failed-to-marshal-envelope", err)
+		return
+	}
+
+	err = build.SaveProvenance(payload)
+	if err != nil {
+		logger.Error("failed-to-save-provenance", err)
+		return
+	}
+}
+
+// Statement describes the build. The subjects are the versions produced by
+// the build's put steps, and the materials are its inputs and images.
+func (generator *Generator) Statement(build db.Build) (Statement, error) {
+	inputs, outputs, err := build.Resources()
+	if err != nil {
+		return Statement{}, fmt.Errorf("get resources: %w", err)
+	}
+
+	images, err := build.ImageResourceVersions()
+	if err != nil {
+		return Statement{}, fmt.Errorf("get image versions: %w", err)
+	}
+
+	workers, err := build.WorkerNames()
+	if err != nil {
+		return Statement{}, fmt.Errorf("get workers: %w", err)
+	}
+
+	resourceURIs := map[int]string{}
+
+	pipeline, found, err := build.Pipeline()
+	if err != nil {
+		return Statement{}, fmt.Errorf("get pipeline: %w", err)
+	}
+
+	if found {
+		resources, err := pipeline.Resources()
+		if err != nil {
+			return Statement{}, fmt.Errorf("get pipeline resources: %w", err)
+		}
+
+		for _, resource := range resources {
+			resourceURIs[resource.ID()] = generator.resourceURI(build, resource)
+		}
+	}
+
+	subjects := []Subject{}
+	for _, output := range outputs {
+		subjects = append(subjects, Subject{
+			Name:   output.Name,
+			Digest: Digest(output.Version),
+		})
+	}
+
+	materials := []Material{}
+	for _, input := range inputs {
+		uri, found := resourceURIs[input.ResourceID]
+		if !found {
+			uri = input.Name
+		}
+
+		materials = append(materials, Material{
+			URI:    uri,
+			Digest: Digest(input.Version),
+		})
+	}
+
+	for _, image := range images {
+		uri := "image"
+		if image.Type != "" {
+			uri = image.Type
+		}
+
+		materials = append(materials, Material{
+			URI:    uri,
+			Digest: Digest(image.Version),
+		})
+	}
+
+	buildURL := atc.JobBuildURL(generator.externalURL, build.TeamName(), build.PipelineRef(), build.JobName(), build.Name())
+
+	return Statement{
+		Type:          StatementType,
+		PredicateType: PredicateType,
+		Subject:       subjects,
+		Predicate: Predicate{
+			Builder:   Builder{ID: generator.externalURL},
+			BuildType: BuildType,
+			Invocation: Invocation{
+				Parameters: InvocationParameters{
+					Team:                 build.TeamName(),
+					Pipeline:             build.PipelineName(),
+					PipelineInstanceVars: build.PipelineInstanceVars(),
+					Job:                  build.JobName(),
+					Build:                build.Name(),
+					Status:               atc.BuildStatus(build.Status()),
+				},
+				Environment: InvocationEnvironment{
+					Workers: workers,
+				},
+			},
+			Metadata: Metadata{
+				BuildInvocationID: buildURL,
+				BuildStartedOn:    build.StartTime().UTC(),
+				BuildFinishedOn:   build.EndTime().UTC(),
+				Completeness: Completeness{
+					Parameters: true,
+					Materials:  true,
+				},
+			},
+			Materials: materials,
+		},
+	}, nil
+}
+
+// resourceURI is the 'uri' of the resource's source when it has one, e.g.
+// the repository of a git resource, or otherwise the resource's URL.
+func (generator *Generator) resourceURI(build db.Build, resource db.Resource) string {
+	if uri, ok := resource.Source()["uri"].(string); ok && uri != "" {
+		return uri
+	}
+
+	if repository, ok := resource.Source()["repository"].(string); ok && repository != "" {
+		return repository
+	}
+
+	return fmt.Sprintf(
+		"%s/teams/%s/pipelines/%s/resources/%s",
+		generator.externalURL,
+		url.PathEscape(build.TeamName()),
+		url.PathEscape(build.PipelineName()),
+		url.PathEscape(resource.Name()),
+	)
+}
@@ -0,0 +1,172 @@
+package provenance_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/provenance"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Generator", func() {
+	var (
+		key          *rsa.PrivateKey
+		fakeBuild    *dbfakes.FakeBuild
+		fakePipeline *dbfakes.FakePipeline
+
+		generator *provenance.Generator
+	)
+
+	BeforeEach(func() {
+		var err error
+		key, err = rsa.GenerateKey(rand.Reader, 2048)
+		Expect(err).ToNot(HaveOccurred())
+
+		gitResource := new(dbfakes.FakeResource)
+		gitResource.IDReturns(1)
+		gitResource.NameReturns("some-repo")
+		gitResource.SourceReturns(atc.Source{"uri": "https://github.com/some-owner/some-repo.git"})
+
+		timeResource := new(dbfakes.FakeResource)
+		timeResource.IDReturns(2)
+		timeResource.NameReturns("some-time")
+		timeResource.SourceReturns(atc.Source{"interval": "1h"})
+
+		fakePipeline = new(dbfakes.FakePipeline)
+		fakePipeline.ResourcesReturns(db.Resources{gitResource, timeResource}, nil)
+
+		fakeBuild = new(dbfakes.FakeBuild)
+		fakeBuild.JobIDReturns(1)
+		fakeBuild.JobNameReturns("some-job")
+		fakeBuild.NameReturns("42")
+		fakeBuild.TeamNameReturns("some-team")
+		fakeBuild.PipelineNameReturns("some-pipeline")
+		fakeBuild.PipelineRefReturns(atc.PipelineRef{Name: "some-pipeline"})
+		fakeBuild.StatusReturns(db.BuildStatusSucceeded)
+		fakeBuild.StartTimeReturns(time.Unix(100, 0))
+		fakeBuild.EndTimeReturns(time.Unix(200, 0))
+		fakeBuild.PipelineReturns(fakePipeline, true, nil)
+		fakeBuild.ResourcesReturns(
+			[]db.BuildInput{
+				{Name: "some-repo", ResourceID: 1, Version: atc.Version{"ref": "0123456789abcdef0123456789abcdef01234567"}},
+				{Name: "some-time", ResourceID: 2, Version: atc.Version{"time": "now"}},
+			},
+			[]db.BuildOutput{
+				{Name: "some-image", Version: atc.Version{"digest": "sha256:abcd"}},
+			},
+			nil,
+		)
+		fakeBuild.ImageResourceVersionsReturns([]db.BuildImageResourceVersion{
+			{Type: "registry-image", Version: atc.Version{"digest": "sha256:1234"}},
+		}, nil)
+		fakeBuild.WorkerNamesReturns([]string{"some-worker"}, nil)
+
+		generator = provenance.NewGenerator("https://example.com", key)
+	})
+
+	Describe("Statement", func() {
+		It("describes the build", func() {
+			statement, err := generator.Statement(fakeBuild)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(statement.Type).To(Equal(provenance.StatementType))
+			Expect(statement.PredicateType).To(Equal(provenance.PredicateType))
+
+			Expect(statement.Subject).To(Equal([]provenance.Subject{
+				{Name: "some-image", Digest: map[string]string{"sha256": "abcd"}},
+			}))
+
+			Expect(statement.Predicate.Builder.ID).To(Equal("https://example.com"))
+			Expect(statement.Predicate.Invocation.Parameters).To(Equal(provenance.InvocationParameters{
+				Team:     "some-team",
+				Pipeline: "some-pipeline",
+				Job:      "some-job",
+				Build:    "42",
+				Status:   atc.StatusSucceeded,
+			}))
+			Expect(statement.Predicate.Invocation.Environment.Workers).To(Equal([]string{"some-worker"}))
+			Expect(statement.Predicate.Metadata.BuildInvocationID).To(Equal("https://example.com/teams/some-team/pipelines/some-pipeline/jobs/some-job/builds/42"))
+			Expect(statement.Predicate.Metadata.BuildStartedOn).To(Equal(time.Unix(100, 0).UTC()))
+			Expect(statement.Predicate.Metadata.BuildFinishedOn).To(Equal(time.Unix(200, 0).UTC()))
+
+			Expect(statement.Predicate.Materials).To(Equal([]provenance.Material{
+				{
+					URI:    "https://github.com/some-owner/some-repo.git",
+					Digest: map[string]string{"sha1": "0123456789abcdef0123456789abcdef01234567"},
+				},
+				{
+					URI:    "https://example.com/teams/some-team/pipelines/some-pipeline/resources/some-time",
+					Digest: provenance.Digest(atc.Version{"time": "now"}),
+				},
+				{
+					URI:    "registry-image",
+					Digest: map[string]string{"sha256": "1234"},
+				},
+			}))
+		})
+
+		Context("when getting the build's resources fails", func() {
+			BeforeEach(func() {
+				fakeBuild.ResourcesReturns(nil, nil, errors.New("nope"))
+			})
+
+			It("errors", func() {
+				_, err := generator.Statement(fakeBuild)
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("BuildFinished", func() {
+		It("saves the signed provenance with the build", func() {
+			generator.BuildFinished(lagertest.NewTestLogger("test"), fakeBuild)
+
+			Expect(fakeBuild.SaveProvenanceCallCount()).To(Equal(1))
+
+			var envelope provenance.Envelope
+			err := json.Unmarshal(fakeBuild.SaveProvenanceArgsForCall(0), &envelope)
+			Expect(err).ToNot(HaveOccurred())
+
+			statement, err := provenance.Verify(envelope, &key.PublicKey)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(statement.Subject).To(HaveLen(1))
+		})
+
+		Context("when the build is not a job build", func() {
+			BeforeEach(func() {
+				fakeBuild.JobIDReturns(0)
+			})
+
+			It("does not generate provenance", func() {
+				generator.BuildFinished(lagertest.NewTestLogger("test"), fakeBuild)
+
+				Expect(fakeBuild.SaveProvenanceCallCount()).To(BeZero())
+			})
+		})
+	})
+})
+
+var _ = Describe("Digest", func() {
+	It("uses digests in the version", func() {
+		Expect(provenance.Digest(atc.Version{"digest": "sha256:abcd", "tag": "latest"})).To(Equal(map[string]string{"sha256": "abcd"}))
+	})
+
+	It("uses git refs as sha1 digests", func() {
+		Expect(provenance.Digest(atc.Version{"ref": "0123456789abcdef0123456789abcdef01234567"})).To(Equal(map[string]string{"sha1": "0123456789abcdef0123456789abcdef01234567"}))
+	})
+
+	It("otherwise digests the version itself, regardless of the order of its fields", func() {
+		digest := provenance.Digest(atc.Version{"a": "1", "b": "2"})
+		Expect(digest).To(HaveKey("sha256"))
+		Expect(provenance.Digest(atc.Version{"b": "2", "a": "1"})).To(Equal(digest))
+		Expect(provenance.Digest(atc.Version{"a": "1", "b": "3"})).ToNot(Equal(digest))
+	})
+})
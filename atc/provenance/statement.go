@@ -0,0 +1,139 @@
+package provenance
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+)
+
+const (
+	StatementType = "https://in-toto.io/Statement/v0.1"
+	PredicateType = "https://slsa.dev/provenance/v0.2"
+	BuildType     = "https://concourse-ci.org/provenance/build/v1"
+)
+
+// Statement is an in-toto statement whose subjects are the outputs of a
+// build, with a SLSA provenance predicate.
+type Statement struct {
+	Type          string    `json:"_type"`
+	PredicateType string    `json:"predicateType"`
+	Subject       []Subject `json:"subject"`
+	Predicate     Predicate `json:"predicate"`
+}
+
+type Subject struct {
+	Name   string            `json:"name"`
+	Digest map[string]string `json:"digest"`
+}
+
+type Predicate struct {
+	Builder    Builder    `json:"builder"`
+	BuildType  string     `json:"buildType"`
+	Invocation Invocation `json:"invocation"`
+	Metadata   Metadata   `json:"metadata"`
+	Materials  []Material `json:"materials"`
+}
+
+type Builder struct {
+	ID string `json:"id"`
+}
+
+type Invocation struct {
+	Parameters  InvocationParameters  `json:"parameters"`
+	Environment InvocationEnvironment `json:"environment"`
+}
+
+type InvocationParameters struct {
+	Team                 string           `json:"team"`
+	Pipeline             string           `json:"pipeline"`
+	PipelineInstanceVars atc.InstanceVars `json:"pipeline_instance_vars,omitempty"`
+	Job                  string           `json:"job"`
+	Build                string           `json:"build"`
+	Status               atc.BuildStatus  `json:"status"`
+}
+
+type InvocationEnvironment struct {
+	// Workers are the names of the workers which ran the build's steps.
+	Workers []string `json:"workers"`
+}
+
+type Metadata struct {
+	BuildInvocationID string       `json:"buildInvocationId"`
+	BuildStartedOn    time.Time    `json:"buildStartedOn"`
+	BuildFinishedOn   time.Time    `json:"buildFinishedOn"`
+	Completeness      Completeness `json:"completeness"`
+	Reproducible      bool         `json:"reproducible"`
+}
+
+type Completeness struct {
+	Parameters  bool `json:"parameters"`
+	Environment bool `json:"environment"`
+	Materials   bool `json:"materials"`
+}
+
+// Material is an input of the build, or an image used by one of its steps.
+type Material struct {
+	URI    string            `json:"uri"`
+	Digest map[string]string `json:"digest"`
+}
+
+// Digest determines the digest of a resource version. Fields of the version
+// which are already digests, e.g. the 'digest' of a registry-image version,
+// are used as they are. Otherwise the digest is the sha256 of the version
+// itself, which identifies the version without describing its contents.
+func Digest(version atc.Version) map[string]string {
+	digest := map[string]string{}
+
+	keys := make([]string, 0, len(version))
+	for k := range version {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		alg, value, found := strings.Cut(version[k], ":")
+		if !found || !isDigestAlgorithm(alg) || !isHex(value) {
+			continue
+		}
+
+		digest[alg] = value
+	}
+
+	if ref, ok := version["ref"]; ok && len(ref) == 40 && isHex(ref) {
+		digest["sha1"] = ref
+	}
+
+	if len(digest) > 0 {
+		return digest
+	}
+
+	// json.Marshal sorts the keys of maps, so the payload is stable
+	payload, _ := json.Marshal(version)
+	sum := sha256.Sum256(payload)
+
+	return map[string]string{"sha256": hex.EncodeToString(sum[:])}
+}
+
+func isDigestAlgorithm(alg string) bool {
+	switch alg {
+	case "sha1", "sha256", "sha384", "sha512":
+		return true
+	default:
+		return false
+	}
+}
+
+func isHex(s string) bool {
+	if s == "" {
+		return false
+	}
+
+	_, err := hex.DecodeString(s)
+	return err == nil
+}
@@ -29,6 +29,7 @@ func (plan *Plan) Public() *json.RawMessage {
 		DependentGet   *json.RawMessage `json:"dependent_get,omitempty"`
 		Timeout        *json.RawMessage `json:"timeout,omitempty"`
 		Retry          *json.RawMessage `json:"retry,omitempty"`
+		When           *json.RawMessage `json:"when,omitempty"`
 		ArtifactInput  *json.RawMessage `json:"artifact_input,omitempty"`
 		ArtifactOutput *json.RawMessage `json:"artifact_output,omitempty"`
 	}
@@ -107,6 +108,10 @@ func (plan *Plan) Public() *json.RawMessage {
 		public.Retry = plan.Retry.Public()
 	}
 
+	if plan.When != nil {
+		public.When = plan.When.Public()
+	}
+
 	if plan.ArtifactInput != nil {
 		public.ArtifactInput = plan.ArtifactInput.Public()
 	}
@@ -333,6 +338,16 @@ func (plan TimeoutPlan) Public() *json.RawMessage {
 	})
 }
 
+func (plan WhenPlan) Public() *json.RawMessage {
+	return enc(struct {
+		Step      *json.RawMessage `json:"step"`
+		Condition interface{}      `json:"condition"`
+	}{
+		Step:      plan.Step.Public(),
+		Condition: plan.Condition,
+	})
+}
+
 func (plan TryPlan) Public() *json.RawMessage {
 	return enc(struct {
 		Step *json.RawMessage `json:"step"`
@@ -0,0 +1,12 @@
+package atc
+
+// RequestLogSettings is how a web node logs the API requests it handles.
+type RequestLogSettings struct {
+	// SampleRate is the fraction of requests which are logged, from 0 to 1.
+	SampleRate float64 `json:"sample_rate"`
+
+	// SlowThreshold is how long a request may take before it is logged along
+	// with the timings of its queries, whether or not it's sampled, such as
+	// '2s'. It is empty when slow requests aren't logged.
+	SlowThreshold string `json:"slow_threshold,omitempty"`
+}
@@ -0,0 +1,109 @@
+package requestlog
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc/db"
+)
+
+// Conn records the queries made through the connection for the requests
+// which make them.
+func (recorder *Recorder) Conn(conn db.Conn) db.Conn {
+	return &recordingConn{
+		Conn:     conn,
+		recorder: recorder,
+	}
+}
+
+type recordingConn struct {
+	db.Conn
+
+	recorder *Recorder
+}
+
+func (c *recordingConn) Begin() (db.Tx, error) {
+	tx, err := c.Conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	return &recordingTx{Tx: tx, recorder: c.recorder}, nil
+}
+
+func (c *recordingConn) BeginTx(ctx context.Context, opts *sql.TxOptions) (db.Tx, error) {
+	tx, err := c.Conn.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &recordingTx{Tx: tx, recorder: c.recorder}, nil
+}
+
+func (c *recordingConn) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	defer c.recorder.recordQuery(query, time.Now())
+	return c.Conn.Query(query, args...)
+}
+
+func (c *recordingConn) QueryRow(query string, args ...interface{}) squirrel.RowScanner {
+	defer c.recorder.recordQuery(query, time.Now())
+	return c.Conn.QueryRow(query, args...)
+}
+
+func (c *recordingConn) Exec(query string, args ...interface{}) (sql.Result, error) {
+	defer c.recorder.recordQuery(query, time.Now())
+	return c.Conn.Exec(query, args...)
+}
+
+func (c *recordingConn) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	defer c.recorder.recordQuery(query, time.Now())
+	return c.Conn.QueryContext(ctx, query, args...)
+}
+
+func (c *recordingConn) QueryRowContext(ctx context.Context, query string, args ...interface{}) squirrel.RowScanner {
+	defer c.recorder.recordQuery(query, time.Now())
+	return c.Conn.QueryRowContext(ctx, query, args...)
+}
+
+func (c *recordingConn) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	defer c.recorder.recordQuery(query, time.Now())
+	return c.Conn.ExecContext(ctx, query, args...)
+}
+
+type recordingTx struct {
+	db.Tx
+
+	recorder *Recorder
+}
+
+func (t *recordingTx) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	defer t.recorder.recordQuery(query, time.Now())
+	return t.Tx.Query(query, args...)
+}
+
+func (t *recordingTx) QueryRow(query string, args ...interface{}) squirrel.RowScanner {
+	defer t.recorder.recordQuery(query, time.Now())
+	return t.Tx.QueryRow(query, args...)
+}
+
+func (t *recordingTx) Exec(query string, args ...interface{}) (sql.Result, error) {
+	defer t.recorder.recordQuery(query, time.Now())
+	return t.Tx.Exec(query, args...)
+}
+
+func (t *recordingTx) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	defer t.recorder.recordQuery(query, time.Now())
+	return t.Tx.QueryContext(ctx, query, args...)
+}
+
+func (t *recordingTx) QueryRowContext(ctx context.Context, query string, args ...interface{}) squirrel.RowScanner {
+	defer t.recorder.recordQuery(query, time.Now())
+	return t.Tx.QueryRowContext(ctx, query, args...)
+}
+
+func (t *recordingTx) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	defer t.recorder.recordQuery(query, time.Now())
+	return t.Tx.ExecContext(ctx, query, args...)
+}
@@ -0,0 +1,223 @@
+// Package requestlog logs the API requests a web node handles along with the
+// database queries they make, to help diagnose slow requests.
+package requestlog
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+)
+
+// maxQueryTimings bounds the timings kept per request, so that a request
+// making thousands of queries doesn't log them all; they are still counted.
+const maxQueryTimings = 100
+
+type settings struct {
+	sampleRate    float64
+	slowThreshold time.Duration
+}
+
+// Recorder keeps track of the requests being handled and the queries they
+// make. Queries are attributed to the request being handled on the goroutine
+// which makes them, so the queries a handler makes on other goroutines are
+// not counted.
+type Recorder struct {
+	// inFlight is the number of requests being recorded, so that queries
+	// don't look for their request when there are none; first for its
+	// alignment
+	inFlight int64
+	requests sync.Map
+
+	logger   lager.Logger
+	settings atomic.Value
+}
+
+func NewRecorder(logger lager.Logger, initial atc.RequestLogSettings) (*Recorder, error) {
+	recorder := &Recorder{
+		logger: logger,
+	}
+
+	err := recorder.SetSettings(initial)
+	if err != nil {
+		return nil, err
+	}
+
+	return recorder, nil
+}
+
+func (recorder *Recorder) Settings() atc.RequestLogSettings {
+	current := recorder.settings.Load().(settings)
+
+	presented := atc.RequestLogSettings{
+		SampleRate: current.sampleRate,
+	}
+
+	if current.slowThreshold > 0 {
+		presented.SlowThreshold = current.slowThreshold.String()
+	}
+
+	return presented
+}
+
+// SetSettings changes how requests are logged from the next request on.
+func (recorder *Recorder) SetSettings(configured atc.RequestLogSettings) error {
+	if configured.SampleRate < 0 || configured.SampleRate > 1 {
+		return fmt.Errorf("invalid sample rate %v: must be between 0 and 1", configured.SampleRate)
+	}
+
+	var slowThreshold time.Duration
+	if configured.SlowThreshold != "" {
+		var err error
+		slowThreshold, err = time.ParseDuration(configured.SlowThreshold)
+		if err != nil || slowThreshold <= 0 {
+			return fmt.Errorf("invalid slow threshold '%s': must be a positive duration such as '2s'", configured.SlowThreshold)
+		}
+	}
+
+	recorder.settings.Store(settings{
+		sampleRate:    configured.SampleRate,
+		slowThreshold: slowThreshold,
+	})
+
+	return nil
+}
+
+// Request is a request being recorded.
+type Request struct {
+	recorder *Recorder
+	settings settings
+	sampled  bool
+
+	goroutine uint64
+	data      lager.Data
+	start     time.Time
+
+	lock          sync.Mutex
+	queries       int
+	queryDuration time.Duration
+	timings       []queryTiming
+}
+
+type queryTiming struct {
+	Query      string  `json:"query"`
+	DurationMS float64 `json:"duration_ms"`
+}
+
+// Start starts recording a request handled on the calling goroutine, which
+// must call Finish once it's handled. It returns nil if the request is
+// neither sampled nor may turn out to be slow.
+func (recorder *Recorder) Start(data lager.Data) *Request {
+	current := recorder.settings.Load().(settings)
+
+	sampled := current.sampleRate > 0 && rand.Float64() < current.sampleRate
+	if !sampled && current.slowThreshold == 0 {
+		return nil
+	}
+
+	request := &Request{
+		recorder:  recorder,
+		settings:  current,
+		sampled:   sampled,
+		goroutine: goroutineID(),
+		data:      data,
+		start:     time.Now(),
+	}
+
+	recorder.requests.Store(request.goroutine, request)
+	atomic.AddInt64(&recorder.inFlight, 1)
+
+	return request
+}
+
+// Finish logs the request if it was sampled or slow.
+func (request *Request) Finish(statusCode int) {
+	recorder := request.recorder
+
+	recorder.requests.Delete(request.goroutine)
+	atomic.AddInt64(&recorder.inFlight, -1)
+
+	duration := time.Since(request.start)
+	slow := request.settings.slowThreshold > 0 && duration >= request.settings.slowThreshold
+	if !slow && !request.sampled {
+		return
+	}
+
+	request.lock.Lock()
+	defer request.lock.Unlock()
+
+	data := lager.Data{
+		"status":            statusCode,
+		"duration-ms":       milliseconds(duration),
+		"queries":           request.queries,
+		"query-duration-ms": milliseconds(request.queryDuration),
+	}
+
+	for key, value := range request.data {
+		data[key] = value
+	}
+
+	if slow {
+		data["sql"] = request.timings
+		recorder.logger.Info("slow-request", data)
+	} else {
+		recorder.logger.Info("request", data)
+	}
+}
+
+func (request *Request) recordQuery(query string, duration time.Duration) {
+	request.lock.Lock()
+	defer request.lock.Unlock()
+
+	request.queries++
+	request.queryDuration += duration
+
+	if request.settings.slowThreshold > 0 && len(request.timings) < maxQueryTimings {
+		request.timings = append(request.timings, queryTiming{
+			Query:      strings.Join(strings.Fields(query), " "),
+			DurationMS: milliseconds(duration),
+		})
+	}
+}
+
+// recordQuery attributes a query to the request being handled on the calling
+// goroutine, if any.
+func (recorder *Recorder) recordQuery(query string, start time.Time) {
+	if atomic.LoadInt64(&recorder.inFlight) == 0 {
+		return
+	}
+
+	request, found := recorder.requests.Load(goroutineID())
+	if !found {
+		return
+	}
+
+	request.(*Request).recordQuery(query, time.Since(start))
+}
+
+func milliseconds(duration time.Duration) float64 {
+	return float64(duration) / float64(time.Millisecond)
+}
+
+// goroutineID parses the ID of the calling goroutine out of its stack trace,
+// which starts with e.g. 'goroutine 42 [running]:'.
+func goroutineID() uint64 {
+	var buf [64]byte
+	stack := buf[:runtime.Stack(buf[:], false)]
+
+	stack = bytes.TrimPrefix(stack, []byte("goroutine "))
+	if space := bytes.IndexByte(stack, ' '); space != -1 {
+		stack = stack[:space]
+	}
+
+	id, _ := strconv.ParseUint(string(stack), 10, 64)
+	return id
+}
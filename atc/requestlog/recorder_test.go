@@ -0,0 +1,170 @@
+package requestlog_test
+
+import (
+	"database/sql"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/requestlog"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Recorder", func() {
+	var (
+		logger   *lagertest.TestLogger
+		recorder *requestlog.Recorder
+		conn     db.Conn
+		fakeConn *dbfakes.FakeConn
+	)
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("test")
+
+		var err error
+		recorder, err = requestlog.NewRecorder(logger, atc.RequestLogSettings{})
+		Expect(err).ToNot(HaveOccurred())
+
+		fakeConn = new(dbfakes.FakeConn)
+		fakeConn.BeginReturns(new(dbfakes.FakeTx), nil)
+
+		conn = recorder.Conn(fakeConn)
+	})
+
+	handle := func(queries ...string) {
+		request := recorder.Start(lager.Data{"route": atc.GetPipeline})
+		if request == nil {
+			return
+		}
+
+		for _, query := range queries {
+			_, err := conn.Exec(query)
+			Expect(err).ToNot(HaveOccurred())
+		}
+
+		tx, err := conn.Begin()
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = tx.Exec("UPDATE pipelines SET paused = true")
+		Expect(err).ToNot(HaveOccurred())
+
+		request.Finish(200)
+	}
+
+	Describe("SetSettings", func() {
+		It("is what Settings returns", func() {
+			err := recorder.SetSettings(atc.RequestLogSettings{SampleRate: 0.5, SlowThreshold: "2s"})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(recorder.Settings()).To(Equal(atc.RequestLogSettings{SampleRate: 0.5, SlowThreshold: "2s"}))
+		})
+
+		It("rejects sample rates outside 0 to 1", func() {
+			err := recorder.SetSettings(atc.RequestLogSettings{SampleRate: 1.5})
+			Expect(err).To(MatchError(ContainSubstring("invalid sample rate")))
+		})
+
+		It("rejects slow thresholds which aren't durations", func() {
+			err := recorder.SetSettings(atc.RequestLogSettings{SlowThreshold: "slow"})
+			Expect(err).To(MatchError(ContainSubstring("invalid slow threshold 'slow'")))
+		})
+	})
+
+	Context("when nothing is logged", func() {
+		It("doesn't record requests", func() {
+			Expect(recorder.Start(lager.Data{})).To(BeNil())
+		})
+
+		It("still passes the queries through", func() {
+			handle("SELECT 1")
+
+			_, err := conn.Exec("SELECT 1")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(fakeConn.ExecCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when every request is sampled", func() {
+		BeforeEach(func() {
+			err := recorder.SetSettings(atc.RequestLogSettings{SampleRate: 1})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("logs each request with the number of queries it made", func() {
+			handle("SELECT 1", "SELECT 2")
+
+			Expect(logger.LogMessages()).To(Equal([]string{"test.request"}))
+
+			data := logger.Logs()[0].Data
+			Expect(data["route"]).To(Equal(atc.GetPipeline))
+			Expect(data["status"]).To(BeNumerically("==", 200))
+			Expect(data["queries"]).To(BeNumerically("==", 3))
+			Expect(data).To(HaveKey("duration-ms"))
+			Expect(data).To(HaveKey("query-duration-ms"))
+			Expect(data).ToNot(HaveKey("sql"))
+		})
+
+		It("doesn't count queries made outside of requests", func() {
+			_, err := conn.Exec("SELECT 1")
+			Expect(err).ToNot(HaveOccurred())
+
+			handle()
+
+			Expect(logger.Logs()[0].Data["queries"]).To(BeNumerically("==", 1))
+		})
+
+		It("doesn't count queries made on other goroutines", func() {
+			request := recorder.Start(lager.Data{})
+
+			done := make(chan struct{})
+			go func() {
+				defer GinkgoRecover()
+				defer close(done)
+
+				_, err := conn.Exec("SELECT 1")
+				Expect(err).ToNot(HaveOccurred())
+			}()
+			<-done
+
+			request.Finish(200)
+
+			Expect(logger.Logs()[0].Data["queries"]).To(BeNumerically("==", 0))
+		})
+	})
+
+	Context("when slow requests are logged", func() {
+		BeforeEach(func() {
+			err := recorder.SetSettings(atc.RequestLogSettings{SlowThreshold: "10ms"})
+			Expect(err).ToNot(HaveOccurred())
+
+			fakeConn.ExecStub = func(string, ...interface{}) (sql.Result, error) {
+				time.Sleep(10 * time.Millisecond)
+				return nil, nil
+			}
+		})
+
+		It("logs the requests taking longer with the timings of their queries", func() {
+			handle("SELECT  1\n\tFROM pipelines")
+
+			Expect(logger.LogMessages()).To(Equal([]string{"test.slow-request"}))
+
+			data := logger.Logs()[0].Data
+			Expect(data["sql"]).To(HaveLen(2))
+
+			timing := data["sql"].([]interface{})[0].(map[string]interface{})
+			Expect(timing["query"]).To(Equal("SELECT 1 FROM pipelines"))
+			Expect(timing["duration_ms"]).To(BeNumerically(">=", 10))
+		})
+
+		It("doesn't log the requests which are quick", func() {
+			handle()
+
+			Expect(logger.LogMessages()).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,40 @@
+package resource
+
+import (
+	"context"
+	"errors"
+
+	"github.com/concourse/concourse/atc"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+
+// ErrUnsupportedSource is returned by a NativeResource when the source uses
+// configuration it doesn't implement. The check then runs in a container as
+// usual.
+var ErrUnsupportedSource = errors.New("source is not supported natively")
+
+// NativeResource is a base resource type implemented inside the ATC rather
+// than by the scripts in its image. Checking such a resource doesn't need a
+// container, which saves a great deal of container churn for the resource
+// types that nearly every pipeline uses.
+//
+//counterfeiter:generate . NativeResource
+type NativeResource interface {
+	Check(ctx context.Context, source atc.Source, from atc.Version) ([]atc.Version, error)
+}
+
+// NativeResources are the native implementations of base resource types, by
+// the name of the type.
+type NativeResources map[string]NativeResource
+
+// Lookup returns the native implementation of the base resource type, if
+// there is one.
+func (resources NativeResources) Lookup(baseType string) (NativeResource, bool) {
+	if baseType == "" {
+		return nil, false
+	}
+
+	native, found := resources[baseType]
+	return native, found
+}
@@ -0,0 +1,70 @@
+// Package native implements checking some of the base resource types inside
+// the ATC, without a container.
+package native
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/resource"
+)
+
+// SupportedTypes are the base resource types with a native implementation.
+var SupportedTypes = []string{"time", "registry-image"}
+
+// NewResources returns the native implementations of the given base resource
+// types. It errors for any type without one.
+func NewResources(types []string) (resource.NativeResources, error) {
+	resources := resource.NativeResources{}
+
+	for _, t := range types {
+		switch t {
+		case "time":
+			resources[t] = NewTime(time.Now)
+		case "registry-image":
+			resources[t] = NewRegistryImage(&http.Client{
+				Transport: http.DefaultTransport,
+				Timeout:   time.Minute,
+			})
+		default:
+			return nil, fmt.Errorf("resource type '%s' has no native implementation (supported: %v)", t, SupportedTypes)
+		}
+	}
+
+	return resources, nil
+}
+
+// decodeSource decodes the source into dest, failing with
+// resource.ErrUnsupportedSource if it has keys that dest doesn't know.
+func decodeSource(source atc.Source, dest interface{}, known ...string) error {
+	for key := range source {
+		if !contains(known, key) {
+			return resource.ErrUnsupportedSource
+		}
+	}
+
+	payload, err := json.Marshal(source)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(payload, dest)
+	if err != nil {
+		return fmt.Errorf("invalid source: %w", err)
+	}
+
+	return nil
+}
+
+func contains(list []string, s string) bool {
+	for _, l := range list {
+		if l == s {
+			return true
+		}
+	}
+
+	return false
+}
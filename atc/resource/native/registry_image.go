@@ -0,0 +1,240 @@
+package native
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/concourse/concourse/atc"
+)
+
+const dockerHubRegistry = "registry-1.docker.io"
+
+var manifestMediaTypes = []string{
+	"application/vnd.oci.image.index.v1+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+}
+
+// RegistryImage is the native implementation of the registry-image resource,
+// for the plain case of a tag in a registry reached with a username and
+// password. It finds the digest of the tag with a HEAD request for its
+// manifest, which unlike pulling an image doesn't count against the rate
+// limits of Docker Hub.
+type RegistryImage struct {
+	client *http.Client
+}
+
+func NewRegistryImage(client *http.Client) RegistryImage {
+	return RegistryImage{client: client}
+}
+
+type registryImageSource struct {
+	Repository string `json:"repository"`
+	Tag        string `json:"tag"`
+	Username   string `json:"username"`
+	Password   string `json:"password"`
+}
+
+func (r RegistryImage) Check(ctx context.Context, source atc.Source, from atc.Version) ([]atc.Version, error) {
+	var src registryImageSource
+	err := decodeSource(source, &src, "repository", "tag", "username", "password")
+	if err != nil {
+		return nil, err
+	}
+
+	if src.Repository == "" {
+		return nil, errors.New("repository must be configured")
+	}
+
+	if src.Tag == "" {
+		src.Tag = "latest"
+	}
+
+	registry, repository := splitRepository(src.Repository)
+
+	manifestURL := fmt.Sprintf("https://%s/v2/%s/manifests/%s", registry, repository, url.PathEscape(src.Tag))
+
+	res, err := r.headManifest(ctx, manifestURL, "")
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode == http.StatusUnauthorized {
+		authorization, err := r.authorize(ctx, res.Header.Get("WWW-Authenticate"), src)
+		if err != nil {
+			return nil, err
+		}
+
+		res, err = r.headManifest(ctx, manifestURL, authorization)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("get manifest of %s:%s: registry returned %s", src.Repository, src.Tag, res.Status)
+	}
+
+	digest := res.Header.Get("Docker-Content-Digest")
+	if digest == "" {
+		return nil, fmt.Errorf("get manifest of %s:%s: registry returned no digest", src.Repository, src.Tag)
+	}
+
+	if from != nil && from["digest"] == digest {
+		return []atc.Version{from}, nil
+	}
+
+	return []atc.Version{{"digest": digest}}, nil
+}
+
+func (r RegistryImage) headManifest(ctx context.Context, manifestURL string, authorization string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, manifestURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", strings.Join(manifestMediaTypes, ", "))
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+
+	res, err := r.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	res.Body.Close()
+
+	return res, nil
+}
+
+// authorize answers the challenge of the registry, returning the value of the
+// Authorization header to retry the request with.
+func (r RegistryImage) authorize(ctx context.Context, challenge string, src registryImageSource) (string, error) {
+	scheme, params := parseChallenge(challenge)
+
+	switch strings.ToLower(scheme) {
+	case "basic":
+		if src.Username == "" {
+			return "", errors.New("registry requires a username and password")
+		}
+
+		req, err := http.NewRequest(http.MethodGet, "/", nil)
+		if err != nil {
+			return "", err
+		}
+
+		req.SetBasicAuth(src.Username, src.Password)
+
+		return req.Header.Get("Authorization"), nil
+
+	case "bearer":
+		realm, err := url.Parse(params["realm"])
+		if err != nil || params["realm"] == "" {
+			return "", fmt.Errorf("invalid token realm in challenge: %s", challenge)
+		}
+
+		query := realm.Query()
+		if params["service"] != "" {
+			query.Set("service", params["service"])
+		}
+		if params["scope"] != "" {
+			query.Set("scope", params["scope"])
+		}
+		realm.RawQuery = query.Encode()
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, realm.String(), nil)
+		if err != nil {
+			return "", err
+		}
+
+		if src.Username != "" {
+			req.SetBasicAuth(src.Username, src.Password)
+		}
+
+		res, err := r.client.Do(req)
+		if err != nil {
+			return "", err
+		}
+
+		defer res.Body.Close()
+
+		if res.StatusCode != http.StatusOK {
+			return "", fmt.Errorf("get registry token: %s", res.Status)
+		}
+
+		var token struct {
+			Token       string `json:"token"`
+			AccessToken string `json:"access_token"`
+		}
+
+		err = json.NewDecoder(res.Body).Decode(&token)
+		if err != nil {
+			return "", fmt.Errorf("decode registry token: %w", err)
+		}
+
+		if token.Token == "" {
+			token.Token = token.AccessToken
+		}
+
+		return "Bearer " + token.Token, nil
+
+	default:
+		return "", fmt.Errorf("unsupported registry auth challenge: %s", challenge)
+	}
+}
+
+// splitRepository splits the repository into the host of its registry and
+// the path of the repository in the registry, the same way docker does.
+func splitRepository(repository string) (string, string) {
+	parts := strings.SplitN(repository, "/", 2)
+	if len(parts) == 2 && (strings.ContainsAny(parts[0], ".:") || parts[0] == "localhost") {
+		return parts[0], parts[1]
+	}
+
+	if !strings.Contains(repository, "/") {
+		repository = "library/" + repository
+	}
+
+	return dockerHubRegistry, repository
+}
+
+// parseChallenge parses a WWW-Authenticate header like
+//
+//	Bearer realm="https://auth.docker.io/token",service="registry.docker.io"
+func parseChallenge(challenge string) (string, map[string]string) {
+	params := map[string]string{}
+
+	scheme, rest, _ := strings.Cut(strings.TrimSpace(challenge), " ")
+
+	for rest != "" {
+		var pair string
+		rest = strings.TrimLeft(rest, ", ")
+
+		key, value, found := strings.Cut(rest, "=")
+		if !found {
+			break
+		}
+
+		if strings.HasPrefix(value, `"`) {
+			end := strings.Index(value[1:], `"`)
+			if end == -1 {
+				break
+			}
+
+			pair, rest = value[1:end+1], value[end+2:]
+		} else {
+			pair, rest, _ = strings.Cut(value, ",")
+		}
+
+		params[strings.ToLower(strings.TrimSpace(key))] = pair
+	}
+
+	return scheme, params
+}
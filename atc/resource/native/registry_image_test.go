@@ -0,0 +1,128 @@
+package native_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/resource"
+	"github.com/concourse/concourse/atc/resource/native"
+	"github.com/stretchr/testify/require"
+)
+
+const someDigest = "sha256:4b5d3d8c4a5e2f8a5fb4e1e7c0e1f2b8a8d4c6f6c3a6e0b0d2e0c9b1a7f3e5d1"
+
+func TestRegistryImageCheck(t *testing.T) {
+	var registry *httptest.Server
+	registry = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/token":
+			user, pass, ok := r.BasicAuth()
+			if !ok || user != "some-user" || pass != "some-password" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+
+			if r.URL.Query().Get("scope") != "repository:some/image:pull" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+
+			fmt.Fprint(w, `{"token":"some-token"}`)
+
+		case "/v2/some/image/manifests/latest", "/v2/some/image/manifests/missing":
+			require.Equal(t, http.MethodHead, r.Method)
+			require.Contains(t, r.Header.Get("Accept"), "application/vnd.oci.image.index.v1+json")
+
+			if r.Header.Get("Authorization") != "Bearer some-token" {
+				w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="some-registry",scope="repository:some/image:pull"`, registry.URL))
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+
+			if strings.HasSuffix(r.URL.Path, "missing") {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+
+			w.Header().Set("Docker-Content-Digest", someDigest)
+
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer registry.Close()
+
+	repository := strings.TrimPrefix(registry.URL, "https://") + "/some/image"
+
+	check := func(source atc.Source, from atc.Version) ([]atc.Version, error) {
+		return native.NewRegistryImage(registry.Client()).Check(context.Background(), source, from)
+	}
+
+	t.Run("emits the digest of the tag", func(t *testing.T) {
+		versions, err := check(atc.Source{
+			"repository": repository,
+			"username":   "some-user",
+			"password":   "some-password",
+		}, nil)
+		require.NoError(t, err)
+		require.Equal(t, []atc.Version{{"digest": someDigest}}, versions)
+	})
+
+	t.Run("keeps the previous version when the digest hasn't changed", func(t *testing.T) {
+		previous := atc.Version{"digest": someDigest, "tag": "latest"}
+
+		versions, err := check(atc.Source{
+			"repository": repository,
+			"username":   "some-user",
+			"password":   "some-password",
+		}, previous)
+		require.NoError(t, err)
+		require.Equal(t, []atc.Version{previous}, versions)
+	})
+
+	t.Run("errors when the credentials are wrong", func(t *testing.T) {
+		_, err := check(atc.Source{
+			"repository": repository,
+			"username":   "some-user",
+			"password":   "wrong",
+		}, nil)
+		require.EqualError(t, err, "get registry token: 401 Unauthorized")
+	})
+
+	t.Run("errors when the tag does not exist", func(t *testing.T) {
+		_, err := check(atc.Source{
+			"repository": repository,
+			"tag":        "missing",
+			"username":   "some-user",
+			"password":   "some-password",
+		}, nil)
+		require.EqualError(t, err, fmt.Sprintf("get manifest of %s:missing: registry returned 404 Not Found", repository))
+	})
+
+	t.Run("does not support other source keys", func(t *testing.T) {
+		_, err := check(atc.Source{
+			"repository":        repository,
+			"aws_access_key_id": "some-key",
+		}, nil)
+		require.ErrorIs(t, err, resource.ErrUnsupportedSource)
+	})
+}
+
+func TestNewResources(t *testing.T) {
+	resources, err := native.NewResources([]string{"time", "registry-image"})
+	require.NoError(t, err)
+
+	_, found := resources.Lookup("time")
+	require.True(t, found)
+
+	_, found = resources.Lookup("git")
+	require.False(t, found)
+
+	_, err = native.NewResources([]string{"git"})
+	require.Error(t, err)
+}
@@ -0,0 +1,220 @@
+package native
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+)
+
+var timeOfDayFormats = []string{
+	"15:04",
+	"1504",
+	"3:04 PM",
+	"3:04PM",
+	"3 PM",
+	"3PM",
+}
+
+// Time is the native implementation of the time resource. Its versions are
+// the same as the ones the time resource emits, so a resource can switch
+// between the two without its version history starting over.
+type Time struct {
+	now func() time.Time
+}
+
+func NewTime(now func() time.Time) Time {
+	return Time{now: now}
+}
+
+type timeSource struct {
+	Interval       string   `json:"interval"`
+	Start          string   `json:"start"`
+	Stop           string   `json:"stop"`
+	Location       string   `json:"location"`
+	Days           []string `json:"days"`
+	InitialVersion bool     `json:"initial_version"`
+}
+
+type timeConfig struct {
+	interval time.Duration
+	hasRange bool
+	start    time.Duration
+	stop     time.Duration
+	location *time.Location
+	days     []time.Weekday
+	initial  bool
+}
+
+func (t Time) Check(ctx context.Context, source atc.Source, from atc.Version) ([]atc.Version, error) {
+	var src timeSource
+	err := decodeSource(source, &src, "interval", "start", "stop", "location", "days", "initial_version")
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := src.config()
+	if err != nil {
+		return nil, err
+	}
+
+	now := t.now().In(config.location)
+
+	previous, found := time.Time{}, false
+	if from != nil {
+		previous, err = time.Parse(time.RFC3339Nano, from["time"])
+		found = err == nil
+	}
+
+	if config.due(now, previous, found) {
+		return []atc.Version{{"time": now.Format(time.RFC3339Nano)}}, nil
+	}
+
+	if from == nil {
+		return []atc.Version{}, nil
+	}
+
+	return []atc.Version{from}, nil
+}
+
+func (src timeSource) config() (timeConfig, error) {
+	config := timeConfig{
+		location: time.UTC,
+		initial:  src.InitialVersion,
+	}
+
+	var err error
+	if src.Interval != "" {
+		config.interval, err = time.ParseDuration(src.Interval)
+		if err != nil {
+			return timeConfig{}, fmt.Errorf("invalid interval: %w", err)
+		}
+	}
+
+	if src.Start != "" || src.Stop != "" {
+		if src.Start == "" || src.Stop == "" {
+			return timeConfig{}, errors.New("both start and stop must be configured")
+		}
+
+		config.hasRange = true
+
+		config.start, err = parseTimeOfDay(src.Start)
+		if err != nil {
+			return timeConfig{}, fmt.Errorf("invalid start: %w", err)
+		}
+
+		config.stop, err = parseTimeOfDay(src.Stop)
+		if err != nil {
+			return timeConfig{}, fmt.Errorf("invalid stop: %w", err)
+		}
+	}
+
+	if config.interval == 0 && !config.hasRange {
+		return timeConfig{}, errors.New("must configure either interval or start and stop")
+	}
+
+	if src.Location != "" {
+		config.location, err = time.LoadLocation(src.Location)
+		if err != nil {
+			return timeConfig{}, fmt.Errorf("invalid location: %w", err)
+		}
+	}
+
+	for _, day := range src.Days {
+		weekday, err := parseWeekday(day)
+		if err != nil {
+			return timeConfig{}, err
+		}
+
+		config.days = append(config.days, weekday)
+	}
+
+	return config, nil
+}
+
+// due returns whether a new version is to be emitted at the given time.
+func (config timeConfig) due(now time.Time, previous time.Time, found bool) bool {
+	if !found && config.initial {
+		return true
+	}
+
+	if !config.inRange(now) {
+		return false
+	}
+
+	if !found {
+		return true
+	}
+
+	if config.hasRange && previous.Before(config.rangeStart(now)) {
+		return true
+	}
+
+	return config.interval != 0 && now.Sub(previous) >= config.interval
+}
+
+func (config timeConfig) inRange(now time.Time) bool {
+	if len(config.days) > 0 {
+		found := false
+		for _, day := range config.days {
+			if now.Weekday() == day {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	if !config.hasRange {
+		return true
+	}
+
+	sinceMidnight := now.Sub(midnight(now))
+	if config.start <= config.stop {
+		return sinceMidnight >= config.start && sinceMidnight < config.stop
+	}
+
+	// the range wraps around midnight
+	return sinceMidnight >= config.start || sinceMidnight < config.stop
+}
+
+// rangeStart returns when the range containing the given time started.
+func (config timeConfig) rangeStart(now time.Time) time.Time {
+	start := midnight(now).Add(config.start)
+	if start.After(now) {
+		start = start.AddDate(0, 0, -1)
+	}
+
+	return start
+}
+
+func midnight(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+func parseTimeOfDay(s string) (time.Duration, error) {
+	for _, format := range timeOfDayFormats {
+		t, err := time.Parse(format, strings.TrimSpace(s))
+		if err == nil {
+			return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+		}
+	}
+
+	return 0, fmt.Errorf("'%s' is not a time of day", s)
+}
+
+func parseWeekday(s string) (time.Weekday, error) {
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if strings.EqualFold(day.String(), s) {
+			return day, nil
+		}
+	}
+
+	return 0, fmt.Errorf("invalid day '%s'", s)
+}
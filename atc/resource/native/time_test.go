@@ -0,0 +1,98 @@
+package native_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/resource"
+	"github.com/concourse/concourse/atc/resource/native"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeCheck(t *testing.T) {
+	now := time.Date(2024, time.March, 6, 10, 30, 0, 0, time.UTC) // a Wednesday
+	nowVersion := atc.Version{"time": now.Format(time.RFC3339Nano)}
+
+	check := func(source atc.Source, from atc.Version) ([]atc.Version, error) {
+		return native.NewTime(func() time.Time { return now }).Check(context.Background(), source, from)
+	}
+
+	version := func(t time.Time) atc.Version {
+		return atc.Version{"time": t.Format(time.RFC3339Nano)}
+	}
+
+	t.Run("emits a version on the first check", func(t *testing.T) {
+		versions, err := check(atc.Source{"interval": "1h"}, nil)
+		require.NoError(t, err)
+		require.Equal(t, []atc.Version{nowVersion}, versions)
+	})
+
+	t.Run("emits a version once the interval has passed", func(t *testing.T) {
+		versions, err := check(atc.Source{"interval": "1h"}, version(now.Add(-time.Hour)))
+		require.NoError(t, err)
+		require.Equal(t, []atc.Version{nowVersion}, versions)
+	})
+
+	t.Run("keeps the previous version within the interval", func(t *testing.T) {
+		previous := version(now.Add(-time.Minute))
+
+		versions, err := check(atc.Source{"interval": "1h"}, previous)
+		require.NoError(t, err)
+		require.Equal(t, []atc.Version{previous}, versions)
+	})
+
+	t.Run("emits a version once per range", func(t *testing.T) {
+		source := atc.Source{"start": "10:00", "stop": "11:00"}
+
+		versions, err := check(source, version(now.AddDate(0, 0, -1)))
+		require.NoError(t, err)
+		require.Equal(t, []atc.Version{nowVersion}, versions)
+
+		previous := version(now.Add(-10 * time.Minute))
+		versions, err = check(source, previous)
+		require.NoError(t, err)
+		require.Equal(t, []atc.Version{previous}, versions)
+	})
+
+	t.Run("emits nothing outside the range", func(t *testing.T) {
+		versions, err := check(atc.Source{"start": "1PM", "stop": "2PM"}, nil)
+		require.NoError(t, err)
+		require.Empty(t, versions)
+	})
+
+	t.Run("emits an initial version outside the range when asked to", func(t *testing.T) {
+		versions, err := check(atc.Source{"start": "1PM", "stop": "2PM", "initial_version": true}, nil)
+		require.NoError(t, err)
+		require.Equal(t, []atc.Version{nowVersion}, versions)
+	})
+
+	t.Run("handles ranges that wrap around midnight", func(t *testing.T) {
+		versions, err := check(atc.Source{"start": "22:00", "stop": "11:00"}, nil)
+		require.NoError(t, err)
+		require.Equal(t, []atc.Version{nowVersion}, versions)
+	})
+
+	t.Run("emits nothing on other days", func(t *testing.T) {
+		versions, err := check(atc.Source{"interval": "1h", "days": []string{"Monday", "Tuesday"}}, nil)
+		require.NoError(t, err)
+		require.Empty(t, versions)
+	})
+
+	t.Run("uses the location", func(t *testing.T) {
+		versions, err := check(atc.Source{"start": "10:00", "stop": "11:00", "location": "America/New_York"}, nil)
+		require.NoError(t, err)
+		require.Empty(t, versions)
+	})
+
+	t.Run("errors without an interval or range", func(t *testing.T) {
+		_, err := check(atc.Source{}, nil)
+		require.EqualError(t, err, "must configure either interval or start and stop")
+	})
+
+	t.Run("does not support unknown source keys", func(t *testing.T) {
+		_, err := check(atc.Source{"interval": "1h", "some": "thing"}, nil)
+		require.ErrorIs(t, err, resource.ErrUnsupportedSource)
+	})
+}
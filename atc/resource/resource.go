@@ -44,6 +44,29 @@ func (resource Resource) Check(ctx context.Context, container runtime.Container,
 	return versions, processResult, nil
 }
 
+// CheckIsolated runs the check as a process of its own in a container shared
+// with the checks of other resource configs. The process is given its own
+// working and temporary directory, so that whatever the check caches doesn't
+// leak into the other checks, and its own environment.
+func (resource Resource) CheckIsolated(ctx context.Context, container runtime.Container, dir string, env []string, stderr io.Writer) ([]atc.Version, runtime.ProcessResult, error) {
+	processEnv := make([]string, 0, len(env)+1)
+	processEnv = append(processEnv, env...)
+	processEnv = append(processEnv, "TMPDIR="+dir)
+
+	spec := runtime.ProcessSpec{
+		Path: "/opt/resource/check",
+		Dir:  dir,
+		Env:  processEnv,
+	}
+
+	var versions []atc.Version
+	processResult, err := resource.run(ctx, container, spec, stderr, false, &versions)
+	if err != nil {
+		return nil, runtime.ProcessResult{}, err
+	}
+	return versions, processResult, nil
+}
+
 func (resource Resource) Get(ctx context.Context, container runtime.Container, stderr io.Writer) (VersionResult, runtime.ProcessResult, error) {
 	var versionResult VersionResult
 
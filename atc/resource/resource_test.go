@@ -70,6 +70,33 @@ func TestResourceCheck(t *testing.T) {
 	})
 }
 
+func TestResourceCheckIsolated(t *testing.T) {
+	resource := Resource{
+		Source:  atc.Source{"some": "source"},
+		Version: atc.Version{"some": "version"},
+	}
+	ctx := context.Background()
+
+	expectedVersions := []atc.Version{
+		{"version": "v1"},
+	}
+	container := runtimetest.NewContainer().
+		WithProcess(
+			runtime.ProcessSpec{
+				Path: "/opt/resource/check",
+				Dir:  "/tmp/build/check-1",
+				Env:  []string{"SOME=env", "TMPDIR=/tmp/build/check-1"},
+			},
+			runtimetest.ProcessStub{
+				Output: expectedVersions,
+			},
+		)
+	versions, processResult, err := resource.CheckIsolated(ctx, container, "/tmp/build/check-1", []string{"SOME=env"}, new(bytes.Buffer))
+	require.NoError(t, err)
+	require.Equal(t, expectedVersions, versions)
+	require.Equal(t, 0, processResult.ExitStatus)
+}
+
 func TestResourceGet(t *testing.T) {
 	resource := Resource{
 		Source:  atc.Source{"some": "source"},
@@ -11,3 +11,11 @@ type CheckRequestBody struct {
 	From    Version `json:"from"`
 	Shallow bool    `json:"shallow"`
 }
+
+// ResetCheckFromRequestBody resets where the next check of a resource starts
+// from, for when the history upstream has been rewritten. The check starts
+// from Version if given, and otherwise from scratch, as if the resource had
+// never been checked.
+type ResetCheckFromRequestBody struct {
+	Version Version `json:"version,omitempty"`
+}
@@ -9,8 +9,45 @@ type SaveConfigResponse struct {
 	Warnings []ConfigWarning `json:"warnings,omitempty"`
 }
 
+// ConfigSchemaVersion is the version of the pipeline config schema which
+// configs are validated against. It is bumped whenever a change to the schema
+// would cause a previously valid config to be reported differently.
+const ConfigSchemaVersion = 2
+
+type ValidateConfigResponse struct {
+	SchemaVersion int             `json:"schema_version"`
+	Valid         bool            `json:"valid"`
+	Errors        []ConfigError   `json:"errors,omitempty"`
+	Warnings      []ConfigWarning `json:"warnings,omitempty"`
+}
+
+// VarReport describes how a ((var)) referenced by a config is resolved,
+// without revealing its value.
+type VarReport struct {
+	Name   string `json:"name"`
+	Source string `json:"source,omitempty"`
+
+	// Paths are the credential manager paths which are searched for the var,
+	// in order.
+	Paths []string `json:"paths,omitempty"`
+
+	// ResolvedPath is the path the var was found at, if any.
+	ResolvedPath string `json:"resolved_path,omitempty"`
+	Resolved     bool   `json:"resolved"`
+
+	// Default is true when the var is not found but has a default declared
+	// in the config.
+	Default bool   `json:"default,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
 type ConfigResponse struct {
 	Config Config `json:"config"`
+
+	// Warnings are found by validating the config as it is now, so that
+	// problems such as jobs that depend on jobs outside of their group are
+	// reported for pipelines that were set before they were checked for.
+	Warnings []ConfigWarning `json:"warnings,omitempty"`
 }
 
 type ClearResourceCacheResponse struct {
@@ -20,3 +57,7 @@ type ClearResourceCacheResponse struct {
 type ClearVersionsResponse struct {
 	VersionsRemoved int64 `json:"versions_removed"`
 }
+
+type PushVersionsResponse struct {
+	VersionsPushed int `json:"versions_pushed"`
+}
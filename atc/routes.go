@@ -3,16 +3,28 @@ package atc
 import "github.com/tedsuo/rata"
 
 const (
-	SaveConfig = "SaveConfig"
-	GetConfig  = "GetConfig"
+	SaveConfig       = "SaveConfig"
+	GetConfig        = "GetConfig"
+	ValidateConfig   = "ValidateConfig"
+	ReportConfigVars = "ReportConfigVars"
+
+	StageConfig            = "StageConfig"
+	PromoteCandidateConfig = "PromoteCandidateConfig"
+	DiscardCandidateConfig = "DiscardCandidateConfig"
+
+	GetConfigSchema = "GetConfigSchema"
 
 	GetBuild            = "GetBuild"
 	GetBuildPlan        = "GetBuildPlan"
+	GetBuildSteps       = "GetBuildSteps"
+	GetBuildProvenance  = "GetBuildProvenance"
 	CreateBuild         = "CreateBuild"
+	RunTask             = "RunTask"
 	ListBuilds          = "ListBuilds"
 	BuildEvents         = "BuildEvents"
 	BuildResources      = "BuildResources"
 	AbortBuild          = "AbortBuild"
+	AbortBuildStep      = "AbortBuildStep"
 	GetBuildPreparation = "GetBuildPreparation"
 	SetBuildComment     = "SetBuildComment"
 
@@ -31,6 +43,13 @@ const (
 	JobBadge       = "JobBadge"
 	MainJobBadge   = "MainJobBadge"
 
+	ListJobStatistics = "ListJobStatistics"
+	GetJobStatistics  = "GetJobStatistics"
+	ListJobFlakiness  = "ListJobFlakiness"
+	ListFlakyJobs     = "ListFlakyJobs"
+
+	ListTeamBuildQueue = "ListTeamBuildQueue"
+
 	ClearTaskCache = "ClearTaskCache"
 
 	ListAllResources          = "ListAllResources"
@@ -46,6 +65,7 @@ const (
 
 	ListResourceVersions           = "ListResourceVersions"
 	ClearResourceVersions          = "ClearResourceVersions"
+	PushResourceVersions           = "PushResourceVersions"
 	ClearResourceTypeVersions      = "ClearResourceTypeVersions"
 	GetResourceVersion             = "GetResourceVersion"
 	EnableResourceVersion          = "EnableResourceVersion"
@@ -53,11 +73,15 @@ const (
 	PinResourceVersion             = "PinResourceVersion"
 	UnpinResource                  = "UnpinResource"
 	SetPinCommentOnResource        = "SetPinCommentOnResource"
+	ResetResourceCheckFrom         = "ResetResourceCheckFrom"
 	ListBuildsWithVersionAsInput   = "ListBuildsWithVersionAsInput"
 	ListBuildsWithVersionAsOutput  = "ListBuildsWithVersionAsOutput"
 	ClearResourceCache             = "ClearResourceCache"
 	GetDownstreamResourceCausality = "GetDownstreamResourceCausality"
 	GetUpstreamResourceCausality   = "GetUpstreamResourceCausality"
+	GetResourceVersionImpact       = "GetResourceVersionImpact"
+	FetchResourceVersionMetadata   = "FetchResourceVersionMetadata"
+	BackfillResourceMetadata       = "BackfillResourceMetadata"
 
 	GetCC = "GetCC"
 
@@ -72,10 +96,17 @@ const (
 	UnpausePipeline           = "UnpausePipeline"
 	ExposePipeline            = "ExposePipeline"
 	HidePipeline              = "HidePipeline"
+	PausePipelines            = "PausePipelines"
+	UnpausePipelines          = "UnpausePipelines"
+	ExposePipelines           = "ExposePipelines"
+	HidePipelines             = "HidePipelines"
+	CheckPipelines            = "CheckPipelines"
 	RenamePipeline            = "RenamePipeline"
+	MovePipeline              = "MovePipeline"
 	ListPipelineBuilds        = "ListPipelineBuilds"
 	CreatePipelineBuild       = "CreatePipelineBuild"
 	PipelineBadge             = "PipelineBadge"
+	GetPipelineGraph          = "GetPipelineGraph"
 
 	RegisterWorker  = "RegisterWorker"
 	LandWorker      = "LandWorker"
@@ -84,23 +115,32 @@ const (
 	HeartbeatWorker = "HeartbeatWorker"
 	ListWorkers     = "ListWorkers"
 	DeleteWorker    = "DeleteWorker"
+	ListWorkerKeys  = "ListWorkerKeys"
 
 	SetLogLevel = "SetLogLevel"
 	GetLogLevel = "GetLogLevel"
 
+	GetRequestLog = "GetRequestLog"
+	SetRequestLog = "SetRequestLog"
+
 	DownloadCLI  = "DownloadCLI"
 	GetInfo      = "GetInfo"
 	GetInfoCreds = "GetInfoCreds"
+	GetOpenAPI   = "GetOpenAPI"
+	GetHealth    = "GetHealth"
 
 	ListContainers           = "ListContainers"
 	GetContainer             = "GetContainer"
 	HijackContainer          = "HijackContainer"
+	ListContainerProcesses   = "ListContainerProcesses"
+	ListBuildContainers      = "ListBuildContainers"
 	ListDestroyingContainers = "ListDestroyingContainers"
 	ReportWorkerContainers   = "ReportWorkerContainers"
 
 	ListVolumes           = "ListVolumes"
 	ListDestroyingVolumes = "ListDestroyingVolumes"
 	ReportWorkerVolumes   = "ReportWorkerVolumes"
+	ListBuildVolumes      = "ListBuildVolumes"
 
 	ListTeams      = "ListTeams"
 	GetTeam        = "GetTeam"
@@ -108,6 +148,74 @@ const (
 	RenameTeam     = "RenameTeam"
 	DestroyTeam    = "DestroyTeam"
 	ListTeamBuilds = "ListTeamBuilds"
+	ListTeamUsers  = "ListTeamUsers"
+
+	GetTeamWorkerKeys = "GetTeamWorkerKeys"
+	SetTeamWorkerKeys = "SetTeamWorkerKeys"
+
+	GetTeamInterceptSettings  = "GetTeamInterceptSettings"
+	SetTeamInterceptSettings  = "SetTeamInterceptSettings"
+	GetTeamPrivilegedSettings = "GetTeamPrivilegedSettings"
+	SetTeamPrivilegedSettings = "SetTeamPrivilegedSettings"
+	GetTeamProxySettings      = "GetTeamProxySettings"
+	SetTeamProxySettings      = "SetTeamProxySettings"
+
+	ListNotifiers   = "ListNotifiers"
+	SetNotifier     = "SetNotifier"
+	DestroyNotifier = "DestroyNotifier"
+
+	ListTeamWebhooks             = "ListTeamWebhooks"
+	SetTeamWebhook               = "SetTeamWebhook"
+	DestroyTeamWebhook           = "DestroyTeamWebhook"
+	ListTeamWebhookDeliveries    = "ListTeamWebhookDeliveries"
+	ListClusterWebhooks          = "ListClusterWebhooks"
+	SetClusterWebhook            = "SetClusterWebhook"
+	DestroyClusterWebhook        = "DestroyClusterWebhook"
+	ListClusterWebhookDeliveries = "ListClusterWebhookDeliveries"
+
+	ListTeamLogDrains   = "ListTeamLogDrains"
+	SetTeamLogDrain     = "SetTeamLogDrain"
+	DestroyTeamLogDrain = "DestroyTeamLogDrain"
+
+	ListTeamFreezeWindows      = "ListTeamFreezeWindows"
+	CreateTeamFreezeWindow     = "CreateTeamFreezeWindow"
+	DestroyTeamFreezeWindow    = "DestroyTeamFreezeWindow"
+	ListClusterFreezeWindows   = "ListClusterFreezeWindows"
+	CreateClusterFreezeWindow  = "CreateClusterFreezeWindow"
+	DestroyClusterFreezeWindow = "DestroyClusterFreezeWindow"
+
+	GetTeamInjectedEnv    = "GetTeamInjectedEnv"
+	SetTeamInjectedEnv    = "SetTeamInjectedEnv"
+	GetClusterInjectedEnv = "GetClusterInjectedEnv"
+	SetClusterInjectedEnv = "SetClusterInjectedEnv"
+
+	GetTeamCABundle    = "GetTeamCABundle"
+	SetTeamCABundle    = "SetTeamCABundle"
+	GetClusterCABundle = "GetClusterCABundle"
+	SetClusterCABundle = "SetClusterCABundle"
+
+	ListTeamRequests   = "ListTeamRequests"
+	CreateTeamRequest  = "CreateTeamRequest"
+	ApproveTeamRequest = "ApproveTeamRequest"
+	RejectTeamRequest  = "RejectTeamRequest"
+
+	GetSchedulerProfile = "GetSchedulerProfile"
+
+	GetDBSchema      = "GetDBSchema"
+	GetDBMaintenance = "GetDBMaintenance"
+	GetDBGrowth      = "GetDBGrowth"
+
+	GetAutoscalingSignal = "GetAutoscalingSignal"
+
+	GetDeprecationReport = "GetDeprecationReport"
+
+	GetClusterOverview = "GetClusterOverview"
+
+	ListComponents         = "ListComponents"
+	SetComponentInterval   = "SetComponentInterval"
+	ResetComponentInterval = "ResetComponentInterval"
+	PauseComponent         = "PauseComponent"
+	UnpauseComponent       = "UnpauseComponent"
 
 	CreateArtifact     = "CreateArtifact"
 	GetArtifact        = "GetArtifact"
@@ -116,6 +224,20 @@ const (
 	GetUser              = "GetUser"
 	ListActiveUsersSince = "ListActiveUsersSince"
 
+	GetDashboardPreferences = "GetDashboardPreferences"
+	OrderDashboardPipelines = "OrderDashboardPipelines"
+	FavoritePipeline        = "FavoritePipeline"
+	UnfavoritePipeline      = "UnfavoritePipeline"
+
+	GetUserPreferences   = "GetUserPreferences"
+	SetUserPreference    = "SetUserPreference"
+	DeleteUserPreference = "DeleteUserPreference"
+
+	ListSessions       = "ListSessions"
+	RevokeSession      = "RevokeSession"
+	ListUserSessions   = "ListUserSessions"
+	RevokeUserSessions = "RevokeUserSessions"
+
 	SetWall   = "SetWall"
 	GetWall   = "GetWall"
 	ClearWall = "ClearWall"
@@ -129,17 +251,31 @@ const (
 var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/config", Method: "PUT", Name: SaveConfig},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/config", Method: "GET", Name: GetConfig},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/config/validate", Method: "POST", Name: ValidateConfig},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/config/vars", Method: "POST", Name: ReportConfigVars},
+
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/config/candidate", Method: "PUT", Name: StageConfig},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/config/candidate/promote", Method: "POST", Name: PromoteCandidateConfig},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/config/candidate", Method: "DELETE", Name: DiscardCandidateConfig},
+
+	{Path: "/api/v1/schemas/:schema_name", Method: "GET", Name: GetConfigSchema},
 
 	{Path: "/api/v1/teams/:team_name/builds", Method: "POST", Name: CreateBuild},
+	{Path: "/api/v1/teams/:team_name/tasks", Method: "POST", Name: RunTask},
 
 	{Path: "/api/v1/builds", Method: "GET", Name: ListBuilds},
 	{Path: "/api/v1/builds/:build_id", Method: "GET", Name: GetBuild},
 	{Path: "/api/v1/builds/:build_id/plan", Method: "GET", Name: GetBuildPlan},
+	{Path: "/api/v1/builds/:build_id/steps", Method: "GET", Name: GetBuildSteps},
+	{Path: "/api/v1/builds/:build_id/provenance", Method: "GET", Name: GetBuildProvenance},
 	{Path: "/api/v1/builds/:build_id/events", Method: "GET", Name: BuildEvents},
 	{Path: "/api/v1/builds/:build_id/resources", Method: "GET", Name: BuildResources},
 	{Path: "/api/v1/builds/:build_id/abort", Method: "PUT", Name: AbortBuild},
+	{Path: "/api/v1/builds/:build_id/steps/:plan_id/abort", Method: "PUT", Name: AbortBuildStep},
 	{Path: "/api/v1/builds/:build_id/preparation", Method: "GET", Name: GetBuildPreparation},
 	{Path: "/api/v1/builds/:build_id/artifacts", Method: "GET", Name: ListBuildArtifacts},
+	{Path: "/api/v1/builds/:build_id/containers", Method: "GET", Name: ListBuildContainers},
+	{Path: "/api/v1/builds/:build_id/volumes", Method: "GET", Name: ListBuildVolumes},
 	{Path: "/api/v1/builds/:build_id/comment", Method: "PUT", Name: SetBuildComment},
 
 	{Path: "/api/v1/jobs", Method: "GET", Name: ListAllJobs},
@@ -155,6 +291,11 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/schedule", Method: "PUT", Name: ScheduleJob},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/badge", Method: "GET", Name: JobBadge},
 	{Path: "/api/v1/pipelines/:pipeline_name/jobs/:job_name/badge", Method: "GET", Name: MainJobBadge},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/statistics", Method: "GET", Name: ListJobStatistics},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/statistics", Method: "GET", Name: GetJobStatistics},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/flakiness", Method: "GET", Name: ListJobFlakiness},
+	{Path: "/api/v1/teams/:team_name/flaky_jobs", Method: "GET", Name: ListFlakyJobs},
+	{Path: "/api/v1/teams/:team_name/build_queue", Method: "GET", Name: ListTeamBuildQueue},
 
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/tasks/:step_name/cache", Method: "DELETE", Name: ClearTaskCache},
 
@@ -163,6 +304,11 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name", Method: "GET", Name: GetPipeline},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name", Method: "DELETE", Name: DeletePipeline},
 	{Path: "/api/v1/teams/:team_name/pipelines/ordering", Method: "PUT", Name: OrderPipelines},
+	{Path: "/api/v1/teams/:team_name/pipelines/pause", Method: "PUT", Name: PausePipelines},
+	{Path: "/api/v1/teams/:team_name/pipelines/unpause", Method: "PUT", Name: UnpausePipelines},
+	{Path: "/api/v1/teams/:team_name/pipelines/expose", Method: "PUT", Name: ExposePipelines},
+	{Path: "/api/v1/teams/:team_name/pipelines/hide", Method: "PUT", Name: HidePipelines},
+	{Path: "/api/v1/teams/:team_name/pipelines/check", Method: "POST", Name: CheckPipelines},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/ordering", Method: "PUT", Name: OrderPipelinesWithinGroup},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/pause", Method: "PUT", Name: PausePipeline},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/archive", Method: "PUT", Name: ArchivePipeline},
@@ -171,9 +317,11 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/hide", Method: "PUT", Name: HidePipeline},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/versions-db", Method: "GET", Name: GetVersionsDB},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/rename", Method: "PUT", Name: RenamePipeline},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/move", Method: "PUT", Name: MovePipeline},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/builds", Method: "GET", Name: ListPipelineBuilds},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/builds", Method: "POST", Name: CreatePipelineBuild},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/badge", Method: "GET", Name: PipelineBadge},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/graph", Method: "GET", Name: GetPipelineGraph},
 
 	{Path: "/api/v1/resources", Method: "GET", Name: ListAllResources},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources", Method: "GET", Name: ListResources},
@@ -189,6 +337,7 @@ var Routes = rata.Routes([]rata.Route{
 
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions", Method: "GET", Name: ListResourceVersions},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions", Method: "DELETE", Name: ClearResourceVersions},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions", Method: "POST", Name: PushResourceVersions},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resource-types/:resource_type_name/versions", Method: "DELETE", Name: ClearResourceTypeVersions},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_config_version_id", Method: "GET", Name: GetResourceVersion},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_config_version_id/enable", Method: "PUT", Name: EnableResourceVersion},
@@ -196,10 +345,14 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_config_version_id/pin", Method: "PUT", Name: PinResourceVersion},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/unpin", Method: "PUT", Name: UnpinResource},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/pin_comment", Method: "PUT", Name: SetPinCommentOnResource},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/check_from", Method: "PUT", Name: ResetResourceCheckFrom},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_config_version_id/input_to", Method: "GET", Name: ListBuildsWithVersionAsInput},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_config_version_id/output_of", Method: "GET", Name: ListBuildsWithVersionAsOutput},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_config_version_id/downstream", Method: "GET", Name: GetDownstreamResourceCausality},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_config_version_id/upstream", Method: "GET", Name: GetUpstreamResourceCausality},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_config_version_id/impact", Method: "GET", Name: GetResourceVersionImpact},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_config_version_id/metadata", Method: "POST", Name: FetchResourceVersionMetadata},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/metadata", Method: "POST", Name: BackfillResourceMetadata},
 	// {Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/causality", Method: "GET", Name: GetResourceCausality},
 
 	{Path: "/api/v1/teams/:team_name/cc.xml", Method: "GET", Name: GetCC},
@@ -211,22 +364,43 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/workers/:worker_name/prune", Method: "PUT", Name: PruneWorker},
 	{Path: "/api/v1/workers/:worker_name/heartbeat", Method: "PUT", Name: HeartbeatWorker},
 	{Path: "/api/v1/workers/:worker_name", Method: "DELETE", Name: DeleteWorker},
+	{Path: "/api/v1/worker_keys", Method: "GET", Name: ListWorkerKeys},
 
 	{Path: "/api/v1/log-level", Method: "GET", Name: GetLogLevel},
 	{Path: "/api/v1/log-level", Method: "PUT", Name: SetLogLevel},
 
+	{Path: "/api/v1/request-log", Method: "GET", Name: GetRequestLog},
+	{Path: "/api/v1/request-log", Method: "PUT", Name: SetRequestLog},
+
 	{Path: "/api/v1/cli", Method: "GET", Name: DownloadCLI},
 	{Path: "/api/v1/info", Method: "GET", Name: GetInfo},
 	{Path: "/api/v1/info/creds", Method: "GET", Name: GetInfoCreds},
+	{Path: "/api/v1/openapi.json", Method: "GET", Name: GetOpenAPI},
+	{Path: "/api/v1/health", Method: "GET", Name: GetHealth},
 
 	{Path: "/api/v1/user", Method: "GET", Name: GetUser},
 	{Path: "/api/v1/users", Method: "GET", Name: ListActiveUsersSince},
 
+	{Path: "/api/v1/user/dashboard", Method: "GET", Name: GetDashboardPreferences},
+	{Path: "/api/v1/user/dashboard/order", Method: "PUT", Name: OrderDashboardPipelines},
+	{Path: "/api/v1/user/dashboard/favorites/:pipeline_id", Method: "PUT", Name: FavoritePipeline},
+	{Path: "/api/v1/user/dashboard/favorites/:pipeline_id", Method: "DELETE", Name: UnfavoritePipeline},
+
+	{Path: "/api/v1/user/preferences", Method: "GET", Name: GetUserPreferences},
+	{Path: "/api/v1/user/preferences/:preference_key", Method: "PUT", Name: SetUserPreference},
+	{Path: "/api/v1/user/preferences/:preference_key", Method: "DELETE", Name: DeleteUserPreference},
+
+	{Path: "/api/v1/user/sessions", Method: "GET", Name: ListSessions},
+	{Path: "/api/v1/user/sessions/:session_id", Method: "DELETE", Name: RevokeSession},
+	{Path: "/api/v1/users/:user_id/sessions", Method: "GET", Name: ListUserSessions},
+	{Path: "/api/v1/users/:user_id/sessions", Method: "DELETE", Name: RevokeUserSessions},
+
 	{Path: "/api/v1/containers/destroying", Method: "GET", Name: ListDestroyingContainers},
 	{Path: "/api/v1/containers/report", Method: "PUT", Name: ReportWorkerContainers},
 	{Path: "/api/v1/teams/:team_name/containers", Method: "GET", Name: ListContainers},
 	{Path: "/api/v1/teams/:team_name/containers/:id", Method: "GET", Name: GetContainer},
 	{Path: "/api/v1/teams/:team_name/containers/:id/hijack", Method: "GET", Name: HijackContainer},
+	{Path: "/api/v1/teams/:team_name/containers/:id/processes", Method: "GET", Name: ListContainerProcesses},
 
 	{Path: "/api/v1/teams/:team_name/volumes", Method: "GET", Name: ListVolumes},
 	{Path: "/api/v1/volumes/destroying", Method: "GET", Name: ListDestroyingVolumes},
@@ -238,6 +412,72 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams/:team_name/rename", Method: "PUT", Name: RenameTeam},
 	{Path: "/api/v1/teams/:team_name", Method: "DELETE", Name: DestroyTeam},
 	{Path: "/api/v1/teams/:team_name/builds", Method: "GET", Name: ListTeamBuilds},
+	{Path: "/api/v1/teams/:team_name/users", Method: "GET", Name: ListTeamUsers},
+	{Path: "/api/v1/teams/:team_name/worker_keys", Method: "GET", Name: GetTeamWorkerKeys},
+	{Path: "/api/v1/teams/:team_name/worker_keys", Method: "PUT", Name: SetTeamWorkerKeys},
+	{Path: "/api/v1/teams/:team_name/intercept_settings", Method: "GET", Name: GetTeamInterceptSettings},
+	{Path: "/api/v1/teams/:team_name/intercept_settings", Method: "PUT", Name: SetTeamInterceptSettings},
+	{Path: "/api/v1/teams/:team_name/privileged_settings", Method: "GET", Name: GetTeamPrivilegedSettings},
+	{Path: "/api/v1/teams/:team_name/privileged_settings", Method: "PUT", Name: SetTeamPrivilegedSettings},
+	{Path: "/api/v1/teams/:team_name/proxy_settings", Method: "GET", Name: GetTeamProxySettings},
+	{Path: "/api/v1/teams/:team_name/proxy_settings", Method: "PUT", Name: SetTeamProxySettings},
+
+	{Path: "/api/v1/teams/:team_name/notifiers", Method: "GET", Name: ListNotifiers},
+	{Path: "/api/v1/teams/:team_name/notifiers/:notifier_name", Method: "PUT", Name: SetNotifier},
+	{Path: "/api/v1/teams/:team_name/notifiers/:notifier_name", Method: "DELETE", Name: DestroyNotifier},
+
+	{Path: "/api/v1/teams/:team_name/webhooks", Method: "GET", Name: ListTeamWebhooks},
+	{Path: "/api/v1/teams/:team_name/webhooks/:webhook_name", Method: "PUT", Name: SetTeamWebhook},
+	{Path: "/api/v1/teams/:team_name/webhooks/:webhook_name", Method: "DELETE", Name: DestroyTeamWebhook},
+	{Path: "/api/v1/teams/:team_name/webhooks/:webhook_name/deliveries", Method: "GET", Name: ListTeamWebhookDeliveries},
+	{Path: "/api/v1/webhooks", Method: "GET", Name: ListClusterWebhooks},
+	{Path: "/api/v1/webhooks/:webhook_name", Method: "PUT", Name: SetClusterWebhook},
+	{Path: "/api/v1/webhooks/:webhook_name", Method: "DELETE", Name: DestroyClusterWebhook},
+	{Path: "/api/v1/webhooks/:webhook_name/deliveries", Method: "GET", Name: ListClusterWebhookDeliveries},
+
+	{Path: "/api/v1/teams/:team_name/log_drains", Method: "GET", Name: ListTeamLogDrains},
+	{Path: "/api/v1/teams/:team_name/log_drains/:log_drain_name", Method: "PUT", Name: SetTeamLogDrain},
+	{Path: "/api/v1/teams/:team_name/log_drains/:log_drain_name", Method: "DELETE", Name: DestroyTeamLogDrain},
+
+	{Path: "/api/v1/teams/:team_name/freeze_windows", Method: "GET", Name: ListTeamFreezeWindows},
+	{Path: "/api/v1/teams/:team_name/freeze_windows", Method: "POST", Name: CreateTeamFreezeWindow},
+	{Path: "/api/v1/teams/:team_name/freeze_windows/:freeze_window_id", Method: "DELETE", Name: DestroyTeamFreezeWindow},
+	{Path: "/api/v1/freeze_windows", Method: "GET", Name: ListClusterFreezeWindows},
+	{Path: "/api/v1/freeze_windows", Method: "POST", Name: CreateClusterFreezeWindow},
+	{Path: "/api/v1/freeze_windows/:freeze_window_id", Method: "DELETE", Name: DestroyClusterFreezeWindow},
+
+	{Path: "/api/v1/teams/:team_name/injected_env", Method: "GET", Name: GetTeamInjectedEnv},
+	{Path: "/api/v1/teams/:team_name/injected_env", Method: "PUT", Name: SetTeamInjectedEnv},
+	{Path: "/api/v1/injected_env", Method: "GET", Name: GetClusterInjectedEnv},
+	{Path: "/api/v1/injected_env", Method: "PUT", Name: SetClusterInjectedEnv},
+
+	{Path: "/api/v1/teams/:team_name/ca_bundle", Method: "GET", Name: GetTeamCABundle},
+	{Path: "/api/v1/teams/:team_name/ca_bundle", Method: "PUT", Name: SetTeamCABundle},
+	{Path: "/api/v1/ca_bundle", Method: "GET", Name: GetClusterCABundle},
+	{Path: "/api/v1/ca_bundle", Method: "PUT", Name: SetClusterCABundle},
+
+	{Path: "/api/v1/team_requests", Method: "GET", Name: ListTeamRequests},
+	{Path: "/api/v1/team_requests", Method: "POST", Name: CreateTeamRequest},
+	{Path: "/api/v1/team_requests/:team_request_id/approve", Method: "PUT", Name: ApproveTeamRequest},
+	{Path: "/api/v1/team_requests/:team_request_id/reject", Method: "PUT", Name: RejectTeamRequest},
+
+	{Path: "/api/v1/scheduler/profile", Method: "GET", Name: GetSchedulerProfile},
+
+	{Path: "/api/v1/db/schema", Method: "GET", Name: GetDBSchema},
+	{Path: "/api/v1/db/maintenance", Method: "GET", Name: GetDBMaintenance},
+	{Path: "/api/v1/db/growth", Method: "GET", Name: GetDBGrowth},
+
+	{Path: "/api/v1/autoscaling", Method: "GET", Name: GetAutoscalingSignal},
+
+	{Path: "/api/v1/deprecations", Method: "GET", Name: GetDeprecationReport},
+
+	{Path: "/api/v1/cluster/overview", Method: "GET", Name: GetClusterOverview},
+
+	{Path: "/api/v1/components", Method: "GET", Name: ListComponents},
+	{Path: "/api/v1/components/:component_name/interval", Method: "PUT", Name: SetComponentInterval},
+	{Path: "/api/v1/components/:component_name/interval", Method: "DELETE", Name: ResetComponentInterval},
+	{Path: "/api/v1/components/:component_name/pause", Method: "PUT", Name: PauseComponent},
+	{Path: "/api/v1/components/:component_name/unpause", Method: "PUT", Name: UnpauseComponent},
 
 	{Path: "/api/v1/teams/:team_name/artifacts", Method: "POST", Name: CreateArtifact},
 	{Path: "/api/v1/teams/:team_name/artifacts/:artifact_id", Method: "GET", Name: GetArtifact},
@@ -0,0 +1,122 @@
+package atc
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// RunTaskRequest is a task to run as a one-off build of a team, the same way
+// fly execute does. Inputs are given by the IDs of artifacts uploaded to the
+// team beforehand. The outputs listed are kept as artifacts of the build, to
+// be downloaded once it finishes.
+type RunTaskRequest struct {
+	Config     TaskConfig       `json:"config"`
+	Inputs     map[string]int   `json:"inputs,omitempty"`
+	Outputs    []string         `json:"outputs,omitempty"`
+	Params     TaskEnv          `json:"params,omitempty"`
+	Privileged bool             `json:"privileged,omitempty"`
+	Tags       Tags             `json:"tags,omitempty"`
+	Timeout    string           `json:"timeout,omitempty"`
+	Limits     *ContainerLimits `json:"container_limits,omitempty"`
+}
+
+func (request RunTaskRequest) Validate() error {
+	if err := request.Config.Validate(); err != nil {
+		return err
+	}
+
+	inputs := map[string]bool{}
+	for _, input := range request.Config.Inputs {
+		inputs[input.Name] = true
+
+		if _, found := request.Inputs[input.Name]; !found && !input.Optional {
+			return fmt.Errorf("missing required input '%s'", input.Name)
+		}
+	}
+
+	var names []string
+	for name := range request.Inputs {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		if !inputs[name] {
+			return fmt.Errorf("unknown input '%s'", name)
+		}
+	}
+
+	outputs := map[string]bool{}
+	for _, output := range request.Config.Outputs {
+		outputs[output.Name] = true
+	}
+
+	for _, name := range request.Outputs {
+		if !outputs[name] {
+			return fmt.Errorf("unknown output '%s'", name)
+		}
+	}
+
+	if request.Timeout != "" {
+		if _, err := time.ParseDuration(request.Timeout); err != nil {
+			return fmt.Errorf("invalid timeout: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Plan returns the plan of the build running the task: the inputs are
+// fetched, the task is run, and then its outputs are kept whether or not it
+// succeeded.
+func (request RunTaskRequest) Plan(factory PlanFactory) Plan {
+	var names []string
+	for name := range request.Inputs {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	inputs := InParallelPlan{}
+	for _, name := range names {
+		inputs.Steps = append(inputs.Steps, factory.NewPlan(ArtifactInputPlan{
+			ArtifactID: request.Inputs[name],
+			Name:       name,
+		}))
+	}
+
+	config := request.Config
+
+	task := factory.NewPlan(TaskPlan{
+		Name:       "one-off",
+		Privileged: request.Privileged,
+		Tags:       request.Tags,
+		Config:     &config,
+		Params:     request.Params,
+		Timeout:    request.Timeout,
+		Limits:     request.Limits,
+	})
+
+	plan := factory.NewPlan(DoPlan{
+		factory.NewPlan(inputs),
+		task,
+	})
+
+	if len(request.Outputs) == 0 {
+		return plan
+	}
+
+	outputs := InParallelPlan{}
+	for _, name := range request.Outputs {
+		outputs.Steps = append(outputs.Steps, factory.NewPlan(ArtifactOutputPlan{
+			Name: name,
+		}))
+	}
+
+	return factory.NewPlan(EnsurePlan{
+		Step: plan,
+		Next: factory.NewPlan(outputs),
+	})
+}
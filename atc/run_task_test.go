@@ -0,0 +1,105 @@
+package atc_test
+
+import (
+	. "github.com/concourse/concourse/atc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RunTaskRequest", func() {
+	var request RunTaskRequest
+
+	BeforeEach(func() {
+		request = RunTaskRequest{
+			Config: TaskConfig{
+				Platform: "linux",
+				Run:      TaskRunConfig{Path: "make"},
+				Inputs: []TaskInputConfig{
+					{Name: "source"},
+					{Name: "cache", Optional: true},
+				},
+				Outputs: []TaskOutputConfig{
+					{Name: "binary"},
+				},
+			},
+			Inputs: map[string]int{"source": 12},
+		}
+	})
+
+	Describe("Validate", func() {
+		It("accepts a valid request", func() {
+			Expect(request.Validate()).To(Succeed())
+		})
+
+		It("rejects an invalid task config", func() {
+			request.Config.Platform = ""
+			Expect(request.Validate()).ToNot(Succeed())
+		})
+
+		It("rejects a missing required input", func() {
+			request.Inputs = nil
+			Expect(request.Validate()).To(MatchError("missing required input 'source'"))
+		})
+
+		It("rejects unknown inputs", func() {
+			request.Inputs["other"] = 13
+			Expect(request.Validate()).To(MatchError("unknown input 'other'"))
+		})
+
+		It("rejects unknown outputs", func() {
+			request.Outputs = []string{"other"}
+			Expect(request.Validate()).To(MatchError("unknown output 'other'"))
+		})
+
+		It("rejects an invalid timeout", func() {
+			request.Timeout = "soon"
+			Expect(request.Validate()).To(MatchError(ContainSubstring("invalid timeout")))
+		})
+	})
+
+	Describe("Plan", func() {
+		var factory PlanFactory
+
+		BeforeEach(func() {
+			factory = NewPlanFactory(0)
+		})
+
+		It("fetches the inputs and runs the task", func() {
+			request.Privileged = true
+			request.Params = TaskEnv{"SOME": "param"}
+
+			plan := request.Plan(factory)
+			Expect(plan.Do).ToNot(BeNil())
+
+			steps := *plan.Do
+			Expect(steps).To(HaveLen(2))
+			Expect(steps[0].InParallel.Steps).To(HaveLen(1))
+			Expect(*steps[0].InParallel.Steps[0].ArtifactInput).To(Equal(ArtifactInputPlan{
+				ArtifactID: 12,
+				Name:       "source",
+			}))
+
+			Expect(steps[1].Task.Name).To(Equal("one-off"))
+			Expect(steps[1].Task.Privileged).To(BeTrue())
+			Expect(steps[1].Task.Params).To(Equal(TaskEnv{"SOME": "param"}))
+			Expect(*steps[1].Task.Config).To(Equal(request.Config))
+		})
+
+		Context("when outputs are requested", func() {
+			BeforeEach(func() {
+				request.Outputs = []string{"binary"}
+			})
+
+			It("keeps them after the task, even if it fails", func() {
+				plan := request.Plan(factory)
+				Expect(plan.Ensure).ToNot(BeNil())
+				Expect(plan.Ensure.Step.Do).ToNot(BeNil())
+				Expect(plan.Ensure.Next.InParallel.Steps).To(HaveLen(1))
+				Expect(*plan.Ensure.Next.InParallel.Steps[0].ArtifactOutput).To(Equal(ArtifactOutputPlan{
+					Name: "binary",
+				}))
+			})
+		})
+	})
+})
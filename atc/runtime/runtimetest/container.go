@@ -91,6 +91,14 @@ func (c *Container) Attach(ctx context.Context, id string, io runtime.ProcessIO)
 	return nil, fmt.Errorf("must setup a ProcessStub for process %q", id)
 }
 
+func (c *Container) ProcessIDs(_ context.Context) ([]string, error) {
+	var ids []string
+	for _, p := range c.RunningProcesses() {
+		ids = append(ids, p.Spec.ID)
+	}
+	return ids, nil
+}
+
 func (c *Container) Properties() (map[string]string, error) {
 	return c.Props, nil
 }
@@ -78,10 +78,22 @@ func (v *Volume) InitializeTaskCache(_ context.Context, _ int, _, _ string, _ bo
 	return nil
 }
 
+func (v Volume) Size(_ context.Context) (int64, error) {
+	return v.Content.Size(), nil
+}
+
 func (v Volume) DBVolume() db.CreatedVolume {
 	return v.DBVolume_
 }
 
+func (vc VolumeContent) Size() int64 {
+	var size int64
+	for _, file := range vc {
+		size += int64(len(file.Data))
+	}
+	return size
+}
+
 func (vc VolumeContent) StreamIn(ctx context.Context, path string, encoding baggageclaim.Encoding, tarStream io.Reader) error {
 	if encoding != baggageclaim.GzipEncoding {
 		return errors.New("only gzip is supported for runtimetest.VolumeContent")
@@ -0,0 +1,149 @@
+package runtime
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"github.com/concourse/concourse/atc"
+)
+
+// StartLatencyRecorder measures the phases of a build up until its first
+// task process starts, so that a build which is slow to start can be pinned
+// on a phase.
+type StartLatencyRecorder struct {
+	clock   clock.Clock
+	started func(map[atc.StartLatencyPhase]time.Duration)
+
+	lock        sync.Mutex
+	phases      map[atc.StartLatencyPhase]time.Duration
+	taskStarted bool
+}
+
+// NewStartLatencyRecorder returns a recorder which calls started with how
+// long each phase took once the build's first task process starts.
+func NewStartLatencyRecorder(clock clock.Clock, started func(map[atc.StartLatencyPhase]time.Duration)) *StartLatencyRecorder {
+	return &StartLatencyRecorder{
+		clock:   clock,
+		started: started,
+		phases:  map[atc.StartLatencyPhase]time.Duration{},
+	}
+}
+
+type startLatencyRecorderKey struct{}
+type startLatencyPhaseKey struct{}
+
+// WithStartLatencyRecorder makes the steps and workers record their phases
+// through the recorder.
+func WithStartLatencyRecorder(ctx context.Context, recorder *StartLatencyRecorder) context.Context {
+	return context.WithValue(ctx, startLatencyRecorderKey{}, recorder)
+}
+
+type startLatencySpan struct {
+	phase atc.StartLatencyPhase
+	start time.Time
+
+	lock   sync.Mutex
+	nested time.Duration
+}
+
+func (span *startLatencySpan) addNested(duration time.Duration) {
+	span.lock.Lock()
+	defer span.lock.Unlock()
+
+	span.nested += duration
+}
+
+func (span *startLatencySpan) exclusive(elapsed time.Duration) time.Duration {
+	span.lock.Lock()
+	defer span.lock.Unlock()
+
+	if span.nested > elapsed {
+		return 0
+	}
+
+	return elapsed - span.nested
+}
+
+// StartPhase starts measuring a phase, returning the context to run the
+// phase with and a func to call once it is over. The time spent in phases
+// started with the returned context is left out of the phase, except for
+// fetching an image: everything done to fetch an image counts towards it.
+//
+// Nothing is measured once the build's first task process has started, or if
+// the context has no StartLatencyRecorder.
+func StartPhase(ctx context.Context, phase atc.StartLatencyPhase) (context.Context, func()) {
+	recorder, found := ctx.Value(startLatencyRecorderKey{}).(*StartLatencyRecorder)
+	if !found || recorder.isTaskStarted() {
+		return ctx, func() {}
+	}
+
+	parent, _ := ctx.Value(startLatencyPhaseKey{}).(*startLatencySpan)
+	if parent != nil && parent.phase == atc.StartLatencyImageFetch {
+		return ctx, func() {}
+	}
+
+	span := &startLatencySpan{
+		phase: phase,
+		start: recorder.clock.Now(),
+	}
+
+	return context.WithValue(ctx, startLatencyPhaseKey{}, span), func() {
+		elapsed := recorder.clock.Since(span.start)
+		if parent != nil {
+			parent.addNested(elapsed)
+		}
+
+		recorder.record(phase, span.exclusive(elapsed))
+	}
+}
+
+// RecordTaskStarted tells the StartLatencyRecorder of the context, if any,
+// that a task process has started. Only the first one counts.
+func RecordTaskStarted(ctx context.Context) {
+	recorder, found := ctx.Value(startLatencyRecorderKey{}).(*StartLatencyRecorder)
+	if !found {
+		return
+	}
+
+	recorder.recordTaskStarted()
+}
+
+func (recorder *StartLatencyRecorder) isTaskStarted() bool {
+	recorder.lock.Lock()
+	defer recorder.lock.Unlock()
+
+	return recorder.taskStarted
+}
+
+func (recorder *StartLatencyRecorder) record(phase atc.StartLatencyPhase, duration time.Duration) {
+	recorder.lock.Lock()
+	defer recorder.lock.Unlock()
+
+	if recorder.taskStarted {
+		return
+	}
+
+	recorder.phases[phase] += duration
+}
+
+func (recorder *StartLatencyRecorder) recordTaskStarted() {
+	recorder.lock.Lock()
+
+	if recorder.taskStarted {
+		recorder.lock.Unlock()
+		return
+	}
+
+	recorder.taskStarted = true
+
+	phases := map[atc.StartLatencyPhase]time.Duration{}
+	for phase, duration := range recorder.phases {
+		phases[phase] = duration
+	}
+
+	recorder.lock.Unlock()
+
+	recorder.started(phases)
+}
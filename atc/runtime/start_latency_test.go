@@ -0,0 +1,109 @@
+package runtime_test
+
+import (
+	"context"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/runtime"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("StartLatencyRecorder", func() {
+	var (
+		fakeClock *fakeclock.FakeClock
+		started   []map[atc.StartLatencyPhase]time.Duration
+		ctx       context.Context
+	)
+
+	BeforeEach(func() {
+		fakeClock = fakeclock.NewFakeClock(time.Unix(123, 456))
+		started = nil
+
+		recorder := runtime.NewStartLatencyRecorder(fakeClock, func(phases map[atc.StartLatencyPhase]time.Duration) {
+			started = append(started, phases)
+		})
+
+		ctx = runtime.WithStartLatencyRecorder(context.Background(), recorder)
+	})
+
+	It("adds up the time spent in each phase until a task starts", func() {
+		_, done := runtime.StartPhase(ctx, atc.StartLatencyInputFetch)
+		fakeClock.Increment(time.Second)
+		done()
+
+		_, done = runtime.StartPhase(ctx, atc.StartLatencyInputFetch)
+		fakeClock.Increment(2 * time.Second)
+		done()
+
+		runtime.RecordTaskStarted(ctx)
+
+		Expect(started).To(Equal([]map[atc.StartLatencyPhase]time.Duration{
+			{atc.StartLatencyInputFetch: 3 * time.Second},
+		}))
+	})
+
+	It("leaves nested phases out of the phase they run in", func() {
+		getCtx, fetched := runtime.StartPhase(ctx, atc.StartLatencyInputFetch)
+		fakeClock.Increment(time.Second)
+
+		createCtx, created := runtime.StartPhase(getCtx, atc.StartLatencyContainerCreation)
+		fakeClock.Increment(2 * time.Second)
+
+		_, streamed := runtime.StartPhase(createCtx, atc.StartLatencyVolumeStreaming)
+		fakeClock.Increment(4 * time.Second)
+		streamed()
+
+		created()
+		fetched()
+
+		runtime.RecordTaskStarted(ctx)
+
+		Expect(started).To(Equal([]map[atc.StartLatencyPhase]time.Duration{
+			{
+				atc.StartLatencyInputFetch:        time.Second,
+				atc.StartLatencyContainerCreation: 2 * time.Second,
+				atc.StartLatencyVolumeStreaming:   4 * time.Second,
+			},
+		}))
+	})
+
+	It("counts everything done to fetch an image towards the image fetch", func() {
+		imageCtx, fetched := runtime.StartPhase(ctx, atc.StartLatencyImageFetch)
+
+		_, done := runtime.StartPhase(imageCtx, atc.StartLatencyInputFetch)
+		fakeClock.Increment(time.Second)
+		done()
+
+		fetched()
+
+		runtime.RecordTaskStarted(ctx)
+
+		Expect(started).To(Equal([]map[atc.StartLatencyPhase]time.Duration{
+			{atc.StartLatencyImageFetch: time.Second},
+		}))
+	})
+
+	It("stops measuring once the first task has started", func() {
+		runtime.RecordTaskStarted(ctx)
+
+		_, done := runtime.StartPhase(ctx, atc.StartLatencyInputFetch)
+		fakeClock.Increment(time.Second)
+		done()
+
+		runtime.RecordTaskStarted(ctx)
+
+		Expect(started).To(Equal([]map[atc.StartLatencyPhase]time.Duration{{}}))
+	})
+
+	It("does nothing without a recorder", func() {
+		_, done := runtime.StartPhase(context.Background(), atc.StartLatencyInputFetch)
+		done()
+
+		runtime.RecordTaskStarted(context.Background())
+
+		Expect(started).To(BeEmpty())
+	})
+})
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/compression"
 	"github.com/concourse/concourse/atc/db"
 	"go.opentelemetry.io/otel/propagation"
@@ -351,6 +352,38 @@ type P2PVolume interface {
 	StreamP2POut(ctx context.Context, path string, destURL string, compression compression.Compression) error
 }
 
+// CapableVolume is an interface that may also be satisfied by Volume
+// implementations which know what the worker they are on supports. When
+// streaming contents from one Volume to another, only the features supported
+// by both workers will be used.
+type CapableVolume interface {
+	Volume
+
+	// WorkerCapabilities gives the capabilities of the worker the Volume is
+	// on.
+	WorkerCapabilities() atc.WorkerCapabilities
+}
+
+// SizedVolume is an interface that may also be satisfied by Volume
+// implementations which can report the size of their contents, for
+// debugging.
+type SizedVolume interface {
+	Volume
+
+	// Size gives the size of the files in the Volume, in bytes.
+	Size(ctx context.Context) (int64, error)
+}
+
+// InspectableContainer is an interface that may also be satisfied by
+// Container implementations which can list their running processes, for
+// debugging.
+type InspectableContainer interface {
+	Container
+
+	// ProcessIDs gives the IDs of the Processes running in the Container.
+	ProcessIDs(ctx context.Context) ([]string, error)
+}
+
 // VolumeMount defines a Volume mounted at a particular path in a Container.
 type VolumeMount struct {
 	// Volume is the mounted Volume.
@@ -26,8 +26,8 @@ func (r *individualResolver) InputConfigs() db.InputConfigs {
 	return db.InputConfigs{r.inputConfig}
 }
 
-// Handles two different configurations of a resource without passed
-// constraints: every and latest
+// Handles the different configurations of a resource without passed
+// constraints: every, sampled and latest
 func (r *individualResolver) Resolve(ctx context.Context) (map[string]*versionCandidate, db.ResolutionFailure, error) {
 	ctx, span := tracing.StartSpan(ctx, "individualResolver.Resolve", tracing.Attrs{
 		"input": r.inputConfig.Name,
@@ -54,6 +54,24 @@ func (r *individualResolver) Resolve(ctx context.Context) (map[string]*versionCa
 		span.AddEvent("found via every", trace.WithAttributes(
 			attribute.String("version", string(version)),
 		))
+	} else if r.inputConfig.Sampling != nil {
+		var found bool
+		var err error
+		version, hasNext, found, err = r.vdb.NextSampledVersion(ctx, r.inputConfig.JobID, r.inputConfig.ResourceID, *r.inputConfig.Sampling)
+		if err != nil {
+			tracing.End(span, err)
+			return nil, "", err
+		}
+
+		if !found {
+			span.AddEvent("next sampled version not found")
+			span.SetStatus(codes.Error, "next sampled version not found")
+			return nil, db.VersionNotFound, nil
+		}
+
+		span.AddEvent("found via sampling", trace.WithAttributes(
+			attribute.String("version", string(version)),
+		))
 	} else {
 		// there are no passed constraints, so just take the latest version
 		var err error
@@ -8,6 +8,7 @@ import (
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/metric"
+	"github.com/concourse/concourse/atc/worker"
 )
 
 //counterfeiter:generate . BuildStarter
@@ -24,6 +25,14 @@ type BuildPlanner interface {
 	Create(atc.StepConfig, db.SchedulerResources, atc.ResourceTypes, atc.Prototypes, []db.BuildInput, bool) (atc.Plan, error)
 }
 
+// WorkerCapacity tells whether a worker compatible with the spec has room to
+// run another step.
+//
+//counterfeiter:generate . WorkerCapacity
+type WorkerCapacity interface {
+	HasCapacity(logger lager.Logger, spec worker.Spec, runsTasks bool) (bool, error)
+}
+
 type Build interface {
 	db.Build
 
@@ -34,16 +43,19 @@ type Build interface {
 func NewBuildStarter(
 	planner BuildPlanner,
 	algorithm Algorithm,
+	workerCapacity WorkerCapacity,
 ) BuildStarter {
 	return &buildStarter{
-		planner:   planner,
-		algorithm: algorithm,
+		planner:        planner,
+		algorithm:      algorithm,
+		workerCapacity: workerCapacity,
 	}
 }
 
 type buildStarter struct {
-	planner   BuildPlanner
-	algorithm Algorithm
+	planner        BuildPlanner
+	algorithm      Algorithm
+	workerCapacity WorkerCapacity
 }
 
 func (s *buildStarter) TryStartPendingBuildsForJob(
@@ -58,6 +70,13 @@ func (s *buildStarter) TryStartPendingBuildsForJob(
 
 	buildsToSchedule := s.constructBuilds(job, jobInputs, nextPendingBuilds)
 
+	if len(buildsToSchedule) > 1 {
+		buildsToSchedule, err = s.collapseDuplicateBuilds(logger, job, buildsToSchedule)
+		if err != nil {
+			return false, err
+		}
+	}
+
 	var needsRetry bool
 	for _, nextSchedulableBuild := range buildsToSchedule {
 		results, err := s.tryStartNextPendingBuild(logger, nextSchedulableBuild, job)
@@ -71,6 +90,13 @@ func (s *buildStarter) TryStartPendingBuildsForJob(
 			continue
 		}
 
+		if results.workerCapacityReached {
+			// If no worker has capacity to run the build, leave it pending and
+			// retry once some may have
+			needsRetry = true
+			break
+		}
+
 		if !results.scheduled || !results.readyToDetermineInputs {
 			// If max in flight is reached or a manually triggered build has not
 			// checked all resources, stop scheduling and retry later
@@ -120,11 +146,101 @@ func (s *buildStarter) constructBuilds(job db.Job, jobInputs db.InputConfigs, bu
 	return buildsToSchedule
 }
 
+// collapseDuplicateBuilds collapses each pending build of a job which
+// deduplicates its builds into the earliest pending build which runs with the
+// same input versions, returning the builds left to schedule.
+func (s *buildStarter) collapseDuplicateBuilds(logger lager.Logger, job db.Job, builds []Build) ([]Build, error) {
+	config, err := job.Config()
+	if err != nil {
+		return nil, fmt.Errorf("config: %w", err)
+	}
+
+	if !config.DeduplicateBuilds {
+		return builds, nil
+	}
+
+	earliest := map[string]Build{}
+
+	var remaining []Build
+	for _, build := range builds {
+		key, err := duplicateKey(logger, build)
+		if err != nil {
+			return nil, err
+		}
+
+		into, found := earliest[key]
+		if key == "" || !found {
+			if key != "" {
+				earliest[key] = build
+			}
+
+			remaining = append(remaining, build)
+			continue
+		}
+
+		collapsed, err := build.CollapseInto(into.ID())
+		if err != nil {
+			return nil, fmt.Errorf("collapse build: %w", err)
+		}
+
+		if collapsed {
+			logger.Info("collapsed-duplicate-build", lager.Data{
+				"build-id":      build.ID(),
+				"into-build-id": into.ID(),
+			})
+		}
+	}
+
+	return remaining, nil
+}
+
+// duplicateKey identifies the input versions a pending build will run with,
+// if they're the same as the ones other pending builds will run with.
+//
+// Builds without inputs of their own all run with the job's next inputs, and
+// reruns of the same build run with the inputs of that build. Manually
+// triggered builds only run with the next inputs once their resources have
+// been checked. Builds with pinned inputs or vars are never duplicates.
+func duplicateKey(logger lager.Logger, build Build) (string, error) {
+	if build.IsAborted() {
+		return "", nil
+	}
+
+	if build.RerunOf() != 0 {
+		return fmt.Sprintf("rerun-of-%d", build.RerunOf()), nil
+	}
+
+	if len(build.Vars()) > 0 {
+		return "", nil
+	}
+
+	pins, err := build.PinnedInputs()
+	if err != nil {
+		return "", fmt.Errorf("pinned inputs: %w", err)
+	}
+
+	if len(pins) > 0 {
+		return "", nil
+	}
+
+	ready, err := build.IsReadyToDetermineInputs(logger)
+	if err != nil {
+		return "", fmt.Errorf("ready to determine inputs: %w", err)
+	}
+
+	if !ready {
+		return "", nil
+	}
+
+	return "next-inputs", nil
+}
+
 type startResults struct {
 	finished               bool
 	scheduled              bool
 	readyToDetermineInputs bool
 	inputsDetermined       bool
+	workerCapacityReached  bool
 }
 
 func (s *buildStarter) tryStartNextPendingBuild(
@@ -211,6 +327,34 @@ func (s *buildStarter) tryStartNextPendingBuild(
 		}, nil
 	}
 
+	if config.WarmPool != nil {
+		plan.Each(func(p *atc.Plan) {
+			if p.Task != nil {
+				p.Task.WarmPool = true
+			}
+		})
+	}
+
+	hasCapacity, err := s.hasWorkerCapacity(logger, job, config)
+	if err != nil {
+		return startResults{}, fmt.Errorf("check worker capacity: %w", err)
+	}
+
+	err = job.SetWorkerCapacityReached(!hasCapacity)
+	if err != nil {
+		return startResults{}, fmt.Errorf("set worker capacity reached: %w", err)
+	}
+
+	if !hasCapacity {
+		logger.Debug("no-worker-capacity")
+		return startResults{
+			scheduled:              scheduled,
+			readyToDetermineInputs: readyToDetermineInputs,
+			inputsDetermined:       inputsDetermined,
+			workerCapacityReached:  true,
+		}, nil
+	}
+
 	started, err := nextPendingBuild.Start(plan)
 	if err != nil {
 		logger.Error("failed-to-mark-build-as-started", err)
@@ -239,3 +383,76 @@ func (s *buildStarter) tryStartNextPendingBuild(
 		finished: true,
 	}, nil
 }
+
+// hasWorkerCapacity returns whether, for each set of tags the steps of the job
+// run with, a worker has room to run them.
+func (s *buildStarter) hasWorkerCapacity(logger lager.Logger, job db.Job, config atc.JobConfig) (bool, error) {
+	type placement struct {
+		tags      atc.Tags
+		runsTasks bool
+	}
+
+	var placements []*placement
+	place := func(tags atc.Tags, runsTasks bool) {
+		for _, p := range placements {
+			if tagsEqual(p.tags, tags) {
+				p.runsTasks = p.runsTasks || runsTasks
+				return
+			}
+		}
+
+		placements = append(placements, &placement{tags: tags, runsTasks: runsTasks})
+	}
+
+	err := config.StepConfig().Visit(atc.StepRecursor{
+		OnGet: func(step *atc.GetStep) error {
+			place(step.Tags, false)
+			return nil
+		},
+		OnPut: func(step *atc.PutStep) error {
+			place(step.Tags, false)
+			return nil
+		},
+		OnTask: func(step *atc.TaskStep) error {
+			place(step.Tags, true)
+			return nil
+		},
+		OnRun: func(step *atc.RunStep) error {
+			place(step.Tags, false)
+			return nil
+		},
+	})
+	if err != nil {
+		return false, err
+	}
+
+	for _, p := range placements {
+		hasCapacity, err := s.workerCapacity.HasCapacity(logger, worker.Spec{
+			TeamID: job.TeamID(),
+			Tags:   p.tags,
+		}, p.runsTasks)
+		if err != nil {
+			return false, err
+		}
+
+		if !hasCapacity {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+func tagsEqual(a, b atc.Tags) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
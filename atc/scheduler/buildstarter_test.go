@@ -22,6 +22,8 @@ var _ = Describe("BuildStarter", func() {
 		pendingBuilds []db.Build
 		fakeAlgorithm *schedulerfakes.FakeAlgorithm
 
+		fakeWorkerCapacity *schedulerfakes.FakeWorkerCapacity
+
 		buildStarter scheduler.BuildStarter
 
 		jobInputs db.InputConfigs
@@ -34,7 +36,10 @@ var _ = Describe("BuildStarter", func() {
 		fakePlanner = new(schedulerfakes.FakeBuildPlanner)
 		fakeAlgorithm = new(schedulerfakes.FakeAlgorithm)
 
-		buildStarter = scheduler.NewBuildStarter(fakePlanner, fakeAlgorithm)
+		fakeWorkerCapacity = new(schedulerfakes.FakeWorkerCapacity)
+		fakeWorkerCapacity.HasCapacityReturns(true, nil)
+
+		buildStarter = scheduler.NewBuildStarter(fakePlanner, fakeAlgorithm, fakeWorkerCapacity)
 
 		disaster = errors.New("bad thing")
 	})
@@ -790,6 +795,31 @@ var _ = Describe("BuildStarter", func() {
 											Expect(rerunBuild.StartArgsForCall(0)).To(Equal(plannedPlan))
 										})
 									})
+
+									Context("when the job has a warm pool", func() {
+										BeforeEach(func() {
+											warmJobConfig := jobConfig
+											warmJobConfig.WarmPool = &atc.WarmPoolConfig{Size: 2}
+											job.ConfigReturns(warmJobConfig, nil)
+
+											fakePlanner.CreateReturns(atc.Plan{
+												Do: &atc.DoPlan{
+													plannedPlan,
+													{Task: &atc.TaskPlan{Name: "some-task"}},
+												},
+											}, nil)
+										})
+
+										It("marks the tasks of the plan as warmed", func() {
+											Expect(pendingBuild1.StartCallCount()).To(Equal(1))
+											Expect(pendingBuild1.StartArgsForCall(0)).To(Equal(atc.Plan{
+												Do: &atc.DoPlan{
+													plannedPlan,
+													{Task: &atc.TaskPlan{Name: "some-task", WarmPool: true}},
+												},
+											}))
+										})
+									})
 								})
 							})
 						})
@@ -907,5 +937,239 @@ var _ = Describe("BuildStarter", func() {
 				})
 			})
 		})
+
+		Context("when the job deduplicates its builds", func() {
+			var (
+				schedulerBuild1 *dbfakes.FakeBuild
+				schedulerBuild2 *dbfakes.FakeBuild
+				varsBuild       *dbfakes.FakeBuild
+				uncheckedBuild  *dbfakes.FakeBuild
+				rerunBuild1     *dbfakes.FakeBuild
+				rerunBuild2     *dbfakes.FakeBuild
+			)
+
+			BeforeEach(func() {
+				schedulerBuild1 = new(dbfakes.FakeBuild)
+				schedulerBuild1.IDReturns(1)
+
+				schedulerBuild2 = new(dbfakes.FakeBuild)
+				schedulerBuild2.IDReturns(2)
+				schedulerBuild2.CollapseIntoReturns(true, nil)
+
+				varsBuild = new(dbfakes.FakeBuild)
+				varsBuild.IDReturns(3)
+				varsBuild.VarsReturns([]atc.BuildVar{{Name: "some-var", Value: "some-value"}})
+
+				uncheckedBuild = new(dbfakes.FakeBuild)
+				uncheckedBuild.IDReturns(4)
+				uncheckedBuild.IsManuallyTriggeredReturns(true)
+				uncheckedBuild.ResourcesCheckedReturns(false, nil)
+
+				rerunBuild1 = new(dbfakes.FakeBuild)
+				rerunBuild1.IDReturns(5)
+				rerunBuild1.RerunOfReturns(42)
+
+				rerunBuild2 = new(dbfakes.FakeBuild)
+				rerunBuild2.IDReturns(6)
+				rerunBuild2.RerunOfReturns(42)
+				rerunBuild2.CollapseIntoReturns(true, nil)
+
+				job = new(dbfakes.FakeJob)
+				job.ConfigReturns(atc.JobConfig{
+					Name:              "some-job",
+					DeduplicateBuilds: true,
+				}, nil)
+				job.GetPendingBuildsReturns([]db.Build{
+					schedulerBuild1,
+					schedulerBuild2,
+					varsBuild,
+					uncheckedBuild,
+					rerunBuild1,
+					rerunBuild2,
+				}, nil)
+
+				// stop once the first build is tried, as scheduling isn't what's
+				// under test
+				job.ScheduleBuildReturns(false, nil)
+			})
+
+			JustBeforeEach(func() {
+				needsReschedule, tryStartErr = buildStarter.TryStartPendingBuildsForJob(
+					lagertest.NewTestLogger("test"),
+					db.SchedulerJob{Job: job},
+					db.InputConfigs{},
+				)
+			})
+
+			It("collapses the builds which run with the same inputs into the earliest one", func() {
+				Expect(tryStartErr).ToNot(HaveOccurred())
+
+				Expect(schedulerBuild2.CollapseIntoCallCount()).To(Equal(1))
+				Expect(schedulerBuild2.CollapseIntoArgsForCall(0)).To(Equal(1))
+
+				Expect(rerunBuild2.CollapseIntoCallCount()).To(Equal(1))
+				Expect(rerunBuild2.CollapseIntoArgsForCall(0)).To(Equal(5))
+			})
+
+			It("leaves the builds with inputs of their own", func() {
+				Expect(schedulerBuild1.CollapseIntoCallCount()).To(BeZero())
+				Expect(varsBuild.CollapseIntoCallCount()).To(BeZero())
+				Expect(uncheckedBuild.CollapseIntoCallCount()).To(BeZero())
+				Expect(rerunBuild1.CollapseIntoCallCount()).To(BeZero())
+			})
+
+			It("schedules the earliest build", func() {
+				Expect(job.ScheduleBuildCallCount()).To(Equal(1))
+				Expect(job.ScheduleBuildArgsForCall(0).ID()).To(Equal(1))
+			})
+
+			Context("when the builds have pinned inputs", func() {
+				BeforeEach(func() {
+					schedulerBuild2.PinnedInputsReturns([]atc.BuildInputPin{{Name: "some-input", VersionID: 7}}, nil)
+				})
+
+				It("does not collapse them", func() {
+					Expect(schedulerBuild2.CollapseIntoCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when collapsing a build fails", func() {
+				BeforeEach(func() {
+					schedulerBuild2.CollapseIntoReturns(false, disaster)
+				})
+
+				It("returns the error", func() {
+					Expect(tryStartErr).To(MatchError(ContainSubstring(disaster.Error())))
+				})
+			})
+
+			Context("when the job does not deduplicate its builds", func() {
+				BeforeEach(func() {
+					job.ConfigReturns(atc.JobConfig{Name: "some-job"}, nil)
+				})
+
+				It("does not collapse any build", func() {
+					Expect(schedulerBuild2.CollapseIntoCallCount()).To(BeZero())
+					Expect(rerunBuild2.CollapseIntoCallCount()).To(BeZero())
+				})
+			})
+		})
+
+		Context("when checking the worker capacity for the job", func() {
+			var (
+				pendingBuild1 *dbfakes.FakeBuild
+				pendingBuild2 *dbfakes.FakeBuild
+			)
+
+			BeforeEach(func() {
+				pendingBuild1 = new(dbfakes.FakeBuild)
+				pendingBuild1.IDReturns(1)
+				pendingBuild1.AdoptInputsAndPipesReturns([]db.BuildInput{}, true, nil)
+				pendingBuild1.StartReturns(true, nil)
+
+				pendingBuild2 = new(dbfakes.FakeBuild)
+				pendingBuild2.IDReturns(2)
+				pendingBuild2.AdoptInputsAndPipesReturns([]db.BuildInput{}, true, nil)
+				pendingBuild2.StartReturns(true, nil)
+
+				job = new(dbfakes.FakeJob)
+				job.TeamIDReturns(7)
+				job.ConfigReturns(atc.JobConfig{
+					Name: "some-job",
+					PlanSequence: []atc.Step{
+						{
+							Config: &atc.GetStep{
+								Name: "some-input",
+							},
+						},
+						{
+							Config: &atc.TaskStep{
+								Name: "some-task",
+								Tags: atc.Tags{"some-tag"},
+							},
+						},
+						{
+							Config: &atc.PutStep{
+								Name: "some-output",
+								Tags: atc.Tags{"some-tag"},
+							},
+						},
+					},
+				}, nil)
+				job.GetPendingBuildsReturns([]db.Build{pendingBuild1, pendingBuild2}, nil)
+				job.ScheduleBuildReturns(true, nil)
+			})
+
+			JustBeforeEach(func() {
+				needsReschedule, tryStartErr = buildStarter.TryStartPendingBuildsForJob(
+					lagertest.NewTestLogger("test"),
+					db.SchedulerJob{Job: job},
+					db.InputConfigs{},
+				)
+			})
+
+			It("checks the capacity for each set of tags the steps run with", func() {
+				Expect(fakeWorkerCapacity.HasCapacityCallCount()).To(Equal(4))
+
+				_, spec, runsTasks := fakeWorkerCapacity.HasCapacityArgsForCall(0)
+				Expect(spec.TeamID).To(Equal(7))
+				Expect(spec.Tags).To(BeEmpty())
+				Expect(runsTasks).To(BeFalse())
+
+				_, spec, runsTasks = fakeWorkerCapacity.HasCapacityArgsForCall(1)
+				Expect(spec.TeamID).To(Equal(7))
+				Expect(spec.Tags).To(Equal([]string{"some-tag"}))
+				Expect(runsTasks).To(BeTrue())
+			})
+
+			Context("when the workers have capacity", func() {
+				It("starts the builds", func() {
+					Expect(tryStartErr).ToNot(HaveOccurred())
+					Expect(needsReschedule).To(BeFalse())
+
+					Expect(pendingBuild1.StartCallCount()).To(Equal(1))
+					Expect(pendingBuild2.StartCallCount()).To(Equal(1))
+				})
+
+				It("records that the capacity is not reached", func() {
+					Expect(job.SetWorkerCapacityReachedCallCount()).To(Equal(2))
+					Expect(job.SetWorkerCapacityReachedArgsForCall(0)).To(BeFalse())
+				})
+			})
+
+			Context("when no worker has capacity for some of the steps", func() {
+				BeforeEach(func() {
+					fakeWorkerCapacity.HasCapacityReturnsOnCall(1, false, nil)
+				})
+
+				It("leaves the builds pending and needs to be rescheduled", func() {
+					Expect(tryStartErr).ToNot(HaveOccurred())
+					Expect(needsReschedule).To(BeTrue())
+
+					Expect(pendingBuild1.StartCallCount()).To(BeZero())
+					Expect(pendingBuild1.FinishCallCount()).To(BeZero())
+					Expect(pendingBuild2.StartCallCount()).To(BeZero())
+				})
+
+				It("records that the capacity is reached", func() {
+					Expect(job.SetWorkerCapacityReachedCallCount()).To(Equal(1))
+					Expect(job.SetWorkerCapacityReachedArgsForCall(0)).To(BeTrue())
+				})
+			})
+
+			Context("when checking the capacity fails", func() {
+				BeforeEach(func() {
+					fakeWorkerCapacity.HasCapacityReturns(false, disaster)
+				})
+
+				It("returns the error", func() {
+					Expect(tryStartErr).To(MatchError(ContainSubstring(disaster.Error())))
+				})
+
+				It("does not start the build", func() {
+					Expect(pendingBuild1.StartCallCount()).To(BeZero())
+				})
+			})
+		})
 	})
 })
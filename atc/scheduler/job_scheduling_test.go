@@ -261,7 +261,10 @@ func (example Example) Run() {
 	fakeAlgorithm := new(schedulerfakes.FakeAlgorithm)
 	fakeAlgorithm.ComputeReturns(nil, true, false, nil)
 
-	buildStarter := scheduler.NewBuildStarter(fakePlanner, fakeAlgorithm)
+	fakeWorkerCapacity := new(schedulerfakes.FakeWorkerCapacity)
+	fakeWorkerCapacity.HasCapacityReturns(true, nil)
+
+	buildStarter := scheduler.NewBuildStarter(fakePlanner, fakeAlgorithm, fakeWorkerCapacity)
 
 	fakeJob := new(dbfakes.FakeJob)
 	fakeJob.ConfigReturns(atc.JobConfig{}, nil)
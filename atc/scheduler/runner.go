@@ -20,23 +20,25 @@ type BuildScheduler interface {
 		ctx context.Context,
 		logger lager.Logger,
 		job db.SchedulerJob,
-	) (bool, error)
+	) (bool, db.SchedulingTimings, error)
 }
 
 type Runner struct {
 	logger     lager.Logger
 	jobFactory db.JobFactory
 	scheduler  BuildScheduler
+	stats      db.SchedulingStatsRepository
 
 	guardJobScheduling chan struct{}
 	running            *sync.Map
 }
 
-func NewRunner(logger lager.Logger, jobFactory db.JobFactory, scheduler BuildScheduler, maxJobs uint64) *Runner {
+func NewRunner(logger lager.Logger, jobFactory db.JobFactory, scheduler BuildScheduler, stats db.SchedulingStatsRepository, maxJobs uint64) *Runner {
 	return &Runner{
 		logger:     logger,
 		jobFactory: jobFactory,
 		scheduler:  scheduler,
+		stats:      stats,
 
 		guardJobScheduling: make(chan struct{}, maxJobs),
 		running:            &sync.Map{},
@@ -51,6 +53,8 @@ func (s *Runner) Run(ctx context.Context) error {
 	spanCtx, span := tracing.StartSpan(ctx, "scheduler.Run", nil)
 	defer span.End()
 
+	tick := time.Now()
+
 	jobs, err := s.jobFactory.JobsToSchedule()
 	if err != nil {
 		return fmt.Errorf("find jobs to schedule: %w", err)
@@ -91,7 +95,7 @@ func (s *Runner) Run(ctx context.Context) error {
 
 			defer schedulingLock.Release()
 
-			err = s.scheduleJob(spanCtx, sLog, job)
+			err = s.scheduleJob(spanCtx, sLog, job, tick)
 			if err != nil {
 				jLog.Error("failed-to-schedule-job", err)
 			}
@@ -101,7 +105,7 @@ func (s *Runner) Run(ctx context.Context) error {
 	return nil
 }
 
-func (s *Runner) scheduleJob(ctx context.Context, logger lager.Logger, job db.SchedulerJob) error {
+func (s *Runner) scheduleJob(ctx context.Context, logger lager.Logger, job db.SchedulerJob, tick time.Time) error {
 	metric.Metrics.JobsScheduling.Inc()
 	defer metric.Metrics.JobsScheduling.Dec()
 	defer metric.Metrics.JobsScheduled.Inc()
@@ -133,7 +137,7 @@ func (s *Runner) scheduleJob(ctx context.Context, logger lager.Logger, job db.Sc
 
 	jStart := time.Now()
 
-	needsRetry, err := s.scheduler.Schedule(
+	needsRetry, timings, err := s.scheduler.Schedule(
 		spanCtx,
 		logger,
 		job,
@@ -142,6 +146,11 @@ func (s *Runner) scheduleJob(ctx context.Context, logger lager.Logger, job db.Sc
 		return fmt.Errorf("schedule job: %w", err)
 	}
 
+	err = s.stats.RecordSchedulingTimings(job.PipelineID(), tick, timings)
+	if err != nil {
+		logger.Error("failed-to-record-scheduling-timings", err)
+	}
+
 	span.SetAttributes(attribute.Bool("needs-retry", needsRetry))
 	if !needsRetry {
 		err = job.UpdateLastScheduled(requestedTime)
@@ -25,6 +25,7 @@ var _ = Describe("Runner", func() {
 	var (
 		fakePipeline  *dbfakes.FakePipeline
 		fakeScheduler *schedulerfakes.FakeBuildScheduler
+		fakeStats     *dbfakes.FakeSchedulingStatsRepository
 		maxInFlight   uint64
 
 		lock *lockfakes.FakeLock
@@ -44,6 +45,7 @@ var _ = Describe("Runner", func() {
 
 	BeforeEach(func() {
 		fakeScheduler = new(schedulerfakes.FakeBuildScheduler)
+		fakeStats = new(dbfakes.FakeSchedulingStatsRepository)
 		fakeJobFactory = new(dbfakes.FakeJobFactory)
 		maxInFlight = 1
 
@@ -55,6 +57,7 @@ var _ = Describe("Runner", func() {
 			lagertest.NewTestLogger("test"),
 			fakeJobFactory,
 			fakeScheduler,
+			fakeStats,
 			maxInFlight,
 		)
 
@@ -209,7 +212,7 @@ var _ = Describe("Runner", func() {
 
 				Context("when all jobs scheduling succeeds", func() {
 					BeforeEach(func() {
-						fakeScheduler.ScheduleReturns(false, nil)
+						fakeScheduler.ScheduleReturns(false, db.SchedulingTimings{}, nil)
 					})
 
 					It("updates last schedule", func() {
@@ -220,6 +223,16 @@ var _ = Describe("Runner", func() {
 						Expect(fakeJob1.UpdateLastScheduledArgsForCall(0)).To(Equal(job1RequestedTime))
 						Expect(fakeJob2.UpdateLastScheduledArgsForCall(0)).To(Equal(job2RequestedTime))
 					})
+
+					It("records the timings of both jobs against the pipeline for the same tick", func() {
+						Eventually(fakeStats.RecordSchedulingTimingsCallCount).Should(Equal(2))
+
+						pipelineID1, tick1, _ := fakeStats.RecordSchedulingTimingsArgsForCall(0)
+						pipelineID2, tick2, _ := fakeStats.RecordSchedulingTimingsArgsForCall(1)
+						Expect(pipelineID1).To(Equal(1))
+						Expect(pipelineID2).To(Equal(1))
+						Expect(tick1).To(Equal(tick2))
+					})
 				})
 
 				Context("when the same job is already being scheduled", func() {
@@ -260,10 +273,10 @@ var _ = Describe("Runner", func() {
 							context.Context,
 							lager.Logger,
 							db.SchedulerJob,
-						) (bool, error) {
+						) (bool, db.SchedulingTimings, error) {
 							wg.Done()
 							wg.Wait()
-							return false, nil
+							return false, db.SchedulingTimings{}, nil
 						}
 					})
 
@@ -280,8 +293,8 @@ var _ = Describe("Runner", func() {
 
 				Context("when job scheduling fails", func() {
 					BeforeEach(func() {
-						fakeScheduler.ScheduleReturnsOnCall(0, false, errors.New("error"))
-						fakeScheduler.ScheduleReturnsOnCall(1, false, nil)
+						fakeScheduler.ScheduleReturnsOnCall(0, false, db.SchedulingTimings{}, errors.New("error"))
+						fakeScheduler.ScheduleReturnsOnCall(1, false, db.SchedulingTimings{}, nil)
 					})
 
 					It("does not update last scheduled", func() {
@@ -293,11 +306,11 @@ var _ = Describe("Runner", func() {
 
 				Context("when job scheduling panic", func() {
 					BeforeEach(func() {
-						fakeScheduler.ScheduleStub = func(_ context.Context, _ lager.Logger, job db.SchedulerJob) (bool, error) {
+						fakeScheduler.ScheduleStub = func(_ context.Context, _ lager.Logger, job db.SchedulerJob) (bool, db.SchedulingTimings, error) {
 							if job.Name() == "some-job" {
 								panic("something went wrong")
 							}
-							return false, nil
+							return false, db.SchedulingTimings{}, nil
 						}
 					})
 
@@ -310,8 +323,8 @@ var _ = Describe("Runner", func() {
 
 				Context("when there is no error but needs retry", func() {
 					BeforeEach(func() {
-						fakeScheduler.ScheduleReturnsOnCall(0, true, nil)
-						fakeScheduler.ScheduleReturnsOnCall(1, false, nil)
+						fakeScheduler.ScheduleReturnsOnCall(0, true, db.SchedulingTimings{}, nil)
+						fakeScheduler.ScheduleReturnsOnCall(1, false, db.SchedulingTimings{}, nil)
 					})
 
 					It("does not update last scheduled for the job that needs retry", func() {
@@ -414,7 +427,7 @@ var _ = Describe("Runner", func() {
 			fakeJob3.PipelineReturns(fakePipeline2, true, nil)
 			fakeJob3.ScheduleRequestedTimeReturns(job3RequestedTime)
 
-			fakeScheduler.ScheduleReturns(false, nil)
+			fakeScheduler.ScheduleReturns(false, db.SchedulingTimings{}, nil)
 		})
 
 		Context("when both pipelines successfully schedule", func() {
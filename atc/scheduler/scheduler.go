@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc/db"
@@ -22,42 +23,80 @@ type Algorithm interface {
 }
 
 type Scheduler struct {
-	Algorithm    Algorithm
-	BuildStarter BuildStarter
+	Algorithm     Algorithm
+	BuildStarter  BuildStarter
+	FreezeWindows db.FreezeWindowRepository
 }
 
+// Schedule resolves the inputs of the job, creates a pending build if there
+// are new versions to trigger it and tries to start its pending builds. It
+// also returns how long each of these took.
 func (s *Scheduler) Schedule(
 	ctx context.Context,
 	logger lager.Logger,
 	job db.SchedulerJob,
-) (bool, error) {
+) (bool, db.SchedulingTimings, error) {
+	var timings db.SchedulingTimings
+
+	start := time.Now()
 	jobInputs, err := job.AlgorithmInputs()
 	if err != nil {
-		return false, fmt.Errorf("inputs: %w", err)
+		return false, timings, fmt.Errorf("inputs: %w", err)
 	}
 
+	timings.LoadingVersions = time.Since(start)
+
+	start = time.Now()
 	inputMapping, resolved, runAgain, err := s.Algorithm.Compute(ctx, job, jobInputs)
 	if err != nil {
-		return false, fmt.Errorf("compute inputs: %w", err)
+		return false, timings, fmt.Errorf("compute inputs: %w", err)
 	}
 
 	if runAgain {
 		err = job.RequestSchedule()
 		if err != nil {
-			return false, fmt.Errorf("request schedule: %w", err)
+			return false, timings, fmt.Errorf("request schedule: %w", err)
 		}
 	}
 
 	err = job.SaveNextInputMapping(inputMapping, resolved)
 	if err != nil {
-		return false, fmt.Errorf("save next input mapping: %w", err)
+		return false, timings, fmt.Errorf("save next input mapping: %w", err)
 	}
 
-	err = s.ensurePendingBuildExists(ctx, logger, job, jobInputs)
+	timings.ResolvingInputs = time.Since(start)
+
+	start = time.Now()
+	needsRetry, err := s.createBuilds(ctx, logger, job, jobInputs)
+	timings.CreatingBuilds = time.Since(start)
+
+	return needsRetry, timings, err
+}
+
+func (s *Scheduler) createBuilds(
+	ctx context.Context,
+	logger lager.Logger,
+	job db.SchedulerJob,
+	jobInputs db.InputConfigs,
+) (bool, error) {
+	err := s.ensurePendingBuildExists(ctx, logger, job, jobInputs)
 	if err != nil {
 		return false, err
 	}
 
+	window, frozen, err := s.FreezeWindows.ActiveFreezeWindow(job.TeamID(), job.PipelineName())
+	if err != nil {
+		return false, fmt.Errorf("find active freeze window: %w", err)
+	}
+
+	if frozen {
+		logger.Debug("frozen", lager.Data{"freeze-window": window.ID})
+
+		// Pending builds are left as they are, to be started once the
+		// window is over.
+		return true, nil
+	}
+
 	return s.BuildStarter.TryStartPendingBuildsForJob(logger, job, jobInputs)
 }
 
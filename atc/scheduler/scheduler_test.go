@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/concourse/atc"
@@ -20,8 +21,9 @@ import (
 
 var _ = Describe("Scheduler", func() {
 	var (
-		fakeAlgorithm    *schedulerfakes.FakeAlgorithm
-		fakeBuildStarter *schedulerfakes.FakeBuildStarter
+		fakeAlgorithm     *schedulerfakes.FakeAlgorithm
+		fakeBuildStarter  *schedulerfakes.FakeBuildStarter
+		fakeFreezeWindows *dbfakes.FakeFreezeWindowRepository
 
 		scheduler *Scheduler
 
@@ -32,10 +34,12 @@ var _ = Describe("Scheduler", func() {
 	BeforeEach(func() {
 		fakeAlgorithm = new(schedulerfakes.FakeAlgorithm)
 		fakeBuildStarter = new(schedulerfakes.FakeBuildStarter)
+		fakeFreezeWindows = new(dbfakes.FakeFreezeWindowRepository)
 
 		scheduler = &Scheduler{
-			Algorithm:    fakeAlgorithm,
-			BuildStarter: fakeBuildStarter,
+			Algorithm:     fakeAlgorithm,
+			BuildStarter:  fakeBuildStarter,
+			FreezeWindows: fakeFreezeWindows,
 		}
 
 		disaster = errors.New("bad thing")
@@ -45,6 +49,8 @@ var _ = Describe("Scheduler", func() {
 		var (
 			fakePipeline *dbfakes.FakePipeline
 			fakeJob      *dbfakes.FakeJob
+			needsRetry   bool
+			timings      db.SchedulingTimings
 			scheduleErr  error
 		)
 
@@ -58,7 +64,7 @@ var _ = Describe("Scheduler", func() {
 		JustBeforeEach(func() {
 			var waiter interface{ Wait() }
 
-			_, scheduleErr = scheduler.Schedule(
+			needsRetry, timings, scheduleErr = scheduler.Schedule(
 				ctx,
 				lagertest.NewTestLogger("test"),
 				db.SchedulerJob{
@@ -118,6 +124,20 @@ var _ = Describe("Scheduler", func() {
 					Expect(actualInputs).To(BeNil())
 				})
 
+				Context("when computing the inputs takes a while", func() {
+					BeforeEach(func() {
+						fakeAlgorithm.ComputeStub = func(context.Context, db.Job, db.InputConfigs) (db.InputMapping, bool, bool, error) {
+							time.Sleep(10 * time.Millisecond)
+							return expectedInputMapping, true, false, nil
+						}
+					})
+
+					It("reports the time spent resolving the inputs", func() {
+						Expect(scheduleErr).ToNot(HaveOccurred())
+						Expect(timings.ResolvingInputs).To(BeNumerically(">=", 10*time.Millisecond))
+					})
+				})
+
 				Context("when the algorithm can run again", func() {
 					BeforeEach(func() {
 						fakeAlgorithm.ComputeReturns(expectedInputMapping, true, true, nil)
@@ -208,6 +228,44 @@ var _ = Describe("Scheduler", func() {
 								Expect(fakeJob.EnsurePendingBuildExistsCallCount()).To(BeZero())
 							})
 						})
+
+						Context("when the pipeline is frozen", func() {
+							BeforeEach(func() {
+								fakeJob.TeamIDReturns(3)
+								fakeJob.PipelineNameReturns("some-pipeline")
+								fakeFreezeWindows.ActiveFreezeWindowReturns(atc.FreezeWindow{ID: 7}, true, nil)
+							})
+
+							It("looks for a freeze window of the job's pipeline", func() {
+								Expect(fakeFreezeWindows.ActiveFreezeWindowCallCount()).To(Equal(1))
+								teamID, pipelineName := fakeFreezeWindows.ActiveFreezeWindowArgsForCall(0)
+								Expect(teamID).To(Equal(3))
+								Expect(pipelineName).To(Equal("some-pipeline"))
+							})
+
+							It("does not start any pending builds", func() {
+								Expect(fakeBuildStarter.TryStartPendingBuildsForJobCallCount()).To(BeZero())
+							})
+
+							It("needs to be retried", func() {
+								Expect(scheduleErr).NotTo(HaveOccurred())
+								Expect(needsRetry).To(BeTrue())
+							})
+						})
+
+						Context("when finding an active freeze window fails", func() {
+							BeforeEach(func() {
+								fakeFreezeWindows.ActiveFreezeWindowReturns(atc.FreezeWindow{}, false, disaster)
+							})
+
+							It("returns the error", func() {
+								Expect(scheduleErr).To(MatchError(disaster))
+							})
+
+							It("does not start any pending builds", func() {
+								Expect(fakeBuildStarter.TryStartPendingBuildsForJobCallCount()).To(BeZero())
+							})
+						})
 					})
 				})
 
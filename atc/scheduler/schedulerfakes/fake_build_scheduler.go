@@ -11,7 +11,7 @@ import (
 )
 
 type FakeBuildScheduler struct {
-	ScheduleStub        func(context.Context, lager.Logger, db.SchedulerJob) (bool, error)
+	ScheduleStub        func(context.Context, lager.Logger, db.SchedulerJob) (bool, db.SchedulingTimings, error)
 	scheduleMutex       sync.RWMutex
 	scheduleArgsForCall []struct {
 		arg1 context.Context
@@ -20,17 +20,19 @@ type FakeBuildScheduler struct {
 	}
 	scheduleReturns struct {
 		result1 bool
-		result2 error
+		result2 db.SchedulingTimings
+		result3 error
 	}
 	scheduleReturnsOnCall map[int]struct {
 		result1 bool
-		result2 error
+		result2 db.SchedulingTimings
+		result3 error
 	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeBuildScheduler) Schedule(arg1 context.Context, arg2 lager.Logger, arg3 db.SchedulerJob) (bool, error) {
+func (fake *FakeBuildScheduler) Schedule(arg1 context.Context, arg2 lager.Logger, arg3 db.SchedulerJob) (bool, db.SchedulingTimings, error) {
 	fake.scheduleMutex.Lock()
 	ret, specificReturn := fake.scheduleReturnsOnCall[len(fake.scheduleArgsForCall)]
 	fake.scheduleArgsForCall = append(fake.scheduleArgsForCall, struct {
@@ -46,9 +48,9 @@ func (fake *FakeBuildScheduler) Schedule(arg1 context.Context, arg2 lager.Logger
 		return stub(arg1, arg2, arg3)
 	}
 	if specificReturn {
-		return ret.result1, ret.result2
+		return ret.result1, ret.result2, ret.result3
 	}
-	return fakeReturns.result1, fakeReturns.result2
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
 }
 
 func (fake *FakeBuildScheduler) ScheduleCallCount() int {
@@ -57,7 +59,7 @@ func (fake *FakeBuildScheduler) ScheduleCallCount() int {
 	return len(fake.scheduleArgsForCall)
 }
 
-func (fake *FakeBuildScheduler) ScheduleCalls(stub func(context.Context, lager.Logger, db.SchedulerJob) (bool, error)) {
+func (fake *FakeBuildScheduler) ScheduleCalls(stub func(context.Context, lager.Logger, db.SchedulerJob) (bool, db.SchedulingTimings, error)) {
 	fake.scheduleMutex.Lock()
 	defer fake.scheduleMutex.Unlock()
 	fake.ScheduleStub = stub
@@ -70,30 +72,33 @@ func (fake *FakeBuildScheduler) ScheduleArgsForCall(i int) (context.Context, lag
 	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
-func (fake *FakeBuildScheduler) ScheduleReturns(result1 bool, result2 error) {
+func (fake *FakeBuildScheduler) ScheduleReturns(result1 bool, result2 db.SchedulingTimings, result3 error) {
 	fake.scheduleMutex.Lock()
 	defer fake.scheduleMutex.Unlock()
 	fake.ScheduleStub = nil
 	fake.scheduleReturns = struct {
 		result1 bool
-		result2 error
-	}{result1, result2}
+		result2 db.SchedulingTimings
+		result3 error
+	}{result1, result2, result3}
 }
 
-func (fake *FakeBuildScheduler) ScheduleReturnsOnCall(i int, result1 bool, result2 error) {
+func (fake *FakeBuildScheduler) ScheduleReturnsOnCall(i int, result1 bool, result2 db.SchedulingTimings, result3 error) {
 	fake.scheduleMutex.Lock()
 	defer fake.scheduleMutex.Unlock()
 	fake.ScheduleStub = nil
 	if fake.scheduleReturnsOnCall == nil {
 		fake.scheduleReturnsOnCall = make(map[int]struct {
 			result1 bool
-			result2 error
+			result2 db.SchedulingTimings
+			result3 error
 		})
 	}
 	fake.scheduleReturnsOnCall[i] = struct {
 		result1 bool
-		result2 error
-	}{result1, result2}
+		result2 db.SchedulingTimings
+		result3 error
+	}{result1, result2, result3}
 }
 
 func (fake *FakeBuildScheduler) Invocations() map[string][][]interface{} {
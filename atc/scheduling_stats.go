@@ -0,0 +1,20 @@
+package atc
+
+// PipelineSchedulingStats breaks down the time the scheduler spent on the
+// jobs of a pipeline during the last tick in which any of them were
+// scheduled. Durations are in milliseconds and add up the time spent on
+// each job, which may have been scheduled concurrently.
+type PipelineSchedulingStats struct {
+	PipelineID           int          `json:"pipeline_id"`
+	PipelineName         string       `json:"pipeline_name"`
+	PipelineInstanceVars InstanceVars `json:"pipeline_instance_vars,omitempty"`
+	TeamName             string       `json:"team_name"`
+
+	TickStartedAt int64 `json:"tick_started_at"`
+	Jobs          int   `json:"jobs"`
+
+	LoadingVersions float64 `json:"loading_versions_ms"`
+	ResolvingInputs float64 `json:"resolving_inputs_ms"`
+	CreatingBuilds  float64 `json:"creating_builds_ms"`
+	Total           float64 `json:"total_ms"`
+}
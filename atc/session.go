@@ -0,0 +1,16 @@
+package atc
+
+// Session is an access token issued to a user when they logged in. The token
+// itself is never shown.
+type Session struct {
+	ID int `json:"id"`
+
+	// ClientID is the client the token was issued to, such as fly or the web
+	// UI.
+	ClientID  string `json:"client_id,omitempty"`
+	CreatedAt int64  `json:"created_at"`
+	ExpiresAt int64  `json:"expires_at"`
+
+	// Current is whether the session is the one making the request.
+	Current bool `json:"current,omitempty"`
+}
@@ -120,6 +120,11 @@ func (recursor StepRecursor) VisitTimeout(step *TimeoutStep) error {
 	return step.Step.Visit(recursor)
 }
 
+// VisitWhen recurses through to the wrapped step.
+func (recursor StepRecursor) VisitWhen(step *WhenStep) error {
+	return step.Step.Visit(recursor)
+}
+
 // VisitRetry recurses through to the wrapped step.
 func (recursor StepRecursor) VisitRetry(step *RetryStep) error {
 	return step.Step.Visit(recursor)
@@ -2,6 +2,8 @@ package atc
 
 import (
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -27,10 +29,34 @@ type StepValidator struct {
 
 	seenGetName    scope
 	localVarScopes []scope
+
+	// artifacts are the names of the artifacts produced by the steps visited
+	// so far. Once a step whose outputs can't be known until it runs has been
+	// visited, such as a task with its config in a file, unknownArtifacts is
+	// set and any artifact may exist.
+	artifacts        scope
+	unknownArtifacts bool
 }
 
 type scope map[string]bool
 
+func (s scope) copy() scope {
+	c := make(scope, len(s))
+	for k, v := range s {
+		c[k] = v
+	}
+	return c
+}
+
+func (s scope) sorted() []string {
+	var names []string
+	for name := range s {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 // NewStepValidator is a constructor which initializes internal data.
 //
 // The Config specified is used to validate the existence of resources and jobs
@@ -45,6 +71,7 @@ func NewStepValidator(config Config, context []string) *StepValidator {
 		context:        context,
 		seenGetName:    scope{},
 		localVarScopes: []scope{{}},
+		artifacts:      scope{},
 	}
 }
 
@@ -87,6 +114,10 @@ func (validator *StepValidator) VisitTask(plan *TaskStep) error {
 		})
 	}
 
+	if plan.Config != nil && PlatformRunsWithoutImage(plan.Config.Platform) && plan.ImageArtifactName != "" {
+		validator.recordError("specifies image: on the step, but tasks on the '%s' platform run without a container image", plan.Config.Platform)
+	}
+
 	if plan.Config != nil {
 		validator.pushContext(".config")
 
@@ -101,11 +132,69 @@ func (validator *StepValidator) VisitTask(plan *TaskStep) error {
 		}
 
 		validator.popContext()
+
+		validator.validateTaskArtifacts(plan)
+	} else {
+		validator.unknownArtifacts = true
 	}
 
 	return nil
 }
 
+// validateTaskArtifacts checks that the inputs the task needs are produced by
+// the steps before it, rather than the build failing with missing inputs once
+// it gets to the task, and that none of its outputs land on the same artifact.
+func (validator *StepValidator) validateTaskArtifacts(plan *TaskStep) {
+	for _, input := range plan.Config.Inputs {
+		name := input.Name
+		if mapped, found := plan.InputMapping[input.Name]; found {
+			name = mapped
+		}
+
+		if input.Optional || hasVars(name) || validator.artifactExists(name) {
+			continue
+		}
+
+		if name != input.Name {
+			validator.recordError("input '%s' (mapped to '%s') is not produced by a prior step", input.Name, name)
+		} else {
+			validator.recordError("input '%s' is not produced by a prior step", name)
+		}
+	}
+
+	if plan.ImageArtifactName != "" && !hasVars(plan.ImageArtifactName) && !validator.artifactExists(plan.ImageArtifactName) {
+		validator.recordError("image artifact '%s' is not produced by a prior step", plan.ImageArtifactName)
+	}
+
+	outputs := map[string]string{}
+	for _, output := range plan.Config.Outputs {
+		name := output.Name
+		if mapped, found := plan.OutputMapping[output.Name]; found {
+			name = mapped
+		}
+
+		if hasVars(name) {
+			// the artifact could end up with any name, including the ones
+			// later steps look for
+			validator.unknownArtifacts = true
+			continue
+		}
+
+		if other, found := outputs[name]; found {
+			if other == output.Name {
+				validator.recordError("output '%s' is declared more than once", output.Name)
+			} else {
+				validator.recordError("outputs '%s' and '%s' are both mapped to '%s'", other, output.Name, name)
+			}
+
+			continue
+		}
+
+		outputs[name] = output.Name
+		validator.artifacts[name] = true
+	}
+}
+
 func (validator *StepValidator) VisitGet(step *GetStep) error {
 	validator.pushContext(fmt.Sprintf(".get(%s)", step.Name))
 	defer validator.popContext()
@@ -123,6 +212,7 @@ func (validator *StepValidator) VisitGet(step *GetStep) error {
 	}
 
 	validator.seenGetName[step.Name] = true
+	validator.artifacts[step.Name] = true
 
 	resourceName := step.ResourceName()
 
@@ -131,6 +221,13 @@ func (validator *StepValidator) VisitGet(step *GetStep) error {
 		validator.recordError("unknown resource '%s'", resourceName)
 	}
 
+	if step.Version != nil && step.Version.Sampling != nil && len(step.Passed) > 0 {
+		validator.recordWarning(ConfigWarning{
+			Type:    "pipeline",
+			Message: validator.annotate("samples versions with version: but also specifies passed: - versions are only sampled for inputs without passed constraints"),
+		})
+	}
+
 	validator.pushContext(".passed")
 
 	for _, job := range step.Passed {
@@ -186,6 +283,9 @@ func (validator *StepValidator) VisitPut(step *PutStep) error {
 		validator.recordError("unknown resource '%s'", resourceName)
 	}
 
+	// the put is followed by an implicit get of the version it created
+	validator.artifacts[step.Name] = true
+
 	return nil
 }
 
@@ -281,15 +381,43 @@ func (validator *StepValidator) VisitInParallel(step *InParallelStep) error {
 	validator.pushContext(".in_parallel")
 	defer validator.popContext()
 
+	// the steps run at the same time, so none of them can use what the others
+	// produce
+	before := validator.artifacts
+	producedBy := map[string]int{}
+
+	var names []string
+
 	for i, sub := range step.Config.Steps {
 		validator.pushContext(".steps[%d]", i)
 
+		validator.artifacts = before.copy()
+
 		err := validator.Validate(sub)
 		if err != nil {
 			return err
 		}
 
 		validator.popContext()
+
+		for _, name := range validator.artifacts.sorted() {
+			if before[name] {
+				continue
+			}
+
+			if other, found := producedBy[name]; found {
+				validator.recordError("steps[%d] and steps[%d] both produce artifact '%s'", other, i, name)
+				continue
+			}
+
+			producedBy[name] = i
+			names = append(names, name)
+		}
+	}
+
+	validator.artifacts = before.copy()
+	for _, name := range names {
+		validator.artifacts[name] = true
 	}
 
 	return nil
@@ -343,6 +471,34 @@ func (validator *StepValidator) VisitTimeout(step *TimeoutStep) error {
 	return nil
 }
 
+func (validator *StepValidator) VisitWhen(step *WhenStep) error {
+	err := step.Step.Visit(validator)
+	if err != nil {
+		return err
+	}
+
+	validator.pushContext(".when")
+	defer validator.popContext()
+
+	switch condition := step.Condition.(type) {
+	case bool:
+	case string:
+		if strings.Contains(condition, "((") {
+			// evaluated once the build runs
+			break
+		}
+
+		_, err := strconv.ParseBool(condition)
+		if err != nil {
+			validator.recordError("invalid condition '%s'", condition)
+		}
+	default:
+		validator.recordError("must be a bool or a var")
+	}
+
+	return nil
+}
+
 func (validator *StepValidator) VisitRetry(step *RetryStep) error {
 	err := step.Step.Visit(validator)
 	if err != nil {
@@ -419,6 +575,16 @@ func (validator *StepValidator) VisitEnsure(step *EnsureStep) error {
 	return validator.Validate(step.Hook)
 }
 
+// hasVars returns whether the artifact name refers to vars, such as the vars
+// of an across step, which are only known once the build runs.
+func hasVars(name string) bool {
+	return strings.Contains(name, "((")
+}
+
+func (validator *StepValidator) artifactExists(name string) bool {
+	return validator.unknownArtifacts || validator.artifacts[name]
+}
+
 func (validator *StepValidator) recordWarning(warning ConfigWarning) {
 	validator.Warnings = append(validator.Warnings, warning)
 }
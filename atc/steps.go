@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"time"
 )
 
 // Step is an "envelope" type, acting as a wrapper to handle the marshaling and
@@ -199,6 +200,7 @@ type StepVisitor interface {
 	VisitInParallel(*InParallelStep) error
 	VisitAcross(*AcrossStep) error
 	VisitTimeout(*TimeoutStep) error
+	VisitWhen(*WhenStep) error
 	VisitRetry(*RetryStep) error
 	VisitOnSuccess(*OnSuccessStep) error
 	VisitOnFailure(*OnFailureStep) error
@@ -222,6 +224,10 @@ type StepDetector struct {
 // some important inter-modifier precedence - while core step types are parsed
 // last.
 var StepPrecedence = []StepDetector{
+	{
+		Key: "when",
+		New: func() StepConfig { return &WhenStep{} },
+	},
 	{
 		Key: "ensure",
 		New: func() StepConfig { return &EnsureStep{} },
@@ -539,6 +545,26 @@ func (step *TimeoutStep) Visit(v StepVisitor) error {
 	return v.VisitTimeout(step)
 }
 
+type WhenStep struct {
+	Step StepConfig `json:"-"`
+
+	// either a bool or a string, so that a var such as `((.:flag))` can be
+	// used for the condition
+	Condition interface{} `json:"when"`
+}
+
+func (step *WhenStep) Wrap(sub StepConfig) {
+	step.Step = sub
+}
+
+func (step *WhenStep) Unwrap() StepConfig {
+	return step.Step
+}
+
+func (step *WhenStep) Visit(v StepVisitor) error {
+	return v.VisitWhen(step)
+}
+
 type OnSuccessStep struct {
 	Step StepConfig `json:"-"`
 	Hook Step       `json:"on_success"`
@@ -678,11 +704,18 @@ type VersionConfig struct {
 	Every  bool
 	Latest bool
 	Pinned Version
+
+	// Sampling thins out the versions the job runs with, for resources which
+	// find versions faster than they're worth running with.
+	Sampling *VersionSampling
 }
 
 const VersionLatest = "latest"
 const VersionEvery = "every"
 
+const VersionSampleEvery = "every"
+const VersionSampleLatestPer = "latest_per"
+
 func (c *VersionConfig) UnmarshalJSON(version []byte) error {
 	var data interface{}
 
@@ -696,6 +729,18 @@ func (c *VersionConfig) UnmarshalJSON(version []byte) error {
 		c.Every = actual == VersionEvery
 		c.Latest = actual == VersionLatest
 	case map[string]interface{}:
+		if len(actual) == 1 {
+			sampling, isSampling, err := parseVersionSampling(actual)
+			if err != nil {
+				return err
+			}
+
+			if isSampling {
+				c.Sampling = &sampling
+				return nil
+			}
+		}
+
 		version := Version{}
 
 		for k, v := range actual {
@@ -724,6 +769,14 @@ func (c *VersionConfig) MarshalJSON() ([]byte, error) {
 		return json.Marshal(VersionEvery)
 	}
 
+	if c.Sampling != nil {
+		if c.Sampling.LatestPer != "" {
+			return json.Marshal(map[string]string{VersionSampleLatestPer: string(c.Sampling.LatestPer)})
+		}
+
+		return json.Marshal(map[string]string{VersionSampleEvery: c.Sampling.Every.String()})
+	}
+
 	if c.Pinned != nil {
 		return json.Marshal(c.Pinned)
 	}
@@ -731,6 +784,78 @@ func (c *VersionConfig) MarshalJSON() ([]byte, error) {
 	return json.Marshal("")
 }
 
+// parseVersionSampling parses `every: <duration>` and `latest_per: <period>`.
+// Any other single field is left to be a pinned version.
+func parseVersionSampling(config map[string]interface{}) (VersionSampling, bool, error) {
+	if every, found := config[VersionSampleEvery]; found {
+		interval, ok := every.(string)
+		if !ok {
+			return VersionSampling{}, false, fmt.Errorf("the value %v of %s is not a string", every, VersionSampleEvery)
+		}
+
+		duration, err := time.ParseDuration(interval)
+		if err != nil {
+			return VersionSampling{}, false, fmt.Errorf("invalid version interval '%s': %w", interval, err)
+		}
+
+		if duration <= 0 {
+			return VersionSampling{}, false, fmt.Errorf("invalid version interval '%s': must be positive", interval)
+		}
+
+		return VersionSampling{Every: duration}, true, nil
+	}
+
+	if latestPer, found := config[VersionSampleLatestPer]; found {
+		period, _ := latestPer.(string)
+
+		switch VersionPeriod(period) {
+		case VersionPeriodHour, VersionPeriodDay, VersionPeriodWeek:
+			return VersionSampling{LatestPer: VersionPeriod(period)}, true, nil
+		default:
+			return VersionSampling{}, false, fmt.Errorf("invalid version period '%v': must be one of hour, day or week", latestPer)
+		}
+	}
+
+	return VersionSampling{}, false, nil
+}
+
+// VersionSampling picks a single version out of each window of versions: the
+// latest version found within Every of the first version of the window, or
+// the latest version found in each period, e.g. each day.
+type VersionSampling struct {
+	Every     time.Duration
+	LatestPer VersionPeriod
+}
+
+type VersionPeriod string
+
+const (
+	VersionPeriodHour VersionPeriod = "hour"
+	VersionPeriodDay  VersionPeriod = "day"
+	VersionPeriodWeek VersionPeriod = "week"
+)
+
+// WindowEnd returns when the window which starts with a version found at the
+// given time is over. Periods are in UTC, with weeks starting on Monday.
+func (s VersionSampling) WindowEnd(found time.Time) time.Time {
+	if s.LatestPer == "" {
+		return found.Add(s.Every)
+	}
+
+	found = found.UTC()
+	day := time.Date(found.Year(), found.Month(), found.Day(), 0, 0, 0, 0, time.UTC)
+
+	switch s.LatestPer {
+	case VersionPeriodHour:
+		return found.Truncate(time.Hour).Add(time.Hour)
+	case VersionPeriodWeek:
+		daysIntoWeek := (int(day.Weekday()) + 6) % 7
+		return day.AddDate(0, 0, 7-daysIntoWeek)
+	default:
+		return day.AddDate(0, 0, 1)
+	}
+}
+
 const InputsAll = "all"
 const InputsDetect = "detect"
 
@@ -429,6 +429,51 @@ var factoryTests = []StepTest{
 			Duration: "1h",
 		},
 	},
+	{
+		Title: "when modifier",
+
+		ConfigYAML: `
+			load_var: some-var
+			file: some-file
+			when: ((.:flag))
+		`,
+
+		StepConfig: &atc.WhenStep{
+			Step: &atc.LoadVarStep{
+				Name: "some-var",
+				File: "some-file",
+			},
+			Condition: "((.:flag))",
+		},
+	},
+	{
+		Title: "when modifier with hooks",
+
+		ConfigYAML: `
+			load_var: some-var
+			file: some-file
+			when: false
+			on_success:
+			  load_var: success-var
+			  file: success-file
+		`,
+
+		StepConfig: &atc.WhenStep{
+			Step: &atc.OnSuccessStep{
+				Step: &atc.LoadVarStep{
+					Name: "some-var",
+					File: "some-file",
+				},
+				Hook: atc.Step{
+					Config: &atc.LoadVarStep{
+						Name: "success-var",
+						File: "success-file",
+					},
+				},
+			},
+			Condition: false,
+		},
+	},
 	{
 		Title: "attempts modifier",
 
@@ -99,6 +99,7 @@ func (config TaskConfig) Validate() error {
 
 	errors = append(errors, config.validateInputContainsNames()...)
 	errors = append(errors, config.validateOutputContainsNames()...)
+	errors = append(errors, config.validatePlatformImage()...)
 
 	if len(errors) > 0 {
 		return TaskValidationError{
@@ -109,6 +110,30 @@ func (config TaskConfig) Validate() error {
 	return nil
 }
 
+// PlatformRunsWithoutImage returns true for platforms whose workers run tasks
+// directly on the host (i.e. with Houdini), rather than in a container image.
+func PlatformRunsWithoutImage(platform string) bool {
+	return platform == "windows" || platform == "darwin"
+}
+
+func (config TaskConfig) validatePlatformImage() []string {
+	if !PlatformRunsWithoutImage(config.Platform) {
+		return nil
+	}
+
+	var messages []string
+
+	if config.ImageResource != nil {
+		messages = append(messages, fmt.Sprintf("image_resource is not supported for tasks on the '%s' platform, as they run without a container image; remove image_resource", config.Platform))
+	}
+
+	if config.RootfsURI != "" {
+		messages = append(messages, fmt.Sprintf("rootfs_uri is not supported for tasks on the '%s' platform, as they run without a container image; remove rootfs_uri", config.Platform))
+	}
+
+	return messages
+}
+
 func (config TaskConfig) validateOutputContainsNames() []string {
 	var messages []string
 
@@ -202,6 +202,27 @@ run: {path: a/file}
 			})
 		})
 
+		Context("when the platform runs tasks without an image", func() {
+			BeforeEach(func() {
+				invalidConfig.Platform = "windows"
+			})
+
+			It("is valid without an image", func() {
+				Expect(invalidConfig.Validate()).To(Succeed())
+			})
+
+			It("returns an error when image_resource is specified", func() {
+				invalidConfig.ImageResource = &ImageResource{Type: "registry-image"}
+				Expect(invalidConfig.Validate()).To(MatchError(ContainSubstring("image_resource is not supported for tasks on the 'windows' platform")))
+			})
+
+			It("returns an error when rootfs_uri is specified", func() {
+				invalidConfig.Platform = "darwin"
+				invalidConfig.RootfsURI = "docker:///busybox"
+				Expect(invalidConfig.Validate()).To(MatchError(ContainSubstring("rootfs_uri is not supported for tasks on the 'darwin' platform")))
+			})
+		})
+
 		Context("when platform is missing", func() {
 			BeforeEach(func() {
 				invalidConfig.Platform = ""
@@ -2,6 +2,8 @@ package atc
 
 import (
 	"errors"
+	"fmt"
+	"net/url"
 )
 
 var (
@@ -19,6 +21,78 @@ func (team Team) Validate() error {
 	return team.Auth.Validate()
 }
 
+// TeamWorkerKeys are the SSH keys, in authorized_keys format, with which the
+// team's workers may register with the TSA.
+type TeamWorkerKeys struct {
+	Team string   `json:"team"`
+	Keys []string `json:"keys"`
+}
+
+// InterceptSettings control whether the containers of the team may be
+// intercepted, and whether what happens in intercept sessions is recorded to
+// the builds' events.
+type InterceptSettings struct {
+	Disabled          bool `json:"disabled"`
+	RecordTranscripts bool `json:"record_transcripts"`
+}
+
+// PrivilegedPolicy controls whether the team's tasks and resource types may
+// run privileged containers.
+type PrivilegedPolicy string
+
+const (
+	PrivilegedPolicyAllow PrivilegedPolicy = "allow"
+	PrivilegedPolicyWarn  PrivilegedPolicy = "warn"
+	PrivilegedPolicyDeny  PrivilegedPolicy = "deny"
+)
+
+func (policy PrivilegedPolicy) Validate() error {
+	switch policy {
+	case PrivilegedPolicyAllow, PrivilegedPolicyWarn, PrivilegedPolicyDeny:
+		return nil
+	default:
+		return fmt.Errorf("unknown privileged policy '%s' (must be one of: allow, warn, deny)", policy)
+	}
+}
+
+// PrivilegedSettings control how the use of 'privileged: true' in the team's
+// tasks and resource types is treated, both when pipelines are set and when
+// their containers are created.
+type PrivilegedSettings struct {
+	Policy PrivilegedPolicy `json:"policy"`
+}
+
+// ProxySettings are the proxies through which the containers of the team
+// egress. Each setting takes precedence over the one configured on the
+// worker running the container.
+type ProxySettings struct {
+	HTTPProxy  string `json:"http_proxy,omitempty"`
+	HTTPSProxy string `json:"https_proxy,omitempty"`
+	NoProxy    string `json:"no_proxy,omitempty"`
+}
+
+func (settings ProxySettings) Validate() error {
+	err := validateProxyURL("http_proxy", settings.HTTPProxy)
+	if err != nil {
+		return err
+	}
+
+	return validateProxyURL("https_proxy", settings.HTTPSProxy)
+}
+
+func validateProxyURL(name string, proxy string) error {
+	if proxy == "" {
+		return nil
+	}
+
+	proxyURL, err := url.Parse(proxy)
+	if err != nil || proxyURL.Scheme == "" || proxyURL.Host == "" {
+		return fmt.Errorf("invalid %s '%s' (must be a URL such as http://proxy:3128)", name, proxy)
+	}
+
+	return nil
+}
+
 type TeamAuth map[string]map[string][]string
 
 func (auth TeamAuth) Validate() error {
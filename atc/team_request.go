@@ -0,0 +1,57 @@
+package atc
+
+import (
+	"errors"
+	"fmt"
+)
+
+type TeamRequestStatus string
+
+const (
+	TeamRequestPending  TeamRequestStatus = "pending"
+	TeamRequestApproved TeamRequestStatus = "approved"
+	TeamRequestRejected TeamRequestStatus = "rejected"
+)
+
+// TeamRequest is a request by a user for a team to be created with the given
+// auth config. The team is created once an admin approves the request.
+type TeamRequest struct {
+	ID       int      `json:"id"`
+	TeamName string   `json:"team_name"`
+	Auth     TeamAuth `json:"auth"`
+	Reason   string   `json:"reason,omitempty"`
+
+	Status      TeamRequestStatus `json:"status"`
+	RequestedBy string            `json:"requested_by"`
+	RequestedAt int64             `json:"requested_at"`
+
+	DecidedBy string `json:"decided_by,omitempty"`
+	DecidedAt int64  `json:"decided_at,omitempty"`
+	Comment   string `json:"comment,omitempty"`
+}
+
+// Validate checks the requested team's name and auth config. Unlike the names
+// of teams set by admins, a requested team's name must be a valid identifier.
+func (request TeamRequest) Validate() error {
+	warning, err := ValidateIdentifier(request.TeamName, "team")
+	if err != nil {
+		return err
+	}
+
+	if warning != nil {
+		return errors.New(warning.Message)
+	}
+
+	err = request.Auth.Validate()
+	if err != nil {
+		return fmt.Errorf("invalid auth: %w", err)
+	}
+
+	return nil
+}
+
+// TeamRequestDecision is the body of a request approving or rejecting a team
+// request, with an optional comment for the user who requested it.
+type TeamRequestDecision struct {
+	Comment string `json:"comment,omitempty"`
+}
@@ -8,6 +8,9 @@ type User struct {
 	Connector string `json:"connector,omitempty"`
 	LastLogin int64  `json:"last_login,omitempty"`
 	Sub       string `json:"sub,omitempty"`
+
+	// Roles are the user's roles in a team, when listing a team's users.
+	Roles []string `json:"roles,omitempty"`
 }
 
 type UserInfo struct {
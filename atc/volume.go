@@ -25,4 +25,9 @@ type Volume struct {
 	PipelineInstanceVars InstanceVars            `json:"pipeline_instance_vars"`
 	JobName              string                  `json:"job_name"`
 	StepName             string                  `json:"step_name"`
+
+	// State and Size are only given when inspecting a build's volumes. Size is
+	// omitted if the volume's worker could not report it.
+	State string `json:"state,omitempty"`
+	Size  *int64 `json:"size,omitempty"`
 }
@@ -0,0 +1,158 @@
+package warmpool
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/runtime"
+	"github.com/concourse/concourse/atc/worker"
+)
+
+// WorkerTimeout is how long the manager waits for a worker to create a warm
+// container on. Warm containers are not worth waiting for a busy cluster.
+const WorkerTimeout = time.Minute
+
+var errImageNotFound = errors.New("image volume not found")
+
+//counterfeiter:generate . Pool
+type Pool interface {
+	FindOrSelectWorker(context.Context, db.ContainerOwner, runtime.ContainerSpec, worker.Spec, worker.PlacementStrategy, worker.PoolCallback) (runtime.Worker, error)
+	ReleaseWorker(lager.Logger, runtime.ContainerSpec, runtime.Worker, worker.PlacementStrategy)
+	LocateVolume(ctx context.Context, teamID int, handle string) (runtime.Volume, runtime.Worker, bool, error)
+}
+
+// Manager keeps the warm pools of jobs filled, creating containers from the
+// templates recorded by their builds.
+type Manager struct {
+	repository db.WarmPoolRepository
+	pool       Pool
+	strategy   worker.PlacementStrategy
+}
+
+func NewManager(repository db.WarmPoolRepository, pool Pool, strategy worker.PlacementStrategy) *Manager {
+	return &Manager{
+		repository: repository,
+		pool:       pool,
+		strategy:   strategy,
+	}
+}
+
+// Run removes the stale slots of warm pools and then creates containers for
+// each template until its pool is full.
+func (manager *Manager) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx).Session("warm-pool-manager")
+
+	logger.Debug("start")
+	defer logger.Debug("done")
+
+	removed, err := manager.repository.RemoveStaleSlots()
+	if err != nil {
+		logger.Error("failed-to-remove-stale-slots", err)
+		return err
+	}
+
+	if removed > 0 {
+		logger.Debug("removed-stale-slots", lager.Data{"removed": removed})
+	}
+
+	templates, err := manager.repository.Templates()
+	if err != nil {
+		logger.Error("failed-to-get-templates", err)
+		return err
+	}
+
+	for _, template := range templates {
+		tLog := logger.Session("fill", lager.Data{
+			"job-id": template.JobID,
+			"step":   template.StepName,
+		})
+
+		for ready := template.Ready; ready < template.Size; ready++ {
+			err := manager.warm(lagerctx.NewContext(ctx, tLog), template)
+			if err == errImageNotFound {
+				// the image has been collected; the next build will record a
+				// template with a fresh one
+				tLog.Debug("image-volume-not-found")
+				break
+			}
+
+			if err != nil {
+				// try again on the next run rather than hammering a worker
+				// which cannot create the container
+				tLog.Error("failed-to-warm-container", err)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+func (manager *Manager) warm(ctx context.Context, dbTemplate db.WarmPoolTemplate) error {
+	logger := lagerctx.FromContext(ctx)
+
+	var template Template
+	err := json.Unmarshal(dbTemplate.Spec, &template)
+	if err != nil {
+		return err
+	}
+
+	var image runtime.Artifact
+	if template.ImageVolume != "" {
+		volume, _, found, err := manager.pool.LocateVolume(ctx, template.TeamID, template.ImageVolume)
+		if err != nil {
+			return err
+		}
+
+		if !found {
+			return errImageNotFound
+		}
+
+		image = volume
+	}
+
+	slotID, err := manager.repository.CreateSlot(dbTemplate)
+	if err != nil {
+		return err
+	}
+
+	owner := db.NewWarmPoolContainerOwner(slotID, template.TeamID)
+	containerSpec := template.ContainerSpec(image)
+
+	ctx, cancel := context.WithTimeout(ctx, WorkerTimeout)
+	defer cancel()
+
+	chosenWorker, err := manager.pool.FindOrSelectWorker(ctx, owner, containerSpec, template.WorkerSpec(), manager.strategy, nil)
+	if err != nil {
+		return err
+	}
+
+	defer manager.pool.ReleaseWorker(logger, containerSpec, chosenWorker, manager.strategy)
+
+	container, _, err := chosenWorker.FindOrCreateContainer(ctx, owner, template.Metadata, containerSpec, noopDelegate{})
+	if err != nil {
+		return err
+	}
+
+	err = container.SetProperty(InputsProperty, InputsPending)
+	if err != nil {
+		return err
+	}
+
+	logger.Debug("warmed-container", lager.Data{
+		"handle": container.DBContainer().Handle(),
+		"worker": chosenWorker.Name(),
+	})
+
+	return nil
+}
+
+type noopDelegate struct{}
+
+func (noopDelegate) StreamingVolume(lager.Logger, string, string, string)  {}
+func (noopDelegate) WaitingForStreamedVolume(lager.Logger, string, string) {}
@@ -0,0 +1,150 @@
+package warmpool_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/runtime"
+	"github.com/concourse/concourse/atc/runtime/runtimetest"
+	"github.com/concourse/concourse/atc/warmpool"
+	"github.com/concourse/concourse/atc/warmpool/warmpoolfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Manager", func() {
+	var (
+		fakeRepository *dbfakes.FakeWarmPoolRepository
+		fakePool       *warmpoolfakes.FakePool
+		chosenWorker   *runtimetest.Worker
+
+		template   warmpool.Template
+		dbTemplate db.WarmPoolTemplate
+
+		runErr error
+	)
+
+	BeforeEach(func() {
+		fakeRepository = new(dbfakes.FakeWarmPoolRepository)
+		fakePool = new(warmpoolfakes.FakePool)
+
+		template = warmpool.Template{
+			TeamID:      1,
+			JobID:       2,
+			StepName:    "some-task",
+			ImageVolume: "some-image",
+			Env:         []string{"SOME=env"},
+			Dir:         "some-dir",
+			Inputs:      []string{"some-dir/some-input"},
+			Metadata:    db.ContainerMetadata{Type: db.ContainerTypeTask, StepName: "some-task", JobID: 2},
+		}
+
+		spec, err := json.Marshal(template)
+		Expect(err).ToNot(HaveOccurred())
+
+		dbTemplate = db.WarmPoolTemplate{
+			JobID:    2,
+			StepName: "some-task",
+			TeamID:   1,
+			Key:      template.Key(),
+			Spec:     spec,
+			Size:     2,
+			Expiry:   time.Hour,
+			Ready:    1,
+		}
+
+		fakeRepository.TemplatesReturns([]db.WarmPoolTemplate{dbTemplate}, nil)
+		fakeRepository.CreateSlotReturns(42, nil)
+
+		owner := db.NewWarmPoolContainerOwner(42, 1)
+		chosenWorker = runtimetest.NewWorker("some-worker").
+			WithContainer(owner, runtimetest.NewContainer(), nil)
+
+		fakePool.FindOrSelectWorkerReturns(chosenWorker, nil)
+		fakePool.LocateVolumeReturns(runtimetest.NewVolume("some-image"), chosenWorker, true, nil)
+	})
+
+	JustBeforeEach(func() {
+		runErr = warmpool.NewManager(fakeRepository, fakePool, nil).Run(context.TODO())
+	})
+
+	It("removes stale slots first", func() {
+		Expect(runErr).ToNot(HaveOccurred())
+		Expect(fakeRepository.RemoveStaleSlotsCallCount()).To(Equal(1))
+	})
+
+	It("creates containers until the pool is full", func() {
+		Expect(runErr).ToNot(HaveOccurred())
+
+		Expect(fakeRepository.CreateSlotCallCount()).To(Equal(1))
+		Expect(fakeRepository.CreateSlotArgsForCall(0)).To(Equal(dbTemplate))
+
+		Expect(fakePool.FindOrSelectWorkerCallCount()).To(Equal(1))
+		_, owner, containerSpec, workerSpec, _, _ := fakePool.FindOrSelectWorkerArgsForCall(0)
+		Expect(owner).To(Equal(db.NewWarmPoolContainerOwner(42, 1)))
+		Expect(containerSpec.ImageSpec.ImageArtifact.Handle()).To(Equal("some-image"))
+		Expect(containerSpec.Env).To(Equal([]string{"SOME=env"}))
+		Expect(containerSpec.Outputs).To(Equal(runtime.OutputPaths{
+			"input:some-dir/some-input": "some-dir/some-input",
+		}))
+		Expect(workerSpec.TeamID).To(Equal(1))
+
+		Expect(fakePool.ReleaseWorkerCallCount()).To(Equal(1))
+	})
+
+	It("marks the container's inputs as pending", func() {
+		Expect(chosenWorker.Containers[0].Props).To(HaveKeyWithValue(warmpool.InputsProperty, warmpool.InputsPending))
+	})
+
+	Context("when the image has been collected", func() {
+		BeforeEach(func() {
+			fakePool.LocateVolumeReturns(nil, nil, false, nil)
+		})
+
+		It("does not create a container", func() {
+			Expect(runErr).ToNot(HaveOccurred())
+			Expect(fakeRepository.CreateSlotCallCount()).To(BeZero())
+			Expect(fakePool.FindOrSelectWorkerCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when the pool is full", func() {
+		BeforeEach(func() {
+			dbTemplate.Ready = 2
+			fakeRepository.TemplatesReturns([]db.WarmPoolTemplate{dbTemplate}, nil)
+		})
+
+		It("does not create a container", func() {
+			Expect(runErr).ToNot(HaveOccurred())
+			Expect(fakeRepository.CreateSlotCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when no worker can be found", func() {
+		BeforeEach(func() {
+			dbTemplate.Ready = 0
+			fakeRepository.TemplatesReturns([]db.WarmPoolTemplate{dbTemplate}, nil)
+			fakePool.FindOrSelectWorkerReturns(nil, context.DeadlineExceeded)
+		})
+
+		It("gives up on the template until the next run", func() {
+			Expect(runErr).ToNot(HaveOccurred())
+			Expect(fakePool.FindOrSelectWorkerCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when removing stale slots fails", func() {
+		BeforeEach(func() {
+			fakeRepository.RemoveStaleSlotsReturns(0, errors.New("nope"))
+		})
+
+		It("returns the error", func() {
+			Expect(runErr).To(MatchError("nope"))
+			Expect(fakeRepository.TemplatesCallCount()).To(BeZero())
+		})
+	})
+})
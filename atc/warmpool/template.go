@@ -0,0 +1,181 @@
+// Package warmpool keeps containers for the tasks of jobs with warm pools
+// created ahead of their builds, so that the tasks can start straight away.
+package warmpool
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/runtime"
+	"github.com/concourse/concourse/atc/worker"
+)
+
+// InputsProperty is set on a warm container until its task's inputs have
+// been streamed into it. Warm containers are created before the inputs
+// exist, so each input gets an empty volume which the task fills in once it
+// claims the container.
+const InputsProperty = "concourse:warm-pool-inputs"
+
+const (
+	InputsPending  = "pending"
+	InputsStreamed = "streamed"
+)
+
+// Template is how to create a task's warm containers. It is the part of the
+// task's container which does not depend on the build.
+type Template struct {
+	TeamID   int    `json:"team_id"`
+	TeamName string `json:"team_name"`
+	JobID    int    `json:"job_id"`
+	StepName string `json:"step_name"`
+
+	// ImageVolume is the handle of the volume holding the task's image, as
+	// fetched from its image_resource.
+	ImageVolume       string `json:"image_volume,omitempty"`
+	ImageURL          string `json:"image_url,omitempty"`
+	ImageResourceType string `json:"image_resource_type,omitempty"`
+	Privileged        bool   `json:"privileged,omitempty"`
+
+	Env     []string          `json:"env,omitempty"`
+	Dir     string            `json:"dir"`
+	Inputs  []string          `json:"inputs,omitempty"`
+	Outputs map[string]string `json:"outputs,omitempty"`
+	Caches  []string          `json:"caches,omitempty"`
+
+	CPU    *uint64 `json:"cpu,omitempty"`
+	Memory *uint64 `json:"memory,omitempty"`
+
+	Platform        string   `json:"platform,omitempty"`
+	Tags            []string `json:"tags,omitempty"`
+	TeamWorkersOnly bool     `json:"team_workers_only,omitempty"`
+
+	Metadata db.ContainerMetadata `json:"metadata"`
+}
+
+// NewTemplate returns the template for the container of a task. env is the
+// task's own environment, leaving out the build's metadata. It returns false
+// if the container cannot be warmed, because its image comes from the build.
+func NewTemplate(spec runtime.ContainerSpec, env []string, workerSpec worker.Spec, metadata db.ContainerMetadata) (Template, bool) {
+	template := Template{
+		TeamID:   spec.TeamID,
+		TeamName: spec.TeamName,
+		JobID:    spec.JobID,
+		StepName: spec.StepName,
+
+		ImageURL:          spec.ImageSpec.ImageURL,
+		ImageResourceType: spec.ImageSpec.ResourceType,
+		Privileged:        spec.ImageSpec.Privileged,
+
+		Dir:     spec.Dir,
+		Outputs: spec.Outputs,
+		Caches:  spec.Caches,
+
+		CPU:    spec.Limits.CPU,
+		Memory: spec.Limits.Memory,
+
+		Platform:        workerSpec.Platform,
+		Tags:            workerSpec.Tags,
+		TeamWorkersOnly: workerSpec.TeamWorkersOnly,
+	}
+
+	if spec.ImageSpec.ImageArtifact != nil {
+		template.ImageVolume = spec.ImageSpec.ImageArtifact.Handle()
+	}
+
+	if template.ImageVolume == "" && template.ImageURL == "" && template.ImageResourceType == "" {
+		return Template{}, false
+	}
+
+	for _, input := range spec.Inputs {
+		template.Inputs = append(template.Inputs, input.DestinationPath)
+	}
+
+	sort.Strings(template.Inputs)
+
+	// the task's params are a map, so their order changes from build to build
+	template.Env = append([]string{}, env...)
+	sort.Strings(template.Env)
+
+	// the metadata which identifies the build is filled in when a build
+	// claims the container
+	template.Metadata = metadata
+	template.Metadata.BuildID = 0
+	template.Metadata.BuildName = ""
+	template.Metadata.Attempt = ""
+
+	return template, true
+}
+
+// Key identifies the containers created from the template. Only a container
+// with the same key as a task's template can be claimed by the task.
+func (template Template) Key() string {
+	payload, _ := json.Marshal(template)
+	sum := sha256.Sum256(payload)
+	return hex.EncodeToString(sum[:])
+}
+
+// ContainerSpec returns the spec of a warm container with the given image.
+// The inputs are mounted as empty volumes, as are the outputs.
+func (template Template) ContainerSpec(image runtime.Artifact) runtime.ContainerSpec {
+	outputs := runtime.OutputPaths{}
+	for name, path := range template.Outputs {
+		outputs[name] = path
+	}
+
+	for _, path := range template.Inputs {
+		outputs["input:"+path] = path
+	}
+
+	return runtime.ContainerSpec{
+		TeamID:   template.TeamID,
+		TeamName: template.TeamName,
+		JobID:    template.JobID,
+		StepName: template.StepName,
+
+		ImageSpec: runtime.ImageSpec{
+			ImageArtifact: image,
+			ImageURL:      template.ImageURL,
+			ResourceType:  template.ImageResourceType,
+			Privileged:    template.Privileged,
+		},
+		Env:  template.Env,
+		Type: db.ContainerTypeTask,
+
+		Dir:     template.Dir,
+		Outputs: outputs,
+		Caches:  template.Caches,
+
+		Limits: runtime.ContainerLimits{
+			CPU:    template.CPU,
+			Memory: template.Memory,
+		},
+	}
+}
+
+func (template Template) WorkerSpec() worker.Spec {
+	return worker.Spec{
+		Platform:        template.Platform,
+		Tags:            template.Tags,
+		TeamID:          template.TeamID,
+		TeamWorkersOnly: template.TeamWorkersOnly,
+	}
+}
+
+// DBTemplate returns the template as it is recorded for the job's warm pool.
+func (template Template) DBTemplate() (db.WarmPoolTemplate, error) {
+	spec, err := json.Marshal(template)
+	if err != nil {
+		return db.WarmPoolTemplate{}, err
+	}
+
+	return db.WarmPoolTemplate{
+		JobID:    template.JobID,
+		StepName: template.StepName,
+		TeamID:   template.TeamID,
+		Key:      template.Key(),
+		Spec:     spec,
+	}, nil
+}
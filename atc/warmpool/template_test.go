@@ -0,0 +1,75 @@
+package warmpool_test
+
+import (
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/runtime"
+	"github.com/concourse/concourse/atc/runtime/runtimetest"
+	"github.com/concourse/concourse/atc/warmpool"
+	"github.com/concourse/concourse/atc/worker"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Template", func() {
+	var (
+		spec     runtime.ContainerSpec
+		metadata db.ContainerMetadata
+	)
+
+	BeforeEach(func() {
+		spec = runtime.ContainerSpec{
+			TeamID:   1,
+			JobID:    2,
+			StepName: "some-task",
+			ImageSpec: runtime.ImageSpec{
+				ImageArtifact: runtimetest.NewVolume("some-image"),
+			},
+			Dir: "some-dir",
+			Inputs: []runtime.Input{
+				{DestinationPath: "some-dir/b"},
+				{DestinationPath: "some-dir/a"},
+			},
+			Outputs: runtime.OutputPaths{"out": "some-dir/out/"},
+		}
+
+		metadata = db.ContainerMetadata{
+			Type:      db.ContainerTypeTask,
+			StepName:  "some-task",
+			JobID:     2,
+			BuildID:   3,
+			BuildName: "4",
+		}
+	})
+
+	It("leaves out the build", func() {
+		template, ok := warmpool.NewTemplate(spec, []string{"B=2", "A=1"}, worker.Spec{TeamID: 1}, metadata)
+		Expect(ok).To(BeTrue())
+
+		Expect(template.ImageVolume).To(Equal("some-image"))
+		Expect(template.Inputs).To(Equal([]string{"some-dir/a", "some-dir/b"}))
+		Expect(template.Env).To(Equal([]string{"A=1", "B=2"}))
+		Expect(template.Metadata.BuildID).To(BeZero())
+		Expect(template.Metadata.BuildName).To(BeEmpty())
+		Expect(template.Metadata.JobID).To(Equal(2))
+	})
+
+	It("has the same key regardless of the order of the env", func() {
+		template, _ := warmpool.NewTemplate(spec, []string{"B=2", "A=1"}, worker.Spec{}, metadata)
+		sameTemplate, _ := warmpool.NewTemplate(spec, []string{"A=1", "B=2"}, worker.Spec{}, metadata)
+		Expect(template.Key()).To(Equal(sameTemplate.Key()))
+
+		otherTemplate, _ := warmpool.NewTemplate(spec, []string{"A=2", "B=2"}, worker.Spec{}, metadata)
+		Expect(template.Key()).ToNot(Equal(otherTemplate.Key()))
+	})
+
+	Context("when the container has no image", func() {
+		BeforeEach(func() {
+			spec.ImageSpec = runtime.ImageSpec{}
+		})
+
+		It("cannot be warmed", func() {
+			_, ok := warmpool.NewTemplate(spec, nil, worker.Spec{}, metadata)
+			Expect(ok).To(BeFalse())
+		})
+	})
+})
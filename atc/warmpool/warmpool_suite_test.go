@@ -0,0 +1,13 @@
+package warmpool_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestWarmPool(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Warm Pool Suite")
+}
@@ -0,0 +1,167 @@
+// Package webhooks delivers the events queued for outgoing webhooks.
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc/db"
+)
+
+const (
+	SignatureHeader = "X-Concourse-Signature-256"
+	EventHeader     = "X-Concourse-Event"
+	DeliveryHeader  = "X-Concourse-Delivery"
+)
+
+const (
+	// MaxAttempts is the number of attempts of a delivery before it is
+	// marked as failed.
+	MaxAttempts = 8
+
+	initialBackoff = 10 * time.Second
+	maxBackoff     = time.Hour
+
+	batchSize = 50
+
+	// deliveryRetention is how long succeeded and failed deliveries are kept
+	// around for their status to be visible through the API.
+	deliveryRetention = 7 * 24 * time.Hour
+)
+
+type Deliverer struct {
+	repository db.OutgoingWebhookRepository
+	client     *http.Client
+	clock      func() time.Time
+}
+
+func NewDeliverer(repository db.OutgoingWebhookRepository, client *http.Client) *Deliverer {
+	return &Deliverer{
+		repository: repository,
+		client:     client,
+		clock:      time.Now,
+	}
+}
+
+// Run attempts each of the deliveries which are due, and cleans up old
+// deliveries.
+func (deliverer *Deliverer) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx).Session("webhook-deliverer")
+
+	logger.Debug("start")
+	defer logger.Debug("done")
+
+	deliveries, err := deliverer.repository.PendingDeliveries(batchSize)
+	if err != nil {
+		logger.Error("failed-to-get-pending-deliveries", err)
+		return err
+	}
+
+	wg := new(sync.WaitGroup)
+	for _, delivery := range deliveries {
+		wg.Add(1)
+
+		go func(delivery db.PendingWebhookDelivery) {
+			defer wg.Done()
+
+			deliverer.deliver(ctx, logger.Session("deliver", lager.Data{
+				"delivery": delivery.ID,
+				"event":    delivery.Event,
+			}), delivery)
+		}(delivery)
+	}
+
+	wg.Wait()
+
+	err = deliverer.repository.DeleteDeliveriesBefore(deliverer.clock().Add(-deliveryRetention))
+	if err != nil {
+		logger.Error("failed-to-delete-old-deliveries", err)
+		return err
+	}
+
+	return nil
+}
+
+func (deliverer *Deliverer) deliver(ctx context.Context, logger lager.Logger, delivery db.PendingWebhookDelivery) {
+	responseStatus, err := deliverer.send(ctx, delivery)
+	if err == nil {
+		err = deliverer.repository.DeliverySucceeded(delivery.ID, responseStatus)
+		if err != nil {
+			logger.Error("failed-to-mark-delivery-as-succeeded", err)
+		}
+
+		return
+	}
+
+	logger.Info("delivery-attempt-failed", lager.Data{"error": err.Error()})
+
+	var retryAt *time.Time
+	if attempts := delivery.Attempts + 1; attempts < MaxAttempts {
+		at := deliverer.clock().Add(Backoff(attempts))
+		retryAt = &at
+	}
+
+	err = deliverer.repository.DeliveryFailed(delivery.ID, responseStatus, err.Error(), retryAt)
+	if err != nil {
+		logger.Error("failed-to-mark-delivery-as-failed", err)
+	}
+}
+
+func (deliverer *Deliverer) send(ctx context.Context, delivery db.PendingWebhookDelivery) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Concourse-Webhook")
+	req.Header.Set(EventHeader, string(delivery.Event))
+	req.Header.Set(DeliveryHeader, strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set(SignatureHeader, Sign(delivery.Secret, delivery.Payload))
+
+	resp, err := deliverer.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("unexpected response status: %s", resp.Status)
+	}
+
+	return resp.StatusCode, nil
+}
+
+// Sign returns the signature of the payload, which receivers can verify by
+// computing the HMAC-SHA256 of the request body with the webhook's secret.
+func Sign(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Backoff returns how long to wait before retrying a delivery after the given
+// number of failed attempts, doubling each time up to an hour.
+func Backoff(attempts int) time.Duration {
+	backoff := initialBackoff
+	for i := 1; i < attempts; i++ {
+		backoff *= 2
+		if backoff >= maxBackoff {
+			return maxBackoff
+		}
+	}
+
+	return backoff
+}
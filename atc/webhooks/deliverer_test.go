@@ -0,0 +1,128 @@
+package webhooks_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/webhooks"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Deliverer", func() {
+	var (
+		server         *ghttp.Server
+		fakeRepository *dbfakes.FakeOutgoingWebhookRepository
+		delivery       db.PendingWebhookDelivery
+
+		runErr error
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		fakeRepository = new(dbfakes.FakeOutgoingWebhookRepository)
+
+		delivery = db.PendingWebhookDelivery{
+			ID:       42,
+			Event:    atc.WebhookEventBuildFinished,
+			Payload:  []byte(`{"event":"build_finished","time":1,"data":{}}`),
+			Attempts: 0,
+			URL:      server.URL() + "/hook",
+			Secret:   "some-secret",
+		}
+
+		fakeRepository.PendingDeliveriesReturns([]db.PendingWebhookDelivery{delivery}, nil)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	JustBeforeEach(func() {
+		runErr = webhooks.NewDeliverer(fakeRepository, http.DefaultClient).Run(context.TODO())
+	})
+
+	Context("when the webhook responds successfully", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest("POST", "/hook"),
+				ghttp.VerifyHeaderKV(webhooks.EventHeader, "build_finished"),
+				ghttp.VerifyHeaderKV(webhooks.DeliveryHeader, "42"),
+				ghttp.VerifyHeaderKV(webhooks.SignatureHeader, webhooks.Sign("some-secret", delivery.Payload)),
+				ghttp.VerifyJSON(`{"event":"build_finished","time":1,"data":{}}`),
+				ghttp.RespondWith(http.StatusAccepted, nil),
+			))
+		})
+
+		It("marks the delivery as succeeded", func() {
+			Expect(runErr).NotTo(HaveOccurred())
+			Expect(server.ReceivedRequests()).To(HaveLen(1))
+
+			Expect(fakeRepository.DeliverySucceededCallCount()).To(Equal(1))
+			id, status := fakeRepository.DeliverySucceededArgsForCall(0)
+			Expect(id).To(Equal(int64(42)))
+			Expect(status).To(Equal(http.StatusAccepted))
+		})
+
+		It("cleans up old deliveries", func() {
+			Expect(fakeRepository.DeleteDeliveriesBeforeCallCount()).To(Equal(1))
+			Expect(fakeRepository.DeleteDeliveriesBeforeArgsForCall(0)).To(BeTemporally("~", time.Now().Add(-7*24*time.Hour), time.Minute))
+		})
+	})
+
+	Context("when the webhook responds with an error", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(ghttp.RespondWith(http.StatusBadGateway, nil))
+		})
+
+		It("schedules a retry with backoff", func() {
+			Expect(fakeRepository.DeliveryFailedCallCount()).To(Equal(1))
+
+			id, status, deliveryErr, retryAt := fakeRepository.DeliveryFailedArgsForCall(0)
+			Expect(id).To(Equal(int64(42)))
+			Expect(status).To(Equal(http.StatusBadGateway))
+			Expect(deliveryErr).To(ContainSubstring("502"))
+			Expect(retryAt).NotTo(BeNil())
+			Expect(*retryAt).To(BeTemporally("~", time.Now().Add(webhooks.Backoff(1)), time.Minute))
+		})
+
+		Context("when it was the last attempt", func() {
+			BeforeEach(func() {
+				delivery.Attempts = webhooks.MaxAttempts - 1
+				fakeRepository.PendingDeliveriesReturns([]db.PendingWebhookDelivery{delivery}, nil)
+			})
+
+			It("gives up on the delivery", func() {
+				Expect(fakeRepository.DeliveryFailedCallCount()).To(Equal(1))
+
+				_, _, _, retryAt := fakeRepository.DeliveryFailedArgsForCall(0)
+				Expect(retryAt).To(BeNil())
+			})
+		})
+	})
+
+	Context("when getting the pending deliveries fails", func() {
+		BeforeEach(func() {
+			fakeRepository.PendingDeliveriesReturns(nil, errors.New("nope"))
+		})
+
+		It("returns the error", func() {
+			Expect(runErr).To(MatchError("nope"))
+		})
+	})
+})
+
+var _ = Describe("Backoff", func() {
+	It("doubles up to an hour", func() {
+		Expect(webhooks.Backoff(1)).To(Equal(10 * time.Second))
+		Expect(webhooks.Backoff(2)).To(Equal(20 * time.Second))
+		Expect(webhooks.Backoff(3)).To(Equal(40 * time.Second))
+		Expect(webhooks.Backoff(20)).To(Equal(time.Hour))
+	})
+})
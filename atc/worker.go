@@ -24,6 +24,7 @@ type Worker struct {
 	ResourceTypes []WorkerResourceType `json:"resource_types"`
 
 	Platform  string `json:"platform"`
+	Runtime   string `json:"runtime,omitempty"`
 	Tags      Tags   `json:"tags"`
 	Team      string `json:"team"`
 	Name      string `json:"name"`
@@ -31,6 +32,124 @@ type Worker struct {
 	StartTime int64  `json:"start_time"`
 	Ephemeral bool   `json:"ephemeral"`
 	State     string `json:"state"`
+
+	// Certificate is the client certificate with which the worker registered
+	// over mutual TLS, if any. It is set by the TSA, never by the worker.
+	Certificate *WorkerCertificate `json:"certificate,omitempty"`
+
+	// Capabilities are the features the worker supports. Workers which
+	// register without them are assumed to have LegacyWorkerCapabilities.
+	Capabilities *WorkerCapabilities `json:"capabilities,omitempty"`
+
+	// DiskUsage is the usage of the disk holding the worker's volumes, as of
+	// its last heartbeat. It is nil if the worker has not reported it.
+	DiskUsage *WorkerDiskUsage `json:"disk_usage,omitempty"`
+
+	// CircuitBreakers are the states of the circuit breakers the web node
+	// serving the request keeps around the worker's Garden and Baggageclaim
+	// servers. They are only reported by the API.
+	CircuitBreakers []WorkerCircuitBreaker `json:"circuit_breakers,omitempty"`
+}
+
+const (
+	CircuitBreakerClosed   = "closed"
+	CircuitBreakerOpen     = "open"
+	CircuitBreakerHalfOpen = "half-open"
+)
+
+// WorkerCircuitBreaker describes a circuit breaker around requests to one of
+// a worker's servers.
+type WorkerCircuitBreaker struct {
+	// Service is either "garden" or "baggageclaim".
+	Service string `json:"service"`
+	State   string `json:"state"`
+
+	ConsecutiveFailures int `json:"consecutive_failures"`
+
+	// OpenedAt is when the breaker last opened, and RetryAt when it will
+	// next let a request through to see if the worker has recovered.
+	OpenedAt int64 `json:"opened_at,omitempty"`
+	RetryAt  int64 `json:"retry_at,omitempty"`
+}
+
+const (
+	StreamingCompressionGzip = "gzip"
+	StreamingCompressionZstd = "zstd"
+)
+
+// StreamingCompressions lists the compression algorithms with which volumes
+// may be streamed.
+var StreamingCompressions = []string{
+	StreamingCompressionGzip,
+	StreamingCompressionZstd,
+}
+
+// WorkerCapabilities describe the features a worker supports, so that the ATC
+// only asks of each worker what it is able to do. This lets new features be
+// rolled out to a fleet of workers running a mix of versions.
+type WorkerCapabilities struct {
+	// StreamingCompression lists the compression algorithms with which the
+	// worker can stream volumes in and out.
+	StreamingCompression []string `json:"streaming_compression"`
+
+	// P2PStreaming is whether volumes can be streamed directly between this
+	// worker and others, rather than through the web node.
+	P2PStreaming bool `json:"p2p_streaming"`
+
+	// ResumableStreaming is whether streams out of the worker's volumes can be
+	// resumed when they are interrupted, and streams into them sent in chunks.
+	ResumableStreaming bool `json:"resumable_streaming"`
+
+	// MaxImageSize is the size, in bytes, of the largest image the worker
+	// will run containers with. Zero means there is no limit.
+	MaxImageSize int64 `json:"max_image_size,omitempty"`
+}
+
+// LegacyWorkerCapabilities are assumed for workers which do not advertise
+// their capabilities, i.e. those running a version which predates them. They
+// are everything such workers were expected to support.
+var LegacyWorkerCapabilities = WorkerCapabilities{
+	StreamingCompression: StreamingCompressions,
+	P2PStreaming:         true,
+}
+
+// SupportsStreamingCompression returns whether the worker can stream volumes
+// compressed with the given algorithm.
+func (c WorkerCapabilities) SupportsStreamingCompression(compression string) bool {
+	for _, supported := range c.StreamingCompression {
+		if supported == compression {
+			return true
+		}
+	}
+
+	return false
+}
+
+// WorkerDiskUsage describes how full the disk holding a worker's volumes is.
+type WorkerDiskUsage struct {
+	TotalBytes uint64 `json:"total_bytes"`
+	UsedBytes  uint64 `json:"used_bytes"`
+}
+
+// UsedPercent returns the percentage of the disk which is in use.
+func (u WorkerDiskUsage) UsedPercent() float64 {
+	if u.TotalBytes == 0 {
+		return 0
+	}
+
+	return float64(u.UsedBytes) / float64(u.TotalBytes) * 100
+}
+
+// WorkerCertificate describes a worker's client certificate.
+type WorkerCertificate struct {
+	SerialNumber string `json:"serial_number"`
+	Subject      string `json:"subject"`
+	Issuer       string `json:"issuer"`
+	NotBefore    int64  `json:"not_before"`
+	NotAfter     int64  `json:"not_after"`
+
+	// Fingerprint is the sha256 of the DER encoding of the certificate.
+	Fingerprint string `json:"fingerprint"`
 }
 
 type Tags []string
@@ -57,9 +176,32 @@ func (t *Tags) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+const (
+	WorkerRuntimeGuardian   = "guardian"
+	WorkerRuntimeContainerd = "containerd"
+	WorkerRuntimeHoudini    = "houdini"
+
+	// WorkerRuntimeKubernetes is the runtime of workers backed by a Kubernetes
+	// cluster. These workers are registered by the web node rather than
+	// through the TSA, so it is not a valid runtime for worker registration.
+	WorkerRuntimeKubernetes = "kubernetes"
+)
+
+// WorkerRuntimes lists the runtimes a worker may register with. Workers which
+// do not report a runtime are assumed to be running Guardian.
+var WorkerRuntimes = []string{
+	WorkerRuntimeGuardian,
+	WorkerRuntimeContainerd,
+	WorkerRuntimeHoudini,
+}
+
 var ErrInvalidWorkerVersion = errors.New("invalid worker version, only numeric characters are allowed")
 var ErrMissingWorkerGardenAddress = errors.New("missing garden address")
 var ErrNoWorkers = errors.New("no workers available for checking")
+var ErrUnknownWorkerRuntime = errors.New("unknown worker runtime")
+var ErrUnknownStreamingCompression = errors.New("unknown streaming compression")
+var ErrInvalidMaxImageSize = errors.New("invalid max image size, must not be negative")
+var ErrInvalidDiskUsage = errors.New("invalid disk usage, more bytes used than the disk holds")
 
 func (w Worker) Validate() error {
 	if w.Version != "" && !regexp.MustCompile(`^[0-9\.]+$`).MatchString(w.Version) {
@@ -70,9 +212,49 @@ func (w Worker) Validate() error {
 		return ErrMissingWorkerGardenAddress
 	}
 
+	if w.Runtime != "" && !w.hasKnownRuntime() {
+		return ErrUnknownWorkerRuntime
+	}
+
+	if w.Capabilities != nil {
+		for _, compression := range w.Capabilities.StreamingCompression {
+			if !isKnownStreamingCompression(compression) {
+				return ErrUnknownStreamingCompression
+			}
+		}
+
+		if w.Capabilities.MaxImageSize < 0 {
+			return ErrInvalidMaxImageSize
+		}
+	}
+
+	if w.DiskUsage != nil && w.DiskUsage.UsedBytes > w.DiskUsage.TotalBytes {
+		return ErrInvalidDiskUsage
+	}
+
 	return nil
 }
 
+func (w Worker) hasKnownRuntime() bool {
+	for _, runtime := range WorkerRuntimes {
+		if w.Runtime == runtime {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isKnownStreamingCompression(compression string) bool {
+	for _, known := range StreamingCompressions {
+		if compression == known {
+			return true
+		}
+	}
+
+	return false
+}
+
 type WorkerResourceType struct {
 	Type                 string `json:"type"`
 	Image                string `json:"image"`
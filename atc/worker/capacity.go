@@ -0,0 +1,89 @@
+package worker
+
+import (
+	"errors"
+	"strings"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/db"
+)
+
+// CapacityChecker tells whether any of the workers compatible with a spec has
+// room for another step under the limits of the configured placement
+// strategies, so that builds can be held back until one does instead of
+// being started only to wait on (or fail to find) a worker.
+type CapacityChecker struct {
+	pool    Pool
+	options PlacementOptions
+}
+
+func NewCapacityChecker(pool Pool, options PlacementOptions) CapacityChecker {
+	return CapacityChecker{
+		pool:    pool,
+		options: options,
+	}
+}
+
+// HasCapacity returns false when no running worker is compatible with the
+// spec, or when every compatible worker is at one of the limits. The limit on
+// active tasks is only considered for steps which run tasks.
+func (checker CapacityChecker) HasCapacity(logger lager.Logger, spec Spec, runsTasks bool) (bool, error) {
+	workers, err := checker.pool.allCompatibleAndRunningWorkers(logger, spec)
+	if err != nil {
+		if errors.Is(err, ErrNoWorkers) || errors.As(err, &NoCompatibleWorkersError{}) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	for _, worker := range workers {
+		hasRoom, err := checker.hasRoom(worker, runsTasks)
+		if err != nil {
+			return false, err
+		}
+
+		if hasRoom {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (checker CapacityChecker) hasRoom(worker db.Worker, runsTasks bool) (bool, error) {
+	for _, s := range checker.options.Strategies {
+		switch strings.TrimSpace(s) {
+		case "limit-active-tasks":
+			if !runsTasks || checker.options.MaxActiveTasksPerWorker == 0 {
+				continue
+			}
+
+			activeTasks, err := worker.ActiveTasks()
+			if err != nil {
+				return false, err
+			}
+
+			if activeTasks >= checker.options.MaxActiveTasksPerWorker {
+				return false, nil
+			}
+		case "limit-active-containers":
+			strategy := limitActiveContainersStrategy{MaxContainers: checker.options.MaxActiveContainersPerWorker}
+			if !strategy.workerSatisfies(worker) {
+				return false, nil
+			}
+		case "limit-active-volumes":
+			strategy := limitActiveVolumesStrategy{MaxVolumes: checker.options.MaxActiveVolumesPerWorker}
+			if !strategy.workerSatisfies(worker) {
+				return false, nil
+			}
+		case "limit-disk-usage":
+			strategy := limitDiskUsageStrategy{MaxPercent: checker.options.MaxDiskUsagePerWorker}
+			if !strategy.workerSatisfies(worker) {
+				return false, nil
+			}
+		}
+	}
+
+	return true, nil
+}
@@ -0,0 +1,105 @@
+package worker_test
+
+import (
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/worker"
+	grt "github.com/concourse/concourse/atc/worker/gardenruntime/gardenruntimetest"
+	"github.com/concourse/concourse/atc/worker/workertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CapacityChecker", func() {
+	Test("has capacity when a compatible worker is below the limits", func() {
+		scenario := Setup(
+			workertest.WithBasicJob(),
+			workertest.WithWorkers(
+				grt.NewWorker("worker1").
+					WithContainersCreatedInDBAndGarden(
+						grt.NewContainer("c1"),
+						grt.NewContainer("c2"),
+					),
+				grt.NewWorker("worker2").
+					WithContainersCreatedInDBAndGarden(
+						grt.NewContainer("c3"),
+					),
+			),
+		)
+
+		checker := worker.NewCapacityChecker(scenario.Pool, worker.PlacementOptions{
+			Strategies:                   []string{"limit-active-containers"},
+			MaxActiveContainersPerWorker: 2,
+		})
+
+		hasCapacity, err := checker.HasCapacity(logger, worker.Spec{TeamID: scenario.TeamID}, false)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(hasCapacity).To(BeTrue())
+	})
+
+	Test("has no capacity when every compatible worker is at the limits", func() {
+		scenario := Setup(
+			workertest.WithBasicJob(),
+			workertest.WithWorkers(
+				grt.NewWorker("worker1").
+					WithContainersCreatedInDBAndGarden(
+						grt.NewContainer("c1"),
+						grt.NewContainer("c2"),
+					),
+				grt.NewWorker("worker2").
+					WithTags("some-tag"),
+			),
+		)
+
+		checker := worker.NewCapacityChecker(scenario.Pool, worker.PlacementOptions{
+			Strategies:                   []string{"limit-active-containers"},
+			MaxActiveContainersPerWorker: 2,
+		})
+
+		hasCapacity, err := checker.HasCapacity(logger, worker.Spec{TeamID: scenario.TeamID}, false)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(hasCapacity).To(BeFalse())
+
+		hasCapacity, err = checker.HasCapacity(logger, worker.Spec{TeamID: scenario.TeamID, Tags: []string{"some-tag"}}, false)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(hasCapacity).To(BeTrue())
+	})
+
+	Test("only limits the active tasks of steps which run tasks", func() {
+		scenario := Setup(
+			workertest.WithBasicJob(),
+			workertest.WithWorkers(
+				grt.NewWorker("worker1").
+					WithActiveTasks(1),
+			),
+		)
+
+		checker := worker.NewCapacityChecker(scenario.Pool, worker.PlacementOptions{
+			Strategies:              []string{"limit-active-tasks"},
+			MaxActiveTasksPerWorker: 1,
+		})
+
+		hasCapacity, err := checker.HasCapacity(logger, worker.Spec{TeamID: scenario.TeamID}, true)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(hasCapacity).To(BeFalse())
+
+		hasCapacity, err = checker.HasCapacity(logger, worker.Spec{TeamID: scenario.TeamID}, false)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(hasCapacity).To(BeTrue())
+	})
+
+	Test("has no capacity without running workers", func() {
+		scenario := Setup(
+			workertest.WithBasicJob(),
+			workertest.WithWorkers(
+				grt.NewWorker("worker1").
+					WithState(db.WorkerStateStalled),
+			),
+		)
+
+		checker := worker.NewCapacityChecker(scenario.Pool, worker.PlacementOptions{})
+
+		hasCapacity, err := checker.HasCapacity(logger, worker.Spec{TeamID: scenario.TeamID}, false)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(hasCapacity).To(BeFalse())
+	})
+})
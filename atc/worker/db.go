@@ -4,6 +4,7 @@ import (
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/lock"
 	"github.com/concourse/concourse/atc/worker/gardenruntime"
+	"github.com/concourse/concourse/atc/worker/k8sruntime"
 )
 
 func NewDB(
@@ -15,6 +16,8 @@ func NewDB(
 	resourceCacheFactory db.ResourceCacheFactory,
 	workerBaseResourceTypeFactory db.WorkerBaseResourceTypeFactory,
 	lockFactory lock.LockFactory,
+	caBundleRepo db.CABundleRepository,
+	teamProxies *db.TeamProxies,
 ) DB {
 	return DB{
 		WorkerFactory:                 workerFactory,
@@ -25,6 +28,8 @@ func NewDB(
 		ResourceCacheFactory:          resourceCacheFactory,
 		WorkerBaseResourceTypeFactory: workerBaseResourceTypeFactory,
 		LockFactory:                   lockFactory,
+		CABundleRepo:                  caBundleRepo,
+		TeamProxies:                   teamProxies,
 	}
 }
 
@@ -37,6 +42,8 @@ type DB struct {
 	ResourceCacheFactory          db.ResourceCacheFactory
 	WorkerBaseResourceTypeFactory db.WorkerBaseResourceTypeFactory
 	LockFactory                   lock.LockFactory
+	CABundleRepo                  db.CABundleRepository
+	TeamProxies                   *db.TeamProxies
 }
 
 func (db DB) ToGardenRuntimeDB() gardenruntime.DB {
@@ -47,5 +54,13 @@ func (db DB) ToGardenRuntimeDB() gardenruntime.DB {
 		ResourceCacheFactory:          db.ResourceCacheFactory,
 		WorkerBaseResourceTypeFactory: db.WorkerBaseResourceTypeFactory,
 		LockFactory:                   db.LockFactory,
+		CABundleRepo:                  db.CABundleRepo,
+		TeamProxies:                   db.TeamProxies,
+	}
+}
+
+func (db DB) ToK8sRuntimeDB() k8sruntime.DB {
+	return k8sruntime.DB{
+		VolumeRepo: db.VolumeRepo,
 	}
 }
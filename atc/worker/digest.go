@@ -0,0 +1,79 @@
+package worker
+
+import (
+	"archive/tar"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+
+	"github.com/concourse/concourse/atc/compression"
+)
+
+const digestAlgorithm = "sha256"
+
+type digestEntry struct {
+	name string
+	sum  []byte
+}
+
+// contentDigest computes the digest of the files in a compressed tar stream.
+//
+// The digest covers the name, type, permissions, link target and content of
+// each entry, but not their order, timestamps or owners, so that the same
+// content streamed out of volumes on different workers has the same digest.
+func contentDigest(compression compression.Compression, stream io.ReadCloser) (string, error) {
+	reader, err := compression.NewReader(stream)
+	if err != nil {
+		return "", err
+	}
+
+	defer reader.Close()
+
+	tarReader := tar.NewReader(reader)
+
+	var entries []digestEntry
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return "", err
+		}
+
+		name := path.Clean("/" + header.Name)
+		if name == "/" {
+			continue
+		}
+
+		hash := sha256.New()
+		fmt.Fprintf(hash, "%s\x00%c\x00%o\x00%s\x00", name, header.Typeflag, header.Mode&0o7777, header.Linkname)
+
+		if header.Typeflag == tar.TypeReg {
+			_, err = io.Copy(hash, tarReader)
+			if err != nil {
+				return "", err
+			}
+		}
+
+		entries = append(entries, digestEntry{
+			name: name,
+			sum:  hash.Sum(nil),
+		})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].name < entries[j].name
+	})
+
+	digest := sha256.New()
+	for _, entry := range entries {
+		digest.Write(entry.sum)
+	}
+
+	return digestAlgorithm + ":" + hex.EncodeToString(digest.Sum(nil)), nil
+}
@@ -3,12 +3,34 @@ package worker
 import (
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/cppforlife/go-semi-semantic/version"
 )
 
 var ErrNoWorkers = errors.New("no workers")
 
+// KubernetesNotConfiguredError is returned for a Kubernetes worker when this
+// web node was not configured with a Kubernetes cluster.
+type KubernetesNotConfiguredError struct {
+	WorkerName string
+}
+
+func (err KubernetesNotConfiguredError) Error() string {
+	return fmt.Sprintf("worker %s runs on kubernetes, which is not configured on this web node", err.WorkerName)
+}
+
+// UnknownRuntimeError is returned for a worker whose runtime this web node
+// does not know how to run containers with.
+type UnknownRuntimeError struct {
+	WorkerName string
+	Runtime    string
+}
+
+func (err UnknownRuntimeError) Error() string {
+	return fmt.Sprintf("worker %s runs on unknown runtime '%s'", err.WorkerName, err.Runtime)
+}
+
 type NoCompatibleWorkersError struct {
 	Spec          Spec
 	WorkerVersion version.Version
@@ -34,3 +56,28 @@ type StreamingResourceCacheNotFoundError struct {
 func (e StreamingResourceCacheNotFoundError) Error() string {
 	return fmt.Sprintf("resource cache not found (id %d, volume handle %s)", e.ResourceCacheID, e.Handle)
 }
+
+type VolumeDigestMismatchError struct {
+	Handle         string
+	WorkerName     string
+	ExpectedDigest string
+	ActualDigest   string
+}
+
+func (e VolumeDigestMismatchError) Error() string {
+	return fmt.Sprintf(
+		"content of volume %s on worker %s does not match its digest (expected %s, got %s)",
+		e.Handle,
+		e.WorkerName,
+		e.ExpectedDigest,
+		e.ActualDigest,
+	)
+}
+
+type NoCommonStreamingCompressionError struct {
+	Sources []string
+}
+
+func (e NoCommonStreamingCompressionError) Error() string {
+	return fmt.Sprintf("no streaming compression is supported by all of: %s", strings.Join(e.Sources, ", "))
+}
@@ -5,17 +5,19 @@ import (
 	"time"
 
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/runtime"
 	"github.com/concourse/concourse/atc/worker/gardenruntime"
 	"github.com/concourse/concourse/atc/worker/gardenruntime/gclient"
 	"github.com/concourse/concourse/atc/worker/gardenruntime/transport"
+	"github.com/concourse/concourse/atc/worker/k8sruntime"
 	bclient "github.com/concourse/concourse/worker/baggageclaim/client"
 	"github.com/concourse/retryhttp"
 )
 
 type Factory interface {
-	NewWorker(lager.Logger, db.Worker) runtime.Worker
+	NewWorker(lager.Logger, db.Worker) (runtime.Worker, error)
 }
 
 type DefaultFactory struct {
@@ -23,13 +25,83 @@ type DefaultFactory struct {
 
 	Streamer Streamer
 
+	// Kubernetes is the cluster used by Kubernetes workers. It is nil when
+	// no Kubernetes worker is configured.
+	Kubernetes *k8sruntime.Cluster
+
+	// CircuitBreakers fail requests to workers which have stopped
+	// responding, instead of retrying them until HTTPRetryTimeout or
+	// BaggageclaimRetryTimeout.
+	CircuitBreakers transport.CircuitBreakers
+
 	GardenRequestTimeout              time.Duration
 	BaggageclaimResponseHeaderTimeout time.Duration
 	HTTPRetryTimeout                  time.Duration
+	BaggageclaimRetryTimeout          time.Duration
+
+	// StreamingChunkSize is the size, in bytes, of the chunks in which
+	// volumes are streamed into workers which support resumable streaming.
+	// Zero disables chunking.
+	StreamingChunkSize int
+}
+
+// A Runtime creates the runtime.Worker through which the ATC runs containers
+// on the workers that registered with it.
+type Runtime interface {
+	NewWorker(lager.Logger, db.Worker) (runtime.Worker, error)
+}
+
+func (f DefaultFactory) NewWorker(logger lager.Logger, dbWorker db.Worker) (runtime.Worker, error) {
+	rt, err := f.Runtime(dbWorker)
+	if err != nil {
+		return nil, err
+	}
+
+	return rt.NewWorker(logger, dbWorker)
+}
+
+// Runtime selects the Runtime for the runtime a worker registered with.
+// Workers that registered without reporting a runtime are assumed to be
+// Guardian.
+func (f DefaultFactory) Runtime(dbWorker db.Worker) (Runtime, error) {
+	switch dbWorker.Runtime() {
+	case atc.WorkerRuntimeKubernetes:
+		// a Kubernetes worker has no Garden server to fall back on
+		if f.Kubernetes == nil {
+			return nil, KubernetesNotConfiguredError{WorkerName: dbWorker.Name()}
+		}
+
+		return kubernetesRuntime{factory: f}, nil
+
+	case "", atc.WorkerRuntimeGuardian, atc.WorkerRuntimeHoudini:
+		return gardenRuntime{factory: f}, nil
+
+	case atc.WorkerRuntimeContainerd:
+		// containerd only listens on the worker's local socket, so the worker
+		// creates the containers through containerd on the ATC's behalf and
+		// serves them over the Garden API.
+		return gardenRuntime{factory: f}, nil
+
+	default:
+		return nil, UnknownRuntimeError{WorkerName: dbWorker.Name(), Runtime: dbWorker.Runtime()}
+	}
 }
 
-func (f DefaultFactory) NewWorker(logger lager.Logger, dbWorker db.Worker) runtime.Worker {
-	return f.newGardenWorker(logger, dbWorker)
+type gardenRuntime struct {
+	factory DefaultFactory
+}
+
+func (rt gardenRuntime) NewWorker(logger lager.Logger, dbWorker db.Worker) (runtime.Worker, error) {
+	return rt.factory.newGardenWorker(logger, dbWorker), nil
+}
+
+type kubernetesRuntime struct {
+	factory DefaultFactory
+}
+
+func (rt kubernetesRuntime) NewWorker(_ lager.Logger, dbWorker db.Worker) (runtime.Worker, error) {
+	f := rt.factory
+	return k8sruntime.NewWorker(dbWorker, *f.Kubernetes, f.DB.ToK8sRuntimeDB(), f.Streamer), nil
 }
 
 func (f DefaultFactory) newGardenWorker(logger lager.Logger, dbWorker db.Worker) *gardenruntime.Worker {
@@ -38,19 +110,30 @@ func (f DefaultFactory) newGardenWorker(logger lager.Logger, dbWorker db.Worker)
 		logger.Session("garden-connection"),
 		dbWorker.Name(),
 		dbWorker.GardenAddr(),
+		f.CircuitBreakers.Breaker(dbWorker.Name(), transport.GardenService),
 		retryhttp.NewExponentialBackOffFactory(f.HTTPRetryTimeout),
 		f.GardenRequestTimeout,
 	)
 	gClient := gcf.NewClient()
-	bcClient := bclient.New("", transport.NewBaggageclaimRoundTripper(
+	bcTransport := transport.NewBaggageclaimRoundTripper(
 		dbWorker.Name(),
 		dbWorker.BaggageclaimURL(),
 		f.DB.WorkerFactory,
-		&http.Transport{
-			DisableKeepAlives:     true,
-			ResponseHeaderTimeout: f.BaggageclaimResponseHeaderTimeout,
-		},
-	))
+		transport.NewCircuitBreakerRoundTripper(
+			f.CircuitBreakers.Breaker(dbWorker.Name(), transport.BaggageclaimService),
+			&http.Transport{
+				DisableKeepAlives:     true,
+				ResponseHeaderTimeout: f.BaggageclaimResponseHeaderTimeout,
+			},
+		),
+	)
+
+	var bcClient bclient.Client
+	if capabilities := dbWorker.Capabilities(); capabilities != nil && capabilities.ResumableStreaming {
+		bcClient = bclient.NewResumable("", bcTransport, f.BaggageclaimRetryTimeout, f.StreamingChunkSize)
+	} else {
+		bcClient = bclient.NewWithRetryTimeout("", bcTransport, f.BaggageclaimRetryTimeout)
+	}
 
 	return gardenruntime.NewWorker(
 		dbWorker,
@@ -0,0 +1,75 @@
+package worker_test
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/worker"
+	"github.com/concourse/concourse/atc/worker/gardenruntime"
+	"github.com/concourse/concourse/atc/worker/gardenruntime/transport/transportfakes"
+	"github.com/concourse/concourse/atc/worker/k8sruntime"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DefaultFactory", func() {
+	Describe("NewWorker", func() {
+		var fakeDBWorker *dbfakes.FakeWorker
+
+		BeforeEach(func() {
+			fakeDBWorker = new(dbfakes.FakeWorker)
+			fakeDBWorker.NameReturns("some-worker")
+		})
+
+		for _, runtime := range []string{"", atc.WorkerRuntimeGuardian, atc.WorkerRuntimeContainerd, atc.WorkerRuntimeHoudini} {
+			runtime := runtime
+
+			Context("when the worker registered with runtime '"+runtime+"'", func() {
+				BeforeEach(func() {
+					fakeDBWorker.RuntimeReturns(runtime)
+				})
+
+				It("runs containers through the worker's garden server", func() {
+					factory := worker.DefaultFactory{CircuitBreakers: new(transportfakes.FakeCircuitBreakers)}
+					runtimeWorker, err := factory.NewWorker(logger, fakeDBWorker)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(runtimeWorker).To(BeAssignableToTypeOf(&gardenruntime.Worker{}))
+				})
+			})
+		}
+
+		Context("when a kubernetes worker is given and a cluster is configured", func() {
+			BeforeEach(func() {
+				fakeDBWorker.RuntimeReturns(atc.WorkerRuntimeKubernetes)
+			})
+
+			It("runs containers on the cluster", func() {
+				factory := worker.DefaultFactory{Kubernetes: &k8sruntime.Cluster{}}
+				runtimeWorker, err := factory.NewWorker(logger, fakeDBWorker)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(runtimeWorker).To(BeAssignableToTypeOf(&k8sruntime.Worker{}))
+			})
+		})
+
+		Context("when the worker registered with an unknown runtime", func() {
+			BeforeEach(func() {
+				fakeDBWorker.RuntimeReturns("bogus")
+			})
+
+			It("errors", func() {
+				_, err := worker.DefaultFactory{}.NewWorker(logger, fakeDBWorker)
+				Expect(err).To(Equal(worker.UnknownRuntimeError{WorkerName: "some-worker", Runtime: "bogus"}))
+			})
+		})
+
+		Context("when a kubernetes worker is given but no cluster is configured", func() {
+			BeforeEach(func() {
+				fakeDBWorker.RuntimeReturns(atc.WorkerRuntimeKubernetes)
+			})
+
+			It("errors instead of treating it as a garden worker", func() {
+				_, err := worker.DefaultFactory{}.NewWorker(logger, fakeDBWorker)
+				Expect(err).To(Equal(worker.KubernetesNotConfiguredError{WorkerName: "some-worker"}))
+			})
+		})
+	})
+})
@@ -0,0 +1,92 @@
+package gardenruntime
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"path"
+
+	"github.com/concourse/concourse/atc/compression"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/worker/baggageclaim"
+)
+
+// The CA certificates registered for the container's team and for the
+// cluster are written to a volume which is mounted at caBundleDir.
+//
+// Go programs pick them up through SSL_CERT_DIR, which still includes the
+// usual system directory. Other programs can be pointed at the bundle through
+// CONCOURSE_CA_BUNDLE or, for Node.js, NODE_EXTRA_CA_CERTS.
+const (
+	caBundleDir  = "/etc/concourse/ca-certificates"
+	caBundleFile = "ca-bundle.crt"
+)
+
+func caBundleEnv() []string {
+	bundlePath := path.Join(caBundleDir, caBundleFile)
+
+	return []string{
+		"CONCOURSE_CA_BUNDLE=" + bundlePath,
+		"NODE_EXTRA_CA_CERTS=" + bundlePath,
+		"SSL_CERT_DIR=/etc/ssl/certs:" + caBundleDir,
+	}
+}
+
+func (worker *Worker) createCABundleVolume(
+	ctx context.Context,
+	privileged bool,
+	container db.CreatingContainer,
+	teamID int,
+	certificates string,
+) (Volume, error) {
+	volume, err := worker.findOrCreateVolumeForContainer(
+		ctx,
+		baggageclaim.VolumeSpec{
+			Strategy:   baggageclaim.EmptyStrategy{},
+			Privileged: privileged,
+		},
+		container,
+		teamID,
+		caBundleDir,
+	)
+	if err != nil {
+		return Volume{}, err
+	}
+
+	buf := new(bytes.Buffer)
+	gzipWriter := gzip.NewWriter(buf)
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	err = tarWriter.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     caBundleFile,
+		Mode:     0644,
+		Size:     int64(len(certificates)),
+	})
+	if err != nil {
+		return Volume{}, err
+	}
+
+	_, err = tarWriter.Write([]byte(certificates))
+	if err != nil {
+		return Volume{}, err
+	}
+
+	err = tarWriter.Close()
+	if err != nil {
+		return Volume{}, err
+	}
+
+	err = gzipWriter.Close()
+	if err != nil {
+		return Volume{}, err
+	}
+
+	err = volume.StreamIn(ctx, ".", compression.NewGzipCompression(), buf)
+	if err != nil {
+		return Volume{}, err
+	}
+
+	return volume, nil
+}
@@ -99,6 +99,15 @@ func (c Container) Properties() (map[string]string, error) {
 	return c.GardenContainer.Properties()
 }
 
+func (c Container) ProcessIDs(_ context.Context) ([]string, error) {
+	info, err := c.GardenContainer.Info()
+	if err != nil {
+		return nil, fmt.Errorf("get info: %w", err)
+	}
+
+	return info.ProcessIDs, nil
+}
+
 func toGardenProcessSpec(spec runtime.ProcessSpec, properties garden.Properties) garden.ProcessSpec {
 	user := spec.User
 	if user == "" {
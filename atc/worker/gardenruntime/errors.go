@@ -34,3 +34,13 @@ type MountedVolumeMissingFromWorker struct {
 func (e MountedVolumeMissingFromWorker) Error() string {
 	return fmt.Sprintf("volume mounted to container is missing '%s' from worker '%s'", e.Handle, e.WorkerName)
 }
+
+type ImageTooLargeError struct {
+	WorkerName string
+	Size       int64
+	MaxSize    int64
+}
+
+func (e ImageTooLargeError) Error() string {
+	return fmt.Sprintf("image of %d bytes is larger than worker '%s' accepts (%d bytes)", e.Size, e.WorkerName, e.MaxSize)
+}
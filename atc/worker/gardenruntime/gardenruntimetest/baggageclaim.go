@@ -88,6 +88,10 @@ func (b *Baggageclaim) DestroyVolume(_ context.Context, handle string) error {
 	return nil
 }
 
+func (b *Baggageclaim) DiskUsage(_ context.Context) (baggageclaim.DiskUsageResponse, error) {
+	return baggageclaim.DiskUsageResponse{}, nil
+}
+
 func matchesFilter(properties map[string]string, filter map[string]string) bool {
 	for k, v := range filter {
 		if properties[k] != v {
@@ -144,6 +148,8 @@ func (v *Volume) SetPrivileged(_ context.Context, p bool) error {
 }
 func (v Volume) GetPrivileged(_ context.Context) (bool, error) { return v.Spec.Privileged, nil }
 
+func (v Volume) Size(_ context.Context) (int64, error) { return v.Content.Size(), nil }
+
 func (v Volume) StreamIn(ctx context.Context, path string, encoding baggageclaim.Encoding, tarStream io.Reader) error {
 	return v.Content.StreamIn(ctx, path, encoding, tarStream)
 }
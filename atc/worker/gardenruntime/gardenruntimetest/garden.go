@@ -132,7 +132,16 @@ func (c Container) Stop(kill bool) error {
 	return nil
 }
 
-func (c *Container) Info() (garden.ContainerInfo, error)     { panic("not implemented") }
+func (c *Container) Info() (garden.ContainerInfo, error) {
+	c.processMtx.Lock()
+	defer c.processMtx.Unlock()
+
+	info := garden.ContainerInfo{State: "active"}
+	for _, proc := range c.Processes {
+		info.ProcessIDs = append(info.ProcessIDs, proc.ID())
+	}
+	return info, nil
+}
 func (c *Container) StreamIn(spec garden.StreamInSpec) error { panic("not implemented") }
 func (c *Container) StreamOut(spec garden.StreamOutSpec) (io.ReadCloser, error) {
 	panic("not implemented")
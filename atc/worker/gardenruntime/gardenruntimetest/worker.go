@@ -219,6 +219,15 @@ func (w Worker) WithVersion(version string) *Worker {
 	})
 }
 
+func (w Worker) WithDiskUsage(totalBytes, usedBytes uint64) *Worker {
+	return w.WithWorkerSetup(func(w *atc.Worker) {
+		w.DiskUsage = &atc.WorkerDiskUsage{
+			TotalBytes: totalBytes,
+			UsedBytes:  usedBytes,
+		}
+	})
+}
+
 func containerHandles(containers []*Container) []string {
 	handles := make([]string, len(containers))
 	for i, c := range containers {
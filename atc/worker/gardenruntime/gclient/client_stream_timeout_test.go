@@ -5,9 +5,11 @@ import (
 	"net/http"
 	"time"
 
+	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/garden"
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc/worker/gardenruntime/gclient"
+	"github.com/concourse/concourse/atc/worker/gardenruntime/transport"
 	"github.com/concourse/concourse/atc/worker/gardenruntime/transport/transportfakes"
 	"github.com/concourse/retryhttp"
 	. "github.com/onsi/ginkgo"
@@ -51,6 +53,8 @@ var _ = Describe("stream http client", func() {
 				fakeLogger,
 				"wont-talk-to-you",
 				hostname,
+				transport.NewCircuitBreakers(fakeLogger, transport.CircuitBreakerConfig{}, clock.NewClock()).
+					Breaker("wont-talk-to-you", transport.GardenService),
 				retryhttp.NewExponentialBackOffFactory(1*time.Second),
 				1*time.Second,
 			)
@@ -22,6 +22,7 @@ type GardenClientFactory struct {
 	logger                     lager.Logger
 	workerName                 string
 	workerHost                 *string
+	breaker                    *transport.CircuitBreaker
 	retryBackOffFactory        retryhttp.BackOffFactory
 	streamClientRequestTimeout time.Duration
 }
@@ -31,6 +32,7 @@ func NewGardenClientFactory(
 	logger lager.Logger,
 	workerName string,
 	workerHost *string,
+	breaker *transport.CircuitBreaker,
 	retryBackOffFactory retryhttp.BackOffFactory,
 	streamClientRequestTimeout time.Duration,
 ) *GardenClientFactory {
@@ -39,6 +41,7 @@ func NewGardenClientFactory(
 		logger:                     logger,
 		workerName:                 workerName,
 		workerHost:                 workerHost,
+		breaker:                    breaker,
 		retryBackOffFactory:        retryBackOffFactory,
 		streamClientRequestTimeout: streamClientRequestTimeout,
 	}
@@ -49,20 +52,33 @@ func (gcf *GardenClientFactory) NewClient() Client {
 		DelegateRetryer: &retryhttp.DefaultRetryer{},
 	}
 
+	roundTripper := transport.NewGardenRoundTripper(
+		gcf.workerName,
+		gcf.workerHost,
+		gcf.db,
+		transport.NewCircuitBreakerRoundTripper(gcf.breaker, &http.Transport{DisableKeepAlives: true}),
+	)
+
 	streamClient := &http.Client{
 		Transport: &retryhttp.RetryRoundTripper{
 			Logger:         gcf.logger.Session("retryable-http-client"),
 			BackOffFactory: gcf.retryBackOffFactory,
-			RoundTripper:   transport.NewGardenRoundTripper(gcf.workerName, gcf.workerHost, gcf.db, &http.Transport{DisableKeepAlives: true}),
+			RoundTripper:   roundTripper,
 			Retryer:        retryer,
 		},
 		Timeout: gcf.streamClientRequestTimeout,
 	}
 
+	innerHijackableClient := transport.NewHijackableClient(
+		gcf.workerName,
+		gcf.db,
+		transport.NewCircuitBreakerHijackableClient(gcf.breaker, retryhttp.DefaultHijackableClient),
+	)
+
 	hijackableClient := &retryhttp.RetryHijackableClient{
 		Logger:           gcf.logger.Session("retry-hijackable-client"),
 		BackOffFactory:   gcf.retryBackOffFactory,
-		HijackableClient: transport.NewHijackableClient(gcf.workerName, gcf.db, retryhttp.DefaultHijackableClient),
+		HijackableClient: innerHijackableClient,
 		Retryer:          retryer,
 	}
 
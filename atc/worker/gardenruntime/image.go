@@ -41,6 +41,12 @@ func (worker *Worker) fetchImageForContainer(
 	logger := lagerctx.FromContext(ctx)
 
 	if imageSpec.ImageArtifact != nil {
+		err := worker.checkImageSize(ctx, imageSpec.ImageArtifact)
+		if err != nil {
+			logger.Error("failed-to-check-image-size", err)
+			return FetchedImage{}, err
+		}
+
 		volume, err := worker.findOrStreamVolume(ctx, imageSpec.Privileged, teamID, container, imageSpec.ImageArtifact, "/", delegate)
 		if err != nil {
 			logger.Error("failed-to-find-or-stream-volume-for-image", err)
@@ -95,6 +101,35 @@ func (worker *Worker) fetchImageForContainer(
 	return FetchedImage{URL: imageSpec.ImageURL}, nil
 }
 
+// checkImageSize refuses images which are larger than the worker accepts,
+// before streaming them to it. Only images whose size is known are checked.
+func (worker *Worker) checkImageSize(ctx context.Context, image runtime.Artifact) error {
+	maxSize := worker.capabilities().MaxImageSize
+	if maxSize == 0 {
+		return nil
+	}
+
+	sized, ok := image.(runtime.SizedVolume)
+	if !ok {
+		return nil
+	}
+
+	size, err := sized.Size(ctx)
+	if err != nil {
+		return fmt.Errorf("get image size: %w", err)
+	}
+
+	if size > maxSize {
+		return ImageTooLargeError{
+			WorkerName: worker.Name(),
+			Size:       size,
+			MaxSize:    maxSize,
+		}
+	}
+
+	return nil
+}
+
 func (worker *Worker) imageFromBaseResourceType(
 	ctx context.Context,
 	resourceType atc.WorkerResourceType,
@@ -0,0 +1,287 @@
+package transport
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/retryhttp"
+)
+
+const (
+	GardenService       = "garden"
+	BaggageclaimService = "baggageclaim"
+)
+
+type CircuitOpenError struct {
+	WorkerName string
+	Service    string
+	RetryAt    time.Time
+}
+
+func (e CircuitOpenError) Error() string {
+	return fmt.Sprintf("requests to %s on worker '%s' are failing; not trying again until %s", e.Service, e.WorkerName, e.RetryAt.Format(time.RFC3339))
+}
+
+type CircuitBreakerConfig struct {
+	// FailureThreshold is how many requests to a worker's server have to
+	// fail in a row for its breaker to open. 0 disables the breakers.
+	FailureThreshold int
+
+	// Cooldown is how long an open breaker fails requests before letting one
+	// through to see if the server has recovered.
+	Cooldown time.Duration
+}
+
+// CircuitBreakers keeps a circuit breaker for each worker's Garden and
+// Baggageclaim servers. They outlive the clients, which are made afresh
+// every time a worker is looked up.
+//
+//counterfeiter:generate . CircuitBreakers
+type CircuitBreakers interface {
+	Breaker(workerName string, service string) *CircuitBreaker
+
+	// States returns the states of the worker's breakers, ordered by
+	// service.
+	States(workerName string) []atc.WorkerCircuitBreaker
+}
+
+type circuitBreakers struct {
+	logger lager.Logger
+	config CircuitBreakerConfig
+	clock  clock.Clock
+
+	breakersL sync.Mutex
+	breakers  map[string]map[string]*CircuitBreaker
+}
+
+func NewCircuitBreakers(logger lager.Logger, config CircuitBreakerConfig, clock clock.Clock) CircuitBreakers {
+	return &circuitBreakers{
+		logger:   logger,
+		config:   config,
+		clock:    clock,
+		breakers: map[string]map[string]*CircuitBreaker{},
+	}
+}
+
+func (b *circuitBreakers) Breaker(workerName string, service string) *CircuitBreaker {
+	b.breakersL.Lock()
+	defer b.breakersL.Unlock()
+
+	services, found := b.breakers[workerName]
+	if !found {
+		services = map[string]*CircuitBreaker{}
+		b.breakers[workerName] = services
+	}
+
+	breaker, found := services[service]
+	if !found {
+		breaker = &CircuitBreaker{
+			logger: b.logger.Session("circuit-breaker", lager.Data{
+				"worker":  workerName,
+				"service": service,
+			}),
+			workerName: workerName,
+			service:    service,
+			config:     b.config,
+			clock:      b.clock,
+			state:      atc.CircuitBreakerClosed,
+		}
+		services[service] = breaker
+	}
+
+	return breaker
+}
+
+func (b *circuitBreakers) States(workerName string) []atc.WorkerCircuitBreaker {
+	b.breakersL.Lock()
+	services := b.breakers[workerName]
+
+	breakers := make([]*CircuitBreaker, 0, len(services))
+	for _, breaker := range services {
+		breakers = append(breakers, breaker)
+	}
+	b.breakersL.Unlock()
+
+	states := []atc.WorkerCircuitBreaker{}
+	for _, breaker := range breakers {
+		states = append(states, breaker.State())
+	}
+
+	sort.Slice(states, func(i, j int) bool {
+		return states[i].Service < states[j].Service
+	})
+
+	return states
+}
+
+// CircuitBreaker fails requests to an unresponsive server straight away,
+// rather than letting each of them wait on timeouts and retries. It opens
+// once enough requests in a row fail to get a response. After the cooldown
+// it lets a single request through: the breaker closes if it succeeds, and
+// opens again if it fails.
+type CircuitBreaker struct {
+	logger     lager.Logger
+	workerName string
+	service    string
+	config     CircuitBreakerConfig
+	clock      clock.Clock
+
+	lock     sync.Mutex
+	state    string
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+// Allow returns a CircuitOpenError if the request must not be made.
+// Otherwise the outcome of the request has to be passed to Done.
+func (b *CircuitBreaker) Allow() error {
+	if b.config.FailureThreshold <= 0 {
+		return nil
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	switch b.state {
+	case atc.CircuitBreakerOpen:
+		retryAt := b.openedAt.Add(b.config.Cooldown)
+		if b.clock.Now().Before(retryAt) {
+			return b.openError(retryAt)
+		}
+
+		b.logger.Info("half-open")
+		b.state = atc.CircuitBreakerHalfOpen
+		b.probing = true
+
+	case atc.CircuitBreakerHalfOpen:
+		// only one request at a time checks whether the server has recovered
+		if b.probing {
+			return b.openError(b.openedAt.Add(b.config.Cooldown))
+		}
+
+		b.probing = true
+	}
+
+	return nil
+}
+
+// Done records the outcome of a request that was allowed. A request which
+// was canceled by its caller counts as neither a success nor a failure.
+func (b *CircuitBreaker) Done(request *http.Request, err error) {
+	if b.config.FailureThreshold <= 0 {
+		return
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if b.state == atc.CircuitBreakerHalfOpen {
+		b.probing = false
+	}
+
+	if err != nil && request.Context().Err() != nil {
+		return
+	}
+
+	if err == nil {
+		if b.state != atc.CircuitBreakerClosed {
+			b.logger.Info("closed")
+		}
+
+		b.state = atc.CircuitBreakerClosed
+		b.failures = 0
+		return
+	}
+
+	b.failures++
+
+	if b.state == atc.CircuitBreakerHalfOpen || b.failures >= b.config.FailureThreshold {
+		if b.state == atc.CircuitBreakerClosed {
+			b.logger.Info("opened", lager.Data{"failures": b.failures, "error": err.Error()})
+		}
+
+		b.state = atc.CircuitBreakerOpen
+		b.openedAt = b.clock.Now()
+	}
+}
+
+func (b *CircuitBreaker) State() atc.WorkerCircuitBreaker {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	state := atc.WorkerCircuitBreaker{
+		Service:             b.service,
+		State:               b.state,
+		ConsecutiveFailures: b.failures,
+	}
+
+	if b.state != atc.CircuitBreakerClosed {
+		state.OpenedAt = b.openedAt.Unix()
+		state.RetryAt = b.openedAt.Add(b.config.Cooldown).Unix()
+	}
+
+	return state
+}
+
+func (b *CircuitBreaker) openError(retryAt time.Time) error {
+	return CircuitOpenError{
+		WorkerName: b.workerName,
+		Service:    b.service,
+		RetryAt:    retryAt,
+	}
+}
+
+type circuitBreakerRoundTripper struct {
+	breaker           *CircuitBreaker
+	innerRoundTripper http.RoundTripper
+}
+
+func NewCircuitBreakerRoundTripper(breaker *CircuitBreaker, innerRoundTripper http.RoundTripper) http.RoundTripper {
+	return &circuitBreakerRoundTripper{
+		breaker:           breaker,
+		innerRoundTripper: innerRoundTripper,
+	}
+}
+
+func (c *circuitBreakerRoundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
+	err := c.breaker.Allow()
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := c.innerRoundTripper.RoundTrip(request)
+	c.breaker.Done(request, err)
+
+	return response, err
+}
+
+type circuitBreakerHijackableClient struct {
+	breaker               *CircuitBreaker
+	innerHijackableClient retryhttp.HijackableClient
+}
+
+func NewCircuitBreakerHijackableClient(breaker *CircuitBreaker, innerHijackableClient retryhttp.HijackableClient) retryhttp.HijackableClient {
+	return &circuitBreakerHijackableClient{
+		breaker:               breaker,
+		innerHijackableClient: innerHijackableClient,
+	}
+}
+
+func (c *circuitBreakerHijackableClient) Do(request *http.Request) (*http.Response, retryhttp.HijackCloser, error) {
+	err := c.breaker.Allow()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	response, hijackCloser, err := c.innerHijackableClient.Do(request)
+	c.breaker.Done(request, err)
+
+	return response, hijackCloser, err
+}
@@ -0,0 +1,199 @@
+package transport_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/worker/gardenruntime/transport"
+	"github.com/concourse/retryhttp/retryhttpfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CircuitBreakers", func() {
+	var (
+		fakeClock        *fakeclock.FakeClock
+		fakeRoundTripper *retryhttpfakes.FakeRoundTripper
+		config           transport.CircuitBreakerConfig
+
+		breakers     transport.CircuitBreakers
+		roundTripper http.RoundTripper
+		request      *http.Request
+	)
+
+	BeforeEach(func() {
+		fakeClock = fakeclock.NewFakeClock(time.Unix(100, 0))
+		fakeRoundTripper = new(retryhttpfakes.FakeRoundTripper)
+		fakeRoundTripper.RoundTripReturns(nil, errors.New("connection refused"))
+
+		config = transport.CircuitBreakerConfig{
+			FailureThreshold: 3,
+			Cooldown:         30 * time.Second,
+		}
+
+		var err error
+		request, err = http.NewRequest("GET", "http://some-worker/containers", nil)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	JustBeforeEach(func() {
+		breakers = transport.NewCircuitBreakers(lagertest.NewTestLogger("test"), config, fakeClock)
+		roundTripper = transport.NewCircuitBreakerRoundTripper(
+			breakers.Breaker("some-worker", transport.GardenService),
+			fakeRoundTripper,
+		)
+	})
+
+	fail := func(times int) {
+		for i := 0; i < times; i++ {
+			_, err := roundTripper.RoundTrip(request)
+			Expect(err).To(MatchError("connection refused"))
+		}
+	}
+
+	It("lets requests through while they succeed", func() {
+		fakeRoundTripper.RoundTripReturns(&http.Response{StatusCode: http.StatusInternalServerError}, nil)
+
+		for i := 0; i < 5; i++ {
+			_, err := roundTripper.RoundTrip(request)
+			Expect(err).ToNot(HaveOccurred())
+		}
+
+		Expect(fakeRoundTripper.RoundTripCallCount()).To(Equal(5))
+		Expect(breakers.States("some-worker")).To(Equal([]atc.WorkerCircuitBreaker{
+			{Service: "garden", State: "closed"},
+		}))
+	})
+
+	Context("when enough requests fail in a row", func() {
+		JustBeforeEach(func() {
+			fail(3)
+		})
+
+		It("fails further requests without making them", func() {
+			_, err := roundTripper.RoundTrip(request)
+			Expect(err).To(Equal(transport.CircuitOpenError{
+				WorkerName: "some-worker",
+				Service:    "garden",
+				RetryAt:    time.Unix(130, 0),
+			}))
+			Expect(fakeRoundTripper.RoundTripCallCount()).To(Equal(3))
+		})
+
+		It("reports the breaker as open", func() {
+			Expect(breakers.States("some-worker")).To(Equal([]atc.WorkerCircuitBreaker{
+				{
+					Service:             "garden",
+					State:               "open",
+					ConsecutiveFailures: 3,
+					OpenedAt:            100,
+					RetryAt:             130,
+				},
+			}))
+		})
+
+		It("does not affect other workers", func() {
+			other := transport.NewCircuitBreakerRoundTripper(
+				breakers.Breaker("other-worker", transport.GardenService),
+				fakeRoundTripper,
+			)
+
+			_, err := other.RoundTrip(request)
+			Expect(err).To(MatchError("connection refused"))
+			Expect(fakeRoundTripper.RoundTripCallCount()).To(Equal(4))
+		})
+
+		Context("after the cooldown", func() {
+			JustBeforeEach(func() {
+				fakeClock.Increment(30 * time.Second)
+			})
+
+			It("lets one request through at a time", func() {
+				proceed := make(chan struct{})
+				fakeRoundTripper.RoundTripStub = func(*http.Request) (*http.Response, error) {
+					<-proceed
+					return &http.Response{StatusCode: http.StatusOK}, nil
+				}
+
+				done := make(chan error)
+				go func() {
+					_, err := roundTripper.RoundTrip(request)
+					done <- err
+				}()
+
+				Eventually(fakeRoundTripper.RoundTripCallCount).Should(Equal(4))
+
+				_, err := roundTripper.RoundTrip(request)
+				Expect(err).To(BeAssignableToTypeOf(transport.CircuitOpenError{}))
+
+				close(proceed)
+				Expect(<-done).ToNot(HaveOccurred())
+			})
+
+			It("closes once a request succeeds", func() {
+				fakeRoundTripper.RoundTripReturns(&http.Response{StatusCode: http.StatusOK}, nil)
+
+				_, err := roundTripper.RoundTrip(request)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(breakers.States("some-worker")[0].State).To(Equal("closed"))
+
+				_, err = roundTripper.RoundTrip(request)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("opens again if the request fails", func() {
+				fail(1)
+
+				state := breakers.States("some-worker")[0]
+				Expect(state.State).To(Equal("open"))
+				Expect(state.RetryAt).To(Equal(int64(160)))
+
+				_, err := roundTripper.RoundTrip(request)
+				Expect(err).To(BeAssignableToTypeOf(transport.CircuitOpenError{}))
+			})
+		})
+	})
+
+	It("starts counting again after a success", func() {
+		fail(2)
+
+		fakeRoundTripper.RoundTripReturns(&http.Response{StatusCode: http.StatusOK}, nil)
+		_, err := roundTripper.RoundTrip(request)
+		Expect(err).ToNot(HaveOccurred())
+
+		fakeRoundTripper.RoundTripReturns(nil, errors.New("connection refused"))
+		fail(2)
+
+		Expect(breakers.States("some-worker")[0].State).To(Equal("closed"))
+	})
+
+	It("does not count requests canceled by their caller", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		request = request.WithContext(ctx)
+		fail(3)
+
+		Expect(breakers.States("some-worker")[0]).To(Equal(atc.WorkerCircuitBreaker{
+			Service: "garden",
+			State:   "closed",
+		}))
+	})
+
+	Context("when the breakers are disabled", func() {
+		BeforeEach(func() {
+			config.FailureThreshold = 0
+		})
+
+		It("never opens", func() {
+			fail(10)
+			Expect(fakeRoundTripper.RoundTripCallCount()).To(Equal(10))
+		})
+	})
+})
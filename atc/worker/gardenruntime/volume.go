@@ -8,6 +8,7 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/compression"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/lock"
@@ -45,6 +46,14 @@ func (v Volume) DBVolume() db.CreatedVolume {
 	return v.dbVolume
 }
 
+func (v Volume) Size(ctx context.Context) (int64, error) {
+	return v.bcVolume.Size(ctx)
+}
+
+func (v Volume) WorkerCapabilities() atc.WorkerCapabilities {
+	return v.worker.capabilities()
+}
+
 func (v Volume) InitializeResourceCache(ctx context.Context, cache db.ResourceCache) error {
 	logger := lagerctx.FromContext(ctx)
 	if err := v.bcVolume.SetPrivileged(ctx, false); err != nil {
@@ -120,6 +129,7 @@ func (v Volume) StreamP2POut(ctx context.Context, path string, destURL string, c
 }
 
 var _ runtime.P2PVolume = Volume{}
+var _ runtime.CapableVolume = Volume{}
 
 func (worker *Worker) newVolume(bcVolume baggageclaim.Volume, dbVolume db.CreatedVolume) Volume {
 	return Volume{bcVolume: bcVolume, dbVolume: dbVolume, worker: worker}
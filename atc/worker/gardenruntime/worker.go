@@ -49,6 +49,8 @@ type DB struct {
 	ResourceCacheFactory          db.ResourceCacheFactory
 	WorkerBaseResourceTypeFactory db.WorkerBaseResourceTypeFactory
 	LockFactory                   lock.LockFactory
+	CABundleRepo                  db.CABundleRepository
+	TeamProxies                   *db.TeamProxies
 }
 
 func NewWorker(dbWorker db.Worker, gardenClient gclient.Client, bcClient baggageclaim.Client, db DB, streamer Streamer) *Worker {
@@ -63,6 +65,17 @@ func NewWorker(dbWorker db.Worker, gardenClient gclient.Client, bcClient baggage
 	}
 }
 
+// capabilities returns the features the worker supports. Workers which did
+// not advertise them are assumed to support what they always have.
+func (worker *Worker) capabilities() atc.WorkerCapabilities {
+	capabilities := worker.dbWorker.Capabilities()
+	if capabilities == nil {
+		return atc.LegacyWorkerCapabilities
+	}
+
+	return *capabilities
+}
+
 func (worker *Worker) Name() string {
 	return worker.dbWorker.Name()
 }
@@ -190,7 +203,21 @@ func (worker *Worker) createGardenContainer(
 		return nil, err
 	}
 
-	volumeMounts, err := worker.createVolumes(ctx, fetchedImage.Privileged, creatingContainer, containerSpec, delegate)
+	caBundle, err := worker.db.CABundleRepo.ContainerCABundle(containerSpec.TeamID)
+	if err != nil {
+		logger.Error("failed-to-find-ca-bundle-for-container", err)
+		markContainerAsFailed(logger, creatingContainer)
+		return nil, err
+	}
+
+	proxySettings, err := worker.proxySettings(containerSpec.TeamID)
+	if err != nil {
+		logger.Error("failed-to-find-proxy-settings-for-container", err)
+		markContainerAsFailed(logger, creatingContainer)
+		return nil, err
+	}
+
+	volumeMounts, err := worker.createVolumes(ctx, fetchedImage.Privileged, creatingContainer, containerSpec, caBundle, delegate)
 	if err != nil {
 		logger.Error("failed-to-create-volume-mounts-for-container", err)
 		markContainerAsFailed(logger, creatingContainer)
@@ -213,7 +240,7 @@ func (worker *Worker) createGardenContainer(
 			Privileged: fetchedImage.Privileged,
 			BindMounts: bindMounts,
 			Limits:     toGardenLimits(containerSpec.Limits),
-			Env:        worker.containerEnv(containerSpec, fetchedImage),
+			Env:        worker.containerEnv(containerSpec, fetchedImage, proxySettings, caBundle != ""),
 			Properties: garden.Properties{
 				userPropertyName: fetchedImage.Metadata.User,
 			},
@@ -227,24 +254,57 @@ func (worker *Worker) createGardenContainer(
 	return gardenContainer, nil
 }
 
-func (worker *Worker) containerEnv(containerSpec runtime.ContainerSpec, fetchedImage FetchedImage) []string {
+func (worker *Worker) containerEnv(containerSpec runtime.ContainerSpec, fetchedImage FetchedImage, proxySettings atc.ProxySettings, hasCABundle bool) []string {
 	env := append(fetchedImage.Metadata.Env, containerSpec.Env...)
 
-	if worker.dbWorker.HTTPProxyURL() != "" {
-		env = append(env, fmt.Sprintf("http_proxy=%s", worker.dbWorker.HTTPProxyURL()))
+	if hasCABundle {
+		env = append(env, caBundleEnv()...)
 	}
 
-	if worker.dbWorker.HTTPSProxyURL() != "" {
-		env = append(env, fmt.Sprintf("https_proxy=%s", worker.dbWorker.HTTPSProxyURL()))
+	if proxySettings.HTTPProxy != "" {
+		env = append(env, fmt.Sprintf("http_proxy=%s", proxySettings.HTTPProxy))
 	}
 
-	if worker.dbWorker.NoProxy() != "" {
-		env = append(env, fmt.Sprintf("no_proxy=%s", worker.dbWorker.NoProxy()))
+	if proxySettings.HTTPSProxy != "" {
+		env = append(env, fmt.Sprintf("https_proxy=%s", proxySettings.HTTPSProxy))
+	}
+
+	if proxySettings.NoProxy != "" {
+		env = append(env, fmt.Sprintf("no_proxy=%s", proxySettings.NoProxy))
 	}
 
 	return env
 }
 
+// proxySettings returns the proxies configured on the worker, overridden by
+// those configured for the container's team.
+func (worker *Worker) proxySettings(teamID int) (atc.ProxySettings, error) {
+	settings := atc.ProxySettings{
+		HTTPProxy:  worker.dbWorker.HTTPProxyURL(),
+		HTTPSProxy: worker.dbWorker.HTTPSProxyURL(),
+		NoProxy:    worker.dbWorker.NoProxy(),
+	}
+
+	teamSettings, err := worker.db.TeamProxies.ProxySettings(teamID)
+	if err != nil {
+		return atc.ProxySettings{}, err
+	}
+
+	if teamSettings.HTTPProxy != "" {
+		settings.HTTPProxy = teamSettings.HTTPProxy
+	}
+
+	if teamSettings.HTTPSProxy != "" {
+		settings.HTTPSProxy = teamSettings.HTTPSProxy
+	}
+
+	if teamSettings.NoProxy != "" {
+		settings.NoProxy = teamSettings.NoProxy
+	}
+
+	return settings, nil
+}
+
 func (worker *Worker) constructContainer(
 	ctx context.Context,
 	createdContainer db.CreatedContainer,
@@ -298,6 +358,7 @@ func (worker *Worker) constructContainer(
 
 // creates volumes required to run any step:
 // * scratch (empty volume)
+// * CA bundle (if the team or the cluster registered CA certificates)
 // * working dir (i.e. spec.Dir, empty volume)
 // * inputs
 //   * local volumes are COW'd
@@ -309,6 +370,7 @@ func (worker *Worker) createVolumes(
 	privileged bool,
 	creatingContainer db.CreatingContainer,
 	spec runtime.ContainerSpec,
+	caBundle string,
 	delegate runtime.BuildStepDelegate,
 ) ([]runtime.VolumeMount, error) {
 	var volumeMounts []runtime.VolumeMount
@@ -334,6 +396,18 @@ func (worker *Worker) createVolumes(
 
 	volumeMounts = append(volumeMounts, scratchMount)
 
+	if caBundle != "" {
+		caBundleVolume, err := worker.createCABundleVolume(ctx, privileged, creatingContainer, spec.TeamID, caBundle)
+		if err != nil {
+			return nil, err
+		}
+
+		volumeMounts = append(volumeMounts, runtime.VolumeMount{
+			Volume:    caBundleVolume,
+			MountPath: caBundleDir,
+		})
+	}
+
 	inputVolumeMounts, inputDestinationPaths, err := worker.cloneInputVolumes(ctx, spec, privileged, creatingContainer, delegate)
 	if err != nil {
 		return nil, err
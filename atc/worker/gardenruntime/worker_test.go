@@ -519,6 +519,49 @@ var _ = Describe("Garden Worker", func() {
 		})
 	})
 
+	Test("refuses images larger than the worker accepts", func() {
+		delegate := new(execfakes.FakeBuildStepDelegate)
+		imageVolume := grt.NewVolume("remote-image-volume").WithContent(runtimetest.VolumeContent{
+			"metadata.json":     grt.ImageMetadataFile(gardenruntime.ImageMetadata{}),
+			"rootfs/other_file": {Data: []byte("some rootfs content")},
+		})
+		scenario := Setup(
+			workertest.WithWorkers(
+				grt.NewWorker("worker1").
+					WithWorkerSetup(func(w *atc.Worker) {
+						w.Capabilities = &atc.WorkerCapabilities{
+							StreamingCompression: atc.StreamingCompressions,
+							MaxImageSize:         10,
+						}
+					}),
+				grt.NewWorker("worker2").
+					WithVolumesCreatedInDBAndBaggageclaim(
+						imageVolume,
+					),
+			),
+		)
+		worker := scenario.Worker("worker1")
+
+		_, _, err := worker.FindOrCreateContainer(
+			ctx,
+			db.NewFixedHandleContainerOwner("my-handle"),
+			db.ContainerMetadata{},
+			runtime.ContainerSpec{
+				ImageSpec: runtime.ImageSpec{
+					ImageArtifact: scenario.WorkerVolume("worker2", imageVolume.Handle()),
+				},
+			},
+			delegate,
+		)
+		Expect(err).To(MatchError(gardenruntime.ImageTooLargeError{
+			WorkerName: "worker1",
+			Size:       imageVolume.Content.Size(),
+			MaxSize:    10,
+		}))
+
+		Expect(delegate.StreamingVolumeCallCount()).To(BeZero())
+	})
+
 	Describe("when parallel steps require the same remote image volume", func() {
 		var (
 			delegate              *execfakes.FakeBuildStepDelegate
@@ -1149,6 +1192,93 @@ var _ = Describe("Garden Worker", func() {
 		})
 	})
 
+	Test("CA bundle", func() {
+		scenario := Setup(
+			workertest.WithWorkers(
+				grt.NewWorker("worker"),
+			),
+		)
+		worker := scenario.Worker("worker")
+
+		caBundles := db.NewCABundleRepository(dbConn)
+		Expect(caBundles.SetCABundle(0, atc.CABundle{Certificates: "cluster-cert"})).To(Succeed())
+
+		container, _, err := worker.FindOrCreateContainer(
+			ctx,
+			db.NewFixedHandleContainerOwner("my-handle"),
+			db.ContainerMetadata{},
+			runtime.ContainerSpec{
+				ImageSpec: runtime.ImageSpec{
+					ImageURL: "raw:///img/rootfs",
+				},
+				Dir: "/workdir",
+			},
+			delegate,
+		)
+		Expect(err).ToNot(HaveOccurred())
+
+		volumes := bindMountVolumes(worker, container)
+
+		By("validating that the bundle is mounted", func() {
+			Expect(volumes).To(consistOfMap(expectMap{
+				"/scratch":                       grt.HaveStrategy(baggageclaim.EmptyStrategy{}),
+				"/workdir":                       grt.HaveStrategy(baggageclaim.EmptyStrategy{}),
+				"/etc/concourse/ca-certificates": grt.HaveStrategy(baggageclaim.EmptyStrategy{}),
+			}))
+
+			caBundleVolume := volumes["/etc/concourse/ca-certificates"]
+			Expect(caBundleVolume.Content).To(HaveKey("ca-bundle.crt"))
+			Expect(string(caBundleVolume.Content["ca-bundle.crt"].Data)).To(Equal("cluster-cert\n"))
+		})
+
+		By("validating that the env points at the bundle", func() {
+			Expect(gardenContainer(container).Spec.Env).To(ContainElements(
+				"CONCOURSE_CA_BUNDLE=/etc/concourse/ca-certificates/ca-bundle.crt",
+				"SSL_CERT_DIR=/etc/ssl/certs:/etc/concourse/ca-certificates",
+			))
+		})
+	})
+
+	Test("proxy settings", func() {
+		scenario := Setup(
+			workertest.WithBasicJob(),
+			workertest.WithWorkers(
+				grt.NewWorker("worker").
+					WithWorkerSetup(func(w *atc.Worker) {
+						w.HTTPProxyURL = "http://worker-proxy:3128"
+						w.NoProxy = "localhost"
+					}),
+			),
+		)
+		worker := scenario.Worker("worker")
+
+		err := scenario.DB.Team.SetProxySettings(atc.ProxySettings{
+			HTTPProxy:  "http://team-proxy:3128",
+			HTTPSProxy: "http://team-proxy:3129",
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		container, _, err := worker.FindOrCreateContainer(
+			ctx,
+			db.NewFixedHandleContainerOwner("my-handle"),
+			db.ContainerMetadata{},
+			runtime.ContainerSpec{
+				TeamID: scenario.TeamID,
+				ImageSpec: runtime.ImageSpec{
+					ImageURL: "raw:///img/rootfs",
+				},
+			},
+			delegate,
+		)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(gardenContainer(container).Spec.Env).To(ConsistOf(
+			"http_proxy=http://team-proxy:3128",
+			"https_proxy=http://team-proxy:3129",
+			"no_proxy=localhost",
+		))
+	})
+
 	Test("privileged image produces privileged volumes", func() {
 		imageVolume := grt.NewVolume("image-volume").WithContent(runtimetest.VolumeContent{
 			"metadata.json": grt.ImageMetadataFile(gardenruntime.ImageMetadata{}),
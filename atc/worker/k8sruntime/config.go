@@ -0,0 +1,109 @@
+package k8sruntime
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// Config configures a worker whose step containers are scheduled as pods on
+// an existing Kubernetes cluster. The worker is registered by the web node
+// itself, so no worker process needs to be deployed.
+type Config struct {
+	WorkerName      string `long:"worker-name" description:"Name of the Kubernetes-backed worker. The worker is only registered when this is set."`
+	InClusterConfig bool   `long:"in-cluster" description:"Use the in-cluster client when the web node is running inside Kubernetes."`
+	ConfigPath      string `long:"config-path" description:"Path to Kubernetes config when the web node is running outside Kubernetes."`
+	NamespacePrefix string `long:"namespace-prefix" default:"concourse-" description:"Prefix to use for the per-team Kubernetes namespaces in which step pods are scheduled."`
+
+	HeartbeatInterval time.Duration `long:"heartbeat-interval" default:"30s" description:"Interval on which to register the worker and clean up the pods of destroyed containers."`
+
+	ArtifactHelperImage string `long:"artifact-helper-image" default:"busybox" description:"Image of the sidecar container used to stream artifacts in and out of step pods. Must provide 'sh' and 'tar'."`
+
+	Tags          []string          `long:"tag" description:"A tag to set during registration. Can be specified multiple times."`
+	ResourceTypes map[string]string `long:"resource-type" value-name:"TYPE:IMAGE" description:"A resource type to advertise, along with the image implementing it. Can be specified multiple times."`
+}
+
+func (config Config) IsConfigured() bool {
+	return config.WorkerName != ""
+}
+
+func (config Config) Validate() error {
+	if !config.IsConfigured() {
+		return nil
+	}
+
+	if !config.InClusterConfig && config.ConfigPath == "" {
+		return errors.New("either in-cluster or config-path must be configured for the kubernetes worker")
+	}
+
+	if config.InClusterConfig && config.ConfigPath != "" {
+		return errors.New("in-cluster and config-path are mutually exclusive for the kubernetes worker")
+	}
+
+	return nil
+}
+
+// Worker gives the registration payload for the worker.
+func (config Config) Worker() atc.Worker {
+	var resourceTypes []atc.WorkerResourceType
+	for name, image := range config.ResourceTypes {
+		resourceTypes = append(resourceTypes, atc.WorkerResourceType{
+			Type:  name,
+			Image: image,
+		})
+	}
+
+	return atc.Worker{
+		Name:          config.WorkerName,
+		Platform:      "linux",
+		Runtime:       atc.WorkerRuntimeKubernetes,
+		Tags:          config.Tags,
+		ResourceTypes: resourceTypes,
+	}
+}
+
+// Cluster constructs the client used to schedule pods on the cluster.
+func (config Config) Cluster() (Cluster, error) {
+	var restConfig *rest.Config
+	var err error
+	if config.InClusterConfig {
+		restConfig, err = rest.InClusterConfig()
+	} else {
+		restConfig, err = clientcmd.BuildConfigFromFlags("", config.ConfigPath)
+	}
+	if err != nil {
+		return Cluster{}, fmt.Errorf("build kubernetes config: %w", err)
+	}
+
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return Cluster{}, fmt.Errorf("create kubernetes client: %w", err)
+	}
+
+	return Cluster{
+		Clientset:           clientset,
+		Executor:            NewExecutor(clientset, restConfig),
+		NamespacePrefix:     config.NamespacePrefix,
+		ArtifactHelperImage: config.ArtifactHelperImage,
+	}, nil
+}
+
+// Cluster is the Kubernetes cluster on which a worker schedules its pods.
+type Cluster struct {
+	Clientset kubernetes.Interface
+	Executor  Executor
+
+	NamespacePrefix     string
+	ArtifactHelperImage string
+}
+
+// Namespace gives the namespace in which pods for the team are scheduled.
+func (cluster Cluster) Namespace(teamName string) string {
+	return cluster.NamespacePrefix + strings.ToLower(teamName)
+}
@@ -0,0 +1,203 @@
+package k8sruntime
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/runtime"
+	uuid "github.com/nu7hatch/gouuid"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const exitStatusPropertyName = "concourse:exit-status"
+
+// propertiesAnnotation is the pod annotation under which the container's
+// properties are stored as a JSON object. Property names are not valid
+// annotation keys, so they cannot be stored as individual annotations.
+const propertiesAnnotation = "concourse-ci.org/properties"
+
+type Container struct {
+	dbContainer db.CreatedContainer
+
+	namespace string
+	podName   string
+
+	worker *Worker
+}
+
+func (worker *Worker) newContainer(dbContainer db.CreatedContainer, pod *corev1.Pod) Container {
+	return Container{
+		dbContainer: dbContainer,
+		namespace:   pod.Namespace,
+		podName:     pod.Name,
+		worker:      worker,
+	}
+}
+
+func (worker *Worker) LookupContainer(ctx context.Context, handle string) (runtime.Container, bool, error) {
+	logger := lagerctx.FromContext(ctx).Session("lookup-container", lager.Data{"handle": handle, "worker": worker.Name()})
+
+	_, createdContainer, err := worker.dbWorker.FindContainer(db.NewFixedHandleContainerOwner(handle))
+	if err != nil {
+		logger.Error("failed-to-lookup-container-in-db", err)
+		return Container{}, false, err
+	}
+
+	if createdContainer == nil {
+		return Container{}, false, nil
+	}
+
+	pod, found, err := worker.findPod(ctx, handle)
+	if err != nil {
+		logger.Error("failed-to-find-pod", err)
+		return Container{}, false, err
+	}
+
+	if !found {
+		logger.Debug("pod-not-found")
+		return Container{}, false, nil
+	}
+
+	return worker.newContainer(createdContainer, pod), true, nil
+}
+
+func (c Container) Run(_ context.Context, spec runtime.ProcessSpec, io runtime.ProcessIO) (runtime.Process, error) {
+	executor := c.worker.cluster.Executor
+
+	err := executor.Exec(c.namespace, c.podName, mainContainerName, []string{"/bin/sh", "-c", `command -v "$0"`, spec.Path}, runtime.ProcessIO{}, nil)
+	if err != nil {
+		if errors.As(err, &ExitError{}) {
+			return nil, runtime.ExecutableNotFoundError{Message: fmt.Sprintf("executable '%s' not found", spec.Path)}
+		}
+		return nil, fmt.Errorf("start process: %w", err)
+	}
+
+	id := spec.ID
+	if id == "" {
+		guid, err := uuid.NewV4()
+		if err != nil {
+			return nil, err
+		}
+		id = guid.String()
+	}
+
+	var tty *TTY
+	if spec.TTY != nil {
+		tty = NewTTY(*spec.TTY)
+	}
+
+	process := &Process{
+		id:        id,
+		container: c,
+		tty:       tty,
+		done:      make(chan struct{}),
+	}
+
+	go func() {
+		process.err = executor.Exec(c.namespace, c.podName, mainContainerName, processCommand(spec), io, tty)
+		if tty != nil {
+			tty.Close()
+		}
+		close(process.done)
+	}()
+
+	return process, nil
+}
+
+// Attach only supports processes which have already exited, as a command
+// executed through the Kubernetes API cannot be reattached to.
+func (c Container) Attach(_ context.Context, id string, io runtime.ProcessIO) (runtime.Process, error) {
+	properties, err := c.Properties()
+	if err != nil {
+		return nil, fmt.Errorf("get properties: %w", err)
+	}
+
+	statusStr, ok := properties[exitStatusPropertyName]
+	if ok {
+		if status, err := strconv.Atoi(statusStr); err == nil {
+			return ExitedProcess{id: id, Result: runtime.ProcessResult{ExitStatus: status}}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("attach to process %s: process has not exited", id)
+}
+
+func (c Container) Properties() (map[string]string, error) {
+	pod, err := c.worker.cluster.Clientset.CoreV1().Pods(c.namespace).Get(context.Background(), c.podName, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return podProperties(pod)
+}
+
+func (c Container) SetProperty(name string, value string) error {
+	pods := c.worker.cluster.Clientset.CoreV1().Pods(c.namespace)
+
+	pod, err := pods.Get(context.Background(), c.podName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	properties, err := podProperties(pod)
+	if err != nil {
+		return err
+	}
+
+	properties[name] = value
+
+	payload, err := json.Marshal(properties)
+	if err != nil {
+		return err
+	}
+
+	if pod.Annotations == nil {
+		pod.Annotations = map[string]string{}
+	}
+	pod.Annotations[propertiesAnnotation] = string(payload)
+
+	_, err = pods.Update(context.Background(), pod, metav1.UpdateOptions{})
+	return err
+}
+
+func (c Container) DBContainer() db.CreatedContainer {
+	return c.dbContainer
+}
+
+func podProperties(pod *corev1.Pod) (map[string]string, error) {
+	properties := map[string]string{}
+
+	payload, found := pod.Annotations[propertiesAnnotation]
+	if !found {
+		return properties, nil
+	}
+
+	err := json.Unmarshal([]byte(payload), &properties)
+	if err != nil {
+		return nil, fmt.Errorf("malformed properties annotation: %w", err)
+	}
+
+	return properties, nil
+}
+
+// processCommand wraps the process in a shell which sets up its working
+// directory and environment, since neither can be specified when executing
+// a command through the Kubernetes API.
+func processCommand(spec runtime.ProcessSpec) []string {
+	dir := spec.Dir
+	if dir == "" {
+		dir = "."
+	}
+
+	command := []string{"/bin/sh", "-c", `cd "$0" && exec env "$@"`, dir}
+	command = append(command, spec.Env...)
+	command = append(command, spec.Path)
+	return append(command, spec.Args...)
+}
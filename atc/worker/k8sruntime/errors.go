@@ -0,0 +1,36 @@
+package k8sruntime
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrUnsupportedResourceType = errors.New("unsupported resource type")
+var ErrImageArtifactNotSupported = errors.New("image artifacts are not supported by kubernetes workers")
+var ErrArtifactVolumesNotSupported = errors.New("artifact volumes are not supported by kubernetes workers")
+
+type UnsupportedImageURLError struct {
+	URL string
+}
+
+func (e UnsupportedImageURLError) Error() string {
+	return fmt.Sprintf("unsupported image url '%s': must be of the form docker:///repository#tag", e.URL)
+}
+
+type PodNotFoundError struct {
+	Handle     string
+	WorkerName string
+}
+
+func (e PodNotFoundError) Error() string {
+	return fmt.Sprintf("pod '%s' disappeared from worker '%s'", e.Handle, e.WorkerName)
+}
+
+type PodExitedError struct {
+	Handle string
+	Phase  string
+}
+
+func (e PodExitedError) Error() string {
+	return fmt.Sprintf("pod '%s' exited before it could be used (phase: %s)", e.Handle, e.Phase)
+}
@@ -0,0 +1,141 @@
+package k8sruntime
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/concourse/concourse/atc/runtime"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+	"k8s.io/client-go/util/exec"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+
+// Executor runs commands in the containers of a pod, equivalent to
+// `kubectl exec`.
+//
+//counterfeiter:generate . Executor
+type Executor interface {
+	// Exec runs the command in the given container of the pod and waits for
+	// it to exit. A non-zero exit status is returned as an ExitError.
+	Exec(namespace string, pod string, container string, command []string, io runtime.ProcessIO, tty *TTY) error
+}
+
+// ExitError is returned by an Executor when the command exits with a
+// non-zero status.
+type ExitError struct {
+	Status int
+}
+
+func (err ExitError) Error() string {
+	return fmt.Sprintf("command exited with status %d", err.Status)
+}
+
+type spdyExecutor struct {
+	clientset kubernetes.Interface
+	config    *rest.Config
+}
+
+// NewExecutor constructs an Executor which streams the process IO to the
+// Kubernetes API server over SPDY.
+func NewExecutor(clientset kubernetes.Interface, config *rest.Config) Executor {
+	return spdyExecutor{
+		clientset: clientset,
+		config:    config,
+	}
+}
+
+func (e spdyExecutor) Exec(namespace string, pod string, container string, command []string, io runtime.ProcessIO, tty *TTY) error {
+	req := e.clientset.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(namespace).
+		Name(pod).
+		SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: container,
+			Command:   command,
+			Stdin:     io.Stdin != nil,
+			Stdout:    io.Stdout != nil,
+			Stderr:    io.Stderr != nil && tty == nil,
+			TTY:       tty != nil,
+		}, scheme.ParameterCodec)
+
+	executor, err := remotecommand.NewSPDYExecutor(e.config, "POST", req.URL())
+	if err != nil {
+		return fmt.Errorf("create executor: %w", err)
+	}
+
+	options := remotecommand.StreamOptions{
+		Stdin:  io.Stdin,
+		Stdout: io.Stdout,
+		Stderr: io.Stderr,
+	}
+
+	if tty != nil {
+		options.Tty = true
+		options.Stderr = nil
+		options.TerminalSizeQueue = tty
+	}
+
+	err = executor.Stream(options)
+	if err != nil {
+		var exitErr exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.Exited() {
+			return ExitError{Status: exitErr.ExitStatus()}
+		}
+		return err
+	}
+
+	return nil
+}
+
+// TTY feeds terminal resizes to a running command. It implements
+// remotecommand.TerminalSizeQueue.
+type TTY struct {
+	sizes chan remotecommand.TerminalSize
+	done  chan struct{}
+}
+
+func NewTTY(spec runtime.TTYSpec) *TTY {
+	tty := &TTY{
+		sizes: make(chan remotecommand.TerminalSize, 1),
+		done:  make(chan struct{}),
+	}
+	tty.Resize(spec)
+	return tty
+}
+
+// Resize queues a new window size, replacing any size which has not yet been
+// sent.
+func (tty *TTY) Resize(spec runtime.TTYSpec) {
+	size := remotecommand.TerminalSize{
+		Width:  uint16(spec.WindowSize.Columns),
+		Height: uint16(spec.WindowSize.Rows),
+	}
+
+	for {
+		select {
+		case tty.sizes <- size:
+			return
+		case <-tty.sizes:
+		}
+	}
+}
+
+// Close stops the queue once the command has exited.
+func (tty *TTY) Close() {
+	close(tty.done)
+}
+
+func (tty *TTY) Next() *remotecommand.TerminalSize {
+	select {
+	case size := <-tty.sizes:
+		return &size
+	case <-tty.done:
+		return nil
+	}
+}
@@ -0,0 +1,81 @@
+package k8sruntime
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/concourse/concourse/atc/runtime"
+	"github.com/hashicorp/go-multierror"
+)
+
+// killCommand signals every process in the container other than the
+// container's init process, which keeps the pod alive.
+var killCommand = []string{"/bin/sh", "-c", "kill -TERM -1"}
+
+type Process struct {
+	id        string
+	container Container
+	tty       *TTY
+
+	done chan struct{}
+	err  error
+}
+
+func (p *Process) ID() string {
+	return p.id
+}
+
+func (p *Process) Wait(ctx context.Context) (runtime.ProcessResult, error) {
+	select {
+	case <-ctx.Done():
+		err := p.container.worker.cluster.Executor.Exec(
+			p.container.namespace,
+			p.container.podName,
+			mainContainerName,
+			killCommand,
+			runtime.ProcessIO{},
+			nil,
+		)
+		<-p.done
+		return runtime.ProcessResult{}, multierror.Append(ctx.Err(), err)
+	case <-p.done:
+	}
+
+	exitStatus := 0
+	if p.err != nil {
+		var exitErr ExitError
+		if !errors.As(p.err, &exitErr) {
+			return runtime.ProcessResult{}, fmt.Errorf("wait for process completion: %w", p.err)
+		}
+		exitStatus = exitErr.Status
+	}
+
+	p.container.SetProperty(exitStatusPropertyName, strconv.Itoa(exitStatus))
+	return runtime.ProcessResult{ExitStatus: exitStatus}, nil
+}
+
+func (p *Process) SetTTY(tty runtime.TTYSpec) error {
+	if p.tty != nil {
+		p.tty.Resize(tty)
+	}
+	return nil
+}
+
+type ExitedProcess struct {
+	id     string
+	Result runtime.ProcessResult
+}
+
+func (p ExitedProcess) ID() string {
+	return p.id
+}
+
+func (p ExitedProcess) Wait(ctx context.Context) (runtime.ProcessResult, error) {
+	return p.Result, nil
+}
+
+func (p ExitedProcess) SetTTY(tty runtime.TTYSpec) error {
+	return nil
+}
@@ -0,0 +1,122 @@
+package k8sruntime
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Registrar keeps a Kubernetes worker registered, and takes the place of the
+// worker-side garbage collection performed by Garden workers: it reports the
+// pods that exist on the cluster and deletes the pods of destroying
+// containers.
+type Registrar struct {
+	Worker  atc.Worker
+	TTL     time.Duration
+	Cluster Cluster
+
+	WorkerFactory db.WorkerFactory
+	ContainerRepo db.ContainerRepository
+	VolumeRepo    db.VolumeRepository
+}
+
+func (r Registrar) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx).Session("kubernetes-worker", lager.Data{"worker": r.Worker.Name})
+
+	pods, err := r.Cluster.Clientset.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", workerLabel, r.Worker.Name),
+	})
+	if err != nil {
+		logger.Error("failed-to-list-pods", err)
+		return err
+	}
+
+	worker := r.Worker
+	worker.ActiveContainers = len(pods.Items)
+	worker.ActiveVolumes = 0
+	for _, pod := range pods.Items {
+		worker.ActiveVolumes += len(podVolumeHandles(pod))
+	}
+
+	_, err = r.WorkerFactory.SaveWorker(worker, r.TTL)
+	if err != nil {
+		logger.Error("failed-to-save-worker", err)
+		return err
+	}
+
+	podsByHandle := map[string]corev1.Pod{}
+	var handles []string
+	for _, pod := range pods.Items {
+		handle := pod.Labels[handleLabel]
+		podsByHandle[handle] = pod
+		handles = append(handles, handle)
+	}
+
+	err = r.ContainerRepo.UpdateContainersMissingSince(r.Worker.Name, handles)
+	if err != nil {
+		logger.Error("failed-to-update-containers-missing-since", err)
+		return err
+	}
+
+	destroying, err := r.ContainerRepo.FindDestroyingContainers(r.Worker.Name)
+	if err != nil {
+		logger.Error("failed-to-find-destroying-containers", err)
+		return err
+	}
+
+	var failedToDelete []string
+	for _, handle := range destroying {
+		pod, found := podsByHandle[handle]
+		if !found {
+			continue
+		}
+
+		err := r.Cluster.Clientset.CoreV1().Pods(pod.Namespace).Delete(ctx, pod.Name, metav1.DeleteOptions{})
+		if err != nil {
+			logger.Error("failed-to-delete-pod", err, lager.Data{"handle": handle})
+			failedToDelete = append(failedToDelete, handle)
+			continue
+		}
+
+		delete(podsByHandle, handle)
+	}
+
+	_, err = r.ContainerRepo.RemoveDestroyingContainers(r.Worker.Name, failedToDelete)
+	if err != nil {
+		logger.Error("failed-to-remove-destroying-containers", err)
+		return err
+	}
+
+	// a volume is deleted along with its pod, so only the volumes of pods
+	// which are still around need to be kept
+	var volumeHandles []string
+	for _, pod := range podsByHandle {
+		volumeHandles = append(volumeHandles, podVolumeHandles(pod)...)
+	}
+
+	_, err = r.VolumeRepo.RemoveDestroyingVolumes(r.Worker.Name, volumeHandles)
+	if err != nil {
+		logger.Error("failed-to-remove-destroying-volumes", err)
+		return err
+	}
+
+	return nil
+}
+
+func podVolumeHandles(pod corev1.Pod) []string {
+	var handles []string
+	for _, volume := range pod.Spec.Volumes {
+		if strings.HasPrefix(volume.Name, volumeName("")) {
+			handles = append(handles, strings.TrimPrefix(volume.Name, volumeName("")))
+		}
+	}
+	return handles
+}
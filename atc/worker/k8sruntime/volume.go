@@ -0,0 +1,200 @@
+package k8sruntime
+
+import (
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"path"
+
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc/compression"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/runtime"
+	"github.com/concourse/concourse/worker/baggageclaim"
+	"github.com/klauspost/compress/zstd"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// streamOutCommand writes a tar stream of the path to stdout. If the path is
+// a file, the tar stream contains just that file.
+const streamOutCommand = `if [ -d "$0" ]; then exec tar -cf - -C "$0" .; else exec tar -cf - -C "$(dirname "$0")" "$(basename "$0")"; fi`
+
+// streamInCommand extracts a tar stream from stdin into the path.
+const streamInCommand = `mkdir -p "$0" && exec tar -xf - -C "$0"`
+
+// Volume is an emptyDir volume of a pod. Its contents are accessed through
+// the pod's artifact helper container.
+type Volume struct {
+	dbVolume db.CreatedVolume
+
+	namespace string
+	podName   string
+
+	worker *Worker
+}
+
+func (worker *Worker) newVolume(dbVolume db.CreatedVolume, pod *corev1.Pod) Volume {
+	return Volume{
+		dbVolume:  dbVolume,
+		namespace: pod.Namespace,
+		podName:   pod.Name,
+		worker:    worker,
+	}
+}
+
+func (v Volume) Handle() string {
+	return v.dbVolume.Handle()
+}
+
+func (v Volume) Source() string {
+	return v.dbVolume.WorkerName()
+}
+
+func (v Volume) DBVolume() db.CreatedVolume {
+	return v.dbVolume
+}
+
+// InitializeResourceCache is a no-op, as the volume is deleted along with its
+// pod and so cannot be reused as a cache.
+func (v Volume) InitializeResourceCache(ctx context.Context, cache db.ResourceCache) error {
+	return nil
+}
+
+// InitializeStreamedResourceCache is a no-op, as the volume is deleted along
+// with its pod and so cannot be reused as a cache.
+func (v Volume) InitializeStreamedResourceCache(ctx context.Context, cache db.ResourceCache, sourceWorker string) error {
+	return nil
+}
+
+// InitializeTaskCache is a no-op, as the volume is deleted along with its pod
+// and so cannot be reused as a cache.
+func (v Volume) InitializeTaskCache(ctx context.Context, jobID int, stepName string, path string, privileged bool) error {
+	return nil
+}
+
+func (v Volume) StreamOut(ctx context.Context, filePath string, compression compression.Compression) (io.ReadCloser, error) {
+	pr, pw := io.Pipe()
+
+	go func() {
+		writer, err := compressor(pw, compression.Encoding())
+		if err != nil {
+			pw.CloseWithError(err)
+			return
+		}
+
+		err = v.exec([]string{"/bin/sh", "-c", streamOutCommand, v.path(filePath)}, runtime.ProcessIO{Stdout: writer})
+		if err != nil {
+			pw.CloseWithError(fmt.Errorf("stream out: %w", err))
+			return
+		}
+
+		pw.CloseWithError(writer.Close())
+	}()
+
+	return pr, nil
+}
+
+func (v Volume) StreamIn(ctx context.Context, filePath string, compression compression.Compression, reader io.Reader) error {
+	decompressed, err := compression.NewReader(io.NopCloser(reader))
+	if err != nil {
+		return err
+	}
+	defer decompressed.Close()
+
+	err = v.exec([]string{"/bin/sh", "-c", streamInCommand, v.path(filePath)}, runtime.ProcessIO{Stdin: decompressed})
+	if err != nil {
+		return fmt.Errorf("stream in: %w", err)
+	}
+
+	return nil
+}
+
+func (v Volume) exec(command []string, io runtime.ProcessIO) error {
+	return v.worker.cluster.Executor.Exec(v.namespace, v.podName, helperContainerName, command, io, nil)
+}
+
+// path gives the location of the path within the volume in the artifact
+// helper container.
+func (v Volume) path(filePath string) string {
+	return path.Join(volumesDir, v.Handle(), filePath)
+}
+
+func compressor(w io.Writer, encoding baggageclaim.Encoding) (io.WriteCloser, error) {
+	switch encoding {
+	case baggageclaim.GzipEncoding:
+		return gzip.NewWriter(w), nil
+	case baggageclaim.ZstdEncoding:
+		return zstd.NewWriter(w)
+	default:
+		return nil, fmt.Errorf("unsupported encoding '%s'", encoding)
+	}
+}
+
+func (worker *Worker) LookupVolume(ctx context.Context, handle string) (runtime.Volume, bool, error) {
+	logger := lagerctx.FromContext(ctx)
+	createdVolume, found, err := worker.db.VolumeRepo.FindVolume(handle)
+	if err != nil {
+		logger.Error("failed-to-lookup-volume-in-db", err)
+		return Volume{}, false, err
+	}
+
+	if !found || createdVolume.ContainerHandle() == "" {
+		return Volume{}, false, nil
+	}
+
+	pod, found, err := worker.findPod(ctx, createdVolume.ContainerHandle())
+	if err != nil {
+		logger.Error("failed-to-find-pod", err)
+		return Volume{}, false, err
+	}
+
+	if !found {
+		return Volume{}, false, nil
+	}
+
+	return worker.newVolume(createdVolume, pod), true, nil
+}
+
+// CreateVolumeForArtifact is not supported, since volumes can only exist
+// within a pod.
+func (worker *Worker) CreateVolumeForArtifact(ctx context.Context, teamID int) (runtime.Volume, db.WorkerArtifact, error) {
+	return nil, nil, ErrArtifactVolumesNotSupported
+}
+
+func (worker *Worker) findOrCreateVolumeForContainer(
+	ctx context.Context,
+	teamID int,
+	container db.CreatingContainer,
+	mountPath string,
+) (db.CreatedVolume, error) {
+	logger := lagerctx.FromContext(ctx).Session("find-or-create-volume-for-container")
+
+	creatingVolume, createdVolume, err := worker.db.VolumeRepo.FindContainerVolume(teamID, worker.Name(), container, mountPath)
+	if err != nil {
+		logger.Error("failed-to-find-volume-in-db", err)
+		return nil, err
+	}
+
+	if createdVolume != nil {
+		return createdVolume, nil
+	}
+
+	if creatingVolume == nil {
+		creatingVolume, err = worker.db.VolumeRepo.CreateContainerVolume(teamID, worker.Name(), container, mountPath)
+		if err != nil {
+			logger.Error("failed-to-create-volume-in-db", err)
+			return nil, err
+		}
+	}
+
+	// the emptyDir is created along with the pod, so there is nothing to
+	// create on the cluster
+	createdVolume, err = creatingVolume.Created()
+	if err != nil {
+		logger.Error("failed-to-mark-volume-as-created", err)
+		return nil, err
+	}
+
+	return createdVolume, nil
+}
@@ -0,0 +1,524 @@
+package k8sruntime
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/metric"
+	"github.com/concourse/concourse/atc/runtime"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var PodStartPollingInterval = 1 * time.Second
+
+const (
+	mainContainerName   = "main"
+	helperContainerName = "artifact-helper"
+
+	// volumesDir is the directory in the artifact helper container under
+	// which each volume is mounted by its handle.
+	volumesDir = "/concourse/volumes"
+
+	handleLabel = "concourse-ci.org/handle"
+	workerLabel = "concourse-ci.org/worker"
+)
+
+// keepAliveCommand keeps a pod's containers running so that processes can be
+// executed in them.
+var keepAliveCommand = []string{"/bin/sh", "-c", "trap 'exit 0' TERM; while true; do sleep 3600 & wait $!; done"}
+
+type Streamer interface {
+	Stream(ctx context.Context, src runtime.Artifact, dst runtime.Volume) error
+}
+
+// Worker runs each container as a pod on a Kubernetes cluster. Alongside the
+// step's image, every pod runs an artifact helper container which shares the
+// pod's volumes, and which is used to stream artifacts in and out of the pod.
+//
+// Volumes are emptyDirs, so their contents only live as long as the pod.
+type Worker struct {
+	streamer Streamer
+
+	dbWorker db.Worker
+	cluster  Cluster
+
+	db DB
+}
+
+type DB struct {
+	VolumeRepo db.VolumeRepository
+}
+
+func NewWorker(dbWorker db.Worker, cluster Cluster, db DB, streamer Streamer) *Worker {
+	return &Worker{
+		streamer: streamer,
+
+		dbWorker: dbWorker,
+		cluster:  cluster,
+
+		db: db,
+	}
+}
+
+func (worker *Worker) Name() string {
+	return worker.dbWorker.Name()
+}
+
+func (worker *Worker) DBWorker() db.Worker {
+	return worker.dbWorker
+}
+
+func (worker *Worker) FindOrCreateContainer(
+	ctx context.Context,
+	owner db.ContainerOwner,
+	metadata db.ContainerMetadata,
+	containerSpec runtime.ContainerSpec,
+	delegate runtime.BuildStepDelegate,
+) (runtime.Container, []runtime.VolumeMount, error) {
+	c, mounts, err := worker.findOrCreateContainer(ctx, owner, metadata, containerSpec, delegate)
+	if err != nil {
+		return nil, nil, fmt.Errorf("find or create container on worker %s: %w", worker.Name(), err)
+	}
+	return c, mounts, nil
+}
+
+func (worker *Worker) findOrCreateContainer(
+	ctx context.Context,
+	owner db.ContainerOwner,
+	metadata db.ContainerMetadata,
+	containerSpec runtime.ContainerSpec,
+	delegate runtime.BuildStepDelegate,
+) (Container, []runtime.VolumeMount, error) {
+	logger := lagerctx.FromContext(ctx)
+	creatingContainer, createdContainer, err := worker.dbWorker.FindContainer(owner)
+	if err != nil {
+		logger.Error("failed-to-find-container-in-db", err)
+		return Container{}, nil, err
+	}
+
+	if createdContainer != nil {
+		logger = logger.WithData(lager.Data{"container": createdContainer.Handle()})
+		logger.Debug("found-created-container-in-db")
+
+		pod, found, err := worker.findPod(ctx, createdContainer.Handle())
+		if err != nil {
+			logger.Error("failed-to-find-pod", err)
+			return Container{}, nil, err
+		}
+		if !found {
+			return Container{}, nil, PodNotFoundError{Handle: createdContainer.Handle(), WorkerName: worker.Name()}
+		}
+
+		return worker.constructContainer(lagerctx.NewContext(ctx, logger), createdContainer, pod)
+	}
+
+	if creatingContainer == nil {
+		creatingContainer, err = worker.dbWorker.CreateContainer(owner, metadata)
+		if err != nil {
+			logger.Error("failed-to-create-container-in-db", err)
+			return Container{}, nil, err
+		}
+		logger.Debug("created-creating-container-in-db")
+	}
+
+	logger = logger.WithData(lager.Data{"container": creatingContainer.Handle()})
+	ctx = lagerctx.NewContext(ctx, logger)
+
+	pod, found, err := worker.findPod(ctx, creatingContainer.Handle())
+	if err != nil {
+		logger.Error("failed-to-find-pod", err)
+		return Container{}, nil, err
+	}
+
+	if !found {
+		pod, err = worker.createPod(ctx, containerSpec, creatingContainer, delegate)
+		if err != nil {
+			logger.Error("failed-to-create-pod", err)
+			markContainerAsFailed(logger, creatingContainer)
+			return Container{}, nil, err
+		}
+		logger.Debug("created-pod")
+	}
+
+	createdContainer, err = creatingContainer.Created()
+	if err != nil {
+		logger.Error("failed-to-mark-container-as-created", err)
+		_ = worker.deletePod(ctx, pod.Namespace, pod.Name)
+		return Container{}, nil, err
+	}
+
+	logger.Debug("created-container-in-db")
+	metric.Metrics.ContainersCreated.Inc()
+
+	return worker.constructContainer(ctx, createdContainer, pod)
+}
+
+func (worker *Worker) createPod(
+	ctx context.Context,
+	spec runtime.ContainerSpec,
+	container db.CreatingContainer,
+	delegate runtime.BuildStepDelegate,
+) (*corev1.Pod, error) {
+	logger := lagerctx.FromContext(ctx)
+
+	image, err := worker.image(spec.ImageSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	var dbVolumes []db.CreatedVolume
+	for _, mountPath := range containerMountPaths(spec) {
+		dbVolume, err := worker.findOrCreateVolumeForContainer(ctx, spec.TeamID, container, mountPath)
+		if err != nil {
+			return nil, err
+		}
+		dbVolumes = append(dbVolumes, dbVolume)
+	}
+
+	namespace := worker.cluster.Namespace(spec.TeamName)
+	err = worker.ensureNamespace(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	pod, err := worker.cluster.Clientset.CoreV1().Pods(namespace).Create(
+		ctx,
+		worker.podSpec(namespace, container.Handle(), image, spec, dbVolumes),
+		metav1.CreateOptions{},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create pod: %w", err)
+	}
+
+	pod, err = worker.waitForPod(ctx, pod)
+	if err != nil {
+		_ = worker.deletePod(ctx, namespace, container.Handle())
+		return nil, err
+	}
+
+	for _, input := range spec.Inputs {
+		mountPath := resolvePath(spec.Dir, input.DestinationPath)
+		for _, dbVolume := range dbVolumes {
+			if dbVolume.Path() != mountPath {
+				continue
+			}
+
+			_, inputName := path.Split(input.DestinationPath)
+			delegate.StreamingVolume(logger, inputName, input.Artifact.Source(), worker.Name())
+
+			err := worker.streamer.Stream(ctx, input.Artifact, worker.newVolume(dbVolume, pod))
+			if err != nil {
+				_ = worker.deletePod(ctx, namespace, container.Handle())
+				return nil, fmt.Errorf("stream input %s: %w", input.DestinationPath, err)
+			}
+		}
+	}
+
+	return pod, nil
+}
+
+func (worker *Worker) podSpec(
+	namespace string,
+	handle string,
+	image string,
+	spec runtime.ContainerSpec,
+	dbVolumes []db.CreatedVolume,
+) *corev1.Pod {
+	var volumes []corev1.Volume
+	var mainMounts []corev1.VolumeMount
+	var helperMounts []corev1.VolumeMount
+	for _, dbVolume := range dbVolumes {
+		name := volumeName(dbVolume.Handle())
+
+		volumes = append(volumes, corev1.Volume{
+			Name: name,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		})
+
+		mainMounts = append(mainMounts, corev1.VolumeMount{
+			Name:      name,
+			MountPath: dbVolume.Path(),
+		})
+
+		helperMounts = append(helperMounts, corev1.VolumeMount{
+			Name:      name,
+			MountPath: path.Join(volumesDir, dbVolume.Handle()),
+		})
+	}
+
+	privileged := spec.ImageSpec.Privileged
+	automountToken := false
+
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      handle,
+			Namespace: namespace,
+			Labels: map[string]string{
+				handleLabel: handle,
+				workerLabel: worker.Name(),
+			},
+		},
+		Spec: corev1.PodSpec{
+			RestartPolicy:                corev1.RestartPolicyNever,
+			AutomountServiceAccountToken: &automountToken,
+			Volumes:                      volumes,
+			Containers: []corev1.Container{
+				{
+					Name:         mainContainerName,
+					Image:        image,
+					Command:      keepAliveCommand,
+					Env:          worker.containerEnv(spec),
+					WorkingDir:   spec.Dir,
+					VolumeMounts: mainMounts,
+					Resources:    toResourceRequirements(spec.Limits),
+					SecurityContext: &corev1.SecurityContext{
+						Privileged: &privileged,
+					},
+				},
+				{
+					Name:         helperContainerName,
+					Image:        worker.cluster.ArtifactHelperImage,
+					Command:      keepAliveCommand,
+					VolumeMounts: helperMounts,
+				},
+			},
+		},
+	}
+}
+
+func (worker *Worker) containerEnv(spec runtime.ContainerSpec) []corev1.EnvVar {
+	env := spec.Env
+
+	if worker.dbWorker.HTTPProxyURL() != "" {
+		env = append(env, fmt.Sprintf("http_proxy=%s", worker.dbWorker.HTTPProxyURL()))
+	}
+
+	if worker.dbWorker.HTTPSProxyURL() != "" {
+		env = append(env, fmt.Sprintf("https_proxy=%s", worker.dbWorker.HTTPSProxyURL()))
+	}
+
+	if worker.dbWorker.NoProxy() != "" {
+		env = append(env, fmt.Sprintf("no_proxy=%s", worker.dbWorker.NoProxy()))
+	}
+
+	var vars []corev1.EnvVar
+	for _, e := range env {
+		segs := strings.SplitN(e, "=", 2)
+		if len(segs) != 2 {
+			continue
+		}
+
+		vars = append(vars, corev1.EnvVar{Name: segs[0], Value: segs[1]})
+	}
+
+	return vars
+}
+
+// image determines the image reference for the pod's main container. The
+// image must be pullable by the cluster, so image artifacts (e.g. fetched by
+// an image_resource) are not supported.
+func (worker *Worker) image(spec runtime.ImageSpec) (string, error) {
+	if spec.ImageArtifact != nil {
+		return "", ErrImageArtifactNotSupported
+	}
+
+	if spec.ResourceType != "" {
+		for _, t := range worker.dbWorker.ResourceTypes() {
+			if t.Type == spec.ResourceType {
+				return t.Image, nil
+			}
+		}
+
+		return "", ErrUnsupportedResourceType
+	}
+
+	// rootfs URIs are of the form docker:///repository#tag
+	const scheme = "docker://"
+	if !strings.HasPrefix(spec.ImageURL, scheme) {
+		return "", UnsupportedImageURLError{URL: spec.ImageURL}
+	}
+
+	image := strings.TrimPrefix(strings.TrimPrefix(spec.ImageURL, scheme), "/")
+	return strings.Replace(image, "#", ":", 1), nil
+}
+
+func (worker *Worker) ensureNamespace(ctx context.Context, namespace string) error {
+	_, err := worker.cluster.Clientset.CoreV1().Namespaces().Create(ctx, &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: namespace},
+	}, metav1.CreateOptions{})
+	if err != nil && !k8serrors.IsAlreadyExists(err) {
+		return fmt.Errorf("create namespace: %w", err)
+	}
+
+	return nil
+}
+
+func (worker *Worker) waitForPod(ctx context.Context, pod *corev1.Pod) (*corev1.Pod, error) {
+	ticker := time.NewTicker(PodStartPollingInterval)
+	defer ticker.Stop()
+
+	for {
+		switch pod.Status.Phase {
+		case corev1.PodRunning:
+			return pod, nil
+		case corev1.PodFailed, corev1.PodSucceeded:
+			return nil, PodExitedError{Handle: pod.Name, Phase: string(pod.Status.Phase)}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+
+		var err error
+		pod, err = worker.cluster.Clientset.CoreV1().Pods(pod.Namespace).Get(ctx, pod.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("get pod: %w", err)
+		}
+	}
+}
+
+func (worker *Worker) findPod(ctx context.Context, handle string) (*corev1.Pod, bool, error) {
+	pods, err := worker.cluster.Clientset.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s,%s=%s", handleLabel, handle, workerLabel, worker.Name()),
+	})
+	if err != nil {
+		return nil, false, fmt.Errorf("list pods: %w", err)
+	}
+
+	if len(pods.Items) == 0 {
+		return nil, false, nil
+	}
+
+	return &pods.Items[0], true, nil
+}
+
+func (worker *Worker) deletePod(ctx context.Context, namespace string, name string) error {
+	err := worker.cluster.Clientset.CoreV1().Pods(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return err
+	}
+
+	return nil
+}
+
+func (worker *Worker) constructContainer(
+	ctx context.Context,
+	createdContainer db.CreatedContainer,
+	pod *corev1.Pod,
+) (Container, []runtime.VolumeMount, error) {
+	logger := lagerctx.FromContext(ctx)
+
+	createdVolumes, err := worker.db.VolumeRepo.FindVolumesForContainer(createdContainer)
+	if err != nil {
+		logger.Error("failed-to-find-container-volumes", err)
+		return Container{}, nil, err
+	}
+
+	volumeMounts := make([]runtime.VolumeMount, len(createdVolumes))
+	for i, dbVolume := range createdVolumes {
+		volumeMounts[i] = runtime.VolumeMount{
+			Volume:    worker.newVolume(dbVolume, pod),
+			MountPath: dbVolume.Path(),
+		}
+	}
+
+	sort.Slice(volumeMounts, func(i, j int) bool {
+		return volumeMounts[i].MountPath < volumeMounts[j].MountPath
+	})
+
+	return worker.newContainer(createdContainer, pod), volumeMounts, nil
+}
+
+// containerMountPaths gives the paths in the container at which an empty
+// volume is mounted: scratch, inputs, outputs, caches and the working
+// directory. Caches are not retained once the pod is deleted.
+func containerMountPaths(spec runtime.ContainerSpec) []string {
+	var paths []string
+	seen := map[string]bool{}
+
+	add := func(p string) {
+		p = resolvePath(spec.Dir, p)
+		if seen[p] {
+			return
+		}
+		seen[p] = true
+		paths = append(paths, p)
+	}
+
+	add("/scratch")
+
+	for _, input := range spec.Inputs {
+		add(input.DestinationPath)
+	}
+
+	outputPaths := make([]string, 0, len(spec.Outputs))
+	for _, outputPath := range spec.Outputs {
+		outputPaths = append(outputPaths, outputPath)
+	}
+	sort.Strings(outputPaths)
+	for _, outputPath := range outputPaths {
+		add(outputPath)
+	}
+
+	for _, cachePath := range spec.Caches {
+		add(cachePath)
+	}
+
+	if spec.Dir != "" {
+		add(spec.Dir)
+	}
+
+	return paths
+}
+
+func resolvePath(dir string, p string) string {
+	if filepath.IsAbs(p) {
+		return filepath.Clean(p)
+	}
+
+	return filepath.Join(dir, p)
+}
+
+// volumeName gives the name of the volume in the pod spec. Volume handles
+// are UUIDs, so the name is always a valid DNS label.
+func volumeName(handle string) string {
+	return "v-" + handle
+}
+
+func toResourceRequirements(limits runtime.ContainerLimits) corev1.ResourceRequirements {
+	resourceLimits := corev1.ResourceList{}
+
+	if limits.CPU != nil && *limits.CPU != 0 {
+		// CPU limits are given in shares, where 1024 shares is one core
+		resourceLimits[corev1.ResourceCPU] = *resource.NewMilliQuantity(int64(*limits.CPU)*1000/1024, resource.DecimalSI)
+	}
+
+	if limits.Memory != nil && *limits.Memory != 0 {
+		resourceLimits[corev1.ResourceMemory] = *resource.NewQuantity(int64(*limits.Memory), resource.BinarySI)
+	}
+
+	return corev1.ResourceRequirements{Limits: resourceLimits}
+}
+
+func markContainerAsFailed(logger lager.Logger, container db.CreatingContainer) {
+	_, err := container.Failed()
+	if err != nil {
+		logger.Error("failed-to-mark-container-as-failed", err)
+	}
+	metric.Metrics.FailedContainers.Inc()
+}
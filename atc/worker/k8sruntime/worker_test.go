@@ -0,0 +1,362 @@
+package k8sruntime_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/compression"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/exec/execfakes"
+	"github.com/concourse/concourse/atc/runtime"
+	"github.com/concourse/concourse/atc/runtime/runtimetest"
+	"github.com/concourse/concourse/atc/worker/k8sruntime"
+	"github.com/concourse/concourse/atc/worker/k8sruntime/k8sruntimefakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sruntimeobj "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+var _ = Describe("Kubernetes Worker", func() {
+	var (
+		ctx context.Context
+
+		clientset    *fake.Clientset
+		fakeExecutor *k8sruntimefakes.FakeExecutor
+		fakeDBWorker *dbfakes.FakeWorker
+		fakeVolumes  *dbfakes.FakeVolumeRepository
+		fakeStreamer *streamerSpy
+		delegate     *execfakes.FakeBuildStepDelegate
+
+		creatingContainer *dbfakes.FakeCreatingContainer
+		createdContainer  *dbfakes.FakeCreatedContainer
+		createdVolumes    []db.CreatedVolume
+
+		worker *k8sruntime.Worker
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		clientset = fake.NewSimpleClientset()
+		clientset.PrependReactor("create", "pods", func(action k8stesting.Action) (bool, k8sruntimeobj.Object, error) {
+			pod := action.(k8stesting.CreateAction).GetObject().(*corev1.Pod)
+			pod.Status.Phase = corev1.PodRunning
+			return false, nil, nil
+		})
+
+		fakeExecutor = new(k8sruntimefakes.FakeExecutor)
+		fakeStreamer = new(streamerSpy)
+		delegate = new(execfakes.FakeBuildStepDelegate)
+
+		fakeDBWorker = new(dbfakes.FakeWorker)
+		fakeDBWorker.NameReturns("k8s-worker")
+		fakeDBWorker.ResourceTypesReturns([]atc.WorkerResourceType{
+			{Type: "git", Image: "concourse/git-resource"},
+		})
+
+		creatingContainer = new(dbfakes.FakeCreatingContainer)
+		creatingContainer.HandleReturns("some-handle")
+		createdContainer = new(dbfakes.FakeCreatedContainer)
+		createdContainer.HandleReturns("some-handle")
+		creatingContainer.CreatedReturns(createdContainer, nil)
+		fakeDBWorker.CreateContainerReturns(creatingContainer, nil)
+
+		createdVolumes = nil
+		fakeVolumes = new(dbfakes.FakeVolumeRepository)
+		fakeVolumes.CreateContainerVolumeStub = func(teamID int, workerName string, container db.CreatingContainer, mountPath string) (db.CreatingVolume, error) {
+			createdVolume := new(dbfakes.FakeCreatedVolume)
+			createdVolume.HandleReturns(mountPath[1:] + "-volume")
+			createdVolume.PathReturns(mountPath)
+			createdVolume.WorkerNameReturns(workerName)
+			createdVolumes = append(createdVolumes, createdVolume)
+
+			creatingVolume := new(dbfakes.FakeCreatingVolume)
+			creatingVolume.CreatedReturns(createdVolume, nil)
+			return creatingVolume, nil
+		}
+		fakeVolumes.FindVolumesForContainerStub = func(db.CreatedContainer) ([]db.CreatedVolume, error) {
+			return createdVolumes, nil
+		}
+
+		worker = k8sruntime.NewWorker(
+			fakeDBWorker,
+			k8sruntime.Cluster{
+				Clientset:           clientset,
+				Executor:            fakeExecutor,
+				NamespacePrefix:     "concourse-",
+				ArtifactHelperImage: "busybox",
+			},
+			k8sruntime.DB{VolumeRepo: fakeVolumes},
+			fakeStreamer,
+		)
+	})
+
+	findOrCreateContainer := func(spec runtime.ContainerSpec) (runtime.Container, []runtime.VolumeMount, error) {
+		return worker.FindOrCreateContainer(
+			ctx,
+			db.NewFixedHandleContainerOwner("some-handle"),
+			db.ContainerMetadata{},
+			spec,
+			delegate,
+		)
+	}
+
+	getPod := func() *corev1.Pod {
+		pod, err := clientset.CoreV1().Pods("concourse-main").Get(ctx, "some-handle", metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		return pod
+	}
+
+	Describe("FindOrCreateContainer", func() {
+		var spec runtime.ContainerSpec
+
+		BeforeEach(func() {
+			spec = runtime.ContainerSpec{
+				TeamID:   1,
+				TeamName: "main",
+				Dir:      "/workdir",
+				Env:      []string{"FOO=bar"},
+				ImageSpec: runtime.ImageSpec{
+					ImageURL: "docker:///alpine#3.16",
+				},
+				Outputs: runtime.OutputPaths{"out": "out"},
+			}
+		})
+
+		It("schedules a pod in the team's namespace", func() {
+			_, _, err := findOrCreateContainer(spec)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = clientset.CoreV1().Namespaces().Get(ctx, "concourse-main", metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			pod := getPod()
+			Expect(pod.Labels).To(HaveKeyWithValue("concourse-ci.org/worker", "k8s-worker"))
+			Expect(pod.Spec.Containers).To(HaveLen(2))
+
+			main := pod.Spec.Containers[0]
+			Expect(main.Image).To(Equal("alpine:3.16"))
+			Expect(main.WorkingDir).To(Equal("/workdir"))
+			Expect(main.Env).To(ConsistOf(corev1.EnvVar{Name: "FOO", Value: "bar"}))
+
+			var mountPaths []string
+			for _, mount := range main.VolumeMounts {
+				mountPaths = append(mountPaths, mount.MountPath)
+			}
+			Expect(mountPaths).To(Equal([]string{"/scratch", "/workdir/out", "/workdir"}))
+
+			helper := pod.Spec.Containers[1]
+			Expect(helper.Image).To(Equal("busybox"))
+			Expect(helper.VolumeMounts[1].MountPath).To(Equal("/concourse/volumes/workdir/out-volume"))
+		})
+
+		It("marks the container as created", func() {
+			container, volumeMounts, err := findOrCreateContainer(spec)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(creatingContainer.CreatedCallCount()).To(Equal(1))
+			Expect(container.DBContainer()).To(Equal(createdContainer))
+			Expect(volumeMounts).To(HaveLen(3))
+			Expect(volumeMounts[0].MountPath).To(Equal("/scratch"))
+		})
+
+		It("streams inputs into their volumes", func() {
+			artifact := runtimetest.Artifact{}
+			spec.Inputs = []runtime.Input{
+				{Artifact: artifact, DestinationPath: "/workdir/input"},
+			}
+
+			_, _, err := findOrCreateContainer(spec)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(fakeStreamer.streamed).To(HaveLen(1))
+			Expect(fakeStreamer.streamed[0].src).To(Equal(artifact))
+			Expect(fakeStreamer.streamed[0].dst.DBVolume().Path()).To(Equal("/workdir/input"))
+		})
+
+		It("uses the image of the worker's resource type", func() {
+			spec.ImageSpec = runtime.ImageSpec{ResourceType: "git"}
+
+			_, _, err := findOrCreateContainer(spec)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(getPod().Spec.Containers[0].Image).To(Equal("concourse/git-resource"))
+		})
+
+		Context("when the image is an artifact", func() {
+			BeforeEach(func() {
+				spec.ImageSpec = runtime.ImageSpec{ImageArtifact: runtimetest.Artifact{}}
+			})
+
+			It("fails and marks the container as failed", func() {
+				_, _, err := findOrCreateContainer(spec)
+				Expect(err).To(MatchError(k8sruntime.ErrImageArtifactNotSupported))
+				Expect(creatingContainer.FailedCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("when the container was already created", func() {
+			BeforeEach(func() {
+				_, _, err := findOrCreateContainer(spec)
+				Expect(err).ToNot(HaveOccurred())
+
+				fakeDBWorker.FindContainerReturns(nil, createdContainer, nil)
+			})
+
+			It("finds the existing pod", func() {
+				container, _, err := findOrCreateContainer(spec)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(container.DBContainer()).To(Equal(createdContainer))
+				Expect(fakeDBWorker.CreateContainerCallCount()).To(Equal(1))
+			})
+
+			It("errors when the pod is gone", func() {
+				err := clientset.CoreV1().Pods("concourse-main").Delete(ctx, "some-handle", metav1.DeleteOptions{})
+				Expect(err).ToNot(HaveOccurred())
+
+				_, _, err = findOrCreateContainer(spec)
+				Expect(err).To(MatchError(ContainSubstring("disappeared")))
+			})
+		})
+	})
+
+	Describe("Container", func() {
+		var container runtime.Container
+
+		BeforeEach(func() {
+			var err error
+			container, _, err = findOrCreateContainer(runtime.ContainerSpec{
+				TeamName:  "main",
+				ImageSpec: runtime.ImageSpec{ImageURL: "docker:///alpine"},
+			})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("runs the process in the main container", func() {
+			fakeExecutor.ExecStub = func(namespace, pod, c string, command []string, io runtime.ProcessIO, tty *k8sruntime.TTY) error {
+				if io.Stdout != nil {
+					io.Stdout.Write([]byte("hello"))
+				}
+				return nil
+			}
+
+			stdout := new(bytes.Buffer)
+			process, err := container.Run(ctx, runtime.ProcessSpec{
+				Path: "echo",
+				Args: []string{"hello"},
+				Dir:  "/tmp",
+				Env:  []string{"A=b"},
+			}, runtime.ProcessIO{Stdout: stdout})
+			Expect(err).ToNot(HaveOccurred())
+
+			result, err := process.Wait(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.ExitStatus).To(Equal(0))
+			Expect(stdout.String()).To(Equal("hello"))
+
+			namespace, pod, c, command, _, _ := fakeExecutor.ExecArgsForCall(1)
+			Expect(namespace).To(Equal("concourse-main"))
+			Expect(pod).To(Equal("some-handle"))
+			Expect(c).To(Equal("main"))
+			Expect(command[3:]).To(Equal([]string{"/tmp", "A=b", "echo", "hello"}))
+		})
+
+		It("records the exit status so it can be attached to later", func() {
+			fakeExecutor.ExecReturnsOnCall(1, k8sruntime.ExitError{Status: 3})
+
+			process, err := container.Run(ctx, runtime.ProcessSpec{ID: "some-id", Path: "false"}, runtime.ProcessIO{})
+			Expect(err).ToNot(HaveOccurred())
+
+			result, err := process.Wait(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.ExitStatus).To(Equal(3))
+
+			process, err = container.Attach(ctx, "some-id", runtime.ProcessIO{})
+			Expect(err).ToNot(HaveOccurred())
+
+			result, err = process.Wait(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.ExitStatus).To(Equal(3))
+		})
+
+		It("fails to attach to a process which has not exited", func() {
+			_, err := container.Attach(ctx, "some-id", runtime.ProcessIO{})
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("returns ExecutableNotFoundError when the executable does not exist", func() {
+			fakeExecutor.ExecReturns(k8sruntime.ExitError{Status: 1})
+
+			_, err := container.Run(ctx, runtime.ProcessSpec{Path: "missing"}, runtime.ProcessIO{})
+			Expect(errors.As(err, &runtime.ExecutableNotFoundError{})).To(BeTrue())
+		})
+
+		It("stores properties on the pod", func() {
+			Expect(container.SetProperty("foo", "bar")).To(Succeed())
+
+			properties, err := container.Properties()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(properties).To(Equal(map[string]string{"foo": "bar"}))
+		})
+	})
+
+	Describe("Volume", func() {
+		var volume runtime.Volume
+
+		BeforeEach(func() {
+			_, mounts, err := findOrCreateContainer(runtime.ContainerSpec{
+				TeamName:  "main",
+				ImageSpec: runtime.ImageSpec{ImageURL: "docker:///alpine"},
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			volume = mounts[0].Volume
+		})
+
+		It("streams through the artifact helper", func() {
+			fakeExecutor.ExecStub = func(namespace, pod, c string, command []string, io runtime.ProcessIO, tty *k8sruntime.TTY) error {
+				if io.Stdout != nil {
+					io.Stdout.Write([]byte("some-tar"))
+				}
+				return nil
+			}
+
+			out, err := volume.StreamOut(ctx, ".", compression.NewGzipCompression())
+			Expect(err).ToNot(HaveOccurred())
+
+			err = volume.StreamIn(ctx, "some/dir", compression.NewGzipCompression(), out)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(fakeExecutor.ExecCallCount()).To(Equal(2))
+
+			_, _, c, command, _, _ := fakeExecutor.ExecArgsForCall(0)
+			Expect(c).To(Equal("artifact-helper"))
+			Expect(command[3]).To(Equal("/concourse/volumes/scratch-volume"))
+
+			_, _, c, command, processIO, _ := fakeExecutor.ExecArgsForCall(1)
+			Expect(c).To(Equal("artifact-helper"))
+			Expect(command[3]).To(Equal("/concourse/volumes/scratch-volume/some/dir"))
+			Expect(processIO.Stdin).ToNot(BeNil())
+		})
+	})
+})
+
+type streamed struct {
+	src runtime.Artifact
+	dst runtime.Volume
+}
+
+type streamerSpy struct {
+	streamed []streamed
+}
+
+func (s *streamerSpy) Stream(ctx context.Context, src runtime.Artifact, dst runtime.Volume) error {
+	s.streamed = append(s.streamed, streamed{src, dst})
+	return nil
+}
@@ -13,16 +13,18 @@ import (
 )
 
 type PlacementOptions struct {
-	Strategies                   []string `long:"container-placement-strategy" default:"volume-locality" choice:"volume-locality" choice:"random" choice:"fewest-build-containers" choice:"limit-active-tasks" choice:"limit-active-containers" choice:"limit-active-volumes" description:"Method by which a worker is selected during container placement. If multiple methods are specified, they will be applied in order. Random strategy should only be used alone."`
-	NoInputStrategies            []string `long:"no-input-container-placement-strategy" choice:"volume-locality" choice:"random" choice:"fewest-build-containers" choice:"limit-active-tasks" choice:"limit-active-containers" choice:"limit-active-volumes" description:"A second container placement strategy that will only be used for get and nested check steps."`
+	Strategies                   []string `long:"container-placement-strategy" default:"volume-locality" choice:"volume-locality" choice:"random" choice:"fewest-build-containers" choice:"limit-active-tasks" choice:"limit-active-containers" choice:"limit-active-volumes" choice:"limit-disk-usage" description:"Method by which a worker is selected during container placement. If multiple methods are specified, they will be applied in order. Random strategy should only be used alone."`
+	NoInputStrategies            []string `long:"no-input-container-placement-strategy" choice:"volume-locality" choice:"random" choice:"fewest-build-containers" choice:"limit-active-tasks" choice:"limit-active-containers" choice:"limit-active-volumes" choice:"limit-disk-usage" description:"A second container placement strategy that will only be used for get and nested check steps."`
 	MaxActiveTasksPerWorker      int      `long:"max-active-tasks-per-worker" default:"0" description:"Maximum allowed number of active build tasks per worker. Has effect only when used with limit-active-tasks placement strategy. 0 means no limit."`
 	MaxActiveContainersPerWorker int      `long:"max-active-containers-per-worker" default:"0" description:"Maximum allowed number of active containers per worker. Has effect only when used with limit-active-containers placement strategy. 0 means no limit."`
 	MaxActiveVolumesPerWorker    int      `long:"max-active-volumes-per-worker" default:"0" description:"Maximum allowed number of active volumes per worker. Has effect only when used with limit-active-volumes placement strategy. 0 means no limit."`
+	MaxDiskUsagePerWorker        int      `long:"max-disk-usage-per-worker" default:"90" description:"Percentage of the disk holding a worker's volumes above which no more containers are placed on it. Has effect only when used with limit-disk-usage placement strategy. 0 means no limit."`
 }
 
 var (
 	ErrTooManyContainers = errors.New("worker has too many containers")
 	ErrTooManyVolumes    = errors.New("worker has too many volumes")
+	ErrDiskTooFull       = errors.New("worker's disk is too full")
 )
 
 func NewPlacementStrategy(options PlacementOptions) (PlacementStrategy, PlacementStrategy, error) {
@@ -71,6 +73,11 @@ func newPlaceStrategy(options PlacementOptions, chain []string) (PlacementStrate
 				return nil, errors.New("max-active-volumes-per-worker must be greater or equal than 0")
 			}
 			strategy = append(strategy, limitActiveVolumesStrategy{MaxVolumes: options.MaxActiveVolumesPerWorker})
+		case "limit-disk-usage":
+			if options.MaxDiskUsagePerWorker < 0 || options.MaxDiskUsagePerWorker > 100 {
+				return nil, errors.New("max-disk-usage-per-worker must be between 0 and 100")
+			}
+			strategy = append(strategy, limitDiskUsageStrategy{MaxPercent: options.MaxDiskUsagePerWorker})
 		default:
 			return nil, fmt.Errorf("invalid container placement strategy %s", strategy)
 		}
@@ -389,6 +396,76 @@ func (strategy limitActiveVolumesStrategy) Approve(_ lager.Logger, worker db.Wor
 func (strategy limitActiveVolumesStrategy) Release(lager.Logger, db.Worker, runtime.ContainerSpec) {
 }
 
+// limit-disk-usage
+
+// limitDiskUsageStrategy keeps containers off workers whose volume disk is
+// nearly full, where they would likely fail for running out of space. Workers
+// which have not reported their disk usage are assumed to have room.
+type limitDiskUsageStrategy struct {
+	MaxPercent int
+}
+
+func (strategy limitDiskUsageStrategy) Order(logger lager.Logger, pool Pool, workers []db.Worker, spec runtime.ContainerSpec) ([]db.Worker, error) {
+	return partitionWorkersBy(workers, strategy.workerSatisfies), nil
+}
+
+func (strategy limitDiskUsageStrategy) workerSatisfies(worker db.Worker) bool {
+	if strategy.MaxPercent == 0 {
+		return true
+	}
+
+	diskUsage := worker.DiskUsage()
+	if diskUsage == nil {
+		return true
+	}
+
+	return diskUsage.UsedPercent() < float64(strategy.MaxPercent)
+}
+
+func (strategy limitDiskUsageStrategy) Approve(_ lager.Logger, worker db.Worker, _ runtime.ContainerSpec) error {
+	if !strategy.workerSatisfies(worker) {
+		return ErrDiskTooFull
+	}
+
+	return nil
+}
+
+func (strategy limitDiskUsageStrategy) Release(lager.Logger, db.Worker, runtime.ContainerSpec) {
+}
+
+// fewest-checks-in-flight
+
+// NewFewestChecksInFlightStrategy returns the strategy resource checks are
+// placed with, which favours the workers running the fewest checks across all
+// the ATCs. Ties are still broken randomly (refer to issue #3251).
+func NewFewestChecksInFlightStrategy(slots db.CheckSlots) PlacementStrategy {
+	return PlacementStrategy{fewestChecksInFlightStrategy{slots: slots}}
+}
+
+type fewestChecksInFlightStrategy struct {
+	slots db.CheckSlots
+}
+
+func (strategy fewestChecksInFlightStrategy) Order(logger lager.Logger, pool Pool, workers []db.Worker, spec runtime.ContainerSpec) ([]db.Worker, error) {
+	counts, err := strategy.slots.InFlightPerWorker()
+	if err != nil {
+		return nil, err
+	}
+
+	sortedWorkers := cloneWorkers(workers)
+	sort.SliceStable(sortedWorkers, func(i, j int) bool {
+		return counts[sortedWorkers[i].Name()] < counts[sortedWorkers[j].Name()]
+	})
+
+	return sortedWorkers, nil
+}
+
+func (fewestChecksInFlightStrategy) Approve(lager.Logger, db.Worker, runtime.ContainerSpec) error {
+	return nil
+}
+
+func (fewestChecksInFlightStrategy) Release(lager.Logger, db.Worker, runtime.ContainerSpec) {}
+
 // helpers
 
 func cloneWorkers(workers []db.Worker) []db.Worker {
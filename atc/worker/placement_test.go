@@ -2,6 +2,7 @@ package worker_test
 
 import (
 	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
 	"github.com/concourse/concourse/atc/runtime"
 	"github.com/concourse/concourse/atc/runtime/runtimetest"
 	"github.com/concourse/concourse/atc/worker"
@@ -755,6 +756,106 @@ var _ = Describe("Container Placement Strategies", func() {
 			}
 		})
 	})
+
+	Describe("Limit Disk Usage", func() {
+		limitDiskUsageStrategy := func(max int) worker.PlacementStrategy {
+			strategy, _, err := worker.NewPlacementStrategy(worker.PlacementOptions{
+				Strategies:            []string{"limit-disk-usage"},
+				MaxDiskUsagePerWorker: max,
+			})
+			Expect(err).ToNot(HaveOccurred())
+			return strategy
+		}
+
+		Test("removes workers whose disk is too full", func() {
+			scenario := Setup(
+				workertest.WithBasicJob(),
+				workertest.WithWorkers(
+					grt.NewWorker("worker1").WithDiskUsage(100, 95),
+					grt.NewWorker("worker2").WithDiskUsage(100, 40),
+					grt.NewWorker("worker3"),
+				),
+			)
+
+			strategy := limitDiskUsageStrategy(90)
+			spec := runtime.ContainerSpec{
+				TeamID:   scenario.TeamID,
+				JobID:    scenario.JobID,
+				StepName: scenario.StepName,
+			}
+
+			workers, err := strategy.Order(logger, scenario.Pool, scenario.DB.Workers, spec)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(workerNames(workers)).To(BeOneOf(
+				[]string{"worker2", "worker3", "worker1"},
+				[]string{"worker3", "worker2", "worker1"},
+			))
+
+			err = strategy.Approve(logger, workers[0], spec)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = strategy.Approve(logger, workers[2], spec)
+			Expect(err).To(MatchError(worker.ErrDiskTooFull))
+		})
+
+		Test("noop if limit is unset", func() {
+			scenario := Setup(
+				workertest.WithBasicJob(),
+				workertest.WithWorkers(
+					grt.NewWorker("worker1").WithDiskUsage(100, 100),
+					grt.NewWorker("worker2").WithDiskUsage(100, 40),
+				),
+			)
+
+			strategy := limitDiskUsageStrategy(0)
+			spec := runtime.ContainerSpec{
+				TeamID:   scenario.TeamID,
+				JobID:    scenario.JobID,
+				StepName: scenario.StepName,
+			}
+
+			workers, err := strategy.Order(logger, scenario.Pool, scenario.DB.Workers, spec)
+			Expect(err).ToNot(HaveOccurred())
+
+			for _, worker := range workers {
+				err := strategy.Approve(logger, worker, spec)
+				Expect(err).ToNot(HaveOccurred())
+			}
+		})
+
+		Test("rejects a limit above 100", func() {
+			_, _, err := worker.NewPlacementStrategy(worker.PlacementOptions{
+				Strategies:            []string{"limit-disk-usage"},
+				MaxDiskUsagePerWorker: 101,
+			})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("Fewest Checks In Flight", func() {
+		Test("returns workers with the fewest checks in flight", func() {
+			scenario := Setup(
+				workertest.WithBasicJob(),
+				workertest.WithWorkers(
+					grt.NewWorker("worker1"),
+					grt.NewWorker("worker2"),
+					grt.NewWorker("worker3"),
+				),
+			)
+
+			slots := new(dbfakes.FakeCheckSlots)
+			slots.InFlightPerWorkerReturns(map[string]int{
+				"worker1": 3,
+				"worker2": 1,
+			}, nil)
+
+			strategy := worker.NewFewestChecksInFlightStrategy(slots)
+
+			workers, err := strategy.Order(logger, scenario.Pool, scenario.DB.Workers, runtime.ContainerSpec{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(workerNames(workers)).To(Equal([]string{"worker3", "worker2", "worker1"}))
+		})
+	})
 })
 
 func BeOneOf(vals ...interface{}) types.GomegaMatcher {
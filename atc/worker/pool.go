@@ -12,6 +12,7 @@ import (
 	"code.cloudfoundry.org/lager/lagerctx"
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/faults"
 	"github.com/concourse/concourse/atc/metric"
 	"github.com/concourse/concourse/atc/runtime"
 	"github.com/cppforlife/go-semi-semantic/version"
@@ -52,6 +53,10 @@ func (pool Pool) FindOrSelectWorker(
 ) (runtime.Worker, error) {
 	logger := lagerctx.FromContext(ctx)
 
+	if err := faults.Inject(faults.Worker); err != nil {
+		return nil, err
+	}
+
 	started := time.Now()
 	labels := metric.StepsWaitingLabels{
 		Platform:   workerSpec.Platform,
@@ -106,7 +111,7 @@ func (pool Pool) FindOrSelectWorker(
 		Duration: elapsed,
 	}.Emit(logger)
 
-	return pool.factory.NewWorker(logger, worker), nil
+	return pool.factory.NewWorker(logger, worker)
 }
 
 func (pool Pool) findOrSelectWorker(logger lager.Logger, owner db.ContainerOwner, containerSpec runtime.ContainerSpec, workerSpec Spec, strategy PlacementStrategy) (db.Worker, error) {
@@ -211,7 +216,10 @@ func (pool Pool) findResourceCacheVolumeOnWorker(ctx context.Context, dbWorker d
 	if !found {
 		return nil, false, nil
 	}
-	worker := pool.factory.NewWorker(lagerctx.FromContext(ctx), dbWorker)
+	worker, err := pool.factory.NewWorker(lagerctx.FromContext(ctx), dbWorker)
+	if err != nil {
+		return nil, false, err
+	}
 	return worker.LookupVolume(ctx, volume.Handle())
 }
 
@@ -223,7 +231,11 @@ func (pool Pool) FindWorkerForContainer(logger lager.Logger, owner db.ContainerO
 	if !found {
 		return nil, false, nil
 	}
-	return pool.factory.NewWorker(logger, worker), true, nil
+	w, err := pool.factory.NewWorker(logger, worker)
+	if err != nil {
+		return nil, false, err
+	}
+	return w, true, nil
 }
 
 func (pool Pool) findWorkerForContainer(logger lager.Logger, owner db.ContainerOwner, workerSpec Spec) (db.Worker, []db.Worker, bool, error) {
@@ -271,7 +283,11 @@ func (pool Pool) FindWorker(logger lager.Logger, name string) (runtime.Worker, b
 		logger.Info("worker-not-found", lager.Data{"worker": name})
 		return nil, false, nil
 	}
-	return pool.factory.NewWorker(logger, worker), true, nil
+	w, err := pool.factory.NewWorker(logger, worker)
+	if err != nil {
+		return nil, false, err
+	}
+	return w, true, nil
 }
 
 func (pool Pool) LocateVolume(ctx context.Context, teamID int, handle string) (runtime.Volume, runtime.Worker, bool, error) {
@@ -293,7 +309,11 @@ func (pool Pool) LocateVolume(ctx context.Context, teamID int, handle string) (r
 	logger = logger.WithData(lager.Data{"worker": dbWorker.Name()})
 	logger.Debug("found-volume-on-worker")
 
-	worker := pool.factory.NewWorker(logger, dbWorker)
+	worker, err := pool.factory.NewWorker(logger, dbWorker)
+	if err != nil {
+		logger.Error("failed-to-construct-worker", err)
+		return nil, nil, false, err
+	}
 
 	volume, found, err := worker.LookupVolume(ctx, handle)
 	if err != nil {
@@ -327,7 +347,11 @@ func (pool Pool) LocateContainer(ctx context.Context, teamID int, handle string)
 	logger = logger.WithData(lager.Data{"worker": dbWorker.Name()})
 	logger.Debug("found-volume-on-worker")
 
-	worker := pool.factory.NewWorker(logger, dbWorker)
+	worker, err := pool.factory.NewWorker(logger, dbWorker)
+	if err != nil {
+		logger.Error("failed-to-construct-worker", err)
+		return nil, nil, false, err
+	}
 
 	container, found, err := worker.LookupContainer(ctx, handle)
 	if err != nil {
@@ -349,7 +373,10 @@ func (pool Pool) CreateVolumeForArtifact(ctx context.Context, spec Spec) (runtim
 		return nil, nil, err
 	}
 
-	worker := pool.factory.NewWorker(logger, compatibleWorkers[rand.Intn(len(compatibleWorkers))])
+	worker, err := pool.factory.NewWorker(logger, compatibleWorkers[rand.Intn(len(compatibleWorkers))])
+	if err != nil {
+		return nil, nil, err
+	}
 	return worker.CreateVolumeForArtifact(ctx, spec.TeamID)
 }
 
@@ -381,7 +408,7 @@ func (pool Pool) allCompatibleAndRunningWorkers(logger lager.Logger, spec Spec)
 		return compatibleTeamWorkers, nil
 	}
 
-	if len(compatibleGeneralWorkers) != 0 {
+	if len(compatibleGeneralWorkers) != 0 && !spec.TeamWorkersOnly {
 		return compatibleGeneralWorkers, nil
 	}
 
@@ -258,6 +258,30 @@ var _ = Describe("Pool", func() {
 			Expect(worker.Name()).To(BeOneOf("worker2", "worker3"))
 		})
 
+		Test("does not consider general workers when restricted to team workers", func() {
+			scenario := Setup(
+				workertest.WithTeam("team"),
+				workertest.WithWorkers(
+					grt.NewWorker("worker1").WithTeam("team").WithPlatform("dummy"),
+					grt.NewWorker("worker2"),
+				),
+			)
+
+			_, err := scenario.Pool.FindOrSelectWorker(
+				ctx,
+				db.NewFixedHandleContainerOwner("my-container"),
+				runtime.ContainerSpec{},
+				worker.Spec{
+					Platform:        "linux",
+					TeamID:          scenario.Team("team").ID(),
+					TeamWorkersOnly: true,
+				},
+				nil,
+				nil,
+			)
+			Expect(err).To(MatchError(ContainSubstring("team workers only")))
+		})
+
 		Test("no worker satisfies strategy", func() {
 			scenario := Setup(
 				workertest.WithWorkers(
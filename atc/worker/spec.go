@@ -10,6 +10,10 @@ type Spec struct {
 	ResourceType string
 	Tags         []string
 	TeamID       int
+
+	// TeamWorkersOnly prevents falling back to the general workers when none
+	// of the team's workers are compatible.
+	TeamWorkersOnly bool
 }
 
 func (spec Spec) Description() string {
@@ -27,5 +31,9 @@ func (spec Spec) Description() string {
 		attrs = append(attrs, fmt.Sprintf("tag '%s'", tag))
 	}
 
+	if spec.TeamWorkersOnly {
+		attrs = append(attrs, "team workers only")
+	}
+
 	return strings.Join(attrs, ", ")
 }
@@ -3,6 +3,7 @@ package worker
 import (
 	"archive/tar"
 	"context"
+	"fmt"
 	"io"
 	"time"
 
@@ -48,7 +49,10 @@ func (s Streamer) Stream(ctx context.Context, src runtime.Artifact, dst runtime.
 	logger.Info("start")
 	defer logger.Info("end")
 
-	err := s.stream(ctx, src, dst)
+	streamCtx, streamed := runtime.StartPhase(ctx, atc.StartLatencyVolumeStreaming)
+	err := s.stream(streamCtx, src, dst)
+	streamed()
+
 	if err != nil {
 		return err
 	}
@@ -91,22 +95,71 @@ func (s Streamer) Stream(ctx context.Context, src runtime.Artifact, dst runtime.
 }
 
 func (s Streamer) stream(ctx context.Context, src runtime.Artifact, dst runtime.Volume) error {
-	if !s.p2p.Enabled {
-		return s.streamThroughATC(ctx, src, dst)
+	compression, err := s.negotiateCompression(src, dst)
+	if err != nil {
+		return err
+	}
+
+	if !s.p2p.Enabled || !supportsP2PStreaming(src) || !supportsP2PStreaming(dst) {
+		return s.streamThroughATC(ctx, src, dst, compression)
 	}
 	p2pSrc, ok := src.(runtime.P2PVolume)
 	if !ok {
-		return s.streamThroughATC(ctx, src, dst)
+		return s.streamThroughATC(ctx, src, dst, compression)
 	}
 	p2pDst, ok := dst.(runtime.P2PVolume)
 	if !ok {
-		return s.streamThroughATC(ctx, src, dst)
+		return s.streamThroughATC(ctx, src, dst, compression)
+	}
+
+	return s.p2pStream(ctx, p2pSrc, p2pDst, compression)
+}
+
+// negotiateCompression picks the compression with which to stream between
+// the artifacts' workers. The configured compression is preferred, but
+// workers running older versions may only support some of the others.
+func (s Streamer) negotiateCompression(artifacts ...runtime.Artifact) (compression.Compression, error) {
+	candidates := append([]compression.Compression{s.compression}, streamingCompressions...)
+	for _, candidate := range candidates {
+		if supportsStreamingCompression(candidate, artifacts...) {
+			return candidate, nil
+		}
 	}
 
-	return s.p2pStream(ctx, p2pSrc, p2pDst)
+	return nil, NoCommonStreamingCompressionError{Sources: artifactSources(artifacts)}
+}
+
+var streamingCompressions = []compression.Compression{
+	compression.NewGzipCompression(),
+	compression.NewZstdCompression(),
 }
 
-func (s Streamer) streamThroughATC(ctx context.Context, src runtime.Artifact, dst runtime.Volume) error {
+func supportsStreamingCompression(compression compression.Compression, artifacts ...runtime.Artifact) bool {
+	for _, artifact := range artifacts {
+		capable, ok := artifact.(runtime.CapableVolume)
+		if ok && !capable.WorkerCapabilities().SupportsStreamingCompression(string(compression.Encoding())) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func supportsP2PStreaming(artifact runtime.Artifact) bool {
+	capable, ok := artifact.(runtime.CapableVolume)
+	return !ok || capable.WorkerCapabilities().P2PStreaming
+}
+
+func artifactSources(artifacts []runtime.Artifact) []string {
+	sources := make([]string, len(artifacts))
+	for i, artifact := range artifacts {
+		sources[i] = artifact.Source()
+	}
+
+	return sources
+}
+
+func (s Streamer) streamThroughATC(ctx context.Context, src runtime.Artifact, dst runtime.Volume, compression compression.Compression) error {
 	traceAttrs := tracing.Attrs{
 		"dest-worker": dst.DBVolume().WorkerName(),
 	}
@@ -114,7 +167,7 @@ func (s Streamer) streamThroughATC(ctx context.Context, src runtime.Artifact, ds
 		traceAttrs["origin-volume"] = srcVolume.Handle()
 		traceAttrs["origin-worker"] = srcVolume.DBVolume().WorkerName()
 	}
-	out, err := src.StreamOut(ctx, ".", s.compression)
+	out, err := src.StreamOut(ctx, ".", compression)
 
 	if err != nil {
 		return err
@@ -122,10 +175,105 @@ func (s Streamer) streamThroughATC(ctx context.Context, src runtime.Artifact, ds
 
 	defer out.Close()
 
-	return dst.StreamIn(ctx, ".", s.compression, out)
+	if !atc.EnableVolumeDigests {
+		return dst.StreamIn(ctx, ".", compression, out)
+	}
+
+	digest, err := s.streamInWithDigest(ctx, out, dst, compression)
+	if err != nil {
+		return err
+	}
+
+	return s.verifyDigest(ctx, src, dst, digest)
 }
 
-func (s Streamer) p2pStream(ctx context.Context, src runtime.P2PVolume, dst runtime.P2PVolume) error {
+// streamInWithDigest streams the content into the destination volume while
+// computing its digest as it passes through.
+func (s Streamer) streamInWithDigest(ctx context.Context, out io.Reader, dst runtime.Volume, compression compression.Compression) (string, error) {
+	digestReader, digestWriter := io.Pipe()
+
+	type digestResult struct {
+		digest string
+		err    error
+	}
+
+	digested := make(chan digestResult, 1)
+	go func() {
+		digest, err := contentDigest(compression, digestReader)
+
+		// keep consuming the stream so that streaming in is never blocked on
+		// the digest, even if it failed
+		io.Copy(io.Discard, digestReader)
+
+		digested <- digestResult{digest, err}
+	}()
+
+	tee := io.TeeReader(out, digestWriter)
+
+	err := dst.StreamIn(ctx, ".", compression, tee)
+	if err == nil {
+		// the destination may stop reading at the end of the archive, before
+		// any trailing bytes which the digest still needs
+		_, err = io.Copy(io.Discard, tee)
+	}
+
+	digestWriter.CloseWithError(err)
+
+	result := <-digested
+	if err != nil {
+		return "", err
+	}
+
+	if result.err != nil {
+		return "", fmt.Errorf("compute content digest: %w", result.err)
+	}
+
+	return result.digest, nil
+}
+
+// verifyDigest compares the digest of the streamed content against the one
+// recorded for the source volume, and records it for both volumes. A
+// mismatch means that the source volume's content has changed since it was
+// first streamed, e.g. because its disk was corrupted.
+func (s Streamer) verifyDigest(ctx context.Context, src runtime.Artifact, dst runtime.Volume, digest string) error {
+	logger := lagerctx.FromContext(ctx)
+
+	if srcVolume, ok := src.(runtime.Volume); ok {
+		expected, found, err := srcVolume.DBVolume().ContentDigest()
+		if err != nil {
+			return err
+		}
+
+		if found && expected != digest {
+			logger.Info("volume-digest-mismatch", lager.Data{
+				"handle":   srcVolume.Handle(),
+				"worker":   srcVolume.DBVolume().WorkerName(),
+				"expected": expected,
+				"actual":   digest,
+			})
+
+			metric.Metrics.VolumeDigestMismatches.Inc()
+
+			return VolumeDigestMismatchError{
+				Handle:         srcVolume.Handle(),
+				WorkerName:     srcVolume.DBVolume().WorkerName(),
+				ExpectedDigest: expected,
+				ActualDigest:   digest,
+			}
+		}
+
+		if !found {
+			err = srcVolume.DBVolume().SetContentDigest(digest)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return dst.DBVolume().SetContentDigest(digest)
+}
+
+func (s Streamer) p2pStream(ctx context.Context, src runtime.P2PVolume, dst runtime.P2PVolume, compression compression.Compression) error {
 	getCtx, getCancel := context.WithTimeout(ctx, 5*time.Second)
 	defer getCancel()
 
@@ -149,16 +297,21 @@ func (s Streamer) p2pStream(ctx context.Context, src runtime.P2PVolume, dst runt
 		defer putCancel()
 	}
 
-	return src.StreamP2POut(putCtx, ".", streamInUrl, s.compression)
+	return src.StreamP2POut(putCtx, ".", streamInUrl, compression)
 }
 
 func (s Streamer) StreamFile(ctx context.Context, artifact runtime.Artifact, path string) (io.ReadCloser, error) {
-	out, err := artifact.StreamOut(ctx, path, s.compression)
+	compression, err := s.negotiateCompression(artifact)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := artifact.StreamOut(ctx, path, compression)
 	if err != nil {
 		return nil, err
 	}
 
-	compressionReader, err := s.compression.NewReader(out)
+	compressionReader, err := compression.NewReader(out)
 	if err != nil {
 		return nil, err
 	}
@@ -3,8 +3,10 @@ package worker_test
 import (
 	"context"
 	"io/ioutil"
+	"testing/fstest"
 
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/compression"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/runtime"
 	"github.com/concourse/concourse/atc/runtime/runtimetest"
@@ -174,6 +176,89 @@ var _ = Describe("Streamer", func() {
 		})
 	})
 
+	Test("records the digest of volumes streamed through ATC", func() {
+		atc.EnableVolumeDigests = true
+		defer func() { atc.EnableVolumeDigests = false }()
+
+		content := runtimetest.VolumeContent{
+			"file1":        {Data: []byte("content 1")},
+			"folder/file2": {Data: []byte("content 2")},
+		}
+		scenario := Setup(
+			workertest.WithWorkers(
+				grt.NewWorker("src-worker").
+					WithVolumesCreatedInDBAndBaggageclaim(
+						grt.NewVolume("src").WithContent(content),
+					),
+				grt.NewWorker("dst-worker").
+					WithVolumesCreatedInDBAndBaggageclaim(
+						grt.NewVolume("dst"),
+					),
+			),
+		)
+
+		streamer := scenario.Streamer(worker.P2PConfig{
+			Enabled: false,
+		})
+
+		ctx := context.Background()
+		src := scenario.WorkerVolume("src-worker", "src")
+		dst := scenario.WorkerVolume("dst-worker", "dst")
+
+		err := streamer.Stream(ctx, src, dst)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(baggageclaimVolume(dst)).To(grt.HaveContent(content))
+
+		srcDigest, found, err := src.DBVolume().ContentDigest()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(srcDigest).To(HavePrefix("sha256:"))
+
+		dstDigest, found, err := dst.DBVolume().ContentDigest()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(dstDigest).To(Equal(srcDigest))
+	})
+
+	Test("fails to stream a volume whose content no longer matches its digest", func() {
+		atc.EnableVolumeDigests = true
+		defer func() { atc.EnableVolumeDigests = false }()
+
+		scenario := Setup(
+			workertest.WithWorkers(
+				grt.NewWorker("src-worker").
+					WithVolumesCreatedInDBAndBaggageclaim(
+						grt.NewVolume("src").WithContent(runtimetest.VolumeContent{
+							"file": {Data: []byte("content")},
+						}),
+					),
+				grt.NewWorker("dst-worker").
+					WithVolumesCreatedInDBAndBaggageclaim(
+						grt.NewVolume("dst1"),
+						grt.NewVolume("dst2"),
+					),
+			),
+		)
+
+		streamer := scenario.Streamer(worker.P2PConfig{
+			Enabled: false,
+		})
+
+		ctx := context.Background()
+		src := scenario.WorkerVolume("src-worker", "src")
+
+		err := streamer.Stream(ctx, src, scenario.WorkerVolume("dst-worker", "dst1"))
+		Expect(err).ToNot(HaveOccurred())
+
+		By("corrupting the src volume", func() {
+			baggageclaimVolume(src).Content["file"] = &fstest.MapFile{Data: []byte("corrupted")}
+		})
+
+		err = streamer.Stream(ctx, src, scenario.WorkerVolume("dst-worker", "dst2"))
+		Expect(err).To(BeAssignableToTypeOf(worker.VolumeDigestMismatchError{}))
+	})
+
 	Test("P2P stream between workers", func() {
 		content := runtimetest.VolumeContent{
 			"file1":        {Data: []byte("content 1")},
@@ -206,6 +291,131 @@ var _ = Describe("Streamer", func() {
 		Expect(baggageclaimVolume(dst)).To(grt.HaveContent(content))
 	})
 
+	Test("falls back on a compression every worker supports", func() {
+		content := runtimetest.VolumeContent{
+			"file1":        {Data: []byte("content 1")},
+			"folder/file2": {Data: []byte("content 2")},
+		}
+		gzipOnly := func(w *atc.Worker) {
+			w.Capabilities = &atc.WorkerCapabilities{
+				StreamingCompression: []string{atc.StreamingCompressionGzip},
+			}
+		}
+		scenario := Setup(
+			workertest.WithWorkers(
+				grt.NewWorker("src-worker").
+					WithWorkerSetup(gzipOnly).
+					WithVolumesCreatedInDBAndBaggageclaim(
+						grt.NewVolume("src").WithContent(content),
+					),
+				grt.NewWorker("dst-worker").
+					WithWorkerSetup(gzipOnly).
+					WithVolumesCreatedInDBAndBaggageclaim(
+						grt.NewVolume("dst"),
+					),
+			),
+		)
+
+		streamer := worker.NewStreamer(
+			scenario.Factory.DB.ResourceCacheFactory,
+			compression.NewZstdCompression(),
+			worker.P2PConfig{Enabled: false},
+		)
+
+		ctx := context.Background()
+		src := scenario.WorkerVolume("src-worker", "src")
+		dst := scenario.WorkerVolume("dst-worker", "dst")
+
+		err := streamer.Stream(ctx, src, dst)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(baggageclaimVolume(dst)).To(grt.HaveContent(content))
+	})
+
+	Test("fails to stream between workers with no compression in common", func() {
+		scenario := Setup(
+			workertest.WithWorkers(
+				grt.NewWorker("src-worker").
+					WithWorkerSetup(func(w *atc.Worker) {
+						w.Capabilities = &atc.WorkerCapabilities{
+							StreamingCompression: []string{atc.StreamingCompressionGzip},
+						}
+					}).
+					WithVolumesCreatedInDBAndBaggageclaim(
+						grt.NewVolume("src"),
+					),
+				grt.NewWorker("dst-worker").
+					WithWorkerSetup(func(w *atc.Worker) {
+						w.Capabilities = &atc.WorkerCapabilities{
+							StreamingCompression: []string{atc.StreamingCompressionZstd},
+						}
+					}).
+					WithVolumesCreatedInDBAndBaggageclaim(
+						grt.NewVolume("dst"),
+					),
+			),
+		)
+
+		streamer := scenario.Streamer(worker.P2PConfig{
+			Enabled: false,
+		})
+
+		ctx := context.Background()
+		src := scenario.WorkerVolume("src-worker", "src")
+		dst := scenario.WorkerVolume("dst-worker", "dst")
+
+		err := streamer.Stream(ctx, src, dst)
+		Expect(err).To(Equal(worker.NoCommonStreamingCompressionError{
+			Sources: []string{"src-worker", "dst-worker"},
+		}))
+	})
+
+	Test("streams through ATC when a worker cannot P2P stream", func() {
+		atc.EnableVolumeDigests = true
+		defer func() { atc.EnableVolumeDigests = false }()
+
+		content := runtimetest.VolumeContent{
+			"file1": {Data: []byte("content 1")},
+		}
+		scenario := Setup(
+			workertest.WithWorkers(
+				grt.NewWorker("src-worker").
+					WithVolumesCreatedInDBAndBaggageclaim(
+						grt.NewVolume("src").WithContent(content),
+					),
+				grt.NewWorker("dst-worker").
+					WithWorkerSetup(func(w *atc.Worker) {
+						w.Capabilities = &atc.WorkerCapabilities{
+							StreamingCompression: atc.StreamingCompressions,
+							P2PStreaming:         false,
+						}
+					}).
+					WithVolumesCreatedInDBAndBaggageclaim(
+						grt.NewVolume("dst"),
+					),
+			),
+		)
+
+		streamer := scenario.Streamer(worker.P2PConfig{
+			Enabled: true,
+		})
+
+		ctx := context.Background()
+		src := scenario.WorkerVolume("src-worker", "src")
+		dst := scenario.WorkerVolume("dst-worker", "dst")
+
+		err := streamer.Stream(ctx, src, dst)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(baggageclaimVolume(dst)).To(grt.HaveContent(content))
+
+		By("validating the content passed through the ATC, which records its digest", func() {
+			_, found, err := dst.DBVolume().ContentDigest()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+		})
+	})
+
 	Test("stream file from volume", func() {
 		content := runtimetest.VolumeContent{
 			"file":        {Data: []byte("content 1")},
@@ -43,6 +43,8 @@ func Setup(dbConn db.Conn, lockFactory lock.LockFactory, setup ...SetupFunc) *Sc
 		db.NewResourceCacheFactory(dbConn, lockFactory),
 		db.NewWorkerBaseResourceTypeFactory(dbConn),
 		lockFactory,
+		db.NewCABundleRepository(dbConn),
+		db.NewTeamProxies(dbConn),
 	)
 	factory := &Factory{DB: db}
 	pool := worker.NewPool(
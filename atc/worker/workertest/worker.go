@@ -22,11 +22,11 @@ type Factory struct {
 	DB      worker.DB
 }
 
-func (f Factory) NewWorker(_ lager.Logger, dbWorker db.Worker) runtime.Worker {
+func (f Factory) NewWorker(_ lager.Logger, dbWorker db.Worker) (runtime.Worker, error) {
 	worker, _, ok := f.FindWorker(dbWorker.Name())
 	Expect(ok).To(BeTrue(), "worker '%s' was not setup in the scenario", dbWorker.Name())
 
-	return worker.Build(f.DB, dbWorker)
+	return worker.Build(f.DB, dbWorker), nil
 }
 
 func (f Factory) FindWorker(name string) (Worker, int, bool) {
@@ -60,5 +60,111 @@ var _ = Describe("Worker", func() {
 				Expect(err.Error()).To(ContainSubstring("missing garden address"))
 			})
 		})
+
+		Context("when runtime is a known runtime", func() {
+			BeforeEach(func() {
+				worker.Runtime = atc.WorkerRuntimeContainerd
+			})
+
+			It("returns no errors", func() {
+				Expect(worker.Validate()).To(Succeed())
+			})
+		})
+
+		Context("when runtime is unknown", func() {
+			BeforeEach(func() {
+				worker.Runtime = "bogus"
+			})
+
+			It("returns errors", func() {
+				err := worker.Validate()
+				Expect(err).To(Equal(atc.ErrUnknownWorkerRuntime))
+			})
+		})
+
+		Context("when the worker advertises its capabilities", func() {
+			BeforeEach(func() {
+				worker.Capabilities = &atc.WorkerCapabilities{
+					StreamingCompression: []string{atc.StreamingCompressionZstd},
+					P2PStreaming:         true,
+					MaxImageSize:         1024,
+				}
+			})
+
+			It("returns no errors", func() {
+				Expect(worker.Validate()).To(Succeed())
+			})
+
+			Context("with an unknown streaming compression", func() {
+				BeforeEach(func() {
+					worker.Capabilities.StreamingCompression = []string{"bogus"}
+				})
+
+				It("returns errors", func() {
+					Expect(worker.Validate()).To(Equal(atc.ErrUnknownStreamingCompression))
+				})
+			})
+
+			Context("with a negative max image size", func() {
+				BeforeEach(func() {
+					worker.Capabilities.MaxImageSize = -1
+				})
+
+				It("returns errors", func() {
+					Expect(worker.Validate()).To(Equal(atc.ErrInvalidMaxImageSize))
+				})
+			})
+		})
+
+		Context("with disk usage", func() {
+			BeforeEach(func() {
+				worker.DiskUsage = &atc.WorkerDiskUsage{
+					TotalBytes: 1024,
+					UsedBytes:  512,
+				}
+			})
+
+			It("returns no errors", func() {
+				Expect(worker.Validate()).To(Succeed())
+			})
+
+			Context("using more bytes than the disk holds", func() {
+				BeforeEach(func() {
+					worker.DiskUsage.UsedBytes = 2048
+				})
+
+				It("returns errors", func() {
+					Expect(worker.Validate()).To(Equal(atc.ErrInvalidDiskUsage))
+				})
+			})
+		})
+	})
+
+	Describe("WorkerDiskUsage", func() {
+		It("reports the percentage of the disk in use", func() {
+			usage := atc.WorkerDiskUsage{TotalBytes: 200, UsedBytes: 150}
+			Expect(usage.UsedPercent()).To(Equal(75.0))
+		})
+
+		It("reports an empty disk as unused", func() {
+			Expect(atc.WorkerDiskUsage{}.UsedPercent()).To(BeZero())
+		})
+	})
+
+	Describe("WorkerCapabilities", func() {
+		It("supports the streaming compressions it lists", func() {
+			capabilities := atc.WorkerCapabilities{
+				StreamingCompression: []string{atc.StreamingCompressionGzip},
+			}
+
+			Expect(capabilities.SupportsStreamingCompression(atc.StreamingCompressionGzip)).To(BeTrue())
+			Expect(capabilities.SupportsStreamingCompression(atc.StreamingCompressionZstd)).To(BeFalse())
+		})
+
+		It("assumes workers without capabilities support every streaming compression", func() {
+			for _, compression := range atc.StreamingCompressions {
+				Expect(atc.LegacyWorkerCapabilities.SupportsStreamingCompression(compression)).To(BeTrue())
+			}
+		})
 	})
 })
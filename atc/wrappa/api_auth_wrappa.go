@@ -47,12 +47,17 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 		case atc.GetBuildPreparation,
 			atc.BuildEvents,
 			atc.GetBuildPlan,
+			atc.GetBuildSteps,
+			atc.GetBuildProvenance,
 			atc.ListBuildArtifacts:
 			newHandler = wrappa.checkBuildReadAccessHandlerFactory.CheckIfPrivateJobHandler(handler, rejector)
 
 			// resource belongs to authorized team
 		case atc.AbortBuild,
-			atc.SetBuildComment:
+			atc.AbortBuildStep,
+			atc.SetBuildComment,
+			atc.ListBuildContainers,
+			atc.ListBuildVolumes:
 			newHandler = wrappa.checkBuildWriteAccessHandlerFactory.HandlerFor(handler, rejector)
 
 		// requester is system, admin team, or worker owning team
@@ -69,9 +74,13 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 		case atc.GetPipeline,
 			atc.GetJobBuild,
 			atc.PipelineBadge,
+			atc.GetPipelineGraph,
 			atc.JobBadge,
 			atc.ListJobs,
 			atc.GetJob,
+			atc.ListJobStatistics,
+			atc.GetJobStatistics,
+			atc.ListJobFlakiness,
 			atc.ListJobBuilds,
 			atc.ListPipelineBuilds,
 			atc.GetResource,
@@ -79,6 +88,7 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.ListBuildsWithVersionAsOutput,
 			atc.GetDownstreamResourceCausality,
 			atc.GetUpstreamResourceCausality,
+			atc.GetResourceVersionImpact,
 			atc.GetResourceVersion,
 			atc.ListResources,
 			atc.ListResourceTypes,
@@ -90,7 +100,21 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.RegisterWorker,
 			atc.HeartbeatWorker,
 			atc.DeleteWorker,
+			atc.ListWorkerKeys,
 			atc.ListTeamBuilds,
+			atc.ListClusterFreezeWindows,
+			atc.GetClusterCABundle,
+			atc.ListTeamRequests,
+			atc.CreateTeamRequest,
+			atc.GetDashboardPreferences,
+			atc.OrderDashboardPipelines,
+			atc.FavoritePipeline,
+			atc.UnfavoritePipeline,
+			atc.GetUserPreferences,
+			atc.SetUserPreference,
+			atc.DeleteUserPreference,
+			atc.ListSessions,
+			atc.RevokeSession,
 			atc.GetUser:
 			newHandler = auth.CheckAuthenticationHandler(handler, rejector)
 
@@ -98,6 +122,9 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 		case atc.DownloadCLI,
 			atc.CheckResourceWebHook,
 			atc.GetInfo,
+			atc.GetOpenAPI,
+			atc.GetHealth,
+			atc.GetConfigSchema,
 			atc.ListTeams,
 			atc.ListAllPipelines,
 			atc.ListPipelines,
@@ -111,27 +138,86 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 		// admin
 		case atc.GetLogLevel,
 			atc.DestroyTeam,
+			atc.SetTeamPrivilegedSettings,
+			atc.SetTeamProxySettings,
 			atc.ListActiveUsersSince,
+			atc.ListUserSessions,
+			atc.RevokeUserSessions,
 			atc.SetLogLevel,
+			atc.GetRequestLog,
+			atc.SetRequestLog,
 			atc.GetInfoCreds,
 			atc.SetWall,
 			atc.ClearWall,
 			atc.ClearResourceVersions,
 			atc.ClearResourceTypeVersions,
 			atc.ListSharedForResource,
-			atc.ListSharedForResourceType:
+			atc.ListSharedForResourceType,
+			atc.ListClusterWebhooks,
+			atc.SetClusterWebhook,
+			atc.DestroyClusterWebhook,
+			atc.ListClusterWebhookDeliveries,
+			atc.CreateClusterFreezeWindow,
+			atc.DestroyClusterFreezeWindow,
+			atc.GetClusterInjectedEnv,
+			atc.SetClusterInjectedEnv,
+			atc.SetClusterCABundle,
+			atc.ApproveTeamRequest,
+			atc.RejectTeamRequest,
+			atc.GetSchedulerProfile,
+			atc.GetDBSchema,
+			atc.GetDBMaintenance,
+			atc.GetDBGrowth,
+			atc.GetAutoscalingSignal,
+			atc.GetDeprecationReport,
+			atc.GetClusterOverview,
+			atc.ListComponents,
+			atc.SetComponentInterval,
+			atc.ResetComponentInterval,
+			atc.PauseComponent,
+			atc.UnpauseComponent:
 			newHandler = auth.CheckAdminHandler(handler, rejector)
 
 		// authorized (requested team matches resource team and has required role, or is admin)
 		case atc.GetTeam,
 			atc.SetTeam,
 			atc.RenameTeam,
+			atc.ListTeamUsers,
+			atc.GetTeamWorkerKeys,
+			atc.SetTeamWorkerKeys,
+			atc.GetTeamInterceptSettings,
+			atc.SetTeamInterceptSettings,
+			atc.GetTeamPrivilegedSettings,
+			atc.GetTeamProxySettings,
+			atc.ListNotifiers,
+			atc.SetNotifier,
+			atc.DestroyNotifier,
+			atc.ListTeamWebhooks,
+			atc.SetTeamWebhook,
+			atc.DestroyTeamWebhook,
+			atc.ListTeamWebhookDeliveries,
+			atc.ListTeamLogDrains,
+			atc.SetTeamLogDrain,
+			atc.DestroyTeamLogDrain,
+			atc.ListTeamFreezeWindows,
+			atc.CreateTeamFreezeWindow,
+			atc.DestroyTeamFreezeWindow,
+			atc.GetTeamInjectedEnv,
+			atc.SetTeamInjectedEnv,
+			atc.GetTeamCABundle,
+			atc.SetTeamCABundle,
+			atc.ListFlakyJobs,
+			atc.ListTeamBuildQueue,
 			atc.ListContainers,
 			atc.GetContainer,
 			atc.HijackContainer,
+			atc.ListContainerProcesses,
 			atc.ListVolumes,
 			atc.CreateBuild,
+			atc.RunTask,
 			atc.CheckResource,
+			atc.FetchResourceVersionMetadata,
+			atc.BackfillResourceMetadata,
 			atc.CheckResourceType,
 			atc.CheckPrototype,
 			atc.CreateJobBuild,
@@ -141,8 +227,10 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.DisableResourceVersion,
 			atc.EnableResourceVersion,
 			atc.PinResourceVersion,
+			atc.PushResourceVersions,
 			atc.UnpinResource,
 			atc.SetPinCommentOnResource,
+			atc.ResetResourceCheckFrom,
 			atc.GetConfig,
 			atc.GetCC,
 			atc.GetVersionsDB,
@@ -154,9 +242,20 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.PausePipeline,
 			atc.UnpausePipeline,
 			atc.RenamePipeline,
+			atc.MovePipeline,
 			atc.ExposePipeline,
 			atc.HidePipeline,
+			atc.PausePipelines,
+			atc.UnpausePipelines,
+			atc.ExposePipelines,
+			atc.HidePipelines,
+			atc.CheckPipelines,
 			atc.SaveConfig,
+			atc.ValidateConfig,
+			atc.ReportConfigVars,
+			atc.StageConfig,
+			atc.PromoteCandidateConfig,
+			atc.DiscardCandidateConfig,
 			atc.ArchivePipeline,
 			atc.ClearTaskCache,
 			atc.ClearResourceCache,
@@ -0,0 +1,80 @@
+package wrappa
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/tedsuo/rata"
+)
+
+//counterfeiter:generate . DeprecationTracker
+type DeprecationTracker interface {
+	Track(deprecation atc.APIDeprecation, userAgent string, team string)
+}
+
+// DeprecationWrappa tags the responses to requests which use deprecated parts
+// of the API with a Deprecation header, and a Sunset header if they have a
+// date for their removal, and tracks which clients made them.
+type DeprecationWrappa struct {
+	tracker      DeprecationTracker
+	deprecations []atc.APIDeprecation
+}
+
+func NewDeprecationWrappa(tracker DeprecationTracker, deprecations []atc.APIDeprecation) Wrappa {
+	return DeprecationWrappa{
+		tracker:      tracker,
+		deprecations: deprecations,
+	}
+}
+
+func (wrappa DeprecationWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
+	deprecations := map[string][]atc.APIDeprecation{}
+	for _, deprecation := range wrappa.deprecations {
+		deprecations[deprecation.Route] = append(deprecations[deprecation.Route], deprecation)
+	}
+
+	wrapped := rata.Handlers{}
+
+	for name, handler := range handlers {
+		if len(deprecations[name]) == 0 {
+			wrapped[name] = handler
+			continue
+		}
+
+		wrapped[name] = deprecatedHandler{
+			tracker:      wrappa.tracker,
+			deprecations: deprecations[name],
+			handler:      handler,
+		}
+	}
+
+	return wrapped
+}
+
+type deprecatedHandler struct {
+	tracker      DeprecationTracker
+	deprecations []atc.APIDeprecation
+	handler      http.Handler
+}
+
+func (handler deprecatedHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	for _, deprecation := range handler.deprecations {
+		if deprecation.Param != "" && !r.URL.Query().Has(deprecation.Param) {
+			continue
+		}
+
+		w.Header().Set("Deprecation", "true")
+
+		if deprecation.Sunset != "" {
+			sunset, err := time.Parse("2006-01-02", deprecation.Sunset)
+			if err == nil {
+				w.Header().Set("Sunset", sunset.Format(http.TimeFormat))
+			}
+		}
+
+		handler.tracker.Track(deprecation, r.UserAgent(), rata.Param(r, "team_name"))
+	}
+
+	handler.handler.ServeHTTP(w, r)
+}
@@ -0,0 +1,113 @@
+package wrappa_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/wrappa"
+	"github.com/concourse/concourse/atc/wrappa/wrappafakes"
+	"github.com/tedsuo/rata"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DeprecationWrappa", func() {
+	var (
+		fakeTracker  *wrappafakes.FakeDeprecationTracker
+		deprecations []atc.APIDeprecation
+
+		handled     int
+		handlerFunc http.HandlerFunc
+
+		request  *http.Request
+		recorder *httptest.ResponseRecorder
+	)
+
+	BeforeEach(func() {
+		fakeTracker = new(wrappafakes.FakeDeprecationTracker)
+		deprecations = []atc.APIDeprecation{
+			{Route: atc.MainJobBadge, Sunset: "2030-01-02"},
+			{Route: atc.ListTeamBuilds, Param: "legacy"},
+		}
+
+		handled = 0
+		handlerFunc = func(w http.ResponseWriter, r *http.Request) {
+			handled++
+		}
+
+		recorder = httptest.NewRecorder()
+	})
+
+	serve := func(route string) {
+		handler := wrappa.NewDeprecationWrappa(fakeTracker, deprecations).Wrap(rata.Handlers{
+			route: handlerFunc,
+		})[route]
+
+		handler.ServeHTTP(recorder, request)
+	}
+
+	It("leaves the other routes as they are", func() {
+		wrapped := wrappa.NewDeprecationWrappa(fakeTracker, deprecations).Wrap(rata.Handlers{
+			atc.GetInfo: handlerFunc,
+		})
+		Expect(wrapped[atc.GetInfo]).To(BeAssignableToTypeOf(handlerFunc))
+	})
+
+	Context("when the route is deprecated", func() {
+		BeforeEach(func() {
+			request = httptest.NewRequest("GET", "/api/v1/pipelines/some-pipeline/jobs/some-job/badge", nil)
+			request.Header.Set("User-Agent", "some-agent")
+		})
+
+		It("tags the response and tracks the client", func() {
+			serve(atc.MainJobBadge)
+
+			Expect(handled).To(Equal(1))
+			Expect(recorder.Header().Get("Deprecation")).To(Equal("true"))
+			Expect(recorder.Header().Get("Sunset")).To(Equal("Wed, 02 Jan 2030 00:00:00 GMT"))
+
+			Expect(fakeTracker.TrackCallCount()).To(Equal(1))
+			deprecation, userAgent, team := fakeTracker.TrackArgsForCall(0)
+			Expect(deprecation).To(Equal(deprecations[0]))
+			Expect(userAgent).To(Equal("some-agent"))
+			Expect(team).To(BeEmpty())
+		})
+	})
+
+	Context("when a param of the route is deprecated", func() {
+		Context("and the request uses it", func() {
+			BeforeEach(func() {
+				request = httptest.NewRequest("GET", "/api/v1/teams/some-team/builds?legacy=true&:team_name=some-team", nil)
+			})
+
+			It("tags the response and tracks the client by team", func() {
+				serve(atc.ListTeamBuilds)
+
+				Expect(handled).To(Equal(1))
+				Expect(recorder.Header().Get("Deprecation")).To(Equal("true"))
+				Expect(recorder.Header().Get("Sunset")).To(BeEmpty())
+
+				Expect(fakeTracker.TrackCallCount()).To(Equal(1))
+				deprecation, _, team := fakeTracker.TrackArgsForCall(0)
+				Expect(deprecation).To(Equal(deprecations[1]))
+				Expect(team).To(Equal("some-team"))
+			})
+		})
+
+		Context("and the request does not use it", func() {
+			BeforeEach(func() {
+				request = httptest.NewRequest("GET", "/api/v1/teams/some-team/builds?:team_name=some-team", nil)
+			})
+
+			It("handles the request as usual", func() {
+				serve(atc.ListTeamBuilds)
+
+				Expect(handled).To(Equal(1))
+				Expect(recorder.Header().Get("Deprecation")).To(BeEmpty())
+				Expect(fakeTracker.TrackCallCount()).To(BeZero())
+			})
+		})
+	})
+})
@@ -0,0 +1,194 @@
+package wrappa
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/tedsuo/rata"
+)
+
+// maxIdempotencyKeyLength is long enough for a UUID, or a build's ID and a
+// step's name.
+const maxIdempotencyKeyLength = 255
+
+// IdempotencyWrappa makes the routes which trigger builds or set pipelines
+// replay the response to the first request made with an Idempotency-Key
+// header to the requests retrying it, rather than doing it again.
+type IdempotencyWrappa struct {
+	logger     lager.Logger
+	repository db.IdempotencyKeyRepository
+}
+
+func NewIdempotencyWrappa(logger lager.Logger, repository db.IdempotencyKeyRepository) Wrappa {
+	return IdempotencyWrappa{
+		logger:     logger,
+		repository: repository,
+	}
+}
+
+func (wrappa IdempotencyWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
+	wrapped := rata.Handlers{}
+
+	for name, handler := range handlers {
+		switch name {
+		case atc.CreateJobBuild, atc.RerunJobBuild, atc.SaveConfig:
+			wrapped[name] = idempotentHandler{
+				logger:     wrappa.logger.Session("idempotency", lager.Data{"route": name}),
+				repository: wrappa.repository,
+				route:      name,
+				handler:    handler,
+			}
+		default:
+			wrapped[name] = handler
+		}
+	}
+
+	return wrapped
+}
+
+type idempotentHandler struct {
+	logger     lager.Logger
+	repository db.IdempotencyKeyRepository
+	route      string
+	handler    http.Handler
+}
+
+func (handler idempotentHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	idempotencyKey := r.Header.Get(atc.IdempotencyKeyHeader)
+	if idempotencyKey == "" {
+		handler.handler.ServeHTTP(w, r)
+		return
+	}
+
+	if len(idempotencyKey) > maxIdempotencyKeyLength {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "idempotency key is longer than %d characters", maxIdempotencyKeyLength)
+		return
+	}
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "read failed: %s", err)
+		return
+	}
+
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	key := db.IdempotencyKey{
+		TeamName: rata.Param(r, "team_name"),
+		Route:    handler.route,
+		Key:      idempotencyKey,
+	}
+
+	logger := handler.logger.WithData(lager.Data{
+		"team": key.TeamName,
+		"key":  key.Key,
+	})
+
+	requestHash := hashRequest(r, body)
+
+	response, reserved, err := handler.repository.ReserveIdempotencyKey(key, requestHash)
+	if err != nil {
+		logger.Error("failed-to-reserve-idempotency-key", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !reserved {
+		switch {
+		case response.RequestHash != requestHash:
+			logger.Info("idempotency-key-reused")
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			fmt.Fprintf(w, "idempotency key '%s' was already used for a different request", key.Key)
+
+		case response.StatusCode == 0:
+			logger.Info("idempotent-request-in-progress")
+			w.WriteHeader(http.StatusConflict)
+			fmt.Fprintf(w, "a request with idempotency key '%s' is still in progress", key.Key)
+
+		default:
+			logger.Debug("replaying-response")
+
+			if response.ContentType != "" {
+				w.Header().Set("Content-Type", response.ContentType)
+			}
+
+			w.Header().Set(atc.IdempotentReplayedHeader, "true")
+			w.WriteHeader(response.StatusCode)
+			_, _ = w.Write(response.Body)
+		}
+
+		return
+	}
+
+	recorder := &responseRecorder{
+		ResponseWriter: w,
+		statusCode:     http.StatusOK,
+	}
+
+	completed := false
+	defer func() {
+		// let the request be retried unless it succeeded, including when the
+		// handler panics
+		if !completed {
+			err := handler.repository.ReleaseIdempotencyKey(key)
+			if err != nil {
+				logger.Error("failed-to-release-idempotency-key", err)
+			}
+		}
+	}()
+
+	handler.handler.ServeHTTP(recorder, r)
+
+	if recorder.statusCode < 200 || recorder.statusCode > 299 {
+		return
+	}
+
+	err = handler.repository.CompleteIdempotencyKey(key, db.IdempotentResponse{
+		StatusCode:  recorder.statusCode,
+		ContentType: recorder.Header().Get("Content-Type"),
+		Body:        recorder.body.Bytes(),
+	})
+	if err != nil {
+		logger.Error("failed-to-complete-idempotency-key", err)
+		return
+	}
+
+	completed = true
+}
+
+// hashRequest identifies a request by its method, URL and body, so that a key
+// can't be reused for a different request.
+func hashRequest(r *http.Request, body []byte) string {
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%s %s\n", r.Method, r.URL.RequestURI())
+	hash.Write(body)
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// responseRecorder writes the response through while keeping a copy of it.
+type responseRecorder struct {
+	http.ResponseWriter
+
+	statusCode int
+	body       bytes.Buffer
+}
+
+func (recorder *responseRecorder) WriteHeader(statusCode int) {
+	recorder.statusCode = statusCode
+	recorder.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (recorder *responseRecorder) Write(p []byte) (int, error) {
+	recorder.body.Write(p)
+	return recorder.ResponseWriter.Write(p)
+}
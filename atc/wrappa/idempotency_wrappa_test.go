@@ -0,0 +1,215 @@
+package wrappa_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/wrappa"
+	"github.com/tedsuo/rata"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("IdempotencyWrappa", func() {
+	var (
+		fakeRepository *dbfakes.FakeIdempotencyKeyRepository
+
+		handled     int
+		handledBody string
+		status      int
+		handlerFunc http.HandlerFunc
+
+		handler  http.Handler
+		request  *http.Request
+		recorder *httptest.ResponseRecorder
+	)
+
+	BeforeEach(func() {
+		fakeRepository = new(dbfakes.FakeIdempotencyKeyRepository)
+		fakeRepository.ReserveIdempotencyKeyStub = func(key db.IdempotencyKey, requestHash string) (db.IdempotentResponse, bool, error) {
+			return db.IdempotentResponse{RequestHash: requestHash}, true, nil
+		}
+
+		handled = 0
+		status = http.StatusOK
+		handlerFunc = func(w http.ResponseWriter, r *http.Request) {
+			handled++
+
+			body, err := ioutil.ReadAll(r.Body)
+			Expect(err).ToNot(HaveOccurred())
+			handledBody = string(body)
+
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(status)
+			w.Write([]byte(`{"id":1}`))
+		}
+
+		request = httptest.NewRequest("POST", "/api/v1/teams/some-team/pipelines/some-pipeline/jobs/some-job/builds?:team_name=some-team", strings.NewReader(`{"comment":"hi"}`))
+		recorder = httptest.NewRecorder()
+	})
+
+	JustBeforeEach(func() {
+		handler = wrappa.NewIdempotencyWrappa(lagertest.NewTestLogger("test"), fakeRepository).Wrap(rata.Handlers{
+			atc.CreateJobBuild: handlerFunc,
+		})[atc.CreateJobBuild]
+
+		handler.ServeHTTP(recorder, request)
+	})
+
+	It("leaves the other routes as they are", func() {
+		handlers := rata.Handlers{
+			atc.ListBuilds: handlerFunc,
+		}
+
+		wrapped := wrappa.NewIdempotencyWrappa(lagertest.NewTestLogger("test"), fakeRepository).Wrap(handlers)
+		Expect(wrapped[atc.ListBuilds]).To(BeAssignableToTypeOf(handlerFunc))
+	})
+
+	Context("without an idempotency key", func() {
+		It("handles the request as usual", func() {
+			Expect(handled).To(Equal(1))
+			Expect(handledBody).To(Equal(`{"comment":"hi"}`))
+			Expect(fakeRepository.ReserveIdempotencyKeyCallCount()).To(BeZero())
+		})
+	})
+
+	Context("with an idempotency key", func() {
+		BeforeEach(func() {
+			request.Header.Set(atc.IdempotencyKeyHeader, "some-key")
+		})
+
+		It("reserves the key for the team and route", func() {
+			Expect(fakeRepository.ReserveIdempotencyKeyCallCount()).To(Equal(1))
+
+			key, requestHash := fakeRepository.ReserveIdempotencyKeyArgsForCall(0)
+			Expect(key).To(Equal(db.IdempotencyKey{
+				TeamName: "some-team",
+				Route:    atc.CreateJobBuild,
+				Key:      "some-key",
+			}))
+			Expect(requestHash).ToNot(BeEmpty())
+		})
+
+		It("handles the request with its body and stores the response", func() {
+			Expect(handled).To(Equal(1))
+			Expect(handledBody).To(Equal(`{"comment":"hi"}`))
+
+			Expect(recorder.Code).To(Equal(http.StatusOK))
+			Expect(recorder.Body.String()).To(Equal(`{"id":1}`))
+
+			Expect(fakeRepository.CompleteIdempotencyKeyCallCount()).To(Equal(1))
+			key, response := fakeRepository.CompleteIdempotencyKeyArgsForCall(0)
+			Expect(key.Key).To(Equal("some-key"))
+			Expect(response).To(Equal(db.IdempotentResponse{
+				StatusCode:  http.StatusOK,
+				ContentType: "application/json",
+				Body:        []byte(`{"id":1}`),
+			}))
+
+			Expect(fakeRepository.ReleaseIdempotencyKeyCallCount()).To(BeZero())
+		})
+
+		Context("when the request fails", func() {
+			BeforeEach(func() {
+				status = http.StatusInternalServerError
+			})
+
+			It("releases the key so that it can be retried", func() {
+				Expect(recorder.Code).To(Equal(http.StatusInternalServerError))
+				Expect(fakeRepository.CompleteIdempotencyKeyCallCount()).To(BeZero())
+				Expect(fakeRepository.ReleaseIdempotencyKeyCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("when storing the response fails", func() {
+			BeforeEach(func() {
+				fakeRepository.CompleteIdempotencyKeyReturns(errors.New("disaster"))
+			})
+
+			It("still responds, and releases the key", func() {
+				Expect(recorder.Code).To(Equal(http.StatusOK))
+				Expect(fakeRepository.ReleaseIdempotencyKeyCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("when the key has been used for the same request", func() {
+			BeforeEach(func() {
+				fakeRepository.ReserveIdempotencyKeyStub = func(key db.IdempotencyKey, requestHash string) (db.IdempotentResponse, bool, error) {
+					return db.IdempotentResponse{
+						RequestHash: requestHash,
+						StatusCode:  http.StatusOK,
+						ContentType: "application/json",
+						Body:        []byte(`{"id":42}`),
+					}, false, nil
+				}
+			})
+
+			It("replays its response without handling the request", func() {
+				Expect(handled).To(BeZero())
+
+				Expect(recorder.Code).To(Equal(http.StatusOK))
+				Expect(recorder.Header().Get("Content-Type")).To(Equal("application/json"))
+				Expect(recorder.Header().Get(atc.IdempotentReplayedHeader)).To(Equal("true"))
+				Expect(recorder.Body.String()).To(Equal(`{"id":42}`))
+			})
+		})
+
+		Context("when the same request is still in progress", func() {
+			BeforeEach(func() {
+				fakeRepository.ReserveIdempotencyKeyStub = func(key db.IdempotencyKey, requestHash string) (db.IdempotentResponse, bool, error) {
+					return db.IdempotentResponse{RequestHash: requestHash}, false, nil
+				}
+			})
+
+			It("conflicts", func() {
+				Expect(handled).To(BeZero())
+				Expect(recorder.Code).To(Equal(http.StatusConflict))
+			})
+		})
+
+		Context("when the key has been used for a different request", func() {
+			BeforeEach(func() {
+				fakeRepository.ReserveIdempotencyKeyReturns(db.IdempotentResponse{
+					RequestHash: "other-hash",
+					StatusCode:  http.StatusOK,
+				}, false, nil)
+			})
+
+			It("rejects the request", func() {
+				Expect(handled).To(BeZero())
+				Expect(recorder.Code).To(Equal(http.StatusUnprocessableEntity))
+				Expect(recorder.Body.String()).To(ContainSubstring("already used for a different request"))
+			})
+		})
+
+		Context("when reserving the key fails", func() {
+			BeforeEach(func() {
+				fakeRepository.ReserveIdempotencyKeyReturns(db.IdempotentResponse{}, false, errors.New("disaster"))
+			})
+
+			It("returns 500 without handling the request", func() {
+				Expect(handled).To(BeZero())
+				Expect(recorder.Code).To(Equal(http.StatusInternalServerError))
+			})
+		})
+	})
+
+	Context("with an idempotency key which is too long", func() {
+		BeforeEach(func() {
+			request.Header.Set(atc.IdempotencyKeyHeader, strings.Repeat("k", 256))
+		})
+
+		It("rejects the request", func() {
+			Expect(handled).To(BeZero())
+			Expect(recorder.Code).To(Equal(http.StatusBadRequest))
+		})
+	})
+})
@@ -29,13 +29,19 @@ func (rw *RejectArchivedWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.CreateJobBuild,
 			atc.ScheduleJob,
 			atc.CheckResource,
+			atc.FetchResourceVersionMetadata,
+			atc.BackfillResourceMetadata,
 			atc.CheckResourceType,
 			atc.CheckPrototype,
 			atc.DisableResourceVersion,
 			atc.EnableResourceVersion,
 			atc.PinResourceVersion,
+			atc.PushResourceVersions,
 			atc.UnpinResource,
 			atc.SetPinCommentOnResource,
+			atc.ResetResourceCheckFrom,
+			atc.StageConfig,
+			atc.PromoteCandidateConfig,
 			atc.RerunJobBuild:
 
 			newHandler = rw.handlerFactory.RejectArchived(handler)
@@ -43,13 +49,19 @@ func (rw *RejectArchivedWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			// leave the handler as-is
 		case
 			atc.GetConfig,
+			atc.ValidateConfig,
+			atc.ReportConfigVars,
+			atc.DiscardCandidateConfig,
 			atc.GetBuild,
 			atc.BuildResources,
 			atc.BuildEvents,
 			atc.ListBuildArtifacts,
 			atc.GetBuildPreparation,
 			atc.GetBuildPlan,
+			atc.GetBuildSteps,
+			atc.GetBuildProvenance,
 			atc.AbortBuild,
+			atc.AbortBuildStep,
 			atc.SetBuildComment,
 			atc.PruneWorker,
 			atc.LandWorker,
@@ -61,9 +73,13 @@ func (rw *RejectArchivedWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.GetPipeline,
 			atc.GetJobBuild,
 			atc.PipelineBadge,
+			atc.GetPipelineGraph,
 			atc.JobBadge,
 			atc.ListJobs,
 			atc.GetJob,
+			atc.ListJobStatistics,
+			atc.GetJobStatistics,
+			atc.ListJobFlakiness,
 			atc.ListJobBuilds,
 			atc.ListPipelineBuilds,
 			atc.GetResource,
@@ -74,23 +90,98 @@ func (rw *RejectArchivedWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.ListResourceVersions,
 			atc.GetDownstreamResourceCausality,
 			atc.GetUpstreamResourceCausality,
+			atc.GetResourceVersionImpact,
 			atc.GetResourceVersion,
 			atc.CreateBuild,
+			atc.RunTask,
 			atc.GetContainer,
 			atc.HijackContainer,
+			atc.ListContainerProcesses,
+			atc.ListBuildContainers,
 			atc.ListContainers,
 			atc.ListVolumes,
+			atc.ListBuildVolumes,
 			atc.ListTeamBuilds,
 			atc.ListWorkers,
 			atc.RegisterWorker,
 			atc.HeartbeatWorker,
 			atc.DeleteWorker,
+			atc.ListWorkerKeys,
 			atc.GetTeam,
 			atc.SetTeam,
 			atc.RenameTeam,
+			atc.ListTeamUsers,
+			atc.GetTeamWorkerKeys,
+			atc.SetTeamWorkerKeys,
+			atc.GetTeamInterceptSettings,
+			atc.SetTeamInterceptSettings,
+			atc.GetTeamPrivilegedSettings,
+			atc.SetTeamPrivilegedSettings,
+			atc.GetTeamProxySettings,
+			atc.SetTeamProxySettings,
 			atc.DestroyTeam,
+			atc.ListNotifiers,
+			atc.SetNotifier,
+			atc.DestroyNotifier,
+			atc.ListTeamWebhooks,
+			atc.SetTeamWebhook,
+			atc.DestroyTeamWebhook,
+			atc.ListTeamWebhookDeliveries,
+			atc.ListClusterWebhooks,
+			atc.SetClusterWebhook,
+			atc.DestroyClusterWebhook,
+			atc.ListClusterWebhookDeliveries,
+			atc.ListTeamLogDrains,
+			atc.SetTeamLogDrain,
+			atc.DestroyTeamLogDrain,
+			atc.ListTeamFreezeWindows,
+			atc.CreateTeamFreezeWindow,
+			atc.DestroyTeamFreezeWindow,
+			atc.ListClusterFreezeWindows,
+			atc.CreateClusterFreezeWindow,
+			atc.DestroyClusterFreezeWindow,
+			atc.GetTeamInjectedEnv,
+			atc.SetTeamInjectedEnv,
+			atc.GetClusterInjectedEnv,
+			atc.SetClusterInjectedEnv,
+			atc.GetTeamCABundle,
+			atc.SetTeamCABundle,
+			atc.GetClusterCABundle,
+			atc.SetClusterCABundle,
+			atc.ListTeamRequests,
+			atc.CreateTeamRequest,
+			atc.ApproveTeamRequest,
+			atc.RejectTeamRequest,
+			atc.GetSchedulerProfile,
+			atc.GetDBSchema,
+			atc.GetDBMaintenance,
+			atc.GetDBGrowth,
+			atc.GetAutoscalingSignal,
+			atc.GetDeprecationReport,
+			atc.GetClusterOverview,
+			atc.ListComponents,
+			atc.SetComponentInterval,
+			atc.ResetComponentInterval,
+			atc.PauseComponent,
+			atc.UnpauseComponent,
+			atc.ListFlakyJobs,
+			atc.ListTeamBuildQueue,
 			atc.GetUser,
+			atc.GetDashboardPreferences,
+			atc.OrderDashboardPipelines,
+			atc.FavoritePipeline,
+			atc.UnfavoritePipeline,
+			atc.GetUserPreferences,
+			atc.SetUserPreference,
+			atc.DeleteUserPreference,
+			atc.ListSessions,
+			atc.RevokeSession,
+			atc.ListUserSessions,
+			atc.RevokeUserSessions,
 			atc.GetInfo,
+			atc.GetOpenAPI,
+			atc.GetHealth,
+			atc.GetConfigSchema,
 			atc.DownloadCLI,
 			atc.CheckResourceWebHook,
 			atc.ListAllPipelines,
@@ -103,6 +194,8 @@ func (rw *RejectArchivedWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.GetWall,
 			atc.GetLogLevel,
 			atc.SetLogLevel,
+			atc.GetRequestLog,
+			atc.SetRequestLog,
 			atc.GetInfoCreds,
 			atc.ListActiveUsersSince,
 			atc.SetWall,
@@ -113,8 +206,14 @@ func (rw *RejectArchivedWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.ListJobInputs,
 			atc.OrderPipelines,
 			atc.OrderPipelinesWithinGroup,
+			atc.PausePipelines,
+			atc.UnpausePipelines,
+			atc.ExposePipelines,
+			atc.HidePipelines,
+			atc.CheckPipelines,
 			atc.ArchivePipeline,
 			atc.RenamePipeline,
+			atc.MovePipeline,
 			atc.SaveConfig,
 			atc.PauseJob,
 			atc.UnpauseJob,
@@ -36,13 +36,19 @@ var _ = Describe("RejectArchivedWrappa", func() {
 			atc.CreateJobBuild,
 			atc.ScheduleJob,
 			atc.CheckResource,
+			atc.FetchResourceVersionMetadata,
+			atc.BackfillResourceMetadata,
 			atc.CheckResourceType,
 			atc.CheckPrototype,
 			atc.DisableResourceVersion,
 			atc.EnableResourceVersion,
 			atc.PinResourceVersion,
+			atc.PushResourceVersions,
 			atc.UnpinResource,
 			atc.SetPinCommentOnResource,
+			atc.ResetResourceCheckFrom,
+			atc.StageConfig,
+			atc.PromoteCandidateConfig,
 			atc.RerunJobBuild,
 		}
 
@@ -0,0 +1,70 @@
+package wrappa
+
+import (
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/requestlog"
+	"github.com/felixge/httpsnoop"
+	"github.com/tedsuo/rata"
+)
+
+// RequestLogWrappa records the requests to every route but those which stream
+// for as long as the client is connected, whose latency says little.
+type RequestLogWrappa struct {
+	recorder *requestlog.Recorder
+}
+
+func NewRequestLogWrappa(recorder *requestlog.Recorder) Wrappa {
+	return RequestLogWrappa{
+		recorder: recorder,
+	}
+}
+
+func (wrappa RequestLogWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
+	wrapped := rata.Handlers{}
+
+	for name, handler := range handlers {
+		switch name {
+		case atc.BuildEvents, atc.DownloadCLI, atc.HijackContainer:
+			wrapped[name] = handler
+		default:
+			wrapped[name] = requestLogHandler{
+				recorder: wrappa.recorder,
+				route:    name,
+				handler:  handler,
+			}
+		}
+	}
+
+	return wrapped
+}
+
+type requestLogHandler struct {
+	recorder *requestlog.Recorder
+	route    string
+	handler  http.Handler
+}
+
+func (handler requestLogHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	data := lager.Data{
+		"route":  handler.route,
+		"method": r.Method,
+		"path":   r.URL.Path,
+	}
+
+	if teamName := rata.Param(r, "team_name"); teamName != "" {
+		data["team"] = teamName
+	}
+
+	request := handler.recorder.Start(data)
+	if request == nil {
+		handler.handler.ServeHTTP(w, r)
+		return
+	}
+
+	metrics := httpsnoop.CaptureMetrics(handler.handler, w, r)
+
+	request.Finish(metrics.Code)
+}
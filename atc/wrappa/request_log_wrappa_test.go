@@ -0,0 +1,61 @@
+package wrappa_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/requestlog"
+	"github.com/concourse/concourse/atc/wrappa"
+	"github.com/tedsuo/rata"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RequestLogWrappa", func() {
+	var (
+		logger   *lagertest.TestLogger
+		recorder *requestlog.Recorder
+		wrapped  rata.Handlers
+	)
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("test")
+
+		var err error
+		recorder, err = requestlog.NewRecorder(logger, atc.RequestLogSettings{SampleRate: 1})
+		Expect(err).ToNot(HaveOccurred())
+
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusTeapot)
+		})
+
+		wrapped = wrappa.NewRequestLogWrappa(recorder).Wrap(rata.Handlers{
+			atc.GetPipeline: handler,
+			atc.BuildEvents: handler,
+		})
+	})
+
+	It("logs the requests with their route, team and status", func() {
+		request := httptest.NewRequest("GET", "/api/v1/teams/some-team/pipelines/some-pipeline?:team_name=some-team", nil)
+		wrapped[atc.GetPipeline].ServeHTTP(httptest.NewRecorder(), request)
+
+		Expect(logger.LogMessages()).To(Equal([]string{"test.request"}))
+
+		data := logger.Logs()[0].Data
+		Expect(data["route"]).To(Equal(atc.GetPipeline))
+		Expect(data["method"]).To(Equal("GET"))
+		Expect(data["path"]).To(Equal("/api/v1/teams/some-team/pipelines/some-pipeline"))
+		Expect(data["team"]).To(Equal("some-team"))
+		Expect(data["status"]).To(BeNumerically("==", http.StatusTeapot))
+	})
+
+	It("leaves out the routes which stream", func() {
+		request := httptest.NewRequest("GET", "/api/v1/builds/1/events", nil)
+		wrapped[atc.BuildEvents].ServeHTTP(httptest.NewRecorder(), request)
+
+		Expect(logger.LogMessages()).To(BeEmpty())
+	})
+})
@@ -38,7 +38,11 @@ func ShowWarnings(warnings []concourse.ConfigWarning) {
 	warningTypes := make(map[string]bool)
 	for _, warning := range warnings {
 		warningTypes[warning.Type] = true
-		fmt.Fprintf(ui.Stderr, "  - %s\n", warning.Message)
+		if warning.Pointer != "" {
+			fmt.Fprintf(ui.Stderr, "  - %s (at %s)\n", warning.Message, warning.Pointer)
+		} else {
+			fmt.Fprintf(ui.Stderr, "  - %s\n", warning.Message)
+		}
 	}
 
 	fmt.Fprintln(ui.Stderr, "")
@@ -63,6 +63,20 @@ func (atcConfig ATCConfig) Set(yamlTemplateWithParams templatehelpers.YamlTempla
 		return err
 	}
 
+	variables, err := yamlTemplateWithParams.Variables()
+	if err != nil {
+		return err
+	}
+
+	missing, err := newConfig.Vars.Missing(variables)
+	if err != nil {
+		return err
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("missing required vars: %s", strings.Join(missing, ", "))
+	}
+
 	configWarnings, _ := configvalidate.Validate(newConfig)
 	for _, w := range configWarnings {
 		atcConfig.CommandWarnings = append(atcConfig.CommandWarnings, concourse.ConfigWarning{
@@ -55,6 +55,30 @@ func (yamlTemplate YamlTemplateWithParams) Evaluate(
 		}
 	}
 
+	params, err := yamlTemplate.variables()
+	if err != nil {
+		return nil, err
+	}
+
+	evaluatedConfig, err := vars.NewTemplateResolver(config, params).Resolve(false, allowEmpty)
+	if err != nil {
+		return nil, err
+	}
+
+	return evaluatedConfig, nil
+}
+
+// Variables returns the vars which are used when evaluating the template.
+func (yamlTemplate YamlTemplateWithParams) Variables() (vars.Variables, error) {
+	params, err := yamlTemplate.variables()
+	if err != nil {
+		return nil, err
+	}
+
+	return vars.NewMultiVars(params), nil
+}
+
+func (yamlTemplate YamlTemplateWithParams) variables() ([]vars.Variables, error) {
 	var params []vars.Variables
 
 	// first, we take explicitly specified variables on the command line
@@ -89,10 +113,5 @@ func (yamlTemplate YamlTemplateWithParams) Evaluate(
 		params = append(params, staticVars)
 	}
 
-	evaluatedConfig, err := vars.NewTemplateResolver(config, params).Resolve(false, allowEmpty)
-	if err != nil {
-		return nil, err
-	}
-
-	return evaluatedConfig, nil
+	return params, nil
 }
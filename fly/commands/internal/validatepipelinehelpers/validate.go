@@ -41,6 +41,7 @@ func Validate(yamlTemplate templatehelpers.YamlTemplateWithParams, strict bool,
 	if len(warnings) > 0 {
 		configWarnings := make([]concourse.ConfigWarning, len(warnings))
 		for idx, warning := range warnings {
+			warning.Pointer = configvalidate.Pointer(unmarshalledTemplate, warning.Message)
 			configWarnings[idx] = concourse.ConfigWarning(warning)
 		}
 		displayhelpers.ShowWarnings(configWarnings)
@@ -95,7 +95,7 @@ var _ = Describe("Fly CLI", func() {
 			Expect(err).NotTo(HaveOccurred())
 
 			Eventually(sess.Err).Should(gbytes.Say("DEPRECATION WARNING:"))
-			Eventually(sess.Err).Should(gbytes.Say("  - jobs.some-job.plan"))
+			Eventually(sess.Err).Should(gbytes.Say("  - jobs.some-job.plan.*\\(at /jobs/0/plan/0\\)"))
 
 			<-sess.Exited
 			Expect(sess.ExitCode()).To(Equal(1))
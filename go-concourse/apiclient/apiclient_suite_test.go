@@ -0,0 +1,32 @@
+package apiclient_test
+
+import (
+	"net/http"
+
+	"github.com/concourse/concourse/go-concourse/apiclient"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"testing"
+)
+
+func TestAPIClient(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "API Client Suite")
+}
+
+var (
+	atcServer *ghttp.Server
+	client    *apiclient.Client
+)
+
+var _ = BeforeEach(func() {
+	atcServer = ghttp.NewServer()
+
+	client = apiclient.New(atcServer.URL(), &http.Client{})
+})
+
+var _ = AfterEach(func() {
+	atcServer.Close()
+})
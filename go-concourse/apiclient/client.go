@@ -0,0 +1,204 @@
+// Package apiclient is a client of the ATC API with a method for each of its
+// routes, generated from the same route definitions and types the ATC serves
+// its OpenAPI document from.
+//
+// Unlike the hand-written client in the concourse package, it covers every
+// route, and leaves what to do with errors and pagination to the caller: the
+// pages of paginated routes are asked for with the Query option.
+package apiclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/tedsuo/rata"
+)
+
+//go:generate go run ../../atc/openapi/clientgen -o operations.go
+
+// Client calls the ATC at a URL. Requests are authorized by the HTTP client,
+// e.g. with a transport adding the token fly logs in with.
+type Client struct {
+	httpClient *http.Client
+	requests   *rata.RequestGenerator
+}
+
+func New(apiURL string, httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	return &Client{
+		httpClient: httpClient,
+		requests:   rata.NewRequestGenerator(strings.TrimRight(apiURL, "/"), atc.Routes),
+	}
+}
+
+// Error is returned when the ATC responds with a status other than 2xx.
+type Error struct {
+	StatusCode int
+	Body       []byte
+}
+
+func (err *Error) Error() string {
+	if len(err.Body) == 0 {
+		return fmt.Sprintf("%d %s", err.StatusCode, http.StatusText(err.StatusCode))
+	}
+
+	return fmt.Sprintf("%d %s: %s", err.StatusCode, http.StatusText(err.StatusCode), strings.TrimSpace(string(err.Body)))
+}
+
+// RequestOption adds to a request what its method doesn't take as arguments.
+type RequestOption func(*http.Request)
+
+// Query adds a query parameter to the request.
+func Query(key, value string) RequestOption {
+	return func(r *http.Request) {
+		query := r.URL.Query()
+		query.Add(key, value)
+		r.URL.RawQuery = query.Encode()
+	}
+}
+
+// Header sets a header of the request.
+func Header(key, value string) RequestOption {
+	return func(r *http.Request) {
+		r.Header.Set(key, value)
+	}
+}
+
+// InstanceVars identifies the instance of the pipeline the request is for.
+func InstanceVars(instanceVars atc.InstanceVars) RequestOption {
+	return func(r *http.Request) {
+		query := r.URL.Query()
+		for key, values := range (atc.PipelineRef{InstanceVars: instanceVars}).QueryParams() {
+			query[key] = values
+		}
+
+		r.URL.RawQuery = query.Encode()
+	}
+}
+
+type body struct {
+	reader      io.Reader
+	contentType string
+	err         error
+}
+
+func jsonBody(value interface{}) *body {
+	payload, err := json.Marshal(value)
+	return &body{
+		reader:      bytes.NewReader(payload),
+		contentType: "application/json",
+		err:         err,
+	}
+}
+
+func textBody(text string) *body {
+	return &body{
+		reader:      strings.NewReader(text),
+		contentType: "text/plain",
+	}
+}
+
+func rawBody(reader io.Reader, contentType string) *body {
+	return &body{
+		reader:      reader,
+		contentType: contentType,
+	}
+}
+
+// send makes the request and returns the response if it succeeded, which the
+// caller must close.
+func (c *Client) send(ctx context.Context, name string, params rata.Params, reqBody *body, opts []RequestOption) (*http.Response, error) {
+	var reader io.Reader
+	if reqBody != nil {
+		if reqBody.err != nil {
+			return nil, reqBody.err
+		}
+
+		reader = reqBody.reader
+	}
+
+	req, err := c.requests.CreateRequest(name, params, reader)
+	if err != nil {
+		return nil, err
+	}
+
+	req = req.WithContext(ctx)
+
+	if reqBody != nil {
+		req.Header.Set("Content-Type", reqBody.contentType)
+	}
+
+	for _, opt := range opts {
+		opt(req)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		defer resp.Body.Close()
+
+		payload, _ := ioutil.ReadAll(resp.Body)
+		return nil, &Error{
+			StatusCode: resp.StatusCode,
+			Body:       payload,
+		}
+	}
+
+	return resp, nil
+}
+
+// sendJSON decodes the response into result, or discards it if result is
+// nil.
+func (c *Client) sendJSON(ctx context.Context, name string, params rata.Params, reqBody *body, result interface{}, opts []RequestOption) error {
+	resp, err := c.send(ctx, name, params, reqBody, opts)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if result == nil || resp.StatusCode == http.StatusNoContent {
+		_, _ = io.Copy(ioutil.Discard, resp.Body)
+		return nil
+	}
+
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
+func (c *Client) sendText(ctx context.Context, name string, params rata.Params, reqBody *body, opts []RequestOption) (string, error) {
+	resp, err := c.send(ctx, name, params, reqBody, opts)
+	if err != nil {
+		return "", err
+	}
+
+	defer resp.Body.Close()
+
+	payload, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+
+	return string(payload), nil
+}
+
+func (c *Client) sendStream(ctx context.Context, name string, params rata.Params, reqBody *body, opts []RequestOption) (io.ReadCloser, error) {
+	resp, err := c.send(ctx, name, params, reqBody, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Body, nil
+}
@@ -0,0 +1,129 @@
+package apiclient_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/apiclient"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Client", func() {
+	var ctx context.Context
+
+	BeforeEach(func() {
+		ctx = context.Background()
+	})
+
+	Context("when the route returns JSON", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/info"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, atc.Info{
+						Version: "12.3.4",
+					}),
+				),
+			)
+		})
+
+		It("decodes it", func() {
+			info, err := client.GetInfo(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(info.Version).To(Equal("12.3.4"))
+		})
+	})
+
+	Context("when the route takes parameters and a body", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/api/v1/teams/some-team/pipelines/some-pipeline/rename", "vars.branch=%22feature%22"),
+					ghttp.VerifyContentType("application/json"),
+					ghttp.VerifyJSONRepresenting(atc.RenameRequest{NewName: "other-pipeline"}),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, atc.SaveConfigResponse{}),
+				),
+			)
+		})
+
+		It("sends them", func() {
+			_, err := client.RenamePipeline(ctx, "some-team", "some-pipeline",
+				atc.RenameRequest{NewName: "other-pipeline"},
+				apiclient.InstanceVars(atc.InstanceVars{"branch": "feature"}),
+			)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(atcServer.ReceivedRequests()).To(HaveLen(1))
+		})
+	})
+
+	Context("when the route exchanges text", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/api/v1/log-level"),
+					ghttp.VerifyBody([]byte("debug")),
+					ghttp.RespondWith(http.StatusOK, nil),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/log-level"),
+					ghttp.RespondWith(http.StatusOK, "debug"),
+				),
+			)
+		})
+
+		It("sends and returns it as is", func() {
+			err := client.SetLogLevel(ctx, atc.LogLevelDebug)
+			Expect(err).NotTo(HaveOccurred())
+
+			level, err := client.GetLogLevel(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(level).To(Equal(atc.LogLevelDebug))
+		})
+	})
+
+	Context("when the route returns something else", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/teams/some-team/cc.xml"),
+					ghttp.RespondWith(http.StatusOK, "<Projects/>"),
+				),
+			)
+		})
+
+		It("returns the body to read", func() {
+			body, err := client.GetCC(ctx, "some-team")
+			Expect(err).NotTo(HaveOccurred())
+
+			defer body.Close()
+
+			payload, err := ioutil.ReadAll(body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(payload)).To(Equal("<Projects/>"))
+		})
+	})
+
+	Context("when the ATC responds with an error", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/teams/some-team"),
+					ghttp.RespondWith(http.StatusForbidden, "not allowed\n"),
+				),
+			)
+		})
+
+		It("returns the status and body", func() {
+			_, err := client.GetTeam(ctx, "some-team")
+			Expect(err).To(Equal(&apiclient.Error{
+				StatusCode: http.StatusForbidden,
+				Body:       []byte("not allowed\n"),
+			}))
+			Expect(err.Error()).To(Equal("403 Forbidden: not allowed"))
+		})
+	})
+})
@@ -0,0 +1,1655 @@
+// Code generated by clientgen. DO NOT EDIT.
+
+package apiclient
+
+import (
+	"context"
+	"encoding/json/jsontext"
+	"io"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/tedsuo/rata"
+)
+
+// SaveConfig calls PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/config.
+//
+// Set the config of a pipeline, creating the pipeline if it doesn't exist.
+func (c *Client) SaveConfig(ctx context.Context, teamName string, pipelineName string, body atc.Config, opts ...RequestOption) (atc.SaveConfigResponse, error) {
+	var result atc.SaveConfigResponse
+	err := c.sendJSON(ctx, atc.SaveConfig, rata.Params{"team_name": teamName, "pipeline_name": pipelineName}, jsonBody(body), &result, opts)
+	return result, err
+}
+
+// GetConfig calls GET /api/v1/teams/:team_name/pipelines/:pipeline_name/config.
+//
+// Get the config of a pipeline.
+func (c *Client) GetConfig(ctx context.Context, teamName string, pipelineName string, opts ...RequestOption) (atc.ConfigResponse, error) {
+	var result atc.ConfigResponse
+	err := c.sendJSON(ctx, atc.GetConfig, rata.Params{"team_name": teamName, "pipeline_name": pipelineName}, nil, &result, opts)
+	return result, err
+}
+
+// ValidateConfig calls POST /api/v1/teams/:team_name/pipelines/:pipeline_name/config/validate.
+//
+// Validate a pipeline config without saving it.
+func (c *Client) ValidateConfig(ctx context.Context, teamName string, pipelineName string, body atc.Config, opts ...RequestOption) (atc.ValidateConfigResponse, error) {
+	var result atc.ValidateConfigResponse
+	err := c.sendJSON(ctx, atc.ValidateConfig, rata.Params{"team_name": teamName, "pipeline_name": pipelineName}, jsonBody(body), &result, opts)
+	return result, err
+}
+
+// ReportConfigVars calls POST /api/v1/teams/:team_name/pipelines/:pipeline_name/config/vars.
+//
+// Report where each var of a pipeline config would be resolved from.
+func (c *Client) ReportConfigVars(ctx context.Context, teamName string, pipelineName string, body atc.Config, opts ...RequestOption) ([]atc.VarReport, error) {
+	var result []atc.VarReport
+	err := c.sendJSON(ctx, atc.ReportConfigVars, rata.Params{"team_name": teamName, "pipeline_name": pipelineName}, jsonBody(body), &result, opts)
+	return result, err
+}
+
+// StageConfig calls PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/config/candidate.
+//
+// Stage a candidate config for a pipeline.
+func (c *Client) StageConfig(ctx context.Context, teamName string, pipelineName string, body atc.Config, opts ...RequestOption) (atc.SaveConfigResponse, error) {
+	var result atc.SaveConfigResponse
+	err := c.sendJSON(ctx, atc.StageConfig, rata.Params{"team_name": teamName, "pipeline_name": pipelineName}, jsonBody(body), &result, opts)
+	return result, err
+}
+
+// PromoteCandidateConfig calls POST /api/v1/teams/:team_name/pipelines/:pipeline_name/config/candidate/promote.
+//
+// Promote the candidate config of a pipeline to its config.
+func (c *Client) PromoteCandidateConfig(ctx context.Context, teamName string, pipelineName string, opts ...RequestOption) error {
+	return c.sendJSON(ctx, atc.PromoteCandidateConfig, rata.Params{"team_name": teamName, "pipeline_name": pipelineName}, nil, nil, opts)
+}
+
+// DiscardCandidateConfig calls DELETE /api/v1/teams/:team_name/pipelines/:pipeline_name/config/candidate.
+//
+// Discard the candidate config of a pipeline.
+func (c *Client) DiscardCandidateConfig(ctx context.Context, teamName string, pipelineName string, opts ...RequestOption) error {
+	return c.sendJSON(ctx, atc.DiscardCandidateConfig, rata.Params{"team_name": teamName, "pipeline_name": pipelineName}, nil, nil, opts)
+}
+
+// GetConfigSchema calls GET /api/v1/schemas/:schema_name.
+//
+// Get the JSON schema of a config.
+func (c *Client) GetConfigSchema(ctx context.Context, schemaName string, opts ...RequestOption) (io.ReadCloser, error) {
+	return c.sendStream(ctx, atc.GetConfigSchema, rata.Params{"schema_name": schemaName}, nil, opts)
+}
+
+// CreateBuild calls POST /api/v1/teams/:team_name/builds.
+//
+// Run a one-off build of a plan.
+func (c *Client) CreateBuild(ctx context.Context, teamName string, body atc.Plan, opts ...RequestOption) (atc.Build, error) {
+	var result atc.Build
+	err := c.sendJSON(ctx, atc.CreateBuild, rata.Params{"team_name": teamName}, jsonBody(body), &result, opts)
+	return result, err
+}
+
+// RunTask calls POST /api/v1/teams/:team_name/tasks.
+//
+// Run a task as a one-off build.
+func (c *Client) RunTask(ctx context.Context, teamName string, body atc.RunTaskRequest, opts ...RequestOption) (atc.Build, error) {
+	var result atc.Build
+	err := c.sendJSON(ctx, atc.RunTask, rata.Params{"team_name": teamName}, jsonBody(body), &result, opts)
+	return result, err
+}
+
+// ListBuilds calls GET /api/v1/builds.
+//
+// List builds.
+func (c *Client) ListBuilds(ctx context.Context, opts ...RequestOption) ([]atc.Build, error) {
+	var result []atc.Build
+	err := c.sendJSON(ctx, atc.ListBuilds, rata.Params{}, nil, &result, opts)
+	return result, err
+}
+
+// GetBuild calls GET /api/v1/builds/:build_id.
+//
+// Get a build.
+func (c *Client) GetBuild(ctx context.Context, buildID string, opts ...RequestOption) (atc.Build, error) {
+	var result atc.Build
+	err := c.sendJSON(ctx, atc.GetBuild, rata.Params{"build_id": buildID}, nil, &result, opts)
+	return result, err
+}
+
+// GetBuildPlan calls GET /api/v1/builds/:build_id/plan.
+//
+// Get the plan of a build.
+func (c *Client) GetBuildPlan(ctx context.Context, buildID string, opts ...RequestOption) (atc.PublicBuildPlan, error) {
+	var result atc.PublicBuildPlan
+	err := c.sendJSON(ctx, atc.GetBuildPlan, rata.Params{"build_id": buildID}, nil, &result, opts)
+	return result, err
+}
+
+// GetBuildSteps calls GET /api/v1/builds/:build_id/steps.
+//
+// Get the status of each step of a build.
+func (c *Client) GetBuildSteps(ctx context.Context, buildID string, opts ...RequestOption) (atc.BuildSteps, error) {
+	var result atc.BuildSteps
+	err := c.sendJSON(ctx, atc.GetBuildSteps, rata.Params{"build_id": buildID}, nil, &result, opts)
+	return result, err
+}
+
+// GetBuildProvenance calls GET /api/v1/builds/:build_id/provenance.
+//
+// Get the provenance attestation of a build.
+func (c *Client) GetBuildProvenance(ctx context.Context, buildID string, opts ...RequestOption) error {
+	return c.sendJSON(ctx, atc.GetBuildProvenance, rata.Params{"build_id": buildID}, nil, nil, opts)
+}
+
+// BuildEvents calls GET /api/v1/builds/:build_id/events.
+//
+// Stream the events of a build, typed by the given version of the event schema.
+func (c *Client) BuildEvents(ctx context.Context, buildID string, opts ...RequestOption) (io.ReadCloser, error) {
+	return c.sendStream(ctx, atc.BuildEvents, rata.Params{"build_id": buildID}, nil, opts)
+}
+
+// BuildResources calls GET /api/v1/builds/:build_id/resources.
+//
+// List the inputs and outputs of a build.
+func (c *Client) BuildResources(ctx context.Context, buildID string, opts ...RequestOption) (atc.BuildInputsOutputs, error) {
+	var result atc.BuildInputsOutputs
+	err := c.sendJSON(ctx, atc.BuildResources, rata.Params{"build_id": buildID}, nil, &result, opts)
+	return result, err
+}
+
+// AbortBuild calls PUT /api/v1/builds/:build_id/abort.
+//
+// Abort a build.
+func (c *Client) AbortBuild(ctx context.Context, buildID string, opts ...RequestOption) error {
+	return c.sendJSON(ctx, atc.AbortBuild, rata.Params{"build_id": buildID}, nil, nil, opts)
+}
+
+// AbortBuildStep calls PUT /api/v1/builds/:build_id/steps/:plan_id/abort.
+//
+// Abort a step of a build.
+func (c *Client) AbortBuildStep(ctx context.Context, buildID string, planID string, opts ...RequestOption) error {
+	return c.sendJSON(ctx, atc.AbortBuildStep, rata.Params{"build_id": buildID, "plan_id": planID}, nil, nil, opts)
+}
+
+// GetBuildPreparation calls GET /api/v1/builds/:build_id/preparation.
+//
+// Get what a pending build is waiting for.
+func (c *Client) GetBuildPreparation(ctx context.Context, buildID string, opts ...RequestOption) (atc.BuildPreparation, error) {
+	var result atc.BuildPreparation
+	err := c.sendJSON(ctx, atc.GetBuildPreparation, rata.Params{"build_id": buildID}, nil, &result, opts)
+	return result, err
+}
+
+// ListBuildArtifacts calls GET /api/v1/builds/:build_id/artifacts.
+//
+// List the artifacts of a build.
+func (c *Client) ListBuildArtifacts(ctx context.Context, buildID string, opts ...RequestOption) ([]atc.WorkerArtifact, error) {
+	var result []atc.WorkerArtifact
+	err := c.sendJSON(ctx, atc.ListBuildArtifacts, rata.Params{"build_id": buildID}, nil, &result, opts)
+	return result, err
+}
+
+// ListBuildContainers calls GET /api/v1/builds/:build_id/containers.
+//
+// List the containers of a build.
+func (c *Client) ListBuildContainers(ctx context.Context, buildID string, opts ...RequestOption) ([]atc.Container, error) {
+	var result []atc.Container
+	err := c.sendJSON(ctx, atc.ListBuildContainers, rata.Params{"build_id": buildID}, nil, &result, opts)
+	return result, err
+}
+
+// ListBuildVolumes calls GET /api/v1/builds/:build_id/volumes.
+//
+// List the volumes of a build.
+func (c *Client) ListBuildVolumes(ctx context.Context, buildID string, opts ...RequestOption) ([]atc.Volume, error) {
+	var result []atc.Volume
+	err := c.sendJSON(ctx, atc.ListBuildVolumes, rata.Params{"build_id": buildID}, nil, &result, opts)
+	return result, err
+}
+
+// SetBuildComment calls PUT /api/v1/builds/:build_id/comment.
+//
+// Set the comment of a build.
+func (c *Client) SetBuildComment(ctx context.Context, buildID string, body atc.SetBuildCommentBody, opts ...RequestOption) error {
+	return c.sendJSON(ctx, atc.SetBuildComment, rata.Params{"build_id": buildID}, jsonBody(body), nil, opts)
+}
+
+// ListAllJobs calls GET /api/v1/jobs.
+//
+// List the jobs of every visible pipeline.
+func (c *Client) ListAllJobs(ctx context.Context, opts ...RequestOption) ([]atc.JobSummary, error) {
+	var result []atc.JobSummary
+	err := c.sendJSON(ctx, atc.ListAllJobs, rata.Params{}, nil, &result, opts)
+	return result, err
+}
+
+// ListJobs calls GET /api/v1/teams/:team_name/pipelines/:pipeline_name/jobs.
+//
+// List the jobs of a pipeline.
+func (c *Client) ListJobs(ctx context.Context, teamName string, pipelineName string, opts ...RequestOption) ([]atc.JobSummary, error) {
+	var result []atc.JobSummary
+	err := c.sendJSON(ctx, atc.ListJobs, rata.Params{"team_name": teamName, "pipeline_name": pipelineName}, nil, &result, opts)
+	return result, err
+}
+
+// GetJob calls GET /api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name.
+//
+// Get a job.
+func (c *Client) GetJob(ctx context.Context, teamName string, pipelineName string, jobName string, opts ...RequestOption) (atc.Job, error) {
+	var result atc.Job
+	err := c.sendJSON(ctx, atc.GetJob, rata.Params{"team_name": teamName, "pipeline_name": pipelineName, "job_name": jobName}, nil, &result, opts)
+	return result, err
+}
+
+// ListJobBuilds calls GET /api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/builds.
+//
+// List the builds of a job.
+func (c *Client) ListJobBuilds(ctx context.Context, teamName string, pipelineName string, jobName string, opts ...RequestOption) ([]atc.Build, error) {
+	var result []atc.Build
+	err := c.sendJSON(ctx, atc.ListJobBuilds, rata.Params{"team_name": teamName, "pipeline_name": pipelineName, "job_name": jobName}, nil, &result, opts)
+	return result, err
+}
+
+// CreateJobBuild calls POST /api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/builds.
+//
+// Trigger a build of a job.
+func (c *Client) CreateJobBuild(ctx context.Context, teamName string, pipelineName string, jobName string, body atc.CreateJobBuildRequestBody, opts ...RequestOption) (atc.Build, error) {
+	var result atc.Build
+	err := c.sendJSON(ctx, atc.CreateJobBuild, rata.Params{"team_name": teamName, "pipeline_name": pipelineName, "job_name": jobName}, jsonBody(body), &result, opts)
+	return result, err
+}
+
+// RerunJobBuild calls POST /api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/builds/:build_name.
+//
+// Rerun a build of a job with the same inputs.
+func (c *Client) RerunJobBuild(ctx context.Context, teamName string, pipelineName string, jobName string, buildName string, opts ...RequestOption) (atc.Build, error) {
+	var result atc.Build
+	err := c.sendJSON(ctx, atc.RerunJobBuild, rata.Params{"team_name": teamName, "pipeline_name": pipelineName, "job_name": jobName, "build_name": buildName}, nil, &result, opts)
+	return result, err
+}
+
+// ListJobInputs calls GET /api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/inputs.
+//
+// List the inputs the next build of a job would run with.
+func (c *Client) ListJobInputs(ctx context.Context, teamName string, pipelineName string, jobName string, opts ...RequestOption) ([]atc.BuildInput, error) {
+	var result []atc.BuildInput
+	err := c.sendJSON(ctx, atc.ListJobInputs, rata.Params{"team_name": teamName, "pipeline_name": pipelineName, "job_name": jobName}, nil, &result, opts)
+	return result, err
+}
+
+// GetJobBuild calls GET /api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/builds/:build_name.
+//
+// Get a build of a job by name.
+func (c *Client) GetJobBuild(ctx context.Context, teamName string, pipelineName string, jobName string, buildName string, opts ...RequestOption) (atc.Build, error) {
+	var result atc.Build
+	err := c.sendJSON(ctx, atc.GetJobBuild, rata.Params{"team_name": teamName, "pipeline_name": pipelineName, "job_name": jobName, "build_name": buildName}, nil, &result, opts)
+	return result, err
+}
+
+// PauseJob calls PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/pause.
+//
+// Pause a job.
+func (c *Client) PauseJob(ctx context.Context, teamName string, pipelineName string, jobName string, opts ...RequestOption) error {
+	return c.sendJSON(ctx, atc.PauseJob, rata.Params{"team_name": teamName, "pipeline_name": pipelineName, "job_name": jobName}, nil, nil, opts)
+}
+
+// UnpauseJob calls PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/unpause.
+//
+// Unpause a job.
+func (c *Client) UnpauseJob(ctx context.Context, teamName string, pipelineName string, jobName string, opts ...RequestOption) error {
+	return c.sendJSON(ctx, atc.UnpauseJob, rata.Params{"team_name": teamName, "pipeline_name": pipelineName, "job_name": jobName}, nil, nil, opts)
+}
+
+// ScheduleJob calls PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/schedule.
+//
+// Schedule a job.
+func (c *Client) ScheduleJob(ctx context.Context, teamName string, pipelineName string, jobName string, opts ...RequestOption) error {
+	return c.sendJSON(ctx, atc.ScheduleJob, rata.Params{"team_name": teamName, "pipeline_name": pipelineName, "job_name": jobName}, nil, nil, opts)
+}
+
+// JobBadge calls GET /api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/badge.
+//
+// Get a badge with the status of a job.
+func (c *Client) JobBadge(ctx context.Context, teamName string, pipelineName string, jobName string, opts ...RequestOption) (io.ReadCloser, error) {
+	return c.sendStream(ctx, atc.JobBadge, rata.Params{"team_name": teamName, "pipeline_name": pipelineName, "job_name": jobName}, nil, opts)
+}
+
+// MainJobBadge calls GET /api/v1/pipelines/:pipeline_name/jobs/:job_name/badge.
+//
+// Get a badge with the status of a job of the main team.
+func (c *Client) MainJobBadge(ctx context.Context, pipelineName string, jobName string, opts ...RequestOption) (io.ReadCloser, error) {
+	return c.sendStream(ctx, atc.MainJobBadge, rata.Params{"pipeline_name": pipelineName, "job_name": jobName}, nil, opts)
+}
+
+// ListJobStatistics calls GET /api/v1/teams/:team_name/pipelines/:pipeline_name/statistics.
+//
+// Get the statistics of each job of a pipeline.
+func (c *Client) ListJobStatistics(ctx context.Context, teamName string, pipelineName string, opts ...RequestOption) ([]atc.JobStatistics, error) {
+	var result []atc.JobStatistics
+	err := c.sendJSON(ctx, atc.ListJobStatistics, rata.Params{"team_name": teamName, "pipeline_name": pipelineName}, nil, &result, opts)
+	return result, err
+}
+
+// GetJobStatistics calls GET /api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/statistics.
+//
+// Get the statistics of a job.
+func (c *Client) GetJobStatistics(ctx context.Context, teamName string, pipelineName string, jobName string, opts ...RequestOption) (atc.JobStatistics, error) {
+	var result atc.JobStatistics
+	err := c.sendJSON(ctx, atc.GetJobStatistics, rata.Params{"team_name": teamName, "pipeline_name": pipelineName, "job_name": jobName}, nil, &result, opts)
+	return result, err
+}
+
+// ListJobFlakiness calls GET /api/v1/teams/:team_name/pipelines/:pipeline_name/flakiness.
+//
+// Get the flakiness of each job of a pipeline.
+func (c *Client) ListJobFlakiness(ctx context.Context, teamName string, pipelineName string, opts ...RequestOption) ([]atc.JobFlakiness, error) {
+	var result []atc.JobFlakiness
+	err := c.sendJSON(ctx, atc.ListJobFlakiness, rata.Params{"team_name": teamName, "pipeline_name": pipelineName}, nil, &result, opts)
+	return result, err
+}
+
+// ListFlakyJobs calls GET /api/v1/teams/:team_name/flaky_jobs.
+//
+// List the flakiest jobs of a team.
+func (c *Client) ListFlakyJobs(ctx context.Context, teamName string, opts ...RequestOption) ([]atc.JobFlakiness, error) {
+	var result []atc.JobFlakiness
+	err := c.sendJSON(ctx, atc.ListFlakyJobs, rata.Params{"team_name": teamName}, nil, &result, opts)
+	return result, err
+}
+
+// ListTeamBuildQueue calls GET /api/v1/teams/:team_name/build_queue.
+//
+// List the queued builds of a team with when they are expected to run.
+func (c *Client) ListTeamBuildQueue(ctx context.Context, teamName string, opts ...RequestOption) ([]atc.QueuedBuild, error) {
+	var result []atc.QueuedBuild
+	err := c.sendJSON(ctx, atc.ListTeamBuildQueue, rata.Params{"team_name": teamName}, nil, &result, opts)
+	return result, err
+}
+
+// ClearTaskCache calls DELETE /api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/tasks/:step_name/cache.
+//
+// Clear the caches of a task of a job.
+func (c *Client) ClearTaskCache(ctx context.Context, teamName string, pipelineName string, jobName string, stepName string, opts ...RequestOption) (atc.ClearTaskCacheResponse, error) {
+	var result atc.ClearTaskCacheResponse
+	err := c.sendJSON(ctx, atc.ClearTaskCache, rata.Params{"team_name": teamName, "pipeline_name": pipelineName, "job_name": jobName, "step_name": stepName}, nil, &result, opts)
+	return result, err
+}
+
+// ListAllPipelines calls GET /api/v1/pipelines.
+//
+// List every visible pipeline.
+func (c *Client) ListAllPipelines(ctx context.Context, opts ...RequestOption) ([]atc.Pipeline, error) {
+	var result []atc.Pipeline
+	err := c.sendJSON(ctx, atc.ListAllPipelines, rata.Params{}, nil, &result, opts)
+	return result, err
+}
+
+// ListPipelines calls GET /api/v1/teams/:team_name/pipelines.
+//
+// List the pipelines of a team.
+func (c *Client) ListPipelines(ctx context.Context, teamName string, opts ...RequestOption) ([]atc.Pipeline, error) {
+	var result []atc.Pipeline
+	err := c.sendJSON(ctx, atc.ListPipelines, rata.Params{"team_name": teamName}, nil, &result, opts)
+	return result, err
+}
+
+// GetPipeline calls GET /api/v1/teams/:team_name/pipelines/:pipeline_name.
+//
+// Get a pipeline.
+func (c *Client) GetPipeline(ctx context.Context, teamName string, pipelineName string, opts ...RequestOption) (atc.Pipeline, error) {
+	var result atc.Pipeline
+	err := c.sendJSON(ctx, atc.GetPipeline, rata.Params{"team_name": teamName, "pipeline_name": pipelineName}, nil, &result, opts)
+	return result, err
+}
+
+// DeletePipeline calls DELETE /api/v1/teams/:team_name/pipelines/:pipeline_name.
+//
+// Delete a pipeline.
+func (c *Client) DeletePipeline(ctx context.Context, teamName string, pipelineName string, opts ...RequestOption) error {
+	return c.sendJSON(ctx, atc.DeletePipeline, rata.Params{"team_name": teamName, "pipeline_name": pipelineName}, nil, nil, opts)
+}
+
+// OrderPipelines calls PUT /api/v1/teams/:team_name/pipelines/ordering.
+//
+// Order the pipelines of a team by name.
+func (c *Client) OrderPipelines(ctx context.Context, teamName string, body []string, opts ...RequestOption) error {
+	return c.sendJSON(ctx, atc.OrderPipelines, rata.Params{"team_name": teamName}, jsonBody(body), nil, opts)
+}
+
+// PausePipelines calls PUT /api/v1/teams/:team_name/pipelines/pause.
+//
+// Pause the pipelines of a team matching a pattern.
+func (c *Client) PausePipelines(ctx context.Context, teamName string, opts ...RequestOption) ([]atc.PipelineBatchResult, error) {
+	var result []atc.PipelineBatchResult
+	err := c.sendJSON(ctx, atc.PausePipelines, rata.Params{"team_name": teamName}, nil, &result, opts)
+	return result, err
+}
+
+// UnpausePipelines calls PUT /api/v1/teams/:team_name/pipelines/unpause.
+//
+// Unpause the pipelines of a team matching a pattern.
+func (c *Client) UnpausePipelines(ctx context.Context, teamName string, opts ...RequestOption) ([]atc.PipelineBatchResult, error) {
+	var result []atc.PipelineBatchResult
+	err := c.sendJSON(ctx, atc.UnpausePipelines, rata.Params{"team_name": teamName}, nil, &result, opts)
+	return result, err
+}
+
+// ExposePipelines calls PUT /api/v1/teams/:team_name/pipelines/expose.
+//
+// Expose the pipelines of a team matching a pattern.
+func (c *Client) ExposePipelines(ctx context.Context, teamName string, opts ...RequestOption) ([]atc.PipelineBatchResult, error) {
+	var result []atc.PipelineBatchResult
+	err := c.sendJSON(ctx, atc.ExposePipelines, rata.Params{"team_name": teamName}, nil, &result, opts)
+	return result, err
+}
+
+// HidePipelines calls PUT /api/v1/teams/:team_name/pipelines/hide.
+//
+// Hide the pipelines of a team matching a pattern.
+func (c *Client) HidePipelines(ctx context.Context, teamName string, opts ...RequestOption) ([]atc.PipelineBatchResult, error) {
+	var result []atc.PipelineBatchResult
+	err := c.sendJSON(ctx, atc.HidePipelines, rata.Params{"team_name": teamName}, nil, &result, opts)
+	return result, err
+}
+
+// CheckPipelines calls POST /api/v1/teams/:team_name/pipelines/check.
+//
+// Check the resources of the pipelines of a team matching a pattern.
+func (c *Client) CheckPipelines(ctx context.Context, teamName string, opts ...RequestOption) ([]atc.PipelineBatchResult, error) {
+	var result []atc.PipelineBatchResult
+	err := c.sendJSON(ctx, atc.CheckPipelines, rata.Params{"team_name": teamName}, nil, &result, opts)
+	return result, err
+}
+
+// OrderPipelinesWithinGroup calls PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/ordering.
+//
+// Order the instances of a pipeline by their instance vars.
+func (c *Client) OrderPipelinesWithinGroup(ctx context.Context, teamName string, pipelineName string, body []atc.InstanceVars, opts ...RequestOption) error {
+	return c.sendJSON(ctx, atc.OrderPipelinesWithinGroup, rata.Params{"team_name": teamName, "pipeline_name": pipelineName}, jsonBody(body), nil, opts)
+}
+
+// PausePipeline calls PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/pause.
+//
+// Pause a pipeline.
+func (c *Client) PausePipeline(ctx context.Context, teamName string, pipelineName string, opts ...RequestOption) error {
+	return c.sendJSON(ctx, atc.PausePipeline, rata.Params{"team_name": teamName, "pipeline_name": pipelineName}, nil, nil, opts)
+}
+
+// ArchivePipeline calls PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/archive.
+//
+// Archive a pipeline.
+func (c *Client) ArchivePipeline(ctx context.Context, teamName string, pipelineName string, opts ...RequestOption) error {
+	return c.sendJSON(ctx, atc.ArchivePipeline, rata.Params{"team_name": teamName, "pipeline_name": pipelineName}, nil, nil, opts)
+}
+
+// UnpausePipeline calls PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/unpause.
+//
+// Unpause a pipeline.
+func (c *Client) UnpausePipeline(ctx context.Context, teamName string, pipelineName string, opts ...RequestOption) error {
+	return c.sendJSON(ctx, atc.UnpausePipeline, rata.Params{"team_name": teamName, "pipeline_name": pipelineName}, nil, nil, opts)
+}
+
+// ExposePipeline calls PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/expose.
+//
+// Expose a pipeline.
+func (c *Client) ExposePipeline(ctx context.Context, teamName string, pipelineName string, body atc.PipelineExposure, opts ...RequestOption) error {
+	return c.sendJSON(ctx, atc.ExposePipeline, rata.Params{"team_name": teamName, "pipeline_name": pipelineName}, jsonBody(body), nil, opts)
+}
+
+// HidePipeline calls PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/hide.
+//
+// Hide a pipeline.
+func (c *Client) HidePipeline(ctx context.Context, teamName string, pipelineName string, opts ...RequestOption) error {
+	return c.sendJSON(ctx, atc.HidePipeline, rata.Params{"team_name": teamName, "pipeline_name": pipelineName}, nil, nil, opts)
+}
+
+// GetVersionsDB calls GET /api/v1/teams/:team_name/pipelines/:pipeline_name/versions-db.
+//
+// Get the versions of a pipeline as the scheduler sees them.
+func (c *Client) GetVersionsDB(ctx context.Context, teamName string, pipelineName string, opts ...RequestOption) (atc.DebugVersionsDB, error) {
+	var result atc.DebugVersionsDB
+	err := c.sendJSON(ctx, atc.GetVersionsDB, rata.Params{"team_name": teamName, "pipeline_name": pipelineName}, nil, &result, opts)
+	return result, err
+}
+
+// RenamePipeline calls PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/rename.
+//
+// Rename a pipeline.
+func (c *Client) RenamePipeline(ctx context.Context, teamName string, pipelineName string, body atc.RenameRequest, opts ...RequestOption) (atc.SaveConfigResponse, error) {
+	var result atc.SaveConfigResponse
+	err := c.sendJSON(ctx, atc.RenamePipeline, rata.Params{"team_name": teamName, "pipeline_name": pipelineName}, jsonBody(body), &result, opts)
+	return result, err
+}
+
+// MovePipeline calls PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/move.
+//
+// Move a pipeline, with its builds and caches, to another team.
+func (c *Client) MovePipeline(ctx context.Context, teamName string, pipelineName string, body atc.MovePipelineRequest, opts ...RequestOption) error {
+	return c.sendJSON(ctx, atc.MovePipeline, rata.Params{"team_name": teamName, "pipeline_name": pipelineName}, jsonBody(body), nil, opts)
+}
+
+// ListPipelineBuilds calls GET /api/v1/teams/:team_name/pipelines/:pipeline_name/builds.
+//
+// List the builds of a pipeline.
+func (c *Client) ListPipelineBuilds(ctx context.Context, teamName string, pipelineName string, opts ...RequestOption) ([]atc.Build, error) {
+	var result []atc.Build
+	err := c.sendJSON(ctx, atc.ListPipelineBuilds, rata.Params{"team_name": teamName, "pipeline_name": pipelineName}, nil, &result, opts)
+	return result, err
+}
+
+// CreatePipelineBuild calls POST /api/v1/teams/:team_name/pipelines/:pipeline_name/builds.
+//
+// Run a one-off build of a plan in a pipeline.
+func (c *Client) CreatePipelineBuild(ctx context.Context, teamName string, pipelineName string, body atc.Plan, opts ...RequestOption) (atc.Build, error) {
+	var result atc.Build
+	err := c.sendJSON(ctx, atc.CreatePipelineBuild, rata.Params{"team_name": teamName, "pipeline_name": pipelineName}, jsonBody(body), &result, opts)
+	return result, err
+}
+
+// PipelineBadge calls GET /api/v1/teams/:team_name/pipelines/:pipeline_name/badge.
+//
+// Get a badge with the status of a pipeline.
+func (c *Client) PipelineBadge(ctx context.Context, teamName string, pipelineName string, opts ...RequestOption) (io.ReadCloser, error) {
+	return c.sendStream(ctx, atc.PipelineBadge, rata.Params{"team_name": teamName, "pipeline_name": pipelineName}, nil, opts)
+}
+
+// GetPipelineGraph calls GET /api/v1/teams/:team_name/pipelines/:pipeline_name/graph.
+//
+// Get how the jobs and resources of a pipeline depend on each other.
+func (c *Client) GetPipelineGraph(ctx context.Context, teamName string, pipelineName string, opts ...RequestOption) (atc.PipelineGraph, error) {
+	var result atc.PipelineGraph
+	err := c.sendJSON(ctx, atc.GetPipelineGraph, rata.Params{"team_name": teamName, "pipeline_name": pipelineName}, nil, &result, opts)
+	return result, err
+}
+
+// ListAllResources calls GET /api/v1/resources.
+//
+// List the resources of every visible pipeline.
+func (c *Client) ListAllResources(ctx context.Context, opts ...RequestOption) ([]atc.Resource, error) {
+	var result []atc.Resource
+	err := c.sendJSON(ctx, atc.ListAllResources, rata.Params{}, nil, &result, opts)
+	return result, err
+}
+
+// ListResources calls GET /api/v1/teams/:team_name/pipelines/:pipeline_name/resources.
+//
+// List the resources of a pipeline.
+func (c *Client) ListResources(ctx context.Context, teamName string, pipelineName string, opts ...RequestOption) ([]atc.Resource, error) {
+	var result []atc.Resource
+	err := c.sendJSON(ctx, atc.ListResources, rata.Params{"team_name": teamName, "pipeline_name": pipelineName}, nil, &result, opts)
+	return result, err
+}
+
+// ListSharedForResource calls GET /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/shared.
+//
+// List the resources and resource types sharing a resource's versions.
+func (c *Client) ListSharedForResource(ctx context.Context, teamName string, pipelineName string, resourceName string, opts ...RequestOption) (atc.ResourcesAndTypes, error) {
+	var result atc.ResourcesAndTypes
+	err := c.sendJSON(ctx, atc.ListSharedForResource, rata.Params{"team_name": teamName, "pipeline_name": pipelineName, "resource_name": resourceName}, nil, &result, opts)
+	return result, err
+}
+
+// ListSharedForResourceType calls GET /api/v1/teams/:team_name/pipelines/:pipeline_name/resource-types/:resource_type_name/shared.
+//
+// List the resources and resource types sharing a resource type's versions.
+func (c *Client) ListSharedForResourceType(ctx context.Context, teamName string, pipelineName string, resourceTypeName string, opts ...RequestOption) (atc.ResourcesAndTypes, error) {
+	var result atc.ResourcesAndTypes
+	err := c.sendJSON(ctx, atc.ListSharedForResourceType, rata.Params{"team_name": teamName, "pipeline_name": pipelineName, "resource_type_name": resourceTypeName}, nil, &result, opts)
+	return result, err
+}
+
+// ListResourceTypes calls GET /api/v1/teams/:team_name/pipelines/:pipeline_name/resource-types.
+//
+// List the resource types of a pipeline.
+func (c *Client) ListResourceTypes(ctx context.Context, teamName string, pipelineName string, opts ...RequestOption) (atc.ResourceTypes, error) {
+	var result atc.ResourceTypes
+	err := c.sendJSON(ctx, atc.ListResourceTypes, rata.Params{"team_name": teamName, "pipeline_name": pipelineName}, nil, &result, opts)
+	return result, err
+}
+
+// GetResource calls GET /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name.
+//
+// Get a resource.
+func (c *Client) GetResource(ctx context.Context, teamName string, pipelineName string, resourceName string, opts ...RequestOption) (atc.Resource, error) {
+	var result atc.Resource
+	err := c.sendJSON(ctx, atc.GetResource, rata.Params{"team_name": teamName, "pipeline_name": pipelineName, "resource_name": resourceName}, nil, &result, opts)
+	return result, err
+}
+
+// CheckResource calls POST /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/check.
+//
+// Check a resource for new versions.
+func (c *Client) CheckResource(ctx context.Context, teamName string, pipelineName string, resourceName string, body atc.CheckRequestBody, opts ...RequestOption) (atc.Build, error) {
+	var result atc.Build
+	err := c.sendJSON(ctx, atc.CheckResource, rata.Params{"team_name": teamName, "pipeline_name": pipelineName, "resource_name": resourceName}, jsonBody(body), &result, opts)
+	return result, err
+}
+
+// CheckResourceWebHook calls POST /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/check/webhook.
+//
+// Check a resource for new versions from a webhook.
+func (c *Client) CheckResourceWebHook(ctx context.Context, teamName string, pipelineName string, resourceName string, opts ...RequestOption) (atc.Build, error) {
+	var result atc.Build
+	err := c.sendJSON(ctx, atc.CheckResourceWebHook, rata.Params{"team_name": teamName, "pipeline_name": pipelineName, "resource_name": resourceName}, nil, &result, opts)
+	return result, err
+}
+
+// CheckResourceType calls POST /api/v1/teams/:team_name/pipelines/:pipeline_name/resource-types/:resource_type_name/check.
+//
+// Check a resource type for new versions.
+func (c *Client) CheckResourceType(ctx context.Context, teamName string, pipelineName string, resourceTypeName string, body atc.CheckRequestBody, opts ...RequestOption) (atc.Build, error) {
+	var result atc.Build
+	err := c.sendJSON(ctx, atc.CheckResourceType, rata.Params{"team_name": teamName, "pipeline_name": pipelineName, "resource_type_name": resourceTypeName}, jsonBody(body), &result, opts)
+	return result, err
+}
+
+// CheckPrototype calls POST /api/v1/teams/:team_name/pipelines/:pipeline_name/prototypes/:prototype_name/check.
+//
+// Check a prototype for new versions.
+func (c *Client) CheckPrototype(ctx context.Context, teamName string, pipelineName string, prototypeName string, body atc.CheckRequestBody, opts ...RequestOption) (atc.Build, error) {
+	var result atc.Build
+	err := c.sendJSON(ctx, atc.CheckPrototype, rata.Params{"team_name": teamName, "pipeline_name": pipelineName, "prototype_name": prototypeName}, jsonBody(body), &result, opts)
+	return result, err
+}
+
+// ClearResourceCache calls DELETE /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/cache.
+//
+// Clear the caches of a resource.
+func (c *Client) ClearResourceCache(ctx context.Context, teamName string, pipelineName string, resourceName string, body atc.VersionDeleteBody, opts ...RequestOption) (atc.ClearResourceCacheResponse, error) {
+	var result atc.ClearResourceCacheResponse
+	err := c.sendJSON(ctx, atc.ClearResourceCache, rata.Params{"team_name": teamName, "pipeline_name": pipelineName, "resource_name": resourceName}, jsonBody(body), &result, opts)
+	return result, err
+}
+
+// ListResourceVersions calls GET /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions.
+//
+// List the versions of a resource.
+func (c *Client) ListResourceVersions(ctx context.Context, teamName string, pipelineName string, resourceName string, opts ...RequestOption) ([]atc.ResourceVersion, error) {
+	var result []atc.ResourceVersion
+	err := c.sendJSON(ctx, atc.ListResourceVersions, rata.Params{"team_name": teamName, "pipeline_name": pipelineName, "resource_name": resourceName}, nil, &result, opts)
+	return result, err
+}
+
+// ClearResourceVersions calls DELETE /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions.
+//
+// Clear the versions of a resource.
+func (c *Client) ClearResourceVersions(ctx context.Context, teamName string, pipelineName string, resourceName string, opts ...RequestOption) (atc.ClearVersionsResponse, error) {
+	var result atc.ClearVersionsResponse
+	err := c.sendJSON(ctx, atc.ClearResourceVersions, rata.Params{"team_name": teamName, "pipeline_name": pipelineName, "resource_name": resourceName}, nil, &result, opts)
+	return result, err
+}
+
+// PushResourceVersions calls POST /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions.
+//
+// Push versions to a resource in push mode.
+func (c *Client) PushResourceVersions(ctx context.Context, teamName string, pipelineName string, resourceName string, body []atc.Version, opts ...RequestOption) (atc.PushVersionsResponse, error) {
+	var result atc.PushVersionsResponse
+	err := c.sendJSON(ctx, atc.PushResourceVersions, rata.Params{"team_name": teamName, "pipeline_name": pipelineName, "resource_name": resourceName}, jsonBody(body), &result, opts)
+	return result, err
+}
+
+// ClearResourceTypeVersions calls DELETE /api/v1/teams/:team_name/pipelines/:pipeline_name/resource-types/:resource_type_name/versions.
+//
+// Clear the versions of a resource type.
+func (c *Client) ClearResourceTypeVersions(ctx context.Context, teamName string, pipelineName string, resourceTypeName string, opts ...RequestOption) (atc.ClearVersionsResponse, error) {
+	var result atc.ClearVersionsResponse
+	err := c.sendJSON(ctx, atc.ClearResourceTypeVersions, rata.Params{"team_name": teamName, "pipeline_name": pipelineName, "resource_type_name": resourceTypeName}, nil, &result, opts)
+	return result, err
+}
+
+// GetResourceVersion calls GET /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_config_version_id.
+//
+// Get a version of a resource.
+func (c *Client) GetResourceVersion(ctx context.Context, teamName string, pipelineName string, resourceName string, resourceConfigVersionID string, opts ...RequestOption) (atc.ResourceVersion, error) {
+	var result atc.ResourceVersion
+	err := c.sendJSON(ctx, atc.GetResourceVersion, rata.Params{"team_name": teamName, "pipeline_name": pipelineName, "resource_name": resourceName, "resource_config_version_id": resourceConfigVersionID}, nil, &result, opts)
+	return result, err
+}
+
+// EnableResourceVersion calls PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_config_version_id/enable.
+//
+// Enable a version of a resource.
+func (c *Client) EnableResourceVersion(ctx context.Context, teamName string, pipelineName string, resourceName string, resourceConfigVersionID string, opts ...RequestOption) error {
+	return c.sendJSON(ctx, atc.EnableResourceVersion, rata.Params{"team_name": teamName, "pipeline_name": pipelineName, "resource_name": resourceName, "resource_config_version_id": resourceConfigVersionID}, nil, nil, opts)
+}
+
+// DisableResourceVersion calls PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_config_version_id/disable.
+//
+// Disable a version of a resource.
+func (c *Client) DisableResourceVersion(ctx context.Context, teamName string, pipelineName string, resourceName string, resourceConfigVersionID string, opts ...RequestOption) error {
+	return c.sendJSON(ctx, atc.DisableResourceVersion, rata.Params{"team_name": teamName, "pipeline_name": pipelineName, "resource_name": resourceName, "resource_config_version_id": resourceConfigVersionID}, nil, nil, opts)
+}
+
+// PinResourceVersion calls PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_config_version_id/pin.
+//
+// Pin a resource to a version.
+func (c *Client) PinResourceVersion(ctx context.Context, teamName string, pipelineName string, resourceName string, resourceConfigVersionID string, opts ...RequestOption) error {
+	return c.sendJSON(ctx, atc.PinResourceVersion, rata.Params{"team_name": teamName, "pipeline_name": pipelineName, "resource_name": resourceName, "resource_config_version_id": resourceConfigVersionID}, nil, nil, opts)
+}
+
+// UnpinResource calls PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/unpin.
+//
+// Unpin a resource.
+func (c *Client) UnpinResource(ctx context.Context, teamName string, pipelineName string, resourceName string, opts ...RequestOption) error {
+	return c.sendJSON(ctx, atc.UnpinResource, rata.Params{"team_name": teamName, "pipeline_name": pipelineName, "resource_name": resourceName}, nil, nil, opts)
+}
+
+// SetPinCommentOnResource calls PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/pin_comment.
+//
+// Set the comment on the pinned version of a resource.
+func (c *Client) SetPinCommentOnResource(ctx context.Context, teamName string, pipelineName string, resourceName string, body atc.SetPinCommentRequestBody, opts ...RequestOption) error {
+	return c.sendJSON(ctx, atc.SetPinCommentOnResource, rata.Params{"team_name": teamName, "pipeline_name": pipelineName, "resource_name": resourceName}, jsonBody(body), nil, opts)
+}
+
+// ResetResourceCheckFrom calls PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/check_from.
+//
+// Reset the version the next check of a resource starts from.
+func (c *Client) ResetResourceCheckFrom(ctx context.Context, teamName string, pipelineName string, resourceName string, body atc.ResetCheckFromRequestBody, opts ...RequestOption) error {
+	return c.sendJSON(ctx, atc.ResetResourceCheckFrom, rata.Params{"team_name": teamName, "pipeline_name": pipelineName, "resource_name": resourceName}, jsonBody(body), nil, opts)
+}
+
+// ListBuildsWithVersionAsInput calls GET /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_config_version_id/input_to.
+//
+// List the builds which used a version of a resource as an input.
+func (c *Client) ListBuildsWithVersionAsInput(ctx context.Context, teamName string, pipelineName string, resourceName string, resourceConfigVersionID string, opts ...RequestOption) ([]atc.Build, error) {
+	var result []atc.Build
+	err := c.sendJSON(ctx, atc.ListBuildsWithVersionAsInput, rata.Params{"team_name": teamName, "pipeline_name": pipelineName, "resource_name": resourceName, "resource_config_version_id": resourceConfigVersionID}, nil, &result, opts)
+	return result, err
+}
+
+// ListBuildsWithVersionAsOutput calls GET /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_config_version_id/output_of.
+//
+// List the builds which produced a version of a resource.
+func (c *Client) ListBuildsWithVersionAsOutput(ctx context.Context, teamName string, pipelineName string, resourceName string, resourceConfigVersionID string, opts ...RequestOption) ([]atc.Build, error) {
+	var result []atc.Build
+	err := c.sendJSON(ctx, atc.ListBuildsWithVersionAsOutput, rata.Params{"team_name": teamName, "pipeline_name": pipelineName, "resource_name": resourceName, "resource_config_version_id": resourceConfigVersionID}, nil, &result, opts)
+	return result, err
+}
+
+// GetDownstreamResourceCausality calls GET /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_config_version_id/downstream.
+//
+// Get the builds and versions which descend from a version of a resource.
+func (c *Client) GetDownstreamResourceCausality(ctx context.Context, teamName string, pipelineName string, resourceName string, resourceConfigVersionID string, opts ...RequestOption) (atc.Causality, error) {
+	var result atc.Causality
+	err := c.sendJSON(ctx, atc.GetDownstreamResourceCausality, rata.Params{"team_name": teamName, "pipeline_name": pipelineName, "resource_name": resourceName, "resource_config_version_id": resourceConfigVersionID}, nil, &result, opts)
+	return result, err
+}
+
+// GetUpstreamResourceCausality calls GET /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_config_version_id/upstream.
+//
+// Get the builds and versions a version of a resource descends from.
+func (c *Client) GetUpstreamResourceCausality(ctx context.Context, teamName string, pipelineName string, resourceName string, resourceConfigVersionID string, opts ...RequestOption) (atc.Causality, error) {
+	var result atc.Causality
+	err := c.sendJSON(ctx, atc.GetUpstreamResourceCausality, rata.Params{"team_name": teamName, "pipeline_name": pipelineName, "resource_name": resourceName, "resource_config_version_id": resourceConfigVersionID}, nil, &result, opts)
+	return result, err
+}
+
+// GetResourceVersionImpact calls GET /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_config_version_id/impact.
+//
+// List the builds a version of a resource went on to affect.
+func (c *Client) GetResourceVersionImpact(ctx context.Context, teamName string, pipelineName string, resourceName string, resourceConfigVersionID string, opts ...RequestOption) ([]atc.ImpactedBuild, error) {
+	var result []atc.ImpactedBuild
+	err := c.sendJSON(ctx, atc.GetResourceVersionImpact, rata.Params{"team_name": teamName, "pipeline_name": pipelineName, "resource_name": resourceName, "resource_config_version_id": resourceConfigVersionID}, nil, &result, opts)
+	return result, err
+}
+
+// FetchResourceVersionMetadata calls POST /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_config_version_id/metadata.
+//
+// Fetch the metadata of a version of a resource.
+func (c *Client) FetchResourceVersionMetadata(ctx context.Context, teamName string, pipelineName string, resourceName string, resourceConfigVersionID string, opts ...RequestOption) (atc.Build, error) {
+	var result atc.Build
+	err := c.sendJSON(ctx, atc.FetchResourceVersionMetadata, rata.Params{"team_name": teamName, "pipeline_name": pipelineName, "resource_name": resourceName, "resource_config_version_id": resourceConfigVersionID}, nil, &result, opts)
+	return result, err
+}
+
+// BackfillResourceMetadata calls POST /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/metadata.
+//
+// Fetch the metadata of the versions of a resource which have none.
+func (c *Client) BackfillResourceMetadata(ctx context.Context, teamName string, pipelineName string, resourceName string, opts ...RequestOption) (atc.Build, error) {
+	var result atc.Build
+	err := c.sendJSON(ctx, atc.BackfillResourceMetadata, rata.Params{"team_name": teamName, "pipeline_name": pipelineName, "resource_name": resourceName}, nil, &result, opts)
+	return result, err
+}
+
+// GetCC calls GET /api/v1/teams/:team_name/cc.xml.
+//
+// Get the status of a team's jobs for CCMenu.
+func (c *Client) GetCC(ctx context.Context, teamName string, opts ...RequestOption) (io.ReadCloser, error) {
+	return c.sendStream(ctx, atc.GetCC, rata.Params{"team_name": teamName}, nil, opts)
+}
+
+// ListWorkers calls GET /api/v1/workers.
+//
+// List the workers.
+func (c *Client) ListWorkers(ctx context.Context, opts ...RequestOption) ([]atc.Worker, error) {
+	var result []atc.Worker
+	err := c.sendJSON(ctx, atc.ListWorkers, rata.Params{}, nil, &result, opts)
+	return result, err
+}
+
+// RegisterWorker calls POST /api/v1/workers.
+//
+// Register a worker.
+func (c *Client) RegisterWorker(ctx context.Context, body atc.Worker, opts ...RequestOption) (atc.Worker, error) {
+	var result atc.Worker
+	err := c.sendJSON(ctx, atc.RegisterWorker, rata.Params{}, jsonBody(body), &result, opts)
+	return result, err
+}
+
+// LandWorker calls PUT /api/v1/workers/:worker_name/land.
+//
+// Land a worker.
+func (c *Client) LandWorker(ctx context.Context, workerName string, opts ...RequestOption) error {
+	return c.sendJSON(ctx, atc.LandWorker, rata.Params{"worker_name": workerName}, nil, nil, opts)
+}
+
+// RetireWorker calls PUT /api/v1/workers/:worker_name/retire.
+//
+// Retire a worker.
+func (c *Client) RetireWorker(ctx context.Context, workerName string, opts ...RequestOption) error {
+	return c.sendJSON(ctx, atc.RetireWorker, rata.Params{"worker_name": workerName}, nil, nil, opts)
+}
+
+// PruneWorker calls PUT /api/v1/workers/:worker_name/prune.
+//
+// Prune a stalled worker.
+func (c *Client) PruneWorker(ctx context.Context, workerName string, opts ...RequestOption) error {
+	return c.sendJSON(ctx, atc.PruneWorker, rata.Params{"worker_name": workerName}, nil, nil, opts)
+}
+
+// HeartbeatWorker calls PUT /api/v1/workers/:worker_name/heartbeat.
+//
+// Heartbeat a worker.
+func (c *Client) HeartbeatWorker(ctx context.Context, workerName string, body atc.Worker, opts ...RequestOption) (atc.Worker, error) {
+	var result atc.Worker
+	err := c.sendJSON(ctx, atc.HeartbeatWorker, rata.Params{"worker_name": workerName}, jsonBody(body), &result, opts)
+	return result, err
+}
+
+// DeleteWorker calls DELETE /api/v1/workers/:worker_name.
+//
+// Delete a worker.
+func (c *Client) DeleteWorker(ctx context.Context, workerName string, opts ...RequestOption) error {
+	return c.sendJSON(ctx, atc.DeleteWorker, rata.Params{"worker_name": workerName}, nil, nil, opts)
+}
+
+// ListWorkerKeys calls GET /api/v1/worker_keys.
+//
+// List the worker keys of every team.
+func (c *Client) ListWorkerKeys(ctx context.Context, opts ...RequestOption) ([]atc.TeamWorkerKeys, error) {
+	var result []atc.TeamWorkerKeys
+	err := c.sendJSON(ctx, atc.ListWorkerKeys, rata.Params{}, nil, &result, opts)
+	return result, err
+}
+
+// GetLogLevel calls GET /api/v1/log-level.
+//
+// Get the log level.
+func (c *Client) GetLogLevel(ctx context.Context, opts ...RequestOption) (atc.LogLevel, error) {
+	text, err := c.sendText(ctx, atc.GetLogLevel, rata.Params{}, nil, opts)
+	return atc.LogLevel(text), err
+}
+
+// SetLogLevel calls PUT /api/v1/log-level.
+//
+// Set the log level.
+func (c *Client) SetLogLevel(ctx context.Context, body atc.LogLevel, opts ...RequestOption) error {
+	return c.sendJSON(ctx, atc.SetLogLevel, rata.Params{}, textBody(string(body)), nil, opts)
+}
+
+// GetRequestLog calls GET /api/v1/request-log.
+//
+// Get how API requests are logged.
+func (c *Client) GetRequestLog(ctx context.Context, opts ...RequestOption) (atc.RequestLogSettings, error) {
+	var result atc.RequestLogSettings
+	err := c.sendJSON(ctx, atc.GetRequestLog, rata.Params{}, nil, &result, opts)
+	return result, err
+}
+
+// SetRequestLog calls PUT /api/v1/request-log.
+//
+// Change how API requests are logged.
+func (c *Client) SetRequestLog(ctx context.Context, body atc.RequestLogSettings, opts ...RequestOption) error {
+	return c.sendJSON(ctx, atc.SetRequestLog, rata.Params{}, jsonBody(body), nil, opts)
+}
+
+// DownloadCLI calls GET /api/v1/cli.
+//
+// Download fly.
+func (c *Client) DownloadCLI(ctx context.Context, opts ...RequestOption) (io.ReadCloser, error) {
+	return c.sendStream(ctx, atc.DownloadCLI, rata.Params{}, nil, opts)
+}
+
+// GetInfo calls GET /api/v1/info.
+//
+// Get the version of Concourse and its enabled features.
+func (c *Client) GetInfo(ctx context.Context, opts ...RequestOption) (atc.Info, error) {
+	var result atc.Info
+	err := c.sendJSON(ctx, atc.GetInfo, rata.Params{}, nil, &result, opts)
+	return result, err
+}
+
+// GetInfoCreds calls GET /api/v1/info/creds.
+//
+// Get the configured credential managers.
+func (c *Client) GetInfoCreds(ctx context.Context, opts ...RequestOption) (map[string]interface{}, error) {
+	var result map[string]interface{}
+	err := c.sendJSON(ctx, atc.GetInfoCreds, rata.Params{}, nil, &result, opts)
+	return result, err
+}
+
+// GetOpenAPI calls GET /api/v1/openapi.json.
+//
+// Get this OpenAPI document.
+func (c *Client) GetOpenAPI(ctx context.Context, opts ...RequestOption) error {
+	return c.sendJSON(ctx, atc.GetOpenAPI, rata.Params{}, nil, nil, opts)
+}
+
+// GetHealth calls GET /api/v1/health.
+//
+// Check the health of the cluster's dependencies.
+func (c *Client) GetHealth(ctx context.Context, opts ...RequestOption) (atc.ClusterHealth, error) {
+	var result atc.ClusterHealth
+	err := c.sendJSON(ctx, atc.GetHealth, rata.Params{}, nil, &result, opts)
+	return result, err
+}
+
+// GetUser calls GET /api/v1/user.
+//
+// Get the user making the request.
+func (c *Client) GetUser(ctx context.Context, opts ...RequestOption) (atc.UserInfo, error) {
+	var result atc.UserInfo
+	err := c.sendJSON(ctx, atc.GetUser, rata.Params{}, nil, &result, opts)
+	return result, err
+}
+
+// ListActiveUsersSince calls GET /api/v1/users.
+//
+// List the users who logged in since a time.
+func (c *Client) ListActiveUsersSince(ctx context.Context, opts ...RequestOption) ([]atc.User, error) {
+	var result []atc.User
+	err := c.sendJSON(ctx, atc.ListActiveUsersSince, rata.Params{}, nil, &result, opts)
+	return result, err
+}
+
+// GetDashboardPreferences calls GET /api/v1/user/dashboard.
+//
+// Get the user's arrangement of the dashboard.
+func (c *Client) GetDashboardPreferences(ctx context.Context, opts ...RequestOption) (atc.DashboardPreferences, error) {
+	var result atc.DashboardPreferences
+	err := c.sendJSON(ctx, atc.GetDashboardPreferences, rata.Params{}, nil, &result, opts)
+	return result, err
+}
+
+// OrderDashboardPipelines calls PUT /api/v1/user/dashboard/order.
+//
+// Order the user's pipelines on the dashboard by ID.
+func (c *Client) OrderDashboardPipelines(ctx context.Context, body []int, opts ...RequestOption) error {
+	return c.sendJSON(ctx, atc.OrderDashboardPipelines, rata.Params{}, jsonBody(body), nil, opts)
+}
+
+// FavoritePipeline calls PUT /api/v1/user/dashboard/favorites/:pipeline_id.
+//
+// Favorite a pipeline for the user.
+func (c *Client) FavoritePipeline(ctx context.Context, pipelineID string, opts ...RequestOption) error {
+	return c.sendJSON(ctx, atc.FavoritePipeline, rata.Params{"pipeline_id": pipelineID}, nil, nil, opts)
+}
+
+// UnfavoritePipeline calls DELETE /api/v1/user/dashboard/favorites/:pipeline_id.
+//
+// Unfavorite a pipeline for the user.
+func (c *Client) UnfavoritePipeline(ctx context.Context, pipelineID string, opts ...RequestOption) error {
+	return c.sendJSON(ctx, atc.UnfavoritePipeline, rata.Params{"pipeline_id": pipelineID}, nil, nil, opts)
+}
+
+// GetUserPreferences calls GET /api/v1/user/preferences.
+//
+// Get the preferences kept for the user.
+func (c *Client) GetUserPreferences(ctx context.Context, opts ...RequestOption) (map[string]jsontext.Value, error) {
+	var result map[string]jsontext.Value
+	err := c.sendJSON(ctx, atc.GetUserPreferences, rata.Params{}, nil, &result, opts)
+	return result, err
+}
+
+// SetUserPreference calls PUT /api/v1/user/preferences/:preference_key.
+//
+// Keep a JSON value as one of the user's preferences.
+func (c *Client) SetUserPreference(ctx context.Context, preferenceKey string, body jsontext.Value, opts ...RequestOption) error {
+	return c.sendJSON(ctx, atc.SetUserPreference, rata.Params{"preference_key": preferenceKey}, jsonBody(body), nil, opts)
+}
+
+// DeleteUserPreference calls DELETE /api/v1/user/preferences/:preference_key.
+//
+// Delete one of the user's preferences.
+func (c *Client) DeleteUserPreference(ctx context.Context, preferenceKey string, opts ...RequestOption) error {
+	return c.sendJSON(ctx, atc.DeleteUserPreference, rata.Params{"preference_key": preferenceKey}, nil, nil, opts)
+}
+
+// ListSessions calls GET /api/v1/user/sessions.
+//
+// List the user's active sessions.
+func (c *Client) ListSessions(ctx context.Context, opts ...RequestOption) ([]atc.Session, error) {
+	var result []atc.Session
+	err := c.sendJSON(ctx, atc.ListSessions, rata.Params{}, nil, &result, opts)
+	return result, err
+}
+
+// RevokeSession calls DELETE /api/v1/user/sessions/:session_id.
+//
+// Revoke one of the user's sessions.
+func (c *Client) RevokeSession(ctx context.Context, sessionID string, opts ...RequestOption) error {
+	return c.sendJSON(ctx, atc.RevokeSession, rata.Params{"session_id": sessionID}, nil, nil, opts)
+}
+
+// ListUserSessions calls GET /api/v1/users/:user_id/sessions.
+//
+// List a user's active sessions.
+func (c *Client) ListUserSessions(ctx context.Context, userID string, opts ...RequestOption) ([]atc.Session, error) {
+	var result []atc.Session
+	err := c.sendJSON(ctx, atc.ListUserSessions, rata.Params{"user_id": userID}, nil, &result, opts)
+	return result, err
+}
+
+// RevokeUserSessions calls DELETE /api/v1/users/:user_id/sessions.
+//
+// Revoke every one of a user's sessions.
+func (c *Client) RevokeUserSessions(ctx context.Context, userID string, opts ...RequestOption) error {
+	return c.sendJSON(ctx, atc.RevokeUserSessions, rata.Params{"user_id": userID}, nil, nil, opts)
+}
+
+// ListDestroyingContainers calls GET /api/v1/containers/destroying.
+//
+// List the handles of a worker's containers to destroy.
+func (c *Client) ListDestroyingContainers(ctx context.Context, opts ...RequestOption) ([]string, error) {
+	var result []string
+	err := c.sendJSON(ctx, atc.ListDestroyingContainers, rata.Params{}, nil, &result, opts)
+	return result, err
+}
+
+// ReportWorkerContainers calls PUT /api/v1/containers/report.
+//
+// Report the handles of a worker's containers.
+func (c *Client) ReportWorkerContainers(ctx context.Context, body []string, opts ...RequestOption) error {
+	return c.sendJSON(ctx, atc.ReportWorkerContainers, rata.Params{}, jsonBody(body), nil, opts)
+}
+
+// ListContainers calls GET /api/v1/teams/:team_name/containers.
+//
+// List the containers of a team.
+func (c *Client) ListContainers(ctx context.Context, teamName string, opts ...RequestOption) ([]atc.Container, error) {
+	var result []atc.Container
+	err := c.sendJSON(ctx, atc.ListContainers, rata.Params{"team_name": teamName}, nil, &result, opts)
+	return result, err
+}
+
+// GetContainer calls GET /api/v1/teams/:team_name/containers/:id.
+//
+// Get a container.
+func (c *Client) GetContainer(ctx context.Context, teamName string, id string, opts ...RequestOption) (atc.Container, error) {
+	var result atc.Container
+	err := c.sendJSON(ctx, atc.GetContainer, rata.Params{"team_name": teamName, "id": id}, nil, &result, opts)
+	return result, err
+}
+
+// ListContainerProcesses calls GET /api/v1/teams/:team_name/containers/:id/processes.
+//
+// List the processes running in a container.
+func (c *Client) ListContainerProcesses(ctx context.Context, teamName string, id string, opts ...RequestOption) ([]atc.ContainerProcess, error) {
+	var result []atc.ContainerProcess
+	err := c.sendJSON(ctx, atc.ListContainerProcesses, rata.Params{"team_name": teamName, "id": id}, nil, &result, opts)
+	return result, err
+}
+
+// ListVolumes calls GET /api/v1/teams/:team_name/volumes.
+//
+// List the volumes of a team.
+func (c *Client) ListVolumes(ctx context.Context, teamName string, opts ...RequestOption) ([]atc.Volume, error) {
+	var result []atc.Volume
+	err := c.sendJSON(ctx, atc.ListVolumes, rata.Params{"team_name": teamName}, nil, &result, opts)
+	return result, err
+}
+
+// ListDestroyingVolumes calls GET /api/v1/volumes/destroying.
+//
+// List the handles of a worker's volumes to destroy.
+func (c *Client) ListDestroyingVolumes(ctx context.Context, opts ...RequestOption) ([]string, error) {
+	var result []string
+	err := c.sendJSON(ctx, atc.ListDestroyingVolumes, rata.Params{}, nil, &result, opts)
+	return result, err
+}
+
+// ReportWorkerVolumes calls PUT /api/v1/volumes/report.
+//
+// Report the handles of a worker's volumes.
+func (c *Client) ReportWorkerVolumes(ctx context.Context, body []string, opts ...RequestOption) error {
+	return c.sendJSON(ctx, atc.ReportWorkerVolumes, rata.Params{}, jsonBody(body), nil, opts)
+}
+
+// ListTeams calls GET /api/v1/teams.
+//
+// List the teams.
+func (c *Client) ListTeams(ctx context.Context, opts ...RequestOption) ([]atc.Team, error) {
+	var result []atc.Team
+	err := c.sendJSON(ctx, atc.ListTeams, rata.Params{}, nil, &result, opts)
+	return result, err
+}
+
+// GetTeam calls GET /api/v1/teams/:team_name.
+//
+// Get a team.
+func (c *Client) GetTeam(ctx context.Context, teamName string, opts ...RequestOption) (atc.Team, error) {
+	var result atc.Team
+	err := c.sendJSON(ctx, atc.GetTeam, rata.Params{"team_name": teamName}, nil, &result, opts)
+	return result, err
+}
+
+// SetTeam calls PUT /api/v1/teams/:team_name.
+//
+// Set the auth of a team, creating the team if it doesn't exist.
+func (c *Client) SetTeam(ctx context.Context, teamName string, body atc.Team, opts ...RequestOption) (atc.Team, error) {
+	var result atc.Team
+	err := c.sendJSON(ctx, atc.SetTeam, rata.Params{"team_name": teamName}, jsonBody(body), &result, opts)
+	return result, err
+}
+
+// RenameTeam calls PUT /api/v1/teams/:team_name/rename.
+//
+// Rename a team.
+func (c *Client) RenameTeam(ctx context.Context, teamName string, body atc.RenameRequest, opts ...RequestOption) (atc.SaveConfigResponse, error) {
+	var result atc.SaveConfigResponse
+	err := c.sendJSON(ctx, atc.RenameTeam, rata.Params{"team_name": teamName}, jsonBody(body), &result, opts)
+	return result, err
+}
+
+// DestroyTeam calls DELETE /api/v1/teams/:team_name.
+//
+// Destroy a team.
+func (c *Client) DestroyTeam(ctx context.Context, teamName string, opts ...RequestOption) error {
+	return c.sendJSON(ctx, atc.DestroyTeam, rata.Params{"team_name": teamName}, nil, nil, opts)
+}
+
+// ListTeamBuilds calls GET /api/v1/teams/:team_name/builds.
+//
+// List the builds of a team.
+func (c *Client) ListTeamBuilds(ctx context.Context, teamName string, opts ...RequestOption) ([]atc.Build, error) {
+	var result []atc.Build
+	err := c.sendJSON(ctx, atc.ListTeamBuilds, rata.Params{"team_name": teamName}, nil, &result, opts)
+	return result, err
+}
+
+// ListTeamUsers calls GET /api/v1/teams/:team_name/users.
+//
+// List the users who have logged in with a role in a team.
+func (c *Client) ListTeamUsers(ctx context.Context, teamName string, opts ...RequestOption) ([]atc.User, error) {
+	var result []atc.User
+	err := c.sendJSON(ctx, atc.ListTeamUsers, rata.Params{"team_name": teamName}, nil, &result, opts)
+	return result, err
+}
+
+// GetTeamWorkerKeys calls GET /api/v1/teams/:team_name/worker_keys.
+//
+// Get the keys a team's workers register with.
+func (c *Client) GetTeamWorkerKeys(ctx context.Context, teamName string, opts ...RequestOption) ([]string, error) {
+	var result []string
+	err := c.sendJSON(ctx, atc.GetTeamWorkerKeys, rata.Params{"team_name": teamName}, nil, &result, opts)
+	return result, err
+}
+
+// SetTeamWorkerKeys calls PUT /api/v1/teams/:team_name/worker_keys.
+//
+// Set the keys a team's workers register with.
+func (c *Client) SetTeamWorkerKeys(ctx context.Context, teamName string, body []string, opts ...RequestOption) error {
+	return c.sendJSON(ctx, atc.SetTeamWorkerKeys, rata.Params{"team_name": teamName}, jsonBody(body), nil, opts)
+}
+
+// GetTeamInterceptSettings calls GET /api/v1/teams/:team_name/intercept_settings.
+//
+// Get whether a team's members may intercept containers.
+func (c *Client) GetTeamInterceptSettings(ctx context.Context, teamName string, opts ...RequestOption) (atc.InterceptSettings, error) {
+	var result atc.InterceptSettings
+	err := c.sendJSON(ctx, atc.GetTeamInterceptSettings, rata.Params{"team_name": teamName}, nil, &result, opts)
+	return result, err
+}
+
+// SetTeamInterceptSettings calls PUT /api/v1/teams/:team_name/intercept_settings.
+//
+// Set whether a team's members may intercept containers.
+func (c *Client) SetTeamInterceptSettings(ctx context.Context, teamName string, body atc.InterceptSettings, opts ...RequestOption) error {
+	return c.sendJSON(ctx, atc.SetTeamInterceptSettings, rata.Params{"team_name": teamName}, jsonBody(body), nil, opts)
+}
+
+// GetTeamPrivilegedSettings calls GET /api/v1/teams/:team_name/privileged_settings.
+//
+// Get whether a team's tasks and resource types may be privileged.
+func (c *Client) GetTeamPrivilegedSettings(ctx context.Context, teamName string, opts ...RequestOption) (atc.PrivilegedSettings, error) {
+	var result atc.PrivilegedSettings
+	err := c.sendJSON(ctx, atc.GetTeamPrivilegedSettings, rata.Params{"team_name": teamName}, nil, &result, opts)
+	return result, err
+}
+
+// SetTeamPrivilegedSettings calls PUT /api/v1/teams/:team_name/privileged_settings.
+//
+// Set whether a team's tasks and resource types may be privileged.
+func (c *Client) SetTeamPrivilegedSettings(ctx context.Context, teamName string, body atc.PrivilegedSettings, opts ...RequestOption) error {
+	return c.sendJSON(ctx, atc.SetTeamPrivilegedSettings, rata.Params{"team_name": teamName}, jsonBody(body), nil, opts)
+}
+
+// GetTeamProxySettings calls GET /api/v1/teams/:team_name/proxy_settings.
+//
+// Get the proxies through which a team's containers egress.
+func (c *Client) GetTeamProxySettings(ctx context.Context, teamName string, opts ...RequestOption) (atc.ProxySettings, error) {
+	var result atc.ProxySettings
+	err := c.sendJSON(ctx, atc.GetTeamProxySettings, rata.Params{"team_name": teamName}, nil, &result, opts)
+	return result, err
+}
+
+// SetTeamProxySettings calls PUT /api/v1/teams/:team_name/proxy_settings.
+//
+// Set the proxies through which a team's containers egress.
+func (c *Client) SetTeamProxySettings(ctx context.Context, teamName string, body atc.ProxySettings, opts ...RequestOption) error {
+	return c.sendJSON(ctx, atc.SetTeamProxySettings, rata.Params{"team_name": teamName}, jsonBody(body), nil, opts)
+}
+
+// ListNotifiers calls GET /api/v1/teams/:team_name/notifiers.
+//
+// List the notifiers of a team.
+func (c *Client) ListNotifiers(ctx context.Context, teamName string, opts ...RequestOption) ([]atc.Notifier, error) {
+	var result []atc.Notifier
+	err := c.sendJSON(ctx, atc.ListNotifiers, rata.Params{"team_name": teamName}, nil, &result, opts)
+	return result, err
+}
+
+// SetNotifier calls PUT /api/v1/teams/:team_name/notifiers/:notifier_name.
+//
+// Set a notifier of a team.
+func (c *Client) SetNotifier(ctx context.Context, teamName string, notifierName string, body atc.NotifierConfig, opts ...RequestOption) (atc.SaveConfigResponse, error) {
+	var result atc.SaveConfigResponse
+	err := c.sendJSON(ctx, atc.SetNotifier, rata.Params{"team_name": teamName, "notifier_name": notifierName}, jsonBody(body), &result, opts)
+	return result, err
+}
+
+// DestroyNotifier calls DELETE /api/v1/teams/:team_name/notifiers/:notifier_name.
+//
+// Destroy a notifier of a team.
+func (c *Client) DestroyNotifier(ctx context.Context, teamName string, notifierName string, opts ...RequestOption) error {
+	return c.sendJSON(ctx, atc.DestroyNotifier, rata.Params{"team_name": teamName, "notifier_name": notifierName}, nil, nil, opts)
+}
+
+// ListTeamWebhooks calls GET /api/v1/teams/:team_name/webhooks.
+//
+// List the outgoing webhooks of a team.
+func (c *Client) ListTeamWebhooks(ctx context.Context, teamName string, opts ...RequestOption) ([]atc.OutgoingWebhook, error) {
+	var result []atc.OutgoingWebhook
+	err := c.sendJSON(ctx, atc.ListTeamWebhooks, rata.Params{"team_name": teamName}, nil, &result, opts)
+	return result, err
+}
+
+// SetTeamWebhook calls PUT /api/v1/teams/:team_name/webhooks/:webhook_name.
+//
+// Set an outgoing webhook of a team.
+func (c *Client) SetTeamWebhook(ctx context.Context, teamName string, webhookName string, body atc.OutgoingWebhook, opts ...RequestOption) (atc.SaveConfigResponse, error) {
+	var result atc.SaveConfigResponse
+	err := c.sendJSON(ctx, atc.SetTeamWebhook, rata.Params{"team_name": teamName, "webhook_name": webhookName}, jsonBody(body), &result, opts)
+	return result, err
+}
+
+// DestroyTeamWebhook calls DELETE /api/v1/teams/:team_name/webhooks/:webhook_name.
+//
+// Destroy an outgoing webhook of a team.
+func (c *Client) DestroyTeamWebhook(ctx context.Context, teamName string, webhookName string, opts ...RequestOption) error {
+	return c.sendJSON(ctx, atc.DestroyTeamWebhook, rata.Params{"team_name": teamName, "webhook_name": webhookName}, nil, nil, opts)
+}
+
+// ListTeamWebhookDeliveries calls GET /api/v1/teams/:team_name/webhooks/:webhook_name/deliveries.
+//
+// List the latest deliveries of an outgoing webhook of a team.
+func (c *Client) ListTeamWebhookDeliveries(ctx context.Context, teamName string, webhookName string, opts ...RequestOption) ([]atc.WebhookDelivery, error) {
+	var result []atc.WebhookDelivery
+	err := c.sendJSON(ctx, atc.ListTeamWebhookDeliveries, rata.Params{"team_name": teamName, "webhook_name": webhookName}, nil, &result, opts)
+	return result, err
+}
+
+// ListClusterWebhooks calls GET /api/v1/webhooks.
+//
+// List the outgoing webhooks of the cluster.
+func (c *Client) ListClusterWebhooks(ctx context.Context, opts ...RequestOption) ([]atc.OutgoingWebhook, error) {
+	var result []atc.OutgoingWebhook
+	err := c.sendJSON(ctx, atc.ListClusterWebhooks, rata.Params{}, nil, &result, opts)
+	return result, err
+}
+
+// SetClusterWebhook calls PUT /api/v1/webhooks/:webhook_name.
+//
+// Set an outgoing webhook of the cluster.
+func (c *Client) SetClusterWebhook(ctx context.Context, webhookName string, body atc.OutgoingWebhook, opts ...RequestOption) (atc.SaveConfigResponse, error) {
+	var result atc.SaveConfigResponse
+	err := c.sendJSON(ctx, atc.SetClusterWebhook, rata.Params{"webhook_name": webhookName}, jsonBody(body), &result, opts)
+	return result, err
+}
+
+// DestroyClusterWebhook calls DELETE /api/v1/webhooks/:webhook_name.
+//
+// Destroy an outgoing webhook of the cluster.
+func (c *Client) DestroyClusterWebhook(ctx context.Context, webhookName string, opts ...RequestOption) error {
+	return c.sendJSON(ctx, atc.DestroyClusterWebhook, rata.Params{"webhook_name": webhookName}, nil, nil, opts)
+}
+
+// ListClusterWebhookDeliveries calls GET /api/v1/webhooks/:webhook_name/deliveries.
+//
+// List the latest deliveries of an outgoing webhook of the cluster.
+func (c *Client) ListClusterWebhookDeliveries(ctx context.Context, webhookName string, opts ...RequestOption) ([]atc.WebhookDelivery, error) {
+	var result []atc.WebhookDelivery
+	err := c.sendJSON(ctx, atc.ListClusterWebhookDeliveries, rata.Params{"webhook_name": webhookName}, nil, &result, opts)
+	return result, err
+}
+
+// ListTeamLogDrains calls GET /api/v1/teams/:team_name/log_drains.
+//
+// List the log drains of a team.
+func (c *Client) ListTeamLogDrains(ctx context.Context, teamName string, opts ...RequestOption) ([]atc.LogDrain, error) {
+	var result []atc.LogDrain
+	err := c.sendJSON(ctx, atc.ListTeamLogDrains, rata.Params{"team_name": teamName}, nil, &result, opts)
+	return result, err
+}
+
+// SetTeamLogDrain calls PUT /api/v1/teams/:team_name/log_drains/:log_drain_name.
+//
+// Set a log drain of a team.
+func (c *Client) SetTeamLogDrain(ctx context.Context, teamName string, logDrainName string, body atc.LogDrain, opts ...RequestOption) (atc.SaveConfigResponse, error) {
+	var result atc.SaveConfigResponse
+	err := c.sendJSON(ctx, atc.SetTeamLogDrain, rata.Params{"team_name": teamName, "log_drain_name": logDrainName}, jsonBody(body), &result, opts)
+	return result, err
+}
+
+// DestroyTeamLogDrain calls DELETE /api/v1/teams/:team_name/log_drains/:log_drain_name.
+//
+// Destroy a log drain of a team.
+func (c *Client) DestroyTeamLogDrain(ctx context.Context, teamName string, logDrainName string, opts ...RequestOption) error {
+	return c.sendJSON(ctx, atc.DestroyTeamLogDrain, rata.Params{"team_name": teamName, "log_drain_name": logDrainName}, nil, nil, opts)
+}
+
+// ListTeamFreezeWindows calls GET /api/v1/teams/:team_name/freeze_windows.
+//
+// List the freeze windows of a team.
+func (c *Client) ListTeamFreezeWindows(ctx context.Context, teamName string, opts ...RequestOption) ([]atc.FreezeWindow, error) {
+	var result []atc.FreezeWindow
+	err := c.sendJSON(ctx, atc.ListTeamFreezeWindows, rata.Params{"team_name": teamName}, nil, &result, opts)
+	return result, err
+}
+
+// CreateTeamFreezeWindow calls POST /api/v1/teams/:team_name/freeze_windows.
+//
+// Create a freeze window for a team.
+func (c *Client) CreateTeamFreezeWindow(ctx context.Context, teamName string, body atc.FreezeWindow, opts ...RequestOption) (atc.FreezeWindow, error) {
+	var result atc.FreezeWindow
+	err := c.sendJSON(ctx, atc.CreateTeamFreezeWindow, rata.Params{"team_name": teamName}, jsonBody(body), &result, opts)
+	return result, err
+}
+
+// DestroyTeamFreezeWindow calls DELETE /api/v1/teams/:team_name/freeze_windows/:freeze_window_id.
+//
+// Destroy a freeze window of a team.
+func (c *Client) DestroyTeamFreezeWindow(ctx context.Context, teamName string, freezeWindowID string, opts ...RequestOption) error {
+	return c.sendJSON(ctx, atc.DestroyTeamFreezeWindow, rata.Params{"team_name": teamName, "freeze_window_id": freezeWindowID}, nil, nil, opts)
+}
+
+// ListClusterFreezeWindows calls GET /api/v1/freeze_windows.
+//
+// List the freeze windows of the cluster.
+func (c *Client) ListClusterFreezeWindows(ctx context.Context, opts ...RequestOption) ([]atc.FreezeWindow, error) {
+	var result []atc.FreezeWindow
+	err := c.sendJSON(ctx, atc.ListClusterFreezeWindows, rata.Params{}, nil, &result, opts)
+	return result, err
+}
+
+// CreateClusterFreezeWindow calls POST /api/v1/freeze_windows.
+//
+// Create a freeze window for the cluster.
+func (c *Client) CreateClusterFreezeWindow(ctx context.Context, body atc.FreezeWindow, opts ...RequestOption) (atc.FreezeWindow, error) {
+	var result atc.FreezeWindow
+	err := c.sendJSON(ctx, atc.CreateClusterFreezeWindow, rata.Params{}, jsonBody(body), &result, opts)
+	return result, err
+}
+
+// DestroyClusterFreezeWindow calls DELETE /api/v1/freeze_windows/:freeze_window_id.
+//
+// Destroy a freeze window of the cluster.
+func (c *Client) DestroyClusterFreezeWindow(ctx context.Context, freezeWindowID string, opts ...RequestOption) error {
+	return c.sendJSON(ctx, atc.DestroyClusterFreezeWindow, rata.Params{"freeze_window_id": freezeWindowID}, nil, nil, opts)
+}
+
+// GetTeamInjectedEnv calls GET /api/v1/teams/:team_name/injected_env.
+//
+// Get the env injected into every task of a team.
+func (c *Client) GetTeamInjectedEnv(ctx context.Context, teamName string, opts ...RequestOption) (atc.TaskEnv, error) {
+	var result atc.TaskEnv
+	err := c.sendJSON(ctx, atc.GetTeamInjectedEnv, rata.Params{"team_name": teamName}, nil, &result, opts)
+	return result, err
+}
+
+// SetTeamInjectedEnv calls PUT /api/v1/teams/:team_name/injected_env.
+//
+// Set the env injected into every task of a team.
+func (c *Client) SetTeamInjectedEnv(ctx context.Context, teamName string, body atc.TaskEnv, opts ...RequestOption) error {
+	return c.sendJSON(ctx, atc.SetTeamInjectedEnv, rata.Params{"team_name": teamName}, jsonBody(body), nil, opts)
+}
+
+// GetClusterInjectedEnv calls GET /api/v1/injected_env.
+//
+// Get the env injected into every task of every team.
+func (c *Client) GetClusterInjectedEnv(ctx context.Context, opts ...RequestOption) (atc.TaskEnv, error) {
+	var result atc.TaskEnv
+	err := c.sendJSON(ctx, atc.GetClusterInjectedEnv, rata.Params{}, nil, &result, opts)
+	return result, err
+}
+
+// SetClusterInjectedEnv calls PUT /api/v1/injected_env.
+//
+// Set the env injected into every task of every team.
+func (c *Client) SetClusterInjectedEnv(ctx context.Context, body atc.TaskEnv, opts ...RequestOption) error {
+	return c.sendJSON(ctx, atc.SetClusterInjectedEnv, rata.Params{}, jsonBody(body), nil, opts)
+}
+
+// GetTeamCABundle calls GET /api/v1/teams/:team_name/ca_bundle.
+//
+// Get the CA certificates trusted by every container of a team.
+func (c *Client) GetTeamCABundle(ctx context.Context, teamName string, opts ...RequestOption) (atc.CABundle, error) {
+	var result atc.CABundle
+	err := c.sendJSON(ctx, atc.GetTeamCABundle, rata.Params{"team_name": teamName}, nil, &result, opts)
+	return result, err
+}
+
+// SetTeamCABundle calls PUT /api/v1/teams/:team_name/ca_bundle.
+//
+// Set the CA certificates trusted by every container of a team.
+func (c *Client) SetTeamCABundle(ctx context.Context, teamName string, body atc.CABundle, opts ...RequestOption) error {
+	return c.sendJSON(ctx, atc.SetTeamCABundle, rata.Params{"team_name": teamName}, jsonBody(body), nil, opts)
+}
+
+// GetClusterCABundle calls GET /api/v1/ca_bundle.
+//
+// Get the CA certificates trusted by every container of every team.
+func (c *Client) GetClusterCABundle(ctx context.Context, opts ...RequestOption) (atc.CABundle, error) {
+	var result atc.CABundle
+	err := c.sendJSON(ctx, atc.GetClusterCABundle, rata.Params{}, nil, &result, opts)
+	return result, err
+}
+
+// SetClusterCABundle calls PUT /api/v1/ca_bundle.
+//
+// Set the CA certificates trusted by every container of every team.
+func (c *Client) SetClusterCABundle(ctx context.Context, body atc.CABundle, opts ...RequestOption) error {
+	return c.sendJSON(ctx, atc.SetClusterCABundle, rata.Params{}, jsonBody(body), nil, opts)
+}
+
+// ListTeamRequests calls GET /api/v1/team_requests.
+//
+// List the requests for teams to be created.
+func (c *Client) ListTeamRequests(ctx context.Context, opts ...RequestOption) ([]atc.TeamRequest, error) {
+	var result []atc.TeamRequest
+	err := c.sendJSON(ctx, atc.ListTeamRequests, rata.Params{}, nil, &result, opts)
+	return result, err
+}
+
+// CreateTeamRequest calls POST /api/v1/team_requests.
+//
+// Request a team to be created.
+func (c *Client) CreateTeamRequest(ctx context.Context, body atc.TeamRequest, opts ...RequestOption) (atc.TeamRequest, error) {
+	var result atc.TeamRequest
+	err := c.sendJSON(ctx, atc.CreateTeamRequest, rata.Params{}, jsonBody(body), &result, opts)
+	return result, err
+}
+
+// ApproveTeamRequest calls PUT /api/v1/team_requests/:team_request_id/approve.
+//
+// Approve a team request, creating the team.
+func (c *Client) ApproveTeamRequest(ctx context.Context, teamRequestID string, body atc.TeamRequestDecision, opts ...RequestOption) (atc.TeamRequest, error) {
+	var result atc.TeamRequest
+	err := c.sendJSON(ctx, atc.ApproveTeamRequest, rata.Params{"team_request_id": teamRequestID}, jsonBody(body), &result, opts)
+	return result, err
+}
+
+// RejectTeamRequest calls PUT /api/v1/team_requests/:team_request_id/reject.
+//
+// Reject a team request.
+func (c *Client) RejectTeamRequest(ctx context.Context, teamRequestID string, body atc.TeamRequestDecision, opts ...RequestOption) (atc.TeamRequest, error) {
+	var result atc.TeamRequest
+	err := c.sendJSON(ctx, atc.RejectTeamRequest, rata.Params{"team_request_id": teamRequestID}, jsonBody(body), &result, opts)
+	return result, err
+}
+
+// GetSchedulerProfile calls GET /api/v1/scheduler/profile.
+//
+// Get how long the last scheduling of each pipeline took.
+func (c *Client) GetSchedulerProfile(ctx context.Context, opts ...RequestOption) ([]atc.PipelineSchedulingStats, error) {
+	var result []atc.PipelineSchedulingStats
+	err := c.sendJSON(ctx, atc.GetSchedulerProfile, rata.Params{}, nil, &result, opts)
+	return result, err
+}
+
+// GetDBSchema calls GET /api/v1/db/schema.
+//
+// Describe the schema of the database.
+func (c *Client) GetDBSchema(ctx context.Context, opts ...RequestOption) (atc.DBSchema, error) {
+	var result atc.DBSchema
+	err := c.sendJSON(ctx, atc.GetDBSchema, rata.Params{}, nil, &result, opts)
+	return result, err
+}
+
+// GetDBMaintenance calls GET /api/v1/db/maintenance.
+//
+// Describe the bloat of the busiest tables and how to maintain them.
+func (c *Client) GetDBMaintenance(ctx context.Context, opts ...RequestOption) (atc.DBMaintenance, error) {
+	var result atc.DBMaintenance
+	err := c.sendJSON(ctx, atc.GetDBMaintenance, rata.Params{}, nil, &result, opts)
+	return result, err
+}
+
+// GetDBGrowth calls GET /api/v1/db/growth.
+//
+// List snapshots of the growth of the database, of a team or of the whole cluster.
+func (c *Client) GetDBGrowth(ctx context.Context, opts ...RequestOption) ([]atc.DBGrowthSnapshot, error) {
+	var result []atc.DBGrowthSnapshot
+	err := c.sendJSON(ctx, atc.GetDBGrowth, rata.Params{}, nil, &result, opts)
+	return result, err
+}
+
+// GetAutoscalingSignal calls GET /api/v1/autoscaling.
+//
+// Get the demand for workers from the builds waiting to start.
+func (c *Client) GetAutoscalingSignal(ctx context.Context, opts ...RequestOption) (atc.AutoscalingSignal, error) {
+	var result atc.AutoscalingSignal
+	err := c.sendJSON(ctx, atc.GetAutoscalingSignal, rata.Params{}, nil, &result, opts)
+	return result, err
+}
+
+// GetDeprecationReport calls GET /api/v1/deprecations.
+//
+// List the deprecated parts of the API and the clients still using them.
+func (c *Client) GetDeprecationReport(ctx context.Context, opts ...RequestOption) (atc.DeprecationReport, error) {
+	var result atc.DeprecationReport
+	err := c.sendJSON(ctx, atc.GetDeprecationReport, rata.Params{}, nil, &result, opts)
+	return result, err
+}
+
+// GetClusterOverview calls GET /api/v1/cluster/overview.
+//
+// Describe what the workers, teams and busiest pipelines are using.
+func (c *Client) GetClusterOverview(ctx context.Context, opts ...RequestOption) (atc.ClusterOverview, error) {
+	var result atc.ClusterOverview
+	err := c.sendJSON(ctx, atc.GetClusterOverview, rata.Params{}, nil, &result, opts)
+	return result, err
+}
+
+// ListComponents calls GET /api/v1/components.
+//
+// List the components and when they last ran.
+func (c *Client) ListComponents(ctx context.Context, opts ...RequestOption) ([]atc.ComponentStatus, error) {
+	var result []atc.ComponentStatus
+	err := c.sendJSON(ctx, atc.ListComponents, rata.Params{}, nil, &result, opts)
+	return result, err
+}
+
+// SetComponentInterval calls PUT /api/v1/components/:component_name/interval.
+//
+// Set how often a component runs.
+func (c *Client) SetComponentInterval(ctx context.Context, componentName string, body atc.SetComponentIntervalRequest, opts ...RequestOption) error {
+	return c.sendJSON(ctx, atc.SetComponentInterval, rata.Params{"component_name": componentName}, jsonBody(body), nil, opts)
+}
+
+// ResetComponentInterval calls DELETE /api/v1/components/:component_name/interval.
+//
+// Reset how often a component runs to its default.
+func (c *Client) ResetComponentInterval(ctx context.Context, componentName string, opts ...RequestOption) error {
+	return c.sendJSON(ctx, atc.ResetComponentInterval, rata.Params{"component_name": componentName}, nil, nil, opts)
+}
+
+// PauseComponent calls PUT /api/v1/components/:component_name/pause.
+//
+// Pause a component.
+func (c *Client) PauseComponent(ctx context.Context, componentName string, opts ...RequestOption) error {
+	return c.sendJSON(ctx, atc.PauseComponent, rata.Params{"component_name": componentName}, nil, nil, opts)
+}
+
+// UnpauseComponent calls PUT /api/v1/components/:component_name/unpause.
+//
+// Unpause a component.
+func (c *Client) UnpauseComponent(ctx context.Context, componentName string, opts ...RequestOption) error {
+	return c.sendJSON(ctx, atc.UnpauseComponent, rata.Params{"component_name": componentName}, nil, nil, opts)
+}
+
+// CreateArtifact calls POST /api/v1/teams/:team_name/artifacts.
+//
+// Upload an artifact as a gzipped tarball.
+func (c *Client) CreateArtifact(ctx context.Context, teamName string, body io.Reader, opts ...RequestOption) (atc.WorkerArtifact, error) {
+	var result atc.WorkerArtifact
+	err := c.sendJSON(ctx, atc.CreateArtifact, rata.Params{"team_name": teamName}, rawBody(body, "application/octet-stream"), &result, opts)
+	return result, err
+}
+
+// GetArtifact calls GET /api/v1/teams/:team_name/artifacts/:artifact_id.
+//
+// Download an artifact as a gzipped tarball.
+func (c *Client) GetArtifact(ctx context.Context, teamName string, artifactID string, opts ...RequestOption) (io.ReadCloser, error) {
+	return c.sendStream(ctx, atc.GetArtifact, rata.Params{"team_name": teamName, "artifact_id": artifactID}, nil, opts)
+}
+
+// GetWall calls GET /api/v1/wall.
+//
+// Get the message on the wall.
+func (c *Client) GetWall(ctx context.Context, opts ...RequestOption) (atc.Wall, error) {
+	var result atc.Wall
+	err := c.sendJSON(ctx, atc.GetWall, rata.Params{}, nil, &result, opts)
+	return result, err
+}
+
+// SetWall calls PUT /api/v1/wall.
+//
+// Set the message on the wall.
+func (c *Client) SetWall(ctx context.Context, body atc.Wall, opts ...RequestOption) error {
+	return c.sendJSON(ctx, atc.SetWall, rata.Params{}, jsonBody(body), nil, opts)
+}
+
+// ClearWall calls DELETE /api/v1/wall.
+//
+// Clear the message on the wall.
+func (c *Client) ClearWall(ctx context.Context, opts ...RequestOption) error {
+	return c.sendJSON(ctx, atc.ClearWall, rata.Params{}, nil, nil, opts)
+}
@@ -579,6 +579,20 @@ type FakeTeam struct {
 		result1 []atc.Volume
 		result2 error
 	}
+	MovePipelineStub        func(atc.PipelineRef, string) (bool, error)
+	movePipelineMutex       sync.RWMutex
+	movePipelineArgsForCall []struct {
+		arg1 atc.PipelineRef
+		arg2 string
+	}
+	movePipelineReturns struct {
+		result1 bool
+		result2 error
+	}
+	movePipelineReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	NameStub        func() string
 	nameMutex       sync.RWMutex
 	nameArgsForCall []struct {
@@ -3439,6 +3453,71 @@ func (fake *FakeTeam) ListVolumesReturnsOnCall(i int, result1 []atc.Volume, resu
 	}{result1, result2}
 }
 
+func (fake *FakeTeam) MovePipeline(arg1 atc.PipelineRef, arg2 string) (bool, error) {
+	fake.movePipelineMutex.Lock()
+	ret, specificReturn := fake.movePipelineReturnsOnCall[len(fake.movePipelineArgsForCall)]
+	fake.movePipelineArgsForCall = append(fake.movePipelineArgsForCall, struct {
+		arg1 atc.PipelineRef
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.MovePipelineStub
+	fakeReturns := fake.movePipelineReturns
+	fake.recordInvocation("MovePipeline", []interface{}{arg1, arg2})
+	fake.movePipelineMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) MovePipelineCallCount() int {
+	fake.movePipelineMutex.RLock()
+	defer fake.movePipelineMutex.RUnlock()
+	return len(fake.movePipelineArgsForCall)
+}
+
+func (fake *FakeTeam) MovePipelineCalls(stub func(atc.PipelineRef, string) (bool, error)) {
+	fake.movePipelineMutex.Lock()
+	defer fake.movePipelineMutex.Unlock()
+	fake.MovePipelineStub = stub
+}
+
+func (fake *FakeTeam) MovePipelineArgsForCall(i int) (atc.PipelineRef, string) {
+	fake.movePipelineMutex.RLock()
+	defer fake.movePipelineMutex.RUnlock()
+	argsForCall := fake.movePipelineArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeTeam) MovePipelineReturns(result1 bool, result2 error) {
+	fake.movePipelineMutex.Lock()
+	defer fake.movePipelineMutex.Unlock()
+	fake.MovePipelineStub = nil
+	fake.movePipelineReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) MovePipelineReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.movePipelineMutex.Lock()
+	defer fake.movePipelineMutex.Unlock()
+	fake.MovePipelineStub = nil
+	if fake.movePipelineReturnsOnCall == nil {
+		fake.movePipelineReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.movePipelineReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) Name() string {
 	fake.nameMutex.Lock()
 	ret, specificReturn := fake.nameReturnsOnCall[len(fake.nameArgsForCall)]
@@ -4911,6 +4990,8 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.listSharedForResourceTypeMutex.RUnlock()
 	fake.listVolumesMutex.RLock()
 	defer fake.listVolumesMutex.RUnlock()
+	fake.movePipelineMutex.RLock()
+	defer fake.movePipelineMutex.RUnlock()
 	fake.nameMutex.RLock()
 	defer fake.nameMutex.RUnlock()
 	fake.orderingPipelinesMutex.RLock()
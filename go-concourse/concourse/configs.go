@@ -47,6 +47,7 @@ func (team *team) PipelineConfig(pipelineRef atc.PipelineRef) (atc.Config, strin
 type ConfigWarning struct {
 	Type    string `json:"type"`
 	Message string `json:"message"`
+	Pointer string `json:"pointer,omitempty"`
 }
 
 type setConfigResponse struct {
@@ -253,6 +253,35 @@ func (team *team) RenamePipeline(oldName string, newName string) (bool, []Config
 	}
 }
 
+func (team *team) MovePipeline(pipelineRef atc.PipelineRef, teamName string) (bool, error) {
+	params := rata.Params{
+		"pipeline_name": pipelineRef.Name,
+		"team_name":     team.Name(),
+	}
+
+	jsonBytes, err := json.Marshal(atc.MovePipelineRequest{Team: teamName})
+	if err != nil {
+		return false, err
+	}
+
+	err = team.connection.Send(internal.Request{
+		RequestName: atc.MovePipeline,
+		Params:      params,
+		Query:       pipelineRef.QueryParams(),
+		Body:        bytes.NewBuffer(jsonBytes),
+		Header:      http.Header{"Content-Type": []string{"application/json"}},
+	}, nil)
+
+	switch err.(type) {
+	case nil:
+		return true, nil
+	case internal.ResourceNotFoundError:
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
 func (team *team) PipelineBuilds(pipelineRef atc.PipelineRef, page Page) ([]atc.Build, Pagination, bool, error) {
 	params := rata.Params{
 		"pipeline_name": pipelineRef.Name,
@@ -94,6 +94,63 @@ var _ = Describe("ATC Handler Pipelines", func() {
 		})
 	})
 
+	Describe("MovePipeline", func() {
+		expectedURL := "/api/v1/teams/some-team/pipelines/mypipeline/move"
+		queryParams := "vars.branch=%22master%22"
+		pipelineRef := atc.PipelineRef{Name: "mypipeline", InstanceVars: atc.InstanceVars{"branch": "master"}}
+
+		Context("when the pipeline exists", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", expectedURL, queryParams),
+						ghttp.VerifyJSON(`{"team":"other-team"}`),
+						ghttp.RespondWith(http.StatusOK, ""),
+					),
+				)
+			})
+
+			It("moves the pipeline to the team", func() {
+				found, err := team.MovePipeline(pipelineRef, "other-team")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+		})
+
+		Context("when the pipeline or team doesn't exist", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", expectedURL, queryParams),
+						ghttp.RespondWith(http.StatusNotFound, ""),
+					),
+				)
+			})
+
+			It("returns false and no error", func() {
+				found, err := team.MovePipeline(pipelineRef, "other-team")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+
+		Context("when the team has a pipeline with the same name", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", expectedURL, queryParams),
+						ghttp.RespondWith(http.StatusConflict, "team already has a pipeline with the same name and instance vars"),
+					),
+				)
+			})
+
+			It("returns an error", func() {
+				_, err := team.MovePipeline(pipelineRef, "other-team")
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
 	Describe("UnpausePipeline", func() {
 
 		expectedURL := "/api/v1/teams/some-team/pipelines/mypipeline/unpause"
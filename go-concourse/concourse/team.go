@@ -27,6 +27,7 @@ type Team interface {
 	ExposePipeline(pipelineRef atc.PipelineRef) (bool, error)
 	HidePipeline(pipelineRef atc.PipelineRef) (bool, error)
 	RenamePipeline(oldName, newName string) (bool, []ConfigWarning, error)
+	MovePipeline(pipelineRef atc.PipelineRef, teamName string) (bool, error)
 	ListPipelines() ([]atc.Pipeline, error)
 	PipelineConfig(pipelineRef atc.PipelineRef) (atc.Config, string, bool, error)
 	CreateOrUpdatePipelineConfig(pipelineRef atc.PipelineRef, configVersion string, passedConfig []byte, checkCredentials bool) (bool, bool, []ConfigWarning, error)
@@ -0,0 +1,281 @@
+package lockout
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/patrickmn/go-cache"
+	"golang.org/x/time/rate"
+)
+
+// FailureWindow is how long a user's failed logins are remembered after the
+// last one.
+const FailureWindow = 24 * time.Hour
+
+const (
+	passwordGrantPath = "/sky/issuer/token"
+	passwordLoginPath = "/sky/issuer/auth/local/login"
+)
+
+type Config struct {
+	// MaxFailures is how many logins in a row may fail before the user is
+	// locked out. Zero disables lockout.
+	MaxFailures int
+
+	// Duration is how long the user is locked out for at first. It doubles
+	// with every further failure, up to MaxDuration.
+	Duration    time.Duration
+	MaxDuration time.Duration
+
+	// RateLimit is how many logins each client may attempt per minute. Zero
+	// disables rate limiting.
+	RateLimit int
+
+	// ClientIPHeader is a header, such as X-Forwarded-For, which a trusted
+	// reverse proxy sets to the client's address. When it is set, logins are
+	// rate limited per client address. Otherwise they are rate limited per
+	// username, since behind a proxy every client shares the proxy's address.
+	ClientIPHeader string
+}
+
+// ProtectLocalLogins rate limits logins of local users, whether through the
+// login form or 'fly login -u ... -p ...', and locks users out after too
+// many failures. A locked out user's password is not checked at all. Every
+// attempt is logged as an audit event.
+func ProtectLocalLogins(
+	logger lager.Logger,
+	handler http.Handler,
+	repository db.LoginLockoutRepository,
+	config Config,
+) http.Handler {
+	limiter := newLoginLimiter(config.RateLimit)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		username, isLogin := localLogin(r)
+		if !isLogin {
+			handler.ServeHTTP(w, r)
+			return
+		}
+
+		logger := logger.Session("local-login", lager.Data{
+			"username":    username,
+			"remote_addr": r.RemoteAddr,
+		})
+
+		if !limiter.allow(config.rateLimitKey(r, username)) {
+			logger.Info("audit-login-rate-limited")
+			tooManyAttempts(w, time.Minute/time.Duration(config.RateLimit))
+			return
+		}
+
+		if config.MaxFailures > 0 {
+			lockedFor, err := repository.LoginLockedFor(username)
+			if err != nil {
+				logger.Error("failed-to-check-lockout", err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			if lockedFor > 0 {
+				logger.Info("audit-login-locked-out", lager.Data{"locked_for": lockedFor.String()})
+				tooManyAttempts(w, lockedFor)
+				return
+			}
+		}
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, r)
+
+		succeeded, failed := outcome(r, rec.Code)
+		switch {
+		case succeeded:
+			logger.Info("audit-login-succeeded")
+
+			if config.MaxFailures > 0 {
+				err := repository.ClearLoginFailures(username)
+				if err != nil {
+					logger.Error("failed-to-clear-login-failures", err)
+				}
+			}
+
+		case failed:
+			recordFailure(logger, repository, config, username)
+		}
+
+		for k, v := range rec.Header() {
+			w.Header()[k] = v
+		}
+
+		w.WriteHeader(rec.Code)
+		io.Copy(w, rec.Body)
+	})
+}
+
+func recordFailure(logger lager.Logger, repository db.LoginLockoutRepository, config Config, username string) {
+	if config.MaxFailures == 0 {
+		logger.Info("audit-login-failed")
+		return
+	}
+
+	failures, err := repository.RecordLoginFailure(username, FailureWindow)
+	if err != nil {
+		logger.Error("failed-to-record-login-failure", err)
+		return
+	}
+
+	logger.Info("audit-login-failed", lager.Data{"failures": failures})
+
+	if failures < config.MaxFailures {
+		return
+	}
+
+	duration := config.lockoutDuration(failures)
+
+	err = repository.LockLogin(username, duration)
+	if err != nil {
+		logger.Error("failed-to-lock-login", err)
+		return
+	}
+
+	logger.Info("audit-login-locked", lager.Data{"locked_for": duration.String()})
+}
+
+func (config Config) lockoutDuration(failures int) time.Duration {
+	duration := config.Duration
+	for i := config.MaxFailures; i < failures && duration < config.MaxDuration; i++ {
+		duration *= 2
+	}
+
+	if duration > config.MaxDuration {
+		return config.MaxDuration
+	}
+
+	return duration
+}
+
+// localLogin returns the username a request is trying to log in as with a
+// local user's password.
+func localLogin(r *http.Request) (string, bool) {
+	if r.Method != http.MethodPost {
+		return "", false
+	}
+
+	var username string
+	switch r.URL.Path {
+	case passwordGrantPath:
+		if r.PostFormValue("grant_type") != "password" {
+			return "", false
+		}
+
+		username = r.PostFormValue("username")
+	case passwordLoginPath:
+		username = r.PostFormValue("login")
+	default:
+		return "", false
+	}
+
+	if username == "" {
+		return "", false
+	}
+
+	return strings.ToLower(username), true
+}
+
+// outcome works out whether a login succeeded or failed from dex's response.
+// Other responses, such as for a malformed request, are neither.
+func outcome(r *http.Request, status int) (bool, bool) {
+	if r.URL.Path == passwordGrantPath {
+		return status == http.StatusOK, status == http.StatusUnauthorized
+	}
+
+	// the login form redirects once the user is logged in, and is shown
+	// again if they are not
+	return status == http.StatusSeeOther, status == http.StatusOK
+}
+
+func tooManyAttempts(w http.ResponseWriter, retryAfter time.Duration) {
+	w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+	w.WriteHeader(http.StatusTooManyRequests)
+	fmt.Fprintf(w, "too many login attempts; try again in %s", retryAfter.Round(time.Second))
+}
+
+// rateLimitKey identifies who a login is rate limited as: the client address
+// when a trusted proxy reports it, and the username otherwise.
+func (config Config) rateLimitKey(r *http.Request, username string) string {
+	if config.ClientIPHeader == "" {
+		return "user:" + username
+	}
+
+	return "addr:" + clientAddr(r, config.ClientIPHeader)
+}
+
+// clientAddr returns the client address the trusted proxy appended to the
+// header last. Earlier entries come from the client and can't be trusted.
+// Requests which reached us without going through the proxy are identified
+// by their remote address.
+func clientAddr(r *http.Request, header string) string {
+	values := r.Header.Values(header)
+	if len(values) > 0 {
+		addrs := strings.Split(values[len(values)-1], ",")
+		if addr := strings.TrimSpace(addrs[len(addrs)-1]); addr != "" {
+			return addr
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
+
+type loginLimiter struct {
+	limit    rate.Limit
+	burst    int
+	limiters *cache.Cache
+	mu       sync.Mutex
+}
+
+func newLoginLimiter(perMinute int) *loginLimiter {
+	if perMinute == 0 {
+		return &loginLimiter{limit: rate.Inf}
+	}
+
+	return &loginLimiter{
+		limit:    rate.Every(time.Minute / time.Duration(perMinute)),
+		burst:    perMinute,
+		limiters: cache.New(10*time.Minute, 10*time.Minute),
+	}
+}
+
+func (l *loginLimiter) allow(key string) bool {
+	if l.limit == rate.Inf {
+		return true
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var limiter *rate.Limiter
+	if cached, found := l.limiters.Get(key); found {
+		limiter = cached.(*rate.Limiter)
+	} else {
+		limiter = rate.NewLimiter(l.limit, l.burst)
+	}
+
+	// clients which stop trying are forgotten
+	l.limiters.Set(key, limiter, cache.DefaultExpiration)
+
+	return limiter.Allow()
+}
@@ -0,0 +1,280 @@
+package lockout_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/skymarshal/lockout"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ProtectLocalLogins", func() {
+	var (
+		repository *dbfakes.FakeLoginLockoutRepository
+		config     lockout.Config
+
+		dexStatus   int
+		dexRequests int
+
+		handler http.Handler
+	)
+
+	BeforeEach(func() {
+		repository = new(dbfakes.FakeLoginLockoutRepository)
+		config = lockout.Config{
+			MaxFailures: 3,
+			Duration:    time.Minute,
+			MaxDuration: 10 * time.Minute,
+		}
+
+		dexStatus = http.StatusUnauthorized
+		dexRequests = 0
+	})
+
+	JustBeforeEach(func() {
+		dex := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			dexRequests++
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(dexStatus)
+			w.Write([]byte(`{"some":"response"}`))
+		})
+
+		handler = lockout.ProtectLocalLogins(lagertest.NewTestLogger("test"), dex, repository, config)
+	})
+
+	passwordGrant := func(username string) *httptest.ResponseRecorder {
+		form := url.Values{
+			"grant_type": {"password"},
+			"username":   {username},
+			"password":   {"some-password"},
+		}
+
+		r := httptest.NewRequest("POST", "/sky/issuer/token", strings.NewReader(form.Encode()))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, r)
+		return rec
+	}
+
+	Context("when a password grant fails", func() {
+		BeforeEach(func() {
+			repository.RecordLoginFailureReturns(1, nil)
+		})
+
+		It("passes on dex's response and counts the failure", func() {
+			rec := passwordGrant("Some-User")
+			Expect(rec.Code).To(Equal(http.StatusUnauthorized))
+			Expect(rec.Header().Get("Content-Type")).To(Equal("application/json"))
+			Expect(rec.Body.String()).To(Equal(`{"some":"response"}`))
+
+			Expect(repository.RecordLoginFailureCallCount()).To(Equal(1))
+			username, window := repository.RecordLoginFailureArgsForCall(0)
+			Expect(username).To(Equal("some-user"))
+			Expect(window).To(Equal(lockout.FailureWindow))
+
+			Expect(repository.LockLoginCallCount()).To(BeZero())
+		})
+
+		Context("when the user has failed too many times", func() {
+			BeforeEach(func() {
+				repository.RecordLoginFailureReturnsOnCall(0, 3, nil)
+				repository.RecordLoginFailureReturnsOnCall(1, 4, nil)
+				repository.RecordLoginFailureReturnsOnCall(2, 10, nil)
+			})
+
+			It("locks them out for longer each time", func() {
+				passwordGrant("some-user")
+				passwordGrant("some-user")
+				passwordGrant("some-user")
+
+				Expect(repository.LockLoginCallCount()).To(Equal(3))
+
+				username, duration := repository.LockLoginArgsForCall(0)
+				Expect(username).To(Equal("some-user"))
+				Expect(duration).To(Equal(time.Minute))
+
+				_, duration = repository.LockLoginArgsForCall(1)
+				Expect(duration).To(Equal(2 * time.Minute))
+
+				_, duration = repository.LockLoginArgsForCall(2)
+				Expect(duration).To(Equal(10 * time.Minute))
+			})
+		})
+	})
+
+	Context("when a password grant succeeds", func() {
+		BeforeEach(func() {
+			dexStatus = http.StatusOK
+		})
+
+		It("forgets the user's failures", func() {
+			rec := passwordGrant("some-user")
+			Expect(rec.Code).To(Equal(http.StatusOK))
+
+			Expect(repository.ClearLoginFailuresCallCount()).To(Equal(1))
+			Expect(repository.ClearLoginFailuresArgsForCall(0)).To(Equal("some-user"))
+			Expect(repository.RecordLoginFailureCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when the user is locked out", func() {
+		BeforeEach(func() {
+			repository.LoginLockedForReturns(90*time.Second, nil)
+		})
+
+		It("refuses the login without checking the password", func() {
+			rec := passwordGrant("some-user")
+			Expect(rec.Code).To(Equal(http.StatusTooManyRequests))
+			Expect(rec.Header().Get("Retry-After")).To(Equal("90"))
+			Expect(rec.Body.String()).To(ContainSubstring("try again in 1m30s"))
+
+			Expect(dexRequests).To(BeZero())
+			Expect(repository.RecordLoginFailureCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when checking the lockout fails", func() {
+		BeforeEach(func() {
+			repository.LoginLockedForReturns(0, errors.New("nope"))
+		})
+
+		It("returns 500", func() {
+			Expect(passwordGrant("some-user").Code).To(Equal(http.StatusInternalServerError))
+			Expect(dexRequests).To(BeZero())
+		})
+	})
+
+	Context("when lockout is disabled", func() {
+		BeforeEach(func() {
+			config.MaxFailures = 0
+		})
+
+		It("does not count failures", func() {
+			Expect(passwordGrant("some-user").Code).To(Equal(http.StatusUnauthorized))
+			Expect(repository.LoginLockedForCallCount()).To(BeZero())
+			Expect(repository.RecordLoginFailureCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when logging in through the login form", func() {
+		login := func() *httptest.ResponseRecorder {
+			form := url.Values{"login": {"some-user"}, "password": {"some-password"}}
+
+			r := httptest.NewRequest("POST", "/sky/issuer/auth/local/login?state=some-state", strings.NewReader(form.Encode()))
+			r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, r)
+			return rec
+		}
+
+		It("counts the form being shown again as a failure", func() {
+			dexStatus = http.StatusOK
+
+			Expect(login().Code).To(Equal(http.StatusOK))
+			Expect(repository.RecordLoginFailureCallCount()).To(Equal(1))
+		})
+
+		It("counts a redirect as success", func() {
+			dexStatus = http.StatusSeeOther
+
+			Expect(login().Code).To(Equal(http.StatusSeeOther))
+			Expect(repository.ClearLoginFailuresCallCount()).To(Equal(1))
+			Expect(repository.RecordLoginFailureCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when rate limited", func() {
+		BeforeEach(func() {
+			config.RateLimit = 2
+		})
+
+		It("refuses logins as a user beyond the limit", func() {
+			Expect(passwordGrant("some-user").Code).To(Equal(http.StatusUnauthorized))
+			Expect(passwordGrant("Some-User").Code).To(Equal(http.StatusUnauthorized))
+
+			rec := passwordGrant("some-user")
+			Expect(rec.Code).To(Equal(http.StatusTooManyRequests))
+			Expect(rec.Header().Get("Retry-After")).To(Equal("30"))
+			Expect(dexRequests).To(Equal(2))
+		})
+
+		It("does not limit other users logging in from the same address", func() {
+			Expect(passwordGrant("some-user").Code).To(Equal(http.StatusUnauthorized))
+			Expect(passwordGrant("some-user").Code).To(Equal(http.StatusUnauthorized))
+			Expect(passwordGrant("other-user").Code).To(Equal(http.StatusUnauthorized))
+			Expect(dexRequests).To(Equal(3))
+		})
+
+		Context("when a client IP header is configured", func() {
+			BeforeEach(func() {
+				config.ClientIPHeader = "X-Forwarded-For"
+			})
+
+			passwordGrantFrom := func(username string, forwardedFor string) int {
+				form := url.Values{
+					"grant_type": {"password"},
+					"username":   {username},
+					"password":   {"some-password"},
+				}
+
+				r := httptest.NewRequest("POST", "/sky/issuer/token", strings.NewReader(form.Encode()))
+				r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+				if forwardedFor != "" {
+					r.Header.Set("X-Forwarded-For", forwardedFor)
+				}
+
+				rec := httptest.NewRecorder()
+				handler.ServeHTTP(rec, r)
+				return rec.Code
+			}
+
+			It("refuses logins from a client address beyond the limit", func() {
+				Expect(passwordGrantFrom("some-user", "1.2.3.4")).To(Equal(http.StatusUnauthorized))
+				Expect(passwordGrantFrom("other-user", "1.2.3.4")).To(Equal(http.StatusUnauthorized))
+				Expect(passwordGrantFrom("some-user", "1.2.3.4")).To(Equal(http.StatusTooManyRequests))
+
+				Expect(passwordGrantFrom("some-user", "5.6.7.8")).To(Equal(http.StatusUnauthorized))
+				Expect(dexRequests).To(Equal(3))
+			})
+
+			It("uses the address the proxy appended rather than ones the client sent", func() {
+				Expect(passwordGrantFrom("some-user", "10.0.0.1, 1.2.3.4")).To(Equal(http.StatusUnauthorized))
+				Expect(passwordGrantFrom("some-user", "10.0.0.2, 1.2.3.4")).To(Equal(http.StatusUnauthorized))
+				Expect(passwordGrantFrom("some-user", "10.0.0.3, 1.2.3.4")).To(Equal(http.StatusTooManyRequests))
+			})
+
+			It("falls back to the remote address when the header is missing", func() {
+				Expect(passwordGrantFrom("some-user", "")).To(Equal(http.StatusUnauthorized))
+				Expect(passwordGrantFrom("other-user", "")).To(Equal(http.StatusUnauthorized))
+				Expect(passwordGrantFrom("third-user", "")).To(Equal(http.StatusTooManyRequests))
+			})
+		})
+	})
+
+	It("forwards other requests untouched", func() {
+		for _, r := range []*http.Request{
+			httptest.NewRequest("GET", "/sky/issuer/auth/local/login", nil),
+			httptest.NewRequest("POST", "/sky/issuer/token", strings.NewReader("grant_type=authorization_code")),
+			httptest.NewRequest("GET", "/sky/issuer/.well-known/openid-configuration", nil),
+		} {
+			r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, r)
+			Expect(rec.Code).To(Equal(http.StatusUnauthorized))
+		}
+
+		Expect(dexRequests).To(Equal(3))
+		Expect(repository.LoginLockedForCallCount()).To(BeZero())
+		Expect(repository.RecordLoginFailureCallCount()).To(BeZero())
+	})
+})
@@ -49,10 +49,17 @@ type AuthFlags struct {
 	PasswordConnector string            `long:"password-connector" default:"local" choice:"local" choice:"ldap" description:"Connector to use when authenticating via 'fly login -u ... -p ...'"`
 	LocalUsers        map[string]string `long:"add-local-user" description:"List of username:password combinations for all your local users. The password can be bcrypted - if so, it must have a minimum cost of 10." value-name:"USERNAME:PASSWORD"`
 	Clients           map[string]string `long:"add-client" description:"List of client_id:client_secret combinations" value-name:"CLIENT_ID:CLIENT_SECRET"`
+
+	LocalLoginMaxFailures    int           `long:"local-login-max-failures" default:"5" description:"Number of failed logins in a row after which a local user is locked out. 0 disables lockout."`
+	LocalLoginLockout        time.Duration `long:"local-login-lockout" default:"1m" description:"Length of time a local user is first locked out for. It doubles with every further failed login."`
+	LocalLoginMaxLockout     time.Duration `long:"local-login-max-lockout" default:"1h" description:"Longest a local user can be locked out for."`
+	LocalLoginRateLimit      int           `long:"local-login-rate-limit" default:"20" description:"Number of local user logins each client may attempt per minute. Clients are told apart by --local-login-client-ip-header if it is set, and by username otherwise. 0 means unlimited."`
+	LocalLoginClientIPHeader string        `long:"local-login-client-ip-header" description:"Header, such as X-Forwarded-For, in which a trusted reverse proxy passes on the client's address. Only set this if every request goes through such a proxy." value-name:"HEADER"`
 }
 
 type AuthTeamFlags struct {
 	LocalUsers []string  `long:"local-user" description:"A whitelisted local concourse user. These are the users you've added at web startup with the --add-local-user flag." value-name:"USERNAME"`
+	SCIMGroups []string  `long:"scim-group" description:"A whitelisted group provisioned by an identity provider over SCIM." value-name:"GROUP_NAME"`
 	Config     flag.File `short:"c" long:"config" description:"Configuration file for specifying team params"`
 }
 
@@ -134,6 +141,16 @@ func (flag *AuthTeamFlags) formatFromFile() (atc.TeamAuth, error) {
 			}
 		}
 
+		if conf, ok := role["scim"].(map[string]interface{}); ok {
+			if scimGroups, ok := conf["groups"].([]interface{}); ok {
+				for _, group := range scimGroups {
+					if group, ok := group.(string); ok && group != "" {
+						groups = append(groups, "scim:"+strings.ToLower(group))
+					}
+				}
+			}
+		}
+
 		if len(users) == 0 && len(groups) == 0 {
 			continue
 		}
@@ -183,6 +200,12 @@ func (flag *AuthTeamFlags) formatFromFlags() (atc.TeamAuth, error) {
 		}
 	}
 
+	for _, group := range flag.SCIMGroups {
+		if group != "" {
+			groups = append(groups, "scim:"+strings.ToLower(group))
+		}
+	}
+
 	if len(users) == 0 && len(groups) == 0 {
 		return nil, atc.ErrAuthConfigInvalid
 	}
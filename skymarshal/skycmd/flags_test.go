@@ -2,6 +2,9 @@ package skycmd_test
 
 import (
 	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/skymarshal/skycmd"
@@ -72,3 +75,60 @@ var _ = Describe("skyDisplayUserIdGenerator", func() {
 		})
 	})
 })
+
+var _ = Describe("AuthTeamFlags", func() {
+	Describe("Format", func() {
+		It("gives SCIM groups their roles", func() {
+			flags := skycmd.AuthTeamFlags{
+				LocalUsers: []string{"Some-User"},
+				SCIMGroups: []string{"Developers"},
+			}
+
+			auth, err := flags.Format()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(auth).To(Equal(atc.TeamAuth{
+				"owner": {
+					"users":  {"local:some-user"},
+					"groups": {"scim:developers"},
+				},
+			}))
+		})
+
+		Context("from a config file", func() {
+			var dir string
+
+			BeforeEach(func() {
+				var err error
+				dir, err = ioutil.TempDir("", "team-config")
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				os.RemoveAll(dir)
+			})
+
+			It("gives SCIM groups their roles", func() {
+				path := filepath.Join(dir, "team.yml")
+				err := ioutil.WriteFile(path, []byte(`
+roles:
+- name: member
+  scim:
+    groups: [Developers]
+`), 0644)
+				Expect(err).ToNot(HaveOccurred())
+
+				flags := skycmd.AuthTeamFlags{}
+				Expect(flags.Config.UnmarshalFlag(path)).To(Succeed())
+
+				auth, err := flags.Format()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(auth).To(Equal(atc.TeamAuth{
+					"member": {
+						"users":  {},
+						"groups": {"scim:developers"},
+					},
+				}))
+			})
+		})
+	})
+})
@@ -19,8 +19,9 @@ func init() {
 
 type SAMLFlags struct {
 	DisplayName        string    `long:"display-name" description:"The auth provider name displayed to users on the login page"`
-	SsoURL             string    `long:"sso-url" description:"(Required) SSO URL used for POST value"`
-	CACert             flag.File `long:"ca-cert" description:"(Required) CA Certificate"`
+	IdPMetadata        flag.File `long:"idp-metadata" description:"The IdP's SAML 2.0 metadata. The SSO URL, SSO issuer and signing certificates are read from it unless they are set explicitly."`
+	SsoURL             string    `long:"sso-url" description:"(Required unless idp-metadata is set) SSO URL used for POST value"`
+	CACert             flag.File `long:"ca-cert" description:"(Required unless idp-metadata is set) CA Certificate"`
 	EntityIssuer       string    `long:"entity-issuer" description:"Manually specify dex's Issuer value."`
 	SsoIssuer          string    `long:"sso-issuer" description:"Issuer value expected in the SAML response."`
 	UsernameAttr       string    `long:"username-attr" default:"name" description:"The user name indicates which claim to use to map an external user name to a Concourse user name."`
@@ -28,7 +29,7 @@ type SAMLFlags struct {
 	GroupsAttr         string    `long:"groups-attr" default:"groups" description:"The groups key indicates which attribute to use to map external groups to Concourse teams."`
 	GroupsDelim        string    `long:"groups-delim" description:"If specified, groups are returned as string, this delimiter will be used to split the group string."`
 	NameIDPolicyFormat string    `long:"name-id-policy-format" description:"Requested format of the NameID. The NameID value is is mapped to the ID Token 'sub' claim."`
-	InsecureSkipVerify bool      `long:"skip-ssl-validation" description:"Skip SSL validation"`
+	InsecureSkipVerify bool      `long:"skip-ssl-validation" description:"Skip validating the signatures of SAML responses and assertions. Insecure."`
 }
 
 func (flag *SAMLFlags) Name() string {
@@ -41,6 +42,10 @@ func (flag *SAMLFlags) Name() string {
 func (flag *SAMLFlags) Validate() error {
 	var errs *multierror.Error
 
+	if flag.IdPMetadata != "" {
+		return nil
+	}
+
 	if flag.SsoURL == "" {
 		errs = multierror.Append(errs, errors.New("Missing sso-url"))
 	}
@@ -57,7 +62,7 @@ func (flag *SAMLFlags) Serialize(redirectURI string) ([]byte, error) {
 		return nil, err
 	}
 
-	return json.Marshal(saml.Config{
+	config := saml.Config{
 		SSOURL:                          flag.SsoURL,
 		CA:                              flag.CACert.Path(),
 		EntityIssuer:                    flag.EntityIssuer,
@@ -69,7 +74,28 @@ func (flag *SAMLFlags) Serialize(redirectURI string) ([]byte, error) {
 		GroupsDelim:                     flag.GroupsDelim,
 		NameIDPolicyFormat:              flag.NameIDPolicyFormat,
 		RedirectURI:                     redirectURI,
-	})
+	}
+
+	if flag.IdPMetadata != "" {
+		metadata, err := loadSAMLIdPMetadata(flag.IdPMetadata.Path())
+		if err != nil {
+			return nil, err
+		}
+
+		if config.SSOURL == "" {
+			config.SSOURL = metadata.SSOURL
+		}
+
+		if config.SSOIssuer == "" {
+			config.SSOIssuer = metadata.EntityID
+		}
+
+		if config.CA == "" && len(metadata.SigningCerts) > 0 {
+			config.CAData = metadata.SigningCerts
+		}
+	}
+
+	return json.Marshal(config)
 }
 
 type SAMLTeamFlags struct {
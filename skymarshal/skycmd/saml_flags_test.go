@@ -0,0 +1,120 @@
+package skycmd_test
+
+import (
+	"encoding/json"
+	"encoding/pem"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/concourse/concourse/skymarshal/skycmd"
+	"github.com/concourse/dex/connector/saml"
+	"github.com/concourse/flag"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SAMLFlags", func() {
+	var (
+		flags       *skycmd.SAMLFlags
+		metadataDir string
+	)
+
+	const metadata = `<?xml version="1.0"?>
+<md:EntityDescriptor xmlns:md="urn:oasis:names:tc:SAML:2.0:metadata" xmlns:ds="http://www.w3.org/2000/09/xmldsig#" entityID="https://idp.example.com/metadata">
+  <md:IDPSSODescriptor protocolSupportEnumeration="urn:oasis:names:tc:SAML:2.0:protocol">
+    <md:KeyDescriptor use="encryption">
+      <ds:KeyInfo><ds:X509Data><ds:X509Certificate>ZW5jcnlwdGlvbg==</ds:X509Certificate></ds:X509Data></ds:KeyInfo>
+    </md:KeyDescriptor>
+    <md:KeyDescriptor use="signing">
+      <ds:KeyInfo><ds:X509Data><ds:X509Certificate>
+        c2lnbmluZw==
+      </ds:X509Certificate></ds:X509Data></ds:KeyInfo>
+    </md:KeyDescriptor>
+    <md:SingleSignOnService Binding="urn:oasis:names:tc:SAML:2.0:bindings:HTTP-Redirect" Location="https://idp.example.com/sso/redirect"/>
+    <md:SingleSignOnService Binding="urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST" Location="https://idp.example.com/sso/post"/>
+  </md:IDPSSODescriptor>
+</md:EntityDescriptor>`
+
+	BeforeEach(func() {
+		var err error
+		metadataDir, err = ioutil.TempDir("", "saml-metadata")
+		Expect(err).ToNot(HaveOccurred())
+
+		flags = &skycmd.SAMLFlags{
+			UsernameAttr: "name",
+			EmailAttr:    "email",
+			GroupsAttr:   "groups",
+		}
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(metadataDir)
+	})
+
+	serialize := func() saml.Config {
+		payload, err := flags.Serialize("https://concourse.example.com/sky/issuer/callback")
+		Expect(err).ToNot(HaveOccurred())
+
+		var config saml.Config
+		Expect(json.Unmarshal(payload, &config)).To(Succeed())
+		return config
+	}
+
+	Context("without idp metadata", func() {
+		It("requires the sso url and ca cert", func() {
+			Expect(flags.Validate()).To(MatchError(And(
+				ContainSubstring("Missing sso-url"),
+				ContainSubstring("Missing ca-cert"),
+			)))
+		})
+	})
+
+	Context("with idp metadata", func() {
+		BeforeEach(func() {
+			path := filepath.Join(metadataDir, "metadata.xml")
+			Expect(ioutil.WriteFile(path, []byte(metadata), 0644)).To(Succeed())
+
+			flags.IdPMetadata = flag.File(path)
+		})
+
+		It("reads the sso url, issuer and signing certificates from it", func() {
+			Expect(flags.Validate()).To(Succeed())
+
+			config := serialize()
+			Expect(config.SSOURL).To(Equal("https://idp.example.com/sso/post"))
+			Expect(config.SSOIssuer).To(Equal("https://idp.example.com/metadata"))
+			Expect(config.CA).To(BeEmpty())
+
+			block, rest := pem.Decode(config.CAData)
+			Expect(block).ToNot(BeNil())
+			Expect(block.Type).To(Equal("CERTIFICATE"))
+			Expect(string(block.Bytes)).To(Equal("signing"))
+			Expect(rest).To(BeEmpty())
+		})
+
+		It("prefers values which are set explicitly", func() {
+			flags.SsoURL = "https://other-idp.example.com/sso"
+			flags.SsoIssuer = "some-issuer"
+			flags.CACert = flag.File("/some/ca.pem")
+
+			config := serialize()
+			Expect(config.SSOURL).To(Equal("https://other-idp.example.com/sso"))
+			Expect(config.SSOIssuer).To(Equal("some-issuer"))
+			Expect(config.CA).To(Equal("/some/ca.pem"))
+			Expect(config.CAData).To(BeEmpty())
+		})
+
+		Context("when the metadata has no HTTP-POST sso endpoint", func() {
+			BeforeEach(func() {
+				Expect(ioutil.WriteFile(flags.IdPMetadata.Path(), []byte(`<EntityDescriptor entityID="some-idp"><IDPSSODescriptor/></EntityDescriptor>`), 0644)).To(Succeed())
+			})
+
+			It("fails to serialize", func() {
+				_, err := flags.Serialize("https://concourse.example.com/sky/issuer/callback")
+				Expect(err).To(MatchError(ContainSubstring("HTTP-POST")))
+			})
+		})
+	})
+})
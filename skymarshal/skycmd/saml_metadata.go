@@ -0,0 +1,99 @@
+package skycmd
+
+import (
+	"encoding/base64"
+	"encoding/pem"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+)
+
+const samlHTTPPostBinding = "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST"
+
+// samlIdPMetadata is the part of an IdP's SAML 2.0 metadata needed to log in
+// through it.
+type samlIdPMetadata struct {
+	EntityID string
+
+	// SSOURL is the IdP's single sign-on endpoint for the HTTP-POST binding,
+	// which is the only binding the connector supports.
+	SSOURL string
+
+	// SigningCerts are the PEM encoded certificates the IdP signs responses
+	// and assertions with.
+	SigningCerts []byte
+}
+
+type samlEntityDescriptor struct {
+	XMLName          xml.Name `xml:"EntityDescriptor"`
+	EntityID         string   `xml:"entityID,attr"`
+	IDPSSODescriptor *struct {
+		KeyDescriptors []struct {
+			Use          string   `xml:"use,attr"`
+			Certificates []string `xml:"KeyInfo>X509Data>X509Certificate"`
+		} `xml:"KeyDescriptor"`
+		SingleSignOnServices []struct {
+			Binding  string `xml:"Binding,attr"`
+			Location string `xml:"Location,attr"`
+		} `xml:"SingleSignOnService"`
+	} `xml:"IDPSSODescriptor"`
+}
+
+func loadSAMLIdPMetadata(path string) (samlIdPMetadata, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return samlIdPMetadata{}, err
+	}
+
+	return parseSAMLIdPMetadata(content)
+}
+
+func parseSAMLIdPMetadata(content []byte) (samlIdPMetadata, error) {
+	var descriptor samlEntityDescriptor
+	err := xml.Unmarshal(content, &descriptor)
+	if err != nil {
+		return samlIdPMetadata{}, fmt.Errorf("parse idp metadata: %w", err)
+	}
+
+	if descriptor.IDPSSODescriptor == nil {
+		return samlIdPMetadata{}, errors.New("idp metadata has no IDPSSODescriptor")
+	}
+
+	metadata := samlIdPMetadata{
+		EntityID: descriptor.EntityID,
+	}
+
+	for _, service := range descriptor.IDPSSODescriptor.SingleSignOnServices {
+		if service.Binding == samlHTTPPostBinding {
+			metadata.SSOURL = service.Location
+			break
+		}
+	}
+
+	if metadata.SSOURL == "" {
+		return samlIdPMetadata{}, errors.New("idp metadata has no SingleSignOnService with the HTTP-POST binding")
+	}
+
+	var certs strings.Builder
+	for _, key := range descriptor.IDPSSODescriptor.KeyDescriptors {
+		// keys without a use are used for both signing and encryption
+		if key.Use != "" && key.Use != "signing" {
+			continue
+		}
+
+		for _, cert := range key.Certificates {
+			der, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(cert), ""))
+			if err != nil {
+				return samlIdPMetadata{}, fmt.Errorf("decode idp signing certificate: %w", err)
+			}
+
+			pem.Encode(&certs, &pem.Block{Type: "CERTIFICATE", Bytes: der})
+		}
+	}
+
+	metadata.SigningCerts = []byte(certs.String())
+
+	return metadata, nil
+}
@@ -106,7 +106,7 @@ func StoreAccessToken(
 			claims.PreferredUsername,
 			claims.Email,
 		)
-		err = userFactory.CreateOrUpdateUser(username, claims.Connector, claims.Subject)
+		err = userFactory.CreateOrUpdateUser(username, claims.Connector, claims.Subject, claims)
 		if err != nil {
 			logger.Error("create-or-update-user", err)
 			w.WriteHeader(http.StatusInternalServerError)
@@ -0,0 +1,188 @@
+package tsa
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+)
+
+// ErrNoCertificate is returned when the worker has neither been configured
+// with a certificate nor been issued one yet.
+var ErrNoCertificate = errors.New("no certificate")
+
+// ClientTLS configures the client to connect to the TSA over mutual TLS.
+//
+// The worker's certificate is either issued by an external CA, in which case
+// it is configured with CertFile and KeyFile, or issued by the TSA itself and
+// kept in CertDir. Either way it is read on each connection, so certificates
+// issued externally can be rotated without restarting the worker, and
+// certificates issued by the TSA are renewed automatically once two thirds of
+// their lifetime has passed.
+type ClientTLS struct {
+	// CACertFile contains the CA certificate(s) with which to verify the TSA.
+	CACertFile string
+
+	CertFile string
+	KeyFile  string
+
+	CertDir string
+
+	// BootstrapToken authenticates the worker when requesting its first
+	// certificate from the TSA, if it has no SSH key to authenticate with.
+	BootstrapToken string
+}
+
+func (config ClientTLS) rootCAs() (*x509.CertPool, error) {
+	if config.CACertFile == "" {
+		return nil, nil
+	}
+
+	caCerts, err := os.ReadFile(config.CACertFile)
+	if err != nil {
+		return nil, err
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caCerts) {
+		return nil, fmt.Errorf("no certificates found in %s", config.CACertFile)
+	}
+
+	return pool, nil
+}
+
+// WorkerCertificate describes a certificate for the workers API.
+func WorkerCertificate(cert *x509.Certificate) *atc.WorkerCertificate {
+	fingerprint := sha256.Sum256(cert.Raw)
+
+	return &atc.WorkerCertificate{
+		SerialNumber: cert.SerialNumber.Text(16),
+		Subject:      cert.Subject.String(),
+		Issuer:       cert.Issuer.String(),
+		NotBefore:    cert.NotBefore.Unix(),
+		NotAfter:     cert.NotAfter.Unix(),
+		Fingerprint:  hex.EncodeToString(fingerprint[:]),
+	}
+}
+
+// NeedsRenewal returns true once two thirds of the certificate's lifetime
+// has passed.
+func NeedsRenewal(cert *x509.Certificate, now time.Time) bool {
+	lifetime := cert.NotAfter.Sub(cert.NotBefore)
+	return now.After(cert.NotBefore.Add(lifetime * 2 / 3))
+}
+
+// CertificateStore keeps the certificate issued to the worker by the TSA and
+// its private key in a directory.
+type CertificateStore struct {
+	Dir string
+}
+
+const (
+	storeCertFile = "worker.crt"
+	storeKeyFile  = "worker.key"
+)
+
+// Load returns the stored certificate, or ErrNoCertificate if none has been
+// issued yet.
+func (store CertificateStore) Load() (*tls.Certificate, error) {
+	cert, err := tls.LoadX509KeyPair(
+		filepath.Join(store.Dir, storeCertFile),
+		filepath.Join(store.Dir, storeKeyFile),
+	)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, ErrNoCertificate
+		}
+
+		return nil, err
+	}
+
+	return parseLeaf(cert)
+}
+
+// Save replaces the stored certificate and its key.
+func (store CertificateStore) Save(certPEM []byte, keyPEM []byte) (*tls.Certificate, error) {
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	err = os.MkdirAll(store.Dir, 0700)
+	if err != nil {
+		return nil, err
+	}
+
+	err = writeFileAtomically(filepath.Join(store.Dir, storeKeyFile), keyPEM, 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	err = writeFileAtomically(filepath.Join(store.Dir, storeCertFile), certPEM, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseLeaf(cert)
+}
+
+// NewCertificateRequest generates a private key for the worker and a
+// certificate request for it, to be signed by the TSA.
+func NewCertificateRequest(workerName string) ([]byte, []byte, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject: pkix.Name{CommonName: workerName},
+	}, key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER})
+
+	return csr, keyPEM, nil
+}
+
+func parseLeaf(cert tls.Certificate) (*tls.Certificate, error) {
+	if cert.Leaf == nil {
+		leaf, err := x509.ParseCertificate(cert.Certificate[0])
+		if err != nil {
+			return nil, err
+		}
+
+		cert.Leaf = leaf
+	}
+
+	return &cert, nil
+}
+
+func writeFileAtomically(path string, data []byte, perm os.FileMode) error {
+	tmp := path + ".tmp"
+
+	err := os.WriteFile(tmp, data, perm)
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, path)
+}
@@ -0,0 +1,125 @@
+package tsa_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"time"
+
+	"github.com/concourse/concourse/tsa"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Certificates", func() {
+	Describe("NeedsRenewal", func() {
+		var cert *x509.Certificate
+
+		BeforeEach(func() {
+			cert = &x509.Certificate{
+				NotBefore: time.Unix(0, 0),
+				NotAfter:  time.Unix(300, 0),
+			}
+		})
+
+		It("does not need renewal during the first two thirds of its lifetime", func() {
+			Expect(tsa.NeedsRenewal(cert, time.Unix(199, 0))).To(BeFalse())
+		})
+
+		It("needs renewal afterwards", func() {
+			Expect(tsa.NeedsRenewal(cert, time.Unix(201, 0))).To(BeTrue())
+		})
+	})
+
+	Describe("CertificateStore", func() {
+		var store tsa.CertificateStore
+
+		BeforeEach(func() {
+			dir, err := os.MkdirTemp("", "tsa-certs")
+			Expect(err).ToNot(HaveOccurred())
+
+			store = tsa.CertificateStore{Dir: dir}
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(store.Dir)
+		})
+
+		It("returns ErrNoCertificate when none has been issued", func() {
+			_, err := store.Load()
+			Expect(err).To(Equal(tsa.ErrNoCertificate))
+		})
+
+		It("loads the saved certificate", func() {
+			csrDER, keyPEM, err := tsa.NewCertificateRequest("some-worker")
+			Expect(err).ToNot(HaveOccurred())
+
+			csr, err := x509.ParseCertificateRequest(csrDER)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(csr.Subject.CommonName).To(Equal("some-worker"))
+
+			certPEM := selfSign(csr)
+
+			saved, err := store.Save(certPEM, keyPEM)
+			Expect(err).ToNot(HaveOccurred())
+
+			loaded, err := store.Load()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(loaded.Leaf.Raw).To(Equal(saved.Leaf.Raw))
+
+			info := tsa.WorkerCertificate(loaded.Leaf)
+			Expect(info.Subject).To(Equal("CN=some-worker"))
+			Expect(info.Issuer).To(Equal("CN=some-ca"))
+			Expect(info.SerialNumber).To(Equal("2a"))
+			Expect(info.Fingerprint).To(HaveLen(64))
+		})
+
+		It("refuses to save a certificate for a different key", func() {
+			csrDER, _, err := tsa.NewCertificateRequest("some-worker")
+			Expect(err).ToNot(HaveOccurred())
+
+			_, otherKeyPEM, err := tsa.NewCertificateRequest("some-worker")
+			Expect(err).ToNot(HaveOccurred())
+
+			csr, err := x509.ParseCertificateRequest(csrDER)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = store.Save(selfSign(csr), otherKeyPEM)
+			Expect(err).To(HaveOccurred())
+
+			_, err = store.Load()
+			Expect(err).To(Equal(tsa.ErrNoCertificate))
+		})
+	})
+})
+
+func selfSign(csr *x509.CertificateRequest) []byte {
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Expect(err).ToNot(HaveOccurred())
+
+	ca := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "some-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, &x509.Certificate{
+		SerialNumber: big.NewInt(42),
+		Subject:      csr.Subject,
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}, ca, csr.PublicKey, caKey)
+	Expect(err).ToNot(HaveOccurred())
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
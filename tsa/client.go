@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"context"
 	"crypto/rsa"
+	"crypto/tls"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -54,6 +56,9 @@ type Client struct {
 
 	PrivateKey *rsa.PrivateKey
 
+	// TLS is configured when connecting to the TSA over mutual TLS.
+	TLS *ClientTLS
+
 	Worker atc.Worker
 }
 
@@ -322,24 +327,130 @@ func (client *Client) ReportVolumes(ctx context.Context, handles []string) error
 	return client.run(ctx, sshClient, strings.Join(command, " "), os.Stdout)
 }
 
+// IssueCertificate invokes the 'issue-certificate' command, requesting a new
+// certificate from the TSA's CA and storing it in the configured directory.
+//
+// The worker authenticates with its current certificate if it has one, or
+// otherwise with its SSH key or bootstrap token.
+func (client *Client) IssueCertificate(ctx context.Context, current *tls.Certificate) (*tls.Certificate, error) {
+	logger := lagerctx.WithSession(ctx, "issue-certificate")
+
+	csr, keyPEM, err := NewCertificateRequest(client.Worker.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	sshClient, _, err := client.connect(ctx, 0, current)
+	if err != nil {
+		logger.Error("failed-to-dial", err)
+		return nil, err
+	}
+
+	defer sshClient.Close()
+
+	certPEM := new(bytes.Buffer)
+	err = client.run(ctx, sshClient, IssueCertificate+" "+base64.RawURLEncoding.EncodeToString(csr), certPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	cert, err := CertificateStore{Dir: client.TLS.CertDir}.Save(certPEM.Bytes(), keyPEM)
+	if err != nil {
+		logger.Error("failed-to-save-certificate", err)
+		return nil, err
+	}
+
+	logger.Info("issued", lager.Data{"not-after": cert.Leaf.NotAfter})
+
+	return cert, nil
+}
+
 func (client *Client) dial(ctx context.Context, idleTimeout time.Duration) (*ssh.Client, *net.TCPConn, error) {
+	var cert *tls.Certificate
+	if client.TLS != nil {
+		var err error
+		cert, err = client.certificate(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return client.connect(ctx, idleTimeout, cert)
+}
+
+// certificate loads the worker's certificate, first requesting one from the
+// TSA if it has not been issued one yet or if it is due for renewal.
+func (client *Client) certificate(ctx context.Context) (*tls.Certificate, error) {
+	logger := lagerctx.WithSession(ctx, "certificate")
+
+	if client.TLS.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(client.TLS.CertFile, client.TLS.KeyFile)
+		if err != nil {
+			return nil, err
+		}
+
+		return &cert, nil
+	}
+
+	cert, err := CertificateStore{Dir: client.TLS.CertDir}.Load()
+	if err != nil {
+		if err != ErrNoCertificate {
+			logger.Error("failed-to-load-certificate", err)
+		}
+
+		return client.IssueCertificate(ctx, nil)
+	}
+
+	now := time.Now()
+	if !NeedsRenewal(cert.Leaf, now) {
+		return cert, nil
+	}
+
+	renewed, err := client.IssueCertificate(ctx, cert)
+	if err != nil {
+		if now.Before(cert.Leaf.NotAfter) {
+			// the current certificate is still valid, so keep using it and try
+			// renewing again on the next connection
+			logger.Error("failed-to-renew-certificate", err)
+			return cert, nil
+		}
+
+		return nil, err
+	}
+
+	return renewed, nil
+}
+
+func (client *Client) connect(ctx context.Context, idleTimeout time.Duration, cert *tls.Certificate) (*ssh.Client, *net.TCPConn, error) {
 	logger := lagerctx.WithSession(ctx, "dial")
 
-	var err error
+	auth, err := client.authMethod(cert)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	tcpConn, tsaAddr, err := client.tryDialAll(ctx)
 	if err != nil {
 		logger.Error("failed-to-connect-to-any-tsa", err)
 		return nil, nil, err
 	}
 
-	var pk ssh.Signer
-	if client.PrivateKey != nil {
-		pk, err = ssh.NewSignerFromKey(client.PrivateKey)
+	hostKeyCallback := client.checkHostKey
+
+	var tsaConn net.Conn = tcpConn
+	if client.TLS != nil {
+		tlsConn, err := client.tlsHandshake(ctx, tcpConn, tsaAddr, cert)
 		if err != nil {
-			return nil, nil, fmt.Errorf("failed to construct ssh public key from worker key: %s", err)
+			tcpConn.Close()
+			return nil, nil, &HandshakeError{Err: err}
+		}
+
+		tsaConn = tlsConn
+
+		// the TSA has already been verified by its TLS certificate
+		hostKeyCallback = func(string, net.Addr, ssh.PublicKey) error {
+			return nil
 		}
-	} else {
-		return nil, nil, fmt.Errorf("private key not provided")
 	}
 
 	clientConfig := &ssh.ClientConfig{
@@ -347,15 +458,14 @@ func (client *Client) dial(ctx context.Context, idleTimeout time.Duration) (*ssh
 
 		User: "beacon", // doesn't matter
 
-		HostKeyCallback: client.checkHostKey,
+		HostKeyCallback: hostKeyCallback,
 
-		Auth: []ssh.AuthMethod{ssh.PublicKeys(pk)},
+		Auth: []ssh.AuthMethod{auth},
 	}
 
-	tsaConn := tcpConn
 	if idleTimeout != 0 {
 		tsaConn = &timeoutConn{
-			Conn:        tcpConn,
+			Conn:        tsaConn,
 			IdleTimeout: idleTimeout,
 		}
 	}
@@ -368,6 +478,66 @@ func (client *Client) dial(ctx context.Context, idleTimeout time.Duration) (*ssh
 	return ssh.NewClient(clientConn, chans, reqs), tcpConn.(*net.TCPConn), nil
 }
 
+// authMethod authenticates the worker with the key of its certificate when
+// connecting over mutual TLS, and otherwise with its SSH key. Without either,
+// the bootstrap token can only be used to request a certificate.
+func (client *Client) authMethod(cert *tls.Certificate) (ssh.AuthMethod, error) {
+	if cert != nil {
+		pk, err := ssh.NewSignerFromKey(cert.PrivateKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to construct ssh public key from certificate key: %s", err)
+		}
+
+		return ssh.PublicKeys(pk), nil
+	}
+
+	if client.PrivateKey != nil {
+		pk, err := ssh.NewSignerFromKey(client.PrivateKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to construct ssh public key from worker key: %s", err)
+		}
+
+		return ssh.PublicKeys(pk), nil
+	}
+
+	if client.TLS != nil && client.TLS.BootstrapToken != "" {
+		return ssh.Password(client.TLS.BootstrapToken), nil
+	}
+
+	return nil, fmt.Errorf("private key not provided")
+}
+
+func (client *Client) tlsHandshake(ctx context.Context, conn net.Conn, tsaAddr string, cert *tls.Certificate) (*tls.Conn, error) {
+	rootCAs, err := client.TLS.rootCAs()
+	if err != nil {
+		return nil, err
+	}
+
+	host, _, err := net.SplitHostPort(tsaAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	config := &tls.Config{
+		RootCAs:    rootCAs,
+		ServerName: host,
+		MinVersion: tls.VersionTLS12,
+	}
+
+	if cert != nil {
+		config.Certificates = []tls.Certificate{*cert}
+	}
+
+	tlsConn := tls.Client(conn, config)
+
+	err = tlsConn.HandshakeContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return tlsConn, nil
+}
+
 func (client *Client) tryDialAll(ctx context.Context) (net.Conn, string, error) {
 	logger := lagerctx.FromContext(ctx)
 
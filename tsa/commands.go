@@ -7,6 +7,8 @@ const (
 	RetireWorker = "retire-worker"
 	DeleteWorker = "delete-worker"
 
+	IssueCertificate = "issue-certificate"
+
 	ReportContainers      = "report-containers"
 	ReportVolumes         = "report-volumes"
 	ResourceActionMissing = "resource-type-missing"
@@ -285,6 +285,19 @@ func (heartbeater *Heartbeater) pingWorker(logger lager.Logger) (atc.Worker, boo
 	registration.ActiveContainers = len(containers)
 	registration.ActiveVolumes = len(volumes)
 
+	// disk usage is only used for placement, so the worker is still healthy
+	// without it, e.g. when its baggageclaim predates reporting it
+	ctx = lagerctx.NewContext(context.Background(), logger.Session("disk-usage"))
+	diskUsage, err := heartbeater.baggageclaimClient.DiskUsage(ctx)
+	if err != nil {
+		logger.Info("failed-to-get-disk-usage", lager.Data{"error": err.Error()})
+	} else {
+		registration.DiskUsage = &atc.WorkerDiskUsage{
+			TotalBytes: diskUsage.TotalBytes,
+			UsedBytes:  diskUsage.UsedBytes,
+		}
+	}
+
 	return registration, true
 }
 
@@ -3,6 +3,7 @@ package tsa_test
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"time"
 
@@ -132,6 +133,15 @@ var _ = Describe("Heartbeater", func() {
 
 		fakeGardenClient = new(gclientfakes.FakeClient)
 		fakeBaggageclaimClient = new(baggageclaimfakes.FakeClient)
+		fakeBaggageclaimClient.DiskUsageReturns(baggageclaim.DiskUsageResponse{
+			TotalBytes: 1024,
+			UsedBytes:  512,
+		}, nil)
+
+		expectedWorker.DiskUsage = &atc.WorkerDiskUsage{
+			TotalBytes: 1024,
+			UsedBytes:  512,
+		}
 
 		clientWriter = gbytes.NewBuffer()
 
@@ -261,6 +271,19 @@ var _ = Describe("Heartbeater", func() {
 					Eventually(heartbeats).Should(Receive(Equal(registration{expectedWorker, 2 * interval})))
 				})
 
+				Context("when Baggageclaim cannot report its disk usage", func() {
+					BeforeEach(func() {
+						fakeBaggageclaimClient.DiskUsageReturns(baggageclaim.DiskUsageResponse{}, errors.New("not found"))
+					})
+
+					It("registers without it", func() {
+						expectedWorker.ActiveContainers = 2
+						expectedWorker.ActiveVolumes = 3
+						expectedWorker.DiskUsage = nil
+						Eventually(registrations).Should(Receive(Equal(registration{expectedWorker, 2 * interval})))
+					})
+				})
+
 				It("emits events", func() {
 					Eventually(registrations).Should(Receive())
 
@@ -0,0 +1,83 @@
+package tsacmd
+
+import (
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// certificateAuthority issues the client certificates with which workers
+// connect to the TSA over mutual TLS.
+type certificateAuthority struct {
+	cert     tls.Certificate
+	lifetime time.Duration
+}
+
+func loadCertificateAuthority(certFile string, keyFile string, lifetime time.Duration) (*certificateAuthority, error) {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	cert.Leaf, err = x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		return nil, err
+	}
+
+	if !cert.Leaf.IsCA {
+		return nil, fmt.Errorf("certificate in %s is not a CA", certFile)
+	}
+
+	return &certificateAuthority{
+		cert:     cert,
+		lifetime: lifetime,
+	}, nil
+}
+
+// Issue signs the certificate request of the named worker, returning the
+// PEM encoded certificate. The worker's team, if any, is the certificate's
+// organization.
+func (ca *certificateAuthority) Issue(csrDER []byte, workerName string, team string) ([]byte, error) {
+	csr, err := x509.ParseCertificateRequest(csrDER)
+	if err != nil {
+		return nil, err
+	}
+
+	err = csr.CheckSignature()
+	if err != nil {
+		return nil, err
+	}
+
+	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, err
+	}
+
+	subject := pkix.Name{CommonName: workerName}
+	if team != "" {
+		subject.Organization = []string{team}
+	}
+
+	now := time.Now()
+
+	template := &x509.Certificate{
+		SerialNumber: serialNumber,
+		Subject:      subject,
+		NotBefore:    now.Add(-time.Minute),
+		NotAfter:     now.Add(ca.lifetime),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert.Leaf, csr.PublicKey, ca.cert.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), nil
+}
@@ -3,6 +3,8 @@ package tsacmd
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"net/http"
 	"os"
@@ -44,6 +46,16 @@ type TSACommand struct {
 	TeamAuthorizedKeys     map[string]flag.AuthorizedKeys `long:"team-authorized-keys" value-name:"NAME:PATH" description:"Path to file containing keys to authorize, in SSH authorized_keys format (one public key per line)."`
 	TeamAuthorizedKeysFile flag.File                      `long:"team-authorized-keys-file" description:"Path to file containing a YAML array of teams and their authorized SSH keys, e.g. [{team:foo,ssh_keys:[key1,key2]}]."`
 
+	TeamWorkerKeysSyncInterval time.Duration `long:"team-worker-keys-sync-interval" default:"30s" description:"Interval on which to fetch the worker keys which teams manage through the API. 0 disables team-managed worker keys."`
+
+	TLSBindPort            uint16        `long:"tls-bind-port" default:"2223" description:"Port on which to listen for SSH over mutual TLS. Only used when --tls-ca-cert is configured."`
+	TLSCert                flag.File     `long:"tls-cert" description:"File containing the certificate presented to workers connecting over mutual TLS. Reloaded on SIGHUP."`
+	TLSKey                 flag.File     `long:"tls-key" description:"File containing the private key of the TLS certificate. Reloaded on SIGHUP."`
+	TLSCACert              flag.File     `long:"tls-ca-cert" description:"File containing the CA certificate(s) with which to verify worker certificates. Enables mutual TLS."`
+	TLSCAKey               flag.File     `long:"tls-ca-key" description:"File containing the private key of the first certificate in --tls-ca-cert. When configured, workers may request certificates from the TSA, which they renew automatically."`
+	TLSCertificateLifetime time.Duration `long:"tls-certificate-lifetime" default:"24h" description:"Lifetime of the certificates issued to workers."`
+	TLSBootstrapToken      string        `long:"tls-bootstrap-token" description:"Token with which workers without an SSH key may request their first certificate."`
+
 	ATCURLs []flag.URL `long:"atc-url" required:"true" description:"ATC API endpoints to which workers will be registered."`
 
 	ClientID     string   `long:"client-id" default:"concourse-worker" description:"Client used to fetch a token from the auth server. NOTE: if you change this value you will also need to change the --system-claim-value flag so the atc knows to allow requests from this client."`
@@ -114,7 +126,9 @@ func (cmd *TSACommand) Runner(args []string) (ifrit.Runner, error) {
 		lock:         &sync.RWMutex{},
 	}
 
-	config, err := cmd.configureSSHServer(sessionAuthTeam, cmd.AuthorizedKeys.Keys, teamAuthorizedKeys)
+	managedKeys := &teamWorkerKeys{}
+
+	config, err := cmd.configureSSHServer(sessionAuthTeam, cmd.AuthorizedKeys.Keys, teamAuthorizedKeys, managedKeys)
 	if err != nil {
 		return nil, fmt.Errorf("failed to configure SSH server: %s", err)
 	}
@@ -144,6 +158,38 @@ func (cmd *TSACommand) Runner(args []string) (ifrit.Runner, error) {
 		sessionTeam:          sessionAuthTeam,
 		gardenRequestTimeout: cmd.GardenRequestTimeout,
 	}
+
+	var tlsConfig *tls.Config
+	var tlsCert *reloadableCertificate
+	if cmd.TLSCACert != "" {
+		if cmd.TLSCert == "" || cmd.TLSKey == "" {
+			return nil, fmt.Errorf("--tls-cert and --tls-key must be configured along with --tls-ca-cert")
+		}
+
+		tlsCert, err = loadReloadableCertificate(cmd.TLSCert.Path(), cmd.TLSKey.Path())
+		if err != nil {
+			return nil, fmt.Errorf("failed to load TLS certificate: %s", err)
+		}
+
+		tlsConfig, err = cmd.configureTLS(tlsCert)
+		if err != nil {
+			return nil, fmt.Errorf("failed to configure TLS: %s", err)
+		}
+
+		if cmd.TLSCAKey != "" {
+			server.ca, err = loadCertificateAuthority(cmd.TLSCACert.Path(), cmd.TLSCAKey.Path(), cmd.TLSCertificateLifetime)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load TLS CA: %s", err)
+			}
+		}
+
+		server.bootstrapToken = cmd.TLSBootstrapToken
+	}
+
+	if cmd.TeamWorkerKeysSyncInterval != 0 {
+		go managedKeys.SyncEvery(logger, atcEndpointPicker, httpClient, cmd.TeamWorkerKeysSyncInterval)
+	}
+
 	// Starts a goroutine whose purpose is to listen to the
 	// SIGHUP syscall and reload configuration upon receiving the signal.
 	// For now it only reloads the TSACommand.AuthorizedKeys but
@@ -172,17 +218,56 @@ func (cmd *TSACommand) Runner(args []string) (ifrit.Runner, error) {
 			}
 
 			// Reconfigure the SSH server with the new keys
-			config, err := cmd.configureSSHServer(sessionAuthTeam, cmd.AuthorizedKeys.Keys, teamAuthorizedKeys)
+			config, err := cmd.configureSSHServer(sessionAuthTeam, cmd.AuthorizedKeys.Keys, teamAuthorizedKeys, managedKeys)
 			if err != nil {
 				logger.Error("failed to configure SSH server: %s", err)
 				continue
 			}
 
 			server.config = config
+
+			if tlsCert != nil {
+				err := tlsCert.Reload()
+				if err != nil {
+					logger.Error("failed to reload TLS certificate : %s", err)
+					continue
+				}
+			}
 		}
 	}()
 
-	return serverRunner{logger, server, listenAddr}, nil
+	runner := serverRunner{logger, server, listenAddr, nil}
+	if tlsConfig == nil {
+		return runner, nil
+	}
+
+	tlsListenAddr := fmt.Sprintf("%s:%d", cmd.BindIP, cmd.TLSBindPort)
+
+	return grouper.NewParallel(os.Interrupt, grouper.Members{
+		{Name: "ssh", Runner: runner},
+		{Name: "tls", Runner: serverRunner{logger.Session("tls"), server, tlsListenAddr, tlsConfig}},
+	}), nil
+}
+
+// configureTLS requires workers connecting over TLS to present a certificate
+// signed by the CA, unless they are only requesting their first certificate.
+func (cmd *TSACommand) configureTLS(cert *reloadableCertificate) (*tls.Config, error) {
+	caCerts, err := ioutil.ReadFile(cmd.TLSCACert.Path())
+	if err != nil {
+		return nil, err
+	}
+
+	clientCAs := x509.NewCertPool()
+	if !clientCAs.AppendCertsFromPEM(caCerts) {
+		return nil, fmt.Errorf("no certificates found in %s", cmd.TLSCACert.Path())
+	}
+
+	return &tls.Config{
+		GetCertificate: cert.GetCertificate,
+		ClientCAs:      clientCAs,
+		ClientAuth:     tls.VerifyClientCertIfGiven,
+		MinVersion:     tls.VersionTLS12,
+	}, nil
 }
 
 func (cmd *TSACommand) constructLogger() (lager.Logger, *lager.ReconfigurableSink) {
@@ -238,7 +323,7 @@ func (cmd *TSACommand) loadTeamAuthorizedKeys() ([]TeamAuthKeys, error) {
 	return teamKeys, nil
 }
 
-func (cmd *TSACommand) configureSSHServer(sessionAuthTeam *sessionTeam, authorizedKeys []ssh.PublicKey, teamAuthorizedKeys []TeamAuthKeys) (*ssh.ServerConfig, error) {
+func (cmd *TSACommand) configureSSHServer(sessionAuthTeam *sessionTeam, authorizedKeys []ssh.PublicKey, teamAuthorizedKeys []TeamAuthKeys, managedKeys *teamWorkerKeys) (*ssh.ServerConfig, error) {
 	certChecker := &ssh.CertChecker{
 		IsUserAuthority: func(key ssh.PublicKey) bool {
 			return false
@@ -255,11 +340,13 @@ func (cmd *TSACommand) configureSSHServer(sessionAuthTeam *sessionTeam, authoriz
 				}
 			}
 
-			for _, teamKeys := range teamAuthorizedKeys {
-				for _, k := range teamKeys.AuthKeys {
-					if bytes.Equal(k.Marshal(), key.Marshal()) {
-						sessionAuthTeam.AuthorizeTeam(string(conn.SessionID()), teamKeys.Team)
-						return nil, nil
+			for _, allTeamKeys := range [][]TeamAuthKeys{teamAuthorizedKeys, managedKeys.Keys()} {
+				for _, teamKeys := range allTeamKeys {
+					for _, k := range teamKeys.AuthKeys {
+						if bytes.Equal(k.Marshal(), key.Marshal()) {
+							sessionAuthTeam.AuthorizeTeam(string(conn.SessionID()), teamKeys.Team)
+							return nil, nil
+						}
 					}
 				}
 			}
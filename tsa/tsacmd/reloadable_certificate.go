@@ -0,0 +1,50 @@
+package tsacmd
+
+import (
+	"crypto/tls"
+	"sync"
+)
+
+// reloadableCertificate is the TSA's TLS certificate, which can be reloaded
+// from its files so that it can be rotated without restarting.
+type reloadableCertificate struct {
+	certFile string
+	keyFile  string
+
+	cert *tls.Certificate
+	lock sync.RWMutex
+}
+
+func loadReloadableCertificate(certFile string, keyFile string) (*reloadableCertificate, error) {
+	cert := &reloadableCertificate{
+		certFile: certFile,
+		keyFile:  keyFile,
+	}
+
+	err := cert.Reload()
+	if err != nil {
+		return nil, err
+	}
+
+	return cert, nil
+}
+
+func (c *reloadableCertificate) Reload() error {
+	cert, err := tls.LoadX509KeyPair(c.certFile, c.keyFile)
+	if err != nil {
+		return err
+	}
+
+	c.lock.Lock()
+	c.cert = &cert
+	c.lock.Unlock()
+
+	return nil
+}
+
+func (c *reloadableCertificate) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	return c.cert, nil
+}
@@ -37,7 +37,7 @@ func (req forwardWorkerRequest) Handle(ctx context.Context, state ConnState, cha
 		return err
 	}
 
-	if err := checkTeam(state, worker); err != nil {
+	if err := checkWorker(state, worker); err != nil {
 		return err
 	}
 
@@ -67,6 +67,11 @@ func (req forwardWorkerRequest) Handle(ctx context.Context, state ConnState, cha
 		return fmt.Errorf("baggageclaim address (%s) not forwarded", req.baggageclaimAddr)
 	}
 
+	worker.Certificate = nil
+	if state.Certificate != nil {
+		worker.Certificate = tsa.WorkerCertificate(state.Certificate)
+	}
+
 	worker.GardenAddr = fmt.Sprintf("%s:%d", req.server.forwardHost, gardenForward.BoundPort)
 	worker.BaggageclaimURL = fmt.Sprintf("http://%s:%d", req.server.forwardHost, baggageclaimForward.BoundPort)
 
@@ -139,6 +144,14 @@ type landWorkerRequest struct {
 	server *server
 }
 
+func checkWorker(state ConnState, worker atc.Worker) error {
+	if err := checkTeam(state, worker); err != nil {
+		return err
+	}
+
+	return checkCertificate(state, worker)
+}
+
 func checkTeam(state ConnState, worker atc.Worker) error {
 	if state.Team == "" {
 		// global keys can be used for all teams
@@ -156,6 +169,18 @@ func checkTeam(state ConnState, worker atc.Worker) error {
 	return nil
 }
 
+func checkCertificate(state ConnState, worker atc.Worker) error {
+	if state.Certificate == nil {
+		return nil
+	}
+
+	if worker.Name != state.Certificate.Subject.CommonName {
+		return fmt.Errorf("certificate was issued to worker %s, not %s", state.Certificate.Subject.CommonName, worker.Name)
+	}
+
+	return nil
+}
+
 func (req landWorkerRequest) Handle(ctx context.Context, state ConnState, channel ssh.Channel) error {
 	var worker atc.Worker
 	err := json.NewDecoder(channel).Decode(&worker)
@@ -163,7 +188,7 @@ func (req landWorkerRequest) Handle(ctx context.Context, state ConnState, channe
 		return err
 	}
 
-	if err := checkTeam(state, worker); err != nil {
+	if err := checkWorker(state, worker); err != nil {
 		return err
 	}
 
@@ -184,7 +209,7 @@ func (req retireWorkerRequest) Handle(ctx context.Context, state ConnState, chan
 		return err
 	}
 
-	if err := checkTeam(state, worker); err != nil {
+	if err := checkWorker(state, worker); err != nil {
 		return err
 	}
 
@@ -205,7 +230,7 @@ func (req deleteWorkerRequest) Handle(ctx context.Context, state ConnState, chan
 		return err
 	}
 
-	if err := checkTeam(state, worker); err != nil {
+	if err := checkWorker(state, worker); err != nil {
 		return err
 	}
 
@@ -215,6 +240,51 @@ func (req deleteWorkerRequest) Handle(ctx context.Context, state ConnState, chan
 	}).Delete(ctx, worker)
 }
 
+type issueCertificateRequest struct {
+	server *server
+
+	csr []byte
+}
+
+func (req issueCertificateRequest) Handle(ctx context.Context, state ConnState, channel ssh.Channel) error {
+	logger := lagerctx.FromContext(ctx)
+
+	var worker atc.Worker
+	err := json.NewDecoder(channel).Decode(&worker)
+	if err != nil {
+		return err
+	}
+
+	if err := checkWorker(state, worker); err != nil {
+		return err
+	}
+
+	if req.server.ca == nil {
+		return fmt.Errorf("the TSA is not configured to issue certificates")
+	}
+
+	// workers authenticated with a team's key are issued certificates for
+	// that team; global keys can be used for all teams
+	team := state.Team
+	if team == "" {
+		team = worker.Team
+	}
+
+	cert, err := req.server.ca.Issue(req.csr, worker.Name, team)
+	if err != nil {
+		logger.Error("failed-to-issue-certificate", err)
+		return err
+	}
+
+	logger.Info("issued-certificate", lager.Data{
+		"worker": worker.Name,
+		"team":   team,
+	})
+
+	_, err = channel.Write(cert)
+	return err
+}
+
 type sweepContainersRequest struct {
 	server *server
 }
@@ -226,7 +296,7 @@ func (req sweepContainersRequest) Handle(ctx context.Context, state ConnState, c
 		return err
 	}
 
-	if err := checkTeam(state, worker); err != nil {
+	if err := checkWorker(state, worker); err != nil {
 		return err
 	}
 
@@ -260,7 +330,7 @@ func (req reportContainersRequest) Handle(ctx context.Context, state ConnState,
 		return err
 	}
 
-	if err := checkTeam(state, worker); err != nil {
+	if err := checkWorker(state, worker); err != nil {
 		return err
 	}
 
@@ -282,7 +352,7 @@ func (req sweepVolumesRequest) Handle(ctx context.Context, state ConnState, chan
 		return err
 	}
 
-	if err := checkTeam(state, worker); err != nil {
+	if err := checkWorker(state, worker); err != nil {
 		return err
 	}
 
@@ -316,7 +386,7 @@ func (req reportVolumesRequest) Handle(ctx context.Context, state ConnState, cha
 		return err
 	}
 
-	if err := checkTeam(state, worker); err != nil {
+	if err := checkWorker(state, worker); err != nil {
 		return err
 	}
 
@@ -1,7 +1,12 @@
 package tsacmd
 
 import (
+	"bytes"
 	"context"
+	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
 	"errors"
 	"flag"
 	"fmt"
@@ -30,8 +35,20 @@ type server struct {
 	config               *ssh.ServerConfig
 	httpClient           *http.Client
 	sessionTeam          *sessionTeam
+
+	// ca issues certificates to workers, when configured.
+	ca *certificateAuthority
+
+	// bootstrapToken allows workers connecting over TLS without a certificate
+	// or SSH key to request their first certificate.
+	bootstrapToken string
 }
 
+const (
+	certificatePermission = "certificate"
+	bootstrapPermission   = "bootstrap"
+)
+
 type sessionTeam struct {
 	sessionTeams map[string]string
 	lock         *sync.RWMutex
@@ -63,6 +80,14 @@ func (s *sessionTeam) AuthorizedTeamFor(sessionID string) string {
 type ConnState struct {
 	Team string
 
+	// Certificate is the client certificate the worker authenticated with,
+	// when connected over mutual TLS.
+	Certificate *x509.Certificate
+
+	// Bootstrap is true when the worker authenticated with the bootstrap
+	// token, and may only request a certificate.
+	Bootstrap bool
+
 	ForwardedTCPIPs <-chan ForwardedTCPIP
 }
 
@@ -103,7 +128,26 @@ func (server *server) Serve(listener net.Listener) {
 }
 
 func (server *server) handshake(logger lager.Logger, netConn net.Conn) {
-	conn, chans, reqs, err := ssh.NewServerConn(netConn, server.config)
+	config := server.config
+
+	var peerCert *x509.Certificate
+	if tlsConn, ok := netConn.(*tls.Conn); ok {
+		err := tlsConn.Handshake()
+		if err != nil {
+			logger.Info("tls-handshake-failed", lager.Data{"error": err.Error()})
+			netConn.Close()
+			return
+		}
+
+		chains := tlsConn.ConnectionState().VerifiedChains
+		if len(chains) > 0 {
+			peerCert = chains[0][0]
+		}
+
+		config = server.tlsSSHConfig(peerCert)
+	}
+
+	conn, chans, reqs, err := ssh.NewServerConn(netConn, config)
 	if err != nil {
 		logger.Info("handshake-failed", lager.Data{"error": err.Error()})
 		return
@@ -125,6 +169,14 @@ func (server *server) handshake(logger lager.Logger, netConn net.Conn) {
 		ForwardedTCPIPs: forwardedTCPIPs,
 	}
 
+	if conn.Permissions != nil {
+		if conn.Permissions.Extensions[certificatePermission] != "" {
+			state.Certificate = peerCert
+		}
+
+		state.Bootstrap = conn.Permissions.Extensions[bootstrapPermission] != ""
+	}
+
 	chansGroup := new(sync.WaitGroup)
 
 	for newChannel := range chans {
@@ -150,6 +202,48 @@ func (server *server) handshake(logger lager.Logger, netConn net.Conn) {
 	chansGroup.Wait()
 }
 
+// tlsSSHConfig authenticates workers connected over mutual TLS. A worker
+// which presented a certificate signed by the CA authenticates with the
+// certificate's key, and is authorized for the team the certificate was
+// issued for. Otherwise the worker must authenticate with an authorized SSH
+// key, or with the bootstrap token in order to request its first
+// certificate.
+func (server *server) tlsSSHConfig(peerCert *x509.Certificate) *ssh.ServerConfig {
+	config := *server.config
+
+	authenticateKey := config.PublicKeyCallback
+	config.PublicKeyCallback = func(conn ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+		if peerCert != nil {
+			certKey, err := ssh.NewPublicKey(peerCert.PublicKey)
+			if err == nil && bytes.Equal(certKey.Marshal(), key.Marshal()) {
+				if len(peerCert.Subject.Organization) > 0 {
+					server.sessionTeam.AuthorizeTeam(string(conn.SessionID()), peerCert.Subject.Organization[0])
+				}
+
+				return &ssh.Permissions{
+					Extensions: map[string]string{certificatePermission: "true"},
+				}, nil
+			}
+		}
+
+		return authenticateKey(conn, key)
+	}
+
+	if server.bootstrapToken != "" {
+		config.PasswordCallback = func(conn ssh.ConnMetadata, password []byte) (*ssh.Permissions, error) {
+			if subtle.ConstantTimeCompare(password, []byte(server.bootstrapToken)) != 1 {
+				return nil, errors.New("invalid bootstrap token")
+			}
+
+			return &ssh.Permissions{
+				Extensions: map[string]string{bootstrapPermission: "true"},
+			}, nil
+		}
+	}
+
+	return &config
+}
+
 type signalMsg struct {
 	Signal string
 }
@@ -214,6 +308,12 @@ func (server *server) handleChannel(
 					continue
 				}
 
+				if state.Bootstrap && command != tsa.IssueCertificate {
+					fmt.Fprintf(channel, "the bootstrap token may only be used to %s", tsa.IssueCertificate)
+					req.Reply(false, nil)
+					continue
+				}
+
 				req.Reply(true, nil)
 
 				cmdLogger := logger.Session("command", lager.Data{
@@ -520,6 +620,20 @@ func (server *server) parseRequest(cli string) (request, string, error) {
 		req = deleteWorkerRequest{
 			server: server,
 		}
+	case tsa.IssueCertificate:
+		if len(args) != 1 {
+			return nil, "", fmt.Errorf("expected a certificate request")
+		}
+
+		csr, err := base64.RawURLEncoding.DecodeString(args[0])
+		if err != nil {
+			return nil, "", fmt.Errorf("malformed certificate request: %s", err)
+		}
+
+		req = issueCertificateRequest{
+			server: server,
+			csr:    csr,
+		}
 	case tsa.SweepContainers:
 		req = sweepContainersRequest{
 			server: server,
@@ -1,6 +1,7 @@
 package tsacmd
 
 import (
+	"crypto/tls"
 	"fmt"
 	"net"
 	"os"
@@ -14,6 +15,9 @@ type serverRunner struct {
 	server *server
 
 	listenAddr string
+
+	// tlsConfig is set when the server listens for SSH over mutual TLS.
+	tlsConfig *tls.Config
 }
 
 func (runner serverRunner) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
@@ -22,6 +26,10 @@ func (runner serverRunner) Run(signals <-chan os.Signal, ready chan<- struct{})
 		return fmt.Errorf("failed to listen on %s: %s", runner.listenAddr, err)
 	}
 
+	if runner.tlsConfig != nil {
+		listener = tls.NewListener(listener, runner.tlsConfig)
+	}
+
 	runner.logger.Info("listening")
 
 	close(ready)
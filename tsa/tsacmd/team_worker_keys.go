@@ -0,0 +1,77 @@
+package tsacmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/tsa"
+	"golang.org/x/crypto/ssh"
+)
+
+// teamWorkerKeys are the keys which teams manage themselves through the API,
+// as opposed to those configured on the TSA. They are synced periodically.
+type teamWorkerKeys struct {
+	keys []TeamAuthKeys
+	lock sync.RWMutex
+}
+
+func (k *teamWorkerKeys) Keys() []TeamAuthKeys {
+	k.lock.RLock()
+	defer k.lock.RUnlock()
+
+	return k.keys
+}
+
+func (k *teamWorkerKeys) Sync(ctx context.Context, fetcher *tsa.WorkerKeysFetcher) error {
+	logger := lagerctx.FromContext(ctx)
+
+	teamKeys, err := fetcher.Fetch(ctx)
+	if err != nil {
+		return err
+	}
+
+	var keys []TeamAuthKeys
+	for _, t := range teamKeys {
+		var authKeys []ssh.PublicKey
+		for _, k := range t.Keys {
+			key, _, _, _, err := ssh.ParseAuthorizedKey([]byte(k))
+			if err != nil {
+				logger.Error("parse-team-worker-key", fmt.Errorf("Invalid format, ignoring (%s): %s", k, err.Error()), lager.Data{"team": t.Team})
+				continue
+			}
+
+			authKeys = append(authKeys, key)
+		}
+
+		keys = append(keys, TeamAuthKeys{Team: t.Team, AuthKeys: authKeys})
+	}
+
+	k.lock.Lock()
+	k.keys = keys
+	k.lock.Unlock()
+
+	return nil
+}
+
+func (k *teamWorkerKeys) SyncEvery(logger lager.Logger, atcEndpointPicker tsa.EndpointPicker, httpClient *http.Client, interval time.Duration) {
+	logger = logger.Session("sync-team-worker-keys")
+
+	for {
+		fetcher := &tsa.WorkerKeysFetcher{
+			ATCEndpoint: atcEndpointPicker.Pick(),
+			HTTPClient:  httpClient,
+		}
+
+		err := k.Sync(lagerctx.NewContext(context.Background(), logger), fetcher)
+		if err != nil {
+			logger.Error("failed-to-sync", err)
+		}
+
+		time.Sleep(interval)
+	}
+}
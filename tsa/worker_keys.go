@@ -0,0 +1,62 @@
+package tsa
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httputil"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc"
+	"github.com/tedsuo/rata"
+)
+
+// WorkerKeysFetcher fetches the SSH keys which teams have configured for
+// registering their own workers.
+type WorkerKeysFetcher struct {
+	ATCEndpoint *rata.RequestGenerator
+	HTTPClient  *http.Client
+}
+
+func (f *WorkerKeysFetcher) Fetch(ctx context.Context) ([]atc.TeamWorkerKeys, error) {
+	logger := lagerctx.FromContext(ctx)
+
+	logger.Debug("start")
+	defer logger.Debug("end")
+
+	request, err := f.ATCEndpoint.CreateRequest(atc.ListWorkerKeys, nil, nil)
+	if err != nil {
+		logger.Error("failed-to-construct-request", err)
+		return nil, err
+	}
+
+	response, err := f.HTTPClient.Do(request)
+	if err != nil {
+		logger.Error("failed-to-fetch-worker-keys", err)
+		return nil, err
+	}
+
+	logger.Debug("atc-response", lager.Data{"response-status": response.StatusCode})
+
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		logger.Error("bad-response", nil, lager.Data{
+			"status-code": response.StatusCode,
+		})
+
+		b, _ := httputil.DumpResponse(response, true)
+		return nil, fmt.Errorf("bad-response (%d): %s", response.StatusCode, string(b))
+	}
+
+	var teamKeys []atc.TeamWorkerKeys
+	err = json.NewDecoder(response.Body).Decode(&teamKeys)
+	if err != nil {
+		logger.Error("failed-to-decode-response-body", err)
+		return nil, err
+	}
+
+	return teamKeys, nil
+}
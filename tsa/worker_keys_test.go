@@ -0,0 +1,78 @@
+package tsa_test
+
+import (
+	"context"
+
+	"code.cloudfoundry.org/lager/lagerctx"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/tsa"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	"github.com/tedsuo/rata"
+	"golang.org/x/oauth2"
+)
+
+var _ = Describe("WorkerKeysFetcher", func() {
+	var (
+		fetcher *tsa.WorkerKeysFetcher
+
+		ctx     context.Context
+		fakeATC *ghttp.Server
+	)
+
+	BeforeEach(func() {
+		ctx = lagerctx.NewContext(context.Background(), lagertest.NewTestLogger("test"))
+
+		fakeATC = ghttp.NewServer()
+
+		atcEndpoint := rata.NewRequestGenerator(fakeATC.URL(), atc.Routes)
+
+		token := &oauth2.Token{TokenType: "Bearer", AccessToken: "yo"}
+		httpClient := oauth2.NewClient(oauth2.NoContext, oauth2.StaticTokenSource(token))
+
+		fetcher = &tsa.WorkerKeysFetcher{
+			ATCEndpoint: atcEndpoint,
+			HTTPClient:  httpClient,
+		}
+	})
+
+	AfterEach(func() {
+		fakeATC.Close()
+	})
+
+	Context("when the ATC returns the keys", func() {
+		BeforeEach(func() {
+			fakeATC.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", "/api/v1/worker_keys"),
+				ghttp.VerifyHeaderKV("Authorization", "Bearer yo"),
+				ghttp.RespondWithJSONEncoded(200, []atc.TeamWorkerKeys{
+					{Team: "some-team", Keys: []string{"some-key"}},
+				}),
+			))
+		})
+
+		It("returns the keys of each team", func() {
+			teamKeys, err := fetcher.Fetch(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(teamKeys).To(Equal([]atc.TeamWorkerKeys{
+				{Team: "some-team", Keys: []string{"some-key"}},
+			}))
+		})
+	})
+
+	Context("when the ATC responds with an error", func() {
+		BeforeEach(func() {
+			fakeATC.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", "/api/v1/worker_keys"),
+				ghttp.RespondWith(500, nil, nil),
+			))
+		})
+
+		It("errors", func() {
+			_, err := fetcher.Fetch(ctx)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -220,6 +220,11 @@ handleEvent event ( model, effects ) =
             , effects
             )
 
+        Skipped origin ->
+            ( updateStep origin.id (setStepState StepStateCancelled) model
+            , effects
+            )
+
         InitializeGet origin time ->
             ( updateStep origin.id (setInitialize time) model
             , effects
@@ -285,6 +290,9 @@ handleEvent event ( model, effects ) =
             , effects
             )
 
+        InterceptLog ->
+            ( model, effects )
+
         End ->
             ( { model | state = StepsComplete, eventStreamUrlPath = Nothing }
             , effects
@@ -66,6 +66,7 @@ type StepTree
     | Ensure HookedStep
     | Try StepTree
     | Timeout StepTree
+    | When StepTree
 
 
 type alias HookedStep =
@@ -197,6 +198,7 @@ type BuildEvent
     | Initialize Origin Time.Posix
     | Start Origin Time.Posix
     | Finish Origin Time.Posix Bool
+    | Skipped Origin
     | InitializeGet Origin Time.Posix
     | StartGet Origin Time.Posix
     | FinishGet Origin Int Concourse.Version Concourse.Metadata (Maybe Time.Posix)
@@ -213,6 +215,7 @@ type BuildEvent
     | ImageCheck Origin Concourse.BuildPlan
     | ImageGet Origin Concourse.BuildPlan
     | AcrossSubsteps Origin (List Concourse.AcrossSubstep)
+    | InterceptLog
     | End
     | Opened
     | NetworkError
@@ -316,6 +319,9 @@ activeStepIds model tree =
         Timeout subTree ->
             activeStepIds model subTree
 
+        When subTree ->
+            activeStepIds model subTree
+
         Retry _ trees ->
             trees
                 |> Array.toList
@@ -417,6 +423,9 @@ updateTreeNodeAt id fn tree =
         Timeout subTree ->
             Timeout <| updateTreeNodeAt id fn subTree
 
+        When subTree ->
+            When <| updateTreeNodeAt id fn subTree
+
         Retry stepId trees ->
             let
                 withUpdatedChildren =
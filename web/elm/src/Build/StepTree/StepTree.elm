@@ -178,6 +178,9 @@ init buildId hl resources plan =
         Concourse.BuildStepTimeout subPlan ->
             initWrappedStep buildId hl resources Timeout subPlan
 
+        Concourse.BuildStepWhen subPlan ->
+            initWrappedStep buildId hl resources When subPlan
+
 
 setImagePlans : Maybe Concourse.JobBuildIdentifier -> StepID -> Maybe Concourse.ImageBuildPlans -> StepTreeModel -> StepTreeModel
 setImagePlans buildId stepId imagePlans model =
@@ -662,6 +665,9 @@ viewTree session model tree depth =
         Timeout subTree ->
             viewTree session model subTree depth
 
+        When subTree ->
+            viewTree session model subTree depth
+
         Aggregate trees ->
             Html.div [ class "aggregate" ]
                 (Array.toList <| Array.map (viewSeq session model depth) trees)
@@ -1316,6 +1322,9 @@ viewStepHeader step =
         Concourse.BuildStepTimeout _ ->
             Html.text ""
 
+        Concourse.BuildStepWhen _ ->
+            Html.text ""
+
 
 stepName : Concourse.BuildStep -> Maybe String
 stepName header =
@@ -1383,6 +1392,9 @@ stepName header =
         Concourse.BuildStepTimeout _ ->
             Nothing
 
+        Concourse.BuildStepWhen _ ->
+            Nothing
+
 
 resourceName : Concourse.BuildStep -> Maybe String
 resourceName step =
@@ -468,6 +468,9 @@ mapBuildPlan fn plan =
 
                 BuildStepTimeout step ->
                     mapBuildPlan fn step
+
+                BuildStepWhen step ->
+                    mapBuildPlan fn step
            )
 
 
@@ -507,6 +510,7 @@ type BuildStep
     | BuildStepTry BuildPlan
     | BuildStepRetry (Array BuildPlan)
     | BuildStepTimeout BuildPlan
+    | BuildStepWhen BuildPlan
 
 
 type alias HookedPlan =
@@ -702,6 +706,8 @@ decodeBuildPlan =
                     lazy (\_ -> decodeBuildStepRetry)
                 , Json.Decode.field "timeout" <|
                     lazy (\_ -> decodeBuildStepTimeout)
+                , Json.Decode.field "when" <|
+                    lazy (\_ -> decodeBuildStepWhen)
                 , Json.Decode.field "set_pipeline" <|
                     lazy (\_ -> decodeBuildSetPipeline)
                 , Json.Decode.field "load_var" <|
@@ -848,6 +854,12 @@ decodeBuildStepTimeout =
         |> andMap (Json.Decode.field "step" <| lazy (\_ -> decodeBuildPlan))
 
 
+decodeBuildStepWhen : Json.Decode.Decoder BuildStep
+decodeBuildStepWhen =
+    Json.Decode.succeed BuildStepWhen
+        |> andMap (Json.Decode.field "step" <| lazy (\_ -> decodeBuildPlan))
+
+
 decodeBuildSetPipeline : Json.Decode.Decoder BuildStep
 decodeBuildSetPipeline =
     Json.Decode.succeed BuildStepSetPipeline
@@ -910,6 +922,7 @@ type alias FeatureFlags =
     , pipeline_instances : Bool
     , cache_streamed_volumes : Bool
     , resource_causality : Bool
+    , volume_digests : Bool
     }
 
 
@@ -922,6 +935,7 @@ defaultFeatureFlags =
     , pipeline_instances = False
     , cache_streamed_volumes = False
     , resource_causality = False
+    , volume_digests = False
     }
 
 
@@ -184,6 +184,13 @@ decodeBuildEvent =
                                 (Json.Decode.field "succeeded" Json.Decode.bool)
                             )
 
+                    "skipped" ->
+                        Json.Decode.field
+                            "data"
+                            (Json.Decode.map Skipped
+                                (Json.Decode.field "origin" decodeOrigin)
+                            )
+
                     "initialize-get" ->
                         Json.Decode.field
                             "data"
@@ -253,6 +260,9 @@ decodeBuildEvent =
                                 )
                             )
 
+                    "intercept-log" ->
+                        Json.Decode.succeed InterceptLog
+
                     unknown ->
                         Json.Decode.fail ("unknown event type: " ++ unknown)
             )
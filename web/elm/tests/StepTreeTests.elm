@@ -12,6 +12,7 @@ module StepTreeTests exposing
     , initTask
     , initTimeout
     , initTry
+    , initWhen
     )
 
 import Ansi.Log
@@ -47,6 +48,7 @@ all =
         , initEnsure
         , initTry
         , initTimeout
+        , initWhen
         ]
 
 
@@ -790,6 +792,32 @@ initTimeout =
         ]
 
 
+initWhen : Test
+initWhen =
+    let
+        { tree, steps } =
+            StepTree.init Nothing
+                Routes.HighlightNothing
+                emptyResources
+                { id = "when-id"
+                , step =
+                    BuildStepWhen { id = "task-a-id", step = task "a" }
+                }
+    in
+    describe "init with When"
+        [ test "the tree" <|
+            \_ ->
+                Expect.equal
+                    (Models.When <|
+                        Models.Task "task-a-id"
+                    )
+                    tree
+        , test "the steps" <|
+            \_ ->
+                assertSteps [ someStep "task-a-id" (task "a") Models.StepStatePending ] steps
+        ]
+
+
 assertSteps : List Models.Step -> Dict Routes.StepID Models.Step -> Expectation
 assertSteps expected actual =
     Expect.equalDicts (Dict.fromList (List.map (\s -> ( s.id, s )) expected)) actual
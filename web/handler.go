@@ -1,7 +1,6 @@
 package web
 
 import (
-	"embed"
 	"fmt"
 	"io/fs"
 	"net/http"
@@ -10,16 +9,13 @@ import (
 	"code.cloudfoundry.org/lager"
 )
 
-//go:embed public
-var publicEmbed embed.FS
-
 func NewHandler(logger lager.Logger, livePublicDir string) (http.Handler, error) {
 	var publicFS fs.FS
 	if livePublicDir != "" {
 		publicFS = os.DirFS(livePublicDir)
 	} else {
 		var err error
-		publicFS, err = fs.Sub(publicEmbed, "public")
+		publicFS, err = embeddedPublicFS()
 		if err != nil {
 			return nil, fmt.Errorf("public fs sub: %w", err)
 		}
@@ -33,3 +29,17 @@ func NewHandler(logger lager.Logger, livePublicDir string) (http.Handler, error)
 
 	return webMux, nil
 }
+
+// NewHeadlessHandler returns the handler of an ATC which serves the API only.
+// Neither the web UI nor its assets are served, and all there is left is
+// robots.txt.
+func NewHeadlessHandler() http.Handler {
+	webMux := http.NewServeMux()
+
+	webMux.Handle("/robots.txt", RobotsHandler)
+	webMux.Handle("/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "the web UI is disabled, as this ATC serves the API only", http.StatusNotFound)
+	}))
+
+	return webMux
+}
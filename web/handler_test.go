@@ -0,0 +1,43 @@
+package web_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/concourse/concourse/web"
+)
+
+var _ = Describe("NewHeadlessHandler", func() {
+	var recorder *httptest.ResponseRecorder
+
+	serve := func(path string) {
+		request, err := http.NewRequest("GET", path, nil)
+		Expect(err).ToNot(HaveOccurred())
+
+		recorder = httptest.NewRecorder()
+		web.NewHeadlessHandler().ServeHTTP(recorder, request)
+	}
+
+	It("does not serve the web UI", func() {
+		serve("/teams/main/pipelines/some-pipeline")
+
+		Expect(recorder.Code).To(Equal(http.StatusNotFound))
+		Expect(recorder.Body.String()).To(ContainSubstring("serves the API only"))
+	})
+
+	It("does not serve the public assets", func() {
+		serve("/public/elm.min.js")
+
+		Expect(recorder.Code).To(Equal(http.StatusNotFound))
+	})
+
+	It("still serves robots.txt", func() {
+		serve("/robots.txt")
+
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		Expect(recorder.Body.String()).To(ContainSubstring("Disallow: /"))
+	})
+})
@@ -0,0 +1,20 @@
+//go:build !headless
+// +build !headless
+
+package web
+
+import (
+	"embed"
+	"io/fs"
+)
+
+// Headless is true for binaries built with the "headless" build tag, which
+// leaves the web UI's assets out of the binary.
+const Headless = false
+
+//go:embed public
+var publicEmbed embed.FS
+
+func embeddedPublicFS() (fs.FS, error) {
+	return fs.Sub(publicEmbed, "public")
+}
@@ -0,0 +1,17 @@
+//go:build headless
+// +build headless
+
+package web
+
+import (
+	"errors"
+	"io/fs"
+)
+
+// Headless is true for binaries built with the "headless" build tag, which
+// leaves the web UI's assets out of the binary.
+const Headless = true
+
+func embeddedPublicFS() (fs.FS, error) {
+	return nil, errors.New("built without the web UI")
+}
@@ -0,0 +1,49 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+)
+
+var ErrGetDiskUsageFailed = errors.New("failed to get disk usage")
+
+func NewDiskServer(
+	logger lager.Logger,
+	volumesDir string,
+) *DiskServer {
+	return &DiskServer{
+		volumesDir: volumesDir,
+		logger:     logger,
+	}
+}
+
+// DiskServer reports on the disk holding the volumes, so that volumes are
+// not placed on workers which are about to run out of space.
+type DiskServer struct {
+	volumesDir string
+
+	logger lager.Logger
+}
+
+func (server *DiskServer) GetDiskUsage(w http.ResponseWriter, req *http.Request) {
+	hLog := server.logger.Session("get-disk-usage")
+	hLog.Debug("start")
+	defer hLog.Debug("done")
+
+	usage, err := diskUsage(server.volumesDir)
+	if err != nil {
+		hLog.Error("failed-to-get-disk-usage", err)
+		RespondWithError(w, ErrGetDiskUsageFailed, http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	err = json.NewEncoder(w).Encode(usage)
+	if err != nil {
+		hLog.Error("failed-to-encode", err)
+	}
+}
@@ -0,0 +1,72 @@
+package api_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"regexp"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/worker/baggageclaim"
+	"github.com/concourse/concourse/worker/baggageclaim/api"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Disk Server", func() {
+	var (
+		handler    http.Handler
+		volumesDir string
+	)
+
+	BeforeEach(func() {
+		var err error
+		volumesDir, err = os.MkdirTemp("", "disk-server")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(volumesDir)).To(Succeed())
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		logger := lagertest.NewTestLogger("disk-server")
+		handler, err = api.NewHandler(logger, nil, nil, "", volumesDir, regexp.MustCompile("lo"), 4, 7766)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Describe("get disk usage", func() {
+		var recorder *httptest.ResponseRecorder
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequest("GET", "/disk-usage", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			recorder = httptest.NewRecorder()
+			handler.ServeHTTP(recorder, request)
+		})
+
+		It("returns the usage of the disk holding the volumes", func() {
+			Expect(recorder.Code).To(Equal(200))
+
+			var usage baggageclaim.DiskUsageResponse
+			err := json.NewDecoder(recorder.Body).Decode(&usage)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(usage.TotalBytes).To(BeNumerically(">", 0))
+			Expect(usage.UsedBytes).To(BeNumerically("<=", usage.TotalBytes))
+		})
+
+		Context("when the volumes dir does not exist", func() {
+			BeforeEach(func() {
+				Expect(os.RemoveAll(volumesDir)).To(Succeed())
+			})
+
+			It("returns 500", func() {
+				Expect(recorder.Code).To(Equal(500))
+			})
+		})
+	})
+})
@@ -0,0 +1,27 @@
+//go:build !windows
+// +build !windows
+
+package api
+
+import (
+	"golang.org/x/sys/unix"
+
+	"github.com/concourse/concourse/worker/baggageclaim"
+)
+
+func diskUsage(path string) (baggageclaim.DiskUsageResponse, error) {
+	var stat unix.Statfs_t
+	err := unix.Statfs(path, &stat)
+	if err != nil {
+		return baggageclaim.DiskUsageResponse{}, err
+	}
+
+	blockSize := uint64(stat.Bsize)
+	total := uint64(stat.Blocks) * blockSize
+	free := uint64(stat.Bfree) * blockSize
+
+	return baggageclaim.DiskUsageResponse{
+		TotalBytes: total,
+		UsedBytes:  total - free,
+	}, nil
+}
@@ -0,0 +1,25 @@
+package api
+
+import (
+	"golang.org/x/sys/windows"
+
+	"github.com/concourse/concourse/worker/baggageclaim"
+)
+
+func diskUsage(path string) (baggageclaim.DiskUsageResponse, error) {
+	dir, err := windows.UTF16PtrFromString(path)
+	if err != nil {
+		return baggageclaim.DiskUsageResponse{}, err
+	}
+
+	var free, total, totalFree uint64
+	err = windows.GetDiskFreeSpaceEx(dir, &free, &total, &totalFree)
+	if err != nil {
+		return baggageclaim.DiskUsageResponse{}, err
+	}
+
+	return baggageclaim.DiskUsageResponse{
+		TotalBytes: total,
+		UsedBytes:  total - totalFree,
+	}, nil
+}
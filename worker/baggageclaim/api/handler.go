@@ -16,6 +16,8 @@ func NewHandler(
 	logger lager.Logger,
 	strategerizer volume.Strategerizer,
 	volumeRepo volume.Repository,
+	uploadsDir string,
+	volumesDir string,
 	p2pInterfacePattern *regexp.Regexp,
 	p2pInterfaceFamily int,
 	p2pStreamPort uint16,
@@ -24,6 +26,7 @@ func NewHandler(
 		logger.Session("volume-server"),
 		strategerizer,
 		volumeRepo,
+		uploadsDir,
 	)
 
 	p2pServer := NewP2pServer(
@@ -33,6 +36,11 @@ func NewHandler(
 		p2pStreamPort,
 	)
 
+	diskServer := NewDiskServer(
+		logger.Session("disk-server"),
+		volumesDir,
+	)
+
 	handlers := rata.Handlers{
 		baggageclaim.CreateVolume:            http.HandlerFunc(volumeServer.CreateVolume),
 		baggageclaim.CreateVolumeAsync:       http.HandlerFunc(volumeServer.CreateVolumeAsync),
@@ -42,14 +50,21 @@ func NewHandler(
 		baggageclaim.GetVolume:               http.HandlerFunc(volumeServer.GetVolume),
 		baggageclaim.SetProperty:             http.HandlerFunc(volumeServer.SetProperty),
 		baggageclaim.GetPrivileged:           http.HandlerFunc(volumeServer.GetPrivileged),
+		baggageclaim.GetSize:                 http.HandlerFunc(volumeServer.GetSize),
 		baggageclaim.SetPrivileged:           http.HandlerFunc(volumeServer.SetPrivileged),
 		baggageclaim.StreamIn:                http.HandlerFunc(volumeServer.StreamIn),
 		baggageclaim.StreamOut:               http.HandlerFunc(volumeServer.StreamOut),
 		baggageclaim.StreamP2pOut:            http.HandlerFunc(volumeServer.StreamP2pOut),
+		baggageclaim.GetStreamInUpload:       http.HandlerFunc(volumeServer.GetStreamInUpload),
+		baggageclaim.AppendStreamInUpload:    http.HandlerFunc(volumeServer.AppendStreamInUpload),
+		baggageclaim.CompleteStreamInUpload:  http.HandlerFunc(volumeServer.CompleteStreamInUpload),
+		baggageclaim.AbortStreamInUpload:     http.HandlerFunc(volumeServer.AbortStreamInUpload),
 		baggageclaim.DestroyVolume:           http.HandlerFunc(volumeServer.DestroyVolume),
 		baggageclaim.DestroyVolumes:          http.HandlerFunc(volumeServer.DestroyVolumes),
 
 		baggageclaim.GetP2pUrl: http.HandlerFunc(p2pServer.GetP2pUrl),
+
+		baggageclaim.GetDiskUsage: http.HandlerFunc(diskServer.GetDiskUsage),
 	}
 
 	return rata.NewRouter(baggageclaim.Routes, handlers)
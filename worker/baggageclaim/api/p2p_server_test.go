@@ -22,7 +22,7 @@ var _ = Describe("P2P Server", func() {
 		var err error
 		logger := lagertest.NewTestLogger("p2p-server")
 		re := regexp.MustCompile(infc)
-		handler, err = api.NewHandler(logger, nil, nil, re, 4, 7766)
+		handler, err = api.NewHandler(logger, nil, nil, "", "", re, 4, 7766)
 		Expect(err).NotTo(HaveOccurred())
 	})
 
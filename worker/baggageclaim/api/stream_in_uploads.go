@@ -0,0 +1,339 @@
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"hash"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/tedsuo/rata"
+	"go.opentelemetry.io/otel/propagation"
+
+	"github.com/concourse/concourse/tracing"
+	"github.com/concourse/concourse/worker/baggageclaim"
+	"github.com/concourse/concourse/worker/baggageclaim/volume"
+)
+
+// StreamInUploadTTL is how long a stream-in upload which is not added to is
+// kept before it is removed.
+var StreamInUploadTTL = time.Hour
+
+var ErrStreamInUploadFailed = errors.New("failed to upload stream in to volume")
+var ErrStreamInUploadNotFound = errors.New("no such stream-in upload")
+var ErrStreamInUploadOffsetMismatch = errors.New("chunk does not begin at the end of the upload")
+var ErrStreamInDigestMismatch = errors.New("stream does not match its digest")
+
+// Stream-in uploads let a stream into a volume be sent in chunks, so that a
+// stream interrupted part way through can be resumed rather than restarted.
+// Each chunk is appended to a file in the uploads directory once it has been
+// verified against its digest. When the upload is completed, the whole
+// stream is verified against its digest and streamed into the volume.
+
+func (vs *VolumeServer) GetStreamInUpload(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	handle := rata.Param(req, "handle")
+	upload := rata.Param(req, "upload")
+
+	hLog := vs.logger.Session("get-stream-in-upload", lager.Data{
+		"volume": handle,
+		"upload": upload,
+	})
+
+	hLog.Debug("start")
+	defer hLog.Debug("done")
+
+	spoolPath := vs.streamInUploadPath(handle, upload)
+
+	vs.uploadLocks.Lock(spoolPath)
+	defer vs.uploadLocks.Unlock(spoolPath)
+
+	info, err := os.Stat(spoolPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			RespondWithError(w, ErrStreamInUploadNotFound, http.StatusNotFound)
+			return
+		}
+
+		hLog.Error("failed-to-stat-upload", err)
+		RespondWithError(w, ErrStreamInUploadFailed, http.StatusInternalServerError)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(baggageclaim.StreamInUploadResponse{Offset: info.Size()}); err != nil {
+		hLog.Error("failed-to-encode", err)
+	}
+}
+
+func (vs *VolumeServer) AppendStreamInUpload(w http.ResponseWriter, req *http.Request) {
+	handle := rata.Param(req, "handle")
+	upload := rata.Param(req, "upload")
+
+	hLog := vs.logger.Session("append-stream-in-upload", lager.Data{
+		"volume": handle,
+		"upload": upload,
+	})
+
+	hLog.Debug("start")
+	defer hLog.Debug("done")
+
+	offset, err := strconv.ParseInt(req.Header.Get(baggageclaim.StreamOffsetHeader), 10, 64)
+	if err != nil || offset < 0 {
+		hLog.Info("bad-offset")
+		RespondWithError(w, ErrStreamInUploadFailed, http.StatusBadRequest)
+		return
+	}
+
+	digest := req.Header.Get(baggageclaim.ChunkDigestHeader)
+	if digest == "" {
+		hLog.Info("missing-chunk-digest")
+		RespondWithError(w, ErrStreamInUploadFailed, http.StatusBadRequest)
+		return
+	}
+
+	spoolPath := vs.streamInUploadPath(handle, upload)
+
+	vs.uploadLocks.Lock(spoolPath)
+	defer vs.uploadLocks.Unlock(spoolPath)
+
+	if offset == 0 {
+		vs.sweepStreamInUploads(hLog)
+	}
+
+	spool, err := os.OpenFile(spoolPath, os.O_WRONLY|os.O_CREATE, 0600)
+	if err != nil {
+		hLog.Error("failed-to-open-upload", err)
+		RespondWithError(w, ErrStreamInUploadFailed, http.StatusInternalServerError)
+		return
+	}
+
+	defer spool.Close()
+
+	end, err := spool.Seek(0, io.SeekEnd)
+	if err != nil {
+		hLog.Error("failed-to-seek-upload", err)
+		RespondWithError(w, ErrStreamInUploadFailed, http.StatusInternalServerError)
+		return
+	}
+
+	// a client which did not hear back about a chunk may send it again, in
+	// which case it needs to know how much of the stream has been received
+	w.Header().Set(baggageclaim.StreamOffsetHeader, strconv.FormatInt(end, 10))
+
+	if offset != end {
+		hLog.Info("offset-mismatch", lager.Data{"offset": offset, "end": end})
+		RespondWithError(w, ErrStreamInUploadOffsetMismatch, http.StatusConflict)
+		return
+	}
+
+	chunkHash := sha256.New()
+	written, err := io.Copy(io.MultiWriter(spool, chunkHash), req.Body)
+	if err == nil && baggageclaim.FormatStreamDigest(chunkHash.Sum(nil)) != digest {
+		err = ErrStreamInDigestMismatch
+	}
+
+	if err != nil {
+		// leave the upload as it was before the chunk, so that the chunk can
+		// be sent again
+		if truncErr := spool.Truncate(end); truncErr != nil {
+			hLog.Error("failed-to-truncate-upload", truncErr)
+		}
+
+		if err == ErrStreamInDigestMismatch {
+			hLog.Info("chunk-digest-mismatch")
+			RespondWithError(w, ErrStreamInDigestMismatch, httpUnprocessableEntity)
+			return
+		}
+
+		hLog.Info("failed-to-receive-chunk", lager.Data{"error": err.Error()})
+		RespondWithError(w, ErrStreamInUploadFailed, http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set(baggageclaim.StreamOffsetHeader, strconv.FormatInt(end+written, 10))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (vs *VolumeServer) CompleteStreamInUpload(w http.ResponseWriter, req *http.Request) {
+	handle := rata.Param(req, "handle")
+	upload := rata.Param(req, "upload")
+
+	ctx := tracing.Extract(req.Context(), propagation.HeaderCarrier(req.Header))
+	hLog := vs.logger.Session("complete-stream-in-upload", lager.Data{
+		"volume": handle,
+		"upload": upload,
+	})
+
+	hLog.Debug("start")
+	defer hLog.Debug("done")
+
+	ctx = lagerctx.NewContext(ctx, hLog)
+
+	var subPath string
+	if queryPath, ok := req.URL.Query()["path"]; ok {
+		subPath = queryPath[0]
+	}
+
+	spoolPath := vs.streamInUploadPath(handle, upload)
+
+	vs.uploadLocks.Lock(spoolPath)
+	defer vs.uploadLocks.Unlock(spoolPath)
+
+	spool, err := os.Open(spoolPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			hLog.Info("upload-not-found")
+			RespondWithError(w, ErrStreamInUploadNotFound, http.StatusNotFound)
+			return
+		}
+
+		hLog.Error("failed-to-open-upload", err)
+		RespondWithError(w, ErrStreamInUploadFailed, http.StatusInternalServerError)
+		return
+	}
+
+	defer os.Remove(spoolPath)
+	defer spool.Close()
+
+	streamHash := sha256.New()
+	_, err = io.Copy(streamHash, spool)
+	if err != nil {
+		hLog.Error("failed-to-read-upload", err)
+		RespondWithError(w, ErrStreamInUploadFailed, http.StatusInternalServerError)
+		return
+	}
+
+	if baggageclaim.FormatStreamDigest(streamHash.Sum(nil)) != req.Header.Get(baggageclaim.StreamDigestHeader) {
+		hLog.Info("stream-digest-mismatch")
+		RespondWithError(w, ErrStreamInDigestMismatch, httpUnprocessableEntity)
+		return
+	}
+
+	_, err = spool.Seek(0, io.SeekStart)
+	if err != nil {
+		hLog.Error("failed-to-seek-upload", err)
+		RespondWithError(w, ErrStreamInUploadFailed, http.StatusInternalServerError)
+		return
+	}
+
+	badStream, err := vs.volumeRepo.StreamIn(ctx, handle, subPath, req.Header.Get("Content-Encoding"), spool)
+	if err != nil {
+		if err == volume.ErrVolumeDoesNotExist {
+			hLog.Info("volume-not-found")
+			RespondWithError(w, ErrStreamInFailed, http.StatusNotFound)
+			return
+		}
+
+		if err == volume.ErrUnsupportedStreamEncoding {
+			hLog.Info("unsupported-stream-encoding")
+			RespondWithError(w, ErrStreamInFailed, http.StatusBadRequest)
+			return
+		}
+
+		if badStream {
+			hLog.Info("bad-stream-payload", lager.Data{"error": err.Error()})
+			RespondWithError(w, ErrStreamInFailed, http.StatusBadRequest)
+			return
+		}
+
+		hLog.Error("failed-to-stream-into-volume", err)
+		RespondWithError(w, ErrStreamInFailed, http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (vs *VolumeServer) AbortStreamInUpload(w http.ResponseWriter, req *http.Request) {
+	handle := rata.Param(req, "handle")
+	upload := rata.Param(req, "upload")
+
+	hLog := vs.logger.Session("abort-stream-in-upload", lager.Data{
+		"volume": handle,
+		"upload": upload,
+	})
+
+	hLog.Debug("start")
+	defer hLog.Debug("done")
+
+	spoolPath := vs.streamInUploadPath(handle, upload)
+
+	vs.uploadLocks.Lock(spoolPath)
+	defer vs.uploadLocks.Unlock(spoolPath)
+
+	err := os.Remove(spoolPath)
+	if err != nil && !os.IsNotExist(err) {
+		hLog.Error("failed-to-remove-upload", err)
+		RespondWithError(w, ErrStreamInUploadFailed, http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// streamInUploadPath returns the path of the file to which an upload is
+// spooled. The handle and upload ID are hashed so that they cannot be used to
+// escape the uploads directory.
+func (vs *VolumeServer) streamInUploadPath(handle string, upload string) string {
+	sum := sha256.Sum256([]byte(handle + "\x00" + upload))
+	return filepath.Join(vs.uploadsDir, hex.EncodeToString(sum[:]))
+}
+
+// sweepStreamInUploads removes uploads which have been abandoned, e.g. because
+// the web node sending them went away.
+func (vs *VolumeServer) sweepStreamInUploads(logger lager.Logger) {
+	entries, err := os.ReadDir(vs.uploadsDir)
+	if err != nil {
+		logger.Error("failed-to-list-uploads", err)
+		return
+	}
+
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+
+		if time.Since(info.ModTime()) > StreamInUploadTTL {
+			err := os.Remove(filepath.Join(vs.uploadsDir, entry.Name()))
+			if err != nil && !os.IsNotExist(err) {
+				logger.Error("failed-to-remove-abandoned-upload", err)
+			}
+		}
+	}
+}
+
+// resumedStreamWriter hashes everything written to it, but only passes on
+// what comes after the offset at which a stream out of a volume is resumed.
+type resumedStreamWriter struct {
+	dest io.Writer
+	skip int64
+	hash hash.Hash
+}
+
+func (w *resumedStreamWriter) Write(p []byte) (int, error) {
+	w.hash.Write(p)
+
+	if w.skip >= int64(len(p)) {
+		w.skip -= int64(len(p))
+		return len(p), nil
+	}
+
+	_, err := w.dest.Write(p[w.skip:])
+	if err != nil {
+		return 0, err
+	}
+
+	w.skip = 0
+
+	return len(p), nil
+}
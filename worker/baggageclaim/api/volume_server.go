@@ -2,12 +2,14 @@ package api
 
 import (
 	"context"
+	"crypto/sha256"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
 	"os"
+	"strconv"
 	"sync"
 	"time"
 
@@ -31,6 +33,7 @@ var ErrDestroyVolumeFailed = errors.New("failed to destroy volume")
 var ErrSetPropertyFailed = errors.New("failed to set property on volume")
 var ErrGetPrivilegedFailed = errors.New("failed to get privileged status of volume")
 var ErrSetPrivilegedFailed = errors.New("failed to change privileged status of volume")
+var ErrGetSizeFailed = errors.New("failed to get size of volume")
 var ErrStreamInFailed = errors.New("failed to stream in to volume")
 var ErrStreamOutFailed = errors.New("failed to stream out from volume")
 var ErrStreamOutNotFound = errors.New("no such file or directory")
@@ -41,6 +44,9 @@ type VolumeServer struct {
 	volumeRepo     volume.Repository
 	volumePromises volume.PromiseList
 
+	uploadsDir  string
+	uploadLocks volume.LockManager
+
 	logger lager.Logger
 }
 
@@ -48,11 +54,14 @@ func NewVolumeServer(
 	logger lager.Logger,
 	strategerizer volume.Strategerizer,
 	volumeRepo volume.Repository,
+	uploadsDir string,
 ) *VolumeServer {
 	return &VolumeServer{
 		strategerizer:  strategerizer,
 		volumeRepo:     volumeRepo,
 		volumePromises: volume.NewPromiseList(),
+		uploadsDir:     uploadsDir,
+		uploadLocks:    volume.NewLockManager(),
 		logger:         logger,
 	}
 }
@@ -424,6 +433,38 @@ func (vs *VolumeServer) GetPrivileged(w http.ResponseWriter, req *http.Request)
 	}
 }
 
+func (vs *VolumeServer) GetSize(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	handle := rata.Param(req, "handle")
+
+	hLog := vs.logger.Session("get-size", lager.Data{
+		"volume": handle,
+	})
+
+	hLog.Debug("start")
+	defer hLog.Debug("done")
+
+	ctx := lagerctx.NewContext(req.Context(), hLog)
+
+	size, err := vs.volumeRepo.GetSize(ctx, handle)
+	if err != nil {
+		hLog.Error("failed-to-get-size", err)
+
+		if err == volume.ErrVolumeDoesNotExist {
+			RespondWithError(w, ErrGetSizeFailed, http.StatusNotFound)
+		} else {
+			RespondWithError(w, ErrGetSizeFailed, http.StatusInternalServerError)
+		}
+
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(size); err != nil {
+		hLog.Error("failed-to-encode", err)
+	}
+}
+
 func (vs *VolumeServer) SetPrivileged(w http.ResponseWriter, req *http.Request) {
 	handle := rata.Param(req, "handle")
 
@@ -527,7 +568,32 @@ func (vs *VolumeServer) StreamOut(w http.ResponseWriter, req *http.Request) {
 		subPath = queryPath[0]
 	}
 
-	err := vs.volumeRepo.StreamOut(ctx, handle, subPath, req.Header.Get("Accept-Encoding"), w)
+	// a stream which was interrupted may be resumed from an offset, in which
+	// case the stream is produced again from the start and only the rest of
+	// it is sent. the digest of the whole stream is sent once it ends, for the
+	// client to verify that the parts it was sent make up the same stream.
+	var offset int64
+	if queryOffset, ok := req.URL.Query()["offset"]; ok {
+		var err error
+		offset, err = strconv.ParseInt(queryOffset[0], 10, 64)
+		if err != nil || offset < 0 {
+			hLog.Info("bad-offset")
+			RespondWithError(w, ErrStreamOutFailed, http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set(baggageclaim.StreamOffsetHeader, strconv.FormatInt(offset, 10))
+	}
+
+	w.Header().Set("Trailer", baggageclaim.StreamDigestHeader)
+
+	out := &resumedStreamWriter{
+		dest: w,
+		skip: offset,
+		hash: sha256.New(),
+	}
+
+	err := vs.volumeRepo.StreamOut(ctx, handle, subPath, req.Header.Get("Accept-Encoding"), out)
 	if err != nil {
 		if err == volume.ErrVolumeDoesNotExist {
 			hLog.Info("volume-not-found")
@@ -551,6 +617,8 @@ func (vs *VolumeServer) StreamOut(w http.ResponseWriter, req *http.Request) {
 		RespondWithError(w, ErrStreamOutFailed, http.StatusInternalServerError)
 		return
 	}
+
+	w.Header().Set(baggageclaim.StreamDigestHeader, baggageclaim.FormatStreamDigest(out.hash.Sum(nil)))
 }
 
 func (vs *VolumeServer) StreamP2pOut(w http.ResponseWriter, req *http.Request) {
@@ -77,7 +77,11 @@ var _ = Describe("Volume Server", func() {
 		strategerizer := volume.NewStrategerizer()
 
 		re := regexp.MustCompile("eth0")
-		handler, err = api.NewHandler(logger, strategerizer, repo, re, 4, 7766)
+		uploadsDir := filepath.Join(tempDir, "uploads")
+		err = os.MkdirAll(uploadsDir, 0700)
+		Expect(err).NotTo(HaveOccurred())
+
+		handler, err = api.NewHandler(logger, strategerizer, repo, uploadsDir, volumeDir, re, 4, 7766)
 		Expect(err).NotTo(HaveOccurred())
 	})
 
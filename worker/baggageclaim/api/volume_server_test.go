@@ -4,6 +4,7 @@ import (
 	"archive/tar"
 	"bytes"
 	"compress/gzip"
+	"crypto/sha256"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -14,6 +15,7 @@ import (
 	"path/filepath"
 	"regexp"
 	"runtime"
+	"strconv"
 	"strings"
 	"time"
 
@@ -86,7 +88,11 @@ var _ = Describe("Volume Server", func() {
 		strategerizer := volume.NewStrategerizer()
 
 		re := regexp.MustCompile("lo")
-		handler, err = api.NewHandler(logger, strategerizer, repo, re, 4, 7766)
+		uploadsDir := filepath.Join(tempDir, "uploads")
+		err = os.MkdirAll(uploadsDir, 0700)
+		Expect(err).NotTo(HaveOccurred())
+
+		handler, err = api.NewHandler(logger, strategerizer, repo, uploadsDir, volumeDir, re, 4, 7766)
 		Expect(err).NotTo(HaveOccurred())
 	})
 
@@ -323,6 +329,217 @@ var _ = Describe("Volume Server", func() {
 		})
 	})
 
+	Describe("uploading a stream into a volume in chunks", func() {
+		var (
+			myVolume volume.Volume
+			stream   []byte
+		)
+
+		BeforeEach(func() {
+			buffer := new(bytes.Buffer)
+			gzWriter := gzip.NewWriter(buffer)
+			tarWriter := tar.NewWriter(gzWriter)
+
+			err := tarWriter.WriteHeader(&tar.Header{
+				Name: "some-file",
+				Mode: 0600,
+				Size: int64(len("file-content")),
+			})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = tarWriter.Write([]byte("file-content"))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(tarWriter.Close()).To(Succeed())
+			Expect(gzWriter.Close()).To(Succeed())
+
+			stream = buffer.Bytes()
+		})
+
+		JustBeforeEach(func() {
+			body := &bytes.Buffer{}
+
+			err := json.NewEncoder(body).Encode(baggageclaim.VolumeRequest{
+				Handle: "some-handle",
+				Strategy: encStrategy(map[string]string{
+					"type": "empty",
+				}),
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			request, err := http.NewRequest("POST", "/volumes", body)
+			Expect(err).NotTo(HaveOccurred())
+
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, request)
+			Expect(recorder.Code).To(Equal(201))
+
+			err = json.NewDecoder(recorder.Body).Decode(&myVolume)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		digest := func(data []byte) string {
+			sum := sha256.Sum256(data)
+			return baggageclaim.FormatStreamDigest(sum[:])
+		}
+
+		appendChunk := func(offset int, chunk []byte, chunkDigest string) *httptest.ResponseRecorder {
+			request, _ := http.NewRequest("PATCH", fmt.Sprintf("/volumes/%s/stream-in-uploads/some-upload", myVolume.Handle), bytes.NewReader(chunk))
+			request.Header.Set(baggageclaim.StreamOffsetHeader, strconv.Itoa(offset))
+			request.Header.Set(baggageclaim.ChunkDigestHeader, chunkDigest)
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, request)
+			return recorder
+		}
+
+		uploadOffset := func() int64 {
+			request, _ := http.NewRequest("GET", fmt.Sprintf("/volumes/%s/stream-in-uploads/some-upload", myVolume.Handle), nil)
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, request)
+			Expect(recorder.Code).To(Equal(200))
+
+			var response baggageclaim.StreamInUploadResponse
+			err := json.NewDecoder(recorder.Body).Decode(&response)
+			Expect(err).NotTo(HaveOccurred())
+
+			return response.Offset
+		}
+
+		complete := func(streamDigest string) *httptest.ResponseRecorder {
+			request, _ := http.NewRequest("PUT", fmt.Sprintf("/volumes/%s/stream-in-uploads/some-upload/complete?path=%s", myVolume.Handle, "dest-path"), nil)
+			request.Header.Set("Content-Encoding", string(baggageclaim.GzipEncoding))
+			request.Header.Set(baggageclaim.StreamDigestHeader, streamDigest)
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, request)
+			return recorder
+		}
+
+		It("extracts the stream into the volume once it is complete", func() {
+			half := len(stream) / 2
+
+			recorder := appendChunk(0, stream[:half], digest(stream[:half]))
+			Expect(recorder.Code).To(Equal(204))
+			Expect(recorder.Header().Get(baggageclaim.StreamOffsetHeader)).To(Equal(strconv.Itoa(half)))
+
+			recorder = appendChunk(half, stream[half:], digest(stream[half:]))
+			Expect(recorder.Code).To(Equal(204))
+
+			Expect(uploadOffset()).To(Equal(int64(len(stream))))
+
+			recorder = complete(digest(stream))
+			Expect(recorder.Code).To(Equal(204))
+
+			tarContentsPath := filepath.Join(volumeDir, "live", myVolume.Handle, "volume", "dest-path", "some-file")
+			Expect(ioutil.ReadFile(tarContentsPath)).To(Equal([]byte("file-content")))
+		})
+
+		It("rejects a chunk which does not begin at the end of the upload", func() {
+			recorder := appendChunk(0, stream[:4], digest(stream[:4]))
+			Expect(recorder.Code).To(Equal(204))
+
+			recorder = appendChunk(0, stream[:4], digest(stream[:4]))
+			Expect(recorder.Code).To(Equal(409))
+			Expect(recorder.Header().Get(baggageclaim.StreamOffsetHeader)).To(Equal("4"))
+
+			Expect(uploadOffset()).To(Equal(int64(4)))
+		})
+
+		It("discards a chunk which does not match its digest", func() {
+			recorder := appendChunk(0, stream[:4], digest(stream[:4]))
+			Expect(recorder.Code).To(Equal(204))
+
+			recorder = appendChunk(4, stream[4:], digest([]byte("something else")))
+			Expect(recorder.Code).To(Equal(422))
+
+			Expect(uploadOffset()).To(Equal(int64(4)))
+		})
+
+		It("does not extract a stream which does not match its digest", func() {
+			recorder := appendChunk(0, stream, digest(stream))
+			Expect(recorder.Code).To(Equal(204))
+
+			recorder = complete(digest([]byte("something else")))
+			Expect(recorder.Code).To(Equal(422))
+
+			tarContentsPath := filepath.Join(volumeDir, "live", myVolume.Handle, "volume", "dest-path", "some-file")
+			Expect(tarContentsPath).ToNot(BeAnExistingFile())
+		})
+
+		It("forgets an aborted upload", func() {
+			recorder := appendChunk(0, stream[:4], digest(stream[:4]))
+			Expect(recorder.Code).To(Equal(204))
+
+			request, _ := http.NewRequest("DELETE", fmt.Sprintf("/volumes/%s/stream-in-uploads/some-upload", myVolume.Handle), nil)
+			recorder = httptest.NewRecorder()
+			handler.ServeHTTP(recorder, request)
+			Expect(recorder.Code).To(Equal(204))
+
+			recorder = complete(digest(stream[:4]))
+			Expect(recorder.Code).To(Equal(404))
+		})
+	})
+
+	Describe("resuming a stream out of a volume", func() {
+		var myVolume volume.Volume
+
+		JustBeforeEach(func() {
+			body := &bytes.Buffer{}
+
+			err := json.NewEncoder(body).Encode(baggageclaim.VolumeRequest{
+				Handle: "some-handle",
+				Strategy: encStrategy(map[string]string{
+					"type": "empty",
+				}),
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			request, err := http.NewRequest("POST", "/volumes", body)
+			Expect(err).NotTo(HaveOccurred())
+
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, request)
+			Expect(recorder.Code).To(Equal(201))
+
+			err = json.NewDecoder(recorder.Body).Decode(&myVolume)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = ioutil.WriteFile(filepath.Join(volumeDir, "live", myVolume.Handle, "volume", "some-file"), []byte("file-content"), 0644)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		streamOut := func(query string) *http.Response {
+			request, _ := http.NewRequest("PUT", fmt.Sprintf("/volumes/%s/stream-out?path=.%s", myVolume.Handle, query), nil)
+			request.Header.Set("Accept-Encoding", string(baggageclaim.GzipEncoding))
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, request)
+			return recorder.Result()
+		}
+
+		It("sends the rest of the stream, and the digest of the whole stream", func() {
+			full := streamOut("")
+			Expect(full.StatusCode).To(Equal(200))
+
+			fullStream, err := ioutil.ReadAll(full.Body)
+			Expect(err).NotTo(HaveOccurred())
+
+			sum := sha256.Sum256(fullStream)
+			Expect(full.Trailer.Get(baggageclaim.StreamDigestHeader)).To(Equal(baggageclaim.FormatStreamDigest(sum[:])))
+
+			resumed := streamOut("&offset=10")
+			Expect(resumed.StatusCode).To(Equal(200))
+			Expect(resumed.Header.Get(baggageclaim.StreamOffsetHeader)).To(Equal("10"))
+
+			rest, err := ioutil.ReadAll(resumed.Body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(rest).To(Equal(fullStream[10:]))
+
+			Expect(resumed.Trailer.Get(baggageclaim.StreamDigestHeader)).To(Equal(baggageclaim.FormatStreamDigest(sum[:])))
+		})
+
+		It("rejects an invalid offset", func() {
+			Expect(streamOut("&offset=-1").StatusCode).To(Equal(400))
+		})
+	})
+
 	Describe("streaming tar out of a volume", func() {
 		var (
 			myVolume  volume.Volume
@@ -678,6 +895,51 @@ var _ = Describe("Volume Server", func() {
 
 	})
 
+	Describe("getting the size of a volume", func() {
+		It("returns the total size of the files in the volume", func() {
+			body := &bytes.Buffer{}
+
+			err := json.NewEncoder(body).Encode(baggageclaim.VolumeRequest{
+				Handle: "some-handle",
+				Strategy: encStrategy(map[string]string{
+					"type": "empty",
+				}),
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			recorder := httptest.NewRecorder()
+			request, _ := http.NewRequest("POST", "/volumes", body)
+			handler.ServeHTTP(recorder, request)
+			Expect(recorder.Code).To(Equal(201))
+
+			var createdVolume volume.Volume
+			err = json.NewDecoder(recorder.Body).Decode(&createdVolume)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = ioutil.WriteFile(filepath.Join(createdVolume.Path, "some-file"), []byte("file-content"), 0644)
+			Expect(err).NotTo(HaveOccurred())
+
+			recorder = httptest.NewRecorder()
+			request, err = http.NewRequest("GET", fmt.Sprintf("/volumes/%s/size", createdVolume.Handle), nil)
+			Expect(err).NotTo(HaveOccurred())
+			handler.ServeHTTP(recorder, request)
+			Expect(recorder.Code).To(Equal(http.StatusOK))
+
+			var size int64
+			err = json.NewDecoder(recorder.Body).Decode(&size)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(size).To(Equal(int64(len("file-content"))))
+		})
+
+		It("returns 404 when the volume does not exist", func() {
+			recorder := httptest.NewRecorder()
+			request, err := http.NewRequest("GET", "/volumes/bogus-handle/size", nil)
+			Expect(err).NotTo(HaveOccurred())
+			handler.ServeHTTP(recorder, request)
+			Expect(recorder.Code).To(Equal(http.StatusNotFound))
+		})
+	})
+
 	Describe("destroying a volume", func() {
 		It("can be destroyed", func() {
 			body := &bytes.Buffer{}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"path/filepath"
 	"regexp"
 
 	"code.cloudfoundry.org/lager"
@@ -38,6 +39,8 @@ type BaggageclaimCommand struct {
 
 	OverlaysDir string `long:"overlays-dir" description:"Path to directory in which to store overlay data"`
 
+	StreamInUploadsDir string `long:"stream-in-uploads-dir" description:"Path to directory in which to keep streams into volumes which are being uploaded in chunks. Defaults to a directory in the system's temporary directory."`
+
 	DisableUserNamespaces bool `long:"disable-user-namespaces" description:"Disable remapping of user/group IDs in unprivileged volumes."`
 }
 
@@ -99,6 +102,25 @@ func (cmd *BaggageclaimCommand) Runner(args []string) (ifrit.Runner, error) {
 		unprivilegedNamespacer,
 	)
 
+	uploadsDir := cmd.StreamInUploadsDir
+	if uploadsDir == "" {
+		uploadsDir = filepath.Join(os.TempDir(), "baggageclaim-stream-in-uploads")
+	}
+
+	// uploads which were in progress cannot be completed by a new process, as
+	// the volumes they were for may not have survived
+	err = os.RemoveAll(uploadsDir)
+	if err != nil {
+		logger.Error("failed-to-clear-stream-in-uploads-dir", err)
+		return nil, err
+	}
+
+	err = os.MkdirAll(uploadsDir, 0700)
+	if err != nil {
+		logger.Error("failed-to-create-stream-in-uploads-dir", err)
+		return nil, err
+	}
+
 	re, err := regexp.Compile(cmd.P2pInterfaceNamePattern)
 	if err != nil {
 		logger.Error("failed-to-compile-p2p-interface-name-pattern", err)
@@ -108,6 +130,8 @@ func (cmd *BaggageclaimCommand) Runner(args []string) (ifrit.Runner, error) {
 		logger.Session("api"),
 		volume.NewStrategerizer(),
 		volumeRepo,
+		uploadsDir,
+		cmd.VolumesDir.Path(),
 		re,
 		cmd.P2pInterfaceFamily,
 		cmd.BindPort,
@@ -48,6 +48,19 @@ type FakeClient struct {
 	destroyVolumesReturnsOnCall map[int]struct {
 		result1 error
 	}
+	DiskUsageStub        func(context.Context) (baggageclaim.DiskUsageResponse, error)
+	diskUsageMutex       sync.RWMutex
+	diskUsageArgsForCall []struct {
+		arg1 context.Context
+	}
+	diskUsageReturns struct {
+		result1 baggageclaim.DiskUsageResponse
+		result2 error
+	}
+	diskUsageReturnsOnCall map[int]struct {
+		result1 baggageclaim.DiskUsageResponse
+		result2 error
+	}
 	ListVolumesStub        func(context.Context, baggageclaim.VolumeProperties) (baggageclaim.Volumes, error)
 	listVolumesMutex       sync.RWMutex
 	listVolumesArgsForCall []struct {
@@ -277,6 +290,70 @@ func (fake *FakeClient) DestroyVolumesReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeClient) DiskUsage(arg1 context.Context) (baggageclaim.DiskUsageResponse, error) {
+	fake.diskUsageMutex.Lock()
+	ret, specificReturn := fake.diskUsageReturnsOnCall[len(fake.diskUsageArgsForCall)]
+	fake.diskUsageArgsForCall = append(fake.diskUsageArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.DiskUsageStub
+	fakeReturns := fake.diskUsageReturns
+	fake.recordInvocation("DiskUsage", []interface{}{arg1})
+	fake.diskUsageMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) DiskUsageCallCount() int {
+	fake.diskUsageMutex.RLock()
+	defer fake.diskUsageMutex.RUnlock()
+	return len(fake.diskUsageArgsForCall)
+}
+
+func (fake *FakeClient) DiskUsageCalls(stub func(context.Context) (baggageclaim.DiskUsageResponse, error)) {
+	fake.diskUsageMutex.Lock()
+	defer fake.diskUsageMutex.Unlock()
+	fake.DiskUsageStub = stub
+}
+
+func (fake *FakeClient) DiskUsageArgsForCall(i int) context.Context {
+	fake.diskUsageMutex.RLock()
+	defer fake.diskUsageMutex.RUnlock()
+	argsForCall := fake.diskUsageArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) DiskUsageReturns(result1 baggageclaim.DiskUsageResponse, result2 error) {
+	fake.diskUsageMutex.Lock()
+	defer fake.diskUsageMutex.Unlock()
+	fake.DiskUsageStub = nil
+	fake.diskUsageReturns = struct {
+		result1 baggageclaim.DiskUsageResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) DiskUsageReturnsOnCall(i int, result1 baggageclaim.DiskUsageResponse, result2 error) {
+	fake.diskUsageMutex.Lock()
+	defer fake.diskUsageMutex.Unlock()
+	fake.DiskUsageStub = nil
+	if fake.diskUsageReturnsOnCall == nil {
+		fake.diskUsageReturnsOnCall = make(map[int]struct {
+			result1 baggageclaim.DiskUsageResponse
+			result2 error
+		})
+	}
+	fake.diskUsageReturnsOnCall[i] = struct {
+		result1 baggageclaim.DiskUsageResponse
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeClient) ListVolumes(arg1 context.Context, arg2 baggageclaim.VolumeProperties) (baggageclaim.Volumes, error) {
 	fake.listVolumesMutex.Lock()
 	ret, specificReturn := fake.listVolumesReturnsOnCall[len(fake.listVolumesArgsForCall)]
@@ -413,6 +490,8 @@ func (fake *FakeClient) LookupVolumeReturnsOnCall(i int, result1 baggageclaim.Vo
 func (fake *FakeClient) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.diskUsageMutex.RLock()
+	defer fake.diskUsageMutex.RUnlock()
 	fake.createVolumeMutex.RLock()
 	defer fake.createVolumeMutex.RUnlock()
 	fake.destroyVolumeMutex.RLock()
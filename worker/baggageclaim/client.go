@@ -69,6 +69,10 @@ type Client interface {
 	// DestroyVolume returns an error if the volume deletion fails. It does not
 	// return an error if the volume was not found on the server.
 	DestroyVolume(context.Context, string) error
+
+	// DiskUsage reports how much of the disk holding the server's volumes is
+	// in use.
+	DiskUsage(context.Context) (DiskUsageResponse, error)
 }
 
 //go:generate counterfeiter . Volume
@@ -94,6 +98,9 @@ type Volume interface {
 	// GetPrivileged returns a bool indicating if the volume is privileged.
 	GetPrivileged(context.Context) (bool, error)
 
+	// Size returns the size of the files in the volume, in bytes.
+	Size(context.Context) (int64, error)
+
 	// StreamIn calls BaggageClaim API endpoint in order to initialize tarStream
 	// to stream the contents of the Reader into this volume at the specified path.
 	StreamIn(ctx context.Context, path string, encoding Encoding, tarStream io.Reader) error
@@ -3,6 +3,7 @@ package client
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -25,6 +26,15 @@ import (
 )
 
 var ErrVolumeDeletion = errors.New("failed-to-delete-volume")
+var ErrStreamOutDigestMismatch = errors.New("stream out of volume does not match its digest")
+
+// MaxStreamOutResumes is how many times a stream out of a volume which is
+// interrupted is resumed before giving up.
+var MaxStreamOutResumes = 5
+
+// MaxStreamInChunkAttempts is how many times a chunk of a stream into a
+// volume is sent before giving up.
+var MaxStreamInChunkAttempts = 5
 
 type Client interface {
 	baggageclaim.Client
@@ -37,15 +47,44 @@ type client struct {
 	nestedRoundTripper  http.RoundTripper
 
 	givenHttpClient *http.Client
+
+	// resumable is set when the worker can resume streams out of its volumes
+	// and accept streams into them in chunks
+	resumable         bool
+	streamInChunkSize int
 }
 
 func New(apiURL string, nestedRoundTripper http.RoundTripper) Client {
+	return NewWithRetryTimeout(apiURL, nestedRoundTripper, 60*time.Minute)
+}
+
+// NewWithRetryTimeout returns a client which gives up retrying a request
+// once it has been failing for the timeout.
+func NewWithRetryTimeout(apiURL string, nestedRoundTripper http.RoundTripper, retryTimeout time.Duration) Client {
+	return &client{
+		requestGenerator: rata.NewRequestGenerator(apiURL, baggageclaim.Routes),
+
+		retryBackOffFactory: retryhttp.NewExponentialBackOffFactory(retryTimeout),
+
+		nestedRoundTripper: nestedRoundTripper,
+	}
+}
+
+// NewResumable returns a client which resumes streams out of volumes when they
+// are interrupted, and sends streams into volumes in chunks of the given size
+// so that only the chunk being sent is lost when one is interrupted. Chunking
+// is disabled when the size is 0. The worker must support resumable
+// streaming.
+func NewResumable(apiURL string, nestedRoundTripper http.RoundTripper, retryTimeout time.Duration, streamInChunkSize int) Client {
 	return &client{
 		requestGenerator: rata.NewRequestGenerator(apiURL, baggageclaim.Routes),
 
-		retryBackOffFactory: retryhttp.NewExponentialBackOffFactory(60 * time.Minute),
+		retryBackOffFactory: retryhttp.NewExponentialBackOffFactory(retryTimeout),
 
 		nestedRoundTripper: nestedRoundTripper,
+
+		resumable:         true,
+		streamInChunkSize: streamInChunkSize,
 	}
 }
 
@@ -239,6 +278,36 @@ func (c *client) DestroyVolume(ctx context.Context, handle string) error {
 	return nil
 }
 
+func (c *client) DiskUsage(ctx context.Context) (baggageclaim.DiskUsageResponse, error) {
+	request, err := c.generateRequest(ctx, baggageclaim.GetDiskUsage, nil, nil)
+	if err != nil {
+		return baggageclaim.DiskUsageResponse{}, err
+	}
+
+	response, err := c.httpClient(ctx).Do(request)
+	if err != nil {
+		return baggageclaim.DiskUsageResponse{}, err
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode != 200 {
+		return baggageclaim.DiskUsageResponse{}, getError(response)
+	}
+
+	if header := response.Header.Get("Content-Type"); header != "application/json" {
+		return baggageclaim.DiskUsageResponse{}, fmt.Errorf("unexpected content-type of: %s", header)
+	}
+
+	var usage baggageclaim.DiskUsageResponse
+	err = json.NewDecoder(response.Body).Decode(&usage)
+	if err != nil {
+		return baggageclaim.DiskUsageResponse{}, err
+	}
+
+	return usage, nil
+}
+
 func (c *client) newVolume(apiVolume baggageclaim.VolumeResponse) baggageclaim.Volume {
 	volume := &clientVolume{
 		handle: apiVolume.Handle,
@@ -257,6 +326,10 @@ func (c *client) streamIn(ctx context.Context, destHandle string, path string, e
 	})
 	defer span.End()
 
+	if c.resumable && c.streamInChunkSize > 0 {
+		return c.streamInChunks(ctx, destHandle, path, encoding, tarContent)
+	}
+
 	request, err := c.generateRequest(ctx, baggageclaim.StreamIn, rata.Params{
 		"handle": destHandle,
 	}, tarContent)
@@ -334,14 +407,40 @@ func (c *client) streamOut(ctx context.Context, srcHandle string, encoding bagga
 	})
 	defer span.End()
 
+	response, err := c.streamOutFrom(ctx, srcHandle, encoding, path, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	if !c.resumable {
+		return response.Body, nil
+	}
+
+	return &resumingStreamOut{
+		ctx:      ctx,
+		client:   c,
+		handle:   srcHandle,
+		encoding: encoding,
+		path:     path,
+		response: response,
+		hash:     sha256.New(),
+	}, nil
+}
+
+func (c *client) streamOutFrom(ctx context.Context, srcHandle string, encoding baggageclaim.Encoding, path string, offset int64) (*http.Response, error) {
 	request, err := c.generateRequest(ctx, baggageclaim.StreamOut, rata.Params{
 		"handle": srcHandle,
 	}, nil)
-
-	request.URL.RawQuery = url.Values{"path": []string{path}}.Encode()
 	if err != nil {
 		return nil, err
 	}
+
+	query := url.Values{"path": []string{path}}
+	if offset > 0 {
+		query.Set("offset", strconv.FormatInt(offset, 10))
+	}
+
+	request.URL.RawQuery = query.Encode()
 	request.Header.Set("Accept-Encoding", string(encoding))
 
 	response, err := c.httpClient(ctx).Do(request)
@@ -350,10 +449,16 @@ func (c *client) streamOut(ctx context.Context, srcHandle string, encoding bagga
 	}
 
 	if response.StatusCode != http.StatusOK {
+		defer response.Body.Close()
 		return nil, getError(response)
 	}
 
-	return response.Body, nil
+	if offset > 0 && response.Header.Get(baggageclaim.StreamOffsetHeader) != strconv.FormatInt(offset, 10) {
+		response.Body.Close()
+		return nil, fmt.Errorf("worker did not resume stream out of volume at offset %d", offset)
+	}
+
+	return response, nil
 }
 
 func (c *client) streamP2pOut(ctx context.Context, srcHandle string, encoding baggageclaim.Encoding, path string, streamInURL string) error {
@@ -514,6 +619,34 @@ func (c *client) getPrivileged(ctx context.Context, handle string) (bool, error)
 	return privileged, nil
 }
 
+func (c *client) getSize(ctx context.Context, handle string) (int64, error) {
+	request, err := c.generateRequest(ctx, baggageclaim.GetSize, rata.Params{
+		"handle": handle,
+	}, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	response, err := c.httpClient(ctx).Do(request)
+	if err != nil {
+		return 0, err
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode != 200 {
+		return 0, getError(response)
+	}
+
+	var size int64
+	err = json.NewDecoder(response.Body).Decode(&size)
+	if err != nil {
+		return 0, err
+	}
+
+	return size, nil
+}
+
 func (c *client) setPrivileged(ctx context.Context, handle string, privileged bool) error {
 	buffer := &bytes.Buffer{}
 	json.NewEncoder(buffer).Encode(baggageclaim.PrivilegedRequest{
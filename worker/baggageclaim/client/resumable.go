@@ -0,0 +1,300 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	uuid "github.com/nu7hatch/gouuid"
+	"github.com/tedsuo/rata"
+
+	"github.com/concourse/concourse/worker/baggageclaim"
+)
+
+// resumingStreamOut reads a stream out of a volume, asking the worker to
+// resume it from where it left off if it is interrupted. The stream is
+// verified against the digest the worker sends once it has been read.
+type resumingStreamOut struct {
+	ctx      context.Context
+	client   *client
+	handle   string
+	encoding baggageclaim.Encoding
+	path     string
+
+	response *http.Response
+	offset   int64
+	hash     hash.Hash
+	resumes  int
+}
+
+func (r *resumingStreamOut) Read(p []byte) (int, error) {
+	for {
+		n, err := r.response.Body.Read(p)
+		r.hash.Write(p[:n])
+		r.offset += int64(n)
+
+		if err == io.EOF {
+			digest := r.response.Trailer.Get(baggageclaim.StreamDigestHeader)
+			if digest != "" && digest != baggageclaim.FormatStreamDigest(r.hash.Sum(nil)) {
+				return n, ErrStreamOutDigestMismatch
+			}
+
+			return n, io.EOF
+		}
+
+		if err == nil {
+			return n, nil
+		}
+
+		if r.ctx.Err() != nil || r.resumes >= MaxStreamOutResumes {
+			return n, err
+		}
+
+		r.resumes++
+
+		lagerctx.FromContext(r.ctx).Info("resuming-stream-out", lager.Data{
+			"volume": r.handle,
+			"offset": r.offset,
+			"error":  err.Error(),
+		})
+
+		r.response.Body.Close()
+
+		response, resumeErr := r.client.streamOutFrom(r.ctx, r.handle, r.encoding, r.path, r.offset)
+		if resumeErr != nil {
+			return n, fmt.Errorf("resume stream out after '%s': %w", err, resumeErr)
+		}
+
+		r.response = response
+
+		if n > 0 {
+			return n, nil
+		}
+	}
+}
+
+func (r *resumingStreamOut) Close() error {
+	return r.response.Body.Close()
+}
+
+// streamInChunks uploads a stream into a volume one chunk at a time. Each
+// chunk is retried on its own, and the whole stream is only streamed into the
+// volume once every chunk has been received.
+func (c *client) streamInChunks(ctx context.Context, destHandle string, path string, encoding baggageclaim.Encoding, tarContent io.Reader) error {
+	id, err := uuid.NewV4()
+	if err != nil {
+		return err
+	}
+
+	upload := id.String()
+
+	streamHash := sha256.New()
+	chunk := make([]byte, c.streamInChunkSize)
+
+	var offset int64
+	for {
+		n, readErr := io.ReadFull(tarContent, chunk)
+		if readErr != nil && readErr != io.EOF && readErr != io.ErrUnexpectedEOF {
+			c.abortStreamInUpload(ctx, destHandle, upload)
+			return readErr
+		}
+
+		// an empty stream still needs an upload to complete
+		if n > 0 || offset == 0 {
+			streamHash.Write(chunk[:n])
+
+			offset, err = c.appendStreamInChunk(ctx, destHandle, upload, offset, chunk[:n])
+			if err != nil {
+				c.abortStreamInUpload(ctx, destHandle, upload)
+				return err
+			}
+		}
+
+		if readErr != nil {
+			break
+		}
+	}
+
+	request, err := c.generateRequest(ctx, baggageclaim.CompleteStreamInUpload, rata.Params{
+		"handle": destHandle,
+		"upload": upload,
+	}, nil)
+	if err != nil {
+		c.abortStreamInUpload(ctx, destHandle, upload)
+		return err
+	}
+
+	request.URL.RawQuery = url.Values{"path": []string{path}}.Encode()
+	request.Header.Set("Content-Encoding", string(encoding))
+	request.Header.Set(baggageclaim.StreamDigestHeader, baggageclaim.FormatStreamDigest(streamHash.Sum(nil)))
+
+	response, err := c.httpClient(ctx).Do(request)
+	if err != nil {
+		c.abortStreamInUpload(ctx, destHandle, upload)
+		return err
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode == http.StatusNoContent {
+		return nil
+	}
+
+	return getError(response)
+}
+
+// appendStreamInChunk sends a chunk of an upload, returning the offset of the
+// end of the upload once the worker has received it.
+func (c *client) appendStreamInChunk(ctx context.Context, destHandle string, upload string, offset int64, chunk []byte) (int64, error) {
+	sum := sha256.Sum256(chunk)
+	digest := baggageclaim.FormatStreamDigest(sum[:])
+	end := offset + int64(len(chunk))
+
+	var err error
+	for attempt := 1; attempt <= MaxStreamInChunkAttempts; attempt++ {
+		if attempt > 1 {
+			select {
+			case <-ctx.Done():
+				return 0, ctx.Err()
+			case <-time.After(time.Duration(attempt-1) * time.Second):
+			}
+
+			lagerctx.FromContext(ctx).Info("retrying-stream-in-chunk", lager.Data{
+				"volume":  destHandle,
+				"offset":  offset,
+				"attempt": attempt,
+				"error":   err.Error(),
+			})
+		}
+
+		var request *http.Request
+		request, err = c.generateRequest(ctx, baggageclaim.AppendStreamInUpload, rata.Params{
+			"handle": destHandle,
+			"upload": upload,
+		}, bytes.NewReader(chunk))
+		if err != nil {
+			return 0, err
+		}
+
+		request.Header.Set(baggageclaim.StreamOffsetHeader, strconv.FormatInt(offset, 10))
+		request.Header.Set(baggageclaim.ChunkDigestHeader, digest)
+
+		var response *http.Response
+		response, err = c.httpClient(ctx).Do(request)
+		if err != nil {
+			if ctx.Err() != nil {
+				return 0, err
+			}
+
+			// the chunk may have been received even though the response was
+			// lost
+			received, found, offsetErr := c.streamInUploadOffset(ctx, destHandle, upload)
+			if offsetErr == nil && found && received == end {
+				return end, nil
+			}
+
+			continue
+		}
+
+		received, _ := strconv.ParseInt(response.Header.Get(baggageclaim.StreamOffsetHeader), 10, 64)
+
+		switch response.StatusCode {
+		case http.StatusNoContent:
+			response.Body.Close()
+			return received, nil
+
+		case http.StatusConflict:
+			response.Body.Close()
+
+			// an earlier attempt was received after all
+			if received == end {
+				return end, nil
+			}
+
+			return 0, fmt.Errorf("worker has received %d bytes of stream in to volume, expected %d", received, offset)
+
+		case http.StatusUnprocessableEntity:
+			// the chunk was corrupted on the way
+			err = getError(response)
+			response.Body.Close()
+			continue
+
+		default:
+			err = getError(response)
+			response.Body.Close()
+			return 0, err
+		}
+	}
+
+	return 0, err
+}
+
+func (c *client) streamInUploadOffset(ctx context.Context, destHandle string, upload string) (int64, bool, error) {
+	request, err := c.generateRequest(ctx, baggageclaim.GetStreamInUpload, rata.Params{
+		"handle": destHandle,
+		"upload": upload,
+	}, nil)
+	if err != nil {
+		return 0, false, err
+	}
+
+	response, err := c.httpClient(ctx).Do(request)
+	if err != nil {
+		return 0, false, err
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode == http.StatusNotFound {
+		return 0, false, nil
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return 0, false, getError(response)
+	}
+
+	var uploadResponse baggageclaim.StreamInUploadResponse
+	err = json.NewDecoder(response.Body).Decode(&uploadResponse)
+	if err != nil {
+		return 0, false, err
+	}
+
+	return uploadResponse.Offset, true, nil
+}
+
+// abortStreamInUpload is best-effort; the worker removes abandoned uploads
+// eventually anyway.
+func (c *client) abortStreamInUpload(ctx context.Context, destHandle string, upload string) {
+	logger := lagerctx.FromContext(ctx)
+
+	// the upload should be aborted even if it failed because the context was
+	// canceled
+	ctx, cancel := context.WithTimeout(lagerctx.NewContext(context.Background(), logger), time.Minute)
+	defer cancel()
+
+	request, err := c.generateRequest(ctx, baggageclaim.AbortStreamInUpload, rata.Params{
+		"handle": destHandle,
+		"upload": upload,
+	}, nil)
+	if err != nil {
+		return
+	}
+
+	response, err := c.httpClient(ctx).Do(request)
+	if err != nil {
+		logger.Info("failed-to-abort-stream-in-upload", lager.Data{"error": err.Error()})
+		return
+	}
+
+	response.Body.Close()
+}
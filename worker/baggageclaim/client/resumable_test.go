@@ -0,0 +1,148 @@
+package client_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/concourse/concourse/worker/baggageclaim"
+	"github.com/concourse/concourse/worker/baggageclaim/client"
+	"github.com/concourse/concourse/worker/baggageclaim/volume"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("resumable streaming", func() {
+	var (
+		gServer *ghttp.Server
+		vol     baggageclaim.Volume
+		stream  string
+		digest  string
+	)
+
+	BeforeEach(func() {
+		gServer = ghttp.NewServer()
+
+		stream = "some-stream-content"
+		sum := sha256.Sum256([]byte(stream))
+		digest = baggageclaim.FormatStreamDigest(sum[:])
+
+		gServer.AppendHandlers(
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest("POST", "/volumes-async"),
+				ghttp.RespondWithJSONEncoded(http.StatusCreated, baggageclaim.VolumeFutureResponse{
+					Handle: "some-volume",
+				}),
+			),
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", "/volumes-async/some-volume"),
+				ghttp.RespondWithJSONEncoded(http.StatusOK, volume.Volume{
+					Handle:     "some-volume",
+					Path:       "/some/path",
+					Properties: map[string]string{},
+				}),
+			),
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest("DELETE", "/volumes-async/some-volume"),
+				ghttp.RespondWith(http.StatusOK, nil),
+			),
+		)
+
+		c := client.NewResumable(gServer.URL(), http.DefaultTransport, time.Second, 8)
+
+		var err error
+		vol, err = c.CreateVolume(context.Background(), "some-volume", baggageclaim.VolumeSpec{})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		gServer.Close()
+	})
+
+	Describe("streaming out", func() {
+		BeforeEach(func() {
+			gServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/volumes/some-volume/stream-out", "path=."),
+					func(w http.ResponseWriter, r *http.Request) {
+						// promise the whole stream, but hang up part way through
+						conn, buf, err := w.(http.Hijacker).Hijack()
+						Expect(err).ToNot(HaveOccurred())
+
+						fmt.Fprintf(buf, "HTTP/1.1 200 OK\r\nContent-Length: %d\r\n\r\n%s", len(stream), stream[:5])
+						buf.Flush()
+						conn.Close()
+					},
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/volumes/some-volume/stream-out", "offset=5&path=."),
+					func(w http.ResponseWriter, r *http.Request) {
+						w.Header().Set("Trailer", baggageclaim.StreamDigestHeader)
+						w.Header().Set(baggageclaim.StreamOffsetHeader, "5")
+						w.WriteHeader(http.StatusOK)
+						w.Write([]byte(stream[5:]))
+						w.Header().Set(baggageclaim.StreamDigestHeader, digest)
+					},
+				),
+			)
+		})
+
+		It("resumes the stream from where it was interrupted", func() {
+			out, err := vol.StreamOut(context.Background(), ".", baggageclaim.GzipEncoding)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(ioutil.ReadAll(out)).To(Equal([]byte(stream)))
+		})
+	})
+
+	Describe("streaming in", func() {
+		var received []string
+
+		BeforeEach(func() {
+			received = nil
+
+			appendChunk := func(offset int, chunk string) http.HandlerFunc {
+				sum := sha256.Sum256([]byte(chunk))
+
+				return ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PATCH", MatchRegexp("/volumes/some-volume/stream-in-uploads/.+")),
+					ghttp.VerifyHeaderKV(baggageclaim.StreamOffsetHeader, fmt.Sprint(offset)),
+					ghttp.VerifyHeaderKV(baggageclaim.ChunkDigestHeader, baggageclaim.FormatStreamDigest(sum[:])),
+					func(w http.ResponseWriter, r *http.Request) {
+						body, err := ioutil.ReadAll(r.Body)
+						Expect(err).ToNot(HaveOccurred())
+						received = append(received, string(body))
+
+						w.Header().Set(baggageclaim.StreamOffsetHeader, fmt.Sprint(offset+len(body)))
+						w.WriteHeader(http.StatusNoContent)
+					},
+				)
+			}
+
+			gServer.AppendHandlers(
+				appendChunk(0, stream[0:8]),
+				appendChunk(8, stream[8:16]),
+				appendChunk(16, stream[16:]),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", MatchRegexp("/volumes/some-volume/stream-in-uploads/.+/complete"), "path=some-path"),
+					ghttp.VerifyHeaderKV("Content-Encoding", "gzip"),
+					ghttp.VerifyHeaderKV(baggageclaim.StreamDigestHeader, digest),
+					ghttp.RespondWith(http.StatusNoContent, nil),
+				),
+			)
+		})
+
+		It("uploads the stream in chunks and then completes the upload", func() {
+			err := vol.StreamIn(context.Background(), "some-path", baggageclaim.GzipEncoding, strings.NewReader(stream))
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(strings.Join(received, "")).To(Equal(stream))
+			Expect(received).To(HaveLen(3))
+		})
+	})
+})
@@ -47,6 +47,10 @@ func (cv *clientVolume) GetPrivileged(ctx context.Context) (bool, error) {
 	return cv.bcClient.getPrivileged(ctx, cv.handle)
 }
 
+func (cv *clientVolume) Size(ctx context.Context) (int64, error) {
+	return cv.bcClient.getSize(ctx, cv.handle)
+}
+
 func (cv *clientVolume) SetPrivileged(ctx context.Context, privileged bool) error {
 	return cv.bcClient.setPrivileged(ctx, cv.handle, privileged)
 }
@@ -1,6 +1,7 @@
 package baggageclaim
 
 import (
+	"encoding/hex"
 	"encoding/json"
 )
 
@@ -28,3 +29,36 @@ type PropertyRequest struct {
 type PrivilegedRequest struct {
 	Value bool `json:"value"`
 }
+
+// DiskUsageResponse describes how much of the disk holding the volumes is in
+// use.
+type DiskUsageResponse struct {
+	TotalBytes uint64 `json:"total_bytes"`
+	UsedBytes  uint64 `json:"used_bytes"`
+}
+
+// StreamInUploadResponse describes how much of a stream-in upload the server
+// has received.
+type StreamInUploadResponse struct {
+	Offset int64 `json:"offset"`
+}
+
+const (
+	// StreamDigestHeader carries the digest of a whole stream. It is sent as a
+	// trailer of streams out of a volume, and with the request which completes
+	// a stream-in upload.
+	StreamDigestHeader = "X-Stream-Digest"
+
+	// ChunkDigestHeader carries the digest of a chunk of a stream-in upload.
+	ChunkDigestHeader = "X-Chunk-Digest"
+
+	// StreamOffsetHeader carries the offset in the stream at which a chunk of
+	// a stream-in upload begins, or at which a stream out of a volume resumes.
+	StreamOffsetHeader = "X-Stream-Offset"
+)
+
+// FormatStreamDigest formats the SHA-256 sum of a stream, or of a chunk of
+// one, for the digest headers.
+func FormatStreamDigest(sum []byte) string {
+	return "sha256:" + hex.EncodeToString(sum)
+}
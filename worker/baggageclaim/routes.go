@@ -15,12 +15,20 @@ const (
 
 	SetProperty   = "SetProperty"
 	GetPrivileged = "GetPrivileged"
+	GetSize       = "GetSize"
 	SetPrivileged = "SetPrivileged"
 	StreamIn      = "StreamIn"
 	StreamOut     = "StreamOut"
 	StreamP2pOut  = "StreamP2pOut"
 
+	GetStreamInUpload      = "GetStreamInUpload"
+	AppendStreamInUpload   = "AppendStreamInUpload"
+	CompleteStreamInUpload = "CompleteStreamInUpload"
+	AbortStreamInUpload    = "AbortStreamInUpload"
+
 	GetP2pUrl = "GetP2pUrl"
+
+	GetDiskUsage = "GetDiskUsage"
 )
 
 var Routes = rata.Routes{
@@ -35,11 +43,18 @@ var Routes = rata.Routes{
 	{Path: "/volumes/:handle/properties/:property", Method: "PUT", Name: SetProperty},
 	{Path: "/volumes/:handle/privileged", Method: "GET", Name: GetPrivileged},
 	{Path: "/volumes/:handle/privileged", Method: "PUT", Name: SetPrivileged},
+	{Path: "/volumes/:handle/size", Method: "GET", Name: GetSize},
 	{Path: "/volumes/:handle/stream-in", Method: "PUT", Name: StreamIn},
 	{Path: "/volumes/:handle/stream-out", Method: "PUT", Name: StreamOut},
 	{Path: "/volumes/:handle/stream-p2p-out", Method: "PUT", Name: StreamP2pOut},
+	{Path: "/volumes/:handle/stream-in-uploads/:upload", Method: "GET", Name: GetStreamInUpload},
+	{Path: "/volumes/:handle/stream-in-uploads/:upload", Method: "PATCH", Name: AppendStreamInUpload},
+	{Path: "/volumes/:handle/stream-in-uploads/:upload/complete", Method: "PUT", Name: CompleteStreamInUpload},
+	{Path: "/volumes/:handle/stream-in-uploads/:upload", Method: "DELETE", Name: AbortStreamInUpload},
 	{Path: "/volumes/destroy", Method: "DELETE", Name: DestroyVolumes},
 	{Path: "/volumes/:handle", Method: "DELETE", Name: DestroyVolume},
 
 	{Path: "/p2p-url", Method: "GET", Name: GetP2pUrl},
+
+	{Path: "/disk-usage", Method: "GET", Name: GetDiskUsage},
 }
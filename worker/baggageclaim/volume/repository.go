@@ -33,6 +33,7 @@ type Repository interface {
 
 	SetProperty(ctx context.Context, handle string, propertyName string, propertyValue string) error
 	GetPrivileged(ctx context.Context, handle string) (bool, error)
+	GetSize(ctx context.Context, handle string) (int64, error)
 	SetPrivileged(ctx context.Context, handle string, privileged bool) error
 
 	StreamIn(ctx context.Context, handle string, path string, encoding string, stream io.Reader) (bool, error)
@@ -331,6 +332,44 @@ func (repo *repository) GetPrivileged(ctx context.Context, handle string) (bool,
 	return privileged, nil
 }
 
+// GetSize gives the apparent size of the files in the volume. Files shared
+// with the volume's parent through copy-on-write are counted in full.
+func (repo *repository) GetSize(ctx context.Context, handle string) (int64, error) {
+	logger := lagerctx.FromContext(ctx).Session("get-size", lager.Data{
+		"volume": handle,
+	})
+
+	volume, found, err := repo.filesystem.LookupVolume(handle)
+	if err != nil {
+		logger.Error("failed-to-lookup-volume", err)
+		return 0, err
+	}
+
+	if !found {
+		logger.Info("volume-not-found")
+		return 0, ErrVolumeDoesNotExist
+	}
+
+	var size int64
+	err = filepath.Walk(volume.DataPath(), func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if info.Mode().IsRegular() {
+			size += info.Size()
+		}
+
+		return nil
+	})
+	if err != nil {
+		logger.Error("failed-to-walk-volume", err)
+		return 0, err
+	}
+
+	return size, nil
+}
+
 func (repo *repository) SetPrivileged(ctx context.Context, handle string, privileged bool) error {
 	repo.locker.Lock(handle)
 	defer repo.locker.Unlock(handle)
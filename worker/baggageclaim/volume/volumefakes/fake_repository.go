@@ -65,6 +65,20 @@ type FakeRepository struct {
 		result1 bool
 		result2 error
 	}
+	GetSizeStub        func(context.Context, string) (int64, error)
+	getSizeMutex       sync.RWMutex
+	getSizeArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	getSizeReturns struct {
+		result1 int64
+		result2 error
+	}
+	getSizeReturnsOnCall map[int]struct {
+		result1 int64
+		result2 error
+	}
 	GetVolumeStub        func(context.Context, string) (volume.Volume, bool, error)
 	getVolumeMutex       sync.RWMutex
 	getVolumeArgsForCall []struct {
@@ -444,6 +458,71 @@ func (fake *FakeRepository) GetPrivilegedReturnsOnCall(i int, result1 bool, resu
 	}{result1, result2}
 }
 
+func (fake *FakeRepository) GetSize(arg1 context.Context, arg2 string) (int64, error) {
+	fake.getSizeMutex.Lock()
+	ret, specificReturn := fake.getSizeReturnsOnCall[len(fake.getSizeArgsForCall)]
+	fake.getSizeArgsForCall = append(fake.getSizeArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.GetSizeStub
+	fakeReturns := fake.getSizeReturns
+	fake.recordInvocation("GetSize", []interface{}{arg1, arg2})
+	fake.getSizeMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeRepository) GetSizeCallCount() int {
+	fake.getSizeMutex.RLock()
+	defer fake.getSizeMutex.RUnlock()
+	return len(fake.getSizeArgsForCall)
+}
+
+func (fake *FakeRepository) GetSizeCalls(stub func(context.Context, string) (int64, error)) {
+	fake.getSizeMutex.Lock()
+	defer fake.getSizeMutex.Unlock()
+	fake.GetSizeStub = stub
+}
+
+func (fake *FakeRepository) GetSizeArgsForCall(i int) (context.Context, string) {
+	fake.getSizeMutex.RLock()
+	defer fake.getSizeMutex.RUnlock()
+	argsForCall := fake.getSizeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeRepository) GetSizeReturns(result1 int64, result2 error) {
+	fake.getSizeMutex.Lock()
+	defer fake.getSizeMutex.Unlock()
+	fake.GetSizeStub = nil
+	fake.getSizeReturns = struct {
+		result1 int64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) GetSizeReturnsOnCall(i int, result1 int64, result2 error) {
+	fake.getSizeMutex.Lock()
+	defer fake.getSizeMutex.Unlock()
+	fake.GetSizeStub = nil
+	if fake.getSizeReturnsOnCall == nil {
+		fake.getSizeReturnsOnCall = make(map[int]struct {
+			result1 int64
+			result2 error
+		})
+	}
+	fake.getSizeReturnsOnCall[i] = struct {
+		result1 int64
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeRepository) GetVolume(arg1 context.Context, arg2 string) (volume.Volume, bool, error) {
 	fake.getVolumeMutex.Lock()
 	ret, specificReturn := fake.getVolumeReturnsOnCall[len(fake.getVolumeArgsForCall)]
@@ -966,6 +1045,8 @@ func (fake *FakeRepository) VolumeParentReturnsOnCall(i int, result1 volume.Volu
 }
 
 func (fake *FakeRepository) Invocations() map[string][][]interface{} {
+	fake.getSizeMutex.RLock()
+	defer fake.getSizeMutex.RUnlock()
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.createVolumeMutex.RLock()
@@ -5,11 +5,15 @@ import (
 	"fmt"
 	"io"
 	"regexp"
+	"strconv"
 	"time"
 
 	"code.cloudfoundry.org/garden"
 	"github.com/containerd/containerd"
 	"github.com/containerd/containerd/cio"
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/runtime/v2/runc/options"
+	"github.com/containerd/typeurl"
 	uuid "github.com/nu7hatch/gouuid"
 	"github.com/opencontainers/runtime-spec/specs-go"
 )
@@ -231,16 +235,102 @@ func (c *Container) SetProperty(name string, value string) error {
 	return nil
 }
 
-// RemoveProperty - Not Implemented
-func (c *Container) RemoveProperty(name string) (err error) {
-	err = ErrNotImplemented
-	return
+// RemoveProperty removes a property from a container, clearing every label
+// that holds a chunk of its value.
+//
+func (c *Container) RemoveProperty(name string) error {
+	ctx := context.Background()
+
+	labels, err := c.container.Labels(ctx)
+	if err != nil {
+		return fmt.Errorf("labels retrieval: %w", err)
+	}
+
+	// containerd deletes the labels which are set to an empty value
+	labelSet := map[string]string{}
+	for sequenceNum := 0; ; sequenceNum++ {
+		chunkKey := name + "." + strconv.Itoa(sequenceNum)
+		if _, found := labels[chunkKey]; !found {
+			break
+		}
+		labelSet[chunkKey] = ""
+	}
+
+	if len(labelSet) == 0 {
+		return ErrNotFound(name)
+	}
+
+	_, err = c.container.SetLabels(ctx, labelSet)
+	if err != nil {
+		return fmt.Errorf("set label: %w", err)
+	}
+
+	return nil
 }
 
-// Info - Not Implemented
-func (c *Container) Info() (info garden.ContainerInfo, err error) {
-	err = ErrNotImplemented
-	return
+// Info returns the container's properties and the IDs of the processes
+// started in it.
+//
+// Only the fields that can be derived from containerd are populated.
+//
+func (c *Container) Info() (garden.ContainerInfo, error) {
+	ctx := context.Background()
+
+	properties, err := c.Properties()
+	if err != nil {
+		return garden.ContainerInfo{}, err
+	}
+
+	processIDs, err := c.processIDs(ctx)
+	if err != nil {
+		return garden.ContainerInfo{}, err
+	}
+
+	return garden.ContainerInfo{
+		State:      "active",
+		Properties: properties,
+		ProcessIDs: processIDs,
+	}, nil
+}
+
+// processIDs lists the IDs of the processes exec'd in the container's task.
+// The task's init process has no ID, so it is left out.
+//
+func (c *Container) processIDs(ctx context.Context) ([]string, error) {
+	task, err := c.container.Task(ctx, nil)
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			return []string{}, nil
+		}
+
+		return nil, fmt.Errorf("task lookup: %w", err)
+	}
+
+	procs, err := task.Pids(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("pids: %w", err)
+	}
+
+	ids := []string{}
+	for _, proc := range procs {
+		if proc.Info == nil {
+			continue
+		}
+
+		info, err := typeurl.UnmarshalAny(proc.Info)
+		if err != nil {
+			return nil, fmt.Errorf("unmarshal process info: %w", err)
+		}
+
+		details, ok := info.(*options.ProcessDetails)
+		if !ok {
+			continue
+		}
+
+		ids = append(ids, details.ExecID)
+	}
+
+	return ids, nil
 }
 
 // Metrics - Not Implemented
@@ -7,6 +7,10 @@ import (
 	"github.com/concourse/concourse/worker/runtime"
 	"github.com/concourse/concourse/worker/runtime/libcontainerd/libcontainerdfakes"
 	"github.com/concourse/concourse/worker/runtime/runtimefakes"
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/runtime/v2/runc/options"
+	"github.com/containerd/typeurl"
 	"github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
@@ -366,6 +370,89 @@ func (s *ContainerSuite) TestPropertyReturnsValue() {
 	s.Equal("some-value", result)
 }
 
+func (s *ContainerSuite) TestRemovePropertyGetLabelsFails() {
+	expectedErr := errors.New("get-labels-error")
+	s.containerdContainer.LabelsReturns(nil, expectedErr)
+	err := s.container.RemoveProperty("any")
+	s.True(errors.Is(err, expectedErr))
+}
+
+func (s *ContainerSuite) TestRemovePropertyNotFound() {
+	s.containerdContainer.LabelsReturns(map[string]string{"other.0": "some-value"}, nil)
+	err := s.container.RemoveProperty("any")
+	s.Equal(runtime.ErrNotFound("any"), err)
+	s.Equal(0, s.containerdContainer.SetLabelsCallCount())
+}
+
+func (s *ContainerSuite) TestRemovePropertyClearsEveryChunk() {
+	s.containerdContainer.LabelsReturns(map[string]string{
+		"any.0":   "first-chunk",
+		"any.1":   "second-chunk",
+		"other.0": "some-value",
+	}, nil)
+	err := s.container.RemoveProperty("any")
+	s.NoError(err)
+	s.Equal(1, s.containerdContainer.SetLabelsCallCount())
+	_, labelSet := s.containerdContainer.SetLabelsArgsForCall(0)
+	s.Equal(map[string]string{"any.0": "", "any.1": ""}, labelSet)
+}
+
+func (s *ContainerSuite) TestRemovePropertySetLabelsFails() {
+	expectedErr := errors.New("set-labels-error")
+	s.containerdContainer.LabelsReturns(map[string]string{"any.0": "some-value"}, nil)
+	s.containerdContainer.SetLabelsReturns(nil, expectedErr)
+	err := s.container.RemoveProperty("any")
+	s.True(errors.Is(err, expectedErr))
+}
+
+func (s *ContainerSuite) TestInfoGetLabelsFails() {
+	expectedErr := errors.New("get-labels-error")
+	s.containerdContainer.LabelsReturns(nil, expectedErr)
+	_, err := s.container.Info()
+	s.True(errors.Is(err, expectedErr))
+}
+
+func (s *ContainerSuite) TestInfoWithoutTask() {
+	s.containerdContainer.LabelsReturns(map[string]string{"any.0": "some-value"}, nil)
+	s.containerdContainer.TaskReturns(nil, errdefs.ErrNotFound)
+	info, err := s.container.Info()
+	s.NoError(err)
+	s.Equal(garden.Properties{"any": "some-value"}, info.Properties)
+	s.Empty(info.ProcessIDs)
+}
+
+func (s *ContainerSuite) TestInfoTaskLookupFails() {
+	expectedErr := errors.New("task-lookup-err")
+	s.containerdContainer.TaskReturns(nil, expectedErr)
+	_, err := s.container.Info()
+	s.True(errors.Is(err, expectedErr))
+}
+
+func (s *ContainerSuite) TestInfoPidsFails() {
+	expectedErr := errors.New("pids-err")
+	s.containerdContainer.TaskReturns(s.containerdTask, nil)
+	s.containerdTask.PidsReturns(nil, expectedErr)
+	_, err := s.container.Info()
+	s.True(errors.Is(err, expectedErr))
+}
+
+func (s *ContainerSuite) TestInfoReturnsExecdProcessIDs() {
+	execInfo, err := typeurl.MarshalAny(&options.ProcessDetails{ExecID: "some-process"})
+	s.NoError(err)
+
+	s.containerdContainer.LabelsReturns(map[string]string{"any.0": "some-value"}, nil)
+	s.containerdContainer.TaskReturns(s.containerdTask, nil)
+	s.containerdTask.PidsReturns([]containerd.ProcessInfo{
+		{Pid: 1},
+		{Pid: 2, Info: execInfo},
+	}, nil)
+
+	info, err := s.container.Info()
+	s.NoError(err)
+	s.Equal(garden.Properties{"any": "some-value"}, info.Properties)
+	s.Equal([]string{"some-process"}, info.ProcessIDs)
+}
+
 func (s *ContainerSuite) TestCurrentCPULimitsGetInfoFails() {
 	expectedErr := errors.New("get-spec-error")
 	s.containerdContainer.SpecReturns(nil, expectedErr)
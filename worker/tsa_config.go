@@ -1,6 +1,8 @@
 package worker
 
 import (
+	"crypto/rsa"
+
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/tsa"
 	"github.com/concourse/flag"
@@ -9,14 +11,37 @@ import (
 type TSAConfig struct {
 	Hosts            []string            `long:"host" default:"127.0.0.1:2222" description:"TSA host to forward the worker through. Can be specified multiple times."`
 	PublicKey        flag.AuthorizedKeys `long:"public-key" description:"File containing a public key to expect from the TSA."`
-	WorkerPrivateKey *flag.PrivateKey    `long:"worker-private-key" required:"true" description:"File containing the private key to use when authenticating to the TSA."`
+	WorkerPrivateKey *flag.PrivateKey    `long:"worker-private-key" description:"File containing the private key to use when authenticating to the TSA. Required unless connecting over mutual TLS."`
+
+	TLSCACert         flag.File `long:"tls-ca-cert" description:"File containing the CA certificate with which to verify the TSA. When configured, the worker connects to the TSA's TLS port over mutual TLS."`
+	TLSCert           flag.File `long:"tls-cert" description:"File containing the worker's certificate, issued by an external CA. Read on each connection, so that it can be rotated."`
+	TLSKey            flag.File `long:"tls-key" description:"File containing the private key of the worker's certificate."`
+	TLSCertDir        string    `long:"tls-cert-dir" description:"Directory in which to keep the certificate issued by the TSA, when --tls-cert is not configured. The certificate is renewed automatically. Defaults to a directory within the work dir."`
+	TLSBootstrapToken string    `long:"tls-bootstrap-token" description:"Token with which to request the worker's first certificate from the TSA, when it has no private key."`
 }
 
 func (config TSAConfig) Client(worker atc.Worker) *tsa.Client {
-	return &tsa.Client{
+	var privateKey *rsa.PrivateKey
+	if config.WorkerPrivateKey != nil {
+		privateKey = config.WorkerPrivateKey.PrivateKey
+	}
+
+	client := &tsa.Client{
 		Hosts:      config.Hosts,
 		HostKeys:   config.PublicKey.Keys,
-		PrivateKey: config.WorkerPrivateKey.PrivateKey,
+		PrivateKey: privateKey,
 		Worker:     worker,
 	}
+
+	if config.TLSCACert != "" {
+		client.TLS = &tsa.ClientTLS{
+			CACertFile:     config.TLSCACert.Path(),
+			CertFile:       config.TLSCert.Path(),
+			KeyFile:        config.TLSKey.Path(),
+			CertDir:        config.TLSCertDir,
+			BootstrapToken: config.TLSBootstrapToken,
+		}
+	}
+
+	return client
 }
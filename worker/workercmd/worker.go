@@ -108,6 +108,10 @@ func (cmd *WorkerCommand) Runner(args []string) (ifrit.Runner, error) {
 		cmd.HealthCheckTimeout,
 	)
 
+	if cmd.TSA.TLSCertDir == "" {
+		cmd.TSA.TLSCertDir = filepath.Join(cmd.WorkDir.Path(), "tsa-certs")
+	}
+
 	tsaClient := cmd.TSA.Client(atcWorker)
 
 	beaconRunner := worker.NewBeaconRunner(
@@ -265,5 +269,7 @@ func (cmd *WorkerCommand) baggageclaimRunner(logger lager.Logger) (ifrit.Runner,
 
 	cmd.Baggageclaim.OverlaysDir = filepath.Join(cmd.WorkDir.Path(), "overlays")
 
+	cmd.Baggageclaim.StreamInUploadsDir = filepath.Join(cmd.WorkDir.Path(), "stream-in-uploads")
+
 	return cmd.Baggageclaim.Runner(nil)
 }
@@ -17,6 +17,9 @@ type WorkerConfig struct {
 
 	Ephemeral bool `long:"ephemeral" description:"If set, the worker will be immediately removed upon stalling."`
 
+	DisableP2PStreaming bool  `long:"disable-p2p-streaming" description:"Advertise that volumes cannot be streamed directly between this worker and others, e.g. because they cannot reach each other."`
+	MaxImageSizeMB      int64 `long:"max-image-size-mb"     description:"Size, in megabytes, of the largest image the worker will run containers with. Unlimited by default."`
+
 	Version string `long:"version" hidden:"true" description:"Version of the worker. This is normally baked in to the binary, so this flag is hidden."`
 }
 
@@ -31,5 +34,11 @@ func (c WorkerConfig) Worker() atc.Worker {
 		HTTPSProxyURL: c.HTTPSProxy,
 		NoProxy:       c.NoProxy,
 		Ephemeral:     c.Ephemeral,
+		Capabilities: &atc.WorkerCapabilities{
+			StreamingCompression: atc.StreamingCompressions,
+			P2PStreaming:         !c.DisableP2PStreaming,
+			ResumableStreaming:   true,
+			MaxImageSize:         c.MaxImageSizeMB * 1024 * 1024,
+		},
 	}
 }
@@ -62,9 +62,9 @@ type DNSConfig struct {
 	Enable bool `long:"enable" description:"Enable proxy DNS server. Note: this will enable containers to access the host network."`
 }
 
-const containerdRuntime = "containerd"
-const guardianRuntime = "guardian"
-const houdiniRuntime = "houdini"
+const containerdRuntime = atc.WorkerRuntimeContainerd
+const guardianRuntime = atc.WorkerRuntimeGuardian
+const houdiniRuntime = atc.WorkerRuntimeHoudini
 
 func (cmd WorkerCommand) LessenRequirements(prefix string, command *flags.Command) {
 	// configured as work-dir/volumes
@@ -88,6 +88,7 @@ func (cmd *WorkerCommand) gardenServerRunner(logger lager.Logger) (atc.Worker, i
 
 	worker := cmd.Worker.Worker()
 	worker.Platform = "linux"
+	worker.Runtime = cmd.Runtime
 
 	if cmd.Certs.Dir != "" {
 		worker.CertsPath = &cmd.Certs.Dir